@@ -0,0 +1,55 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/arazzo"
+	lowarazzo "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/utils"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// NewArazzoDocument parses an Arazzo (workflows) specification and builds its model. Arazzo documents are not
+// OpenAPI documents, so they are not built with NewDocument. Use Validate on the returned document to check the
+// workflows against the OpenAPI documents they describe.
+//
+// A document is returned along with any errors, as long as the specification could be parsed.
+func NewArazzoDocument(arazzoBytes []byte) (*arazzo.Document, error) {
+	return NewArazzoDocumentWithConfiguration(arazzoBytes, datamodel.NewDocumentConfiguration())
+}
+
+// NewArazzoDocumentWithConfiguration is the same as NewArazzoDocument, except the supplied configuration is used to
+// build the index and rolodex (to follow file or remote references in workflow inputs).
+func NewArazzoDocumentWithConfiguration(arazzoBytes []byte,
+	configuration *datamodel.DocumentConfiguration,
+) (*arazzo.Document, error) {
+	if configuration == nil {
+		configuration = datamodel.NewDocumentConfiguration()
+	}
+	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(arazzoBytes, configuration.BypassDocumentCheck)
+	if err != nil {
+		return nil, err
+	}
+	if info.SpecType != utils.Arazzo {
+		return nil, errors.New("unable to build arazzo document, the specification is not an arazzo document")
+	}
+	lowDoc, err := lowarazzo.CreateDocumentFromConfig(info, configuration)
+	if lowDoc == nil {
+		return nil, err
+	}
+	return arazzo.NewDocument(lowDoc), err
+}
+
+// CompareArazzoDocuments compares a left (original) and right (updated) Arazzo document, returning every change
+// found, or nil if nothing changed.
+func CompareArazzoDocuments(original, updated *arazzo.Document) *model.ArazzoChanges {
+	if original == nil || updated == nil {
+		return nil
+	}
+	return what_changed.CompareArazzoDocuments(original.GoLow(), updated.GoLow())
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const arazzoSpec = `arazzo: 1.0.0
+info:
+  title: Pet adoption
+  version: 1.0.0
+sourceDescriptions:
+  - name: pets
+    url: ./pets.yaml
+    type: openapi
+workflows:
+  - workflowId: adopt
+    steps:
+      - stepId: find
+        operationId: findPet
+      - stepId: adopt
+        operationPath: '{$sourceDescriptions.pets.url}#/paths/~1pets/post'`
+
+const arazzoPetsSpec = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPet
+    post:
+      operationId: adoptPet`
+
+func TestNewArazzoDocument(t *testing.T) {
+	doc, err := NewArazzoDocument([]byte(arazzoSpec))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", doc.Arazzo)
+	require.NotNil(t, doc.FindWorkflow("adopt"))
+
+	pets, err := NewDocument([]byte(arazzoPetsSpec))
+	require.NoError(t, err)
+	model, errs := pets.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Empty(t, doc.Validate(map[string]*v3.Document{"pets": &model.Model}))
+}
+
+func TestNewArazzoDocument_NotArazzo(t *testing.T) {
+	_, err := NewArazzoDocument([]byte(arazzoPetsSpec))
+	assert.Error(t, err)
+
+	_, err = NewArazzoDocumentWithConfiguration([]byte("not: [valid"), nil)
+	assert.Error(t, err)
+}
+
+func TestCompareArazzoDocuments(t *testing.T) {
+	left, err := NewArazzoDocument([]byte(arazzoSpec))
+	require.NoError(t, err)
+	right, err := NewArazzoDocument([]byte(arazzoSpec + "\n      - stepId: pay\n        operationId: payFee"))
+	require.NoError(t, err)
+
+	assert.Nil(t, CompareArazzoDocuments(left, left))
+	changes := CompareArazzoDocuments(left, right)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Zero(t, changes.TotalBreakingChanges())
+}
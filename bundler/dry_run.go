@@ -0,0 +1,93 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"errors"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// DryRunResult is a preview of what a transform would do to a specification, without doing it.
+type DryRunResult struct {
+	// Original is the rendered specification before the transform. The specification is rendered (rather than the
+	// bytes passed in being used as is) so the Diff only contains changes made by the transform, not formatting.
+	Original []byte
+
+	// Output is what the transform would return.
+	Output []byte
+
+	// Changes is the what-changed tree between the Original and the Output, nil if the models are the same.
+	Changes *model.DocumentChanges
+
+	// Diff is a unified text diff between the Original and Output, empty if they are the same.
+	Diff string
+}
+
+// PreviewChanges compares the output of a transform against the original specification it was created from,
+// returning the what-changed tree and a unified text diff of the two. The configuration is used to build both
+// documents, so references in the original (to local or remote files) can be resolved.
+//
+// Any transform can be previewed with PreviewChanges, as long as it produces a specification.
+func PreviewChanges(original, output []byte, configuration *datamodel.DocumentConfiguration) (*DryRunResult, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(original)),
+		B:        difflib.SplitLines(string(output)),
+		FromFile: "original",
+		ToFile:   "output",
+		Context:  3,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	left, err := libopenapi.NewDocumentWithConfiguration(original, configuration)
+	if err != nil {
+		return nil, err
+	}
+	right, err := libopenapi.NewDocumentWithConfiguration(output, configuration)
+	if err != nil {
+		return nil, err
+	}
+	changes, errs := libopenapi.CompareDocuments(left, right)
+
+	return &DryRunResult{
+		Original: original,
+		Output:   output,
+		Changes:  changes,
+		Diff:     diff,
+	}, errors.Join(errs...)
+}
+
+// BundleBytesDryRun is the dry-run mode of BundleBytes. Nothing is bundled, instead a DryRunResult is returned
+// containing what BundleBytes would return, and the changes that bundling makes to the specification.
+func BundleBytesDryRun(bytes []byte, configuration *datamodel.DocumentConfiguration) (*DryRunResult, error) {
+	doc, err := libopenapi.NewDocumentWithConfiguration(bytes, configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	v3Doc, errs := doc.BuildV3Model()
+	err = errors.Join(errs...)
+	if v3Doc == nil {
+		return nil, errors.Join(ErrInvalidModel, err)
+	}
+
+	original, e := v3Doc.Model.Render()
+	if e != nil {
+		return nil, errors.Join(err, e)
+	}
+
+	// bundling modifies the model in place, the original will be built again from the rendered bytes.
+	output, e := bundle(&v3Doc.Model, configuration.BundleInlineRefs)
+	if e != nil {
+		return nil, errors.Join(err, e)
+	}
+
+	result, e := PreviewChanges(original, output, configuration)
+	return result, errors.Join(err, e)
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleBytesDryRun(t *testing.T) {
+	spec, err := os.ReadFile("../test_specs/ref-followed.yaml")
+	require.NoError(t, err)
+
+	config := &datamodel.DocumentConfiguration{
+		BasePath:                ".",
+		ExtractRefsSequentially: true,
+		BundleInlineRefs:        true,
+	}
+
+	result, err := BundleBytesDryRun(spec, config)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	bundled, err := BundleBytes(spec, config)
+	require.NoError(t, err)
+	assert.Equal(t, string(bundled), string(result.Output))
+
+	assert.Contains(t, result.Diff, "--- original\n+++ output\n")
+	assert.Contains(t, result.Diff, `-            $ref: "#/components/schemas/FP"`)
+	assert.Contains(t, result.Diff, `+            type: string`)
+
+	// inlining a reference does not change what the schema means.
+	assert.Nil(t, result.Changes)
+
+	// nothing to bundle, nothing changes.
+	result, err = BundleBytesDryRun(result.Output, config)
+	require.NoError(t, err)
+	assert.Equal(t, string(result.Original), string(result.Output))
+	assert.Empty(t, result.Diff)
+	assert.Nil(t, result.Changes)
+}
+
+func TestBundleBytesDryRun_Bad(t *testing.T) {
+	_, err := BundleBytesDryRun([]byte("not an openapi spec"), &datamodel.DocumentConfiguration{})
+	assert.Error(t, err)
+
+	_, err = BundleBytesDryRun([]byte(`swagger: 2.0`), &datamodel.DocumentConfiguration{})
+	assert.ErrorIs(t, err, ErrInvalidModel)
+}
+
+func TestPreviewChanges_Bad(t *testing.T) {
+	config := &datamodel.DocumentConfiguration{}
+	_, err := PreviewChanges([]byte("openapi: 3.1.0"), []byte("not an openapi spec"), config)
+	assert.Error(t, err)
+	_, err = PreviewChanges([]byte("not an openapi spec"), []byte("openapi: 3.1.0"), config)
+	assert.Error(t, err)
+
+	result, err := PreviewChanges([]byte("openapi: 3.1.0\ninfo:\n  title: a\n"),
+		[]byte("openapi: 3.1.0\ninfo:\n  title: b\n"), config)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Changes.TotalChanges())
+	assert.Contains(t, result.Diff, "-  title: a\n+  title: b\n")
+}
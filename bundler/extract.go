@@ -0,0 +1,390 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/walk"
+)
+
+// NamingStrategy names an inline schema extracted to the components of a document, from where the schema is
+// defined (see walk.Location). A name already taken is made unique by ExtractInlineSchemas, by appending a number.
+type NamingStrategy func(location walk.Location, schema *base.Schema) string
+
+// ExtractedSchema is an inline schema moved to the components of a document.
+type ExtractedSchema struct {
+	Name      string        // the name of the component schema, such as `PostPetsRequest`.
+	Reference string        // the reference the inline schema was replaced with, such as `#/components/schemas/PostPetsRequest`.
+	Location  walk.Location // where the inline schema was defined.
+}
+
+// ExtractionReport lists the inline schemas extracted by ExtractInlineSchemas, in the order of the document.
+type ExtractionReport struct {
+	Schemas []*ExtractedSchema
+}
+
+// Mapping returns the reference each extracted schema was replaced with, by the location it was defined at (see
+// walk.Location.String).
+func (r *ExtractionReport) Mapping() map[string]string {
+	mapping := make(map[string]string, len(r.Schemas))
+	for _, s := range r.Schemas {
+		mapping[s.Location.String()] = s.Reference
+	}
+	return mapping
+}
+
+// ExtractInlineSchemas moves the anonymous inline object schemas (those defining properties) of a document to
+// `components/schemas`, replacing each one with a `$ref` to it. The schemas of request bodies, responses,
+// parameters and headers are extracted, as are the objects nested in other schemas (properties, items, allOf...),
+// including those nested in component schemas. References (and objects holding references) are left as they are.
+//
+// Each schema is named by the naming strategy, NameFromLocation if it's nil. The model is changed in place, render
+// it to get the refactored specification.
+func ExtractInlineSchemas(model *v3.Document, naming NamingStrategy) (*ExtractionReport, error) {
+	if model == nil {
+		return nil, ErrInvalidModel
+	}
+	if naming == nil {
+		naming = NameFromLocation
+	}
+	if model.Components == nil {
+		model.Components = &v3.Components{}
+	}
+	if model.Components.Schemas == nil {
+		model.Components.Schemas = orderedmap.New[string, *base.SchemaProxy]()
+	}
+	e := &extractor{
+		naming:     naming,
+		components: model.Components.Schemas,
+		taken:      make(map[string]struct{}),
+		report:     new(ExtractionReport),
+	}
+
+	// the component schemas are listed first, so the schemas extracted (and appended to them) aren't walked twice.
+	var components []string
+	for name := range e.components.KeysFromOldest() {
+		components = append(components, name)
+		e.taken[name] = struct{}{}
+	}
+	for _, name := range components {
+		sp := e.components.GetOrZero(name)
+		e.children(&sp, walk.Location{"components", "schemas", name})
+	}
+	c := model.Components
+	for name, p := range c.Parameters.FromOldest() {
+		e.parameter(p, walk.Location{"components", "parameters", name})
+	}
+	for name, h := range c.Headers.FromOldest() {
+		e.header(h, walk.Location{"components", "headers", name})
+	}
+	for name, rb := range c.RequestBodies.FromOldest() {
+		e.requestBody(rb, walk.Location{"components", "requestBodies", name})
+	}
+	for name, r := range c.Responses.FromOldest() {
+		e.response(r, walk.Location{"components", "responses", name})
+	}
+	for name, cb := range c.Callbacks.FromOldest() {
+		e.callback(cb, walk.Location{"components", "callbacks", name})
+	}
+	for name, pi := range c.PathItems.FromOldest() {
+		e.pathItem(pi, walk.Location{"components", "pathItems", name})
+	}
+	if model.Paths != nil {
+		for path, pi := range model.Paths.PathItems.FromOldest() {
+			e.pathItem(pi, walk.Location{"paths", path})
+		}
+	}
+	for name, pi := range model.Webhooks.FromOldest() {
+		e.pathItem(pi, walk.Location{"webhooks", name})
+	}
+	return e.report, errors.Join(e.errs...)
+}
+
+type extractor struct {
+	naming     NamingStrategy
+	components *orderedmap.Map[string, *base.SchemaProxy]
+	taken      map[string]struct{}
+	report     *ExtractionReport
+	errs       []error
+}
+
+// isReference reports whether a model object was built from a reference (it's rendered as the reference).
+func isReference(r *low.Reference) bool {
+	return r != nil && r.IsReference()
+}
+
+// schema extracts an inline object schema, then the objects nested in it.
+func (e *extractor) schema(sp **base.SchemaProxy, loc walk.Location) {
+	p := *sp
+	if p == nil || p.IsReference() {
+		return
+	}
+	s, err := p.BuildSchema()
+	if err != nil || s == nil {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+		return
+	}
+	if orderedmap.Len(s.Properties) > 0 {
+		name := e.name(loc, s)
+		ref := "#/components/schemas/" + name
+		e.components.Set(name, p)
+		*sp = base.CreateSchemaProxyRef(ref)
+		e.report.Schemas = append(e.report.Schemas, &ExtractedSchema{Name: name, Reference: ref, Location: loc})
+	}
+	e.children(&p, loc)
+}
+
+// children extracts the inline object schemas nested in a schema.
+func (e *extractor) children(sp **base.SchemaProxy, loc walk.Location) {
+	if *sp == nil || (*sp).IsReference() {
+		return
+	}
+	s := (*sp).Schema()
+	if s == nil {
+		return
+	}
+	for _, m := range []struct {
+		keyword string
+		schemas *orderedmap.Map[string, *base.SchemaProxy]
+	}{{"properties", s.Properties}, {"patternProperties", s.PatternProperties}} {
+		var keys []string
+		for k := range m.schemas.KeysFromOldest() {
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			child := m.schemas.GetOrZero(k)
+			e.schema(&child, with(loc, m.keyword, k))
+			m.schemas.Set(k, child)
+		}
+	}
+	for _, l := range []struct {
+		keyword string
+		schemas []*base.SchemaProxy
+	}{{"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}, {"prefixItems", s.PrefixItems}} {
+		for i := range l.schemas {
+			e.schema(&l.schemas[i], with(loc, l.keyword, strconv.Itoa(i)))
+		}
+	}
+	for _, d := range []struct {
+		keyword string
+		value   *base.DynamicValue[*base.SchemaProxy, bool]
+	}{
+		{"items", s.Items}, {"additionalProperties", s.AdditionalProperties},
+		{"unevaluatedItems", s.UnevaluatedItems}, {"unevaluatedProperties", s.UnevaluatedProperties},
+	} {
+		if d.value != nil && d.value.IsA() {
+			e.schema(&d.value.A, with(loc, d.keyword))
+		}
+	}
+	e.schema(&s.Contains, with(loc, "contains"))
+	e.schema(&s.If, with(loc, "if"))
+	e.schema(&s.Then, with(loc, "then"))
+	e.schema(&s.Else, with(loc, "else"))
+	e.schema(&s.Not, with(loc, "not"))
+}
+
+// name names a schema with the naming strategy, making the name unique.
+func (e *extractor) name(loc walk.Location, s *base.Schema) string {
+	name := e.naming(loc, s)
+	if name == "" {
+		name = "Schema"
+	}
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := e.taken[unique]; !ok {
+			break
+		}
+		unique = name + strconv.Itoa(i)
+	}
+	e.taken[unique] = struct{}{}
+	return unique
+}
+
+func (e *extractor) pathItem(pi *v3.PathItem, loc walk.Location) {
+	if pi == nil {
+		return
+	}
+	if l := pi.GoLow(); l != nil && isReference(l.Reference) {
+		return
+	}
+	for i, p := range pi.Parameters {
+		e.parameter(p, with(loc, "parameters", strconv.Itoa(i)))
+	}
+	for method, op := range pi.GetOperations().FromOldest() {
+		if pi.AdditionalOperations != nil && pi.AdditionalOperations.GetOrZero(method) == op {
+			e.operation(op, with(loc, "additionalOperations", method))
+			continue
+		}
+		e.operation(op, with(loc, method))
+	}
+}
+
+func (e *extractor) operation(op *v3.Operation, loc walk.Location) {
+	if op == nil {
+		return
+	}
+	for i, p := range op.Parameters {
+		e.parameter(p, with(loc, "parameters", strconv.Itoa(i)))
+	}
+	e.requestBody(op.RequestBody, with(loc, "requestBody"))
+	if op.Responses != nil {
+		for code, r := range op.Responses.Codes.FromOldest() {
+			e.response(r, with(loc, "responses", code))
+		}
+		e.response(op.Responses.Default, with(loc, "responses", "default"))
+	}
+	for name, cb := range op.Callbacks.FromOldest() {
+		e.callback(cb, with(loc, "callbacks", name))
+	}
+}
+
+func (e *extractor) callback(cb *v3.Callback, loc walk.Location) {
+	if cb == nil {
+		return
+	}
+	if l := cb.GoLow(); l != nil && isReference(l.Reference) {
+		return
+	}
+	for expression, pi := range cb.Expression.FromOldest() {
+		e.pathItem(pi, with(loc, expression))
+	}
+}
+
+func (e *extractor) parameter(p *v3.Parameter, loc walk.Location) {
+	if p == nil {
+		return
+	}
+	if l := p.GoLow(); l != nil && isReference(l.Reference) {
+		return
+	}
+	e.schema(&p.Schema, with(loc, "schema"))
+	e.content(p.Content, loc)
+}
+
+func (e *extractor) header(h *v3.Header, loc walk.Location) {
+	if h == nil {
+		return
+	}
+	if l := h.GoLow(); l != nil && isReference(l.Reference) {
+		return
+	}
+	e.schema(&h.Schema, with(loc, "schema"))
+	e.content(h.Content, loc)
+}
+
+func (e *extractor) requestBody(rb *v3.RequestBody, loc walk.Location) {
+	if rb == nil {
+		return
+	}
+	if l := rb.GoLow(); l != nil && isReference(l.Reference) {
+		return
+	}
+	e.content(rb.Content, loc)
+}
+
+func (e *extractor) response(r *v3.Response, loc walk.Location) {
+	if r == nil {
+		return
+	}
+	if l := r.GoLow(); l != nil && isReference(l.Reference) {
+		return
+	}
+	for name, h := range r.Headers.FromOldest() {
+		e.header(h, with(loc, "headers", name))
+	}
+	e.content(r.Content, loc)
+}
+
+func (e *extractor) content(content *orderedmap.Map[string, *v3.MediaType], loc walk.Location) {
+	for mediaType, mt := range content.FromOldest() {
+		if mt == nil {
+			continue
+		}
+		if l := mt.GoLow(); l != nil && isReference(l.Reference) {
+			continue
+		}
+		mtLoc := with(loc, "content", mediaType)
+		e.schema(&mt.Schema, with(mtLoc, "schema"))
+		e.schema(&mt.ItemSchema, with(mtLoc, "itemSchema"))
+		for name, enc := range mt.Encoding.FromOldest() {
+			if enc != nil {
+				for header, h := range enc.Headers.FromOldest() {
+					e.header(h, with(mtLoc, "encoding", name, "headers", header))
+				}
+			}
+		}
+	}
+}
+
+// with returns a copy of a location, with segments appended.
+func with(loc walk.Location, segments ...string) walk.Location {
+	l := make(walk.Location, len(loc), len(loc)+len(segments))
+	copy(l, loc)
+	return append(l, segments...)
+}
+
+// NameFromLocation is the default NamingStrategy. A schema with a title is named after it, other schemas are named
+// after where they're defined: the component or operation they're part of, then the keywords and names leading to
+// them. The request body of `POST /pets` is named `PostPetsRequest`, its `200` response `PostPets200Response`, and
+// the `address` property of the `Pet` component schema `PetAddress`.
+func NameFromLocation(location walk.Location, schema *base.Schema) string {
+	if schema != nil && schema.Title != "" {
+		return pascalCase(schema.Title)
+	}
+	var words []string
+	i := 0
+	switch {
+	case len(location) >= 3 && location[0] == "components":
+		words, i = append(words, pascalCase(location[2])), 3
+	case len(location) >= 3 && (location[0] == "paths" || location[0] == "webhooks"):
+		words, i = append(words, pascalCase(location[2]), pascalCase(location[1])), 3
+	}
+	for ; i < len(location); i++ {
+		next := ""
+		if i+1 < len(location) {
+			next = location[i+1]
+		}
+		switch location[i] {
+		case "schema", "properties", "patternProperties":
+		case "content":
+			i++ // the media type.
+		case "requestBody":
+			words = append(words, "Request")
+		case "responses":
+			words, i = append(words, pascalCase(next)+"Response"), i+1
+		case "headers":
+			words, i = append(words, pascalCase(next)+"Header"), i+1
+		case "parameters":
+			words, i = append(words, "Parameter"+next), i+1
+		case "items":
+			words = append(words, "Item")
+		case "additionalProperties":
+			words = append(words, "Value")
+		default:
+			words = append(words, pascalCase(location[i]))
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// pascalCase joins the words of a string (separated by anything that isn't a letter or a digit), capitalizing each.
+func pascalCase(s string) string {
+	var sb strings.Builder
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		r := []rune(w)
+		sb.WriteRune(unicode.ToUpper(r[0]))
+		sb.WriteString(string(r[1:]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,221 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/walk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var inlineSchemasSpec = `openapi: 3.1.0
+info:
+  title: Inline
+  version: 1.0.0
+paths:
+  /pets:
+    parameters:
+      - $ref: '#/components/parameters/Filter'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                address:
+                  type: object
+                  properties:
+                    street:
+                      type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  title: pet summary
+                  type: object
+                  properties:
+                    id:
+                      type: integer
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  parameters:
+    Filter:
+      name: filter
+      in: query
+      schema:
+        type: object
+        properties:
+          tag:
+            type: string
+  responses:
+    Error:
+      description: error
+      content:
+        application/json:
+          schema:
+            type: object
+            properties:
+              message:
+                type: string
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tags:
+          type: array
+          items:
+            type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+func buildInlineSchemasModel(t *testing.T) *v3.Document {
+	doc, err := libopenapi.NewDocument([]byte(inlineSchemasSpec))
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	return &model.Model
+}
+
+func TestExtractInlineSchemas(t *testing.T) {
+	model := buildInlineSchemasModel(t)
+
+	report, err := ExtractInlineSchemas(model, nil)
+	require.NoError(t, err)
+
+	var names []string
+	for _, s := range report.Schemas {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{
+		"PetAddress", "Filter", "Error", "PostPetsRequest", "PostPetsRequestAddress", "PetSummary",
+	}, names)
+	assert.Equal(t, map[string]string{
+		"components/schemas/Pet/properties/address":                                         "#/components/schemas/PetAddress",
+		"components/parameters/Filter/schema":                                               "#/components/schemas/Filter",
+		"components/responses/Error/content/application~1json/schema":                       "#/components/schemas/Error",
+		"paths/~1pets/post/requestBody/content/application~1json/schema":                    "#/components/schemas/PostPetsRequest",
+		"paths/~1pets/post/requestBody/content/application~1json/schema/properties/address": "#/components/schemas/PostPetsRequestAddress",
+		"paths/~1pets/post/responses/200/content/application~1json/schema/items":            "#/components/schemas/PetSummary",
+	}, report.Mapping())
+
+	rendered, err := model.Render()
+	require.NoError(t, err)
+
+	// the refactored document is built again, every extracted schema is now a reference to a component.
+	doc, err := libopenapi.NewDocument(rendered)
+	require.NoError(t, err)
+	refactored, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	schemas := refactored.Model.Components.Schemas
+	assert.Equal(t, 8, schemas.Len())
+	assert.Equal(t, "#/components/schemas/PetAddress",
+		schemas.GetOrZero("Pet").Schema().Properties.GetOrZero("address").GetReference())
+	assert.Equal(t, []string{"object"}, schemas.GetOrZero("PetAddress").Schema().Type)
+	assert.Equal(t, "#/components/schemas/PostPetsRequestAddress",
+		schemas.GetOrZero("PostPetsRequest").Schema().Properties.GetOrZero("address").GetReference())
+
+	post := refactored.Model.Paths.PathItems.GetOrZero("/pets").Post
+	assert.Equal(t, "#/components/schemas/PostPetsRequest",
+		post.RequestBody.Content.GetOrZero("application/json").Schema.GetReference())
+	items := post.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema.Schema().Items
+	assert.Equal(t, "#/components/schemas/PetSummary", items.A.GetReference())
+	assert.Equal(t, "#/components/schemas/Error",
+		refactored.Model.Components.Responses.GetOrZero("Error").Content.GetOrZero("application/json").
+			Schema.GetReference())
+
+	// references are left as they are.
+	assert.Equal(t, "#/components/schemas/Owner",
+		schemas.GetOrZero("Pet").Schema().Properties.GetOrZero("owner").GetReference())
+	assert.True(t, refactored.Model.Paths.PathItems.GetOrZero("/pets").Parameters[0].GoLow().IsReference())
+
+	// nothing is left to extract.
+	report, err = ExtractInlineSchemas(&refactored.Model, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Schemas)
+}
+
+func TestExtractInlineSchemas_NamingStrategy(t *testing.T) {
+	model := buildInlineSchemasModel(t)
+
+	report, err := ExtractInlineSchemas(model, func(location walk.Location, schema *base.Schema) string {
+		return "Inline"
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Schemas, 6)
+	assert.Equal(t, "Inline", report.Schemas[0].Name)
+	assert.Equal(t, "Inline2", report.Schemas[1].Name)
+	assert.Equal(t, "#/components/schemas/Inline6", report.Schemas[5].Reference)
+	assert.Equal(t, walk.Location{"paths", "/pets", "post", "responses", "200", "content", "application/json",
+		"schema", "items"}, report.Schemas[5].Location)
+}
+
+func TestExtractInlineSchemas_NoComponents(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: Inline
+  version: 1.0.0
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              properties:
+                name:
+                  type: string`))
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	report, err := ExtractInlineSchemas(&model.Model, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Schemas, 1)
+	assert.Equal(t, "PostNewPetRequest", report.Schemas[0].Name)
+
+	rendered, err := model.Model.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "$ref: '#/components/schemas/PostNewPetRequest'")
+	assert.Contains(t, string(rendered), "components:\n    schemas:\n        PostNewPetRequest:\n")
+
+	_, err = ExtractInlineSchemas(nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidModel)
+}
+
+func TestNameFromLocation(t *testing.T) {
+	assert.Equal(t, "GetPetsPetId200ResponseItem", NameFromLocation(walk.Location{"paths", "/pets/{pet_id}", "get",
+		"responses", "200", "content", "application/json", "schema", "items"}, nil))
+	assert.Equal(t, "ErrorXRateHeader", NameFromLocation(walk.Location{"components", "responses", "Error",
+		"headers", "x-rate", "schema"}, &base.Schema{}))
+	assert.Equal(t, "GetPetsParameter0Value", NameFromLocation(walk.Location{"paths", "/pets", "get",
+		"parameters", "0", "schema", "additionalProperties"}, nil))
+	assert.Equal(t, "PetAllOf1", NameFromLocation(walk.Location{"components", "schemas", "Pet", "allOf", "1"}, nil))
+	assert.Equal(t, "NewPet", NameFromLocation(walk.Location{"components", "schemas", "Pet"},
+		&base.Schema{Title: "new pet"}))
+}
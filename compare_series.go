@@ -0,0 +1,142 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// CompareDocumentSeries will accept a series of Document implementing structs (versions of the same specification,
+// oldest first), build a model for each, then compare every version with the one before it. The result is a
+// timeline of the changes made across the whole series, with running totals of changes and breaking changes.
+//
+// Every document must be the same version of OpenAPI. Errors building the models are returned alongside the series,
+// if a model can't be built at all, the errors are returned with a nil *model.ChangeSeries.
+func CompareDocumentSeries(documents []Document) (*model.ChangeSeries, []error) {
+	if len(documents) == 0 {
+		return new(model.ChangeSeries), nil
+	}
+	specType := documents[0].GetSpecInfo().SpecType
+	for i, d := range documents {
+		if d.GetSpecInfo().SpecType != specType {
+			return nil, []error{fmt.Errorf("unable to compare the series, document %d is not the same version as the first", i+1)}
+		}
+	}
+
+	var errs []error
+	switch specType {
+	case utils.OpenApi3:
+		lows := make([]*v3low.Document, len(documents))
+		for i, d := range documents {
+			m, buildErrs := d.BuildV3Model()
+			errs = append(errs, buildErrs...)
+			if m == nil {
+				return nil, errs
+			}
+			lows[i] = m.Model.GoLow()
+		}
+		return what_changed.CompareSeries(lows), errs
+	case utils.OpenApi2:
+		lows := make([]*v2low.Swagger, len(documents))
+		for i, d := range documents {
+			m, buildErrs := d.BuildV2Model()
+			errs = append(errs, buildErrs...)
+			if m == nil {
+				return nil, errs
+			}
+			lows[i] = m.Model.GoLow()
+		}
+		return what_changed.CompareSwaggerSeries(lows), errs
+	}
+	return nil, []error{fmt.Errorf("unable to compare the series, the documents are not OpenAPI or Swagger documents")}
+}
+
+// CompareDirectory loads every specification in a directory of historical versions (every .yaml, .yml and .json
+// file), orders them by name, numbers compared as numbers (so `v2.yaml` comes before `v10.yaml`), then compares
+// them as a series with CompareDocumentSeries. The documents are loaded in parallel with LoadAll, using config, and
+// errors are prefixed with the name of the file.
+func CompareDirectory(dir string, config *LoadAllConfig) (*model.ChangeSeries, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, []error{fmt.Errorf("unable to read the directory of versions: %w", err)}
+	}
+	var names []string
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !e.IsDir() {
+				names = append(names, e.Name())
+			}
+		}
+	}
+	slices.SortFunc(names, compareVersionNames)
+
+	sources := make([]Source, len(names))
+	for i, name := range names {
+		sources[i] = Source{Name: name, Path: filepath.Join(dir, name)}
+	}
+	loaded := LoadAll(sources, config)
+	var errs []error
+	documents := make([]Document, len(names))
+	for i, res := range loaded.Results {
+		for _, e := range res.Errors {
+			errs = append(errs, fmt.Errorf("%s: %w", res.Source.Name, e))
+		}
+		if res.Document == nil {
+			return nil, errs
+		}
+		documents[i] = res.Document
+	}
+	series, seriesErrs := CompareDocumentSeries(documents)
+	return series, append(errs, seriesErrs...)
+}
+
+// compareVersionNames compares two file names without their extensions, comparing runs of digits by their value.
+// Names of the same value (such as `v2.yaml` and `v02.yaml`) are compared as strings.
+func compareVersionNames(a, b string) int {
+	if c := compareNatural(strings.TrimSuffix(a, filepath.Ext(a)), strings.TrimSuffix(b, filepath.Ext(b))); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		da, db := leadingDigits(a), leadingDigits(b)
+		if da != "" && db != "" {
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if c := len(na) - len(nb); c != 0 {
+				return c
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
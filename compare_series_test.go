@@ -0,0 +1,113 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func seriesVersion(version, paths string) string {
+	return fmt.Sprintf("openapi: 3.1.0\ninfo:\n  title: pets\n  version: %s\npaths:\n%s", version, paths)
+}
+
+func TestCompareDocumentSeries(t *testing.T) {
+	var documents []Document
+	for _, spec := range []string{
+		seriesVersion("1.0.0", "  /pets:\n    get:\n      description: pets\n"),
+		seriesVersion("1.1.0", "  /pets:\n    get:\n      description: pets\n  /toys:\n    get:\n      description: toys\n"),
+		seriesVersion("2.0.0", "  /toys:\n    get:\n      description: toys\n"),
+	} {
+		doc, err := NewDocument([]byte(spec))
+		require.NoError(t, err)
+		documents = append(documents, doc)
+	}
+
+	series, errs := CompareDocumentSeries(documents)
+	assert.Empty(t, errs)
+	require.Len(t, series.Steps, 2)
+
+	added := series.GetStep("1.1.0")
+	assert.Equal(t, "1.0.0", added.From)
+	assert.Zero(t, added.TotalBreakingChanges)
+	assert.Equal(t, 2, added.TotalChanges)
+	assert.Equal(t, 4, series.TotalChanges)
+
+	removed := series.GetStep("2.0.0")
+	assert.Equal(t, 2, removed.TotalChanges)
+	assert.Equal(t, 4, removed.CumulativeChanges)
+	assert.Equal(t, 1, removed.TotalBreakingChanges)
+	assert.Equal(t, 1, removed.CumulativeBreakingChanges)
+	assert.Same(t, removed, series.GetLastBreakingStep())
+
+	series, errs = CompareDocumentSeries(nil)
+	assert.Empty(t, errs)
+	assert.Empty(t, series.Steps)
+}
+
+func TestCompareDocumentSeries_Swagger(t *testing.T) {
+	original, _ := os.ReadFile("test_specs/petstorev2-complete.yaml")
+	modified, _ := os.ReadFile("test_specs/petstorev2-complete-modified.yaml")
+	origDoc, _ := NewDocument(original)
+	modDoc, _ := NewDocument(modified)
+
+	series, errs := CompareDocumentSeries([]Document{origDoc, modDoc})
+	assert.Empty(t, errs)
+	assert.Equal(t, 52, series.TotalChanges)
+	assert.Equal(t, 26, series.TotalBreakingChanges)
+}
+
+func TestCompareDocumentSeries_MixedVersions(t *testing.T) {
+	v3Doc, _ := NewDocument([]byte(seriesVersion("1.0.0", "  {}")))
+	v2Doc, _ := NewDocument([]byte("swagger: 2.0\ninfo:\n  title: pets\n  version: 1.0.0"))
+
+	series, errs := CompareDocumentSeries([]Document{v3Doc, v2Doc})
+	assert.Nil(t, series)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "unable to compare the series, document 2 is not the same version as the first", errs[0].Error())
+}
+
+func TestCompareDirectory(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, spec string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(spec), 0o644))
+	}
+	write("v1.yaml", seriesVersion("1", "  /pets:\n    get:\n      description: pets\n"))
+	write("v2.yaml", seriesVersion("2", "  /pets:\n    get:\n      description: all the pets\n"))
+	write("v10.yml", seriesVersion("10", "  {}"))
+	write("notes.txt", "not a specification")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "archive.yaml"), 0o755))
+
+	series, errs := CompareDirectory(dir, nil)
+	assert.Empty(t, errs)
+	require.Len(t, series.Steps, 2)
+	assert.Equal(t, "1", series.Steps[0].From)
+	assert.Equal(t, "2", series.Steps[0].To)
+	assert.Equal(t, 2, series.Steps[0].TotalChanges)
+	assert.Equal(t, "10", series.Steps[1].To)
+	assert.Equal(t, 1, series.Steps[1].TotalBreakingChanges)
+
+	write("v3.yaml", "this is not a spec")
+	series, errs = CompareDirectory(dir, nil)
+	assert.Nil(t, series)
+	require.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "v3.yaml: ")
+
+	_, errs = CompareDirectory(filepath.Join(dir, "missing"), nil)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], os.ErrNotExist)
+}
+
+func TestCompareVersionNames(t *testing.T) {
+	names := []string{"v10.yaml", "v2.yaml", "v1.10.0.yaml", "v1.2.0.yaml", "v1.yaml", "v02.yaml", "alpha.yaml"}
+	slices.SortFunc(names, compareVersionNames)
+	assert.Equal(t, []string{"alpha.yaml", "v1.yaml", "v1.2.0.yaml", "v1.10.0.yaml", "v02.yaml", "v2.yaml", "v10.yaml"}, names)
+	assert.Negative(t, compareVersionNames("v2.json", "v2.yaml"))
+}
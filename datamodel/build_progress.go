@@ -0,0 +1,85 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"log/slog"
+	"sync"
+)
+
+// The keys of the structured fields of every event logged while a document is built, so log events can be filtered
+// and grouped by the document, file, path or build phase they are about.
+const (
+	LogKeyDocument = "document" // the root specification file of the document being built.
+	LogKeyFile     = "file"     // the file (or URL) being read.
+	LogKeyPath     = "path"     // the path (of the paths object) being built.
+	LogKeyPhase    = "phase"    // the BuildPhase running.
+)
+
+// BuildPhase is a stage of building a document.
+type BuildPhase string
+
+const (
+	PhaseIndex   BuildPhase = "index"   // files are fetched and indexed by the rolodex.
+	PhaseResolve BuildPhase = "resolve" // references are resolved and checked for circular references.
+	PhaseBuild   BuildPhase = "build"   // the model is built, path by path.
+)
+
+// ProgressEvent is sent every time a step of building a document completes, such as a file being fetched, a
+// resolution pass completing or a path item being built.
+type ProgressEvent struct {
+	Phase    BuildPhase
+	Document string // the root specification file of the document, when it is known.
+	File     string // the file (or URL) fetched, for PhaseIndex events.
+	Path     string // the path built, for PhaseBuild events.
+	Done     int    // how many steps of the phase have completed, including this one.
+	Total    int    // how many steps the phase has, or 0 when it's not known (files are found as they are fetched).
+}
+
+// ProgressFunc receives the progress of a document build. Calls are never concurrent, but can come from any
+// goroutine, so a ProgressFunc should return quickly.
+type ProgressFunc func(event ProgressEvent)
+
+// ProgressReporter counts the steps of each phase of a build, and passes them on to a ProgressFunc. A nil
+// ProgressReporter, or one without a ProgressFunc, reports nothing.
+type ProgressReporter struct {
+	progress ProgressFunc
+	document string
+	mu       sync.Mutex
+	done     map[BuildPhase]int
+}
+
+// NewProgressReporter creates a ProgressReporter that reports the progress of building document to progress.
+// Returns nil if progress is nil.
+func NewProgressReporter(document string, progress ProgressFunc) *ProgressReporter {
+	if progress == nil {
+		return nil
+	}
+	return &ProgressReporter{progress: progress, document: document, done: make(map[BuildPhase]int)}
+}
+
+// Report sends an event with its Document set. Unless the event sets Done itself, it's counted as the next completed
+// step of its phase.
+func (p *ProgressReporter) Report(event ProgressEvent) {
+	if p == nil || p.progress == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if event.Done == 0 {
+		p.done[event.Phase]++
+		event.Done = p.done[event.Phase]
+	}
+	event.Document = p.document
+	p.progress(event)
+}
+
+// BuildLogger returns the logger of a document configuration, with the document as a structured field. Returns nil
+// if there is no logger.
+func (c *DocumentConfiguration) BuildLogger() *slog.Logger {
+	if c.Logger == nil || c.SpecFilePath == "" {
+		return c.Logger
+	}
+	return c.Logger.With(LogKeyDocument, c.SpecFilePath)
+}
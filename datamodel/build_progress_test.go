@@ -0,0 +1,70 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProgressReporter(t *testing.T) {
+	assert.Nil(t, NewProgressReporter("openapi.yaml", nil))
+
+	// a nil reporter reports nothing, and doesn't panic.
+	var p *ProgressReporter
+	p.Report(ProgressEvent{Phase: PhaseIndex})
+}
+
+func TestProgressReporter_Report(t *testing.T) {
+	var events []ProgressEvent
+	p := NewProgressReporter("openapi.yaml", func(event ProgressEvent) {
+		events = append(events, event)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.Report(ProgressEvent{Phase: PhaseBuild, Total: 10})
+		}()
+	}
+	wg.Wait()
+	p.Report(ProgressEvent{Phase: PhaseIndex, File: "a.yaml"})
+	p.Report(ProgressEvent{Phase: PhaseResolve, Done: 3, Total: 4})
+
+	require.Len(t, events, 12)
+	for i, e := range events[:10] {
+		assert.Equal(t, PhaseBuild, e.Phase)
+		assert.Equal(t, i+1, e.Done)
+		assert.Equal(t, 10, e.Total)
+		assert.Equal(t, "openapi.yaml", e.Document)
+	}
+	// steps are counted per phase.
+	assert.Equal(t, ProgressEvent{Phase: PhaseIndex, Document: "openapi.yaml", File: "a.yaml", Done: 1}, events[10])
+	// a step that knows its position isn't counted.
+	assert.Equal(t, ProgressEvent{Phase: PhaseResolve, Document: "openapi.yaml", Done: 3, Total: 4}, events[11])
+}
+
+func TestDocumentConfiguration_BuildLogger(t *testing.T) {
+	assert.Nil(t, new(DocumentConfiguration).BuildLogger())
+
+	var buf bytes.Buffer
+	config := &DocumentConfiguration{Logger: slog.New(slog.NewJSONHandler(&buf, nil))}
+	assert.Same(t, config.Logger, config.BuildLogger())
+
+	config.SpecFilePath = "openapi.yaml"
+	config.BuildLogger().Info("built", LogKeyPhase, PhaseBuild)
+
+	var logged map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+	assert.Equal(t, "openapi.yaml", logged[LogKeyDocument])
+	assert.Equal(t, "build", logged[LogKeyPhase])
+}
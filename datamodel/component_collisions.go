@@ -0,0 +1,39 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+// ComponentCollisionStrategy decides the name of a component when several files define different components of the
+// same type and name, and their components are combined (see index.Rolodex.ComponentNames).
+type ComponentCollisionStrategy int
+
+const (
+	// ComponentCollisionError reports every collision as an error.
+	ComponentCollisionError ComponentCollisionStrategy = iota
+
+	// ComponentCollisionFirstWins keeps the component defined first, the others are replaced by it.
+	ComponentCollisionFirstWins
+
+	// ComponentCollisionFilePrefix renames the components defined after the first, prefixing their name with the
+	// name of their file, such as `pets_Pet` for a `Pet` schema defined in `pets.yaml`.
+	ComponentCollisionFilePrefix
+
+	// ComponentCollisionContentHash renames the components defined after the first, suffixing their name with a
+	// hash of their content, such as `Pet_1a2b3c4d`.
+	ComponentCollisionContentHash
+)
+
+// String returns the name of the strategy.
+func (s ComponentCollisionStrategy) String() string {
+	switch s {
+	case ComponentCollisionError:
+		return "error"
+	case ComponentCollisionFirstWins:
+		return "first-wins"
+	case ComponentCollisionFilePrefix:
+		return "file-prefix"
+	case ComponentCollisionContentHash:
+		return "content-hash"
+	}
+	return "unknown"
+}
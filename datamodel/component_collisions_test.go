@@ -0,0 +1,18 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentCollisionStrategy_String(t *testing.T) {
+	assert.Equal(t, "error", ComponentCollisionError.String())
+	assert.Equal(t, "first-wins", ComponentCollisionFirstWins.String())
+	assert.Equal(t, "file-prefix", ComponentCollisionFilePrefix.String())
+	assert.Equal(t, "content-hash", ComponentCollisionContentHash.String())
+	assert.Equal(t, "unknown", ComponentCollisionStrategy(42).String())
+}
@@ -29,6 +29,12 @@ const (
 
 	// OAS31 represents OpenAPI 3.1+ Documents
 	OAS31 = "oas3_1"
+
+	// OAS32 represents OpenAPI 3.2+ Documents
+	OAS32 = "oas3_2"
+
+	// Arazzo1 represents Arazzo 1.x (workflow) Documents
+	Arazzo1 = "arazzo1"
 )
 
 // OpenAPI3SchemaData is an embedded version of the OpenAPI 3 Schema
@@ -49,14 +55,17 @@ var OpenAPI2SchemaData string // embedded OAS3 schema
 // OAS3_1Format defines documents that can only be version 3.1
 var OAS3_1Format = []string{OAS31}
 
+// OAS3_2Format defines documents that can only be version 3.2
+var OAS3_2Format = []string{OAS32}
+
 // OAS3Format defines documents that can only be version 3.0
 var OAS3Format = []string{OAS3}
 
 // OAS3AllFormat defines documents that compose all 3+ versions
-var OAS3AllFormat = []string{OAS3, OAS31}
+var OAS3AllFormat = []string{OAS3, OAS31, OAS32}
 
 // OAS2Format defines documents that compose swagger documnets (version 2.0)
 var OAS2Format = []string{OAS2}
 
 // AllFormats defines all versions of OpenAPI
-var AllFormats = []string{OAS3, OAS31, OAS2}
+var AllFormats = []string{OAS3, OAS31, OAS32, OAS2}
@@ -53,6 +53,10 @@ type DocumentConfiguration struct {
 
 	// LocalFS is a filesystem that will be used to retrieve local documents. If not set, then the rolodex will
 	// use its own internal local filesystem implementation. The default is to use the internal local filesystem loader.
+	//
+	// Any fs.FS can be supplied, such as an embed.FS, an fstest.MapFS or a zip.Reader. If it is not an
+	// index.RolodexFS, it is mounted at the BasePath as a virtual file system, and local references are read
+	// from it instead of the disk.
 	LocalFS fs.FS
 
 	// AllowFileReferences will allow the index to locate relative file references. This is disabled by default.
@@ -105,6 +109,18 @@ type DocumentConfiguration struct {
 	// will be used, set to the Error level.
 	Logger *slog.Logger
 
+	// Progress receives an event every time a file is fetched, a resolution pass completes and a path item is built,
+	// so long builds of large specifications can show progress. Events are counted per BuildPhase. Not set by default.
+	Progress ProgressFunc
+
+	// IncludePaths and ExcludePaths limit which paths are built into the model. Each is a list of path selectors,
+	// globs such as `/users/**`, or regular expressions prefixed with `regex:` (see utils.PathSelector). A path is
+	// built if it matches any include selector (or IncludePaths is empty), and no exclude selector. Paths that are
+	// not built are left out of the Paths object entirely, which cuts build time for tools that only need a subset of
+	// a large specification. Everything else, including the index, is unaffected. Every path is built by default.
+	IncludePaths []string
+	ExcludePaths []string
+
 	// ExtractRefsSequentially will extract all references sequentially, which means the index will look up references
 	// as it finds them, vs looking up everything asynchronously.
 	// This is a more thorough way of building the index, but it's slower. It's required building a document
@@ -114,6 +130,95 @@ type DocumentConfiguration struct {
 	// BundleInlineRefs is used by the bundler module. If set to true, all references will be inlined, including
 	// local references (to the root document) as well as all external references. This is false by default.
 	BundleInlineRefs bool
+
+	// Limits are hard limits enforced during indexing and resolution, such as the maximum number of nodes in a file
+	// or the maximum number of remote files that will be fetched. Use these when parsing untrusted specifications.
+	// No limits are applied by default.
+	Limits DocumentLimits
+
+	// RemoteFetch controls how remote files are fetched by the rolodex, such as how many are fetched at the same
+	// time, how failed fetches are retried and the overall time allowed. No limits are applied by default.
+	RemoteFetch RemoteFetchConfig
+
+	// OfflineBaseDir is the directory of a local mirror of remote files, for builds that must not touch the network
+	// (such as air-gapped CI). When set, remote references are resolved without fetching anything: every remote URL
+	// is read from the file it maps to in the mirror (see OfflinePath), overriding the RemoteURLHandler. A remote
+	// file that has not been vendored into the mirror fails to load. Remote references are enabled by setting it,
+	// whether AllowRemoteReferences is set or not. Not set by default.
+	OfflineBaseDir string
+
+	// OfflineMappings are the rules mapping remote URLs to the mirror in OfflineBaseDir, keyed by URL prefix. A URL
+	// that starts with a prefix is mapped to the path (relative to OfflineBaseDir) the prefix maps to, followed by
+	// the rest of the URL, the longest prefix wins. For example `https://pb33f.io/specs/` mapped to `pb33f` maps
+	// `https://pb33f.io/specs/pets.yaml` to `pb33f/pets.yaml`. URLs that match no prefix are mapped to their host
+	// followed by their path.
+	OfflineMappings map[string]string
+
+	// InternStrings shares the memory of every repeated key and reference string across the document (every file of
+	// it), instead of holding a copy for every occurrence. Strings are interned once each file is parsed, so only the
+	// memory held after a file is indexed is cut, not the memory used to parse it, and only the strings themselves
+	// are shared (the nodes holding them are not), at the cost of a pass over each file when it's indexed. Strings
+	// are not shared between documents. This is false by default.
+	InternStrings bool
+
+	// Normalize rewrites semantically equivalent forms in every file of the document into a single canonical form
+	// before the model is built (see utils.NormalizeNodes), for example `type: [string]` becomes `type: string` and
+	// a `style` holding the default for its parameter is removed. Documents that only differ in how they're written
+	// then hash the same, and what-changed does not report false differences between them. As the nodes are
+	// rewritten, rendering the document renders the canonical forms. This is false by default.
+	Normalize bool
+
+	// BuildSearchIndex builds the full-text search index of the descriptions, summaries and titles of every file
+	// while the document is indexed (see index.SpecIndex.Search and index.Rolodex.Search), rather than the first
+	// time a file is searched. This is false by default.
+	BuildSearchIndex bool
+
+	// ComponentCollisionStrategy decides the names of components defined by several files with the same type and
+	// name, but different content, when the components of every file are combined (see
+	// index.Rolodex.ComponentNames): an error, the first wins, or renaming with a file prefix or content hash
+	// suffix. Collisions are errors by default.
+	ComponentCollisionStrategy ComponentCollisionStrategy
+
+	// ExtensionRegistry holds typed models registered against extension keys (for example `x-streaming`). When set,
+	// every extension with a registered key is built into its typed model by the standard low-level pipeline, with
+	// full index and reference resolution support. Create one with low.NewExtensionRegistry and register types using
+	// low.RegisterExtension.
+	ExtensionRegistry ExtensionRegistry
+
+	// EmbedProvenance will render the provenance of the document (where it came from, the hash of its bytes, when
+	// it was read, the libopenapi version and this configuration) as an `x-libopenapi-provenance` extension at the top
+	// of the rendered document. This is false by default.
+	EmbedProvenance bool
+
+	// Concurrency is the number of workers building the low-level model in parallel (the paths, components and maps
+	// of every object). Builds with the same concurrency share one pool of workers (see datamodel.SharedWorkerPool),
+	// rather than spawning goroutines for every object built, until datamodel.CloseSharedWorkerPools stops them.
+	// Zero (the default) is GOMAXPROCS.
+	Concurrency int
+
+	// Strict turns problems that are tolerated while building the model into build errors. By default, a path item
+	// that fails to build (for example because it holds a reference that cannot be resolved, or responses that are
+	// not a map) is logged and left partially built, and only the last error of the components is returned. When
+	// strict, every one of these is returned, joined into the error of the build, for those that would rather fail
+	// than work with a partial model. When Lenient is set as well, it wins (see Lenient). This is false by default.
+	Strict bool
+
+	// Lenient guarantees a model is built for as much of a broken document as possible, with every problem found
+	// along the way returned, rather than the first. Path items, components and tags that fail to build are
+	// skipped (or kept partially built) and the build carries on, references that cannot be resolved no longer
+	// prevent a model from being returned, and the errors are available as the BuildErrors of the model, with
+	// where they happened, for editors that show all the problems of a document at once. A build cut short (by a
+	// cancelled context, an exceeded limit or a pin mismatch) still returns what was built, but that model is not
+	// kept by the document, so the next build starts over. When Strict is set as well, every problem is collected as
+	// Strict would, but a model is still returned rather than failing. This is false by default.
+	Lenient bool
+}
+
+// ExtensionRegistry is implemented by low.ExtensionRegistry. It exists here so a registry can be supplied as part of
+// the document configuration, without the datamodel package depending on the low-level model.
+type ExtensionRegistry interface {
+	// IsRegistered returns true if a typed model has been registered for the extension key.
+	IsRegistered(key string) bool
 }
 
 func NewDocumentConfiguration() *DocumentConfiguration {
@@ -0,0 +1,110 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrLimitExceeded is wrapped by every error returned when one of the DocumentLimits has been exceeded.
+// Use errors.Is(err, datamodel.ErrLimitExceeded) to check for it.
+var ErrLimitExceeded = errors.New("document limit exceeded")
+
+// DocumentLimits defines hard limits that are enforced while indexing and resolving a document. They are designed to
+// protect services that parse user-supplied specifications from YAML bombs (billion laughs), huge files and runaway
+// remote reference chains.
+//
+// A zero value for any limit means no limit is applied.
+type DocumentLimits struct {
+	// MaxNodes is the maximum number of YAML nodes allowed in any single file (including the root document).
+	// Aliases are expanded when counting, so alias bombs are caught.
+	MaxNodes int
+
+	// MaxRefDepth is the maximum length of a reference chain (a reference to a reference, to a reference...) that
+	// will be followed when building models and when resolving.
+	MaxRefDepth int
+
+	// MaxFileSize is the maximum size in bytes of any single file, local or remote (including the root document).
+	MaxFileSize int64
+
+	// MaxRemoteFiles is the maximum number of remote files that will be fetched by the rolodex.
+	MaxRemoteFiles int
+
+	// MaxCompositionDepth is the maximum nesting of allOf, oneOf and anyOf compositions (a schema composed of schemas
+	// that are composed of schemas...) in any component schema, with references followed.
+	MaxCompositionDepth int
+
+	// MaxCompositionSize is the maximum number of schemas any component schema expands to, when every allOf, oneOf
+	// and anyOf composition reachable from it is expanded (with references followed). Each time a schema is
+	// referenced it's counted again, so chains of compositions that share schemas grow exponentially, which makes
+	// resolving and hashing them hang.
+	MaxCompositionSize int
+}
+
+// CheckFileSize returns an error if size exceeds MaxFileSize.
+func (l DocumentLimits) CheckFileSize(location string, size int64) error {
+	if l.MaxFileSize > 0 && size > l.MaxFileSize {
+		return fmt.Errorf("%w: file '%s' is larger than the maximum file size of %d bytes",
+			ErrLimitExceeded, location, l.MaxFileSize)
+	}
+	return nil
+}
+
+// CheckNodes returns an error if the tree below root (with aliases expanded) contains more than MaxNodes nodes.
+func (l DocumentLimits) CheckNodes(location string, root *yaml.Node) error {
+	if l.MaxNodes > 0 && utils.CountNodes(root, l.MaxNodes) > l.MaxNodes {
+		return fmt.Errorf("%w: file '%s' contains more than the maximum of %d nodes",
+			ErrLimitExceeded, location, l.MaxNodes)
+	}
+	return nil
+}
+
+// CheckSpecInfo returns an error if the size or the nodes of a parsed spec exceed MaxFileSize or MaxNodes. A spec
+// already checked against the same limits as it was parsed (see ExtractSpecInfoWithLimits) isn't checked again.
+func (l DocumentLimits) CheckSpecInfo(location string, info *SpecInfo) error {
+	if info.CheckedLimits.MaxFileSize == l.MaxFileSize && info.CheckedLimits.MaxNodes == l.MaxNodes {
+		return nil
+	}
+	if info.SpecBytes != nil {
+		if err := l.CheckFileSize(location, int64(len(*info.SpecBytes))); err != nil {
+			return err
+		}
+	}
+	return l.CheckNodes(location, info.RootNode)
+}
+
+// CheckRefDepth returns an error if depth exceeds MaxRefDepth.
+func (l DocumentLimits) CheckRefDepth(reference string, depth int) error {
+	if l.MaxRefDepth > 0 && depth > l.MaxRefDepth {
+		return fmt.Errorf("%w: reference '%s' exceeds the maximum reference depth of %d",
+			ErrLimitExceeded, reference, l.MaxRefDepth)
+	}
+	return nil
+}
+
+// CheckRemoteFiles returns an error if count exceeds MaxRemoteFiles.
+func (l DocumentLimits) CheckRemoteFiles(location string, count int) error {
+	if l.MaxRemoteFiles > 0 && count > l.MaxRemoteFiles {
+		return fmt.Errorf("%w: fetching '%s' exceeds the maximum of %d remote files",
+			ErrLimitExceeded, location, l.MaxRemoteFiles)
+	}
+	return nil
+}
+
+// CheckComposition returns an error if depth exceeds MaxCompositionDepth, or size exceeds MaxCompositionSize.
+func (l DocumentLimits) CheckComposition(schema string, depth, size int) error {
+	if l.MaxCompositionDepth > 0 && depth > l.MaxCompositionDepth {
+		return fmt.Errorf("%w: schema '%s' nests compositions %d deep, which exceeds the maximum composition "+
+			"depth of %d (flatten its allOf, oneOf and anyOf chains)", ErrLimitExceeded, schema, depth, l.MaxCompositionDepth)
+	}
+	if l.MaxCompositionSize > 0 && size > l.MaxCompositionSize {
+		return fmt.Errorf("%w: schema '%s' expands to %d schemas, which exceeds the maximum composition size "+
+			"of %d (reduce the schemas its allOf, oneOf and anyOf chains share)", ErrLimitExceeded, schema, size, l.MaxCompositionSize)
+	}
+	return nil
+}
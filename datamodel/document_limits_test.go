@@ -0,0 +1,101 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDocumentLimits_Unlimited(t *testing.T) {
+	var l DocumentLimits
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`a: b`), &root)
+
+	assert.NoError(t, l.CheckFileSize("root", 1<<40))
+	assert.NoError(t, l.CheckNodes("root", &root))
+	assert.NoError(t, l.CheckRefDepth("#/components/schemas/A", 1000))
+	assert.NoError(t, l.CheckRemoteFiles("https://pb33f.io/spec.yaml", 1000))
+	assert.NoError(t, l.CheckComposition("#/components/schemas/A", 1000, 1<<40))
+}
+
+func TestDocumentLimits_Exceeded(t *testing.T) {
+	l := DocumentLimits{MaxNodes: 2, MaxRefDepth: 3, MaxFileSize: 10, MaxRemoteFiles: 1}
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`a: b`), &root)
+
+	err := l.CheckFileSize("root", 11)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.Equal(t, "document limit exceeded: file 'root' is larger than the maximum file size of 10 bytes", err.Error())
+	assert.NoError(t, l.CheckFileSize("root", 10))
+
+	err = l.CheckNodes("root", &root)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, "document limit exceeded: file 'root' contains more than the maximum of 2 nodes", err.Error())
+
+	assert.ErrorIs(t, l.CheckRefDepth("#/a", 4), ErrLimitExceeded)
+	assert.NoError(t, l.CheckRefDepth("#/a", 3))
+
+	assert.ErrorIs(t, l.CheckRemoteFiles("https://pb33f.io", 2), ErrLimitExceeded)
+	assert.NoError(t, l.CheckRemoteFiles("https://pb33f.io", 1))
+}
+
+func TestDocumentLimits_CheckSpecInfo(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: pizza`)
+	l := DocumentLimits{MaxNodes: 3}
+
+	info, err := ExtractSpecInfo(spec)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, l.CheckSpecInfo("root", info), ErrLimitExceeded)
+	assert.ErrorIs(t, DocumentLimits{MaxFileSize: 10}.CheckSpecInfo("root", info), ErrLimitExceeded)
+
+	// checked as it was parsed, so it's not counted again.
+	info, err = ExtractSpecInfoWithLimits(spec, false, "root", DocumentLimits{MaxNodes: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, DocumentLimits{MaxNodes: 100}, info.CheckedLimits)
+	info.RootNode = nil
+	assert.NoError(t, DocumentLimits{MaxNodes: 100}.CheckSpecInfo("root", info))
+}
+
+func TestExtractSpecInfoWithLimits(t *testing.T) {
+	// the size is checked before anything is parsed, so the broken spec is never seen.
+	_, err := ExtractSpecInfoWithLimits([]byte("openapi: 3.1.0\n{{{{{{"), false, "spec.yaml", DocumentLimits{MaxFileSize: 10})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Contains(t, err.Error(), "'spec.yaml'")
+
+	_, err = ExtractSpecInfoWithLimits([]byte("openapi: 3.1.0\na: [1, 2, 3]"), false, "spec.yaml", DocumentLimits{MaxNodes: 5})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+
+	_, err = ExtractSpecInfoWithConfig([]byte("openapi: 3.1.0\na: [1, 2, 3]"), &DocumentConfiguration{
+		SpecFilePath: "pizza.yaml",
+		Limits:       DocumentLimits{MaxNodes: 5},
+	})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Contains(t, err.Error(), "'pizza.yaml'")
+
+	info, err := ExtractSpecInfoWithLimits([]byte("openapi: 3.1.0\na: [1, 2, 3]"), false, "spec.yaml", DocumentLimits{MaxNodes: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "3.1.0", info.Version)
+}
+
+func TestDocumentLimits_CheckComposition(t *testing.T) {
+	l := DocumentLimits{MaxCompositionDepth: 3, MaxCompositionSize: 100}
+
+	err := l.CheckComposition("#/components/schemas/A", 4, 10)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, "document limit exceeded: schema '#/components/schemas/A' nests compositions 4 deep, which "+
+		"exceeds the maximum composition depth of 3 (flatten its allOf, oneOf and anyOf chains)", err.Error())
+
+	err = l.CheckComposition("#/components/schemas/A", 3, 101)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, "document limit exceeded: schema '#/components/schemas/A' expands to 101 schemas, which "+
+		"exceeds the maximum composition size of 100 (reduce the schemas its allOf, oneOf and anyOf chains share)", err.Error())
+
+	assert.NoError(t, l.CheckComposition("#/components/schemas/A", 3, 100))
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// PreserveAnchors walks a rendered node tree alongside the source it was originally built from, and puts back the
+// anchors, aliases and merge keys (`<<`) of the source. Models are built from the values of aliases, so without
+// this every alias is rendered as a full copy of what it points to, and specs that reuse anchors heavily balloon.
+//
+// An anchor is put back on the rendered node at the same location as it was defined in the source. An alias (or
+// merge key) is only put back if what was rendered in its place is still identical to what was rendered for its
+// anchor, and the anchor is rendered before it, so aliases that have been changed in the model (or whose anchor
+// has been removed, or moved after them) are left as full copies.
+//
+// The rendered tree is updated in place, the source is never changed.
+func PreserveAnchors(rendered, source *yaml.Node) {
+	if rendered == nil || source == nil {
+		return
+	}
+	p := &anchorPreserver{
+		anchors: make(map[*yaml.Node]*yaml.Node),
+		order:   make(map[*yaml.Node]int),
+		seen:    make(map[*yaml.Node]struct{}),
+	}
+	p.walk(rendered, source)
+	p.number(rendered)
+
+	// every alias is checked against the anchors as they were rendered, before any of them are changed.
+	var apply []func()
+	for _, a := range p.aliases {
+		if target := p.target(a.anchor, a.parent.Content[a.index]); target != nil && equalNodes(a.parent.Content[a.index], target) {
+			apply = append(apply, func() {
+				a.parent.Content[a.index] = newAliasNode(target)
+			})
+		}
+	}
+	for _, m := range p.merges {
+		if f := p.merge(m); f != nil {
+			apply = append(apply, f)
+		}
+	}
+	for _, f := range apply {
+		f()
+	}
+}
+
+// anchorPreserver holds everything found walking a rendered tree alongside its source.
+type anchorPreserver struct {
+	anchors map[*yaml.Node]*yaml.Node // anchored source nodes, and the nodes rendered for them.
+	order   map[*yaml.Node]int        // the position of every rendered node, in the order they are rendered.
+	seen    map[*yaml.Node]struct{}
+	aliases []aliasSite
+	merges  []mergeSite
+}
+
+// aliasSite is a rendered node (parent.Content[index]) that was an alias in the source.
+type aliasSite struct {
+	parent *yaml.Node
+	index  int
+	anchor *yaml.Node
+}
+
+// mergeSite is a rendered mapping that merged the keys of anchors in the source.
+type mergeSite struct {
+	rendered *yaml.Node
+	explicit map[string]struct{} // the keys of the source mapping itself, which override merged keys.
+	anchors  []*yaml.Node
+	position int // how many keys of the source mapping come before the merge key.
+}
+
+func (p *anchorPreserver) walk(rendered, source *yaml.Node) {
+	if rendered == nil || source == nil || rendered == source {
+		return
+	}
+	if _, ok := p.seen[rendered]; ok {
+		return
+	}
+	p.seen[rendered] = struct{}{}
+	if rendered.Kind == yaml.DocumentNode && len(rendered.Content) > 0 {
+		p.walk(rendered.Content[0], source)
+		return
+	}
+	if source.Kind == yaml.DocumentNode && len(source.Content) > 0 {
+		source = source.Content[0]
+	}
+	if source.Kind == yaml.AliasNode || rendered.Kind != source.Kind {
+		return
+	}
+	if source.Anchor != "" {
+		rendered.Anchor = source.Anchor
+		p.anchors[source] = rendered
+	}
+
+	switch rendered.Kind {
+	case yaml.MappingNode:
+		p.walkMapping(rendered, source)
+	case yaml.SequenceNode:
+		for i := 0; i < len(rendered.Content) && i < len(source.Content); i++ {
+			p.visit(rendered, i, source.Content[i])
+		}
+	}
+}
+
+// visit walks the rendered node at parent.Content[index], or records it as an alias site if the source is an alias.
+func (p *anchorPreserver) visit(parent *yaml.Node, index int, source *yaml.Node) {
+	if source.Kind == yaml.AliasNode && source.Alias != nil {
+		p.aliases = append(p.aliases, aliasSite{parent: parent, index: index, anchor: source.Alias})
+		return
+	}
+	p.walk(parent.Content[index], source)
+}
+
+func (p *anchorPreserver) walkMapping(rendered, source *yaml.Node) {
+	// merged keys are appended to the source mapping when it's built, they are the keys of the anchors it merges.
+	site := mergeSite{rendered: rendered, explicit: make(map[string]struct{})}
+	merged := make(map[*yaml.Node]struct{})
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		if source.Content[i].Tag != "!!merge" {
+			continue
+		}
+		if site.anchors == nil {
+			site.position = i / 2
+		}
+		value := source.Content[i+1]
+		values := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			values = value.Content
+		}
+		for _, v := range values {
+			if v.Kind != yaml.AliasNode || v.Alias == nil {
+				continue
+			}
+			site.anchors = append(site.anchors, v.Alias)
+			for _, n := range v.Alias.Content {
+				merged[n] = struct{}{}
+			}
+		}
+	}
+	var pairs []*yaml.Node
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		if _, ok := merged[source.Content[i]]; ok || source.Content[i].Tag == "!!merge" {
+			continue
+		}
+		site.explicit[source.Content[i].Value] = struct{}{}
+		pairs = append(pairs, source.Content[i], source.Content[i+1])
+	}
+	if site.anchors != nil {
+		p.merges = append(p.merges, site)
+	}
+	for i := 0; i+1 < len(rendered.Content); i += 2 {
+		for j := 0; j+1 < len(pairs); j += 2 {
+			if rendered.Content[i].Value == pairs[j].Value {
+				p.visit(rendered, i+1, pairs[j+1])
+				break
+			}
+		}
+	}
+}
+
+// number records the position of every rendered node, in the order they will be rendered.
+func (p *anchorPreserver) number(node *yaml.Node) {
+	if node == nil {
+		return
+	}
+	if _, ok := p.order[node]; ok {
+		return
+	}
+	p.order[node] = len(p.order)
+	for _, n := range node.Content {
+		p.number(n)
+	}
+}
+
+// target returns the node rendered for an anchor, if it's rendered before the node that refers to it.
+func (p *anchorPreserver) target(anchor, before *yaml.Node) *yaml.Node {
+	target := p.anchors[anchor]
+	if target == nil {
+		return nil
+	}
+	if p.order[target] >= p.order[before] {
+		return nil
+	}
+	return target
+}
+
+// merge returns a function that puts back the merge key of a site, or nil if the keys merged into the rendered
+// mapping are no longer those of the anchors.
+func (p *anchorPreserver) merge(m mergeSite) func() {
+	var targets []*yaml.Node
+	remove := make(map[string]struct{})
+	for _, anchor := range m.anchors {
+		target := p.target(anchor, m.rendered)
+		if target == nil || target.Kind != yaml.MappingNode {
+			return nil
+		}
+		targets = append(targets, target)
+		for i := 0; i+1 < len(target.Content); i += 2 {
+			key := target.Content[i].Value
+			if _, ok := m.explicit[key]; ok {
+				continue
+			}
+			if _, ok := remove[key]; ok {
+				continue // the first anchor merged wins.
+			}
+			value := mappingValue(m.rendered, key)
+			if value == nil || !equalNodes(value, target.Content[i+1]) {
+				return nil
+			}
+			remove[key] = struct{}{}
+		}
+	}
+	return func() {
+		var content []*yaml.Node
+		for i := 0; i+1 < len(m.rendered.Content); i += 2 {
+			if _, ok := remove[m.rendered.Content[i].Value]; !ok {
+				content = append(content, m.rendered.Content[i], m.rendered.Content[i+1])
+			}
+		}
+		value := newAliasNode(targets[0])
+		if len(targets) > 1 {
+			value = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle}
+			for _, t := range targets {
+				value.Content = append(value.Content, newAliasNode(t))
+			}
+		}
+		position := min(m.position*2, len(content))
+		mergeKey := &yaml.Node{Kind: yaml.ScalarNode, Value: "<<"}
+		m.rendered.Content = append(content[:position:position], append([]*yaml.Node{mergeKey, value}, content[position:]...)...)
+	}
+}
+
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func newAliasNode(anchor *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.AliasNode, Value: anchor.Anchor, Alias: anchor}
+}
+
+// equalNodes returns true if two rendered nodes hold the same content (styles are not compared).
+func equalNodes(a, b *yaml.Node) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil || a.Kind != b.Kind || a.Value != b.Value || len(a.Content) != len(b.Content) {
+		return false
+	}
+	if a.Kind == yaml.AliasNode {
+		return a.Alias == b.Alias
+	}
+	for i := range a.Content {
+		if !equalNodes(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPreserveAnchors(t *testing.T) {
+	var source, rendered yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`base: &base
+  a: 1
+  b: [x, y]
+same: *base
+changed: *base
+list:
+  - &item {name: one}
+  - *item
+merged:
+  c: 3
+  <<: *base
+overridden:
+  <<: *base
+  a: 2
+`), &source))
+	// rendered from a model: every alias is a copy, and merged keys are in place.
+	require.NoError(t, yaml.Unmarshal([]byte(`base:
+  a: 1
+  b: [x, y]
+same:
+  a: 1
+  b: [x, y]
+changed:
+  a: 1
+  b: [x, z]
+list:
+  - {name: one}
+  - {name: one}
+merged:
+  c: 3
+  a: 1
+  b: [x, y]
+overridden:
+  a: 2
+  b: [x, y]
+`), &rendered))
+
+	PreserveAnchors(&rendered, &source)
+
+	out, err := yaml.Marshal(&rendered)
+	require.NoError(t, err)
+	assert.Equal(t, `base: &base
+    a: 1
+    b: [x, y]
+same: *base
+changed:
+    a: 1
+    b: [x, z]
+list:
+    - &item {name: one}
+    - *item
+merged:
+    c: 3
+    <<: *base
+overridden:
+    <<: *base
+    a: 2
+`, string(out))
+
+	// what's rendered still reads back as the same values.
+	var reread map[string]any
+	require.NoError(t, yaml.Unmarshal(out, &reread))
+	assert.Equal(t, map[string]any{"a": 2, "b": []any{"x", "y"}}, reread["overridden"])
+}
+
+func TestPreserveAnchors_Unchanged(t *testing.T) {
+	var source, rendered yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`later: &later
+  a: 1
+first: *later
+merged:
+  <<: *later
+`), &source))
+	require.NoError(t, yaml.Unmarshal([]byte(`first:
+  a: 1
+later:
+  a: 1
+merged:
+  a: 2
+`), &rendered))
+
+	// an alias can't be rendered before its anchor, and merged keys that changed are kept.
+	PreserveAnchors(&rendered, &source)
+	out, err := yaml.Marshal(&rendered)
+	require.NoError(t, err)
+	assert.Equal(t, `first:
+    a: 1
+later: &later
+    a: 1
+merged:
+    a: 2
+`, string(out))
+
+	// mismatched shapes are ignored.
+	PreserveAnchors(&rendered, &yaml.Node{Kind: yaml.SequenceNode})
+	PreserveAnchors(nil, &source)
+}
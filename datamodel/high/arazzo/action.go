@@ -0,0 +1,128 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// Action types defined by the Arazzo specification, for success and failure actions.
+const (
+	ActionTypeEnd   = "end"
+	ActionTypeGoto  = "goto"
+	ActionTypeRetry = "retry"
+)
+
+// SuccessAction represents a high-level Arazzo Success Action object, that is backed by a low-level one.
+//
+// If the action is a Reusable object, ComponentReference is the expression referencing the action in the
+// components (for example `$components.successActions.done`).
+//   - https://spec.openapis.org/arazzo/latest.html#success-action-object
+type SuccessAction struct {
+	Name               string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	Type               string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	WorkflowId         string                              `json:"workflowId,omitempty" yaml:"workflowId,omitempty"`
+	StepId             string                              `json:"stepId,omitempty" yaml:"stepId,omitempty"`
+	Criteria           []*Criterion                        `json:"criteria,omitempty" yaml:"criteria,omitempty"`
+	ComponentReference string                              `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Extensions         *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                *low.SuccessAction
+}
+
+// NewSuccessAction creates a new high-level SuccessAction instance from a low-level one.
+func NewSuccessAction(action *low.SuccessAction) *SuccessAction {
+	s := new(SuccessAction)
+	s.low = action
+	s.Name = action.Name.Value
+	s.Type = action.Type.Value
+	s.WorkflowId = action.WorkflowId.Value
+	s.StepId = action.StepId.Value
+	s.Criteria = fromReferenceSlice(action.Criteria.Value, NewCriterion)
+	s.ComponentReference = action.ComponentReference.Value
+	s.Extensions = high.ExtractExtensions(action.Extensions)
+	return s
+}
+
+// GoLow returns the low-level SuccessAction instance used to create the high-level one.
+func (s *SuccessAction) GoLow() *low.SuccessAction {
+	return s.low
+}
+
+// GoLowUntyped will return the low-level SuccessAction instance that was used to create the high-level one, with
+// no type
+func (s *SuccessAction) GoLowUntyped() any {
+	return s.low
+}
+
+// Render will return a YAML representation of the SuccessAction object as a byte slice.
+func (s *SuccessAction) Render() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the SuccessAction object.
+func (s *SuccessAction) MarshalYAML() (interface{}, error) {
+	return high.NewNodeBuilder(s, s.low).Render(), nil
+}
+
+// FailureAction represents a high-level Arazzo Failure Action object, that is backed by a low-level one.
+//
+// If the action is a Reusable object, ComponentReference is the expression referencing the action in the
+// components (for example `$components.failureActions.retry`).
+//   - https://spec.openapis.org/arazzo/latest.html#failure-action-object
+type FailureAction struct {
+	Name               string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	Type               string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	WorkflowId         string                              `json:"workflowId,omitempty" yaml:"workflowId,omitempty"`
+	StepId             string                              `json:"stepId,omitempty" yaml:"stepId,omitempty"`
+	RetryAfter         *float64                            `json:"retryAfter,omitempty" yaml:"retryAfter,omitempty"`
+	RetryLimit         *int64                              `json:"retryLimit,omitempty" yaml:"retryLimit,omitempty"`
+	Criteria           []*Criterion                        `json:"criteria,omitempty" yaml:"criteria,omitempty"`
+	ComponentReference string                              `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Extensions         *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                *low.FailureAction
+}
+
+// NewFailureAction creates a new high-level FailureAction instance from a low-level one.
+func NewFailureAction(action *low.FailureAction) *FailureAction {
+	f := new(FailureAction)
+	f.low = action
+	f.Name = action.Name.Value
+	f.Type = action.Type.Value
+	f.WorkflowId = action.WorkflowId.Value
+	f.StepId = action.StepId.Value
+	if !action.RetryAfter.IsEmpty() {
+		f.RetryAfter = &action.RetryAfter.Value
+	}
+	if !action.RetryLimit.IsEmpty() {
+		f.RetryLimit = &action.RetryLimit.Value
+	}
+	f.Criteria = fromReferenceSlice(action.Criteria.Value, NewCriterion)
+	f.ComponentReference = action.ComponentReference.Value
+	f.Extensions = high.ExtractExtensions(action.Extensions)
+	return f
+}
+
+// GoLow returns the low-level FailureAction instance used to create the high-level one.
+func (f *FailureAction) GoLow() *low.FailureAction {
+	return f.low
+}
+
+// GoLowUntyped will return the low-level FailureAction instance that was used to create the high-level one, with
+// no type
+func (f *FailureAction) GoLowUntyped() any {
+	return f.low
+}
+
+// Render will return a YAML representation of the FailureAction object as a byte slice.
+func (f *FailureAction) Render() ([]byte, error) {
+	return yaml.Marshal(f)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the FailureAction object.
+func (f *FailureAction) MarshalYAML() (interface{}, error) {
+	return high.NewNodeBuilder(f, f.low).Render(), nil
+}
@@ -0,0 +1,26 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package arazzo contains the high-level models for the Arazzo specification, which describes workflows: sequences
+// of API calls made against the operations of one or more OpenAPI documents (source descriptions).
+//   - https://spec.openapis.org/arazzo/latest.html
+//
+// Every field of a model can also be read through a nil-safe getter, generated into getters.go. A getter returns the
+// zero value of its field when the model is nil, so optional objects can be chained through without checks.
+package arazzo
+
+//go:generate go run ../internal/getters
+
+import "github.com/pb33f/libopenapi/datamodel/low"
+
+// fromReferenceSlice converts a slice of low-level references into a slice of high-level objects.
+func fromReferenceSlice[L any, H any](refs []low.ValueReference[L], create func(L) H) []H {
+	if len(refs) == 0 {
+		return nil
+	}
+	items := make([]H, 0, len(refs))
+	for _, ref := range refs {
+		items = append(items, create(ref.Value))
+	}
+	return items
+}
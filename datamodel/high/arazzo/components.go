@@ -0,0 +1,60 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// Components represents a high-level Arazzo Components object, that is backed by a low-level one.
+//   - https://spec.openapis.org/arazzo/latest.html#components-object
+type Components struct {
+	Inputs         *orderedmap.Map[string, *base.SchemaProxy] `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	Parameters     *orderedmap.Map[string, *Parameter]        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	SuccessActions *orderedmap.Map[string, *SuccessAction]    `json:"successActions,omitempty" yaml:"successActions,omitempty"`
+	FailureActions *orderedmap.Map[string, *FailureAction]    `json:"failureActions,omitempty" yaml:"failureActions,omitempty"`
+	Extensions     *orderedmap.Map[string, *yaml.Node]        `json:"-" yaml:"-"`
+	low            *low.Components
+}
+
+// NewComponents creates a new high-level Components instance from a low-level one.
+func NewComponents(components *low.Components) *Components {
+	c := new(Components)
+	c.low = components
+	c.Inputs = lowmodel.FromReferenceMapWithFunc(components.Inputs.Value, func(sp *lowbase.SchemaProxy) *base.SchemaProxy {
+		return base.NewSchemaProxy(&lowmodel.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: sp.GetValueNode()})
+	})
+	c.Parameters = lowmodel.FromReferenceMapWithFunc(components.Parameters.Value, NewParameter)
+	c.SuccessActions = lowmodel.FromReferenceMapWithFunc(components.SuccessActions.Value, NewSuccessAction)
+	c.FailureActions = lowmodel.FromReferenceMapWithFunc(components.FailureActions.Value, NewFailureAction)
+	c.Extensions = high.ExtractExtensions(components.Extensions)
+	return c
+}
+
+// GoLow returns the low-level Components instance used to create the high-level one.
+func (c *Components) GoLow() *low.Components {
+	return c.low
+}
+
+// GoLowUntyped will return the low-level Components instance that was used to create the high-level one, with no
+// type
+func (c *Components) GoLowUntyped() any {
+	return c.low
+}
+
+// Render will return a YAML representation of the Components object as a byte slice.
+func (c *Components) Render() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Components object.
+func (c *Components) MarshalYAML() (interface{}, error) {
+	return high.NewNodeBuilder(c, c.low).Render(), nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Criterion represents a high-level Arazzo Criterion object, that is backed by a low-level one.
+//
+// Type is either a string, or a Criterion Expression Type object, use GetType to read it either way.
+//   - https://spec.openapis.org/arazzo/latest.html#criterion-object
+type Criterion struct {
+	Context    string                              `json:"context,omitempty" yaml:"context,omitempty"`
+	Condition  string                              `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Type       *yaml.Node                          `json:"type,omitempty" yaml:"type,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *low.Criterion
+}
+
+// NewCriterion creates a new high-level Criterion instance from a low-level one.
+func NewCriterion(criterion *low.Criterion) *Criterion {
+	c := new(Criterion)
+	c.low = criterion
+	c.Context = criterion.Context.Value
+	c.Condition = criterion.Condition.Value
+	c.Type = criterion.Type.Value
+	c.Extensions = high.ExtractExtensions(criterion.Extensions)
+	return c
+}
+
+// GetType returns the type of the criterion and the version of the expression type (if it's a Criterion Expression
+// Type object). The type defaults to `simple` when it's not set.
+func (c *Criterion) GetType() (string, string) {
+	if c.Type == nil {
+		return "simple", ""
+	}
+	if utils.IsNodeMap(c.Type) {
+		var typ, version string
+		if _, v := utils.FindKeyNodeTop(low.TypeLabel, c.Type.Content); v != nil {
+			typ = v.Value
+		}
+		if _, v := utils.FindKeyNodeTop("version", c.Type.Content); v != nil {
+			version = v.Value
+		}
+		return typ, version
+	}
+	return c.Type.Value, ""
+}
+
+// GoLow returns the low-level Criterion instance used to create the high-level one.
+func (c *Criterion) GoLow() *low.Criterion {
+	return c.low
+}
+
+// GoLowUntyped will return the low-level Criterion instance that was used to create the high-level one, with no type
+func (c *Criterion) GoLowUntyped() any {
+	return c.low
+}
+
+// Render will return a YAML representation of the Criterion object as a byte slice.
+func (c *Criterion) Render() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Criterion object.
+func (c *Criterion) MarshalYAML() (interface{}, error) {
+	return high.NewNodeBuilder(c, c.low).Render(), nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// Document represents a high-level Arazzo document, that is backed by a low-level one.
+//   - https://spec.openapis.org/arazzo/latest.html#arazzo-specification-object
+type Document struct {
+	// Arazzo is the version of the Arazzo specification the document uses.
+	Arazzo string `json:"arazzo,omitempty" yaml:"arazzo,omitempty"`
+
+	// Info provides metadata about the workflows.
+	Info *base.Info `json:"info,omitempty" yaml:"info,omitempty"`
+
+	// SourceDescriptions are the OpenAPI (or Arazzo) documents the workflows use.
+	SourceDescriptions []*SourceDescription `json:"sourceDescriptions,omitempty" yaml:"sourceDescriptions,omitempty"`
+
+	// Workflows are all the workflows defined by the document.
+	Workflows []*Workflow `json:"workflows,omitempty" yaml:"workflows,omitempty"`
+
+	// Components holds the reusable objects of the document.
+	Components *Components `json:"components,omitempty" yaml:"components,omitempty"`
+
+	// Extensions contains all custom extensions defined for the top-level document.
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+
+	// Index is a reference to the *index.SpecIndex that was created for the document.
+	//
+	// This property is not a part of the Arazzo schema, this is custom to libopenapi.
+	Index *index.SpecIndex `json:"-" yaml:"-"`
+
+	// Rolodex is a reference to the rolodex used when creating this document.
+	//
+	// This property is not a part of the Arazzo schema, this is custom to libopenapi.
+	Rolodex *index.Rolodex `json:"-" yaml:"-"`
+	low     *low.Document
+}
+
+// NewDocument creates a new high-level Document instance from a low-level one.
+func NewDocument(document *low.Document) *Document {
+	d := new(Document)
+	d.low = document
+	d.Arazzo = document.Arazzo.Value
+	if !document.Info.IsEmpty() {
+		d.Info = base.NewInfo(document.Info.Value)
+	}
+	d.SourceDescriptions = fromReferenceSlice(document.SourceDescriptions.Value, NewSourceDescription)
+	d.Workflows = fromReferenceSlice(document.Workflows.Value, NewWorkflow)
+	if !document.Components.IsEmpty() {
+		d.Components = NewComponents(document.Components.Value)
+	}
+	d.Extensions = high.ExtractExtensions(document.Extensions)
+	d.Index = document.Index
+	d.Rolodex = document.Rolodex
+	return d
+}
+
+// FindSourceDescription returns the SourceDescription with the supplied name, or nil if there isn't one.
+func (d *Document) FindSourceDescription(name string) *SourceDescription {
+	for _, s := range d.SourceDescriptions {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// FindWorkflow returns the Workflow with the supplied workflowId, or nil if there isn't one.
+func (d *Document) FindWorkflow(workflowId string) *Workflow {
+	for _, w := range d.Workflows {
+		if w.WorkflowId == workflowId {
+			return w
+		}
+	}
+	return nil
+}
+
+// GoLow returns the low-level Document instance used to create the high-level one.
+func (d *Document) GoLow() *low.Document {
+	return d.low
+}
+
+// GoLowUntyped will return the low-level Document instance that was used to create the high-level one, with no type
+func (d *Document) GoLowUntyped() any {
+	return d.low
+}
+
+// Render will return a YAML representation of the Document object as a byte slice.
+func (d *Document) Render() ([]byte, error) {
+	return yaml.Marshal(d)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Document object.
+func (d *Document) MarshalYAML() (interface{}, error) {
+	return high.NewNodeBuilder(d, d.low).Render(), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testArazzo = `arazzo: 1.0.0
+info:
+  title: Pet adoption
+  version: 1.0.0
+sourceDescriptions:
+  - name: pets
+    url: ./pets.yaml
+    type: openapi
+workflows:
+  - workflowId: adopt
+    summary: adopt a pet
+    inputs:
+      type: object
+      properties:
+        petId:
+          type: string
+    steps:
+      - stepId: find
+        operationId: findPet
+        parameters:
+          - name: id
+            in: path
+            value: $inputs.petId
+          - reference: $components.parameters.page
+        successCriteria:
+          - condition: $statusCode == 200
+        onSuccess:
+          - name: next
+            type: goto
+            stepId: adopt
+        onFailure:
+          - name: retry
+            type: retry
+            retryAfter: 1.5
+            retryLimit: 3
+        outputs:
+          pet: $response.body
+      - stepId: adopt
+        operationPath: '{$sourceDescriptions.pets.url}#/paths/~1pets~1{id}/post'
+        requestBody:
+          contentType: application/json
+          payload:
+            name: fido
+          replacements:
+            - target: /name
+              value: $steps.find.outputs.pet.name
+    outputs:
+      pet: $steps.find.outputs.pet
+components:
+  parameters:
+    page:
+      name: page
+      in: query
+      value: 1
+`
+
+func createTestDocument(t *testing.T, spec string) *Document {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+	lowDoc, err := low.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return NewDocument(lowDoc)
+}
+
+func TestNewDocument(t *testing.T) {
+	doc := createTestDocument(t, testArazzo)
+
+	assert.Equal(t, "1.0.0", doc.Arazzo)
+	assert.Equal(t, "Pet adoption", doc.Info.Title)
+	assert.True(t, doc.FindSourceDescription("pets").IsOpenAPI())
+	assert.Nil(t, doc.FindSourceDescription("cakes"))
+	assert.NotNil(t, doc.Index)
+	assert.NotNil(t, doc.Rolodex)
+	assert.Equal(t, "1.0.0", doc.GoLow().Arazzo.Value)
+	assert.Equal(t, doc.GoLow(), doc.GoLowUntyped())
+
+	w := doc.FindWorkflow("adopt")
+	require.NotNil(t, w)
+	assert.Nil(t, doc.FindWorkflow("cakes"))
+	assert.NotNil(t, w.Inputs.Schema())
+	assert.Equal(t, "$steps.find.outputs.pet", w.Outputs.GetOrZero("pet"))
+
+	find := w.FindStep("find")
+	require.NotNil(t, find)
+	require.Len(t, find.Parameters, 2)
+	assert.Equal(t, "$inputs.petId", find.Parameters[0].Value.Value)
+	assert.Equal(t, "$components.parameters.page", find.Parameters[1].ComponentReference)
+	typ, version := find.SuccessCriteria[0].GetType()
+	assert.Equal(t, "simple", typ)
+	assert.Empty(t, version)
+	assert.Equal(t, ActionTypeGoto, find.OnSuccess[0].Type)
+	assert.Equal(t, 1.5, *find.OnFailure[0].RetryAfter)
+	assert.Equal(t, int64(3), *find.OnFailure[0].RetryLimit)
+
+	adopt := w.FindStep("adopt")
+	require.NotNil(t, adopt)
+	assert.Equal(t, "application/json", adopt.RequestBody.ContentType)
+	assert.Equal(t, "/name", adopt.RequestBody.Replacements[0].Target)
+
+	assert.Equal(t, "query", doc.Components.Parameters.GetOrZero("page").In)
+}
+
+func TestDocument_Render(t *testing.T) {
+	doc := createTestDocument(t, testArazzo)
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(rendered), "arazzo: 1.0.0\n"))
+	assert.Contains(t, string(rendered), "operationPath: '{$sourceDescriptions.pets.url}#/paths/~1pets~1{id}/post'")
+
+	// a rendered document builds the same model.
+	again := createTestDocument(t, string(rendered))
+	assert.Equal(t, doc.GoLow().Hash(), again.GoLow().Hash())
+}
@@ -0,0 +1,581 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Code generated by go run ../internal/getters; DO NOT EDIT.
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// GetInputs returns the Inputs of the Components, or the zero value if the Components is nil.
+func (c *Components) GetInputs() *orderedmap.Map[string, *base.SchemaProxy] {
+	if c == nil {
+		return nil
+	}
+	return c.Inputs
+}
+
+// GetParameters returns the Parameters of the Components, or the zero value if the Components is nil.
+func (c *Components) GetParameters() *orderedmap.Map[string, *Parameter] {
+	if c == nil {
+		return nil
+	}
+	return c.Parameters
+}
+
+// GetSuccessActions returns the SuccessActions of the Components, or the zero value if the Components is nil.
+func (c *Components) GetSuccessActions() *orderedmap.Map[string, *SuccessAction] {
+	if c == nil {
+		return nil
+	}
+	return c.SuccessActions
+}
+
+// GetFailureActions returns the FailureActions of the Components, or the zero value if the Components is nil.
+func (c *Components) GetFailureActions() *orderedmap.Map[string, *FailureAction] {
+	if c == nil {
+		return nil
+	}
+	return c.FailureActions
+}
+
+// GetExtensions returns the Extensions of the Components, or the zero value if the Components is nil.
+func (c *Components) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if c == nil {
+		return nil
+	}
+	return c.Extensions
+}
+
+// GetContext returns the Context of the Criterion, or the zero value if the Criterion is nil.
+func (c *Criterion) GetContext() string {
+	if c == nil {
+		return ""
+	}
+	return c.Context
+}
+
+// GetCondition returns the Condition of the Criterion, or the zero value if the Criterion is nil.
+func (c *Criterion) GetCondition() string {
+	if c == nil {
+		return ""
+	}
+	return c.Condition
+}
+
+// GetExtensions returns the Extensions of the Criterion, or the zero value if the Criterion is nil.
+func (c *Criterion) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if c == nil {
+		return nil
+	}
+	return c.Extensions
+}
+
+// GetArazzo returns the Arazzo of the Document, or the zero value if the Document is nil.
+func (d *Document) GetArazzo() string {
+	if d == nil {
+		return ""
+	}
+	return d.Arazzo
+}
+
+// GetInfo returns the Info of the Document, or the zero value if the Document is nil.
+func (d *Document) GetInfo() *base.Info {
+	if d == nil {
+		return nil
+	}
+	return d.Info
+}
+
+// GetSourceDescriptions returns the SourceDescriptions of the Document, or the zero value if the Document is nil.
+func (d *Document) GetSourceDescriptions() []*SourceDescription {
+	if d == nil {
+		return nil
+	}
+	return d.SourceDescriptions
+}
+
+// GetWorkflows returns the Workflows of the Document, or the zero value if the Document is nil.
+func (d *Document) GetWorkflows() []*Workflow {
+	if d == nil {
+		return nil
+	}
+	return d.Workflows
+}
+
+// GetComponents returns the Components of the Document, or the zero value if the Document is nil.
+func (d *Document) GetComponents() *Components {
+	if d == nil {
+		return nil
+	}
+	return d.Components
+}
+
+// GetExtensions returns the Extensions of the Document, or the zero value if the Document is nil.
+func (d *Document) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if d == nil {
+		return nil
+	}
+	return d.Extensions
+}
+
+// GetIndex returns the Index of the Document, or the zero value if the Document is nil.
+func (d *Document) GetIndex() *index.SpecIndex {
+	if d == nil {
+		return nil
+	}
+	return d.Index
+}
+
+// GetRolodex returns the Rolodex of the Document, or the zero value if the Document is nil.
+func (d *Document) GetRolodex() *index.Rolodex {
+	if d == nil {
+		return nil
+	}
+	return d.Rolodex
+}
+
+// GetName returns the Name of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetName() string {
+	if f == nil {
+		return ""
+	}
+	return f.Name
+}
+
+// GetType returns the Type of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetType() string {
+	if f == nil {
+		return ""
+	}
+	return f.Type
+}
+
+// GetWorkflowId returns the WorkflowId of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetWorkflowId() string {
+	if f == nil {
+		return ""
+	}
+	return f.WorkflowId
+}
+
+// GetStepId returns the StepId of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetStepId() string {
+	if f == nil {
+		return ""
+	}
+	return f.StepId
+}
+
+// GetRetryAfter returns the RetryAfter of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetRetryAfter() *float64 {
+	if f == nil {
+		return nil
+	}
+	return f.RetryAfter
+}
+
+// GetRetryLimit returns the RetryLimit of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetRetryLimit() *int64 {
+	if f == nil {
+		return nil
+	}
+	return f.RetryLimit
+}
+
+// GetCriteria returns the Criteria of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetCriteria() []*Criterion {
+	if f == nil {
+		return nil
+	}
+	return f.Criteria
+}
+
+// GetComponentReference returns the ComponentReference of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetComponentReference() string {
+	if f == nil {
+		return ""
+	}
+	return f.ComponentReference
+}
+
+// GetExtensions returns the Extensions of the FailureAction, or the zero value if the FailureAction is nil.
+func (f *FailureAction) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if f == nil {
+		return nil
+	}
+	return f.Extensions
+}
+
+// GetName returns the Name of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetName() string {
+	if p == nil {
+		return ""
+	}
+	return p.Name
+}
+
+// GetIn returns the In of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetIn() string {
+	if p == nil {
+		return ""
+	}
+	return p.In
+}
+
+// GetValue returns the Value of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetValue() *yaml.Node {
+	if p == nil {
+		return nil
+	}
+	return p.Value
+}
+
+// GetComponentReference returns the ComponentReference of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetComponentReference() string {
+	if p == nil {
+		return ""
+	}
+	return p.ComponentReference
+}
+
+// GetExtensions returns the Extensions of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetTarget returns the Target of the PayloadReplacement, or the zero value if the PayloadReplacement is nil.
+func (p *PayloadReplacement) GetTarget() string {
+	if p == nil {
+		return ""
+	}
+	return p.Target
+}
+
+// GetValue returns the Value of the PayloadReplacement, or the zero value if the PayloadReplacement is nil.
+func (p *PayloadReplacement) GetValue() *yaml.Node {
+	if p == nil {
+		return nil
+	}
+	return p.Value
+}
+
+// GetExtensions returns the Extensions of the PayloadReplacement, or the zero value if the PayloadReplacement is nil.
+func (p *PayloadReplacement) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetContentType returns the ContentType of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetContentType() string {
+	if r == nil {
+		return ""
+	}
+	return r.ContentType
+}
+
+// GetPayload returns the Payload of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetPayload() *yaml.Node {
+	if r == nil {
+		return nil
+	}
+	return r.Payload
+}
+
+// GetReplacements returns the Replacements of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetReplacements() []*PayloadReplacement {
+	if r == nil {
+		return nil
+	}
+	return r.Replacements
+}
+
+// GetExtensions returns the Extensions of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if r == nil {
+		return nil
+	}
+	return r.Extensions
+}
+
+// GetName returns the Name of the SourceDescription, or the zero value if the SourceDescription is nil.
+func (s *SourceDescription) GetName() string {
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
+
+// GetURL returns the URL of the SourceDescription, or the zero value if the SourceDescription is nil.
+func (s *SourceDescription) GetURL() string {
+	if s == nil {
+		return ""
+	}
+	return s.URL
+}
+
+// GetType returns the Type of the SourceDescription, or the zero value if the SourceDescription is nil.
+func (s *SourceDescription) GetType() string {
+	if s == nil {
+		return ""
+	}
+	return s.Type
+}
+
+// GetExtensions returns the Extensions of the SourceDescription, or the zero value if the SourceDescription is nil.
+func (s *SourceDescription) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetStepId returns the StepId of the Step, or the zero value if the Step is nil.
+func (s *Step) GetStepId() string {
+	if s == nil {
+		return ""
+	}
+	return s.StepId
+}
+
+// GetDescription returns the Description of the Step, or the zero value if the Step is nil.
+func (s *Step) GetDescription() string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+// GetOperationId returns the OperationId of the Step, or the zero value if the Step is nil.
+func (s *Step) GetOperationId() string {
+	if s == nil {
+		return ""
+	}
+	return s.OperationId
+}
+
+// GetOperationPath returns the OperationPath of the Step, or the zero value if the Step is nil.
+func (s *Step) GetOperationPath() string {
+	if s == nil {
+		return ""
+	}
+	return s.OperationPath
+}
+
+// GetWorkflowId returns the WorkflowId of the Step, or the zero value if the Step is nil.
+func (s *Step) GetWorkflowId() string {
+	if s == nil {
+		return ""
+	}
+	return s.WorkflowId
+}
+
+// GetParameters returns the Parameters of the Step, or the zero value if the Step is nil.
+func (s *Step) GetParameters() []*Parameter {
+	if s == nil {
+		return nil
+	}
+	return s.Parameters
+}
+
+// GetRequestBody returns the RequestBody of the Step, or the zero value if the Step is nil.
+func (s *Step) GetRequestBody() *RequestBody {
+	if s == nil {
+		return nil
+	}
+	return s.RequestBody
+}
+
+// GetSuccessCriteria returns the SuccessCriteria of the Step, or the zero value if the Step is nil.
+func (s *Step) GetSuccessCriteria() []*Criterion {
+	if s == nil {
+		return nil
+	}
+	return s.SuccessCriteria
+}
+
+// GetOnSuccess returns the OnSuccess of the Step, or the zero value if the Step is nil.
+func (s *Step) GetOnSuccess() []*SuccessAction {
+	if s == nil {
+		return nil
+	}
+	return s.OnSuccess
+}
+
+// GetOnFailure returns the OnFailure of the Step, or the zero value if the Step is nil.
+func (s *Step) GetOnFailure() []*FailureAction {
+	if s == nil {
+		return nil
+	}
+	return s.OnFailure
+}
+
+// GetOutputs returns the Outputs of the Step, or the zero value if the Step is nil.
+func (s *Step) GetOutputs() *orderedmap.Map[string, string] {
+	if s == nil {
+		return nil
+	}
+	return s.Outputs
+}
+
+// GetExtensions returns the Extensions of the Step, or the zero value if the Step is nil.
+func (s *Step) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetName returns the Name of the SuccessAction, or the zero value if the SuccessAction is nil.
+func (s *SuccessAction) GetName() string {
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
+
+// GetType returns the Type of the SuccessAction, or the zero value if the SuccessAction is nil.
+func (s *SuccessAction) GetType() string {
+	if s == nil {
+		return ""
+	}
+	return s.Type
+}
+
+// GetWorkflowId returns the WorkflowId of the SuccessAction, or the zero value if the SuccessAction is nil.
+func (s *SuccessAction) GetWorkflowId() string {
+	if s == nil {
+		return ""
+	}
+	return s.WorkflowId
+}
+
+// GetStepId returns the StepId of the SuccessAction, or the zero value if the SuccessAction is nil.
+func (s *SuccessAction) GetStepId() string {
+	if s == nil {
+		return ""
+	}
+	return s.StepId
+}
+
+// GetCriteria returns the Criteria of the SuccessAction, or the zero value if the SuccessAction is nil.
+func (s *SuccessAction) GetCriteria() []*Criterion {
+	if s == nil {
+		return nil
+	}
+	return s.Criteria
+}
+
+// GetComponentReference returns the ComponentReference of the SuccessAction, or the zero value if the SuccessAction is nil.
+func (s *SuccessAction) GetComponentReference() string {
+	if s == nil {
+		return ""
+	}
+	return s.ComponentReference
+}
+
+// GetExtensions returns the Extensions of the SuccessAction, or the zero value if the SuccessAction is nil.
+func (s *SuccessAction) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetWorkflowId returns the WorkflowId of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetWorkflowId() string {
+	if w == nil {
+		return ""
+	}
+	return w.WorkflowId
+}
+
+// GetSummary returns the Summary of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetSummary() string {
+	if w == nil {
+		return ""
+	}
+	return w.Summary
+}
+
+// GetDescription returns the Description of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetDescription() string {
+	if w == nil {
+		return ""
+	}
+	return w.Description
+}
+
+// GetInputs returns the Inputs of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetInputs() *base.SchemaProxy {
+	if w == nil {
+		return nil
+	}
+	return w.Inputs
+}
+
+// GetDependsOn returns the DependsOn of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetDependsOn() []string {
+	if w == nil {
+		return nil
+	}
+	return w.DependsOn
+}
+
+// GetSteps returns the Steps of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetSteps() []*Step {
+	if w == nil {
+		return nil
+	}
+	return w.Steps
+}
+
+// GetSuccessActions returns the SuccessActions of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetSuccessActions() []*SuccessAction {
+	if w == nil {
+		return nil
+	}
+	return w.SuccessActions
+}
+
+// GetFailureActions returns the FailureActions of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetFailureActions() []*FailureAction {
+	if w == nil {
+		return nil
+	}
+	return w.FailureActions
+}
+
+// GetOutputs returns the Outputs of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetOutputs() *orderedmap.Map[string, string] {
+	if w == nil {
+		return nil
+	}
+	return w.Outputs
+}
+
+// GetParameters returns the Parameters of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetParameters() []*Parameter {
+	if w == nil {
+		return nil
+	}
+	return w.Parameters
+}
+
+// GetExtensions returns the Extensions of the Workflow, or the zero value if the Workflow is nil.
+func (w *Workflow) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if w == nil {
+		return nil
+	}
+	return w.Extensions
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// Parameter represents a high-level Arazzo Parameter object, that is backed by a low-level one.
+//
+// If the parameter is a Reusable object, ComponentReference is the expression referencing the parameter in the
+// components (for example `$components.parameters.page`), and Value overrides the value of that parameter.
+//   - https://spec.openapis.org/arazzo/latest.html#parameter-object
+//   - https://spec.openapis.org/arazzo/latest.html#reusable-object
+type Parameter struct {
+	Name               string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	In                 string                              `json:"in,omitempty" yaml:"in,omitempty"`
+	Value              *yaml.Node                          `json:"value,omitempty" yaml:"value,omitempty"`
+	ComponentReference string                              `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Extensions         *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                *low.Parameter
+}
+
+// NewParameter creates a new high-level Parameter instance from a low-level one.
+func NewParameter(param *low.Parameter) *Parameter {
+	p := new(Parameter)
+	p.low = param
+	p.Name = param.Name.Value
+	p.In = param.In.Value
+	p.Value = param.Value.Value
+	p.ComponentReference = param.ComponentReference.Value
+	p.Extensions = high.ExtractExtensions(param.Extensions)
+	return p
+}
+
+// GoLow returns the low-level Parameter instance used to create the high-level one.
+func (p *Parameter) GoLow() *low.Parameter {
+	return p.low
+}
+
+// GoLowUntyped will return the low-level Parameter instance that was used to create the high-level one, with no type
+func (p *Parameter) GoLowUntyped() any {
+	return p.low
+}
+
+// Render will return a YAML representation of the Parameter object as a byte slice.
+func (p *Parameter) Render() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Parameter object.
+func (p *Parameter) MarshalYAML() (interface{}, error) {
+	return high.NewNodeBuilder(p, p.low).Render(), nil
+}
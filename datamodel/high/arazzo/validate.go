@@ -0,0 +1,348 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"fmt"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sourceDescriptionsPrefix = "$sourceDescriptions."
+	componentsPrefix         = "$components."
+)
+
+// Validate checks that the workflows of the document are consistent, and that every operation they call exists in
+// the source descriptions they use. Problems are returned as diagnostics (with positions, when the document was
+// built from a low-level model), in document order. An empty result means the document is valid.
+//
+// sources are the OpenAPI documents of the source descriptions, keyed by source description name. Operations are
+// only checked against the sources supplied, so a step calling a source that isn't in sources is not reported.
+// Every document checks that:
+//
+//   - source description names and workflowIds are unique, stepIds are unique within a workflow.
+//   - every step calls exactly one of an operationId, an operationPath or a workflowId.
+//   - operationIds, operationPaths and workflowIds refer to source descriptions, operations and workflows that exist.
+//   - dependsOn, goto actions and component references refer to workflows, steps and components that exist.
+func (d *Document) Validate(sources map[string]*v3.Document) []*lowmodel.Diagnostic {
+	v := &validator{doc: d, sources: sources}
+	v.validate()
+	return v.diagnostics
+}
+
+type validator struct {
+	doc         *Document
+	sources     map[string]*v3.Document
+	diagnostics []*lowmodel.Diagnostic
+}
+
+func (v *validator) report(key string, node *yaml.Node, format string, args ...any) {
+	d := &lowmodel.Diagnostic{Message: fmt.Sprintf(format, args...), Key: key, ValueNode: node}
+	if node != nil {
+		d.Line, d.Column = node.Line, node.Column
+	}
+	v.diagnostics = append(v.diagnostics, d)
+}
+
+// lowNode returns a node of a low-level object, or nil if there is no low-level object.
+func lowNode[L any](l *L, node func(*L) *yaml.Node) *yaml.Node {
+	if l == nil {
+		return nil
+	}
+	return node(l)
+}
+
+func (v *validator) validate() {
+	seenSources := make(map[string]bool)
+	for _, s := range v.doc.SourceDescriptions {
+		node := lowNode(s.low, func(l *low.SourceDescription) *yaml.Node { return l.Name.ValueNode })
+		switch {
+		case s.Name == "":
+			v.report(s.Name, lowNode(s.low, func(l *low.SourceDescription) *yaml.Node { return l.RootNode }),
+				"source description has no name")
+		case seenSources[s.Name]:
+			v.report(s.Name, node, "source description '%s' is defined more than once", s.Name)
+		}
+		seenSources[s.Name] = true
+		if s.Type != "" && s.Type != SourceDescriptionTypeOpenAPI && s.Type != SourceDescriptionTypeArazzo {
+			v.report(s.Name, lowNode(s.low, func(l *low.SourceDescription) *yaml.Node { return l.Type.ValueNode }),
+				"source description '%s' has an unknown type '%s'", s.Name, s.Type)
+		}
+	}
+
+	seenWorkflows := make(map[string]bool)
+	for _, w := range v.doc.Workflows {
+		if seenWorkflows[w.WorkflowId] {
+			v.report(w.WorkflowId, lowNode(w.low, func(l *low.Workflow) *yaml.Node { return l.WorkflowId.ValueNode }),
+				"workflow '%s' is defined more than once", w.WorkflowId)
+		}
+		seenWorkflows[w.WorkflowId] = true
+	}
+
+	for _, w := range v.doc.Workflows {
+		v.validateWorkflow(w)
+	}
+}
+
+func (v *validator) validateWorkflow(w *Workflow) {
+	for i, dep := range w.DependsOn {
+		node := lowNode(w.low, func(l *low.Workflow) *yaml.Node {
+			if i < len(l.DependsOn.Value) {
+				return l.DependsOn.Value[i].ValueNode
+			}
+			return nil
+		})
+		v.checkWorkflowId(dep, node, fmt.Sprintf("workflow '%s' depends on", w.WorkflowId))
+	}
+	for _, p := range w.Parameters {
+		v.checkParameter(p, fmt.Sprintf("workflow '%s'", w.WorkflowId))
+	}
+	for _, a := range w.SuccessActions {
+		v.checkSuccessAction(w, a, fmt.Sprintf("workflow '%s'", w.WorkflowId))
+	}
+	for _, a := range w.FailureActions {
+		v.checkFailureAction(w, a, fmt.Sprintf("workflow '%s'", w.WorkflowId))
+	}
+
+	seenSteps := make(map[string]bool)
+	for _, s := range w.Steps {
+		where := fmt.Sprintf("step '%s' of workflow '%s'", s.StepId, w.WorkflowId)
+		stepNode := lowNode(s.low, func(l *low.Step) *yaml.Node { return l.StepId.ValueNode })
+		if stepNode == nil {
+			stepNode = lowNode(s.low, func(l *low.Step) *yaml.Node { return l.RootNode })
+		}
+		switch {
+		case s.StepId == "":
+			v.report(s.StepId, stepNode, "a step of workflow '%s' has no stepId", w.WorkflowId)
+		case seenSteps[s.StepId]:
+			v.report(s.StepId, stepNode, "%s is defined more than once", where)
+		}
+		seenSteps[s.StepId] = true
+
+		targets := 0
+		for _, t := range []string{s.OperationId, s.OperationPath, s.WorkflowId} {
+			if t != "" {
+				targets++
+			}
+		}
+		if targets != 1 {
+			v.report(s.StepId, stepNode, "%s must call exactly one of an operationId, operationPath or workflowId", where)
+		}
+		if s.OperationId != "" {
+			v.checkOperationId(s.OperationId,
+				lowNode(s.low, func(l *low.Step) *yaml.Node { return l.OperationId.ValueNode }), where)
+		}
+		if s.OperationPath != "" {
+			v.checkOperationPath(s.OperationPath,
+				lowNode(s.low, func(l *low.Step) *yaml.Node { return l.OperationPath.ValueNode }), where)
+		}
+		if s.WorkflowId != "" {
+			v.checkWorkflowId(s.WorkflowId,
+				lowNode(s.low, func(l *low.Step) *yaml.Node { return l.WorkflowId.ValueNode }), where+" calls")
+		}
+		for _, p := range s.Parameters {
+			v.checkParameter(p, where)
+		}
+		for _, a := range s.OnSuccess {
+			v.checkSuccessAction(w, a, where)
+		}
+		for _, a := range s.OnFailure {
+			v.checkFailureAction(w, a, where)
+		}
+	}
+}
+
+// splitSourceExpression splits a `$sourceDescriptions.<name>.<value>` expression into the source description name
+// and the value. ok is false if the expression does not start with `$sourceDescriptions.`.
+func splitSourceExpression(expr string) (source, value string, ok bool) {
+	if !strings.HasPrefix(expr, sourceDescriptionsPrefix) {
+		return "", "", false
+	}
+	source, value, _ = strings.Cut(strings.TrimPrefix(expr, sourceDescriptionsPrefix), ".")
+	return source, value, true
+}
+
+// checkSource reports a source description that does not exist, returns the source description if it does.
+func (v *validator) checkSource(name string, node *yaml.Node, where string) *SourceDescription {
+	s := v.doc.FindSourceDescription(name)
+	if s == nil {
+		v.report(name, node, "%s uses source description '%s', which is not defined", where, name)
+	}
+	return s
+}
+
+func (v *validator) checkOperationId(operationId string, node *yaml.Node, where string) {
+	if source, opId, ok := splitSourceExpression(operationId); ok {
+		if v.checkSource(source, node, where) == nil {
+			return
+		}
+		if doc := v.sources[source]; doc != nil && len(findOperations(doc, opId)) == 0 {
+			v.report(operationId, node, "%s calls operationId '%s', which is not defined by source description '%s'",
+				where, opId, source)
+		}
+		return
+	}
+	// a plain operationId can only be reported as missing when every OpenAPI source description has been supplied.
+	var found []string
+	complete := true
+	for _, s := range v.doc.SourceDescriptions {
+		if !s.IsOpenAPI() {
+			continue
+		}
+		doc := v.sources[s.Name]
+		if doc == nil {
+			complete = false
+			continue
+		}
+		if len(findOperations(doc, operationId)) > 0 {
+			found = append(found, s.Name)
+		}
+	}
+	switch {
+	case complete && len(v.sources) > 0 && len(found) == 0:
+		v.report(operationId, node, "%s calls operationId '%s', which is not defined by any source description",
+			where, operationId)
+	case len(found) > 1:
+		v.report(operationId, node, "%s calls operationId '%s', which is defined by more than one source description "+
+			"(%s), use a $sourceDescriptions expression to choose one", where, operationId, strings.Join(found, ", "))
+	}
+}
+
+func (v *validator) checkOperationPath(operationPath string, node *yaml.Node, where string) {
+	// the path is in the form {$sourceDescriptions.<name>.url}#/paths/~1pets/get
+	expr, pointer, _ := strings.Cut(operationPath, "#")
+	expr = strings.TrimSuffix(strings.TrimPrefix(expr, "{"), "}")
+	source, value, ok := splitSourceExpression(expr)
+	if !ok || value != "url" {
+		v.report(operationPath, node, "%s has an operationPath '%s' that does not start with "+
+			"'{$sourceDescriptions.<name>.url}'", where, operationPath)
+		return
+	}
+	if v.checkSource(source, node, where) == nil {
+		return
+	}
+	doc := v.sources[source]
+	if doc == nil {
+		return
+	}
+	segments, err := utils.ParseJSONPointer("#" + pointer)
+	if err != nil || len(segments) != 3 || segments[0] != "paths" {
+		v.report(operationPath, node, "%s has an operationPath '%s' that does not point to an operation",
+			where, operationPath)
+		return
+	}
+	var op *v3.Operation
+	if doc.Paths != nil {
+		if pathItem := doc.Paths.PathItems.GetOrZero(segments[1]); pathItem != nil {
+			op = pathItem.GetOperations().GetOrZero(segments[2])
+		}
+	}
+	if op == nil {
+		v.report(operationPath, node, "%s calls operationPath '%s', which is not defined by source description '%s'",
+			where, operationPath, source)
+	}
+}
+
+func (v *validator) checkWorkflowId(workflowId string, node *yaml.Node, where string) {
+	if source, _, ok := splitSourceExpression(workflowId); ok {
+		if s := v.checkSource(source, node, where); s != nil && s.Type != SourceDescriptionTypeArazzo {
+			v.report(workflowId, node, "%s workflow '%s', but source description '%s' is not an arazzo document",
+				where, workflowId, source)
+		}
+		return
+	}
+	if v.doc.FindWorkflow(workflowId) == nil {
+		v.report(workflowId, node, "%s workflow '%s', which is not defined", where, workflowId)
+	}
+}
+
+func (v *validator) checkParameter(p *Parameter, where string) {
+	if p.ComponentReference != "" {
+		node := lowNode(p.low, func(l *low.Parameter) *yaml.Node { return l.ComponentReference.ValueNode })
+		v.checkComponent(p.ComponentReference, low.ParametersLabel, node, where)
+	}
+}
+
+func (v *validator) checkSuccessAction(w *Workflow, a *SuccessAction, where string) {
+	if a.ComponentReference != "" {
+		node := lowNode(a.low, func(l *low.SuccessAction) *yaml.Node { return l.ComponentReference.ValueNode })
+		v.checkComponent(a.ComponentReference, low.SuccessActionsLabel, node, where)
+		return
+	}
+	if a.Type == ActionTypeGoto {
+		v.checkGoto(w, a.Name, a.WorkflowId, a.StepId,
+			lowNode(a.low, func(l *low.SuccessAction) *yaml.Node { return l.RootNode }), where)
+	}
+}
+
+func (v *validator) checkFailureAction(w *Workflow, a *FailureAction, where string) {
+	if a.ComponentReference != "" {
+		node := lowNode(a.low, func(l *low.FailureAction) *yaml.Node { return l.ComponentReference.ValueNode })
+		v.checkComponent(a.ComponentReference, low.FailureActionsLabel, node, where)
+		return
+	}
+	if a.Type == ActionTypeGoto || (a.Type == ActionTypeRetry && (a.WorkflowId != "" || a.StepId != "")) {
+		v.checkGoto(w, a.Name, a.WorkflowId, a.StepId,
+			lowNode(a.low, func(l *low.FailureAction) *yaml.Node { return l.RootNode }), where)
+	}
+}
+
+// checkGoto checks the target of an action that moves to another workflow or step.
+func (v *validator) checkGoto(w *Workflow, name, workflowId, stepId string, node *yaml.Node, where string) {
+	where = fmt.Sprintf("action '%s' of %s", name, where)
+	switch {
+	case (workflowId == "") == (stepId == ""):
+		v.report(name, node, "%s must set exactly one of a workflowId or a stepId", where)
+	case workflowId != "":
+		v.checkWorkflowId(workflowId, node, where+" goes to")
+	case w.FindStep(stepId) == nil:
+		v.report(stepId, node, "%s goes to step '%s', which is not defined by workflow '%s'",
+			where, stepId, w.WorkflowId)
+	}
+}
+
+// checkComponent checks a `$components.<kind>.<name>` reference points to a component that exists.
+func (v *validator) checkComponent(reference, kind string, node *yaml.Node, where string) {
+	name, ok := strings.CutPrefix(reference, componentsPrefix+kind+".")
+	c := v.doc.Components
+	var found bool
+	if ok && c != nil {
+		switch kind {
+		case low.ParametersLabel:
+			found = c.Parameters != nil && c.Parameters.GetOrZero(name) != nil
+		case low.SuccessActionsLabel:
+			found = c.SuccessActions != nil && c.SuccessActions.GetOrZero(name) != nil
+		case low.FailureActionsLabel:
+			found = c.FailureActions != nil && c.FailureActions.GetOrZero(name) != nil
+		}
+	}
+	if !found {
+		v.report(reference, node, "%s references '%s', which is not defined in components.%s", where, reference, kind)
+	}
+}
+
+// findOperations returns every operation of the document with the supplied operationId.
+func findOperations(doc *v3.Document, operationId string) []*v3.Operation {
+	var ops []*v3.Operation
+	if doc.Paths == nil {
+		return ops
+	}
+	for _, pathItem := range doc.Paths.PathItems.FromOldest() {
+		if pathItem == nil {
+			continue
+		}
+		for _, op := range pathItem.GetOperations().FromOldest() {
+			if op != nil && op.OperationId == operationId {
+				ops = append(ops, op)
+			}
+		}
+	}
+	return ops
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPets = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      operationId: findPet
+    post:
+      operationId: adoptPet
+`
+
+func createTestOpenAPI(t *testing.T, spec string) *v3.Document {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return v3.NewDocument(lowDoc)
+}
+
+func diagnosticMessages(t *testing.T, doc *Document, sources map[string]*v3.Document) []string {
+	var messages []string
+	for _, d := range doc.Validate(sources) {
+		messages = append(messages, d.Message)
+	}
+	return messages
+}
+
+func TestDocument_Validate(t *testing.T) {
+	doc := createTestDocument(t, testArazzo)
+	pets := createTestOpenAPI(t, testPets)
+	assert.Empty(t, diagnosticMessages(t, doc, map[string]*v3.Document{"pets": pets}))
+}
+
+func TestDocument_Validate_NoSources(t *testing.T) {
+	// operations can't be checked without their source descriptions, so nothing is reported.
+	doc := createTestDocument(t, testArazzo)
+	assert.Empty(t, diagnosticMessages(t, doc, nil))
+}
+
+func TestDocument_Validate_Problems(t *testing.T) {
+	spec := `arazzo: 1.0.0
+info:
+  title: Broken
+  version: 1.0.0
+sourceDescriptions:
+  - name: pets
+    url: ./pets.yaml
+  - name: pets
+    url: ./cakes.yaml
+    type: graphql
+workflows:
+  - workflowId: adopt
+    dependsOn:
+      - missing
+    steps:
+      - stepId: find
+        operationId: feedPet
+        onSuccess:
+          - name: next
+            type: goto
+            stepId: nowhere
+      - stepId: find
+        operationPath: '{$sourceDescriptions.pets.url}#/paths/~1pets~1{id}/delete'
+      - stepId: both
+        operationId: findPet
+        workflowId: adopt
+        parameters:
+          - reference: $components.parameters.missing
+  - workflowId: adopt
+    steps:
+      - stepId: other
+        operationId: $sourceDescriptions.cakes.findPet
+`
+	doc := createTestDocument(t, spec)
+	pets := createTestOpenAPI(t, testPets)
+	messages := diagnosticMessages(t, doc, map[string]*v3.Document{"pets": pets})
+
+	assert.Equal(t, []string{
+		"source description 'pets' is defined more than once",
+		"source description 'pets' has an unknown type 'graphql'",
+		"workflow 'adopt' is defined more than once",
+		"workflow 'adopt' depends on workflow 'missing', which is not defined",
+		"step 'find' of workflow 'adopt' calls operationId 'feedPet', which is not defined by any source description",
+		"action 'next' of step 'find' of workflow 'adopt' goes to step 'nowhere', which is not defined by workflow 'adopt'",
+		"step 'find' of workflow 'adopt' is defined more than once",
+		"step 'find' of workflow 'adopt' calls operationPath '{$sourceDescriptions.pets.url}#/paths/~1pets~1{id}/delete', " +
+			"which is not defined by source description 'pets'",
+		"step 'both' of workflow 'adopt' must call exactly one of an operationId, operationPath or workflowId",
+		"step 'both' of workflow 'adopt' references '$components.parameters.missing', which is not defined in components.parameters",
+		"step 'other' of workflow 'adopt' uses source description 'cakes', which is not defined",
+	}, messages)
+
+	diagnostics := doc.Validate(map[string]*v3.Document{"pets": pets})
+	require.NotEmpty(t, diagnostics)
+	assert.NotZero(t, diagnostics[0].Line)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// Workflow represents a high-level Arazzo Workflow object, that is backed by a low-level one.
+//   - https://spec.openapis.org/arazzo/latest.html#workflow-object
+type Workflow struct {
+	WorkflowId     string                              `json:"workflowId,omitempty" yaml:"workflowId,omitempty"`
+	Summary        string                              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description    string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Inputs         *base.SchemaProxy                   `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	DependsOn      []string                            `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Steps          []*Step                             `json:"steps,omitempty" yaml:"steps,omitempty"`
+	SuccessActions []*SuccessAction                    `json:"successActions,omitempty" yaml:"successActions,omitempty"`
+	FailureActions []*FailureAction                    `json:"failureActions,omitempty" yaml:"failureActions,omitempty"`
+	Outputs        *orderedmap.Map[string, string]     `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Parameters     []*Parameter                        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Extensions     *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low            *low.Workflow
+}
+
+// NewWorkflow creates a new high-level Workflow instance from a low-level one.
+func NewWorkflow(workflow *low.Workflow) *Workflow {
+	w := new(Workflow)
+	w.low = workflow
+	w.WorkflowId = workflow.WorkflowId.Value
+	w.Summary = workflow.Summary.Value
+	w.Description = workflow.Description.Value
+	if !workflow.Inputs.IsEmpty() {
+		w.Inputs = base.NewSchemaProxy(&workflow.Inputs)
+	}
+	for _, d := range workflow.DependsOn.Value {
+		w.DependsOn = append(w.DependsOn, d.Value)
+	}
+	w.Steps = fromReferenceSlice(workflow.Steps.Value, NewStep)
+	w.SuccessActions = fromReferenceSlice(workflow.SuccessActions.Value, NewSuccessAction)
+	w.FailureActions = fromReferenceSlice(workflow.FailureActions.Value, NewFailureAction)
+	if orderedmap.Len(workflow.Outputs.Value) > 0 {
+		w.Outputs = lowmodel.FromReferenceMap(workflow.Outputs.Value)
+	}
+	w.Parameters = fromReferenceSlice(workflow.Parameters.Value, NewParameter)
+	w.Extensions = high.ExtractExtensions(workflow.Extensions)
+	return w
+}
+
+// FindStep returns the Step with the supplied stepId, or nil if there isn't one.
+func (w *Workflow) FindStep(stepId string) *Step {
+	for _, s := range w.Steps {
+		if s.StepId == stepId {
+			return s
+		}
+	}
+	return nil
+}
+
+// GoLow returns the low-level Workflow instance used to create the high-level one.
+func (w *Workflow) GoLow() *low.Workflow {
+	return w.low
+}
+
+// GoLowUntyped will return the low-level Workflow instance that was used to create the high-level one, with no type
+func (w *Workflow) GoLowUntyped() any {
+	return w.low
+}
+
+// Render will return a YAML representation of the Workflow object as a byte slice.
+func (w *Workflow) Render() ([]byte, error) {
+	return yaml.Marshal(w)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the Workflow object.
+func (w *Workflow) MarshalYAML() (interface{}, error) {
+	return high.NewNodeBuilder(w, w.low).Render(), nil
+}
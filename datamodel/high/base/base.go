@@ -8,4 +8,9 @@
 // to not duplicate the schemas is to allow a graceful degradation pattern to be used. Schemas are the most complex
 // beats, particularly when polymorphism is used. By re-using the same superset Schema across versions, we can ensure
 // that all the latest features are collected, without damaging backwards compatibility.
+//
+// The fields of the shared models can be read with the nil-safe getters of getters.go as well, to chain through
+// optional objects, such as schema.GetExternalDocs().GetURL().
 package base
+
+//go:generate go run ../internal/getters
@@ -0,0 +1,144 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CodeSample represents a high-level code sample, as used by the `x-codeSamples` (and older `x-code-samples`)
+// extension on operations.
+//
+// Code samples are held by the extension of the operation, so always change them with the SetCodeSample and
+// RemoveCodeSample methods of an operation, which keep the rendered extension up to date.
+type CodeSample struct {
+	Lang       string                              `json:"lang,omitempty" yaml:"lang,omitempty"`
+	Label      string                              `json:"label,omitempty" yaml:"label,omitempty"`
+	Source     string                              `json:"source,omitempty" yaml:"source,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *low.CodeSample
+}
+
+// NewCodeSample will create a new high-level CodeSample from a low-level one.
+func NewCodeSample(sample *low.CodeSample) *CodeSample {
+	c := new(CodeSample)
+	c.low = sample
+	c.Lang = sample.Lang.Value
+	c.Label = sample.Label.Value
+	c.Source = sample.Source.Value
+	c.Extensions = high.ExtractExtensions(sample.Extensions)
+	return c
+}
+
+// NewCodeSamples will create high-level code samples from the low-level code samples of an operation.
+func NewCodeSamples(samples lowmodel.NodeReference[[]lowmodel.ValueReference[*low.CodeSample]]) []*CodeSample {
+	if samples.IsEmpty() {
+		return nil
+	}
+	var codeSamples []*CodeSample
+	for i := range samples.Value {
+		codeSamples = append(codeSamples, NewCodeSample(samples.Value[i].Value))
+	}
+	return codeSamples
+}
+
+// GoLow returns the low-level CodeSample instance used to create the high-level one.
+func (c *CodeSample) GoLow() *low.CodeSample {
+	return c.low
+}
+
+// GoLowUntyped will return the low-level CodeSample instance that was used to create the high-level one, with no type
+func (c *CodeSample) GoLowUntyped() any {
+	return c.low
+}
+
+func (c *CodeSample) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	return c.Extensions
+}
+
+// Render will return a YAML representation of the CodeSample object as a byte slice.
+func (c *CodeSample) Render() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// MarshalYAML will create a ready to render YAML representation of the CodeSample object.
+func (c *CodeSample) MarshalYAML() (interface{}, error) {
+	nb := high.NewNodeBuilder(c, c.low)
+	return nb.Render(), nil
+}
+
+// FindCodeSample returns the code sample for a language (matched case-insensitively), or nil if there isn't one.
+func FindCodeSample(samples []*CodeSample, lang string) *CodeSample {
+	for _, s := range samples {
+		if s != nil && strings.EqualFold(s.Lang, lang) {
+			return s
+		}
+	}
+	return nil
+}
+
+// SetCodeSample returns samples with sample added. An existing sample for the same language (matched
+// case-insensitively) is replaced in place, otherwise the sample is appended.
+func SetCodeSample(samples []*CodeSample, sample *CodeSample) []*CodeSample {
+	for i, s := range samples {
+		if s != nil && strings.EqualFold(s.Lang, sample.Lang) {
+			samples[i] = sample
+			return samples
+		}
+	}
+	return append(samples, sample)
+}
+
+// RemoveCodeSample returns samples without the sample for a language (matched case-insensitively), and true if
+// a sample was removed.
+func RemoveCodeSample(samples []*CodeSample, lang string) ([]*CodeSample, bool) {
+	for i, s := range samples {
+		if s != nil && strings.EqualFold(s.Lang, lang) {
+			return append(samples[:i:i], samples[i+1:]...), true
+		}
+	}
+	return samples, false
+}
+
+// RenderCodeSamples writes code samples to an extension map, so they are rendered with the object that owns the
+// map. The existing code sample extension key is kept (`x-codeSamples` or `x-code-samples`), new samples use
+// `x-codeSamples`. If there are no samples the extension is removed. Returns the extension map, which is created
+// if extensions is nil and there are samples to write.
+func RenderCodeSamples(extensions *orderedmap.Map[string, *yaml.Node], samples []*CodeSample) *orderedmap.Map[string, *yaml.Node] {
+	key := low.CodeSamplesLabel
+	if extensions != nil {
+		if _, ok := extensions.Get(low.CodeSamplesLegacyLabel); ok {
+			if _, ok = extensions.Get(low.CodeSamplesLabel); !ok {
+				key = low.CodeSamplesLegacyLabel
+			}
+		}
+	}
+	if len(samples) == 0 {
+		if extensions != nil {
+			extensions.Delete(key)
+		}
+		return extensions
+	}
+	seq := utils.CreateEmptySequenceNode()
+	for _, s := range samples {
+		if s == nil {
+			continue
+		}
+		n, _ := s.MarshalYAML()
+		seq.Content = append(seq.Content, n.(*yaml.Node))
+	}
+	if extensions == nil {
+		extensions = orderedmap.New[string, *yaml.Node]()
+	}
+	// setting an existing key keeps its position, the low-level node is shared so is replaced rather than changed.
+	extensions.Set(key, seq)
+	return extensions
+}
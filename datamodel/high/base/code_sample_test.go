@@ -0,0 +1,97 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewCodeSample(t *testing.T) {
+	var cNode yaml.Node
+
+	yml := `lang: Go
+label: libopenapi
+source: doc, err := libopenapi.NewDocument(spec)
+x-generated: true`
+
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	var lowSample lowbase.CodeSample
+	_ = lowmodel.BuildModel(cNode.Content[0], &lowSample)
+	_ = lowSample.Build(context.Background(), nil, cNode.Content[0], nil)
+
+	sample := NewCodeSample(&lowSample)
+	assert.Equal(t, "Go", sample.Lang)
+	assert.Equal(t, "libopenapi", sample.Label)
+	assert.Equal(t, "doc, err := libopenapi.NewDocument(spec)", sample.Source)
+	assert.Equal(t, 1, orderedmap.Len(sample.GetExtensions()))
+	assert.Equal(t, &lowSample, sample.GoLow())
+	assert.Equal(t, &lowSample, sample.GoLowUntyped())
+
+	rendered, _ := sample.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rendered)))
+}
+
+func TestNewCodeSamples(t *testing.T) {
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`x-code-samples:
+  - lang: Go
+  - lang: Shell`), &cNode)
+
+	samples := NewCodeSamples(lowbase.ExtractCodeSamples(context.Background(), cNode.Content[0], nil))
+	assert.Len(t, samples, 2)
+	assert.Equal(t, "Shell", samples[1].Lang)
+	assert.Nil(t, NewCodeSamples(lowmodel.NodeReference[[]lowmodel.ValueReference[*lowbase.CodeSample]]{}))
+}
+
+func TestCodeSamples_SetFindRemove(t *testing.T) {
+	var samples []*CodeSample
+	samples = SetCodeSample(samples, &CodeSample{Lang: "Go", Source: "one"})
+	samples = SetCodeSample(samples, &CodeSample{Lang: "Shell", Source: "curl"})
+	samples = SetCodeSample(samples, &CodeSample{Lang: "go", Source: "two"})
+
+	assert.Len(t, samples, 2)
+	assert.Equal(t, "two", FindCodeSample(samples, "GO").Source)
+	assert.Nil(t, FindCodeSample(samples, "Rust"))
+
+	samples, removed := RemoveCodeSample(samples, "shell")
+	assert.True(t, removed)
+	assert.Len(t, samples, 1)
+	_, removed = RemoveCodeSample(samples, "shell")
+	assert.False(t, removed)
+}
+
+func TestRenderCodeSamples(t *testing.T) {
+	samples := []*CodeSample{{Lang: "Go", Source: "fmt.Println(\"hi\")\nos.Exit(0)\n"}}
+
+	// a new extension map is created.
+	ext := RenderCodeSamples(nil, samples)
+	node := ext.GetOrZero(lowbase.CodeSamplesLabel)
+	assert.NotNil(t, node)
+	b, _ := yaml.Marshal(node)
+	assert.Equal(t, "- lang: Go\n  source: |\n    fmt.Println(\"hi\")\n    os.Exit(0)\n", string(b))
+
+	// the older key is kept, along with the position of the extension.
+	ext = orderedmap.New[string, *yaml.Node]()
+	ext.Set("x-first", &yaml.Node{Kind: yaml.ScalarNode, Value: "1"})
+	ext.Set(lowbase.CodeSamplesLegacyLabel, &yaml.Node{Kind: yaml.SequenceNode})
+	ext.Set("x-last", &yaml.Node{Kind: yaml.ScalarNode, Value: "2"})
+	ext = RenderCodeSamples(ext, samples)
+	assert.Equal(t, []string{"x-first", lowbase.CodeSamplesLegacyLabel, "x-last"}, slices.Collect(ext.KeysFromOldest()))
+	assert.Len(t, ext.GetOrZero(lowbase.CodeSamplesLegacyLabel).Content, 1)
+
+	// no samples removes the extension.
+	ext = RenderCodeSamples(ext, nil)
+	assert.Equal(t, []string{"x-first", "x-last"}, slices.Collect(ext.KeysFromOldest()))
+	assert.Nil(t, RenderCodeSamples(nil, nil))
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+	"strings"
+)
+
+// discriminatorCandidates returns the schemas a discriminator can select from (oneOf, or anyOf if there is no oneOf).
+func (s *Schema) discriminatorCandidates() []*SchemaProxy {
+	if len(s.OneOf) > 0 {
+		return s.OneOf
+	}
+	return s.AnyOf
+}
+
+// referenceName returns the name of the component a reference points to, for example `Dog` for
+// `#/components/schemas/Dog`.
+func referenceName(ref string) string {
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		return ref[i+1:]
+	}
+	_, name, _ := strings.Cut(ref, "#")
+	return name
+}
+
+// matchesDiscriminatorTarget returns true if a candidate schema is the target of a mapping value. Mapping values
+// can be a reference (`#/components/schemas/Dog`) or the name of a schema component (`Dog`).
+func matchesDiscriminatorTarget(candidate *SchemaProxy, target string) bool {
+	if candidate == nil || !candidate.IsReference() {
+		return false
+	}
+	ref := candidate.GetReference()
+	if ref == target {
+		return true
+	}
+	if strings.Contains(target, "/") || strings.Contains(target, "#") {
+		// an external or relative reference (pet.yaml#/Dog vs #/Dog).
+		return strings.HasSuffix(ref, target) || strings.HasSuffix(target, ref)
+	}
+	return referenceName(ref) == target
+}
+
+// discriminatorMapping looks up an explicit mapping for a discriminator value.
+func (s *Schema) discriminatorMapping(value string) (string, bool) {
+	if s.Discriminator.Mapping == nil {
+		return "", false
+	}
+	return s.Discriminator.Mapping.Get(value)
+}
+
+// ResolveDiscriminator will resolve a discriminator value (the value of the discriminator property found in a
+// payload) to the concrete schema it selects, from the oneOf (or anyOf) schemas of the Schema.
+//
+// An explicit mapping for the value is used first. If there is no mapping, the value is implicitly treated as the
+// name of a schema component (`Dog` selects a `#/components/schemas/Dog` reference). Inline schemas can never be
+// selected by a discriminator. An error is returned if the schema has no discriminator, or nothing can be selected.
+func (s *Schema) ResolveDiscriminator(value string) (*SchemaProxy, error) {
+	if s.Discriminator == nil {
+		return nil, fmt.Errorf("unable to resolve discriminator value '%s', schema has no discriminator", value)
+	}
+	candidates := s.discriminatorCandidates()
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("unable to resolve discriminator value '%s', schema has no oneOf or anyOf schemas",
+			value)
+	}
+
+	if target, ok := s.discriminatorMapping(value); ok {
+		for _, c := range candidates {
+			if matchesDiscriminatorTarget(c, target) {
+				return c, nil
+			}
+		}
+		return nil, fmt.Errorf("unable to resolve discriminator value '%s', mapped schema '%s' is not one of "+
+			"the oneOf or anyOf schemas", value, target)
+	}
+
+	for _, c := range candidates {
+		if c != nil && c.IsReference() && referenceName(c.GetReference()) == value {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to resolve discriminator value '%s' (property '%s'), there is no mapping "+
+		"or schema with that name", value, s.Discriminator.PropertyName)
+}
+
+// ValidateDiscriminator checks that every oneOf (or anyOf) schema can be selected by the discriminator of the
+// Schema, and that every mapping points to one of those schemas. A schema can be selected if it's a reference
+// that is the target of a mapping, or that can be matched implicitly by its component name.
+//
+// An error is returned for every inline schema (which can never be selected), and every mapping that does not
+// point to one of the schemas. Returns nil if there is no discriminator, or nothing is wrong.
+func (s *Schema) ValidateDiscriminator() []error {
+	if s.Discriminator == nil {
+		return nil
+	}
+	var errs []error
+	candidates := s.discriminatorCandidates()
+	label := "oneOf"
+	if len(s.OneOf) == 0 {
+		label = "anyOf"
+	}
+
+	for i, c := range candidates {
+		if c == nil || !c.IsReference() {
+			errs = append(errs, fmt.Errorf("%s schema [%d] is an inline schema, it can't be selected by the "+
+				"discriminator '%s'", label, i, s.Discriminator.PropertyName))
+		}
+	}
+
+	for value, target := range s.Discriminator.Mapping.FromOldest() {
+		found := false
+		for _, c := range candidates {
+			if matchesDiscriminatorTarget(c, target) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("discriminator mapping '%s' points to '%s', which is not one of the "+
+				"%s schemas", value, target, label))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_ResolveDiscriminator(t *testing.T) {
+	yml := `components:
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+        - $ref: '#/components/schemas/Lizard'
+      discriminator:
+        propertyName: petType
+        mapping:
+          dog: '#/components/schemas/Dog'
+          kitty: Cat
+    Cat:
+      description: a cat
+    Dog:
+      description: a dog
+    Lizard:
+      description: a lizard`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	petNode := idxNode.Content[0].Content[1].Content[1].Content[1]
+	sp := new(lowbase.SchemaProxy)
+	err := sp.Build(context.Background(), nil, petNode, idx)
+	assert.NoError(t, err)
+
+	pet := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: petNode}).Schema()
+	assert.NotNil(t, pet)
+
+	dog, err := pet.ResolveDiscriminator("dog")
+	assert.NoError(t, err)
+	assert.Equal(t, "a dog", dog.Schema().Description)
+
+	cat, err := pet.ResolveDiscriminator("kitty")
+	assert.NoError(t, err)
+	assert.Equal(t, "a cat", cat.Schema().Description)
+
+	// implicit mapping by component name.
+	lizard, err := pet.ResolveDiscriminator("Lizard")
+	assert.NoError(t, err)
+	assert.Equal(t, "a lizard", lizard.Schema().Description)
+
+	_, err = pet.ResolveDiscriminator("fish")
+	assert.EqualError(t, err, "unable to resolve discriminator value 'fish' (property 'petType'), "+
+		"there is no mapping or schema with that name")
+
+	assert.Empty(t, pet.ValidateDiscriminator())
+}
+
+func TestSchema_ResolveDiscriminator_AnyOf(t *testing.T) {
+	s := &Schema{
+		AnyOf: []*SchemaProxy{
+			CreateSchemaProxyRef("pets.yaml#/components/schemas/Dog"),
+			CreateSchemaProxyRef("#/components/schemas/Cat"),
+		},
+		Discriminator: &Discriminator{
+			PropertyName: "petType",
+			Mapping:      orderedmap.ToOrderedMap(map[string]string{"dog": "#/components/schemas/Dog"}),
+		},
+	}
+	dog, err := s.ResolveDiscriminator("dog")
+	assert.NoError(t, err)
+	assert.Equal(t, "pets.yaml#/components/schemas/Dog", dog.GetReference())
+
+	cat, err := s.ResolveDiscriminator("Cat")
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/schemas/Cat", cat.GetReference())
+}
+
+func TestSchema_ResolveDiscriminator_Errors(t *testing.T) {
+	_, err := (&Schema{}).ResolveDiscriminator("dog")
+	assert.EqualError(t, err, "unable to resolve discriminator value 'dog', schema has no discriminator")
+	assert.Nil(t, (&Schema{}).ValidateDiscriminator())
+
+	_, err = (&Schema{Discriminator: &Discriminator{PropertyName: "petType"}}).ResolveDiscriminator("dog")
+	assert.EqualError(t, err, "unable to resolve discriminator value 'dog', schema has no oneOf or anyOf schemas")
+
+	s := &Schema{
+		OneOf: []*SchemaProxy{
+			CreateSchemaProxyRef("#/components/schemas/Cat"),
+			CreateSchemaProxy(&Schema{Description: "inline"}),
+		},
+		Discriminator: &Discriminator{
+			PropertyName: "petType",
+			Mapping:      orderedmap.ToOrderedMap(map[string]string{"dog": "#/components/schemas/Dog"}),
+		},
+	}
+	_, err = s.ResolveDiscriminator("dog")
+	assert.EqualError(t, err, "unable to resolve discriminator value 'dog', mapped schema "+
+		"'#/components/schemas/Dog' is not one of the oneOf or anyOf schemas")
+
+	errs := s.ValidateDiscriminator()
+	assert.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "oneOf schema [1] is an inline schema, it can't be selected by the "+
+		"discriminator 'petType'")
+	assert.EqualError(t, errs[1], "discriminator mapping 'dog' points to '#/components/schemas/Dog', "+
+		"which is not one of the oneOf schemas")
+}
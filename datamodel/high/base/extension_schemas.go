@@ -0,0 +1,201 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtensionSchemas is a registry of the JSON Schemas of known extensions (such as `x-internal` or `x-rate-limit`).
+// Validate checks every occurrence of a registered extension in a document against its schema, so an organization
+// can govern the extensions its specifications use.
+//
+// A registry is safe for concurrent use, however all schemas should be registered before anything is validated.
+type ExtensionSchemas struct {
+	lock    sync.RWMutex
+	schemas map[string]*Schema
+}
+
+// NewExtensionSchemas creates an empty *ExtensionSchemas, ready for schemas to be registered.
+func NewExtensionSchemas() *ExtensionSchemas {
+	return &ExtensionSchemas{schemas: make(map[string]*Schema)}
+}
+
+// Register registers the schema of an extension key. Registering the same key again replaces the previous schema.
+func (e *ExtensionSchemas) Register(key string, schema *Schema) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.schemas[key] = schema
+}
+
+// RegisterJSONSchema registers the schema of an extension key from a JSON Schema (2020-12, the dialect of OpenAPI
+// 3.1), in JSON or YAML. The schema can only reference its own definitions (`#/$defs/...`).
+//
+// to use:
+//
+//	schemas := base.NewExtensionSchemas()
+//	err := schemas.RegisterJSONSchema("x-rate-limit", []byte(`{"type": "integer", "minimum": 1}`))
+func (e *ExtensionSchemas) RegisterJSONSchema(key string, schema []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(schema, &node); err != nil {
+		return fmt.Errorf("unable to register the schema of extension '%s': %w", key, err)
+	}
+	if len(node.Content) == 0 {
+		return fmt.Errorf("unable to register the schema of extension '%s': the schema is empty", key)
+	}
+	config := index.CreateClosedAPIIndexConfig()
+	config.SpecInfo = &datamodel.SpecInfo{VersionNumeric: 3.1}
+	idx := index.NewSpecIndexWithConfig(&node, config)
+	sp := new(lowbase.SchemaProxy)
+	if err := sp.Build(context.Background(), nil, node.Content[0], idx); err != nil {
+		return fmt.Errorf("unable to register the schema of extension '%s': %w", key, err)
+	}
+	s := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: node.Content[0]}).Schema()
+	if s == nil {
+		return fmt.Errorf("unable to register the schema of extension '%s': %w", key,
+			errors.Join(errors.New("the schema can't be built"), sp.GetBuildError()))
+	}
+	e.Register(key, s)
+	return nil
+}
+
+// GetSchema returns the schema registered for an extension key, or nil if there isn't one.
+func (e *ExtensionSchemas) GetSchema(key string) *Schema {
+	if e == nil {
+		return nil
+	}
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.schemas[key]
+}
+
+// GetRegisteredKeys returns all registered extension keys, sorted.
+func (e *ExtensionSchemas) GetRegisteredKeys() []string {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	keys := make([]string, 0, len(e.schemas))
+	for k := range e.schemas {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate checks every extension under root (a whole specification, or any part of one) that has a registered
+// schema, and returns a Diagnostic for every mismatch, in document order. The Key of each Diagnostic is the JSON
+// Path to the extension, and it's positioned at the part of the extension value that does not match.
+//
+// Only keys that can be extensions are checked: the names of properties, headers, components and the like are not
+// extensions even when they start with `x-`, and examples, defaults, enums and extension values themselves are data,
+// so they are not searched for more extensions.
+func (e *ExtensionSchemas) Validate(root *yaml.Node) []*low.Diagnostic {
+	if e == nil {
+		return nil
+	}
+	root = utils.NodeAlias(root)
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	v := &extensionValidator{schemas: e}
+	v.walk(root, "$", 0, false)
+	return v.diagnostics
+}
+
+// extensionNameMaps are the keys of maps whose own keys are names, not extensions.
+var extensionNameMaps = map[string]bool{
+	"properties": true, "patternProperties": true, "dependentSchemas": true, "$defs": true, "definitions": true,
+	"securityDefinitions": true, "headers": true, "links": true, "callbacks": true, "content": true, "encoding": true,
+	"variables": true, "scopes": true, "mapping": true, "examples": true,
+}
+
+// extensionNameMapsAtRoot are the keys of the root of a (Swagger) document whose maps have names as keys.
+var extensionNameMapsAtRoot = map[string]bool{"parameters": true, "responses": true}
+
+// extensionDataKeys are the keys whose values are data, not part of the specification.
+var extensionDataKeys = map[string]bool{"example": true, "default": true, "const": true, "enum": true, "value": true}
+
+var extensionPathIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type extensionValidator struct {
+	schemas     *ExtensionSchemas
+	diagnostics []*low.Diagnostic
+}
+
+// walk searches node for extensions. names is true when the keys of node are names, so can't be extensions.
+func (v *extensionValidator) walk(node *yaml.Node, path string, depth int, names bool) {
+	node = utils.NodeAlias(node)
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			v.walk(n, fmt.Sprintf("%s[%d]", path, i), depth+1, false)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, val := node.Content[i], node.Content[i+1]
+			p := extensionPath(path, k.Value)
+			switch {
+			case names:
+				v.walk(val, p, depth+1, false)
+			case strings.HasPrefix(k.Value, "x-"):
+				v.validate(k, val, p)
+			case depth == 1 && path == "$.components":
+				// every map of components has names as keys.
+				v.walk(val, p, depth+1, true)
+			case extensionDataKeys[k.Value]:
+			case k.Value == "examples" && utils.IsNodeArray(utils.NodeAlias(val)):
+				// the examples of a schema are data.
+			case extensionNameMaps[k.Value] || (depth == 0 && extensionNameMapsAtRoot[k.Value]):
+				v.walk(val, p, depth+1, true)
+			default:
+				v.walk(val, p, depth+1, false)
+			}
+		}
+	}
+}
+
+func (v *extensionValidator) validate(key, value *yaml.Node, path string) {
+	schema := v.schemas.GetSchema(key.Value)
+	if schema == nil {
+		return
+	}
+	for _, violation := range schema.ValidateValue(value) {
+		location := path + strings.TrimPrefix(violation.Path, "$")
+		d := &low.Diagnostic{
+			Message:   fmt.Sprintf("extension '%s' at '%s' does not match its schema: %s", key.Value, location, violation.Message),
+			Key:       location,
+			KeyNode:   key,
+			ValueNode: violation.Node,
+		}
+		if n := utils.NodeAlias(violation.Node); n != nil {
+			d.Line, d.Column = n.Line, n.Column
+		} else {
+			d.Line, d.Column = key.Line, key.Column
+		}
+		v.diagnostics = append(v.diagnostics, d)
+	}
+}
+
+// extensionPath appends a key to a JSON Path, in brackets unless it's a plain identifier.
+func extensionPath(path, key string) string {
+	if extensionPathIdentifier.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s['%s']", path, key)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var extensionSchemasSpec = `openapi: 3.1.0
+x-rate-limit: 100
+paths:
+  x-internal: yes
+  /pets:
+    get:
+      x-rate-limit: -1
+      responses:
+        "200":
+          description: OK
+          headers:
+            x-rate-limit:
+              schema:
+                type: string
+components:
+  schemas:
+    x-internal:
+      type: object
+      x-internal: true
+      properties:
+        x-rate-limit:
+          type: integer
+          default: {x-rate-limit: nope}
+  x-owner:
+    team: pets`
+
+func TestExtensionSchemas_Validate(t *testing.T) {
+	schemas := NewExtensionSchemas()
+	require.NoError(t, schemas.RegisterJSONSchema("x-rate-limit", []byte(`{"type": "integer", "minimum": 1}`)))
+	require.NoError(t, schemas.RegisterJSONSchema("x-internal", []byte(`type: boolean`)))
+	require.NoError(t, schemas.RegisterJSONSchema("x-owner", []byte(`
+type: object
+required: [team, slack]
+properties:
+  team:
+    $ref: '#/$defs/name'
+$defs:
+  name:
+    type: string
+    minLength: 5`)))
+	assert.Equal(t, []string{"x-internal", "x-owner", "x-rate-limit"}, schemas.GetRegisteredKeys())
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(extensionSchemasSpec), &root))
+	diagnostics := schemas.Validate(&root)
+
+	var messages []string
+	for _, d := range diagnostics {
+		messages = append(messages, d.Message)
+	}
+	assert.Equal(t, []string{
+		"extension 'x-internal' at '$.paths['x-internal']' does not match its schema: expected boolean, but got string",
+		"extension 'x-rate-limit' at '$.paths['/pets'].get['x-rate-limit']' does not match its schema: value must be at least 1",
+		"extension 'x-owner' at '$.components['x-owner']' does not match its schema: object is missing the required property 'slack'",
+		"extension 'x-owner' at '$.components['x-owner'].team' does not match its schema: value must be at least 5 characters long",
+	}, messages)
+	assert.Equal(t, "$.paths['/pets'].get['x-rate-limit']", diagnostics[1].Key)
+	assert.Equal(t, 7, diagnostics[1].Line)
+	assert.Equal(t, 21, diagnostics[1].Column)
+	assert.Equal(t, "x-rate-limit", diagnostics[1].KeyNode.Value)
+	assert.Equal(t, 25, diagnostics[3].Line)
+}
+
+func TestExtensionSchemas_Register(t *testing.T) {
+	var schemas *ExtensionSchemas
+	assert.Nil(t, schemas.GetSchema("x-internal"))
+	assert.Empty(t, schemas.Validate(nil))
+
+	schemas = NewExtensionSchemas()
+	s := &Schema{Type: []string{"boolean"}}
+	schemas.Register("x-internal", s)
+	assert.Same(t, s, schemas.GetSchema("x-internal"))
+	assert.Nil(t, schemas.GetSchema("x-other"))
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`{x-internal: 1, x-other: 1}`), &root))
+	assert.Len(t, schemas.Validate(root.Content[0]), 1)
+
+	err := schemas.RegisterJSONSchema("x-bad", []byte(`{`))
+	assert.ErrorContains(t, err, "unable to register the schema of extension 'x-bad'")
+	assert.EqualError(t, schemas.RegisterJSONSchema("x-empty", []byte(``)),
+		"unable to register the schema of extension 'x-empty': the schema is empty")
+}
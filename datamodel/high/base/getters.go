@@ -0,0 +1,819 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Code generated by go run ../internal/getters; DO NOT EDIT.
+
+package base
+
+import (
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// GetLang returns the Lang of the CodeSample, or the zero value if the CodeSample is nil.
+func (c *CodeSample) GetLang() string {
+	if c == nil {
+		return ""
+	}
+	return c.Lang
+}
+
+// GetLabel returns the Label of the CodeSample, or the zero value if the CodeSample is nil.
+func (c *CodeSample) GetLabel() string {
+	if c == nil {
+		return ""
+	}
+	return c.Label
+}
+
+// GetSource returns the Source of the CodeSample, or the zero value if the CodeSample is nil.
+func (c *CodeSample) GetSource() string {
+	if c == nil {
+		return ""
+	}
+	return c.Source
+}
+
+// GetName returns the Name of the Contact, or the zero value if the Contact is nil.
+func (c *Contact) GetName() string {
+	if c == nil {
+		return ""
+	}
+	return c.Name
+}
+
+// GetURL returns the URL of the Contact, or the zero value if the Contact is nil.
+func (c *Contact) GetURL() string {
+	if c == nil {
+		return ""
+	}
+	return c.URL
+}
+
+// GetEmail returns the Email of the Contact, or the zero value if the Contact is nil.
+func (c *Contact) GetEmail() string {
+	if c == nil {
+		return ""
+	}
+	return c.Email
+}
+
+// GetExtensions returns the Extensions of the Contact, or the zero value if the Contact is nil.
+func (c *Contact) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if c == nil {
+		return nil
+	}
+	return c.Extensions
+}
+
+// GetPropertyName returns the PropertyName of the Discriminator, or the zero value if the Discriminator is nil.
+func (d *Discriminator) GetPropertyName() string {
+	if d == nil {
+		return ""
+	}
+	return d.PropertyName
+}
+
+// GetMapping returns the Mapping of the Discriminator, or the zero value if the Discriminator is nil.
+func (d *Discriminator) GetMapping() *orderedmap.Map[string, string] {
+	if d == nil {
+		return nil
+	}
+	return d.Mapping
+}
+
+// GetSummary returns the Summary of the Example, or the zero value if the Example is nil.
+func (e *Example) GetSummary() string {
+	if e == nil {
+		return ""
+	}
+	return e.Summary
+}
+
+// GetDescription returns the Description of the Example, or the zero value if the Example is nil.
+func (e *Example) GetDescription() string {
+	if e == nil {
+		return ""
+	}
+	return e.Description
+}
+
+// GetValue returns the Value of the Example, or the zero value if the Example is nil.
+func (e *Example) GetValue() *yaml.Node {
+	if e == nil {
+		return nil
+	}
+	return e.Value
+}
+
+// GetExternalValue returns the ExternalValue of the Example, or the zero value if the Example is nil.
+func (e *Example) GetExternalValue() string {
+	if e == nil {
+		return ""
+	}
+	return e.ExternalValue
+}
+
+// GetExtensions returns the Extensions of the Example, or the zero value if the Example is nil.
+func (e *Example) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if e == nil {
+		return nil
+	}
+	return e.Extensions
+}
+
+// GetDescription returns the Description of the ExternalDoc, or the zero value if the ExternalDoc is nil.
+func (e *ExternalDoc) GetDescription() string {
+	if e == nil {
+		return ""
+	}
+	return e.Description
+}
+
+// GetURL returns the URL of the ExternalDoc, or the zero value if the ExternalDoc is nil.
+func (e *ExternalDoc) GetURL() string {
+	if e == nil {
+		return ""
+	}
+	return e.URL
+}
+
+// GetSummary returns the Summary of the Info, or the zero value if the Info is nil.
+func (i *Info) GetSummary() string {
+	if i == nil {
+		return ""
+	}
+	return i.Summary
+}
+
+// GetTitle returns the Title of the Info, or the zero value if the Info is nil.
+func (i *Info) GetTitle() string {
+	if i == nil {
+		return ""
+	}
+	return i.Title
+}
+
+// GetDescription returns the Description of the Info, or the zero value if the Info is nil.
+func (i *Info) GetDescription() string {
+	if i == nil {
+		return ""
+	}
+	return i.Description
+}
+
+// GetTermsOfService returns the TermsOfService of the Info, or the zero value if the Info is nil.
+func (i *Info) GetTermsOfService() string {
+	if i == nil {
+		return ""
+	}
+	return i.TermsOfService
+}
+
+// GetContact returns the Contact of the Info, or the zero value if the Info is nil.
+func (i *Info) GetContact() *Contact {
+	if i == nil {
+		return nil
+	}
+	return i.Contact
+}
+
+// GetLicense returns the License of the Info, or the zero value if the Info is nil.
+func (i *Info) GetLicense() *License {
+	if i == nil {
+		return nil
+	}
+	return i.License
+}
+
+// GetVersion returns the Version of the Info, or the zero value if the Info is nil.
+func (i *Info) GetVersion() string {
+	if i == nil {
+		return ""
+	}
+	return i.Version
+}
+
+// GetExtensions returns the Extensions of the Info, or the zero value if the Info is nil.
+func (i *Info) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if i == nil {
+		return nil
+	}
+	return i.Extensions
+}
+
+// GetName returns the Name of the License, or the zero value if the License is nil.
+func (l *License) GetName() string {
+	if l == nil {
+		return ""
+	}
+	return l.Name
+}
+
+// GetURL returns the URL of the License, or the zero value if the License is nil.
+func (l *License) GetURL() string {
+	if l == nil {
+		return ""
+	}
+	return l.URL
+}
+
+// GetIdentifier returns the Identifier of the License, or the zero value if the License is nil.
+func (l *License) GetIdentifier() string {
+	if l == nil {
+		return ""
+	}
+	return l.Identifier
+}
+
+// GetExtensions returns the Extensions of the License, or the zero value if the License is nil.
+func (l *License) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if l == nil {
+		return nil
+	}
+	return l.Extensions
+}
+
+// GetSchemaTypeRef returns the SchemaTypeRef of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetSchemaTypeRef() string {
+	if s == nil {
+		return ""
+	}
+	return s.SchemaTypeRef
+}
+
+// GetExclusiveMaximum returns the ExclusiveMaximum of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetExclusiveMaximum() *DynamicValue[bool, float64] {
+	if s == nil {
+		return nil
+	}
+	return s.ExclusiveMaximum
+}
+
+// GetExclusiveMinimum returns the ExclusiveMinimum of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetExclusiveMinimum() *DynamicValue[bool, float64] {
+	if s == nil {
+		return nil
+	}
+	return s.ExclusiveMinimum
+}
+
+// GetType returns the Type of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetType() []string {
+	if s == nil {
+		return nil
+	}
+	return s.Type
+}
+
+// GetAllOf returns the AllOf of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetAllOf() []*SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.AllOf
+}
+
+// GetOneOf returns the OneOf of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetOneOf() []*SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.OneOf
+}
+
+// GetAnyOf returns the AnyOf of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetAnyOf() []*SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.AnyOf
+}
+
+// GetDiscriminator returns the Discriminator of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDiscriminator() *Discriminator {
+	if s == nil {
+		return nil
+	}
+	return s.Discriminator
+}
+
+// GetExamples returns the Examples of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetExamples() []*yaml.Node {
+	if s == nil {
+		return nil
+	}
+	return s.Examples
+}
+
+// GetPrefixItems returns the PrefixItems of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetPrefixItems() []*SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.PrefixItems
+}
+
+// GetContains returns the Contains of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetContains() *SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.Contains
+}
+
+// GetMinContains returns the MinContains of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMinContains() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MinContains
+}
+
+// GetMaxContains returns the MaxContains of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMaxContains() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MaxContains
+}
+
+// GetIf returns the If of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetIf() *SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.If
+}
+
+// GetElse returns the Else of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetElse() *SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.Else
+}
+
+// GetThen returns the Then of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetThen() *SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.Then
+}
+
+// GetDependentSchemas returns the DependentSchemas of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDependentSchemas() *orderedmap.Map[string, *SchemaProxy] {
+	if s == nil {
+		return nil
+	}
+	return s.DependentSchemas
+}
+
+// GetPatternProperties returns the PatternProperties of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetPatternProperties() *orderedmap.Map[string, *SchemaProxy] {
+	if s == nil {
+		return nil
+	}
+	return s.PatternProperties
+}
+
+// GetPropertyNames returns the PropertyNames of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetPropertyNames() *SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.PropertyNames
+}
+
+// GetUnevaluatedItems returns the UnevaluatedItems of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetUnevaluatedItems() *DynamicValue[*SchemaProxy, bool] {
+	if s == nil {
+		return nil
+	}
+	return s.UnevaluatedItems
+}
+
+// GetUnevaluatedProperties returns the UnevaluatedProperties of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetUnevaluatedProperties() *DynamicValue[*SchemaProxy, bool] {
+	if s == nil {
+		return nil
+	}
+	return s.UnevaluatedProperties
+}
+
+// GetItems returns the Items of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetItems() *DynamicValue[*SchemaProxy, bool] {
+	if s == nil {
+		return nil
+	}
+	return s.Items
+}
+
+// GetAnchor returns the Anchor of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetAnchor() string {
+	if s == nil {
+		return ""
+	}
+	return s.Anchor
+}
+
+// GetDynamicAnchor returns the DynamicAnchor of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDynamicAnchor() string {
+	if s == nil {
+		return ""
+	}
+	return s.DynamicAnchor
+}
+
+// GetDynamicRef returns the DynamicRef of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDynamicRef() string {
+	if s == nil {
+		return ""
+	}
+	return s.DynamicRef
+}
+
+// GetContentEncoding returns the ContentEncoding of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetContentEncoding() string {
+	if s == nil {
+		return ""
+	}
+	return s.ContentEncoding
+}
+
+// GetContentMediaType returns the ContentMediaType of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetContentMediaType() string {
+	if s == nil {
+		return ""
+	}
+	return s.ContentMediaType
+}
+
+// GetDefs returns the Defs of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDefs() *orderedmap.Map[string, *SchemaProxy] {
+	if s == nil {
+		return nil
+	}
+	return s.Defs
+}
+
+// GetNot returns the Not of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetNot() *SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.Not
+}
+
+// GetProperties returns the Properties of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetProperties() *orderedmap.Map[string, *SchemaProxy] {
+	if s == nil {
+		return nil
+	}
+	return s.Properties
+}
+
+// GetTitle returns the Title of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetTitle() string {
+	if s == nil {
+		return ""
+	}
+	return s.Title
+}
+
+// GetMultipleOf returns the MultipleOf of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMultipleOf() *float64 {
+	if s == nil {
+		return nil
+	}
+	return s.MultipleOf
+}
+
+// GetMaximum returns the Maximum of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMaximum() *float64 {
+	if s == nil {
+		return nil
+	}
+	return s.Maximum
+}
+
+// GetMinimum returns the Minimum of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMinimum() *float64 {
+	if s == nil {
+		return nil
+	}
+	return s.Minimum
+}
+
+// GetMaxLength returns the MaxLength of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMaxLength() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MaxLength
+}
+
+// GetMinLength returns the MinLength of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMinLength() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MinLength
+}
+
+// GetPattern returns the Pattern of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetPattern() string {
+	if s == nil {
+		return ""
+	}
+	return s.Pattern
+}
+
+// GetFormat returns the Format of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetFormat() string {
+	if s == nil {
+		return ""
+	}
+	return s.Format
+}
+
+// GetMaxItems returns the MaxItems of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMaxItems() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MaxItems
+}
+
+// GetMinItems returns the MinItems of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMinItems() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MinItems
+}
+
+// GetUniqueItems returns the UniqueItems of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetUniqueItems() *bool {
+	if s == nil {
+		return nil
+	}
+	return s.UniqueItems
+}
+
+// GetMaxProperties returns the MaxProperties of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMaxProperties() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MaxProperties
+}
+
+// GetMinProperties returns the MinProperties of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetMinProperties() *int64 {
+	if s == nil {
+		return nil
+	}
+	return s.MinProperties
+}
+
+// GetRequired returns the Required of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetRequired() []string {
+	if s == nil {
+		return nil
+	}
+	return s.Required
+}
+
+// GetEnum returns the Enum of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetEnum() []*yaml.Node {
+	if s == nil {
+		return nil
+	}
+	return s.Enum
+}
+
+// GetAdditionalProperties returns the AdditionalProperties of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetAdditionalProperties() *DynamicValue[*SchemaProxy, bool] {
+	if s == nil {
+		return nil
+	}
+	return s.AdditionalProperties
+}
+
+// GetDescription returns the Description of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDescription() string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+// GetDefault returns the Default of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDefault() *yaml.Node {
+	if s == nil {
+		return nil
+	}
+	return s.Default
+}
+
+// GetConst returns the Const of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetConst() *yaml.Node {
+	if s == nil {
+		return nil
+	}
+	return s.Const
+}
+
+// GetNullable returns the Nullable of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetNullable() *bool {
+	if s == nil {
+		return nil
+	}
+	return s.Nullable
+}
+
+// GetReadOnly returns the ReadOnly of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetReadOnly() *bool {
+	if s == nil {
+		return nil
+	}
+	return s.ReadOnly
+}
+
+// GetWriteOnly returns the WriteOnly of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetWriteOnly() *bool {
+	if s == nil {
+		return nil
+	}
+	return s.WriteOnly
+}
+
+// GetXML returns the XML of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetXML() *XML {
+	if s == nil {
+		return nil
+	}
+	return s.XML
+}
+
+// GetExternalDocs returns the ExternalDocs of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetExternalDocs() *ExternalDoc {
+	if s == nil {
+		return nil
+	}
+	return s.ExternalDocs
+}
+
+// GetExample returns the Example of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetExample() *yaml.Node {
+	if s == nil {
+		return nil
+	}
+	return s.Example
+}
+
+// GetDeprecated returns the Deprecated of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetDeprecated() *bool {
+	if s == nil {
+		return nil
+	}
+	return s.Deprecated
+}
+
+// GetExtensions returns the Extensions of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetParentProxy returns the ParentProxy of the Schema, or the zero value if the Schema is nil.
+func (s *Schema) GetParentProxy() *SchemaProxy {
+	if s == nil {
+		return nil
+	}
+	return s.ParentProxy
+}
+
+// GetRequirements returns the Requirements of the SecurityRequirement, or the zero value if the SecurityRequirement is nil.
+func (s *SecurityRequirement) GetRequirements() *orderedmap.Map[string, []string] {
+	if s == nil {
+		return nil
+	}
+	return s.Requirements
+}
+
+// GetContainsEmptyRequirement returns the ContainsEmptyRequirement of the SecurityRequirement, or the zero value if the SecurityRequirement is nil.
+func (s *SecurityRequirement) GetContainsEmptyRequirement() bool {
+	if s == nil {
+		return false
+	}
+	return s.ContainsEmptyRequirement
+}
+
+// GetName returns the Name of the Tag, or the zero value if the Tag is nil.
+func (t *Tag) GetName() string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+// GetSummary returns the Summary of the Tag, or the zero value if the Tag is nil.
+func (t *Tag) GetSummary() string {
+	if t == nil {
+		return ""
+	}
+	return t.Summary
+}
+
+// GetDescription returns the Description of the Tag, or the zero value if the Tag is nil.
+func (t *Tag) GetDescription() string {
+	if t == nil {
+		return ""
+	}
+	return t.Description
+}
+
+// GetExternalDocs returns the ExternalDocs of the Tag, or the zero value if the Tag is nil.
+func (t *Tag) GetExternalDocs() *ExternalDoc {
+	if t == nil {
+		return nil
+	}
+	return t.ExternalDocs
+}
+
+// GetParent returns the Parent of the Tag, or the zero value if the Tag is nil.
+func (t *Tag) GetParent() string {
+	if t == nil {
+		return ""
+	}
+	return t.Parent
+}
+
+// GetKind returns the Kind of the Tag, or the zero value if the Tag is nil.
+func (t *Tag) GetKind() string {
+	if t == nil {
+		return ""
+	}
+	return t.Kind
+}
+
+// GetExtensions returns the Extensions of the Tag, or the zero value if the Tag is nil.
+func (t *Tag) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if t == nil {
+		return nil
+	}
+	return t.Extensions
+}
+
+// GetName returns the Name of the XML, or the zero value if the XML is nil.
+func (x *XML) GetName() string {
+	if x == nil {
+		return ""
+	}
+	return x.Name
+}
+
+// GetNamespace returns the Namespace of the XML, or the zero value if the XML is nil.
+func (x *XML) GetNamespace() string {
+	if x == nil {
+		return ""
+	}
+	return x.Namespace
+}
+
+// GetPrefix returns the Prefix of the XML, or the zero value if the XML is nil.
+func (x *XML) GetPrefix() string {
+	if x == nil {
+		return ""
+	}
+	return x.Prefix
+}
+
+// GetAttribute returns the Attribute of the XML, or the zero value if the XML is nil.
+func (x *XML) GetAttribute() bool {
+	if x == nil {
+		return false
+	}
+	return x.Attribute
+}
+
+// GetWrapped returns the Wrapped of the XML, or the zero value if the XML is nil.
+func (x *XML) GetWrapped() bool {
+	if x == nil {
+		return false
+	}
+	return x.Wrapped
+}
+
+// GetExtensions returns the Extensions of the XML, or the zero value if the XML is nil.
+func (x *XML) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if x == nil {
+		return nil
+	}
+	return x.Extensions
+}
@@ -62,7 +62,9 @@ type Schema struct {
 	DependentSchemas  *orderedmap.Map[string, *SchemaProxy] `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
 	PatternProperties *orderedmap.Map[string, *SchemaProxy] `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
 	PropertyNames     *SchemaProxy                          `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
-	UnevaluatedItems  *SchemaProxy                          `json:"unevaluatedItems,omitempty" yaml:"unevaluatedItems,omitempty"`
+
+	// in 3.1 UnevaluatedItems can be a Schema or a boolean
+	UnevaluatedItems *DynamicValue[*SchemaProxy, bool] `json:"unevaluatedItems,omitempty" yaml:"unevaluatedItems,omitempty"`
 
 	// in 3.1 UnevaluatedProperties can be a Schema or a boolean
 	// https://github.com/pb33f/libopenapi/issues/118
@@ -74,6 +76,19 @@ type Schema struct {
 	// 3.1 only, part of the JSON Schema spec provides a way to identify a sub-schema
 	Anchor string `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
 
+	// 3.1 only, DynamicAnchor identifies a sub-schema that can be extended by DynamicRef, and DynamicRef is a
+	// reference that is resolved using the dynamic scope, see ResolveDynamicRef.
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
+	DynamicRef    string `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
+
+	// 3.1 only, ContentEncoding and ContentMediaType describe the encoding and media type of the content of a
+	// string, for example a `contentMediaType` of `image/png` for an uploaded file.
+	ContentEncoding  string `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+	ContentMediaType string `json:"contentMediaType,omitempty" yaml:"contentMediaType,omitempty"`
+
+	// 3.1 only, Defs holds re-usable schemas, referenced with `#/$defs/name` (or a path to the schema).
+	Defs *orderedmap.Map[string, *SchemaProxy] `json:"$defs,omitempty" yaml:"$defs,omitempty"`
+
 	// Compatible with all versions
 	Not                  *SchemaProxy                          `json:"not,omitempty" yaml:"not,omitempty"`
 	Properties           *orderedmap.Map[string, *SchemaProxy] `json:"properties,omitempty" yaml:"properties,omitempty"`
@@ -211,12 +226,21 @@ func NewSchema(schema *base.Schema) *Schema {
 			Value:     schema.PropertyNames.Value,
 		})
 	}
+	var unevaluatedItems *DynamicValue[*SchemaProxy, bool]
 	if !schema.UnevaluatedItems.IsEmpty() {
-		s.UnevaluatedItems = NewSchemaProxy(&lowmodel.NodeReference[*base.SchemaProxy]{
-			ValueNode: schema.UnevaluatedItems.ValueNode,
-			Value:     schema.UnevaluatedItems.Value,
-		})
+		if schema.UnevaluatedItems.Value.IsA() {
+			unevaluatedItems = &DynamicValue[*SchemaProxy, bool]{
+				A: NewSchemaProxy(&lowmodel.NodeReference[*base.SchemaProxy]{
+					ValueNode: schema.UnevaluatedItems.ValueNode,
+					Value:     schema.UnevaluatedItems.Value.A,
+					KeyNode:   schema.UnevaluatedItems.KeyNode,
+				}),
+			}
+		} else {
+			unevaluatedItems = &DynamicValue[*SchemaProxy, bool]{N: 1, B: schema.UnevaluatedItems.Value.B}
+		}
 	}
+	s.UnevaluatedItems = unevaluatedItems
 
 	var unevaluatedProperties *DynamicValue[*SchemaProxy, bool]
 	if !schema.UnevaluatedProperties.IsEmpty() {
@@ -306,6 +330,18 @@ func NewSchema(schema *base.Schema) *Schema {
 	if !schema.Anchor.IsEmpty() {
 		s.Anchor = schema.Anchor.Value
 	}
+	if !schema.DynamicAnchor.IsEmpty() {
+		s.DynamicAnchor = schema.DynamicAnchor.Value
+	}
+	if !schema.DynamicRef.IsEmpty() {
+		s.DynamicRef = schema.DynamicRef.Value
+	}
+	if !schema.ContentEncoding.IsEmpty() {
+		s.ContentEncoding = schema.ContentEncoding.Value
+	}
+	if !schema.ContentMediaType.IsEmpty() {
+		s.ContentMediaType = schema.ContentMediaType.Value
+	}
 
 	var enum []*yaml.Node
 	for i := range schema.Enum.Value {
@@ -373,6 +409,8 @@ func NewSchema(schema *base.Schema) *Schema {
 			s.DependentSchemas = props
 		case 2:
 			s.PatternProperties = props
+		case 3:
+			s.Defs = props
 		}
 	}
 
@@ -391,6 +429,11 @@ func NewSchema(schema *base.Schema) *Schema {
 		buildProps(name, schemaProxy, patternProps, 2)
 	}
 
+	defs := orderedmap.New[string, *SchemaProxy]()
+	for name, schemaProxy := range schema.Defs.Value.FromOldest() {
+		buildProps(name, schemaProxy, defs, 3)
+	}
+
 	var allOf []*SchemaProxy
 	var oneOf []*SchemaProxy
 	var anyOf []*SchemaProxy
@@ -0,0 +1,203 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Coercion describes an example or default value that was converted to the type declared by its schema.
+type Coercion struct {
+	Path     string     // the JSON Path to the value, e.g. $.properties['age'].example
+	From     string     // the YAML tag of the original value, e.g. !!str
+	To       string     // the schema type the value was coerced to, e.g. integer
+	Original *yaml.Node // the original value, left untouched.
+	Coerced  *yaml.Node // the value that replaced it in the schema.
+}
+
+// CoercionFailure describes an example or default value that does not match the type declared by its schema,
+// and could not be converted to it.
+type CoercionFailure struct {
+	Path  string     // the JSON Path to the value, e.g. $.properties['age'].default
+	Node  *yaml.Node // the value that could not be coerced.
+	Error error
+}
+
+// CoercionReport is the result of a normalization pass by CoerceValues.
+type CoercionReport struct {
+	Coercions []*Coercion
+	Failures  []*CoercionFailure
+}
+
+// CoerceValues runs a normalization pass over the Schema and all of its sub-schemas (properties, items, prefixItems,
+// additionalProperties, allOf, oneOf, anyOf and $defs), converting scalar example, examples and default values to
+// the type declared by their schema, where the conversion is unambiguous. For example `"42"` becomes `42` for an
+// integer schema, and `true` becomes `"true"` for a string schema.
+//
+// A value is only coerced when the schema declares a single type (a `null` type is ignored), and the value can be
+// converted without loss. Values that can't be converted are left as they are and reported as failures. Coerced
+// values replace the originals in the high-level model, the low-level model is never changed.
+func (s *Schema) CoerceValues() *CoercionReport {
+	report := new(CoercionReport)
+	s.coerceValues("$", report, make(map[any]struct{}))
+	return report
+}
+
+func (s *Schema) coerceValues(path string, report *CoercionReport, seen map[any]struct{}) {
+	if s == nil {
+		return
+	}
+	// schemas are tracked by their node (if they have one), so circular references are only visited once.
+	var key any = s
+	if s.low != nil && s.low.RootNode != nil {
+		key = s.low.RootNode
+	}
+	if _, ok := seen[key]; ok {
+		return
+	}
+	seen[key] = struct{}{}
+
+	if s.Example != nil {
+		s.Example = s.coerceValue(s.Example, path+".example", report)
+	}
+	for i := range s.Examples {
+		s.Examples[i] = s.coerceValue(s.Examples[i], fmt.Sprintf("%s.examples[%d]", path, i), report)
+	}
+	if s.Default != nil {
+		s.Default = s.coerceValue(s.Default, path+".default", report)
+	}
+
+	sub := func(sp *SchemaProxy, p string) {
+		if sp != nil {
+			sp.Schema().coerceValues(p, report, seen)
+		}
+	}
+	for name, sp := range s.Properties.FromOldest() {
+		sub(sp, fmt.Sprintf("%s.properties['%s']", path, name))
+	}
+	for name, sp := range s.Defs.FromOldest() {
+		sub(sp, fmt.Sprintf("%s.$defs['%s']", path, name))
+	}
+	if s.Items != nil && s.Items.IsA() {
+		sub(s.Items.A, path+".items")
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.IsA() {
+		sub(s.AdditionalProperties.A, path+".additionalProperties")
+	}
+	for i, sp := range s.PrefixItems {
+		sub(sp, fmt.Sprintf("%s.prefixItems[%d]", path, i))
+	}
+	for i, sp := range s.AllOf {
+		sub(sp, fmt.Sprintf("%s.allOf[%d]", path, i))
+	}
+	for i, sp := range s.OneOf {
+		sub(sp, fmt.Sprintf("%s.oneOf[%d]", path, i))
+	}
+	for i, sp := range s.AnyOf {
+		sub(sp, fmt.Sprintf("%s.anyOf[%d]", path, i))
+	}
+}
+
+// coercionType returns the single (non-null) type of the schema, or an empty string if there is none.
+func (s *Schema) coercionType() string {
+	var typ string
+	for _, t := range s.Type {
+		if t == "null" {
+			continue
+		}
+		if typ != "" {
+			return ""
+		}
+		typ = t
+	}
+	return typ
+}
+
+func (s *Schema) coerceValue(node *yaml.Node, path string, report *CoercionReport) *yaml.Node {
+	typ := s.coercionType()
+	if typ == "" || node.Kind != yaml.ScalarNode || node.ShortTag() == "!!null" {
+		return node
+	}
+	coerced, err := CoerceScalar(node, typ)
+	if err != nil {
+		report.Failures = append(report.Failures, &CoercionFailure{Path: path, Node: node, Error: err})
+		return node
+	}
+	if coerced != node {
+		report.Coercions = append(report.Coercions, &Coercion{
+			Path: path, From: node.ShortTag(), To: typ, Original: node, Coerced: coerced,
+		})
+	}
+	return coerced
+}
+
+// CoerceScalar converts a scalar node to a JSON Schema type (string, integer, number or boolean). If the node
+// already has the correct type, it is returned as is. Otherwise, a new node is returned, the original is never
+// changed. An error is returned if the value can't be converted without loss (for example 1.5 to an integer).
+// Nodes that are not scalars, and types that are not scalar types, are always returned as is.
+func CoerceScalar(node *yaml.Node, schemaType string) (*yaml.Node, error) {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return node, nil
+	}
+	tag := node.ShortTag()
+	value := strings.TrimSpace(node.Value)
+
+	var coercedTag, coercedValue string
+	switch schemaType {
+	case "string":
+		if tag == "!!str" {
+			return node, nil
+		}
+		coercedTag, coercedValue = "!!str", node.Value
+	case "integer":
+		if tag == "!!int" {
+			return node, nil
+		}
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			coercedTag, coercedValue = "!!int", strconv.FormatInt(i, 10)
+		} else if f, fErr := strconv.ParseFloat(value, 64); fErr == nil && !math.IsInf(f, 0) && f == math.Trunc(f) &&
+			math.Abs(f) < math.MaxInt64 {
+			coercedTag, coercedValue = "!!int", strconv.FormatInt(int64(f), 10)
+		} else {
+			return node, fmt.Errorf("unable to coerce value '%s' to an integer", node.Value)
+		}
+	case "number":
+		if tag == "!!int" || tag == "!!float" {
+			return node, nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return node, fmt.Errorf("unable to coerce value '%s' to a number", node.Value)
+		}
+		coercedTag, coercedValue = "!!float", strconv.FormatFloat(f, 'f', -1, 64)
+		if f == math.Trunc(f) && !strings.ContainsAny(value, ".eE") {
+			coercedTag = "!!int"
+		}
+	case "boolean":
+		if tag == "!!bool" {
+			return node, nil
+		}
+		if value != "true" && value != "false" {
+			return node, fmt.Errorf("unable to coerce value '%s' to a boolean", node.Value)
+		}
+		coercedTag, coercedValue = "!!bool", value
+	default:
+		return node, nil
+	}
+
+	coerced := *node
+	coerced.Tag = coercedTag
+	coerced.Value = coercedValue
+	coerced.Style = 0
+	if coercedTag == "!!str" {
+		// keep the value a string when rendered.
+		coerced.Style = yaml.DoubleQuotedStyle
+	}
+	return &coerced, nil
+}
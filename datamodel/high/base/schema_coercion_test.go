@@ -0,0 +1,138 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCoerceScalar(t *testing.T) {
+	tests := []struct {
+		name       string
+		yml        string
+		schemaType string
+		tag        string
+		value      string
+		err        string
+	}{
+		{"string to integer", `"42"`, "integer", "!!int", "42", ""},
+		{"float to integer", `42.0`, "integer", "!!int", "42", ""},
+		{"integer", `42`, "integer", "!!int", "42", ""},
+		{"bad integer", `"4.2"`, "integer", "", "", "unable to coerce value '4.2' to an integer"},
+		{"infinite integer", `"1e999"`, "integer", "", "", "unable to coerce value '1e999' to an integer"},
+		{"string to number", `"4.2"`, "number", "!!float", "4.2", ""},
+		{"string to whole number", `"4"`, "number", "!!int", "4", ""},
+		{"exponent to number", `"1e3"`, "number", "!!float", "1000", ""},
+		{"number", `4.2`, "number", "!!float", "4.2", ""},
+		{"bad number", `"four"`, "number", "", "", "unable to coerce value 'four' to a number"},
+		{"nan number", `"NaN"`, "number", "", "", "unable to coerce value 'NaN' to a number"},
+		{"string to boolean", `"true"`, "boolean", "!!bool", "true", ""},
+		{"boolean", `false`, "boolean", "!!bool", "false", ""},
+		{"bad boolean", `"yes"`, "boolean", "", "", "unable to coerce value 'yes' to a boolean"},
+		{"integer to string", `42`, "string", "!!str", "42", ""},
+		{"boolean to string", `true`, "string", "!!str", "true", ""},
+		{"string", `hello`, "string", "!!str", "hello", ""},
+		{"object type", `"42"`, "object", "!!str", "42", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var n yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tc.yml), &n))
+			original := *n.Content[0]
+			coerced, err := CoerceScalar(n.Content[0], tc.schemaType)
+			assert.Equal(t, original, *n.Content[0]) // never changed.
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Same(t, n.Content[0], coerced)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.tag, coerced.ShortTag())
+			assert.Equal(t, tc.value, coerced.Value)
+		})
+	}
+
+	c, err := CoerceScalar(nil, "string")
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	c, err = CoerceScalar(seq, "string")
+	assert.NoError(t, err)
+	assert.Same(t, seq, c)
+}
+
+func TestSchema_CoerceValues(t *testing.T) {
+	yml := `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        age:
+          type: integer
+          example: "4"
+          default: "1"
+        weight:
+          type: [number, "null"]
+          examples: ["4.5", 5, null]
+        name:
+          type: string
+          example: 42
+        chipped:
+          type: boolean
+          default: "maybe"
+        tags:
+          type: array
+          items:
+            type: string
+            example: true
+        id:
+          type: [string, integer]
+          example: 42
+        parent:
+          $ref: '#/components/schemas/Pet'`
+
+	var idxNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(yml), &idxNode))
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	petNode := idxNode.Content[0].Content[1].Content[1].Content[1]
+	sp := new(lowbase.SchemaProxy)
+	require.NoError(t, sp.Build(context.Background(), nil, petNode, idx))
+	pet := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: petNode}).Schema()
+
+	report := pet.CoerceValues()
+	require.Len(t, report.Coercions, 5)
+	assert.Equal(t, "$.properties['age'].example", report.Coercions[0].Path)
+	assert.Equal(t, "!!str", report.Coercions[0].From)
+	assert.Equal(t, "integer", report.Coercions[0].To)
+	assert.Equal(t, "$.properties['age'].default", report.Coercions[1].Path)
+	assert.Equal(t, "$.properties['weight'].examples[0]", report.Coercions[2].Path)
+	assert.Equal(t, "$.properties['name'].example", report.Coercions[3].Path)
+	assert.Equal(t, "$.properties['tags'].items.example", report.Coercions[4].Path)
+
+	require.Len(t, report.Failures, 1)
+	assert.Equal(t, "$.properties['chipped'].default", report.Failures[0].Path)
+	assert.EqualError(t, report.Failures[0].Error, "unable to coerce value 'maybe' to a boolean")
+
+	age := pet.Properties.GetOrZero("age").Schema()
+	assert.Same(t, report.Coercions[0].Coerced, age.Example)
+	assert.Equal(t, "!!str", age.GoLow().Example.Value.ShortTag()) // the low-level model is untouched.
+
+	rendered, err := age.Render()
+	require.NoError(t, err)
+	assert.Equal(t, "type: integer\nexample: 4\ndefault: 1\n", string(rendered))
+
+	// a second pass has nothing to do.
+	report = pet.CoerceValues()
+	assert.Empty(t, report.Coercions)
+	assert.Len(t, report.Failures, 1)
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NumericBound is the lowest or highest number allowed by a schema, and whether the bound itself is excluded.
+type NumericBound struct {
+	Value     float64
+	Exclusive bool
+}
+
+// Constraints are the effective constraints of a Schema, once the constraints of every schema it's composed of
+// with allOf are combined. Nil (or empty) fields are not constrained.
+type Constraints struct {
+	Minimum       *NumericBound // the lowest number allowed, `exclusiveMinimum` resolved in (3.0 and 3.1 forms).
+	Maximum       *NumericBound // the highest number allowed, `exclusiveMaximum` resolved in (3.0 and 3.1 forms).
+	MultipleOf    []float64     // numbers must be a multiple of every value.
+	MinLength     *int64
+	MaxLength     *int64
+	Patterns      []string // strings must match every pattern.
+	Formats       []string // every format of the schemas, strings must be valid for each.
+	MinItems      *int64
+	MaxItems      *int64
+	UniqueItems   bool
+	MinProperties *int64
+	MaxProperties *int64
+
+	// Enum holds the values allowed by every enum (and const) of the schemas, in the order of the first enum. It's
+	// nil if none of the schemas has an enum or a const, and empty (but not nil) if no value is allowed by all.
+	Enum []*yaml.Node
+}
+
+// EffectiveConstraints returns the constraints a value must satisfy to be valid for the Schema, combining the
+// constraints of the Schema with those of every schema it's composed of with allOf (nested allOf schemas and
+// references included). The tightest bound wins, so the effective minimum is the highest minimum (exclusive if an
+// exclusive minimum is the highest), the effective maxLength is the lowest maxLength and so on. Patterns, formats
+// and multipleOf values are collected, and enums are intersected (a const is an enum of one value).
+//
+// oneOf and anyOf schemas are not combined, as a value only has to satisfy some of them.
+func (s *Schema) EffectiveConstraints() *Constraints {
+	c := new(Constraints)
+	s.collectConstraints(c, make(map[any]struct{}))
+	return c
+}
+
+func (s *Schema) collectConstraints(c *Constraints, seen map[any]struct{}) {
+	if s == nil {
+		return
+	}
+	var key any = s
+	if s.low != nil && s.low.RootNode != nil {
+		key = s.low.RootNode
+	}
+	if _, ok := seen[key]; ok {
+		return
+	}
+	seen[key] = struct{}{}
+
+	if s.Minimum != nil {
+		c.Minimum = lowerBound(c.Minimum, &NumericBound{*s.Minimum,
+			s.ExclusiveMinimum != nil && s.ExclusiveMinimum.IsA() && s.ExclusiveMinimum.A})
+	}
+	if s.ExclusiveMinimum != nil && s.ExclusiveMinimum.IsB() {
+		c.Minimum = lowerBound(c.Minimum, &NumericBound{s.ExclusiveMinimum.B, true})
+	}
+	if s.Maximum != nil {
+		c.Maximum = upperBound(c.Maximum, &NumericBound{*s.Maximum,
+			s.ExclusiveMaximum != nil && s.ExclusiveMaximum.IsA() && s.ExclusiveMaximum.A})
+	}
+	if s.ExclusiveMaximum != nil && s.ExclusiveMaximum.IsB() {
+		c.Maximum = upperBound(c.Maximum, &NumericBound{s.ExclusiveMaximum.B, true})
+	}
+	if s.MultipleOf != nil && !slices.Contains(c.MultipleOf, *s.MultipleOf) {
+		c.MultipleOf = append(c.MultipleOf, *s.MultipleOf)
+	}
+	c.MinLength = highest(c.MinLength, s.MinLength)
+	c.MaxLength = lowest(c.MaxLength, s.MaxLength)
+	c.MinItems = highest(c.MinItems, s.MinItems)
+	c.MaxItems = lowest(c.MaxItems, s.MaxItems)
+	c.MinProperties = highest(c.MinProperties, s.MinProperties)
+	c.MaxProperties = lowest(c.MaxProperties, s.MaxProperties)
+	if s.Pattern != "" && !slices.Contains(c.Patterns, s.Pattern) {
+		c.Patterns = append(c.Patterns, s.Pattern)
+	}
+	if s.Format != "" && !slices.Contains(c.Formats, s.Format) {
+		c.Formats = append(c.Formats, s.Format)
+	}
+	if s.UniqueItems != nil && *s.UniqueItems {
+		c.UniqueItems = true
+	}
+	if len(s.Enum) > 0 {
+		c.Enum = intersectEnum(c.Enum, s.Enum)
+	}
+	if s.Const != nil {
+		c.Enum = intersectEnum(c.Enum, []*yaml.Node{s.Const})
+	}
+
+	for _, sp := range s.AllOf {
+		if sp != nil {
+			sp.Schema().collectConstraints(c, seen)
+		}
+	}
+}
+
+// Conflicts returns a description of every constraint that can't be satisfied alongside another, such as a minimum
+// above the maximum, or enums without a value in common. A schema with conflicting constraints allows no value.
+func (c *Constraints) Conflicts() []string {
+	var conflicts []string
+	if c.Minimum != nil && c.Maximum != nil && (c.Minimum.Value > c.Maximum.Value ||
+		(c.Minimum.Value == c.Maximum.Value && (c.Minimum.Exclusive || c.Maximum.Exclusive))) {
+		conflicts = append(conflicts, fmt.Sprintf("the minimum %s is not below the maximum %s",
+			c.Minimum, c.Maximum))
+	}
+	limits := func(name string, minimum, maximum *int64) {
+		if minimum != nil && maximum != nil && *minimum > *maximum {
+			conflicts = append(conflicts, fmt.Sprintf("the minimum %s %d is greater than the maximum %s %d",
+				name, *minimum, name, *maximum))
+		}
+	}
+	limits("length", c.MinLength, c.MaxLength)
+	limits("items", c.MinItems, c.MaxItems)
+	limits("properties", c.MinProperties, c.MaxProperties)
+	if c.Enum != nil && len(c.Enum) == 0 {
+		conflicts = append(conflicts, "the enums have no value in common")
+	}
+	return conflicts
+}
+
+// String returns the bound, e.g. `5` or `5 (exclusive)`.
+func (b *NumericBound) String() string {
+	if b.Exclusive {
+		return fmt.Sprintf("%v (exclusive)", b.Value)
+	}
+	return fmt.Sprintf("%v", b.Value)
+}
+
+// lowerBound returns the tighter of two lower bounds.
+func lowerBound(current, bound *NumericBound) *NumericBound {
+	if current == nil || bound.Value > current.Value || (bound.Value == current.Value && bound.Exclusive) {
+		return bound
+	}
+	return current
+}
+
+// upperBound returns the tighter of two upper bounds.
+func upperBound(current, bound *NumericBound) *NumericBound {
+	if current == nil || bound.Value < current.Value || (bound.Value == current.Value && bound.Exclusive) {
+		return bound
+	}
+	return current
+}
+
+func highest(current, value *int64) *int64 {
+	if value != nil && (current == nil || *value > *current) {
+		return value
+	}
+	return current
+}
+
+func lowest(current, value *int64) *int64 {
+	if value != nil && (current == nil || *value < *current) {
+		return value
+	}
+	return current
+}
+
+// intersectEnum returns the values of current also in values, or values if there is no current enum.
+func intersectEnum(current, values []*yaml.Node) []*yaml.Node {
+	if current == nil {
+		return slices.Clone(values)
+	}
+	intersection := make([]*yaml.Node, 0, len(current))
+	for _, v := range current {
+		if slices.ContainsFunc(values, func(e *yaml.Node) bool { return valuesEqual(v, e) }) {
+			intersection = append(intersection, v)
+		}
+	}
+	return intersection
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// constraintsSchema builds the first component schema of a document.
+func constraintsSchema(t *testing.T, yml string) *Schema {
+	info, err := datamodel.ExtractSpecInfo([]byte(yml))
+	require.NoError(t, err)
+	config := index.CreateOpenAPIIndexConfig()
+	config.SpecInfo = info
+	idx := index.NewSpecIndexWithConfig(info.RootNode, config)
+
+	_, components := utils.FindKeyNodeTop("components", info.RootNode.Content[0].Content)
+	schemaNode := components.Content[1].Content[1]
+	sp := new(lowbase.SchemaProxy)
+	require.NoError(t, sp.Build(context.Background(), nil, schemaNode, idx))
+	return NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: schemaNode}).Schema()
+}
+
+func TestSchema_EffectiveConstraints(t *testing.T) {
+	schema := constraintsSchema(t, `openapi: 3.1.0
+components:
+  schemas:
+    Code:
+      type: string
+      minLength: 2
+      maxLength: 10
+      pattern: '^[A-Z]+$'
+      enum: [AB, CD, EF, 12]
+      allOf:
+        - $ref: '#/components/schemas/Short'
+        - allOf:
+            - enum: [CD, EF, 12.0]
+              format: code
+              pattern: '^[A-Z]+$'
+        - $ref: '#/components/schemas/Code'
+    Short:
+      minLength: 1
+      maxLength: 4
+      pattern: '^.{2,}$'`)
+
+	c := schema.EffectiveConstraints()
+	assert.Equal(t, int64(2), *c.MinLength)
+	assert.Equal(t, int64(4), *c.MaxLength)
+	assert.Equal(t, []string{"^[A-Z]+$", "^.{2,}$"}, c.Patterns)
+	assert.Equal(t, []string{"code"}, c.Formats)
+	require.Len(t, c.Enum, 3)
+	assert.Equal(t, "CD", c.Enum[0].Value)
+	assert.Equal(t, "EF", c.Enum[1].Value)
+	assert.Equal(t, "12", c.Enum[2].Value)
+	assert.Nil(t, c.Minimum)
+	assert.Nil(t, c.Maximum)
+	assert.Empty(t, c.Conflicts())
+}
+
+func TestSchema_EffectiveConstraints_Numbers(t *testing.T) {
+	schema := constraintsSchema(t, `openapi: 3.1.0
+components:
+  schemas:
+    Score:
+      type: number
+      minimum: 0
+      maximum: 100
+      multipleOf: 0.5
+      allOf:
+        - exclusiveMinimum: 0
+          maximum: 50
+        - maximum: 50
+          exclusiveMaximum: 50
+        - minimum: -10
+          multipleOf: 2
+          minItems: 1
+          maxItems: 5
+          uniqueItems: true
+        - minItems: 2
+          maxProperties: 3`)
+
+	c := schema.EffectiveConstraints()
+	assert.Equal(t, &NumericBound{Value: 0, Exclusive: true}, c.Minimum)
+	assert.Equal(t, &NumericBound{Value: 50, Exclusive: true}, c.Maximum)
+	assert.Equal(t, "0 (exclusive)", c.Minimum.String())
+	assert.Equal(t, []float64{0.5, 2}, c.MultipleOf)
+	assert.Equal(t, int64(2), *c.MinItems)
+	assert.Equal(t, int64(5), *c.MaxItems)
+	assert.True(t, c.UniqueItems)
+	assert.Nil(t, c.MinProperties)
+	assert.Equal(t, int64(3), *c.MaxProperties)
+	assert.Nil(t, c.Enum)
+	assert.Empty(t, c.Conflicts())
+}
+
+func TestSchema_EffectiveConstraints_BooleanExclusives(t *testing.T) {
+	schema := constraintsSchema(t, `openapi: 3.0.3
+components:
+  schemas:
+    Score:
+      type: number
+      minimum: 1
+      exclusiveMinimum: true
+      maximum: 10
+      allOf:
+        - minimum: 1
+        - maximum: 10
+          exclusiveMaximum: false`)
+
+	c := schema.EffectiveConstraints()
+	assert.Equal(t, &NumericBound{Value: 1, Exclusive: true}, c.Minimum)
+	assert.Equal(t, &NumericBound{Value: 10}, c.Maximum)
+	assert.Equal(t, "10", c.Maximum.String())
+}
+
+func TestSchema_EffectiveConstraints_Conflicts(t *testing.T) {
+	schema := constraintsSchema(t, `openapi: 3.1.0
+components:
+  schemas:
+    Broken:
+      minimum: 10
+      exclusiveMaximum: 10
+      minLength: 5
+      minItems: 3
+      minProperties: 2
+      const: red
+      allOf:
+        - maxLength: 4
+          maxItems: 2
+          maxProperties: 1
+          enum: [blue, green]`)
+
+	c := schema.EffectiveConstraints()
+	assert.NotNil(t, c.Enum)
+	assert.Empty(t, c.Enum)
+	assert.Equal(t, []string{
+		"the minimum 10 is not below the maximum 10 (exclusive)",
+		"the minimum length 5 is greater than the maximum length 4",
+		"the minimum items 3 is greater than the maximum items 2",
+		"the minimum properties 2 is greater than the maximum properties 1",
+		"the enums have no value in common",
+	}, c.Conflicts())
+}
+
+func TestSchema_EffectiveConstraints_Nil(t *testing.T) {
+	var schema *Schema
+	c := schema.EffectiveConstraints()
+	assert.Empty(t, c.Conflicts())
+	assert.Nil(t, c.Enum)
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"errors"
+
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+)
+
+// ResolveDynamicRef will resolve the DynamicRef of the Schema to the schema it points to (3.1+ only). scope is
+// the chain of schemas that were followed to reach this Schema (outermost first), it's used to find the schema
+// that overrides a $dynamicAnchor. See the low-level Schema.ResolveDynamicRef for the resolution rules.
+//
+// The Schema must have been built from a document, an error is returned if it was created programmatically.
+func (s *Schema) ResolveDynamicRef(scope ...*Schema) (*SchemaProxy, error) {
+	if s.low == nil {
+		return nil, errors.New("unable to resolve dynamic reference, schema was not built from a document")
+	}
+	var lowScope []*base.Schema
+	for _, sch := range scope {
+		if sch != nil && sch.low != nil {
+			lowScope = append(lowScope, sch.low)
+		}
+	}
+	sp, err := s.low.ResolveDynamicRef(context.Background(), lowScope...)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchemaProxy(&lowmodel.NodeReference[*base.SchemaProxy]{
+		Value:     sp,
+		ValueNode: sp.GetValueNode(),
+	}), nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_ResolveDynamicRef(t *testing.T) {
+	yml := `components:
+  schemas:
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $dynamicRef: '#node'
+          unevaluatedItems: false
+      $defs:
+        leaf:
+          type: string
+    StrictTree:
+      $dynamicAnchor: node
+      allOf:
+        - $ref: '#/components/schemas/Tree'
+      unevaluatedProperties: false`
+
+	var idxNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(yml), &idxNode))
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	build := func(n *yaml.Node) *Schema {
+		sp := new(lowbase.SchemaProxy)
+		require.NoError(t, sp.Build(context.Background(), nil, n, idx))
+		return NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: n}).Schema()
+	}
+	schemas := idxNode.Content[0].Content[1].Content[1]
+	tree := build(schemas.Content[1])
+	strict := build(schemas.Content[3])
+
+	assert.Equal(t, "node", tree.DynamicAnchor)
+	assert.Equal(t, "string", tree.Defs.GetOrZero("leaf").Schema().Type[0])
+	children := tree.Properties.GetOrZero("children").Schema()
+	assert.False(t, children.UnevaluatedItems.B)
+	items := children.Items.A.Schema()
+	assert.Equal(t, "#node", items.DynamicRef)
+
+	// keywords are rendered, not lost.
+	rendered, err := tree.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "$dynamicAnchor: node")
+	assert.Contains(t, string(rendered), "$dynamicRef: '#node'")
+	assert.Contains(t, string(rendered), "unevaluatedItems: false")
+	assert.True(t, strings.Contains(string(rendered), "$defs:\n    leaf:\n        type: string"))
+
+	sp, err := items.ResolveDynamicRef()
+	require.NoError(t, err)
+	assert.Nil(t, sp.Schema().UnevaluatedProperties)
+
+	sp, err = items.ResolveDynamicRef(strict, nil, tree)
+	require.NoError(t, err)
+	assert.False(t, sp.Schema().UnevaluatedProperties.B)
+
+	_, err = tree.ResolveDynamicRef()
+	assert.Error(t, err)
+
+	_, err = (&Schema{DynamicRef: "#node"}).ResolveDynamicRef()
+	assert.EqualError(t, err, "unable to resolve dynamic reference, schema was not built from a document")
+}
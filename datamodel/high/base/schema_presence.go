@@ -0,0 +1,206 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Presence describes how a missing value can be represented for a property: by leaving the property out (absent),
+// by sending it with a null value, by both or by neither.
+type Presence string
+
+const (
+	// PresenceAbsentOrNull is an optional and nullable property. Absent and null are both valid, and are distinct
+	// states, so serializers must keep them apart (for example to tell "not changed" from "cleared").
+	PresenceAbsentOrNull Presence = "absent-or-null"
+
+	// PresenceNullOnly is a required and nullable property. It is always sent, a missing value is sent as null.
+	PresenceNullOnly Presence = "null-only"
+
+	// PresenceAbsentOnly is an optional property that can't be null. A missing value is sent by leaving the
+	// property out, serializers must not send null.
+	PresenceAbsentOnly Presence = "absent-only"
+
+	// PresenceValueOnly is a required property that can't be null. It is always sent with a value.
+	PresenceValueOnly Presence = "value-only"
+
+	// PresenceAmbiguous is a property where tools disagree on whether absence or null is allowed, the reasons are
+	// listed in PropertyPresence.Reasons.
+	PresenceAmbiguous Presence = "ambiguous"
+)
+
+// PropertyPresence describes whether absence and null are representable for a single property.
+type PropertyPresence struct {
+	Path     string   // the JSON Path to the property, e.g. $.properties['pet'].properties['name']
+	Name     string   // the name of the property.
+	Required bool     // true if the property is in the required list of its parent.
+	Nullable bool     // true if the property allows null.
+	Presence Presence // how a missing value is represented.
+	Reasons  []string // why the property is ambiguous, empty unless Presence is PresenceAmbiguous.
+	Schema   *Schema  // the schema of the property.
+}
+
+// PresenceReport is the result of analyzing a Schema with AnalyzePresence.
+type PresenceReport struct {
+	Properties []*PropertyPresence
+}
+
+// WithPresence returns every property in the report with the supplied presence.
+func (r *PresenceReport) WithPresence(presence Presence) []*PropertyPresence {
+	var props []*PropertyPresence
+	for _, p := range r.Properties {
+		if p.Presence == presence {
+			props = append(props, p)
+		}
+	}
+	return props
+}
+
+// Ambiguous returns every property in the report that is ambiguous.
+func (r *PresenceReport) Ambiguous() []*PropertyPresence {
+	return r.WithPresence(PresenceAmbiguous)
+}
+
+// AnalyzePresence reports, for every property of the Schema and all of its sub-schemas (properties, items,
+// prefixItems, additionalProperties, allOf, oneOf, anyOf and $defs), whether absence and null are both
+// representable, only one of them, or neither.
+//
+// A property allows null when its type includes `null`, it is `nullable` (OpenAPI 3.0), its `const` is null, or one
+// of its oneOf / anyOf schemas is a `null` type. A property is ambiguous when tools are known to disagree on its
+// meaning, for example `nullable` next to a `$ref` (which OpenAPI 3.0 ignores), `nullable` without a type, an enum
+// containing null when the type does not, a nullable schema composing non-nullable schemas with allOf, or a required
+// property that is readOnly or writeOnly (and so is only required in responses or requests).
+func (s *Schema) AnalyzePresence() *PresenceReport {
+	report := new(PresenceReport)
+	s.analyzePresence("$", report, make(map[any]struct{}))
+	return report
+}
+
+func (s *Schema) analyzePresence(path string, report *PresenceReport, seen map[any]struct{}) {
+	if s == nil {
+		return
+	}
+	// schemas are tracked by their node (if they have one), so circular references are only visited once.
+	var key any = s
+	if s.low != nil && s.low.RootNode != nil {
+		key = s.low.RootNode
+	}
+	if _, ok := seen[key]; ok {
+		return
+	}
+	seen[key] = struct{}{}
+
+	for name, sp := range s.Properties.FromOldest() {
+		if sp == nil {
+			continue
+		}
+		p := fmt.Sprintf("%s.properties['%s']", path, name)
+		report.Properties = append(report.Properties, propertyPresence(p, name, slices.Contains(s.Required, name), sp))
+	}
+
+	sub := func(sp *SchemaProxy, p string) {
+		if sp != nil {
+			sp.Schema().analyzePresence(p, report, seen)
+		}
+	}
+	for name, sp := range s.Properties.FromOldest() {
+		sub(sp, fmt.Sprintf("%s.properties['%s']", path, name))
+	}
+	for name, sp := range s.Defs.FromOldest() {
+		sub(sp, fmt.Sprintf("%s.$defs['%s']", path, name))
+	}
+	if s.Items != nil && s.Items.IsA() {
+		sub(s.Items.A, path+".items")
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.IsA() {
+		sub(s.AdditionalProperties.A, path+".additionalProperties")
+	}
+	for i, sp := range s.PrefixItems {
+		sub(sp, fmt.Sprintf("%s.prefixItems[%d]", path, i))
+	}
+	for i, sp := range s.AllOf {
+		sub(sp, fmt.Sprintf("%s.allOf[%d]", path, i))
+	}
+	for i, sp := range s.OneOf {
+		sub(sp, fmt.Sprintf("%s.oneOf[%d]", path, i))
+	}
+	for i, sp := range s.AnyOf {
+		sub(sp, fmt.Sprintf("%s.anyOf[%d]", path, i))
+	}
+}
+
+func propertyPresence(path, name string, required bool, sp *SchemaProxy) *PropertyPresence {
+	prop := &PropertyPresence{Path: path, Name: name, Required: required, Schema: sp.Schema()}
+	var reasons []string
+
+	if sp.IsReference() {
+		if n := sp.GetReferenceNode(); n != nil && n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == "nullable" {
+					reasons = append(reasons, "nullable next to $ref is ignored by OpenAPI 3.0, but not by every tool")
+				}
+			}
+		}
+	}
+
+	s := prop.Schema
+	if s != nil {
+		prop.Nullable = s.allowsNull()
+		if s.Nullable != nil && *s.Nullable && len(s.Type) == 0 {
+			reasons = append(reasons, "nullable without a type has no effect in OpenAPI 3.0")
+		}
+		if !prop.Nullable && slices.ContainsFunc(s.Enum, isNullNode) {
+			reasons = append(reasons, "enum contains null, but the type does not allow null")
+		}
+		if prop.Nullable && slices.ContainsFunc(s.AllOf, func(all *SchemaProxy) bool {
+			return all != nil && all.Schema() != nil && !all.Schema().allowsNull()
+		}) {
+			reasons = append(reasons, "nullable schema composes a non-nullable schema with allOf, which does not allow null")
+		}
+		if required && s.ReadOnly != nil && *s.ReadOnly {
+			reasons = append(reasons, "required and readOnly, the property is only required in responses")
+		}
+		if required && s.WriteOnly != nil && *s.WriteOnly {
+			reasons = append(reasons, "required and writeOnly, the property is only required in requests")
+		}
+	}
+
+	switch {
+	case len(reasons) > 0:
+		prop.Presence = PresenceAmbiguous
+		prop.Reasons = reasons
+	case required && prop.Nullable:
+		prop.Presence = PresenceNullOnly
+	case required:
+		prop.Presence = PresenceValueOnly
+	case prop.Nullable:
+		prop.Presence = PresenceAbsentOrNull
+	default:
+		prop.Presence = PresenceAbsentOnly
+	}
+	return prop
+}
+
+// allowsNull returns true if the schema allows a null value.
+func (s *Schema) allowsNull() bool {
+	if slices.Contains(s.Type, "null") || (s.Nullable != nil && *s.Nullable && len(s.Type) > 0) || isNullNode(s.Const) {
+		return true
+	}
+	isNull := func(sp *SchemaProxy) bool {
+		if sp == nil {
+			return false
+		}
+		sub := sp.Schema()
+		return sub != nil && len(sub.Type) == 1 && sub.Type[0] == "null"
+	}
+	return slices.ContainsFunc(s.OneOf, isNull) || slices.ContainsFunc(s.AnyOf, isNull)
+}
+
+func isNullNode(n *yaml.Node) bool {
+	return n != nil && n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null"
+}
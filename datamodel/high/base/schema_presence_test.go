@@ -0,0 +1,110 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSchema_AnalyzePresence(t *testing.T) {
+	yml := `components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, nickname, owner, createdAt, password]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        nickname:
+          type: [string, "null"]
+        tag:
+          type: string
+          nullable: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+          nullable: true
+        color:
+          nullable: true
+        size:
+          type: string
+          enum: [small, large, null]
+        parent:
+          oneOf:
+            - $ref: '#/components/schemas/Pet'
+            - type: 'null'
+        createdAt:
+          type: string
+          readOnly: true
+        password:
+          type: string
+          writeOnly: true
+        breed:
+          type: object
+          nullable: true
+          allOf:
+            - $ref: '#/components/schemas/Owner'
+          properties:
+            origin:
+              const: null
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	var idxNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(yml), &idxNode))
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	petNode := idxNode.Content[0].Content[1].Content[1].Content[1]
+	sp := new(lowbase.SchemaProxy)
+	require.NoError(t, sp.Build(context.Background(), nil, petNode, idx))
+	pet := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: petNode}).Schema()
+
+	report := pet.AnalyzePresence()
+	presence := make(map[string]*PropertyPresence)
+	for _, p := range report.Properties {
+		presence[p.Path] = p
+	}
+
+	assert.Equal(t, PresenceValueOnly, presence["$.properties['id']"].Presence)
+	assert.Equal(t, PresenceAbsentOnly, presence["$.properties['name']"].Presence)
+	assert.Equal(t, PresenceNullOnly, presence["$.properties['nickname']"].Presence)
+	assert.True(t, presence["$.properties['nickname']"].Required)
+	assert.True(t, presence["$.properties['nickname']"].Nullable)
+	assert.Equal(t, PresenceAbsentOrNull, presence["$.properties['tag']"].Presence)
+	assert.Equal(t, PresenceAbsentOrNull, presence["$.properties['parent']"].Presence)
+	assert.Equal(t, "parent", presence["$.properties['parent']"].Name)
+
+	ambiguous := map[string]string{
+		"$.properties['owner']":     "nullable next to $ref is ignored by OpenAPI 3.0, but not by every tool",
+		"$.properties['color']":     "nullable without a type has no effect in OpenAPI 3.0",
+		"$.properties['size']":      "enum contains null, but the type does not allow null",
+		"$.properties['createdAt']": "required and readOnly, the property is only required in responses",
+		"$.properties['password']":  "required and writeOnly, the property is only required in requests",
+		"$.properties['breed']":     "nullable schema composes a non-nullable schema with allOf, which does not allow null",
+	}
+	assert.Len(t, report.Ambiguous(), len(ambiguous))
+	for path, reason := range ambiguous {
+		require.Contains(t, presence, path)
+		assert.Equal(t, PresenceAmbiguous, presence[path].Presence, path)
+		assert.Equal(t, []string{reason}, presence[path].Reasons, path)
+	}
+
+	// nested properties are reported, and circular references are visited once.
+	assert.Equal(t, PresenceAbsentOrNull, presence["$.properties['breed'].properties['origin']"].Presence)
+	assert.Equal(t, PresenceAbsentOnly, presence["$.properties['owner'].properties['name']"].Presence)
+	assert.Len(t, report.WithPresence(PresenceAbsentOnly), 2)
+	assert.Len(t, report.Properties, 13)
+}
@@ -305,7 +305,7 @@ $schema: https://example.com/custom-json-schema-dialect`
 	assert.Equal(t, "string", compiled.PatternProperties.GetOrZero("patternOne").Schema().Type[0])
 	assert.Equal(t, "string", compiled.DependentSchemas.GetOrZero("schemaOne").Schema().Type[0])
 	assert.Equal(t, "string", compiled.PropertyNames.Schema().Type[0])
-	assert.Equal(t, "boolean", compiled.UnevaluatedItems.Schema().Type[0])
+	assert.Equal(t, "boolean", compiled.UnevaluatedItems.A.Schema().Type[0])
 	assert.Equal(t, "integer", compiled.UnevaluatedProperties.A.Schema().Type[0])
 	assert.True(t, *compiled.ReadOnly)
 	assert.True(t, *compiled.WriteOnly)
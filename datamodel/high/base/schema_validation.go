@@ -0,0 +1,358 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// maxValidationDepth stops validation of schemas that refer to themselves without consuming any of the value, for
+// example a schema that is one of its own allOf schemas.
+const maxValidationDepth = 64
+
+// ValueViolation describes a part of a value that does not match a schema.
+type ValueViolation struct {
+	// Path is the JSON Path to the part of the value that does not match, e.g. $.pets[0].name
+	Path string
+
+	// Message explains why the value does not match.
+	Message string
+
+	// Node is the node of the part of the value that does not match, used for the position.
+	Node *yaml.Node
+}
+
+// ValidateValue validates a value (for example an example, or a default) against the Schema, using JSON Schema
+// semantics, and returns every violation found. An empty result means the value matches.
+//
+// Types (including the OpenAPI 3.0 `nullable`), enum, const, numeric limits (with both the boolean OpenAPI 3.0 and the
+// numeric 3.1 forms of exclusiveMinimum and exclusiveMaximum), string lengths and patterns, array items, prefixItems,
+// contains and limits, object properties, patternProperties, additionalProperties, propertyNames, required and limits,
+// as well as allOf, anyOf, oneOf, not and if / then / else are checked. Formats and unevaluated keywords are not.
+func (s *Schema) ValidateValue(value *yaml.Node) []*ValueViolation {
+	var violations []*ValueViolation
+	s.validateValue(utils.NodeAlias(value), "$", 0, &violations)
+	return violations
+}
+
+func (s *Schema) validateValue(value *yaml.Node, path string, depth int, violations *[]*ValueViolation) {
+	if s == nil || value == nil || depth > maxValidationDepth {
+		return
+	}
+	report := func(format string, args ...any) {
+		*violations = append(*violations, &ValueViolation{Path: path, Message: fmt.Sprintf(format, args...), Node: value})
+	}
+	sub := func(sp *SchemaProxy, v *yaml.Node, p string) {
+		if sp != nil {
+			sp.Schema().validateValue(utils.NodeAlias(v), p, depth+1, violations)
+		}
+	}
+
+	typ := valueType(value)
+	if len(s.Type) > 0 || (s.Nullable != nil && *s.Nullable) {
+		if typ == "null" && s.Nullable != nil && *s.Nullable {
+			return
+		}
+		if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return typeMatches(t, typ) }) {
+			report("expected %s, but got %s", strings.Join(s.Type, " or "), typ)
+			return
+		}
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e *yaml.Node) bool { return valuesEqual(e, value) }) {
+		report("value is not one of the enum values")
+	}
+	if s.Const != nil && !valuesEqual(s.Const, value) {
+		report("value does not match the const value")
+	}
+
+	switch typ {
+	case "integer", "number":
+		s.validateNumber(value, report)
+	case "string":
+		s.validateString(value, report)
+	case "array":
+		s.validateArray(value, path, sub, report)
+	case "object":
+		s.validateObject(value, path, sub, report)
+	}
+
+	for _, sp := range s.AllOf {
+		sub(sp, value, path)
+	}
+	matches := func(sp *SchemaProxy) bool {
+		return sp != nil && len(sp.Schema().validateCopy(value, path, depth+1)) == 0
+	}
+	if len(s.AnyOf) > 0 && !slices.ContainsFunc(s.AnyOf, matches) {
+		report("value does not match any of the anyOf schemas")
+	}
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, sp := range s.OneOf {
+			if matches(sp) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			report("value must match exactly one of the oneOf schemas, but matches %d", matched)
+		}
+	}
+	if s.Not != nil && matches(s.Not) {
+		report("value must not match the not schema")
+	}
+	if s.If != nil {
+		if matches(s.If) {
+			sub(s.Then, value, path)
+		} else {
+			sub(s.Else, value, path)
+		}
+	}
+}
+
+// validateCopy validates the value without reporting anything, returning the violations found.
+func (s *Schema) validateCopy(value *yaml.Node, path string, depth int) []*ValueViolation {
+	var violations []*ValueViolation
+	s.validateValue(value, path, depth, &violations)
+	return violations
+}
+
+func (s *Schema) validateNumber(value *yaml.Node, report func(string, ...any)) {
+	n, err := strconv.ParseFloat(value.Value, 64)
+	if err != nil {
+		return
+	}
+	exclusiveMin := s.ExclusiveMinimum != nil && s.ExclusiveMinimum.IsA() && s.ExclusiveMinimum.A
+	exclusiveMax := s.ExclusiveMaximum != nil && s.ExclusiveMaximum.IsA() && s.ExclusiveMaximum.A
+	if s.Minimum != nil && (n < *s.Minimum || (exclusiveMin && n == *s.Minimum)) {
+		report("value must be %s %v", limitWord(exclusiveMin, "greater than", "at least"), *s.Minimum)
+	}
+	if s.Maximum != nil && (n > *s.Maximum || (exclusiveMax && n == *s.Maximum)) {
+		report("value must be %s %v", limitWord(exclusiveMax, "less than", "at most"), *s.Maximum)
+	}
+	if s.ExclusiveMinimum != nil && s.ExclusiveMinimum.IsB() && n <= s.ExclusiveMinimum.B {
+		report("value must be greater than %v", s.ExclusiveMinimum.B)
+	}
+	if s.ExclusiveMaximum != nil && s.ExclusiveMaximum.IsB() && n >= s.ExclusiveMaximum.B {
+		report("value must be less than %v", s.ExclusiveMaximum.B)
+	}
+	if s.MultipleOf != nil && *s.MultipleOf > 0 {
+		if q := n / *s.MultipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+			report("value must be a multiple of %v", *s.MultipleOf)
+		}
+	}
+}
+
+func limitWord(exclusive bool, exclusiveWord, inclusiveWord string) string {
+	if exclusive {
+		return exclusiveWord
+	}
+	return inclusiveWord
+}
+
+func (s *Schema) validateString(value *yaml.Node, report func(string, ...any)) {
+	length := int64(utf8.RuneCountInString(value.Value))
+	if s.MinLength != nil && length < *s.MinLength {
+		report("value must be at least %d characters long", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		report("value must be at most %d characters long", *s.MaxLength)
+	}
+	if s.Pattern != "" {
+		if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(value.Value) {
+			report("value does not match the pattern '%s'", s.Pattern)
+		}
+	}
+}
+
+func (s *Schema) validateArray(value *yaml.Node, path string, sub func(*SchemaProxy, *yaml.Node, string),
+	report func(string, ...any),
+) {
+	items := value.Content
+	count := int64(len(items))
+	if s.MinItems != nil && count < *s.MinItems {
+		report("array must have at least %d items", *s.MinItems)
+	}
+	if s.MaxItems != nil && count > *s.MaxItems {
+		report("array must have at most %d items", *s.MaxItems)
+	}
+	if s.UniqueItems != nil && *s.UniqueItems {
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if valuesEqual(items[i], items[j]) {
+					report("array items must be unique, items %d and %d are the same", i, j)
+				}
+			}
+		}
+	}
+	for i, item := range items {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i < len(s.PrefixItems):
+			sub(s.PrefixItems[i], item, p)
+		case s.Items != nil && s.Items.IsA():
+			sub(s.Items.A, item, p)
+		case s.Items != nil && s.Items.IsB() && !s.Items.B:
+			report("array must have at most %d items", len(s.PrefixItems))
+		}
+	}
+	if s.Contains != nil {
+		var contained int64
+		for _, item := range items {
+			if len(s.Contains.Schema().validateCopy(utils.NodeAlias(item), path, 0)) == 0 {
+				contained++
+			}
+		}
+		minContains := int64(1)
+		if s.MinContains != nil {
+			minContains = *s.MinContains
+		}
+		if contained < minContains {
+			report("array must contain at least %d items matching the contains schema", minContains)
+		}
+		if s.MaxContains != nil && contained > *s.MaxContains {
+			report("array must contain at most %d items matching the contains schema", *s.MaxContains)
+		}
+	}
+}
+
+func (s *Schema) validateObject(value *yaml.Node, path string, sub func(*SchemaProxy, *yaml.Node, string),
+	report func(string, ...any),
+) {
+	count := int64(len(value.Content) / 2)
+	if s.MinProperties != nil && count < *s.MinProperties {
+		report("object must have at least %d properties", *s.MinProperties)
+	}
+	if s.MaxProperties != nil && count > *s.MaxProperties {
+		report("object must have at most %d properties", *s.MaxProperties)
+	}
+	present := make(map[string]bool, count)
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		present[value.Content[i].Value] = true
+	}
+	for _, r := range s.Required {
+		if !present[r] {
+			report("object is missing the required property '%s'", r)
+		}
+	}
+
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		name, v := value.Content[i].Value, value.Content[i+1]
+		p := fmt.Sprintf("%s.%s", path, name)
+		if !isSimpleName(name) {
+			p = fmt.Sprintf("%s['%s']", path, name)
+		}
+		if s.PropertyNames != nil {
+			nameNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name,
+				Line: value.Content[i].Line, Column: value.Content[i].Column}
+			sub(s.PropertyNames, nameNode, p)
+		}
+		evaluated := false
+		if s.Properties != nil {
+			if sp, ok := s.Properties.Get(name); ok {
+				evaluated = true
+				sub(sp, v, p)
+			}
+		}
+		for pattern, sp := range s.PatternProperties.FromOldest() {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+				evaluated = true
+				sub(sp, v, p)
+			}
+		}
+		if evaluated || s.AdditionalProperties == nil {
+			continue
+		}
+		if s.AdditionalProperties.IsA() {
+			sub(s.AdditionalProperties.A, v, p)
+		} else if !s.AdditionalProperties.B {
+			report("object has the property '%s', which is not allowed", name)
+		}
+	}
+}
+
+func isSimpleName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return true
+}
+
+// valueType returns the JSON Schema type of a node. A whole number is an integer, even when written as a float.
+func valueType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		if f, err := strconv.ParseFloat(n.Value, 64); err == nil && f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "string"
+}
+
+func typeMatches(schemaType, valueType string) bool {
+	return schemaType == valueType || (schemaType == "number" && valueType == "integer")
+}
+
+// valuesEqual compares two nodes as JSON values, so 1 and 1.0 are equal, and the order of object keys is ignored.
+func valuesEqual(a, b *yaml.Node) bool {
+	return reflect.DeepEqual(jsonValue(utils.NodeAlias(a)), jsonValue(utils.NodeAlias(b)))
+}
+
+func jsonValue(n *yaml.Node) any {
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			m[n.Content[i].Value] = jsonValue(utils.NodeAlias(n.Content[i+1]))
+		}
+		return m
+	case yaml.SequenceNode:
+		s := make([]any, len(n.Content))
+		for i := range n.Content {
+			s[i] = jsonValue(utils.NodeAlias(n.Content[i]))
+		}
+		return s
+	}
+	switch valueType(n) {
+	case "null":
+		return nil
+	case "boolean":
+		return n.Value == "true"
+	case "integer", "number":
+		if f, err := strconv.ParseFloat(n.Value, 64); err == nil {
+			return f
+		}
+	}
+	return n.Value
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func buildValidationSchema(t *testing.T, version float32, schema string) *Schema {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(schema), &node))
+	config := index.CreateOpenAPIIndexConfig()
+	config.SpecInfo = &datamodel.SpecInfo{VersionNumeric: version}
+	idx := index.NewSpecIndexWithConfig(&node, config)
+	sp := new(lowbase.SchemaProxy)
+	require.NoError(t, sp.Build(context.Background(), nil, node.Content[0], idx))
+	return NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: node.Content[0]}).Schema()
+}
+
+func TestSchema_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  float32
+		schema   string
+		value    string
+		messages []string
+	}{
+		{"type", 3.1, `type: string`, `42`, []string{"expected string, but got integer"}},
+		{"type list", 3.1, `type: [string, "null"]`, `null`, nil},
+		{"integer is a number", 3.1, `type: number`, `42`, nil},
+		{"whole float is an integer", 3.1, `type: integer`, `42.0`, nil},
+		{"float is not an integer", 3.1, `type: integer`, `4.2`, []string{"expected integer, but got number"}},
+		{"nullable", 3.0, "type: string\nnullable: true", `null`, nil},
+		{"not nullable", 3.0, `type: string`, `null`, []string{"expected string, but got null"}},
+		{"enum", 3.1, `enum: [cat, dog]`, `fish`, []string{"value is not one of the enum values"}},
+		{"enum numbers", 3.1, `enum: [1, 2]`, `2.0`, nil},
+		{"const", 3.1, `const: {a: 1}`, `{a: 2}`, []string{"value does not match the const value"}},
+		{"minimum", 3.1, `minimum: 5`, `4`, []string{"value must be at least 5"}},
+		{"exclusive minimum 3.0", 3.0, "minimum: 5\nexclusiveMinimum: true", `5`, []string{"value must be greater than 5"}},
+		{"exclusive maximum 3.1", 3.1, `exclusiveMaximum: 5`, `5`, []string{"value must be less than 5"}},
+		{"maximum", 3.1, `maximum: 5`, `5`, nil},
+		{"multipleOf", 3.1, `multipleOf: 0.5`, `1.25`, []string{"value must be a multiple of 0.5"}},
+		{"minLength", 3.1, `minLength: 3`, `ab`, []string{"value must be at least 3 characters long"}},
+		{"maxLength", 3.1, `maxLength: 1`, `ü`, nil},
+		{"pattern", 3.1, `pattern: ^[a-z]+$`, `ABC`, []string{"value does not match the pattern '^[a-z]+$'"}},
+		{"items", 3.1, "type: array\nitems:\n  type: integer", `[1, two]`, []string{"expected integer, but got string"}},
+		{"minItems", 3.1, `minItems: 2`, `[1]`, []string{"array must have at least 2 items"}},
+		{"uniqueItems", 3.1, `uniqueItems: true`, `[1, 2, 1]`, []string{"array items must be unique, items 0 and 2 are the same"}},
+		{"prefixItems", 3.1, "prefixItems:\n  - type: string\nitems: false", `[a, b]`, []string{"array must have at most 1 items"}},
+		{"contains", 3.1, "contains:\n  type: string", `[1, 2]`, []string{"array must contain at least 1 items matching the contains schema"}},
+		{"required", 3.1, "required: [name]\nproperties:\n  name:\n    type: string", `{age: 1}`,
+			[]string{"object is missing the required property 'name'"}},
+		{"properties", 3.1, "properties:\n  name:\n    type: string", `{name: 1}`, []string{"expected string, but got integer"}},
+		{"additionalProperties false", 3.1, "properties:\n  name: {}\nadditionalProperties: false", `{name: a, age: 1}`,
+			[]string{"object has the property 'age', which is not allowed"}},
+		{"additionalProperties schema", 3.1, "additionalProperties:\n  type: integer", `{age: old}`,
+			[]string{"expected integer, but got string"}},
+		{"patternProperties", 3.1, "patternProperties:\n  ^x-:\n    type: string\nadditionalProperties: false", `{x-a: b}`, nil},
+		{"propertyNames", 3.1, "propertyNames:\n  maxLength: 2", `{abc: 1}`, []string{"value must be at most 2 characters long"}},
+		{"minProperties", 3.1, `minProperties: 1`, `{}`, []string{"object must have at least 1 properties"}},
+		{"allOf", 3.1, "allOf:\n  - type: string\n  - minLength: 2", `a`, []string{"value must be at least 2 characters long"}},
+		{"anyOf", 3.1, "anyOf:\n  - type: string\n  - type: integer", `true`, []string{"value does not match any of the anyOf schemas"}},
+		{"oneOf", 3.1, "oneOf:\n  - type: number\n  - type: integer", `1`,
+			[]string{"value must match exactly one of the oneOf schemas, but matches 2"}},
+		{"not", 3.1, "not:\n  type: string", `a`, []string{"value must not match the not schema"}},
+		{"if then", 3.1, "if:\n  type: string\nthen:\n  minLength: 2\nelse:\n  minimum: 5", `1`, []string{"value must be at least 5"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schema := buildValidationSchema(t, tc.version, tc.schema)
+			var value yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(tc.value), &value))
+			var messages []string
+			for _, v := range schema.ValidateValue(value.Content[0]) {
+				messages = append(messages, v.Message)
+			}
+			assert.Equal(t, tc.messages, messages)
+		})
+	}
+}
+
+func TestSchema_ValidateValue_Paths(t *testing.T) {
+	schema := buildValidationSchema(t, 3.1, `type: object
+properties:
+  pets:
+    type: array
+    items:
+      type: object
+      properties:
+        name:
+          type: string
+        "the tag":
+          type: string`)
+
+	var value yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("pets:\n  - name: 1\n    the tag: 2"), &value))
+	violations := schema.ValidateValue(value.Content[0])
+	require.Len(t, violations, 2)
+	assert.Equal(t, "$.pets[0].name", violations[0].Path)
+	assert.Equal(t, 2, violations[0].Node.Line)
+	assert.Equal(t, 11, violations[0].Node.Column)
+	assert.Equal(t, "$.pets[0]['the tag']", violations[1].Path)
+}
+
+func TestSchema_ValidateValue_Circular(t *testing.T) {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        child:
+          $ref: '#/components/schemas/Node'`), &node))
+	config := index.CreateOpenAPIIndexConfig()
+	config.SpecInfo = &datamodel.SpecInfo{VersionNumeric: 3.1}
+	idx := index.NewSpecIndexWithConfig(&node, config)
+	schemaNode := node.Content[0].Content[1].Content[1].Content[1]
+	sp := new(lowbase.SchemaProxy)
+	require.NoError(t, sp.Build(context.Background(), nil, schemaNode, idx))
+	schema := NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: sp, ValueNode: schemaNode}).Schema()
+
+	var value yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("child:\n  child:\n    child: 1"), &value))
+	violations := schema.ValidateValue(value.Content[0])
+	require.Len(t, violations, 1)
+	assert.Equal(t, "$.child.child.child", violations[0].Path)
+}
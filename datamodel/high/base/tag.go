@@ -18,8 +18,11 @@ import (
 //   - v3: https://swagger.io/specification/#tag-object
 type Tag struct {
 	Name         string       `json:"name,omitempty" yaml:"name,omitempty"`
+	Summary      string       `json:"summary,omitempty" yaml:"summary,omitempty"` // 3.2
 	Description  string       `json:"description,omitempty" yaml:"description,omitempty"`
 	ExternalDocs *ExternalDoc `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	Parent       string       `json:"parent,omitempty" yaml:"parent,omitempty"` // 3.2
+	Kind         string       `json:"kind,omitempty" yaml:"kind,omitempty"`     // 3.2
 	Extensions   *orderedmap.Map[string, *yaml.Node]
 	low          *low.Tag
 }
@@ -37,6 +40,9 @@ func NewTag(tag *low.Tag) *Tag {
 	if !tag.ExternalDocs.IsEmpty() {
 		t.ExternalDocs = NewExternalDoc(tag.ExternalDocs.Value)
 	}
+	t.Summary = tag.Summary.Value
+	t.Parent = tag.Parent.Value
+	t.Kind = tag.Kind.Value
 	t.Extensions = high.ExtractExtensions(tag.Extensions)
 	return t
 }
@@ -0,0 +1,87 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"slices"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtensionLevel is a level of a document that extensions can be defined at, and cascade from.
+type ExtensionLevel string
+
+const (
+	ExtensionLevelDocument  ExtensionLevel = "document"
+	ExtensionLevelTag       ExtensionLevel = "tag"
+	ExtensionLevelPathItem  ExtensionLevel = "pathItem"
+	ExtensionLevelOperation ExtensionLevel = "operation"
+)
+
+// DefaultExtensionPrecedence is the precedence used when an ExtensionCascade does not define one, the most
+// specific level wins.
+var DefaultExtensionPrecedence = []ExtensionLevel{
+	ExtensionLevelOperation, ExtensionLevelPathItem, ExtensionLevelTag, ExtensionLevelDocument,
+}
+
+// ExtensionCascade configures how extensions cascade from the document and tags down to operations. Some
+// organizations define extensions (like `x-ratelimit-tier`) once at the document or tag level, and expect every
+// operation to inherit them unless it overrides them.
+type ExtensionCascade struct {
+	// Precedence lists the levels extensions are resolved from, highest precedence first. Levels that are not
+	// listed do not cascade at all. If empty, DefaultExtensionPrecedence is used.
+	Precedence []ExtensionLevel
+
+	// Extensions limits cascading to the listed extension keys, if empty every extension cascades.
+	Extensions []string
+}
+
+// ExtensionSource is a set of extensions defined at a level of a document.
+type ExtensionSource struct {
+	Level      ExtensionLevel
+	Name       string // the name of the source, for example the name of the tag, or the path of the path item.
+	Extensions *orderedmap.Map[string, *yaml.Node]
+}
+
+// CascadedExtension is the effective value of an extension, and where it was resolved from.
+type CascadedExtension struct {
+	Value  *yaml.Node
+	Level  ExtensionLevel
+	Source string // the name of the source the value came from, see ExtensionSource.
+}
+
+// Resolve computes the effective extensions from a set of sources. For every extension, the value from the source
+// with the highest precedence level wins. When more than one source exists at the same level (an operation with
+// multiple tags), the first source supplied wins. A nil ExtensionCascade uses the defaults.
+//
+// Extensions are returned in order of precedence: the extensions of the highest level come first.
+func (c *ExtensionCascade) Resolve(sources ...ExtensionSource) *orderedmap.Map[string, *CascadedExtension] {
+	precedence := DefaultExtensionPrecedence
+	var keys []string
+	if c != nil {
+		if len(c.Precedence) > 0 {
+			precedence = c.Precedence
+		}
+		keys = c.Extensions
+	}
+	resolved := orderedmap.New[string, *CascadedExtension]()
+	for _, level := range precedence {
+		for _, source := range sources {
+			if source.Level != level {
+				continue
+			}
+			for key, value := range source.Extensions.FromOldest() {
+				if len(keys) > 0 && !slices.Contains(keys, key) {
+					continue
+				}
+				if _, ok := resolved.Get(key); ok {
+					continue
+				}
+				resolved.Set(key, &CascadedExtension{Value: value, Level: level, Source: source.Name})
+			}
+		}
+	}
+	return resolved
+}
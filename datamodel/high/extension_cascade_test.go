@@ -0,0 +1,81 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func cascadeExtensions(kv ...string) *orderedmap.Map[string, *yaml.Node] {
+	m := orderedmap.New[string, *yaml.Node]()
+	for i := 0; i+1 < len(kv); i += 2 {
+		m.Set(kv[i], &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: kv[i+1]})
+	}
+	return m
+}
+
+func cascadeSources() []ExtensionSource {
+	return []ExtensionSource{
+		{Level: ExtensionLevelDocument, Extensions: cascadeExtensions("x-tier", "doc", "x-owner", "platform")},
+		{Level: ExtensionLevelTag, Name: "pets", Extensions: cascadeExtensions("x-tier", "pets", "x-team", "pets-team")},
+		{Level: ExtensionLevelTag, Name: "store", Extensions: cascadeExtensions("x-team", "store-team")},
+		{Level: ExtensionLevelPathItem, Name: "/pets", Extensions: nil},
+		{Level: ExtensionLevelOperation, Name: "listPets", Extensions: cascadeExtensions("x-internal", "true")},
+	}
+}
+
+func TestExtensionCascade_Resolve_Defaults(t *testing.T) {
+	var c *ExtensionCascade
+	resolved := c.Resolve(cascadeSources()...)
+
+	assert.Equal(t, []string{"x-internal", "x-tier", "x-team", "x-owner"}, slices.Collect(resolved.KeysFromOldest()))
+
+	internal := resolved.GetOrZero("x-internal")
+	assert.Equal(t, ExtensionLevelOperation, internal.Level)
+	assert.Equal(t, "listPets", internal.Source)
+	assert.Equal(t, "true", internal.Value.Value)
+
+	tier := resolved.GetOrZero("x-tier")
+	assert.Equal(t, ExtensionLevelTag, tier.Level)
+	assert.Equal(t, "pets", tier.Source)
+	assert.Equal(t, "pets", tier.Value.Value)
+
+	// the first tag wins.
+	assert.Equal(t, "pets-team", resolved.GetOrZero("x-team").Value.Value)
+
+	owner := resolved.GetOrZero("x-owner")
+	assert.Equal(t, ExtensionLevelDocument, owner.Level)
+	assert.Empty(t, owner.Source)
+}
+
+func TestExtensionCascade_Resolve_Precedence(t *testing.T) {
+	c := &ExtensionCascade{Precedence: []ExtensionLevel{ExtensionLevelDocument, ExtensionLevelTag}}
+	resolved := c.Resolve(cascadeSources()...)
+
+	assert.Equal(t, []string{"x-tier", "x-owner", "x-team"}, slices.Collect(resolved.KeysFromOldest()))
+	assert.Equal(t, "doc", resolved.GetOrZero("x-tier").Value.Value)
+	assert.Equal(t, ExtensionLevelDocument, resolved.GetOrZero("x-tier").Level)
+
+	// operation extensions are not part of the precedence, so they are not resolved.
+	_, ok := resolved.Get("x-internal")
+	assert.False(t, ok)
+}
+
+func TestExtensionCascade_Resolve_Filter(t *testing.T) {
+	c := &ExtensionCascade{Extensions: []string{"x-tier", "x-missing"}}
+	resolved := c.Resolve(cascadeSources()...)
+
+	assert.Equal(t, 1, resolved.Len())
+	assert.Equal(t, "pets", resolved.GetOrZero("x-tier").Value.Value)
+}
+
+func TestExtensionCascade_Resolve_NoSources(t *testing.T) {
+	c := new(ExtensionCascade)
+	assert.Equal(t, 0, c.Resolve().Len())
+}
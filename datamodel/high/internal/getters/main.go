@@ -0,0 +1,293 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Getters generates the nil-safe accessors of the high-level models of a package: a GetX method for every exported
+// field X of every model (every struct with a GoLow method), returning the zero value of the field when the model
+// is nil. Calls can then be chained through optional objects without checking each for nil, such as
+// op.GetRequestBody().GetContent(). Fields that already have a GetX method are left alone.
+//
+// It's run by go generate, in the directory of the package, and writes getters.go:
+//
+//	//go:generate go run ../internal/getters
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// output is the file written in the directory of the package.
+const output = "getters.go"
+
+func main() {
+	src, err := generate(".")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(output, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+type model struct {
+	name       string
+	receiver   string
+	typeParams []string
+	fields     []*ast.Field
+	file       *ast.File
+}
+
+// generate returns the source of the getters of the package in dir.
+func generate(dir string) ([]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var pkg string
+	structs := make(map[string]*model)
+	methods := make(map[string]map[string]bool)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == output {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		pkg = f.Name.Name
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || !ts.Name.IsExported() {
+						continue
+					}
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					m := &model{name: ts.Name.Name, fields: st.Fields.List, file: f}
+					if ts.TypeParams != nil {
+						for _, p := range ts.TypeParams.List {
+							for _, n := range p.Names {
+								m.typeParams = append(m.typeParams, n.Name)
+							}
+						}
+					}
+					structs[m.name] = m
+				}
+			case *ast.FuncDecl:
+				if d.Recv == nil || len(d.Recv.List) == 0 {
+					continue
+				}
+				recv, typeName := receiverOf(d.Recv.List[0])
+				if methods[typeName] == nil {
+					methods[typeName] = make(map[string]bool)
+				}
+				methods[typeName][d.Name.Name] = true
+				if d.Name.Name == "GoLow" {
+					methods[typeName]["receiver:"+recv] = true
+				}
+			}
+		}
+	}
+
+	var names []string
+	for name := range structs {
+		if methods[name]["GoLow"] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var body bytes.Buffer
+	imports := make(map[string]string)
+	for _, name := range names {
+		m := structs[name]
+		for k := range methods[name] {
+			if r, ok := strings.CutPrefix(k, "receiver:"); ok {
+				m.receiver = r
+			}
+		}
+		if m.receiver == "" || m.receiver == "_" {
+			m.receiver = strings.ToLower(name[:1])
+		}
+		for _, field := range m.fields {
+			for _, n := range field.Names {
+				getter := "Get" + n.Name
+				if !n.IsExported() || methods[name][getter] || hasField(m, getter) {
+					continue
+				}
+				if err = collectImports(m.file, field.Type, imports); err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", name, n.Name, err)
+				}
+				writeGetter(&body, fset, m, n.Name, field.Type)
+			}
+		}
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley\n")
+	src.WriteString("// SPDX-License-Identifier: MIT\n\n")
+	src.WriteString("// Code generated by go run ../internal/getters; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+	if len(imports) > 0 {
+		var paths []string
+		for p := range imports {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		src.WriteString("import (\n")
+		for _, p := range paths {
+			if imports[p] == packageName(p) {
+				fmt.Fprintf(&src, "\t%s\n", strconv.Quote(p))
+			} else {
+				fmt.Fprintf(&src, "\t%s %s\n", imports[p], strconv.Quote(p))
+			}
+		}
+		src.WriteString(")\n\n")
+	}
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// receiverOf returns the name of a receiver, and the name of its type.
+func receiverOf(field *ast.Field) (string, string) {
+	recv := ""
+	if len(field.Names) > 0 {
+		recv = field.Names[0].Name
+	}
+	t := field.Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	switch x := t.(type) {
+	case *ast.IndexExpr:
+		t = x.X
+	case *ast.IndexListExpr:
+		t = x.X
+	}
+	if ident, ok := t.(*ast.Ident); ok {
+		return recv, ident.Name
+	}
+	return recv, ""
+}
+
+func hasField(m *model, name string) bool {
+	for _, f := range m.fields {
+		for _, n := range f.Names {
+			if n.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectImports adds the imports of the packages a field type uses, named as they are first named in a file of the
+// package. A package named differently in the file of the model is renamed in the field type.
+func collectImports(file *ast.File, expr ast.Expr, imports map[string]string) error {
+	var err error
+	ast.Inspect(expr, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := packageName(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name != ident.Name {
+				continue
+			}
+			if existing, ok := imports[path]; ok {
+				ident.Name = existing
+				return false
+			}
+			for p, n := range imports {
+				if n == name {
+					err = fmt.Errorf("both %s and %s are imported as %s", p, path, name)
+				}
+			}
+			imports[path] = name
+			return false
+		}
+		err = fmt.Errorf("the import of package %s is not found", ident.Name)
+		return false
+	})
+	return err
+}
+
+// packageName returns the name of the package at an import path, its last element without a version suffix.
+func packageName(path string) string {
+	name := path[strings.LastIndex(path, "/")+1:]
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	return strings.ReplaceAll(name, "-", "")
+}
+
+func writeGetter(b *bytes.Buffer, fset *token.FileSet, m *model, field string, expr ast.Expr) {
+	var t bytes.Buffer
+	_ = printer.Fprint(&t, fset, expr)
+	recvType := m.name
+	if len(m.typeParams) > 0 {
+		recvType += "[" + strings.Join(m.typeParams, ", ") + "]"
+	}
+	fmt.Fprintf(b, "// Get%s returns the %s of the %s, or the zero value if the %s is nil.\n",
+		field, field, m.name, m.name)
+	fmt.Fprintf(b, "func (%s *%s) Get%s() %s {\n", m.receiver, recvType, field, t.String())
+	fmt.Fprintf(b, "\tif %s == nil {\n", m.receiver)
+	if zero := zeroValue(expr); zero != "" {
+		fmt.Fprintf(b, "\t\treturn %s\n", zero)
+	} else {
+		fmt.Fprintf(b, "\t\tvar zero %s\n\t\treturn zero\n", t.String())
+	}
+	fmt.Fprintf(b, "\t}\n\treturn %s.%s\n}\n\n", m.receiver, field)
+}
+
+// zeroValue returns the literal zero value of a type, or an empty string if it has none.
+func zeroValue(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr, *ast.MapType, *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return "nil"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil"
+		}
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "any", "error":
+			return "nil"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"float32", "float64", "byte", "rune":
+			return "0"
+		}
+	}
+	return ""
+}
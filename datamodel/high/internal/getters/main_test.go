@@ -0,0 +1,86 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	for _, pkg := range []string{"base", "v2", "v3", "arazzo"} {
+		dir := filepath.Join("..", "..", pkg)
+		src, err := generate(dir)
+		require.NoError(t, err)
+		existing, err := os.ReadFile(filepath.Join(dir, output))
+		require.NoError(t, err)
+		assert.Equal(t, string(src), string(existing), "%s/%s is stale, run go generate", pkg, output)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.go"), []byte(`package model
+
+import (
+	lowmodel "example.com/low"
+	"gopkg.in/yaml.v3"
+)
+
+type Thing[T any] struct {
+	Name    string
+	Count   int
+	Value   T
+	Node    *yaml.Node
+	Tags    []string
+	Other   lowmodel.Thing
+	hidden  bool
+	low     *lowmodel.Thing
+}
+
+func (t *Thing[T]) GoLow() *lowmodel.Thing { return t.low }
+
+func (t *Thing[T]) GetName() string { return "custom" }
+
+type NotAModel struct {
+	Name string
+}
+`), 0o644))
+
+	src, err := generate(dir)
+	require.NoError(t, err)
+	code := string(src)
+	assert.Contains(t, code, "// Code generated by go run ../internal/getters; DO NOT EDIT.")
+	assert.Contains(t, code, `lowmodel "example.com/low"`)
+	assert.Contains(t, code, "\t\"gopkg.in/yaml.v3\"")
+	assert.Contains(t, code, "func (t *Thing[T]) GetCount() int {\n\tif t == nil {\n\t\treturn 0\n")
+	assert.Contains(t, code, "func (t *Thing[T]) GetValue() T {\n\tif t == nil {\n\t\tvar zero T\n\t\treturn zero\n")
+	assert.Contains(t, code, "func (t *Thing[T]) GetNode() *yaml.Node {")
+	assert.Contains(t, code, "func (t *Thing[T]) GetTags() []string {")
+	assert.Contains(t, code, "func (t *Thing[T]) GetOther() lowmodel.Thing {")
+	assert.NotContains(t, code, "GetName")
+	assert.NotContains(t, code, "GetHidden")
+	assert.NotContains(t, code, "NotAModel")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	_, err := generate(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.go"), []byte(`package model
+
+type Thing struct {
+	Node *yaml.Node
+}
+
+func (t *Thing) GoLow() any { return nil }
+`), 0o644))
+	_, err = generate(dir)
+	assert.EqualError(t, err, "Thing.Node: the import of package yaml is not found")
+}
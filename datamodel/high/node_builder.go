@@ -50,9 +50,28 @@ func NewNodeBuilder(high any, low any) *NodeBuilder {
 	for i := 0; i < num; i++ {
 		nb.add(v.Type().Field(i).Name, i)
 	}
+	nb.addUnknownFields()
 	return nb
 }
 
+// addUnknownFields adds the keys the low level object did not recognize, so they are rendered back out where they
+// were found, as they were found.
+func (n *NodeBuilder) addUnknownFields() {
+	if n.Low == nil || reflect.ValueOf(n.Low).IsZero() {
+		return
+	}
+	u, ok := n.Low.(low.HasUnknownFields)
+	if !ok {
+		return
+	}
+	for k, v := range u.GetUnknownFields().FromOldest() {
+		n.Nodes = append(n.Nodes, &nodes.NodeEntry{
+			Tag: k.Value, Key: k.Value, Value: v.Value, Line: k.KeyNode.Line,
+			KeyNode: k.KeyNode, ValueNode: v.ValueNode, LowValue: v,
+		})
+	}
+}
+
 func (n *NodeBuilder) add(key string, i int) {
 	// only operate on exported fields.
 	if unicode.IsLower(rune(key[0])) {
@@ -89,8 +108,11 @@ func (n *NodeBuilder) add(key string, i int) {
 			nodeEntry := &nodes.NodeEntry{Tag: ext, Key: ext, Value: node, Line: j}
 
 			if lowExtensions != nil {
-				lowItem := low.FindItemInOrderedMap(ext, lowExtensions)
+				lowKey, lowItem := low.FindItemInOrderedMapWithKey(ext, lowExtensions)
 				nodeEntry.LowValue = lowItem
+				if lowKey != nil {
+					nodeEntry.KeyNode, nodeEntry.ValueNode = lowKey.KeyNode, lowItem.ValueNode
+				}
 			}
 			n.Nodes = append(n.Nodes, nodeEntry)
 			j++
@@ -179,6 +201,12 @@ func (n *NodeBuilder) add(key string, i int) {
 		value = reflect.ValueOf(fLow)
 
 		nodeEntry.LowValue = fLow
+		if kn, ok := fLow.(low.HasKeyNode); ok {
+			nodeEntry.KeyNode = kn.GetKeyNode()
+		}
+		if vn, ok := fLow.(low.HasValueNodeUntyped); ok {
+			nodeEntry.ValueNode = vn.GetValueNode()
+		}
 		switch value.Kind() {
 
 		case reflect.Slice:
@@ -543,6 +571,11 @@ func (n *NodeBuilder) AddYAMLNode(parent *yaml.Node, entry *nodes.NodeEntry) *ya
 	if valueNode == nil {
 		return parent
 	}
+	// carry comments over from the original nodes, so they survive the model being mutated and rendered again.
+	low.CopyComments(l, entry.KeyNode)
+	if entry.ValueNode != nil && valueNode.Kind == entry.ValueNode.Kind {
+		low.CopyComments(valueNode, entry.ValueNode)
+	}
 	if l != nil {
 		parent.Content = append(parent.Content, l, valueNode)
 	} else {
@@ -13,4 +13,8 @@ type NodeEntry struct {
 	// ValueStyle  yaml.Style
 	RenderZero bool
 	LowValue   any
+	// KeyNode and ValueNode are the original nodes of the entry (if there are any), their comments are
+	// carried over to the rendered nodes.
+	KeyNode   *yaml.Node
+	ValueNode *yaml.Node
 }
@@ -0,0 +1,74 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"strconv"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// PreserveNumberFormatting walks a rendered node tree alongside the source it was originally built from. Any number
+// in the rendered tree that has the same value as the number at the same location in the source, takes the original
+// text of the source number (so `1.0` is not rendered as `1`, and `2.50` is not rendered as `2.5`). Numbers that
+// have been changed in the model, or that no longer exist in the source, are left as they were rendered.
+//
+// The rendered tree is updated in place, the source is never changed.
+func PreserveNumberFormatting(rendered, source *yaml.Node) {
+	preserveNumberFormatting(rendered, source, make(map[*yaml.Node]struct{}))
+}
+
+func preserveNumberFormatting(rendered, source *yaml.Node, seen map[*yaml.Node]struct{}) {
+	if rendered == nil || source == nil || rendered == source {
+		return
+	}
+	if _, ok := seen[rendered]; ok {
+		return
+	}
+	seen[rendered] = struct{}{}
+	if rendered.Kind == yaml.DocumentNode && len(rendered.Content) > 0 {
+		rendered = rendered.Content[0]
+	}
+	if source.Kind == yaml.DocumentNode && len(source.Content) > 0 {
+		source = source.Content[0]
+	}
+	source = utils.NodeAlias(source)
+
+	switch rendered.Kind {
+	case yaml.MappingNode:
+		if source.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(rendered.Content); i += 2 {
+			for j := 0; j+1 < len(source.Content); j += 2 {
+				if rendered.Content[i].Value == source.Content[j].Value {
+					preserveNumberFormatting(rendered.Content[i+1], source.Content[j+1], seen)
+					break
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		if source.Kind != yaml.SequenceNode {
+			return
+		}
+		for i := 0; i < len(rendered.Content) && i < len(source.Content); i++ {
+			preserveNumberFormatting(rendered.Content[i], source.Content[i], seen)
+		}
+	case yaml.ScalarNode:
+		if !isNumberNode(rendered) || !isNumberNode(source) || rendered.Value == source.Value {
+			return
+		}
+		r, rErr := strconv.ParseFloat(rendered.Value, 64)
+		s, sErr := strconv.ParseFloat(source.Value, 64)
+		if rErr == nil && sErr == nil && r == s {
+			rendered.Value = source.Value
+			rendered.Tag = source.Tag
+		}
+	}
+}
+
+func isNumberNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && (node.ShortTag() == "!!int" || node.ShortTag() == "!!float")
+}
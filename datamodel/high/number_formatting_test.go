@@ -0,0 +1,59 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPreserveNumberFormatting(t *testing.T) {
+	var source, rendered yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`a: 1.0
+b: 2.50
+c: 3.0
+d: [1.50, 2]
+e: 1e3
+f: "1.0"
+g:
+  h: 0x10
+`), &source))
+	require.NoError(t, yaml.Unmarshal([]byte(`a: 1
+b: 2.5
+c: 4
+d: [1.5, 2, 3.0]
+e: 1000
+f: 1
+g:
+  h: 16
+new: 5
+`), &rendered))
+
+	PreserveNumberFormatting(&rendered, &source)
+
+	out, err := yaml.Marshal(&rendered)
+	require.NoError(t, err)
+	assert.Equal(t, `a: 1.0
+b: 2.50
+c: 4
+d: [1.50, 2, 3.0]
+e: 1e3
+f: 1
+g:
+    h: 16
+new: 5
+`, string(out))
+
+	// the source is never changed.
+	out, err = yaml.Marshal(&source)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "h: 0x10")
+
+	// mismatched shapes are ignored.
+	PreserveNumberFormatting(&rendered, &yaml.Node{Kind: yaml.SequenceNode})
+	PreserveNumberFormatting(nil, &source)
+}
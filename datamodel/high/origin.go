@@ -0,0 +1,42 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"reflect"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// FindOrigin returns where a high-level object was defined: the absolute path (or URL) of the file of the rolodex
+// the object was read from, and the line and column it starts at (NodeOrigin.EndLine is the last line it spans).
+// An object defined by a reference is located where the reference points, not where the $ref is, so errors
+// reported about objects of a multi-file specification point at the right file.
+//
+// Any high-level object can be located, as long as its low-level model records the node it was built from (see
+// low.HasRootNode). nil is returned if it doesn't, or if the node isn't in any file of the rolodex.
+func FindOrigin(rolodex *index.Rolodex, object GoesLowUntyped) *index.NodeOrigin {
+	if rolodex == nil || isNilValue(object) {
+		return nil
+	}
+	l, ok := object.GoLowUntyped().(low.HasRootNode)
+	if !ok || isNilValue(l) {
+		return nil
+	}
+	root := utils.NodeAlias(l.GetRootNode())
+	if root == nil {
+		return nil
+	}
+	return rolodex.FindNodeOrigin(root)
+}
+
+func isNilValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type originLow struct {
+	root *yaml.Node
+}
+
+func (o *originLow) GetRootNode() *yaml.Node {
+	return o.root
+}
+
+type originHigh struct {
+	low any
+}
+
+func (o *originHigh) GoLowUntyped() any {
+	return o.low
+}
+
+func TestFindOrigin(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`info:
+  title: origins`), &root)
+	config := index.CreateOpenAPIIndexConfig()
+	config.SpecAbsolutePath = "/specs/origins.yaml"
+	rolodex := index.NewRolodex(config)
+	rolodex.SetRootNode(&root)
+	_ = rolodex.IndexTheRolodex()
+
+	info := root.Content[0].Content[1]
+	origin := FindOrigin(rolodex, &originHigh{low: &originLow{root: info}})
+	if assert.NotNil(t, origin) {
+		assert.Equal(t, "/specs/origins.yaml", origin.AbsoluteLocation)
+		assert.Equal(t, 2, origin.Line)
+		assert.Equal(t, 3, origin.Column)
+		assert.Equal(t, 2, origin.EndLine())
+	}
+
+	var missing *originLow
+	var none *originHigh
+	assert.Nil(t, FindOrigin(nil, &originHigh{low: &originLow{root: info}}))
+	assert.Nil(t, FindOrigin(rolodex, nil))
+	assert.Nil(t, FindOrigin(rolodex, none))
+	assert.Nil(t, FindOrigin(rolodex, &originHigh{low: missing}))
+	assert.Nil(t, FindOrigin(rolodex, &originHigh{low: &originLow{}}))
+	assert.Nil(t, FindOrigin(rolodex, &originHigh{low: "no root node"}))
+	assert.Nil(t, FindOrigin(rolodex, &originHigh{low: &originLow{root: &yaml.Node{Line: 99, Column: 1}}}))
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ResolvePointer walks a high-level model using a JSON Pointer (RFC 6901), for example `/paths/~1pets/get`, and
+// returns the model object found at that location (in this case a *v3.Operation).
+//
+// The model is walked using the `yaml` tags of each high-level object. Maps without a tag (such as path items or
+// response codes) are looked up by key, `x-` segments are looked up in extensions, schema proxies are built and
+// dynamic values are followed to whichever value is set. When the pointer reaches a raw *yaml.Node (for example an
+// extension or an example), the remaining segments are resolved against that node and the *yaml.Node is returned.
+func ResolvePointer(model any, pointer string) (any, error) {
+	segments, err := utils.ParseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(model)
+	for i, seg := range segments {
+		if node, ok := asYAMLNode(v); ok {
+			return utils.FindNodeByJSONPointerSegments(node, segments[i:])
+		}
+		next, found := resolvePointerSegment(v, seg)
+		if !found || isEmptyPointerValue(next) {
+			return nil, fmt.Errorf("unable to resolve json pointer '%s': segment '%s' not found",
+				utils.BuildJSONPointer(segments[:i+1]), seg)
+		}
+		v = next
+	}
+	return v.Interface(), nil
+}
+
+// isEmptyPointerValue returns true for values that exist in the model, but were not set in the specification.
+func isEmptyPointerValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+func asYAMLNode(v reflect.Value) (*yaml.Node, bool) {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil, false
+	}
+	n, ok := v.Interface().(*yaml.Node)
+	return n, ok && n != nil
+}
+
+// followPointerValue unwraps interfaces, builds schema proxies and picks the set side of dynamic values.
+func followPointerValue(v reflect.Value) reflect.Value {
+	for v.IsValid() {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return reflect.Value{}
+		}
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+			continue
+		}
+		if m := v.MethodByName("Schema"); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+			v = m.Call(nil)[0]
+			continue
+		}
+		if m := v.MethodByName("IsA"); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+			s := reflect.Indirect(v)
+			if m.Call(nil)[0].Bool() {
+				v = s.FieldByName("A")
+			} else {
+				v = s.FieldByName("B")
+			}
+			continue
+		}
+		break
+	}
+	return v
+}
+
+func resolvePointerSegment(v reflect.Value, seg string) (reflect.Value, bool) {
+	v = followPointerValue(v)
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	if next, ok := lookupMap(v, seg); ok {
+		return next, true
+	}
+	s := reflect.Indirect(v)
+	switch s.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i >= s.Len() {
+			return reflect.Value{}, false
+		}
+		return s.Index(i), true
+	case reflect.Map:
+		if s.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		next := s.MapIndex(reflect.ValueOf(seg).Convert(s.Type().Key()))
+		return next, next.IsValid()
+	case reflect.Struct:
+		return lookupStructField(s, seg)
+	}
+	return reflect.Value{}, false
+}
+
+// lookupMap looks up a key in an orderedmap.Map (or anything else with a `Get(string) (V, bool)` method).
+func lookupMap(v reflect.Value, key string) (reflect.Value, bool) {
+	m := v.MethodByName("Get")
+	if !m.IsValid() || m.Type().NumIn() != 1 || m.Type().NumOut() != 2 || m.Type().In(0).Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return reflect.Value{}, false
+	}
+	out := m.Call([]reflect.Value{reflect.ValueOf(key).Convert(m.Type().In(0))})
+	return out[0], out[1].Bool()
+}
+
+func lookupStructField(s reflect.Value, seg string) (reflect.Value, bool) {
+	t := s.Type()
+	var untagged []reflect.Value
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		switch {
+		case f.Name == "Extensions":
+			if strings.HasPrefix(seg, "x-") {
+				if next, ok := lookupMap(s.Field(i), seg); ok {
+					return next, true
+				}
+			}
+		case name == seg:
+			return s.Field(i), true
+		case name == "-" || name == "":
+			untagged = append(untagged, s.Field(i))
+		}
+	}
+
+	// anything not matched by a tag may live in an untagged map (path items, response codes, scopes etc.)
+	for _, f := range untagged {
+		if next, ok := lookupMap(f, seg); ok {
+			return next, true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,445 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SequenceStyle controls how sequences are rendered.
+type SequenceStyle int
+
+const (
+	// SequencePreserve renders sequences the way the model renders them (block, unless the original was flow).
+	SequencePreserve SequenceStyle = iota
+
+	// SequenceBlock renders every sequence as a block, one item per line.
+	SequenceBlock
+
+	// SequenceFlow renders every sequence in flow style, e.g. [a, b, c]
+	SequenceFlow
+
+	// SequenceFlowScalars renders sequences of scalars (like `required` or `enum`) in flow style, and everything
+	// else as a block.
+	SequenceFlowScalars
+)
+
+// QuoteStyle controls how string values are quoted.
+type QuoteStyle int
+
+const (
+	// QuotePreserve quotes string values the way the model renders them.
+	QuotePreserve QuoteStyle = iota
+
+	// QuoteMinimal only quotes string values that would not be strings without quotes (like '200' or 'true').
+	QuoteMinimal
+
+	// QuoteSingle quotes every string value with single quotes.
+	QuoteSingle
+
+	// QuoteDouble quotes every string value with double quotes.
+	QuoteDouble
+)
+
+// KeyOrder controls the order keys are rendered in.
+type KeyOrder int
+
+const (
+	// KeyOrderOriginal renders keys in the order they were found in the original document, new keys first.
+	KeyOrderOriginal KeyOrder = iota
+
+	// KeyOrderAlphabetical sorts the keys of every mapping alphabetically.
+	KeyOrderAlphabetical
+
+	// KeyOrderCanonical renders the keys of every OpenAPI object in the order they are defined in the
+	// specification (openapi, info, jsonSchemaDialect, servers, paths...). Keys that are not defined by the
+	// specification (extensions) are rendered after them, in their original order. The order of names in a map
+	// (paths, properties, schemas and so on) is never changed, neither is anything inside an example, default or
+	// extension value.
+	KeyOrderCanonical
+)
+
+// RenderConfig configures the style of rendered YAML, so rendered output matches formatting conventions.
+// The zero value renders output the same way as Render.
+type RenderConfig struct {
+	Indent        int // the number of spaces used to indent, defaults to 4 (the same as Render).
+	SequenceStyle SequenceStyle
+	QuoteStyle    QuoteStyle
+	KeyOrder      KeyOrder
+}
+
+// Encode applies the configuration to a rendered node (such as the result of MarshalYAML) and encodes it to YAML.
+// The node is copied before it is changed, nodes that belong to a model are never modified.
+func (c *RenderConfig) Encode(node *yaml.Node) ([]byte, error) {
+	if c == nil {
+		c = new(RenderConfig)
+	}
+	styled := c.Apply(node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	if c.Indent > 0 {
+		enc.SetIndent(c.Indent)
+	} else {
+		enc.SetIndent(4)
+	}
+	if err := enc.Encode(styled); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Apply returns a copy of node with the sequence style, quote style and key order of the configuration applied.
+func (c *RenderConfig) Apply(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	n := copyRenderNode(node, make(map[*yaml.Node]*yaml.Node))
+	root := n
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	switch c.KeyOrder {
+	case KeyOrderAlphabetical:
+		sortKeysAlphabetically(root, make(map[*yaml.Node]struct{}))
+	case KeyOrderCanonical:
+		rootType := "openapi"
+		if _, v := utils.FindKeyNodeTop("swagger", root.Content); v != nil {
+			rootType = "swagger"
+		}
+		sortKeysCanonically(root, rootType, make(map[*yaml.Node]struct{}))
+	}
+	c.applyStyles(root, make(map[*yaml.Node]struct{}))
+	return n
+}
+
+func copyRenderNode(node *yaml.Node, copied map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if c, ok := copied[node]; ok {
+		return c
+	}
+	c := *node
+	copied[node] = &c
+	c.Alias = copyRenderNode(node.Alias, copied)
+	if len(node.Content) > 0 {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i := range node.Content {
+			c.Content[i] = copyRenderNode(node.Content[i], copied)
+		}
+	}
+	return &c
+}
+
+func (c *RenderConfig) applyStyles(node *yaml.Node, seen map[*yaml.Node]struct{}) {
+	if _, ok := seen[node]; ok {
+		return
+	}
+	seen[node] = struct{}{}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		switch c.SequenceStyle {
+		case SequenceBlock:
+			node.Style &^= yaml.FlowStyle
+		case SequenceFlow:
+			node.Style |= yaml.FlowStyle
+		case SequenceFlowScalars:
+			scalars := len(node.Content) > 0
+			for _, item := range node.Content {
+				if item.Kind != yaml.ScalarNode || strings.Contains(item.Value, "\n") {
+					scalars = false
+					break
+				}
+			}
+			if scalars {
+				node.Style |= yaml.FlowStyle
+			} else {
+				node.Style &^= yaml.FlowStyle
+			}
+		}
+		for _, item := range node.Content {
+			c.applyStyles(item, seen)
+		}
+	case yaml.MappingNode:
+		if c.SequenceStyle == SequenceBlock {
+			node.Style &^= yaml.FlowStyle
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c.applyStyles(node.Content[i+1], seen)
+		}
+	case yaml.ScalarNode:
+		c.applyQuoteStyle(node)
+	}
+}
+
+func (c *RenderConfig) applyQuoteStyle(node *yaml.Node) {
+	if c.QuoteStyle == QuotePreserve || node.ShortTag() != "!!str" {
+		return
+	}
+	// multi-line strings keep their block style.
+	if strings.Contains(node.Value, "\n") {
+		return
+	}
+	switch c.QuoteStyle {
+	case QuoteMinimal:
+		node.Style = 0
+		node.Tag = "!!str" // makes sure the encoder quotes anything that won't be read back as a string.
+	case QuoteSingle:
+		node.Style = yaml.SingleQuotedStyle
+	case QuoteDouble:
+		node.Style = yaml.DoubleQuotedStyle
+	}
+}
+
+func sortKeysAlphabetically(node *yaml.Node, seen map[*yaml.Node]struct{}) {
+	if _, ok := seen[node]; ok {
+		return
+	}
+	seen[node] = struct{}{}
+	if node.Kind == yaml.MappingNode {
+		sortMappingPairs(node, func(a, b string) bool { return a < b })
+	}
+	for _, c := range node.Content {
+		sortKeysAlphabetically(c, seen)
+	}
+}
+
+// sortMappingPairs stably sorts the key/value pairs of a mapping node.
+func sortMappingPairs(node *yaml.Node, less func(a, b string) bool) {
+	type pair struct{ k, v *yaml.Node }
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, pair{node.Content[i], node.Content[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i].k.Value, pairs[j].k.Value)
+	})
+	for i, p := range pairs {
+		node.Content[i*2] = p.k
+		node.Content[i*2+1] = p.v
+	}
+}
+
+// canonical object definitions, keys are listed in specification order. children maps a key to the type of its
+// value: an object type, `map:<type>` for a map of names to a type, `list:<type>` for a sequence of a type.
+// Values of keys that are not in children are never re-ordered.
+type canonicalObject struct {
+	keys     []string
+	children map[string]string
+}
+
+var canonicalObjects = map[string]canonicalObject{
+	"openapi": {
+		keys: []string{"openapi", "$self", "info", "jsonSchemaDialect", "servers", "paths", "webhooks", "components",
+			"security", "tags", "externalDocs"},
+		children: map[string]string{"info": "info", "servers": "list:server", "paths": "map:pathItem",
+			"webhooks": "map:pathItem", "components": "components", "tags": "list:tag", "externalDocs": "externalDocs"},
+	},
+	"swagger": {
+		keys: []string{"swagger", "info", "host", "basePath", "schemes", "consumes", "produces", "paths",
+			"definitions", "parameters", "responses", "securityDefinitions", "security", "tags", "externalDocs"},
+		children: map[string]string{"info": "info", "paths": "map:pathItem2", "definitions": "map:schema",
+			"parameters": "map:parameter2", "responses": "map:response2", "securityDefinitions": "map:securityScheme2",
+			"tags": "list:tag", "externalDocs": "externalDocs"},
+	},
+	"info": {
+		keys:     []string{"title", "summary", "description", "termsOfService", "contact", "license", "version"},
+		children: map[string]string{"contact": "contact", "license": "license"},
+	},
+	"contact": {keys: []string{"name", "url", "email"}},
+	"license": {keys: []string{"name", "identifier", "url"}},
+	"server": {
+		keys:     []string{"url", "description", "variables"},
+		children: map[string]string{"variables": "map:serverVariable"},
+	},
+	"serverVariable": {keys: []string{"enum", "default", "description"}},
+	"components": {
+		keys: []string{"schemas", "responses", "parameters", "examples", "requestBodies", "headers",
+			"securitySchemes", "links", "callbacks", "pathItems"},
+		children: map[string]string{"schemas": "map:schema", "responses": "map:response", "parameters": "map:parameter",
+			"examples": "map:example", "requestBodies": "map:requestBody", "headers": "map:header",
+			"securitySchemes": "map:securityScheme", "links": "map:link", "callbacks": "map:callback",
+			"pathItems": "map:pathItem"},
+	},
+	"pathItem": {
+		keys: []string{"$ref", "summary", "description", "get", "put", "post", "delete", "options", "head",
+			"patch", "trace", "query", "additionalOperations", "servers", "parameters"},
+		children: map[string]string{"get": "operation", "put": "operation", "post": "operation",
+			"delete": "operation", "options": "operation", "head": "operation", "patch": "operation",
+			"trace": "operation", "query": "operation", "additionalOperations": "map:operation",
+			"servers": "list:server", "parameters": "list:parameter"},
+	},
+	"operation": {
+		keys: []string{"tags", "summary", "description", "externalDocs", "operationId", "parameters", "requestBody",
+			"responses", "callbacks", "deprecated", "security", "servers"},
+		children: map[string]string{"externalDocs": "externalDocs", "parameters": "list:parameter",
+			"requestBody": "requestBody", "responses": "map:response", "callbacks": "map:callback",
+			"servers": "list:server"},
+	},
+	"callback": {
+		children: map[string]string{"*": "pathItem"},
+	},
+	"parameter": {
+		keys: []string{"$ref", "name", "in", "description", "required", "deprecated", "allowEmptyValue", "style",
+			"explode", "allowReserved", "schema", "example", "examples", "content"},
+		children: map[string]string{"schema": "schema", "examples": "map:example", "content": "map:mediaType"},
+	},
+	"header": {
+		keys: []string{"$ref", "description", "required", "deprecated", "allowEmptyValue", "style", "explode",
+			"allowReserved", "schema", "example", "examples", "content"},
+		children: map[string]string{"schema": "schema", "examples": "map:example", "content": "map:mediaType"},
+	},
+	"requestBody": {
+		keys:     []string{"$ref", "description", "content", "required"},
+		children: map[string]string{"content": "map:mediaType"},
+	},
+	"mediaType": {
+		keys:     []string{"schema", "example", "examples", "encoding"},
+		children: map[string]string{"schema": "schema", "examples": "map:example", "encoding": "map:encoding"},
+	},
+	"encoding": {
+		keys:     []string{"contentType", "headers", "style", "explode", "allowReserved"},
+		children: map[string]string{"headers": "map:header"},
+	},
+	"response": {
+		keys:     []string{"$ref", "description", "headers", "content", "links"},
+		children: map[string]string{"headers": "map:header", "content": "map:mediaType", "links": "map:link"},
+	},
+	"link": {
+		keys:     []string{"$ref", "operationRef", "operationId", "parameters", "requestBody", "description", "server"},
+		children: map[string]string{"server": "server"},
+	},
+	"example":      {keys: []string{"$ref", "summary", "description", "value", "externalValue"}},
+	"tag":          {keys: []string{"name", "summary", "description", "externalDocs", "parent", "kind"}, children: map[string]string{"externalDocs": "externalDocs"}},
+	"externalDocs": {keys: []string{"description", "url"}},
+	"securityScheme": {
+		keys:     []string{"$ref", "type", "description", "name", "in", "scheme", "bearerFormat", "flows", "openIdConnectUrl"},
+		children: map[string]string{"flows": "oauthFlows"},
+	},
+	"oauthFlows": {
+		keys: []string{"implicit", "password", "clientCredentials", "authorizationCode"},
+		children: map[string]string{"implicit": "oauthFlow", "password": "oauthFlow",
+			"clientCredentials": "oauthFlow", "authorizationCode": "oauthFlow"},
+	},
+	"oauthFlow": {keys: []string{"authorizationUrl", "tokenUrl", "refreshUrl", "scopes"}},
+	"schema": {
+		keys: []string{"$schema", "$id", "$anchor", "$dynamicAnchor", "$ref", "$dynamicRef", "title", "description",
+			"type", "format", "enum", "const", "default", "nullable", "readOnly", "writeOnly", "deprecated",
+			"multipleOf", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "maxLength", "minLength",
+			"pattern", "maxItems", "minItems", "uniqueItems", "maxContains", "minContains", "maxProperties",
+			"minProperties", "required", "discriminator", "allOf", "oneOf", "anyOf", "not", "if", "then", "else",
+			"properties", "patternProperties", "additionalProperties", "dependentSchemas", "propertyNames",
+			"unevaluatedProperties", "items", "prefixItems", "contains", "unevaluatedItems", "$defs",
+			"contentEncoding", "contentMediaType", "xml", "externalDocs", "example", "examples"},
+		children: map[string]string{"properties": "map:schema", "patternProperties": "map:schema",
+			"dependentSchemas": "map:schema", "$defs": "map:schema", "allOf": "list:schema", "oneOf": "list:schema",
+			"anyOf": "list:schema", "prefixItems": "list:schema", "not": "schema", "if": "schema", "then": "schema",
+			"else": "schema", "additionalProperties": "schema", "propertyNames": "schema",
+			"unevaluatedProperties": "schema", "items": "schema", "contains": "schema", "unevaluatedItems": "schema",
+			"discriminator": "discriminator", "xml": "xml", "externalDocs": "externalDocs"},
+	},
+	"discriminator": {keys: []string{"propertyName", "mapping"}},
+	"xml":           {keys: []string{"name", "namespace", "prefix", "attribute", "wrapped"}},
+
+	// swagger 2 objects that differ from OpenAPI 3.
+	"pathItem2": {
+		keys: []string{"$ref", "get", "put", "post", "delete", "options", "head", "patch", "parameters"},
+		children: map[string]string{"get": "operation2", "put": "operation2", "post": "operation2",
+			"delete": "operation2", "options": "operation2", "head": "operation2", "patch": "operation2",
+			"parameters": "list:parameter2"},
+	},
+	"operation2": {
+		keys: []string{"tags", "summary", "description", "externalDocs", "operationId", "consumes", "produces",
+			"parameters", "responses", "schemes", "deprecated", "security"},
+		children: map[string]string{"externalDocs": "externalDocs", "parameters": "list:parameter2",
+			"responses": "map:response2"},
+	},
+	"parameter2": {
+		keys: []string{"$ref", "name", "in", "description", "required", "schema", "type", "format",
+			"allowEmptyValue", "items", "collectionFormat", "default", "maximum", "exclusiveMaximum", "minimum",
+			"exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems", "uniqueItems", "enum",
+			"multipleOf"},
+		children: map[string]string{"schema": "schema", "items": "items2"},
+	},
+	"items2": {
+		keys: []string{"type", "format", "items", "collectionFormat", "default", "maximum", "exclusiveMaximum",
+			"minimum", "exclusiveMinimum", "maxLength", "minLength", "pattern", "maxItems", "minItems",
+			"uniqueItems", "enum", "multipleOf"},
+		children: map[string]string{"items": "items2"},
+	},
+	"response2": {
+		keys:     []string{"$ref", "description", "schema", "headers", "examples"},
+		children: map[string]string{"schema": "schema", "headers": "map:items2"},
+	},
+	"securityScheme2": {
+		keys: []string{"type", "description", "name", "in", "flow", "authorizationUrl", "tokenUrl", "scopes"},
+	},
+}
+
+func sortKeysCanonically(node *yaml.Node, typ string, seen map[*yaml.Node]struct{}) {
+	if node == nil {
+		return
+	}
+	if _, ok := seen[node]; ok {
+		return
+	}
+	seen[node] = struct{}{}
+
+	if t, ok := strings.CutPrefix(typ, "list:"); ok {
+		if node.Kind == yaml.SequenceNode {
+			for _, item := range node.Content {
+				sortKeysCanonically(item, t, seen)
+			}
+		}
+		return
+	}
+	if t, ok := strings.CutPrefix(typ, "map:"); ok {
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if !strings.HasPrefix(node.Content[i].Value, "x-") {
+					sortKeysCanonically(node.Content[i+1], t, seen)
+				}
+			}
+		}
+		return
+	}
+
+	obj, ok := canonicalObjects[typ]
+	if !ok || node.Kind != yaml.MappingNode {
+		return
+	}
+	if len(obj.keys) > 0 {
+		rank := func(k string) int {
+			if i := slices.Index(obj.keys, k); i >= 0 {
+				return i
+			}
+			return len(obj.keys)
+		}
+		sortMappingPairs(node, func(a, b string) bool { return rank(a) < rank(b) })
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if strings.HasPrefix(key, "x-") {
+			continue
+		}
+		child, found := obj.children[key]
+		if !found {
+			child, found = obj.children["*"]
+		}
+		if found {
+			sortKeysCanonically(node.Content[i+1], child, seen)
+		}
+	}
+}
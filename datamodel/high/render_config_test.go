@@ -0,0 +1,217 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func renderConfigNode(t *testing.T, yml string) *yaml.Node {
+	var n yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(yml), &n))
+	return &n
+}
+
+func TestRenderConfig_Encode_Default(t *testing.T) {
+	yml := `b: 1
+a:
+    - one
+    - 'two'
+`
+	n := renderConfigNode(t, yml)
+	out, err := (&RenderConfig{}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, yml, string(out))
+
+	var nilConfig *RenderConfig
+	out, err = nilConfig.Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, yml, string(out))
+
+	assert.Nil(t, nilConfig.Apply(nil))
+}
+
+func TestRenderConfig_Indent(t *testing.T) {
+	n := renderConfigNode(t, "a:\n    b:\n        - c\n")
+	out, err := (&RenderConfig{Indent: 2}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n  b:\n    - c\n", string(out))
+}
+
+func TestRenderConfig_SequenceStyle(t *testing.T) {
+	yml := `required: [a, b]
+enum:
+    - one
+    - two
+allOf:
+    - type: string
+`
+	n := renderConfigNode(t, yml)
+
+	out, err := (&RenderConfig{SequenceStyle: SequenceBlock}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "required:\n    - a\n    - b\nenum:\n    - one\n    - two\nallOf:\n    - type: string\n", string(out))
+
+	out, err = (&RenderConfig{SequenceStyle: SequenceFlow}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "required: [a, b]\nenum: [one, two]\nallOf: [{type: string}]\n", string(out))
+
+	out, err = (&RenderConfig{SequenceStyle: SequenceFlowScalars}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "required: [a, b]\nenum: [one, two]\nallOf:\n    - type: string\n", string(out))
+
+	// the original node is never changed.
+	out, err = (&RenderConfig{}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, yml, string(out))
+}
+
+func TestRenderConfig_QuoteStyle(t *testing.T) {
+	yml := `a: "hello"
+b: 'true'
+c: 42
+d: |
+    multi
+    line
+"200": plain
+`
+	n := renderConfigNode(t, yml)
+
+	out, err := (&RenderConfig{QuoteStyle: QuoteMinimal}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "a: hello\nb: \"true\"\nc: 42\nd: |\n    multi\n    line\n\"200\": plain\n", string(out))
+
+	out, err = (&RenderConfig{QuoteStyle: QuoteSingle}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "a: 'hello'\nb: 'true'\nc: 42\nd: |\n    multi\n    line\n\"200\": 'plain'\n", string(out))
+
+	out, err = (&RenderConfig{QuoteStyle: QuoteDouble}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "a: \"hello\"\nb: \"true\"\nc: 42\nd: |\n    multi\n    line\n\"200\": \"plain\"\n", string(out))
+}
+
+func TestRenderConfig_KeyOrder_Alphabetical(t *testing.T) {
+	n := renderConfigNode(t, "b: 1\na:\n    z: 1\n    y: [{d: 1, c: 2}]\n")
+	out, err := (&RenderConfig{KeyOrder: KeyOrderAlphabetical}).Encode(n)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n    y: [{c: 2, d: 1}]\n    z: 1\nb: 1\n", string(out))
+}
+
+func TestRenderConfig_KeyOrder_Canonical(t *testing.T) {
+	yml := `x-root: true
+paths:
+    /b:
+        post:
+            responses:
+                "200":
+                    content:
+                        application/json:
+                            schema:
+                                properties:
+                                    type:
+                                        type: string
+                                    description:
+                                        description: a property called description
+                                        type: string
+                                type: object
+                    description: ok
+            operationId: b
+    /a:
+        get:
+            x-thing:
+                z: 1
+                a: 2
+            summary: a
+            tags: [a]
+info:
+    version: 1.0.0
+    title: test
+openapi: 3.1.0
+components:
+    schemas:
+        B:
+            example:
+                z: 1
+                a: 2
+            type: object
+        A:
+            type: string
+`
+	out, err := (&RenderConfig{KeyOrder: KeyOrderCanonical}).Encode(renderConfigNode(t, yml))
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: test
+    version: 1.0.0
+paths:
+    /b:
+        post:
+            operationId: b
+            responses:
+                "200":
+                    description: ok
+                    content:
+                        application/json:
+                            schema:
+                                type: object
+                                properties:
+                                    type:
+                                        type: string
+                                    description:
+                                        description: a property called description
+                                        type: string
+    /a:
+        get:
+            tags: [a]
+            summary: a
+            x-thing:
+                z: 1
+                a: 2
+components:
+    schemas:
+        B:
+            type: object
+            example:
+                z: 1
+                a: 2
+        A:
+            type: string
+x-root: true
+`, string(out))
+}
+
+func TestRenderConfig_KeyOrder_Canonical_Swagger(t *testing.T) {
+	yml := `paths:
+    /a:
+        get:
+            responses:
+                "200":
+                    schema:
+                        type: string
+                    description: ok
+            parameters:
+                - in: query
+                  name: a
+swagger: "2.0"
+`
+	out, err := (&RenderConfig{KeyOrder: KeyOrderCanonical}).Encode(renderConfigNode(t, yml))
+	require.NoError(t, err)
+	assert.Equal(t, `swagger: "2.0"
+paths:
+    /a:
+        get:
+            parameters:
+                - name: a
+                  in: query
+            responses:
+                "200":
+                    description: ok
+                    schema:
+                        type: string
+`, string(out))
+}
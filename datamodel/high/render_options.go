@@ -0,0 +1,137 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedRenderOptions is wrapped by every RenderCapabilityError.
+var ErrUnsupportedRenderOptions = errors.New("unsupported render options")
+
+// RenderFormat is the format a model is rendered to.
+type RenderFormat int
+
+const (
+	// RenderFormatYAML renders YAML, styled by the RenderConfig of the options.
+	RenderFormatYAML RenderFormat = iota
+
+	// RenderFormatJSON renders indented JSON.
+	RenderFormatJSON
+
+	// RenderFormatJSONMinified renders minified JSON.
+	RenderFormatJSONMinified
+)
+
+// String returns the name of the format.
+func (f RenderFormat) String() string {
+	switch f {
+	case RenderFormatJSON:
+		return "json"
+	case RenderFormatJSONMinified:
+		return "json-minified"
+	default:
+		return "yaml"
+	}
+}
+
+// RenderOptions describes everything asked of a renderer, so the combination can be checked (with Validate) before
+// anything is rendered. Options a renderer can't honor are reported, never silently ignored.
+type RenderOptions struct {
+	Format          RenderFormat
+	Style           *RenderConfig // the style of YAML output, only for RenderFormatYAML.
+	JSONIndent      string        // the indention of JSON output, only for RenderFormatJSON (defaults to two spaces).
+	Inline          bool          // references are resolved, and rendered inline.
+	PreserveNumbers bool          // numbers are rendered exactly as they were written in the source document.
+	PreserveAnchors bool          // anchors, aliases and merge keys of the source document are kept.
+}
+
+// RenderCapabilityError reports an option, or a combination of options, a renderer does not support.
+type RenderCapabilityError struct {
+	// Option is the name of the option that is not supported, e.g. `PreserveAnchors`.
+	Option string `json:"option" yaml:"option"`
+
+	// ConflictsWith is the option Option can't be combined with (such as `Format: json`), if any.
+	ConflictsWith string `json:"conflictsWith,omitempty" yaml:"conflictsWith,omitempty"`
+
+	// Version is the version of the document Option is not supported for, if it's not supported because of the
+	// version.
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// Reason explains why Option is not supported.
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// Error returns a description of the unsupported option.
+func (e *RenderCapabilityError) Error() string {
+	switch {
+	case e.ConflictsWith != "":
+		return fmt.Sprintf("render option '%s' can't be combined with '%s': %s", e.Option, e.ConflictsWith, e.Reason)
+	case e.Version != "":
+		return fmt.Sprintf("render option '%s' is not supported for version %s documents: %s", e.Option, e.Version, e.Reason)
+	default:
+		return fmt.Sprintf("render option '%s' is not supported: %s", e.Option, e.Reason)
+	}
+}
+
+// Unwrap returns ErrUnsupportedRenderOptions.
+func (e *RenderCapabilityError) Unwrap() error {
+	return ErrUnsupportedRenderOptions
+}
+
+// Validate checks the options can all be honored when rendering a model of a document of version (the value of
+// `openapi` or `swagger`, an empty version is not checked), and returns a RenderCapabilityError for every option
+// that can't be. A nil *RenderOptions renders YAML, with nothing to check but the version.
+func (o *RenderOptions) Validate(version string) []*RenderCapabilityError {
+	if o == nil {
+		o = new(RenderOptions)
+	}
+	var errs []*RenderCapabilityError
+	add := func(option, conflict, reason string) {
+		errs = append(errs, &RenderCapabilityError{Option: option, ConflictsWith: conflict, Reason: reason})
+	}
+	if version != "" && !strings.HasPrefix(version, "3.") {
+		reason := "only OpenAPI 3 models can be rendered"
+		if strings.HasPrefix(version, "2.") {
+			reason = "Swagger models can't be rendered, only OpenAPI 3 models can"
+		}
+		errs = append(errs, &RenderCapabilityError{Option: "Format", Version: version, Reason: reason})
+	}
+
+	format := "Format: " + o.Format.String()
+	switch o.Format {
+	case RenderFormatYAML:
+		if o.JSONIndent != "" {
+			add("JSONIndent", format, "YAML is indented by the Indent of the Style")
+		}
+	case RenderFormatJSON, RenderFormatJSONMinified:
+		if o.Style != nil && *o.Style != (RenderConfig{}) {
+			add("Style", format, "styles only apply to YAML")
+		}
+		if o.Format == RenderFormatJSONMinified && o.JSONIndent != "" {
+			add("JSONIndent", format, "minified JSON is not indented")
+		}
+		if o.Format == RenderFormatJSONMinified && o.PreserveNumbers {
+			add("PreserveNumbers", format, "minified JSON renders numbers as they are decoded")
+		}
+	default:
+		add("Format", "", fmt.Sprintf("unknown format %d", o.Format))
+	}
+	if o.PreserveAnchors && o.Format != RenderFormatYAML {
+		add("PreserveAnchors", format, "JSON has no anchors or aliases")
+	}
+	return errs
+}
+
+// Check is the same as Validate, returning every RenderCapabilityError joined in a single error, or nil if there
+// are none.
+func (o *RenderOptions) Check(version string) error {
+	var errs []error
+	for _, e := range o.Validate(version) {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
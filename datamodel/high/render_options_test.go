@@ -0,0 +1,72 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderOptions_Validate(t *testing.T) {
+	var options *RenderOptions
+	assert.Empty(t, options.Validate("3.1.0"))
+	assert.Empty(t, (&RenderOptions{Style: &RenderConfig{Indent: 2}, Inline: true, PreserveNumbers: true}).Validate("3.0.3"))
+	assert.Empty(t, (&RenderOptions{Format: RenderFormatJSON, Style: new(RenderConfig), JSONIndent: "\t"}).Validate(""))
+	assert.Empty(t, (&RenderOptions{Format: RenderFormatJSONMinified, Inline: true}).Validate("3.2.0"))
+
+	errs := (&RenderOptions{
+		Format:          RenderFormatJSONMinified,
+		Style:           &RenderConfig{KeyOrder: KeyOrderCanonical},
+		JSONIndent:      "  ",
+		PreserveNumbers: true,
+		PreserveAnchors: true,
+	}).Validate("3.1.0")
+	require.Len(t, errs, 4)
+	for i, option := range []string{"Style", "JSONIndent", "PreserveNumbers", "PreserveAnchors"} {
+		assert.Equal(t, option, errs[i].Option)
+		assert.Equal(t, "Format: json-minified", errs[i].ConflictsWith)
+	}
+
+	errs = (&RenderOptions{JSONIndent: "  ", PreserveAnchors: true}).Validate("3.1.0")
+	require.Len(t, errs, 1)
+	assert.Equal(t, "render option 'JSONIndent' can't be combined with 'Format: yaml': YAML is indented by the "+
+		"Indent of the Style", errs[0].Error())
+
+	errs = options.Validate("2.0")
+	require.Len(t, errs, 1)
+	assert.Equal(t, "render option 'Format' is not supported for version 2.0 documents: Swagger models can't be "+
+		"rendered, only OpenAPI 3 models can", errs[0].Error())
+	assert.Equal(t, "only OpenAPI 3 models can be rendered", options.Validate("4.0")[0].Reason)
+	assert.Equal(t, "unknown format 7", (&RenderOptions{Format: 7}).Validate("3.1.0")[0].Reason)
+
+	data, err := json.Marshal(errs[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"option":"Format","version":"2.0","reason":"Swagger models can't be rendered, only OpenAPI 3 models can"}`,
+		string(data))
+}
+
+func TestRenderOptions_Check(t *testing.T) {
+	assert.NoError(t, (&RenderOptions{}).Check("3.1.0"))
+
+	err := (&RenderOptions{Format: RenderFormatJSON, Style: &RenderConfig{Indent: 2}, PreserveAnchors: true}).Check("2.0")
+	assert.ErrorIs(t, err, ErrUnsupportedRenderOptions)
+	var capability *RenderCapabilityError
+	require.True(t, errors.As(err, &capability))
+	assert.Equal(t, "Format", capability.Option)
+	assert.Equal(t, "render option 'Format' is not supported for version 2.0 documents: Swagger models can't be "+
+		"rendered, only OpenAPI 3 models can\n"+
+		"render option 'Style' can't be combined with 'Format: json': styles only apply to YAML\n"+
+		"render option 'PreserveAnchors' can't be combined with 'Format: json': JSON has no anchors or aliases",
+		err.Error())
+}
+
+func TestRenderFormat_String(t *testing.T) {
+	assert.Equal(t, "yaml", RenderFormatYAML.String())
+	assert.Equal(t, "json", RenderFormatJSON.String())
+	assert.Equal(t, "json-minified", RenderFormatJSONMinified.String())
+}
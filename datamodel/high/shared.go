@@ -45,9 +45,9 @@ func ExtractExtensions(extensions *orderedmap.Map[low.KeyReference[string], low.
 // into a complex type, provided as a generic. This function is for high-level models that implement `GoesLow()`
 // and for low-level models that support extensions via `HasExtensions`.
 //
-// This feature will be upgraded at some point to hold a registry of types and extension mappings to make this
-// functionality available a little more automatically.
-// You can read more about the discussion here: https://github.com/pb33f/libopenapi/issues/8
+// Values are decoded directly from YAML, references are not resolved. To have extensions built as typed models by the
+// standard pipeline (with index and reference support), register them with a low.ExtensionRegistry and use
+// GetExtensionModel. You can read more about the discussion here: https://github.com/pb33f/libopenapi/issues/8
 //
 // `T` represents the Type you want to unpack into
 // `R` represents the LOW type of the object that contains the extensions (not the high)
@@ -71,3 +71,60 @@ func UnpackExtensions[T any, R low.HasExtensions[T]](low GoesLow[R]) (*orderedma
 	}
 	return m, nil
 }
+
+// GetExtensionModel returns the typed model built for an extension key by a low.ExtensionRegistry, from a high-level
+// extension map. Returns false if the extension does not exist, was not built, or is not of type N.
+//
+// to use:
+//
+//	streaming, ok := GetExtensionModel[Streaming](registry, operation.Extensions, "x-streaming")
+func GetExtensionModel[N any](registry *low.ExtensionRegistry, extensions *orderedmap.Map[string, *yaml.Node], key string) (*N, bool) {
+	if extensions == nil {
+		return nil, false
+	}
+	node, ok := extensions.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return low.GetExtensionModelFromNode[N](registry, node)
+}
+
+// MergeParameters merges path-level and operation-level parameters into a single, de-duplicated list, following the
+// override rules of the specification: an operation-level parameter replaces a path-level parameter with the same
+// key (the key being a combination of name and location, as returned by the key function).
+//
+// Path-level parameters come first (in the order they were defined), an overridden one replaced in place by the
+// operation-level parameter overriding it, followed by the remaining operation-level parameters. If a list contains
+// the same parameter more than once, the first one defined wins. nil parameters are skipped.
+func MergeParameters[T comparable](pathParams, operationParams []T, key func(T) string) []T {
+	var zero T
+	overrides := make(map[string]T)
+	for _, p := range operationParams {
+		if p == zero {
+			continue
+		}
+		if _, ok := overrides[key(p)]; !ok {
+			overrides[key(p)] = p
+		}
+	}
+	seen := make(map[string]bool)
+	var merged []T
+	for _, p := range pathParams {
+		if p == zero || seen[key(p)] {
+			continue
+		}
+		seen[key(p)] = true
+		if o, ok := overrides[key(p)]; ok {
+			p = o
+		}
+		merged = append(merged, p)
+	}
+	for _, p := range operationParams {
+		if p == zero || seen[key(p)] {
+			continue
+		}
+		seen[key(p)] = true
+		merged = append(merged, p)
+	}
+	return merged
+}
@@ -135,3 +135,21 @@ power: hello`
 	assert.Error(t, er)
 	assert.Empty(t, res)
 }
+
+func TestMergeParameters_Order(t *testing.T) {
+	type param struct{ name, in, from string }
+	key := func(p *param) string { return p.in + ":" + p.name }
+	pathParams := []*param{{"a", "query", "path"}, {"b", "query", "path"}, nil, {"c", "query", "path"}}
+	operationParams := []*param{{"d", "query", "op"}, {"b", "query", "op"}, {"b", "query", "duplicate"},
+		{"a", "header", "op"}}
+
+	// an overridden path-level parameter keeps its place, the operation-level parameters that are left follow.
+	var merged []string
+	for _, p := range MergeParameters(pathParams, operationParams, key) {
+		merged = append(merged, p.from+":"+key(p))
+	}
+	assert.Equal(t, []string{"path:query:a", "op:query:b", "path:query:c", "op:query:d", "op:header:a"}, merged)
+
+	assert.Empty(t, MergeParameters(nil, nil, key))
+	assert.Len(t, MergeParameters(nil, operationParams, key), 3)
+}
@@ -48,3 +48,8 @@ func NewDefinitions(definitions *low.Definitions) *Definitions {
 func (d *Definitions) GoLow() *low.Definitions {
 	return d.low
 }
+
+// GoLowUntyped returns the low-level Definitions used to create the high-level one, with no type.
+func (d *Definitions) GoLowUntyped() any {
+	return d.low
+}
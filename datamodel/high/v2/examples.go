@@ -32,3 +32,8 @@ func NewExample(examples *lowv2.Examples) *Example {
 func (e *Example) GoLow() *lowv2.Examples {
 	return e.low
 }
+
+// GoLowUntyped returns the low-level Example used to create the high-level one, with no type.
+func (e *Example) GoLowUntyped() any {
+	return e.low
+}
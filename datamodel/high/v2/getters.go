@@ -0,0 +1,1020 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Code generated by go run ../internal/getters; DO NOT EDIT.
+
+package v2
+
+import (
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// GetDefinitions returns the Definitions of the Definitions, or the zero value if the Definitions is nil.
+func (d *Definitions) GetDefinitions() *orderedmap.Map[string, *highbase.SchemaProxy] {
+	if d == nil {
+		return nil
+	}
+	return d.Definitions
+}
+
+// GetValues returns the Values of the Example, or the zero value if the Example is nil.
+func (e *Example) GetValues() *orderedmap.Map[string, *yaml.Node] {
+	if e == nil {
+		return nil
+	}
+	return e.Values
+}
+
+// GetType returns the Type of the Header, or the zero value if the Header is nil.
+func (h *Header) GetType() string {
+	if h == nil {
+		return ""
+	}
+	return h.Type
+}
+
+// GetFormat returns the Format of the Header, or the zero value if the Header is nil.
+func (h *Header) GetFormat() string {
+	if h == nil {
+		return ""
+	}
+	return h.Format
+}
+
+// GetDescription returns the Description of the Header, or the zero value if the Header is nil.
+func (h *Header) GetDescription() string {
+	if h == nil {
+		return ""
+	}
+	return h.Description
+}
+
+// GetItems returns the Items of the Header, or the zero value if the Header is nil.
+func (h *Header) GetItems() *Items {
+	if h == nil {
+		return nil
+	}
+	return h.Items
+}
+
+// GetCollectionFormat returns the CollectionFormat of the Header, or the zero value if the Header is nil.
+func (h *Header) GetCollectionFormat() string {
+	if h == nil {
+		return ""
+	}
+	return h.CollectionFormat
+}
+
+// GetDefault returns the Default of the Header, or the zero value if the Header is nil.
+func (h *Header) GetDefault() any {
+	if h == nil {
+		return nil
+	}
+	return h.Default
+}
+
+// GetMaximum returns the Maximum of the Header, or the zero value if the Header is nil.
+func (h *Header) GetMaximum() int {
+	if h == nil {
+		return 0
+	}
+	return h.Maximum
+}
+
+// GetExclusiveMaximum returns the ExclusiveMaximum of the Header, or the zero value if the Header is nil.
+func (h *Header) GetExclusiveMaximum() bool {
+	if h == nil {
+		return false
+	}
+	return h.ExclusiveMaximum
+}
+
+// GetMinimum returns the Minimum of the Header, or the zero value if the Header is nil.
+func (h *Header) GetMinimum() int {
+	if h == nil {
+		return 0
+	}
+	return h.Minimum
+}
+
+// GetExclusiveMinimum returns the ExclusiveMinimum of the Header, or the zero value if the Header is nil.
+func (h *Header) GetExclusiveMinimum() bool {
+	if h == nil {
+		return false
+	}
+	return h.ExclusiveMinimum
+}
+
+// GetMaxLength returns the MaxLength of the Header, or the zero value if the Header is nil.
+func (h *Header) GetMaxLength() int {
+	if h == nil {
+		return 0
+	}
+	return h.MaxLength
+}
+
+// GetMinLength returns the MinLength of the Header, or the zero value if the Header is nil.
+func (h *Header) GetMinLength() int {
+	if h == nil {
+		return 0
+	}
+	return h.MinLength
+}
+
+// GetPattern returns the Pattern of the Header, or the zero value if the Header is nil.
+func (h *Header) GetPattern() string {
+	if h == nil {
+		return ""
+	}
+	return h.Pattern
+}
+
+// GetMaxItems returns the MaxItems of the Header, or the zero value if the Header is nil.
+func (h *Header) GetMaxItems() int {
+	if h == nil {
+		return 0
+	}
+	return h.MaxItems
+}
+
+// GetMinItems returns the MinItems of the Header, or the zero value if the Header is nil.
+func (h *Header) GetMinItems() int {
+	if h == nil {
+		return 0
+	}
+	return h.MinItems
+}
+
+// GetUniqueItems returns the UniqueItems of the Header, or the zero value if the Header is nil.
+func (h *Header) GetUniqueItems() bool {
+	if h == nil {
+		return false
+	}
+	return h.UniqueItems
+}
+
+// GetEnum returns the Enum of the Header, or the zero value if the Header is nil.
+func (h *Header) GetEnum() []any {
+	if h == nil {
+		return nil
+	}
+	return h.Enum
+}
+
+// GetMultipleOf returns the MultipleOf of the Header, or the zero value if the Header is nil.
+func (h *Header) GetMultipleOf() int {
+	if h == nil {
+		return 0
+	}
+	return h.MultipleOf
+}
+
+// GetExtensions returns the Extensions of the Header, or the zero value if the Header is nil.
+func (h *Header) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if h == nil {
+		return nil
+	}
+	return h.Extensions
+}
+
+// GetType returns the Type of the Items, or the zero value if the Items is nil.
+func (i *Items) GetType() string {
+	if i == nil {
+		return ""
+	}
+	return i.Type
+}
+
+// GetFormat returns the Format of the Items, or the zero value if the Items is nil.
+func (i *Items) GetFormat() string {
+	if i == nil {
+		return ""
+	}
+	return i.Format
+}
+
+// GetCollectionFormat returns the CollectionFormat of the Items, or the zero value if the Items is nil.
+func (i *Items) GetCollectionFormat() string {
+	if i == nil {
+		return ""
+	}
+	return i.CollectionFormat
+}
+
+// GetItems returns the Items of the Items, or the zero value if the Items is nil.
+func (i *Items) GetItems() *Items {
+	if i == nil {
+		return nil
+	}
+	return i.Items
+}
+
+// GetDefault returns the Default of the Items, or the zero value if the Items is nil.
+func (i *Items) GetDefault() *yaml.Node {
+	if i == nil {
+		return nil
+	}
+	return i.Default
+}
+
+// GetMaximum returns the Maximum of the Items, or the zero value if the Items is nil.
+func (i *Items) GetMaximum() int {
+	if i == nil {
+		return 0
+	}
+	return i.Maximum
+}
+
+// GetExclusiveMaximum returns the ExclusiveMaximum of the Items, or the zero value if the Items is nil.
+func (i *Items) GetExclusiveMaximum() bool {
+	if i == nil {
+		return false
+	}
+	return i.ExclusiveMaximum
+}
+
+// GetMinimum returns the Minimum of the Items, or the zero value if the Items is nil.
+func (i *Items) GetMinimum() int {
+	if i == nil {
+		return 0
+	}
+	return i.Minimum
+}
+
+// GetExclusiveMinimum returns the ExclusiveMinimum of the Items, or the zero value if the Items is nil.
+func (i *Items) GetExclusiveMinimum() bool {
+	if i == nil {
+		return false
+	}
+	return i.ExclusiveMinimum
+}
+
+// GetMaxLength returns the MaxLength of the Items, or the zero value if the Items is nil.
+func (i *Items) GetMaxLength() int {
+	if i == nil {
+		return 0
+	}
+	return i.MaxLength
+}
+
+// GetMinLength returns the MinLength of the Items, or the zero value if the Items is nil.
+func (i *Items) GetMinLength() int {
+	if i == nil {
+		return 0
+	}
+	return i.MinLength
+}
+
+// GetPattern returns the Pattern of the Items, or the zero value if the Items is nil.
+func (i *Items) GetPattern() string {
+	if i == nil {
+		return ""
+	}
+	return i.Pattern
+}
+
+// GetMaxItems returns the MaxItems of the Items, or the zero value if the Items is nil.
+func (i *Items) GetMaxItems() int {
+	if i == nil {
+		return 0
+	}
+	return i.MaxItems
+}
+
+// GetMinItems returns the MinItems of the Items, or the zero value if the Items is nil.
+func (i *Items) GetMinItems() int {
+	if i == nil {
+		return 0
+	}
+	return i.MinItems
+}
+
+// GetUniqueItems returns the UniqueItems of the Items, or the zero value if the Items is nil.
+func (i *Items) GetUniqueItems() bool {
+	if i == nil {
+		return false
+	}
+	return i.UniqueItems
+}
+
+// GetEnum returns the Enum of the Items, or the zero value if the Items is nil.
+func (i *Items) GetEnum() []*yaml.Node {
+	if i == nil {
+		return nil
+	}
+	return i.Enum
+}
+
+// GetMultipleOf returns the MultipleOf of the Items, or the zero value if the Items is nil.
+func (i *Items) GetMultipleOf() int {
+	if i == nil {
+		return 0
+	}
+	return i.MultipleOf
+}
+
+// GetTags returns the Tags of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetTags() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Tags
+}
+
+// GetSummary returns the Summary of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetSummary() string {
+	if o == nil {
+		return ""
+	}
+	return o.Summary
+}
+
+// GetDescription returns the Description of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetDescription() string {
+	if o == nil {
+		return ""
+	}
+	return o.Description
+}
+
+// GetExternalDocs returns the ExternalDocs of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetExternalDocs() *highbase.ExternalDoc {
+	if o == nil {
+		return nil
+	}
+	return o.ExternalDocs
+}
+
+// GetOperationId returns the OperationId of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetOperationId() string {
+	if o == nil {
+		return ""
+	}
+	return o.OperationId
+}
+
+// GetConsumes returns the Consumes of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetConsumes() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Consumes
+}
+
+// GetProduces returns the Produces of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetProduces() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Produces
+}
+
+// GetParameters returns the Parameters of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetParameters() []*Parameter {
+	if o == nil {
+		return nil
+	}
+	return o.Parameters
+}
+
+// GetResponses returns the Responses of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetResponses() *Responses {
+	if o == nil {
+		return nil
+	}
+	return o.Responses
+}
+
+// GetSchemes returns the Schemes of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetSchemes() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Schemes
+}
+
+// GetDeprecated returns the Deprecated of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetDeprecated() bool {
+	if o == nil {
+		return false
+	}
+	return o.Deprecated
+}
+
+// GetSecurity returns the Security of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetSecurity() []*highbase.SecurityRequirement {
+	if o == nil {
+		return nil
+	}
+	return o.Security
+}
+
+// GetCodeSamples returns the CodeSamples of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetCodeSamples() []*highbase.CodeSample {
+	if o == nil {
+		return nil
+	}
+	return o.CodeSamples
+}
+
+// GetExtensions returns the Extensions of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if o == nil {
+		return nil
+	}
+	return o.Extensions
+}
+
+// GetName returns the Name of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetName() string {
+	if p == nil {
+		return ""
+	}
+	return p.Name
+}
+
+// GetIn returns the In of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetIn() string {
+	if p == nil {
+		return ""
+	}
+	return p.In
+}
+
+// GetType returns the Type of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetType() string {
+	if p == nil {
+		return ""
+	}
+	return p.Type
+}
+
+// GetFormat returns the Format of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetFormat() string {
+	if p == nil {
+		return ""
+	}
+	return p.Format
+}
+
+// GetDescription returns the Description of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetDescription() string {
+	if p == nil {
+		return ""
+	}
+	return p.Description
+}
+
+// GetRequired returns the Required of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetRequired() *bool {
+	if p == nil {
+		return nil
+	}
+	return p.Required
+}
+
+// GetAllowEmptyValue returns the AllowEmptyValue of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetAllowEmptyValue() *bool {
+	if p == nil {
+		return nil
+	}
+	return p.AllowEmptyValue
+}
+
+// GetSchema returns the Schema of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetSchema() *highbase.SchemaProxy {
+	if p == nil {
+		return nil
+	}
+	return p.Schema
+}
+
+// GetItems returns the Items of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetItems() *Items {
+	if p == nil {
+		return nil
+	}
+	return p.Items
+}
+
+// GetCollectionFormat returns the CollectionFormat of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetCollectionFormat() string {
+	if p == nil {
+		return ""
+	}
+	return p.CollectionFormat
+}
+
+// GetDefault returns the Default of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetDefault() *yaml.Node {
+	if p == nil {
+		return nil
+	}
+	return p.Default
+}
+
+// GetMaximum returns the Maximum of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetMaximum() *int {
+	if p == nil {
+		return nil
+	}
+	return p.Maximum
+}
+
+// GetExclusiveMaximum returns the ExclusiveMaximum of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExclusiveMaximum() *bool {
+	if p == nil {
+		return nil
+	}
+	return p.ExclusiveMaximum
+}
+
+// GetMinimum returns the Minimum of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetMinimum() *int {
+	if p == nil {
+		return nil
+	}
+	return p.Minimum
+}
+
+// GetExclusiveMinimum returns the ExclusiveMinimum of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExclusiveMinimum() *bool {
+	if p == nil {
+		return nil
+	}
+	return p.ExclusiveMinimum
+}
+
+// GetMaxLength returns the MaxLength of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetMaxLength() *int {
+	if p == nil {
+		return nil
+	}
+	return p.MaxLength
+}
+
+// GetMinLength returns the MinLength of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetMinLength() *int {
+	if p == nil {
+		return nil
+	}
+	return p.MinLength
+}
+
+// GetPattern returns the Pattern of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetPattern() string {
+	if p == nil {
+		return ""
+	}
+	return p.Pattern
+}
+
+// GetMaxItems returns the MaxItems of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetMaxItems() *int {
+	if p == nil {
+		return nil
+	}
+	return p.MaxItems
+}
+
+// GetMinItems returns the MinItems of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetMinItems() *int {
+	if p == nil {
+		return nil
+	}
+	return p.MinItems
+}
+
+// GetUniqueItems returns the UniqueItems of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetUniqueItems() *bool {
+	if p == nil {
+		return nil
+	}
+	return p.UniqueItems
+}
+
+// GetEnum returns the Enum of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetEnum() []*yaml.Node {
+	if p == nil {
+		return nil
+	}
+	return p.Enum
+}
+
+// GetMultipleOf returns the MultipleOf of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetMultipleOf() *int {
+	if p == nil {
+		return nil
+	}
+	return p.MultipleOf
+}
+
+// GetExtensions returns the Extensions of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetDefinitions returns the Definitions of the ParameterDefinitions, or the zero value if the ParameterDefinitions is nil.
+func (p *ParameterDefinitions) GetDefinitions() *orderedmap.Map[string, *Parameter] {
+	if p == nil {
+		return nil
+	}
+	return p.Definitions
+}
+
+// GetRef returns the Ref of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetRef() string {
+	if p == nil {
+		return ""
+	}
+	return p.Ref
+}
+
+// GetGet returns the Get of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetGet() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Get
+}
+
+// GetPut returns the Put of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetPut() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Put
+}
+
+// GetPost returns the Post of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetPost() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Post
+}
+
+// GetDelete returns the Delete of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetDelete() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Delete
+}
+
+// GetOptions returns the Options of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetOptions() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Options
+}
+
+// GetHead returns the Head of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetHead() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Head
+}
+
+// GetPatch returns the Patch of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetPatch() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Patch
+}
+
+// GetParameters returns the Parameters of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetParameters() []*Parameter {
+	if p == nil {
+		return nil
+	}
+	return p.Parameters
+}
+
+// GetExtensions returns the Extensions of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetPathItems returns the PathItems of the Paths, or the zero value if the Paths is nil.
+func (p *Paths) GetPathItems() *orderedmap.Map[string, *PathItem] {
+	if p == nil {
+		return nil
+	}
+	return p.PathItems
+}
+
+// GetExtensions returns the Extensions of the Paths, or the zero value if the Paths is nil.
+func (p *Paths) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetDescription returns the Description of the Response, or the zero value if the Response is nil.
+func (r *Response) GetDescription() string {
+	if r == nil {
+		return ""
+	}
+	return r.Description
+}
+
+// GetSchema returns the Schema of the Response, or the zero value if the Response is nil.
+func (r *Response) GetSchema() *highbase.SchemaProxy {
+	if r == nil {
+		return nil
+	}
+	return r.Schema
+}
+
+// GetHeaders returns the Headers of the Response, or the zero value if the Response is nil.
+func (r *Response) GetHeaders() *orderedmap.Map[string, *Header] {
+	if r == nil {
+		return nil
+	}
+	return r.Headers
+}
+
+// GetExamples returns the Examples of the Response, or the zero value if the Response is nil.
+func (r *Response) GetExamples() *Example {
+	if r == nil {
+		return nil
+	}
+	return r.Examples
+}
+
+// GetExtensions returns the Extensions of the Response, or the zero value if the Response is nil.
+func (r *Response) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if r == nil {
+		return nil
+	}
+	return r.Extensions
+}
+
+// GetCodes returns the Codes of the Responses, or the zero value if the Responses is nil.
+func (r *Responses) GetCodes() *orderedmap.Map[string, *Response] {
+	if r == nil {
+		return nil
+	}
+	return r.Codes
+}
+
+// GetDefault returns the Default of the Responses, or the zero value if the Responses is nil.
+func (r *Responses) GetDefault() *Response {
+	if r == nil {
+		return nil
+	}
+	return r.Default
+}
+
+// GetExtensions returns the Extensions of the Responses, or the zero value if the Responses is nil.
+func (r *Responses) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if r == nil {
+		return nil
+	}
+	return r.Extensions
+}
+
+// GetDefinitions returns the Definitions of the ResponsesDefinitions, or the zero value if the ResponsesDefinitions is nil.
+func (r *ResponsesDefinitions) GetDefinitions() *orderedmap.Map[string, *Response] {
+	if r == nil {
+		return nil
+	}
+	return r.Definitions
+}
+
+// GetValues returns the Values of the Scopes, or the zero value if the Scopes is nil.
+func (s *Scopes) GetValues() *orderedmap.Map[string, string] {
+	if s == nil {
+		return nil
+	}
+	return s.Values
+}
+
+// GetDefinitions returns the Definitions of the SecurityDefinitions, or the zero value if the SecurityDefinitions is nil.
+func (sd *SecurityDefinitions) GetDefinitions() *orderedmap.Map[string, *SecurityScheme] {
+	if sd == nil {
+		return nil
+	}
+	return sd.Definitions
+}
+
+// GetType returns the Type of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetType() string {
+	if s == nil {
+		return ""
+	}
+	return s.Type
+}
+
+// GetDescription returns the Description of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetDescription() string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+// GetName returns the Name of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetName() string {
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
+
+// GetIn returns the In of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetIn() string {
+	if s == nil {
+		return ""
+	}
+	return s.In
+}
+
+// GetFlow returns the Flow of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetFlow() string {
+	if s == nil {
+		return ""
+	}
+	return s.Flow
+}
+
+// GetAuthorizationUrl returns the AuthorizationUrl of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetAuthorizationUrl() string {
+	if s == nil {
+		return ""
+	}
+	return s.AuthorizationUrl
+}
+
+// GetTokenUrl returns the TokenUrl of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetTokenUrl() string {
+	if s == nil {
+		return ""
+	}
+	return s.TokenUrl
+}
+
+// GetScopes returns the Scopes of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetScopes() *Scopes {
+	if s == nil {
+		return nil
+	}
+	return s.Scopes
+}
+
+// GetExtensions returns the Extensions of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetSwagger returns the Swagger of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetSwagger() string {
+	if s == nil {
+		return ""
+	}
+	return s.Swagger
+}
+
+// GetInfo returns the Info of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetInfo() *highbase.Info {
+	if s == nil {
+		return nil
+	}
+	return s.Info
+}
+
+// GetHost returns the Host of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetHost() string {
+	if s == nil {
+		return ""
+	}
+	return s.Host
+}
+
+// GetBasePath returns the BasePath of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetBasePath() string {
+	if s == nil {
+		return ""
+	}
+	return s.BasePath
+}
+
+// GetSchemes returns the Schemes of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetSchemes() []string {
+	if s == nil {
+		return nil
+	}
+	return s.Schemes
+}
+
+// GetConsumes returns the Consumes of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetConsumes() []string {
+	if s == nil {
+		return nil
+	}
+	return s.Consumes
+}
+
+// GetProduces returns the Produces of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetProduces() []string {
+	if s == nil {
+		return nil
+	}
+	return s.Produces
+}
+
+// GetPaths returns the Paths of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetPaths() *Paths {
+	if s == nil {
+		return nil
+	}
+	return s.Paths
+}
+
+// GetDefinitions returns the Definitions of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetDefinitions() *Definitions {
+	if s == nil {
+		return nil
+	}
+	return s.Definitions
+}
+
+// GetParameters returns the Parameters of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetParameters() *ParameterDefinitions {
+	if s == nil {
+		return nil
+	}
+	return s.Parameters
+}
+
+// GetResponses returns the Responses of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetResponses() *ResponsesDefinitions {
+	if s == nil {
+		return nil
+	}
+	return s.Responses
+}
+
+// GetSecurityDefinitions returns the SecurityDefinitions of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetSecurityDefinitions() *SecurityDefinitions {
+	if s == nil {
+		return nil
+	}
+	return s.SecurityDefinitions
+}
+
+// GetSecurity returns the Security of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetSecurity() []*highbase.SecurityRequirement {
+	if s == nil {
+		return nil
+	}
+	return s.Security
+}
+
+// GetTags returns the Tags of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetTags() []*highbase.Tag {
+	if s == nil {
+		return nil
+	}
+	return s.Tags
+}
+
+// GetExternalDocs returns the ExternalDocs of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetExternalDocs() *highbase.ExternalDoc {
+	if s == nil {
+		return nil
+	}
+	return s.ExternalDocs
+}
+
+// GetExtensions returns the Extensions of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetUnclaimed returns the Unclaimed of the Swagger, or the zero value if the Swagger is nil.
+func (s *Swagger) GetUnclaimed() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Unclaimed
+}
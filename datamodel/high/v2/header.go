@@ -45,7 +45,7 @@ func NewHeader(header *low.Header) *Header {
 		h.Type = header.Type.Value
 	}
 	if !header.Format.IsEmpty() {
-		h.Format = header.Type.Value
+		h.Format = header.Format.Value
 	}
 	if !header.Description.IsEmpty() {
 		h.Description = header.Description.Value
@@ -106,3 +106,8 @@ func NewHeader(header *low.Header) *Header {
 func (h *Header) GoLow() *low.Header {
 	return h.low
 }
+
+// GoLowUntyped returns the low-level Header used to create the high-level one, with no type.
+func (h *Header) GoLowUntyped() any {
+	return h.low
+}
@@ -99,3 +99,8 @@ func NewItems(items *low.Items) *Items {
 func (i *Items) GoLow() *low.Items {
 	return i.low
 }
+
+// GoLowUntyped returns the low-level Items used to create the high-level one, with no type.
+func (i *Items) GoLowUntyped() any {
+	return i.low
+}
@@ -4,6 +4,8 @@
 package v2
 
 import (
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
@@ -27,8 +29,10 @@ type Operation struct {
 	Schemes      []string
 	Deprecated   bool
 	Security     []*base.SecurityRequirement
+	CodeSamples  []*base.CodeSample
 	Extensions   *orderedmap.Map[string, *yaml.Node]
 	low          *low.Operation
+	effective    effectiveCache
 }
 
 // NewOperation creates a new high-level Operation instance from a low-level one.
@@ -36,6 +40,7 @@ func NewOperation(operation *low.Operation) *Operation {
 	o := new(Operation)
 	o.low = operation
 	o.Extensions = high.ExtractExtensions(operation.Extensions)
+	o.CodeSamples = base.NewCodeSamples(operation.CodeSamples)
 	if !operation.Tags.IsEmpty() {
 		var tags []string
 		for t := range operation.Tags.Value {
@@ -94,12 +99,41 @@ func NewOperation(operation *low.Operation) *Operation {
 		for s := range operation.Security.Value {
 			sec = append(sec, base.NewSecurityRequirement(operation.Security.Value[s].Value))
 		}
-		o.Security = sec
+		if len(sec) > 0 {
+			o.Security = sec
+		} else {
+			o.Security = []*base.SecurityRequirement{} // security is defined, but empty.
+		}
 	}
 	return o
 }
 
+// EffectiveParameters returns the parameters that apply to the operation, once the parameters of the PathItem
+// the operation belongs to have been merged in. An operation-level parameter overrides a path-level parameter
+// with the same name and location (header names are case-insensitive). Referenced parameters have already been
+// resolved when the model was built. pathItem may be nil, in which case only the operation parameters are used.
+func (o *Operation) EffectiveParameters(pathItem *PathItem) []*Parameter {
+	var pathParams []*Parameter
+	if pathItem != nil {
+		pathParams = pathItem.Parameters
+	}
+	return high.MergeParameters(pathParams, o.Parameters, parameterKey)
+}
+
+// parameterKey identifies a parameter by its location and name.
+func parameterKey(p *Parameter) string {
+	if strings.EqualFold(p.In, "header") {
+		return "header:" + strings.ToLower(p.Name)
+	}
+	return p.In + ":" + p.Name
+}
+
 // GoLow returns the low-level operation used to create the high-level one.
 func (o *Operation) GoLow() *low.Operation {
 	return o.low
 }
+
+// GoLowUntyped returns the low-level Operation used to create the high-level one, with no type.
+func (o *Operation) GoLowUntyped() any {
+	return o.low
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation_CodeSamples(t *testing.T) {
+	yml := `swagger: "2.0"
+paths:
+  /pets:
+    get:
+      x-code-samples:
+        - lang: Shell
+          source: curl /pets
+      responses:
+        "200":
+          description: ok
+    post:
+      responses:
+        "201":
+          description: added`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	doc := NewSwaggerDocument(lowDoc)
+
+	get := doc.Paths.PathItems.GetOrZero("/pets").Get
+	assert.Equal(t, "curl /pets", get.GetCodeSample("shell").Source)
+
+	get.SetCodeSample(&base.CodeSample{Lang: "Go", Source: "client.ListPets()"})
+	assert.Len(t, get.Extensions.GetOrZero(lowbase.CodeSamplesLegacyLabel).Content, 2)
+	assert.True(t, get.RemoveCodeSample("Shell"))
+
+	count := doc.InjectCodeSamples(func(op *SelectedOperation) []*base.CodeSample {
+		return []*base.CodeSample{{Lang: "Shell", Source: "curl -X " + op.Method + " " + op.Path}}
+	})
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "curl -X post /pets", doc.Paths.PathItems.GetOrZero("/pets").Post.GetCodeSample("Shell").Source)
+	assert.Len(t, get.CodeSamples, 2)
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// EffectiveOperation is a denormalized snapshot of a Swagger Operation, with everything it inherits from the PathItem
+// and Swagger document it belongs to merged in and resolved. It's the view most runtime consumers (routers,
+// validators, gateways, mock servers) want of an operation.
+type EffectiveOperation struct {
+	// Operation and PathItem the snapshot was created from.
+	Operation *Operation
+	PathItem  *PathItem
+
+	// Parameters are the path-level and operation-level parameters merged, see Operation.EffectiveParameters.
+	Parameters []*Parameter
+
+	// Security contains the requirements that apply to the operation, the operation overrides the document.
+	// An empty (non-nil) slice means security has been explicitly removed for the operation.
+	Security []*base.SecurityRequirement
+
+	// SecuritySchemes contains every scheme named by Security, resolved from the document securityDefinitions.
+	SecuritySchemes *orderedmap.Map[string, *SecurityScheme]
+
+	// UnresolvedSecurity contains the names used by Security that don't exist in the document securityDefinitions.
+	UnresolvedSecurity []string
+
+	// Consumes, Produces and Schemes that apply to the operation, the operation overrides the document.
+	Consumes []string
+	Produces []string
+	Schemes  []string
+
+	// Host and BasePath of the document the operation belongs to.
+	Host     string
+	BasePath string
+
+	// RequestSchema is the resolved schema of the body parameter (if there is one).
+	RequestSchema *base.Schema
+
+	// ResponseSchemas contains the resolved schema of every response, keyed by status code (and 'default').
+	// Responses without a schema are skipped.
+	ResponseSchemas *orderedmap.Map[string, *base.Schema]
+}
+
+type effectiveKey struct {
+	document *Swagger
+	pathItem *PathItem
+}
+
+// effectiveCache holds the snapshots created for an operation.
+type effectiveCache struct {
+	lock      sync.Mutex
+	snapshots map[effectiveKey]*EffectiveOperation
+}
+
+// Effective returns an EffectiveOperation snapshot of the operation, as it belongs to pathItem in doc. Either can be
+// nil, in which case nothing is inherited from them.
+//
+// The snapshot is computed once and cached for each document and path item, so it's cheap to call repeatedly. The
+// snapshot does not track changes made to the model after it was created, use ResetEffective to discard it.
+func (o *Operation) Effective(doc *Swagger, pathItem *PathItem) *EffectiveOperation {
+	o.effective.lock.Lock()
+	defer o.effective.lock.Unlock()
+	key := effectiveKey{document: doc, pathItem: pathItem}
+	if eo, ok := o.effective.snapshots[key]; ok {
+		return eo
+	}
+	eo := o.buildEffective(doc, pathItem)
+	if o.effective.snapshots == nil {
+		o.effective.snapshots = make(map[effectiveKey]*EffectiveOperation)
+	}
+	o.effective.snapshots[key] = eo
+	return eo
+}
+
+// ResetEffective discards any snapshots cached by Effective.
+func (o *Operation) ResetEffective() {
+	o.effective.lock.Lock()
+	defer o.effective.lock.Unlock()
+	o.effective.snapshots = nil
+}
+
+func (o *Operation) buildEffective(doc *Swagger, pathItem *PathItem) *EffectiveOperation {
+	eo := &EffectiveOperation{
+		Operation:       o,
+		PathItem:        pathItem,
+		Parameters:      o.EffectiveParameters(pathItem),
+		SecuritySchemes: orderedmap.New[string, *SecurityScheme](),
+		Security:        o.Security,
+		Consumes:        o.Consumes,
+		Produces:        o.Produces,
+		Schemes:         o.Schemes,
+	}
+
+	if doc != nil {
+		// security is only inherited when the operation does not define any (an empty list is a definition).
+		if eo.Security == nil {
+			eo.Security = doc.Security
+		}
+		if len(eo.Consumes) == 0 {
+			eo.Consumes = doc.Consumes
+		}
+		if len(eo.Produces) == 0 {
+			eo.Produces = doc.Produces
+		}
+		if len(eo.Schemes) == 0 {
+			eo.Schemes = doc.Schemes
+		}
+		eo.Host = doc.Host
+		eo.BasePath = doc.BasePath
+	}
+
+	var schemes *orderedmap.Map[string, *SecurityScheme]
+	if doc != nil && doc.SecurityDefinitions != nil {
+		schemes = doc.SecurityDefinitions.Definitions
+	}
+	for _, req := range eo.Security {
+		if req == nil {
+			continue
+		}
+		for name := range req.Requirements.KeysFromOldest() {
+			if _, ok := eo.SecuritySchemes.Get(name); ok {
+				continue
+			}
+			if schemes != nil {
+				if ss, ok := schemes.Get(name); ok {
+					eo.SecuritySchemes.Set(name, ss)
+					continue
+				}
+			}
+			eo.UnresolvedSecurity = append(eo.UnresolvedSecurity, name)
+		}
+	}
+
+	for _, p := range eo.Parameters {
+		if p.In == "body" && p.Schema != nil {
+			eo.RequestSchema = p.Schema.Schema()
+			break
+		}
+	}
+	if o.Responses != nil {
+		eo.ResponseSchemas = orderedmap.New[string, *base.Schema]()
+		for code, resp := range o.Responses.Codes.FromOldest() {
+			if resp != nil && resp.Schema != nil {
+				if s := resp.Schema.Schema(); s != nil {
+					eo.ResponseSchemas.Set(code, s)
+				}
+			}
+		}
+		if o.Responses.Default != nil && o.Responses.Default.Schema != nil {
+			if s := o.Responses.Default.Schema.Schema(); s != nil {
+				eo.ResponseSchemas.Set("default", s)
+			}
+		}
+	}
+	return eo
+}
+
+// CascadedExtensions returns the effective extensions of the operation, with extensions defined by the document,
+// the tags of the operation and pathItem cascaded down to it, following the precedence of cascade (a nil cascade
+// uses high.DefaultExtensionPrecedence). Tags are used in the order they are listed by the operation, doc and
+// pathItem can be nil.
+func (o *Operation) CascadedExtensions(doc *Swagger, pathItem *PathItem,
+	cascade *high.ExtensionCascade,
+) *orderedmap.Map[string, *high.CascadedExtension] {
+	sources := []high.ExtensionSource{{Level: high.ExtensionLevelOperation, Name: o.OperationId, Extensions: o.Extensions}}
+	if pathItem != nil {
+		var path string
+		if doc != nil && doc.Paths != nil {
+			for p, pi := range doc.Paths.PathItems.FromOldest() {
+				if pi == pathItem {
+					path = p
+					break
+				}
+			}
+		}
+		sources = append(sources, high.ExtensionSource{
+			Level: high.ExtensionLevelPathItem, Name: path, Extensions: pathItem.Extensions,
+		})
+	}
+	if doc != nil {
+		for _, name := range o.Tags {
+			for _, tag := range doc.Tags {
+				if tag != nil && tag.Name == name {
+					sources = append(sources, high.ExtensionSource{
+						Level: high.ExtensionLevelTag, Name: name, Extensions: tag.Extensions,
+					})
+					break
+				}
+			}
+		}
+		sources = append(sources, high.ExtensionSource{Level: high.ExtensionLevelDocument, Extensions: doc.Extensions})
+	}
+	return cascade.Resolve(sources...)
+}
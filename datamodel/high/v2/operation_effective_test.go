@@ -0,0 +1,155 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation_Effective(t *testing.T) {
+	yml := `swagger: "2.0"
+host: api.example.com
+basePath: /v1
+schemes: [https]
+consumes: [application/json]
+produces: [application/json]
+security:
+  - apiKey: []
+securityDefinitions:
+  apiKey:
+    type: apiKey
+    in: header
+    name: X-API-Key
+paths:
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        type: string
+    get:
+      responses:
+        "200":
+          description: ok
+          schema:
+            $ref: '#/definitions/Pet'
+        "404":
+          description: no schema
+        default:
+          description: error
+          schema:
+            type: string
+    put:
+      consumes: [application/xml]
+      schemes: [http]
+      security:
+        - missing: []
+      parameters:
+        - name: pet
+          in: body
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        "204":
+          description: done
+    delete:
+      security: []
+definitions:
+  Pet:
+    type: object
+    description: a pet`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+
+	doc := NewSwaggerDocument(lowDoc)
+	pathItem := doc.Paths.PathItems.GetOrZero("/pets/{petId}")
+
+	get := pathItem.Get.Effective(doc, pathItem)
+	assert.Same(t, pathItem.Get, get.Operation)
+	assert.Len(t, get.Parameters, 1)
+	assert.Equal(t, doc.Security, get.Security)
+	assert.Equal(t, "X-API-Key", get.SecuritySchemes.GetOrZero("apiKey").Name)
+	assert.Empty(t, get.UnresolvedSecurity)
+	assert.Equal(t, []string{"application/json"}, get.Consumes)
+	assert.Equal(t, []string{"application/json"}, get.Produces)
+	assert.Equal(t, []string{"https"}, get.Schemes)
+	assert.Equal(t, "api.example.com", get.Host)
+	assert.Equal(t, "/v1", get.BasePath)
+	assert.Nil(t, get.RequestSchema)
+	assert.Equal(t, 2, get.ResponseSchemas.Len())
+	assert.Equal(t, "a pet", get.ResponseSchemas.GetOrZero("200").Description)
+	assert.Equal(t, []string{"string"}, get.ResponseSchemas.GetOrZero("default").Type)
+
+	// snapshots are cached.
+	assert.Same(t, get, pathItem.Get.Effective(doc, pathItem))
+	pathItem.Get.ResetEffective()
+	assert.NotSame(t, get, pathItem.Get.Effective(doc, pathItem))
+
+	put := pathItem.Put.Effective(doc, pathItem)
+	assert.Len(t, put.Parameters, 2)
+	assert.Equal(t, []string{"application/xml"}, put.Consumes)
+	assert.Equal(t, []string{"application/json"}, put.Produces)
+	assert.Equal(t, []string{"http"}, put.Schemes)
+	assert.Equal(t, 0, put.SecuritySchemes.Len())
+	assert.Equal(t, []string{"missing"}, put.UnresolvedSecurity)
+	assert.Equal(t, "a pet", put.RequestSchema.Description)
+
+	del := pathItem.Delete.Effective(doc, pathItem)
+	assert.NotNil(t, del.Security)
+	assert.Empty(t, del.Security)
+	assert.Nil(t, del.ResponseSchemas)
+
+	none := (&Operation{}).Effective(nil, nil)
+	assert.Nil(t, none.Security)
+	assert.Empty(t, none.Host)
+}
+
+func TestOperation_CascadedExtensions(t *testing.T) {
+	yml := `swagger: "2.0"
+x-ratelimit-tier: bronze
+tags:
+  - name: pets
+    x-ratelimit-tier: silver
+paths:
+  /pets:
+    x-cache: short
+    get:
+      operationId: listPets
+      tags: [pets]
+      x-internal: true
+      responses:
+        "200":
+          description: ok
+    post:
+      operationId: createPet
+      x-ratelimit-tier: gold
+      responses:
+        "200":
+          description: ok`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+
+	doc := NewSwaggerDocument(lowDoc)
+	pathItem := doc.Paths.PathItems.GetOrZero("/pets")
+
+	get := pathItem.Get.CascadedExtensions(doc, pathItem, nil)
+	assert.Equal(t, 3, get.Len())
+	assert.Equal(t, "silver", get.GetOrZero("x-ratelimit-tier").Value.Value)
+	assert.Equal(t, high.ExtensionLevelTag, get.GetOrZero("x-ratelimit-tier").Level)
+	assert.Equal(t, "/pets", get.GetOrZero("x-cache").Source)
+
+	post := pathItem.Post.CascadedExtensions(doc, pathItem, nil)
+	assert.Equal(t, "gold", post.GetOrZero("x-ratelimit-tier").Value.Value)
+	assert.Equal(t, "createPet", post.GetOrZero("x-ratelimit-tier").Source)
+}
@@ -165,3 +165,8 @@ func NewParameter(parameter *low.Parameter) *Parameter {
 func (p *Parameter) GoLow() *low.Parameter {
 	return p.low
 }
+
+// GoLowUntyped returns the low-level Parameter used to create the high-level one, with no type.
+func (p *Parameter) GoLowUntyped() any {
+	return p.low
+}
@@ -46,3 +46,8 @@ func NewParametersDefinitions(parametersDefinitions *low.ParameterDefinitions) *
 func (p *ParameterDefinitions) GoLow() *low.ParameterDefinitions {
 	return p.low
 }
+
+// GoLowUntyped returns the low-level Parameter Definitions used to create the high-level one, with no type.
+func (p *ParameterDefinitions) GoLowUntyped() any {
+	return p.low
+}
@@ -110,6 +110,11 @@ func (p *PathItem) GoLow() *lowV2.PathItem {
 	return p.low
 }
 
+// GoLowUntyped returns the low-level Path Item used to create the high-level one, with no type.
+func (p *PathItem) GoLowUntyped() any {
+	return p.low
+}
+
 func (p *PathItem) GetOperations() *orderedmap.Map[string, *Operation] {
 	o := orderedmap.New[string, *Operation]()
 
@@ -95,3 +95,43 @@ func TestPathItem_NewPathItem_WithParameters(t *testing.T) {
 	})
 	assert.NotNil(t, pi.Parameters)
 }
+
+func TestPathItem_EffectiveParameters(t *testing.T) {
+	yml := `parameters:
+  - name: petId
+    in: path
+    required: true
+  - name: limit
+    in: query
+    description: path limit
+get:
+  parameters:
+    - name: limit
+      in: query
+      description: operation limit
+    - name: body
+      in: body
+post:
+  description: no parameters
+`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n lowV2.PathItem
+	_ = low.BuildModel(&idxNode, &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	r := NewPathItem(&n)
+
+	params := r.Get.EffectiveParameters(r)
+	assert.Len(t, params, 3)
+	assert.Equal(t, "petId", params[0].Name)
+	assert.Equal(t, "operation limit", params[1].Description)
+	assert.Equal(t, "body", params[2].In)
+
+	params = r.Post.EffectiveParameters(r)
+	assert.Len(t, params, 2)
+	assert.Equal(t, "path limit", params[1].Description)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -43,7 +44,67 @@ func NewPaths(paths *v2low.Paths) *Paths {
 	return p
 }
 
+// SelectedOperation is an Operation selected by Paths.SelectOperations, along with the path and method it belongs to.
+type SelectedOperation struct {
+	Path      string
+	Method    string
+	PathItem  *PathItem
+	Operation *Operation
+}
+
+// Select returns every PathItem with a path matching the selector, in document order. Selectors are globs
+// (`/users/**`) or regular expressions (`regex:^/users/[^/]+$`), see utils.PathSelector for the syntax. If methods
+// are supplied, only path items with at least one operation using one of those methods are selected.
+func (p *Paths) Select(selector string, methods ...string) (*orderedmap.Map[string, *PathItem], error) {
+	ps, err := utils.CompilePathSelector(selector, methods...)
+	if err != nil {
+		return nil, err
+	}
+	selected := orderedmap.New[string, *PathItem]()
+	for path, pathItem := range p.PathItems.FromOldest() {
+		if pathItem == nil || !ps.MatchPath(path) {
+			continue
+		}
+		for method := range pathItem.GetOperations().KeysFromOldest() {
+			if ps.MatchMethod(method) {
+				selected.Set(path, pathItem)
+				break
+			}
+		}
+	}
+	return selected, nil
+}
+
+// SelectOperations returns every operation with a path matching the selector, and a method matching one of the
+// supplied methods (or any method, if none are supplied). Operations are returned in document order. See Select
+// for the selector syntax.
+func (p *Paths) SelectOperations(selector string, methods ...string) ([]*SelectedOperation, error) {
+	ps, err := utils.CompilePathSelector(selector, methods...)
+	if err != nil {
+		return nil, err
+	}
+	var selected []*SelectedOperation
+	for path, pathItem := range p.PathItems.FromOldest() {
+		if pathItem == nil || !ps.MatchPath(path) {
+			continue
+		}
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			if ps.MatchMethod(method) {
+				selected = append(selected, &SelectedOperation{
+					Path: path, Method: method, PathItem: pathItem, Operation: op,
+				})
+			}
+		}
+	}
+	return selected, nil
+}
+
 // GoLow returns the low-level Paths instance that backs the high level one.
 func (p *Paths) GoLow() *v2low.Paths {
 	return p.low
 }
+
+// GoLowUntyped returns the low-level Paths used to create the high-level one, with no type.
+func (p *Paths) GoLowUntyped() any {
+	return p.low
+}
@@ -4,6 +4,8 @@
 package v2
 
 import (
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -44,7 +46,26 @@ func NewResponse(response *lowv2.Response) *Response {
 	return r
 }
 
+// FindHeader will locate a Header by name, header names are matched case-insensitively. Returns nil if the
+// header does not exist.
+func (r *Response) FindHeader(name string) *Header {
+	if r == nil {
+		return nil
+	}
+	for k, h := range r.Headers.FromOldest() {
+		if strings.EqualFold(k, name) {
+			return h
+		}
+	}
+	return nil
+}
+
 // GoLow will return the low-level Response instance used to create the high level one.
 func (r *Response) GoLow() *lowv2.Response {
 	return r.low
 }
+
+// GoLowUntyped returns the low-level Response used to create the high-level one, with no type.
+func (r *Response) GoLowUntyped() any {
+	return r.low
+}
@@ -4,6 +4,8 @@
 package v2
 
 import (
+	"fmt"
+
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
 	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
@@ -49,7 +51,23 @@ func NewResponses(responses *low.Responses) *Responses {
 	return r
 }
 
+// FindResponseByCode is a shortcut for looking up code by an integer vs. a string
+func (r *Responses) FindResponseByCode(code int) *Response {
+	return r.Codes.GetOrZero(fmt.Sprintf("%d", code))
+}
+
+// FindHeader is a shortcut for locating a Header (case-insensitively) in the Response for an HTTP response code.
+// Returns nil if the response or the header does not exist.
+func (r *Responses) FindHeader(code int, name string) *Header {
+	return r.FindResponseByCode(code).FindHeader(name)
+}
+
 // GoLow will return the low-level object used to create the high-level one.
 func (r *Responses) GoLow() *low.Responses {
 	return r.low
 }
+
+// GoLowUntyped returns the low-level Responses used to create the high-level one, with no type.
+func (r *Responses) GoLowUntyped() any {
+	return r.low
+}
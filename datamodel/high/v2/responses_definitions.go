@@ -46,3 +46,8 @@ func NewResponsesDefinitions(responsesDefinitions *low.ResponsesDefinitions) *Re
 func (r *ResponsesDefinitions) GoLow() *low.ResponsesDefinitions {
 	return r.low
 }
+
+// GoLowUntyped returns the low-level Responses Definitions used to create the high-level one, with no type.
+func (r *ResponsesDefinitions) GoLowUntyped() any {
+	return r.low
+}
@@ -30,3 +30,8 @@ func NewScopes(scopes *lowv2.Scopes) *Scopes {
 func (s *Scopes) GoLow() *lowv2.Scopes {
 	return s.low
 }
+
+// GoLowUntyped returns the low-level Scopes used to create the high-level one, with no type.
+func (s *Scopes) GoLowUntyped() any {
+	return s.low
+}
@@ -46,3 +46,8 @@ func NewSecurityDefinitions(definitions *low.SecurityDefinitions) *SecurityDefin
 func (sd *SecurityDefinitions) GoLow() *low.SecurityDefinitions {
 	return sd.low
 }
+
+// GoLowUntyped returns the low-level Security Definitions used to create the high-level one, with no type.
+func (sd *SecurityDefinitions) GoLowUntyped() any {
+	return sd.low
+}
@@ -66,3 +66,8 @@ func NewSecurityScheme(securityScheme *low.SecurityScheme) *SecurityScheme {
 func (s *SecurityScheme) GoLow() *low.SecurityScheme {
 	return s.low
 }
+
+// GoLowUntyped returns the low-level Security Scheme used to create the high-level one, with no type.
+func (s *SecurityScheme) GoLowUntyped() any {
+	return s.low
+}
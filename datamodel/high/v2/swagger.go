@@ -9,11 +9,17 @@
 // API, which provides fine grain detail to the underlying AST powering the data, lines, columns, raw nodes etc.
 //
 // IMPORTANT: As a general rule, Swagger / OpenAPI 2 should be avoided for new projects.
+//
+// Fields can also be read with nil-safe getters (see getters.go), which return a zero value when the model is nil,
+// such as op.GetResponses().GetDefault().GetSchema().
 package v2
 
+//go:generate go run ../internal/getters
+
 import (
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"gopkg.in/yaml.v3"
@@ -92,7 +98,14 @@ type Swagger struct {
 
 	// Extensions contains all custom extensions defined for the top-level document.
 	Extensions *orderedmap.Map[string, *yaml.Node]
-	low        *low.Swagger
+
+	// Unclaimed contains all root keys that are not part of the Swagger specification and are not extensions.
+	// Unclaimed content is never rendered, the positions of each key (and diagnostics) are available from the
+	// low-level Swagger document.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Unclaimed *orderedmap.Map[string, *yaml.Node] `yaml:"-"`
+	low       *low.Swagger
 }
 
 // NewSwaggerDocument will create a new high-level Swagger document from a low-level one.
@@ -100,6 +113,9 @@ func NewSwaggerDocument(document *low.Swagger) *Swagger {
 	d := new(Swagger)
 	d.low = document
 	d.Extensions = high.ExtractExtensions(document.Extensions)
+	if orderedmap.Len(document.Unclaimed) > 0 {
+		d.Unclaimed = lowmodel.FromReferenceMap(document.Unclaimed)
+	}
 	if !document.Info.IsEmpty() {
 		d.Info = base.NewInfo(document.Info.Value)
 	}
@@ -174,3 +190,8 @@ func NewSwaggerDocument(document *low.Swagger) *Swagger {
 func (s *Swagger) GoLow() *low.Swagger {
 	return s.low
 }
+
+// GoLowUntyped returns the low-level Swagger used to create the high-level one, with no type.
+func (s *Swagger) GoLowUntyped() any {
+	return s.low
+}
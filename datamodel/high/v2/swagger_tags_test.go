@@ -0,0 +1,49 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v2
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwagger_OperationsByTag(t *testing.T) {
+	yml := `swagger: "2.0"
+tags:
+  - name: store
+  - name: pets
+  - name: admin
+paths:
+  /pets:
+    get:
+      tags: [pets, beta]
+      responses:
+        "200":
+          description: ok
+  /orders:
+    get:
+      tags: [store]
+      responses:
+        "200":
+          description: ok`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	doc := NewSwaggerDocument(lowDoc)
+
+	byTag := doc.OperationsByTag()
+	assert.Equal(t, []string{"store", "pets", "beta"}, slices.Collect(byTag.KeysFromOldest()))
+	require.Len(t, byTag.GetOrZero("pets"), 1)
+	assert.Equal(t, "/pets", byTag.GetOrZero("pets")[0].Path)
+
+	assert.Equal(t, []string{"beta"}, doc.UndeclaredTags())
+	require.Len(t, doc.UnusedTags(), 1)
+	assert.Equal(t, "admin", doc.UnusedTags()[0].Name)
+}
@@ -5,6 +5,7 @@ package v2
 
 import (
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel"
@@ -303,3 +304,58 @@ func TestNewSwaggerDocument_Responses(t *testing.T) {
 	assert.Equal(t, 107, wentLower.Schema.KeyNode.Line)
 	assert.Equal(t, 11, wentLower.Schema.KeyNode.Column)
 }
+
+func TestNewSwaggerDocument_Responses_FindHeader(t *testing.T) {
+	initTest()
+	highDoc := NewSwaggerDocument(doc)
+	login := highDoc.Paths.PathItems.GetOrZero("/user/login").Get
+
+	h := login.Responses.FindHeader(200, "x-expires-after")
+	assert.NotNil(t, h)
+	assert.Equal(t, "date-time", h.Format)
+	assert.Equal(t, h, login.Responses.FindResponseByCode(200).FindHeader("X-EXPIRES-AFTER"))
+	assert.Nil(t, login.Responses.FindHeader(200, "x-nope"))
+	assert.Nil(t, login.Responses.FindHeader(500, "x-expires-after"))
+	assert.NotNil(t, login.Responses.GoLow().FindHeader("200", "X-Expires-after"))
+	assert.Nil(t, login.Responses.GoLow().FindHeader("500", "X-Expires-after"))
+}
+
+func TestPaths_Select(t *testing.T) {
+	initTest()
+	h := NewSwaggerDocument(doc)
+
+	selected, err := h.Paths.Select("/pet/**")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/pet/{petId}/uploadImage", "/pet", "/pet/findByStatus", "/pet/findByTags", "/pet/{petId}"},
+		slices.Collect(selected.KeysFromOldest()))
+
+	selected, err = h.Paths.Select("/store/*", "get")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/store/inventory"}, slices.Collect(selected.KeysFromOldest()))
+
+	ops, err := h.Paths.SelectOperations("regex:^/pet/\\{petId\\}$")
+	assert.NoError(t, err)
+	assert.Len(t, ops, 3)
+	assert.Equal(t, "get", ops[0].Method)
+	assert.Equal(t, "/pet/{petId}", ops[0].Path)
+	assert.Same(t, h.Paths.PathItems.GetOrZero("/pet/{petId}").Get, ops[0].Operation)
+
+	_, err = h.Paths.Select("regex:(")
+	assert.Error(t, err)
+	_, err = h.Paths.SelectOperations("regex:(")
+	assert.Error(t, err)
+}
+
+func TestNewSwaggerDocument_Unclaimed(t *testing.T) {
+	yml := `swagger: "2.0"
+servers:
+  - url: https://api.example.com`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := v2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	h := NewSwaggerDocument(lDoc)
+
+	assert.Equal(t, 1, orderedmap.Len(h.Unclaimed))
+	assert.NotNil(t, h.Unclaimed.GetOrZero("servers"))
+}
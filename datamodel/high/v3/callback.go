@@ -67,12 +67,14 @@ func (c *Callback) MarshalYAML() (interface{}, error) {
 		line     int
 		style    yaml.Style
 		rendered *yaml.Node
+		key      *yaml.Node
 	}
 	var mapped []*pathItem
 
 	for k, pi := range c.Expression.FromOldest() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		var style yaml.Style
+		var key *yaml.Node
 		if c.low != nil {
 			lpi := c.low.FindExpression(k)
 			if lpi != nil {
@@ -82,11 +84,12 @@ func (c *Callback) MarshalYAML() (interface{}, error) {
 			for lk := range c.low.Expression.KeysFromOldest() {
 				if lk.Value == k {
 					style = lk.KeyNode.Style
+					key = lk.KeyNode
 					break
 				}
 			}
 		}
-		mapped = append(mapped, &pathItem{pi, k, ln, style, nil})
+		mapped = append(mapped, &pathItem{pi, k, ln, style, nil, key})
 	}
 
 	nb := high.NewNodeBuilder(c, c.low)
@@ -100,7 +103,7 @@ func (c *Callback) MarshalYAML() (interface{}, error) {
 			}
 			mapped = append(mapped, &pathItem{
 				nil, label,
-				extNode.Content[u].Line, 0, extNode.Content[u],
+				extNode.Content[u].Line, 0, extNode.Content[u], extNode.Content[u-1],
 			})
 		}
 	}
@@ -114,12 +117,15 @@ func (c *Callback) MarshalYAML() (interface{}, error) {
 
 			kn := utils.CreateStringNode(mp.path)
 			kn.Style = mp.style
+			low.CopyComments(kn, mp.key)
 
 			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, rendered.(*yaml.Node))
 		}
 		if mp.rendered != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mp.path))
+			kn := utils.CreateStringNode(mp.path)
+			low.CopyComments(kn, mp.key)
+			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, mp.rendered)
 		}
 	}
@@ -136,12 +142,14 @@ func (c *Callback) MarshalYAMLInline() (interface{}, error) {
 		line     int
 		style    yaml.Style
 		rendered *yaml.Node
+		key      *yaml.Node
 	}
 	var mapped []*pathItem
 
 	for k, pi := range c.Expression.FromOldest() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		var style yaml.Style
+		var key *yaml.Node
 		if c.low != nil {
 			lpi := c.low.FindExpression(k)
 			if lpi != nil {
@@ -151,11 +159,12 @@ func (c *Callback) MarshalYAMLInline() (interface{}, error) {
 			for lk := range c.low.Expression.KeysFromOldest() {
 				if lk.Value == k {
 					style = lk.KeyNode.Style
+					key = lk.KeyNode
 					break
 				}
 			}
 		}
-		mapped = append(mapped, &pathItem{pi, k, ln, style, nil})
+		mapped = append(mapped, &pathItem{pi, k, ln, style, nil, key})
 	}
 
 	nb := high.NewNodeBuilder(c, c.low)
@@ -170,7 +179,7 @@ func (c *Callback) MarshalYAMLInline() (interface{}, error) {
 			}
 			mapped = append(mapped, &pathItem{
 				nil, label,
-				extNode.Content[u].Line, 0, extNode.Content[u],
+				extNode.Content[u].Line, 0, extNode.Content[u], extNode.Content[u-1],
 			})
 		}
 	}
@@ -184,12 +193,15 @@ func (c *Callback) MarshalYAMLInline() (interface{}, error) {
 
 			kn := utils.CreateStringNode(mp.path)
 			kn.Style = mp.style
+			low.CopyComments(kn, mp.key)
 
 			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, rendered.(*yaml.Node))
 		}
 		if mp.rendered != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mp.path))
+			kn := utils.CreateStringNode(mp.path)
+			low.CopyComments(kn, mp.key)
+			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, mp.rendered)
 		}
 	}
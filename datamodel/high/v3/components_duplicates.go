@@ -0,0 +1,140 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DuplicateSchemas is a group of component schemas that are structurally identical, they have the same hash.
+type DuplicateSchemas struct {
+	// Hash is the hex encoded hash every schema in the group shares.
+	Hash string `json:"hash" yaml:"hash"`
+
+	// Names are the names of the schemas in the group, in document order. The first name is the one kept when
+	// duplicates are collapsed.
+	Names []string `json:"names" yaml:"names"`
+}
+
+// FindDuplicateSchemas groups the component schemas that are structurally identical, using the Hash() of their
+// low-level models. Only groups of two or more schemas are returned, in the order their first schema appears.
+// Schemas that are references, or that were created without a low-level model, are never duplicates.
+func (c *Components) FindDuplicateSchemas() []*DuplicateSchemas {
+	var groups []*DuplicateSchemas
+	found := make(map[[32]byte]*DuplicateSchemas)
+	for name, sp := range c.Schemas.FromOldest() {
+		if sp == nil || sp.IsReference() || sp.GoLow() == nil {
+			continue
+		}
+		hash := sp.GoLow().Hash()
+		if group, ok := found[hash]; ok {
+			group.Names = append(group.Names, name)
+			continue
+		}
+		group := &DuplicateSchemas{Hash: hex.EncodeToString(hash[:])}
+		group.Names = append(group.Names, name)
+		found[hash] = group
+		groups = append(groups, group)
+	}
+	var duplicates []*DuplicateSchemas
+	for _, group := range groups {
+		if len(group.Names) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	return duplicates
+}
+
+// RenderWithoutDuplicateSchemas renders the Document as YAML, with every group of duplicate component schemas (see
+// Components.FindDuplicateSchemas) collapsed into the first schema of the group. Every local reference to a
+// schema that was removed, and every discriminator mapping, is repointed at the schema kept. The groups that were
+// collapsed are returned along with the rendered bytes, which can be used to create a new document.
+//
+// The Document itself is not changed.
+func (d *Document) RenderWithoutDuplicateSchemas() ([]byte, []*DuplicateSchemas, error) {
+	if d.Components == nil {
+		return nil, nil, errors.New("unable to collapse duplicate schemas, the document has no components")
+	}
+	duplicates := d.Components.FindDuplicateSchemas()
+
+	rendered := high.NewNodeBuilder(d, d.low).Render()
+	kept := make(map[string]string)
+	for _, group := range duplicates {
+		for _, name := range group.Names[1:] {
+			kept[name] = group.Names[0]
+		}
+	}
+	if len(kept) > 0 {
+		removeSchemas(rendered, kept)
+		repointSchemaRefs(rendered, kept, false)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(rendered); err != nil {
+		return nil, duplicates, err
+	}
+	return buf.Bytes(), duplicates, nil
+}
+
+// removeSchemas deletes the schemas named in removed from the components of a rendered document.
+func removeSchemas(root *yaml.Node, removed map[string]string) {
+	_, _, components := utils.FindKeyNodeFullTop("components", root.Content)
+	if components == nil {
+		return
+	}
+	_, _, schemas := utils.FindKeyNodeFullTop("schemas", components.Content)
+	if schemas == nil {
+		return
+	}
+	var content []*yaml.Node
+	for i := 0; i+1 < len(schemas.Content); i += 2 {
+		if _, ok := removed[schemas.Content[i].Value]; !ok {
+			content = append(content, schemas.Content[i], schemas.Content[i+1])
+		}
+	}
+	schemas.Content = content
+}
+
+// repointSchemaRefs rewrites every `$ref` (and discriminator mapping value, when mapping is true) that points to
+// a schema in kept, so it points to the name kept instead.
+func repointSchemaRefs(n *yaml.Node, kept map[string]string, mapping bool) {
+	if n == nil {
+		return
+	}
+	if n.Kind != yaml.MappingNode {
+		for _, c := range n.Content {
+			repointSchemaRefs(c, kept, false)
+		}
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if value.Kind == yaml.ScalarNode && (key.Value == "$ref" || mapping) {
+			value.Value = repointSchemaRef(value.Value, kept)
+			continue
+		}
+		repointSchemaRefs(value, kept, key.Value == "mapping")
+	}
+}
+
+func repointSchemaRef(ref string, kept map[string]string) string {
+	segments, err := utils.ParseJSONPointer(ref)
+	if err != nil || len(segments) < 3 || segments[0] != "components" || segments[1] != "schemas" {
+		return ref
+	}
+	name, ok := kept[segments[2]]
+	if !ok {
+		return ref
+	}
+	segments[2] = name
+	return "#" + utils.BuildJSONPointer(segments)
+}
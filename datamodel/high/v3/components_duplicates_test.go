@@ -0,0 +1,128 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var duplicateSchemasSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Animal'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Animal:
+      type: object
+      properties:
+        name:
+          type: string
+    Owner:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Creature/properties/name'
+        kind:
+          oneOf:
+            - $ref: '#/components/schemas/Pet'
+            - $ref: '#/components/schemas/Animal'
+          discriminator:
+            propertyName: name
+            mapping:
+              pet: '#/components/schemas/Pet'
+              animal: '#/components/schemas/Animal'
+    Creature:
+      type: object
+      properties:
+        name:
+          type: string
+    Alias:
+      $ref: '#/components/schemas/Pet'
+    Id:
+      type: integer
+    Count:
+      type: integer`
+
+// buildTestDocument builds a high-level document from an inline spec, failing the test if it does not build.
+func buildTestDocument(t *testing.T, spec string) *Document {
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	lowDoc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return NewDocument(lowDoc)
+}
+
+func TestComponents_FindDuplicateSchemas(t *testing.T) {
+	doc := buildTestDocument(t, duplicateSchemasSpec)
+
+	duplicates := doc.Components.FindDuplicateSchemas()
+	require.Len(t, duplicates, 2)
+	assert.Equal(t, []string{"Pet", "Animal", "Creature"}, duplicates[0].Names)
+	assert.Equal(t, []string{"Id", "Count"}, duplicates[1].Names)
+	assert.Len(t, duplicates[0].Hash, 64)
+	assert.NotEqual(t, duplicates[0].Hash, duplicates[1].Hash)
+}
+
+func TestComponents_FindDuplicateSchemas_None(t *testing.T) {
+	doc := buildTestDocument(t, `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: string
+    Id:
+      type: integer`)
+	assert.Empty(t, doc.Components.FindDuplicateSchemas())
+}
+
+func TestDocument_RenderWithoutDuplicateSchemas(t *testing.T) {
+	doc := buildTestDocument(t, duplicateSchemasSpec)
+
+	rendered, duplicates, err := doc.RenderWithoutDuplicateSchemas()
+	require.NoError(t, err)
+	require.Len(t, duplicates, 2)
+
+	collapsed := buildTestDocument(t, string(rendered))
+	var names []string
+	for name := range collapsed.Components.Schemas.KeysFromOldest() {
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{"Pet", "Owner", "Alias", "Id"}, names)
+	assert.Empty(t, collapsed.Components.FindDuplicateSchemas())
+
+	response := collapsed.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200")
+	assert.Equal(t, "#/components/schemas/Pet", response.Content.GetOrZero("application/json").Schema.GetReference())
+
+	owner := collapsed.Components.Schemas.GetOrZero("Owner").Schema()
+	assert.Equal(t, "#/components/schemas/Pet/properties/name", owner.Properties.GetOrZero("pet").GetReference())
+	kind := owner.Properties.GetOrZero("kind").Schema()
+	assert.Equal(t, "#/components/schemas/Pet", kind.OneOf[0].GetReference())
+	assert.Equal(t, "#/components/schemas/Pet", kind.OneOf[1].GetReference())
+	assert.Equal(t, "#/components/schemas/Pet", kind.Discriminator.Mapping.GetOrZero("animal"))
+	assert.Equal(t, "#/components/schemas/Pet", collapsed.Components.Schemas.GetOrZero("Alias").GetReference())
+
+	// the original document is untouched.
+	assert.Equal(t, 7, doc.Components.Schemas.Len())
+}
+
+func TestDocument_RenderWithoutDuplicateSchemas_NoComponents(t *testing.T) {
+	doc := buildTestDocument(t, "openapi: 3.1.0\npaths: {}")
+	_, _, err := doc.RenderWithoutDuplicateSchemas()
+	assert.EqualError(t, err, "unable to collapse duplicate schemas, the document has no components")
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"maps"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// match scores, higher is more specific.
+const (
+	matchExactWithParams = 100
+	matchExact           = 90
+	matchSuffix          = 60
+	matchKeySubtype      = 40
+	matchRangeSubtype    = 30
+	matchKeyAnything     = 20
+	matchRangeAnything   = 10
+)
+
+type mediaRange struct {
+	typ, subtype string
+	params       map[string]string
+	quality      float64
+}
+
+// parseMediaRange parses a single media type or range (such as `application/json; charset=utf-8` or
+// `text/*;q=0.5`). Returns false if the value is not a media type.
+func parseMediaRange(value string) (mediaRange, bool) {
+	mt, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+	if err != nil {
+		return mediaRange{}, false
+	}
+	typ, subtype, found := strings.Cut(mt, "/")
+	if !found || typ == "" || subtype == "" {
+		return mediaRange{}, false
+	}
+	mr := mediaRange{typ: typ, subtype: subtype, params: params, quality: 1}
+	if q, ok := params["q"]; ok {
+		if f, qErr := strconv.ParseFloat(q, 64); qErr == nil {
+			mr.quality = f
+		}
+		delete(params, "q")
+	}
+	return mr, true
+}
+
+// suffix returns the structured syntax suffix of a subtype, for example `json` for `vnd.api+json`.
+func suffix(subtype string) string {
+	if i := strings.LastIndex(subtype, "+"); i >= 0 {
+		return subtype[i+1:]
+	}
+	return ""
+}
+
+// scoreMediaRange returns how well a requested media range matches a media type defined in content, 0 if it
+// does not match at all.
+func scoreMediaRange(requested, defined mediaRange) int {
+	switch {
+	case requested.typ == "*" && requested.subtype == "*":
+		return matchRangeAnything
+	case defined.typ == "*" && defined.subtype == "*":
+		return matchKeyAnything
+	case requested.typ != defined.typ:
+		return 0
+	case requested.subtype == defined.subtype:
+		if maps.Equal(requested.params, defined.params) {
+			return matchExactWithParams
+		}
+		return matchExact
+	case requested.subtype == "*":
+		return matchRangeSubtype
+	case defined.subtype == "*":
+		return matchKeySubtype
+	case suffix(requested.subtype) != "" && suffix(requested.subtype) == defined.subtype:
+		// application/problem+json is a valid application/json
+		return matchSuffix
+	case suffix(defined.subtype) != "" && strings.HasPrefix(defined.subtype, "*+") &&
+		suffix(defined.subtype) == suffix(requested.subtype):
+		// application/*+json
+		return matchSuffix
+	}
+	return 0
+}
+
+// FindBestContentMatch selects the MediaType from content that best matches mediaType, using the media type
+// matching rules of RFC 9110. mediaType can be a single type (such as a Content-Type header,
+// `application/json; charset=utf-8`) or a list of media ranges (such as an Accept header,
+// `application/xml;q=0.5, application/*`).
+//
+// Media ranges are tried in order of quality (ranges with a quality of 0 are never matched). For each range, the
+// most specific match wins: an exact match (parameters included), an exact match ignoring parameters, a structured
+// syntax suffix match (`application/vnd.api+json` matches `application/json` or `application/*+json`), then
+// wildcards (`application/*` and `*/*`), whether the wildcard is in mediaType or in content. Ties are broken by the
+// order the media types are defined in content.
+//
+// Returns the key and MediaType that matched, or an empty string and nil if nothing matches.
+func FindBestContentMatch(content *orderedmap.Map[string, *MediaType], mediaType string) (string, *MediaType) {
+	var ranges []mediaRange
+	for _, v := range strings.Split(mediaType, ",") {
+		if mr, ok := parseMediaRange(v); ok && mr.quality > 0 {
+			ranges = append(ranges, mr)
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	for _, requested := range ranges {
+		bestScore := 0
+		var bestKey string
+		var best *MediaType
+		for k, mt := range content.FromOldest() {
+			defined, ok := parseMediaRange(k)
+			if !ok {
+				continue
+			}
+			if score := scoreMediaRange(requested, defined); score > bestScore {
+				bestScore, bestKey, best = score, k, mt
+			}
+		}
+		if bestScore > 0 {
+			return bestKey, best
+		}
+	}
+	return "", nil
+}
+
+// FindBestMatch selects the MediaType of the RequestBody that best matches a Content-Type header. See
+// FindBestContentMatch for the matching rules.
+func (r *RequestBody) FindBestMatch(mediaType string) (string, *MediaType) {
+	if r == nil {
+		return "", nil
+	}
+	return FindBestContentMatch(r.Content, mediaType)
+}
+
+// FindBestMatch selects the MediaType of the Response that best matches a Content-Type or Accept header. See
+// FindBestContentMatch for the matching rules.
+func (r *Response) FindBestMatch(mediaType string) (string, *MediaType) {
+	if r == nil {
+		return "", nil
+	}
+	return FindBestContentMatch(r.Content, mediaType)
+}
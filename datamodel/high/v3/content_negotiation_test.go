@@ -0,0 +1,82 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func negotiationContent(keys ...string) *orderedmap.Map[string, *MediaType] {
+	content := orderedmap.New[string, *MediaType]()
+	for _, k := range keys {
+		content.Set(k, &MediaType{})
+	}
+	return content
+}
+
+func TestFindBestContentMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   []string
+		mediaType string
+		expected  string
+	}{
+		{"exact", []string{"application/xml", "application/json"}, "application/json", "application/json"},
+		{"case", []string{"application/json"}, "Application/JSON", "application/json"},
+		{"ignore params", []string{"application/json"}, "application/json; charset=utf-8", "application/json"},
+		{
+			"params preferred", []string{"text/plain", "text/plain; charset=utf-8"},
+			"text/plain; charset=utf-8", "text/plain; charset=utf-8",
+		},
+		{"suffix", []string{"application/xml", "application/json"}, "application/problem+json", "application/json"},
+		{"suffix wildcard", []string{"application/*+json"}, "application/vnd.api+json", "application/*+json"},
+		{"exact over suffix", []string{"application/json", "application/problem+json"}, "application/problem+json", "application/problem+json"},
+		{"content wildcard", []string{"text/*", "application/*"}, "application/json", "application/*"},
+		{"content any", []string{"*/*"}, "image/png", "*/*"},
+		{"specific over wildcard", []string{"*/*", "application/*", "application/json"}, "application/json", "application/json"},
+		{"range wildcard", []string{"text/plain", "application/xml", "application/json"}, "application/*", "application/xml"},
+		{"range any", []string{"text/plain", "application/json"}, "*/*", "text/plain"},
+		{"quality", []string{"application/json", "application/xml"}, "application/json;q=0.5, application/xml", "application/xml"},
+		{"quality fallback", []string{"application/json"}, "application/xml, application/json;q=0.1", "application/json"},
+		{"quality zero", []string{"application/json"}, "application/json;q=0", ""},
+		{"no match", []string{"application/json"}, "text/plain", ""},
+		{"bad media type", []string{"application/json", "not a media type"}, "//", ""},
+		{"empty", nil, "application/json", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content := negotiationContent(tc.content...)
+			k, mt := FindBestContentMatch(content, tc.mediaType)
+			assert.Equal(t, tc.expected, k)
+			if tc.expected == "" {
+				assert.Nil(t, mt)
+			} else {
+				assert.Same(t, content.GetOrZero(tc.expected), mt)
+			}
+		})
+	}
+}
+
+func TestRequestBody_Response_FindBestMatch(t *testing.T) {
+	rb := &RequestBody{Content: negotiationContent("application/json", "application/xml")}
+	k, mt := rb.FindBestMatch("application/xml; charset=utf-8")
+	assert.Equal(t, "application/xml", k)
+	assert.NotNil(t, mt)
+
+	r := &Response{Content: negotiationContent("application/json", "application/xml")}
+	k, _ = r.FindBestMatch("text/html, application/*;q=0.8")
+	assert.Equal(t, "application/json", k)
+
+	var nilBody *RequestBody
+	k, mt = nilBody.FindBestMatch("application/json")
+	assert.Empty(t, k)
+	assert.Nil(t, mt)
+	var nilResponse *Response
+	k, mt = nilResponse.FindBestMatch("application/json")
+	assert.Empty(t, k)
+	assert.Nil(t, mt)
+}
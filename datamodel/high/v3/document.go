@@ -7,8 +7,13 @@
 // High-level models are backed by low-level ones. There is a 'GoLow()' method available on every high level
 // object. 'Going Low' allows engineers to transition from a high-level or 'porcelain' API, to a low-level 'plumbing'
 // API, which provides fine grain detail to the underlying AST powering the data, lines, columns, raw nodes etc.
+//
+// Every field can also be read with a nil-safe getter (see getters.go), which returns the zero value of the field
+// when the model is nil, so op.GetRequestBody().GetContent() doesn't panic when there is no request body.
 package v3
 
+//go:generate go run ../internal/getters
+
 import (
 	"bytes"
 
@@ -28,6 +33,11 @@ type Document struct {
 	// This is not a standard property of the OpenAPI model, it's a convenience mechanism only.
 	Version string `json:"openapi,omitempty" yaml:"openapi,omitempty"`
 
+	// Self is a 3.2+ property that is the URI of the document, it's used as the base URI for resolving relative
+	// references.
+	// - https://spec.openapis.org/oas/v3.2.0#openapi-object
+	Self string `json:"$self,omitempty" yaml:"$self,omitempty"`
+
 	// Info represents a specification Info definitions
 	// Provides metadata about the API. The metadata MAY be used by tooling as required.
 	// - https://spec.openapis.org/oas/v3.1.0#info-object
@@ -71,6 +81,13 @@ type Document struct {
 	// Extensions contains all custom extensions defined for the top-level document.
 	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 
+	// Unclaimed contains all root keys that are not part of the OpenAPI 3 specification and are not extensions.
+	// Unclaimed content is never rendered, the positions of each key (and diagnostics) are available from the
+	// low-level Document.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Unclaimed *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+
 	// JsonSchemaDialect is a 3.1+ property that sets the dialect to use for validating *base.Schema definitions
 	// The default value for the $schema keyword within Schema Objects contained within this OAS document.
 	// This MUST be in the form of a URI.
@@ -125,6 +142,9 @@ func NewDocument(document *lowv3.Document) *Document {
 	if orderedmap.Len(document.Extensions) > 0 {
 		d.Extensions = high.ExtractExtensions(document.Extensions)
 	}
+	if orderedmap.Len(document.Unclaimed) > 0 {
+		d.Unclaimed = low.FromReferenceMap(document.Unclaimed)
+	}
 	if !document.Components.IsEmpty() {
 		d.Components = NewComponents(document.Components.Value)
 	}
@@ -134,6 +154,7 @@ func NewDocument(document *lowv3.Document) *Document {
 	if !document.JsonSchemaDialect.IsEmpty() {
 		d.JsonSchemaDialect = document.JsonSchemaDialect.Value
 	}
+	d.Self = document.Self.Value
 	if !document.Webhooks.IsEmpty() {
 		d.Webhooks = low.FromReferenceMapWithFunc(document.Webhooks.Value, NewPathItem)
 	}
@@ -172,6 +193,14 @@ func (d *Document) RenderWithIndention(indent int) []byte {
 	return buf.Bytes()
 }
 
+// RenderWithConfig will return a YAML representation of the Document object as a byte slice, styled using the
+// supplied configuration (indention, sequence style, quote style and key order). A nil configuration renders the
+// same way as Render.
+func (d *Document) RenderWithConfig(config *high.RenderConfig) ([]byte, error) {
+	nb := high.NewNodeBuilder(d, d.low)
+	return config.Encode(nb.Render())
+}
+
 // RenderJSON will return a JSON representation of the Document object as a byte slice.
 func (d *Document) RenderJSON(indention string) ([]byte, error) {
 	nb := high.NewNodeBuilder(d, d.low)
@@ -183,6 +212,63 @@ func (d *Document) RenderJSON(indention string) ([]byte, error) {
 	return dat, nil
 }
 
+// RenderJSONPreserveNumbers will return a JSON representation of the Document object as a byte slice, the same
+// as RenderJSON, except numbers are rendered exactly as they were written in the source document (integers vs floats,
+// large integers and formatting are all kept), rather than being converted through float64.
+func (d *Document) RenderJSONPreserveNumbers(indention string) ([]byte, error) {
+	nb := high.NewNodeBuilder(d, d.low)
+	rendered := nb.Render()
+	if d.Index != nil {
+		high.PreserveNumberFormatting(rendered, d.Index.GetRootNode())
+	}
+	return json.YAMLNodeToJSONPreserveNumbers(rendered, indention)
+}
+
+// RenderJSONMinified will return a minified JSON representation of the Document object as a byte slice, along with
+// a json.SourceMap that links every value in the output (by JSON Pointer and byte offset) back to its location in
+// the original document.
+func (d *Document) RenderJSONMinified() ([]byte, *json.SourceMap, error) {
+	nb := high.NewNodeBuilder(d, d.low)
+	return json.YAMLNodeToMinifiedJSON(nb.Render())
+}
+
+// RenderWithOptions will return a representation of the Document object as a byte slice, in the format and with
+// the options supplied. The options are checked against the version of the document first, and if any can't be
+// honored nothing is rendered, a joined error of every *high.RenderCapabilityError is returned instead.
+func (d *Document) RenderWithOptions(options *high.RenderOptions) ([]byte, error) {
+	if err := options.Check(d.Version); err != nil {
+		return nil, err
+	}
+	if options == nil {
+		options = new(high.RenderOptions)
+	}
+	nb := high.NewNodeBuilder(d, d.low)
+	nb.Resolve = options.Inline
+	rendered := nb.Render()
+	if options.PreserveNumbers && d.Index != nil {
+		high.PreserveNumberFormatting(rendered, d.Index.GetRootNode())
+	}
+	if options.PreserveAnchors && d.Index != nil {
+		high.PreserveAnchors(rendered, d.Index.GetRootNode())
+	}
+	switch options.Format {
+	case high.RenderFormatJSON:
+		indent := options.JSONIndent
+		if indent == "" {
+			indent = "  "
+		}
+		if options.PreserveNumbers {
+			return json.YAMLNodeToJSONPreserveNumbers(rendered, indent)
+		}
+		return json.YAMLNodeToJSON(rendered, indent)
+	case high.RenderFormatJSONMinified:
+		b, _, err := json.YAMLNodeToMinifiedJSON(rendered)
+		return b, err
+	default:
+		return options.Style.Encode(rendered)
+	}
+}
+
 func (d *Document) RenderInline() ([]byte, error) {
 	di, _ := d.MarshalYAMLInline()
 	return yaml.Marshal(di)
@@ -0,0 +1,164 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// The headers of conditional requests (RFC 9110).
+const (
+	ETagHeader    = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+// ConditionalRequestKind is the convention a ConditionalRequestViolation breaks.
+type ConditionalRequestKind string
+
+const (
+	MissingETag    ConditionalRequestKind = "missingETag"    // a successful response does not declare an ETag header.
+	MissingIfMatch ConditionalRequestKind = "missingIfMatch" // a mutating operation does not accept an If-Match header.
+)
+
+// ConditionalRequestConventions are the conditional request conventions a Document is checked against. Methods are
+// lower case, the way they are keyed by PathItem.GetOperations.
+type ConditionalRequestConventions struct {
+	// ETagMethods are the methods whose successful (2xx) responses must declare an ETag header.
+	ETagMethods []string
+
+	// IfMatchMethods are the methods that must accept an If-Match header parameter.
+	IfMatchMethods []string
+
+	// RequireIfMatch marks the If-Match parameters injected by InjectConditionalRequestHeaders as required.
+	RequireIfMatch bool
+}
+
+// DefaultConditionalRequestConventions returns the conventions most API style guides mandate: reads and updates
+// return an ETag, and updates and deletes accept an (optional) If-Match header.
+func DefaultConditionalRequestConventions() *ConditionalRequestConventions {
+	return &ConditionalRequestConventions{
+		ETagMethods:    []string{lowv3.GetLabel, lowv3.HeadLabel, lowv3.PutLabel, lowv3.PatchLabel},
+		IfMatchMethods: []string{lowv3.PutLabel, lowv3.PatchLabel, lowv3.DeleteLabel},
+	}
+}
+
+// ConditionalRequestViolation is an operation that breaks a conditional request convention.
+type ConditionalRequestViolation struct {
+	Kind      ConditionalRequestKind
+	Operation *SelectedOperation
+	Code      string // the response code missing an ETag header, empty for MissingIfMatch.
+	Message   string
+}
+
+// CheckConditionalRequests checks every operation of the Document against the conventions (or the default
+// conventions when nil), and returns every violation in document order. Header names are matched case-insensitively,
+// and If-Match parameters declared by the PathItem of an operation count (see Operation.EffectiveParameters).
+func (d *Document) CheckConditionalRequests(conventions *ConditionalRequestConventions) []*ConditionalRequestViolation {
+	if conventions == nil {
+		conventions = DefaultConditionalRequestConventions()
+	}
+	var violations []*ConditionalRequestViolation
+	d.eachOperation(func(op *SelectedOperation) {
+		method := strings.ToLower(op.Method)
+		if slices.Contains(conventions.ETagMethods, method) && op.Operation.Responses != nil {
+			for code, r := range op.Operation.Responses.Codes.FromOldest() {
+				if r != nil && strings.HasPrefix(code, "2") && r.FindHeader(ETagHeader) == nil {
+					violations = append(violations, &ConditionalRequestViolation{
+						Kind:      MissingETag,
+						Operation: op,
+						Code:      code,
+						Message: fmt.Sprintf("the %s response of %s %s does not declare an %s header",
+							code, strings.ToUpper(op.Method), op.Path, ETagHeader),
+					})
+				}
+			}
+		}
+		if slices.Contains(conventions.IfMatchMethods, method) && !acceptsHeader(op, IfMatchHeader) {
+			violations = append(violations, &ConditionalRequestViolation{
+				Kind:      MissingIfMatch,
+				Operation: op,
+				Message: fmt.Sprintf("%s %s does not accept an %s header",
+					strings.ToUpper(op.Method), op.Path, IfMatchHeader),
+			})
+		}
+	})
+	return violations
+}
+
+// InjectConditionalRequestHeaders fixes every violation of the conventions (or the default conventions when nil)
+// found by CheckConditionalRequests. An ETag header is added to each successful response missing one, and an If-Match
+// header parameter is added to each operation missing one. When a response is a reference to a response of the
+// Components, the header is added to the component, a response referenced from another document is left alone.
+// Returns the number of headers and parameters added.
+func (d *Document) InjectConditionalRequestHeaders(conventions *ConditionalRequestConventions) int {
+	if conventions == nil {
+		conventions = DefaultConditionalRequestConventions()
+	}
+	count := 0
+	for _, v := range d.CheckConditionalRequests(conventions) {
+		op := v.Operation.Operation
+		switch v.Kind {
+		case MissingETag:
+			r := d.referencedResponse(op.Responses, v.Code)
+			// a component response is fixed once, for the first operation that references it.
+			if r == nil || r.FindHeader(ETagHeader) != nil {
+				continue
+			}
+			if r.Headers == nil {
+				r.Headers = orderedmap.New[string, *Header]()
+			}
+			r.Headers.Set(ETagHeader, &Header{
+				Description: "The entity tag of the current representation of the resource.",
+				Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			})
+		case MissingIfMatch:
+			required := conventions.RequireIfMatch
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:        IfMatchHeader,
+				In:          "header",
+				Description: "Only perform the operation if the entity tag of the resource matches.",
+				Required:    &required,
+				Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			})
+		}
+		count++
+	}
+	return count
+}
+
+// acceptsHeader returns true if an operation (or its PathItem) has a header parameter called name.
+func acceptsHeader(op *SelectedOperation, name string) bool {
+	for _, p := range op.Operation.EffectiveParameters(op.PathItem) {
+		if p != nil && strings.EqualFold(p.In, "header") && strings.EqualFold(p.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// referencedResponse returns the response to change for a response code. That's the response itself, unless it's
+// a reference, then it's the response of the Components it references, or nil if it references another document.
+func (d *Document) referencedResponse(responses *Responses, code string) *Response {
+	r := responses.Codes.GetOrZero(code)
+	if responses.low == nil {
+		return r
+	}
+	for k, v := range responses.low.Codes.FromOldest() {
+		if k.Value != code || !v.IsReference() {
+			continue
+		}
+		name, found := strings.CutPrefix(v.GetReference(), "#/components/responses/")
+		if !found || d.Components == nil {
+			return nil
+		}
+		return d.Components.Responses.GetOrZero(name)
+	}
+	return r
+}
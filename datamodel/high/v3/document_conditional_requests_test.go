@@ -0,0 +1,115 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var conditionalRequestsSpec = `openapi: 3.1.0
+paths:
+  /pets/{id}:
+    parameters:
+      - name: if-match
+        in: header
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: OK
+          headers:
+            etag:
+              schema:
+                type: string
+        "404":
+          description: Not Found
+    put:
+      responses:
+        "200":
+          $ref: '#/components/responses/Pet'
+  /pets:
+    post:
+      responses:
+        "201":
+          description: Created
+    patch:
+      responses:
+        "2XX":
+          $ref: '#/components/responses/Pet'
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  responses:
+    Pet:
+      description: A pet`
+
+func TestDocument_CheckConditionalRequests(t *testing.T) {
+	doc := buildTestDocument(t, conditionalRequestsSpec)
+
+	violations := doc.CheckConditionalRequests(nil)
+	var messages []string
+	for _, v := range violations {
+		messages = append(messages, v.Message)
+	}
+	assert.Equal(t, []string{
+		"the 200 response of PUT /pets/{id} does not declare an ETag header",
+		"the 2XX response of PATCH /pets does not declare an ETag header",
+		"PATCH /pets does not accept an If-Match header",
+		"DELETE /pets does not accept an If-Match header",
+	}, messages)
+	assert.Equal(t, MissingETag, violations[0].Kind)
+	assert.Equal(t, "200", violations[0].Code)
+	assert.Equal(t, "put", violations[0].Operation.Method)
+	assert.Equal(t, MissingIfMatch, violations[2].Kind)
+	assert.Empty(t, violations[2].Code)
+}
+
+func TestDocument_CheckConditionalRequests_Conventions(t *testing.T) {
+	doc := buildTestDocument(t, conditionalRequestsSpec)
+
+	violations := doc.CheckConditionalRequests(&ConditionalRequestConventions{IfMatchMethods: []string{"post"}})
+	require.Len(t, violations, 1)
+	assert.Equal(t, "POST /pets does not accept an If-Match header", violations[0].Message)
+
+	assert.Empty(t, doc.CheckConditionalRequests(&ConditionalRequestConventions{}))
+	assert.Empty(t, new(Document).CheckConditionalRequests(nil))
+}
+
+func TestDocument_InjectConditionalRequestHeaders(t *testing.T) {
+	doc := buildTestDocument(t, conditionalRequestsSpec)
+
+	// the Pet response is referenced twice, but is only given one header.
+	assert.Equal(t, 2, doc.InjectConditionalRequestHeaders(&ConditionalRequestConventions{
+		ETagMethods:    []string{"put", "patch"},
+		IfMatchMethods: []string{"delete"},
+		RequireIfMatch: true,
+	}))
+	pet := doc.Components.Responses.GetOrZero("Pet")
+	require.NotNil(t, pet.FindHeader("etag"))
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), `$ref: '#/components/responses/Pet'`)
+
+	doc = buildTestDocument(t, string(rendered))
+	violations := doc.CheckConditionalRequests(nil)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "PATCH /pets does not accept an If-Match header", violations[0].Message)
+
+	params := doc.Paths.PathItems.GetOrZero("/pets").Delete.Parameters
+	require.Len(t, params, 1)
+	assert.Equal(t, IfMatchHeader, params[0].Name)
+	assert.True(t, *params[0].Required)
+	assert.Equal(t, []string{"string"}, params[0].Schema.Schema().Type)
+
+	assert.Equal(t, 1, doc.InjectConditionalRequestHeaders(nil))
+	assert.Empty(t, doc.CheckConditionalRequests(nil))
+	assert.False(t, *doc.Paths.PathItems.GetOrZero("/pets").Patch.Parameters[0].Required)
+}
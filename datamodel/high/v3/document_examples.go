@@ -0,0 +1,233 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateExamples validates every example of the Document against its schema, using base.Schema.ValidateValue,
+// and returns a Diagnostic for every mismatch. The `example` and `examples` of media types, parameters
+// and headers are validated against their sibling schema, and the `example` and `examples` of every schema (and
+// sub-schema) against the schema itself. Examples with an externalValue are not checked.
+//
+// Every path item, operation, callback and webhook is walked, along with every component. The Key of each
+// Diagnostic is the JSON Path to the example, and it's positioned at the part of the example that does not match.
+// Components are walked first, so an example of a component that is referenced elsewhere is checked (and reported)
+// once, at the component.
+func (d *Document) ValidateExamples() []*low.Diagnostic {
+	v := &exampleValidator{seen: make(map[any]struct{})}
+	if c := d.Components; c != nil {
+		for name, sp := range c.Schemas.FromOldest() {
+			v.schema(sp, fmt.Sprintf("$.components.schemas['%s']", name))
+		}
+		for name, p := range c.Parameters.FromOldest() {
+			v.parameter(p, fmt.Sprintf("$.components.parameters['%s']", name))
+		}
+		for name, h := range c.Headers.FromOldest() {
+			v.header(h, fmt.Sprintf("$.components.headers['%s']", name))
+		}
+		for name, rb := range c.RequestBodies.FromOldest() {
+			if rb != nil {
+				v.content(rb.Content, fmt.Sprintf("$.components.requestBodies['%s']", name))
+			}
+		}
+		for name, r := range c.Responses.FromOldest() {
+			v.response(r, fmt.Sprintf("$.components.responses['%s']", name))
+		}
+		for name, cb := range c.Callbacks.FromOldest() {
+			v.callback(cb, fmt.Sprintf("$.components.callbacks['%s']", name))
+		}
+	}
+	if d.Paths != nil {
+		for path, pi := range d.Paths.PathItems.FromOldest() {
+			v.pathItem(pi, fmt.Sprintf("$.paths['%s']", path))
+		}
+	}
+	for name, pi := range d.Webhooks.FromOldest() {
+		v.pathItem(pi, fmt.Sprintf("$.webhooks['%s']", name))
+	}
+	return v.diagnostics
+}
+
+type exampleValidator struct {
+	seen        map[any]struct{}
+	diagnostics []*low.Diagnostic
+}
+
+func (v *exampleValidator) pathItem(pi *PathItem, path string) {
+	if pi == nil {
+		return
+	}
+	for i, p := range pi.Parameters {
+		v.parameter(p, fmt.Sprintf("%s.parameters[%d]", path, i))
+	}
+	for method, op := range pi.GetOperations().FromOldest() {
+		v.operation(op, fmt.Sprintf("%s.%s", path, method))
+	}
+}
+
+func (v *exampleValidator) operation(op *Operation, path string) {
+	if op == nil {
+		return
+	}
+	for i, p := range op.Parameters {
+		v.parameter(p, fmt.Sprintf("%s.parameters[%d]", path, i))
+	}
+	if op.RequestBody != nil {
+		v.content(op.RequestBody.Content, path+".requestBody")
+	}
+	if op.Responses != nil {
+		for code, r := range op.Responses.Codes.FromOldest() {
+			v.response(r, fmt.Sprintf("%s.responses['%s']", path, code))
+		}
+		v.response(op.Responses.Default, path+".responses.default")
+	}
+	for name, cb := range op.Callbacks.FromOldest() {
+		v.callback(cb, fmt.Sprintf("%s.callbacks['%s']", path, name))
+	}
+}
+
+func (v *exampleValidator) callback(cb *Callback, path string) {
+	if cb == nil {
+		return
+	}
+	for expression, pi := range cb.Expression.FromOldest() {
+		v.pathItem(pi, fmt.Sprintf("%s['%s']", path, expression))
+	}
+}
+
+func (v *exampleValidator) response(r *Response, path string) {
+	if r == nil {
+		return
+	}
+	for name, h := range r.Headers.FromOldest() {
+		v.header(h, fmt.Sprintf("%s.headers['%s']", path, name))
+	}
+	v.content(r.Content, path)
+}
+
+func (v *exampleValidator) parameter(p *Parameter, path string) {
+	if p == nil {
+		return
+	}
+	v.examples(p.Schema, p.Example, p.Examples, path)
+	v.content(p.Content, path)
+}
+
+func (v *exampleValidator) header(h *Header, path string) {
+	if h == nil {
+		return
+	}
+	v.examples(h.Schema, h.Example, h.Examples, path)
+	v.content(h.Content, path)
+}
+
+func (v *exampleValidator) content(content *orderedmap.Map[string, *MediaType], path string) {
+	for mediaType, mt := range content.FromOldest() {
+		if mt != nil {
+			v.examples(mt.Schema, mt.Example, mt.Examples, fmt.Sprintf("%s.content['%s']", path, mediaType))
+		}
+	}
+}
+
+// examples validates the example and examples of an object against its sibling schema.
+func (v *exampleValidator) examples(sp *base.SchemaProxy, example *yaml.Node,
+	examples *orderedmap.Map[string, *base.Example], path string,
+) {
+	v.schema(sp, path+".schema")
+	if sp == nil {
+		return
+	}
+	schema := sp.Schema()
+	v.validate(schema, example, path+".example")
+	for name, ex := range examples.FromOldest() {
+		if ex != nil && ex.ExternalValue == "" {
+			v.validate(schema, ex.Value, fmt.Sprintf("%s.examples['%s'].value", path, name))
+		}
+	}
+}
+
+// schema validates the examples of a schema against itself, then the examples of all of its sub-schemas.
+func (v *exampleValidator) schema(sp *base.SchemaProxy, path string) {
+	if sp == nil {
+		return
+	}
+	s := sp.Schema()
+	if s == nil {
+		return
+	}
+	var key any = s
+	if l := s.GoLow(); l != nil && l.RootNode != nil {
+		key = l.RootNode
+	}
+	if _, ok := v.seen[key]; ok {
+		return
+	}
+	v.seen[key] = struct{}{}
+
+	v.validate(s, s.Example, path+".example")
+	for i, ex := range s.Examples {
+		v.validate(s, ex, fmt.Sprintf("%s.examples[%d]", path, i))
+	}
+
+	for name, p := range s.Properties.FromOldest() {
+		v.schema(p, fmt.Sprintf("%s.properties['%s']", path, name))
+	}
+	for name, p := range s.PatternProperties.FromOldest() {
+		v.schema(p, fmt.Sprintf("%s.patternProperties['%s']", path, name))
+	}
+	for name, p := range s.Defs.FromOldest() {
+		v.schema(p, fmt.Sprintf("%s.$defs['%s']", path, name))
+	}
+	if s.Items != nil && s.Items.IsA() {
+		v.schema(s.Items.A, path+".items")
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.IsA() {
+		v.schema(s.AdditionalProperties.A, path+".additionalProperties")
+	}
+	for i, p := range s.PrefixItems {
+		v.schema(p, fmt.Sprintf("%s.prefixItems[%d]", path, i))
+	}
+	for i, p := range s.AllOf {
+		v.schema(p, fmt.Sprintf("%s.allOf[%d]", path, i))
+	}
+	for i, p := range s.OneOf {
+		v.schema(p, fmt.Sprintf("%s.oneOf[%d]", path, i))
+	}
+	for i, p := range s.AnyOf {
+		v.schema(p, fmt.Sprintf("%s.anyOf[%d]", path, i))
+	}
+	v.schema(s.Not, path+".not")
+}
+
+func (v *exampleValidator) validate(s *base.Schema, example *yaml.Node, path string) {
+	if s == nil || example == nil {
+		return
+	}
+	// referenced parameters, headers and media types share example nodes with their components.
+	if _, ok := v.seen[example]; ok {
+		return
+	}
+	v.seen[example] = struct{}{}
+	for _, violation := range s.ValidateValue(example) {
+		location := path + strings.TrimPrefix(violation.Path, "$")
+		d := &low.Diagnostic{
+			Message:   fmt.Sprintf("example at '%s' does not match its schema: %s", location, violation.Message),
+			Key:       location,
+			ValueNode: violation.Node,
+		}
+		if n := utils.NodeAlias(violation.Node); n != nil {
+			d.Line, d.Column = n.Line, n.Column
+		}
+		v.diagnostics = append(v.diagnostics, d)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument_ValidateExamples(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+          example: 500
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Rate:
+              schema:
+                type: integer
+              example: fast
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              examples:
+                good:
+                  value:
+                    name: Rex
+                bad:
+                  value:
+                    name: 12
+                remote:
+                  externalValue: https://example.com/pet.json
+components:
+  parameters:
+    Limit:
+      name: max
+      in: query
+      schema:
+        type: integer
+        maximum: 100
+      example: 101
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          examples: [Rex, 7]
+      example:
+        colour: brown`
+
+	doc := buildTestDocument(t, yml)
+
+	diagnostics := doc.ValidateExamples()
+	var messages []string
+	for _, d := range diagnostics {
+		messages = append(messages, d.Message)
+	}
+	assert.Equal(t, []string{
+		"example at '$.components.schemas['Pet'].example' does not match its schema: " +
+			"object is missing the required property 'name'",
+		"example at '$.components.schemas['Pet'].properties['name'].examples[1]' does not match its schema: " +
+			"expected string, but got integer",
+		"example at '$.components.parameters['Limit'].example' does not match its schema: value must be at most 100",
+		"example at '$.paths['/pets'].get.parameters[0].example' does not match its schema: value must be at most 100",
+		"example at '$.paths['/pets'].get.responses['200'].headers['X-Rate'].example' does not match its schema: " +
+			"expected integer, but got string",
+		"example at '$.paths['/pets'].get.responses['200'].content['application/json'].examples['bad'].value.name' " +
+			"does not match its schema: expected string, but got integer",
+	}, messages)
+
+	assert.Equal(t, "$.paths['/pets'].get.parameters[0].example", diagnostics[3].Key)
+	assert.Equal(t, 11, diagnostics[3].Line)
+	assert.Equal(t, 20, diagnostics[3].Column)
+	assert.Equal(t, 31, diagnostics[5].Line)
+	assert.Equal(t, 27, diagnostics[5].Column)
+}
+
+func TestDocument_ValidateExamples_Valid(t *testing.T) {
+	yml := `openapi: 3.0.3
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+        nullable: true
+      example: null
+  schemas:
+    Pet:
+      type: string
+      example: Rex`
+
+	assert.Empty(t, buildTestDocument(t, yml).ValidateExamples())
+}
@@ -0,0 +1,19 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/datamodel/low"
+)
+
+// ValidateExtensions validates every extension of the Document that has a schema registered in schemas, using
+// base.ExtensionSchemas.Validate, and returns a Diagnostic for every mismatch. The whole root specification is
+// checked, wherever the extensions are defined. Files the specification references are not.
+func (d *Document) ValidateExtensions(schemas *base.ExtensionSchemas) []*low.Diagnostic {
+	if d.Index == nil {
+		return nil
+	}
+	return schemas.Validate(d.Index.GetRootNode())
+}
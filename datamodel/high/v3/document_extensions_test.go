@@ -0,0 +1,45 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_ValidateExtensions(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pets
+  version: "1"
+  x-internal: false
+paths:
+  /pets:
+    get:
+      x-internal: "no"
+      responses:
+        "200":
+          description: OK`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	lowDoc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	doc := NewDocument(lowDoc)
+
+	schemas := base.NewExtensionSchemas()
+	require.NoError(t, schemas.RegisterJSONSchema("x-internal", []byte(`{"type": "boolean"}`)))
+
+	diagnostics := doc.ValidateExtensions(schemas)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "extension 'x-internal' at '$.paths['/pets'].get['x-internal']' does not match its schema: "+
+		"expected boolean, but got string", diagnostics[0].Message)
+	assert.Equal(t, 9, diagnostics[0].Line)
+
+	assert.Empty(t, new(Document).ValidateExtensions(schemas))
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultModule is the name of the module used for operations a ModuleGroupFunc cannot place, for example untagged
+// operations when grouping by tag.
+const DefaultModule = "default"
+
+// ModuleGroupFunc decides which module an operation belongs to. Returning an empty string places the operation in
+// the DefaultModule.
+type ModuleGroupFunc func(op *SelectedOperation) string
+
+// GroupByTag places an operation in a module named after its first tag.
+func GroupByTag(op *SelectedOperation) string {
+	if op.Operation != nil && len(op.Operation.Tags) > 0 {
+		return op.Operation.Tags[0]
+	}
+	return ""
+}
+
+// GroupByPathSegment places an operation in a module named after the first segment of its path, for example
+// `/pets/{petId}` is placed in `pets`. Path parameters are not used as names, so `/{tenant}/pets` is placed in `pets`.
+func GroupByPathSegment(op *SelectedOperation) string {
+	for _, segment := range strings.Split(op.Path, "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			return segment
+		}
+	}
+	return ""
+}
+
+// ModulePlan is a plan of how the operations of a Document can be laid out as modules (or packages) by an SDK
+// generator. It can be rendered as JSON or YAML.
+type ModulePlan struct {
+	Modules []*Module `json:"modules" yaml:"modules"`
+}
+
+// Module is a group of operations, along with the component schemas it owns, and the other modules it depends on.
+type Module struct {
+	Name       string             `json:"name" yaml:"name"`
+	Operations []*ModuleOperation `json:"operations" yaml:"operations"`
+
+	// Schemas are the names of the component schemas the module owns. A schema is owned by the first module (in
+	// plan order) that uses it, directly or through another component.
+	Schemas []string `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+
+	// Dependencies are the names of the other modules that own schemas used by this module, in plan order.
+	Dependencies []string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+}
+
+// ModuleOperation is an operation placed in a Module.
+type ModuleOperation struct {
+	Path        string     `json:"path" yaml:"path"`
+	Method      string     `json:"method" yaml:"method"`
+	OperationId string     `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Operation   *Operation `json:"-" yaml:"-"`
+}
+
+// FindModule returns the module with the supplied name, or nil if there isn't one.
+func (p *ModulePlan) FindModule(name string) *Module {
+	for _, m := range p.Modules {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// ModulePlan groups every operation of the Document into modules using group (GroupByTag if group is nil). Modules
+// are in the order they are first used, and operations are in document order.
+//
+// Dependencies are resolved from the local references ($ref) of each operation, followed through every component
+// they point to, so a module using a schema that references another schema uses both. References to other files are
+// not followed. Operations created without a low-level model have no references.
+func (d *Document) ModulePlan(group ModuleGroupFunc) *ModulePlan {
+	if group == nil {
+		group = GroupByTag
+	}
+	plan := &ModulePlan{}
+	if d.Paths == nil {
+		return plan
+	}
+
+	var root *yaml.Node
+	if d.low != nil && d.low.Index != nil {
+		root = d.low.Index.GetRootNode()
+	}
+
+	used := make(map[*Module][]string)
+	for path, pathItem := range d.Paths.PathItems.FromOldest() {
+		if pathItem == nil {
+			continue
+		}
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			name := group(&SelectedOperation{Path: path, Method: method, PathItem: pathItem, Operation: op})
+			if name == "" {
+				name = DefaultModule
+			}
+			m := plan.FindModule(name)
+			if m == nil {
+				m = &Module{Name: name}
+				plan.Modules = append(plan.Modules, m)
+			}
+			m.Operations = append(m.Operations, &ModuleOperation{
+				Path: path, Method: method, OperationId: op.OperationId, Operation: op,
+			})
+			if op.low != nil {
+				for _, schema := range usedSchemas(root, op.low.RootNode) {
+					if !slices.Contains(used[m], schema) {
+						used[m] = append(used[m], schema)
+					}
+				}
+			}
+		}
+	}
+
+	owners := make(map[string]*Module)
+	for _, m := range plan.Modules {
+		for _, schema := range used[m] {
+			if _, ok := owners[schema]; !ok {
+				owners[schema] = m
+				m.Schemas = append(m.Schemas, schema)
+			}
+		}
+	}
+	for _, m := range plan.Modules {
+		for _, dep := range plan.Modules {
+			if dep == m {
+				continue
+			}
+			for _, schema := range used[m] {
+				if owners[schema] == dep {
+					m.Dependencies = append(m.Dependencies, dep.Name)
+					break
+				}
+			}
+		}
+	}
+	return plan
+}
+
+// usedSchemas returns the names of the component schemas node references, following local references through
+// the components they point to, in the order they are found.
+func usedSchemas(root, node *yaml.Node) []string {
+	var schemas []string
+	walkLocalRefs(root, node, func(segments []string) {
+		if len(segments) >= 3 && segments[0] == "components" && segments[1] == "schemas" &&
+			!slices.Contains(schemas, segments[2]) {
+			schemas = append(schemas, segments[2])
+		}
+	})
+	return schemas
+}
+
+// walkLocalRefs calls found with the segments of every local reference ($ref) in node, following each reference
+// through the node it points to in root (when root is not nil). Every reference is only found once.
+func walkLocalRefs(root, node *yaml.Node, found func(segments []string)) {
+	seenRefs := make(map[string]struct{})
+	seenNodes := make(map[*yaml.Node]struct{})
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n == nil {
+			return
+		}
+		if _, ok := seenNodes[n]; ok {
+			return
+		}
+		seenNodes[n] = struct{}{}
+		if n.Kind == yaml.AliasNode {
+			walk(n.Alias)
+			return
+		}
+		for i, c := range n.Content {
+			if n.Kind == yaml.MappingNode && i%2 == 0 && c.Value == "$ref" && i+1 < len(n.Content) {
+				ref := n.Content[i+1].Value
+				if _, ok := seenRefs[ref]; ok || !strings.HasPrefix(ref, "#/") {
+					continue
+				}
+				seenRefs[ref] = struct{}{}
+				segments, err := utils.ParseJSONPointer(ref)
+				if err != nil {
+					continue
+				}
+				found(segments)
+				if root != nil {
+					if target, fErr := utils.FindNodeByJSONPointerSegments(root, segments); fErr == nil {
+						walk(target)
+					}
+				}
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(node)
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func modulePlanTestDocument(t *testing.T) *Document {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      operationId: listPets
+      responses:
+        "200":
+          $ref: '#/components/responses/Pets'
+  /stores/{storeId}/orders:
+    post:
+      tags: [store]
+      operationId: placeOrder
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order'
+      responses:
+        "201":
+          description: placed
+  /{tenant}/health:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  responses:
+    Pets:
+      description: pets
+      content:
+        application/json:
+          schema:
+            type: array
+            items:
+              $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+      properties:
+        category:
+          $ref: '#/components/schemas/Category'
+    Category:
+      type: object
+    Order:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+        price:
+          $ref: '#/components/schemas/Price/properties/amount'
+    Price:
+      type: object
+      properties:
+        amount:
+          type: number`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return NewDocument(lowDoc)
+}
+
+func TestDocument_ModulePlan_ByTag(t *testing.T) {
+	plan := modulePlanTestDocument(t).ModulePlan(nil)
+	require.Len(t, plan.Modules, 3)
+
+	pets := plan.FindModule("pets")
+	require.NotNil(t, pets)
+	assert.Equal(t, "listPets", pets.Operations[0].OperationId)
+	assert.Equal(t, []string{"Pet", "Category"}, pets.Schemas)
+	assert.Empty(t, pets.Dependencies)
+
+	store := plan.FindModule("store")
+	assert.Equal(t, "/stores/{storeId}/orders", store.Operations[0].Path)
+	assert.Equal(t, "post", store.Operations[0].Method)
+	assert.Equal(t, []string{"Order", "Price"}, store.Schemas)
+	assert.Equal(t, []string{"pets"}, store.Dependencies)
+
+	def := plan.FindModule(DefaultModule)
+	assert.Len(t, def.Operations, 1)
+	assert.Empty(t, def.Schemas)
+	assert.Nil(t, plan.FindModule("nope"))
+
+	b, err := json.Marshal(plan.Modules[1])
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"store","operations":[{"path":"/stores/{storeId}/orders","method":"post",`+
+		`"operationId":"placeOrder"}],"schemas":["Order","Price"],"dependencies":["pets"]}`, string(b))
+}
+
+func TestDocument_ModulePlan_ByPathSegment(t *testing.T) {
+	plan := modulePlanTestDocument(t).ModulePlan(GroupByPathSegment)
+	var names []string
+	for _, m := range plan.Modules {
+		names = append(names, m.Name)
+	}
+	assert.Equal(t, []string{"pets", "stores", "health"}, names)
+}
+
+func TestDocument_ModulePlan_Custom(t *testing.T) {
+	doc := modulePlanTestDocument(t)
+	plan := doc.ModulePlan(func(op *SelectedOperation) string {
+		return op.Method
+	})
+	require.Len(t, plan.Modules, 2)
+	assert.Equal(t, "get", plan.Modules[0].Name)
+	assert.Len(t, plan.Modules[0].Operations, 2)
+	assert.Equal(t, []string{"get"}, plan.Modules[1].Dependencies)
+
+	// operations without a low-level model are placed, but have no dependencies.
+	doc.Paths.PathItems.GetOrZero("/pets").Put = &Operation{OperationId: "updatePets"}
+	plan = doc.ModulePlan(GroupByTag)
+	assert.Equal(t, "updatePets", plan.FindModule(DefaultModule).Operations[0].OperationId)
+
+	assert.Empty(t, (&Document{}).ModulePlan(nil).Modules)
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// AnalyzePresence reports whether absence and null are representable for every property of every component schema
+// of the Document, using base.Schema.AnalyzePresence. Paths are from the root of the document, for example
+// `$.components.schemas['Pet'].properties['name']`, properties are in document order. A property of a schema that is
+// referenced by more than one component is reported under each of them.
+func (d *Document) AnalyzePresence() *base.PresenceReport {
+	report := new(base.PresenceReport)
+	if d.Components == nil {
+		return report
+	}
+	for name, sp := range d.Components.Schemas.FromOldest() {
+		if sp == nil {
+			continue
+		}
+		schema := sp.Schema()
+		if schema == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("$.components.schemas['%s']", name)
+		for _, p := range schema.AnalyzePresence().Properties {
+			p.Path = prefix + strings.TrimPrefix(p.Path, "$")
+			report.Properties = append(report.Properties, p)
+		}
+	}
+	return report
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_AnalyzePresence(t *testing.T) {
+	yml := `openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+        tag:
+          type: string
+          nullable: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+          nullable: true
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	doc := NewDocument(lowDoc)
+
+	report := doc.AnalyzePresence()
+	require.Len(t, report.Properties, 5)
+	assert.Equal(t, "$.components.schemas['Pet'].properties['id']", report.Properties[0].Path)
+	assert.Equal(t, base.PresenceValueOnly, report.Properties[0].Presence)
+	assert.Equal(t, "$.components.schemas['Pet'].properties['tag']", report.Properties[1].Path)
+	assert.Equal(t, base.PresenceAbsentOrNull, report.Properties[1].Presence)
+	assert.Equal(t, "$.components.schemas['Pet'].properties['owner']", report.Properties[2].Path)
+	assert.Equal(t, base.PresenceAmbiguous, report.Properties[2].Presence)
+	assert.Equal(t, "$.components.schemas['Pet'].properties['owner'].properties['name']", report.Properties[3].Path)
+	assert.Equal(t, "$.components.schemas['Owner'].properties['name']", report.Properties[4].Path)
+	assert.Equal(t, base.PresenceAbsentOnly, report.Properties[4].Presence)
+	assert.Len(t, report.Ambiguous(), 1)
+
+	assert.Empty(t, (&Document{}).AnalyzePresence().Properties)
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// FindOperationsUsingSchema returns every operation that uses the schema at ref (a local reference, such as
+// `#/components/schemas/Order`), in document order. An operation uses a schema when it references it, or
+// references part of it, directly or transitively: references are followed through every component they point to,
+// so an operation returning a schema that (somewhere) nests the Order schema uses Order. The parameters of the
+// path item an operation belongs to are included, as are its callbacks.
+//
+// Only operations under paths are searched. References to other files are not followed, and operations created
+// without a low-level model have no references.
+func (d *Document) FindOperationsUsingSchema(ref string) ([]*SelectedOperation, error) {
+	target, err := utils.ParseJSONPointer(ref)
+	if err != nil || !strings.HasPrefix(ref, "#/") || len(target) == 0 {
+		return nil, fmt.Errorf("unable to find operations using '%s', it's not a local reference", ref)
+	}
+	var root *yaml.Node
+	if d.low != nil && d.low.Index != nil {
+		root = d.low.Index.GetRootNode()
+	}
+	uses := func(node *yaml.Node) bool {
+		used := false
+		walkLocalRefs(root, node, func(segments []string) {
+			if len(segments) >= len(target) && slices.Equal(segments[:len(target)], target) {
+				used = true
+			}
+		})
+		return used
+	}
+
+	var found []*SelectedOperation
+	d.eachOperation(func(op *SelectedOperation) {
+		if op.Operation.low == nil {
+			return
+		}
+		if uses(op.Operation.low.RootNode) ||
+			(op.PathItem.low != nil && uses(op.PathItem.low.Parameters.ValueNode)) {
+			found = append(found, op)
+		}
+	})
+	return found, nil
+}
+
+// FindOperationsReturningContentType returns every operation with a response (including the default response)
+// that has content of contentType, in document order. Parameters and case are ignored, so `text/event-stream`
+// matches `Text/Event-Stream; charset=utf-8`. contentType can be a range, `text/*` matches any text content, but
+// ranges defined in a response (such as `*/*`) only match the same range.
+func (d *Document) FindOperationsReturningContentType(contentType string) []*SelectedOperation {
+	requested, ok := parseMediaRange(contentType)
+	if !ok {
+		return nil
+	}
+	returns := func(r *Response) bool {
+		if r == nil {
+			return false
+		}
+		for k := range r.Content.KeysFromOldest() {
+			defined, valid := parseMediaRange(k)
+			if !valid {
+				continue
+			}
+			switch scoreMediaRange(requested, defined) {
+			case matchExactWithParams, matchExact, matchRangeSubtype, matchRangeAnything:
+				return true
+			}
+		}
+		return false
+	}
+
+	var found []*SelectedOperation
+	d.eachOperation(func(op *SelectedOperation) {
+		responses := op.Operation.Responses
+		if responses == nil {
+			return
+		}
+		if returns(responses.Default) {
+			found = append(found, op)
+			return
+		}
+		for r := range responses.Codes.ValuesFromOldest() {
+			if returns(r) {
+				found = append(found, op)
+				return
+			}
+		}
+	})
+	return found
+}
+
+// eachOperation calls visit with every operation under paths, in document order.
+func (d *Document) eachOperation(visit func(op *SelectedOperation)) {
+	if d.Paths == nil {
+		return
+	}
+	for path, pathItem := range d.Paths.PathItems.FromOldest() {
+		if pathItem == nil {
+			continue
+		}
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			if op != nil {
+				visit(&SelectedOperation{Path: path, Method: method, PathItem: pathItem, Operation: op})
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var operationQueriesSpec = `openapi: 3.1.0
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/OrderPage'
+    post:
+      operationId: createOrder
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Order/properties/id'
+      responses:
+        default:
+          description: Error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+  /orders/{id}:
+    parameters:
+      - $ref: '#/components/parameters/OrderFilter'
+    get:
+      operationId: getOrder
+      responses:
+        "200":
+          $ref: '#/components/responses/Stream'
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+          content:
+            '*/*':
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    OrderFilter:
+      name: filter
+      in: query
+      schema:
+        $ref: '#/components/schemas/Order'
+  responses:
+    Stream:
+      description: Events
+      content:
+        Text/Event-Stream; charset=utf-8:
+          schema:
+            type: string
+  schemas:
+    OrderPage:
+      type: object
+      properties:
+        orders:
+          type: array
+          items:
+            $ref: '#/components/schemas/Order'
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+    Pet:
+      type: object`
+
+func operationIds(ops []*SelectedOperation) []string {
+	var ids []string
+	for _, op := range ops {
+		ids = append(ids, op.Operation.OperationId)
+	}
+	return ids
+}
+
+func TestDocument_FindOperationsUsingSchema(t *testing.T) {
+	doc := buildTestDocument(t, operationQueriesSpec)
+
+	ops, err := doc.FindOperationsUsingSchema("#/components/schemas/Order")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"listOrders", "createOrder", "getOrder"}, operationIds(ops))
+	assert.Equal(t, "/orders/{id}", ops[2].Path)
+	assert.Equal(t, "get", ops[2].Method)
+
+	ops, err = doc.FindOperationsUsingSchema("#/components/schemas/OrderPage")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"listOrders"}, operationIds(ops))
+
+	ops, err = doc.FindOperationsUsingSchema("#/components/schemas/Pet")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"listPets"}, operationIds(ops))
+
+	ops, err = doc.FindOperationsUsingSchema("#/components/schemas/Missing")
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+}
+
+func TestDocument_FindOperationsUsingSchema_NotLocal(t *testing.T) {
+	doc := buildTestDocument(t, operationQueriesSpec)
+	_, err := doc.FindOperationsUsingSchema("models.yaml#/Order")
+	assert.EqualError(t, err, "unable to find operations using 'models.yaml#/Order', it's not a local reference")
+}
+
+func TestDocument_FindOperationsReturningContentType(t *testing.T) {
+	doc := buildTestDocument(t, operationQueriesSpec)
+
+	assert.Equal(t, []string{"getOrder"}, operationIds(doc.FindOperationsReturningContentType("text/event-stream")))
+	assert.Equal(t, []string{"listOrders"}, operationIds(doc.FindOperationsReturningContentType("application/json")))
+	assert.Equal(t, []string{"createOrder"},
+		operationIds(doc.FindOperationsReturningContentType("application/problem+json")))
+	assert.Equal(t, []string{"listOrders", "createOrder"},
+		operationIds(doc.FindOperationsReturningContentType("application/*")))
+	assert.Equal(t, []string{"listOrders", "createOrder", "getOrder", "listPets"},
+		operationIds(doc.FindOperationsReturningContentType("*/*")))
+	assert.Empty(t, doc.FindOperationsReturningContentType("not a media type"))
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// OperationsByTag returns every operation defined by the Paths of the Document, grouped by tag. Tags declared in the
+// top-level tags array come first (in the order they are declared), followed by tags that are only used by
+// operations, in the order they are first used. Operations are in document order, an operation with more than one
+// tag is listed under each of them. Untagged operations, and declared tags no operation uses, are not included.
+func (d *Document) OperationsByTag() *orderedmap.Map[string, []*SelectedOperation] {
+	used := orderedmap.New[string, []*SelectedOperation]()
+	if d.Paths != nil {
+		for path, pathItem := range d.Paths.PathItems.FromOldest() {
+			if pathItem == nil {
+				continue
+			}
+			for method, op := range pathItem.GetOperations().FromOldest() {
+				seen := make(map[string]struct{}, len(op.Tags))
+				for _, tag := range op.Tags {
+					if _, ok := seen[tag]; ok {
+						continue
+					}
+					seen[tag] = struct{}{}
+					used.Set(tag, append(used.GetOrZero(tag), &SelectedOperation{
+						Path: path, Method: method, PathItem: pathItem, Operation: op,
+					}))
+				}
+			}
+		}
+	}
+
+	byTag := orderedmap.New[string, []*SelectedOperation]()
+	for _, tag := range d.Tags {
+		if tag == nil {
+			continue
+		}
+		if ops, ok := used.Get(tag.Name); ok {
+			byTag.Set(tag.Name, ops)
+		}
+	}
+	for tag, ops := range used.FromOldest() {
+		if _, ok := byTag.Get(tag); !ok {
+			byTag.Set(tag, ops)
+		}
+	}
+	return byTag
+}
+
+// UndeclaredTags returns the names of tags used by operations that are not declared in the top-level tags array,
+// in the order they are first used.
+func (d *Document) UndeclaredTags() []string {
+	declared := make(map[string]struct{}, len(d.Tags))
+	for _, tag := range d.Tags {
+		if tag != nil {
+			declared[tag.Name] = struct{}{}
+		}
+	}
+	var undeclared []string
+	for tag := range d.OperationsByTag().KeysFromOldest() {
+		if _, ok := declared[tag]; !ok {
+			undeclared = append(undeclared, tag)
+		}
+	}
+	return undeclared
+}
+
+// UnusedTags returns the tags declared in the top-level tags array that are not used by any operation, in the order
+// they are declared.
+func (d *Document) UnusedTags() []*base.Tag {
+	byTag := d.OperationsByTag()
+	var unused []*base.Tag
+	for _, tag := range d.Tags {
+		if tag == nil {
+			continue
+		}
+		if _, ok := byTag.Get(tag.Name); !ok {
+			unused = append(unused, tag)
+		}
+	}
+	return unused
+}
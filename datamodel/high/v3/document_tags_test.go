@@ -0,0 +1,64 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_OperationsByTag(t *testing.T) {
+	yml := `openapi: 3.1.0
+tags:
+  - name: store
+  - name: pets
+  - name: admin
+paths:
+  /pets:
+    get:
+      tags: [pets, beta, pets]
+    post:
+      tags: [pets]
+  /orders:
+    get:
+      tags: [legacy, store]
+    delete: {}`
+
+	doc := buildTestDocument(t, yml)
+
+	byTag := doc.OperationsByTag()
+	assert.Equal(t, []string{"store", "pets", "beta", "legacy"}, slices.Collect(byTag.KeysFromOldest()))
+
+	pets := byTag.GetOrZero("pets")
+	require.Len(t, pets, 2)
+	assert.Equal(t, "/pets", pets[0].Path)
+	assert.Equal(t, "get", pets[0].Method)
+	assert.Same(t, doc.Paths.PathItems.GetOrZero("/pets").Get, pets[0].Operation)
+	assert.Equal(t, "post", pets[1].Method)
+
+	store := byTag.GetOrZero("store")
+	require.Len(t, store, 1)
+	assert.Equal(t, "/orders", store[0].Path)
+
+	assert.Equal(t, []string{"beta", "legacy"}, doc.UndeclaredTags())
+
+	unused := doc.UnusedTags()
+	require.Len(t, unused, 1)
+	assert.Equal(t, "admin", unused[0].Name)
+}
+
+func TestDocument_OperationsByTag_NoPaths(t *testing.T) {
+	yml := `openapi: 3.1.0
+tags:
+  - name: pets`
+
+	doc := buildTestDocument(t, yml)
+
+	assert.Equal(t, 0, doc.OperationsByTag().Len())
+	assert.Empty(t, doc.UndeclaredTags())
+	assert.Len(t, doc.UnusedTags(), 1)
+}
@@ -4,6 +4,8 @@
 package v3
 
 import (
+	"bytes"
+	stdjson "encoding/json"
 	"fmt"
 	"log"
 	"log/slog"
@@ -18,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
 	lowv2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -636,6 +639,171 @@ func TestDocument_MarshalIndention(t *testing.T) {
 	}
 }
 
+func TestDocument_RenderWithConfig(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/single-definition.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	lowDoc, _ = lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+
+	highDoc := NewDocument(lowDoc)
+	rendered, err := highDoc.RenderWithConfig(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, string(highDoc.RenderWithIndention(4)), string(rendered))
+
+	rendered, err = highDoc.RenderWithConfig(&high.RenderConfig{Indent: 2})
+	assert.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, string(data), strings.TrimSpace(string(rendered)))
+	}
+}
+
+func TestDocument_RenderWithOptions(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: '#/components/responses/Pets'
+components:
+  responses:
+    Pets:
+      description: ok
+      content:
+        application/json:
+          schema:
+            type: number
+            maximum: 1.0`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	h := NewDocument(lDoc)
+
+	rendered, err := h.RenderWithOptions(nil)
+	assert.NoError(t, err)
+	expected, _ := h.Render()
+	assert.Equal(t, string(expected), string(rendered))
+
+	rendered, err = h.RenderWithOptions(&high.RenderOptions{Style: &high.RenderConfig{Indent: 2}, PreserveNumbers: true})
+	assert.NoError(t, err)
+	assert.Contains(t, string(rendered), "\n            maximum: 1.0\n")
+	rendered, _ = h.RenderWithOptions(&high.RenderOptions{Style: &high.RenderConfig{Indent: 2}})
+	assert.Contains(t, string(rendered), "\n            maximum: 1\n")
+
+	rendered, err = h.RenderWithOptions(&high.RenderOptions{Format: high.RenderFormatJSON, Inline: true})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(rendered), "$ref")
+	assert.Contains(t, string(rendered), "\n  \"info\": {")
+
+	rendered, err = h.RenderWithOptions(&high.RenderOptions{Format: high.RenderFormatJSON, JSONIndent: "\t", PreserveNumbers: true})
+	assert.NoError(t, err)
+	assert.Contains(t, string(rendered), "\"maximum\": 1.0")
+	assert.Contains(t, string(rendered), "\n\t\"info\": {")
+
+	rendered, err = h.RenderWithOptions(&high.RenderOptions{Format: high.RenderFormatJSONMinified})
+	assert.NoError(t, err)
+	minified, _, _ := h.RenderJSONMinified()
+	assert.Equal(t, string(minified), string(rendered))
+
+	rendered, err = h.RenderWithOptions(&high.RenderOptions{Format: high.RenderFormatJSON, PreserveAnchors: true})
+	assert.ErrorIs(t, err, high.ErrUnsupportedRenderOptions)
+	assert.EqualError(t, err, "render option 'PreserveAnchors' can't be combined with 'Format: json': JSON has no anchors or aliases")
+	assert.Nil(t, rendered)
+}
+
+func TestDocument_RenderWithOptions_PreserveAnchors(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - &limit
+          name: limit
+          in: query
+      responses:
+        "200": &ok
+          description: ok
+          content:
+            application/json:
+              schema: &pet
+                type: object
+  /owners:
+    get:
+      parameters:
+        - *limit
+      responses:
+        "200": *ok
+components:
+  schemas:
+    Pet: *pet
+    Cat:
+      <<: *pet`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	h := NewDocument(lDoc)
+
+	options := &high.RenderOptions{Style: &high.RenderConfig{Indent: 2}, PreserveAnchors: true}
+	rendered, err := h.RenderWithOptions(options)
+	assert.NoError(t, err)
+	assert.Equal(t, yml, strings.TrimSpace(string(rendered)))
+
+	// without it, every alias is a copy.
+	rendered, _ = h.RenderWithOptions(&high.RenderOptions{Style: &high.RenderConfig{Indent: 2}})
+	assert.NotContains(t, string(rendered), "*")
+
+	// an alias changed in the model is no longer an alias.
+	h.Paths.PathItems.GetOrZero("/owners").Get.Parameters[0].Name = "max"
+	rendered, err = h.RenderWithOptions(options)
+	assert.NoError(t, err)
+	assert.Contains(t, string(rendered), "      parameters:\n        - name: max\n          in: query\n")
+	assert.Contains(t, string(rendered), "\"200\": *ok")
+}
+
+func TestDocument_RenderWithConfig_Canonical(t *testing.T) {
+	yml := `paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+      summary: list pets
+info:
+  version: 1.0.0
+  title: pets
+openapi: 3.1.0`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	rendered, err := NewDocument(lDoc).RenderWithConfig(&high.RenderConfig{
+		Indent:   2,
+		KeyOrder: high.KeyOrderCanonical,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      summary: list pets
+      responses:
+        "200":
+          description: ok
+`, string(rendered))
+}
+
 func TestDocument_Nullable_Example(t *testing.T) {
 	data, _ := os.ReadFile("../../../test_specs/nullable-examples.openapi.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -692,6 +860,76 @@ func TestDocument_MarshalJSON(t *testing.T) {
 	assert.Equal(t, orderedmap.Len(newDoc.Components.Schemas), orderedmap.Len(highDoc.Components.Schemas))
 }
 
+func TestDocument_RenderJSONPreserveNumbers(t *testing.T) {
+	jsonFile := `{"openapi":"3.0.0","info":{"title":"dummy","version":"1.0.0"},"paths":{"/dummy":{"post":{"requestBody":{"content":{"application/json":{"schema":{"type":"object","properties":{"value":{"type":"number","format":"decimal","multipleOf":0.01,"minimum":-999.99,"maximum":1.0,"example":12345678901234567890123}}}}}},"responses":{"200":{"description":"OK"}}}}}}`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(jsonFile))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	h := NewDocument(lDoc)
+
+	// the default renderer can't render the minimum.
+	_, err = h.RenderJSON("")
+	assert.Error(t, err)
+
+	r, err := h.RenderJSONPreserveNumbers("")
+	assert.NoError(t, err)
+
+	var compacted bytes.Buffer
+	assert.NoError(t, stdjson.Compact(&compacted, r))
+	assert.Contains(t, compacted.String(), `"multipleOf":0.01,"maximum":1.0,"minimum":-999.99,"format":"decimal","example":12345678901234567890123`)
+
+	// values changed in the model are rendered as they are.
+	value := h.Paths.PathItems.GetOrZero("/dummy").Post.RequestBody.Content.GetOrZero("application/json").Schema.Schema().Properties.GetOrZero("value").Schema()
+	max := 2.0
+	value.Maximum = &max
+	r, err = h.RenderJSONPreserveNumbers("")
+	assert.NoError(t, err)
+	compacted.Reset()
+	assert.NoError(t, stdjson.Compact(&compacted, r))
+	assert.Contains(t, compacted.String(), `"maximum":2,`)
+}
+
+func TestDocument_RenderJSONMinified(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/petstorev3.json")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	lowDoc, _ = lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+
+	highDoc := NewDocument(lowDoc)
+
+	minified, sourceMap, err := highDoc.RenderJSONMinified()
+	assert.NoError(t, err)
+
+	rendered, _ := highDoc.RenderJSON("  ")
+	var compacted bytes.Buffer
+	assert.NoError(t, stdjson.Compact(&compacted, rendered))
+	assert.Equal(t, compacted.String(), string(minified))
+
+	// every value can be traced back to the model.
+	title := sourceMap.Find("/info/title")
+	assert.NotNil(t, title)
+	assert.Equal(t, `"Swagger Petstore - OpenAPI 3.0"`, string(minified[title.Offset:title.Offset+title.Length]))
+	assert.Equal(t, highDoc.Info.GoLow().Title.ValueNode.Line, title.Line)
+
+	op := sourceMap.Find("/paths/~1pet/put/operationId")
+	assert.NotNil(t, op)
+	assert.Equal(t, "/paths/~1pet/put/operationId", sourceMap.At(op.Offset).Pointer)
+}
+
+func TestDocument_RenderJSONMinified_Error(t *testing.T) {
+	jsonFile := `{"openapi":"3.0.0","info":{"title":"dummy","version":"1.0.0"},"paths":{"/dummy":{"post":{"requestBody":{"content":{"application/json":{"schema":{"type":"object","properties":{"value":{"type":"number","format":"decimal","multipleOf":0.01,"minimum":-999.99}}}}}},"responses":{"200":{"description":"OK"}}}}}}`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(jsonFile))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	r, sm, e := NewDocument(lDoc).RenderJSONMinified()
+	assert.Nil(t, r)
+	assert.Nil(t, sm)
+	assert.Error(t, e)
+}
+
 func TestDocument_MarshalYAMLInline(t *testing.T) {
 	// create a new document
 	initTest()
@@ -901,3 +1139,73 @@ func TestDocument_RenderJSONError(t *testing.T) {
 	assert.Error(t, e)
 	assert.Equal(t, "yaml: cannot decode !!float `-999.99` as a !!int", e.Error())
 }
+
+func TestDocument_Unclaimed(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: pasted
+  version: "1"
+definitions:
+  Pet:
+    type: object`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	h := NewDocument(lDoc)
+
+	assert.Equal(t, 1, orderedmap.Len(h.Unclaimed))
+	assert.Equal(t, "Pet", h.Unclaimed.GetOrZero("definitions").Content[0].Value)
+
+	// unclaimed content is not rendered.
+	r, err := h.Render()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(r), "definitions")
+}
+
+func TestDocument_RenderComments(t *testing.T) {
+	yml := `openapi: 3.1.0
+# owner: platform-team
+info:
+  # contact the api guild before changing
+  title: Pets # public name
+  version: 1.0.0
+  x-owner: payments # team
+paths:
+  # owner: pets-team
+  /pets:
+    get:
+      operationId: listPets # stable
+      responses:
+        # default response
+        '200':
+          description: ok`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+	h := NewDocument(lDoc)
+
+	assert.Equal(t, []string{"owner: platform-team"}, lDoc.Info.Comments().Text())
+	assert.Equal(t, "# contact the api guild before changing", lDoc.Info.Value.Title.Comments().Head)
+
+	// mutate the model, comments should survive being rendered again.
+	h.Info.Description = "all the pets"
+	h.Paths.PathItems.GetOrZero("/pets").Get.Summary = "list pets"
+
+	r, err := h.Render()
+	assert.NoError(t, err)
+	rendered := string(r)
+	for _, c := range []string{
+		"# owner: platform-team\ninfo:",
+		"# contact the api guild before changing\n    title: Pets # public name",
+		"x-owner: payments # team",
+		"# owner: pets-team\n    /pets:",
+		"operationId: listPets # stable",
+		"# default response\n                '200':",
+	} {
+		assert.Contains(t, rendered, c)
+	}
+	assert.Contains(t, rendered, "description: all the pets")
+	assert.Contains(t, rendered, "summary: list pets")
+}
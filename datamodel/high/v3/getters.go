@@ -0,0 +1,1157 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Code generated by go run ../internal/getters; DO NOT EDIT.
+
+package v3
+
+import (
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// GetExpression returns the Expression of the Callback, or the zero value if the Callback is nil.
+func (c *Callback) GetExpression() *orderedmap.Map[string, *PathItem] {
+	if c == nil {
+		return nil
+	}
+	return c.Expression
+}
+
+// GetExtensions returns the Extensions of the Callback, or the zero value if the Callback is nil.
+func (c *Callback) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if c == nil {
+		return nil
+	}
+	return c.Extensions
+}
+
+// GetSchemas returns the Schemas of the Components, or the zero value if the Components is nil.
+func (c *Components) GetSchemas() *orderedmap.Map[string, *highbase.SchemaProxy] {
+	if c == nil {
+		return nil
+	}
+	return c.Schemas
+}
+
+// GetResponses returns the Responses of the Components, or the zero value if the Components is nil.
+func (c *Components) GetResponses() *orderedmap.Map[string, *Response] {
+	if c == nil {
+		return nil
+	}
+	return c.Responses
+}
+
+// GetParameters returns the Parameters of the Components, or the zero value if the Components is nil.
+func (c *Components) GetParameters() *orderedmap.Map[string, *Parameter] {
+	if c == nil {
+		return nil
+	}
+	return c.Parameters
+}
+
+// GetExamples returns the Examples of the Components, or the zero value if the Components is nil.
+func (c *Components) GetExamples() *orderedmap.Map[string, *highbase.Example] {
+	if c == nil {
+		return nil
+	}
+	return c.Examples
+}
+
+// GetRequestBodies returns the RequestBodies of the Components, or the zero value if the Components is nil.
+func (c *Components) GetRequestBodies() *orderedmap.Map[string, *RequestBody] {
+	if c == nil {
+		return nil
+	}
+	return c.RequestBodies
+}
+
+// GetHeaders returns the Headers of the Components, or the zero value if the Components is nil.
+func (c *Components) GetHeaders() *orderedmap.Map[string, *Header] {
+	if c == nil {
+		return nil
+	}
+	return c.Headers
+}
+
+// GetSecuritySchemes returns the SecuritySchemes of the Components, or the zero value if the Components is nil.
+func (c *Components) GetSecuritySchemes() *orderedmap.Map[string, *SecurityScheme] {
+	if c == nil {
+		return nil
+	}
+	return c.SecuritySchemes
+}
+
+// GetLinks returns the Links of the Components, or the zero value if the Components is nil.
+func (c *Components) GetLinks() *orderedmap.Map[string, *Link] {
+	if c == nil {
+		return nil
+	}
+	return c.Links
+}
+
+// GetCallbacks returns the Callbacks of the Components, or the zero value if the Components is nil.
+func (c *Components) GetCallbacks() *orderedmap.Map[string, *Callback] {
+	if c == nil {
+		return nil
+	}
+	return c.Callbacks
+}
+
+// GetPathItems returns the PathItems of the Components, or the zero value if the Components is nil.
+func (c *Components) GetPathItems() *orderedmap.Map[string, *PathItem] {
+	if c == nil {
+		return nil
+	}
+	return c.PathItems
+}
+
+// GetExtensions returns the Extensions of the Components, or the zero value if the Components is nil.
+func (c *Components) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if c == nil {
+		return nil
+	}
+	return c.Extensions
+}
+
+// GetVersion returns the Version of the Document, or the zero value if the Document is nil.
+func (d *Document) GetVersion() string {
+	if d == nil {
+		return ""
+	}
+	return d.Version
+}
+
+// GetSelf returns the Self of the Document, or the zero value if the Document is nil.
+func (d *Document) GetSelf() string {
+	if d == nil {
+		return ""
+	}
+	return d.Self
+}
+
+// GetInfo returns the Info of the Document, or the zero value if the Document is nil.
+func (d *Document) GetInfo() *highbase.Info {
+	if d == nil {
+		return nil
+	}
+	return d.Info
+}
+
+// GetServers returns the Servers of the Document, or the zero value if the Document is nil.
+func (d *Document) GetServers() []*Server {
+	if d == nil {
+		return nil
+	}
+	return d.Servers
+}
+
+// GetPaths returns the Paths of the Document, or the zero value if the Document is nil.
+func (d *Document) GetPaths() *Paths {
+	if d == nil {
+		return nil
+	}
+	return d.Paths
+}
+
+// GetComponents returns the Components of the Document, or the zero value if the Document is nil.
+func (d *Document) GetComponents() *Components {
+	if d == nil {
+		return nil
+	}
+	return d.Components
+}
+
+// GetSecurity returns the Security of the Document, or the zero value if the Document is nil.
+func (d *Document) GetSecurity() []*highbase.SecurityRequirement {
+	if d == nil {
+		return nil
+	}
+	return d.Security
+}
+
+// GetTags returns the Tags of the Document, or the zero value if the Document is nil.
+func (d *Document) GetTags() []*highbase.Tag {
+	if d == nil {
+		return nil
+	}
+	return d.Tags
+}
+
+// GetExternalDocs returns the ExternalDocs of the Document, or the zero value if the Document is nil.
+func (d *Document) GetExternalDocs() *highbase.ExternalDoc {
+	if d == nil {
+		return nil
+	}
+	return d.ExternalDocs
+}
+
+// GetExtensions returns the Extensions of the Document, or the zero value if the Document is nil.
+func (d *Document) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if d == nil {
+		return nil
+	}
+	return d.Extensions
+}
+
+// GetUnclaimed returns the Unclaimed of the Document, or the zero value if the Document is nil.
+func (d *Document) GetUnclaimed() *orderedmap.Map[string, *yaml.Node] {
+	if d == nil {
+		return nil
+	}
+	return d.Unclaimed
+}
+
+// GetJsonSchemaDialect returns the JsonSchemaDialect of the Document, or the zero value if the Document is nil.
+func (d *Document) GetJsonSchemaDialect() string {
+	if d == nil {
+		return ""
+	}
+	return d.JsonSchemaDialect
+}
+
+// GetWebhooks returns the Webhooks of the Document, or the zero value if the Document is nil.
+func (d *Document) GetWebhooks() *orderedmap.Map[string, *PathItem] {
+	if d == nil {
+		return nil
+	}
+	return d.Webhooks
+}
+
+// GetIndex returns the Index of the Document, or the zero value if the Document is nil.
+func (d *Document) GetIndex() *index.SpecIndex {
+	if d == nil {
+		return nil
+	}
+	return d.Index
+}
+
+// GetRolodex returns the Rolodex of the Document, or the zero value if the Document is nil.
+func (d *Document) GetRolodex() *index.Rolodex {
+	if d == nil {
+		return nil
+	}
+	return d.Rolodex
+}
+
+// GetContentType returns the ContentType of the Encoding, or the zero value if the Encoding is nil.
+func (e *Encoding) GetContentType() string {
+	if e == nil {
+		return ""
+	}
+	return e.ContentType
+}
+
+// GetHeaders returns the Headers of the Encoding, or the zero value if the Encoding is nil.
+func (e *Encoding) GetHeaders() *orderedmap.Map[string, *Header] {
+	if e == nil {
+		return nil
+	}
+	return e.Headers
+}
+
+// GetStyle returns the Style of the Encoding, or the zero value if the Encoding is nil.
+func (e *Encoding) GetStyle() string {
+	if e == nil {
+		return ""
+	}
+	return e.Style
+}
+
+// GetExplode returns the Explode of the Encoding, or the zero value if the Encoding is nil.
+func (e *Encoding) GetExplode() *bool {
+	if e == nil {
+		return nil
+	}
+	return e.Explode
+}
+
+// GetAllowReserved returns the AllowReserved of the Encoding, or the zero value if the Encoding is nil.
+func (e *Encoding) GetAllowReserved() bool {
+	if e == nil {
+		return false
+	}
+	return e.AllowReserved
+}
+
+// GetDescription returns the Description of the Header, or the zero value if the Header is nil.
+func (h *Header) GetDescription() string {
+	if h == nil {
+		return ""
+	}
+	return h.Description
+}
+
+// GetRequired returns the Required of the Header, or the zero value if the Header is nil.
+func (h *Header) GetRequired() bool {
+	if h == nil {
+		return false
+	}
+	return h.Required
+}
+
+// GetDeprecated returns the Deprecated of the Header, or the zero value if the Header is nil.
+func (h *Header) GetDeprecated() bool {
+	if h == nil {
+		return false
+	}
+	return h.Deprecated
+}
+
+// GetAllowEmptyValue returns the AllowEmptyValue of the Header, or the zero value if the Header is nil.
+func (h *Header) GetAllowEmptyValue() bool {
+	if h == nil {
+		return false
+	}
+	return h.AllowEmptyValue
+}
+
+// GetStyle returns the Style of the Header, or the zero value if the Header is nil.
+func (h *Header) GetStyle() string {
+	if h == nil {
+		return ""
+	}
+	return h.Style
+}
+
+// GetExplode returns the Explode of the Header, or the zero value if the Header is nil.
+func (h *Header) GetExplode() bool {
+	if h == nil {
+		return false
+	}
+	return h.Explode
+}
+
+// GetAllowReserved returns the AllowReserved of the Header, or the zero value if the Header is nil.
+func (h *Header) GetAllowReserved() bool {
+	if h == nil {
+		return false
+	}
+	return h.AllowReserved
+}
+
+// GetSchema returns the Schema of the Header, or the zero value if the Header is nil.
+func (h *Header) GetSchema() *highbase.SchemaProxy {
+	if h == nil {
+		return nil
+	}
+	return h.Schema
+}
+
+// GetExample returns the Example of the Header, or the zero value if the Header is nil.
+func (h *Header) GetExample() *yaml.Node {
+	if h == nil {
+		return nil
+	}
+	return h.Example
+}
+
+// GetExamples returns the Examples of the Header, or the zero value if the Header is nil.
+func (h *Header) GetExamples() *orderedmap.Map[string, *highbase.Example] {
+	if h == nil {
+		return nil
+	}
+	return h.Examples
+}
+
+// GetContent returns the Content of the Header, or the zero value if the Header is nil.
+func (h *Header) GetContent() *orderedmap.Map[string, *MediaType] {
+	if h == nil {
+		return nil
+	}
+	return h.Content
+}
+
+// GetExtensions returns the Extensions of the Header, or the zero value if the Header is nil.
+func (h *Header) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if h == nil {
+		return nil
+	}
+	return h.Extensions
+}
+
+// GetOperationRef returns the OperationRef of the Link, or the zero value if the Link is nil.
+func (l *Link) GetOperationRef() string {
+	if l == nil {
+		return ""
+	}
+	return l.OperationRef
+}
+
+// GetOperationId returns the OperationId of the Link, or the zero value if the Link is nil.
+func (l *Link) GetOperationId() string {
+	if l == nil {
+		return ""
+	}
+	return l.OperationId
+}
+
+// GetParameters returns the Parameters of the Link, or the zero value if the Link is nil.
+func (l *Link) GetParameters() *orderedmap.Map[string, string] {
+	if l == nil {
+		return nil
+	}
+	return l.Parameters
+}
+
+// GetRequestBody returns the RequestBody of the Link, or the zero value if the Link is nil.
+func (l *Link) GetRequestBody() string {
+	if l == nil {
+		return ""
+	}
+	return l.RequestBody
+}
+
+// GetDescription returns the Description of the Link, or the zero value if the Link is nil.
+func (l *Link) GetDescription() string {
+	if l == nil {
+		return ""
+	}
+	return l.Description
+}
+
+// GetServer returns the Server of the Link, or the zero value if the Link is nil.
+func (l *Link) GetServer() *Server {
+	if l == nil {
+		return nil
+	}
+	return l.Server
+}
+
+// GetExtensions returns the Extensions of the Link, or the zero value if the Link is nil.
+func (l *Link) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if l == nil {
+		return nil
+	}
+	return l.Extensions
+}
+
+// GetSchema returns the Schema of the MediaType, or the zero value if the MediaType is nil.
+func (m *MediaType) GetSchema() *highbase.SchemaProxy {
+	if m == nil {
+		return nil
+	}
+	return m.Schema
+}
+
+// GetItemSchema returns the ItemSchema of the MediaType, or the zero value if the MediaType is nil.
+func (m *MediaType) GetItemSchema() *highbase.SchemaProxy {
+	if m == nil {
+		return nil
+	}
+	return m.ItemSchema
+}
+
+// GetExample returns the Example of the MediaType, or the zero value if the MediaType is nil.
+func (m *MediaType) GetExample() *yaml.Node {
+	if m == nil {
+		return nil
+	}
+	return m.Example
+}
+
+// GetExamples returns the Examples of the MediaType, or the zero value if the MediaType is nil.
+func (m *MediaType) GetExamples() *orderedmap.Map[string, *highbase.Example] {
+	if m == nil {
+		return nil
+	}
+	return m.Examples
+}
+
+// GetEncoding returns the Encoding of the MediaType, or the zero value if the MediaType is nil.
+func (m *MediaType) GetEncoding() *orderedmap.Map[string, *Encoding] {
+	if m == nil {
+		return nil
+	}
+	return m.Encoding
+}
+
+// GetExtensions returns the Extensions of the MediaType, or the zero value if the MediaType is nil.
+func (m *MediaType) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if m == nil {
+		return nil
+	}
+	return m.Extensions
+}
+
+// GetAuthorizationUrl returns the AuthorizationUrl of the OAuthFlow, or the zero value if the OAuthFlow is nil.
+func (o *OAuthFlow) GetAuthorizationUrl() string {
+	if o == nil {
+		return ""
+	}
+	return o.AuthorizationUrl
+}
+
+// GetTokenUrl returns the TokenUrl of the OAuthFlow, or the zero value if the OAuthFlow is nil.
+func (o *OAuthFlow) GetTokenUrl() string {
+	if o == nil {
+		return ""
+	}
+	return o.TokenUrl
+}
+
+// GetRefreshUrl returns the RefreshUrl of the OAuthFlow, or the zero value if the OAuthFlow is nil.
+func (o *OAuthFlow) GetRefreshUrl() string {
+	if o == nil {
+		return ""
+	}
+	return o.RefreshUrl
+}
+
+// GetScopes returns the Scopes of the OAuthFlow, or the zero value if the OAuthFlow is nil.
+func (o *OAuthFlow) GetScopes() *orderedmap.Map[string, string] {
+	if o == nil {
+		return nil
+	}
+	return o.Scopes
+}
+
+// GetExtensions returns the Extensions of the OAuthFlow, or the zero value if the OAuthFlow is nil.
+func (o *OAuthFlow) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if o == nil {
+		return nil
+	}
+	return o.Extensions
+}
+
+// GetImplicit returns the Implicit of the OAuthFlows, or the zero value if the OAuthFlows is nil.
+func (o *OAuthFlows) GetImplicit() *OAuthFlow {
+	if o == nil {
+		return nil
+	}
+	return o.Implicit
+}
+
+// GetPassword returns the Password of the OAuthFlows, or the zero value if the OAuthFlows is nil.
+func (o *OAuthFlows) GetPassword() *OAuthFlow {
+	if o == nil {
+		return nil
+	}
+	return o.Password
+}
+
+// GetClientCredentials returns the ClientCredentials of the OAuthFlows, or the zero value if the OAuthFlows is nil.
+func (o *OAuthFlows) GetClientCredentials() *OAuthFlow {
+	if o == nil {
+		return nil
+	}
+	return o.ClientCredentials
+}
+
+// GetAuthorizationCode returns the AuthorizationCode of the OAuthFlows, or the zero value if the OAuthFlows is nil.
+func (o *OAuthFlows) GetAuthorizationCode() *OAuthFlow {
+	if o == nil {
+		return nil
+	}
+	return o.AuthorizationCode
+}
+
+// GetExtensions returns the Extensions of the OAuthFlows, or the zero value if the OAuthFlows is nil.
+func (o *OAuthFlows) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if o == nil {
+		return nil
+	}
+	return o.Extensions
+}
+
+// GetTags returns the Tags of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetTags() []string {
+	if o == nil {
+		return nil
+	}
+	return o.Tags
+}
+
+// GetSummary returns the Summary of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetSummary() string {
+	if o == nil {
+		return ""
+	}
+	return o.Summary
+}
+
+// GetDescription returns the Description of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetDescription() string {
+	if o == nil {
+		return ""
+	}
+	return o.Description
+}
+
+// GetExternalDocs returns the ExternalDocs of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetExternalDocs() *highbase.ExternalDoc {
+	if o == nil {
+		return nil
+	}
+	return o.ExternalDocs
+}
+
+// GetOperationId returns the OperationId of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetOperationId() string {
+	if o == nil {
+		return ""
+	}
+	return o.OperationId
+}
+
+// GetParameters returns the Parameters of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetParameters() []*Parameter {
+	if o == nil {
+		return nil
+	}
+	return o.Parameters
+}
+
+// GetRequestBody returns the RequestBody of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetRequestBody() *RequestBody {
+	if o == nil {
+		return nil
+	}
+	return o.RequestBody
+}
+
+// GetResponses returns the Responses of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetResponses() *Responses {
+	if o == nil {
+		return nil
+	}
+	return o.Responses
+}
+
+// GetCallbacks returns the Callbacks of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetCallbacks() *orderedmap.Map[string, *Callback] {
+	if o == nil {
+		return nil
+	}
+	return o.Callbacks
+}
+
+// GetDeprecated returns the Deprecated of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetDeprecated() *bool {
+	if o == nil {
+		return nil
+	}
+	return o.Deprecated
+}
+
+// GetSecurity returns the Security of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetSecurity() []*highbase.SecurityRequirement {
+	if o == nil {
+		return nil
+	}
+	return o.Security
+}
+
+// GetServers returns the Servers of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetServers() []*Server {
+	if o == nil {
+		return nil
+	}
+	return o.Servers
+}
+
+// GetCodeSamples returns the CodeSamples of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetCodeSamples() []*highbase.CodeSample {
+	if o == nil {
+		return nil
+	}
+	return o.CodeSamples
+}
+
+// GetExtensions returns the Extensions of the Operation, or the zero value if the Operation is nil.
+func (o *Operation) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if o == nil {
+		return nil
+	}
+	return o.Extensions
+}
+
+// GetName returns the Name of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetName() string {
+	if p == nil {
+		return ""
+	}
+	return p.Name
+}
+
+// GetIn returns the In of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetIn() string {
+	if p == nil {
+		return ""
+	}
+	return p.In
+}
+
+// GetDescription returns the Description of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetDescription() string {
+	if p == nil {
+		return ""
+	}
+	return p.Description
+}
+
+// GetRequired returns the Required of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetRequired() *bool {
+	if p == nil {
+		return nil
+	}
+	return p.Required
+}
+
+// GetDeprecated returns the Deprecated of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetDeprecated() bool {
+	if p == nil {
+		return false
+	}
+	return p.Deprecated
+}
+
+// GetAllowEmptyValue returns the AllowEmptyValue of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetAllowEmptyValue() bool {
+	if p == nil {
+		return false
+	}
+	return p.AllowEmptyValue
+}
+
+// GetStyle returns the Style of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetStyle() string {
+	if p == nil {
+		return ""
+	}
+	return p.Style
+}
+
+// GetExplode returns the Explode of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExplode() *bool {
+	if p == nil {
+		return nil
+	}
+	return p.Explode
+}
+
+// GetAllowReserved returns the AllowReserved of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetAllowReserved() bool {
+	if p == nil {
+		return false
+	}
+	return p.AllowReserved
+}
+
+// GetSchema returns the Schema of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetSchema() *highbase.SchemaProxy {
+	if p == nil {
+		return nil
+	}
+	return p.Schema
+}
+
+// GetExample returns the Example of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExample() *yaml.Node {
+	if p == nil {
+		return nil
+	}
+	return p.Example
+}
+
+// GetExamples returns the Examples of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExamples() *orderedmap.Map[string, *highbase.Example] {
+	if p == nil {
+		return nil
+	}
+	return p.Examples
+}
+
+// GetContent returns the Content of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetContent() *orderedmap.Map[string, *MediaType] {
+	if p == nil {
+		return nil
+	}
+	return p.Content
+}
+
+// GetExtensions returns the Extensions of the Parameter, or the zero value if the Parameter is nil.
+func (p *Parameter) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetDescription returns the Description of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetDescription() string {
+	if p == nil {
+		return ""
+	}
+	return p.Description
+}
+
+// GetSummary returns the Summary of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetSummary() string {
+	if p == nil {
+		return ""
+	}
+	return p.Summary
+}
+
+// GetGet returns the Get of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetGet() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Get
+}
+
+// GetPut returns the Put of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetPut() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Put
+}
+
+// GetPost returns the Post of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetPost() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Post
+}
+
+// GetDelete returns the Delete of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetDelete() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Delete
+}
+
+// GetOptions returns the Options of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetOptions() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Options
+}
+
+// GetHead returns the Head of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetHead() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Head
+}
+
+// GetPatch returns the Patch of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetPatch() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Patch
+}
+
+// GetTrace returns the Trace of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetTrace() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Trace
+}
+
+// GetQuery returns the Query of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetQuery() *Operation {
+	if p == nil {
+		return nil
+	}
+	return p.Query
+}
+
+// GetAdditionalOperations returns the AdditionalOperations of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetAdditionalOperations() *orderedmap.Map[string, *Operation] {
+	if p == nil {
+		return nil
+	}
+	return p.AdditionalOperations
+}
+
+// GetServers returns the Servers of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetServers() []*Server {
+	if p == nil {
+		return nil
+	}
+	return p.Servers
+}
+
+// GetParameters returns the Parameters of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetParameters() []*Parameter {
+	if p == nil {
+		return nil
+	}
+	return p.Parameters
+}
+
+// GetExtensions returns the Extensions of the PathItem, or the zero value if the PathItem is nil.
+func (p *PathItem) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetPathItems returns the PathItems of the Paths, or the zero value if the Paths is nil.
+func (p *Paths) GetPathItems() *orderedmap.Map[string, *PathItem] {
+	if p == nil {
+		return nil
+	}
+	return p.PathItems
+}
+
+// GetExtensions returns the Extensions of the Paths, or the zero value if the Paths is nil.
+func (p *Paths) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.Extensions
+}
+
+// GetInvalidPaths returns the InvalidPaths of the Paths, or the zero value if the Paths is nil.
+func (p *Paths) GetInvalidPaths() *orderedmap.Map[string, *yaml.Node] {
+	if p == nil {
+		return nil
+	}
+	return p.InvalidPaths
+}
+
+// GetDescription returns the Description of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetDescription() string {
+	if r == nil {
+		return ""
+	}
+	return r.Description
+}
+
+// GetContent returns the Content of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetContent() *orderedmap.Map[string, *MediaType] {
+	if r == nil {
+		return nil
+	}
+	return r.Content
+}
+
+// GetRequired returns the Required of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetRequired() *bool {
+	if r == nil {
+		return nil
+	}
+	return r.Required
+}
+
+// GetExtensions returns the Extensions of the RequestBody, or the zero value if the RequestBody is nil.
+func (r *RequestBody) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if r == nil {
+		return nil
+	}
+	return r.Extensions
+}
+
+// GetDescription returns the Description of the Response, or the zero value if the Response is nil.
+func (r *Response) GetDescription() string {
+	if r == nil {
+		return ""
+	}
+	return r.Description
+}
+
+// GetHeaders returns the Headers of the Response, or the zero value if the Response is nil.
+func (r *Response) GetHeaders() *orderedmap.Map[string, *Header] {
+	if r == nil {
+		return nil
+	}
+	return r.Headers
+}
+
+// GetContent returns the Content of the Response, or the zero value if the Response is nil.
+func (r *Response) GetContent() *orderedmap.Map[string, *MediaType] {
+	if r == nil {
+		return nil
+	}
+	return r.Content
+}
+
+// GetLinks returns the Links of the Response, or the zero value if the Response is nil.
+func (r *Response) GetLinks() *orderedmap.Map[string, *Link] {
+	if r == nil {
+		return nil
+	}
+	return r.Links
+}
+
+// GetExtensions returns the Extensions of the Response, or the zero value if the Response is nil.
+func (r *Response) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if r == nil {
+		return nil
+	}
+	return r.Extensions
+}
+
+// GetCodes returns the Codes of the Responses, or the zero value if the Responses is nil.
+func (r *Responses) GetCodes() *orderedmap.Map[string, *Response] {
+	if r == nil {
+		return nil
+	}
+	return r.Codes
+}
+
+// GetDefault returns the Default of the Responses, or the zero value if the Responses is nil.
+func (r *Responses) GetDefault() *Response {
+	if r == nil {
+		return nil
+	}
+	return r.Default
+}
+
+// GetExtensions returns the Extensions of the Responses, or the zero value if the Responses is nil.
+func (r *Responses) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if r == nil {
+		return nil
+	}
+	return r.Extensions
+}
+
+// GetType returns the Type of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetType() string {
+	if s == nil {
+		return ""
+	}
+	return s.Type
+}
+
+// GetDescription returns the Description of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetDescription() string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+// GetName returns the Name of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetName() string {
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
+
+// GetIn returns the In of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetIn() string {
+	if s == nil {
+		return ""
+	}
+	return s.In
+}
+
+// GetScheme returns the Scheme of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetScheme() string {
+	if s == nil {
+		return ""
+	}
+	return s.Scheme
+}
+
+// GetBearerFormat returns the BearerFormat of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetBearerFormat() string {
+	if s == nil {
+		return ""
+	}
+	return s.BearerFormat
+}
+
+// GetFlows returns the Flows of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetFlows() *OAuthFlows {
+	if s == nil {
+		return nil
+	}
+	return s.Flows
+}
+
+// GetOpenIdConnectUrl returns the OpenIdConnectUrl of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetOpenIdConnectUrl() string {
+	if s == nil {
+		return ""
+	}
+	return s.OpenIdConnectUrl
+}
+
+// GetExtensions returns the Extensions of the SecurityScheme, or the zero value if the SecurityScheme is nil.
+func (s *SecurityScheme) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetURL returns the URL of the Server, or the zero value if the Server is nil.
+func (s *Server) GetURL() string {
+	if s == nil {
+		return ""
+	}
+	return s.URL
+}
+
+// GetDescription returns the Description of the Server, or the zero value if the Server is nil.
+func (s *Server) GetDescription() string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+// GetVariables returns the Variables of the Server, or the zero value if the Server is nil.
+func (s *Server) GetVariables() *orderedmap.Map[string, *ServerVariable] {
+	if s == nil {
+		return nil
+	}
+	return s.Variables
+}
+
+// GetExtensions returns the Extensions of the Server, or the zero value if the Server is nil.
+func (s *Server) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
+
+// GetEnum returns the Enum of the ServerVariable, or the zero value if the ServerVariable is nil.
+func (s *ServerVariable) GetEnum() []string {
+	if s == nil {
+		return nil
+	}
+	return s.Enum
+}
+
+// GetDefault returns the Default of the ServerVariable, or the zero value if the ServerVariable is nil.
+func (s *ServerVariable) GetDefault() string {
+	if s == nil {
+		return ""
+	}
+	return s.Default
+}
+
+// GetDescription returns the Description of the ServerVariable, or the zero value if the ServerVariable is nil.
+func (s *ServerVariable) GetDescription() string {
+	if s == nil {
+		return ""
+	}
+	return s.Description
+}
+
+// GetExtensions returns the Extensions of the ServerVariable, or the zero value if the ServerVariable is nil.
+func (s *ServerVariable) GetExtensions() *orderedmap.Map[string, *yaml.Node] {
+	if s == nil {
+		return nil
+	}
+	return s.Extensions
+}
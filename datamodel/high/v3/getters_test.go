@@ -0,0 +1,31 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetters_NilSafe(t *testing.T) {
+	var op *Operation
+	assert.Nil(t, op.GetRequestBody().GetContent().GetOrZero("application/json").GetSchema())
+	assert.Empty(t, op.GetOperationId())
+	assert.Nil(t, op.GetDeprecated())
+	assert.Nil(t, op.GetTags())
+
+	var doc *Document
+	assert.Empty(t, doc.GetInfo().GetTitle())
+	assert.Nil(t, doc.GetComponents().GetSchemas().GetOrZero("Pet"))
+
+	op = &Operation{OperationId: "listPets", RequestBody: &RequestBody{Content: orderedmap.New[string, *MediaType]()}}
+	schema := base.CreateSchemaProxy(&base.Schema{Description: "a pet"})
+	op.RequestBody.Content.Set("application/json", &MediaType{Schema: schema})
+	assert.Equal(t, "listPets", op.GetOperationId())
+	assert.Same(t, schema, op.GetRequestBody().GetContent().GetOrZero("application/json").GetSchema())
+	assert.Nil(t, op.GetRequestBody().GetContent().GetOrZero("application/xml").GetSchema())
+}
@@ -4,9 +4,15 @@
 package v3
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"gopkg.in/yaml.v3"
 )
@@ -50,6 +56,59 @@ func NewLink(link *lowv3.Link) *Link {
 	return l
 }
 
+// ResolveOperation returns the operation the link points to in doc. An operationId is matched against the
+// operations of every path of doc. An operationRef pointing into doc (`#/paths/~1pets/get`) is resolved against
+// the model, any other operationRef (such as `./pets.yaml#/paths/~1pets/get`) is located the same way as a
+// reference, using the index of doc, and the operation is built from the file it is located in.
+//
+// Dangling links of a document are also reported by the index, see index.SpecIndex.GetLinkIndexErrors.
+func (l *Link) ResolveOperation(doc *Document) (*Operation, error) {
+	switch {
+	case l.OperationId != "" && l.OperationRef != "":
+		return nil, errors.New("link has both an operationId and an operationRef, only one is allowed")
+	case l.OperationId != "":
+		var found *Operation
+		doc.eachOperation(func(op *SelectedOperation) {
+			if found == nil && op.Operation.OperationId == l.OperationId {
+				found = op.Operation
+			}
+		})
+		if found == nil {
+			return nil, fmt.Errorf("link operationId '%s' does not match any operation", l.OperationId)
+		}
+		return found, nil
+	case l.OperationRef == "":
+		return nil, errors.New("link has no operationId or operationRef")
+	}
+
+	if strings.HasPrefix(l.OperationRef, "#") {
+		v, err := high.ResolvePointer(doc, strings.TrimPrefix(l.OperationRef, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve link operationRef '%s': %w", l.OperationRef, err)
+		}
+		op, ok := v.(*Operation)
+		if !ok {
+			return nil, fmt.Errorf("link operationRef '%s' does not point to an operation", l.OperationRef)
+		}
+		return op, nil
+	}
+
+	if doc.GoLow() == nil || doc.GoLow().Index == nil {
+		return nil, fmt.Errorf("unable to resolve link operationRef '%s', the document has no index", l.OperationRef)
+	}
+	ref := doc.GoLow().Index.FindComponent(l.OperationRef)
+	if ref == nil || ref.Node == nil {
+		return nil, fmt.Errorf("unable to resolve link operationRef '%s', it can't be located", l.OperationRef)
+	}
+	lowOp := new(lowv3.Operation)
+	_ = low.BuildModel(ref.Node, lowOp)
+	ctx := context.WithValue(context.Background(), index.CurrentPathKey, ref.RemoteLocation)
+	if err := lowOp.Build(ctx, nil, ref.Node, ref.Index); err != nil {
+		return nil, fmt.Errorf("unable to build the operation of link operationRef '%s': %w", l.OperationRef, err)
+	}
+	return NewOperation(lowOp), nil
+}
+
 // GoLow will return the low-level Link instance used to create the high-level one.
 func (l *Link) GoLow() *lowv3.Link {
 	return l.low
@@ -4,11 +4,16 @@
 package v3
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLink_MarshalYAML(t *testing.T) {
@@ -37,3 +42,128 @@ server:
 
 	assert.Equal(t, desired, strings.TrimSpace(string(dat)))
 }
+
+func linksDocument(t *testing.T) *Document {
+	dir := t.TempDir()
+	spec := `openapi: 3.1.0
+info:
+  title: links
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets
+          links:
+            byId:
+              operationId: getPet
+            byRef:
+              operationRef: '#/paths/~1pets~1{id}/get'
+            toys:
+              operationRef: './toys.yaml#/paths/~1toys/get'
+            missing:
+              operationId: nope
+            both:
+              operationId: getPet
+              operationRef: '#/paths/~1pets~1{id}/get'
+            neither:
+              description: nothing
+            missingRef:
+              operationRef: '#/paths/~1nope/get'
+            notAnOperation:
+              operationRef: '#/info'
+  /pets/{id}:
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: a pet
+components:
+  links:
+    missingToy:
+      operationRef: './toys.yaml#/paths/~1missing/get'
+  responses:
+    Pet:
+      description: a pet
+      links:
+        self:
+          operationId: getPet`
+	toys := `openapi: 3.1.0
+paths:
+  /toys:
+    get:
+      operationId: listToys
+      summary: every toy
+      responses:
+        "200":
+          description: toys`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "toys.yaml"), []byte(toys), 0o644))
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+	config := datamodel.NewDocumentConfiguration()
+	config.AllowFileReferences = true
+	config.BasePath = dir
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+	return NewDocument(lowDoc)
+}
+
+func TestLink_ResolveOperation(t *testing.T) {
+	doc := linksDocument(t)
+	links := doc.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200").Links
+	getPet := doc.Paths.PathItems.GetOrZero("/pets/{id}").Get
+
+	op, err := links.GetOrZero("byId").ResolveOperation(doc)
+	require.NoError(t, err)
+	assert.Same(t, getPet, op)
+
+	op, err = links.GetOrZero("byRef").ResolveOperation(doc)
+	require.NoError(t, err)
+	assert.Same(t, getPet, op)
+
+	op, err = links.GetOrZero("toys").ResolveOperation(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "listToys", op.OperationId)
+	assert.Equal(t, "every toy", op.Summary)
+	assert.Equal(t, "toys", op.Responses.Codes.GetOrZero("200").Description)
+	require.NotNil(t, op.GoLow())
+
+	_, err = links.GetOrZero("missing").ResolveOperation(doc)
+	assert.EqualError(t, err, "link operationId 'nope' does not match any operation")
+
+	_, err = links.GetOrZero("both").ResolveOperation(doc)
+	assert.EqualError(t, err, "link has both an operationId and an operationRef, only one is allowed")
+
+	_, err = links.GetOrZero("neither").ResolveOperation(doc)
+	assert.EqualError(t, err, "link has no operationId or operationRef")
+
+	_, err = links.GetOrZero("missingRef").ResolveOperation(doc)
+	assert.EqualError(t, err, "unable to resolve link operationRef '#/paths/~1nope/get': "+
+		"unable to resolve json pointer '/paths/~1nope': segment '/nope' not found")
+
+	_, err = links.GetOrZero("notAnOperation").ResolveOperation(doc)
+	assert.EqualError(t, err, "link operationRef '#/info' does not point to an operation")
+
+	_, err = doc.Components.Links.GetOrZero("missingToy").ResolveOperation(doc)
+	assert.EqualError(t, err, "unable to resolve link operationRef './toys.yaml#/paths/~1missing/get', it can't be located")
+
+	_, err = (&Link{OperationRef: "./toys.yaml#/paths/~1toys/get"}).ResolveOperation(&Document{})
+	assert.EqualError(t, err, "unable to resolve link operationRef './toys.yaml#/paths/~1toys/get', the document has no index")
+}
+
+func TestLink_ResolveOperation_IndexErrors(t *testing.T) {
+	doc := linksDocument(t)
+	var messages []string
+	for _, err := range doc.GoLow().Index.GetLinkIndexErrors() {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		"link `missing` at `$.paths['/pets'].get.responses['200'].links['missing']` has an operationId `nope` that does not match any operation",
+		"link `both` at `$.paths['/pets'].get.responses['200'].links['both']` has both an operationId and an operationRef, only one is allowed",
+		"link `neither` at `$.paths['/pets'].get.responses['200'].links['neither']` has no operationId or operationRef",
+		"link `missingRef` at `$.paths['/pets'].get.responses['200'].links['missingRef']` has an operationRef `#/paths/~1nope/get` that can't be located",
+		"link `missingToy` at `$.components.links['missingToy']` has an operationRef `./toys.yaml#/paths/~1missing/get` that can't be located",
+	}, messages)
+}
@@ -17,8 +17,12 @@ import (
 //
 // Each Media Type Object provides schema and examples for the media type identified by its key.
 //   - https://spec.openapis.org/oas/v3.1.0#media-type-object
+//
+// ItemSchema was added in OpenAPI 3.2, it describes each item of a sequential media type (such as each event of a
+// `text/event-stream`), where Schema describes the content as a whole.
 type MediaType struct {
 	Schema     *base.SchemaProxy                      `json:"schema,omitempty" yaml:"schema,omitempty"`
+	ItemSchema *base.SchemaProxy                      `json:"itemSchema,omitempty" yaml:"itemSchema,omitempty"`
 	Example    *yaml.Node                             `json:"example,omitempty" yaml:"example,omitempty"`
 	Examples   *orderedmap.Map[string, *base.Example] `json:"examples,omitempty" yaml:"examples,omitempty"`
 	Encoding   *orderedmap.Map[string, *Encoding]     `json:"encoding,omitempty" yaml:"encoding,omitempty"`
@@ -33,6 +37,9 @@ func NewMediaType(mediaType *low.MediaType) *MediaType {
 	if !mediaType.Schema.IsEmpty() {
 		m.Schema = base.NewSchemaProxy(&mediaType.Schema)
 	}
+	if !mediaType.ItemSchema.IsEmpty() {
+		m.ItemSchema = base.NewSchemaProxy(&mediaType.ItemSchema)
+	}
 	m.Example = mediaType.Example.Value
 	m.Examples = base.ExtractExamples(mediaType.Examples.Value)
 	m.Extensions = high.ExtractExtensions(mediaType.Extensions)
@@ -0,0 +1,173 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+const (
+	// FormURLEncodedMediaType is the media type of form-encoded bodies, the only kind of body Encoding styles
+	// apply to by default.
+	FormURLEncodedMediaType = "application/x-www-form-urlencoded"
+
+	// contentTypeHeader is described by the contentType of an Encoding, a header with this name is ignored.
+	contentTypeHeader = "Content-Type"
+)
+
+// PropertyEncoding is the effective encoding of a property of a multipart or form-encoded body. It's the Encoding
+// defined for the property, with every default the specification gives filled in, and everything the specification
+// says to ignore left out.
+type PropertyEncoding struct {
+	// Property is the name of the property.
+	Property string
+
+	// ContentType is the media type (or a comma separated list of media types and ranges) of the property. It's the
+	// contentType of the Encoding, or the default for the schema of the property: `application/json` for objects,
+	// `application/octet-stream` for binary content (or its contentMediaType), `text/plain` for other primitive
+	// types, and the default of the items for arrays.
+	ContentType string
+
+	// Headers are the headers of the part, they are only used by multipart bodies. A Content-Type header is
+	// ignored, it's described by ContentType.
+	Headers *orderedmap.Map[string, *Header]
+
+	// Style, Explode and AllowReserved describe how the property is serialized. They are used by form-encoded
+	// bodies, and multipart bodies only when the Encoding sets them explicitly. Style defaults to `form`, and Explode
+	// to true when the Style is `form`.
+	Style         string
+	Explode       bool
+	AllowReserved bool
+
+	// Encoding is the Encoding defined for the property, nil if there isn't one.
+	Encoding *Encoding
+
+	// Schema is the schema of the property, nil if the property is only known by its Encoding.
+	Schema *base.Schema
+}
+
+// PropertyEncodings returns the effective encoding of every property of the schema of content of mediaType (the key
+// of the MediaType), in the order of the properties (see PropertyEncoding). Returns nil if mediaType is not a
+// multipart or form-encoded body, or the schema has no properties.
+func (m *MediaType) PropertyEncodings(mediaType string) *orderedmap.Map[string, *PropertyEncoding] {
+	if m == nil || m.Schema == nil || !encodedMediaType(mediaType) {
+		return nil
+	}
+	schema := m.Schema.Schema()
+	if schema == nil || orderedmap.Len(schema.Properties) == 0 {
+		return nil
+	}
+	encodings := orderedmap.New[string, *PropertyEncoding]()
+	for name, sp := range schema.Properties.FromOldest() {
+		var prop *base.Schema
+		if sp != nil {
+			prop = sp.Schema()
+		}
+		encodings.Set(name, newPropertyEncoding(mediaType, name, prop, m.Encoding.GetOrZero(name)))
+	}
+	return encodings
+}
+
+// PropertyEncoding returns the effective encoding of a property of content of mediaType (the key of the MediaType),
+// see PropertyEncoding. Returns nil if mediaType is not a multipart or form-encoded body, or the property is
+// neither in the schema or the Encoding.
+func (m *MediaType) PropertyEncoding(mediaType, property string) *PropertyEncoding {
+	if m == nil || !encodedMediaType(mediaType) {
+		return nil
+	}
+	var prop *base.Schema
+	found := false
+	if m.Schema != nil {
+		if schema := m.Schema.Schema(); schema != nil {
+			if sp, ok := schema.Properties.Get(property); ok {
+				found = true
+				if sp != nil {
+					prop = sp.Schema()
+				}
+			}
+		}
+	}
+	enc := m.Encoding.GetOrZero(property)
+	if !found && enc == nil {
+		return nil
+	}
+	return newPropertyEncoding(mediaType, property, prop, enc)
+}
+
+func newPropertyEncoding(mediaType, property string, schema *base.Schema, enc *Encoding) *PropertyEncoding {
+	pe := &PropertyEncoding{
+		Property:    property,
+		ContentType: defaultEncodingContentType(schema),
+		Encoding:    enc,
+		Schema:      schema,
+	}
+	form := isFormURLEncoded(mediaType)
+	if enc != nil {
+		if enc.ContentType != "" {
+			pe.ContentType = enc.ContentType
+		}
+		if !form {
+			pe.Headers = orderedmap.New[string, *Header]()
+			for name, h := range enc.Headers.FromOldest() {
+				if !strings.EqualFold(name, contentTypeHeader) {
+					pe.Headers.Set(name, h)
+				}
+			}
+		}
+	}
+	if !form && (enc == nil || (enc.Style == "" && enc.Explode == nil && !enc.AllowReserved)) {
+		return pe
+	}
+	pe.Style = "form"
+	if enc != nil {
+		if enc.Style != "" {
+			pe.Style = enc.Style
+		}
+		pe.AllowReserved = enc.AllowReserved
+	}
+	pe.Explode = pe.Style == "form"
+	if enc != nil && enc.Explode != nil {
+		pe.Explode = *enc.Explode
+	}
+	return pe
+}
+
+// defaultEncodingContentType returns the content type of a property that has no contentType in its Encoding.
+func defaultEncodingContentType(schema *base.Schema) string {
+	switch {
+	case schema == nil:
+		return "application/octet-stream"
+	case IsBinarySchema(schema):
+		if schema.ContentMediaType != "" {
+			return schema.ContentMediaType
+		}
+		return "application/octet-stream"
+	case slices.Contains(schema.Type, "object"):
+		return "application/json"
+	case slices.Contains(schema.Type, "array"):
+		if schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+			return defaultEncodingContentType(schema.Items.A.Schema())
+		}
+		return "application/octet-stream"
+	case len(schema.Type) == 0 && orderedmap.Len(schema.Properties) > 0:
+		return "application/json"
+	}
+	return "text/plain"
+}
+
+// encodedMediaType returns true if the properties of content of mediaType can be encoded with an Encoding.
+func encodedMediaType(mediaType string) bool {
+	mt, _, _ := mime.ParseMediaType(mediaType)
+	return isFormURLEncoded(mediaType) || (strings.HasPrefix(mt, "multipart/") && mt != "multipart/byteranges")
+}
+
+func isFormURLEncoded(mediaType string) bool {
+	mt, _, _ := mime.ParseMediaType(mediaType)
+	return mt == FormURLEncodedMediaType
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var encodingSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                address:
+                  type: object
+                photo:
+                  type: string
+                  contentMediaType: image/png
+                tags:
+                  type: array
+                  items:
+                    type: integer
+                styled:
+                  type: array
+            encoding:
+              address:
+                contentType: application/xml
+                headers:
+                  Content-Type:
+                    schema:
+                      type: string
+                  X-Rate-Limit:
+                    schema:
+                      type: integer
+              styled:
+                style: spaceDelimited
+              missing:
+                contentType: text/csv
+          application/x-www-form-urlencoded:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                tags:
+                  type: array
+                  items:
+                    type: string
+            encoding:
+              tags:
+                style: pipeDelimited
+                allowReserved: true
+                headers:
+                  X-Ignored:
+                    schema:
+                      type: string
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string`
+
+func buildEncodingRequestBody(t *testing.T) *RequestBody {
+	doc := buildTestDocument(t, encodingSpec)
+	return doc.Paths.PathItems.GetOrZero("/pets").Post.RequestBody
+}
+
+func TestMediaType_PropertyEncodings_Multipart(t *testing.T) {
+	mt := buildEncodingRequestBody(t).Content.GetOrZero("multipart/form-data")
+	encodings := mt.PropertyEncodings("multipart/form-data")
+	require.Equal(t, 5, encodings.Len())
+
+	contentTypes := make(map[string]string)
+	for name, pe := range encodings.FromOldest() {
+		contentTypes[name] = pe.ContentType
+	}
+	assert.Equal(t, map[string]string{
+		"name":    "text/plain",
+		"address": "application/xml",
+		"photo":   "image/png",
+		"tags":    "text/plain",
+		"styled":  "application/octet-stream",
+	}, contentTypes)
+
+	// the content type header is described by the content type.
+	address := encodings.GetOrZero("address")
+	require.NotNil(t, address.Encoding)
+	assert.Equal(t, 1, address.Headers.Len())
+	assert.NotNil(t, address.Headers.GetOrZero("X-Rate-Limit"))
+	assert.Empty(t, address.Style)
+
+	// styles are only used by multipart bodies when they are set.
+	styled := encodings.GetOrZero("styled")
+	assert.Equal(t, "spaceDelimited", styled.Style)
+	assert.False(t, styled.Explode)
+	assert.Nil(t, encodings.GetOrZero("name").Encoding)
+	assert.Empty(t, encodings.GetOrZero("name").Style)
+
+	// a property only known by its encoding.
+	missing := mt.PropertyEncoding("multipart/form-data", "missing")
+	require.NotNil(t, missing)
+	assert.Equal(t, "text/csv", missing.ContentType)
+	assert.Nil(t, missing.Schema)
+	assert.Nil(t, mt.PropertyEncoding("multipart/form-data", "nope"))
+	assert.Equal(t, "application/xml", mt.PropertyEncoding("multipart/mixed", "address").ContentType)
+}
+
+func TestMediaType_PropertyEncodings_FormURLEncoded(t *testing.T) {
+	rb := buildEncodingRequestBody(t)
+	mt := rb.Content.GetOrZero(FormURLEncodedMediaType)
+
+	name := mt.PropertyEncoding(FormURLEncodedMediaType, "name")
+	assert.Equal(t, "form", name.Style)
+	assert.True(t, name.Explode)
+	assert.False(t, name.AllowReserved)
+	assert.Nil(t, name.Headers)
+
+	tags := mt.PropertyEncoding(FormURLEncodedMediaType+"; charset=utf-8", "tags")
+	assert.Equal(t, "pipeDelimited", tags.Style)
+	assert.False(t, tags.Explode)
+	assert.True(t, tags.AllowReserved)
+	assert.Nil(t, tags.Headers)
+
+	// other bodies are not encoded.
+	json := rb.Content.GetOrZero("application/json")
+	assert.Nil(t, json.PropertyEncodings("application/json"))
+	assert.Nil(t, json.PropertyEncoding("application/json", "name"))
+	var nilMediaType *MediaType
+	assert.Nil(t, nilMediaType.PropertyEncodings(FormURLEncodedMediaType))
+}
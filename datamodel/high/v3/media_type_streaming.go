@@ -0,0 +1,299 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+// Streaming (sequential) media types, where content is a sequence of items rather than a single value.
+const (
+	EventStreamMediaType = "text/event-stream"    // server-sent events
+	NDJSONMediaType      = "application/x-ndjson" // newline delimited JSON
+	JSONLinesMediaType   = "application/jsonl"    // JSON lines
+	JSONSeqMediaType     = "application/json-seq" // JSON text sequences (RFC 7464)
+)
+
+// the fields of a server-sent event, in the order they are rendered.
+var eventStreamFields = []string{"event", "id", "retry", "data"}
+
+// StreamEvent is a single event (or item) of streamed content.
+type StreamEvent struct {
+	// Value is the event. For a `text/event-stream`, it's a mapping of the fields of the event (`event`, `data`,
+	// `id` and `retry`, multiple data lines are joined with a newline), the way OpenAPI 3.2 describes events.
+	// For the JSON streaming media types, it's the item itself.
+	Value *yaml.Node
+
+	// Line is the line of the stream the event starts on. The nodes of Value are positioned in the stream.
+	Line int
+}
+
+// EventViolation describes a part of an event that does not match the event schema of a MediaType.
+type EventViolation struct {
+	*base.ValueViolation
+
+	// Event is the index of the event in the stream.
+	Event int
+}
+
+// IsStreamingMediaType returns true if mediaType is one of the streaming media types, parameters are ignored.
+func IsStreamingMediaType(mediaType string) bool {
+	return streamingMediaType(mediaType) != ""
+}
+
+func streamingMediaType(mediaType string) string {
+	mt, _, err := mime.ParseMediaType(strings.TrimSpace(mediaType))
+	if err != nil {
+		return ""
+	}
+	switch mt {
+	case EventStreamMediaType, NDJSONMediaType, JSONLinesMediaType, JSONSeqMediaType:
+		return mt
+	}
+	return ""
+}
+
+// GetEventSchema returns the schema of each event (or item) of streamed content. That's the ItemSchema, or when it's
+// not set, the items of an array Schema (the way streams are often described before OpenAPI 3.2). Returns nil if
+// there is neither.
+func (m *MediaType) GetEventSchema() *base.SchemaProxy {
+	if m.ItemSchema != nil {
+		return m.ItemSchema
+	}
+	if m.Schema == nil {
+		return nil
+	}
+	if s := m.Schema.Schema(); s != nil && s.Items != nil && s.Items.IsA() && slices.Contains(s.Type, "array") {
+		return s.Items.A
+	}
+	return nil
+}
+
+// ValidateEvent validates a single event against the event schema (see GetEventSchema), and returns every
+// violation found. Nothing is returned if there is no event schema.
+func (m *MediaType) ValidateEvent(event *yaml.Node) []*base.ValueViolation {
+	sp := m.GetEventSchema()
+	if sp == nil {
+		return nil
+	}
+	return sp.Schema().ValidateValue(event)
+}
+
+// ValidateEventStream parses stream as mediaType (see ParseEventStream), then validates every event against the
+// event schema. An error is returned if the stream can't be parsed.
+func (m *MediaType) ValidateEventStream(mediaType string, stream []byte) ([]*EventViolation, error) {
+	events, err := ParseEventStream(mediaType, stream)
+	if err != nil {
+		return nil, err
+	}
+	var violations []*EventViolation
+	for i, event := range events {
+		for _, v := range m.ValidateEvent(event.Value) {
+			violations = append(violations, &EventViolation{ValueViolation: v, Event: i})
+		}
+	}
+	return violations, nil
+}
+
+// ParseEventStream splits stream into its events, using the framing of mediaType, which must be a streaming
+// media type (see IsStreamingMediaType).
+//
+// Server-sent events are parsed following the HTML event stream rules: comments are ignored, a blank line ends an
+// event, and a `retry` holding a number is an integer. Events without any fields are dropped. Each item of the JSON
+// streaming media types must be valid JSON, or an error is returned.
+func ParseEventStream(mediaType string, stream []byte) ([]*StreamEvent, error) {
+	switch streamingMediaType(mediaType) {
+	case EventStreamMediaType:
+		return parseServerSentEvents(stream), nil
+	case NDJSONMediaType, JSONLinesMediaType:
+		var events []*StreamEvent
+		for i, line := range strings.Split(string(stream), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			event, err := parseStreamItem(line, i+1)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, event)
+		}
+		return events, nil
+	case JSONSeqMediaType:
+		var events []*StreamEvent
+		line := 1
+		for _, record := range strings.Split(string(stream), "\x1e") {
+			if strings.TrimSpace(record) != "" {
+				event, err := parseStreamItem(record, line)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
+			line += strings.Count(record, "\n")
+		}
+		return events, nil
+	}
+	return nil, fmt.Errorf("unable to parse stream, '%s' is not a streaming media type", mediaType)
+}
+
+func parseStreamItem(item string, line int) (*StreamEvent, error) {
+	var n yaml.Node
+	if !json.Valid([]byte(item)) {
+		return nil, fmt.Errorf("unable to parse stream, the item on line %d is not valid JSON", line)
+	}
+	if err := yaml.Unmarshal([]byte(item), &n); err != nil || len(n.Content) == 0 {
+		return nil, fmt.Errorf("unable to parse stream, the item on line %d cannot be read", line)
+	}
+	value := n.Content[0]
+	offsetLines(value, line-1)
+	return &StreamEvent{Value: value, Line: line}, nil
+}
+
+func offsetLines(n *yaml.Node, offset int) {
+	n.Line += offset
+	for _, c := range n.Content {
+		offsetLines(c, offset)
+	}
+}
+
+func parseServerSentEvents(stream []byte) []*StreamEvent {
+	text := strings.ReplaceAll(strings.ReplaceAll(string(stream), "\r\n", "\n"), "\r", "\n")
+	var events []*StreamEvent
+	var event *StreamEvent
+	var data *yaml.Node
+	dispatch := func() {
+		if event != nil && len(event.Value.Content) > 0 {
+			events = append(events, event)
+		}
+		event, data = nil, nil
+	}
+	for i, line := range strings.Split(text, "\n") {
+		if line == "" {
+			dispatch()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		column := len(field) + 2
+		if strings.HasPrefix(value, " ") {
+			value = value[1:]
+			column++
+		}
+		if !slices.Contains(eventStreamFields, field) {
+			continue
+		}
+		if event == nil {
+			event = &StreamEvent{Value: &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: i + 1, Column: 1}, Line: i + 1}
+		}
+		if field == "data" && data != nil {
+			data.Value += "\n" + value
+			continue
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: field, Line: i + 1, Column: 1}
+		val := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Line: i + 1, Column: column}
+		if field == "retry" {
+			if _, err := strconv.Atoi(value); err == nil {
+				val.Tag = "!!int"
+			}
+		}
+		// a repeated field (other than data) replaces the earlier one.
+		replaced := false
+		for j := 0; j+1 < len(event.Value.Content); j += 2 {
+			if event.Value.Content[j].Value == field {
+				event.Value.Content[j+1] = val
+				replaced = true
+			}
+		}
+		if !replaced {
+			event.Value.Content = append(event.Value.Content, key, val)
+		}
+		if field == "data" {
+			data = val
+		}
+	}
+	dispatch()
+	return events
+}
+
+// FormatEventStream renders events as streamed content of mediaType, which must be a streaming media type (see
+// IsStreamingMediaType). Events can be *yaml.Node values or anything that can be rendered as JSON.
+//
+// For a `text/event-stream`, an event that is a map is rendered as the fields of a server-sent event (`event`,
+// `id`, `retry` and `data`, other keys are ignored), data that isn't a string is rendered as JSON. Any other event is
+// rendered as the data of the event.
+func FormatEventStream(mediaType string, events []any) ([]byte, error) {
+	mt := streamingMediaType(mediaType)
+	if mt == "" {
+		return nil, fmt.Errorf("unable to format stream, '%s' is not a streaming media type", mediaType)
+	}
+	var buf bytes.Buffer
+	for i, event := range events {
+		if n, ok := event.(*yaml.Node); ok {
+			event = nil
+			if err := n.Decode(&event); err != nil {
+				return nil, fmt.Errorf("unable to format stream, event %d cannot be decoded: %w", i, err)
+			}
+		}
+		if mt == EventStreamMediaType {
+			if err := writeServerSentEvent(&buf, event); err != nil {
+				return nil, fmt.Errorf("unable to format stream, event %d: %w", i, err)
+			}
+			continue
+		}
+		item, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("unable to format stream, event %d: %w", i, err)
+		}
+		if mt == JSONSeqMediaType {
+			buf.WriteByte('\x1e')
+		}
+		buf.Write(item)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func writeServerSentEvent(buf *bytes.Buffer, event any) error {
+	fields, ok := event.(map[string]any)
+	if !ok {
+		fields = map[string]any{"data": event}
+	}
+	for _, field := range eventStreamFields {
+		value, found := fields[field]
+		if !found || value == nil {
+			continue
+		}
+		text, isString := value.(string)
+		if !isString {
+			if field != "data" {
+				text = fmt.Sprint(value)
+			} else {
+				rendered, err := json.Marshal(value)
+				if err != nil {
+					return err
+				}
+				text = string(rendered)
+			}
+		}
+		for _, line := range strings.Split(text, "\n") {
+			buf.WriteString(field)
+			buf.WriteString(": ")
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+	buf.WriteByte('\n')
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var streamingSpec = `openapi: 3.2.0
+paths:
+  /events:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            text/event-stream:
+              itemSchema:
+                type: object
+                required: [data]
+                properties:
+                  event:
+                    type: string
+                    enum: [tick, done]
+                  data:
+                    type: string
+                  id:
+                    type: string
+                  retry:
+                    type: integer
+            application/x-ndjson:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Tick'
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Tick'
+components:
+  schemas:
+    Tick:
+      type: object
+      required: [count]
+      properties:
+        count:
+          type: integer`
+
+func buildStreamingContent(t *testing.T) map[string]*MediaType {
+	doc := buildTestDocument(t, streamingSpec)
+	content := doc.Paths.PathItems.GetOrZero("/events").Get.Responses.Codes.GetOrZero("200").Content
+	found := make(map[string]*MediaType)
+	for k, mt := range content.FromOldest() {
+		found[k] = mt
+	}
+	return found
+}
+
+func TestIsStreamingMediaType(t *testing.T) {
+	assert.True(t, IsStreamingMediaType("text/event-stream"))
+	assert.True(t, IsStreamingMediaType("Text/Event-Stream; charset=utf-8"))
+	assert.True(t, IsStreamingMediaType("application/x-ndjson"))
+	assert.True(t, IsStreamingMediaType("application/jsonl"))
+	assert.True(t, IsStreamingMediaType("application/json-seq"))
+	assert.False(t, IsStreamingMediaType("application/json"))
+	assert.False(t, IsStreamingMediaType("not a media type"))
+}
+
+func TestMediaType_GetEventSchema(t *testing.T) {
+	content := buildStreamingContent(t)
+
+	sse := content["text/event-stream"].GetEventSchema()
+	require.NotNil(t, sse)
+	assert.Equal(t, []string{"data"}, sse.Schema().Required)
+
+	ndjson := content["application/x-ndjson"].GetEventSchema()
+	require.NotNil(t, ndjson)
+	assert.Equal(t, "#/components/schemas/Tick", ndjson.GetReference())
+
+	assert.Nil(t, content["application/json"].GetEventSchema())
+}
+
+func TestParseEventStream_ServerSentEvents(t *testing.T) {
+	stream := ": keep alive\r\n\r\nevent: tick\ndata: one\ndata:two\nid: 1\nignored: field\n\nretry: 500\ndata: {}\n\n\n"
+	events, err := ParseEventStream("text/event-stream", []byte(stream))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	var first map[string]any
+	require.NoError(t, events[0].Value.Decode(&first))
+	assert.Equal(t, map[string]any{"event": "tick", "data": "one\ntwo", "id": "1"}, first)
+	assert.Equal(t, 3, events[0].Line)
+
+	var second map[string]any
+	require.NoError(t, events[1].Value.Decode(&second))
+	assert.Equal(t, map[string]any{"retry": 500, "data": "{}"}, second)
+	assert.Equal(t, 9, events[1].Line)
+	assert.Equal(t, 8, events[1].Value.Content[1].Column)
+}
+
+func TestParseEventStream_JSON(t *testing.T) {
+	events, err := ParseEventStream("application/x-ndjson", []byte("{\"count\": 1}\n\n{\"count\": 2}\n"))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, 3, events[1].Line)
+	assert.Equal(t, 3, events[1].Value.Content[1].Line)
+
+	events, err = ParseEventStream("application/json-seq", []byte("\x1e{\"count\": 1}\n\x1e[1,\n2]\n\x1e3\n"))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, yaml.SequenceNode, events[1].Value.Kind)
+	assert.Equal(t, 4, events[2].Line)
+
+	_, err = ParseEventStream("application/jsonl", []byte("{\"count\": 1}\n{nope}"))
+	assert.EqualError(t, err, "unable to parse stream, the item on line 2 is not valid JSON")
+
+	_, err = ParseEventStream("application/json", nil)
+	assert.EqualError(t, err, "unable to parse stream, 'application/json' is not a streaming media type")
+}
+
+func TestFormatEventStream(t *testing.T) {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("event: tick\ndata: \"one\\ntwo\"\nid: 7\nother: x"), &node))
+
+	stream, err := FormatEventStream("text/event-stream", []any{
+		node.Content[0],
+		map[string]any{"data": map[string]any{"count": 1}, "retry": 10},
+		"plain",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "event: tick\nid: 7\ndata: one\ndata: two\n\n"+
+		"retry: 10\ndata: {\"count\":1}\n\n"+
+		"data: plain\n\n", string(stream))
+
+	stream, err = FormatEventStream("application/x-ndjson", []any{map[string]any{"count": 1}, 2})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"count\":1}\n2\n", string(stream))
+
+	stream, err = FormatEventStream("application/json-seq", []any{true})
+	require.NoError(t, err)
+	assert.Equal(t, "\x1etrue\n", string(stream))
+
+	_, err = FormatEventStream("application/json", nil)
+	assert.EqualError(t, err, "unable to format stream, 'application/json' is not a streaming media type")
+}
+
+func TestMediaType_ValidateEventStream(t *testing.T) {
+	content := buildStreamingContent(t)
+
+	violations, err := content["text/event-stream"].ValidateEventStream("text/event-stream",
+		[]byte("event: tick\ndata: 1\n\nevent: nope\ndata: 2\n\nid: 3\nretry: soon\n\n"))
+	require.NoError(t, err)
+	require.Len(t, violations, 3)
+	assert.Equal(t, 1, violations[0].Event)
+	assert.Equal(t, "$.event", violations[0].Path)
+	assert.Equal(t, "value is not one of the enum values", violations[0].Message)
+	assert.Equal(t, 4, violations[0].Node.Line)
+	assert.Equal(t, 2, violations[1].Event)
+	assert.Equal(t, "object is missing the required property 'data'", violations[1].Message)
+	assert.Equal(t, "$.retry", violations[2].Path)
+
+	violations, err = content["application/x-ndjson"].ValidateEventStream("application/x-ndjson",
+		[]byte("{\"count\": 1}\n{\"count\": \"two\"}\n"))
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, 1, violations[0].Event)
+	assert.Equal(t, "$.count", violations[0].Path)
+	assert.Equal(t, 2, violations[0].Node.Line)
+
+	assert.Empty(t, content["application/json"].ValidateEvent(&yaml.Node{Kind: yaml.ScalarNode, Value: "1"}))
+
+	_, err = content["application/x-ndjson"].ValidateEventStream("text/plain", nil)
+	assert.Error(t, err)
+}
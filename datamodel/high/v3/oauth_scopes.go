@@ -0,0 +1,90 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"errors"
+	"fmt"
+
+	low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// FlowsByName returns every flow that is defined, keyed by its name in the specification (`implicit`, `password`,
+// `clientCredentials` and `authorizationCode`), in that order.
+func (o *OAuthFlows) FlowsByName() *orderedmap.Map[string, *OAuthFlow] {
+	flows := orderedmap.New[string, *OAuthFlow]()
+	if o == nil {
+		return flows
+	}
+	for _, f := range []struct {
+		name string
+		flow *OAuthFlow
+	}{
+		{low.ImplicitLabel, o.Implicit},
+		{low.PasswordLabel, o.Password},
+		{low.ClientCredentialsLabel, o.ClientCredentials},
+		{low.AuthorizationCodeLabel, o.AuthorizationCode},
+	} {
+		if f.flow != nil {
+			flows.Set(f.name, f.flow)
+		}
+	}
+	return flows
+}
+
+// AllScopes returns every scope of every flow, with its description. A scope that is defined by more than one flow
+// is only returned once, with the description of the first flow that defines it.
+func (o *OAuthFlows) AllScopes() *orderedmap.Map[string, string] {
+	scopes := orderedmap.New[string, string]()
+	for _, flow := range o.FlowsByName().FromOldest() {
+		for scope, description := range flow.Scopes.FromOldest() {
+			if _, ok := scopes.Get(scope); !ok {
+				scopes.Set(scope, description)
+			}
+		}
+	}
+	return scopes
+}
+
+// Validate checks that every flow has the URLs and the scopes the specification requires for it. Returns an error
+// for each one that is missing, or nil if the flows are valid.
+func (o *OAuthFlows) Validate() []error {
+	var errs []error
+	for name, flow := range o.FlowsByName().FromOldest() {
+		authorization := name == low.ImplicitLabel || name == low.AuthorizationCodeLabel
+		token := name != low.ImplicitLabel
+		if authorization && flow.AuthorizationUrl == "" {
+			errs = append(errs, fmt.Errorf("the '%s' flow requires an authorizationUrl", name))
+		}
+		if token && flow.TokenUrl == "" {
+			errs = append(errs, fmt.Errorf("the '%s' flow requires a tokenUrl", name))
+		}
+		if flow.low != nil && flow.low.Scopes.IsEmpty() {
+			errs = append(errs, fmt.Errorf("the '%s' flow requires scopes, even if there are none", name))
+		}
+	}
+	return errs
+}
+
+// AllScopes returns every scope of every OAuth2 flow of the SecurityScheme, with its description. Returns an empty
+// map if the SecurityScheme has no flows.
+func (s *SecurityScheme) AllScopes() *orderedmap.Map[string, string] {
+	if s == nil {
+		return orderedmap.New[string, string]()
+	}
+	return s.Flows.AllScopes()
+}
+
+// ValidateFlows checks the flows of an `oauth2` SecurityScheme (see OAuthFlows.Validate), and that there is at least
+// one. Returns nil for any other type of SecurityScheme.
+func (s *SecurityScheme) ValidateFlows() []error {
+	if s == nil || s.Type != "oauth2" {
+		return nil
+	}
+	if s.Flows.FlowsByName().Len() == 0 {
+		return []error{errors.New("an oauth2 security scheme requires at least one flow")}
+	}
+	return s.Flows.Validate()
+}
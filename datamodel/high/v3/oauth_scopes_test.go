@@ -0,0 +1,83 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func buildSecurityScheme(t *testing.T, yml string) *SecurityScheme {
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	var n v3.SecurityScheme
+	_ = low.BuildModel(node.Content[0], &n)
+	assert.NoError(t, n.Build(context.Background(), nil, node.Content[0], nil))
+	return NewSecurityScheme(&n)
+}
+
+func TestSecurityScheme_AllScopes(t *testing.T) {
+	ss := buildSecurityScheme(t, `type: oauth2
+flows:
+  implicit:
+    authorizationUrl: https://pb33f.io/oauth
+    scopes:
+      read:burgers: read all burgers
+      write:burgers: modify burgers
+  clientCredentials:
+    tokenUrl: https://pb33f.io/token
+    scopes:
+      read:burgers: read burgers with creds
+      admin:burgers: eat all the burgers`)
+
+	scopes := ss.AllScopes()
+	assert.Equal(t, 3, scopes.Len())
+	var names []string
+	for name := range scopes.KeysFromOldest() {
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{"read:burgers", "write:burgers", "admin:burgers"}, names)
+	assert.Equal(t, "read all burgers", scopes.GetOrZero("read:burgers"))
+
+	flows := ss.Flows.FlowsByName()
+	assert.Equal(t, 2, flows.Len())
+	assert.Equal(t, ss.Flows.ClientCredentials, flows.GetOrZero(v3.ClientCredentialsLabel))
+	assert.Empty(t, ss.ValidateFlows())
+
+	var nilScheme *SecurityScheme
+	assert.Equal(t, 0, nilScheme.AllScopes().Len())
+	assert.Equal(t, 0, buildSecurityScheme(t, `type: apiKey`).AllScopes().Len())
+}
+
+func TestSecurityScheme_ValidateFlows(t *testing.T) {
+	ss := buildSecurityScheme(t, `type: oauth2
+flows:
+  implicit:
+    scopes: {}
+  password:
+    tokenUrl: https://pb33f.io/token
+  authorizationCode:
+    authorizationUrl: https://pb33f.io/oauth
+    scopes: {}`)
+
+	var messages []string
+	for _, err := range ss.ValidateFlows() {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		"the 'implicit' flow requires an authorizationUrl",
+		"the 'password' flow requires scopes, even if there are none",
+		"the 'authorizationCode' flow requires a tokenUrl",
+	}, messages)
+
+	assert.EqualError(t, buildSecurityScheme(t, `type: oauth2`).ValidateFlows()[0],
+		"an oauth2 security scheme requires at least one flow")
+	assert.Nil(t, buildSecurityScheme(t, `type: http`).ValidateFlows())
+}
@@ -4,6 +4,8 @@
 package v3
 
 import (
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -30,8 +32,10 @@ type Operation struct {
 	Deprecated   *bool                               `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	Security     []*base.SecurityRequirement         `json:"security,omitempty" yaml:"security,omitempty"`
 	Servers      []*Server                           `json:"servers,omitempty" yaml:"servers,omitempty"`
+	CodeSamples  []*base.CodeSample                  `json:"-" yaml:"-"` // rendered by the x-codeSamples extension.
 	Extensions   *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
 	low          *lowv3.Operation
+	effective    effectiveCache
 }
 
 // NewOperation will create a new Operation instance from a low-level one.
@@ -84,12 +88,33 @@ func NewOperation(operation *lowv3.Operation) *Operation {
 	}
 	o.Servers = servers
 	o.Extensions = high.ExtractExtensions(operation.Extensions)
+	o.CodeSamples = base.NewCodeSamples(operation.CodeSamples)
 	if !operation.Callbacks.IsEmpty() {
 		o.Callbacks = low.FromReferenceMapWithFunc(operation.Callbacks.Value, NewCallback)
 	}
 	return o
 }
 
+// EffectiveParameters returns the parameters that apply to the operation, once the parameters of the PathItem
+// the operation belongs to have been merged in. An operation-level parameter overrides a path-level parameter
+// with the same name and location (header names are case-insensitive). Referenced parameters have already been
+// resolved when the model was built. pathItem may be nil, in which case only the operation parameters are used.
+func (o *Operation) EffectiveParameters(pathItem *PathItem) []*Parameter {
+	var pathParams []*Parameter
+	if pathItem != nil {
+		pathParams = pathItem.Parameters
+	}
+	return high.MergeParameters(pathParams, o.Parameters, parameterKey)
+}
+
+// parameterKey identifies a parameter by its location and name.
+func parameterKey(p *Parameter) string {
+	if strings.EqualFold(p.In, "header") {
+		return "header:" + strings.ToLower(p.Name)
+	}
+	return p.In + ":" + p.Name
+}
+
 // GoLow will return the low-level Operation instance that was used to create the high-level one.
 func (o *Operation) GoLow() *lowv3.Operation {
 	return o.low
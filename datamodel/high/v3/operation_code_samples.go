@@ -0,0 +1,65 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// CodeSampleGenerator creates code samples for an operation, for example a request in a number of languages created
+// by a request builder. Returning nil leaves the operation untouched.
+type CodeSampleGenerator func(op *SelectedOperation) []*base.CodeSample
+
+// GetCodeSample returns the code sample of the operation for a language (matched case-insensitively), or nil if the
+// operation has no sample for the language.
+func (o *Operation) GetCodeSample(lang string) *base.CodeSample {
+	return base.FindCodeSample(o.CodeSamples, lang)
+}
+
+// SetCodeSample adds a code sample to the operation, replacing any sample for the same language. The code sample
+// extension is updated, so the sample is rendered with the operation.
+func (o *Operation) SetCodeSample(sample *base.CodeSample) {
+	if sample == nil {
+		return
+	}
+	o.CodeSamples = base.SetCodeSample(o.CodeSamples, sample)
+	o.Extensions = base.RenderCodeSamples(o.Extensions, o.CodeSamples)
+}
+
+// RemoveCodeSample removes the code sample of the operation for a language, returns true if there was one. The code
+// sample extension is removed along with the last sample.
+func (o *Operation) RemoveCodeSample(lang string) bool {
+	var removed bool
+	o.CodeSamples, removed = base.RemoveCodeSample(o.CodeSamples, lang)
+	if removed {
+		o.Extensions = base.RenderCodeSamples(o.Extensions, o.CodeSamples)
+	}
+	return removed
+}
+
+// InjectCodeSamples runs the generator for every operation of the Document in document order, and sets each code
+// sample generated on the operation (replacing an existing sample for the same language). Returns the number of code
+// samples set.
+func (d *Document) InjectCodeSamples(generate CodeSampleGenerator) int {
+	if d.Paths == nil || generate == nil {
+		return 0
+	}
+	count := 0
+	for path, pathItem := range d.Paths.PathItems.FromOldest() {
+		if pathItem == nil {
+			continue
+		}
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			for _, sample := range generate(&SelectedOperation{
+				Path: path, Method: method, PathItem: pathItem, Operation: op,
+			}) {
+				if sample != nil {
+					op.SetCodeSample(sample)
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
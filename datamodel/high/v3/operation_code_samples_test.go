@@ -0,0 +1,105 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation_CodeSamples(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-codeSamples:
+        - lang: Shell
+          label: cURL
+          source: curl https://api.example.com/pets
+      x-internal: false
+      responses:
+        "200":
+          description: ok
+    post:
+      operationId: addPet
+      x-code-samples:
+        - lang: Go
+          source: client.AddPet(pet)
+      responses:
+        "201":
+          description: added`
+
+	doc := buildTestDocument(t, yml)
+
+	get := doc.Paths.PathItems.GetOrZero("/pets").Get
+	require.Len(t, get.CodeSamples, 1)
+	assert.Equal(t, "cURL", get.GetCodeSample("shell").Label)
+	assert.Nil(t, get.GetCodeSample("Go"))
+
+	// replace a sample, and add a new one.
+	get.SetCodeSample(&base.CodeSample{Lang: "Shell", Label: "cURL", Source: "curl -s https://api.example.com/pets"})
+	get.SetCodeSample(&base.CodeSample{Lang: "Go", Source: "client.ListPets()"})
+	get.SetCodeSample(nil)
+	assert.Len(t, get.CodeSamples, 2)
+
+	post := doc.Paths.PathItems.GetOrZero("/pets").Post
+	assert.True(t, post.RemoveCodeSample("go"))
+	assert.False(t, post.RemoveCodeSample("go"))
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	r := string(rendered)
+	assert.Contains(t, r, `            x-codeSamples:
+                - lang: Shell
+                  label: cURL
+                  source: curl -s https://api.example.com/pets
+                - lang: Go
+                  source: client.ListPets()
+            x-internal: false`)
+	assert.NotContains(t, r, "x-code-samples")
+
+	// the rendered document reads back the same samples.
+	get = buildTestDocument(t, string(rendered)).Paths.PathItems.GetOrZero("/pets").Get
+	assert.Equal(t, "client.ListPets()", get.GetCodeSample("Go").Source)
+}
+
+func TestDocument_InjectCodeSamples(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+  /pets/{id}:
+    delete:
+      responses:
+        "204":
+          description: gone`
+
+	doc := buildTestDocument(t, yml)
+
+	count := doc.InjectCodeSamples(func(op *SelectedOperation) []*base.CodeSample {
+		if op.Method == "delete" {
+			return nil
+		}
+		return []*base.CodeSample{
+			{Lang: "Shell", Source: "curl -X " + strings.ToUpper(op.Method) + " " + op.Path},
+			{Lang: "Go", Source: "http.NewRequest(\"" + strings.ToUpper(op.Method) + "\", \"" + op.Path + "\", nil)"},
+		}
+	})
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "curl -X GET /pets", doc.Paths.PathItems.GetOrZero("/pets").Get.GetCodeSample("shell").Source)
+	assert.Empty(t, doc.Paths.PathItems.GetOrZero("/pets/{id}").Delete.CodeSamples)
+
+	rendered, _ := doc.Render()
+	assert.Contains(t, string(rendered), "source: curl -X GET /pets")
+	assert.Equal(t, 0, doc.InjectCodeSamples(nil))
+	assert.Equal(t, 0, (&Document{}).InjectCodeSamples(func(*SelectedOperation) []*base.CodeSample { return nil }))
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// EffectiveOperation is a denormalized snapshot of an Operation, with everything it inherits from the PathItem and
+// Document it belongs to merged in and resolved. It's the view most runtime consumers (routers, validators,
+// gateways, mock servers) want of an operation.
+type EffectiveOperation struct {
+	// Operation and PathItem the snapshot was created from.
+	Operation *Operation
+	PathItem  *PathItem
+
+	// Parameters are the path-level and operation-level parameters merged, see Operation.EffectiveParameters.
+	Parameters []*Parameter
+
+	// Security contains the requirements that apply to the operation, the operation overrides the document.
+	// An empty (non-nil) slice means security has been explicitly removed for the operation.
+	Security []*base.SecurityRequirement
+
+	// SecuritySchemes contains every scheme named by Security, resolved from the document components.
+	SecuritySchemes *orderedmap.Map[string, *SecurityScheme]
+
+	// UnresolvedSecurity contains the names used by Security that don't exist in the document components.
+	UnresolvedSecurity []string
+
+	// Servers that apply to the operation, the operation overrides the path item which overrides the document.
+	// If no servers are defined anywhere, a single server with a URL of '/' is used (as defined by the specification).
+	Servers []*Server
+
+	// RequestSchemas contains the resolved schema of each media type of the request body (if there is one).
+	RequestSchemas *orderedmap.Map[string, *base.Schema]
+
+	// ResponseSchemas contains the resolved schema of each media type of every response, keyed by status code
+	// (and 'default').
+	ResponseSchemas *orderedmap.Map[string, *orderedmap.Map[string, *base.Schema]]
+}
+
+type effectiveKey struct {
+	document *Document
+	pathItem *PathItem
+}
+
+// effectiveCache holds the snapshots created for an operation.
+type effectiveCache struct {
+	lock      sync.Mutex
+	snapshots map[effectiveKey]*EffectiveOperation
+}
+
+// Effective returns an EffectiveOperation snapshot of the operation, as it belongs to pathItem in doc. Either can be
+// nil, in which case nothing is inherited from them.
+//
+// The snapshot is computed once and cached for each document and path item, so it's cheap to call repeatedly. The
+// snapshot does not track changes made to the model after it was created, use ResetEffective to discard it.
+func (o *Operation) Effective(doc *Document, pathItem *PathItem) *EffectiveOperation {
+	o.effective.lock.Lock()
+	defer o.effective.lock.Unlock()
+	key := effectiveKey{document: doc, pathItem: pathItem}
+	if eo, ok := o.effective.snapshots[key]; ok {
+		return eo
+	}
+	eo := o.buildEffective(doc, pathItem)
+	if o.effective.snapshots == nil {
+		o.effective.snapshots = make(map[effectiveKey]*EffectiveOperation)
+	}
+	o.effective.snapshots[key] = eo
+	return eo
+}
+
+// ResetEffective discards any snapshots cached by Effective.
+func (o *Operation) ResetEffective() {
+	o.effective.lock.Lock()
+	defer o.effective.lock.Unlock()
+	o.effective.snapshots = nil
+}
+
+func (o *Operation) buildEffective(doc *Document, pathItem *PathItem) *EffectiveOperation {
+	eo := &EffectiveOperation{
+		Operation:       o,
+		PathItem:        pathItem,
+		Parameters:      o.EffectiveParameters(pathItem),
+		SecuritySchemes: orderedmap.New[string, *SecurityScheme](),
+	}
+
+	// security is only inherited when the operation does not define any (an empty list is a definition).
+	eo.Security = o.Security
+	if eo.Security == nil && doc != nil {
+		eo.Security = doc.Security
+	}
+	var schemes *orderedmap.Map[string, *SecurityScheme]
+	if doc != nil && doc.Components != nil {
+		schemes = doc.Components.SecuritySchemes
+	}
+	for _, req := range eo.Security {
+		if req == nil {
+			continue
+		}
+		for name := range req.Requirements.KeysFromOldest() {
+			if _, ok := eo.SecuritySchemes.Get(name); ok {
+				continue
+			}
+			if schemes != nil {
+				if ss, ok := schemes.Get(name); ok {
+					eo.SecuritySchemes.Set(name, ss)
+					continue
+				}
+			}
+			eo.UnresolvedSecurity = append(eo.UnresolvedSecurity, name)
+		}
+	}
+
+	switch {
+	case len(o.Servers) > 0:
+		eo.Servers = o.Servers
+	case pathItem != nil && len(pathItem.Servers) > 0:
+		eo.Servers = pathItem.Servers
+	case doc != nil && len(doc.Servers) > 0:
+		eo.Servers = doc.Servers
+	default:
+		eo.Servers = []*Server{{URL: "/"}}
+	}
+
+	if o.RequestBody != nil {
+		eo.RequestSchemas = contentSchemas(o.RequestBody.Content)
+	}
+	if o.Responses != nil {
+		eo.ResponseSchemas = orderedmap.New[string, *orderedmap.Map[string, *base.Schema]]()
+		for code, resp := range o.Responses.Codes.FromOldest() {
+			if resp != nil {
+				eo.ResponseSchemas.Set(code, contentSchemas(resp.Content))
+			}
+		}
+		if o.Responses.Default != nil {
+			eo.ResponseSchemas.Set("default", contentSchemas(o.Responses.Default.Content))
+		}
+	}
+	return eo
+}
+
+// contentSchemas resolves the schema of every media type in content, media types without a schema are skipped.
+func contentSchemas(content *orderedmap.Map[string, *MediaType]) *orderedmap.Map[string, *base.Schema] {
+	schemas := orderedmap.New[string, *base.Schema]()
+	for mediaType, mt := range content.FromOldest() {
+		if mt == nil || mt.Schema == nil {
+			continue
+		}
+		if s := mt.Schema.Schema(); s != nil {
+			schemas.Set(mediaType, s)
+		}
+	}
+	return schemas
+}
+
+// CascadedExtensions returns the effective extensions of the operation, with extensions defined by the document,
+// the tags of the operation and pathItem cascaded down to it, following the precedence of cascade (a nil cascade
+// uses high.DefaultExtensionPrecedence). Tags are used in the order they are listed by the operation, doc and
+// pathItem can be nil.
+func (o *Operation) CascadedExtensions(doc *Document, pathItem *PathItem,
+	cascade *high.ExtensionCascade,
+) *orderedmap.Map[string, *high.CascadedExtension] {
+	sources := []high.ExtensionSource{{Level: high.ExtensionLevelOperation, Name: o.OperationId, Extensions: o.Extensions}}
+	if pathItem != nil {
+		var path string
+		if doc != nil && doc.Paths != nil {
+			for p, pi := range doc.Paths.PathItems.FromOldest() {
+				if pi == pathItem {
+					path = p
+					break
+				}
+			}
+		}
+		sources = append(sources, high.ExtensionSource{
+			Level: high.ExtensionLevelPathItem, Name: path, Extensions: pathItem.Extensions,
+		})
+	}
+	if doc != nil {
+		for _, name := range o.Tags {
+			for _, tag := range doc.Tags {
+				if tag != nil && tag.Name == name {
+					sources = append(sources, high.ExtensionSource{
+						Level: high.ExtensionLevelTag, Name: name, Extensions: tag.Extensions,
+					})
+					break
+				}
+			}
+		}
+		sources = append(sources, high.ExtensionSource{Level: high.ExtensionLevelDocument, Extensions: doc.Extensions})
+	}
+	return cascade.Resolve(sources...)
+}
@@ -0,0 +1,181 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperation_Effective(t *testing.T) {
+	yml := `openapi: 3.1.0
+servers:
+  - url: https://api.example.com
+security:
+  - apiKey: []
+paths:
+  /pets/{petId}:
+    servers:
+      - url: https://pets.example.com
+    parameters:
+      - name: petId
+        in: path
+        required: true
+    get:
+      parameters:
+        - name: limit
+          in: query
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+            text/plain:
+              description: no schema
+        default:
+          description: error
+          content:
+            application/json:
+              schema:
+                type: string
+    put:
+      servers:
+        - url: https://put.example.com
+      security:
+        - oauth: [write]
+          missing: []
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "204":
+          description: done
+    delete:
+      security: []
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    oauth:
+      type: oauth2
+  schemas:
+    Pet:
+      type: object
+      description: a pet`
+
+	doc := buildTestDocument(t, yml)
+	pathItem := doc.Paths.PathItems.GetOrZero("/pets/{petId}")
+
+	get := pathItem.Get.Effective(doc, pathItem)
+	assert.Same(t, pathItem.Get, get.Operation)
+	assert.Same(t, pathItem, get.PathItem)
+	assert.Len(t, get.Parameters, 2)
+	assert.Equal(t, "petId", get.Parameters[0].Name)
+	assert.Equal(t, "limit", get.Parameters[1].Name)
+	assert.Equal(t, doc.Security, get.Security)
+	assert.Equal(t, 1, get.SecuritySchemes.Len())
+	assert.Equal(t, "X-API-Key", get.SecuritySchemes.GetOrZero("apiKey").Name)
+	assert.Empty(t, get.UnresolvedSecurity)
+	assert.Equal(t, "https://pets.example.com", get.Servers[0].URL)
+	assert.Nil(t, get.RequestSchemas)
+	assert.Equal(t, 2, get.ResponseSchemas.Len())
+	assert.Equal(t, 1, get.ResponseSchemas.GetOrZero("200").Len())
+	assert.Equal(t, "a pet", get.ResponseSchemas.GetOrZero("200").GetOrZero("application/json").Description)
+	assert.Equal(t, []string{"string"}, get.ResponseSchemas.GetOrZero("default").GetOrZero("application/json").Type)
+
+	// snapshots are cached.
+	assert.Same(t, get, pathItem.Get.Effective(doc, pathItem))
+	pathItem.Get.ResetEffective()
+	assert.NotSame(t, get, pathItem.Get.Effective(doc, pathItem))
+
+	put := pathItem.Put.Effective(doc, pathItem)
+	assert.Len(t, put.Parameters, 1)
+	assert.Equal(t, "https://put.example.com", put.Servers[0].URL)
+	assert.Equal(t, 1, put.SecuritySchemes.Len())
+	assert.Equal(t, "oauth2", put.SecuritySchemes.GetOrZero("oauth").Type)
+	assert.Equal(t, []string{"missing"}, put.UnresolvedSecurity)
+	assert.Equal(t, "a pet", put.RequestSchemas.GetOrZero("application/json").Description)
+	assert.Equal(t, 0, put.ResponseSchemas.GetOrZero("204").Len())
+
+	del := pathItem.Delete.Effective(doc, pathItem)
+	assert.NotNil(t, del.Security)
+	assert.Empty(t, del.Security)
+	assert.Equal(t, 0, del.SecuritySchemes.Len())
+	assert.Nil(t, del.ResponseSchemas)
+
+	// without a path item, the document servers are used.
+	noPath := pathItem.Get.Effective(doc, nil)
+	assert.Nil(t, noPath.PathItem)
+	assert.Len(t, noPath.Parameters, 1)
+	assert.Equal(t, "https://api.example.com", noPath.Servers[0].URL)
+}
+
+func TestOperation_Effective_NoDocument(t *testing.T) {
+	op := &Operation{OperationId: "test"}
+	eo := op.Effective(nil, nil)
+	assert.Nil(t, eo.Security)
+	assert.Empty(t, eo.Parameters)
+	assert.Len(t, eo.Servers, 1)
+	assert.Equal(t, "/", eo.Servers[0].URL)
+}
+
+func TestOperation_CascadedExtensions(t *testing.T) {
+	yml := `openapi: 3.1.0
+x-ratelimit-tier: bronze
+x-owner: platform
+tags:
+  - name: store
+    x-team: store-team
+  - name: pets
+    x-ratelimit-tier: silver
+    x-team: pets-team
+paths:
+  /pets:
+    x-cache: short
+    get:
+      operationId: listPets
+      tags: [pets, store, unknown]
+      x-internal: true
+    post:
+      operationId: createPet
+      x-ratelimit-tier: gold`
+
+	doc := buildTestDocument(t, yml)
+	pathItem := doc.Paths.PathItems.GetOrZero("/pets")
+
+	get := pathItem.Get.CascadedExtensions(doc, pathItem, nil)
+	assert.Equal(t, 5, get.Len())
+	assert.Equal(t, "true", get.GetOrZero("x-internal").Value.Value)
+	assert.Equal(t, high.ExtensionLevelOperation, get.GetOrZero("x-internal").Level)
+	assert.Equal(t, "listPets", get.GetOrZero("x-internal").Source)
+	assert.Equal(t, "/pets", get.GetOrZero("x-cache").Source)
+	assert.Equal(t, high.ExtensionLevelPathItem, get.GetOrZero("x-cache").Level)
+	assert.Equal(t, "silver", get.GetOrZero("x-ratelimit-tier").Value.Value)
+	assert.Equal(t, "pets", get.GetOrZero("x-ratelimit-tier").Source)
+	assert.Equal(t, "pets-team", get.GetOrZero("x-team").Value.Value)
+	assert.Equal(t, high.ExtensionLevelDocument, get.GetOrZero("x-owner").Level)
+
+	post := pathItem.Post.CascadedExtensions(doc, pathItem, &high.ExtensionCascade{
+		Extensions: []string{"x-ratelimit-tier"},
+	})
+	assert.Equal(t, 1, post.Len())
+	assert.Equal(t, "gold", post.GetOrZero("x-ratelimit-tier").Value.Value)
+
+	tags := pathItem.Get.CascadedExtensions(doc, pathItem, &high.ExtensionCascade{
+		Precedence: []high.ExtensionLevel{high.ExtensionLevelTag, high.ExtensionLevelDocument},
+	})
+	assert.Equal(t, 3, tags.Len())
+	assert.Equal(t, "silver", tags.GetOrZero("x-ratelimit-tier").Value.Value)
+
+	orphan := pathItem.Post.CascadedExtensions(nil, nil, nil)
+	assert.Equal(t, 1, orphan.Len())
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -56,6 +57,36 @@ callbacks:
 	assert.Equal(t, 3, r.GoLow().Callbacks.KeyNode.Line)
 }
 
+func TestOperation_UnknownFields(t *testing.T) {
+	yml := `operationId: listPets
+x-team: pets
+rateLimit:
+    perMinute: 10
+responses:
+    '200':
+        description: OK
+        schemaz: oops
+`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n v3.Operation
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	assert.Equal(t, 1, n.UnknownFields.Len())
+	assert.Equal(t, "rateLimit", n.UnknownFields.First().Key().Value)
+	assert.Equal(t, 3, n.UnknownFields.First().Key().KeyNode.Line)
+	ok := n.Responses.Value.FindResponseByCode("200").Value
+	assert.Equal(t, "schemaz", ok.GetUnknownFields().First().Key().Value)
+
+	r := NewOperation(&n)
+	rend, _ := r.Render()
+	assert.Equal(t, yml, string(rend))
+}
+
 func TestOperation_MarshalYAML(t *testing.T) {
 	op := &Operation{
 		Tags:        []string{"test"},
@@ -161,3 +192,62 @@ func TestOperation_NoSecurity(t *testing.T) {
 
 	assert.Nil(t, r.Security)
 }
+
+func TestOperation_EffectiveParameters(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/petId'
+      - name: X-Trace
+        in: header
+        description: path trace
+      - name: limit
+        in: query
+        description: path limit
+    get:
+      parameters:
+        - name: limit
+          in: query
+          description: operation limit
+        - name: x-trace
+          in: header
+          description: operation trace
+        - name: limit
+          in: cookie
+        - name: limit
+          in: query
+          description: duplicate limit
+    put:
+      description: no parameters
+components:
+  parameters:
+    petId:
+      name: petId
+      in: path
+      required: true
+      description: the pet`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	lowDoc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	doc := NewDocument(lowDoc)
+	pathItem := doc.Paths.PathItems.GetOrZero("/pets/{petId}")
+
+	params := pathItem.Get.EffectiveParameters(pathItem)
+	assert.Len(t, params, 4)
+	assert.Equal(t, "petId", params[0].Name)
+	assert.Equal(t, "the pet", params[0].Description)
+	assert.Equal(t, "operation trace", params[1].Description)
+	assert.Equal(t, "operation limit", params[2].Description)
+	assert.Equal(t, "cookie", params[3].In)
+
+	params = pathItem.Put.EffectiveParameters(pathItem)
+	assert.Len(t, params, 3)
+	assert.Equal(t, "path trace", params[1].Description)
+
+	params = pathItem.Get.EffectiveParameters(nil)
+	assert.Len(t, params, 3)
+	assert.Equal(t, "operation limit", params[0].Description)
+}
@@ -0,0 +1,313 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// CookieLabel is the `in` value used by cookie parameters.
+const CookieLabel = "cookie"
+
+// cookieSeparator separates cookie pairs in a Cookie header (RFC 6265).
+const cookieSeparator = "; "
+
+// cookieStyle returns the style and explode settings used by a cookie parameter. `form` is the only style
+// the specification allows for cookies, and (as with all form style parameters) explode defaults to true.
+func (p *Parameter) cookieStyle() (bool, error) {
+	if !strings.EqualFold(p.In, CookieLabel) {
+		return false, fmt.Errorf("parameter '%s' is not a cookie parameter (in: %s)", p.Name, p.In)
+	}
+	if p.Style != "" && p.Style != "form" {
+		return false, fmt.Errorf("parameter '%s' uses style '%s', cookie parameters only support 'form'",
+			p.Name, p.Style)
+	}
+	return p.Explode == nil || *p.Explode, nil
+}
+
+// SerializeCookie will serialize a value for a cookie parameter, using the `form` style and the explode setting
+// of the parameter. The result is one or more cookie pairs, ready to be used as (or appended to) a Cookie header.
+//
+// Using the examples from the specification, for a parameter named `color`:
+//
+//	primitive  "blue"                           -> color=blue
+//	array      []string{"blue","black","brown"} -> color=blue,black,brown  (explode: false)
+//	                                            -> color=blue; color=black; color=brown
+//	object     map[string]int{"R":100,...}      -> color=R,100,G,200,B,150 (explode: false)
+//	                                            -> R=100; G=200; B=150
+//
+// The specification uses `&` to separate exploded form values, which is not valid inside a Cookie header, so
+// exploded values are separated by `; ` as each value becomes its own cookie. Values are percent-encoded so they
+// can't break the cookie or the delimiters. Map keys are serialized in alphabetical order, unless the parameter
+// schema defines properties, in which case the property order is used. An *orderedmap.Map[string, any] keeps the
+// order of its keys.
+func (p *Parameter) SerializeCookie(value any) (string, error) {
+	explode, err := p.cookieStyle()
+	if err != nil {
+		return "", err
+	}
+	if m, ok := value.(*orderedmap.Map[string, any]); ok && m != nil {
+		var keys, values []string
+		for k, val := range m.FromOldest() {
+			keys = append(keys, k)
+			values = append(values, url.PathEscape(fmt.Sprint(val)))
+		}
+		return p.cookieObject(keys, values, explode), nil
+	}
+	v := reflect.ValueOf(value)
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return "", fmt.Errorf("unable to serialize cookie parameter '%s', value is nil", p.Name)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", fmt.Errorf("unable to serialize cookie parameter '%s', value is nil", p.Name)
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = url.PathEscape(fmt.Sprint(v.Index(i).Interface()))
+		}
+		if !explode {
+			return p.Name + "=" + strings.Join(values, ","), nil
+		}
+		pairs := make([]string, len(values))
+		for i := range values {
+			pairs[i] = p.Name + "=" + values[i]
+		}
+		return strings.Join(pairs, cookieSeparator), nil
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return "", fmt.Errorf("unable to serialize cookie parameter '%s', object keys must be strings", p.Name)
+		}
+		keys := p.cookieObjectKeys(v)
+		values := make([]string, len(keys))
+		for i, k := range keys {
+			values[i] = url.PathEscape(fmt.Sprint(v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())).Interface()))
+		}
+		return p.cookieObject(keys, values, explode), nil
+
+	case reflect.Struct, reflect.Func, reflect.Chan:
+		return "", fmt.Errorf("unable to serialize cookie parameter '%s', unsupported type '%s'", p.Name, v.Type())
+	}
+	return p.Name + "=" + url.PathEscape(fmt.Sprint(v.Interface())), nil
+}
+
+// cookieObject serializes the keys and (encoded) values of an object, in order.
+func (p *Parameter) cookieObject(keys, values []string, explode bool) string {
+	var parts []string
+	for i, k := range keys {
+		if explode {
+			parts = append(parts, url.PathEscape(k)+"="+values[i])
+		} else {
+			parts = append(parts, url.PathEscape(k), values[i])
+		}
+	}
+	if explode {
+		return strings.Join(parts, cookieSeparator)
+	}
+	return p.Name + "=" + strings.Join(parts, ",")
+}
+
+// cookieObjectKeys returns the keys of a map, in schema property order if the schema defines properties,
+// followed by any remaining keys in alphabetical order.
+func (p *Parameter) cookieObjectKeys(v reflect.Value) []string {
+	var keys []string
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	props := p.cookieSchemaProperties()
+	if len(props) == 0 {
+		return keys
+	}
+	ordered := make([]string, 0, len(keys))
+	for _, prop := range props {
+		if slices.Contains(keys, prop) {
+			ordered = append(ordered, prop)
+		}
+	}
+	for _, k := range keys {
+		if !slices.Contains(ordered, k) {
+			ordered = append(ordered, k)
+		}
+	}
+	return ordered
+}
+
+// cookieSchemaType returns the schema type of the parameter ('array', 'object' or a primitive type).
+func (p *Parameter) cookieSchemaType() string {
+	if p.Schema == nil {
+		return ""
+	}
+	s := p.Schema.Schema()
+	if s == nil {
+		return ""
+	}
+	for _, t := range s.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	if s.Properties != nil {
+		return "object"
+	}
+	if s.Items != nil {
+		return "array"
+	}
+	return ""
+}
+
+// cookieSchemaProperties returns the property names defined by the parameter schema, in order.
+func (p *Parameter) cookieSchemaProperties() []string {
+	if p.Schema == nil {
+		return nil
+	}
+	s := p.Schema.Schema()
+	if s == nil {
+		return nil
+	}
+	var props []string
+	for k := range s.Properties.KeysFromOldest() {
+		props = append(props, k)
+	}
+	return props
+}
+
+// ParseCookie will parse the value of a cookie parameter from a Cookie header (for example
+// `color=blue; session=abc`), using the `form` style, the explode setting and the schema of the parameter.
+//
+// A string is returned for primitive schemas, a []string for `array` schemas and a map[string]string for `object`
+// schemas. Values are percent-decoded, no type conversion is performed. When an exploded object is parsed, every
+// cookie named after a property of the schema is collected, so the schema must define properties. An error is
+// returned if the cookie (or none of the object properties) can be found.
+func (p *Parameter) ParseCookie(cookieHeader string) (any, error) {
+	explode, err := p.cookieStyle()
+	if err != nil {
+		return nil, err
+	}
+	cookies, err := parseCookiePairs(cookieHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := func(name string) []string {
+		var found []string
+		for _, c := range cookies {
+			if c[0] == name {
+				found = append(found, c[1])
+			}
+		}
+		return found
+	}
+	notFound := fmt.Errorf("cookie parameter '%s' not found", p.Name)
+
+	switch p.cookieSchemaType() {
+	case "array":
+		found := lookup(p.Name)
+		if len(found) == 0 {
+			return nil, notFound
+		}
+		if !explode {
+			return splitCookieValue(p.Name, found[0])
+		}
+		values := make([]string, len(found))
+		for i := range found {
+			if values[i], err = url.PathUnescape(found[i]); err != nil {
+				return nil, fmt.Errorf("unable to parse cookie '%s': %w", p.Name, err)
+			}
+		}
+		return values, nil
+
+	case "object":
+		obj := make(map[string]string)
+		if explode {
+			props := p.cookieSchemaProperties()
+			if len(props) == 0 {
+				return nil, fmt.Errorf("unable to parse exploded cookie parameter '%s', "+
+					"the schema does not define any properties", p.Name)
+			}
+			for _, prop := range props {
+				if found := lookup(prop); len(found) > 0 {
+					if obj[prop], err = url.PathUnescape(found[0]); err != nil {
+						return nil, fmt.Errorf("unable to parse cookie '%s': %w", prop, err)
+					}
+				}
+			}
+			if len(obj) == 0 {
+				return nil, notFound
+			}
+			return obj, nil
+		}
+		found := lookup(p.Name)
+		if len(found) == 0 {
+			return nil, notFound
+		}
+		parts, err := splitCookieValue(p.Name, found[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(parts)%2 != 0 {
+			return nil, fmt.Errorf("unable to parse cookie parameter '%s', object value '%s' has an odd "+
+				"number of elements", p.Name, found[0])
+		}
+		for i := 0; i < len(parts); i += 2 {
+			obj[parts[i]] = parts[i+1]
+		}
+		return obj, nil
+	}
+
+	found := lookup(p.Name)
+	if len(found) == 0 {
+		return nil, notFound
+	}
+	value, err := url.PathUnescape(found[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse cookie '%s': %w", p.Name, err)
+	}
+	return value, nil
+}
+
+// parseCookiePairs splits a Cookie header into name/value pairs. Values are left encoded, so that encoded
+// delimiters inside values (such as `%2C`) survive until the value has been split.
+func parseCookiePairs(cookieHeader string) ([][2]string, error) {
+	var pairs [][2]string
+	for _, c := range strings.Split(cookieHeader, ";") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(c, "=")
+		name, err := url.PathUnescape(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse cookie '%s': %w", c, err)
+		}
+		pairs = append(pairs, [2]string{name, strings.Trim(strings.TrimSpace(value), `"`)})
+	}
+	return pairs, nil
+}
+
+// splitCookieValue splits a form encoded cookie value on commas and percent-decodes each element.
+func splitCookieValue(name, value string) ([]string, error) {
+	elements := strings.Split(value, ",")
+	for i := range elements {
+		d, err := url.PathUnescape(elements[i])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse cookie '%s': %w", name, err)
+		}
+		elements[i] = d
+	}
+	return elements, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func cookieParam(explode *bool, schema *base.Schema) *Parameter {
+	p := &Parameter{Name: "color", In: "cookie", Explode: explode}
+	if schema != nil {
+		p.Schema = base.CreateSchemaProxy(schema)
+	}
+	return p
+}
+
+func rgbSchema() *base.Schema {
+	props := orderedmap.New[string, *base.SchemaProxy]()
+	props.Set("R", base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}))
+	props.Set("G", base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}))
+	props.Set("B", base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}))
+	return &base.Schema{Type: []string{"object"}, Properties: props}
+}
+
+// conformance with the style examples in the specification: https://spec.openapis.org/oas/v3.1.0#style-examples
+func TestParameter_Cookie_SpecExamples(t *testing.T) {
+	f, tr := false, true
+	rgb := map[string]int{"R": 100, "G": 200, "B": 150}
+
+	tests := []struct {
+		name       string
+		explode    *bool
+		schema     *base.Schema
+		value      any
+		serialized string
+		parsed     any
+	}{
+		{"primitive", &f, &base.Schema{Type: []string{"string"}}, "blue", "color=blue", "blue"},
+		{"primitive exploded", &tr, &base.Schema{Type: []string{"string"}}, "blue", "color=blue", "blue"},
+		{"primitive default", nil, nil, "blue", "color=blue", "blue"},
+		{
+			"array", &f, &base.Schema{Type: []string{"array"}}, []string{"blue", "black", "brown"},
+			"color=blue,black,brown", []string{"blue", "black", "brown"},
+		},
+		{
+			"array exploded", &tr, &base.Schema{Type: []string{"array"}}, []string{"blue", "black", "brown"},
+			"color=blue; color=black; color=brown", []string{"blue", "black", "brown"},
+		},
+		{
+			"array default explode", nil, &base.Schema{Type: []string{"array"}}, []any{"blue", "black"},
+			"color=blue; color=black", []string{"blue", "black"},
+		},
+		{
+			"object", &f, rgbSchema(), rgb,
+			"color=R,100,G,200,B,150", map[string]string{"R": "100", "G": "200", "B": "150"},
+		},
+		{
+			"object exploded", &tr, rgbSchema(), rgb,
+			"R=100; G=200; B=150", map[string]string{"R": "100", "G": "200", "B": "150"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := cookieParam(tc.explode, tc.schema)
+			s, err := p.SerializeCookie(tc.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.serialized, s)
+
+			// parse from a full header, with other cookies mixed in.
+			v, err := p.ParseCookie("session=abc123; " + s + "; theme=dark")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.parsed, v)
+		})
+	}
+}
+
+func TestParameter_Cookie_Escaping(t *testing.T) {
+	f := false
+	p := cookieParam(&f, &base.Schema{Type: []string{"array"}})
+	s, err := p.SerializeCookie([]string{"a;b", "c,d", "e f"})
+	assert.NoError(t, err)
+	assert.Equal(t, "color=a%3Bb,c%2Cd,e%20f", s)
+
+	v, err := p.ParseCookie(s)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a;b", "c,d", "e f"}, v)
+
+	p = cookieParam(nil, nil)
+	v, err = p.ParseCookie(`color="blue"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "blue", v)
+}
+
+func TestParameter_Cookie_UnorderedObject(t *testing.T) {
+	f := false
+	p := cookieParam(&f, &base.Schema{Type: []string{"object"}})
+	s, err := p.SerializeCookie(map[string]string{"b": "2", "a": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "color=a,1,b,2", s)
+}
+
+func TestParameter_Cookie_Errors(t *testing.T) {
+	f, tr := false, true
+
+	_, err := (&Parameter{Name: "color", In: "query"}).SerializeCookie("blue")
+	assert.EqualError(t, err, "parameter 'color' is not a cookie parameter (in: query)")
+	_, err = (&Parameter{Name: "color", In: "query"}).ParseCookie("color=blue")
+	assert.Error(t, err)
+
+	_, err = (&Parameter{Name: "color", In: "cookie", Style: "simple"}).SerializeCookie("blue")
+	assert.EqualError(t, err, "parameter 'color' uses style 'simple', cookie parameters only support 'form'")
+
+	p := cookieParam(nil, nil)
+	_, err = p.SerializeCookie(nil)
+	assert.Error(t, err)
+	var nilPtr *string
+	_, err = p.SerializeCookie(nilPtr)
+	assert.Error(t, err)
+	_, err = p.SerializeCookie(struct{}{})
+	assert.Error(t, err)
+	_, err = p.SerializeCookie(map[int]string{1: "a"})
+	assert.Error(t, err)
+
+	_, err = p.ParseCookie("theme=dark")
+	assert.EqualError(t, err, "cookie parameter 'color' not found")
+	_, err = p.ParseCookie("color=%zz")
+	assert.Error(t, err)
+	_, err = p.ParseCookie("%zz=blue")
+	assert.Error(t, err)
+
+	p = cookieParam(&f, &base.Schema{Type: []string{"array"}})
+	_, err = p.ParseCookie("theme=dark")
+	assert.Error(t, err)
+	_, err = p.ParseCookie("color=%zz")
+	assert.Error(t, err)
+	p = cookieParam(&tr, &base.Schema{Type: []string{"array"}})
+	_, err = p.ParseCookie("color=%zz")
+	assert.Error(t, err)
+
+	p = cookieParam(&f, rgbSchema())
+	_, err = p.ParseCookie("color=R,100,G")
+	assert.EqualError(t, err, "unable to parse cookie parameter 'color', object value 'R,100,G' has an odd number of elements")
+	_, err = p.ParseCookie("theme=dark")
+	assert.Error(t, err)
+	_, err = p.ParseCookie("color=%zz")
+	assert.Error(t, err)
+
+	p = cookieParam(&tr, rgbSchema())
+	_, err = p.ParseCookie("theme=dark")
+	assert.Error(t, err)
+	_, err = p.ParseCookie("R=%zz")
+	assert.Error(t, err)
+	p = cookieParam(&tr, &base.Schema{Type: []string{"object"}})
+	_, err = p.ParseCookie("R=100")
+	assert.Error(t, err)
+}
@@ -23,6 +23,7 @@ const (
 	head
 	patch
 	trace
+	query
 )
 
 // PathItem represents a high-level OpenAPI 3+ PathItem object backed by a low-level one.
@@ -31,21 +32,26 @@ const (
 // The path itself is still exposed to the documentation viewer but they will not know which operations and parameters
 // are available.
 //   - https://spec.openapis.org/oas/v3.1.0#path-item-object
+//
+// Query and AdditionalOperations were added in OpenAPI 3.2. AdditionalOperations holds operations for any other
+// HTTP method, keyed by the method as it is written in the document (for example `COPY`).
 type PathItem struct {
-	Description string                              `json:"description,omitempty" yaml:"description,omitempty"`
-	Summary     string                              `json:"summary,omitempty" yaml:"summary,omitempty"`
-	Get         *Operation                          `json:"get,omitempty" yaml:"get,omitempty"`
-	Put         *Operation                          `json:"put,omitempty" yaml:"put,omitempty"`
-	Post        *Operation                          `json:"post,omitempty" yaml:"post,omitempty"`
-	Delete      *Operation                          `json:"delete,omitempty" yaml:"delete,omitempty"`
-	Options     *Operation                          `json:"options,omitempty" yaml:"options,omitempty"`
-	Head        *Operation                          `json:"head,omitempty" yaml:"head,omitempty"`
-	Patch       *Operation                          `json:"patch,omitempty" yaml:"patch,omitempty"`
-	Trace       *Operation                          `json:"trace,omitempty" yaml:"trace,omitempty"`
-	Servers     []*Server                           `json:"servers,omitempty" yaml:"servers,omitempty"`
-	Parameters  []*Parameter                        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	Extensions  *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
-	low         *lowV3.PathItem
+	Description          string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Summary              string                              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Get                  *Operation                          `json:"get,omitempty" yaml:"get,omitempty"`
+	Put                  *Operation                          `json:"put,omitempty" yaml:"put,omitempty"`
+	Post                 *Operation                          `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete               *Operation                          `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options              *Operation                          `json:"options,omitempty" yaml:"options,omitempty"`
+	Head                 *Operation                          `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch                *Operation                          `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Trace                *Operation                          `json:"trace,omitempty" yaml:"trace,omitempty"`
+	Query                *Operation                          `json:"query,omitempty" yaml:"query,omitempty"`                               // 3.2
+	AdditionalOperations *orderedmap.Map[string, *Operation] `json:"additionalOperations,omitempty" yaml:"additionalOperations,omitempty"` // 3.2
+	Servers              []*Server                           `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Parameters           []*Parameter                        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Extensions           *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                  *lowV3.PathItem
 }
 
 // NewPathItem creates a new high-level PathItem instance from a low-level one.
@@ -83,6 +89,11 @@ func NewPathItem(pathItem *lowV3.PathItem) *PathItem {
 	go buildOperation(head, pathItem.Head.Value, opChan)
 	go buildOperation(patch, pathItem.Patch.Value, opChan)
 	go buildOperation(trace, pathItem.Trace.Value, opChan)
+	go buildOperation(query, pathItem.Query.Value, opChan)
+
+	if !pathItem.AdditionalOperations.IsEmpty() {
+		pi.AdditionalOperations = low.FromReferenceMapWithFunc(pathItem.AdditionalOperations.Value, NewOperation)
+	}
 
 	if !pathItem.Parameters.IsEmpty() {
 		params := make([]*Parameter, len(pathItem.Parameters.Value))
@@ -113,10 +124,12 @@ func NewPathItem(pathItem *lowV3.PathItem) *PathItem {
 			pi.Patch = opRes.op
 		case trace:
 			pi.Trace = opRes.op
+		case query:
+			pi.Query = opRes.op
 		}
 
 		opCount++
-		if opCount == 8 {
+		if opCount == 9 {
 			complete = true
 		}
 	}
@@ -133,6 +146,8 @@ func (p *PathItem) GoLowUntyped() any {
 	return p.low
 }
 
+// GetOperations returns every operation of the PathItem keyed by method, in document order. Additional operations
+// (3.2) are keyed by their method as it is written in the document.
 func (p *PathItem) GetOperations() *orderedmap.Map[string, *Operation] {
 	o := orderedmap.New[string, *Operation]()
 
@@ -183,6 +198,23 @@ func (p *PathItem) GetOperations() *orderedmap.Map[string, *Operation] {
 	if p.Trace != nil {
 		ops = append(ops, op{name: lowV3.TraceLabel, op: p.Trace, line: getLine("Trace", -1)})
 	}
+	if p.Query != nil {
+		ops = append(ops, op{name: lowV3.QueryLabel, op: p.Query, line: getLine("Query", 0)})
+	}
+	if p.AdditionalOperations != nil {
+		i := 1
+		for method, additional := range p.AdditionalOperations.FromOldest() {
+			if additional == nil {
+				continue
+			}
+			line := i
+			if additional.GoLow() != nil && additional.GoLow().KeyNode != nil {
+				line = additional.GoLow().KeyNode.Line
+			}
+			ops = append(ops, op{name: method, op: additional, line: line})
+			i++
+		}
+	}
 
 	slices.SortStableFunc(ops, func(a op, b op) int {
 		return a.line - b.line
@@ -24,14 +24,25 @@ import (
 type Paths struct {
 	PathItems  *orderedmap.Map[string, *PathItem]  `json:"-" yaml:"-"`
 	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
-	low        *v3low.Paths
+
+	// InvalidPaths contains every malformed path key, and `x-` keys holding a path item. Malformed paths are also in
+	// PathItems, `x-` keys are in Extensions. The positions of each key (and diagnostics) are available from the
+	// low-level Paths.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	InvalidPaths *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low          *v3low.Paths
 }
 
-// NewPaths creates a new high-level instance of Paths from a low-level one.
+// NewPaths creates a new high-level instance of Paths from a low-level one. PathItems are translated concurrently,
+// but are always stored in the same order as the low-level PathItems.
 func NewPaths(paths *v3low.Paths) *Paths {
 	p := new(Paths)
 	p.low = paths
 	p.Extensions = high.ExtractExtensions(paths.Extensions)
+	if orderedmap.Len(paths.InvalidPaths) > 0 {
+		p.InvalidPaths = low.FromReferenceMap(paths.InvalidPaths)
+	}
 	items := orderedmap.New[string, *PathItem]()
 
 	type pathItemResult struct {
@@ -53,6 +64,74 @@ func NewPaths(paths *v3low.Paths) *Paths {
 	return p
 }
 
+// PathKeys returns every path key held by the Paths object, in order. Paths built from a document retain the
+// order in which they appear in the source document, new paths are appended to the end.
+func (p *Paths) PathKeys() []string {
+	if p.PathItems == nil {
+		return nil
+	}
+	keys := make([]string, 0, p.PathItems.Len())
+	for k := range p.PathItems.KeysFromOldest() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// SelectedOperation is an Operation selected by Paths.SelectOperations, along with the path and method it belongs to.
+type SelectedOperation struct {
+	Path      string
+	Method    string
+	PathItem  *PathItem
+	Operation *Operation
+}
+
+// Select returns every PathItem with a path matching the selector, in document order. Selectors are globs
+// (`/users/**`) or regular expressions (`regex:^/users/[^/]+$`), see utils.PathSelector for the syntax. If methods
+// are supplied, only path items with at least one operation using one of those methods are selected.
+func (p *Paths) Select(selector string, methods ...string) (*orderedmap.Map[string, *PathItem], error) {
+	ps, err := utils.CompilePathSelector(selector, methods...)
+	if err != nil {
+		return nil, err
+	}
+	selected := orderedmap.New[string, *PathItem]()
+	for path, pathItem := range p.PathItems.FromOldest() {
+		if pathItem == nil || !ps.MatchPath(path) {
+			continue
+		}
+		for method := range pathItem.GetOperations().KeysFromOldest() {
+			if ps.MatchMethod(method) {
+				selected.Set(path, pathItem)
+				break
+			}
+		}
+	}
+	return selected, nil
+}
+
+// SelectOperations returns every operation with a path matching the selector, and a method matching one of the
+// supplied methods (or any method, if none are supplied). Operations are returned in document order. See Select
+// for the selector syntax.
+func (p *Paths) SelectOperations(selector string, methods ...string) ([]*SelectedOperation, error) {
+	ps, err := utils.CompilePathSelector(selector, methods...)
+	if err != nil {
+		return nil, err
+	}
+	var selected []*SelectedOperation
+	for path, pathItem := range p.PathItems.FromOldest() {
+		if pathItem == nil || !ps.MatchPath(path) {
+			continue
+		}
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			if ps.MatchMethod(method) {
+				selected = append(selected, &SelectedOperation{
+					Path: path, Method: method, PathItem: pathItem, Operation: op,
+				})
+			}
+		}
+	}
+	return selected, nil
+}
+
 // GoLow returns the low-level Paths instance used to create the high-level one.
 func (p *Paths) GoLow() *v3low.Paths {
 	return p.low
@@ -83,12 +162,14 @@ func (p *Paths) MarshalYAML() (interface{}, error) {
 		line     int
 		style    yaml.Style
 		rendered *yaml.Node
+		key      *yaml.Node
 	}
 	var mapped []*pathItem
 
 	for k, pi := range p.PathItems.FromOldest() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		var style yaml.Style
+		var key *yaml.Node
 		if p.low != nil {
 			lpi := p.low.FindPath(k)
 			if lpi != nil {
@@ -98,11 +179,12 @@ func (p *Paths) MarshalYAML() (interface{}, error) {
 			for lk := range p.low.PathItems.KeysFromOldest() {
 				if lk.Value == k {
 					style = lk.KeyNode.Style
+					key = lk.KeyNode
 					break
 				}
 			}
 		}
-		mapped = append(mapped, &pathItem{pi, k, ln, style, nil})
+		mapped = append(mapped, &pathItem{pi, k, ln, style, nil, key})
 	}
 
 	nb := high.NewNodeBuilder(p, p.low)
@@ -116,7 +198,7 @@ func (p *Paths) MarshalYAML() (interface{}, error) {
 			}
 			mapped = append(mapped, &pathItem{
 				nil, label,
-				extNode.Content[u].Line, 0, extNode.Content[u],
+				extNode.Content[u].Line, 0, extNode.Content[u], extNode.Content[u-1],
 			})
 		}
 	}
@@ -130,12 +212,15 @@ func (p *Paths) MarshalYAML() (interface{}, error) {
 
 			kn := utils.CreateStringNode(mp.path)
 			kn.Style = mp.style
+			low.CopyComments(kn, mp.key)
 
 			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, rendered.(*yaml.Node))
 		}
 		if mp.rendered != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mp.path))
+			kn := utils.CreateStringNode(mp.path)
+			low.CopyComments(kn, mp.key)
+			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, mp.rendered)
 		}
 	}
@@ -152,12 +237,14 @@ func (p *Paths) MarshalYAMLInline() (interface{}, error) {
 		line     int
 		style    yaml.Style
 		rendered *yaml.Node
+		key      *yaml.Node
 	}
 	var mapped []*pathItem
 
 	for k, pi := range p.PathItems.FromOldest() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		var style yaml.Style
+		var key *yaml.Node
 		if p.low != nil {
 			lpi := p.low.FindPath(k)
 			if lpi != nil {
@@ -167,11 +254,12 @@ func (p *Paths) MarshalYAMLInline() (interface{}, error) {
 			for lk := range p.low.PathItems.KeysFromOldest() {
 				if lk.Value == k {
 					style = lk.KeyNode.Style
+					key = lk.KeyNode
 					break
 				}
 			}
 		}
-		mapped = append(mapped, &pathItem{pi, k, ln, style, nil})
+		mapped = append(mapped, &pathItem{pi, k, ln, style, nil, key})
 	}
 
 	nb := high.NewNodeBuilder(p, p.low)
@@ -186,7 +274,7 @@ func (p *Paths) MarshalYAMLInline() (interface{}, error) {
 			}
 			mapped = append(mapped, &pathItem{
 				nil, label,
-				extNode.Content[u].Line, 0, extNode.Content[u],
+				extNode.Content[u].Line, 0, extNode.Content[u], extNode.Content[u-1],
 			})
 		}
 	}
@@ -200,12 +288,15 @@ func (p *Paths) MarshalYAMLInline() (interface{}, error) {
 
 			kn := utils.CreateStringNode(mp.path)
 			kn.Style = mp.style
+			low.CopyComments(kn, mp.key)
 
 			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, rendered.(*yaml.Node))
 		}
 		if mp.rendered != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mp.path))
+			kn := utils.CreateStringNode(mp.path)
+			low.CopyComments(kn, mp.key)
+			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, mp.rendered)
 		}
 	}
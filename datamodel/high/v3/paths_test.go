@@ -5,6 +5,7 @@ package v3
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
 
@@ -118,3 +119,101 @@ func TestPaths_MarshalYAMLInline(t *testing.T) {
 	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
 
 }
+
+func TestPaths_PathKeys(t *testing.T) {
+	yml := `/zoo:
+  get:
+    description: zoo
+/aardvark:
+  get:
+    description: aardvark
+/middle:
+  get:
+    description: middle`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3low.Paths
+	_ = low.BuildModel(&idxNode, &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	high := NewPaths(&n)
+	assert.Equal(t, []string{"/zoo", "/aardvark", "/middle"}, high.PathKeys())
+	assert.Equal(t, n.PathKeys(), high.PathKeys())
+
+	high.PathItems.Set("/new", &PathItem{})
+	assert.Equal(t, []string{"/zoo", "/aardvark", "/middle", "/new"}, high.PathKeys())
+
+	assert.Nil(t, (&Paths{}).PathKeys())
+}
+
+func TestPaths_Select(t *testing.T) {
+	yml := `/users:
+  get:
+    description: list users
+  post:
+    description: create user
+/users/{id}:
+  get:
+    description: get user
+  delete:
+    description: delete user
+/users/{id}/pets:
+  get:
+    description: get pets
+/pets:
+  put:
+    description: put pets`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3low.Paths
+	_ = low.BuildModel(&idxNode, &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	paths := NewPaths(&n)
+
+	selected, err := paths.Select("/users/**")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/users", "/users/{id}", "/users/{id}/pets"}, slices.Collect(selected.KeysFromOldest()))
+
+	selected, err = paths.Select("/users/*")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/users/{id}"}, slices.Collect(selected.KeysFromOldest()))
+
+	selected, err = paths.Select("**", "delete", "PUT")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/users/{id}", "/pets"}, slices.Collect(selected.KeysFromOldest()))
+	assert.Same(t, paths.PathItems.GetOrZero("/pets"), selected.GetOrZero("/pets"))
+
+	selected, err = paths.Select("regex:pets$")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/users/{id}/pets", "/pets"}, slices.Collect(selected.KeysFromOldest()))
+
+	ops, err := paths.SelectOperations("/users/**", "get")
+	assert.NoError(t, err)
+	assert.Len(t, ops, 3)
+	assert.Equal(t, "/users", ops[0].Path)
+	assert.Equal(t, "get", ops[0].Method)
+	assert.Equal(t, "list users", ops[0].Operation.Description)
+	assert.Same(t, paths.PathItems.GetOrZero("/users"), ops[0].PathItem)
+	assert.Equal(t, "get pets", ops[2].Operation.Description)
+
+	ops, err = paths.SelectOperations("/users/{id}")
+	assert.NoError(t, err)
+	assert.Len(t, ops, 2)
+	assert.Equal(t, "delete", ops[1].Method)
+
+	_, err = paths.Select("regex:(")
+	assert.Error(t, err)
+	_, err = paths.SelectOperations("regex:(")
+	assert.Error(t, err)
+
+	empty, err := (&Paths{}).Select("**")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, empty.Len())
+}
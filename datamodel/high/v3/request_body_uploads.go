@@ -0,0 +1,213 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"fmt"
+	"mime"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// ContentLengthHeader is the header an Encoding can declare to limit the size of a part.
+const ContentLengthHeader = "Content-Length"
+
+// BinaryUpload is a file uploaded by a RequestBody, either the whole body, or a property of a `multipart/form-data`
+// body. The size and content types allowed are collected from the schema of the file, and the Encoding of the
+// property.
+type BinaryUpload struct {
+	// MediaType is the key of the content of the RequestBody the file is uploaded with.
+	MediaType string
+
+	// Property is the name of the property of a multipart body that holds the file, empty when the file is
+	// the whole body.
+	Property string
+
+	// Array is true when the property holds an array of files.
+	Array bool
+
+	// ContentTypes are the media types (or ranges, such as `image/*`) the file can be. They come from the
+	// contentType of the Encoding of the property (which can be a comma separated list), or the contentMediaType of
+	// its schema, or the media type of the body when the file is the whole body. Empty means any type is allowed.
+	ContentTypes []string
+
+	// MinSize and MaxSize are the limits of the size of the file in bytes, taken from the minLength and maxLength of
+	// its schema, or the minimum and maximum of a Content-Length header declared by the Encoding of the property.
+	MinSize *int64
+	MaxSize *int64
+
+	// Schema is the schema of the file, it can be nil when the whole body is a file.
+	Schema *base.Schema
+}
+
+// IsBinarySchema returns true if schema describes binary content, either with a `format` of `binary`
+// (OpenAPI 3.0), or with a contentMediaType and no contentEncoding (OpenAPI 3.1).
+func IsBinarySchema(schema *base.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	if len(schema.Type) > 0 && !slices.Contains(schema.Type, "string") {
+		return false
+	}
+	return schema.Format == "binary" || (schema.ContentMediaType != "" && schema.ContentEncoding == "")
+}
+
+// IsBinaryMediaType returns true if mediaType is always binary content, such as `application/octet-stream`,
+// images, audio, video, fonts and `multipart/byteranges`. Parameters are ignored.
+func IsBinaryMediaType(mediaType string) bool {
+	mt, _, err := mime.ParseMediaType(strings.TrimSpace(mediaType))
+	if err != nil {
+		return false
+	}
+	switch mt {
+	case "application/octet-stream", "application/pdf", "application/zip", "multipart/byteranges":
+		return true
+	}
+	typ, _, _ := strings.Cut(mt, "/")
+	switch typ {
+	case "image", "audio", "video", "font":
+		return true
+	}
+	return false
+}
+
+// BinaryUploads returns every file uploaded by the RequestBody, in the order of its content (see
+// MediaType.BinaryUploads).
+func (r *RequestBody) BinaryUploads() []*BinaryUpload {
+	var uploads []*BinaryUpload
+	for key, mt := range r.Content.FromOldest() {
+		if mt != nil {
+			uploads = append(uploads, mt.BinaryUploads(key)...)
+		}
+	}
+	return uploads
+}
+
+// BinaryUploads returns the files uploaded with content of mediaType (the key of the MediaType). The content is a
+// file when mediaType is binary (see IsBinaryMediaType) or the schema is (see IsBinarySchema). The binary properties
+// (and arrays of binary items) of `multipart/form-data` and `multipart/mixed` content are files, in the order of
+// the properties.
+func (m *MediaType) BinaryUploads(mediaType string) []*BinaryUpload {
+	var schema *base.Schema
+	if m.Schema != nil {
+		schema = m.Schema.Schema()
+	}
+	mt, _, _ := mime.ParseMediaType(mediaType)
+	if strings.HasPrefix(mt, "multipart/") && mt != "multipart/byteranges" {
+		if schema == nil {
+			return nil
+		}
+		return multipartUploads(mediaType, m, schema)
+	}
+	if !IsBinaryMediaType(mediaType) && !IsBinarySchema(schema) {
+		return nil
+	}
+	upload := &BinaryUpload{MediaType: mediaType, Schema: schema}
+	if IsBinaryMediaType(mediaType) && mt != "application/octet-stream" {
+		upload.ContentTypes = []string{mediaType}
+	} else if schema != nil && schema.ContentMediaType != "" {
+		upload.ContentTypes = []string{schema.ContentMediaType}
+	}
+	if schema != nil {
+		upload.MinSize, upload.MaxSize = schema.MinLength, schema.MaxLength
+	}
+	return []*BinaryUpload{upload}
+}
+
+func multipartUploads(key string, mt *MediaType, schema *base.Schema) []*BinaryUpload {
+	var uploads []*BinaryUpload
+	for name, sp := range schema.Properties.FromOldest() {
+		if sp == nil {
+			continue
+		}
+		prop := sp.Schema()
+		upload := &BinaryUpload{MediaType: key, Property: name, Schema: prop}
+		if !IsBinarySchema(prop) {
+			if prop == nil || prop.Items == nil || !prop.Items.IsA() || !IsBinarySchema(prop.Items.A.Schema()) {
+				continue
+			}
+			upload.Array = true
+			upload.Schema = prop.Items.A.Schema()
+		}
+		upload.MinSize, upload.MaxSize = upload.Schema.MinLength, upload.Schema.MaxLength
+		if upload.Schema.ContentMediaType != "" {
+			upload.ContentTypes = []string{upload.Schema.ContentMediaType}
+		}
+		if enc := mt.Encoding.GetOrZero(name); enc != nil {
+			if enc.ContentType != "" {
+				upload.ContentTypes = nil
+				for _, ct := range strings.Split(enc.ContentType, ",") {
+					if ct = strings.TrimSpace(ct); ct != "" {
+						upload.ContentTypes = append(upload.ContentTypes, ct)
+					}
+				}
+			}
+			for header, h := range enc.Headers.FromOldest() {
+				if !strings.EqualFold(header, ContentLengthHeader) || h == nil || h.Schema == nil {
+					continue
+				}
+				if hs := h.Schema.Schema(); hs != nil {
+					if hs.Minimum != nil {
+						size := int64(*hs.Minimum)
+						upload.MinSize = &size
+					}
+					if hs.Maximum != nil {
+						size := int64(*hs.Maximum)
+						upload.MaxSize = &size
+					}
+				}
+			}
+		}
+		uploads = append(uploads, upload)
+	}
+	return uploads
+}
+
+// Validate checks a file with the supplied content type and size (in bytes) against the constraints of the
+// upload, and returns an error for every constraint it breaks. Content types are matched ignoring parameters and
+// case, and ranges (such as `image/*` or `*/*`) match any type they cover.
+func (u *BinaryUpload) Validate(contentType string, size int64) []error {
+	var errs []error
+	name := "the request body"
+	if u.Property != "" {
+		name = fmt.Sprintf("file '%s'", u.Property)
+	}
+	if len(u.ContentTypes) > 0 {
+		matched := false
+		if requested, ok := parseMediaRange(contentType); ok {
+			for _, ct := range u.ContentTypes {
+				if defined, valid := parseMediaRange(ct); valid && uploadTypeMatches(requested, defined) {
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			errs = append(errs, fmt.Errorf("%s has the content type '%s', which is not one of: %s",
+				name, contentType, strings.Join(u.ContentTypes, ", ")))
+		}
+	}
+	if u.MinSize != nil && size < *u.MinSize {
+		errs = append(errs, fmt.Errorf("%s is %d bytes, it must be at least %d bytes",
+			name, size, *u.MinSize))
+	}
+	if u.MaxSize != nil && size > *u.MaxSize {
+		errs = append(errs, fmt.Errorf("%s is %d bytes, it must be at most %d bytes",
+			name, size, *u.MaxSize))
+	}
+	return errs
+}
+
+// uploadTypeMatches returns true if the content type of a file is covered by a content type that is allowed.
+func uploadTypeMatches(file, allowed mediaRange) bool {
+	switch {
+	case allowed.typ == "*" && allowed.subtype == "*":
+		return true
+	case allowed.typ != file.typ:
+		return false
+	}
+	return allowed.subtype == "*" || allowed.subtype == file.subtype
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var uploadsSpec = `openapi: 3.1.0
+paths:
+  /upload:
+    post:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                avatar:
+                  type: string
+                  format: binary
+                  maxLength: 1024
+                scans:
+                  type: array
+                  items:
+                    type: string
+                    contentMediaType: application/pdf
+                encoded:
+                  type: string
+                  contentMediaType: image/png
+                  contentEncoding: base64
+            encoding:
+              avatar:
+                contentType: image/png, image/jpeg
+                headers:
+                  Content-Length:
+                    schema:
+                      type: integer
+                      minimum: 10
+                      maximum: 2048
+          application/octet-stream:
+            schema:
+              type: string
+              format: binary
+          image/*: {}
+          application/json:
+            schema:
+              type: object`
+
+func buildUploadsRequestBody(t *testing.T) *RequestBody {
+	doc := buildTestDocument(t, uploadsSpec)
+	return doc.Paths.PathItems.GetOrZero("/upload").Post.RequestBody
+}
+
+func TestIsBinarySchema(t *testing.T) {
+	assert.True(t, IsBinarySchema(&base.Schema{Type: []string{"string"}, Format: "binary"}))
+	assert.True(t, IsBinarySchema(&base.Schema{ContentMediaType: "image/png"}))
+	assert.False(t, IsBinarySchema(&base.Schema{ContentMediaType: "image/png", ContentEncoding: "base64"}))
+	assert.False(t, IsBinarySchema(&base.Schema{Type: []string{"integer"}, Format: "binary"}))
+	assert.False(t, IsBinarySchema(&base.Schema{Type: []string{"string"}}))
+	assert.False(t, IsBinarySchema(nil))
+}
+
+func TestIsBinaryMediaType(t *testing.T) {
+	assert.True(t, IsBinaryMediaType("application/octet-stream"))
+	assert.True(t, IsBinaryMediaType("image/png"))
+	assert.True(t, IsBinaryMediaType("multipart/byteranges; boundary=abc"))
+	assert.True(t, IsBinaryMediaType("Video/MP4"))
+	assert.False(t, IsBinaryMediaType("application/json"))
+	assert.False(t, IsBinaryMediaType("multipart/form-data"))
+	assert.False(t, IsBinaryMediaType("not a media type"))
+}
+
+func TestRequestBody_BinaryUploads(t *testing.T) {
+	uploads := buildUploadsRequestBody(t).BinaryUploads()
+	require.Len(t, uploads, 4)
+
+	avatar := uploads[0]
+	assert.Equal(t, "multipart/form-data", avatar.MediaType)
+	assert.Equal(t, "avatar", avatar.Property)
+	assert.False(t, avatar.Array)
+	assert.Equal(t, []string{"image/png", "image/jpeg"}, avatar.ContentTypes)
+	// the Content-Length header of the encoding overrides the maxLength of the schema.
+	require.NotNil(t, avatar.MinSize)
+	require.NotNil(t, avatar.MaxSize)
+	assert.Equal(t, int64(10), *avatar.MinSize)
+	assert.Equal(t, int64(2048), *avatar.MaxSize)
+
+	scans := uploads[1]
+	assert.Equal(t, "scans", scans.Property)
+	assert.True(t, scans.Array)
+	assert.Equal(t, []string{"application/pdf"}, scans.ContentTypes)
+	assert.Nil(t, scans.MaxSize)
+
+	body := uploads[2]
+	assert.Equal(t, "application/octet-stream", body.MediaType)
+	assert.Empty(t, body.Property)
+	assert.Empty(t, body.ContentTypes)
+	assert.NotNil(t, body.Schema)
+
+	image := uploads[3]
+	assert.Equal(t, "image/*", image.MediaType)
+	assert.Equal(t, []string{"image/*"}, image.ContentTypes)
+	assert.Nil(t, image.Schema)
+}
+
+func TestBinaryUpload_Validate(t *testing.T) {
+	uploads := buildUploadsRequestBody(t).BinaryUploads()
+	avatar, image := uploads[0], uploads[3]
+
+	assert.Empty(t, avatar.Validate("image/png", 100))
+	assert.Empty(t, avatar.Validate("IMAGE/JPEG; q=1", 2048))
+	assert.Empty(t, image.Validate("image/webp", 5))
+
+	errs := avatar.Validate("image/gif", 4096)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "file 'avatar' has the content type 'image/gif', which is not one of: image/png, image/jpeg",
+		errs[0].Error())
+	assert.Equal(t, "file 'avatar' is 4096 bytes, it must be at most 2048 bytes", errs[1].Error())
+
+	errs = avatar.Validate("image/png", 1)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "file 'avatar' is 1 bytes, it must be at least 10 bytes", errs[0].Error())
+
+	errs = image.Validate("not a media type", 1)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "the request body has the content type 'not a media type', which is not one of: image/*",
+		errs[0].Error())
+
+	// any file is allowed when there are no constraints.
+	assert.Empty(t, uploads[2].Validate("text/plain", 1<<20))
+}
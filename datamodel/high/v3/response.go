@@ -4,6 +4,8 @@
 package v3
 
 import (
+	"strings"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -43,6 +45,20 @@ func NewResponse(response *lowv3.Response) *Response {
 	return r
 }
 
+// FindHeader will locate a Header by name, header names are matched case-insensitively. Returns nil if the
+// header does not exist.
+func (r *Response) FindHeader(name string) *Header {
+	if r == nil {
+		return nil
+	}
+	for k, h := range r.Headers.FromOldest() {
+		if strings.EqualFold(k, name) {
+			return h
+		}
+	}
+	return nil
+}
+
 // GoLow returns the low-level Response object that was used to create the high-level one.
 func (r *Response) GoLow() *lowv3.Response {
 	return r.low
@@ -68,6 +68,12 @@ func (r *Responses) FindResponseByCode(code int) *Response {
 	return r.Codes.GetOrZero(fmt.Sprintf("%d", code))
 }
 
+// FindHeader is a shortcut for locating a Header (case-insensitively) in the Response for an HTTP response code.
+// Returns nil if the response or the header does not exist.
+func (r *Responses) FindHeader(code int, name string) *Header {
+	return r.FindResponseByCode(code).FindHeader(name)
+}
+
 // GoLow returns the low-level Response object used to create the high-level one.
 func (r *Responses) GoLow() *low.Responses {
 	return r.low
@@ -98,21 +104,24 @@ func (r *Responses) MarshalYAML() (interface{}, error) {
 		line  int
 		ext   *yaml.Node
 		style yaml.Style
+		key   *yaml.Node
 	}
 	var mapped []*responseItem
 
 	for code, resp := range r.Codes.FromOldest() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		var style yaml.Style
+		var key *yaml.Node
 		if r.low != nil {
 			for lk := range r.low.Codes.KeysFromOldest() {
 				if lk.Value == code {
 					ln = lk.KeyNode.Line
 					style = lk.KeyNode.Style
+					key = lk.KeyNode
 				}
 			}
 		}
-		mapped = append(mapped, &responseItem{resp, code, ln, nil, style})
+		mapped = append(mapped, &responseItem{resp, code, ln, nil, style, key})
 	}
 
 	// extract extensions
@@ -127,7 +136,7 @@ func (r *Responses) MarshalYAML() (interface{}, error) {
 			}
 			mapped = append(mapped, &responseItem{
 				nil, label,
-				extNode.Content[u].Line, extNode.Content[u], 0,
+				extNode.Content[u].Line, extNode.Content[u], 0, extNode.Content[u-1],
 			})
 		}
 	}
@@ -141,12 +150,15 @@ func (r *Responses) MarshalYAML() (interface{}, error) {
 
 			kn := utils.CreateStringNode(mp.code)
 			kn.Style = mp.style
+			lowbase.CopyComments(kn, mp.key)
 
 			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, rendered.(*yaml.Node))
 		}
 		if mp.ext != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mp.code))
+			kn := utils.CreateStringNode(mp.code)
+			lowbase.CopyComments(kn, mp.key)
+			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, mp.ext)
 		}
 
@@ -163,21 +175,24 @@ func (r *Responses) MarshalYAMLInline() (interface{}, error) {
 		line  int
 		ext   *yaml.Node
 		style yaml.Style
+		key   *yaml.Node
 	}
 	var mapped []*responseItem
 
 	for code, resp := range r.Codes.FromOldest() {
 		ln := 9999 // default to a high value to weight new content to the bottom.
 		var style yaml.Style
+		var key *yaml.Node
 		if r.low != nil {
 			for lk := range r.low.Codes.KeysFromOldest() {
 				if lk.Value == code {
 					ln = lk.KeyNode.Line
 					style = lk.KeyNode.Style
+					key = lk.KeyNode
 				}
 			}
 		}
-		mapped = append(mapped, &responseItem{resp, code, ln, nil, style})
+		mapped = append(mapped, &responseItem{resp, code, ln, nil, style, key})
 	}
 
 	// extract extensions
@@ -193,7 +208,7 @@ func (r *Responses) MarshalYAMLInline() (interface{}, error) {
 			}
 			mapped = append(mapped, &responseItem{
 				nil, label,
-				extNode.Content[u].Line, extNode.Content[u], 0,
+				extNode.Content[u].Line, extNode.Content[u], 0, extNode.Content[u-1],
 			})
 		}
 	}
@@ -207,13 +222,16 @@ func (r *Responses) MarshalYAMLInline() (interface{}, error) {
 
 			kn := utils.CreateStringNode(mp.code)
 			kn.Style = mp.style
+			lowbase.CopyComments(kn, mp.key)
 
 			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, rendered.(*yaml.Node))
 
 		}
 		if mp.ext != nil {
-			m.Content = append(m.Content, utils.CreateStringNode(mp.code))
+			kn := utils.CreateStringNode(mp.code)
+			lowbase.CopyComments(kn, mp.key)
+			m.Content = append(m.Content, kn)
 			m.Content = append(m.Content, mp.ext)
 		}
 
@@ -99,3 +99,32 @@ func TestResponses_MarshalYAMLInline(t *testing.T) {
 	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
 
 }
+
+func TestResponses_FindHeader(t *testing.T) {
+	yml := `"200":
+  description: OK
+  headers:
+    X-Rate-Limit:
+      description: calls per hour
+      schema:
+        type: integer`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3.Responses
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	r := NewResponses(&n)
+
+	h := r.FindHeader(200, "x-rate-limit")
+	assert.NotNil(t, h)
+	assert.Equal(t, "calls per hour", h.Description)
+	assert.Equal(t, h, r.FindResponseByCode(200).FindHeader("X-RATE-LIMIT"))
+	assert.Nil(t, r.FindHeader(200, "x-nope"))
+	assert.Nil(t, r.FindHeader(404, "x-rate-limit"))
+	assert.NotNil(t, n.FindHeader("200", "x-rate-limit"))
+	assert.Nil(t, n.FindHeader("404", "x-rate-limit"))
+}
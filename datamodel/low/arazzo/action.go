@@ -0,0 +1,182 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SuccessAction represents a low-level Arazzo Success Action object.
+//
+// A single action to take when a step is successful (end the workflow, or go to another step or workflow), when the
+// criteria are met. An action may instead be a Reusable object referencing an action defined in the components, in
+// which case the ComponentReference is set.
+//   - https://spec.openapis.org/arazzo/latest.html#success-action-object
+type SuccessAction struct {
+	Name               low.NodeReference[string]
+	Type               low.NodeReference[string]
+	WorkflowId         low.NodeReference[string]
+	StepId             low.NodeReference[string]
+	Criteria           low.NodeReference[[]low.ValueReference[*Criterion]]
+	ComponentReference low.NodeReference[string]
+	Extensions         *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode            *yaml.Node
+	RootNode           *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the SuccessAction object.
+func (s *SuccessAction) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the SuccessAction object.
+func (s *SuccessAction) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// GetExtensions returns all SuccessAction extensions and satisfies the low.HasExtensions interface.
+func (s *SuccessAction) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.Extensions
+}
+
+// GetUnknownFields returns the keys of the SuccessAction that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (s *SuccessAction) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.UnknownFields
+}
+
+// Build will extract extensions, the criteria and the component reference for the SuccessAction.
+func (s *SuccessAction) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	s.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	s.RootNode = root
+	utils.CheckForMergeNodes(root)
+	s.Reference = new(low.Reference)
+	s.Nodes = low.ExtractNodes(ctx, root)
+	s.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	s.UnknownFields = low.ExtractUnknownFields(root, s, ReferenceLabel)
+	low.ExtractExtensionNodes(ctx, s.Extensions, s.Nodes)
+	s.ComponentReference = extractComponentReference(root)
+
+	criteria, err := extractArray[*Criterion](ctx, CriteriaLabel, root, idx)
+	s.Criteria = criteria
+	return err
+}
+
+// Hash will return a consistent SHA256 Hash of the SuccessAction object.
+func (s *SuccessAction) Hash() [32]byte {
+	return sha256.Sum256([]byte(strings.Join(hashAction(s.Name, s.Type, s.WorkflowId, s.StepId, s.Criteria,
+		s.ComponentReference, s.Extensions), "|")))
+}
+
+// FailureAction represents a low-level Arazzo Failure Action object.
+//
+// A single action to take when a step fails (end the workflow, retry the step, or go to another step or workflow),
+// when the criteria are met. An action may instead be a Reusable object referencing an action defined in the
+// components, in which case the ComponentReference is set.
+//   - https://spec.openapis.org/arazzo/latest.html#failure-action-object
+type FailureAction struct {
+	Name               low.NodeReference[string]
+	Type               low.NodeReference[string]
+	WorkflowId         low.NodeReference[string]
+	StepId             low.NodeReference[string]
+	RetryAfter         low.NodeReference[float64]
+	RetryLimit         low.NodeReference[int64]
+	Criteria           low.NodeReference[[]low.ValueReference[*Criterion]]
+	ComponentReference low.NodeReference[string]
+	Extensions         *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode            *yaml.Node
+	RootNode           *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the FailureAction object.
+func (f *FailureAction) GetRootNode() *yaml.Node {
+	return f.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the FailureAction object.
+func (f *FailureAction) GetKeyNode() *yaml.Node {
+	return f.KeyNode
+}
+
+// GetExtensions returns all FailureAction extensions and satisfies the low.HasExtensions interface.
+func (f *FailureAction) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return f.Extensions
+}
+
+// GetUnknownFields returns the keys of the FailureAction that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (f *FailureAction) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return f.UnknownFields
+}
+
+// Build will extract extensions, the criteria and the component reference for the FailureAction.
+func (f *FailureAction) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	f.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	f.RootNode = root
+	utils.CheckForMergeNodes(root)
+	f.Reference = new(low.Reference)
+	f.Nodes = low.ExtractNodes(ctx, root)
+	f.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	f.UnknownFields = low.ExtractUnknownFields(root, f, ReferenceLabel)
+	low.ExtractExtensionNodes(ctx, f.Extensions, f.Nodes)
+	f.ComponentReference = extractComponentReference(root)
+
+	criteria, err := extractArray[*Criterion](ctx, CriteriaLabel, root, idx)
+	f.Criteria = criteria
+	return err
+}
+
+// Hash will return a consistent SHA256 Hash of the FailureAction object.
+func (f *FailureAction) Hash() [32]byte {
+	h := hashAction(f.Name, f.Type, f.WorkflowId, f.StepId, f.Criteria, f.ComponentReference, f.Extensions)
+	if !f.RetryAfter.IsEmpty() {
+		h = append(h, fmt.Sprintf("%s-%v", RetryAfterLabel, f.RetryAfter.Value))
+	}
+	if !f.RetryLimit.IsEmpty() {
+		h = append(h, fmt.Sprintf("%s-%d", RetryLimitLabel, f.RetryLimit.Value))
+	}
+	return sha256.Sum256([]byte(strings.Join(h, "|")))
+}
+
+// hashAction returns the hash strings for the properties shared by success and failure actions.
+func hashAction(name, typ, workflowId, stepId low.NodeReference[string],
+	criteria low.NodeReference[[]low.ValueReference[*Criterion]], ref low.NodeReference[string],
+	ext *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]],
+) []string {
+	var f []string
+	if !name.IsEmpty() {
+		f = append(f, name.Value)
+	}
+	if !typ.IsEmpty() {
+		f = append(f, typ.Value)
+	}
+	if !workflowId.IsEmpty() {
+		f = append(f, WorkflowIdLabel+"-"+workflowId.Value)
+	}
+	if !stepId.IsEmpty() {
+		f = append(f, StepIdLabel+"-"+stepId.Value)
+	}
+	f = append(f, hashArray(CriteriaLabel, criteria.Value)...)
+	if !ref.IsEmpty() {
+		f = append(f, ReferenceLabel+"-"+ref.Value)
+	}
+	return append(f, low.HashExtensions(ext)...)
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package arazzo contains the low-level models for the Arazzo specification, which describes workflows: sequences of
+// API calls made against the operations of one or more OpenAPI documents (source descriptions).
+//   - https://spec.openapis.org/arazzo/latest.html
+package arazzo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// extractArray extracts a sequence of objects found under label into a NodeReference, it's empty if the label
+// is not found.
+func extractArray[T low.Buildable[N], N any](ctx context.Context, label string, root *yaml.Node,
+	idx *index.SpecIndex,
+) (low.NodeReference[[]low.ValueReference[T]], error) {
+	items, ln, vn, err := low.ExtractArray[T](ctx, label, root, idx)
+	if err != nil || ln == nil {
+		return low.NodeReference[[]low.ValueReference[T]]{}, err
+	}
+	return low.NodeReference[[]low.ValueReference[T]]{Value: items, KeyNode: ln, ValueNode: vn}, nil
+}
+
+// hashArray returns a hash string for every object in items, prefixed with the label.
+func hashArray[T any](label string, items []low.ValueReference[T]) []string {
+	var f []string
+	for _, item := range items {
+		f = append(f, fmt.Sprintf("%s-%s", label, low.GenerateHashString(item.Value)))
+	}
+	return f
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Components represents a low-level Arazzo Components object.
+//
+// Holds a set of reusable objects (inputs, parameters and actions) that can be referenced from the workflows of
+// the document, using expressions such as `$components.parameters.page`.
+//   - https://spec.openapis.org/arazzo/latest.html#components-object
+type Components struct {
+	Inputs         low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.SchemaProxy]]]
+	Parameters     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Parameter]]]
+	SuccessActions low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*SuccessAction]]]
+	FailureActions low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*FailureAction]]]
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the Components object.
+func (c *Components) GetRootNode() *yaml.Node {
+	return c.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Components object.
+func (c *Components) GetKeyNode() *yaml.Node {
+	return c.KeyNode
+}
+
+// GetExtensions returns all Components extensions and satisfies the low.HasExtensions interface.
+func (c *Components) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.Extensions
+}
+
+// GetUnknownFields returns the keys of the Components that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (c *Components) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.UnknownFields
+}
+
+// Build will extract extensions, inputs, parameters and actions for the Components.
+func (c *Components) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	c.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	c.RootNode = root
+	utils.CheckForMergeNodes(root)
+	c.Reference = new(low.Reference)
+	c.Nodes = low.ExtractNodes(ctx, root)
+	c.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	c.UnknownFields = low.ExtractUnknownFields(root, c)
+	low.ExtractExtensionNodes(ctx, c.Extensions, c.Nodes)
+
+	var err error
+	if c.Inputs, err = extractComponentMap[*base.SchemaProxy](ctx, InputsLabel, root, idx); err != nil {
+		return err
+	}
+	if c.Parameters, err = extractComponentMap[*Parameter](ctx, ParametersLabel, root, idx); err != nil {
+		return err
+	}
+	if c.SuccessActions, err = extractComponentMap[*SuccessAction](ctx, SuccessActionsLabel, root, idx); err != nil {
+		return err
+	}
+	c.FailureActions, err = extractComponentMap[*FailureAction](ctx, FailureActionsLabel, root, idx)
+	return err
+}
+
+// Hash will return a consistent SHA256 Hash of the Components object.
+func (c *Components) Hash() [32]byte {
+	var f []string
+	f = low.AppendMapHashes(f, c.Inputs.Value)
+	f = low.AppendMapHashes(f, c.Parameters.Value)
+	f = low.AppendMapHashes(f, c.SuccessActions.Value)
+	f = low.AppendMapHashes(f, c.FailureActions.Value)
+	f = append(f, low.HashExtensions(c.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
+
+// extractComponentMap extracts a map of objects found under label into a NodeReference, it's empty if the label is
+// not found.
+func extractComponentMap[T low.Buildable[N], N any](ctx context.Context, label string, root *yaml.Node,
+	idx *index.SpecIndex,
+) (low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[T]]], error) {
+	items, ln, vn, err := low.ExtractMap[T](ctx, label, root, idx)
+	if err != nil || ln == nil {
+		return low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[T]]]{}, err
+	}
+	return low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[T]]]{
+		Value: items, KeyNode: ln, ValueNode: vn,
+	}, nil
+}
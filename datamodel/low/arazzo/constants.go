@@ -0,0 +1,45 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+// Constants for labels used to look up values within Arazzo specifications.
+const (
+	ArazzoLabel             = "arazzo"
+	InfoLabel               = "info"
+	SourceDescriptionsLabel = "sourceDescriptions"
+	WorkflowsLabel          = "workflows"
+	ComponentsLabel         = "components"
+	NameLabel               = "name"
+	URLLabel                = "url"
+	TypeLabel               = "type"
+	WorkflowIdLabel         = "workflowId"
+	SummaryLabel            = "summary"
+	DescriptionLabel        = "description"
+	InputsLabel             = "inputs"
+	DependsOnLabel          = "dependsOn"
+	StepsLabel              = "steps"
+	StepIdLabel             = "stepId"
+	OperationIdLabel        = "operationId"
+	OperationPathLabel      = "operationPath"
+	ParametersLabel         = "parameters"
+	RequestBodyLabel        = "requestBody"
+	SuccessCriteriaLabel    = "successCriteria"
+	SuccessActionsLabel     = "successActions"
+	FailureActionsLabel     = "failureActions"
+	OnSuccessLabel          = "onSuccess"
+	OnFailureLabel          = "onFailure"
+	OutputsLabel            = "outputs"
+	InLabel                 = "in"
+	ValueLabel              = "value"
+	ReferenceLabel          = "reference"
+	ConditionLabel          = "condition"
+	ContextLabel            = "context"
+	CriteriaLabel           = "criteria"
+	RetryAfterLabel         = "retryAfter"
+	RetryLimitLabel         = "retryLimit"
+	ContentTypeLabel        = "contentType"
+	PayloadLabel            = "payload"
+	ReplacementsLabel       = "replacements"
+	TargetLabel             = "target"
+)
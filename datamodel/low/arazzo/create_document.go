@@ -0,0 +1,127 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// CreateDocumentFromConfig creates a new Arazzo Document from the provided SpecInfo and DocumentConfiguration.
+//
+// The document is indexed by a rolodex, exactly like an OpenAPI document, so references to local and remote files
+// are followed when the configuration allows them.
+func CreateDocumentFromConfig(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
+	return CreateDocumentFromConfigWithContext(context.Background(), info, config)
+}
+
+// CreateDocumentFromConfigWithContext is the same as CreateDocumentFromConfig, except the supplied context is
+// honored by indexing and every model build.
+func CreateDocumentFromConfigWithContext(ctx context.Context, info *datamodel.SpecInfo,
+	config *datamodel.DocumentConfiguration,
+) (*Document, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if config == nil {
+		config = datamodel.NewDocumentConfiguration()
+	}
+	if info == nil || info.RootNode == nil || len(info.RootNode.Content) == 0 {
+		return nil, errors.New("no arazzo version/tag found, cannot create document")
+	}
+	root := info.RootNode.Content[0]
+	_, labelNode, versionNode := utils.FindKeyNodeFullTop(ArazzoLabel, root.Content)
+	if versionNode == nil {
+		return nil, errors.New("no arazzo version/tag found, cannot create document")
+	}
+	doc := Document{Arazzo: low.NodeReference[string]{Value: versionNode.Value, KeyNode: labelNode, ValueNode: versionNode}}
+	doc.Reference = new(low.Reference)
+	doc.Nodes = low.ExtractNodes(ctx, root)
+
+	// workflow inputs are JSON Schema 2020-12, the dialect used by OpenAPI 3.1, so schemas are built as 3.1 schemas.
+	schemaInfo := *info
+	schemaInfo.VersionNumeric = 3.1
+
+	idxConfig := index.CreateClosedAPIIndexConfig()
+	idxConfig.SpecInfo = &schemaInfo
+	idxConfig.AvoidCircularReferenceCheck = true
+	idxConfig.BaseURL = config.BaseURL
+	idxConfig.BasePath = config.BasePath
+	idxConfig.SpecFilePath = config.SpecFilePath
+	idxConfig.Logger = config.Logger
+	idxConfig.ExtractRefsSequentially = config.ExtractRefsSequentially
+	idxConfig.Limits = config.Limits
+	idxConfig.RemoteFetch = config.RemoteFetch
+
+	rolodex := index.NewRolodex(idxConfig)
+	rolodex.SetRootNode(info.RootNode)
+	doc.Rolodex = rolodex
+
+	if idxConfig.BasePath != "" || config.AllowFileReferences {
+		cwd, _ := filepath.Abs(config.BasePath)
+		if config.LocalFS != nil {
+			if _, ok := config.LocalFS.(index.RolodexFS); ok {
+				rolodex.AddLocalFS(cwd, config.LocalFS)
+			} else {
+				rolodex.AddVirtualFS(cwd, config.LocalFS)
+				idxConfig.AllowFileLookup = true
+			}
+		} else {
+			fileFS, _ := index.NewLocalFSWithConfig(&index.LocalFSConfig{
+				BaseDirectory: cwd,
+				IndexConfig:   idxConfig,
+				FileFilters:   config.FileFilter,
+			})
+			idxConfig.AllowFileLookup = true
+			rolodex.AddLocalFS(cwd, fileFS)
+		}
+	}
+	if idxConfig.BaseURL != nil || config.AllowRemoteReferences {
+		remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
+		if config.RemoteURLHandler != nil {
+			remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
+		}
+		idxConfig.AllowRemoteLookup = true
+		u := "default"
+		if config.BaseURL != nil {
+			u = config.BaseURL.String()
+		}
+		rolodex.AddRemoteFS(u, remoteFS)
+	}
+
+	var errs []error
+	_ = rolodex.IndexTheRolodexWithContext(ctx)
+	errs = append(errs, rolodex.GetCaughtErrors()...)
+	doc.Index = rolodex.GetRootIndex()
+	if err := ctx.Err(); err != nil {
+		return &doc, errors.Join(append(errs, err)...)
+	}
+	idx := doc.Index
+
+	doc.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	low.ExtractExtensionNodes(ctx, doc.Extensions, doc.Nodes)
+	doc.UnknownFields = low.ExtractUnknownFields(root, &doc)
+
+	var err error
+	if doc.Info, err = low.ExtractObject[*base.Info](ctx, InfoLabel, root, idx); err != nil {
+		errs = append(errs, err)
+	}
+	if doc.SourceDescriptions, err = extractArray[*SourceDescription](ctx, SourceDescriptionsLabel, root, idx); err != nil {
+		errs = append(errs, err)
+	}
+	if doc.Workflows, err = extractArray[*Workflow](ctx, WorkflowsLabel, root, idx); err != nil {
+		errs = append(errs, err)
+	}
+	if doc.Components, err = low.ExtractObject[*Components](ctx, ComponentsLabel, root, idx); err != nil {
+		errs = append(errs, err)
+	}
+	return &doc, errors.Join(errs...)
+}
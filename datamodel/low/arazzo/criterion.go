@@ -0,0 +1,85 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Criterion represents a low-level Arazzo Criterion object.
+//
+// A condition that determines the success of a step, or whether an action is taken. The Type is either a string
+// (simple, regex, jsonpath or xpath) or a Criterion Expression Type object (with a type and a version), so it's
+// kept as a node.
+//   - https://spec.openapis.org/arazzo/latest.html#criterion-object
+type Criterion struct {
+	Context       low.NodeReference[string]
+	Condition     low.NodeReference[string]
+	Type          low.NodeReference[*yaml.Node]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the Criterion object.
+func (c *Criterion) GetRootNode() *yaml.Node {
+	return c.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Criterion object.
+func (c *Criterion) GetKeyNode() *yaml.Node {
+	return c.KeyNode
+}
+
+// GetExtensions returns all Criterion extensions and satisfies the low.HasExtensions interface.
+func (c *Criterion) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.Extensions
+}
+
+// GetUnknownFields returns the keys of the Criterion that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (c *Criterion) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.UnknownFields
+}
+
+// Build will extract extensions for the Criterion.
+func (c *Criterion) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	c.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	c.RootNode = root
+	utils.CheckForMergeNodes(root)
+	c.Reference = new(low.Reference)
+	c.Nodes = low.ExtractNodes(ctx, root)
+	c.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	c.UnknownFields = low.ExtractUnknownFields(root, c)
+	low.ExtractExtensionNodes(ctx, c.Extensions, c.Nodes)
+	return nil
+}
+
+// Hash will return a consistent SHA256 Hash of the Criterion object.
+func (c *Criterion) Hash() [32]byte {
+	var f []string
+	if !c.Context.IsEmpty() {
+		f = append(f, c.Context.Value)
+	}
+	if !c.Condition.IsEmpty() {
+		f = append(f, c.Condition.Value)
+	}
+	if !c.Type.IsEmpty() {
+		f = append(f, low.GenerateHashString(c.Type.Value))
+	}
+	f = append(f, low.HashExtensions(c.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
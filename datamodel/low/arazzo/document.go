@@ -0,0 +1,111 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// Document represents a low-level Arazzo document.
+//
+// An Arazzo document describes workflows, sequences of calls made to the operations of the source descriptions
+// (OpenAPI documents, or other Arazzo documents) it lists.
+//   - https://spec.openapis.org/arazzo/latest.html#arazzo-specification-object
+type Document struct {
+	// Arazzo is the version of the Arazzo specification being used, extracted from the 'arazzo: x.x.x' definition.
+	Arazzo low.NodeReference[string]
+
+	// Info provides metadata about the workflows.
+	// - https://spec.openapis.org/arazzo/latest.html#info-object
+	Info low.NodeReference[*base.Info]
+
+	// SourceDescriptions are the OpenAPI (or Arazzo) documents the workflows use.
+	// - https://spec.openapis.org/arazzo/latest.html#source-description-object
+	SourceDescriptions low.NodeReference[[]low.ValueReference[*SourceDescription]]
+
+	// Workflows are all the workflows defined by the document.
+	// - https://spec.openapis.org/arazzo/latest.html#workflow-object
+	Workflows low.NodeReference[[]low.ValueReference[*Workflow]]
+
+	// Components holds the reusable objects of the document.
+	// - https://spec.openapis.org/arazzo/latest.html#components-object
+	Components low.NodeReference[*Components]
+
+	// Extensions contains all custom extensions defined for the top-level document.
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+
+	// Index is a reference to the *index.SpecIndex that was created for the document and used
+	// as a guide when building out the Document. Ideal if further processing is required on the model and
+	// the original details are required to continue the work.
+	//
+	// This property is not a part of the Arazzo schema, this is custom to libopenapi.
+	Index *index.SpecIndex
+
+	// Rolodex is a reference to the rolodex used when creating this document.
+	Rolodex *index.Rolodex
+	*low.Reference
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance for the document.
+func (d *Document) GetIndex() *index.SpecIndex {
+	return d.Index
+}
+
+// GetExtensions returns all Document extensions and satisfies the low.HasExtensions interface.
+func (d *Document) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return d.Extensions
+}
+
+// GetUnknownFields returns the keys of the Document that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (d *Document) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return d.UnknownFields
+}
+
+// FindSourceDescription returns the SourceDescription with the supplied name, or nil if there isn't one.
+func (d *Document) FindSourceDescription(name string) *SourceDescription {
+	for _, s := range d.SourceDescriptions.Value {
+		if s.Value != nil && s.Value.Name.Value == name {
+			return s.Value
+		}
+	}
+	return nil
+}
+
+// FindWorkflow returns the Workflow with the supplied workflowId, or nil if there isn't one.
+func (d *Document) FindWorkflow(workflowId string) *Workflow {
+	for _, w := range d.Workflows.Value {
+		if w.Value != nil && w.Value.WorkflowId.Value == workflowId {
+			return w.Value
+		}
+	}
+	return nil
+}
+
+// Hash will return a consistent SHA256 Hash of the Document object.
+func (d *Document) Hash() [32]byte {
+	var f []string
+	if !d.Arazzo.IsEmpty() {
+		f = append(f, d.Arazzo.Value)
+	}
+	if !d.Info.IsEmpty() {
+		f = append(f, low.GenerateHashString(d.Info.Value))
+	}
+	f = append(f, hashArray(SourceDescriptionsLabel, d.SourceDescriptions.Value)...)
+	f = append(f, hashArray(WorkflowsLabel, d.Workflows.Value)...)
+	if !d.Components.IsEmpty() {
+		f = append(f, low.GenerateHashString(d.Components.Value))
+	}
+	f = append(f, low.HashExtensions(d.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testArazzo = `arazzo: 1.0.0
+info:
+  title: Pet adoption
+  version: 1.0.0
+sourceDescriptions:
+  - name: pets
+    url: ./pets.yaml
+    type: openapi
+workflows:
+  - workflowId: adopt
+    summary: adopt a pet
+    inputs:
+      type: object
+      properties:
+        petId:
+          type: string
+    steps:
+      - stepId: find
+        operationId: findPet
+        parameters:
+          - name: id
+            in: path
+            value: $inputs.petId
+        successCriteria:
+          - condition: $statusCode == 200
+        onFailure:
+          - name: retry
+            type: retry
+            retryAfter: 1.5
+            retryLimit: 3
+        outputs:
+          pet: $response.body
+      - stepId: adopt
+        operationPath: '{$sourceDescriptions.pets.url}#/paths/~1pets~1{id}/post'
+        requestBody:
+          contentType: application/json
+          payload:
+            name: fido
+          replacements:
+            - target: /name
+              value: $steps.find.outputs.pet.name
+    outputs:
+      pet: $steps.find.outputs.pet
+    x-team: pets
+components:
+  parameters:
+    page:
+      name: page
+      in: query
+      value: 1
+`
+
+func createTestDocument(t *testing.T, spec string) *Document {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+	doc, err := CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return doc
+}
+
+func TestCreateDocument(t *testing.T) {
+	doc := createTestDocument(t, testArazzo)
+
+	assert.Equal(t, "1.0.0", doc.Arazzo.Value)
+	assert.Equal(t, "Pet adoption", doc.Info.Value.Title.Value)
+	require.Len(t, doc.SourceDescriptions.Value, 1)
+	assert.Equal(t, "./pets.yaml", doc.FindSourceDescription("pets").URL.Value)
+	assert.Nil(t, doc.FindSourceDescription("cakes"))
+
+	w := doc.FindWorkflow("adopt")
+	require.NotNil(t, w)
+	assert.Nil(t, doc.FindWorkflow("cakes"))
+	assert.Equal(t, "adopt a pet", w.Summary.Value)
+	assert.Equal(t, 1, w.Inputs.Value.Schema().Properties.Value.Len())
+	assert.Equal(t, "pets", w.Extensions.First().Value().Value.Value)
+	assert.Equal(t, 1, w.Outputs.Value.Len())
+
+	find := w.FindStep("find")
+	require.NotNil(t, find)
+	assert.Equal(t, "findPet", find.OperationId.Value)
+	assert.Equal(t, "$inputs.petId", find.Parameters.Value[0].Value.Value.Value.Value)
+	assert.Equal(t, "$statusCode == 200", find.SuccessCriteria.Value[0].Value.Condition.Value)
+	assert.Equal(t, 1.5, find.OnFailure.Value[0].Value.RetryAfter.Value)
+	assert.Equal(t, int64(3), find.OnFailure.Value[0].Value.RetryLimit.Value)
+
+	adopt := w.FindStep("adopt")
+	require.NotNil(t, adopt)
+	assert.Equal(t, "application/json", adopt.RequestBody.Value.ContentType.Value)
+	assert.Equal(t, "/name", adopt.RequestBody.Value.Replacements.Value[0].Value.Target.Value)
+	assert.Nil(t, w.FindStep("cakes"))
+
+	assert.Equal(t, 1, doc.Components.Value.Parameters.Value.Len())
+	assert.Nil(t, doc.GetUnknownFields())
+	assert.Nil(t, w.GetUnknownFields())
+	assert.Nil(t, find.Parameters.Value[0].Value.GetUnknownFields())
+	assert.Nil(t, find.OnFailure.Value[0].Value.GetUnknownFields())
+	assert.Nil(t, adopt.RequestBody.Value.Replacements.Value[0].Value.GetUnknownFields())
+	assert.NotNil(t, doc.Rolodex)
+	assert.NotNil(t, doc.GetIndex())
+}
+
+func TestCreateDocument_NoVersion(t *testing.T) {
+	_, err := CreateDocumentFromConfig(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestDocument_Hash(t *testing.T) {
+	left := createTestDocument(t, testArazzo)
+	right := createTestDocument(t, testArazzo)
+	assert.Equal(t, left.Hash(), right.Hash())
+
+	changed := createTestDocument(t, testArazzo+"x-changed: true\n")
+	assert.NotEqual(t, left.Hash(), changed.Hash())
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Parameter represents a low-level Arazzo Parameter object.
+//
+// Describes a single parameter passed to an operation or workflow. A parameter may instead be a Reusable object,
+// referencing a parameter defined in the components (with an optional value to override it), in which case the
+// ComponentReference is set.
+//   - https://spec.openapis.org/arazzo/latest.html#parameter-object
+//   - https://spec.openapis.org/arazzo/latest.html#reusable-object
+type Parameter struct {
+	Name               low.NodeReference[string]
+	In                 low.NodeReference[string]
+	Value              low.NodeReference[*yaml.Node]
+	ComponentReference low.NodeReference[string]
+	Extensions         *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode            *yaml.Node
+	RootNode           *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the Parameter object.
+func (p *Parameter) GetRootNode() *yaml.Node {
+	return p.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Parameter object.
+func (p *Parameter) GetKeyNode() *yaml.Node {
+	return p.KeyNode
+}
+
+// GetExtensions returns all Parameter extensions and satisfies the low.HasExtensions interface.
+func (p *Parameter) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.Extensions
+}
+
+// GetUnknownFields returns the keys of the Parameter that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (p *Parameter) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.UnknownFields
+}
+
+// Build will extract extensions and the component reference (if the parameter is a Reusable object).
+func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	p.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	p.RootNode = root
+	utils.CheckForMergeNodes(root)
+	p.Reference = new(low.Reference)
+	p.Nodes = low.ExtractNodes(ctx, root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	p.UnknownFields = low.ExtractUnknownFields(root, p, ReferenceLabel)
+	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
+	p.ComponentReference = extractComponentReference(root)
+	return nil
+}
+
+// Hash will return a consistent SHA256 Hash of the Parameter object.
+func (p *Parameter) Hash() [32]byte {
+	var f []string
+	if !p.Name.IsEmpty() {
+		f = append(f, p.Name.Value)
+	}
+	if !p.In.IsEmpty() {
+		f = append(f, p.In.Value)
+	}
+	if !p.Value.IsEmpty() {
+		f = append(f, low.GenerateHashString(p.Value.Value))
+	}
+	if !p.ComponentReference.IsEmpty() {
+		f = append(f, ReferenceLabel+"-"+p.ComponentReference.Value)
+	}
+	f = append(f, low.HashExtensions(p.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
+
+// extractComponentReference extracts the `reference` of a Reusable object, it's empty if there isn't one.
+func extractComponentReference(root *yaml.Node) low.NodeReference[string] {
+	if root == nil {
+		return low.NodeReference[string]{}
+	}
+	_, ln, vn := utils.FindKeyNodeFullTop(ReferenceLabel, root.Content)
+	if vn == nil {
+		return low.NodeReference[string]{}
+	}
+	return low.NodeReference[string]{Value: vn.Value, KeyNode: ln, ValueNode: vn}
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RequestBody represents a low-level Arazzo Request Body object.
+//
+// The request body to pass to an operation, as a payload, with replacements applied to it.
+//   - https://spec.openapis.org/arazzo/latest.html#request-body-object
+type RequestBody struct {
+	ContentType   low.NodeReference[string]
+	Payload       low.NodeReference[*yaml.Node]
+	Replacements  low.NodeReference[[]low.ValueReference[*PayloadReplacement]]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the RequestBody object.
+func (r *RequestBody) GetRootNode() *yaml.Node {
+	return r.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the RequestBody object.
+func (r *RequestBody) GetKeyNode() *yaml.Node {
+	return r.KeyNode
+}
+
+// GetExtensions returns all RequestBody extensions and satisfies the low.HasExtensions interface.
+func (r *RequestBody) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return r.Extensions
+}
+
+// GetUnknownFields returns the keys of the RequestBody that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (r *RequestBody) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return r.UnknownFields
+}
+
+// Build will extract extensions and replacements for the RequestBody.
+func (r *RequestBody) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	r.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	r.RootNode = root
+	utils.CheckForMergeNodes(root)
+	r.Reference = new(low.Reference)
+	r.Nodes = low.ExtractNodes(ctx, root)
+	r.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	r.UnknownFields = low.ExtractUnknownFields(root, r)
+	low.ExtractExtensionNodes(ctx, r.Extensions, r.Nodes)
+
+	replacements, err := extractArray[*PayloadReplacement](ctx, ReplacementsLabel, root, idx)
+	r.Replacements = replacements
+	return err
+}
+
+// Hash will return a consistent SHA256 Hash of the RequestBody object.
+func (r *RequestBody) Hash() [32]byte {
+	var f []string
+	if !r.ContentType.IsEmpty() {
+		f = append(f, r.ContentType.Value)
+	}
+	if !r.Payload.IsEmpty() {
+		f = append(f, low.GenerateHashString(r.Payload.Value))
+	}
+	f = append(f, hashArray(ReplacementsLabel, r.Replacements.Value)...)
+	f = append(f, low.HashExtensions(r.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
+
+// PayloadReplacement represents a low-level Arazzo Payload Replacement object.
+//
+// Describes a location within a payload (a JSON Pointer or XPath target) and the value to set there.
+//   - https://spec.openapis.org/arazzo/latest.html#payload-replacement-object
+type PayloadReplacement struct {
+	Target        low.NodeReference[string]
+	Value         low.NodeReference[*yaml.Node]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the PayloadReplacement object.
+func (p *PayloadReplacement) GetRootNode() *yaml.Node {
+	return p.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the PayloadReplacement object.
+func (p *PayloadReplacement) GetKeyNode() *yaml.Node {
+	return p.KeyNode
+}
+
+// GetExtensions returns all PayloadReplacement extensions and satisfies the low.HasExtensions interface.
+func (p *PayloadReplacement) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.Extensions
+}
+
+// GetUnknownFields returns the keys of the PayloadReplacement that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (p *PayloadReplacement) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.UnknownFields
+}
+
+// Build will extract extensions for the PayloadReplacement.
+func (p *PayloadReplacement) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	p.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	p.RootNode = root
+	utils.CheckForMergeNodes(root)
+	p.Reference = new(low.Reference)
+	p.Nodes = low.ExtractNodes(ctx, root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	p.UnknownFields = low.ExtractUnknownFields(root, p)
+	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
+	return nil
+}
+
+// Hash will return a consistent SHA256 Hash of the PayloadReplacement object.
+func (p *PayloadReplacement) Hash() [32]byte {
+	var f []string
+	if !p.Target.IsEmpty() {
+		f = append(f, p.Target.Value)
+	}
+	if !p.Value.IsEmpty() {
+		f = append(f, low.GenerateHashString(p.Value.Value))
+	}
+	f = append(f, low.HashExtensions(p.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SourceDescription represents a low-level Arazzo Source Description object.
+//
+// Describes a source description (an OpenAPI document or another Arazzo document) that the workflows of the
+// document use.
+//   - https://spec.openapis.org/arazzo/latest.html#source-description-object
+type SourceDescription struct {
+	Name          low.NodeReference[string]
+	URL           low.NodeReference[string]
+	Type          low.NodeReference[string]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the SourceDescription object.
+func (s *SourceDescription) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the SourceDescription object.
+func (s *SourceDescription) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// GetExtensions returns all SourceDescription extensions and satisfies the low.HasExtensions interface.
+func (s *SourceDescription) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.Extensions
+}
+
+// GetUnknownFields returns the keys of the SourceDescription that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (s *SourceDescription) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.UnknownFields
+}
+
+// Build will extract extensions for the SourceDescription.
+func (s *SourceDescription) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	s.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	s.RootNode = root
+	utils.CheckForMergeNodes(root)
+	s.Reference = new(low.Reference)
+	s.Nodes = low.ExtractNodes(ctx, root)
+	s.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	s.UnknownFields = low.ExtractUnknownFields(root, s)
+	low.ExtractExtensionNodes(ctx, s.Extensions, s.Nodes)
+	return nil
+}
+
+// Hash will return a consistent SHA256 Hash of the SourceDescription object.
+func (s *SourceDescription) Hash() [32]byte {
+	var f []string
+	if !s.Name.IsEmpty() {
+		f = append(f, s.Name.Value)
+	}
+	if !s.URL.IsEmpty() {
+		f = append(f, s.URL.Value)
+	}
+	if !s.Type.IsEmpty() {
+		f = append(f, s.Type.Value)
+	}
+	f = append(f, low.HashExtensions(s.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
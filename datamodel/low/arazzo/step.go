@@ -0,0 +1,120 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Step represents a low-level Arazzo Step object.
+//
+// A single step of a workflow, which calls an operation (by operationId or operationPath) of a source description,
+// or runs another workflow (by workflowId).
+//   - https://spec.openapis.org/arazzo/latest.html#step-object
+type Step struct {
+	StepId          low.NodeReference[string]
+	Description     low.NodeReference[string]
+	OperationId     low.NodeReference[string]
+	OperationPath   low.NodeReference[string]
+	WorkflowId      low.NodeReference[string]
+	Parameters      low.NodeReference[[]low.ValueReference[*Parameter]]
+	RequestBody     low.NodeReference[*RequestBody]
+	SuccessCriteria low.NodeReference[[]low.ValueReference[*Criterion]]
+	OnSuccess       low.NodeReference[[]low.ValueReference[*SuccessAction]]
+	OnFailure       low.NodeReference[[]low.ValueReference[*FailureAction]]
+	Outputs         low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	Extensions      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode         *yaml.Node
+	RootNode        *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the Step object.
+func (s *Step) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Step object.
+func (s *Step) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// GetExtensions returns all Step extensions and satisfies the low.HasExtensions interface.
+func (s *Step) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.Extensions
+}
+
+// GetUnknownFields returns the keys of the Step that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (s *Step) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.UnknownFields
+}
+
+// Build will extract extensions, parameters, the request body, success criteria and actions for the Step.
+func (s *Step) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	s.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	s.RootNode = root
+	utils.CheckForMergeNodes(root)
+	s.Reference = new(low.Reference)
+	s.Nodes = low.ExtractNodes(ctx, root)
+	s.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	s.UnknownFields = low.ExtractUnknownFields(root, s)
+	low.ExtractExtensionNodes(ctx, s.Extensions, s.Nodes)
+
+	var err error
+	if s.Parameters, err = extractArray[*Parameter](ctx, ParametersLabel, root, idx); err != nil {
+		return err
+	}
+	if s.RequestBody, err = low.ExtractObject[*RequestBody](ctx, RequestBodyLabel, root, idx); err != nil {
+		return err
+	}
+	if s.SuccessCriteria, err = extractArray[*Criterion](ctx, SuccessCriteriaLabel, root, idx); err != nil {
+		return err
+	}
+	if s.OnSuccess, err = extractArray[*SuccessAction](ctx, OnSuccessLabel, root, idx); err != nil {
+		return err
+	}
+	s.OnFailure, err = extractArray[*FailureAction](ctx, OnFailureLabel, root, idx)
+	return err
+}
+
+// Hash will return a consistent SHA256 Hash of the Step object.
+func (s *Step) Hash() [32]byte {
+	var f []string
+	for _, p := range []struct {
+		label string
+		value low.NodeReference[string]
+	}{
+		{StepIdLabel, s.StepId},
+		{DescriptionLabel, s.Description},
+		{OperationIdLabel, s.OperationId},
+		{OperationPathLabel, s.OperationPath},
+		{WorkflowIdLabel, s.WorkflowId},
+	} {
+		if !p.value.IsEmpty() {
+			f = append(f, p.label+"-"+p.value.Value)
+		}
+	}
+	f = append(f, hashArray(ParametersLabel, s.Parameters.Value)...)
+	if !s.RequestBody.IsEmpty() {
+		f = append(f, low.GenerateHashString(s.RequestBody.Value))
+	}
+	f = append(f, hashArray(SuccessCriteriaLabel, s.SuccessCriteria.Value)...)
+	f = append(f, hashArray(OnSuccessLabel, s.OnSuccess.Value)...)
+	f = append(f, hashArray(OnFailureLabel, s.OnFailure.Value)...)
+	f = low.AppendMapHashes(f, s.Outputs.Value)
+	f = append(f, low.HashExtensions(s.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Workflow represents a low-level Arazzo Workflow object.
+//
+// Describes the steps to take to achieve a goal, the inputs (a JSON Schema) that the workflow needs, and the
+// outputs it produces.
+//   - https://spec.openapis.org/arazzo/latest.html#workflow-object
+type Workflow struct {
+	WorkflowId     low.NodeReference[string]
+	Summary        low.NodeReference[string]
+	Description    low.NodeReference[string]
+	Inputs         low.NodeReference[*base.SchemaProxy]
+	DependsOn      low.NodeReference[[]low.ValueReference[string]]
+	Steps          low.NodeReference[[]low.ValueReference[*Step]]
+	SuccessActions low.NodeReference[[]low.ValueReference[*SuccessAction]]
+	FailureActions low.NodeReference[[]low.ValueReference[*FailureAction]]
+	Outputs        low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	Parameters     low.NodeReference[[]low.ValueReference[*Parameter]]
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// GetRootNode returns the root yaml node of the Workflow object.
+func (w *Workflow) GetRootNode() *yaml.Node {
+	return w.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Workflow object.
+func (w *Workflow) GetKeyNode() *yaml.Node {
+	return w.KeyNode
+}
+
+// GetExtensions returns all Workflow extensions and satisfies the low.HasExtensions interface.
+func (w *Workflow) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return w.Extensions
+}
+
+// GetUnknownFields returns the keys of the Workflow that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (w *Workflow) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return w.UnknownFields
+}
+
+// FindStep returns the Step with the supplied stepId, or nil if there isn't one.
+func (w *Workflow) FindStep(stepId string) *Step {
+	for _, s := range w.Steps.Value {
+		if s.Value != nil && s.Value.StepId.Value == stepId {
+			return s.Value
+		}
+	}
+	return nil
+}
+
+// Build will extract extensions, inputs, steps, actions and parameters for the Workflow.
+func (w *Workflow) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	w.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	w.RootNode = root
+	utils.CheckForMergeNodes(root)
+	w.Reference = new(low.Reference)
+	w.Nodes = low.ExtractNodes(ctx, root)
+	w.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	w.UnknownFields = low.ExtractUnknownFields(root, w)
+	low.ExtractExtensionNodes(ctx, w.Extensions, w.Nodes)
+
+	var err error
+	if w.Inputs, err = low.ExtractObject[*base.SchemaProxy](ctx, InputsLabel, root, idx); err != nil {
+		return err
+	}
+	if w.Steps, err = extractArray[*Step](ctx, StepsLabel, root, idx); err != nil {
+		return err
+	}
+	if w.SuccessActions, err = extractArray[*SuccessAction](ctx, SuccessActionsLabel, root, idx); err != nil {
+		return err
+	}
+	if w.FailureActions, err = extractArray[*FailureAction](ctx, FailureActionsLabel, root, idx); err != nil {
+		return err
+	}
+	w.Parameters, err = extractArray[*Parameter](ctx, ParametersLabel, root, idx)
+	return err
+}
+
+// Hash will return a consistent SHA256 Hash of the Workflow object.
+func (w *Workflow) Hash() [32]byte {
+	var f []string
+	for _, p := range []struct {
+		label string
+		value low.NodeReference[string]
+	}{
+		{WorkflowIdLabel, w.WorkflowId},
+		{SummaryLabel, w.Summary},
+		{DescriptionLabel, w.Description},
+	} {
+		if !p.value.IsEmpty() {
+			f = append(f, p.label+"-"+p.value.Value)
+		}
+	}
+	if !w.Inputs.IsEmpty() {
+		f = append(f, low.GenerateHashString(w.Inputs.Value))
+	}
+	for _, d := range w.DependsOn.Value {
+		f = append(f, DependsOnLabel+"-"+d.Value)
+	}
+	f = append(f, hashArray(StepsLabel, w.Steps.Value)...)
+	f = append(f, hashArray(SuccessActionsLabel, w.SuccessActions.Value)...)
+	f = append(f, hashArray(FailureActionsLabel, w.FailureActions.Value)...)
+	f = low.AppendMapHashes(f, w.Outputs.Value)
+	f = append(f, hashArray(ParametersLabel, w.Parameters.Value)...)
+	f = append(f, low.HashExtensions(w.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
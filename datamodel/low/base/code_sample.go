@@ -0,0 +1,106 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"crypto/sha256"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CodeSample represents a low-level code sample, as used by the `x-codeSamples` (and older `x-code-samples`)
+// extension on operations. Code samples are not part of the specification, but are supported by most documentation
+// tools.
+//
+//	x-codeSamples:
+//	  - lang: Go
+//	    label: libopenapi
+//	    source: |
+//	      doc, err := libopenapi.NewDocument(spec)
+type CodeSample struct {
+	Lang          low.NodeReference[string]
+	Label         low.NodeReference[string]
+	Source        low.NodeReference[string]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
+	*low.Reference
+	low.NodeMap
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (c *CodeSample) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap[*yaml.Node](ext, c.Extensions)
+}
+
+// GetRootNode will return the root yaml node of the CodeSample object
+func (c *CodeSample) GetRootNode() *yaml.Node {
+	return c.RootNode
+}
+
+// GetKeyNode will return the key yaml node of the CodeSample object
+func (c *CodeSample) GetKeyNode() *yaml.Node {
+	return c.KeyNode
+}
+
+// Build will extract extensions from the CodeSample instance.
+func (c *CodeSample) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	c.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	c.RootNode = root
+	utils.CheckForMergeNodes(root)
+	c.Reference = new(low.Reference)
+	c.Nodes = low.ExtractNodes(ctx, root)
+	c.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	c.UnknownFields = low.ExtractUnknownFields(root, c)
+	return nil
+}
+
+// GetExtensions returns all CodeSample extensions and satisfies the low.HasExtensions interface.
+func (c *CodeSample) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.Extensions
+}
+
+// GetUnknownFields returns the keys of the CodeSample that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (c *CodeSample) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.UnknownFields
+}
+
+// Hash will return a consistent SHA256 Hash of the CodeSample object
+func (c *CodeSample) Hash() [32]byte {
+	f := []string{
+		c.Lang.Value,
+		c.Label.Value,
+		c.Source.Value,
+	}
+	f = append(f, low.HashExtensions(c.Extensions)...)
+	return sha256.Sum256([]byte(strings.Join(f, "|")))
+}
+
+// ExtractCodeSamples extracts code samples from the `x-codeSamples` extension of an operation root node, or from
+// `x-code-samples` if there is no `x-codeSamples` extension. Code samples are an extension, so a value that
+// cannot be built as a sequence of code samples is ignored rather than failing the operation, it remains available
+// as a raw extension.
+func ExtractCodeSamples(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) low.NodeReference[[]low.ValueReference[*CodeSample]] {
+	for _, label := range []string{CodeSamplesLabel, CodeSamplesLegacyLabel} {
+		samples, ln, vn, err := low.ExtractArray[*CodeSample](ctx, label, root, idx)
+		if err != nil || ln == nil || vn == nil {
+			continue
+		}
+		return low.NodeReference[[]low.ValueReference[*CodeSample]]{
+			Value:     samples,
+			KeyNode:   ln,
+			ValueNode: vn,
+		}
+	}
+	return low.NodeReference[[]low.ValueReference[*CodeSample]]{}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCodeSample_Build(t *testing.T) {
+	yml := `lang: Go
+label: libopenapi
+source: doc, err := libopenapi.NewDocument(spec)
+x-generated: true`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n CodeSample
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", n.Lang.Value)
+	assert.Equal(t, "libopenapi", n.Label.Value)
+	assert.Equal(t, "doc, err := libopenapi.NewDocument(spec)", n.Source.Value)
+	assert.Equal(t, "true", n.FindExtension("x-generated").Value.Value)
+	assert.Equal(t, 1, n.GetExtensions().Len())
+	assert.NotNil(t, n.GetRootNode())
+	assert.Nil(t, n.GetKeyNode())
+}
+
+func TestCodeSample_Hash(t *testing.T) {
+	build := func(yml string) *CodeSample {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		var n CodeSample
+		_ = low.BuildModel(node.Content[0], &n)
+		_ = n.Build(context.Background(), nil, node.Content[0], nil)
+		return &n
+	}
+	a := build("lang: Go\nsource: fmt.Println()")
+	b := build("source: fmt.Println()\nlang: Go")
+	c := build("lang: Go\nsource: log.Println()")
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.NotEqual(t, a.Hash(), c.Hash())
+}
+
+func TestExtractCodeSamples(t *testing.T) {
+	extract := func(yml string) low.NodeReference[[]low.ValueReference[*CodeSample]] {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		return ExtractCodeSamples(context.Background(), node.Content[0], nil)
+	}
+
+	samples := extract(`x-codeSamples:
+  - lang: Go
+    source: go
+  - lang: Shell
+    label: cURL
+    source: curl`)
+	assert.Equal(t, CodeSamplesLabel, samples.KeyNode.Value)
+	assert.Len(t, samples.Value, 2)
+	assert.Equal(t, "cURL", samples.Value[1].Value.Label.Value)
+
+	// the older key is used when there is no x-codeSamples extension.
+	samples = extract(`x-code-samples:
+  - lang: Go
+    source: go`)
+	assert.Equal(t, CodeSamplesLegacyLabel, samples.KeyNode.Value)
+	assert.Len(t, samples.Value, 1)
+
+	// x-codeSamples wins over x-code-samples.
+	samples = extract(`x-code-samples:
+  - lang: Go
+x-codeSamples:
+  - lang: Rust`)
+	assert.Equal(t, "Rust", samples.Value[0].Value.Lang.Value)
+
+	// not code samples, ignored.
+	assert.True(t, extract(`x-codeSamples: nope`).IsEmpty())
+	assert.True(t, extract(`summary: no samples`).IsEmpty())
+}
@@ -45,6 +45,17 @@ const (
 	SchemaLabel                = "schema"
 	SchemaTypeLabel            = "$schema"
 	AnchorLabel                = "$anchor"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	DynamicRefLabel            = "$dynamicRef"
+	DefsLabel                  = "$defs"
+	CodeSamplesLabel           = "x-codeSamples"
+	CodeSamplesLegacyLabel     = "x-code-samples"
+	LangLabel                  = "lang"
+	LabelLabel                 = "label"
+	SourceLabel                = "source"
+	SummaryLabel               = "summary"
+	ParentLabel                = "parent"
+	KindLabel                  = "kind"
 )
 
 /*
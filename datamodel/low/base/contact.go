@@ -18,22 +18,24 @@ import (
 //	v2 - https://swagger.io/specification/v2/#contactObject
 //	v3 - https://spec.openapis.org/oas/v3.1.0#contact-object
 type Contact struct {
-	Name       low.NodeReference[string]
-	URL        low.NodeReference[string]
-	Email      low.NodeReference[string]
-	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode    *yaml.Node
-	RootNode   *yaml.Node
+	Name          low.NodeReference[string]
+	URL           low.NodeReference[string]
+	Email         low.NodeReference[string]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
 
-func (c *Contact) Build(ctx context.Context, keyNode, root *yaml.Node, _ *index.SpecIndex) error {
+func (c *Contact) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	c.KeyNode = keyNode
 	c.RootNode = root
 	c.Reference = new(low.Reference)
 	c.Nodes = low.ExtractNodes(ctx, root)
-	c.Extensions = low.ExtractExtensions(root)
+	c.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	c.UnknownFields = low.ExtractUnknownFields(root, c)
 	return nil
 }
 
@@ -66,3 +68,9 @@ func (c *Contact) Hash() [32]byte {
 func (c *Contact) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return c.Extensions
 }
+
+// GetUnknownFields returns the keys of the Contact that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (c *Contact) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.UnknownFields
+}
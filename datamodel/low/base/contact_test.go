@@ -42,3 +42,20 @@ x-beer: cold`
 	assert.Equal(t, 1, c.GetExtensions().Len())
 
 }
+
+func TestContact_Fingerprint_Stable(t *testing.T) {
+	yml := `name: buckaroo
+url: https://pb33f.io
+email: buckaroo@pb33f.io`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	var c Contact
+	_ = low.BuildModel(cNode.Content[0], &c)
+
+	// this fingerprint must never change, it guards the stability guarantee of low.HashVersion1.
+	fp, err := low.FingerprintWithVersion(&c, low.HashVersion1)
+	assert.NoError(t, err)
+	assert.Equal(t, "v1:10aea8a6eaa3648e8b1489868dee72fdea23465c766415d3c12dc7f68904da77", fp)
+}
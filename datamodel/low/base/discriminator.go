@@ -23,10 +23,11 @@ import (
 //
 //	v3 - https://spec.openapis.org/oas/v3.1.0#discriminator-object
 type Discriminator struct {
-	PropertyName low.NodeReference[string]
-	Mapping      low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
-	KeyNode      *yaml.Node
-	RootNode     *yaml.Node
+	PropertyName  low.NodeReference[string]
+	Mapping       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	low.Reference
 	low.NodeMap
 }
@@ -41,6 +42,12 @@ func (d *Discriminator) GetKeyNode() *yaml.Node {
 	return d.KeyNode
 }
 
+// GetUnknownFields returns the keys of the Discriminator that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (d *Discriminator) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return d.UnknownFields
+}
+
 // FindMappingValue will return a ValueReference containing the string mapping value
 func (d *Discriminator) FindMappingValue(key string) *low.ValueReference[string] {
 	for k, v := range d.Mapping.Value.FromOldest() {
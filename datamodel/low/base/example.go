@@ -25,6 +25,7 @@ type Example struct {
 	Value         low.NodeReference[*yaml.Node]
 	ExternalValue low.NodeReference[string]
 	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode       *yaml.Node
 	RootNode      *yaml.Node
 	*low.Reference
@@ -68,14 +69,15 @@ func (ex *Example) Hash() [32]byte {
 }
 
 // Build extracts extensions and example value
-func (ex *Example) Build(ctx context.Context, keyNode, root *yaml.Node, _ *index.SpecIndex) error {
+func (ex *Example) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	ex.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	ex.RootNode = root
 	utils.CheckForMergeNodes(root)
 	ex.Reference = new(low.Reference)
 	ex.Nodes = low.ExtractNodes(ctx, root)
-	ex.Extensions = low.ExtractExtensions(root)
+	ex.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	ex.UnknownFields = low.ExtractUnknownFields(root, ex)
 	_, ln, vn := utils.FindKeyNodeFull(ValueLabel, root.Content)
 
 	if vn != nil {
@@ -102,3 +104,9 @@ func (ex *Example) Build(ctx context.Context, keyNode, root *yaml.Node, _ *index
 func (ex *Example) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return ex.Extensions
 }
+
+// GetUnknownFields returns the keys of the Example that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (ex *Example) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return ex.UnknownFields
+}
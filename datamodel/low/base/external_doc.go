@@ -22,11 +22,12 @@ import (
 //	v2 - https://swagger.io/specification/v2/#externalDocumentationObject
 //	v3 - https://spec.openapis.org/oas/v3.1.0#external-documentation-object
 type ExternalDoc struct {
-	Description low.NodeReference[string]
-	URL         low.NodeReference[string]
-	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode     *yaml.Node
-	RootNode    *yaml.Node
+	Description   low.NodeReference[string]
+	URL           low.NodeReference[string]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -54,7 +55,8 @@ func (ex *ExternalDoc) Build(ctx context.Context, keyNode, root *yaml.Node, idx
 	utils.CheckForMergeNodes(root)
 	ex.Reference = new(low.Reference)
 	ex.Nodes = low.ExtractNodes(ctx, root)
-	ex.Extensions = low.ExtractExtensions(root)
+	ex.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	ex.UnknownFields = low.ExtractUnknownFields(root, ex)
 	return nil
 }
 
@@ -63,6 +65,12 @@ func (ex *ExternalDoc) GetExtensions() *orderedmap.Map[low.KeyReference[string],
 	return ex.Extensions
 }
 
+// GetUnknownFields returns the keys of the ExternalDoc that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (ex *ExternalDoc) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return ex.UnknownFields
+}
+
 func (ex *ExternalDoc) Hash() [32]byte {
 	// calculate a hash from every property.
 	f := []string{
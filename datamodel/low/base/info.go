@@ -32,6 +32,7 @@ type Info struct {
 	License        low.NodeReference[*License]
 	Version        low.NodeReference[string]
 	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode        *yaml.Node
 	RootNode       *yaml.Node
 	*low.Reference
@@ -58,6 +59,12 @@ func (i *Info) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.Val
 	return i.Extensions
 }
 
+// GetUnknownFields returns the keys of the Info that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (i *Info) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return i.UnknownFields
+}
+
 // Build will extract out the Contact and Info objects from the supplied root node.
 func (i *Info) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	i.KeyNode = keyNode
@@ -66,7 +73,8 @@ func (i *Info) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.S
 	utils.CheckForMergeNodes(root)
 	i.Reference = new(low.Reference)
 	i.Nodes = low.ExtractNodes(ctx, root)
-	i.Extensions = low.ExtractExtensions(root)
+	i.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	i.UnknownFields = low.ExtractUnknownFields(root, i)
 
 	// extract contact
 	contact, _ := low.ExtractObject[*Contact](ctx, ContactLabel, root, idx)
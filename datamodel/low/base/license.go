@@ -19,12 +19,13 @@ import (
 //	v2 - https://swagger.io/specification/v2/#licenseObject
 //	v3 - https://spec.openapis.org/oas/v3.1.0#license-object
 type License struct {
-	Name       low.NodeReference[string]
-	URL        low.NodeReference[string]
-	Identifier low.NodeReference[string]
-	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode    *yaml.Node
-	RootNode   *yaml.Node
+	Name          low.NodeReference[string]
+	URL           low.NodeReference[string]
+	Identifier    low.NodeReference[string]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -37,7 +38,8 @@ func (l *License) Build(ctx context.Context, keyNode, root *yaml.Node, idx *inde
 	utils.CheckForMergeNodes(root)
 	l.Reference = new(low.Reference)
 	no := low.ExtractNodes(ctx, root)
-	l.Extensions = low.ExtractExtensions(root)
+	l.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	l.UnknownFields = low.ExtractUnknownFields(root, l)
 	l.Nodes = no
 	return nil
 }
@@ -71,3 +73,9 @@ func (l *License) Hash() [32]byte {
 func (l *License) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return l.Extensions
 }
+
+// GetUnknownFields returns the keys of the License that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (l *License) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return l.UnknownFields
+}
@@ -41,11 +41,20 @@ func (s *SchemaDynamicValue[A, B]) IsB() bool {
 
 // Hash will generate a stable hash of the SchemaDynamicValue
 func (s *SchemaDynamicValue[A, B]) Hash() [32]byte {
+	return s.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the SchemaDynamicValue object, computed using the requested low.HashVersion.
+func (s *SchemaDynamicValue[A, B]) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, s.hash)
+}
+
+func (s *SchemaDynamicValue[A, B]) hash(version low.HashVersion) [32]byte {
 	var hash string
 	if s.IsA() {
-		hash = low.GenerateHashString(s.A)
+		hash = low.GenerateHashStringForVersion(s.A, version)
 	} else {
-		hash = low.GenerateHashString(s.B)
+		hash = low.GenerateHashStringForVersion(s.B, version)
 	}
 	return sha256.Sum256([]byte(hash))
 }
@@ -100,9 +109,12 @@ type Schema struct {
 	DependentSchemas      low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*SchemaProxy]]]
 	PatternProperties     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*SchemaProxy]]]
 	PropertyNames         low.NodeReference[*SchemaProxy]
-	UnevaluatedItems      low.NodeReference[*SchemaProxy]
+	UnevaluatedItems      low.NodeReference[*SchemaDynamicValue[*SchemaProxy, bool]]
 	UnevaluatedProperties low.NodeReference[*SchemaDynamicValue[*SchemaProxy, bool]]
 	Anchor                low.NodeReference[string]
+	DynamicAnchor         low.NodeReference[string]
+	DynamicRef            low.NodeReference[string]
+	Defs                  low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*SchemaProxy]]]
 
 	// Compatible with all versions
 	Title                low.NodeReference[string]
@@ -136,6 +148,7 @@ type Schema struct {
 	Example              low.NodeReference[*yaml.Node]
 	Deprecated           low.NodeReference[bool]
 	Extensions           *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields        *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 
 	// Parent Proxy refers back to the low level SchemaProxy that is proxying this schema.
 	ParentProxy *SchemaProxy
@@ -149,7 +162,19 @@ type Schema struct {
 
 // Hash will calculate a SHA256 hash from the values of the schema, This allows equality checking against
 // Schemas defined inside an OpenAPI document. The only way to know if a schema has changed, is to hash it.
+//
+// $defs, $dynamicRef and $dynamicAnchor are part of the hash from low.HashVersion2, use HashForVersion to produce
+// the hash of an earlier version.
 func (s *Schema) Hash() [32]byte {
+	return s.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Schema object, computed using the requested low.HashVersion.
+func (s *Schema) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, s.hash)
+}
+
+func (s *Schema) hash(version low.HashVersion) [32]byte {
 	// calculate a hash from every property in the schema.
 	var d []string
 	if !s.SchemaTypeRef.IsEmpty() {
@@ -195,7 +220,7 @@ func (s *Schema) Hash() [32]byte {
 		d = append(d, fmt.Sprint(s.MinProperties.Value))
 	}
 	if !s.AdditionalProperties.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.AdditionalProperties.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.AdditionalProperties.Value, version))
 	}
 	if !s.Description.IsEmpty() {
 		d = append(d, fmt.Sprint(s.Description.Value))
@@ -207,10 +232,10 @@ func (s *Schema) Hash() [32]byte {
 		d = append(d, fmt.Sprint(s.ContentMediaType.Value))
 	}
 	if !s.Default.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Default.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Default.Value, version))
 	}
 	if !s.Const.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Const.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Const.Value, version))
 	}
 	if !s.Nullable.IsEmpty() {
 		d = append(d, fmt.Sprint(s.Nullable.Value))
@@ -262,15 +287,15 @@ func (s *Schema) Hash() [32]byte {
 	sort.Strings(keys)
 	d = append(d, keys...)
 
-	d = low.AppendMapHashes(d, s.Properties.Value)
+	d = low.AppendMapHashesForVersion(d, s.Properties.Value, version)
 	if s.XML.Value != nil {
-		d = append(d, low.GenerateHashString(s.XML.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.XML.Value, version))
 	}
 	if s.ExternalDocs.Value != nil {
-		d = append(d, low.GenerateHashString(s.ExternalDocs.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.ExternalDocs.Value, version))
 	}
 	if s.Discriminator.Value != nil {
-		d = append(d, low.GenerateHashString(s.Discriminator.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Discriminator.Value, version))
 	}
 
 	// hash polymorphic data
@@ -280,7 +305,7 @@ func (s *Schema) Hash() [32]byte {
 		z := 0
 		for i := range s.OneOf.Value {
 			g := s.OneOf.Value[i].Value
-			r := low.GenerateHashString(g)
+			r := low.GenerateHashStringForVersion(g, version)
 			oneOfEntities[r] = g
 			oneOfKeys[z] = r
 			z++
@@ -288,7 +313,7 @@ func (s *Schema) Hash() [32]byte {
 		}
 		sort.Strings(oneOfKeys)
 		for k := range oneOfKeys {
-			d = append(d, low.GenerateHashString(oneOfEntities[oneOfKeys[k]]))
+			d = append(d, low.GenerateHashStringForVersion(oneOfEntities[oneOfKeys[k]], version))
 		}
 	}
 
@@ -298,7 +323,7 @@ func (s *Schema) Hash() [32]byte {
 		z := 0
 		for i := range s.AllOf.Value {
 			g := s.AllOf.Value[i].Value
-			r := low.GenerateHashString(g)
+			r := low.GenerateHashStringForVersion(g, version)
 			allOfEntities[r] = g
 			allOfKeys[z] = r
 			z++
@@ -306,7 +331,7 @@ func (s *Schema) Hash() [32]byte {
 		}
 		sort.Strings(allOfKeys)
 		for k := range allOfKeys {
-			d = append(d, low.GenerateHashString(allOfEntities[allOfKeys[k]]))
+			d = append(d, low.GenerateHashStringForVersion(allOfEntities[allOfKeys[k]], version))
 		}
 	}
 
@@ -316,7 +341,7 @@ func (s *Schema) Hash() [32]byte {
 		z := 0
 		for i := range s.AnyOf.Value {
 			g := s.AnyOf.Value[i].Value
-			r := low.GenerateHashString(g)
+			r := low.GenerateHashStringForVersion(g, version)
 			anyOfEntities[r] = g
 			anyOfKeys[z] = r
 			z++
@@ -324,46 +349,60 @@ func (s *Schema) Hash() [32]byte {
 		}
 		sort.Strings(anyOfKeys)
 		for k := range anyOfKeys {
-			d = append(d, low.GenerateHashString(anyOfEntities[anyOfKeys[k]]))
+			d = append(d, low.GenerateHashStringForVersion(anyOfEntities[anyOfKeys[k]], version))
 		}
 	}
 
 	if !s.Not.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Not.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Not.Value, version))
 	}
 
 	// check if items is a schema or a bool.
 	if !s.Items.IsEmpty() && s.Items.Value.IsA() {
-		d = append(d, low.GenerateHashString(s.Items.Value.A))
+		d = append(d, low.GenerateHashStringForVersion(s.Items.Value.A, version))
 	}
 	if !s.Items.IsEmpty() && s.Items.Value.IsB() {
 		d = append(d, fmt.Sprint(s.Items.Value.B))
 	}
 	// 3.1 only props
 	if !s.If.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.If.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.If.Value, version))
 	}
 	if !s.Else.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Else.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Else.Value, version))
 	}
 	if !s.Then.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Then.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Then.Value, version))
 	}
 	if !s.PropertyNames.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.PropertyNames.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.PropertyNames.Value, version))
 	}
 	if !s.UnevaluatedProperties.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.UnevaluatedProperties.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.UnevaluatedProperties.Value, version))
 	}
 	if !s.UnevaluatedItems.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.UnevaluatedItems.Value))
+		// hash the schema itself, so the hash is the same as before unevaluatedItems could be a boolean.
+		if s.UnevaluatedItems.Value.IsA() {
+			d = append(d, low.GenerateHashStringForVersion(s.UnevaluatedItems.Value.A, version))
+		} else {
+			d = append(d, low.GenerateHashStringForVersion(s.UnevaluatedItems.Value, version))
+		}
 	}
 	if !s.Anchor.IsEmpty() {
 		d = append(d, fmt.Sprint(s.Anchor.Value))
 	}
+	if version >= low.HashVersion2 {
+		if !s.DynamicAnchor.IsEmpty() {
+			d = append(d, fmt.Sprint(s.DynamicAnchor.Value))
+		}
+		if !s.DynamicRef.IsEmpty() {
+			d = append(d, fmt.Sprint(s.DynamicRef.Value))
+		}
+		d = low.AppendMapHashesForVersion(d, s.Defs.Value, version)
+	}
 
-	d = low.AppendMapHashes(d, orderedmap.SortAlpha(s.DependentSchemas.Value))
-	d = low.AppendMapHashes(d, orderedmap.SortAlpha(s.PatternProperties.Value))
+	d = low.AppendMapHashesForVersion(d, orderedmap.SortAlpha(s.DependentSchemas.Value), version)
+	d = low.AppendMapHashesForVersion(d, orderedmap.SortAlpha(s.PatternProperties.Value), version)
 
 	if len(s.PrefixItems.Value) > 0 {
 		itemsKeys := make([]string, len(s.PrefixItems.Value))
@@ -371,25 +410,25 @@ func (s *Schema) Hash() [32]byte {
 		z := 0
 		for i := range s.PrefixItems.Value {
 			g := s.PrefixItems.Value[i].Value
-			r := low.GenerateHashString(g)
+			r := low.GenerateHashStringForVersion(g, version)
 			itemsEntities[r] = g
 			itemsKeys[z] = r
 			z++
 		}
 		sort.Strings(itemsKeys)
 		for k := range itemsKeys {
-			d = append(d, low.GenerateHashString(itemsEntities[itemsKeys[k]]))
+			d = append(d, low.GenerateHashStringForVersion(itemsEntities[itemsKeys[k]], version))
 		}
 	}
 
 	d = append(d, low.HashExtensions(s.Extensions)...)
 	if s.Example.Value != nil {
-		d = append(d, low.GenerateHashString(s.Example.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Example.Value, version))
 	}
 
 	// contains
 	if !s.Contains.IsEmpty() {
-		d = append(d, low.GenerateHashString(s.Contains.Value))
+		d = append(d, low.GenerateHashStringForVersion(s.Contains.Value, version))
 	}
 	if !s.MinContains.IsEmpty() {
 		d = append(d, fmt.Sprint(s.MinContains.Value))
@@ -399,7 +438,7 @@ func (s *Schema) Hash() [32]byte {
 	}
 	if !s.Examples.IsEmpty() {
 		for _, ex := range s.Examples.Value {
-			d = append(d, low.GenerateHashString(ex.Value))
+			d = append(d, low.GenerateHashStringForVersion(ex.Value, version))
 		}
 	}
 	return sha256.Sum256([]byte(strings.Join(d, "|")))
@@ -417,6 +456,12 @@ func (s *Schema) FindDependentSchema(name string) *low.ValueReference[*SchemaPro
 	return low.FindItemInOrderedMap[*SchemaProxy](name, s.DependentSchemas.Value)
 }
 
+// FindDef will return a ValueReference pointer containing a SchemaProxy pointer
+// from a $defs key name. if found (3.1+ only)
+func (s *Schema) FindDef(name string) *low.ValueReference[*SchemaProxy] {
+	return low.FindItemInOrderedMap[*SchemaProxy](name, s.Defs.Value)
+}
+
 // FindPatternProperty will return a ValueReference pointer containing a SchemaProxy pointer
 // from a pattern property key name. if found (3.1+ only)
 func (s *Schema) FindPatternProperty(name string) *low.ValueReference[*SchemaProxy] {
@@ -428,6 +473,12 @@ func (s *Schema) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.V
 	return s.Extensions
 }
 
+// GetUnknownFields returns the keys of the Schema that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (s *Schema) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.UnknownFields
+}
+
 // GetRootNode will return the root yaml node of the Schema object
 func (s *Schema) GetRootNode() *yaml.Node {
 	return s.RootNode
@@ -457,6 +508,9 @@ func (s *Schema) GetRootNode() *yaml.Node {
 //   - UnevaluatedItems
 //   - UnevaluatedProperties
 //   - Anchor
+//   - DynamicAnchor
+//   - DynamicRef
+//   - Defs
 func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) error {
 	if root == nil {
 		return fmt.Errorf("cannot build schema from a nil node")
@@ -492,7 +546,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		return err
 	}
 
-	s.extractExtensions(root)
+	s.extractExtensions(ctx, root, idx)
 
 	// if the schema has required values, extract the nodes for them.
 	if s.Required.Value != nil {
@@ -540,7 +594,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	if exMinValue != nil {
 		// if there is an index, determine if this a 3.0 or 3.1 schema
 		if idx != nil {
-			if idx.GetConfig().SpecInfo.VersionNumeric == 3.1 {
+			if idx.GetConfig().SpecInfo.VersionNumeric >= 3.1 {
 				val, _ := strconv.ParseFloat(exMinValue.Value, 64)
 				s.ExclusiveMinimum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
 					KeyNode:   exMinLabel,
@@ -583,7 +637,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	if exMaxValue != nil {
 		// if there is an index, determine if this a 3.0 or 3.1 schema
 		if idx != nil {
-			if idx.GetConfig().SpecInfo.VersionNumeric == 3.1 {
+			if idx.GetConfig().SpecInfo.VersionNumeric >= 3.1 {
 				val, _ := strconv.ParseFloat(exMaxValue.Value, 64)
 				s.ExclusiveMaximum = low.NodeReference[*SchemaDynamicValue[bool, float64]]{
 					KeyNode:   exMaxLabel,
@@ -625,7 +679,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	_, schemaRefLabel, schemaRefNode := utils.FindKeyNodeFullTop(SchemaTypeLabel, root.Content)
 	if schemaRefNode != nil {
 		s.SchemaTypeRef = low.NodeReference[string]{
-			Value: schemaRefNode.Value, KeyNode: schemaRefLabel, ValueNode: schemaRefLabel,
+			Value: schemaRefNode.Value, KeyNode: schemaRefLabel, ValueNode: schemaRefNode,
 		}
 	}
 
@@ -633,7 +687,23 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 	_, anchorLabel, anchorNode := utils.FindKeyNodeFullTop(AnchorLabel, root.Content)
 	if anchorNode != nil {
 		s.Anchor = low.NodeReference[string]{
-			Value: anchorNode.Value, KeyNode: anchorLabel, ValueNode: anchorLabel,
+			Value: anchorNode.Value, KeyNode: anchorLabel, ValueNode: anchorNode,
+		}
+	}
+
+	// handle dynamic anchor if set. (3.1)
+	_, dynamicAnchorLabel, dynamicAnchorNode := utils.FindKeyNodeFullTop(DynamicAnchorLabel, root.Content)
+	if dynamicAnchorNode != nil {
+		s.DynamicAnchor = low.NodeReference[string]{
+			Value: dynamicAnchorNode.Value, KeyNode: dynamicAnchorLabel, ValueNode: dynamicAnchorNode,
+		}
+	}
+
+	// handle dynamic reference if set. (3.1)
+	_, dynamicRefLabel, dynamicRefNode := utils.FindKeyNodeFullTop(DynamicRefLabel, root.Content)
+	if dynamicRefNode != nil {
+		s.DynamicRef = low.NodeReference[string]{
+			Value: dynamicRefNode.Value, KeyNode: dynamicRefLabel, ValueNode: dynamicRefNode,
 		}
 	}
 
@@ -711,6 +781,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		discriminator.KeyNode = discLabel
 		discriminator.RootNode = discNode
 		discriminator.Nodes = low.ExtractNodes(ctx, discNode)
+		discriminator.UnknownFields = low.ExtractUnknownFields(discNode, &discriminator)
 		s.Discriminator = low.NodeReference[*Discriminator]{Value: &discriminator, KeyNode: discLabel, ValueNode: discNode}
 		// add discriminator nodes, because there is no build method.
 		dn := low.ExtractNodesRecursive(ctx, discNode)
@@ -763,6 +834,15 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		s.DependentSchemas = *props
 	}
 
+	// handle definitions (3.1)
+	props, err = buildPropertyMap(ctx, s, root, idx, DefsLabel)
+	if err != nil {
+		return err
+	}
+	if props != nil {
+		s.Defs = *props
+	}
+
 	// handle pattern properties
 	props, err = buildPropertyMap(ctx, s, root, idx, PatternPropertiesLabel)
 	if err != nil {
@@ -793,6 +873,27 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		}
 	}
 
+	// check unevaluatedItems type for schema or bool (3.1 only)
+	unevalItemsIsBool := false
+	unevalItemsBoolValue := true
+	_, unevalItemsBoolLabel, unevalItemsBoolNode := utils.FindKeyNodeFullTop(UnevaluatedItemsLabel, root.Content)
+	if unevalItemsBoolNode != nil {
+		if utils.IsNodeBoolValue(unevalItemsBoolNode) {
+			unevalItemsIsBool = true
+			unevalItemsBoolValue, _ = strconv.ParseBool(unevalItemsBoolNode.Value)
+		}
+	}
+	if unevalItemsIsBool {
+		s.UnevaluatedItems = low.NodeReference[*SchemaDynamicValue[*SchemaProxy, bool]]{
+			Value: &SchemaDynamicValue[*SchemaProxy, bool]{
+				B: unevalItemsBoolValue,
+				N: 1,
+			},
+			KeyNode:   unevalItemsBoolLabel,
+			ValueNode: unevalItemsBoolNode,
+		}
+	}
+
 	// check unevaluatedProperties type for schema or bool (3.1 only)
 	unevalIsBool := false
 	unevalBoolValue := true
@@ -892,7 +993,7 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 		totalBuilds++
 		go buildSchema(ctx, propNamesChan, propNamesLabel, propNamesValue, errorChan, idx)
 	}
-	if unevalItemsValue != nil {
+	if !unevalItemsIsBool && unevalItemsValue != nil {
 		totalBuilds++
 		go buildSchema(ctx, unevalItemsChan, unevalItemsLabel, unevalItemsValue, errorChan, idx)
 	}
@@ -1034,9 +1135,11 @@ func (s *Schema) Build(ctx context.Context, root *yaml.Node, idx *index.SpecInde
 			ValueNode: propNamesValue,
 		}
 	}
-	if !unevalItems.IsEmpty() {
-		s.UnevaluatedItems = low.NodeReference[*SchemaProxy]{
-			Value:     unevalItems.Value,
+	if !unevalItemsIsBool && !unevalItems.IsEmpty() {
+		s.UnevaluatedItems = low.NodeReference[*SchemaDynamicValue[*SchemaProxy, bool]]{
+			Value: &SchemaDynamicValue[*SchemaProxy, bool]{
+				A: unevalItems.Value,
+			},
 			KeyNode:   unevalItemsLabel,
 			ValueNode: unevalItemsValue,
 		}
@@ -1133,8 +1236,9 @@ type schemaProxyBuildResult struct {
 }
 
 // extract extensions from schema
-func (s *Schema) extractExtensions(root *yaml.Node) {
-	s.Extensions = low.ExtractExtensions(root)
+func (s *Schema) extractExtensions(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) {
+	s.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	s.UnknownFields = low.ExtractUnknownFields(root, s, SchemaTypeLabel)
 }
 
 // build out a child schema for parent schema.
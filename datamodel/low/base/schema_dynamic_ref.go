@@ -0,0 +1,128 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ResolveDynamicRef will resolve the $dynamicRef of the Schema to the schema it points to (3.1+ only).
+//
+// A $dynamicRef that points to a JSON pointer (`#/$defs/node`) or an $anchor is resolved the same way as a $ref.
+// A $dynamicRef that points to a $dynamicAnchor (`#node`) is resolved in the dynamic scope: scope is the chain of
+// schemas that were followed to reach this Schema (outermost first), and the first schema in scope that
+// contains the same $dynamicAnchor is selected. If no schema in the scope contains it, the anchor found in the
+// document of this Schema is used.
+func (s *Schema) ResolveDynamicRef(ctx context.Context, scope ...*Schema) (*SchemaProxy, error) {
+	if s.DynamicRef.IsEmpty() {
+		return nil, fmt.Errorf("unable to resolve dynamic reference, schema has no %s", DynamicRefLabel)
+	}
+	ref := s.DynamicRef.Value
+	uri, fragment, _ := strings.Cut(ref, "#")
+
+	// a JSON pointer, resolved just like a $ref
+	if fragment == "" || strings.HasPrefix(fragment, "/") {
+		node, idx, err, fctx := low.LocateRefNodeWithContext(ctx, utils.CreateRefNode(ref), s.Index)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve dynamic reference '%s': %w", ref, err)
+		}
+		if node == nil {
+			return nil, fmt.Errorf("unable to resolve dynamic reference '%s', it cannot be found", ref)
+		}
+		return buildDynamicProxy(fctx, node, idx), nil
+	}
+
+	// find the document the anchor lives in.
+	root, idx, fctx := s.dynamicRefDocument(), s.Index, ctx
+	if uri != "" {
+		node, fIdx, err, nctx := low.LocateRefNodeWithContext(ctx, utils.CreateRefNode(uri), s.Index)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve dynamic reference '%s': %w", ref, err)
+		}
+		if node == nil {
+			return nil, fmt.Errorf("unable to resolve dynamic reference '%s', '%s' cannot be found", ref, uri)
+		}
+		root, idx, fctx = node, fIdx, nctx
+	}
+
+	target, dynamic := findSchemaAnchor(root, fragment)
+	if target == nil {
+		return nil, fmt.Errorf("unable to resolve dynamic reference '%s', there is no %s or %s named '%s'",
+			ref, DynamicAnchorLabel, AnchorLabel, fragment)
+	}
+
+	// only a $dynamicAnchor can be overridden by the dynamic scope.
+	if dynamic {
+		for _, sch := range scope {
+			if sch == nil {
+				continue
+			}
+			if n, ok := findSchemaAnchor(sch.RootNode, fragment); n != nil && ok {
+				scopeIdx := sch.Index
+				if scopeIdx == nil {
+					scopeIdx = idx
+				}
+				return buildDynamicProxy(fctx, n, scopeIdx), nil
+			}
+		}
+	}
+	return buildDynamicProxy(fctx, target, idx), nil
+}
+
+// dynamicRefDocument returns the root node of the document the Schema was built from.
+func (s *Schema) dynamicRefDocument() *yaml.Node {
+	if s.Index != nil && s.Index.GetRootNode() != nil {
+		return s.Index.GetRootNode()
+	}
+	return s.RootNode
+}
+
+func buildDynamicProxy(ctx context.Context, node *yaml.Node, idx *index.SpecIndex) *SchemaProxy {
+	sp := new(SchemaProxy)
+	_ = sp.Build(ctx, nil, node, idx) // returns no errors.
+	return sp
+}
+
+// findSchemaAnchor searches node (and everything below it, in document order) for a schema with a $dynamicAnchor
+// or $anchor named name. Returns the schema node, and true if the match is a $dynamicAnchor.
+func findSchemaAnchor(node *yaml.Node, name string) (*yaml.Node, bool) {
+	seen := make(map[*yaml.Node]struct{})
+	var search func(n *yaml.Node) (*yaml.Node, bool)
+	search = func(n *yaml.Node) (*yaml.Node, bool) {
+		if n == nil {
+			return nil, false
+		}
+		if _, ok := seen[n]; ok {
+			return nil, false
+		}
+		seen[n] = struct{}{}
+		n = utils.NodeAlias(n)
+
+		if utils.IsNodeMap(n) {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				k, v := n.Content[i].Value, n.Content[i+1].Value
+				if v == name && (k == DynamicAnchorLabel || k == AnchorLabel) {
+					return n, k == DynamicAnchorLabel
+				}
+			}
+		}
+		for _, c := range n.Content {
+			if found, dynamic := search(c); found != nil {
+				return found, dynamic
+			}
+		}
+		return nil, false
+	}
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	return search(node)
+}
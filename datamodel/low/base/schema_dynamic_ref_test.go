@@ -0,0 +1,141 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var dynamicRefSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Tree:
+      $dynamicAnchor: node
+      type: object
+      properties:
+        data: true
+        children:
+          type: array
+          items:
+            $dynamicRef: '#node'
+    StrictTree:
+      $dynamicAnchor: node
+      allOf:
+        - $ref: '#/components/schemas/Tree'
+      unevaluatedProperties: false
+    List:
+      type: array
+      items:
+        $dynamicRef: '#/components/schemas/List/$defs/leaf'
+      unevaluatedItems: false
+      $defs:
+        leaf:
+          $anchor: leafNode
+          type: string
+    Leaf:
+      $dynamicRef: '#leafNode'
+    Missing:
+      $dynamicRef: '#nothing'
+    MissingPointer:
+      $dynamicRef: '#/components/schemas/Nope'`
+
+func buildDynamicRefSchema(t *testing.T, name string) (*Schema, *index.SpecIndex) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(dynamicRefSpec), &root))
+	idx := index.NewSpecIndexWithConfig(&root, index.CreateOpenAPIIndexConfig())
+	return buildDynamicRefSchemaFromIndex(t, idx, name), idx
+}
+
+func buildDynamicRefSchemaFromIndex(t *testing.T, idx *index.SpecIndex, name string) *Schema {
+	schemas := idx.GetRootNode().Content[0].Content[3].Content[1]
+	for i := 0; i < len(schemas.Content); i += 2 {
+		if schemas.Content[i].Value == name {
+			sch := new(Schema)
+			require.NoError(t, sch.Build(context.Background(), schemas.Content[i+1], idx))
+			return sch
+		}
+	}
+	t.Fatalf("schema %s not found", name)
+	return nil
+}
+
+func TestSchema_Build_DynamicKeywords(t *testing.T) {
+	list, _ := buildDynamicRefSchema(t, "List")
+	assert.Equal(t, "#/components/schemas/List/$defs/leaf", list.Items.Value.A.Schema().DynamicRef.Value)
+	assert.Equal(t, 21, list.Items.Value.A.Schema().DynamicRef.ValueNode.Line)
+	assert.True(t, list.UnevaluatedItems.Value.IsB())
+	assert.False(t, list.UnevaluatedItems.Value.B)
+	assert.Equal(t, "leafNode", list.FindDef("leaf").Value.Schema().Anchor.Value)
+	assert.Equal(t, "leafNode", list.FindDef("leaf").Value.Schema().Anchor.ValueNode.Value)
+	assert.Nil(t, list.FindDef("nope"))
+
+	tree, _ := buildDynamicRefSchema(t, "Tree")
+	assert.Equal(t, "node", tree.DynamicAnchor.Value)
+	assert.Equal(t, "node", tree.DynamicAnchor.ValueNode.Value)
+	assert.NotEqual(t, tree.Hash(), list.Hash())
+}
+
+func TestSchema_ResolveDynamicRef(t *testing.T) {
+	tree, idx := buildDynamicRefSchema(t, "Tree")
+	strict := buildDynamicRefSchemaFromIndex(t, idx, "StrictTree")
+	items := tree.FindProperty("children").Value.Schema().Items.Value.A.Schema()
+
+	// no dynamic scope, the anchor in the document is used.
+	sp, err := items.ResolveDynamicRef(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "object", sp.Schema().Type.Value.A)
+	assert.True(t, sp.Schema().UnevaluatedProperties.IsEmpty())
+
+	// StrictTree is in the dynamic scope, it overrides the anchor.
+	sp, err = items.ResolveDynamicRef(context.Background(), strict, tree)
+	require.NoError(t, err)
+	assert.False(t, sp.Schema().UnevaluatedProperties.Value.B)
+
+	// a scope without the anchor changes nothing.
+	list := buildDynamicRefSchemaFromIndex(t, idx, "List")
+	sp, err = items.ResolveDynamicRef(context.Background(), nil, list)
+	require.NoError(t, err)
+	assert.True(t, sp.Schema().UnevaluatedProperties.IsEmpty())
+}
+
+func TestSchema_ResolveDynamicRef_Static(t *testing.T) {
+	list, idx := buildDynamicRefSchema(t, "List")
+	tree := buildDynamicRefSchemaFromIndex(t, idx, "Tree")
+
+	// a JSON pointer ignores the dynamic scope.
+	sp, err := list.Items.Value.A.Schema().ResolveDynamicRef(context.Background(), tree)
+	require.NoError(t, err)
+	assert.Equal(t, "string", sp.Schema().Type.Value.A)
+
+	// an $anchor ignores the dynamic scope.
+	leaf := buildDynamicRefSchemaFromIndex(t, idx, "Leaf")
+	sp, err = leaf.ResolveDynamicRef(context.Background(), list)
+	require.NoError(t, err)
+	assert.Equal(t, "string", sp.Schema().Type.Value.A)
+}
+
+func TestSchema_ResolveDynamicRef_Errors(t *testing.T) {
+	tree, idx := buildDynamicRefSchema(t, "Tree")
+	_, err := tree.ResolveDynamicRef(context.Background())
+	assert.EqualError(t, err, "unable to resolve dynamic reference, schema has no $dynamicRef")
+
+	missing := buildDynamicRefSchemaFromIndex(t, idx, "Missing")
+	_, err = missing.ResolveDynamicRef(context.Background())
+	assert.EqualError(t, err, "unable to resolve dynamic reference '#nothing', there is no $dynamicAnchor "+
+		"or $anchor named 'nothing'")
+
+	missing = buildDynamicRefSchemaFromIndex(t, idx, "MissingPointer")
+	_, err = missing.ResolveDynamicRef(context.Background())
+	assert.Error(t, err)
+
+	missing.DynamicRef.Value = "other.yaml#node"
+	_, err = missing.ResolveDynamicRef(context.Background())
+	assert.Error(t, err)
+}
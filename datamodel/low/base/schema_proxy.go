@@ -145,9 +145,18 @@ func (sp *SchemaProxy) GetValueNode() *yaml.Node {
 
 // Hash will return a consistent SHA256 Hash of the SchemaProxy object (it will resolve it)
 func (sp *SchemaProxy) Hash() [32]byte {
+	return sp.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the SchemaProxy object, computed using the requested low.HashVersion.
+func (sp *SchemaProxy) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, sp.hash)
+}
+
+func (sp *SchemaProxy) hash(version low.HashVersion) [32]byte {
 	if sp.rendered != nil {
 		if !sp.IsReference() {
-			return sp.rendered.Hash()
+			return sp.rendered.hash(version)
 		}
 	} else {
 		if !sp.IsReference() {
@@ -155,7 +164,7 @@ func (sp *SchemaProxy) Hash() [32]byte {
 			sch := sp.Schema()
 			sp.rendered = sch
 			if sch != nil {
-				return sch.Hash()
+				return sch.hash(version)
 			}
 			var logger *slog.Logger
 			if sp.idx != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -341,7 +342,7 @@ func Test_Schema(t *testing.T) {
 	assert.Equal(t, "string", sch.FindDependentSchema("schemaOne").Value.Schema().Type.Value.A)
 	assert.Equal(t, "string", sch.FindPatternProperty("patternOne").Value.Schema().Type.Value.A)
 	assert.Equal(t, "string", sch.PropertyNames.Value.Schema().Type.Value.A)
-	assert.Equal(t, "boolean", sch.UnevaluatedItems.Value.Schema().Type.Value.A)
+	assert.Equal(t, "boolean", sch.UnevaluatedItems.Value.A.Schema().Type.Value.A)
 	assert.Equal(t, "integer", sch.UnevaluatedProperties.Value.A.Schema().Type.Value.A)
 	assert.Equal(t, "anchor", sch.Anchor.Value)
 }
@@ -1569,6 +1570,42 @@ func TestSchema_Hash_NotEqual(t *testing.T) {
 	assert.False(t, low.AreEqual(lDoc.Value.Schema(), rDoc.Value.Schema()))
 }
 
+func TestSchema_HashForVersion_DynamicKeywords(t *testing.T) {
+	left := `schema:
+  title: a tree
+  items:
+    $dynamicRef: '#node'`
+
+	right := `schema:
+  title: a tree
+  $dynamicAnchor: node
+  $defs:
+    leaf:
+      type: string
+  items:
+    $dynamicRef: '#leaf'`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	lDoc, _ := ExtractSchema(context.Background(), lNode.Content[0], nil)
+	rDoc, _ := ExtractSchema(context.Background(), rNode.Content[0], nil)
+
+	// the dynamic keywords are part of the current hash.
+	assert.False(t, low.AreEqual(lDoc.Value.Schema(), rDoc.Value.Schema()))
+
+	// but version 1 hashes are unchanged, all the way down.
+	lHash, err := low.HashWithVersion(lDoc.Value, low.HashVersion1)
+	require.NoError(t, err)
+	rHash, err := low.HashWithVersion(rDoc.Value, low.HashVersion1)
+	require.NoError(t, err)
+	assert.Equal(t, lHash, rHash)
+
+	_, found := rDoc.Value.Schema().HashForVersion(low.HashVersion(9))
+	assert.False(t, found)
+}
+
 func TestSchema_Hash_EqualJumbled(t *testing.T) {
 	left := `schema:
   title: an OK message
@@ -21,13 +21,19 @@ import (
 // tag defined in the Operation Object instances.
 //   - v2: https://swagger.io/specification/v2/#tagObject
 //   - v3: https://swagger.io/specification/#tag-object
+//
+// Summary, Parent and Kind were added in OpenAPI 3.2, they are only built for 3.2+ documents.
 type Tag struct {
-	Name         low.NodeReference[string]
-	Description  low.NodeReference[string]
-	ExternalDocs low.NodeReference[*ExternalDoc]
-	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode      *yaml.Node
-	RootNode     *yaml.Node
+	Name          low.NodeReference[string]
+	Summary       low.NodeReference[string] // 3.2
+	Description   low.NodeReference[string]
+	ExternalDocs  low.NodeReference[*ExternalDoc]
+	Parent        low.NodeReference[string] // 3.2
+	Kind          low.NodeReference[string] // 3.2
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -55,9 +61,17 @@ func (t *Tag) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.Sp
 	utils.CheckForMergeNodes(root)
 	t.Reference = new(low.Reference)
 	t.Nodes = low.ExtractNodes(ctx, root)
-	t.Extensions = low.ExtractExtensions(root)
+	t.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	t.UnknownFields = low.ExtractUnknownFields(root, t)
 	low.ExtractExtensionNodes(ctx, t.Extensions, t.Nodes)
 
+	if !low.SupportsVersion(idx, 3.2) {
+		// older versions do not define these properties, so they are not part of the model.
+		t.Summary = low.NodeReference[string]{}
+		t.Parent = low.NodeReference[string]{}
+		t.Kind = low.NodeReference[string]{}
+	}
+
 	// extract externalDocs
 	extDocs, err := low.ExtractObject[*ExternalDoc](ctx, ExternalDocsLabel, root, idx)
 	t.ExternalDocs = extDocs
@@ -69,6 +83,12 @@ func (t *Tag) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.Valu
 	return t.Extensions
 }
 
+// GetUnknownFields returns the keys of the Tag that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (t *Tag) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return t.UnknownFields
+}
+
 // Hash will return a consistent SHA256 Hash of the Info object
 func (t *Tag) Hash() [32]byte {
 	var f []string
@@ -81,6 +101,15 @@ func (t *Tag) Hash() [32]byte {
 	if !t.ExternalDocs.IsEmpty() {
 		f = append(f, low.GenerateHashString(t.ExternalDocs.Value))
 	}
+	if !t.Summary.IsEmpty() {
+		f = append(f, SummaryLabel+"-"+t.Summary.Value)
+	}
+	if !t.Parent.IsEmpty() {
+		f = append(f, ParentLabel+"-"+t.Parent.Value)
+	}
+	if !t.Kind.IsEmpty() {
+		f = append(f, KindLabel+"-"+t.Kind.Value)
+	}
 	f = append(f, low.HashExtensions(t.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
@@ -22,17 +22,23 @@ import (
 //	v2 - https://swagger.io/specification/v2/#xmlObject
 //	v3 - https://swagger.io/specification/#xml-object
 type XML struct {
-	Name       low.NodeReference[string]
-	Namespace  low.NodeReference[string]
-	Prefix     low.NodeReference[string]
-	Attribute  low.NodeReference[bool]
-	Wrapped    low.NodeReference[bool]
-	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	RootNode   *yaml.Node
+	Name          low.NodeReference[string]
+	Namespace     low.NodeReference[string]
+	Prefix        low.NodeReference[string]
+	Attribute     low.NodeReference[bool]
+	Wrapped       low.NodeReference[bool]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
 
+// GetRootNode returns the root yaml node of the XML object
+func (x *XML) GetRootNode() *yaml.Node {
+	return x.RootNode
+}
+
 // Build will extract extensions from the XML instance.
 func (x *XML) Build(root *yaml.Node, _ *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
@@ -41,6 +47,7 @@ func (x *XML) Build(root *yaml.Node, _ *index.SpecIndex) error {
 	x.Reference = new(low.Reference)
 	x.Nodes = low.ExtractNodes(nil, root)
 	x.Extensions = low.ExtractExtensions(root)
+	x.UnknownFields = low.ExtractUnknownFields(root, x)
 	return nil
 }
 
@@ -49,6 +56,12 @@ func (x *XML) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.Valu
 	return x.Extensions
 }
 
+// GetUnknownFields returns the keys of the XML that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (x *XML) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return x.UnknownFields
+}
+
 // Hash generates a SHA256 hash of the XML object using properties
 func (x *XML) Hash() [32]byte {
 	var f []string
@@ -0,0 +1,97 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Comments are the YAML comments attached to a node. Head comments are on the lines above the node, the line
+// comment is on the same line, and foot comments are on the lines below it. Comments are kept exactly as they
+// were written, including the leading '#'.
+//
+// The YAML parser attaches head and foot comments to the key of a map entry, and line comments to a scalar value,
+// so KeyReference.Comments and ValueReference.Comments each only see part of the picture. NodeReference.Comments
+// combines the comments of both the key and value.
+type Comments struct {
+	Head string
+	Line string
+	Foot string
+}
+
+// IsEmpty returns true if there are no comments.
+func (c Comments) IsEmpty() bool {
+	return c.Head == "" && c.Line == "" && c.Foot == ""
+}
+
+// Text returns every comment line (head, then line, then foot) with the leading '#' and surrounding whitespace
+// removed, for example `# owner: payments-team` becomes `owner: payments-team`. Blank lines are skipped.
+func (c Comments) Text() []string {
+	var text []string
+	for _, comment := range []string{c.Head, c.Line, c.Foot} {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+			if line != "" {
+				text = append(text, line)
+			}
+		}
+	}
+	return text
+}
+
+// NodeComments returns the comments attached to a node.
+func NodeComments(node *yaml.Node) Comments {
+	if node == nil {
+		return Comments{}
+	}
+	return Comments{Head: node.HeadComment, Line: node.LineComment, Foot: node.FootComment}
+}
+
+// MergeComments combines comments, joining each kind of comment with a new line in the order supplied.
+func MergeComments(comments ...Comments) Comments {
+	var m Comments
+	join := func(a, b string) string {
+		if a == "" {
+			return b
+		}
+		if b == "" {
+			return a
+		}
+		return a + "\n" + b
+	}
+	for _, c := range comments {
+		m.Head = join(m.Head, c.Head)
+		m.Line = join(m.Line, c.Line)
+		m.Foot = join(m.Foot, c.Foot)
+	}
+	return m
+}
+
+// CopyComments copies the comments of one node to another, only the kinds of comment the target does not
+// already have are copied. Used to carry comments over to nodes created when rendering a model.
+func CopyComments(target, source *yaml.Node) {
+	if target == nil || source == nil || target == source {
+		return
+	}
+	if target.HeadComment == "" {
+		target.HeadComment = source.HeadComment
+	}
+	if target.LineComment == "" {
+		target.LineComment = source.LineComment
+	}
+	if target.FootComment == "" {
+		target.FootComment = source.FootComment
+	}
+}
+
+// SetComments replaces the comments of a node, they are kept when the node (or a model built from it) is rendered.
+// Comments are written as YAML expects, each line starting with '#'.
+func SetComments(node *yaml.Node, comments Comments) {
+	if node == nil {
+		return
+	}
+	node.HeadComment, node.LineComment, node.FootComment = comments.Head, comments.Line, comments.Foot
+}
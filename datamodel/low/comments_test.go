@@ -0,0 +1,67 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestComments(t *testing.T) {
+	yml := `# owner: pets-team
+# reviewed: 2024
+name: pets # public
+# end of name
+
+other: thing`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	keyNode, valueNode := root.Content[0].Content[0], root.Content[0].Content[1]
+
+	k := KeyReference[string]{Value: "name", KeyNode: keyNode}
+	assert.Equal(t, "# owner: pets-team\n# reviewed: 2024", k.Comments().Head)
+	assert.Equal(t, "# end of name", k.Comments().Foot)
+
+	v := ValueReference[string]{Value: "pets", ValueNode: valueNode}
+	assert.Equal(t, Comments{Line: "# public"}, v.Comments())
+
+	n := NodeReference[string]{Value: "pets", KeyNode: keyNode, ValueNode: valueNode}
+	c := n.Comments()
+	assert.Equal(t, "# owner: pets-team\n# reviewed: 2024", c.Head)
+	assert.Equal(t, "# public", c.Line)
+	assert.Equal(t, []string{"owner: pets-team", "reviewed: 2024", "public", "end of name"}, c.Text())
+	assert.False(t, c.IsEmpty())
+
+	assert.True(t, KeyReference[string]{Value: "other", KeyNode: root.Content[0].Content[2]}.Comments().IsEmpty())
+	assert.True(t, NodeReference[string]{}.Comments().IsEmpty())
+	assert.Nil(t, Comments{}.Text())
+}
+
+func TestCopyComments(t *testing.T) {
+	source := &yaml.Node{HeadComment: "# head", LineComment: "# line", FootComment: "# foot"}
+	target := &yaml.Node{LineComment: "# mine"}
+	CopyComments(target, source)
+	assert.Equal(t, Comments{Head: "# head", Line: "# mine", Foot: "# foot"}, NodeComments(target))
+
+	// nothing to copy, or nowhere to copy it.
+	CopyComments(target, nil)
+	CopyComments(nil, source)
+	CopyComments(source, source)
+	assert.Equal(t, "# head", source.HeadComment)
+}
+
+func TestSetComments(t *testing.T) {
+	n := &yaml.Node{Kind: yaml.ScalarNode, Value: "pets", LineComment: "# old"}
+	SetComments(n, Comments{Head: "# owner: pets-team"})
+	assert.Equal(t, Comments{Head: "# owner: pets-team"}, NodeComments(n))
+	SetComments(nil, Comments{Head: "# nope"})
+}
+
+func TestMergeComments(t *testing.T) {
+	m := MergeComments(Comments{Head: "# a"}, Comments{Head: "# b", Line: "# c"}, Comments{})
+	assert.Equal(t, Comments{Head: "# a\n# b", Line: "# c"}, m)
+}
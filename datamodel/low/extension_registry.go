@@ -0,0 +1,250 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtensionRegistryKey is the context key used to carry an *ExtensionRegistry through the build pipeline.
+const ExtensionRegistryKey index.ContextKey = "extensionRegistry"
+
+// ExtensionErrorsKey is the context key used to carry the *ExtensionErrors of a build through the build pipeline.
+const ExtensionErrorsKey index.ContextKey = "extensionErrors"
+
+type extensionBuilder func(ctx context.Context, keyNode, valueNode *yaml.Node, idx *index.SpecIndex) (any, error)
+
+// ExtensionRegistry maps extension keys (such as `x-streaming`) to Buildable model types. When a registry is
+// supplied via datamodel.DocumentConfiguration, every extension found with a registered key is built into its typed
+// model using the same pipeline as the rest of the low-level model (references are located and resolved, BuildModel
+// is run and then Build is called with the index the extension was found in).
+//
+// Built models are held by the registry and can be retrieved with GetExtensionModel, or with
+// high.GetExtensionModel for high-level models. Errors raised while building extensions do not stop the document
+// from building, they are returned along with any other document errors (each build collects its own, see
+// ExtensionErrors).
+//
+// A registry is safe for concurrent use, however all types should be registered before the document is built. Built
+// models and errors are kept for the lifetime of the registry, GetErrors returns the errors of every build.
+type ExtensionRegistry struct {
+	lock     sync.RWMutex
+	builders map[string]extensionBuilder
+	models   sync.Map // *yaml.Node -> any
+	errLock  sync.Mutex
+	errors   []error
+}
+
+// NewExtensionRegistry creates an empty *ExtensionRegistry, ready for types to be registered.
+func NewExtensionRegistry() *ExtensionRegistry {
+	return &ExtensionRegistry{builders: make(map[string]extensionBuilder)}
+}
+
+// RegisterExtension registers the Buildable type N against an extension key. Registering the same key
+// again replaces the previous type.
+//
+// to use:
+//
+//	registry := low.NewExtensionRegistry()
+//	low.RegisterExtension[*Streaming](registry, "x-streaming")
+func RegisterExtension[PT Buildable[N], N any](registry *ExtensionRegistry, key string) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.builders[key] = func(ctx context.Context, keyNode, valueNode *yaml.Node, idx *index.SpecIndex) (any, error) {
+		n, _, err := extractObjectRaw[PT, N](ctx, keyNode, valueNode, idx)
+		return n, err
+	}
+}
+
+// IsRegistered returns true if a typed model has been registered for the extension key.
+func (r *ExtensionRegistry) IsRegistered(key string) bool {
+	if r == nil {
+		return false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	_, ok := r.builders[key]
+	return ok
+}
+
+// GetRegisteredKeys returns all registered extension keys, sorted. A nil registry has no keys.
+func (r *ExtensionRegistry) GetRegisteredKeys() []string {
+	if r == nil {
+		return nil
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	keys := make([]string, 0, len(r.builders))
+	for k := range r.builders {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetModel returns the typed model built for an extension value node, if one has been built.
+func (r *ExtensionRegistry) GetModel(valueNode *yaml.Node) (any, bool) {
+	if r == nil || valueNode == nil {
+		return nil, false
+	}
+	return r.models.Load(valueNode)
+}
+
+// GetErrors returns any errors raised while building registered extensions, by every build using the registry. A nil
+// registry has no errors.
+func (r *ExtensionRegistry) GetErrors() []error {
+	if r == nil {
+		return nil
+	}
+	r.errLock.Lock()
+	defer r.errLock.Unlock()
+	return append([]error(nil), r.errors...)
+}
+
+// ErrorCount returns the number of errors raised while building registered extensions. A nil registry has no errors.
+func (r *ExtensionRegistry) ErrorCount() int {
+	if r == nil {
+		return 0
+	}
+	r.errLock.Lock()
+	defer r.errLock.Unlock()
+	return len(r.errors)
+}
+
+// build will build every registered extension found in the extension map that has not already been built.
+func (r *ExtensionRegistry) build(ctx context.Context,
+	extensions *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]], idx *index.SpecIndex,
+) {
+	for k, v := range extensions.FromOldest() {
+		r.lock.RLock()
+		builder := r.builders[k.Value]
+		r.lock.RUnlock()
+		if builder == nil {
+			continue
+		}
+		if _, built := r.models.Load(v.ValueNode); built {
+			continue
+		}
+		model, err := builder(ctx, k.KeyNode, v.ValueNode, idx)
+		if err != nil {
+			err = fmt.Errorf("unable to build extension '%s' [%d:%d]: %w", k.Value, k.KeyNode.Line, k.KeyNode.Column, err)
+			r.errLock.Lock()
+			r.errors = append(r.errors, err)
+			r.errLock.Unlock()
+			GetExtensionErrors(ctx).add(err)
+			continue
+		}
+		r.models.Store(v.ValueNode, model)
+	}
+}
+
+// WithExtensionRegistry returns a copy of ctx that carries the registry, if the registry is an *ExtensionRegistry.
+// Any other value (including nil) returns ctx untouched.
+func WithExtensionRegistry(ctx context.Context, registry datamodel.ExtensionRegistry) context.Context {
+	if r, ok := registry.(*ExtensionRegistry); ok && r != nil {
+		return context.WithValue(ctx, ExtensionRegistryKey, r)
+	}
+	return ctx
+}
+
+// GetExtensionRegistry returns the *ExtensionRegistry carried by ctx, or nil if there isn't one.
+func GetExtensionRegistry(ctx context.Context) *ExtensionRegistry {
+	if ctx == nil {
+		return nil
+	}
+	r, _ := ctx.Value(ExtensionRegistryKey).(*ExtensionRegistry)
+	return r
+}
+
+// ExtensionErrors collects the errors raised while building the registered extensions of a single build. A registry
+// is shared by every document built with it, so each build carries its own collector, and objects built lazily once
+// the build has returned (such as schemas) add their errors to the collector of the build they came from. It is safe
+// for concurrent use.
+type ExtensionErrors struct {
+	lock   sync.Mutex
+	errors []error
+}
+
+// WithExtensionErrors returns a copy of ctx that carries a new *ExtensionErrors, and the *ExtensionErrors.
+func WithExtensionErrors(ctx context.Context) (context.Context, *ExtensionErrors) {
+	e := new(ExtensionErrors)
+	return context.WithValue(ctx, ExtensionErrorsKey, e), e
+}
+
+// GetExtensionErrors returns the *ExtensionErrors carried by ctx, or nil if there isn't one.
+func GetExtensionErrors(ctx context.Context) *ExtensionErrors {
+	if ctx == nil {
+		return nil
+	}
+	e, _ := ctx.Value(ExtensionErrorsKey).(*ExtensionErrors)
+	return e
+}
+
+// GetErrors returns the errors collected so far. A nil *ExtensionErrors has no errors.
+func (e *ExtensionErrors) GetErrors() []error {
+	if e == nil {
+		return nil
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]error(nil), e.errors...)
+}
+
+func (e *ExtensionErrors) add(err error) {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	e.errors = append(e.errors, err)
+	e.lock.Unlock()
+}
+
+// ExtractExtensionsWithContext behaves the same as ExtractExtensions, but will also build a typed model for every
+// extension key registered with the *ExtensionRegistry carried by ctx (if there is one).
+func ExtractExtensionsWithContext(ctx context.Context, root *yaml.Node,
+	idx *index.SpecIndex,
+) *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]] {
+	extensions := ExtractExtensions(root)
+	if extensions == nil {
+		return nil
+	}
+	if r := GetExtensionRegistry(ctx); r != nil {
+		r.build(ctx, extensions, idx)
+	}
+	return extensions
+}
+
+// GetExtensionModel returns the typed model built for the extension key, from a low-level extension map. Returns
+// false if the extension does not exist, was not built, or is not of type N.
+//
+// to use:
+//
+//	streaming, ok := low.GetExtensionModel[Streaming](registry, operation.Extensions, "x-streaming")
+func GetExtensionModel[N any](registry *ExtensionRegistry,
+	extensions *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]], key string,
+) (*N, bool) {
+	v := FindItemInOrderedMap[*yaml.Node](key, extensions)
+	if v == nil {
+		return nil, false
+	}
+	return GetExtensionModelFromNode[N](registry, v.ValueNode)
+}
+
+// GetExtensionModelFromNode returns the typed model built for an extension value node. Returns false if nothing
+// was built for the node, or the model is not of type N.
+func GetExtensionModelFromNode[N any](registry *ExtensionRegistry, valueNode *yaml.Node) (*N, bool) {
+	m, ok := registry.GetModel(valueNode)
+	if !ok {
+		return nil, false
+	}
+	n, ok := m.(*N)
+	return n, ok
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type failingExtension struct{}
+
+func (f *failingExtension) Build(_ context.Context, _, _ *yaml.Node, _ *index.SpecIndex) error {
+	return errors.New("no thanks")
+}
+
+func TestExtensionRegistry_ExtractExtensionsWithContext(t *testing.T) {
+	idx := originTestIndex(t)
+	registry := NewExtensionRegistry()
+	RegisterExtension[*streaming](registry, "x-streaming")
+	RegisterExtension[*streaming](registry, "x-events")
+
+	assert.True(t, registry.IsRegistered("x-streaming"))
+	assert.False(t, registry.IsRegistered("x-nothing"))
+	assert.Equal(t, []string{"x-events", "x-streaming"}, registry.GetRegisteredKeys())
+
+	yml := `x-streaming:
+  protocol: websocket
+x-events:
+  $ref: '#/components/schemas/sse'
+x-plain: hello`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	ctx := WithExtensionRegistry(context.Background(), registry)
+	ext := ExtractExtensionsWithContext(ctx, cNode.Content[0], idx)
+	require.Equal(t, 3, ext.Len())
+
+	s, ok := GetExtensionModel[streaming](registry, ext, "x-streaming")
+	require.True(t, ok)
+	assert.True(t, s.built)
+	assert.Equal(t, "websocket", s.Protocol.Value)
+
+	// references are resolved by the standard pipeline.
+	e, ok := GetExtensionModel[streaming](registry, ext, "x-events")
+	require.True(t, ok)
+	assert.Equal(t, "sse", e.Protocol.Value)
+	assert.Len(t, e.Events.Value, 2)
+
+	_, ok = GetExtensionModel[streaming](registry, ext, "x-plain")
+	assert.False(t, ok)
+	_, ok = GetExtensionModel[failingExtension](registry, ext, "x-streaming")
+	assert.False(t, ok)
+	assert.Empty(t, registry.GetErrors())
+}
+
+func TestExtensionRegistry_BuildError(t *testing.T) {
+	registry := NewExtensionRegistry()
+	RegisterExtension[*failingExtension](registry, "x-fail")
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`x-fail: true`), &cNode)
+
+	ctx, collected := WithExtensionErrors(WithExtensionRegistry(context.Background(), registry))
+	assert.Same(t, collected, GetExtensionErrors(ctx))
+	ext := ExtractExtensionsWithContext(ctx, cNode.Content[0], nil)
+	assert.Equal(t, 1, ext.Len())
+	assert.Equal(t, 1, registry.ErrorCount())
+	assert.EqualError(t, registry.GetErrors()[0], "unable to build extension 'x-fail' [1:1]: no thanks")
+	assert.Equal(t, registry.GetErrors(), collected.GetErrors())
+
+	// without a collector, the errors are only kept by the registry.
+	var nothing *ExtensionErrors
+	assert.Nil(t, GetExtensionErrors(nil))
+	assert.Empty(t, nothing.GetErrors())
+
+	_, ok := GetExtensionModel[failingExtension](registry, ext, "x-fail")
+	assert.False(t, ok)
+}
+
+func TestExtensionRegistry_NoRegistry(t *testing.T) {
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`x-streaming: {}`), &cNode)
+
+	ctx := WithExtensionRegistry(context.Background(), nil)
+	assert.Nil(t, GetExtensionRegistry(ctx))
+	assert.Nil(t, GetExtensionRegistry(nil))
+	assert.Equal(t, 1, ExtractExtensionsWithContext(ctx, cNode.Content[0], nil).Len())
+	assert.Nil(t, ExtractExtensionsWithContext(ctx, nil, nil))
+
+	var r *ExtensionRegistry
+	assert.False(t, r.IsRegistered("x-streaming"))
+	assert.Equal(t, 0, r.ErrorCount())
+	assert.Empty(t, r.GetErrors())
+	assert.Empty(t, r.GetRegisteredKeys())
+	_, ok := r.GetModel(cNode.Content[0])
+	assert.False(t, ok)
+}
@@ -31,16 +31,13 @@ func FindItemInOrderedMap[T any](item string, collection *orderedmap.Map[KeyRefe
 
 // FindItemInOrderedMapWithKey is the same as FindItemInOrderedMap, except this code returns the key as well as the value.
 func FindItemInOrderedMapWithKey[T any](item string, collection *orderedmap.Map[KeyReference[string], ValueReference[T]]) (*KeyReference[string], *ValueReference[T]) {
-	for pair := orderedmap.First(collection); pair != nil; pair = pair.Next() {
-		n := pair.Key()
-		if n.Value == item {
-			return &n, pair.ValuePtr()
-		}
-		if strings.EqualFold(item, n.Value) {
-			return &n, pair.ValuePtr()
-		}
+	// the map indexes its keys on the first lookup, so repeated lookups don't scan it.
+	pair := collection.FindKeyFold(item)
+	if pair == nil {
+		return nil, nil
 	}
-	return nil, nil
+	n := pair.Key()
+	return &n, pair.ValuePtr()
 }
 
 // HashExtensions will generate a hash from the low representation of extensions.
@@ -222,6 +219,18 @@ func LocateRefNode(root *yaml.Node, idx *index.SpecIndex) (*yaml.Node, *index.Sp
 // ExtractObjectRaw will extract a typed Buildable[N] object from a root yaml.Node. The 'raw' aspect is
 // that there is no NodeReference wrapper around the result returned, just the raw object.
 func ExtractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yaml.Node, idx *index.SpecIndex) (T, error, bool, string) {
+	n, origin, err := extractObjectRaw[T, N](ctx, key, root, idx)
+	return n, err, origin.IsReference(), origin.Reference
+}
+
+// ExtractObjectRawWithOrigin is the same as ExtractObjectRaw, except that instead of returning the reference state
+// as loose values, an *Origin is returned that describes exactly where (and with which index and context) the object
+// was built from. The error is always the last return value. The *Origin is never nil.
+func ExtractObjectRawWithOrigin[T Buildable[N], N any](ctx context.Context, key, root *yaml.Node, idx *index.SpecIndex) (T, *Origin, error) {
+	return extractObjectRaw[T, N](ctx, key, root, idx)
+}
+
+func extractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yaml.Node, idx *index.SpecIndex) (T, *Origin, error) {
 	var circError error
 	var isReference bool
 	var referenceValue string
@@ -241,18 +250,31 @@ func ExtractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yam
 			}
 		} else {
 			if err != nil {
-				return nil, fmt.Errorf("object extraction failed: %s", err.Error()), isReference, referenceValue
+				// the reference that failed is kept, so callers can tell which one it was.
+				return nil, &Origin{KeyNode: key, Reference: rv, ReferenceNode: root, Index: idx, Context: ctx},
+					fmt.Errorf("object extraction failed: %s", err.Error())
 			}
 		}
 	}
+	origin := &Origin{
+		KeyNode:       key,
+		ValueNode:     root,
+		Reference:     referenceValue,
+		ReferenceNode: refNode,
+		Index:         idx,
+		Context:       ctx,
+	}
+	if key != nil {
+		origin.Label = key.Value
+	}
 	var n T = new(N)
 	err := BuildModel(root, n)
 	if err != nil {
-		return n, err, isReference, referenceValue
+		return n, origin, err
 	}
 	err = n.Build(ctx, key, root, idx)
 	if err != nil {
-		return n, err, isReference, referenceValue
+		return n, origin, err
 	}
 
 	// if this is a reference, keep track of the reference in the value
@@ -262,14 +284,26 @@ func ExtractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yam
 
 	// do we want to throw an error as well if circular error reporting is on?
 	if circError != nil && !idx.AllowCircularReferenceResolving() {
-		return n, circError, isReference, referenceValue
+		return n, origin, circError
 	}
-	return n, nil, isReference, referenceValue
+	return n, origin, nil
 }
 
 // ExtractObject will extract a typed Buildable[N] object from a root yaml.Node. The result is wrapped in a
 // NodeReference[T] that contains the key node found and value node found when looking up the reference.
 func ExtractObject[T Buildable[N], N any](ctx context.Context, label string, root *yaml.Node, idx *index.SpecIndex) (NodeReference[T], error) {
+	res, _, err := extractObject[T, N](ctx, label, root, idx)
+	return res, err
+}
+
+// ExtractObjectWithOrigin is the same as ExtractObject, except an *Origin is also returned that describes where
+// the object was built from. The *Origin is never nil, when the label cannot be found its KeyNode and ValueNode are
+// nil, and when a reference cannot be located it holds the reference that failed.
+func ExtractObjectWithOrigin[T Buildable[N], N any](ctx context.Context, label string, root *yaml.Node, idx *index.SpecIndex) (NodeReference[T], *Origin, error) {
+	return extractObject[T, N](ctx, label, root, idx)
+}
+
+func extractObject[T Buildable[N], N any](ctx context.Context, label string, root *yaml.Node, idx *index.SpecIndex) (NodeReference[T], *Origin, error) {
 	var ln, vn *yaml.Node
 	var circError error
 	var isReference bool
@@ -291,7 +325,9 @@ func ExtractObject[T Buildable[N], N any](ctx context.Context, label string, roo
 			}
 		} else {
 			if err != nil {
-				return NodeReference[T]{}, fmt.Errorf("object extraction failed: %s", err.Error())
+				// the reference that failed is kept, so callers can tell which one it was.
+				return NodeReference[T]{}, &Origin{Label: label, Reference: refVal, ReferenceNode: root, Index: idx, Context: ctx},
+					fmt.Errorf("object extraction failed: %s", err.Error())
 			}
 		}
 	} else {
@@ -313,23 +349,33 @@ func ExtractObject[T Buildable[N], N any](ctx context.Context, label string, roo
 					}
 				} else {
 					if lerr != nil {
-						return NodeReference[T]{}, fmt.Errorf("object extraction failed: %s", lerr.Error())
+						return NodeReference[T]{}, &Origin{Label: label, KeyNode: ln, Reference: rVal, ReferenceNode: vn,
+							Index: idx, Context: ctx}, fmt.Errorf("object extraction failed: %s", lerr.Error())
 					}
 				}
 			}
 		}
 	}
+	origin := &Origin{
+		Label:         label,
+		KeyNode:       ln,
+		ValueNode:     vn,
+		Reference:     referenceValue,
+		ReferenceNode: refNode,
+		Index:         idx,
+		Context:       ctx,
+	}
 	var n T = new(N)
 	err := BuildModel(vn, n)
 	if err != nil {
-		return NodeReference[T]{}, err
+		return NodeReference[T]{}, origin, err
 	}
 	if ln == nil {
-		return NodeReference[T]{}, nil
+		return NodeReference[T]{}, origin, nil
 	}
 	err = n.Build(ctx, ln, vn, idx)
 	if err != nil {
-		return NodeReference[T]{}, err
+		return NodeReference[T]{}, origin, err
 	}
 
 	// if this is a reference, keep track of the reference in the value
@@ -346,9 +392,9 @@ func ExtractObject[T Buildable[N], N any](ctx context.Context, label string, roo
 
 	// do we want to throw an error as well if circular error reporting is on?
 	if circError != nil && !idx.AllowCircularReferenceResolving() {
-		return res, circError
+		return res, origin, circError
 	}
-	return res, nil
+	return res, origin, nil
 }
 
 func SetReference(obj any, ref string, refNode *yaml.Node) {
@@ -492,6 +538,29 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 	root *yaml.Node,
 	idx *index.SpecIndex,
 	includeExtensions bool,
+) (*orderedmap.Map[KeyReference[string], ValueReference[PT]], error) {
+	return extractMapNoLookup[PT, N](ctx, root, idx, includeExtensions, nil)
+}
+
+// ExtractMapNoLookupWithOrigin is the same as ExtractMapNoLookupExtensions, except that an *Origin is also returned
+// for every entry in the map, keyed by the map key, in the same order as the extracted map.
+func ExtractMapNoLookupWithOrigin[PT Buildable[N], N any](
+	ctx context.Context,
+	root *yaml.Node,
+	idx *index.SpecIndex,
+	includeExtensions bool,
+) (*orderedmap.Map[KeyReference[string], ValueReference[PT]], *orderedmap.Map[string, *Origin], error) {
+	origins := orderedmap.New[string, *Origin]()
+	valueMap, err := extractMapNoLookup[PT, N](ctx, root, idx, includeExtensions, origins)
+	return valueMap, origins, err
+}
+
+func extractMapNoLookup[PT Buildable[N], N any](
+	ctx context.Context,
+	root *yaml.Node,
+	idx *index.SpecIndex,
+	includeExtensions bool,
+	origins *orderedmap.Map[string, *Origin],
 ) (*orderedmap.Map[KeyReference[string], ValueReference[PT]], error) {
 	valueMap := orderedmap.New[KeyReference[string], ValueReference[PT]]()
 	var circError error
@@ -578,6 +647,17 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 					},
 					v,
 				)
+				if origins != nil {
+					origins.Set(currentKey.Value, &Origin{
+						Label:         currentKey.Value,
+						KeyNode:       currentKey,
+						ValueNode:     node,
+						Reference:     referenceValue,
+						ReferenceNode: refNode,
+						Index:         foundIndex,
+						Context:       foundContext,
+					})
+				}
 			}
 		}
 	}
@@ -781,7 +861,7 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 			}, nil
 		}
 
-		err := datamodel.TranslatePipeline[buildInput, mappingResult[PT]](in, out, translateFunc)
+		err := datamodel.TranslatePipelineWithContext[buildInput, mappingResult[PT]](ctx, in, out, translateFunc)
 		wg.Wait()
 		if err != nil {
 			return nil, labelNode, valueNode, err
@@ -895,9 +975,15 @@ func ValueToString(v any) string {
 // LocateRefEnd will perform a complete lookup for a $ref node. This function searches the entire index for
 // the reference being supplied. If there is a match found, the reference *yaml.Node is returned.
 // the function operates recursively and will keep iterating through references until it finds a non-reference
-// node.
+// node. If the index has been configured with a MaxRefDepth limit, that limit is used instead of the default of 100.
 func LocateRefEnd(ctx context.Context, root *yaml.Node, idx *index.SpecIndex, depth int) (*yaml.Node, *index.SpecIndex, error, context.Context) {
 	depth++
+	if idx != nil && idx.GetConfig() != nil && idx.GetConfig().Limits.MaxRefDepth > 0 {
+		_, _, rv := utils.IsNodeRefValue(root)
+		if err := idx.GetConfig().Limits.CheckRefDepth(rv, depth); err != nil {
+			return nil, nil, err, ctx
+		}
+	}
 	if depth > 100 {
 		return nil, nil, fmt.Errorf("reference resolution depth exceeded, possible circular reference"), ctx
 	}
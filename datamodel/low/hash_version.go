@@ -0,0 +1,158 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// HashVersion identifies the algorithm used to produce a model hash.
+//
+// Stability guarantee: the output of a hash for a given HashVersion will never change between releases of
+// libopenapi. When the hashing internals of any model change in a way that would change its hash, a new HashVersion
+// is introduced and CurrentHashVersion is moved forward. Earlier versions remain available through HashWithVersion,
+// so fingerprints stored with an earlier version can always be recomputed and compared.
+//
+// Hash() always uses CurrentHashVersion. Use HashWithVersion or Fingerprint for anything that is stored long-term.
+type HashVersion int
+
+const (
+	// HashVersion1 is the original hashing algorithm: a SHA-256 of the model's significant values.
+	HashVersion1 HashVersion = 1
+
+	// HashVersion2 adds the $defs, $dynamicRef and $dynamicAnchor keywords to the hash of a schema, and so to the
+	// hash of every model that contains a schema.
+	HashVersion2 HashVersion = 2
+
+	// CurrentHashVersion is the version used by every Hash() method in this release.
+	CurrentHashVersion = HashVersion2
+)
+
+// ErrUnsupportedHashVersion is returned when a hash is requested for a version that a model cannot produce.
+var ErrUnsupportedHashVersion = errors.New("unsupported hash version")
+
+// VersionedHashable is implemented by models whose hash has changed between versions, so they can still produce
+// the hashes of earlier versions. HashForVersion returns false if the model cannot produce a hash for the requested
+// version. Models that do not implement VersionedHashable hash the same way in every version.
+type VersionedHashable interface {
+	Hashable
+	HashForVersion(version HashVersion) ([32]byte, bool)
+}
+
+// String returns the version identifier used in fingerprints, e.g. 'v1'.
+func (v HashVersion) String() string {
+	return fmt.Sprintf("v%d", int(v))
+}
+
+func (v HashVersion) supported() bool {
+	return v >= HashVersion1 && v <= CurrentHashVersion
+}
+
+// HashForSupportedVersion is a helper for implementing VersionedHashable: it calls hash with the requested version,
+// or returns false if the version is not one this release knows about.
+func HashForSupportedVersion(version HashVersion, hash func(HashVersion) [32]byte) ([32]byte, bool) {
+	if !version.supported() {
+		return [32]byte{}, false
+	}
+	return hash(version), true
+}
+
+// GenerateHashStringForVersion is the same as GenerateHashString, but a VersionedHashable is hashed using the
+// requested HashVersion. It is used by models that contain other models to produce a hash for a version.
+func GenerateHashStringForVersion(v any, version HashVersion) string {
+	if vh, ok := v.(VersionedHashable); ok && vh != nil {
+		if hash, found := vh.HashForVersion(version); found {
+			return fmt.Sprintf(HASH, hash)
+		}
+	}
+	return GenerateHashString(v)
+}
+
+// AppendMapHashesForVersion is the same as AppendMapHashes, but every value is hashed using the requested
+// HashVersion.
+func AppendMapHashesForVersion[v any](
+	a []string,
+	m *orderedmap.Map[KeyReference[string], ValueReference[v]],
+	version HashVersion,
+) []string {
+	for k, v := range orderedmap.SortAlpha(m).FromOldest() {
+		a = append(a, fmt.Sprintf("%s-%s", k.Value, GenerateHashStringForVersion(v.Value, version)))
+	}
+	return a
+}
+
+// HashWithVersion returns a hash of h, computed using the requested HashVersion. Returns ErrUnsupportedHashVersion
+// if the model cannot produce a hash for that version.
+func HashWithVersion(h Hashable, version HashVersion) ([32]byte, error) {
+	if h == nil {
+		return [32]byte{}, errors.New("unable to hash, nothing to hash")
+	}
+	if vh, ok := h.(VersionedHashable); ok {
+		if hash, found := vh.HashForVersion(version); found {
+			return hash, nil
+		}
+	} else if version.supported() {
+		return h.Hash(), nil
+	}
+	if version == CurrentHashVersion {
+		return h.Hash(), nil
+	}
+	return [32]byte{}, fmt.Errorf("%w: %s (current version is %s)", ErrUnsupportedHashVersion, version,
+		CurrentHashVersion)
+}
+
+// Fingerprint returns a hash of h prefixed with the hash version used to produce it, for example 'v1:1f3c...'.
+// Fingerprints are suitable for long-term storage, use CompareFingerprint to check a model against one later.
+func Fingerprint(h Hashable) string {
+	fp, _ := FingerprintWithVersion(h, CurrentHashVersion)
+	return fp
+}
+
+// FingerprintWithVersion is the same as Fingerprint, but the hash is computed using the requested HashVersion.
+func FingerprintWithVersion(h Hashable, version HashVersion) (string, error) {
+	hash, err := HashWithVersion(h, version)
+	if err != nil {
+		return "", err
+	}
+	return version.String() + ":" + HashToString(hash), nil
+}
+
+// ParseFingerprint splits a fingerprint created by Fingerprint into its HashVersion and hash.
+func ParseFingerprint(fingerprint string) (HashVersion, [32]byte, error) {
+	var hash [32]byte
+	v, h, found := strings.Cut(fingerprint, ":")
+	if !found || !strings.HasPrefix(v, "v") {
+		return 0, hash, fmt.Errorf("invalid fingerprint '%s', expected '<version>:<hash>'", fingerprint)
+	}
+	n, err := strconv.Atoi(v[1:])
+	if err != nil || n <= 0 {
+		return 0, hash, fmt.Errorf("invalid fingerprint '%s', bad version '%s'", fingerprint, v)
+	}
+	b, err := hex.DecodeString(h)
+	if err != nil || len(b) != len(hash) {
+		return 0, hash, fmt.Errorf("invalid fingerprint '%s', bad hash", fingerprint)
+	}
+	copy(hash[:], b)
+	return HashVersion(n), hash, nil
+}
+
+// CompareFingerprint returns true if h produces the same hash as a stored fingerprint, using the hash version
+// recorded in the fingerprint.
+func CompareFingerprint(h Hashable, fingerprint string) (bool, error) {
+	version, stored, err := ParseFingerprint(fingerprint)
+	if err != nil {
+		return false, err
+	}
+	hash, err := HashWithVersion(h, version)
+	if err != nil {
+		return false, err
+	}
+	return hash == stored, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hashV1 struct{ v string }
+
+func (h *hashV1) Hash() [32]byte {
+	return sha256.Sum256([]byte(h.v))
+}
+
+// hashV2 pretends to be a model whose hashing changed in version 2, but can still produce version 1 hashes.
+type hashV2 struct{ v string }
+
+func (h *hashV2) Hash() [32]byte {
+	return sha256.Sum256([]byte("v2|" + h.v))
+}
+
+func (h *hashV2) HashForVersion(version HashVersion) ([32]byte, bool) {
+	switch version {
+	case HashVersion1:
+		return sha256.Sum256([]byte(h.v)), true
+	case HashVersion2:
+		return h.Hash(), true
+	}
+	return [32]byte{}, false
+}
+
+func TestHashWithVersion(t *testing.T) {
+	h := &hashV1{v: "pizza"}
+	hash, err := HashWithVersion(h, CurrentHashVersion)
+	require.NoError(t, err)
+	assert.Equal(t, h.Hash(), hash)
+
+	// a model that is not versioned hashes the same way in every version.
+	old, err := HashWithVersion(h, HashVersion1)
+	require.NoError(t, err)
+	assert.Equal(t, hash, old)
+
+	_, err = HashWithVersion(h, HashVersion(3))
+	assert.True(t, errors.Is(err, ErrUnsupportedHashVersion))
+	assert.EqualError(t, err, "unsupported hash version: v3 (current version is v2)")
+
+	_, err = HashWithVersion(nil, CurrentHashVersion)
+	assert.Error(t, err)
+
+	// a model with a changed algorithm still produces the prior version.
+	old, err = HashWithVersion(&hashV2{v: "pizza"}, HashVersion1)
+	require.NoError(t, err)
+	assert.Equal(t, hash, old)
+	newer, err := HashWithVersion(&hashV2{v: "pizza"}, HashVersion2)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, newer)
+}
+
+func TestFingerprint(t *testing.T) {
+	h := &hashV1{v: "pizza"}
+	fp := Fingerprint(h)
+	assert.Equal(t, "v2:"+HashToString(h.Hash()), fp)
+
+	version, hash, err := ParseFingerprint(fp)
+	require.NoError(t, err)
+	assert.Equal(t, HashVersion2, version)
+	assert.Equal(t, h.Hash(), hash)
+
+	same, err := CompareFingerprint(h, fp)
+	require.NoError(t, err)
+	assert.True(t, same)
+
+	// stored v1 fingerprints still match after the hashing algorithm changes.
+	fp, err = FingerprintWithVersion(h, HashVersion1)
+	require.NoError(t, err)
+	same, err = CompareFingerprint(&hashV2{v: "pizza"}, fp)
+	require.NoError(t, err)
+	assert.True(t, same)
+
+	same, err = CompareFingerprint(&hashV1{v: "burger"}, fp)
+	require.NoError(t, err)
+	assert.False(t, same)
+
+	_, err = FingerprintWithVersion(h, HashVersion(9))
+	assert.Error(t, err)
+	_, err = CompareFingerprint(h, "v9:"+HashToString(h.Hash()))
+	assert.ErrorIs(t, err, ErrUnsupportedHashVersion)
+}
+
+func TestParseFingerprint_Invalid(t *testing.T) {
+	for _, fp := range []string{"", "nope", "x1:abc", "v0:abc", "vX:abc", "v1:zz", "v1:abcd"} {
+		_, _, err := ParseFingerprint(fp)
+		assert.Error(t, err, fp)
+	}
+}
@@ -11,6 +11,20 @@
 //
 // Navigating maps that use a KeyReference as a key is tricky, because there is no easy way to provide a lookup.
 // Convenience methods for lookup up properties in a low-level model have therefore been provided.
+//
+// # Building custom low-level models
+//
+// The Extract* functions (ExtractObject, ExtractArray, ExtractMap, ExtractMapNoLookup and friends) are the same
+// building blocks used by every model in this library, and they work with any type that satisfies Buildable.
+// Third-party types can use them to build their own low-level models from a yaml.Node, with full reference
+// resolution against the index (and rolodex). The *WithOrigin variants additionally return an Origin that describes
+// where each model was built from, including the index and context that were used after following any references.
+//
+// # Hashing
+//
+// Every low-level model implements Hashable. Hashes are versioned (see HashVersion): the hash produced for a given
+// version never changes between releases, so use Fingerprint (or HashWithVersion) when hashes are stored long-term,
+// rather than the result of Hash(), which always uses CurrentHashVersion.
 package low
 
 import "gopkg.in/yaml.v3"
@@ -0,0 +1,53 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// Origin describes where a low-level model was built from when using one of the *WithOrigin extraction functions.
+//
+// When the extracted value was a reference, KeyNode and ValueNode point to the resolved location, ReferenceNode
+// points to the original $ref node, and Index and Context are the ones the model was built with (which may belong to
+// a different file in the rolodex than the one that was passed in).
+type Origin struct {
+	// Label is the key used to locate the value (the map key for map entries).
+	Label string
+
+	// KeyNode is the yaml.Node of the key the value was found under.
+	KeyNode *yaml.Node
+
+	// ValueNode is the yaml.Node the model was built from.
+	ValueNode *yaml.Node
+
+	// Reference is the $ref value that was followed, or an empty string if the value was inline.
+	Reference string
+
+	// ReferenceNode is the node that contained the $ref, or nil if the value was inline.
+	ReferenceNode *yaml.Node
+
+	// Index is the index that owns ValueNode.
+	Index *index.SpecIndex
+
+	// Context is the context the model was built with.
+	Context context.Context
+}
+
+// IsReference returns true if the value was located by following a $ref.
+func (o *Origin) IsReference() bool {
+	return o != nil && o.Reference != ""
+}
+
+// GetSpecAbsolutePath returns the absolute path of the file (or URL) the value was located in, or an empty string
+// if no index is available.
+func (o *Origin) GetSpecAbsolutePath() string {
+	if o == nil || o.Index == nil {
+		return ""
+	}
+	return o.Index.GetSpecAbsolutePath()
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// streaming is a custom, third-party low-level model, built with the standard extraction functions.
+type streaming struct {
+	Protocol NodeReference[string]
+	Events   NodeReference[[]ValueReference[string]]
+	built    bool
+}
+
+func (s *streaming) Build(_ context.Context, _, _ *yaml.Node, _ *index.SpecIndex) error {
+	s.built = true
+	return nil
+}
+
+func originTestIndex(t *testing.T) *index.SpecIndex {
+	yml := `components:
+  schemas:
+    sse:
+      protocol: sse
+      events:
+        - ping
+        - pong`
+
+	var idxNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(yml), &idxNode))
+	return index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+}
+
+func TestExtractObjectWithOrigin(t *testing.T) {
+	idx := originTestIndex(t)
+
+	yml := `x-streaming:
+  protocol: websocket`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	res, origin, err := ExtractObjectWithOrigin[*streaming](context.Background(), "x-streaming", cNode.Content[0], idx)
+	require.NoError(t, err)
+	require.NotNil(t, origin)
+	assert.True(t, res.Value.built)
+	assert.Equal(t, "websocket", res.Value.Protocol.Value)
+	assert.Equal(t, "x-streaming", origin.Label)
+	assert.False(t, origin.IsReference())
+	assert.Nil(t, origin.ReferenceNode)
+	assert.Equal(t, res.KeyNode, origin.KeyNode)
+	assert.Equal(t, res.ValueNode, origin.ValueNode)
+	assert.Equal(t, idx, origin.Index)
+	assert.NotNil(t, origin.Context)
+}
+
+func TestExtractObjectWithOrigin_Ref(t *testing.T) {
+	idx := originTestIndex(t)
+
+	yml := `x-streaming:
+  $ref: '#/components/schemas/sse'`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	res, origin, err := ExtractObjectWithOrigin[*streaming](context.Background(), "x-streaming", cNode.Content[0], idx)
+	require.NoError(t, err)
+	assert.Equal(t, "sse", res.Value.Protocol.Value)
+	assert.Len(t, res.Value.Events.Value, 2)
+	assert.True(t, origin.IsReference())
+	assert.Equal(t, "#/components/schemas/sse", origin.Reference)
+	assert.NotNil(t, origin.ReferenceNode)
+	assert.Equal(t, 4, origin.ValueNode.Line)
+	assert.Equal(t, res.GetReference(), origin.Reference)
+}
+
+func TestExtractObjectWithOrigin_Missing(t *testing.T) {
+	idx := originTestIndex(t)
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`nothing: here`), &cNode)
+
+	res, origin, err := ExtractObjectWithOrigin[*streaming](context.Background(), "x-streaming", cNode.Content[0], idx)
+	assert.NoError(t, err)
+	require.NotNil(t, origin)
+	assert.Equal(t, "x-streaming", origin.Label)
+	assert.Nil(t, origin.KeyNode)
+	assert.Nil(t, origin.ValueNode)
+	assert.False(t, origin.IsReference())
+	assert.Nil(t, res.Value)
+}
+
+func TestExtractObjectWithOrigin_BadRef(t *testing.T) {
+	idx := originTestIndex(t)
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`x-streaming:
+  $ref: '#/components/schemas/nope'`), &cNode)
+
+	_, origin, err := ExtractObjectWithOrigin[*streaming](context.Background(), "x-streaming", cNode.Content[0], idx)
+	assert.Error(t, err)
+	require.NotNil(t, origin)
+	assert.Equal(t, "#/components/schemas/nope", origin.Reference)
+	assert.Equal(t, "x-streaming", origin.KeyNode.Value)
+	assert.Same(t, cNode.Content[0].Content[1], origin.ReferenceNode)
+
+	// the root itself is the reference.
+	_ = yaml.Unmarshal([]byte(`$ref: '#/components/schemas/nope'`), &cNode)
+	_, origin, err = ExtractObjectWithOrigin[*streaming](context.Background(), "x-streaming", cNode.Content[0], idx)
+	assert.Error(t, err)
+	require.NotNil(t, origin)
+	assert.Equal(t, "#/components/schemas/nope", origin.Reference)
+	assert.Same(t, cNode.Content[0], origin.ReferenceNode)
+}
+
+func TestExtractObjectRawWithOrigin(t *testing.T) {
+	idx := originTestIndex(t)
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`$ref: '#/components/schemas/sse'`), &cNode)
+
+	n, origin, err := ExtractObjectRawWithOrigin[*streaming](context.Background(), nil, cNode.Content[0], idx)
+	require.NoError(t, err)
+	assert.Equal(t, "sse", n.Protocol.Value)
+	assert.True(t, origin.IsReference())
+	assert.Empty(t, origin.Label)
+	assert.Equal(t, idx, origin.Index)
+
+	// ExtractObjectRaw reports the same reference state.
+	_, rerr, isRef, rv := ExtractObjectRaw[*streaming](context.Background(), nil, cNode.Content[0], idx)
+	assert.NoError(t, rerr)
+	assert.True(t, isRef)
+	assert.Equal(t, origin.Reference, rv)
+}
+
+func TestExtractObjectRawWithOrigin_BadRef(t *testing.T) {
+	idx := originTestIndex(t)
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`$ref: '#/components/schemas/nope'`), &cNode)
+
+	_, origin, err := ExtractObjectRawWithOrigin[*streaming](context.Background(), nil, cNode.Content[0], idx)
+	assert.Error(t, err)
+	require.NotNil(t, origin)
+	assert.Equal(t, "#/components/schemas/nope", origin.Reference)
+	assert.Same(t, cNode.Content[0], origin.ReferenceNode)
+}
+
+func TestExtractMapNoLookupWithOrigin(t *testing.T) {
+	idx := originTestIndex(t)
+
+	yml := `one:
+  protocol: websocket
+two:
+  $ref: '#/components/schemas/sse'
+x-three:
+  protocol: grpc`
+
+	var cNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &cNode)
+
+	m, origins, err := ExtractMapNoLookupWithOrigin[*streaming](context.Background(), cNode.Content[0], idx, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 2, origins.Len())
+
+	one := origins.GetOrZero("one")
+	assert.False(t, one.IsReference())
+	assert.Equal(t, 2, one.ValueNode.Line)
+
+	two := origins.GetOrZero("two")
+	assert.True(t, two.IsReference())
+	assert.Equal(t, "#/components/schemas/sse", two.Reference)
+	assert.Equal(t, "sse", FindItemInOrderedMap("two", m).Value.Protocol.Value)
+
+	m, origins, err = ExtractMapNoLookupWithOrigin[*streaming](context.Background(), cNode.Content[0], idx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, m.Len())
+	var keys []string
+	for k := range origins.KeysFromOldest() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []string{"one", "two", "x-three"}, keys)
+}
@@ -196,6 +196,28 @@ func (n NodeReference[T]) GetValueUntyped() any {
 	return n.Value
 }
 
+// JSONPointer will return a JSON Pointer (RFC 6901) to the value node, relative to root. yaml nodes do not know
+// their parents, so root must be supplied; it is usually the root node of the index the reference was built
+// with (idx.GetRootNode()). Returns false if the value node cannot be found under root.
+func (n NodeReference[T]) JSONPointer(root *yaml.Node) (string, bool) {
+	if n.ValueNode == nil {
+		return "", false
+	}
+	return utils.JSONPointerForNode(root, n.ValueNode)
+}
+
+// Range will return the span of the reference in the document, from the start of the key node to the end of the
+// value node (including all of its children), so the whole of an operation or schema can be highlighted.
+// See utils.GetNodeRange for how the end is computed.
+func (n NodeReference[T]) Range() utils.NodeRange {
+	return utils.MergeNodeRanges(utils.GetNodeRange(n.KeyNode), utils.GetNodeRange(n.ValueNode))
+}
+
+// Comments returns the comments attached to the key and value of the reference combined, key comments first.
+func (n NodeReference[T]) Comments() Comments {
+	return MergeComments(NodeComments(n.KeyNode), NodeComments(n.ValueNode))
+}
+
 // IsEmpty will return true if this reference has no key or value nodes assigned (it's been ignored)
 func (n ValueReference[T]) IsEmpty() bool {
 	return n.ValueNode == nil
@@ -220,6 +242,26 @@ func (n ValueReference[T]) GetValueNode() *yaml.Node {
 	return n.ValueNode
 }
 
+// JSONPointer will return a JSON Pointer (RFC 6901) to the value node, relative to root.
+// See NodeReference.JSONPointer for details.
+func (n ValueReference[T]) JSONPointer(root *yaml.Node) (string, bool) {
+	if n.ValueNode == nil {
+		return "", false
+	}
+	return utils.JSONPointerForNode(root, n.ValueNode)
+}
+
+// Range will return the span of the value node in the document, including all of its children.
+// See utils.GetNodeRange for how the end is computed.
+func (n ValueReference[T]) Range() utils.NodeRange {
+	return utils.GetNodeRange(n.ValueNode)
+}
+
+// Comments returns the comments attached to the value node, for a scalar value this is where the line comment is.
+func (n ValueReference[T]) Comments() Comments {
+	return NodeComments(n.ValueNode)
+}
+
 // GetValue will return the  raw value of the node
 func (n ValueReference[T]) GetValue() T {
 	return n.Value
@@ -258,6 +300,16 @@ func (n KeyReference[T]) GetKeyNode() *yaml.Node {
 	return n.KeyNode
 }
 
+// Range will return the span of the key node in the document.
+func (n KeyReference[T]) Range() utils.NodeRange {
+	return utils.GetNodeRange(n.KeyNode)
+}
+
+// Comments returns the comments attached to the key node, this is where head and foot comments are.
+func (n KeyReference[T]) Comments() Comments {
+	return NodeComments(n.KeyNode)
+}
+
 // GenerateMapKey will return a string based on the line and column number of the node, e.g. 33:56 for line 33, col 56.
 func (n KeyReference[T]) GenerateMapKey() string {
 	return fmt.Sprintf("%d:%d", n.KeyNode.Line, n.KeyNode.Column)
@@ -712,3 +712,60 @@ func TestKeyReference_MarshalYAML(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, kn, on)
 }
+
+func TestNodeReference_JSONPointer(t *testing.T) {
+	yml := `paths:
+  /pets:
+    get:
+      description: list`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	desc, _ := utils.FindNodeByJSONPointer(&root, "/paths/~1pets/get/description")
+
+	n := NodeReference[string]{Value: "list", ValueNode: desc}
+	p, ok := n.JSONPointer(&root)
+	assert.True(t, ok)
+	assert.Equal(t, "/paths/~1pets/get/description", p)
+
+	v := ValueReference[string]{Value: "list", ValueNode: desc}
+	p, ok = v.JSONPointer(&root)
+	assert.True(t, ok)
+	assert.Equal(t, "/paths/~1pets/get/description", p)
+
+	_, ok = NodeReference[string]{}.JSONPointer(&root)
+	assert.False(t, ok)
+	_, ok = ValueReference[string]{}.JSONPointer(&root)
+	assert.False(t, ok)
+}
+
+func TestReference_Range(t *testing.T) {
+	yml := `paths:
+  /pets:
+    get:
+      description: |
+        list all
+        the pets
+      operationId: listPets`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	pets := root.Content[0].Content[1]
+	getKey, get := pets.Content[1].Content[0], pets.Content[1].Content[1]
+
+	n := NodeReference[string]{KeyNode: getKey, ValueNode: get}
+	assert.Equal(t, utils.NodeRange{StartLine: 3, StartColumn: 5, EndLine: 7, EndColumn: 28}, n.Range())
+
+	v := ValueReference[string]{ValueNode: get}
+	assert.Equal(t, utils.NodeRange{StartLine: 4, StartColumn: 7, EndLine: 7, EndColumn: 28}, v.Range())
+
+	k := KeyReference[string]{Value: "get", KeyNode: getKey}
+	assert.Equal(t, utils.NodeRange{StartLine: 3, StartColumn: 5, EndLine: 3, EndColumn: 8}, k.Range())
+
+	desc := ValueReference[string]{ValueNode: get.Content[1]}
+	assert.Equal(t, utils.NodeRange{StartLine: 4, StartColumn: 20, EndLine: 7, EndColumn: 1}, desc.Range())
+
+	assert.True(t, NodeReference[string]{}.Range().IsEmpty())
+	assert.True(t, ValueReference[string]{}.Range().IsEmpty())
+	assert.True(t, KeyReference[string]{}.Range().IsEmpty())
+}
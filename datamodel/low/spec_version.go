@@ -0,0 +1,22 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import "github.com/pb33f/libopenapi/index"
+
+// SpecVersion returns the numeric version (for example 3.1) of the specification an index was created for, or 0 if
+// the version is not known. It's used to only build properties that were added in later versions of OpenAPI.
+func SpecVersion(idx *index.SpecIndex) float32 {
+	if idx == nil || idx.GetConfig() == nil || idx.GetConfig().SpecInfo == nil {
+		return 0
+	}
+	return idx.GetConfig().SpecInfo.VersionNumeric
+}
+
+// SupportsVersion returns true if the specification an index was created for is at least version (for example 3.2).
+// If the version is not known (there is no index, or the index has no SpecInfo), everything is supported.
+func SupportsVersion(idx *index.SpecIndex, version float32) bool {
+	v := SpecVersion(idx)
+	return v == 0 || v >= version
+}
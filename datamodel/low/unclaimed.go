@@ -0,0 +1,141 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Diagnostic describes something found in a document that does not stop it from being built, but is most likely
+// a mistake, for example a root key that is not part of the specification.
+type Diagnostic struct {
+	Message   string
+	Key       string     // the key the diagnostic is about.
+	KeyNode   *yaml.Node // the node of the key, used for the position.
+	ValueNode *yaml.Node
+	Line      int
+	Column    int
+}
+
+// Error returns the message of the Diagnostic along with its position, so a Diagnostic can be used as an error.
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%s [%d:%d]", d.Message, d.Line, d.Column)
+}
+
+// ExtractUnclaimed returns every key of the root map node that is not in known, and is not an extension (`x-`).
+// Unclaimed content is not part of the specification, and would otherwise be silently ignored when building a model:
+// a `definitions` block pasted into an OpenAPI 3 document from Swagger, or a block of settings for some tool.
+// Keys are returned in document order, along with their positions.
+func ExtractUnclaimed(root *yaml.Node, known ...string) *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]] {
+	root = utils.NodeAlias(root)
+	if root == nil || !utils.IsNodeMap(root) {
+		return nil
+	}
+	var unclaimed *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		k, v := root.Content[i], root.Content[i+1]
+		if k.Tag == "!!merge" || strings.HasPrefix(strings.ToLower(k.Value), "x-") || slices.Contains(known, k.Value) {
+			continue
+		}
+		if unclaimed == nil {
+			unclaimed = orderedmap.New[KeyReference[string], ValueReference[*yaml.Node]]()
+		}
+		unclaimed.Set(KeyReference[string]{Value: k.Value, KeyNode: k},
+			ValueReference[*yaml.Node]{Value: v, ValueNode: v})
+	}
+	return unclaimed
+}
+
+// HasUnknownFields is implemented by low-level models that keep the keys they don't recognize.
+type HasUnknownFields interface {
+	// GetUnknownFields returns every key of the object that is not part of the specification, and is not an extension.
+	GetUnknownFields() *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]]
+}
+
+// modelFields caches the (lower case) keys of every model type seen by ExtractUnknownFields.
+var modelFields sync.Map
+
+// ExtractUnknownFields returns every key of the root map node that is neither a field of the model, nor an
+// extension (`x-`), nor a `$ref`. The fields of a model are its NodeReference fields (or slices of them), matched to keys without case
+// (and without a leading `$`), the same way BuildModel finds them. Keys that don't match a field name are added
+// with known, such as `$schema` for the SchemaTypeRef of a Schema.
+//
+// Unknown fields are never built into the model, they are kept so they can be flagged by validators, and rendered
+// back out as they were found.
+func ExtractUnknownFields(root *yaml.Node, model any, known ...string) *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]] {
+	root = utils.NodeAlias(root)
+	if root == nil || !utils.IsNodeMap(root) {
+		return nil
+	}
+	fields := fieldsOf(reflect.TypeOf(model))
+	var unknown *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		k, v := root.Content[i], root.Content[i+1]
+		key := strings.ToLower(k.Value)
+		if k.Tag == "!!merge" || strings.HasPrefix(key, "x-") || key == "$ref" ||
+			fields[strings.TrimPrefix(key, "$")] || slices.Contains(known, k.Value) {
+			continue
+		}
+		if unknown == nil {
+			unknown = orderedmap.New[KeyReference[string], ValueReference[*yaml.Node]]()
+		}
+		unknown.Set(KeyReference[string]{Value: k.Value, KeyNode: k},
+			ValueReference[*yaml.Node]{Value: v, ValueNode: v})
+	}
+	return unknown
+}
+
+func fieldsOf(t reflect.Type) map[string]bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if f, ok := modelFields.Load(t); ok {
+		return f.(map[string]bool)
+	}
+	fields := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+		if ft.PkgPath() == reflect.TypeOf(NodeReference[any]{}).PkgPath() && strings.HasPrefix(ft.Name(), "NodeReference[") {
+			fields[strings.ToLower(f.Name)] = true
+		}
+	}
+	modelFields.Store(t, fields)
+	return fields
+}
+
+// UnclaimedDiagnostics creates a Diagnostic for every unclaimed key, in document order. The spec name is used
+// in the message (e.g. OpenAPI 3), and hints can supply a suggestion for keys that are commonly misplaced, for example
+// `definitions` in an OpenAPI 3 document should be `components.schemas`.
+func UnclaimedDiagnostics(spec string, unclaimed *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]],
+	hints map[string]string,
+) []*Diagnostic {
+	var diagnostics []*Diagnostic
+	for k, v := range unclaimed.FromOldest() {
+		msg := fmt.Sprintf("root key '%s' is not part of the %s specification, it has been ignored", k.Value, spec)
+		if hint, ok := hints[k.Value]; ok {
+			msg = fmt.Sprintf("%s (%s)", msg, hint)
+		}
+		d := &Diagnostic{Message: msg, Key: k.Value, KeyNode: k.KeyNode, ValueNode: v.ValueNode}
+		if k.KeyNode != nil {
+			d.Line, d.Column = k.KeyNode.Line, k.KeyNode.Column
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
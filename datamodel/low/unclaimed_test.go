@@ -0,0 +1,87 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExtractUnclaimed(t *testing.T) {
+	yml := `known: yes
+x-ext: 1
+X-Upper: 2
+tool:
+  setting: true
+other: value`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+
+	unclaimed := ExtractUnclaimed(root.Content[0], "known")
+	require.Equal(t, 2, orderedmap.Len(unclaimed))
+	assert.Equal(t, "tool", unclaimed.First().Key().Value)
+	assert.Equal(t, 4, unclaimed.First().Key().KeyNode.Line)
+	assert.Equal(t, "other", unclaimed.First().Next().Key().Value)
+	assert.Equal(t, "value", unclaimed.First().Next().Value().Value.Value)
+
+	assert.Nil(t, ExtractUnclaimed(root.Content[0], "known", "tool", "other"))
+	assert.Nil(t, ExtractUnclaimed(nil))
+	assert.Nil(t, ExtractUnclaimed(&yaml.Node{Kind: yaml.SequenceNode}))
+}
+
+func TestUnclaimedDiagnostics(t *testing.T) {
+	yml := `tool: 1
+definitions: {}`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+
+	diagnostics := UnclaimedDiagnostics("Test", ExtractUnclaimed(root.Content[0]),
+		map[string]string{"definitions": "try components"})
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "root key 'tool' is not part of the Test specification, it has been ignored", diagnostics[0].Message)
+	assert.Equal(t, "tool", diagnostics[0].Key)
+	assert.Equal(t, "1", diagnostics[0].ValueNode.Value)
+	assert.Equal(t, "root key 'definitions' is not part of the Test specification, it has been ignored "+
+		"(try components) [2:1]", diagnostics[1].Error())
+
+	assert.Empty(t, UnclaimedDiagnostics("Test", nil, nil))
+}
+
+func TestExtractUnknownFields(t *testing.T) {
+	yml := `name: pizza
+$ref: '#/components/things/pizza'
+$anchor: pie
+X-Ext: 1
+toppings:
+  - cheese
+$schema: dialect`
+
+	type model struct {
+		Name       NodeReference[string]
+		Anchor     NodeReference[string]
+		Tags       []NodeReference[string]
+		Extensions *orderedmap.Map[KeyReference[string], ValueReference[*yaml.Node]]
+		KeyNode    *yaml.Node
+	}
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+
+	unknown := ExtractUnknownFields(root.Content[0], &model{})
+	require.Equal(t, 2, orderedmap.Len(unknown))
+	assert.Equal(t, "toppings", unknown.First().Key().Value)
+	assert.Equal(t, 5, unknown.First().Key().KeyNode.Line)
+	assert.Equal(t, "$schema", unknown.First().Next().Key().Value)
+
+	unknown = ExtractUnknownFields(root.Content[0], &model{}, "$schema", "toppings")
+	assert.Nil(t, unknown)
+	assert.Nil(t, ExtractUnknownFields(nil, &model{}))
+	assert.Nil(t, ExtractUnknownFields(&yaml.Node{Kind: yaml.SequenceNode}, &model{}))
+}
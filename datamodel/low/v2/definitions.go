@@ -25,6 +25,12 @@ import (
 //   - https://swagger.io/specification/v2/#parametersDefinitionsObject
 type ParameterDefinitions struct {
 	Definitions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*Parameter]]
+	RootNode    *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Parameter Definitions object
+func (pd *ParameterDefinitions) GetRootNode() *yaml.Node {
+	return pd.RootNode
 }
 
 // ResponsesDefinitions is a low-level representation of a Swagger / OpenAPI 2 Responses Definitions object.
@@ -34,6 +40,12 @@ type ParameterDefinitions struct {
 //   - https://swagger.io/specification/v2/#responsesDefinitionsObject
 type ResponsesDefinitions struct {
 	Definitions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*Response]]
+	RootNode    *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Responses Definitions object
+func (r *ResponsesDefinitions) GetRootNode() *yaml.Node {
+	return r.RootNode
 }
 
 // SecurityDefinitions is a low-level representation of a Swagger / OpenAPI 2 Security Definitions object.
@@ -43,6 +55,12 @@ type ResponsesDefinitions struct {
 //   - https://swagger.io/specification/v2/#securityDefinitionsObject
 type SecurityDefinitions struct {
 	Definitions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*SecurityScheme]]
+	RootNode    *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Security Definitions object
+func (s *SecurityDefinitions) GetRootNode() *yaml.Node {
+	return s.RootNode
 }
 
 // Definitions is a low-level representation of a Swagger / OpenAPI 2 Definitions object
@@ -51,7 +69,13 @@ type SecurityDefinitions struct {
 // arrays or models.
 //   - https://swagger.io/specification/v2/#definitionsObject
 type Definitions struct {
-	Schemas *orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.SchemaProxy]]
+	Schemas  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.SchemaProxy]]
+	RootNode *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Definitions object
+func (d *Definitions) GetRootNode() *yaml.Node {
+	return d.RootNode
 }
 
 // FindSchema will attempt to locate a base.SchemaProxy instance using a name.
@@ -76,6 +100,7 @@ func (s *SecurityDefinitions) FindSecurityDefinition(securityDef string) *low.Va
 
 // Build will extract all definitions into SchemaProxy instances.
 func (d *Definitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	d.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	type buildInput struct {
@@ -145,7 +170,7 @@ func (d *Definitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.
 		return definitionResult[*base.SchemaProxy]{k: value.label, v: v}, nil
 	}
 
-	err := datamodel.TranslatePipeline[buildInput, definitionResult[*base.SchemaProxy]](in, out, translateFunc)
+	err := datamodel.TranslatePipelineWithContext[buildInput, definitionResult[*base.SchemaProxy]](ctx, in, out, translateFunc)
 	wg.Wait()
 	if err != nil {
 		return err
@@ -157,15 +182,25 @@ func (d *Definitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.
 
 // Hash will return a consistent SHA256 Hash of the Definitions object
 func (d *Definitions) Hash() [32]byte {
+	return d.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Definitions object, computed using the requested low.HashVersion.
+func (d *Definitions) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, d.hash)
+}
+
+func (d *Definitions) hash(version low.HashVersion) [32]byte {
 	var f []string
 	for k := range orderedmap.SortAlpha(d.Schemas).KeysFromOldest() {
-		f = append(f, low.GenerateHashString(d.FindSchema(k.Value).Value))
+		f = append(f, low.GenerateHashStringForVersion(d.FindSchema(k.Value).Value, version))
 	}
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
 // Build will extract all ParameterDefinitions into Parameter instances.
 func (pd *ParameterDefinitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	pd.RootNode = root
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*Parameter])
 	var defLabel *yaml.Node
@@ -222,6 +257,7 @@ type definitionResult[T any] struct {
 
 // Build will extract all ResponsesDefinitions into Response instances.
 func (r *ResponsesDefinitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	r.RootNode = root
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*Response])
 	var defLabel *yaml.Node
@@ -272,6 +308,7 @@ func (r *ResponsesDefinitions) Build(ctx context.Context, _, root *yaml.Node, id
 
 // Build will extract all SecurityDefinitions into SecurityScheme instances.
 func (s *SecurityDefinitions) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	s.RootNode = root
 	errorChan := make(chan error)
 	resultChan := make(chan definitionResult[*SecurityScheme])
 	var defLabel *yaml.Node
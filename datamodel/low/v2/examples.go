@@ -19,7 +19,13 @@ import (
 // Allows sharing examples for operation responses
 //   - https://swagger.io/specification/v2/#exampleObject
 type Examples struct {
-	Values *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	Values   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Examples object
+func (e *Examples) GetRootNode() *yaml.Node {
+	return e.RootNode
 }
 
 // FindExample attempts to locate an example value, using a key label.
@@ -29,6 +35,7 @@ func (e *Examples) FindExample(name string) *low.ValueReference[*yaml.Node] {
 
 // Build will extract all examples and will attempt to unmarshal content into a map or slice based on type.
 func (e *Examples) Build(_ context.Context, _, root *yaml.Node, _ *index.SpecIndex) error {
+	e.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
 	var keyNode, currNode *yaml.Node
@@ -41,6 +41,13 @@ type Header struct {
 	Enum             low.NodeReference[[]low.ValueReference[*yaml.Node]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode         *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Header object
+func (h *Header) GetRootNode() *yaml.Node {
+	return h.RootNode
 }
 
 // FindExtension will attempt to locate an extension value using a name lookup.
@@ -53,11 +60,19 @@ func (h *Header) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.V
 	return h.Extensions
 }
 
+// GetUnknownFields returns the keys of the Header that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (h *Header) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return h.UnknownFields
+}
+
 // Build will build out items, extensions and default value from the supplied node.
 func (h *Header) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	h.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	h.Extensions = low.ExtractExtensions(root)
+	h.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	h.UnknownFields = low.ExtractUnknownFields(root, h)
 	items, err := low.ExtractObject[*Items](ctx, ItemsLabel, root, idx)
 	if err != nil {
 		return err
@@ -41,6 +41,13 @@ type Items struct {
 	Enum             low.NodeReference[[]low.ValueReference[*yaml.Node]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode         *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Items object
+func (i *Items) GetRootNode() *yaml.Node {
+	return i.RootNode
 }
 
 // FindExtension will attempt to locate an extension value using a name lookup.
@@ -53,6 +60,12 @@ func (i *Items) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.Va
 	return i.Extensions
 }
 
+// GetUnknownFields returns the keys of the Items that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (i *Items) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return i.UnknownFields
+}
+
 // Hash will return a consistent SHA256 Hash of the Items object
 func (i *Items) Hash() [32]byte {
 	var f []string
@@ -99,9 +112,11 @@ func (i *Items) Hash() [32]byte {
 
 // Build will build out items and default value.
 func (i *Items) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	i.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	i.Extensions = low.ExtractExtensions(root)
+	i.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	i.UnknownFields = low.ExtractUnknownFields(root, i)
 	items, iErr := low.ExtractObject[*Items](ctx, ItemsLabel, root, idx)
 	if iErr != nil {
 		return iErr
@@ -23,26 +23,37 @@ import (
 // It describes a single API operation on a path.
 //   - https://swagger.io/specification/v2/#operationObject
 type Operation struct {
-	Tags         low.NodeReference[[]low.ValueReference[string]]
-	Summary      low.NodeReference[string]
-	Description  low.NodeReference[string]
-	ExternalDocs low.NodeReference[*base.ExternalDoc]
-	OperationId  low.NodeReference[string]
-	Consumes     low.NodeReference[[]low.ValueReference[string]]
-	Produces     low.NodeReference[[]low.ValueReference[string]]
-	Parameters   low.NodeReference[[]low.ValueReference[*Parameter]]
-	Responses    low.NodeReference[*Responses]
-	Schemes      low.NodeReference[[]low.ValueReference[string]]
-	Deprecated   low.NodeReference[bool]
-	Security     low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]
-	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-}
-
-// Build will extract external docs, extensions, parameters, responses and security requirements.
+	Tags          low.NodeReference[[]low.ValueReference[string]]
+	Summary       low.NodeReference[string]
+	Description   low.NodeReference[string]
+	ExternalDocs  low.NodeReference[*base.ExternalDoc]
+	OperationId   low.NodeReference[string]
+	Consumes      low.NodeReference[[]low.ValueReference[string]]
+	Produces      low.NodeReference[[]low.ValueReference[string]]
+	Parameters    low.NodeReference[[]low.ValueReference[*Parameter]]
+	Responses     low.NodeReference[*Responses]
+	Schemes       low.NodeReference[[]low.ValueReference[string]]
+	Deprecated    low.NodeReference[bool]
+	Security      low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]
+	CodeSamples   low.NodeReference[[]low.ValueReference[*base.CodeSample]]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode      *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Operation object
+func (o *Operation) GetRootNode() *yaml.Node {
+	return o.RootNode
+}
+
+// Build will extract external docs, extensions, parameters, responses, security requirements and code samples.
 func (o *Operation) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	o.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	o.Extensions = low.ExtractExtensions(root)
+	o.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	o.UnknownFields = low.ExtractUnknownFields(root, o)
+	o.CodeSamples = base.ExtractCodeSamples(ctx, root, idx)
 
 	// extract externalDocs
 	extDocs, dErr := low.ExtractObject[*base.ExternalDoc](ctx, base.ExternalDocsLabel, root, idx)
@@ -83,11 +94,29 @@ func (o *Operation) Build(ctx context.Context, _, root *yaml.Node, idx *index.Sp
 			ValueNode: svn,
 		}
 	}
+
+	// if security is set, but no requirements are defined.
+	if sln != nil && sec == nil {
+		o.Security = low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]{
+			Value:     []low.ValueReference[*base.SecurityRequirement]{}, // empty
+			KeyNode:   sln,
+			ValueNode: svn,
+		}
+	}
 	return nil
 }
 
 // Hash will return a consistent SHA256 Hash of the Operation object
 func (o *Operation) Hash() [32]byte {
+	return o.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Operation object, computed using the requested low.HashVersion.
+func (o *Operation) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, o.hash)
+}
+
+func (o *Operation) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if !o.Summary.IsEmpty() {
 		f = append(f, o.Summary.Value)
@@ -102,10 +131,10 @@ func (o *Operation) Hash() [32]byte {
 		f = append(f, o.Summary.Value)
 	}
 	if !o.ExternalDocs.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.ExternalDocs.Value))
+		f = append(f, low.GenerateHashStringForVersion(o.ExternalDocs.Value, version))
 	}
 	if !o.Responses.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.Responses.Value))
+		f = append(f, low.GenerateHashStringForVersion(o.Responses.Value, version))
 	}
 	if !o.Deprecated.IsEmpty() {
 		f = append(f, fmt.Sprint(o.Deprecated.Value))
@@ -141,14 +170,14 @@ func (o *Operation) Hash() [32]byte {
 
 	keys = make([]string, len(o.Parameters.Value))
 	for k := range o.Parameters.Value {
-		keys[k] = low.GenerateHashString(o.Parameters.Value[k].Value)
+		keys[k] = low.GenerateHashStringForVersion(o.Parameters.Value[k].Value, version)
 	}
 	sort.Strings(keys)
 	f = append(f, keys...)
 
 	keys = make([]string, len(o.Security.Value))
 	for k := range o.Security.Value {
-		keys[k] = low.GenerateHashString(o.Security.Value[k].Value)
+		keys[k] = low.GenerateHashStringForVersion(o.Security.Value[k].Value, version)
 	}
 	sort.Strings(keys)
 	f = append(f, keys...)
@@ -183,6 +212,12 @@ func (o *Operation) GetDeprecated() low.NodeReference[bool] {
 func (o *Operation) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
 	return o.Extensions
 }
+
+// GetUnknownFields returns the keys of the Operation that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (o *Operation) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return o.UnknownFields
+}
 func (o *Operation) GetResponses() low.NodeReference[any] {
 	return low.NodeReference[any]{
 		ValueNode: o.Responses.ValueNode,
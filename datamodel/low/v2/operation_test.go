@@ -83,6 +83,25 @@ func TestOperation_Build_Security(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestOperation_Build_Security_Empty(t *testing.T) {
+	yml := `security: []`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Operation
+	err := low.BuildModel(&idxNode, &n)
+	assert.NoError(t, err)
+
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.False(t, n.Security.IsEmpty())
+	assert.NotNil(t, n.Security.Value)
+	assert.Len(t, n.Security.Value, 0)
+}
+
 func TestOperation_Hash_n_Grab(t *testing.T) {
 	yml := `tags:
   - nice
@@ -84,6 +84,13 @@ type Parameter struct {
 	Enum             low.NodeReference[[]low.ValueReference[*yaml.Node]]
 	MultipleOf       low.NodeReference[int]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode         *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Parameter object
+func (p *Parameter) GetRootNode() *yaml.Node {
+	return p.RootNode
 }
 
 // FindExtension attempts to locate a extension value given a name.
@@ -96,11 +103,19 @@ func (p *Parameter) GetExtensions() *orderedmap.Map[low.KeyReference[string], lo
 	return p.Extensions
 }
 
+// GetUnknownFields returns the keys of the Parameter that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (p *Parameter) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.UnknownFields
+}
+
 // Build will extract out extensions, schema, items and default value
 func (p *Parameter) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	p.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	p.Extensions = low.ExtractExtensions(root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	p.UnknownFields = low.ExtractUnknownFields(root, p)
 	sch, sErr := base.ExtractSchema(ctx, root, idx)
 	if sErr != nil {
 		return sErr
@@ -128,6 +143,15 @@ func (p *Parameter) Build(ctx context.Context, _, root *yaml.Node, idx *index.Sp
 
 // Hash will return a consistent SHA256 Hash of the Parameter object
 func (p *Parameter) Hash() [32]byte {
+	return p.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Parameter object, computed using the requested low.HashVersion.
+func (p *Parameter) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, p.hash)
+}
+
+func (p *Parameter) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if p.Name.Value != "" {
 		f = append(f, p.Name.Value)
@@ -147,13 +171,13 @@ func (p *Parameter) Hash() [32]byte {
 	f = append(f, fmt.Sprint(p.Required.Value))
 	f = append(f, fmt.Sprint(p.AllowEmptyValue.Value))
 	if p.Schema.Value != nil {
-		f = append(f, low.GenerateHashString(p.Schema.Value.Schema()))
+		f = append(f, low.GenerateHashStringForVersion(p.Schema.Value.Schema(), version))
 	}
 	if p.CollectionFormat.Value != "" {
 		f = append(f, p.CollectionFormat.Value)
 	}
 	if p.Default.Value != nil && !p.Default.Value.IsZero() {
-		f = append(f, low.GenerateHashString(p.Default.Value))
+		f = append(f, low.GenerateHashStringForVersion(p.Default.Value, version))
 	}
 	f = append(f, fmt.Sprint(p.Maximum.Value))
 	f = append(f, fmt.Sprint(p.Minimum.Value))
@@ -26,16 +26,23 @@ import (
 //
 //   - https://swagger.io/specification/v2/#pathItemObject
 type PathItem struct {
-	Ref        low.NodeReference[string]
-	Get        low.NodeReference[*Operation]
-	Put        low.NodeReference[*Operation]
-	Post       low.NodeReference[*Operation]
-	Delete     low.NodeReference[*Operation]
-	Options    low.NodeReference[*Operation]
-	Head       low.NodeReference[*Operation]
-	Patch      low.NodeReference[*Operation]
-	Parameters low.NodeReference[[]low.ValueReference[*Parameter]]
-	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	Ref           low.NodeReference[string]
+	Get           low.NodeReference[*Operation]
+	Put           low.NodeReference[*Operation]
+	Post          low.NodeReference[*Operation]
+	Delete        low.NodeReference[*Operation]
+	Options       low.NodeReference[*Operation]
+	Head          low.NodeReference[*Operation]
+	Patch         low.NodeReference[*Operation]
+	Parameters    low.NodeReference[[]low.ValueReference[*Parameter]]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode      *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Path Item object
+func (p *PathItem) GetRootNode() *yaml.Node {
+	return p.RootNode
 }
 
 // FindExtension will attempt to locate an extension given a name.
@@ -48,12 +55,20 @@ func (p *PathItem) GetExtensions() *orderedmap.Map[low.KeyReference[string], low
 	return p.Extensions
 }
 
+// GetUnknownFields returns the keys of the PathItem that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (p *PathItem) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.UnknownFields
+}
+
 // Build will extract extensions, parameters and operations for all methods. Every method is handled
 // asynchronously, in order to keep things moving quickly for complex operations.
 func (p *PathItem) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	p.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	p.Extensions = low.ExtractExtensions(root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	p.UnknownFields = low.ExtractUnknownFields(root, p)
 	skip := false
 	var currentNode *yaml.Node
 
@@ -189,31 +204,40 @@ func (p *PathItem) Build(ctx context.Context, _, root *yaml.Node, idx *index.Spe
 
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *PathItem) Hash() [32]byte {
+	return p.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the PathItem object, computed using the requested low.HashVersion.
+func (p *PathItem) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, p.hash)
+}
+
+func (p *PathItem) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if !p.Get.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", GetLabel, low.GenerateHashString(p.Get.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", GetLabel, low.GenerateHashStringForVersion(p.Get.Value, version)))
 	}
 	if !p.Put.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", PutLabel, low.GenerateHashString(p.Put.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", PutLabel, low.GenerateHashStringForVersion(p.Put.Value, version)))
 	}
 	if !p.Post.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", PostLabel, low.GenerateHashString(p.Post.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", PostLabel, low.GenerateHashStringForVersion(p.Post.Value, version)))
 	}
 	if !p.Delete.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", DeleteLabel, low.GenerateHashString(p.Delete.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", DeleteLabel, low.GenerateHashStringForVersion(p.Delete.Value, version)))
 	}
 	if !p.Options.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", OptionsLabel, low.GenerateHashString(p.Options.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", OptionsLabel, low.GenerateHashStringForVersion(p.Options.Value, version)))
 	}
 	if !p.Head.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", HeadLabel, low.GenerateHashString(p.Head.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", HeadLabel, low.GenerateHashStringForVersion(p.Head.Value, version)))
 	}
 	if !p.Patch.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", PatchLabel, low.GenerateHashString(p.Patch.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", PatchLabel, low.GenerateHashStringForVersion(p.Patch.Value, version)))
 	}
 	keys := make([]string, len(p.Parameters.Value))
 	for k := range p.Parameters.Value {
-		keys[k] = low.GenerateHashString(p.Parameters.Value[k].Value)
+		keys[k] = low.GenerateHashStringForVersion(p.Parameters.Value[k].Value, version)
 	}
 	sort.Strings(keys)
 	f = append(f, keys...)
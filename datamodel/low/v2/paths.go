@@ -6,6 +6,8 @@ package v2
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -21,6 +23,12 @@ import (
 type Paths struct {
 	PathItems  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*PathItem]]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode   *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Paths object
+func (p *Paths) GetRootNode() *yaml.Node {
+	return p.RootNode
 }
 
 // GetExtensions returns all Paths extensions and satisfies the low.HasExtensions interface.
@@ -30,23 +38,17 @@ func (p *Paths) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.Va
 
 // FindPath attempts to locate a PathItem instance, given a path key.
 func (p *Paths) FindPath(path string) (result *low.ValueReference[*PathItem]) {
-	for pair := orderedmap.First(p.PathItems); pair != nil; pair = pair.Next() {
-		if pair.Key().Value == path {
-			result = pair.ValuePtr()
-			break
-		}
+	if pair := p.PathItems.FindKey(path); pair != nil {
+		result = pair.ValuePtr()
 	}
 	return result
 }
 
 // FindPathAndKey attempts to locate a PathItem instance, given a path key.
 func (p *Paths) FindPathAndKey(path string) (key *low.KeyReference[string], value *low.ValueReference[*PathItem]) {
-	for pair := orderedmap.First(p.PathItems); pair != nil; pair = pair.Next() {
-		if pair.Key().Value == path {
-			key = pair.KeyPtr()
-			value = pair.ValuePtr()
-			break
-		}
+	if pair := p.PathItems.FindKey(path); pair != nil {
+		key = pair.KeyPtr()
+		value = pair.ValuePtr()
 	}
 	return key, value
 }
@@ -58,9 +60,10 @@ func (p *Paths) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 
 // Build will extract extensions and paths from node.
 func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	p.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	p.Extensions = low.ExtractExtensions(root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
 
 	// Translate YAML nodes to pathsMap using `TranslatePipeline`.
 	type pathBuildResult struct {
@@ -78,6 +81,17 @@ func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIn
 	var wg sync.WaitGroup
 	wg.Add(2) // input and output goroutines.
 
+	// paths that are filtered out are not built at all.
+	var filter *utils.PathFilter
+	// when lenient, a path item that fails to build is skipped, and the rest are still built.
+	var lenient bool
+	if idx != nil && idx.GetConfig() != nil {
+		filter = idx.GetConfig().PathFilter
+		lenient = idx.GetConfig().Lenient
+	}
+	var buildErrs []error
+	var buildErrsLock sync.Mutex
+
 	// TranslatePipeline input.
 	go func() {
 		defer func() {
@@ -99,6 +113,9 @@ func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIn
 				currentNode = pathNode
 				continue
 			}
+			if !filter.Keep(currentNode.Value) {
+				continue
+			}
 
 			select {
 			case in <- buildInput{
@@ -131,6 +148,12 @@ func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIn
 		_ = low.BuildModel(pNode, path)
 		err := path.Build(ctx, cNode, pNode, idx)
 		if err != nil {
+			if lenient {
+				buildErrsLock.Lock()
+				buildErrs = append(buildErrs, fmt.Errorf("path '%s' build failed: %w", cNode.Value, err))
+				buildErrsLock.Unlock()
+				return pathBuildResult{}, datamodel.Continue
+			}
 			return pathBuildResult{}, err
 		}
 		return pathBuildResult{
@@ -144,21 +167,30 @@ func (p *Paths) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIn
 			},
 		}, nil
 	}
-	err := datamodel.TranslatePipeline[buildInput, pathBuildResult](in, out, translateFunc)
+	err := datamodel.TranslatePipelineWithContext[buildInput, pathBuildResult](ctx, in, out, translateFunc)
 	wg.Wait()
 	if err != nil {
 		return err
 	}
 
 	p.PathItems = pathsMap
-	return nil
+	return errors.Join(buildErrs...)
 }
 
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *Paths) Hash() [32]byte {
+	return p.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Paths object, computed using the requested low.HashVersion.
+func (p *Paths) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, p.hash)
+}
+
+func (p *Paths) hash(version low.HashVersion) [32]byte {
 	var f []string
 	for v := range orderedmap.SortAlpha(p.PathItems).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 	f = append(f, low.HashExtensions(p.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
@@ -123,3 +123,30 @@ func TestPaths_Build_Fail_Many(t *testing.T) {
 	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
 	assert.Error(t, err)
 }
+
+func TestPaths_Build_Lenient(t *testing.T) {
+	yml := `"/fresh/code":
+  $ref: break
+"/stale/code":
+  parameters:
+    $ref: break
+"/fine/code":
+  get:
+    description: fine`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, &index.SpecIndexConfig{Lenient: true})
+
+	var n Paths
+	err := low.BuildModel(&idxNode, &n)
+	assert.NoError(t, err)
+
+	// every path that fails is an error, and every path that doesn't is built.
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.ErrorContains(t, err, "path '/fresh/code' build failed")
+	assert.ErrorContains(t, err, "path '/stale/code' build failed")
+	assert.Equal(t, 1, n.PathItems.Len())
+	assert.NotNil(t, n.FindPath("/fine/code"))
+}
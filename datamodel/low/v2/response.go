@@ -21,11 +21,18 @@ import (
 // Response describes a single response from an API Operation
 //   - https://swagger.io/specification/v2/#responseObject
 type Response struct {
-	Description low.NodeReference[string]
-	Schema      low.NodeReference[*base.SchemaProxy]
-	Headers     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Header]]]
-	Examples    low.NodeReference[*Examples]
-	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	Description   low.NodeReference[string]
+	Schema        low.NodeReference[*base.SchemaProxy]
+	Headers       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Header]]]
+	Examples      low.NodeReference[*Examples]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode      *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Response object
+func (r *Response) GetRootNode() *yaml.Node {
+	return r.RootNode
 }
 
 // FindExtension will attempt to locate an extension value given a key to lookup.
@@ -38,16 +45,24 @@ func (r *Response) GetExtensions() *orderedmap.Map[low.KeyReference[string], low
 	return r.Extensions
 }
 
-// FindHeader will attempt to locate a Header value, given a key
+// GetUnknownFields returns the keys of the Response that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (r *Response) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return r.UnknownFields
+}
+
+// FindHeader will attempt to locate a Header value, given a key (header names are case-insensitive)
 func (r *Response) FindHeader(hType string) *low.ValueReference[*Header] {
 	return low.FindItemInOrderedMap[*Header](hType, r.Headers.Value)
 }
 
 // Build will extract schema, extensions, examples and headers from node
 func (r *Response) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	r.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	r.Extensions = low.ExtractExtensions(root)
+	r.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	r.UnknownFields = low.ExtractUnknownFields(root, r)
 	s, err := base.ExtractSchema(ctx, root, idx)
 	if err != nil {
 		return err
@@ -63,8 +78,8 @@ func (r *Response) Build(ctx context.Context, _, root *yaml.Node, idx *index.Spe
 	}
 	r.Examples = examples
 
-	// extract headers
-	headers, lN, kN, err := low.ExtractMap[*Header](ctx, HeadersLabel, root, idx)
+	// extract headers, header names are not extensions, even when prefixed with x-
+	headers, lN, kN, err := low.ExtractMapExtensions[*Header](ctx, HeadersLabel, root, idx, true)
 	if err != nil {
 		return err
 	}
@@ -80,16 +95,25 @@ func (r *Response) Build(ctx context.Context, _, root *yaml.Node, idx *index.Spe
 
 // Hash will return a consistent SHA256 Hash of the Response object
 func (r *Response) Hash() [32]byte {
+	return r.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Response object, computed using the requested low.HashVersion.
+func (r *Response) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, r.hash)
+}
+
+func (r *Response) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if r.Description.Value != "" {
 		f = append(f, r.Description.Value)
 	}
 	if !r.Schema.IsEmpty() {
-		f = append(f, low.GenerateHashString(r.Schema.Value))
+		f = append(f, low.GenerateHashStringForVersion(r.Schema.Value, version))
 	}
 	if !r.Examples.IsEmpty() {
 		for v := range orderedmap.SortAlpha(r.Examples.Value.Values).ValuesFromOldest() {
-			f = append(f, low.GenerateHashString(v.Value))
+			f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 		}
 	}
 	f = append(f, low.HashExtensions(r.Extensions)...)
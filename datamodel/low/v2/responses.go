@@ -21,6 +21,12 @@ type Responses struct {
 	Codes      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*Response]]
 	Default    low.NodeReference[*Response]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode   *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Responses object
+func (r *Responses) GetRootNode() *yaml.Node {
+	return r.RootNode
 }
 
 // GetExtensions returns all Responses extensions and satisfies the low.HasExtensions interface.
@@ -30,9 +36,10 @@ func (r *Responses) GetExtensions() *orderedmap.Map[low.KeyReference[string], lo
 
 // Build will extract default value and extensions from node.
 func (r *Responses) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	r.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	r.Extensions = low.ExtractExtensions(root)
+	r.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
 
 	if utils.IsNodeMap(root) {
 		codes, err := low.ExtractMapNoLookup[*Response](ctx, root, idx)
@@ -91,12 +98,30 @@ func (r *Responses) FindResponseByCode(code string) *low.ValueReference[*Respons
 	return low.FindItemInOrderedMap[*Response](code, r.Codes)
 }
 
+// FindHeader will attempt to locate a Header (case-insensitively) in the Response for an HTTP response code string.
+func (r *Responses) FindHeader(code, name string) *low.ValueReference[*Header] {
+	resp := r.FindResponseByCode(code)
+	if resp == nil || resp.Value == nil {
+		return nil
+	}
+	return resp.Value.FindHeader(name)
+}
+
 // Hash will return a consistent SHA256 Hash of the Examples object
 func (r *Responses) Hash() [32]byte {
+	return r.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Responses object, computed using the requested low.HashVersion.
+func (r *Responses) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, r.hash)
+}
+
+func (r *Responses) hash(version low.HashVersion) [32]byte {
 	var f []string
-	f = low.AppendMapHashes(f, orderedmap.SortAlpha(r.Codes))
+	f = low.AppendMapHashesForVersion(f, orderedmap.SortAlpha(r.Codes), version)
 	if !r.Default.IsEmpty() {
-		f = append(f, low.GenerateHashString(r.Default.Value))
+		f = append(f, low.GenerateHashStringForVersion(r.Default.Value, version))
 	}
 	f = append(f, low.HashExtensions(r.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
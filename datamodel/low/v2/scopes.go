@@ -23,6 +23,12 @@ import (
 type Scopes struct {
 	Values     *orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode   *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Scopes object
+func (s *Scopes) GetRootNode() *yaml.Node {
+	return s.RootNode
 }
 
 // GetExtensions returns all Scopes extensions and satisfies the low.HasExtensions interface.
@@ -36,10 +42,11 @@ func (s *Scopes) FindScope(scope string) *low.ValueReference[string] {
 }
 
 // Build will extract scope values and extensions from node.
-func (s *Scopes) Build(_ context.Context, _, root *yaml.Node, _ *index.SpecIndex) error {
+func (s *Scopes) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	s.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	s.Extensions = low.ExtractExtensions(root)
+	s.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
 	valueMap := orderedmap.New[low.KeyReference[string], low.ValueReference[string]]()
 	if utils.IsNodeMap(root) {
 		for k := range root.Content {
@@ -31,6 +31,13 @@ type SecurityScheme struct {
 	TokenUrl         low.NodeReference[string]
 	Scopes           low.NodeReference[*Scopes]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode         *yaml.Node
+}
+
+// GetRootNode returns the root yaml node of the Security Scheme object
+func (ss *SecurityScheme) GetRootNode() *yaml.Node {
+	return ss.RootNode
 }
 
 // GetExtensions returns all SecurityScheme extensions and satisfies the low.HasExtensions interface.
@@ -38,11 +45,19 @@ func (ss *SecurityScheme) GetExtensions() *orderedmap.Map[low.KeyReference[strin
 	return ss.Extensions
 }
 
+// GetUnknownFields returns the keys of the SecurityScheme that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (ss *SecurityScheme) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return ss.UnknownFields
+}
+
 // Build will extract extensions and scopes from the node.
 func (ss *SecurityScheme) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	ss.RootNode = root
 	root = utils.NodeAlias(root)
 	utils.CheckForMergeNodes(root)
-	ss.Extensions = low.ExtractExtensions(root)
+	ss.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	ss.UnknownFields = low.ExtractUnknownFields(root, ss)
 
 	scopes, sErr := low.ExtractObject[*Scopes](ctx, ScopesLabel, root, idx)
 	if sErr != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -101,6 +102,19 @@ type Swagger struct {
 	// Extensions contains all custom extensions defined for the top-level document.
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 
+	// Unclaimed contains all root keys that are not part of the Swagger specification, and are not extensions
+	// (for example a `components` block pasted in from an OpenAPI 3 document). Unclaimed content is not built into
+	// the model, it's kept here (with positions) so it is not silently lost.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Unclaimed *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+
+	// Diagnostics contains problems found when building the document that did not stop it being built, such as
+	// unclaimed root keys.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Diagnostics []*low.Diagnostic
+
 	// Index is a reference to the index.SpecIndex that was created for the document and used
 	// as a guide when building out the Document. Ideal if further processing is required on the model and
 	// the original details are required to continue the work.
@@ -116,6 +130,14 @@ type Swagger struct {
 	// Rolodex is a reference to the index.Rolodex instance created when the specification was read.
 	// The rolodex is used to look up references from file systems (local or remote)
 	Rolodex *index.Rolodex
+
+	extensionErrors *low.ExtensionErrors
+}
+
+// GetExtensionErrors returns the errors raised while building the registered extensions of the document (see
+// low.ExtensionRegistry), including those of objects built once the document was built.
+func (s *Swagger) GetExtensionErrors() []error {
+	return s.extensionErrors.GetErrors()
 }
 
 // FindExtension locates an extension from the root of the Swagger document.
@@ -131,12 +153,22 @@ func (s *Swagger) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.
 // CreateDocumentFromConfig will create a new Swagger document from the provided SpecInfo and DocumentConfiguration.
 func CreateDocumentFromConfig(info *datamodel.SpecInfo,
 	configuration *datamodel.DocumentConfiguration) (*Swagger, error) {
-	return createDocument(info, configuration)
+	return createDocument(context.Background(), info, configuration)
 }
 
-func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Swagger, error) {
+// CreateDocumentFromConfigWithContext is the same as CreateDocumentFromConfig, except the supplied context is
+// honored by indexing and every model build. If the context is cancelled or its deadline expires, the build is
+// abandoned and the context error is returned alongside a partially built Swagger document.
+func CreateDocumentFromConfigWithContext(ctx context.Context, info *datamodel.SpecInfo,
+	configuration *datamodel.DocumentConfiguration) (*Swagger, error) {
+	return createDocument(ctx, info, configuration)
+}
+
+func createDocument(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Swagger, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
 	doc := Swagger{Swagger: low.ValueReference[string]{Value: info.Version, ValueNode: info.RootNode}}
-	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
 
 	// create an index config and shadow the document configuration.
 	idxConfig := index.CreateClosedAPIIndexConfig()
@@ -146,7 +178,25 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	idxConfig.AvoidCircularReferenceCheck = true
 	idxConfig.BaseURL = config.BaseURL
 	idxConfig.BasePath = config.BasePath
-	idxConfig.Logger = config.Logger
+	logger := config.BuildLogger()
+	idxConfig.Logger = logger
+	idxConfig.Progress = datamodel.NewProgressReporter(config.SpecFilePath, config.Progress)
+	idxConfig.Limits = config.Limits
+	idxConfig.RemoteFetch = config.RemoteFetch
+	idxConfig.InternStrings = config.InternStrings
+	idxConfig.Normalize = config.Normalize
+	idxConfig.BuildSearchIndex = config.BuildSearchIndex
+	idxConfig.ComponentCollisionStrategy = config.ComponentCollisionStrategy
+	idxConfig.Strict = config.Strict
+	idxConfig.Lenient = config.Lenient
+	pathFilter, err := utils.NewPathFilter(config.IncludePaths, config.ExcludePaths)
+	if err != nil {
+		return nil, err
+	}
+	idxConfig.PathFilter = pathFilter
+	if err := config.Limits.CheckSpecInfo("root", info); err != nil {
+		return nil, err
+	}
 	rolodex := index.NewRolodex(idxConfig)
 	rolodex.SetRootNode(info.RootNode)
 	doc.Rolodex = rolodex
@@ -157,7 +207,13 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		cwd, _ = filepath.Abs(config.BasePath)
 		// if a supplied local filesystem is provided, add it to the rolodex.
 		if config.LocalFS != nil {
-			rolodex.AddLocalFS(cwd, config.LocalFS)
+			if _, ok := config.LocalFS.(index.RolodexFS); ok {
+				rolodex.AddLocalFS(cwd, config.LocalFS)
+			} else {
+				// any other fs.FS (embed.FS, in-memory, zip etc.) is mounted as a virtual file system.
+				rolodex.AddVirtualFS(cwd, config.LocalFS)
+				idxConfig.AllowFileLookup = true
+			}
 		} else {
 
 			// create a local filesystem
@@ -175,17 +231,25 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	}
 
 	// if base url is provided, add a remote filesystem to the rolodex.
-	if idxConfig.BaseURL != nil {
+	if idxConfig.BaseURL != nil || config.OfflineBaseDir != "" {
 
 		// create a remote filesystem
 		remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
 		if config.RemoteURLHandler != nil {
 			remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
 		}
+		// offline, remote files are read from the mirror, never fetched.
+		if config.OfflineBaseDir != "" {
+			remoteFS.RemoteHandlerFunc = config.OfflineURLHandler()
+		}
 		idxConfig.AllowRemoteLookup = true
 
 		// add to the rolodex
-		rolodex.AddRemoteFS(config.BaseURL.String(), remoteFS)
+		u := "default"
+		if config.BaseURL != nil {
+			u = config.BaseURL.String()
+		}
+		rolodex.AddRemoteFS(u, remoteFS)
 
 	}
 
@@ -194,11 +258,16 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	var errs []error
 
 	// index all the things!
-	_ = rolodex.IndexTheRolodex()
+	_ = rolodex.IndexTheRolodexWithContext(ctx)
+
+	// fail fast on compositions that would explode when resolved, before they are.
+	if err := rolodex.GetRootIndex().CheckCompositionLimits(); err != nil {
+		return nil, err
+	}
 
 	// check for circular references
-	if !config.SkipCircularReferenceCheck {
-		rolodex.CheckForCircularReferences()
+	if !config.SkipCircularReferenceCheck && ctx.Err() == nil {
+		rolodex.CheckForCircularReferencesWithContext(ctx)
 	}
 
 	// extract errors
@@ -210,11 +279,34 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	// set the index on the document.
 	doc.Index = rolodex.GetRootIndex()
 	doc.SpecInfo = info
+	if err := ctx.Err(); err != nil {
+		if !errors.Is(errors.Join(errs...), err) {
+			errs = append(errs, err)
+		}
+		return &doc, errors.Join(errs...)
+	}
 
 	// build out swagger scalar variables.
 	_ = low.BuildModel(info.RootNode.Content[0], &doc)
 
-	ctx := context.Background()
+	// any registered extension types are built as extensions are extracted.
+	ctx = low.WithExtensionRegistry(ctx, config.ExtensionRegistry)
+	// the model is built by the pool of workers its concurrency asks for, otherwise the pool of the context.
+	if config.Concurrency > 0 {
+		ctx = datamodel.WithWorkerPool(ctx, datamodel.SharedWorkerPool(config.Concurrency))
+	}
+	// the errors of the extensions of this build are collected apart from those of any other build of the registry.
+	ctx, doc.extensionErrors = low.WithExtensionErrors(ctx)
+	doc.Extensions = low.ExtractExtensionsWithContext(ctx, info.RootNode.Content[0], rolodex.GetRootIndex())
+
+	// anything at the root that isn't part of the spec is kept, and reported.
+	doc.Unclaimed = low.ExtractUnclaimed(info.RootNode.Content[0], rootLabels...)
+	doc.Diagnostics = low.UnclaimedDiagnostics("Swagger", doc.Unclaimed, rootHints)
+	if logger != nil {
+		for _, d := range doc.Diagnostics {
+			logger.Warn(d.Message, datamodel.LogKeyPhase, datamodel.PhaseBuild, "line", d.Line, "column", d.Column)
+		}
+	}
 
 	// extract externalDocs
 	extDocs, err := low.ExtractObject[*base.ExternalDoc](ctx, base.ExternalDocsLabel, info.RootNode, rolodex.GetRootIndex())
@@ -249,10 +341,30 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 			errs = append(errs, e)
 		}
 	}
+	errs = append(errs, doc.extensionErrors.GetErrors()...)
+	if err := ctx.Err(); err != nil && !errors.Is(errors.Join(errs...), err) {
+		errs = append(errs, err)
+	}
 
 	return &doc, errors.Join(errs...)
 }
 
+// rootLabels are all the keys allowed at the root of a Swagger document (besides extensions).
+var rootLabels = []string{
+	"swagger", base.InfoLabel, "host", "basePath", "schemes", "consumes", "produces", PathsLabel,
+	DefinitionsLabel, ParametersLabel, ResponsesLabel, SecurityDefinitionsLabel, SecurityLabel,
+	base.TagsLabel, base.ExternalDocsLabel,
+}
+
+// rootHints are suggestions for root keys that commonly end up in Swagger documents by mistake.
+var rootHints = map[string]string{
+	"openapi":           "this is an OpenAPI 3 property",
+	"components":        "this is an OpenAPI 3 property, use 'definitions', 'parameters', 'responses' or 'securityDefinitions' instead",
+	"servers":           "this is an OpenAPI 3 property, use 'host', 'basePath' and 'schemes' instead",
+	"webhooks":          "this is an OpenAPI 3.1 property, webhooks are not supported by Swagger",
+	"jsonSchemaDialect": "this is an OpenAPI 3.1 property",
+}
+
 func (s *Swagger) GetExternalDocs() *low.NodeReference[any] {
 	return &low.NodeReference[any]{
 		KeyNode:   s.ExternalDocs.KeyNode,
@@ -272,6 +384,22 @@ func extractInfo(ctx context.Context, root *yaml.Node, doc *Swagger, idx *index.
 }
 
 func extractPaths(ctx context.Context, root *yaml.Node, doc *Swagger, idx *index.SpecIndex, c chan<- bool, e chan<- error) {
+	// when lenient, the paths that built are kept alongside the errors of those that didn't.
+	if idx != nil && idx.GetConfig() != nil && idx.GetConfig().Lenient {
+		_, ln, vn := utils.FindKeyNodeFullTop(PathsLabel, root.Content)
+		if vn != nil {
+			paths := new(Paths)
+			_ = low.BuildModel(vn, paths)
+			err := paths.Build(ctx, ln, vn, idx)
+			doc.Paths = low.NodeReference[*Paths]{Value: paths, KeyNode: ln, ValueNode: vn}
+			if err != nil {
+				e <- err
+				return
+			}
+		}
+		c <- true
+		return
+	}
 	paths, err := low.ExtractObject[*Paths](ctx, PathsLabel, root, idx)
 	if err != nil {
 		e <- err
@@ -318,10 +318,10 @@ func TestRolodexLocalFileSystem_ProvideNonRolodexFS(t *testing.T) {
 	cf := datamodel.NewDocumentConfiguration()
 	cf.BasePath = baseDir
 	cf.FileFilter = []string{"first.yaml", "second.yaml", "third.yaml"}
-	cf.LocalFS = os.DirFS(baseDir)
+	cf.LocalFS = os.DirFS(baseDir) // mounted as a virtual file system.
 	lDoc, err := CreateDocumentFromConfig(info, cf)
 	assert.NotNil(t, lDoc)
-	assert.Error(t, err)
+	assert.NoError(t, err)
 }
 
 func TestRolodexLocalFileSystem_ProvideRolodexFS(t *testing.T) {
@@ -433,3 +433,49 @@ func TestRolodexRemoteFileSystem_FailRemoteFS(t *testing.T) {
 	assert.NotNil(t, lDoc)
 	assert.Error(t, err)
 }
+
+func TestCreateDocument_Unclaimed(t *testing.T) {
+	yml := `swagger: "2.0"
+info:
+  title: pasted
+  version: "1"
+components:
+  schemas:
+    Pet:
+      type: object`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+
+	require.Equal(t, 1, orderedmap.Len(d.Unclaimed))
+	assert.Equal(t, "components", d.Unclaimed.First().Key().Value)
+	require.Len(t, d.Diagnostics, 1)
+	assert.Equal(t, 5, d.Diagnostics[0].Line)
+	assert.Contains(t, d.Diagnostics[0].Message, "Swagger specification")
+	assert.Contains(t, d.Diagnostics[0].Message, "use 'definitions'")
+}
+
+func TestCreateDocument_IncludeExcludePaths(t *testing.T) {
+	yml := `swagger: 2.0
+paths:
+  /users:
+    get: {}
+  /users/{id}:
+    get: {}
+  /orders/{id}:
+    get: {}`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	config := datamodel.NewDocumentConfiguration()
+	config.IncludePaths = []string{"/users/**"}
+	config.ExcludePaths = []string{"/users/*"}
+	d, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+	require.Equal(t, 1, orderedmap.Len(d.Paths.Value.PathItems))
+	assert.Equal(t, "/users", d.Paths.Value.PathItems.First().Key().Value)
+
+	config.ExcludePaths = []string{"regex:("}
+	_, err = CreateDocumentFromConfig(info, config)
+	assert.ErrorContains(t, err, "invalid path selector 'regex:('")
+}
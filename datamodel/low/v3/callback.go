@@ -60,7 +60,7 @@ func (cb *Callback) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	utils.CheckForMergeNodes(root)
 	cb.Reference = new(low.Reference)
 	cb.Nodes = low.ExtractNodes(ctx, root)
-	cb.Extensions = low.ExtractExtensions(root)
+	cb.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
 	low.ExtractExtensionNodes(ctx, cb.Extensions, cb.Nodes)
 
 	expressions, err := extractPathItemsMap(ctx, root, idx)
@@ -76,9 +76,18 @@ func (cb *Callback) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 
 // Hash will return a consistent SHA256 Hash of the Callback object
 func (cb *Callback) Hash() [32]byte {
+	return cb.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Callback object, computed using the requested low.HashVersion.
+func (cb *Callback) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, cb.hash)
+}
+
+func (cb *Callback) hash(version low.HashVersion) [32]byte {
 	var f []string
 	for v := range orderedmap.SortAlpha(cb.Expression).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 
 	f = append(f, low.HashExtensions(cb.Extensions)...)
@@ -6,6 +6,7 @@ package v3
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -37,6 +38,7 @@ type Components struct {
 	Callbacks       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Callback]]]
 	PathItems       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*PathItem]]]
 	Extensions      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode         *yaml.Node
 	RootNode        *yaml.Node
 	*low.Reference
@@ -58,6 +60,12 @@ func (co *Components) GetExtensions() *orderedmap.Map[low.KeyReference[string],
 	return co.Extensions
 }
 
+// GetUnknownFields returns the keys of the Components that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (co *Components) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return co.UnknownFields
+}
+
 // GetRootNode returns the root yaml node of the Components object
 func (co *Components) GetRootNode() *yaml.Node {
 	return co.RootNode
@@ -70,24 +78,37 @@ func (co *Components) GetKeyNode() *yaml.Node {
 
 // Hash will return a consistent SHA256 Hash of the Encoding object
 func (co *Components) Hash() [32]byte {
+	return co.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Components object, computed using the requested low.HashVersion.
+func (co *Components) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, co.hash)
+}
+
+func (co *Components) hash(version low.HashVersion) [32]byte {
 	var f []string
-	generateHashForObjectMap(co.Schemas.Value, &f)
-	generateHashForObjectMap(co.Responses.Value, &f)
-	generateHashForObjectMap(co.Parameters.Value, &f)
-	generateHashForObjectMap(co.Examples.Value, &f)
-	generateHashForObjectMap(co.RequestBodies.Value, &f)
-	generateHashForObjectMap(co.Headers.Value, &f)
-	generateHashForObjectMap(co.SecuritySchemes.Value, &f)
-	generateHashForObjectMap(co.Links.Value, &f)
-	generateHashForObjectMap(co.Callbacks.Value, &f)
-	generateHashForObjectMap(co.PathItems.Value, &f)
+	generateHashForObjectMap(co.Schemas.Value, &f, version)
+	generateHashForObjectMap(co.Responses.Value, &f, version)
+	generateHashForObjectMap(co.Parameters.Value, &f, version)
+	generateHashForObjectMap(co.Examples.Value, &f, version)
+	generateHashForObjectMap(co.RequestBodies.Value, &f, version)
+	generateHashForObjectMap(co.Headers.Value, &f, version)
+	generateHashForObjectMap(co.SecuritySchemes.Value, &f, version)
+	generateHashForObjectMap(co.Links.Value, &f, version)
+	generateHashForObjectMap(co.Callbacks.Value, &f, version)
+	generateHashForObjectMap(co.PathItems.Value, &f, version)
 	f = append(f, low.HashExtensions(co.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
-func generateHashForObjectMap[T any](collection *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]], hash *[]string) {
+func generateHashForObjectMap[T any](
+	collection *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	hash *[]string,
+	version low.HashVersion,
+) {
 	for v := range orderedmap.SortAlpha(collection).ValuesFromOldest() {
-		*hash = append(*hash, low.GenerateHashString(v.Value))
+		*hash = append(*hash, low.GenerateHashStringForVersion(v.Value, version))
 	}
 }
 
@@ -148,7 +169,8 @@ func (co *Components) Build(ctx context.Context, root *yaml.Node, idx *index.Spe
 	utils.CheckForMergeNodes(root)
 	co.Reference = new(low.Reference)
 	co.Nodes = low.ExtractNodes(ctx, root)
-	co.Extensions = low.ExtractExtensions(root)
+	co.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	co.UnknownFields = low.ExtractUnknownFields(root, co)
 	low.ExtractExtensionNodes(ctx, co.Extensions, co.Nodes)
 	co.RootNode = root
 	co.KeyNode = root
@@ -157,11 +179,17 @@ func (co *Components) Build(ctx context.Context, root *yaml.Node, idx *index.Spe
 	var wg sync.WaitGroup
 	wg.Add(10)
 
+	// when strict or lenient, the errors of every type of component are returned, rather than the last one.
+	collect := idx != nil && idx.GetConfig() != nil && (idx.GetConfig().Strict || idx.GetConfig().Lenient)
 	captureError := func(err error) {
 		ceMutex.Lock()
 		defer ceMutex.Unlock()
 		if err != nil {
-			reterr = err
+			if collect {
+				reterr = errors.Join(reterr, err)
+			} else {
+				reterr = err
+			}
 		}
 	}
 
@@ -280,6 +308,20 @@ func extractComponentValues[T low.Buildable[N], N any](ctx context.Context, labe
 		}
 	}()
 
+	// when lenient, a component that fails to build is skipped, and the rest are still built.
+	lenient := isLenient(idx)
+	var buildErrs []error
+	var buildErrsLock sync.Mutex
+	skipOnError := func(key *yaml.Node, err error) error {
+		if !lenient {
+			return err
+		}
+		buildErrsLock.Lock()
+		buildErrs = append(buildErrs, fmt.Errorf("component '%s' build failed: %w", key.Value, err))
+		buildErrsLock.Unlock()
+		return datamodel.Continue
+	}
+
 	// Collect output.
 	go func() {
 		for result := range out {
@@ -306,14 +348,14 @@ func extractComponentValues[T low.Buildable[N], N any](ctx context.Context, labe
 			nCtx = context.WithValue(nCtx, "reference", rv)
 		}
 		if err != nil {
-			return componentBuildResult[T]{}, err
+			return componentBuildResult[T]{}, skipOnError(currentLabel, err)
 		}
 
 		// build.
 		_ = low.BuildModel(node, n)
 		err = n.Build(nCtx, currentLabel, node, fIdx)
 		if err != nil {
-			return componentBuildResult[T]{}, err
+			return componentBuildResult[T]{}, skipOnError(currentLabel, err)
 		}
 
 		nType := reflect.TypeOf(n)
@@ -341,7 +383,7 @@ func extractComponentValues[T low.Buildable[N], N any](ctx context.Context, labe
 			},
 		}, nil
 	}
-	err := datamodel.TranslatePipeline[componentInput, componentBuildResult[T]](in, out, translateFunc)
+	err := datamodel.TranslatePipelineWithContext[componentInput, componentBuildResult[T]](ctx, in, out, translateFunc)
 	wg.Wait()
 	if err != nil {
 		return emptyResult, err
@@ -352,5 +394,5 @@ func extractComponentValues[T low.Buildable[N], N any](ctx context.Context, labe
 		ValueNode: nodeValue,
 		Value:     componentValues,
 	}
-	return results, nil
+	return results, errors.Join(buildErrs...)
 }
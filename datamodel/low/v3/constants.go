@@ -8,6 +8,7 @@ const (
 	ComponentsLabel            = "components"
 	SchemasLabel               = "schemas"
 	EncodingLabel              = "encoding"
+	ItemSchemaLabel            = "itemSchema"
 	HeadersLabel               = "headers"
 	ExpressionLabel            = "expression"
 	InfoLabel                  = "info"
@@ -32,6 +33,9 @@ const (
 	OptionsLabel               = "options"
 	HeadLabel                  = "head"
 	TraceLabel                 = "trace"
+	QueryLabel                 = "query"
+	AdditionalOperationsLabel  = "additionalOperations"
+	SelfLabel                  = "$self"
 	LinksLabel                 = "links"
 	DefaultLabel               = "default"
 	ConstLabel                 = "const"
@@ -139,4 +143,7 @@ const (
 	DependentSchemasLabel      = "dependentSchemas"
 	PatternPropertiesLabel     = "patternProperties"
 	AnchorLabel                = "$anchor"
+	DynamicAnchorLabel         = "$dynamicAnchor"
+	DynamicRefLabel            = "$dynamicRef"
+	DefsLabel                  = "$defs"
 )
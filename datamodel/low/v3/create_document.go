@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -20,15 +21,25 @@ import (
 // Deprecated: Use CreateDocumentFromConfig instead. This function will be removed in a later version, it
 // defaults to allowing file and remote references, and does not support relative file references.
 func CreateDocument(info *datamodel.SpecInfo) (*Document, error) {
-	return createDocument(info, datamodel.NewDocumentConfiguration())
+	return createDocument(context.Background(), info, datamodel.NewDocumentConfiguration())
 }
 
 // CreateDocumentFromConfig Create a new document from the provided SpecInfo and DocumentConfiguration pointer.
 func CreateDocumentFromConfig(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
-	return createDocument(info, config)
+	return createDocument(context.Background(), info, config)
 }
 
-func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
+// CreateDocumentFromConfigWithContext is the same as CreateDocumentFromConfig, except the supplied context is
+// honored by indexing and every model build. If the context is cancelled or its deadline expires, the build is
+// abandoned and the context error is returned alongside a partially built Document.
+func CreateDocumentFromConfigWithContext(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
+	return createDocument(ctx, info, config)
+}
+
+func createDocument(parent context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
+	if parent == nil {
+		parent = context.Background()
+	}
 	_, labelNode, versionNode := utils.FindKeyNodeFull(OpenAPILabel, info.RootNode.Content)
 	var version low.NodeReference[string]
 	if versionNode == nil {
@@ -46,9 +57,27 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	idxConfig.BaseURL = config.BaseURL
 	idxConfig.BasePath = config.BasePath
 	idxConfig.SpecFilePath = config.SpecFilePath
-	idxConfig.Logger = config.Logger
+	logger := config.BuildLogger()
+	idxConfig.Logger = logger
+	idxConfig.Progress = datamodel.NewProgressReporter(config.SpecFilePath, config.Progress)
 	extract := config.ExtractRefsSequentially
 	idxConfig.ExtractRefsSequentially = extract
+	idxConfig.Limits = config.Limits
+	idxConfig.RemoteFetch = config.RemoteFetch
+	idxConfig.InternStrings = config.InternStrings
+	idxConfig.Normalize = config.Normalize
+	idxConfig.BuildSearchIndex = config.BuildSearchIndex
+	idxConfig.ComponentCollisionStrategy = config.ComponentCollisionStrategy
+	idxConfig.Strict = config.Strict
+	idxConfig.Lenient = config.Lenient
+	pathFilter, err := utils.NewPathFilter(config.IncludePaths, config.ExcludePaths)
+	if err != nil {
+		return nil, err
+	}
+	idxConfig.PathFilter = pathFilter
+	if err := checkRootLimits(info, config); err != nil {
+		return nil, err
+	}
 	rolodex := index.NewRolodex(idxConfig)
 	rolodex.SetRootNode(info.RootNode)
 	doc.Rolodex = rolodex
@@ -59,7 +88,13 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		cwd, _ = filepath.Abs(config.BasePath)
 		// if a supplied local filesystem is provided, add it to the rolodex.
 		if config.LocalFS != nil {
-			rolodex.AddLocalFS(cwd, config.LocalFS)
+			if _, ok := config.LocalFS.(index.RolodexFS); ok {
+				rolodex.AddLocalFS(cwd, config.LocalFS)
+			} else {
+				// any other fs.FS (embed.FS, in-memory, zip etc.) is mounted as a virtual file system.
+				rolodex.AddVirtualFS(cwd, config.LocalFS)
+				idxConfig.AllowFileLookup = true
+			}
 		} else {
 
 			// create a local filesystem
@@ -77,13 +112,17 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		}
 	}
 	// if base url is provided, add a remote filesystem to the rolodex.
-	if idxConfig.BaseURL != nil || config.AllowRemoteReferences {
+	if idxConfig.BaseURL != nil || config.AllowRemoteReferences || config.OfflineBaseDir != "" {
 
 		// create a remote filesystem
 		remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
 		if config.RemoteURLHandler != nil {
 			remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
 		}
+		// offline, remote files are read from the mirror, never fetched.
+		if config.OfflineBaseDir != "" {
+			remoteFS.RemoteHandlerFunc = config.OfflineURLHandler()
+		}
 		idxConfig.AllowRemoteLookup = true
 
 		// add to the rolodex
@@ -98,27 +137,31 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	var errs []error
 
 	// index all the things.
-	if config.Logger != nil {
-		config.Logger.Debug("indexing rolodex")
+	if logger != nil {
+		logger.Debug("indexing rolodex", datamodel.LogKeyPhase, datamodel.PhaseIndex)
 	}
 	now := time.Now()
-	_ = rolodex.IndexTheRolodex()
+	_ = rolodex.IndexTheRolodexWithContext(parent)
 	done := time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
-		config.Logger.Debug("rolodex indexed", "ms", done)
+	if logger != nil {
+		logger.Debug("rolodex indexed", datamodel.LogKeyPhase, datamodel.PhaseIndex, "ms", done)
+	}
+	// fail fast on compositions that would explode when resolved, before they are.
+	if err := rolodex.GetRootIndex().CheckCompositionLimits(); err != nil {
+		return nil, err
 	}
 	// check for circular references
-	if config.Logger != nil {
-		config.Logger.Debug("checking for circular references")
+	if logger != nil {
+		logger.Debug("checking for circular references", datamodel.LogKeyPhase, datamodel.PhaseResolve)
 	}
 	now = time.Now()
-	if !config.SkipCircularReferenceCheck {
-		rolodex.CheckForCircularReferences()
+	if !config.SkipCircularReferenceCheck && parent.Err() == nil {
+		rolodex.CheckForCircularReferencesWithContext(parent)
 	}
 	done = time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
+	if logger != nil {
 		if !config.SkipCircularReferenceCheck {
-			config.Logger.Debug("circular check completed", "ms", done)
+			logger.Debug("circular check completed", datamodel.LogKeyPhase, datamodel.PhaseResolve, "ms", done)
 		}
 	}
 	// extract errors
@@ -129,15 +172,43 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 
 	// set root index.
 	doc.Index = rolodex.GetRootIndex()
+	if err := parent.Err(); err != nil {
+		if !errors.Is(errors.Join(errs...), err) {
+			errs = append(errs, err)
+		}
+		return &doc, errors.Join(errs...)
+	}
 	var wg sync.WaitGroup
 
 	var cacheMap sync.Map
 	modelContext := base.ModelContext{SchemaCache: &cacheMap}
-	ctx := context.WithValue(context.Background(), "modelCtx", &modelContext)
+	ctx := context.WithValue(parent, "modelCtx", &modelContext)
 
-	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
+	// any registered extension types are built as extensions are extracted.
+	ctx = low.WithExtensionRegistry(ctx, config.ExtensionRegistry)
+	// the model is built by the pool of workers its concurrency asks for, otherwise the pool of the context.
+	if config.Concurrency > 0 {
+		ctx = datamodel.WithWorkerPool(ctx, datamodel.SharedWorkerPool(config.Concurrency))
+	}
+	// the errors of the extensions of this build are collected apart from those of any other build of the registry.
+	ctx, doc.extensionErrors = low.WithExtensionErrors(ctx)
+
+	doc.Extensions = low.ExtractExtensionsWithContext(ctx, info.RootNode.Content[0], rolodex.GetRootIndex())
 	low.ExtractExtensionNodes(ctx, doc.Extensions, doc.Nodes)
 
+	// anything at the root that isn't part of the spec is kept, and reported.
+	labels := rootLabels
+	if info.VersionNumeric >= 3.2 {
+		labels = append(slices.Clone(rootLabels), SelfLabel)
+	}
+	doc.Unclaimed = low.ExtractUnclaimed(info.RootNode.Content[0], labels...)
+	doc.Diagnostics = low.UnclaimedDiagnostics("OpenAPI 3", doc.Unclaimed, rootHints)
+	if logger != nil {
+		for _, d := range doc.Diagnostics {
+			logger.Warn(d.Message, datamodel.LogKeyPhase, datamodel.PhaseBuild, "line", d.Line, "column", d.Column)
+		}
+	}
+
 	// if set, extract jsonSchemaDialect (3.1)
 	_, dialectLabel, dialectNode := utils.FindKeyNodeFull(JSONSchemaDialectLabel, info.RootNode.Content)
 	if dialectNode != nil {
@@ -146,6 +217,14 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		}
 	}
 
+	// if set, extract $self (3.2)
+	if info.VersionNumeric >= 3.2 {
+		_, selfLabel, selfNode := utils.FindKeyNodeFullTop(SelfLabel, info.RootNode.Content[0].Content)
+		if selfNode != nil {
+			doc.Self = low.NodeReference[string]{Value: selfNode.Value, KeyNode: selfLabel, ValueNode: selfNode}
+		}
+	}
+
 	runExtraction := func(ctx context.Context, info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex,
 		runFunc func(ctx context.Context, i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error,
 		ers *[]error,
@@ -168,8 +247,8 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	}
 
 	wg.Add(len(extractionFuncs))
-	if config.Logger != nil {
-		config.Logger.Debug("running extractions")
+	if logger != nil {
+		logger.Debug("running extractions", datamodel.LogKeyPhase, datamodel.PhaseBuild)
 	}
 	now = time.Now()
 	for _, f := range extractionFuncs {
@@ -177,12 +256,59 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	}
 	wg.Wait()
 	done = time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
-		config.Logger.Debug("extractions complete", "time", done)
+	if doc.Paths.Value != nil && len(doc.Paths.Value.Diagnostics) > 0 {
+		doc.Diagnostics = append(doc.Diagnostics, doc.Paths.Value.Diagnostics...)
+		if logger != nil {
+			for _, d := range doc.Paths.Value.Diagnostics {
+				logger.Warn(d.Message, datamodel.LogKeyPhase, datamodel.PhaseBuild, "line", d.Line, "column", d.Column)
+			}
+		}
+	}
+	if logger != nil {
+		logger.Debug("extractions complete", datamodel.LogKeyPhase, datamodel.PhaseBuild, "time", done)
+	}
+	errs = append(errs, doc.extensionErrors.GetErrors()...)
+	if err := parent.Err(); err != nil && !errors.Is(errors.Join(errs...), err) {
+		errs = append(errs, err)
 	}
 	return &doc, errors.Join(errs...)
 }
 
+// isLenient returns true if the index was configured to carry on past objects that fail to build.
+func isLenient(idx *index.SpecIndex) bool {
+	return idx != nil && idx.GetConfig() != nil && idx.GetConfig().Lenient
+}
+
+// rootLabels are all the keys allowed at the root of an OpenAPI 3 document (besides extensions).
+var rootLabels = []string{
+	OpenAPILabel, base.InfoLabel, JSONSchemaDialectLabel, ServersLabel, PathsLabel, WebhooksLabel,
+	ComponentsLabel, SecurityLabel, base.TagsLabel, base.ExternalDocsLabel,
+}
+
+// rootHints are suggestions for root keys that commonly end up in OpenAPI 3 documents by mistake.
+var rootHints = map[string]string{
+	"swagger":             "this is a Swagger (OpenAPI 2) property",
+	"definitions":         "this is a Swagger (OpenAPI 2) property, use 'components.schemas' instead",
+	"parameters":          "this is a Swagger (OpenAPI 2) property, use 'components.parameters' instead",
+	"responses":           "this is a Swagger (OpenAPI 2) property, use 'components.responses' instead",
+	"securityDefinitions": "this is a Swagger (OpenAPI 2) property, use 'components.securitySchemes' instead",
+	"host":                "this is a Swagger (OpenAPI 2) property, use 'servers' instead",
+	"basePath":            "this is a Swagger (OpenAPI 2) property, use 'servers' instead",
+	"schemes":             "this is a Swagger (OpenAPI 2) property, use 'servers' instead",
+	"consumes":            "this is a Swagger (OpenAPI 2) property, use 'content' on request bodies instead",
+	"produces":            "this is a Swagger (OpenAPI 2) property, use 'content' on responses instead",
+}
+
+// checkRootLimits checks the root specification against the document limits before anything is indexed, unless it
+// was checked as it was parsed.
+func checkRootLimits(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) error {
+	location := config.SpecFilePath
+	if location == "" {
+		location = "root"
+	}
+	return config.Limits.CheckSpecInfo(location, info)
+}
+
 func extractInfo(ctx context.Context, info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex) error {
 	_, ln, vn := utils.FindKeyNodeFullTop(base.InfoLabel, info.RootNode.Content[0].Content)
 	if vn != nil {
@@ -225,11 +351,13 @@ func extractComponents(ctx context.Context, info *datamodel.SpecInfo, doc *Docum
 		ir := Components{}
 		_ = low.BuildModel(vn, &ir)
 		err := ir.Build(ctx, vn, idx)
-		if err != nil {
+		// when lenient, the components that built are kept alongside the errors of those that didn't.
+		if err != nil && !isLenient(idx) {
 			return err
 		}
 		nr := low.NodeReference[*Components]{Value: &ir, ValueNode: vn, KeyNode: ln}
 		doc.Components = nr
+		return err
 	}
 	return nil
 }
@@ -265,12 +393,18 @@ func extractTags(ctx context.Context, info *datamodel.SpecInfo, doc *Document, i
 	if vn != nil {
 		if utils.IsNodeArray(vn) {
 			var tags []low.ValueReference[*base.Tag]
+			var tagErrs []error
 			for _, tagN := range vn.Content {
 				if utils.IsNodeMap(tagN) {
 					tag := base.Tag{}
 					_ = low.BuildModel(tagN, &tag)
 					if err := tag.Build(ctx, ln, tagN, idx); err != nil {
-						return err
+						// when lenient, a tag that fails to build is skipped, and the rest are still built.
+						if !isLenient(idx) {
+							return err
+						}
+						tagErrs = append(tagErrs, err)
+						continue
 					}
 					tags = append(tags, low.ValueReference[*base.Tag]{
 						Value:     &tag,
@@ -283,6 +417,7 @@ func extractTags(ctx context.Context, info *datamodel.SpecInfo, doc *Document, i
 				KeyNode:   ln,
 				ValueNode: vn,
 			}
+			return errors.Join(tagErrs...)
 		}
 	}
 	return nil
@@ -293,11 +428,12 @@ func extractPaths(ctx context.Context, info *datamodel.SpecInfo, doc *Document,
 	if vn != nil {
 		ir := Paths{}
 		err := ir.Build(ctx, ln, vn, idx)
-		if err != nil {
+		if ir.PathItems == nil {
 			return err
 		}
 		nr := low.NodeReference[*Paths]{Value: &ir, ValueNode: vn, KeyNode: ln}
 		doc.Paths = nr
+		return err
 	}
 	return nil
 }
@@ -1,12 +1,17 @@
 package v3
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"unsafe"
 
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
@@ -41,6 +46,39 @@ func BenchmarkCreateDocument(b *testing.B) {
 	}
 }
 
+// BenchmarkCreateDocument_InternStrings parses and builds stripe with and without interned strings, and reports the
+// heap each document retains once it's built (retained-B/op) next to what was allocated to build it.
+func BenchmarkCreateDocument_InternStrings(b *testing.B) {
+	data, _ := os.ReadFile("../../../test_specs/stripe.yaml")
+	for _, intern := range []bool{false, true} {
+		b.Run(fmt.Sprintf("intern=%v", intern), func(b *testing.B) {
+			config := datamodel.NewDocumentConfiguration()
+			config.InternStrings = intern
+			var stats runtime.MemStats
+			var retained int64
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				doc = nil
+				runtime.GC()
+				runtime.ReadMemStats(&stats)
+				before := int64(stats.HeapAlloc)
+				b.StartTimer()
+
+				info, _ := datamodel.ExtractSpecInfo(data)
+				doc, _ = CreateDocumentFromConfig(info, config)
+
+				b.StopTimer()
+				runtime.GC()
+				runtime.ReadMemStats(&stats)
+				retained += int64(stats.HeapAlloc) - before
+				b.StartTimer()
+			}
+			b.ReportMetric(float64(retained)/float64(b.N), "retained-B/op")
+		})
+	}
+}
+
 func BenchmarkCreateDocument_Circular(b *testing.B) {
 	data, _ := os.ReadFile("../../../test_specs/circular-tests.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -83,10 +121,10 @@ func TestRolodexLocalFileSystem_ProvideNonRolodexFS(t *testing.T) {
 	cf := datamodel.NewDocumentConfiguration()
 	cf.BasePath = baseDir
 	cf.FileFilter = []string{"first.yaml", "second.yaml", "third.yaml"}
-	cf.LocalFS = os.DirFS(baseDir)
+	cf.LocalFS = os.DirFS(baseDir) // mounted as a virtual file system.
 	lDoc, err := CreateDocumentFromConfig(info, cf)
 	assert.NotNil(t, lDoc)
-	assert.Error(t, err)
+	assert.NoError(t, err)
 }
 
 func TestRolodexLocalFileSystem_ProvideRolodexFS(t *testing.T) {
@@ -567,6 +605,21 @@ func TestCreateDocument_Components_Schemas(t *testing.T) {
 		p.Value.Schema().Description.Value)
 }
 
+func TestCreateDocument_Concurrency(t *testing.T) {
+	initTest()
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	config := datamodel.NewDocumentConfiguration()
+	config.Concurrency = 1
+
+	// a single worker builds the same model, in the same order.
+	single, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+	assert.Equal(t, doc.Paths.Value.PathKeys(), single.Paths.Value.PathKeys())
+	assert.Equal(t, doc.Components.Value.Schemas.Value.Len(), single.Components.Value.Schemas.Value.Len())
+	assert.Equal(t, doc.Paths.Value.Hash(), single.Paths.Value.Hash())
+}
+
 func TestCreateDocument_Components_SecuritySchemes(t *testing.T) {
 	initTest()
 	components := doc.Components.Value
@@ -881,3 +934,252 @@ func ExampleCreateDocument() {
 	fmt.Print(document.Info.Value.Contact.Value.Email.Value)
 	// Output: apiteam@swagger.io
 }
+
+func TestCreateDocument_Unclaimed(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: pasted
+  version: "1"
+x-tool: swagger
+definitions:
+  Pet:
+    type: object
+my-tool:
+  lint: strict`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	d, err := CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+
+	require.Equal(t, 2, orderedmap.Len(d.Unclaimed))
+	defs := d.Unclaimed.First()
+	assert.Equal(t, "definitions", defs.Key().Value)
+	assert.Equal(t, 6, defs.Key().KeyNode.Line)
+	assert.Equal(t, "Pet", defs.Value().Value.Content[0].Value)
+	assert.Equal(t, "my-tool", defs.Next().Key().Value)
+
+	require.Len(t, d.Diagnostics, 2)
+	assert.Equal(t, "definitions", d.Diagnostics[0].Key)
+	assert.Equal(t, 6, d.Diagnostics[0].Line)
+	assert.Equal(t, 1, d.Diagnostics[0].Column)
+	assert.Contains(t, d.Diagnostics[0].Message, "use 'components.schemas' instead")
+	assert.Equal(t, "root key 'my-tool' is not part of the OpenAPI 3 specification, it has been ignored [9:1]",
+		d.Diagnostics[1].Error())
+}
+
+func TestCreateDocument_Unclaimed_None(t *testing.T) {
+	initTest()
+	assert.Nil(t, doc.Unclaimed)
+	assert.Empty(t, doc.Diagnostics)
+}
+
+func TestCreateDocument_Progress(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(`openapi: 3.1.0
+info:
+  title: progress
+  version: "1"
+definitions: {}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: 'responses.yaml#/Pets'
+  /pets/{id}:
+    get: {}
+  x-internal:
+    get: {}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "responses.yaml"), []byte(`Pets:
+  description: pets`), 0o644))
+
+	var buf bytes.Buffer
+	var events []datamodel.ProgressEvent
+	config := datamodel.NewDocumentConfiguration()
+	config.BasePath = dir
+	config.SpecFilePath = "openapi.yaml"
+	config.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	config.Progress = func(event datamodel.ProgressEvent) {
+		events = append(events, event)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	info, _ := datamodel.ExtractSpecInfo(data)
+	d, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+	require.NotNil(t, d.Paths.Value)
+
+	phases := make(map[datamodel.BuildPhase][]datamodel.ProgressEvent)
+	for _, e := range events {
+		assert.Equal(t, "openapi.yaml", e.Document)
+		phases[e.Phase] = append(phases[e.Phase], e)
+	}
+	require.Len(t, phases[datamodel.PhaseIndex], 1)
+	assert.Equal(t, filepath.Join(dir, "responses.yaml"), phases[datamodel.PhaseIndex][0].File)
+	require.Len(t, phases[datamodel.PhaseResolve], 1)
+	assert.Equal(t, 1, phases[datamodel.PhaseResolve][0].Total)
+
+	// extensions of the paths object are not path items.
+	built := phases[datamodel.PhaseBuild]
+	require.Len(t, built, 2)
+	var paths []string
+	for i, e := range built {
+		assert.Equal(t, i+1, e.Done)
+		assert.Equal(t, 2, e.Total)
+		paths = append(paths, e.Path)
+	}
+	assert.ElementsMatch(t, []string{"/pets", "/pets/{id}"}, paths)
+
+	// the unclaimed root key is logged with the document and phase.
+	var logged map[string]any
+	require.NoError(t, json.Unmarshal(bytes.Split(buf.Bytes(), []byte("\n"))[0], &logged))
+	assert.Equal(t, "openapi.yaml", logged[datamodel.LogKeyDocument])
+	assert.Equal(t, string(datamodel.PhaseBuild), logged[datamodel.LogKeyPhase])
+	assert.Contains(t, logged["msg"], "definitions")
+}
+
+func TestCreateDocument_IncludeExcludePaths(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /users:
+    get: {}
+  /users/{id}:
+    get: {}
+  /users/{id}/secrets:
+    get: {}
+  /orders/{id}:
+    get: {}
+  x-internal: true`
+
+	build := func(include, exclude []string) []string {
+		info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+		config := datamodel.NewDocumentConfiguration()
+		config.IncludePaths = include
+		config.ExcludePaths = exclude
+		d, err := CreateDocumentFromConfig(info, config)
+		require.NoError(t, err)
+		var paths []string
+		for k := range d.Paths.Value.PathItems.KeysFromOldest() {
+			paths = append(paths, k.Value)
+		}
+		// filtered documents keep their extensions.
+		assert.NotNil(t, d.Paths.Value.FindExtension("x-internal"))
+		return paths
+	}
+
+	assert.Equal(t, []string{"/users", "/users/{id}", "/users/{id}/secrets", "/orders/{id}"}, build(nil, nil))
+	assert.Equal(t, []string{"/users", "/users/{id}"}, build([]string{"/users/**"}, []string{"**/secrets"}))
+	assert.Equal(t, []string{"/orders/{id}"}, build([]string{"regex:^/orders/"}, nil))
+	assert.Equal(t, []string{"/users", "/orders/{id}"}, build(nil, []string{"/users/{id}/**"}))
+}
+
+func TestCreateDocument_IncludePaths_Invalid(t *testing.T) {
+	info, _ := datamodel.ExtractSpecInfo([]byte(`openapi: 3.1.0`))
+	config := datamodel.NewDocumentConfiguration()
+	config.IncludePaths = []string{"regex:("}
+	_, err := CreateDocumentFromConfig(info, config)
+	assert.ErrorContains(t, err, "invalid path selector 'regex:('")
+}
+
+func TestCreateDocument_Strict(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses: nope
+  /owners:
+    get:
+      responses:
+        '200':
+          description: ok`
+
+	for _, strict := range []bool{false, true} {
+		info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+		config := datamodel.NewDocumentConfiguration()
+		config.Strict = strict
+		d, err := CreateDocumentFromConfig(info, config)
+		if strict {
+			assert.ErrorContains(t, err, "path '/pets' build failed: responses build failed")
+		} else {
+			assert.NoError(t, err)
+		}
+		// the paths that built are there either way.
+		assert.NotNil(t, d.Paths.Value.FindPath("/owners"))
+	}
+}
+
+func TestCreateDocument_InternStrings(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	config := datamodel.NewDocumentConfiguration()
+	config.InternStrings = true
+
+	info, _ := datamodel.ExtractSpecInfo(data)
+	d, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+
+	// the keys were decoded separately, but share their memory, however often the garbage collector runs.
+	runtime.GC()
+	description := d.Info.Value.Description.KeyNode.Value
+	tag := d.Tags.Value[0].Value
+	assert.Same(t, unsafe.StringData(description), unsafe.StringData(tag.Description.KeyNode.Value))
+	assert.Same(t, unsafe.StringData(description), unsafe.StringData(tag.ExternalDocs.Value.Description.KeyNode.Value))
+
+	// but not with the keys of another document.
+	info, _ = datamodel.ExtractSpecInfo(data)
+	other, err := CreateDocumentFromConfig(info, config)
+	require.NoError(t, err)
+	assert.NotSame(t, unsafe.StringData(description), unsafe.StringData(other.Info.Value.Description.KeyNode.Value))
+}
+
+func TestCreateDocument_Lenient(t *testing.T) {
+	yml := `openapi: 3.1.0
+tags:
+  - name: broken
+    externalDocs:
+      $ref: '#/nope'
+  - name: pets
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Missing'
+  /owners:
+    get:
+      responses: nope
+  /vets:
+    get:
+      responses:
+        '200':
+          description: ok
+components:
+  parameters:
+    Broken:
+      $ref: '#/components/parameters/Missing'
+    Fine:
+      name: fine
+      in: query`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	config := datamodel.NewDocumentConfiguration()
+	config.Lenient = true
+	d, err := CreateDocumentFromConfig(info, config)
+
+	// every problem is returned, not just the first of each.
+	assert.ErrorContains(t, err, "cannot find reference: '#/components/pathItems/Missing'")
+	assert.ErrorContains(t, err, "path '/owners' build failed: responses build failed")
+	assert.ErrorContains(t, err, "component 'Broken' build failed")
+	assert.ErrorContains(t, err, "reference '#/nope' at line 5, column 7 was not found")
+
+	// and everything that built is kept.
+	assert.Equal(t, 2, d.Paths.Value.PathItems.Len())
+	assert.NotNil(t, d.Paths.Value.FindPath("/vets"))
+	assert.Equal(t, 1, d.Components.Value.Parameters.Value.Len())
+	require.Len(t, d.Tags.Value, 1)
+	assert.Equal(t, "pets", d.Tags.Value[0].Value.Name.Value)
+
+	// without it, the paths, components and tags all stop at the first problem.
+	info, _ = datamodel.ExtractSpecInfo([]byte(yml))
+	d, err = CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.Error(t, err)
+	assert.Nil(t, d.Paths.Value)
+	assert.Nil(t, d.Components.Value)
+	assert.Nil(t, d.Tags.Value)
+}
@@ -21,6 +21,11 @@ type Document struct {
 	// This is not a standard property of the OpenAPI model, it's a convenience mechanism only.
 	Version low.NodeReference[string]
 
+	// Self is a 3.2+ property that is the URI of the document, it's used as the base URI for resolving relative
+	// references. It's only built for 3.2+ documents.
+	// - https://spec.openapis.org/oas/v3.2.0#openapi-object
+	Self low.NodeReference[string] // 3.2
+
 	// Info represents a specification Info definitions
 	// Provides metadata about the API. The metadata MAY be used by tooling as required.
 	// - https://spec.openapis.org/oas/v3.1.0#info-object
@@ -77,6 +82,19 @@ type Document struct {
 	// Extensions contains all custom extensions defined for the top-level document.
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 
+	// Unclaimed contains all root keys that are not part of the OpenAPI 3 specification, and are not extensions
+	// (for example a `definitions` block pasted in from a Swagger document). Unclaimed content is not built into
+	// the model, it's kept here (with positions) so it is not silently lost.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Unclaimed *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+
+	// Diagnostics contains problems found when building the document that did not stop it being built, such as
+	// unclaimed root keys and malformed path keys.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	Diagnostics []*low.Diagnostic
+
 	// Index is a reference to the *index.SpecIndex that was created for the document and used
 	// as a guide when building out the Document. Ideal if further processing is required on the model and
 	// the original details are required to continue the work.
@@ -88,6 +106,13 @@ type Document struct {
 	Rolodex *index.Rolodex
 
 	low.NodeMap
+	extensionErrors *low.ExtensionErrors
+}
+
+// GetExtensionErrors returns the errors raised while building the registered extensions of the document (see
+// low.ExtensionRegistry), including those of schemas built lazily once the document was built.
+func (d *Document) GetExtensionErrors() []error {
+	return d.extensionErrors.GetErrors()
 }
 
 // FindSecurityRequirement will attempt to locate a security requirement string from a supplied name.
@@ -24,6 +24,7 @@ type Encoding struct {
 	Style         low.NodeReference[string]
 	Explode       low.NodeReference[bool]
 	AllowReserved low.NodeReference[bool]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode       *yaml.Node
 	RootNode      *yaml.Node
 	*low.Reference
@@ -45,14 +46,29 @@ func (en *Encoding) GetKeyNode() *yaml.Node {
 	return en.KeyNode
 }
 
+// GetUnknownFields returns the keys of the Encoding that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (en *Encoding) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return en.UnknownFields
+}
+
 // Hash will return a consistent SHA256 Hash of the Encoding object
 func (en *Encoding) Hash() [32]byte {
+	return en.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Encoding object, computed using the requested low.HashVersion.
+func (en *Encoding) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, en.hash)
+}
+
+func (en *Encoding) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if en.ContentType.Value != "" {
 		f = append(f, en.ContentType.Value)
 	}
 	for k, v := range orderedmap.SortAlpha(en.Headers.Value).FromOldest() {
-		f = append(f, fmt.Sprintf("%s-%x", k.Value, v.Value.Hash()))
+		f = append(f, fmt.Sprintf("%s-%s", k.Value, low.GenerateHashStringForVersion(v.Value, version)))
 	}
 	if en.Style.Value != "" {
 		f = append(f, en.Style.Value)
@@ -70,6 +86,7 @@ func (en *Encoding) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	utils.CheckForMergeNodes(root)
 	en.Nodes = low.ExtractNodes(ctx, root)
 	en.Reference = new(low.Reference)
+	en.UnknownFields = low.ExtractUnknownFields(root, en)
 	headers, hL, hN, err := low.ExtractMap[*Header](ctx, HeadersLabel, root, idx)
 	if err != nil {
 		return err
@@ -32,6 +32,7 @@ type Header struct {
 	Examples        low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
 	Content         low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*MediaType]]]
 	Extensions      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode         *yaml.Node
 	RootNode        *yaml.Node
 	*low.Reference
@@ -68,8 +69,23 @@ func (h *Header) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.V
 	return h.Extensions
 }
 
+// GetUnknownFields returns the keys of the Header that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (h *Header) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return h.UnknownFields
+}
+
 // Hash will return a consistent SHA256 Hash of the Header object
 func (h *Header) Hash() [32]byte {
+	return h.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Header object, computed using the requested low.HashVersion.
+func (h *Header) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, h.hash)
+}
+
+func (h *Header) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if h.Description.Value != "" {
 		f = append(f, h.Description.Value)
@@ -83,16 +99,16 @@ func (h *Header) Hash() [32]byte {
 	f = append(f, fmt.Sprint(h.Explode.Value))
 	f = append(f, fmt.Sprint(h.AllowReserved.Value))
 	if h.Schema.Value != nil {
-		f = append(f, low.GenerateHashString(h.Schema.Value))
+		f = append(f, low.GenerateHashStringForVersion(h.Schema.Value, version))
 	}
 	if h.Example.Value != nil && !h.Example.Value.IsZero() {
-		f = append(f, low.GenerateHashString(h.Example.Value))
+		f = append(f, low.GenerateHashStringForVersion(h.Example.Value, version))
 	}
 	for k, v := range orderedmap.SortAlpha(h.Examples.Value).FromOldest() {
 		f = append(f, fmt.Sprintf("%s-%x", k.Value, v.Value.Hash()))
 	}
 	for k, v := range orderedmap.SortAlpha(h.Content.Value).FromOldest() {
-		f = append(f, fmt.Sprintf("%s-%x", k.Value, v.Value.Hash()))
+		f = append(f, fmt.Sprintf("%s-%s", k.Value, low.GenerateHashStringForVersion(v.Value, version)))
 	}
 	f = append(f, low.HashExtensions(h.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
@@ -106,7 +122,8 @@ func (h *Header) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index
 	utils.CheckForMergeNodes(root)
 	h.Reference = new(low.Reference)
 	h.Nodes = low.ExtractNodes(ctx, root)
-	h.Extensions = low.ExtractExtensions(root)
+	h.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	h.UnknownFields = low.ExtractUnknownFields(root, h)
 	low.ExtractExtensionNodes(ctx, h.Extensions, h.Nodes)
 	// handle example if set.
 	_, expLabel, expNode := utils.FindKeyNodeFull(base.ExampleLabel, root.Content)
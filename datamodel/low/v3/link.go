@@ -28,15 +28,16 @@ import (
 // in an operation and using them as parameters while invoking the linked operation.
 //   - https://spec.openapis.org/oas/v3.1.0#link-object
 type Link struct {
-	OperationRef low.NodeReference[string]
-	OperationId  low.NodeReference[string]
-	Parameters   low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
-	RequestBody  low.NodeReference[string]
-	Description  low.NodeReference[string]
-	Server       low.NodeReference[*Server]
-	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode      *yaml.Node
-	RootNode     *yaml.Node
+	OperationRef  low.NodeReference[string]
+	OperationId   low.NodeReference[string]
+	Parameters    low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	RequestBody   low.NodeReference[string]
+	Description   low.NodeReference[string]
+	Server        low.NodeReference[*Server]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -46,6 +47,12 @@ func (l *Link) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.Val
 	return l.Extensions
 }
 
+// GetUnknownFields returns the keys of the Link that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (l *Link) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return l.UnknownFields
+}
+
 // FindParameter will attempt to locate a parameter string value, using a parameter name input.
 func (l *Link) FindParameter(pName string) *low.ValueReference[string] {
 	return low.FindItemInOrderedMap[string](pName, l.Parameters.Value)
@@ -74,7 +81,8 @@ func (l *Link) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.S
 	utils.CheckForMergeNodes(root)
 	l.Reference = new(low.Reference)
 	l.Nodes = low.ExtractNodes(ctx, root)
-	l.Extensions = low.ExtractExtensions(root)
+	l.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	l.UnknownFields = low.ExtractUnknownFields(root, l)
 	low.ExtractExtensionNodes(ctx, l.Extensions, l.Nodes)
 
 	// extract parameter nodes.
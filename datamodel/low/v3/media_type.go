@@ -22,13 +22,15 @@ import (
 // Each Media Type Object provides schema and examples for the media type identified by its key.
 //   - https://spec.openapis.org/oas/v3.1.0#media-type-object
 type MediaType struct {
-	Schema     low.NodeReference[*base.SchemaProxy]
-	Example    low.NodeReference[*yaml.Node]
-	Examples   low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
-	Encoding   low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
-	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode    *yaml.Node
-	RootNode   *yaml.Node
+	Schema        low.NodeReference[*base.SchemaProxy]
+	ItemSchema    low.NodeReference[*base.SchemaProxy]
+	Example       low.NodeReference[*yaml.Node]
+	Examples      low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
+	Encoding      low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -38,6 +40,12 @@ func (mt *MediaType) GetExtensions() *orderedmap.Map[low.KeyReference[string], l
 	return mt.Extensions
 }
 
+// GetUnknownFields returns the keys of the MediaType that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (mt *MediaType) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return mt.UnknownFields
+}
+
 // FindExtension will attempt to locate an extension with the supplied name.
 func (mt *MediaType) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, mt.Extensions)
@@ -76,7 +84,8 @@ func (mt *MediaType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 	utils.CheckForMergeNodes(root)
 	mt.Reference = new(low.Reference)
 	mt.Nodes = low.ExtractNodes(ctx, root)
-	mt.Extensions = low.ExtractExtensions(root)
+	mt.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	mt.UnknownFields = low.ExtractUnknownFields(root, mt)
 	low.ExtractExtensionNodes(ctx, mt.Extensions, mt.Nodes)
 
 	// handle example if set.
@@ -100,6 +109,15 @@ func (mt *MediaType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 		mt.Schema = *sch
 	}
 
+	// handle item schema (OpenAPI 3.2), the schema of every item of a sequential media type.
+	_, itemLabel, itemNode := utils.FindKeyNodeFullTop(ItemSchemaLabel, root.Content)
+	if itemNode != nil {
+		sp := new(base.SchemaProxy)
+		_ = sp.Build(ctx, itemLabel, itemNode, idx) // returns no errors.
+		mt.ItemSchema = low.NodeReference[*base.SchemaProxy]{Value: sp, KeyNode: itemLabel, ValueNode: itemNode}
+		mt.Nodes.Store(itemLabel.Line, itemLabel)
+	}
+
 	// handle examples if set.
 	exps, expsL, expsN, eErr := low.ExtractMap[*base.Example](ctx, base.ExamplesLabel, root, idx)
 	if eErr != nil {
@@ -139,18 +157,30 @@ func (mt *MediaType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 
 // Hash will return a consistent SHA256 Hash of the MediaType object
 func (mt *MediaType) Hash() [32]byte {
+	return mt.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the MediaType object, computed using the requested low.HashVersion.
+func (mt *MediaType) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, mt.hash)
+}
+
+func (mt *MediaType) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if mt.Schema.Value != nil {
-		f = append(f, low.GenerateHashString(mt.Schema.Value))
+		f = append(f, low.GenerateHashStringForVersion(mt.Schema.Value, version))
+	}
+	if mt.ItemSchema.Value != nil {
+		f = append(f, ItemSchemaLabel+":"+low.GenerateHashStringForVersion(mt.ItemSchema.Value, version))
 	}
 	if mt.Example.Value != nil && !mt.Example.Value.IsZero() {
-		f = append(f, low.GenerateHashString(mt.Example.Value))
+		f = append(f, low.GenerateHashStringForVersion(mt.Example.Value, version))
 	}
 	for v := range orderedmap.SortAlpha(mt.Examples.Value).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 	for v := range orderedmap.SortAlpha(mt.Encoding.Value).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 	f = append(f, low.HashExtensions(mt.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
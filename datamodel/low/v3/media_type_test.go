@@ -159,6 +159,42 @@ example: a thing`
 	assert.Equal(t, 1, orderedmap.Len(n.GetExtensions()))
 }
 
+func TestMediaType_HashForVersion(t *testing.T) {
+	yml := `schema:
+  type: object
+  $defs:
+    leaf:
+      type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n MediaType
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	yml2 := `schema:
+  type: object`
+
+	var idxNode2 yaml.Node
+	_ = yaml.Unmarshal([]byte(yml2), &idxNode2)
+	idx2 := index.NewSpecIndex(&idxNode2)
+
+	var n2 MediaType
+	_ = low.BuildModel(idxNode2.Content[0], &n2)
+	_ = n2.Build(context.Background(), nil, idxNode2.Content[0], idx2)
+
+	assert.NotEqual(t, n.Hash(), n2.Hash())
+
+	// $defs were not part of the hash of the schema in version 1.
+	v1, err := low.FingerprintWithVersion(&n, low.HashVersion1)
+	assert.NoError(t, err)
+	same, err := low.CompareFingerprint(&n2, v1)
+	assert.NoError(t, err)
+	assert.True(t, same)
+}
+
 func TestMediaType_Examples(t *testing.T) {
 	yml := `examples:
     pbjBurger:
@@ -201,3 +237,39 @@ func TestMediaType_Examples_NotFromSchema(t *testing.T) {
 
 	assert.Equal(t, 0, orderedmap.Len(n.Examples.Value))
 }
+
+func TestMediaType_Build_ItemSchema(t *testing.T) {
+	yml := `itemSchema:
+  type: object
+  properties:
+    data:
+      type: string`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n MediaType
+	err := low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, err)
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	assert.True(t, n.Schema.IsEmpty())
+	assert.Equal(t, ItemSchemaLabel, n.ItemSchema.KeyNode.Value)
+	assert.Equal(t, "object", n.ItemSchema.Value.Schema().Type.Value.A)
+
+	yml2 := `schema:
+  type: object
+  properties:
+    data:
+      type: string`
+
+	var idxNode2 yaml.Node
+	_ = yaml.Unmarshal([]byte(yml2), &idxNode2)
+
+	var n2 MediaType
+	_ = low.BuildModel(idxNode2.Content[0], &n2)
+	_ = n2.Build(context.Background(), nil, idxNode2.Content[0], index.NewSpecIndex(&idxNode2))
+	assert.NotEqual(t, n.Hash(), n2.Hash())
+}
@@ -24,6 +24,7 @@ type OAuthFlows struct {
 	ClientCredentials low.NodeReference[*OAuthFlow]
 	AuthorizationCode low.NodeReference[*OAuthFlow]
 	Extensions        *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode           *yaml.Node
 	RootNode          *yaml.Node
 	*low.Reference
@@ -35,6 +36,12 @@ func (o *OAuthFlows) GetExtensions() *orderedmap.Map[low.KeyReference[string], l
 	return o.Extensions
 }
 
+// GetUnknownFields returns the keys of the OAuthFlows that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (o *OAuthFlows) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return o.UnknownFields
+}
+
 // FindExtension will attempt to locate an extension with the supplied name.
 func (o *OAuthFlows) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, o.Extensions)
@@ -58,7 +65,8 @@ func (o *OAuthFlows) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 	utils.CheckForMergeNodes(root)
 	o.Reference = new(low.Reference)
 	o.Nodes = low.ExtractNodes(ctx, root)
-	o.Extensions = low.ExtractExtensions(root)
+	o.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	o.UnknownFields = low.ExtractUnknownFields(root, o)
 
 	v, vErr := low.ExtractObject[*OAuthFlow](ctx, ImplicitLabel, root, idx)
 	if vErr != nil {
@@ -113,6 +121,7 @@ type OAuthFlow struct {
 	RefreshUrl       low.NodeReference[string]
 	Scopes           low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	RootNode         *yaml.Node
 	*low.Reference
 	low.NodeMap
@@ -123,6 +132,12 @@ func (o *OAuthFlow) GetExtensions() *orderedmap.Map[low.KeyReference[string], lo
 	return o.Extensions
 }
 
+// GetUnknownFields returns the keys of the OAuthFlow that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (o *OAuthFlow) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return o.UnknownFields
+}
+
 // FindScope attempts to locate a scope using a specified name.
 func (o *OAuthFlow) FindScope(scope string) *low.ValueReference[string] {
 	return low.FindItemInOrderedMap[string](scope, o.Scopes.Value)
@@ -142,7 +157,8 @@ func (o *OAuthFlow) GetRootNode() *yaml.Node {
 func (o *OAuthFlow) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
 	o.Reference = new(low.Reference)
 	o.Nodes = low.ExtractNodes(ctx, root)
-	o.Extensions = low.ExtractExtensions(root)
+	o.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	o.UnknownFields = low.ExtractUnknownFields(root, o)
 	low.ExtractExtensionNodes(ctx, o.Extensions, o.Nodes)
 
 	if o.Scopes.Value != nil && o.Scopes.Value.Len() > 0 {
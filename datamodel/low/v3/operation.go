@@ -24,21 +24,23 @@ import (
 // happens here. The entire being for existence of this library and the specification, is this Operation.
 //   - https://spec.openapis.org/oas/v3.1.0#operation-object
 type Operation struct {
-	Tags         low.NodeReference[[]low.ValueReference[string]]
-	Summary      low.NodeReference[string]
-	Description  low.NodeReference[string]
-	ExternalDocs low.NodeReference[*base.ExternalDoc]
-	OperationId  low.NodeReference[string]
-	Parameters   low.NodeReference[[]low.ValueReference[*Parameter]]
-	RequestBody  low.NodeReference[*RequestBody]
-	Responses    low.NodeReference[*Responses]
-	Callbacks    low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Callback]]]
-	Deprecated   low.NodeReference[bool]
-	Security     low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]
-	Servers      low.NodeReference[[]low.ValueReference[*Server]]
-	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode      *yaml.Node
-	RootNode     *yaml.Node
+	Tags          low.NodeReference[[]low.ValueReference[string]]
+	Summary       low.NodeReference[string]
+	Description   low.NodeReference[string]
+	ExternalDocs  low.NodeReference[*base.ExternalDoc]
+	OperationId   low.NodeReference[string]
+	Parameters    low.NodeReference[[]low.ValueReference[*Parameter]]
+	RequestBody   low.NodeReference[*RequestBody]
+	Responses     low.NodeReference[*Responses]
+	Callbacks     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Callback]]]
+	Deprecated    low.NodeReference[bool]
+	Security      low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]
+	Servers       low.NodeReference[[]low.ValueReference[*Server]]
+	CodeSamples   low.NodeReference[[]low.ValueReference[*base.CodeSample]]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -71,7 +73,7 @@ func (o *Operation) GetKeyNode() *yaml.Node {
 	return o.KeyNode
 }
 
-// Build will extract external docs, parameters, request body, responses, callbacks, security and servers.
+// Build will extract external docs, parameters, request body, responses, callbacks, security, servers and code samples.
 func (o *Operation) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	o.KeyNode = keyNode
 	o.RootNode = root
@@ -79,8 +81,10 @@ func (o *Operation) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	utils.CheckForMergeNodes(root)
 	o.Reference = new(low.Reference)
 	o.Nodes = low.ExtractNodes(ctx, root)
-	o.Extensions = low.ExtractExtensions(root)
+	o.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	o.UnknownFields = low.ExtractUnknownFields(root, o)
 	low.ExtractExtensionNodes(ctx, o.Extensions, o.Nodes)
+	o.CodeSamples = base.ExtractCodeSamples(ctx, root, idx)
 
 	// extract externalDocs
 	extDocs, dErr := low.ExtractObject[*base.ExternalDoc](ctx, base.ExternalDocsLabel, root, idx)
@@ -190,6 +194,15 @@ func (o *Operation) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 
 // Hash will return a consistent SHA256 Hash of the Operation object
 func (o *Operation) Hash() [32]byte {
+	return o.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Operation object, computed using the requested low.HashVersion.
+func (o *Operation) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, o.hash)
+}
+
+func (o *Operation) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if !o.Summary.IsEmpty() {
 		f = append(f, o.Summary.Value)
@@ -201,20 +214,20 @@ func (o *Operation) Hash() [32]byte {
 		f = append(f, o.OperationId.Value)
 	}
 	if !o.RequestBody.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.RequestBody.Value))
+		f = append(f, low.GenerateHashStringForVersion(o.RequestBody.Value, version))
 	}
 	if !o.Summary.IsEmpty() {
 		f = append(f, o.Summary.Value)
 	}
 	if !o.ExternalDocs.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.ExternalDocs.Value))
+		f = append(f, low.GenerateHashStringForVersion(o.ExternalDocs.Value, version))
 	}
 	if !o.Responses.IsEmpty() {
-		f = append(f, low.GenerateHashString(o.Responses.Value))
+		f = append(f, low.GenerateHashStringForVersion(o.Responses.Value, version))
 	}
 	if !o.Security.IsEmpty() {
 		for k := range o.Security.Value {
-			f = append(f, low.GenerateHashString(o.Security.Value[k].Value))
+			f = append(f, low.GenerateHashStringForVersion(o.Security.Value[k].Value, version))
 		}
 	}
 	if !o.Deprecated.IsEmpty() {
@@ -230,20 +243,20 @@ func (o *Operation) Hash() [32]byte {
 
 	keys = make([]string, len(o.Servers.Value))
 	for k := range o.Servers.Value {
-		keys[k] = low.GenerateHashString(o.Servers.Value[k].Value)
+		keys[k] = low.GenerateHashStringForVersion(o.Servers.Value[k].Value, version)
 	}
 	sort.Strings(keys)
 	f = append(f, keys...)
 
 	keys = make([]string, len(o.Parameters.Value))
 	for k := range o.Parameters.Value {
-		keys[k] = low.GenerateHashString(o.Parameters.Value[k].Value)
+		keys[k] = low.GenerateHashStringForVersion(o.Parameters.Value[k].Value, version)
 	}
 	sort.Strings(keys)
 	f = append(f, keys...)
 
 	for v := range orderedmap.SortAlpha(o.Callbacks.Value).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 	f = append(f, low.HashExtensions(o.Extensions)...)
 
@@ -284,6 +297,12 @@ func (o *Operation) GetExtensions() *orderedmap.Map[low.KeyReference[string], lo
 	return o.Extensions
 }
 
+// GetUnknownFields returns the keys of the Operation that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (o *Operation) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return o.UnknownFields
+}
+
 func (o *Operation) GetResponses() low.NodeReference[any] {
 	return low.NodeReference[any]{
 		ValueNode: o.Responses.ValueNode,
@@ -39,6 +39,7 @@ type Parameter struct {
 	Examples        low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
 	Content         low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*MediaType]]]
 	Extensions      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	*low.Reference
 	low.NodeMap
 }
@@ -73,6 +74,12 @@ func (p *Parameter) GetExtensions() *orderedmap.Map[low.KeyReference[string], lo
 	return p.Extensions
 }
 
+// GetUnknownFields returns the keys of the Parameter that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (p *Parameter) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.UnknownFields
+}
+
 // Build will extract examples, extensions and content/media types.
 func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
@@ -81,7 +88,8 @@ func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	utils.CheckForMergeNodes(root)
 	p.Reference = new(low.Reference)
 	p.Nodes = low.ExtractNodes(ctx, root)
-	p.Extensions = low.ExtractExtensions(root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	p.UnknownFields = low.ExtractUnknownFields(root, p)
 	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
 
 	// handle example if set.
@@ -140,6 +148,15 @@ func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 
 // Hash will return a consistent SHA256 Hash of the Parameter object
 func (p *Parameter) Hash() [32]byte {
+	return p.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Parameter object, computed using the requested low.HashVersion.
+func (p *Parameter) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, p.hash)
+}
+
+func (p *Parameter) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if p.Name.Value != "" {
 		f = append(f, p.Name.Value)
@@ -159,16 +176,16 @@ func (p *Parameter) Hash() [32]byte {
 	f = append(f, fmt.Sprint(p.Explode.Value))
 	f = append(f, fmt.Sprint(p.AllowReserved.Value))
 	if p.Schema.Value != nil && p.Schema.Value.Schema() != nil {
-		f = append(f, fmt.Sprintf("%x", p.Schema.Value.Schema().Hash()))
+		f = append(f, low.GenerateHashStringForVersion(p.Schema.Value.Schema(), version))
 	}
 	if p.Example.Value != nil && !p.Example.Value.IsZero() {
-		f = append(f, low.GenerateHashString(p.Example.Value))
+		f = append(f, low.GenerateHashStringForVersion(p.Example.Value, version))
 	}
 	for v := range orderedmap.SortAlpha(p.Examples.Value).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 	for v := range orderedmap.SortAlpha(p.Content.Value).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 	f = append(f, low.HashExtensions(p.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
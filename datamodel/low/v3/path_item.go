@@ -25,28 +25,42 @@ import (
 // The path itself is still exposed to the documentation viewer, but they will not know which operations and parameters
 // are available.
 //   - https://spec.openapis.org/oas/v3.1.0#path-item-object
+//
+// Query and AdditionalOperations were added in OpenAPI 3.2, they are only built for 3.2+ documents.
 type PathItem struct {
-	Description low.NodeReference[string]
-	Summary     low.NodeReference[string]
-	Get         low.NodeReference[*Operation]
-	Put         low.NodeReference[*Operation]
-	Post        low.NodeReference[*Operation]
-	Delete      low.NodeReference[*Operation]
-	Options     low.NodeReference[*Operation]
-	Head        low.NodeReference[*Operation]
-	Patch       low.NodeReference[*Operation]
-	Trace       low.NodeReference[*Operation]
-	Servers     low.NodeReference[[]low.ValueReference[*Server]]
-	Parameters  low.NodeReference[[]low.ValueReference[*Parameter]]
-	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode     *yaml.Node
-	RootNode    *yaml.Node
+	Description          low.NodeReference[string]
+	Summary              low.NodeReference[string]
+	Get                  low.NodeReference[*Operation]
+	Put                  low.NodeReference[*Operation]
+	Post                 low.NodeReference[*Operation]
+	Delete               low.NodeReference[*Operation]
+	Options              low.NodeReference[*Operation]
+	Head                 low.NodeReference[*Operation]
+	Patch                low.NodeReference[*Operation]
+	Trace                low.NodeReference[*Operation]
+	Query                low.NodeReference[*Operation]                                                                // 3.2
+	AdditionalOperations low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Operation]]] // 3.2
+	Servers              low.NodeReference[[]low.ValueReference[*Server]]
+	Parameters           low.NodeReference[[]low.ValueReference[*Parameter]]
+	Extensions           *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields        *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode              *yaml.Node
+	RootNode             *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
 
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *PathItem) Hash() [32]byte {
+	return p.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the PathItem object, computed using the requested low.HashVersion.
+func (p *PathItem) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, p.hash)
+}
+
+func (p *PathItem) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if !p.Description.IsEmpty() {
 		f = append(f, p.Description.Value)
@@ -55,38 +69,44 @@ func (p *PathItem) Hash() [32]byte {
 		f = append(f, p.Summary.Value)
 	}
 	if !p.Get.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", GetLabel, low.GenerateHashString(p.Get.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", GetLabel, low.GenerateHashStringForVersion(p.Get.Value, version)))
 	}
 	if !p.Put.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", PutLabel, low.GenerateHashString(p.Put.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", PutLabel, low.GenerateHashStringForVersion(p.Put.Value, version)))
 	}
 	if !p.Post.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", PutLabel, low.GenerateHashString(p.Post.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", PutLabel, low.GenerateHashStringForVersion(p.Post.Value, version)))
 	}
 	if !p.Delete.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", DeleteLabel, low.GenerateHashString(p.Delete.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", DeleteLabel, low.GenerateHashStringForVersion(p.Delete.Value, version)))
 	}
 	if !p.Options.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", OptionsLabel, low.GenerateHashString(p.Options.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", OptionsLabel, low.GenerateHashStringForVersion(p.Options.Value, version)))
 	}
 	if !p.Head.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", HeadLabel, low.GenerateHashString(p.Head.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", HeadLabel, low.GenerateHashStringForVersion(p.Head.Value, version)))
 	}
 	if !p.Patch.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", PatchLabel, low.GenerateHashString(p.Patch.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", PatchLabel, low.GenerateHashStringForVersion(p.Patch.Value, version)))
 	}
 	if !p.Trace.IsEmpty() {
-		f = append(f, fmt.Sprintf("%s-%s", TraceLabel, low.GenerateHashString(p.Trace.Value)))
+		f = append(f, fmt.Sprintf("%s-%s", TraceLabel, low.GenerateHashStringForVersion(p.Trace.Value, version)))
+	}
+	if !p.Query.IsEmpty() {
+		f = append(f, fmt.Sprintf("%s-%s", QueryLabel, low.GenerateHashStringForVersion(p.Query.Value, version)))
+	}
+	for k, v := range p.AdditionalOperations.Value.FromOldest() {
+		f = append(f, fmt.Sprintf("%s-%s-%s", AdditionalOperationsLabel, k.Value, low.GenerateHashStringForVersion(v.Value, version)))
 	}
 	keys := make([]string, len(p.Parameters.Value))
 	for k := range p.Parameters.Value {
-		keys[k] = low.GenerateHashString(p.Parameters.Value[k].Value)
+		keys[k] = low.GenerateHashStringForVersion(p.Parameters.Value[k].Value, version)
 	}
 	sort.Strings(keys)
 	f = append(f, keys...)
 	keys = make([]string, len(p.Servers.Value))
 	for k := range p.Servers.Value {
-		keys[k] = low.GenerateHashString(p.Servers.Value[k].Value)
+		keys[k] = low.GenerateHashStringForVersion(p.Servers.Value[k].Value, version)
 	}
 	sort.Strings(keys)
 	f = append(f, keys...)
@@ -114,6 +134,12 @@ func (p *PathItem) GetExtensions() *orderedmap.Map[low.KeyReference[string], low
 	return p.Extensions
 }
 
+// GetUnknownFields returns the keys of the PathItem that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (p *PathItem) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.UnknownFields
+}
+
 // Build extracts extensions, parameters, servers and each http method defined.
 // everything is extracted asynchronously for speed.
 func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
@@ -123,7 +149,8 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 	utils.CheckForMergeNodes(root)
 	p.Reference = new(low.Reference)
 	p.Nodes = low.ExtractNodes(ctx, root)
-	p.Extensions = low.ExtractExtensions(root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	p.UnknownFields = low.ExtractUnknownFields(root, p)
 	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
 	skip := false
 	var currentNode *yaml.Node
@@ -170,44 +197,16 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 		}
 	}
 
-	for i, pathNode := range root.Content {
-		if strings.HasPrefix(strings.ToLower(pathNode.Value), "x-") {
-			skip = true
-			continue
-		}
-		if strings.HasPrefix(strings.ToLower(pathNode.Value), "parameters") {
-			skip = true
-			continue
-		}
-		if skip {
-			skip = false
-			continue
-		}
-		if i%2 == 0 {
-			currentNode = pathNode
-			continue
-		}
-
-		// the only thing we now care about is handling operations, filter out anything that's not a verb.
-		switch currentNode.Value {
-		case GetLabel:
-		case PostLabel:
-		case PutLabel:
-		case PatchLabel:
-		case DeleteLabel:
-		case HeadLabel:
-		case OptionsLabel:
-		case TraceLabel:
-		default:
-			continue // ignore everything else.
-		}
+	supports32 := low.SupportsVersion(idx, 3.2)
 
+	// buildOp superficially builds the operation found at opNode, operations are fully built once they are all found.
+	buildOp := func(keyNode, opNode *yaml.Node) (low.NodeReference[*Operation], error) {
 		foundContext := ctx
 		var op Operation
 		opIsRef := false
 		var opRefVal string
 		var opRefNode *yaml.Node
-		if ok, _, ref := utils.IsNodeRefValue(pathNode); ok {
+		if ok, _, ref := utils.IsNodeRefValue(opNode); ok {
 			// According to OpenAPI spec the only valid $ref for paths is
 			// reference for the whole pathItem. Unfortunately, internet is full of invalid specs
 			// even from trusted companies like DigitalOcean where they tend to
@@ -219,40 +218,40 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 
 			opIsRef = true
 			opRefVal = ref
-			opRefNode = pathNode
-			r, newIdx, err, nCtx := low.LocateRefNodeWithContext(ctx, pathNode, idx)
+			opRefNode = opNode
+			r, newIdx, err, nCtx := low.LocateRefNodeWithContext(ctx, opNode, idx)
 			if r != nil {
 				if r.Kind == yaml.DocumentNode {
 					r = r.Content[0]
 				}
-				pathNode = r
+				opNode = r
 				foundContext = nCtx
 				foundContext = context.WithValue(foundContext, index.FoundIndexKey, newIdx)
 
 				if r.Tag == "" {
 					// If it's a node from file, tag is empty
-					pathNode = r.Content[0]
+					opNode = r.Content[0]
 				}
 
 				if err != nil {
 					if !idx.AllowCircularReferenceResolving() {
-						return fmt.Errorf("build schema failed: %s", err.Error())
+						return low.NodeReference[*Operation]{}, fmt.Errorf("build schema failed: %s", err.Error())
 					}
 				}
 			} else {
-				return fmt.Errorf("path item build failed: cannot find reference: %s at line %d, col %d",
-					pathNode.Content[1].Value, pathNode.Content[1].Line, pathNode.Content[1].Column)
+				return low.NodeReference[*Operation]{}, fmt.Errorf("path item build failed: cannot find reference: %s at line %d, col %d",
+					opNode.Content[1].Value, opNode.Content[1].Line, opNode.Content[1].Column)
 			}
 		} else {
 			foundContext = context.WithValue(foundContext, index.FoundIndexKey, idx)
 		}
 		wg.Add(1)
-		low.BuildModelAsync(pathNode, &op, &wg, &errors)
+		low.BuildModelAsync(opNode, &op, &wg, &errors)
 
 		opRef := low.NodeReference[*Operation]{
 			Value:     &op,
-			KeyNode:   currentNode,
-			ValueNode: pathNode,
+			KeyNode:   keyNode,
+			ValueNode: opNode,
 			Context:   foundContext,
 		}
 		if opIsRef {
@@ -260,6 +259,71 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 		}
 
 		ops = append(ops, opRef)
+		return opRef, nil
+	}
+
+	for i, pathNode := range root.Content {
+		if strings.HasPrefix(strings.ToLower(pathNode.Value), "x-") {
+			skip = true
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(pathNode.Value), "parameters") {
+			skip = true
+			continue
+		}
+		if skip {
+			skip = false
+			continue
+		}
+		if i%2 == 0 {
+			currentNode = pathNode
+			continue
+		}
+
+		// the only thing we now care about is handling operations, filter out anything that's not a verb.
+		switch currentNode.Value {
+		case GetLabel:
+		case PostLabel:
+		case PutLabel:
+		case PatchLabel:
+		case DeleteLabel:
+		case HeadLabel:
+		case OptionsLabel:
+		case TraceLabel:
+		case QueryLabel, AdditionalOperationsLabel:
+			if !supports32 {
+				continue // not an operation before 3.2.
+			}
+		default:
+			continue // ignore everything else.
+		}
+
+		if currentNode.Value == AdditionalOperationsLabel {
+			if !utils.IsNodeMap(pathNode) {
+				continue
+			}
+			additional := orderedmap.New[low.KeyReference[string], low.ValueReference[*Operation]]()
+			for j := 0; j+1 < len(pathNode.Content); j += 2 {
+				methodNode := pathNode.Content[j]
+				opRef, err := buildOp(methodNode, pathNode.Content[j+1])
+				if err != nil {
+					return err
+				}
+				additional.Set(low.KeyReference[string]{Value: methodNode.Value, KeyNode: methodNode},
+					low.ValueReference[*Operation]{Value: opRef.Value, ValueNode: opRef.ValueNode, Reference: opRef.Reference})
+			}
+			p.AdditionalOperations = low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Operation]]]{
+				Value:     additional,
+				KeyNode:   currentNode,
+				ValueNode: pathNode,
+			}
+			continue
+		}
+
+		opRef, err := buildOp(currentNode, pathNode)
+		if err != nil {
+			return err
+		}
 
 		switch currentNode.Value {
 		case GetLabel:
@@ -278,6 +342,8 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 			p.Options = opRef
 		case TraceLabel:
 			p.Trace = opRef
+		case QueryLabel:
+			p.Query = opRef
 		}
 	}
 
@@ -300,7 +366,7 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 		}
 		return nil, nil
 	}
-	err := datamodel.TranslateSliceParallel[low.NodeReference[*Operation], any](ops, translateFunc, nil)
+	err := datamodel.TranslateSliceParallelWithContext[low.NodeReference[*Operation], any](ctx, ops, translateFunc, nil)
 	if err != nil {
 		return err
 	}
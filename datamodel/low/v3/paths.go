@@ -6,6 +6,7 @@ package v3
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -27,8 +28,19 @@ import (
 type Paths struct {
 	PathItems  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*PathItem]]
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode    *yaml.Node
-	RootNode   *yaml.Node
+
+	// InvalidPaths contains every path key that is malformed: keys that don't start with `/`, that contain a query
+	// string or fragment, or that have unbalanced template braces. Malformed paths are still built into PathItems.
+	// Keys that start with `x-` but hold a path item are also included, they are built as extensions, not paths.
+	//
+	// This property is not a part of the OpenAPI schema, this is custom to libopenapi.
+	InvalidPaths *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+
+	// Diagnostics contains a Diagnostic for every key in InvalidPaths, in document order.
+	Diagnostics []*low.Diagnostic
+
+	KeyNode  *yaml.Node
+	RootNode *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -45,27 +57,36 @@ func (p *Paths) GetKeyNode() *yaml.Node {
 
 // FindPath will attempt to locate a PathItem using the provided path string.
 func (p *Paths) FindPath(path string) (result *low.ValueReference[*PathItem]) {
-	for pair := orderedmap.First(p.PathItems); pair != nil; pair = pair.Next() {
-		if pair.Key().Value == path {
-			result = pair.ValuePtr()
-			break
-		}
+	if pair := p.PathItems.FindKey(path); pair != nil {
+		result = pair.ValuePtr()
 	}
 	return result
 }
 
 // FindPathAndKey attempts to locate a PathItem instance, given a path key.
 func (p *Paths) FindPathAndKey(path string) (key *low.KeyReference[string], value *low.ValueReference[*PathItem]) {
-	for pair := orderedmap.First(p.PathItems); pair != nil; pair = pair.Next() {
-		if pair.Key().Value == path {
-			key = pair.KeyPtr()
-			value = pair.ValuePtr()
-			break
-		}
+	if pair := p.PathItems.FindKey(path); pair != nil {
+		key = pair.KeyPtr()
+		value = pair.ValuePtr()
 	}
 	return key, value
 }
 
+// PathKeys returns every path key held by the Paths object, in the order the paths appear in the source document.
+//
+// PathItems are built concurrently, but results are always collected in document order, so PathKeys() and
+// iterating PathItems via FromOldest() will always agree, regardless of worker scheduling.
+func (p *Paths) PathKeys() []string {
+	if p.PathItems == nil {
+		return nil
+	}
+	keys := make([]string, 0, p.PathItems.Len())
+	for k := range p.PathItems.KeysFromOldest() {
+		keys = append(keys, k.Value)
+	}
+	return keys
+}
+
 // FindExtension will attempt to locate an extension using the specified string.
 func (p *Paths) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
 	return low.FindItemInOrderedMap(ext, p.Extensions)
@@ -76,7 +97,8 @@ func (p *Paths) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.Va
 	return p.Extensions
 }
 
-// Build will extract extensions and all PathItems. This happens asynchronously for speed.
+// Build will extract extensions and all PathItems. This happens asynchronously for speed, however PathItems
+// are always stored in the same order they appear in the document.
 func (p *Paths) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	root = utils.NodeAlias(root)
 	p.KeyNode = keyNode
@@ -84,32 +106,108 @@ func (p *Paths) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.
 	utils.CheckForMergeNodes(root)
 	p.Reference = new(low.Reference)
 	p.Nodes = low.ExtractNodes(ctx, nil) // don't extract anything.
-	p.Extensions = low.ExtractExtensions(root)
+	p.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
 	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
 
+	// when strict or lenient, the paths that built are kept alongside the errors of those that didn't.
 	pathsMap, err := extractPathItemsMap(ctx, root, idx)
-	if err != nil {
+	if pathsMap == nil {
 		return err
 	}
 
 	p.PathItems = pathsMap
+	p.InvalidPaths, p.Diagnostics = checkPathKeys(root)
 
 	for k, v := range pathsMap.FromOldest() {
 		// add path as node to path item, not this path object.
 		v.Value.Nodes.Store(k.KeyNode.Line, k.KeyNode)
 	}
 
-	return nil
+	return err
 }
 
 // Hash will return a consistent SHA256 Hash of the PathItem object
 func (p *Paths) Hash() [32]byte {
+	return p.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Paths object, computed using the requested low.HashVersion.
+func (p *Paths) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, p.hash)
+}
+
+func (p *Paths) hash(version low.HashVersion) [32]byte {
 	var f []string
-	f = low.AppendMapHashes(f, p.PathItems)
+	f = low.AppendMapHashesForVersion(f, p.PathItems, version)
 	f = append(f, low.HashExtensions(p.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
 
+// checkPathKeys finds every malformed path key of the paths map node, and creates a Diagnostic for each one.
+func checkPathKeys(root *yaml.Node) (*orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]], []*low.Diagnostic) {
+	var invalid *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	var diagnostics []*low.Diagnostic
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		k, v := root.Content[i], root.Content[i+1]
+		if k.Tag == "!!merge" {
+			continue
+		}
+		reason := invalidPathReason(k.Value, v)
+		if reason == "" {
+			continue
+		}
+		if invalid == nil {
+			invalid = orderedmap.New[low.KeyReference[string], low.ValueReference[*yaml.Node]]()
+		}
+		invalid.Set(low.KeyReference[string]{Value: k.Value, KeyNode: k},
+			low.ValueReference[*yaml.Node]{Value: v, ValueNode: v})
+		diagnostics = append(diagnostics, &low.Diagnostic{
+			Message:   fmt.Sprintf("path '%s' %s", k.Value, reason),
+			Key:       k.Value,
+			KeyNode:   k,
+			ValueNode: v,
+			Line:      k.Line,
+			Column:    k.Column,
+		})
+	}
+	return invalid, diagnostics
+}
+
+// invalidPathReason returns why a path key is malformed, or an empty string if it's not.
+func invalidPathReason(path string, value *yaml.Node) string {
+	if strings.HasPrefix(strings.ToLower(path), "x-") {
+		if looksLikePathItem(value) {
+			return "starts with 'x-' and has been built as an extension, not a path"
+		}
+		return ""
+	}
+	switch {
+	case !strings.HasPrefix(path, "/"):
+		return "does not start with '/'"
+	case strings.ContainsAny(path, "?#"):
+		return "contains a query string or fragment, query parameters must be defined as parameters"
+	case strings.Count(path, "{") != strings.Count(path, "}"):
+		return "has unbalanced template braces"
+	}
+	return ""
+}
+
+// looksLikePathItem returns true if the node is a map containing an operation or a reference.
+func looksLikePathItem(node *yaml.Node) bool {
+	node = utils.NodeAlias(node)
+	if !utils.IsNodeMap(node) {
+		return false
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		switch strings.ToLower(node.Content[i].Value) {
+		case GetLabel, PutLabel, PostLabel, DeleteLabel, OptionsLabel, HeadLabel, PatchLabel, TraceLabel, QueryLabel,
+			RefLabel:
+			return true
+		}
+	}
+	return false
+}
+
 func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) (*orderedmap.Map[low.KeyReference[string], low.ValueReference[*PathItem]], error) {
 	// Translate YAML nodes to pathsMap using `TranslatePipeline`.
 	type buildResult struct {
@@ -120,13 +218,40 @@ func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIn
 		currentNode *yaml.Node
 		pathNode    *yaml.Node
 	}
-	pathsMap := orderedmap.New[low.KeyReference[string], low.ValueReference[*PathItem]]()
 	in := make(chan buildInput)
 	out := make(chan buildResult)
 	done := make(chan struct{})
 	var wg sync.WaitGroup
 	wg.Add(2) // input and output goroutines.
 
+	// every path item built is reported as progress.
+	var progress *datamodel.ProgressReporter
+	if idx != nil && idx.GetConfig() != nil {
+		progress = idx.GetConfig().Progress
+	}
+	// paths that are filtered out are not built at all.
+	var filter *utils.PathFilter
+	// when strict or lenient, every path item that fails to build is an error, rather than a log entry.
+	var collect bool
+	if idx != nil && idx.GetConfig() != nil {
+		filter = idx.GetConfig().PathFilter
+		collect = idx.GetConfig().Strict || idx.GetConfig().Lenient
+	}
+	var buildErrs []error
+	var buildErrsLock sync.Mutex
+	addBuildErr := func(err error) {
+		buildErrsLock.Lock()
+		buildErrs = append(buildErrs, err)
+		buildErrsLock.Unlock()
+	}
+	total := 0
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if !strings.HasPrefix(strings.ToLower(root.Content[i].Value), "x-") && filter.Keep(root.Content[i].Value) {
+			total++
+		}
+	}
+	pathsMap := orderedmap.NewWithCapacity[low.KeyReference[string], low.ValueReference[*PathItem]](total)
+
 	// TranslatePipeline input.
 	go func() {
 		defer func() {
@@ -148,6 +273,9 @@ func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIn
 				currentNode = pathNode
 				continue
 			}
+			if !filter.Keep(currentNode.Value) {
+				continue
+			}
 
 			select {
 			case in <- buildInput{
@@ -173,37 +301,54 @@ func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIn
 		wg.Done()
 	}()
 
-	err := datamodel.TranslatePipeline[buildInput, buildResult](in, out,
+	err := datamodel.TranslatePipelineWithContext[buildInput, buildResult](ctx, in, out,
 		func(value buildInput) (buildResult, error) {
 			pNode := value.pathNode
 			cNode := value.currentNode
 
 			foundContext := ctx
+			foundIdx := idx
 			if ok, _, _ := utils.IsNodeRefValue(pNode); ok {
-				r, _, err, fCtx := low.LocateRefNodeWithContext(ctx, pNode, idx)
+				r, fIdx, err, fCtx := low.LocateRefNodeWithContext(ctx, pNode, idx)
 				if r != nil {
 					pNode = r
 					foundContext = fCtx
+					if fIdx != nil {
+						foundIdx = fIdx
+					}
 					if err != nil {
 						if !idx.AllowCircularReferenceResolving() {
 							return buildResult{}, fmt.Errorf("path item build failed: %s", err.Error())
 						}
 					}
 				} else {
-					return buildResult{}, fmt.Errorf("path item build failed: cannot find reference: '%s' at line %d, col %d",
+					err = fmt.Errorf("path item build failed: cannot find reference: '%s' at line %d, col %d",
 						pNode.Content[1].Value, pNode.Content[1].Line, pNode.Content[1].Column)
+					if collect {
+						// keep going, so every path that fails is reported.
+						addBuildErr(err)
+						return buildResult{}, datamodel.Continue
+					}
+					return buildResult{}, err
 				}
 			}
 
 			path := new(PathItem)
 			_ = low.BuildModel(pNode, path)
-			err := path.Build(foundContext, cNode, pNode, idx)
+			err := path.Build(foundContext, cNode, pNode, foundIdx)
 			if err != nil {
 				if idx != nil && idx.GetLogger() != nil {
-					idx.GetLogger().Error(fmt.Sprintf("error building path item: %s", err.Error()))
+					args := []any{datamodel.LogKeyPhase, datamodel.PhaseBuild, datamodel.LogKeyPath, cNode.Value}
+					if file := idx.GetSpecAbsolutePath(); file != "" {
+						args = append(args, datamodel.LogKeyFile, file)
+					}
+					idx.GetLogger().Error("error building path item", append(args, "error", err.Error())...)
+				}
+				if collect {
+					addBuildErr(fmt.Errorf("path '%s' build failed: %w", cNode.Value, err))
 				}
-				// return buildResult{}, err
 			}
+			progress.Report(datamodel.ProgressEvent{Phase: datamodel.PhaseBuild, Path: cNode.Value, Total: total})
 
 			return buildResult{
 				key: low.KeyReference[string]{
@@ -221,5 +366,8 @@ func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIn
 	if err != nil {
 		return nil, err
 	}
+	if len(buildErrs) > 0 {
+		return pathsMap, errors.Join(buildErrs...)
+	}
 	return pathsMap, nil
 }
@@ -227,6 +227,38 @@ func TestPaths_Build_BadParams(t *testing.T) {
 	assert.Contains(t, er, "array build failed, input is not an array, line 3, column 5")
 }
 
+func TestPaths_Build_Strict(t *testing.T) {
+	yml := `"/some/path":
+  parameters:
+    this: shouldFail
+"/some/other/path":
+  get:
+    responses: nope
+"/missing":
+  $ref: '#/components/pathItems/Missing'
+"/fine":
+  get:
+    description: fine`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, &index.SpecIndexConfig{Strict: true})
+
+	var n Paths
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.ErrorContains(t, err, "path '/some/path' build failed: array build failed, input is not an array")
+	assert.ErrorContains(t, err, "path '/some/other/path' build failed: responses build failed: vn node is not a map!")
+	assert.ErrorContains(t, err, "path item build failed: cannot find reference: '#/components/pathItems/Missing'")
+	assert.Equal(t, []string{"/some/path", "/some/other/path", "/fine"}, n.PathKeys())
+
+	// not strict, the path item errors are only logged.
+	idx = index.NewSpecIndexWithConfig(&idxNode, &index.SpecIndexConfig{})
+	n = Paths{}
+	err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.ErrorContains(t, err, "path item build failed: cannot find reference")
+	assert.NotContains(t, err.Error(), "/some/path")
+}
+
 func TestPaths_Build_BadRef(t *testing.T) {
 	// this is kinda nuts, it's also not illegal, however the mechanics still need to work.
 	yml := `"/some/path":
@@ -261,7 +293,8 @@ func TestPaths_Build_BadRef(t *testing.T) {
 
 	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
 	assert.Contains(t, buf.String(), "unable to locate reference anywhere in the rolodex\" reference=#/no-where")
-	assert.Contains(t, buf.String(), "error building path item: path item build failed: cannot find reference: #/no-where at line 4, col 10")
+	assert.Contains(t, buf.String(), `msg="error building path item" phase=build path=/some/path `+
+		`error="path item build failed: cannot find reference: #/no-where at line 4, col 10"`)
 }
 
 func TestPathItem_Build_GoodRef(t *testing.T) {
@@ -329,7 +362,8 @@ func TestPathItem_Build_BadRef(t *testing.T) {
 
 	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
 	assert.Contains(t, buf.String(), "unable to locate reference anywhere in the rolodex\" reference=#/~1cakes/NotFound")
-	assert.Contains(t, buf.String(), "error building path item: path item build failed: cannot find reference: #/~1another~1path/get at line 4, col 10")
+	assert.Contains(t, buf.String(), `msg="error building path item" phase=build path=/some/path `+
+		`error="path item build failed: cannot find reference: #/~1another~1path/get at line 4, col 10"`)
 }
 
 func TestPathNoOps(t *testing.T) {
@@ -461,7 +495,8 @@ func TestPath_Build_Using_CircularRefWithOp(t *testing.T) {
 	assert.NoError(t, err)
 
 	_ = n.Build(context.Background(), nil, rootNode.Content[0], idx)
-	assert.Contains(t, buf.String(), "error building path item: build schema failed: circular reference 'post -> post -> post' found during lookup at line 4, column 7, It cannot be resolved")
+	assert.Contains(t, buf.String(), `msg="error building path item" phase=build path=/some/path `+
+		`error="build schema failed: circular reference 'post -> post -> post' found during lookup at line 4, column 7, It cannot be resolved"`)
 }
 
 func TestPaths_Build_BrokenOp(t *testing.T) {
@@ -488,7 +523,8 @@ func TestPaths_Build_BrokenOp(t *testing.T) {
 	assert.NoError(t, err)
 
 	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
-	assert.Contains(t, buf.String(), "error building path item: object extraction failed: reference at line 4, column 7 is empty, it cannot be resolved")
+	assert.Contains(t, buf.String(), `msg="error building path item" phase=build path=/some/path `+
+		`error="object extraction failed: reference at line 4, column 7 is empty, it cannot be resolved"`)
 }
 
 func TestPaths_Hash(t *testing.T) {
@@ -568,3 +604,110 @@ func TestPaths_Build_Fail_Many(t *testing.T) {
 	errors := strings.Split(buf.String(), "\n")
 	assert.Len(t, errors, 1001)
 }
+
+func TestPaths_Build_PreservesDocumentOrder(t *testing.T) {
+	var yml string
+	var expected []string
+	for i := 0; i < 500; i++ {
+		// mix up the lexical order, so sorting can't accidentally produce the right answer.
+		path := fmt.Sprintf("/path/%d", (i*7919)%500)
+		expected = append(expected, path)
+		yml += fmt.Sprintf("%q:\n  get:\n    description: op %d\n", path, i)
+		if i%50 == 0 {
+			yml += fmt.Sprintf("x-ext-%d: ignored\n", i)
+		}
+	}
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	for i := 0; i < 5; i++ {
+		var n Paths
+		err := low.BuildModel(&idxNode, &n)
+		assert.NoError(t, err)
+
+		err = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, n.PathKeys())
+
+		j := 0
+		for k, v := range n.PathItems.FromOldest() {
+			assert.Equal(t, expected[j], k.Value)
+			assert.Equal(t, fmt.Sprintf("op %d", j), v.Value.Get.Value.Description.Value)
+			j++
+		}
+	}
+}
+
+func TestPaths_PathKeys_Empty(t *testing.T) {
+	var n Paths
+	assert.Nil(t, n.PathKeys())
+}
+
+func TestPaths_Build_InvalidPaths(t *testing.T) {
+	yml := `/pets:
+  get:
+    description: fine
+pets:
+  get:
+    description: no slash
+/pets?limit=10:
+  get:
+    description: query string
+/pets/{petId:
+  get:
+    description: unbalanced
+x-pets:
+  get:
+    description: extension
+x-cake: yummy`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Paths
+	_ = low.BuildModel(&idxNode, &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"/pets", "pets", "/pets?limit=10", "/pets/{petId"}, n.PathKeys())
+	assert.Equal(t, 2, n.Extensions.Len())
+
+	var invalid []string
+	for k := range n.InvalidPaths.KeysFromOldest() {
+		invalid = append(invalid, k.Value)
+	}
+	assert.Equal(t, []string{"pets", "/pets?limit=10", "/pets/{petId", "x-pets"}, invalid)
+
+	assert.Len(t, n.Diagnostics, 4)
+	assert.Equal(t, "path 'pets' does not start with '/' [4:1]", n.Diagnostics[0].Error())
+	assert.Contains(t, n.Diagnostics[1].Message, "contains a query string")
+	assert.Equal(t, 7, n.Diagnostics[1].Line)
+	assert.Contains(t, n.Diagnostics[2].Message, "has unbalanced template braces")
+	assert.Equal(t, "path 'x-pets' starts with 'x-' and has been built as an extension, not a path",
+		n.Diagnostics[3].Message)
+	assert.Equal(t, "x-pets", n.Diagnostics[3].Key)
+	assert.Equal(t, 13, n.Diagnostics[3].Line)
+	assert.Equal(t, 1, n.Diagnostics[3].Column)
+}
+
+func TestPaths_Build_NoInvalidPaths(t *testing.T) {
+	yml := `/pets/{petId}:
+  get:
+    description: fine
+x-cake:
+  description: not a path item`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Paths
+	_ = low.BuildModel(&idxNode, &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+
+	assert.Nil(t, n.InvalidPaths)
+	assert.Empty(t, n.Diagnostics)
+}
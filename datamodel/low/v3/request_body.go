@@ -19,12 +19,13 @@ import (
 // RequestBody represents a low-level OpenAPI 3+ RequestBody object.
 //   - https://spec.openapis.org/oas/v3.1.0#request-body-object
 type RequestBody struct {
-	Description low.NodeReference[string]
-	Content     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*MediaType]]]
-	Required    low.NodeReference[bool]
-	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode     *yaml.Node
-	RootNode    *yaml.Node
+	Description   low.NodeReference[string]
+	Content       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*MediaType]]]
+	Required      low.NodeReference[bool]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -49,6 +50,12 @@ func (rb *RequestBody) GetExtensions() *orderedmap.Map[low.KeyReference[string],
 	return rb.Extensions
 }
 
+// GetUnknownFields returns the keys of the RequestBody that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (rb *RequestBody) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return rb.UnknownFields
+}
+
 // FindContent attempts to find content/MediaType defined using a specified name.
 func (rb *RequestBody) FindContent(cType string) *low.ValueReference[*MediaType] {
 	return low.FindItemInOrderedMap[*MediaType](cType, rb.Content.Value)
@@ -62,7 +69,8 @@ func (rb *RequestBody) Build(ctx context.Context, keyNode, root *yaml.Node, idx
 	utils.CheckForMergeNodes(root)
 	rb.Reference = new(low.Reference)
 	rb.Nodes = low.ExtractNodes(ctx, root)
-	rb.Extensions = low.ExtractExtensions(root)
+	rb.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	rb.UnknownFields = low.ExtractUnknownFields(root, rb)
 	low.ExtractExtensionNodes(ctx, rb.Extensions, rb.Nodes)
 
 	// handle content, if set.
@@ -86,6 +94,15 @@ func (rb *RequestBody) Build(ctx context.Context, keyNode, root *yaml.Node, idx
 
 // Hash will return a consistent SHA256 Hash of the RequestBody object
 func (rb *RequestBody) Hash() [32]byte {
+	return rb.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the RequestBody object, computed using the requested low.HashVersion.
+func (rb *RequestBody) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, rb.hash)
+}
+
+func (rb *RequestBody) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if rb.Description.Value != "" {
 		f = append(f, rb.Description.Value)
@@ -94,7 +111,7 @@ func (rb *RequestBody) Hash() [32]byte {
 		f = append(f, fmt.Sprint(rb.Required.Value))
 	}
 	for v := range orderedmap.SortAlpha(rb.Content.Value).ValuesFromOldest() {
-		f = append(f, low.GenerateHashString(v.Value))
+		f = append(f, low.GenerateHashStringForVersion(v.Value, version))
 	}
 	f = append(f, low.HashExtensions(rb.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
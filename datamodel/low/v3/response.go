@@ -21,13 +21,14 @@ import (
 // operations based on the response.
 //   - https://spec.openapis.org/oas/v3.1.0#response-object
 type Response struct {
-	Description low.NodeReference[string]
-	Headers     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Header]]]
-	Content     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*MediaType]]]
-	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	Links       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Link]]]
-	KeyNode     *yaml.Node
-	RootNode    *yaml.Node
+	Description   low.NodeReference[string]
+	Headers       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Header]]]
+	Content       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*MediaType]]]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	Links         low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Link]]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -52,12 +53,18 @@ func (r *Response) GetExtensions() *orderedmap.Map[low.KeyReference[string], low
 	return r.Extensions
 }
 
+// GetUnknownFields returns the keys of the Response that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (r *Response) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return r.UnknownFields
+}
+
 // FindContent will attempt to locate a MediaType instance using the supplied key.
 func (r *Response) FindContent(cType string) *low.ValueReference[*MediaType] {
 	return low.FindItemInOrderedMap[*MediaType](cType, r.Content.Value)
 }
 
-// FindHeader will attempt to locate a Header instance using the supplied key.
+// FindHeader will attempt to locate a Header instance using the supplied key, header names are case-insensitive.
 func (r *Response) FindHeader(hType string) *low.ValueReference[*Header] {
 	return low.FindItemInOrderedMap[*Header](hType, r.Headers.Value)
 }
@@ -75,7 +82,8 @@ func (r *Response) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 	utils.CheckForMergeNodes(root)
 	r.Reference = new(low.Reference)
 	r.Nodes = low.ExtractNodes(ctx, root)
-	r.Extensions = low.ExtractExtensions(root)
+	r.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	r.UnknownFields = low.ExtractUnknownFields(root, r)
 	low.ExtractExtensionNodes(ctx, r.Extensions, r.Nodes)
 
 	// extract headers
@@ -132,13 +140,22 @@ func (r *Response) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 
 // Hash will return a consistent SHA256 Hash of the Response object
 func (r *Response) Hash() [32]byte {
+	return r.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Response object, computed using the requested low.HashVersion.
+func (r *Response) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, r.hash)
+}
+
+func (r *Response) hash(version low.HashVersion) [32]byte {
 	var f []string
 	if r.Description.Value != "" {
 		f = append(f, r.Description.Value)
 	}
-	f = low.AppendMapHashes(f, r.Headers.Value)
-	f = low.AppendMapHashes(f, r.Content.Value)
-	f = low.AppendMapHashes(f, r.Links.Value)
+	f = low.AppendMapHashesForVersion(f, r.Headers.Value, version)
+	f = low.AppendMapHashesForVersion(f, r.Content.Value, version)
+	f = low.AppendMapHashesForVersion(f, r.Links.Value, version)
 	f = append(f, low.HashExtensions(r.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
 }
@@ -66,7 +66,7 @@ func (r *Responses) Build(ctx context.Context, keyNode, root *yaml.Node, idx *in
 	r.RootNode = root
 	r.Reference = new(low.Reference)
 	r.Nodes = low.ExtractNodes(ctx, root)
-	r.Extensions = low.ExtractExtensions(root)
+	r.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
 	low.ExtractExtensionNodes(ctx, r.Extensions, r.Nodes)
 	utils.CheckForMergeNodes(root)
 	if utils.IsNodeMap(root) {
@@ -129,12 +129,30 @@ func (r *Responses) FindResponseByCode(code string) *low.ValueReference[*Respons
 	return low.FindItemInOrderedMap[*Response](code, r.Codes)
 }
 
+// FindHeader will attempt to locate a Header (case-insensitively) in the Response for an HTTP response code.
+func (r *Responses) FindHeader(code, name string) *low.ValueReference[*Header] {
+	resp := r.FindResponseByCode(code)
+	if resp == nil || resp.Value == nil {
+		return nil
+	}
+	return resp.Value.FindHeader(name)
+}
+
 // Hash will return a consistent SHA256 Hash of the Examples object
 func (r *Responses) Hash() [32]byte {
+	return r.hash(low.CurrentHashVersion)
+}
+
+// HashForVersion will return a hash of the Responses object, computed using the requested low.HashVersion.
+func (r *Responses) HashForVersion(version low.HashVersion) ([32]byte, bool) {
+	return low.HashForSupportedVersion(version, r.hash)
+}
+
+func (r *Responses) hash(version low.HashVersion) [32]byte {
 	var f []string
-	f = low.AppendMapHashes(f, r.Codes)
+	f = low.AppendMapHashesForVersion(f, r.Codes, version)
 	if !r.Default.IsEmpty() {
-		f = append(f, low.GenerateHashString(r.Default.Value))
+		f = append(f, low.GenerateHashStringForVersion(r.Default.Value, version))
 	}
 	f = append(f, low.HashExtensions(r.Extensions)...)
 	return sha256.Sum256([]byte(strings.Join(f, "|")))
@@ -35,6 +35,7 @@ type SecurityScheme struct {
 	Flows            low.NodeReference[*OAuthFlows]
 	OpenIdConnectUrl low.NodeReference[string]
 	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	KeyNode          *yaml.Node
 	RootNode         *yaml.Node
 	*low.Reference
@@ -61,6 +62,12 @@ func (ss *SecurityScheme) GetExtensions() *orderedmap.Map[low.KeyReference[strin
 	return ss.Extensions
 }
 
+// GetUnknownFields returns the keys of the SecurityScheme that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (ss *SecurityScheme) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return ss.UnknownFields
+}
+
 // Build will extract OAuthFlows and extensions from the node.
 func (ss *SecurityScheme) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	ss.KeyNode = keyNode
@@ -69,7 +76,8 @@ func (ss *SecurityScheme) Build(ctx context.Context, keyNode, root *yaml.Node, i
 	utils.CheckForMergeNodes(root)
 	ss.Reference = new(low.Reference)
 	ss.Nodes = low.ExtractNodes(ctx, root)
-	ss.Extensions = low.ExtractExtensions(root)
+	ss.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	ss.UnknownFields = low.ExtractUnknownFields(root, ss)
 	low.ExtractExtensionNodes(ctx, ss.Extensions, ss.Nodes)
 
 	oa, oaErr := low.ExtractObject[*OAuthFlows](ctx, OAuthFlowsLabel, root, idx)
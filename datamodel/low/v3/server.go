@@ -18,12 +18,13 @@ import (
 // Server represents a low-level OpenAPI 3+ Server object.
 //   - https://spec.openapis.org/oas/v3.1.0#server-object
 type Server struct {
-	URL         low.NodeReference[string]
-	Description low.NodeReference[string]
-	Variables   low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*ServerVariable]]]
-	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode     *yaml.Node
-	RootNode    *yaml.Node
+	URL           low.NodeReference[string]
+	Description   low.NodeReference[string]
+	Variables     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*ServerVariable]]]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -38,20 +39,27 @@ func (s *Server) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.V
 	return s.Extensions
 }
 
+// GetUnknownFields returns the keys of the Server that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (s *Server) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.UnknownFields
+}
+
 // FindVariable attempts to locate a ServerVariable instance using the supplied key.
 func (s *Server) FindVariable(serverVar string) *low.ValueReference[*ServerVariable] {
 	return low.FindItemInOrderedMap[*ServerVariable](serverVar, s.Variables.Value)
 }
 
 // Build will extract server variables from the supplied node.
-func (s *Server) Build(ctx context.Context, keyNode, root *yaml.Node, _ *index.SpecIndex) error {
+func (s *Server) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	s.KeyNode = keyNode
 	root = utils.NodeAlias(root)
 	s.RootNode = root
 	utils.CheckForMergeNodes(root)
 	s.Reference = new(low.Reference)
 	s.Nodes = low.ExtractNodes(ctx, root)
-	s.Extensions = low.ExtractExtensions(root)
+	s.Extensions = low.ExtractExtensionsWithContext(ctx, root, idx)
+	s.UnknownFields = low.ExtractUnknownFields(root, s)
 	low.ExtractExtensionNodes(ctx, s.Extensions, s.Nodes)
 
 	kn, vars := utils.FindKeyNode(VariablesLabel, root.Content)
@@ -72,7 +80,8 @@ func (s *Server) Build(ctx context.Context, keyNode, root *yaml.Node, _ *index.S
 			variable.Reference = new(low.Reference)
 			_ = low.BuildModel(varNode, &variable)
 			variable.Nodes = low.ExtractNodesRecursive(ctx, varNode)
-			variable.Extensions = low.ExtractExtensions(varNode)
+			variable.Extensions = low.ExtractExtensionsWithContext(ctx, varNode, idx)
+			variable.UnknownFields = low.ExtractUnknownFields(varNode, &variable)
 			if localKeyNode != nil {
 				variable.Nodes.Store(localKeyNode.Line, localKeyNode)
 			}
@@ -19,12 +19,13 @@ import (
 // This is the only struct that is not Buildable, it's not used by anything other than a Server instance,
 // and it has nothing to build that requires it to be buildable.
 type ServerVariable struct {
-	Enum        []low.NodeReference[string]
-	Default     low.NodeReference[string]
-	Description low.NodeReference[string]
-	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode     *yaml.Node
-	RootNode    *yaml.Node
+	Enum          []low.NodeReference[string]
+	Default       low.NodeReference[string]
+	Description   low.NodeReference[string]
+	Extensions    *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	UnknownFields *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode       *yaml.Node
+	RootNode      *yaml.Node
 	*low.Reference
 	low.NodeMap
 }
@@ -44,6 +45,12 @@ func (s *ServerVariable) GetExtensions() *orderedmap.Map[low.KeyReference[string
 	return s.Extensions
 }
 
+// GetUnknownFields returns the keys of the ServerVariable that are not part of the specification, and satisfies the
+// low.HasUnknownFields interface.
+func (s *ServerVariable) GetUnknownFields() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.UnknownFields
+}
+
 // Hash will return a consistent SHA256 Hash of the ServerVariable object
 func (s *ServerVariable) Hash() [32]byte {
 	var f []string
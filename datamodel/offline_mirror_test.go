@@ -0,0 +1,63 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentConfiguration_OfflinePath(t *testing.T) {
+	c := &DocumentConfiguration{
+		OfflineBaseDir: "vendor",
+		OfflineMappings: map[string]string{
+			"https://pb33f.io/":       "pb33f",
+			"https://pb33f.io/specs/": "specs",
+		},
+	}
+	for remoteURL, expected := range map[string]string{
+		"https://pb33f.io/specs/pets.yaml?v=2#/Pet": "vendor/specs/pets.yaml",
+		"https://pb33f.io/other/owners.yaml":        "vendor/pb33f/other/owners.yaml",
+		"https://pb33f.io:8443/specs/pets.yaml":     "vendor/pb33f.io_8443/specs/pets.yaml",
+		"http://quobix.com/a/../../../../etc/pets":  "vendor/quobix.com/etc/pets",
+		"https://pb33f.io/specs/../../../../passwd": "vendor/specs/passwd",
+	} {
+		p, err := c.OfflinePath(remoteURL)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.FromSlash(expected), p, remoteURL)
+	}
+
+	_, err := c.OfflinePath("https://pb33f.io/%zz")
+	assert.Error(t, err)
+	_, err = NewDocumentConfiguration().OfflinePath("https://pb33f.io/specs/pets.yaml")
+	assert.Error(t, err)
+}
+
+func TestDocumentConfiguration_OfflineURLHandler(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "pb33f.io", "specs"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pb33f.io", "specs", "pets.yaml"), []byte("pets: true"), 0o600))
+
+	handler := (&DocumentConfiguration{OfflineBaseDir: dir}).OfflineURLHandler()
+	response, err := handler("https://pb33f.io/specs/pets.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.NotEmpty(t, response.Header.Get("Last-Modified"))
+	body, _ := io.ReadAll(response.Body)
+	assert.Equal(t, "pets: true", string(body))
+
+	_, err = handler("https://pb33f.io/specs/owners.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.ErrorContains(t, err, "has not been vendored")
+
+	_, err = handler("https://pb33f.io/specs")
+	assert.Error(t, err)
+}
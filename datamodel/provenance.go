@@ -0,0 +1,141 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProvenanceExtension is the extension key provenance is rendered under, when
+// DocumentConfiguration.EmbedProvenance is set.
+const ProvenanceExtension = "x-libopenapi-provenance"
+
+const libopenapiModule = "github.com/pb33f/libopenapi"
+
+// Provenance describes where a document came from and how it was built, so downstream systems can trace a model
+// back to the exact bytes and settings that produced it.
+type Provenance struct {
+	// Source is the URL or path of the specification, worked out from the BaseURL, BasePath and SpecFilePath of
+	// the configuration. It's empty if the configuration has none of them.
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+
+	// SHA256 is the hex encoded SHA-256 hash of the specification bytes.
+	SHA256 string `json:"sha256" yaml:"sha256"`
+
+	// FetchedAt is when the specification bytes were handed to libopenapi.
+	FetchedAt time.Time `json:"fetchedAt" yaml:"fetchedAt"`
+
+	// LibraryVersion is the version of libopenapi that built the document, taken from the build information of
+	// the running binary. It's "(devel)" when the version is not known.
+	LibraryVersion string `json:"libopenapiVersion" yaml:"libopenapiVersion"`
+
+	// Configuration is a snapshot of the settings the document was built with.
+	Configuration *ProvenanceConfiguration `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+}
+
+// ProvenanceConfiguration is a snapshot of the DocumentConfiguration settings that change how a document is built.
+// Settings that can't be serialized (such as file systems, handlers and loggers) are left out.
+type ProvenanceConfiguration struct {
+	BaseURL                             string   `json:"baseURL,omitempty" yaml:"baseURL,omitempty"`
+	BasePath                            string   `json:"basePath,omitempty" yaml:"basePath,omitempty"`
+	SpecFilePath                        string   `json:"specFilePath,omitempty" yaml:"specFilePath,omitempty"`
+	AllowFileReferences                 bool     `json:"allowFileReferences,omitempty" yaml:"allowFileReferences,omitempty"`
+	AllowRemoteReferences               bool     `json:"allowRemoteReferences,omitempty" yaml:"allowRemoteReferences,omitempty"`
+	AvoidIndexBuild                     bool     `json:"avoidIndexBuild,omitempty" yaml:"avoidIndexBuild,omitempty"`
+	BypassDocumentCheck                 bool     `json:"bypassDocumentCheck,omitempty" yaml:"bypassDocumentCheck,omitempty"`
+	IgnorePolymorphicCircularReferences bool     `json:"ignorePolymorphicCircularReferences,omitempty" yaml:"ignorePolymorphicCircularReferences,omitempty"`
+	IgnoreArrayCircularReferences       bool     `json:"ignoreArrayCircularReferences,omitempty" yaml:"ignoreArrayCircularReferences,omitempty"`
+	SkipCircularReferenceCheck          bool     `json:"skipCircularReferenceCheck,omitempty" yaml:"skipCircularReferenceCheck,omitempty"`
+	ExtractRefsSequentially             bool     `json:"extractRefsSequentially,omitempty" yaml:"extractRefsSequentially,omitempty"`
+	BundleInlineRefs                    bool     `json:"bundleInlineRefs,omitempty" yaml:"bundleInlineRefs,omitempty"`
+	EmbedProvenance                     bool     `json:"embedProvenance,omitempty" yaml:"embedProvenance,omitempty"`
+	IncludePaths                        []string `json:"includePaths,omitempty" yaml:"includePaths,omitempty"`
+	ExcludePaths                        []string `json:"excludePaths,omitempty" yaml:"excludePaths,omitempty"`
+}
+
+// NewProvenance creates the provenance for a specification that has been read into a byte array, built with
+// the supplied configuration (which can be nil).
+func NewProvenance(spec []byte, config *DocumentConfiguration) *Provenance {
+	sum := sha256.Sum256(spec)
+	p := &Provenance{
+		SHA256:         hex.EncodeToString(sum[:]),
+		FetchedAt:      time.Now().UTC(),
+		LibraryVersion: LibraryVersion(),
+	}
+	p.SetConfiguration(config)
+	return p
+}
+
+// SetConfiguration replaces the source and the configuration snapshot with ones taken from config, which can be nil.
+func (p *Provenance) SetConfiguration(config *DocumentConfiguration) {
+	if config == nil {
+		p.Source = ""
+		p.Configuration = nil
+		return
+	}
+	c := &ProvenanceConfiguration{
+		BasePath:                            config.BasePath,
+		SpecFilePath:                        config.SpecFilePath,
+		AllowFileReferences:                 config.AllowFileReferences,
+		AllowRemoteReferences:               config.AllowRemoteReferences,
+		AvoidIndexBuild:                     config.AvoidIndexBuild,
+		BypassDocumentCheck:                 config.BypassDocumentCheck,
+		IgnorePolymorphicCircularReferences: config.IgnorePolymorphicCircularReferences,
+		IgnoreArrayCircularReferences:       config.IgnoreArrayCircularReferences,
+		SkipCircularReferenceCheck:          config.SkipCircularReferenceCheck,
+		ExtractRefsSequentially:             config.ExtractRefsSequentially,
+		BundleInlineRefs:                    config.BundleInlineRefs,
+		EmbedProvenance:                     config.EmbedProvenance,
+		IncludePaths:                        config.IncludePaths,
+		ExcludePaths:                        config.ExcludePaths,
+	}
+	switch {
+	case config.BaseURL != nil:
+		c.BaseURL = config.BaseURL.String()
+		if config.SpecFilePath != "" {
+			p.Source = config.BaseURL.JoinPath(config.SpecFilePath).String()
+		} else {
+			p.Source = c.BaseURL
+		}
+	case config.SpecFilePath != "":
+		p.Source = filepath.Join(config.BasePath, config.SpecFilePath)
+	default:
+		p.Source = config.BasePath
+	}
+	p.Configuration = c
+}
+
+// Node returns the provenance as a YAML node, ready to be rendered as an extension.
+func (p *Provenance) Node() *yaml.Node {
+	n := new(yaml.Node)
+	_ = n.Encode(p)
+	return n
+}
+
+// LibraryVersion returns the version of libopenapi compiled into the running binary, or "(devel)" if it's not known
+// (for example when running the tests of libopenapi itself).
+func LibraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "(devel)"
+	}
+	if info.Main.Path == libopenapiModule && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == libopenapiModule {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "(devel)"
+}
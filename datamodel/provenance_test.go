@@ -0,0 +1,63 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProvenance(t *testing.T) {
+	p := NewProvenance([]byte("openapi: 3.1.0"), nil)
+	assert.Equal(t, "2fb0d1c2b023895b7bf1b743fa8554f9572cfd63667a21703a7ea57bc0fdd4f5", p.SHA256)
+	assert.WithinDuration(t, time.Now(), p.FetchedAt, time.Minute)
+	assert.Equal(t, "(devel)", p.LibraryVersion)
+	assert.Empty(t, p.Source)
+	assert.Nil(t, p.Configuration)
+}
+
+func TestProvenance_SetConfiguration(t *testing.T) {
+	p := NewProvenance([]byte("openapi: 3.1.0"), &DocumentConfiguration{
+		BasePath:            "specs",
+		SpecFilePath:        "pets.yaml",
+		AllowFileReferences: true,
+	})
+	assert.Equal(t, filepath.Join("specs", "pets.yaml"), p.Source)
+	require.NotNil(t, p.Configuration)
+	assert.True(t, p.Configuration.AllowFileReferences)
+	assert.Equal(t, "specs", p.Configuration.BasePath)
+
+	u, _ := url.Parse("https://example.com/specs")
+	p.SetConfiguration(&DocumentConfiguration{BaseURL: u, SpecFilePath: "pets.yaml"})
+	assert.Equal(t, "https://example.com/specs/pets.yaml", p.Source)
+	assert.Equal(t, "https://example.com/specs", p.Configuration.BaseURL)
+	assert.False(t, p.Configuration.AllowFileReferences)
+
+	p.SetConfiguration(&DocumentConfiguration{BaseURL: u})
+	assert.Equal(t, "https://example.com/specs", p.Source)
+
+	p.SetConfiguration(&DocumentConfiguration{BasePath: "specs"})
+	assert.Equal(t, "specs", p.Source)
+
+	p.SetConfiguration(nil)
+	assert.Empty(t, p.Source)
+	assert.Nil(t, p.Configuration)
+}
+
+func TestProvenance_Node(t *testing.T) {
+	p := NewProvenance([]byte("openapi: 3.1.0"), &DocumentConfiguration{SpecFilePath: "pets.yaml"})
+	n := p.Node()
+	require.Len(t, n.Content, 10)
+	assert.Equal(t, "source", n.Content[0].Value)
+	assert.Equal(t, "pets.yaml", n.Content[1].Value)
+	assert.Equal(t, "sha256", n.Content[2].Value)
+	assert.Equal(t, "fetchedAt", n.Content[4].Value)
+	assert.Equal(t, "libopenapiVersion", n.Content[6].Value)
+	assert.Equal(t, "configuration", n.Content[8].Value)
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRemoteFetchTimeout is wrapped by every error returned when RemoteFetchConfig.Timeout has passed. Use
+// errors.Is(err, datamodel.ErrRemoteFetchTimeout) to check for it.
+var ErrRemoteFetchTimeout = errors.New("remote fetch timeout exceeded")
+
+// ErrPinMismatch is wrapped by every error returned when a remote file no longer matches the checksum it's pinned
+// to in RemoteFetchConfig.Pins. Use errors.Is(err, datamodel.ErrPinMismatch) to check for it.
+var ErrPinMismatch = errors.New("remote file does not match its pinned checksum")
+
+const (
+	// DefaultRetryBackoff is the wait before the first retry, when RemoteFetchConfig.RetryBackoff is not set.
+	DefaultRetryBackoff = 250 * time.Millisecond
+
+	// DefaultMaxRetryBackoff is the longest wait between retries, when RemoteFetchConfig.MaxRetryBackoff is not set.
+	DefaultMaxRetryBackoff = 10 * time.Second
+)
+
+// RemoteFetchConfig controls how the rolodex fetches remote files. By default, every remote reference found is
+// fetched as soon as it is found, with no limit on how many are fetched at the same time, and a failed fetch is
+// not retried. Large multi-file specifications can stampede the hosts they are served from, set limits here to
+// control that.
+//
+// A zero value for any setting means no limit is applied (or no retries are made).
+type RemoteFetchConfig struct {
+	// MaxParallel is the maximum number of remote files fetched at the same time, across all hosts.
+	MaxParallel int
+
+	// MaxPerHost is the maximum number of remote files fetched at the same time from a single host.
+	MaxPerHost int
+
+	// Retries is the number of times a failed fetch is retried. A fetch fails when the request returns an error,
+	// or the response status is 429 (too many requests) or 5xx. Client errors (4xx) are not retried.
+	Retries int
+
+	// RetryBackoff is the wait before the first retry, it doubles with every retry after that, up to
+	// MaxRetryBackoff. Defaults to DefaultRetryBackoff. A Retry-After header (in seconds) is honored, up to
+	// MaxRetryBackoff.
+	RetryBackoff time.Duration
+
+	// MaxRetryBackoff is the longest wait between retries. Defaults to DefaultMaxRetryBackoff.
+	MaxRetryBackoff time.Duration
+
+	// Timeout is the overall time allowed for loading every remote file, starting when the first file is fetched.
+	// Once it has passed, fetches that are waiting or in flight are abandoned and no new fetches are started.
+	Timeout time.Duration
+
+	// Pins are the hex encoded SHA256 checksums remote files must match, keyed by URL, as exported by
+	// index.Rolodex.ExportPins (think of them as a lockfile). A remote file that no longer matches its pin fails to
+	// load, so a build never silently picks up a change made upstream. Files that are not pinned are not checked.
+	Pins map[string]string
+}
+
+// Backoff returns how long to wait before retry number attempt (starting at 1). If the response that failed has a
+// Retry-After header in seconds, that is used instead. Waits are never longer than MaxRetryBackoff.
+func (c RemoteFetchConfig) Backoff(attempt int, response *http.Response) time.Duration {
+	maxBackoff := c.MaxRetryBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxRetryBackoff
+	}
+	if response != nil {
+		if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxBackoff)
+		}
+	}
+	backoff := c.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// CheckPin returns an error wrapping ErrPinMismatch if data, fetched from location, does not match the checksum
+// location is pinned to. Returns nil if it matches, or location is not pinned.
+func (c RemoteFetchConfig) CheckPin(location string, data []byte) error {
+	pin, ok := c.Pins[location]
+	if !ok {
+		return nil
+	}
+	sum := sha256.Sum256(data)
+	if checksum := hex.EncodeToString(sum[:]); !strings.EqualFold(checksum, pin) {
+		return fmt.Errorf("%w: '%s' is pinned to '%s', but its checksum is '%s'", ErrPinMismatch, location, pin,
+			checksum)
+	}
+	return nil
+}
+
+// ShouldRetry returns true if a fetch that returned the response and error should be retried.
+func (c RemoteFetchConfig) ShouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return response != nil && (response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteFetchConfig_Backoff(t *testing.T) {
+	var c RemoteFetchConfig
+	assert.Equal(t, DefaultRetryBackoff, c.Backoff(1, nil))
+	assert.Equal(t, 2*DefaultRetryBackoff, c.Backoff(2, nil))
+	assert.Equal(t, DefaultMaxRetryBackoff, c.Backoff(100, nil))
+
+	c = RemoteFetchConfig{RetryBackoff: time.Second, MaxRetryBackoff: 3 * time.Second}
+	assert.Equal(t, time.Second, c.Backoff(1, nil))
+	assert.Equal(t, 2*time.Second, c.Backoff(2, nil))
+	assert.Equal(t, 3*time.Second, c.Backoff(3, nil))
+}
+
+func TestRemoteFetchConfig_Backoff_RetryAfter(t *testing.T) {
+	c := RemoteFetchConfig{RetryBackoff: time.Second, MaxRetryBackoff: 5 * time.Second}
+	response := &http.Response{Header: http.Header{}}
+	response.Header.Set("Retry-After", "2")
+	assert.Equal(t, 2*time.Second, c.Backoff(1, response))
+
+	response.Header.Set("Retry-After", "60")
+	assert.Equal(t, 5*time.Second, c.Backoff(1, response))
+
+	response.Header.Set("Retry-After", "Wed, 21 Oct 2015 07:28:00 GMT")
+	assert.Equal(t, time.Second, c.Backoff(1, response))
+}
+
+func TestRemoteFetchConfig_ShouldRetry(t *testing.T) {
+	var c RemoteFetchConfig
+	assert.True(t, c.ShouldRetry(nil, errors.New("connection refused")))
+	assert.True(t, c.ShouldRetry(&http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+	assert.True(t, c.ShouldRetry(&http.Response{StatusCode: http.StatusBadGateway}, nil))
+	assert.False(t, c.ShouldRetry(&http.Response{StatusCode: http.StatusNotFound}, nil))
+	assert.False(t, c.ShouldRetry(&http.Response{StatusCode: http.StatusOK}, nil))
+	assert.False(t, c.ShouldRetry(nil, nil))
+}
+
+func TestRemoteFetchConfig_CheckPin(t *testing.T) {
+	// sha256 of "pb33f".
+	c := RemoteFetchConfig{Pins: map[string]string{
+		"https://pb33f.io/spec.yaml": "16B40B7E5AA30B65217922DE57BF3EB71DB8638C91C1716352FA5732083B0D3D",
+	}}
+	assert.NoError(t, c.CheckPin("https://pb33f.io/spec.yaml", []byte("pb33f")))
+	assert.NoError(t, c.CheckPin("https://pb33f.io/other.yaml", []byte("anything")))
+
+	err := c.CheckPin("https://pb33f.io/spec.yaml", []byte("changed"))
+	assert.True(t, errors.Is(err, ErrPinMismatch))
+	assert.ErrorContains(t, err, "https://pb33f.io/spec.yaml")
+}
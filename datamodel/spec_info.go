@@ -35,10 +35,18 @@ type SpecInfo struct {
 	APISchema           string                  `json:"-"`     // API Schema for supplied spec type (2 or 3)
 	Generated           time.Time               `json:"-"`
 	OriginalIndentation int                     `json:"-"` // the original whitespace
+	CheckedLimits       DocumentLimits          `json:"-"` // the limits checked as the spec was parsed
 }
 
+// ExtractSpecInfoWithConfig is the same as ExtractSpecInfoWithDocumentCheck, except the document check is bypassed
+// if the configuration says so, and the size and the nodes of the spec are checked against the configured
+// DocumentLimits as it's parsed (the size before anything is parsed, the nodes before aliases are expanded).
 func ExtractSpecInfoWithConfig(spec []byte, config *DocumentConfiguration) (*SpecInfo, error) {
-	return ExtractSpecInfoWithDocumentCheck(spec, config.BypassDocumentCheck)
+	location := config.SpecFilePath
+	if location == "" {
+		location = "root"
+	}
+	return ExtractSpecInfoWithLimits(spec, config.BypassDocumentCheck, location, config.Limits)
 }
 
 // ExtractSpecInfoWithDocumentCheckSync accepts an OpenAPI/Swagger specification that has been read into a byte array
@@ -56,8 +64,19 @@ func ExtractSpecInfoWithDocumentCheckSync(spec []byte, bypass bool) (*SpecInfo,
 // and will return a SpecInfo pointer, which contains details on the version and an un-marshaled
 // ensures the document is an OpenAPI document.
 func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, error) {
+	return ExtractSpecInfoWithLimits(spec, bypass, "", DocumentLimits{})
+}
+
+// ExtractSpecInfoWithLimits is the same as ExtractSpecInfoWithDocumentCheck, except the spec is checked against
+// limits as it's parsed. The size is checked before anything is parsed, and the nodes are counted as soon as the
+// tree is parsed, before anything expands its aliases. Errors are reported against location.
+func ExtractSpecInfoWithLimits(spec []byte, bypass bool, location string, limits DocumentLimits) (*SpecInfo, error) {
 	var parsedSpec yaml.Node
 
+	if err := limits.CheckFileSize(location, int64(len(spec))); err != nil {
+		return nil, err
+	}
+
 	specInfo := &SpecInfo{}
 
 	// set original bytes
@@ -81,12 +100,17 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse specification: %s", err.Error())
 	}
+	if err = limits.CheckNodes(location, &parsedSpec); err != nil {
+		return nil, err
+	}
 
 	specInfo.RootNode = &parsedSpec
+	specInfo.CheckedLimits = limits
 
-	_, openAPI3 := utils.FindKeyNode(utils.OpenApi3, parsedSpec.Content)
-	_, openAPI2 := utils.FindKeyNode(utils.OpenApi2, parsedSpec.Content)
-	_, asyncAPI := utils.FindKeyNode(utils.AsyncApi, parsedSpec.Content)
+	openAPI3 := findRootKey(utils.OpenApi3, &parsedSpec)
+	openAPI2 := findRootKey(utils.OpenApi2, &parsedSpec)
+	asyncAPI := findRootKey(utils.AsyncApi, &parsedSpec)
+	arazzo := findRootKey(utils.Arazzo, &parsedSpec)
 
 	parseJSON := func(bytes []byte, spec *SpecInfo, parsedNode *yaml.Node) {
 		var jsonSpec map[string]interface{}
@@ -119,6 +143,11 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 				specInfo.VersionNumeric = 3.1
 				specInfo.APISchema = OpenAPI31SchemaData
 				specInfo.SpecFormat = OAS31
+			case "3.2.0", "3.2":
+				// there is no published 3.2 schema yet, 3.2 is a superset of 3.1.
+				specInfo.VersionNumeric = 3.2
+				specInfo.APISchema = OpenAPI31SchemaData
+				specInfo.SpecFormat = OAS32
 			default:
 				specInfo.VersionNumeric = 3.0
 				specInfo.APISchema = OpenAPI3SchemaData
@@ -175,6 +204,27 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 			}
 		}
 
+		if arazzo != nil {
+			version, majorVersion, versionErr := parseVersionTypeData(arazzo.Value)
+			if versionErr != nil {
+				return nil, versionErr
+			}
+
+			specInfo.SpecType = utils.Arazzo
+			specInfo.Version = version
+			specInfo.SpecFormat = Arazzo1
+			specInfo.VersionNumeric = 1.0
+
+			// parse JSON
+			parseJSON(spec, specInfo, &parsedSpec)
+
+			// so far there is only 1 as a major release of Arazzo
+			if majorVersion != 1 {
+				specInfo.Error = errors.New("spec is defined as arazzo, but has a major version that is invalid")
+				return specInfo, specInfo.Error
+			}
+		}
+
 		if specInfo.SpecType == "" {
 			// parse JSON
 			parseJSON(spec, specInfo, &parsedSpec)
@@ -192,6 +242,24 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 	return specInfo, nil
 }
 
+// findRootKey returns the value of a key at the root of the document. Only keys are matched, values (and anything
+// nested) are not, so unknown root content (like `x-generator: swagger`) can't break version detection.
+func findRootKey(key string, doc *yaml.Node) *yaml.Node {
+	if doc == nil || len(doc.Content) == 0 {
+		return nil
+	}
+	root := utils.NodeAlias(doc.Content[0])
+	if !utils.IsNodeMap(root) {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			return utils.NodeAlias(root.Content[i+1])
+		}
+	}
+	return nil
+}
+
 // ExtractSpecInfo accepts an OpenAPI/Swagger specification that has been read into a byte array
 // and will return a SpecInfo pointer, which contains details on the version and an un-marshaled
 // *yaml.Node root node tree. The root node tree is what's used by the library when building out models.
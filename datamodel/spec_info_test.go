@@ -163,6 +163,29 @@ func TestExtractSpecInfo_OpenAPI31(t *testing.T) {
 	assert.Contains(t, r.APISchema, "https://spec.openapis.org/oas/3.1/schema/2022-10-07")
 }
 
+func TestExtractSpecInfo_OpenAPI32(t *testing.T) {
+	r, e := ExtractSpecInfo([]byte("openapi: 3.2.0\ninfo:\n  title: query all the things"))
+	assert.Nil(t, e)
+	assert.Equal(t, OpenApi3, r.SpecType)
+	assert.Equal(t, "3.2.0", r.Version)
+	assert.Equal(t, float32(3.2), r.VersionNumeric)
+	assert.Equal(t, OAS32, r.SpecFormat)
+}
+
+func TestExtractSpecInfo_Arazzo(t *testing.T) {
+	r, e := ExtractSpecInfo([]byte("arazzo: 1.0.1\ninfo:\n  title: all the workflows"))
+	assert.Nil(t, e)
+	assert.Equal(t, utils.Arazzo, r.SpecType)
+	assert.Equal(t, "1.0.1", r.Version)
+	assert.Equal(t, float32(1.0), r.VersionNumeric)
+	assert.Equal(t, Arazzo1, r.SpecFormat)
+}
+
+func TestExtractSpecInfo_ArazzoBadVersion(t *testing.T) {
+	_, e := ExtractSpecInfo([]byte("arazzo: 2.0.0\ninfo:\n  title: from the future"))
+	assert.Error(t, e)
+}
+
 func TestExtractSpecInfo_AnyDocument(t *testing.T) {
 	random := `something: yeah
 nothing:
@@ -304,3 +327,16 @@ func TestExtractSpecInfoSync_Error(t *testing.T) {
 	_, e := ExtractSpecInfoWithDocumentCheckSync([]byte(random), true)
 	assert.Error(t, e)
 }
+
+func TestExtractSpecInfo_RootValuesIgnored(t *testing.T) {
+	spec := `openapi: 3.1.0
+x-generator: swagger
+info:
+  title: asyncapi
+  version: "1"`
+
+	r, err := ExtractSpecInfo([]byte(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, utils.OpenApi3, r.SpecType)
+	assert.Equal(t, "3.1.0", r.Version)
+}
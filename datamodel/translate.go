@@ -43,11 +43,23 @@ type pipelineJobStatus[IN any, OUT any] struct {
 // translate() or result() may return `io.EOF` to break iteration.
 // Results are provided sequentially to result() in stable order from slice.
 func TranslateSliceParallel[IN any, OUT any](in []IN, translate TranslateSliceFunc[IN, OUT], result ActionFunc[OUT]) error {
+	return TranslateSliceParallelWithContext(context.Background(), in, translate, result)
+}
+
+// TranslateSliceParallelWithContext is the same as TranslateSliceParallel, except iteration stops as soon as the
+// supplied context is cancelled or its deadline expires, in which case the context error is returned.
+func TranslateSliceParallelWithContext[IN any, OUT any](parent context.Context, in []IN, translate TranslateSliceFunc[IN, OUT], result ActionFunc[OUT]) error {
 	if in == nil {
 		return nil
 	}
+	if parent == nil {
+		parent = context.Background()
+	}
+	if err := parent.Err(); err != nil {
+		return err
+	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
 	concurrency := runtime.NumCPU()
 	jobChan := make(chan *jobStatus[OUT], concurrency)
@@ -74,6 +86,10 @@ func TranslateSliceParallel[IN any, OUT any](in []IN, translate TranslateSliceFu
 
 			wg.Add(1)
 			go func(idx int, valueIn IN) {
+				if ctx.Err() != nil {
+					wg.Done()
+					return
+				}
 				valueOut, err := translate(idx, valueIn)
 				if err == Continue {
 					j.cont = true
@@ -120,6 +136,9 @@ JOBLOOP:
 	if reterr == io.EOF {
 		return nil
 	}
+	if reterr == nil {
+		return parent.Err()
+	}
 	return reterr
 }
 
@@ -129,11 +148,23 @@ JOBLOOP:
 // Safely handles nil pointer.
 // Results are provided sequentially to result() in stable order from `*orderedmap.Map`.
 func TranslateMapParallel[K comparable, V any, RV any](m *orderedmap.Map[K, V], translate TranslateFunc[orderedmap.Pair[K, V], RV], result ResultFunc[RV]) error {
+	return TranslateMapParallelWithContext(context.Background(), m, translate, result)
+}
+
+// TranslateMapParallelWithContext is the same as TranslateMapParallel, except iteration stops as soon as the
+// supplied context is cancelled or its deadline expires, in which case the context error is returned.
+func TranslateMapParallelWithContext[K comparable, V any, RV any](parent context.Context, m *orderedmap.Map[K, V], translate TranslateFunc[orderedmap.Pair[K, V], RV], result ResultFunc[RV]) error {
 	if m == nil {
 		return nil
 	}
+	if parent == nil {
+		parent = context.Background()
+	}
+	if err := parent.Err(); err != nil {
+		return err
+	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
 	concurrency := runtime.NumCPU()
 	c := orderedmap.Iterate(ctx, m)
@@ -161,6 +192,10 @@ func TranslateMapParallel[K comparable, V any, RV any](m *orderedmap.Map[K, V],
 
 			wg.Add(1)
 			go func(pair orderedmap.Pair[K, V]) {
+				if ctx.Err() != nil {
+					wg.Done()
+					return
+				}
 				value, err := translate(pair)
 				if err != nil {
 					mu.Lock()
@@ -200,68 +235,74 @@ JOBLOOP:
 		}
 	}
 
+	cancel()
+	wg.Wait()
 	if reterr == io.EOF {
 		return nil
 	}
+	if reterr == nil {
+		return parent.Err()
+	}
 	return reterr
 }
 
 // TranslatePipeline processes input sequentially through predicate(), sends to
 // translate() in parallel, then outputs in stable order.
+// Results are always sent to `out` in the same order the input was received from `in`,
+// regardless of how long each translate() call takes.
 // translate() may return `datamodel.Continue` to continue iteration.
 // Caller must close `in` channel to indicate EOF.
 // TranslatePipeline closes `out` channel to indicate EOF.
 func TranslatePipeline[IN any, OUT any](in <-chan IN, out chan<- OUT, translate TranslateFunc[IN, OUT]) error {
-	ctx, cancel := context.WithCancel(context.Background())
+	return TranslatePipelineWithContext(context.Background(), in, out, translate)
+}
+
+// TranslatePipelineWithContext is the same as TranslatePipeline, except workers stop picking up new input as soon
+// as the supplied context is cancelled or its deadline expires, in which case the context error is returned.
+// `out` is always closed, cancelled or not.
+// translate() runs on the workers of the pool carried by the context (see WithWorkerPool), or of the shared pool
+// of GOMAXPROCS workers.
+func TranslatePipelineWithContext[IN any, OUT any](parent context.Context, in <-chan IN, out chan<- OUT, translate TranslateFunc[IN, OUT]) error {
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
-	concurrency := runtime.NumCPU()
-	workChan := make(chan *pipelineJobStatus[IN, OUT])
-	resultChan := make(chan *pipelineJobStatus[IN, OUT])
+	pool := GetWorkerPool(parent)
+	resultChan := make(chan *pipelineJobStatus[IN, OUT], pool.Size())
 	var reterr error
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	wg.Add(1) // input goroutine.
 
-	// Launch worker pool.
-	for i := 0; i < concurrency; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for {
-				select {
-				case j, ok := <-workChan:
-					if !ok {
-						return
-					}
-					result, err := translate(j.input)
-					if err == Continue {
-						j.cont = true
-						close(j.done)
-						continue
-					}
-					if err != nil {
-						mu.Lock()
-						defer mu.Unlock()
-						if reterr == nil {
-							reterr = err
-						}
-						cancel()
-						return
-					}
-					j.result = result
-					close(j.done)
-				case <-ctx.Done():
-					return
-				}
+	run := func(j *pipelineJobStatus[IN, OUT]) {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			return
+		}
+		result, err := translate(j.input)
+		if err == Continue {
+			j.cont = true
+			close(j.done)
+			return
+		}
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			if reterr == nil {
+				reterr = err
 			}
-		}()
+			cancel()
+			return
+		}
+		j.result = result
+		close(j.done)
 	}
 
 	// Iterate input, send to workers.
 	go func() {
 		defer func() {
-			close(workChan)
 			close(resultChan)
 			wg.Done()
 		}()
@@ -275,16 +316,14 @@ func TranslatePipeline[IN any, OUT any](in <-chan IN, out chan<- OUT, translate
 					done:  make(chan struct{}),
 					input: value,
 				}
-				select {
-				case workChan <- j:
-				case <-ctx.Done():
-					return
-				}
+				// results are queued first, so at most the size of the pool are in flight.
 				select {
 				case resultChan <- j:
 				case <-ctx.Done():
 					return
 				}
+				wg.Add(1)
+				pool.Go(func() { run(j) })
 			case <-ctx.Done():
 				return
 			}
@@ -293,6 +332,14 @@ func TranslatePipeline[IN any, OUT any](in <-chan IN, out chan<- OUT, translate
 
 	// Collect results in stable order, send to output channel.
 	defer close(out)
+	pipelineErr := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		if reterr == nil {
+			return parent.Err()
+		}
+		return reterr
+	}
 	for j := range resultChan {
 		select {
 		case <-j.done:
@@ -301,9 +348,9 @@ func TranslatePipeline[IN any, OUT any](in <-chan IN, out chan<- OUT, translate
 			}
 			out <- j.result
 		case <-ctx.Done():
-			return reterr
+			return pipelineErr()
 		}
 	}
 
-	return reterr
+	return pipelineErr()
 }
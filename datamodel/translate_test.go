@@ -546,3 +546,137 @@ func TestTranslatePipeline(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateSliceParallelWithContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sl := make([]int, 1000)
+
+	var translateCounter int64
+	translateFunc := func(_, value int) (string, error) {
+		if atomic.AddInt64(&translateCounter, 1) == 10 {
+			cancel()
+		}
+		return "", nil
+	}
+	err := datamodel.TranslateSliceParallelWithContext[int, string](ctx, sl, translateFunc, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, atomic.LoadInt64(&translateCounter), int64(len(sl)))
+}
+
+func TestTranslateSliceParallelWithContext_AlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var translateCounter int64
+	translateFunc := func(_, value int) (string, error) {
+		atomic.AddInt64(&translateCounter, 1)
+		return "", nil
+	}
+	err := datamodel.TranslateSliceParallelWithContext[int, string](ctx, []int{1, 2, 3}, translateFunc, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, translateCounter)
+}
+
+func TestTranslateMapParallelWithContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := orderedmap.New[string, int]()
+	for i := 0; i < 1000; i++ {
+		m.Set(fmt.Sprintf("key%d", i), i)
+	}
+
+	var resultCounter int
+	translateFunc := func(pair orderedmap.Pair[string, int]) (string, error) {
+		return pair.Key(), nil
+	}
+	resultFunc := func(value string) error {
+		resultCounter++
+		if resultCounter == 10 {
+			cancel()
+		}
+		return nil
+	}
+	err := datamodel.TranslateMapParallelWithContext[string, int, string](ctx, m, translateFunc, resultFunc)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, resultCounter, m.Len())
+}
+
+func TestTranslatePipelineWithContext_Deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	in := make(chan int)
+	out := make(chan string)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2) // input and output goroutines.
+
+	// Send input until the pipeline stops accepting it.
+	go func() {
+		defer func() {
+			close(in)
+			wg.Done()
+		}()
+		for i := 0; ; i++ {
+			select {
+			case in <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// Collect output.
+	go func() {
+		for range out {
+		}
+		close(done)
+		wg.Done()
+	}()
+
+	err := datamodel.TranslatePipelineWithContext[int, string](ctx, in, out,
+		func(value int) (string, error) {
+			time.Sleep(time.Millisecond)
+			return strconv.Itoa(value), nil
+		},
+	)
+	wg.Wait()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTranslatePipeline_StableOrderWithUnevenWork(t *testing.T) {
+	const itemCount = 200
+	in := make(chan int)
+	out := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(2) // input and output goroutines.
+
+	go func() {
+		defer func() {
+			close(in)
+			wg.Done()
+		}()
+		for i := 0; i < itemCount; i++ {
+			in <- i
+		}
+	}()
+
+	var results []int
+	go func() {
+		for v := range out {
+			results = append(results, v)
+		}
+		wg.Done()
+	}()
+
+	err := datamodel.TranslatePipeline[int, int](in, out,
+		func(value int) (int, error) {
+			// early items take the longest, so completion order is the reverse of input order.
+			time.Sleep(time.Duration(itemCount-value) * 10 * time.Microsecond)
+			return value, nil
+		},
+	)
+	wg.Wait()
+	require.NoError(t, err)
+	require.Len(t, results, itemCount)
+	assert.True(t, sort.IntsAreSorted(results))
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// WorkerPool runs jobs on a fixed number of long-lived goroutines, shared by every pipeline using the pool, rather
+// than each pipeline spawning goroutines of its own. A job is handed to an idle worker, or run by the goroutine
+// submitting it when every worker is busy, so pipelines nested in the jobs of other pipelines (as models are built
+// inside models) never wait on the workers their parents hold.
+type WorkerPool struct {
+	size  int
+	jobs  chan func()
+	done  chan struct{}
+	start sync.Once
+	close sync.Once
+}
+
+type workerPoolKey struct{}
+
+var sharedWorkerPools sync.Map // of *WorkerPool, by size.
+
+// NewWorkerPool creates a pool of `size` workers, or of GOMAXPROCS workers when size is zero or less. Workers are
+// started when the first job is submitted, and live until the pool is closed.
+func NewWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	return &WorkerPool{size: size, jobs: make(chan func()), done: make(chan struct{})}
+}
+
+// SharedWorkerPool returns the pool of `size` workers shared by every caller asking for the same size, creating it
+// the first time. A size of zero or less is GOMAXPROCS. The workers of shared pools live until
+// CloseSharedWorkerPools is called.
+func SharedWorkerPool(size int) *WorkerPool {
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	if p, ok := sharedWorkerPools.Load(size); ok {
+		return p.(*WorkerPool)
+	}
+	p, _ := sharedWorkerPools.LoadOrStore(size, NewWorkerPool(size))
+	return p.(*WorkerPool)
+}
+
+// CloseSharedWorkerPools closes every shared pool (see WorkerPool.Close) and forgets them, so their workers stop.
+// Builds still running on them carry on, each job running on the goroutine that submits it. Pools are created again
+// by the next builds that need them.
+func CloseSharedWorkerPools() {
+	sharedWorkerPools.Range(func(size, p any) bool {
+		sharedWorkerPools.Delete(size)
+		p.(*WorkerPool).Close()
+		return true
+	})
+}
+
+// Size returns the number of workers of the pool.
+func (p *WorkerPool) Size() int {
+	return p.size
+}
+
+// Go runs a job on an idle worker, or on the calling goroutine if every worker is busy (or the pool is closed).
+func (p *WorkerPool) Go(job func()) {
+	select {
+	case <-p.done:
+		job()
+		return
+	default:
+	}
+	p.start.Do(func() {
+		for i := 0; i < p.size; i++ {
+			go func() {
+				for {
+					select {
+					case j := <-p.jobs:
+						j()
+					case <-p.done:
+						return
+					}
+				}
+			}()
+		}
+	})
+	select {
+	case p.jobs <- job:
+	default:
+		job()
+	}
+}
+
+// Close stops the workers of the pool once they finish the jobs they are running. Jobs submitted to a closed pool
+// run on the goroutine submitting them. Closing a pool more than once does nothing.
+func (p *WorkerPool) Close() {
+	p.close.Do(func() {
+		close(p.done)
+	})
+}
+
+// WithWorkerPool returns a copy of ctx that carries the pool used by TranslatePipelineWithContext.
+func WithWorkerPool(ctx context.Context, pool *WorkerPool) context.Context {
+	if pool == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, workerPoolKey{}, pool)
+}
+
+// GetWorkerPool returns the pool carried by ctx, or the shared pool of GOMAXPROCS workers if there isn't one.
+func GetWorkerPool(ctx context.Context) *WorkerPool {
+	if ctx != nil {
+		if p, ok := ctx.Value(workerPoolKey{}).(*WorkerPool); ok {
+			return p
+		}
+	}
+	return SharedWorkerPool(0)
+}
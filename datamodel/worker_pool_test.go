@@ -0,0 +1,142 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel_test
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWorkerPool(t *testing.T) {
+	assert.Equal(t, 3, datamodel.NewWorkerPool(3).Size())
+	assert.Equal(t, runtime.GOMAXPROCS(0), datamodel.NewWorkerPool(0).Size())
+}
+
+func TestSharedWorkerPool(t *testing.T) {
+	assert.Same(t, datamodel.SharedWorkerPool(2), datamodel.SharedWorkerPool(2))
+	assert.NotSame(t, datamodel.SharedWorkerPool(2), datamodel.SharedWorkerPool(3))
+	assert.Same(t, datamodel.SharedWorkerPool(0), datamodel.SharedWorkerPool(runtime.GOMAXPROCS(0)))
+}
+
+func TestGetWorkerPool(t *testing.T) {
+	pool := datamodel.NewWorkerPool(1)
+	assert.Same(t, pool, datamodel.GetWorkerPool(datamodel.WithWorkerPool(context.Background(), pool)))
+	assert.Same(t, datamodel.SharedWorkerPool(0), datamodel.GetWorkerPool(context.Background()))
+
+	//nolint:staticcheck // a nil context gets the shared pool.
+	assert.Same(t, datamodel.SharedWorkerPool(0), datamodel.GetWorkerPool(nil))
+	ctx := context.Background()
+	assert.Equal(t, ctx, datamodel.WithWorkerPool(ctx, nil))
+}
+
+func TestWorkerPool_Go(t *testing.T) {
+	pool := datamodel.NewWorkerPool(1)
+	var wg sync.WaitGroup
+	var ran int64
+
+	// jobs submit jobs of their own, which run on the submitting goroutine when the only worker is busy.
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		pool.Go(func() {
+			defer wg.Done()
+			wg.Add(1)
+			pool.Go(func() {
+				defer wg.Done()
+				atomic.AddInt64(&ran, 1)
+			})
+			atomic.AddInt64(&ran, 1)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int64(200), ran)
+}
+
+func TestWorkerPool_Close(t *testing.T) {
+	before := runtime.NumGoroutine()
+	pool := datamodel.NewWorkerPool(4)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	pool.Go(wg.Done)
+	wg.Wait()
+
+	pool.Close()
+	pool.Close()
+	for i := 0; i < 1000 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before)
+
+	// a closed pool runs jobs on the goroutine submitting them.
+	ran := false
+	pool.Go(func() { ran = true })
+	assert.True(t, ran)
+}
+
+func TestCloseSharedWorkerPools(t *testing.T) {
+	pool := datamodel.SharedWorkerPool(5)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	pool.Go(wg.Done)
+	wg.Wait()
+
+	datamodel.CloseSharedWorkerPools()
+	assert.NotSame(t, pool, datamodel.SharedWorkerPool(5))
+
+	ran := false
+	pool.Go(func() { ran = true })
+	assert.True(t, ran)
+}
+
+func TestTranslatePipelineWithContext_NestedPipelines(t *testing.T) {
+	// a pool of one worker builds pipelines nested in the jobs of other pipelines.
+	ctx := datamodel.WithWorkerPool(context.Background(), datamodel.NewWorkerPool(1))
+	var translated int64
+
+	pipeline := func(count int, translate func(int) (int, error)) ([]int, error) {
+		in := make(chan int)
+		out := make(chan int)
+		go func() {
+			defer close(in)
+			for i := 0; i < count; i++ {
+				in <- i
+			}
+		}()
+		var results []int
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for v := range out {
+				results = append(results, v)
+			}
+		}()
+		err := datamodel.TranslatePipelineWithContext[int, int](ctx, in, out, translate)
+		<-done
+		return results, err
+	}
+
+	results, err := pipeline(20, func(value int) (int, error) {
+		inner, err := pipeline(20, func(v int) (int, error) {
+			atomic.AddInt64(&translated, 1)
+			return v, nil
+		})
+		if err != nil || len(inner) != 20 {
+			return 0, err
+		}
+		return value, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 20)
+	for i, v := range results {
+		assert.Equal(t, i, v)
+	}
+	assert.Equal(t, int64(400), translated)
+}
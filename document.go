@@ -14,16 +14,20 @@
 package libopenapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/pb33f/libopenapi/index"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
 	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/upgrade"
 	"github.com/pb33f/libopenapi/utils"
 	what_changed "github.com/pb33f/libopenapi/what-changed"
 	"github.com/pb33f/libopenapi/what-changed/model"
@@ -53,15 +57,28 @@ type Document interface {
 	// BuildV2Model will build out a Swagger (version 2) model from the specification used to create the document
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 2 specifications and will throw an error for
-	// any other types.
+	// any other types. When the document is configured to be lenient, a model is returned whenever one could be
+	// built, along with the errors (which are also its BuildErrors).
 	BuildV2Model() (*DocumentModel[v2high.Swagger], []error)
 
+	// BuildV2ModelWithContext is the same as BuildV2Model, except the supplied context is honored throughout
+	// indexing and model building. If the context is cancelled or its deadline expires, no model is returned and
+	// the context error is included in the returned errors.
+	BuildV2ModelWithContext(ctx context.Context) (*DocumentModel[v2high.Swagger], []error)
+
 	// BuildV3Model will build out an OpenAPI (version 3+) model from the specification used to create the document
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 3 specifications and will throw an error for
-	// any other types.
+	// any other types. When the document is configured to be lenient, a model is returned whenever one could be
+	// built, along with the errors (which are also its BuildErrors).
 	BuildV3Model() (*DocumentModel[v3high.Document], []error)
 
+	// BuildV3ModelWithContext is the same as BuildV3Model, except the supplied context is honored throughout
+	// indexing and model building. This is useful for bounding the time spent parsing untrusted specifications.
+	// If the context is cancelled or its deadline expires, no model is returned and the context error is included
+	// in the returned errors.
+	BuildV3ModelWithContext(ctx context.Context) (*DocumentModel[v3high.Document], []error)
+
 	// RenderAndReload will render the high level model as it currently exists (including any mutations, additions
 	// and removals to and from any object in the tree). It will then reload the low level model with the new bytes
 	// extracted from the model that was re-rendered. This is useful if you want to make changes to the high level model
@@ -102,6 +119,100 @@ type Document interface {
 	// Deprecated: This method is deprecated and will be removed in a future release. Use RenderAndReload() instead.
 	// This method does not support mutations correctly.
 	Serialize() ([]byte, error)
+
+	// MarshalBinary will encode the parsed document into a parse cache, a compact binary form ready to be kept on
+	// disk: the specification bytes, its spec information and the parsed tree of nodes, with the line, column and
+	// comments of every node. UnmarshalBinary (or NewDocumentFromParseCache) restores the document without parsing
+	// the specification again, so CLI tools repeatedly working on the same unchanged specification only parse it
+	// once. Compare the SHA256 of the provenance with a hash of the specification on disk to know the cache is still
+	// good.
+	//
+	// When the document has been built, the references its index extracted from the specification are encoded too,
+	// and the first model built from the restored document creates its index from them. Models are not encoded:
+	// they are built (lazily, in part) from the index and the nodes, and hold the state of the rolodex (file
+	// systems, remote documents) and of the configuration, which can't be. They are built again (from the decoded
+	// nodes) by BuildV3Model or BuildV2Model, every time.
+	MarshalBinary() ([]byte, error)
+
+	// UnmarshalBinary will restore the document from the parse cache written by MarshalBinary, replacing anything
+	// the document held (and any model built from it). The configuration of the document is kept. Returns an error
+	// if the data was not written by MarshalBinary, was written by a different version of libopenapi, or is damaged.
+	UnmarshalBinary(data []byte) error
+
+	// ResolvePointer will resolve a JSON Pointer (RFC 6901), such as `/paths/~1pets/get`, against the model that was
+	// built by BuildV3Model or BuildV2Model and return the high-level model object at that location. This allows
+	// locations from overlays or validation errors to be mapped back to model objects. A model must be built first,
+	// otherwise an error is returned.
+	ResolvePointer(pointer string) (any, error)
+
+	// GetProvenance will return where the document came from and how it was built: the source URL or path, the
+	// SHA-256 hash of the specification bytes, when they were read, the libopenapi version and a snapshot of the
+	// configuration. Set DocumentConfiguration.EmbedProvenance to render it into the document as an extension.
+	GetProvenance() *datamodel.Provenance
+
+	// Snapshot will create an independent, deep copy of the OpenAPI 3 model (built by BuildV3Model), that is safe to
+	// share across goroutines. The model is rendered (including any mutations) and rebuilt into a new Document, so
+	// the snapshot shares no ordered maps, yaml nodes, low-level models or index with this one. Every schema of the
+	// snapshot is built before it's returned. Only the copies of a schema created by following recursive references
+	// beyond their first cycle are still built when first read, which SchemaProxy guards with a lock.
+	//
+	// The snapshot must be treated as read-only, it's only safe for concurrent use as long as nobody changes it.
+	// Changing the Document (or its model) after the snapshot is taken does not change the snapshot.
+	//
+	// **IMPORTANT** This method only supports OpenAPI Documents.
+	Snapshot() (*DocumentModel[v3high.Document], []error)
+
+	// ApplyJSONPatch will apply a JSON Patch (RFC 6902) to the document, editing the underlying yaml tree in place.
+	// Only the patched nodes change, everything else keeps its comments, formatting, line and column numbers, and
+	// added values are given the line and column of the node they replace (or of the object or array they're
+	// added to). The patch is applied atomically: if an operation fails, or the patched document is no longer a
+	// specification, the error is returned and the document is not changed.
+	//
+	// Once patched, any model built from the document is rebuilt (re-indexing the document) and the errors of the
+	// build are returned, located in the patched document. Models and indexes built before the patch are stale,
+	// they must not be used anymore.
+	ApplyJSONPatch(patch []byte) []error
+
+	// ConvertTo will convert the document to another version of OpenAPI, editing a copy of the underlying yaml tree,
+	// such as ConvertTo("3.1.0") for an OpenAPI 3.0 document, or ConvertTo("3.0.3") for an OpenAPI 3.1 document.
+	// Upgrading makes the mechanical changes (nullable becomes a type array, example becomes examples, boolean
+	// exclusive bounds become numbers), downgrading is best-effort: everything OpenAPI 3.0 can't express is
+	// removed, and listed by the Losses of the returned report. The upgrade package has the details of each change.
+	//
+	// The conversion is atomic, if it fails the document is not changed. Once converted, any model built from the
+	// document is rebuilt, and the errors of the build are returned. Models and indexes built before the conversion
+	// are stale, they must not be used anymore.
+	//
+	// **IMPORTANT** This method only supports OpenAPI 3.0 and 3.1 Documents.
+	ConvertTo(version string) (*upgrade.Report, []error)
+
+	// DeprecationReport will list every deprecated operation, parameter, schema and property of the OpenAPI 3 model
+	// (built by BuildV3Model), with where each is defined and its sunset date, read from the first of the
+	// SunsetExtensions found (such as `x-sunset`). References are followed (across files too), and every deprecated
+	// object is reported once, where it's defined (or where it's first found, for objects that aren't components).
+	//
+	// **IMPORTANT** This method only supports OpenAPI Documents.
+	DeprecationReport() (*DeprecationReport, error)
+
+	// RenameComponent will rename a component of the root document, such as RenameComponent("schemas", "User",
+	// "Customer"), and rewrite every `$ref` to it (or into it) across the root document and every file of the
+	// rolodex, editing the yaml trees in place. The location of each `$ref` is kept as written, only the JSON
+	// Pointer changes. Swagger documents accept the type of component of OpenAPI 3 (schemas, parameters, responses,
+	// securitySchemes) or the definitions holding them.
+	//
+	// Files are not written: the files changed are returned, with the nodes changed in each, for the caller to
+	// render and write back. Names used outside of a `$ref` (such as security requirements or discriminator
+	// mappings) are not rewritten. Models and indexes built before the rename are stale, write the files back and
+	// load the document again before using them. A model must be built first, otherwise an error is returned.
+	RenameComponent(componentType, name, newName string) (*ComponentRename, error)
+
+	// Close drops everything the document holds: the specification, the rolodex (with the index and yaml tree of
+	// every file) and the models built from it. Very large specifications hold gigabytes, so a service building them
+	// one after another should close each when it's done with it. The memory is reclaimed by the garbage collector
+	// once the caller lets go of the models too, models still held are left as they are and can still be used.
+	//
+	// Building a model once the document is closed returns an error.
+	Close()
 }
 
 type document struct {
@@ -109,8 +220,11 @@ type document struct {
 	version           string
 	info              *datamodel.SpecInfo
 	config            *datamodel.DocumentConfiguration
+	provenance        *datamodel.Provenance
+	indexCache        *index.SpecIndexCache
 	highOpenAPI3Model *DocumentModel[v3high.Document]
 	highSwaggerModel  *DocumentModel[v2high.Swagger]
+	closed            bool
 }
 
 // DocumentModel represents either a Swagger document (version 2) or an OpenAPI document (version 3) that is
@@ -118,6 +232,25 @@ type document struct {
 type DocumentModel[T v2high.Swagger | v3high.Document] struct {
 	Model T
 	Index *index.SpecIndex // index created from the document.
+
+	// BuildErrors is every error returned building the model, one for each problem, along with where it was
+	// found. When the document is configured to be lenient, a model is returned however many there are.
+	BuildErrors []BuildError
+}
+
+// ResolvePointer will resolve a JSON Pointer (RFC 6901), such as `/paths/~1pets/get`, against the model and
+// return the high-level model object at that location.
+func (m *DocumentModel[T]) ResolvePointer(pointer string) (any, error) {
+	return high.ResolvePointer(&m.Model, pointer)
+}
+
+// Origin will return where an object of the model was defined: the absolute path (or URL) of the file of the
+// rolodex it was read from, and the lines it spans. Returns nil if the object can't be located.
+func (m *DocumentModel[T]) Origin(object high.GoesLowUntyped) *index.NodeOrigin {
+	if m.Index == nil {
+		return nil
+	}
+	return high.FindOrigin(m.Index.GetRolodex(), object)
 }
 
 // NewDocument will create a new OpenAPI instance from an OpenAPI specification []byte array. If anything goes
@@ -141,27 +274,32 @@ func NewDocumentWithTypeCheck(specByteArray []byte, bypassCheck bool) (Document,
 	if err != nil {
 		return nil, err
 	}
-	d := new(document)
-	d.version = info.Version
-	d.info = info
-	return d, nil
+	return newDocument(specByteArray, info), nil
 }
 
 // NewDocumentWithConfiguration is the same as NewDocument, except it's a convenience function that calls NewDocument
-// under the hood and then calls SetConfiguration() on the returned Document.
+// under the hood and then calls SetConfiguration() on the returned Document. The specification is checked against
+// the size and node limits of the configuration as it's parsed, so a specification that exceeds them is never
+// fully parsed.
 func NewDocumentWithConfiguration(specByteArray []byte, configuration *datamodel.DocumentConfiguration) (Document, error) {
-	var d Document
-	var err error
-	if configuration != nil && configuration.BypassDocumentCheck {
-		d, err = NewDocumentWithTypeCheck(specByteArray, true)
-	} else {
-		d, err = NewDocument(specByteArray)
+	if configuration == nil {
+		return NewDocument(specByteArray)
 	}
-
-	if d != nil {
-		d.SetConfiguration(configuration)
+	info, err := datamodel.ExtractSpecInfoWithConfig(specByteArray, configuration)
+	if err != nil {
+		return nil, err
 	}
-	return d, err
+	d := newDocument(specByteArray, info)
+	d.SetConfiguration(configuration)
+	return d, nil
+}
+
+func newDocument(specByteArray []byte, info *datamodel.SpecInfo) *document {
+	d := new(document)
+	d.version = info.Version
+	d.info = info
+	d.provenance = datamodel.NewProvenance(specByteArray, nil)
+	return d
 }
 
 func (d *document) GetRolodex() *index.Rolodex {
@@ -182,6 +320,23 @@ func (d *document) GetConfiguration() *datamodel.DocumentConfiguration {
 
 func (d *document) SetConfiguration(configuration *datamodel.DocumentConfiguration) {
 	d.config = configuration
+	if d.provenance != nil {
+		d.provenance.SetConfiguration(configuration)
+	}
+}
+
+func (d *document) GetProvenance() *datamodel.Provenance {
+	return d.provenance
+}
+
+func (d *document) ResolvePointer(pointer string) (any, error) {
+	if d.highOpenAPI3Model != nil {
+		return d.highOpenAPI3Model.ResolvePointer(pointer)
+	}
+	if d.highSwaggerModel != nil {
+		return d.highSwaggerModel.ResolvePointer(pointer)
+	}
+	return nil, errors.New("unable to resolve pointer, no model has been built for this document")
 }
 
 func (d *document) Serialize() ([]byte, error) {
@@ -196,6 +351,15 @@ func (d *document) Serialize() ([]byte, error) {
 	}
 }
 
+func (d *document) Close() {
+	d.highOpenAPI3Model = nil
+	d.highSwaggerModel = nil
+	d.rolodex = nil
+	d.indexCache = nil
+	d.info = nil
+	d.closed = true
+}
+
 func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Document], []error) {
 	newBytes, rerr := d.Render()
 	if rerr != nil {
@@ -217,10 +381,27 @@ func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Do
 }
 
 func (d *document) Render() ([]byte, error) {
+	return d.render(d.config != nil && d.config.EmbedProvenance)
+}
+
+func (d *document) render(embedProvenance bool) ([]byte, error) {
 	if d.highSwaggerModel != nil && d.highOpenAPI3Model == nil {
 		return nil, errors.New("this method only supports OpenAPI 3 documents, not Swagger")
 	}
 
+	// render a copy of the model, so the provenance extension is never added to the model itself.
+	model := d.highOpenAPI3Model.Model
+	if embedProvenance && d.provenance != nil {
+		extensions := orderedmap.New[string, *yaml.Node]()
+		extensions.Set(datamodel.ProvenanceExtension, d.provenance.Node())
+		for k, v := range model.Extensions.FromOldest() {
+			if k != datamodel.ProvenanceExtension {
+				extensions.Set(k, v)
+			}
+		}
+		model.Extensions = extensions
+	}
+
 	var newBytes []byte
 	var jsonErr error
 	if d.info.SpecFileType == datamodel.JSONFileType {
@@ -231,19 +412,26 @@ func (d *document) Render() ([]byte, error) {
 				jsonIndent += " "
 			}
 		}
-		newBytes, jsonErr = d.highOpenAPI3Model.Model.RenderJSON(jsonIndent)
+		newBytes, jsonErr = model.RenderJSON(jsonIndent)
 	}
 	if d.info.SpecFileType == datamodel.YAMLFileType {
-		newBytes = d.highOpenAPI3Model.Model.RenderWithIndention(d.info.OriginalIndentation)
+		newBytes = model.RenderWithIndention(d.info.OriginalIndentation)
 	}
 	return newBytes, jsonErr
 }
 
 func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
+	return d.BuildV2ModelWithContext(context.Background())
+}
+
+func (d *document) BuildV2ModelWithContext(ctx context.Context) (*DocumentModel[v2high.Swagger], []error) {
 	if d.highSwaggerModel != nil {
 		return d.highSwaggerModel, nil
 	}
 	var errs []error
+	if d.closed {
+		return nil, append(errs, fmt.Errorf("unable to build swagger document, the document has been closed"))
+	}
 	if d.info == nil {
 		errs = append(errs, fmt.Errorf("unable to build swagger document, no specification has been loaded"))
 		return nil, errs
@@ -260,42 +448,64 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], []error) {
 	}
 
 	var docErr error
-	lowDoc, docErr = v2low.CreateDocumentFromConfig(d.info, d.config)
+	lowDoc, docErr = v2low.CreateDocumentFromConfigWithContext(index.WithSpecIndexCache(ctx, d.indexCache), d.info,
+		d.config)
+	if lowDoc == nil {
+		return nil, append(errs, utils.UnwrapErrors(docErr)...)
+	}
 	d.rolodex = lowDoc.Rolodex
 
 	if docErr != nil {
 		errs = append(errs, utils.UnwrapErrors(docErr)...)
 	}
+	// when lenient, the model built is returned whatever went wrong.
+	lenient := d.config.Lenient
+	aborted := abortedBuild(docErr)
+	if !lenient && aborted {
+		return nil, errs
+	}
 
 	// Do not short-circuit on circular reference errors, so the client
 	// has the option of ignoring them.
 	for _, err := range errs {
 		var refErr *index.ResolvingError
 		if errors.As(err, &refErr) {
-			if refErr.CircularReference == nil {
+			if refErr.CircularReference == nil && !lenient {
 				return nil, errs
 			}
 		}
 	}
 	highDoc := v2high.NewSwaggerDocument(lowDoc)
 
-	d.highSwaggerModel = &DocumentModel[v2high.Swagger]{
-		Model: *highDoc,
-		Index: lowDoc.Index,
+	model := &DocumentModel[v2high.Swagger]{
+		Model:       *highDoc,
+		Index:       lowDoc.Index,
+		BuildErrors: NewBuildErrors(d.rolodex, errs),
 	}
-	return d.highSwaggerModel, errs
+	// the partial model of a build that was cut short is not kept, the next build starts over.
+	if !aborted {
+		d.highSwaggerModel = model
+	}
+	return model, errs
 }
 
 func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
+	return d.BuildV3ModelWithContext(context.Background())
+}
+
+func (d *document) BuildV3ModelWithContext(ctx context.Context) (*DocumentModel[v3high.Document], []error) {
 	if d.highOpenAPI3Model != nil {
 		return d.highOpenAPI3Model, nil
 	}
 	var errs []error
+	if d.closed {
+		return nil, append(errs, fmt.Errorf("unable to build document, the document has been closed"))
+	}
 	if d.info == nil {
 		errs = append(errs, fmt.Errorf("unable to build document, no specification has been loaded"))
 		return nil, errs
 	}
-	if d.info.SpecFormat != datamodel.OAS3 && d.info.SpecFormat != datamodel.OAS31 {
+	if d.info.SpecFormat != datamodel.OAS3 && d.info.SpecFormat != datamodel.OAS31 && d.info.SpecFormat != datamodel.OAS32 {
 		errs = append(errs, fmt.Errorf("unable to build openapi document, "+
 			"supplied spec is a different version (%v). Try 'BuildV2Model()'", d.info.SpecFormat))
 		return nil, errs
@@ -310,32 +520,75 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], []error) {
 	}
 
 	var docErr error
-	lowDoc, docErr = v3low.CreateDocumentFromConfig(d.info, d.config)
+	lowDoc, docErr = v3low.CreateDocumentFromConfigWithContext(index.WithSpecIndexCache(ctx, d.indexCache), d.info,
+		d.config)
+	if lowDoc == nil {
+		return nil, append(errs, utils.UnwrapErrors(docErr)...)
+	}
 	d.rolodex = lowDoc.Rolodex
 
 	if docErr != nil {
 		errs = append(errs, utils.UnwrapErrors(docErr)...)
 	}
+	// when lenient, the model built is returned whatever went wrong.
+	lenient := d.config.Lenient
+	aborted := abortedBuild(docErr)
+	if !lenient && aborted {
+		return nil, errs
+	}
 
 	// Do not short-circuit on circular reference errors, so the client
 	// has the option of ignoring them.
 	for _, err := range utils.UnwrapErrors(docErr) {
 		var refErr *index.ResolvingError
 		if errors.As(err, &refErr) {
-			if refErr.CircularReference == nil {
+			if refErr.CircularReference == nil && !lenient {
 				return nil, errs
 			}
 		}
 	}
 
 	highDoc := v3high.NewDocument(lowDoc)
-	highDoc.Rolodex = lowDoc.Index.GetRolodex()
+	// a build cut short may not have got as far as the index.
+	if lowDoc.Index != nil {
+		highDoc.Rolodex = lowDoc.Index.GetRolodex()
+	}
+
+	model := &DocumentModel[v3high.Document]{
+		Model:       *highDoc,
+		Index:       lowDoc.Index,
+		BuildErrors: NewBuildErrors(d.rolodex, errs),
+	}
+	// the partial model of a build that was cut short is not kept, the next build starts over.
+	if !aborted {
+		d.highOpenAPI3Model = model
+	}
+	return model, errs
+}
+
+// abortedBuild returns true if the build was cut short by a cancelled or expired context, an exceeded limit or a
+// remote file that no longer matches its pin, rather than by a problem in the document.
+func abortedBuild(err error) bool {
+	return isContextError(err) || errors.Is(err, datamodel.ErrLimitExceeded) || errors.Is(err, datamodel.ErrPinMismatch)
+}
+
+// isContextError returns true if the error was caused by a cancelled or expired context.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
 
-	d.highOpenAPI3Model = &DocumentModel[v3high.Document]{
-		Model: *highDoc,
-		Index: lowDoc.Index,
+// buildModel builds the model for the version of the document, honoring ctx. Returns true if a model was built,
+// along with any errors hit building it.
+func buildModel(ctx context.Context, doc Document) (bool, []error) {
+	switch doc.GetSpecInfo().SpecType {
+	case utils.OpenApi3:
+		m, errs := doc.BuildV3ModelWithContext(ctx)
+		return m != nil, errs
+	case utils.OpenApi2:
+		m, errs := doc.BuildV2ModelWithContext(ctx)
+		return m != nil, errs
 	}
-	return d.highOpenAPI3Model, errs
+	return false, nil
 }
 
 // CompareDocuments will accept a left and right Document implementing struct, build a model for the correct
@@ -0,0 +1,90 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// BuildError is a single problem found building a model, along with where it was found (when that's known).
+// The BuildErrors of a DocumentModel hold every one of them, so editors can show all the problems of a document
+// at once, rather than the first.
+type BuildError struct {
+	Err      error                // the error itself.
+	Phase    datamodel.BuildPhase // the phase of the build the error happened in.
+	Path     string               // the path to the problem, taken by the index or resolver, if known.
+	File     string               // the absolute path (or URL) of the file holding the problem, if known.
+	Line     int                  // the line of the problem, or 0 if it's not known.
+	Column   int                  // the column of the problem, or 0 if it's not known.
+	Circular bool                 // true if the error is a circular reference, which may be safe to ignore.
+}
+
+func (b BuildError) Error() string {
+	return b.Err.Error()
+}
+
+// Unwrap returns the underlying error, so it can be matched with errors.Is and errors.As.
+func (b BuildError) Unwrap() error {
+	return b.Err
+}
+
+// NewBuildErrors flattens the errors returned by building a model (joined errors are split apart) into an
+// inventory of BuildError, located using the rolodex of the document when it's not nil.
+func NewBuildErrors(rolodex *index.Rolodex, errs []error) []BuildError {
+	var buildErrors []BuildError
+	for _, err := range flattenErrors(errs) {
+		buildErrors = append(buildErrors, newBuildError(rolodex, err))
+	}
+	return buildErrors
+}
+
+func newBuildError(rolodex *index.Rolodex, err error) BuildError {
+	be := BuildError{Err: err, Phase: datamodel.PhaseBuild}
+	var node *yaml.Node
+	var resolvingErr *index.ResolvingError
+	var indexingErr *index.IndexingError
+	switch {
+	case errors.As(err, &resolvingErr):
+		be.Phase = datamodel.PhaseResolve
+		be.Path = resolvingErr.Path
+		be.Circular = resolvingErr.CircularReference != nil
+		node = resolvingErr.Node
+	case errors.As(err, &indexingErr):
+		be.Phase = datamodel.PhaseIndex
+		be.Path = indexingErr.Path
+		node = indexingErr.Node
+		if node == nil {
+			node = indexingErr.KeyNode
+		}
+	}
+	if node != nil {
+		be.Line, be.Column = node.Line, node.Column
+		if rolodex != nil {
+			if origin := rolodex.FindNodeOrigin(node); origin != nil {
+				be.File = origin.AbsoluteLocation
+			}
+		}
+	}
+	return be
+}
+
+// flattenErrors splits joined errors apart (however deeply they are joined), so each one stands alone.
+func flattenErrors(errs []error) []error {
+	var flat []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			flat = append(flat, flattenErrors(joined.Unwrap())...)
+			continue
+		}
+		flat = append(flat, err)
+	}
+	return flat
+}
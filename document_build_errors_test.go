@@ -0,0 +1,184 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDocument_BuildV3Model_Lenient(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: lenient
+  version: 1.0.0
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Missing'
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Missing'
+  /vets:
+    get:
+      responses: nope`
+
+	// without it, the paths stop at the first that fails.
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	assert.NotEmpty(t, errs)
+	assert.Nil(t, model.Model.Paths)
+
+	doc, err = NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{Lenient: true})
+	require.NoError(t, err)
+	model, errs = doc.BuildV3Model()
+	require.NotNil(t, model)
+	assert.NotEmpty(t, errs)
+
+	// everything that built is there.
+	assert.Equal(t, 2, model.Model.Paths.PathItems.Len())
+	assert.NotNil(t, model.Model.Paths.PathItems.GetOrZero("/owners").Get)
+
+	// and every problem is in the inventory, on its own.
+	require.Len(t, model.BuildErrors, 5)
+	var messages []string
+	for _, be := range model.BuildErrors {
+		messages = append(messages, be.Error())
+		if be.Path == "$.components.schemas['Missing']" {
+			assert.Equal(t, datamodel.PhaseIndex, be.Phase)
+			assert.Equal(t, 16, be.Line)
+			assert.Equal(t, 17, be.Column)
+		}
+	}
+	assert.Contains(t, messages,
+		"path item build failed: cannot find reference: '#/components/pathItems/Missing' at line 7, col 11")
+	assert.Contains(t, messages,
+		"path '/vets' build failed: responses build failed: vn node is not a map! line 19, col 18")
+}
+
+func TestDocument_BuildV3Model_LenientAborted(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: lenient
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets`)
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{Lenient: true})
+	require.NoError(t, err)
+
+	// a cancelled build returns what was built, with the reason why it stopped.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs := doc.BuildV3ModelWithContext(ctx)
+	assert.ErrorIs(t, errors.Join(errs...), context.Canceled)
+
+	// but it's not kept, the next build starts over.
+	model, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	require.NotNil(t, model)
+	assert.Equal(t, 1, model.Model.Paths.PathItems.Len())
+	again, _ := doc.BuildV3Model()
+	assert.Same(t, model, again)
+
+	// lenient wins over strict, a model is still built for a broken document.
+	doc, err = NewDocumentWithConfiguration([]byte(`openapi: 3.1.0
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Missing'`), &datamodel.DocumentConfiguration{Lenient: true, Strict: true})
+	require.NoError(t, err)
+	model, errs = doc.BuildV3Model()
+	assert.NotEmpty(t, errs)
+	assert.NotNil(t, model)
+}
+
+func TestDocument_BuildV3Model_OperationErrors(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: operations
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: pets
+          links:
+            owner:
+              operationId: getOwner
+    post:
+      operationId: listPets
+      responses:
+        "200":
+          description: pet`)
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	// by default, duplicated operationIds and dangling links are left to the index.
+	model, errs := doc.BuildV3Model()
+	require.NotNil(t, model)
+	assert.Empty(t, errs)
+	assert.Len(t, model.Index.GetOperationIdIndexErrors(), 1)
+	assert.Len(t, model.Index.GetLinkIndexErrors(), 1)
+
+	// when strict, they are errors of the build (lenient still returns the model).
+	doc, err = NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{Lenient: true, Strict: true})
+	require.NoError(t, err)
+	model, errs = doc.BuildV3Model()
+	require.NotNil(t, model)
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], "the operationId `listPets` of the `POST` operation at `/pets` is already used")
+	assert.ErrorContains(t, errs[1], "getOwner")
+
+	require.Len(t, model.BuildErrors, 2)
+	assert.Equal(t, datamodel.PhaseIndex, model.BuildErrors[0].Phase)
+	assert.Equal(t, "$.paths['/pets'].post", model.BuildErrors[0].Path)
+	assert.Equal(t, 16, model.BuildErrors[0].Line)
+	assert.Equal(t, datamodel.PhaseIndex, model.BuildErrors[1].Phase)
+}
+
+func TestNewBuildErrors(t *testing.T) {
+	node := &yaml.Node{Line: 3, Column: 5}
+	joined := errors.Join(
+		errors.New("one"),
+		errors.Join(&index.ResolvingError{ErrorRef: errors.New("two"), Node: node, Path: "$.two"}, nil),
+		&index.IndexingError{Err: errors.New("three"), KeyNode: node, Path: "$.three"},
+	)
+
+	buildErrors := NewBuildErrors(nil, []error{joined, nil})
+	require.Len(t, buildErrors, 3)
+
+	assert.Equal(t, "one", buildErrors[0].Error())
+	assert.Equal(t, datamodel.PhaseBuild, buildErrors[0].Phase)
+	assert.Zero(t, buildErrors[0].Line)
+
+	assert.Equal(t, datamodel.PhaseResolve, buildErrors[1].Phase)
+	assert.Equal(t, "$.two", buildErrors[1].Path)
+	assert.Equal(t, 3, buildErrors[1].Line)
+	assert.Equal(t, 5, buildErrors[1].Column)
+	assert.False(t, buildErrors[1].Circular)
+	var resolvingErr *index.ResolvingError
+	assert.True(t, errors.As(buildErrors[1], &resolvingErr))
+
+	assert.Equal(t, datamodel.PhaseIndex, buildErrors[2].Phase)
+	assert.Equal(t, "$.three", buildErrors[2].Path)
+	assert.Equal(t, 3, buildErrors[2].Line)
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/upgrade"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+func (d *document) ConvertTo(version string) (*upgrade.Report, []error) {
+	if d.info == nil || d.info.RootNode == nil {
+		return nil, []error{errors.New("unable to convert, document has not yet been initialized")}
+	}
+
+	// the document is converted as a copy first, so a conversion that fails never changes the document.
+	converted := utils.CopyNode(d.info.RootNode)
+	report, err := upgrade.Convert(converted, version)
+	if err != nil {
+		return nil, []error{err}
+	}
+	spec, err := d.renderRoot(converted)
+	if err != nil {
+		return report, []error{fmt.Errorf("unable to render the converted document: %w", err)}
+	}
+	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(spec, d.config != nil && d.config.BypassDocumentCheck)
+	if err != nil {
+		return report, []error{fmt.Errorf("unable to read the converted document: %w", err)}
+	}
+	info.RootNode = converted
+
+	rebuild := d.highOpenAPI3Model != nil
+	d.info = info
+	d.version = info.Version
+	d.provenance = datamodel.NewProvenance(spec, d.config)
+	d.highOpenAPI3Model, d.highSwaggerModel, d.rolodex = nil, nil, nil
+
+	var errs []error
+	if rebuild {
+		_, errs = d.BuildV3Model()
+	}
+	return report, errs
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_ConvertTo(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: convert
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: string
+      nullable: true
+      example: rex
+      minimum: 1
+      exclusiveMinimum: true`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	report, errs := doc.ConvertTo("3.1.0")
+	require.Empty(t, errs)
+	assert.Len(t, report.Rewritable(), 4)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+	assert.Equal(t, datamodel.OAS31, doc.GetSpecInfo().SpecFormat)
+
+	// the model is rebuilt from the converted document.
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	pet := model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, []string{"string", "null"}, pet.Type)
+	assert.Equal(t, "rex", pet.Examples[0].Value)
+	assert.Equal(t, 1.0, pet.ExclusiveMinimum.B)
+
+	// and back again, without losing anything.
+	report, errs = doc.ConvertTo("3.0.3")
+	require.Empty(t, errs)
+	assert.Empty(t, report.Losses())
+	model, _ = doc.BuildV3Model()
+	pet = model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, []string{"string"}, pet.Type)
+	assert.True(t, *pet.Nullable)
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "exclusiveMinimum: true")
+
+	// a conversion that can't be made leaves the document alone.
+	_, errs = doc.ConvertTo("2.0")
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], upgrade.ErrUnsupportedConversion)
+	assert.Equal(t, "3.0.3", doc.GetVersion())
+
+	_, errs = new(document).ConvertTo("3.1.0")
+	assert.EqualError(t, errs[0], "unable to convert, document has not yet been initialized")
+}
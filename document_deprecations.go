@@ -0,0 +1,284 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// SunsetExtensions are the extensions read for the sunset date of a deprecated operation, parameter, schema or
+// property, in order of preference.
+var SunsetExtensions = []string{"x-sunset", "x-sunset-date", "x-deprecated-sunset"}
+
+// sunsetLayouts are the date formats a sunset date is parsed with: a date, a date and time, and an HTTP date (as
+// used by the Sunset header, RFC 8594).
+var sunsetLayouts = []string{time.DateOnly, time.RFC3339, http.TimeFormat, time.RFC1123}
+
+// Deprecation is an operation, parameter, schema or property marked as deprecated.
+type Deprecation struct {
+	// Name is what is deprecated: the method and path of an operation (e.g. `GET /pets`), the name of a parameter
+	// or property, or the name of a component schema (empty for an inline schema).
+	Name string
+
+	// JSONPath is where the deprecated object is defined, e.g. $.paths['/pets'].get
+	JSONPath string
+
+	// Sunset is the sunset date, as written in the first sunset extension (see SunsetExtensions) of the object,
+	// SunsetDate is the date parsed (zero if there isn't one, or it can't be parsed).
+	Sunset     string
+	SunsetDate time.Time
+
+	Node   *yaml.Node        // the `deprecated` value node.
+	Origin *index.NodeOrigin // where the `deprecated` value node was read from (the file, line and column).
+}
+
+// DeprecationReport lists everything deprecated in a document, by kind.
+type DeprecationReport struct {
+	Operations []*Deprecation
+	Parameters []*Deprecation
+	Schemas    []*Deprecation
+	Properties []*Deprecation
+}
+
+// Total returns the number of deprecations in the report.
+func (r *DeprecationReport) Total() int {
+	return len(r.Operations) + len(r.Parameters) + len(r.Schemas) + len(r.Properties)
+}
+
+type deprecationWalker struct {
+	report  *DeprecationReport
+	rolodex *index.Rolodex
+	seen    map[*yaml.Node]struct{}
+	schemas map[*yaml.Node]struct{}
+}
+
+func (d *document) DeprecationReport() (*DeprecationReport, error) {
+	if d.highOpenAPI3Model == nil {
+		return nil, errors.New("unable to create a deprecation report, the OpenAPI 3 model has not been built")
+	}
+	doc := &d.highOpenAPI3Model.Model
+	w := &deprecationWalker{
+		report:  new(DeprecationReport),
+		rolodex: d.rolodex,
+		seen:    make(map[*yaml.Node]struct{}),
+		schemas: make(map[*yaml.Node]struct{}),
+	}
+
+	// component schemas and parameters are walked first, so they're reported where they're defined, not where
+	// they're first referenced.
+	if c := doc.Components; c != nil {
+		for name, s := range c.Schemas.FromOldest() {
+			w.schema(s, name, "", fmt.Sprintf("$.components.schemas['%s']", name))
+		}
+		for name, p := range c.Parameters.FromOldest() {
+			w.parameter(p, fmt.Sprintf("$.components.parameters['%s']", name))
+		}
+	}
+	if doc.Paths != nil {
+		for path, pi := range doc.Paths.PathItems.FromOldest() {
+			w.pathItem(pi, path, fmt.Sprintf("$.paths['%s']", path))
+		}
+	}
+	for name, pi := range doc.Webhooks.FromOldest() {
+		w.pathItem(pi, name, fmt.Sprintf("$.webhooks['%s']", name))
+	}
+	if c := doc.Components; c != nil {
+		for name, r := range c.Responses.FromOldest() {
+			w.response(r, fmt.Sprintf("$.components.responses['%s']", name))
+		}
+		for name, rb := range c.RequestBodies.FromOldest() {
+			if rb != nil {
+				w.content(rb.Content, fmt.Sprintf("$.components.requestBodies['%s']", name))
+			}
+		}
+		for name, cb := range c.Callbacks.FromOldest() {
+			w.callback(cb, fmt.Sprintf("$.components.callbacks['%s']", name))
+		}
+		for name, pi := range c.PathItems.FromOldest() {
+			w.pathItem(pi, name, fmt.Sprintf("$.components.pathItems['%s']", name))
+		}
+	}
+	return w.report, nil
+}
+
+// add creates a deprecation, unless the object isn't deprecated or has already been reported.
+func (w *deprecationWalker) add(list *[]*Deprecation, node *yaml.Node, deprecated bool, name, jsonPath string,
+	extensions *orderedmap.Map[string, *yaml.Node],
+) {
+	if !deprecated || node == nil {
+		return
+	}
+	if _, ok := w.seen[node]; ok {
+		return
+	}
+	w.seen[node] = struct{}{}
+	dep := &Deprecation{Name: name, JSONPath: jsonPath, Node: node}
+	for _, ext := range SunsetExtensions {
+		if extensions == nil {
+			break
+		}
+		if v := extensions.GetOrZero(ext); v != nil && v.Value != "" {
+			dep.Sunset = v.Value
+			for _, layout := range sunsetLayouts {
+				if t, err := time.Parse(layout, v.Value); err == nil {
+					dep.SunsetDate = t
+					break
+				}
+			}
+			break
+		}
+	}
+	if w.rolodex != nil {
+		dep.Origin = w.rolodex.FindNodeOrigin(node)
+	}
+	*list = append(*list, dep)
+}
+
+func (w *deprecationWalker) pathItem(pi *v3high.PathItem, path, jsonPath string) {
+	if pi == nil {
+		return
+	}
+	for i, p := range pi.Parameters {
+		w.parameter(p, fmt.Sprintf("%s.parameters[%d]", jsonPath, i))
+	}
+	for method, op := range pi.GetOperations().FromOldest() {
+		w.operation(op, fmt.Sprintf("%s %s", strings.ToUpper(method), path), fmt.Sprintf("%s.%s", jsonPath, method))
+	}
+}
+
+func (w *deprecationWalker) operation(op *v3high.Operation, name, jsonPath string) {
+	if op == nil {
+		return
+	}
+	if l := op.GoLow(); l != nil {
+		w.add(&w.report.Operations, l.Deprecated.ValueNode, op.Deprecated != nil && *op.Deprecated, name, jsonPath,
+			op.Extensions)
+	}
+	for i, p := range op.Parameters {
+		w.parameter(p, fmt.Sprintf("%s.parameters[%d]", jsonPath, i))
+	}
+	if op.RequestBody != nil {
+		w.content(op.RequestBody.Content, jsonPath+".requestBody")
+	}
+	if op.Responses != nil {
+		for code, r := range op.Responses.Codes.FromOldest() {
+			w.response(r, fmt.Sprintf("%s.responses['%s']", jsonPath, code))
+		}
+		w.response(op.Responses.Default, jsonPath+".responses.default")
+	}
+	for name, cb := range op.Callbacks.FromOldest() {
+		w.callback(cb, fmt.Sprintf("%s.callbacks['%s']", jsonPath, name))
+	}
+}
+
+func (w *deprecationWalker) callback(cb *v3high.Callback, jsonPath string) {
+	if cb == nil {
+		return
+	}
+	for expression, pi := range cb.Expression.FromOldest() {
+		w.pathItem(pi, expression, fmt.Sprintf("%s['%s']", jsonPath, expression))
+	}
+}
+
+func (w *deprecationWalker) parameter(p *v3high.Parameter, jsonPath string) {
+	if p == nil {
+		return
+	}
+	if l := p.GoLow(); l != nil {
+		w.add(&w.report.Parameters, l.Deprecated.ValueNode, p.Deprecated, p.Name, jsonPath, p.Extensions)
+	}
+	w.schema(p.Schema, "", "", jsonPath+".schema")
+	w.content(p.Content, jsonPath)
+}
+
+func (w *deprecationWalker) response(r *v3high.Response, jsonPath string) {
+	if r == nil {
+		return
+	}
+	w.content(r.Content, jsonPath)
+	for name, h := range r.Headers.FromOldest() {
+		if h != nil {
+			w.schema(h.Schema, "", "", fmt.Sprintf("%s.headers['%s'].schema", jsonPath, name))
+		}
+	}
+}
+
+func (w *deprecationWalker) content(content *orderedmap.Map[string, *v3high.MediaType], jsonPath string) {
+	for mediaType, mt := range content.FromOldest() {
+		if mt != nil {
+			w.schema(mt.Schema, "", "", fmt.Sprintf("%s.content['%s'].schema", jsonPath, mediaType))
+		}
+	}
+}
+
+// schema reports a deprecated schema (or property, when property is set), then walks the schemas it's made of.
+// Each schema is walked once, however many times it's referenced.
+func (w *deprecationWalker) schema(sp *base.SchemaProxy, name, property, jsonPath string) {
+	if sp == nil {
+		return
+	}
+	// local references are components, walked (and reported) where they're defined.
+	ref := sp.GetReference()
+	if sp.IsReference() && strings.HasPrefix(ref, "#/") {
+		return
+	}
+	s := sp.Schema()
+	if s == nil || s.GoLow() == nil {
+		return
+	}
+	l := s.GoLow()
+	deprecated := s.Deprecated != nil && *s.Deprecated
+	if sp.IsReference() {
+		// a schema of another file is named after its reference.
+		w.add(&w.report.Schemas, l.Deprecated.ValueNode, deprecated, ref[strings.LastIndex(ref, "/")+1:], jsonPath,
+			s.Extensions)
+	} else if property != "" {
+		w.add(&w.report.Properties, l.Deprecated.ValueNode, deprecated, property, jsonPath, s.Extensions)
+	} else {
+		w.add(&w.report.Schemas, l.Deprecated.ValueNode, deprecated, name, jsonPath, s.Extensions)
+	}
+	if l.RootNode != nil {
+		if _, ok := w.schemas[l.RootNode]; ok {
+			return
+		}
+		w.schemas[l.RootNode] = struct{}{}
+	}
+
+	for k, p := range s.Properties.FromOldest() {
+		w.schema(p, "", k, fmt.Sprintf("%s.properties['%s']", jsonPath, k))
+	}
+	for k, p := range s.PatternProperties.FromOldest() {
+		w.schema(p, "", "", fmt.Sprintf("%s.patternProperties['%s']", jsonPath, k))
+	}
+	for _, c := range []struct {
+		label   string
+		schemas []*base.SchemaProxy
+	}{{"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}, {"prefixItems", s.PrefixItems}} {
+		for i, p := range c.schemas {
+			w.schema(p, "", "", fmt.Sprintf("%s.%s[%d]", jsonPath, c.label, i))
+		}
+	}
+	w.schema(s.Not, "", "", jsonPath+".not")
+	for _, c := range []struct {
+		label string
+		value *base.DynamicValue[*base.SchemaProxy, bool]
+	}{
+		{"items", s.Items}, {"additionalProperties", s.AdditionalProperties},
+		{"unevaluatedItems", s.UnevaluatedItems}, {"unevaluatedProperties", s.UnevaluatedProperties},
+	} {
+		if c.value != nil && c.value.IsA() {
+			w.schema(c.value.A, "", "", fmt.Sprintf("%s.%s", jsonPath, c.label))
+		}
+	}
+}
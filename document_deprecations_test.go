@@ -0,0 +1,134 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var deprecationSpec = `openapi: 3.1.0
+info:
+  title: Deprecations
+  version: 1.0.0
+paths:
+  /pets:
+    parameters:
+      - name: legacy
+        in: header
+        deprecated: true
+    get:
+      deprecated: true
+      x-sunset: "2025-06-30"
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+        - name: page
+          in: query
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      deprecated: false
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                tag:
+                  type: string
+                  deprecated: true
+                  x-sunset: Sat, 01 Nov 2025 00:00:00 GMT
+      responses:
+        "201":
+          description: created
+      callbacks:
+        created:
+          '{$request.body#/url}':
+            post:
+              deprecated: true
+              responses:
+                "200":
+                  description: ok
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      deprecated: true
+      x-sunset-date: soon
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        nickname:
+          type: string
+          deprecated: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      deprecated: true
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'
+`
+
+func TestDocument_DeprecationReport(t *testing.T) {
+	doc, err := NewDocument([]byte(deprecationSpec))
+	require.NoError(t, err)
+
+	_, err = doc.DeprecationReport()
+	assert.EqualError(t, err, "unable to create a deprecation report, the OpenAPI 3 model has not been built")
+
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	report, err := doc.DeprecationReport()
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Total())
+
+	require.Len(t, report.Operations, 2)
+	get := report.Operations[0]
+	assert.Equal(t, "GET /pets", get.Name)
+	assert.Equal(t, "$.paths['/pets'].get", get.JSONPath)
+	assert.Equal(t, "2025-06-30", get.Sunset)
+	assert.Equal(t, time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), get.SunsetDate)
+	require.NotNil(t, get.Origin)
+	assert.Equal(t, 12, get.Origin.Line)
+	assert.Equal(t, "POST {$request.body#/url}", report.Operations[1].Name)
+	assert.Equal(t, "$.paths['/pets'].post.callbacks['created']['{$request.body#/url}'].post",
+		report.Operations[1].JSONPath)
+
+	require.Len(t, report.Parameters, 2)
+	assert.Equal(t, "limit", report.Parameters[0].Name)
+	assert.Equal(t, "$.components.parameters['Limit']", report.Parameters[0].JSONPath)
+	assert.Equal(t, "soon", report.Parameters[0].Sunset)
+	assert.True(t, report.Parameters[0].SunsetDate.IsZero())
+	assert.Equal(t, "legacy", report.Parameters[1].Name)
+	assert.Equal(t, "$.paths['/pets'].parameters[0]", report.Parameters[1].JSONPath)
+
+	require.Len(t, report.Schemas, 1)
+	assert.Equal(t, "Owner", report.Schemas[0].Name)
+	assert.Equal(t, "$.components.schemas['Owner']", report.Schemas[0].JSONPath)
+	assert.Equal(t, 70, report.Schemas[0].Node.Line)
+
+	require.Len(t, report.Properties, 2)
+	assert.Equal(t, "nickname", report.Properties[0].Name)
+	assert.Equal(t, "$.components.schemas['Pet'].properties['nickname']", report.Properties[0].JSONPath)
+	assert.Equal(t, "tag", report.Properties[1].Name)
+	assert.Equal(t, "$.paths['/pets'].post.requestBody.content['application/json'].schema.properties['tag']",
+		report.Properties[1].JSONPath)
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), report.Properties[1].SunsetDate)
+}
@@ -18,7 +18,7 @@ type loopFrame struct {
 	Restricted bool
 }
 
-type context struct {
+type iterationContext struct {
 	visited []string
 	stack   []loopFrame
 }
@@ -53,7 +53,7 @@ func Test_Speakeasy_Document_Iteration(t *testing.T) {
 	for name, schemaProxy := range m.Model.Components.Schemas.FromOldest() {
 		t.Log(name)
 
-		handleSchema(t, schemaProxy, context{})
+		handleSchema(t, schemaProxy, iterationContext{})
 	}
 }
 
@@ -65,7 +65,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 			t.Log("param", i, param.Name)
 
 			if param.Schema != nil {
-				handleSchema(t, param.Schema, context{})
+				handleSchema(t, param.Schema, iterationContext{})
 			}
 		}
 
@@ -76,7 +76,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 				t.Log(contentType)
 
 				if mediaType.Schema != nil {
-					handleSchema(t, mediaType.Schema, context{})
+					handleSchema(t, mediaType.Schema, iterationContext{})
 				}
 			}
 		}
@@ -92,7 +92,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 				t.Log(contentType)
 
 				if mediaType.Schema != nil {
-					handleSchema(t, mediaType.Schema, context{})
+					handleSchema(t, mediaType.Schema, iterationContext{})
 				}
 			}
 		}
@@ -113,7 +113,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 	}
 }
 
-func handleSchema(t *testing.T, schProxy *base.SchemaProxy, ctx context) {
+func handleSchema(t *testing.T, schProxy *base.SchemaProxy, ctx iterationContext) {
 	if checkCircularReference(t, &ctx, schProxy) {
 		return
 	}
@@ -206,7 +206,7 @@ func getResolvedType(sch *base.Schema) (string, []string) {
 	return "oneOf", subTypes
 }
 
-func handleAllOfAnyOfOneOf(t *testing.T, sch *base.Schema, ctx context) {
+func handleAllOfAnyOfOneOf(t *testing.T, sch *base.Schema, ctx iterationContext) {
 	var schemas []*base.SchemaProxy
 
 	switch {
@@ -225,7 +225,7 @@ func handleAllOfAnyOfOneOf(t *testing.T, sch *base.Schema, ctx context) {
 	}
 }
 
-func handleArray(t *testing.T, sch *base.Schema, ctx context) {
+func handleArray(t *testing.T, sch *base.Schema, ctx iterationContext) {
 	ctx.stack = append(ctx.stack, loopFrame{Type: "array", Restricted: sch.MinItems != nil && *sch.MinItems > 0})
 
 	if sch.Items != nil && sch.Items.IsA() {
@@ -243,7 +243,7 @@ func handleArray(t *testing.T, sch *base.Schema, ctx context) {
 	}
 }
 
-func handleObject(t *testing.T, sch *base.Schema, ctx context) {
+func handleObject(t *testing.T, sch *base.Schema, ctx iterationContext) {
 	for name, schemaProxy := range sch.Properties.FromOldest() {
 		ctx.stack = append(ctx.stack, loopFrame{Type: "object", Restricted: slices.Contains(sch.Required, name)})
 		handleSchema(t, schemaProxy, ctx)
@@ -254,7 +254,7 @@ func handleObject(t *testing.T, sch *base.Schema, ctx context) {
 	}
 }
 
-func checkCircularReference(t *testing.T, ctx *context, schProxy *base.SchemaProxy) bool {
+func checkCircularReference(t *testing.T, ctx *iterationContext, schProxy *base.SchemaProxy) bool {
 	loopRef := getSimplifiedRef(schProxy.GetReference())
 
 	if loopRef != "" {
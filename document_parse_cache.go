@@ -0,0 +1,173 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// parseCacheMagic starts every parse cache written by MarshalBinary, parseCacheVersion changes with the format of
+// the encoding.
+const (
+	parseCacheMagic   = "LIBOPENAPI-PARSED"
+	parseCacheVersion = 2
+)
+
+var errParseCacheDamaged = errors.New("the data is damaged")
+
+// NewDocumentFromParseCache creates a new Document from the parse cache written by MarshalBinary, configured with
+// configuration (which can be nil). The specification is not parsed again, so a tool repeatedly working on the
+// same unchanged specification can keep the cache on disk, and skip the parse for every run. When the cache was
+// written by a built document, the first BuildV3Model or BuildV2Model creates the index of the specification from
+// the cache, rather than extracting its references again. The model itself is always built.
+func NewDocumentFromParseCache(data []byte, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	d := new(document)
+	if err := d.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	d.SetConfiguration(configuration)
+	return d, nil
+}
+
+func (d *document) MarshalBinary() ([]byte, error) {
+	if d.info == nil || d.info.RootNode == nil {
+		return nil, errors.New("unable to marshal document, no specification has been loaded")
+	}
+	info := d.info
+	var spec, specJSON []byte
+	if info.SpecBytes != nil {
+		spec = *info.SpecBytes
+	}
+	if info.SpecJSONBytes != nil {
+		specJSON = *info.SpecJSONBytes
+	}
+	fetched := time.Now()
+	if d.provenance != nil {
+		fetched = d.provenance.FetchedAt
+	}
+
+	// a built document caches the references of the specification along with its nodes, extracted with the
+	// configuration used to index it.
+	var nodes []byte
+	if d.rolodex != nil && d.rolodex.GetRootIndex() != nil {
+		nodes = index.EncodeSpecIndexCache(spec, info.RootNode, d.rolodex.GetConfig())
+	} else {
+		nodes = index.EncodeNodes(info.RootNode)
+	}
+
+	data := binary.AppendUvarint([]byte(parseCacheMagic), parseCacheVersion)
+	for _, b := range [][]byte{
+		spec, []byte(info.SpecType), []byte(info.Version), []byte(info.SpecFormat), []byte(info.SpecFileType),
+		specJSON, nodes,
+	} {
+		data = binary.AppendUvarint(data, uint64(len(b)))
+		data = append(data, b...)
+	}
+	data = binary.AppendUvarint(data, uint64(info.NumLines))
+	data = binary.AppendUvarint(data, uint64(info.OriginalIndentation))
+	data = binary.AppendUvarint(data, uint64(math.Float32bits(info.VersionNumeric)))
+	return binary.AppendVarint(data, fetched.UnixNano()), nil
+}
+
+func (d *document) UnmarshalBinary(data []byte) error {
+	if len(data) < len(parseCacheMagic) || string(data[:len(parseCacheMagic)]) != parseCacheMagic {
+		return errors.New("unable to unmarshal document, the data was not written by MarshalBinary")
+	}
+	data = data[len(parseCacheMagic):]
+	next := func() (uint64, bool) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, false
+		}
+		data = data[n:]
+		return v, true
+	}
+	if v, ok := next(); !ok || v != parseCacheVersion {
+		return errors.New("unable to unmarshal document, the data was written by a different version of libopenapi")
+	}
+
+	var fields [7][]byte
+	for i := range fields {
+		n, ok := next()
+		if !ok || n > uint64(len(data)) {
+			return fmt.Errorf("unable to unmarshal document: %w", errParseCacheDamaged)
+		}
+		fields[i], data = data[:n:n], data[n:]
+	}
+	var numbers [3]uint64
+	for i := range numbers {
+		v, ok := next()
+		if !ok {
+			return fmt.Errorf("unable to unmarshal document: %w", errParseCacheDamaged)
+		}
+		numbers[i] = v
+	}
+	fetched, n := binary.Varint(data)
+	if n <= 0 || n != len(data) {
+		return fmt.Errorf("unable to unmarshal document: %w", errParseCacheDamaged)
+	}
+	var root *yaml.Node
+	cache, err := index.DecodeSpecIndexCache(fields[6])
+	if err == nil {
+		root = cache.GetRootNode()
+	} else if root, err = index.DecodeNodes(fields[6]); err != nil {
+		return fmt.Errorf("unable to unmarshal document: %w", err)
+	}
+
+	// the data is not retained, as BinaryUnmarshaler requires.
+	spec := bytes.Clone(fields[0])
+	info := &datamodel.SpecInfo{
+		SpecBytes:           &spec,
+		SpecType:            string(fields[1]),
+		Version:             string(fields[2]),
+		SpecFormat:          string(fields[3]),
+		SpecFileType:        string(fields[4]),
+		RootNode:            root,
+		NumLines:            int(numbers[0]),
+		OriginalIndentation: int(numbers[1]),
+		VersionNumeric:      math.Float32frombits(uint32(numbers[2])),
+	}
+	switch info.SpecFormat {
+	case datamodel.OAS2:
+		info.APISchema = datamodel.OpenAPI2SchemaData
+	case datamodel.OAS3:
+		info.APISchema = datamodel.OpenAPI3SchemaData
+	case datamodel.OAS31, datamodel.OAS32:
+		info.APISchema = datamodel.OpenAPI31SchemaData
+	}
+	if len(fields[5]) > 0 {
+		specJSON := bytes.Clone(fields[5])
+		// decoded the way ExtractSpecInfo decodes it, so YAML integers are still integers.
+		var jsonSpec map[string]interface{}
+		if utils.IsYAML(string(spec)) {
+			_ = root.Decode(&jsonSpec)
+		} else {
+			_ = json.Unmarshal(specJSON, &jsonSpec)
+		}
+		info.SpecJSONBytes = &specJSON
+		info.SpecJSON = &jsonSpec
+	}
+
+	// anything built from whatever the document held before is gone, models are built from the decoded nodes.
+	d.rolodex = nil
+	d.indexCache = cache
+	d.highOpenAPI3Model = nil
+	d.highSwaggerModel = nil
+	d.info = info
+	d.version = info.Version
+	d.provenance = datamodel.NewProvenance(spec, d.config)
+	d.provenance.FetchedAt = time.Unix(0, fetched).UTC()
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_MarshalBinary(t *testing.T) {
+	spec, err := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	require.NoError(t, err)
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+	original, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	data, err := doc.MarshalBinary()
+	require.NoError(t, err)
+
+	config := datamodel.NewDocumentConfiguration()
+	restored, err := NewDocumentFromParseCache(data, config)
+	require.NoError(t, err)
+	assert.Same(t, config, restored.GetConfiguration())
+	assert.Equal(t, doc.GetVersion(), restored.GetVersion())
+	assert.Equal(t, doc.GetProvenance().SHA256, restored.GetProvenance().SHA256)
+	assert.Equal(t, doc.GetProvenance().FetchedAt, restored.GetProvenance().FetchedAt)
+
+	info, restoredInfo := doc.GetSpecInfo(), restored.GetSpecInfo()
+	assert.Equal(t, *info.SpecBytes, *restoredInfo.SpecBytes)
+	assert.Equal(t, *info.SpecJSON, *restoredInfo.SpecJSON)
+	assert.Equal(t, info.SpecType, restoredInfo.SpecType)
+	assert.Equal(t, info.SpecFormat, restoredInfo.SpecFormat)
+	assert.Equal(t, info.SpecFileType, restoredInfo.SpecFileType)
+	assert.Equal(t, info.VersionNumeric, restoredInfo.VersionNumeric)
+	assert.Equal(t, info.NumLines, restoredInfo.NumLines)
+	assert.Equal(t, info.OriginalIndentation, restoredInfo.OriginalIndentation)
+	assert.Equal(t, info.APISchema, restoredInfo.APISchema)
+
+	// the document was built, so the references of its index are cached with the nodes.
+	assert.NotNil(t, restored.(*document).indexCache)
+
+	// the model is built from the decoded nodes, located exactly where the original is.
+	m, errs := restored.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, len(original.Index.GetMappedReferences()), len(m.Index.GetMappedReferences()))
+	assert.Equal(t, original.Model.Paths.PathItems.Len(), m.Model.Paths.PathItems.Len())
+	assert.Equal(t, original.Model.Info.GoLow().Title.ValueNode.Line, m.Model.Info.GoLow().Title.ValueNode.Line)
+	assert.Equal(t, len(original.Index.GetAllReferences()), len(m.Index.GetAllReferences()))
+
+	rendered, err := restored.Render()
+	require.NoError(t, err)
+	expected, err := doc.Render()
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(rendered))
+}
+
+func TestDocument_MarshalBinary_Swagger(t *testing.T) {
+	spec, err := os.ReadFile("test_specs/petstorev2.json")
+	require.NoError(t, err)
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+	data, err := doc.MarshalBinary()
+	require.NoError(t, err)
+
+	restored, err := NewDocumentFromParseCache(data, nil)
+	require.NoError(t, err)
+	assert.Nil(t, restored.(*document).indexCache)
+	assert.Equal(t, datamodel.JSONFileType, restored.GetSpecInfo().SpecFileType)
+	m, errs := restored.BuildV2Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "Swagger Petstore", m.Model.Info.Title)
+
+	serialized, err := restored.Serialize()
+	require.NoError(t, err)
+	expected, err := doc.Serialize()
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(serialized))
+}
+
+func TestDocument_UnmarshalBinary_Replaces(t *testing.T) {
+	doc, err := NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: first`))
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	other, err := NewDocument([]byte(`openapi: 3.0.3
+info:
+  title: second`))
+	require.NoError(t, err)
+	data, err := other.MarshalBinary()
+	require.NoError(t, err)
+
+	require.NoError(t, doc.UnmarshalBinary(data))
+	assert.Equal(t, "3.0.3", doc.GetVersion())
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "second", m.Model.Info.Title)
+}
+
+func TestDocument_UnmarshalBinary_Errors(t *testing.T) {
+	doc, err := NewDocument([]byte(`openapi: 3.1.0`))
+	require.NoError(t, err)
+	data, err := doc.MarshalBinary()
+	require.NoError(t, err)
+
+	_, err = NewDocumentFromParseCache([]byte("openapi: 3.1.0"), nil)
+	assert.EqualError(t, err, "unable to unmarshal document, the data was not written by MarshalBinary")
+
+	_, err = NewDocumentFromParseCache(append([]byte(parseCacheMagic), 99), nil)
+	assert.EqualError(t, err,
+		"unable to unmarshal document, the data was written by a different version of libopenapi")
+
+	for _, damaged := range [][]byte{data[:len(data)-1], append(data, 0), data[:len(parseCacheMagic)+4]} {
+		_, err = NewDocumentFromParseCache(damaged, nil)
+		assert.ErrorIs(t, err, errParseCacheDamaged)
+	}
+
+	var closed document
+	_, err = closed.MarshalBinary()
+	assert.EqualError(t, err, "unable to marshal document, no specification has been loaded")
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+func (d *document) ApplyJSONPatch(patch []byte) []error {
+	if d.info == nil || d.info.RootNode == nil {
+		return []error{errors.New("unable to apply JSON Patch, document has not yet been initialized")}
+	}
+
+	// the patch is tried on a copy of the document first, so a patch that fails, or that leaves something that
+	// isn't a specification, never changes the document.
+	current, err := yaml.Marshal(d.info.RootNode)
+	if err != nil {
+		return []error{fmt.Errorf("unable to apply JSON Patch, the document can't be rendered: %w", err)}
+	}
+	var trial yaml.Node
+	if err = yaml.Unmarshal(current, &trial); err != nil {
+		return []error{fmt.Errorf("unable to apply JSON Patch, the document can't be read: %w", err)}
+	}
+	if err = utils.ApplyJSONPatch(&trial, patch); err != nil {
+		return []error{err}
+	}
+	spec, err := d.renderRoot(&trial)
+	if err != nil {
+		return []error{fmt.Errorf("unable to render the patched document: %w", err)}
+	}
+	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(spec, d.config != nil && d.config.BypassDocumentCheck)
+	if err != nil {
+		return []error{fmt.Errorf("unable to read the patched document: %w", err)}
+	}
+
+	// the patch can't fail now, it has been applied to an identical tree.
+	_ = utils.ApplyJSONPatch(d.info.RootNode, patch)
+	info.RootNode = d.info.RootNode
+
+	rebuildV3, rebuildV2 := d.highOpenAPI3Model != nil, d.highSwaggerModel != nil
+	d.info = info
+	d.version = info.Version
+	d.provenance = datamodel.NewProvenance(spec, d.config)
+	d.highOpenAPI3Model, d.highSwaggerModel, d.rolodex = nil, nil, nil
+
+	var errs []error
+	switch {
+	case rebuildV3:
+		_, errs = d.BuildV3Model()
+	case rebuildV2:
+		_, errs = d.BuildV2Model()
+	}
+	return errs
+}
+
+// renderRoot renders a root node in the file type of the document.
+func (d *document) renderRoot(root *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(max(d.info.OriginalIndentation, 2))
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	if d.info.SpecFileType == datamodel.JSONFileType {
+		return utils.ConvertYAMLtoJSON(buf.Bytes())
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var patchSpec = `openapi: 3.1.0
+info:
+  title: Patches # keep me
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+`
+
+func TestDocument_ApplyJSONPatch(t *testing.T) {
+	doc, err := NewDocument([]byte(patchSpec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	title := m.Model.GoLow().Info.Value.Title.ValueNode
+	hash := doc.GetProvenance().SHA256
+
+	errs = doc.ApplyJSONPatch([]byte(`[
+		{"op": "replace", "path": "/info/version", "value": "2.0.0"},
+		{"op": "add", "path": "/components/schemas/Toy", "value": {"type": "string"}},
+		{"op": "add", "path": "/paths/~1pets/get/responses/200/content", "value": {
+			"application/json": {"schema": {"$ref": "#/components/schemas/Toy"}}}}
+	]`))
+	require.Empty(t, errs)
+
+	m, errs = doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "2.0.0", m.Model.Info.Version)
+	assert.Same(t, title, m.Model.GoLow().Info.Value.Title.ValueNode)
+	schema := m.Model.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200").
+		Content.GetOrZero("application/json").Schema.Schema()
+	assert.Equal(t, []string{"string"}, schema.Type)
+	assert.NotNil(t, m.Index.FindComponent("#/components/schemas/Toy"))
+	assert.NotEqual(t, hash, doc.GetProvenance().SHA256)
+
+	out, err := doc.Serialize()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "title: Patches # keep me\n")
+	assert.Contains(t, string(out), "version: 2.0.0\n")
+}
+
+func TestDocument_ApplyJSONPatch_BuildErrors(t *testing.T) {
+	doc, err := NewDocument([]byte(patchSpec))
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	errs = doc.ApplyJSONPatch([]byte(`[{"op": "add", "path": "/components/schemas/Pet/properties",
+		"value": {"toy": {"$ref": "#/components/schemas/Toy"}}}]`))
+	require.NotEmpty(t, errs)
+	assert.ErrorContains(t, errs[0], "#/components/schemas/Toy")
+
+	// the added reference is located at the schema it's added to.
+	var idxErr *index.IndexingError
+	require.ErrorAs(t, errs[0], &idxErr)
+	assert.Equal(t, 14, idxErr.Node.Line)
+}
+
+func TestDocument_ApplyJSONPatch_Unchanged(t *testing.T) {
+	doc, err := NewDocument([]byte(patchSpec))
+	require.NoError(t, err)
+
+	errs := doc.ApplyJSONPatch([]byte(`[{"op": "remove", "path": "/info/title"}, {"op": "remove", "path": "/nope"}]`))
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "unable to apply JSON Patch operation 1 (`remove` at `/nope`): `/nope` does not exist")
+
+	errs = doc.ApplyJSONPatch([]byte(`[{"op": "remove", "path": "/openapi"}]`))
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "unable to read the patched document")
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "Patches", m.Model.Info.Title)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+
+	assert.EqualError(t, new(document).ApplyJSONPatch(nil)[0],
+		"unable to apply JSON Patch, document has not yet been initialized")
+}
+
+func TestDocument_ApplyJSONPatch_JSON(t *testing.T) {
+	doc, err := NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "json", "version": "1"}}`))
+	require.NoError(t, err)
+	require.Empty(t, doc.ApplyJSONPatch([]byte(`[{"op": "add", "path": "/paths", "value": {}}]`)))
+	out, err := doc.Serialize()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"openapi": "3.1.0", "info": {"title": "json", "version": "1"}, "paths": {}}`, string(out))
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// swaggerComponentTypes are the Swagger definitions holding each type of component, by the type of component
+// of OpenAPI 3 they are.
+var swaggerComponentTypes = map[string]string{
+	"schemas":         "definitions",
+	"parameters":      "parameters",
+	"responses":       "responses",
+	"securitySchemes": "securityDefinitions",
+}
+
+// ComponentRename is what RenameComponent changed: every file holding the component, or a reference to it.
+type ComponentRename struct {
+	// Pointer and NewPointer are the JSON Pointers of the component before and after the rename, such as
+	// /components/schemas/User and /components/schemas/Customer.
+	Pointer    string
+	NewPointer string
+
+	// Files are the files changed, the root document first, then the other files of the rolodex by location.
+	Files []*RenamedFile
+}
+
+// RenamedFile is a file changed by RenameComponent, to be written back by the caller.
+type RenamedFile struct {
+	// Location is the absolute path (or URL) of the file.
+	Location string
+
+	// Root is true for the root document.
+	Root bool
+
+	// Node is the root node of the file, changed in place.
+	Node *yaml.Node
+
+	// Nodes are the nodes changed: the key of the component renamed, and the value of every `$ref` rewritten.
+	Nodes []*yaml.Node
+
+	render func() ([]byte, error)
+}
+
+// Render renders the file as it was changed (as JSON if the file is JSON), ready to be written back.
+func (f *RenamedFile) Render() ([]byte, error) {
+	return f.render()
+}
+
+func (d *document) RenameComponent(componentType, name, newName string) (*ComponentRename, error) {
+	if d.rolodex == nil || d.rolodex.GetRootIndex() == nil || d.info == nil || d.info.RootNode == nil ||
+		len(d.info.RootNode.Content) == 0 {
+		return nil, errors.New("unable to rename component, the model has not been built")
+	}
+	if name == "" || newName == "" || name == newName {
+		return nil, fmt.Errorf("unable to rename component '%s' to '%s', the names must differ, and not be empty",
+			name, newName)
+	}
+	section := []string{"components", componentType}
+	if d.info.SpecFormat == datamodel.OAS2 {
+		definitions, ok := swaggerComponentTypes[componentType]
+		if !ok && !slices.Contains([]string{"definitions", "securityDefinitions"}, componentType) {
+			return nil, fmt.Errorf("unable to rename component, '%s' is not a type of Swagger component", componentType)
+		}
+		if !ok {
+			definitions = componentType
+		}
+		section = []string{definitions}
+	}
+	components := utils.NodeAlias(findChild(d.info.RootNode.Content[0], section))
+	if components == nil || !utils.IsNodeMap(components) {
+		return nil, fmt.Errorf("unable to rename component '%s', the document has no %s", name,
+			strings.Join(section, "."))
+	}
+	var key *yaml.Node
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		switch components.Content[i].Value {
+		case name:
+			key = components.Content[i]
+		case newName:
+			return nil, fmt.Errorf("unable to rename component '%s', '%s' already exists", name, newName)
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unable to rename component, '%s' does not exist in %s", name,
+			strings.Join(section, "."))
+	}
+
+	rename := &ComponentRename{
+		Pointer:    utils.BuildJSONPointer(append(slices.Clone(section), name)),
+		NewPointer: utils.BuildJSONPointer(append(slices.Clone(section), newName)),
+	}
+	rootIndex := d.rolodex.GetRootIndex()
+	target := rootIndex.GetSpecAbsolutePath() + "#" + rename.Pointer
+
+	// every file of the rolodex referencing the component, the root document first. A file referencing the root
+	// document has it indexed again, as a file of the rolodex, that copy is not the document and isn't changed.
+	indexes := []*index.SpecIndex{rootIndex}
+	var others []*index.SpecIndex
+	for _, idx := range d.rolodex.GetIndexes() {
+		if idx != nil && idx != rootIndex && idx.GetSpecAbsolutePath() != rootIndex.GetSpecAbsolutePath() &&
+			!slices.Contains(others, idx) {
+			others = append(others, idx)
+		}
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		return others[i].GetSpecAbsolutePath() < others[j].GetSpecAbsolutePath()
+	})
+	indexes = append(indexes, others...)
+
+	key.Value = newName
+	for i, idx := range indexes {
+		file := &RenamedFile{Location: idx.GetSpecAbsolutePath(), Root: i == 0, Node: idx.GetRootNode()}
+		if file.Root {
+			file.Node = d.info.RootNode
+			file.Nodes = append(file.Nodes, key)
+			file.render = func() ([]byte, error) { return d.renderRoot(d.info.RootNode) }
+		} else {
+			file.render = renderFile(file)
+		}
+		seen := make(map[*yaml.Node]struct{})
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			if ref.FullDefinition != target && !strings.HasPrefix(ref.FullDefinition, target+"/") {
+				continue
+			}
+			_, fragment, _ := strings.Cut(ref.FullDefinition, "#")
+			value := refValueNode(ref.Node)
+			if value == nil {
+				continue
+			}
+			if _, ok := seen[value]; ok {
+				continue
+			}
+			seen[value] = struct{}{}
+			// the location is written as it was, only the fragment is rewritten.
+			written, _, _ := strings.Cut(value.Value, "#")
+			value.Value = written + "#" + rename.NewPointer + strings.TrimPrefix(fragment, rename.Pointer)
+			file.Nodes = append(file.Nodes, value)
+		}
+		if len(file.Nodes) > 0 {
+			rename.Files = append(rename.Files, file)
+		}
+	}
+	return rename, nil
+}
+
+// findChild returns the node at the path of keys below a mapping, or nil.
+func findChild(node *yaml.Node, keys []string) *yaml.Node {
+	for _, k := range keys {
+		node = utils.NodeAlias(node)
+		if node == nil || !utils.IsNodeMap(node) {
+			return nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == k {
+				next = node.Content[i+1]
+				break
+			}
+		}
+		node = next
+	}
+	return node
+}
+
+// refValueNode returns the value of the `$ref` of the object holding it.
+func refValueNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// renderFile renders a file of the rolodex, as JSON if the file is JSON.
+func renderFile(file *RenamedFile) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(file.Node); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(filepath.Ext(file.Location), ".json") {
+			return utils.ConvertYAMLtoJSON(buf.Bytes())
+		}
+		return buf.Bytes(), nil
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_RenameComponent(t *testing.T) {
+	dir := t.TempDir()
+	root := `openapi: 3.1.0
+info:
+  title: rename
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: users
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: pets.yaml#/components/schemas/Pet
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name:
+          type: string
+        friend:
+          $ref: '#/components/schemas/User'
+    UserName:
+      $ref: '#/components/schemas/User/properties/name'
+    Username:
+      type: string
+`
+	// the pets have their own User, which isn't the one renamed.
+	pets := `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: openapi.yaml#/components/schemas/User
+        keeper:
+          $ref: '#/components/schemas/User'
+    User:
+      type: string
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(root), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.yaml"), []byte(pets), 0o644))
+
+	doc, err := NewDocumentWithConfiguration([]byte(root), &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		SpecFilePath:        "openapi.yaml",
+		AllowFileReferences: true,
+	})
+	require.NoError(t, err)
+
+	_, err = doc.RenameComponent("schemas", "User", "Customer")
+	assert.EqualError(t, err, "unable to rename component, the model has not been built")
+
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	rename, err := doc.RenameComponent("schemas", "User", "Customer")
+	require.NoError(t, err)
+	assert.Equal(t, "/components/schemas/User", rename.Pointer)
+	assert.Equal(t, "/components/schemas/Customer", rename.NewPointer)
+	require.Len(t, rename.Files, 2)
+
+	rootFile := rename.Files[0]
+	assert.True(t, rootFile.Root)
+	assert.Equal(t, filepath.Join(dir, "openapi.yaml"), rootFile.Location)
+	// the key, the two references to the component and the one into it.
+	assert.Len(t, rootFile.Nodes, 4)
+	rendered, err := rootFile.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), `    Customer:
+      type: object`)
+	assert.Contains(t, string(rendered), `$ref: '#/components/schemas/Customer/properties/name'`)
+	assert.NotContains(t, string(rendered), "schemas/User'")
+	assert.Contains(t, string(rendered), "    Username:")
+
+	petsFile := rename.Files[1]
+	assert.False(t, petsFile.Root)
+	assert.Equal(t, filepath.Join(dir, "pets.yaml"), petsFile.Location)
+	require.Len(t, petsFile.Nodes, 1)
+	assert.Equal(t, "openapi.yaml#/components/schemas/Customer", petsFile.Nodes[0].Value)
+	rendered, err = petsFile.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "$ref: '#/components/schemas/User'")
+
+	// once written back, the document loads again, referencing the renamed component.
+	for _, f := range rename.Files {
+		b, err := f.Render()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(f.Location, b, 0o644))
+	}
+	spec, err := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	require.NoError(t, err)
+	reloaded, err := NewDocumentWithConfiguration(spec, doc.GetConfiguration())
+	require.NoError(t, err)
+	m, errs := reloaded.BuildV3Model()
+	require.Empty(t, errs)
+	friend := m.Model.Components.Schemas.GetOrZero("Customer").Schema().Properties.GetOrZero("friend")
+	assert.Equal(t, "#/components/schemas/Customer", friend.GetReference())
+	assert.Equal(t, 2, friend.Schema().Properties.Len())
+	assert.Nil(t, m.Model.Components.Schemas.GetOrZero("User"))
+}
+
+func TestDocument_RenameComponent_Swagger(t *testing.T) {
+	doc, err := NewDocument([]byte(`{"swagger": "2.0",
+  "info": {"title": "rename", "version": "1.0.0"},
+  "paths": {"/pets": {"get": {"responses": {"200": {"$ref": "#/responses/Pets"}}}}},
+  "responses": {"Pets": {"description": "pets", "schema": {"$ref": "#/definitions/Pet"}}},
+  "definitions": {"Pet": {"type": "object"}}}`))
+	require.NoError(t, err)
+	_, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+
+	rename, err := doc.RenameComponent("schemas", "Pet", "Animal")
+	require.NoError(t, err)
+	assert.Equal(t, "/definitions/Animal", rename.NewPointer)
+	require.Len(t, rename.Files, 1)
+	assert.Len(t, rename.Files[0].Nodes, 2)
+
+	rename, err = doc.RenameComponent("responses", "Pets", "Animals")
+	require.NoError(t, err)
+	rendered, err := rename.Files[0].Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), `"$ref":"#/responses/Animals"`)
+	assert.Contains(t, string(rendered), `"$ref":"#/definitions/Animal"`)
+
+	_, err = doc.RenameComponent("definitions", "Animal", "Pet")
+	assert.NoError(t, err)
+	_, err = doc.RenameComponent("links", "Pet", "Animal")
+	assert.EqualError(t, err, "unable to rename component, 'links' is not a type of Swagger component")
+}
+
+func TestDocument_RenameComponent_Errors(t *testing.T) {
+	doc, err := NewDocument([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+    Animal:
+      type: object`))
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	_, err = doc.RenameComponent("schemas", "Pet", "Pet")
+	assert.EqualError(t, err, "unable to rename component 'Pet' to 'Pet', the names must differ, and not be empty")
+	_, err = doc.RenameComponent("schemas", "", "Pet")
+	assert.Error(t, err)
+	_, err = doc.RenameComponent("schemas", "Pet", "Animal")
+	assert.EqualError(t, err, "unable to rename component 'Pet', 'Animal' already exists")
+	_, err = doc.RenameComponent("schemas", "Dog", "Hound")
+	assert.EqualError(t, err, "unable to rename component, 'Dog' does not exist in components.schemas")
+	_, err = doc.RenameComponent("responses", "Pet", "Animal")
+	assert.EqualError(t, err, "unable to rename component 'Pet', the document has no components.responses")
+
+	// nothing references the component, only the root document changes.
+	rename, err := doc.RenameComponent("schemas", "Pet", "Dog")
+	require.NoError(t, err)
+	require.Len(t, rename.Files, 1)
+	assert.Len(t, rename.Files[0].Nodes, 1)
+}
@@ -0,0 +1,274 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// setComponentTypes are the components an entry file of a DocumentSet can contribute.
+var setComponentTypes = []string{
+	"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links",
+	"callbacks", "pathItems",
+}
+
+// setRootKeys are the root keys copied from the first entry file that defines them.
+var setRootKeys = []string{"info", "jsonSchemaDialect", "servers", "security", "externalDocs"}
+
+// DocumentSet is a single logical OpenAPI 3 description split across several entry files, each contributing
+// paths, webhooks and components, without ever being bundled into a single file.
+type DocumentSet struct {
+	// Document is the document of the whole set. Its root is generated: every path, webhook and component is a
+	// reference into the entry file that defines it, so each node of the model is read from (and indexed as part
+	// of) the entry file it's defined in, and relative references in entry files are followed from the entry file.
+	Document Document
+
+	// Entries are the absolute paths of the entry files, in the order they were supplied.
+	Entries []string
+
+	// BasePath is the directory holding every entry file. The references of the generated root are relative to it.
+	BasePath string
+
+	origins map[string]string
+}
+
+// NewDocumentSet creates a document from several entry files, which each contribute paths, webhooks and components
+// to one logical API description. Every entry file must be an OpenAPI 3 document of the same version. The root
+// keys of the document (info, servers, security...) are taken from the first entry file that defines them, tags are
+// combined (the first tag of each name is kept), and root extensions are equally taken from the first entry file
+// that defines them.
+//
+// A path, webhook or component defined by more than one entry file is an error, as is an entry file that can't be
+// read. The configuration is copied, with file references allowed and the BasePath set to the directory holding
+// every entry file (unless the configuration sets a BasePath).
+func NewDocumentSet(entries []string, configuration *datamodel.DocumentConfiguration) (*DocumentSet, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("unable to create a document set, no entry files were supplied")
+	}
+	var config datamodel.DocumentConfiguration
+	if configuration != nil {
+		config = *configuration
+	} else {
+		config = *datamodel.NewDocumentConfiguration()
+	}
+
+	set := &DocumentSet{origins: make(map[string]string)}
+	for _, entry := range entries {
+		abs, err := filepath.Abs(entry)
+		if err != nil {
+			return nil, fmt.Errorf("unable to locate entry file '%s': %w", entry, err)
+		}
+		if !slices.Contains(set.Entries, abs) {
+			set.Entries = append(set.Entries, abs)
+		}
+	}
+	set.BasePath = config.BasePath
+	if set.BasePath == "" {
+		set.BasePath = commonDirectory(set.Entries)
+	}
+
+	root, err := set.buildRoot()
+	if err != nil {
+		return nil, err
+	}
+	spec, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the root of the document set: %w", err)
+	}
+
+	config.BasePath = set.BasePath
+	config.AllowFileReferences = true
+	set.Document, err = NewDocumentWithConfiguration(spec, &config)
+	if err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// EntryFor returns the absolute path of the entry file contributing the element at a JSON Path of the document,
+// such as `$.paths['/pets']`, `$.webhooks['newPet']`, `$.components.schemas['Pet']`, `$.tags['pets']` or `$.info`.
+// An empty string is returned if no entry file contributes the element.
+func (s *DocumentSet) EntryFor(jsonPath string) string {
+	return s.origins[jsonPath]
+}
+
+// Origin returns where a node of the model was read from: the entry file (or a file it references) and the
+// position of the node in it. A model must be built before nodes can be located, nil is returned if the node
+// can't be found.
+func (s *DocumentSet) Origin(node *yaml.Node) *index.NodeOrigin {
+	if rolodex := s.Document.GetRolodex(); rolodex != nil {
+		return rolodex.FindNodeOrigin(node)
+	}
+	return nil
+}
+
+// buildRoot reads every entry file, and generates the root of the document set.
+func (s *DocumentSet) buildRoot() (*yaml.Node, error) {
+	root := utils.CreateEmptyMapNode()
+	paths := utils.CreateEmptyMapNode()
+	webhooks := utils.CreateEmptyMapNode()
+	tags := utils.CreateEmptySequenceNode()
+	components := make(map[string]*yaml.Node)
+	var version string
+	var errs []error
+
+	for _, entry := range s.Entries {
+		rel, err := filepath.Rel(s.BasePath, entry)
+		if err != nil {
+			return nil, fmt.Errorf("unable to locate entry file '%s' from '%s': %w", entry, s.BasePath, err)
+		}
+		rel = filepath.ToSlash(rel)
+		data, err := os.ReadFile(entry)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read entry file '%s': %w", entry, err)
+		}
+		var doc yaml.Node
+		if err = yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("unable to parse entry file '%s': %w", entry, err)
+		}
+		var top *yaml.Node
+		if len(doc.Content) > 0 {
+			top = doc.Content[0]
+		}
+		_, v := utils.FindKeyNodeTop("openapi", nodeContent(top))
+		if v == nil || !strings.HasPrefix(v.Value, "3") {
+			return nil, fmt.Errorf("entry file '%s' is not an OpenAPI 3 document", entry)
+		}
+		if version == "" {
+			version = v.Value
+		} else if v.Value != version {
+			return nil, fmt.Errorf("entry file '%s' is OpenAPI %s, but the document set is OpenAPI %s",
+				entry, v.Value, version)
+		}
+
+		for i := 0; i+1 < len(top.Content); i += 2 {
+			key, value := top.Content[i], top.Content[i+1]
+			switch {
+			case key.Value == "paths" || key.Value == "webhooks":
+				target := paths
+				if key.Value == "webhooks" {
+					target = webhooks
+				}
+				errs = append(errs, s.addRefs(target, value, fmt.Sprintf("$.%s", key.Value), rel, entry,
+					"#/"+key.Value+"/")...)
+			case key.Value == "components":
+				for _, componentType := range setComponentTypes {
+					_, defs := utils.FindKeyNodeTop(componentType, value.Content)
+					if defs == nil {
+						continue
+					}
+					if components[componentType] == nil {
+						components[componentType] = utils.CreateEmptyMapNode()
+					}
+					errs = append(errs, s.addRefs(components[componentType], defs,
+						fmt.Sprintf("$.components.%s", componentType), rel, entry,
+						"#/components/"+componentType+"/")...)
+				}
+			case key.Value == "tags":
+				for _, tag := range value.Content {
+					_, name := utils.FindKeyNodeTop("name", tag.Content)
+					if name == nil {
+						continue
+					}
+					jsonPath := fmt.Sprintf("$.tags['%s']", name.Value)
+					if _, ok := s.origins[jsonPath]; !ok {
+						s.origins[jsonPath] = entry
+						tags.Content = append(tags.Content, tag)
+					}
+				}
+			case slices.Contains(setRootKeys, key.Value) || strings.HasPrefix(key.Value, "x-"):
+				jsonPath := "$." + key.Value
+				if _, ok := s.origins[jsonPath]; !ok {
+					s.origins[jsonPath] = entry
+					root.Content = append(root.Content, key, value)
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	contents := []*yaml.Node{utils.CreateStringNode("openapi"), utils.CreateStringNode(version)}
+	contents = append(contents, root.Content...)
+	if len(tags.Content) > 0 {
+		contents = append(contents, utils.CreateStringNode("tags"), tags)
+	}
+	contents = append(contents, utils.CreateStringNode("paths"), paths)
+	if len(webhooks.Content) > 0 {
+		contents = append(contents, utils.CreateStringNode("webhooks"), webhooks)
+	}
+	componentsNode := utils.CreateEmptyMapNode()
+	for _, componentType := range setComponentTypes {
+		if components[componentType] != nil {
+			componentsNode.Content = append(componentsNode.Content, utils.CreateStringNode(componentType),
+				components[componentType])
+		}
+	}
+	if len(componentsNode.Content) > 0 {
+		contents = append(contents, utils.CreateStringNode("components"), componentsNode)
+	}
+	root.Content = contents
+	return root, nil
+}
+
+// addRefs adds a reference to every definition of a map (paths, webhooks or a type of component) of an entry file to
+// the same map of the generated root, returning an error for every definition another entry file already added.
+func (s *DocumentSet) addRefs(target, defs *yaml.Node, jsonPath, rel, entry, pointer string) []error {
+	if defs == nil || !utils.IsNodeMap(defs) {
+		return nil
+	}
+	var errs []error
+	for i := 0; i+1 < len(defs.Content); i += 2 {
+		name := defs.Content[i].Value
+		if strings.HasPrefix(name, "x-") {
+			continue
+		}
+		p := fmt.Sprintf("%s['%s']", jsonPath, name)
+		if existing, ok := s.origins[p]; ok {
+			errs = append(errs, fmt.Errorf("`%s` is defined by entry files '%s' and '%s'", p, existing, entry))
+			continue
+		}
+		s.origins[p] = entry
+		target.Content = append(target.Content, utils.CreateStringNode(name),
+			utils.CreateRefNode(rel+pointer+utils.EscapeJSONPointerSegment(name)))
+	}
+	return errs
+}
+
+// commonDirectory returns the deepest directory holding every file.
+func commonDirectory(files []string) string {
+	dir := filepath.Dir(files[0])
+	for _, f := range files[1:] {
+		for {
+			rel, err := filepath.Rel(dir, f)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				break
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+	return dir
+}
+
+func nodeContent(node *yaml.Node) []*yaml.Node {
+	if node == nil {
+		return nil
+	}
+	return node.Content
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSetFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestNewDocumentSet(t *testing.T) {
+	dir := writeSetFiles(t, map[string]string{
+		"services/pets/openapi.yaml": `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+tags:
+  - name: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        default:
+          $ref: '../../shared/errors.yaml#/components/responses/Error'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`,
+		"services/orders/openapi.yaml": `openapi: 3.1.0
+info:
+  title: orders
+  version: 2.0.0
+tags:
+  - name: pets
+    description: ignored, the first tag is kept
+  - name: orders
+servers:
+  - url: https://api.example.com
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      responses:
+        "201":
+          description: created
+webhooks:
+  orderShipped:
+    post:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Order:
+      type: object`,
+		"shared/errors.yaml": `openapi: 3.1.0
+components:
+  responses:
+    Error:
+      description: an error`,
+	})
+	pets := filepath.Join(dir, "services", "pets", "openapi.yaml")
+	orders := filepath.Join(dir, "services", "orders", "openapi.yaml")
+
+	set, err := NewDocumentSet([]string{pets, orders, pets}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{pets, orders}, set.Entries)
+	assert.Equal(t, filepath.Join(dir, "services"), set.BasePath)
+
+	m, errs := set.Document.BuildV3Model()
+	require.Empty(t, errs)
+	doc := m.Model
+	assert.Equal(t, "pets", doc.Info.Title)
+	assert.Equal(t, "https://api.example.com", doc.Servers[0].URL)
+	require.Len(t, doc.Tags, 2)
+	assert.Empty(t, doc.Tags[0].Description)
+	assert.Equal(t, "orders", doc.Tags[1].Name)
+
+	listPets := doc.Paths.PathItems.GetOrZero("/pets").Get
+	require.NotNil(t, listPets)
+	assert.Equal(t, "listPets", listPets.OperationId)
+	assert.Equal(t, "an error", listPets.Responses.Default.Description)
+	petSchema := listPets.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema.Schema()
+	assert.Equal(t, []string{"object"}, petSchema.Type)
+	assert.Equal(t, "createOrder", doc.Paths.PathItems.GetOrZero("/orders").Post.OperationId)
+	assert.NotNil(t, doc.Webhooks.GetOrZero("orderShipped"))
+	assert.Equal(t, []string{"object"}, doc.Components.Schemas.GetOrZero("Order").Schema().Type)
+
+	assert.Equal(t, pets, set.EntryFor("$.paths['/pets']"))
+	assert.Equal(t, orders, set.EntryFor("$.paths['/orders']"))
+	assert.Equal(t, orders, set.EntryFor("$.webhooks['orderShipped']"))
+	assert.Equal(t, pets, set.EntryFor("$.components.schemas['Pet']"))
+	assert.Equal(t, pets, set.EntryFor("$.tags['pets']"))
+	assert.Equal(t, orders, set.EntryFor("$.servers"))
+	assert.Equal(t, pets, set.EntryFor("$.info"))
+	assert.Empty(t, set.EntryFor("$.paths['/missing']"))
+
+	origin := set.Origin(listPets.GoLow().OperationId.ValueNode)
+	require.NotNil(t, origin)
+	assert.Equal(t, pets, origin.AbsoluteLocation)
+	assert.Equal(t, 10, origin.Line)
+
+	origin = set.Origin(listPets.Responses.Default.GoLow().Description.ValueNode)
+	require.NotNil(t, origin)
+	assert.Equal(t, filepath.Join(dir, "shared", "errors.yaml"), origin.AbsoluteLocation)
+}
+
+func TestNewDocumentSet_Conflicts(t *testing.T) {
+	dir := writeSetFiles(t, map[string]string{
+		"a.yaml": `openapi: 3.1.0
+paths:
+  /pets: {}
+components:
+  schemas:
+    Pet: {}`,
+		"b.yaml": `openapi: 3.1.0
+paths:
+  /pets: {}
+  x-internal: true
+components:
+  schemas:
+    Pet: {}
+    Toy: {}`,
+		"c.yaml": `openapi: 3.0.3
+paths: {}`,
+		"d.yaml": `swagger: "2.0"`,
+	})
+	a, b := filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")
+
+	_, err := NewDocumentSet([]string{a, b}, nil)
+	require.Error(t, err)
+	assert.Equal(t, "`$.paths['/pets']` is defined by entry files '"+a+"' and '"+b+"'\n"+
+		"`$.components.schemas['Pet']` is defined by entry files '"+a+"' and '"+b+"'", err.Error())
+
+	_, err = NewDocumentSet([]string{a, filepath.Join(dir, "c.yaml")}, nil)
+	assert.ErrorContains(t, err, "c.yaml' is OpenAPI 3.0.3, but the document set is OpenAPI 3.1.0")
+
+	_, err = NewDocumentSet([]string{filepath.Join(dir, "d.yaml")}, nil)
+	assert.ErrorContains(t, err, "d.yaml' is not an OpenAPI 3 document")
+
+	_, err = NewDocumentSet([]string{filepath.Join(dir, "missing.yaml")}, nil)
+	assert.ErrorContains(t, err, "unable to read entry file")
+
+	_, err = NewDocumentSet(nil, nil)
+	assert.EqualError(t, err, "unable to create a document set, no entry files were supplied")
+}
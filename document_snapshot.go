@@ -0,0 +1,101 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"gopkg.in/yaml.v3"
+)
+
+func (d *document) Snapshot() (*DocumentModel[v3high.Document], []error) {
+	if d.highOpenAPI3Model == nil {
+		return nil, []error{errors.New("unable to create a snapshot, the OpenAPI 3 model has not been built")}
+	}
+	// the provenance is never embedded, the snapshot is a copy of the model, not a rendering of it.
+	rendered, err := d.render(false)
+	if err != nil {
+		return nil, []error{err}
+	}
+	snapshot, err := NewDocumentWithConfiguration(rendered, d.config)
+	if err != nil {
+		return nil, []error{err}
+	}
+	m, errs := snapshot.BuildV3Model()
+	if m == nil {
+		return nil, errs
+	}
+	buildSchemas(reflect.ValueOf(&m.Model), make(map[any]struct{}))
+	return m, errs
+}
+
+const highModelPackage = "github.com/pb33f/libopenapi/datamodel/high"
+
+var (
+	schemaProxyType = reflect.TypeOf(&base.SchemaProxy{})
+	yamlNodeType    = reflect.TypeOf(&yaml.Node{})
+)
+
+// buildSchemas walks every exported field, slice and ordered map of a high-level model, and builds every schema
+// proxy found. Each schema is walked once, so the sub-schemas of the copies of a schema created by other references to
+// it (which are endless for recursive schemas) are built when they are first read, under the lock of their proxy.
+func buildSchemas(v reflect.Value, seen map[any]struct{}) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			buildSchemas(v.Elem(), seen)
+		}
+	case reflect.Pointer:
+		if v.IsNil() || v.Type() == yamlNodeType {
+			return
+		}
+		key := [2]any{v.Type(), v.Pointer()}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		if v.Type() == schemaProxyType {
+			s := v.Interface().(*base.SchemaProxy).Schema()
+			if s == nil {
+				return
+			}
+			// every reference to a schema builds a new copy of it, so each schema is only walked once, or
+			// recursive schemas would never end.
+			if l := s.GoLow(); l != nil && l.RootNode != nil {
+				if _, ok := seen[l.RootNode]; ok {
+					return
+				}
+				seen[l.RootNode] = struct{}{}
+			}
+			buildSchemas(reflect.ValueOf(s), seen)
+			return
+		}
+		// ordered maps are walked through their values.
+		if values := v.MethodByName("ValuesFromOldest"); values.IsValid() && values.Type().NumIn() == 0 {
+			for value := range values.Call(nil)[0].Seq() {
+				buildSchemas(value, seen)
+			}
+			return
+		}
+		buildSchemas(v.Elem(), seen)
+	case reflect.Struct:
+		// only the high-level model is walked, not the index, rolodex or anything else it points to.
+		if !strings.HasPrefix(v.Type().PkgPath(), highModelPackage) {
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				buildSchemas(v.Field(i), seen)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			buildSchemas(v.Index(i), seen)
+		}
+	}
+}
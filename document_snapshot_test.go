@@ -0,0 +1,131 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var snapshotSpec = `openapi: 3.1.0
+info:
+  title: Snapshots
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        friend:
+          $ref: '#/components/schemas/Pet'`
+
+func TestDocument_Snapshot(t *testing.T) {
+	doc, err := NewDocument([]byte(snapshotSpec))
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	m.Model.Info.Title = "Changed"
+	snapshot, errs := doc.Snapshot()
+	require.Empty(t, errs)
+	require.NotNil(t, snapshot)
+	assert.Equal(t, "Changed", snapshot.Model.Info.Title)
+	assert.NotSame(t, m.Index, snapshot.Index)
+
+	// changing the model after the snapshot is taken doesn't change the snapshot.
+	m.Model.Info.Title = "Changed again"
+	m.Model.Components.Schemas.Delete("Pet")
+	assert.Equal(t, "Changed", snapshot.Model.Info.Title)
+	require.NotNil(t, snapshot.Model.Components.Schemas.GetOrZero("Pet"))
+
+	original := m.Model.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200")
+	copied := snapshot.Model.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200")
+	assert.NotSame(t, original, copied)
+	assert.NotSame(t, original.GoLow().RootNode, copied.GoLow().RootNode)
+}
+
+func TestDocument_Snapshot_ConcurrentReads(t *testing.T) {
+	doc, err := NewDocument([]byte(snapshotSpec))
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	snapshot, errs := doc.Snapshot()
+	require.Empty(t, errs)
+
+	var wg sync.WaitGroup
+	names := make([]string, 8)
+	for i := range names {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pet := snapshot.Model.Components.Schemas.GetOrZero("Pet").Schema()
+			friend := pet.Properties.GetOrZero("friend").Schema()
+			var name *base.SchemaProxy
+			for k, v := range friend.Properties.FromOldest() {
+				if k == "name" {
+					name = v
+				}
+			}
+			names[i] = name.Schema().Type[0]
+		}(i)
+	}
+	wg.Wait()
+	for _, n := range names {
+		assert.Equal(t, "string", n)
+	}
+}
+
+func TestDocument_Snapshot_FileReferences(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object\nproperties:\n  name:\n    type: string"), 0o600))
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`
+
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		AllowFileReferences: true,
+	})
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	snapshot, errs := doc.Snapshot()
+	require.Empty(t, errs)
+	pet := snapshot.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	require.NotNil(t, pet)
+	assert.Equal(t, []string{"string"}, pet.Properties.GetOrZero("name").Schema().Type)
+}
+
+func TestDocument_Snapshot_NotBuilt(t *testing.T) {
+	doc, err := NewDocument([]byte(snapshotSpec))
+	require.NoError(t, err)
+	_, errs := doc.Snapshot()
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "unable to create a snapshot, the OpenAPI 3 model has not been built")
+}
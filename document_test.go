@@ -4,22 +4,37 @@ package libopenapi
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoadDocument_Simple_V2(t *testing.T) {
@@ -118,6 +133,37 @@ paths:
 	assert.Len(t, rolo.GetCaughtErrors(), 1)
 }
 
+func TestDocument_Close(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+	v3Doc, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	require.NotNil(t, v3Doc)
+
+	doc.Close()
+	assert.Nil(t, doc.GetRolodex())
+	assert.Nil(t, doc.GetSpecInfo())
+	// the model held is left as it is.
+	assert.NotNil(t, v3Doc.Model.GoLow())
+	assert.Equal(t, 13, v3Doc.Model.Paths.PathItems.Len())
+	_, errs = doc.BuildV3Model()
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "the document has been closed")
+	doc.Close()
+
+	petstore, _ = os.ReadFile("test_specs/petstorev2.json")
+	doc, err = NewDocument(petstore)
+	require.NoError(t, err)
+	v2Doc, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+	doc.Close()
+	assert.NotNil(t, v2Doc.Model.GoLow())
+	_, errs = doc.BuildV2Model()
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "the document has been closed")
+}
+
 func TestDocument_Serialize_Error(t *testing.T) {
 	doc := new(document) // not how this should be instantiated.
 	_, err := doc.Serialize()
@@ -1388,3 +1434,829 @@ components:
 	_, errs := doc.BuildV3Model()
 	assert.Len(t, errs, 0)
 }
+
+func TestDocument_BuildV3ModelWithContext_Cancelled(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m, errs := doc.BuildV3ModelWithContext(ctx)
+	assert.Nil(t, m)
+	require.NotEmpty(t, errs)
+	assert.ErrorIs(t, errors.Join(errs...), context.Canceled)
+}
+
+func TestDocument_BuildV3ModelWithContext(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	m, errs := doc.BuildV3ModelWithContext(ctx)
+	assert.Empty(t, errs)
+	assert.NotNil(t, m)
+	assert.Equal(t, 5, m.Model.Paths.PathItems.Len())
+}
+
+func TestDocument_BuildV2ModelWithContext_Cancelled(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m, errs := doc.BuildV2ModelWithContext(ctx)
+	assert.Nil(t, m)
+	assert.ErrorIs(t, errors.Join(errs...), context.Canceled)
+}
+
+func TestDocument_Limits_MaxNodes(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxNodes: 100},
+	})
+	assert.Nil(t, doc)
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+}
+
+func TestDocument_Limits_MaxNodes_AliasBomb(t *testing.T) {
+	spec := `openapi: 3.1.0
+a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]
+i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h]`
+
+	// the aliases are never expanded, the nodes are counted before the spec is decoded.
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxNodes: 10_000},
+	})
+	assert.Nil(t, doc)
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+}
+
+func TestDocument_Limits_MaxFileSize(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxFileSize: 1024},
+	})
+	assert.Nil(t, doc)
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+}
+
+func TestDocument_Limits_SetConfiguration(t *testing.T) {
+	// a document parsed before it was configured is checked as it's built.
+	spec, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+	doc.SetConfiguration(&datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxFileSize: 1024},
+	})
+
+	m, errs := doc.BuildV2Model()
+	assert.Nil(t, m)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], datamodel.ErrLimitExceeded)
+
+	spec, _ = os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err = NewDocument(spec)
+	require.NoError(t, err)
+	doc.SetConfiguration(&datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxNodes: 100},
+	})
+
+	v3, errs := doc.BuildV3Model()
+	assert.Nil(t, v3)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], datamodel.ErrLimitExceeded)
+}
+
+func TestDocument_Limits_WithinLimits(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxNodes: 100_000, MaxFileSize: int64(len(spec)), MaxRefDepth: 10},
+	})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+	assert.NotNil(t, m)
+}
+
+func TestDocument_Limits_MaxRefDepth(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /chain:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/A'
+components:
+  schemas:
+    A:
+      $ref: '#/components/schemas/B'
+    B:
+      $ref: '#/components/schemas/C'
+    C:
+      $ref: '#/components/schemas/D'
+    D:
+      type: string`
+
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxRefDepth: 2},
+	})
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	assert.ErrorIs(t, errors.Join(errs...), datamodel.ErrLimitExceeded)
+
+	doc, _ = NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		Limits: datamodel.DocumentLimits{MaxRefDepth: 10},
+	})
+	_, errs = doc.BuildV3Model()
+	assert.Empty(t, errs)
+}
+
+func TestDocument_Limits_Composition(t *testing.T) {
+	// each schema is composed of the previous one twice, so S30 expands to over two billion schemas.
+	var v3Spec, v2Spec strings.Builder
+	v3Spec.WriteString("openapi: 3.1.0\ncomponents:\n  schemas:\n    S0:\n      type: object\n")
+	v2Spec.WriteString("swagger: \"2.0\"\ndefinitions:\n  S0:\n    type: object\n")
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(&v3Spec, "    S%d:\n      oneOf:\n        - $ref: '#/components/schemas/S%d'\n"+
+			"        - $ref: '#/components/schemas/S%d'\n", i, i-1, i-1)
+		fmt.Fprintf(&v2Spec, "  S%d:\n    allOf:\n      - $ref: '#/definitions/S%d'\n"+
+			"      - $ref: '#/definitions/S%d'\n", i, i-1, i-1)
+	}
+	config := &datamodel.DocumentConfiguration{Limits: datamodel.DocumentLimits{MaxCompositionSize: 1_000_000}}
+
+	doc, err := NewDocumentWithConfiguration([]byte(v3Spec.String()), config)
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	assert.Nil(t, m)
+	assert.ErrorIs(t, errors.Join(errs...), datamodel.ErrLimitExceeded)
+	assert.Contains(t, errors.Join(errs...).Error(), "schema '#/components/schemas/S30' expands to 2147483647 schemas")
+
+	doc, err = NewDocumentWithConfiguration([]byte(v2Spec.String()), config)
+	require.NoError(t, err)
+	m2, errs := doc.BuildV2Model()
+	assert.Nil(t, m2)
+	assert.ErrorIs(t, errors.Join(errs...), datamodel.ErrLimitExceeded)
+	assert.Contains(t, errors.Join(errs...).Error(), "schema '#/definitions/S19' expands to 1048575 schemas")
+}
+
+func TestDocument_RemoteFetch_Pins(t *testing.T) {
+	remote := `components:
+  schemas:
+    Thing:
+      type: string`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remote))
+	}))
+	defer server.Close()
+	spec := []byte(`openapi: 3.1.0
+components:
+  schemas:
+    Remote:
+      $ref: '` + server.URL + `/remote.yaml#/components/schemas/Thing'`)
+
+	config := &datamodel.DocumentConfiguration{AllowRemoteReferences: true}
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	config.RemoteFetch.Pins = m.Index.GetRolodex().ExportPins()
+	assert.Len(t, config.RemoteFetch.Pins, 1)
+
+	// the remote file has changed upstream.
+	remote += "\n      format: uuid"
+	doc, err = NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	m, errs = doc.BuildV3Model()
+	assert.Nil(t, m)
+	assert.ErrorIs(t, errors.Join(errs...), datamodel.ErrPinMismatch)
+}
+
+func TestDocument_OfflineBaseDir(t *testing.T) {
+	// nothing listens on this host, the remote file is only in the mirror.
+	spec := []byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'https://pb33f.invalid/specs/pets.yaml#/components/schemas/Pet'`)
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "pets"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets", "pets.yaml"), []byte(`components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: 'https://pb33f.invalid/specs/owners.yaml#/components/schemas/Owner'`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets", "owners.yaml"), []byte(`components:
+  schemas:
+    Owner:
+      type: string`), 0o600))
+
+	config := &datamodel.DocumentConfiguration{
+		OfflineBaseDir:  dir,
+		OfflineMappings: map[string]string{"https://pb33f.invalid/specs/": "pets"},
+	}
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	pet := m.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, []string{"string"}, pet.Properties.GetOrZero("owner").Schema().Type)
+
+	// a remote file that has not been vendored cannot be fetched.
+	require.NoError(t, os.Remove(filepath.Join(dir, "pets", "owners.yaml")))
+	doc, err = NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	_, errs = doc.BuildV3Model()
+	assert.NotEmpty(t, errs)
+}
+
+type streamingExtension struct {
+	Protocol low.NodeReference[string]
+	Schema   low.NodeReference[*lowbase.SchemaProxy]
+}
+
+func (s *streamingExtension) Build(ctx context.Context, _, root *yaml.Node, idx *index.SpecIndex) error {
+	sch, err := low.ExtractObject[*lowbase.SchemaProxy](ctx, "schema", root, idx)
+	if err != nil {
+		return err
+	}
+	s.Schema = sch
+	return nil
+}
+
+func TestDocument_ExtensionRegistry(t *testing.T) {
+	spec := `openapi: 3.1.0
+x-streaming:
+  protocol: sse
+paths:
+  /events:
+    get:
+      x-streaming:
+        $ref: '#/components/x-streams/ws'
+      responses:
+        "200":
+          description: ok
+components:
+  x-streams:
+    ws:
+      protocol: websocket
+      schema:
+        $ref: '#/components/schemas/Event'
+  schemas:
+    Event:
+      type: object`
+
+	registry := low.NewExtensionRegistry()
+	low.RegisterExtension[*streamingExtension](registry, "x-streaming")
+
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		ExtensionRegistry: registry,
+	})
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	root, ok := high.GetExtensionModel[streamingExtension](registry, m.Model.Extensions, "x-streaming")
+	require.True(t, ok)
+	assert.Equal(t, "sse", root.Protocol.Value)
+	assert.Nil(t, root.Schema.Value)
+
+	op := m.Model.Paths.PathItems.GetOrZero("/events").Get
+	ws, ok := high.GetExtensionModel[streamingExtension](registry, op.Extensions, "x-streaming")
+	require.True(t, ok)
+	assert.Equal(t, "websocket", ws.Protocol.Value)
+	require.NotNil(t, ws.Schema.Value)
+	assert.Equal(t, "object", ws.Schema.Value.Schema().Type.Value.A)
+}
+
+func TestDocument_ExtensionRegistry_Errors(t *testing.T) {
+	spec := `openapi: 3.1.0
+x-streaming:
+  schema:
+    $ref: '#/components/schemas/Missing'`
+
+	registry := low.NewExtensionRegistry()
+	low.RegisterExtension[*streamingExtension](registry, "x-streaming")
+
+	doc, _ := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		ExtensionRegistry: registry,
+	})
+	m, errs := doc.BuildV3Model()
+	assert.NotNil(t, m)
+	assert.ErrorContains(t, errors.Join(errs...), "unable to build extension 'x-streaming'")
+}
+
+type brokenExtension struct{}
+
+func (b *brokenExtension) Build(_ context.Context, _, _ *yaml.Node, _ *index.SpecIndex) error {
+	return errors.New("broken")
+}
+
+func TestDocument_ExtensionRegistry_ErrorsPerBuild(t *testing.T) {
+	registry := low.NewExtensionRegistry()
+	low.RegisterExtension[*brokenExtension](registry, "x-broken")
+	config := &datamodel.DocumentConfiguration{ExtensionRegistry: registry}
+
+	broken, _ := NewDocumentWithConfiguration([]byte(`openapi: 3.1.0
+x-broken: true`), config)
+	fine, _ := NewDocumentWithConfiguration([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    Event:
+      type: object
+      x-broken: true`), config)
+
+	// each build reports the errors of its own extensions, not those of another build of the registry.
+	_, errs := broken.BuildV3Model()
+	assert.Len(t, errs, 1)
+	m, errs := fine.BuildV3Model()
+	assert.Empty(t, errs)
+
+	// errors of schemas built once the build returned are kept by the document.
+	assert.Empty(t, m.Model.GoLow().GetExtensionErrors())
+	assert.NotNil(t, m.Model.Components.Schemas.GetOrZero("Event").Schema())
+	require.Len(t, m.Model.GoLow().GetExtensionErrors(), 1)
+	assert.EqualError(t, m.Model.GoLow().GetExtensionErrors()[0], "unable to build extension 'x-broken' [6:7]: broken")
+	assert.Len(t, registry.GetErrors(), 2)
+}
+
+func TestDocument_ResolvePointer(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets/{id}:
+    x-private: true
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+      examples:
+        - name: fluffy`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+
+	_, err = doc.ResolvePointer("/paths")
+	assert.Error(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	op, err := doc.ResolvePointer("/paths/~1pets~1{id}/get")
+	require.NoError(t, err)
+	require.IsType(t, &v3high.Operation{}, op)
+	assert.Equal(t, "getPet", op.(*v3high.Operation).OperationId)
+
+	// round trip back to the pointer from the low-level model.
+	lowOp := op.(*v3high.Operation).GoLow()
+	p, ok := low.NodeReference[string]{ValueNode: lowOp.RootNode}.JSONPointer(m.Index.GetRootNode())
+	assert.True(t, ok)
+	assert.Equal(t, "/paths/~1pets~1{id}/get", p)
+
+	param, err := doc.ResolvePointer("/paths/~1pets~1{id}/get/parameters/0")
+	require.NoError(t, err)
+	assert.Equal(t, "id", param.(*v3high.Parameter).Name)
+
+	resp, err := m.ResolvePointer("#/paths/~1pets~1%7Bid%7D/get/responses/200")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp.(*v3high.Response).Description)
+
+	// schema proxies are followed through references.
+	name, err := doc.ResolvePointer("/paths/~1pets~1{id}/get/responses/200/content/application~1json/schema/properties/name")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"string"}, name.(*base.SchemaProxy).Schema().Type)
+
+	ext, err := doc.ResolvePointer("/paths/~1pets~1{id}/x-private")
+	require.NoError(t, err)
+	assert.Equal(t, "true", ext.(*yaml.Node).Value)
+
+	example, err := doc.ResolvePointer("/components/schemas/Pet/examples/0/name")
+	require.NoError(t, err)
+	assert.Equal(t, "fluffy", example.(*yaml.Node).Value)
+
+	_, err = doc.ResolvePointer("/paths/~1pets~1{id}/post")
+	assert.EqualError(t, err, "unable to resolve json pointer '/paths/~1pets~1{id}/post': segment 'post' not found")
+	_, err = doc.ResolvePointer("paths")
+	assert.Error(t, err)
+}
+
+func TestDocument_VirtualLocalFS(t *testing.T) {
+	testFS := fstest.MapFS{
+		"schemas/pet.yaml": {Data: []byte(`type: object
+properties:
+  owner:
+    $ref: "../common.yaml#/components/schemas/Owner"`)},
+		"common.yaml": {Data: []byte(`components:
+  schemas:
+    Owner:
+      type: string
+      description: the owner`)},
+	}
+
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: "./schemas/pet.yaml"`
+
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		BasePath: "embedded",
+		LocalFS:  testFS,
+	})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	pet := m.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	require.NotNil(t, pet)
+	owner := pet.Properties.GetOrZero("owner").Schema()
+	require.NotNil(t, owner)
+	assert.Equal(t, "the owner", owner.Description)
+}
+
+const openAPI32Spec = `openapi: 3.2.0
+$self: https://example.com/specs/pets.yaml
+info:
+  title: pets
+  version: 1.0.0
+tags:
+  - name: pets
+    summary: Pets
+    kind: nav
+  - name: cats
+    summary: Cats
+    parent: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+    query:
+      operationId: queryPets
+      responses:
+        "200":
+          description: ok
+    additionalOperations:
+      COPY:
+        operationId: copyPets
+        responses:
+          "200":
+            description: ok`
+
+func TestDocument_OpenAPI32(t *testing.T) {
+	doc, err := NewDocument([]byte(openAPI32Spec))
+	require.NoError(t, err)
+	assert.Equal(t, datamodel.OAS32, doc.GetSpecInfo().SpecFormat)
+
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "https://example.com/specs/pets.yaml", m.Model.Self)
+	assert.Equal(t, "Pets", m.Model.Tags[0].Summary)
+	assert.Equal(t, "nav", m.Model.Tags[0].Kind)
+	assert.Equal(t, "pets", m.Model.Tags[1].Parent)
+
+	pathItem := m.Model.Paths.PathItems.GetOrZero("/pets")
+	require.NotNil(t, pathItem.Query)
+	assert.Equal(t, "queryPets", pathItem.Query.OperationId)
+	require.Equal(t, 1, pathItem.AdditionalOperations.Len())
+	assert.Equal(t, "copyPets", pathItem.AdditionalOperations.GetOrZero("COPY").OperationId)
+
+	var methods []string
+	for method := range pathItem.GetOperations().KeysFromOldest() {
+		methods = append(methods, method)
+	}
+	assert.Equal(t, []string{"get", "query", "COPY"}, methods)
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "$self: https://example.com/specs/pets.yaml")
+	assert.Contains(t, string(rendered), "query:\n")
+	assert.Contains(t, string(rendered), "additionalOperations:\n")
+	assert.Contains(t, string(rendered), "parent: pets")
+}
+
+func TestDocument_OpenAPI32_Changes(t *testing.T) {
+	modified := strings.Replace(openAPI32Spec, "operationId: queryPets", "operationId: searchPets", 1)
+	modified = strings.Replace(modified, "COPY:", "MOVE:", 1)
+	modified = strings.Replace(modified, "parent: pets", "parent: animals", 1)
+
+	original, err := NewDocument([]byte(openAPI32Spec))
+	require.NoError(t, err)
+	updated, err := NewDocument([]byte(modified))
+	require.NoError(t, err)
+
+	changes, errs := CompareDocuments(original, updated)
+	require.Empty(t, errs)
+	pathChanges := changes.PathsChanges.PathItemsChanges["/pets"]
+	require.NotNil(t, pathChanges.QueryChanges)
+	assert.Equal(t, 1, pathChanges.QueryChanges.TotalChanges())
+	assert.Len(t, pathChanges.Changes, 2)
+	assert.Equal(t, 1, changes.TagChanges[0].TotalChanges())
+	assert.Equal(t, 4, changes.TotalChanges())
+}
+
+func TestDocument_OpenAPI31_No32Fields(t *testing.T) {
+	doc, err := NewDocument([]byte(strings.Replace(openAPI32Spec, "openapi: 3.2.0", "openapi: 3.1.0", 1)))
+	require.NoError(t, err)
+
+	m, _ := doc.BuildV3Model()
+	require.NotNil(t, m)
+	assert.Empty(t, m.Model.Self)
+	assert.Empty(t, m.Model.Tags[0].Summary)
+	assert.Empty(t, m.Model.Tags[1].Parent)
+
+	pathItem := m.Model.Paths.PathItems.GetOrZero("/pets")
+	assert.Nil(t, pathItem.Query)
+	assert.Nil(t, pathItem.AdditionalOperations)
+	assert.Equal(t, 1, pathItem.GetOperations().Len())
+}
+
+func TestDocument_InvalidPaths(t *testing.T) {
+	spec := `openapi: 3.1.0
+definitions: {}
+paths:
+  pets:
+    get:
+      responses:
+        "200":
+          description: ok
+  x-cats:
+    $ref: '#/components/pathItems/cats'`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	m, _ := doc.BuildV3Model()
+	require.NotNil(t, m)
+
+	assert.Equal(t, 2, m.Model.Paths.InvalidPaths.Len())
+	assert.NotNil(t, m.Model.Paths.PathItems.GetOrZero("pets"))
+	assert.NotNil(t, m.Model.Paths.InvalidPaths.GetOrZero("x-cats"))
+
+	diagnostics := m.Model.GoLow().Diagnostics
+	require.Len(t, diagnostics, 3)
+	assert.Equal(t, "definitions", diagnostics[0].Key)
+	assert.Equal(t, "path 'pets' does not start with '/' [4:3]", diagnostics[1].Error())
+	assert.Equal(t, "x-cats", diagnostics[2].Key)
+}
+
+func TestDocument_GetProvenance(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: provenance\n  version: 1.0.0\npaths: {}\n")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		BasePath:     "specs",
+		SpecFilePath: "pets.yaml",
+	})
+	require.NoError(t, err)
+
+	p := doc.GetProvenance()
+	require.NotNil(t, p)
+	sum := sha256.Sum256(spec)
+	assert.Equal(t, hex.EncodeToString(sum[:]), p.SHA256)
+	assert.Equal(t, filepath.Join("specs", "pets.yaml"), p.Source)
+	assert.False(t, p.FetchedAt.IsZero())
+	assert.NotEmpty(t, p.LibraryVersion)
+	assert.Equal(t, "pets.yaml", p.Configuration.SpecFilePath)
+
+	// provenance is not rendered unless asked for.
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.NotContains(t, string(rendered), datamodel.ProvenanceExtension)
+}
+
+func TestDocument_Render_EmbedProvenance(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: provenance\n  version: 1.0.0\npaths: {}\nx-team: pets\n")
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{EmbedProvenance: true})
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), datamodel.ProvenanceExtension+":")
+	assert.Contains(t, string(rendered), "sha256: "+doc.GetProvenance().SHA256)
+	assert.Contains(t, string(rendered), "x-team: pets")
+
+	// the model itself is left alone.
+	assert.Equal(t, 1, m.Model.Extensions.Len())
+
+	// re-rendering a reloaded document replaces the block, rather than adding another one.
+	_, newDoc, _, errs := doc.RenderAndReload()
+	require.Empty(t, errs)
+	again, err := newDoc.Render()
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(again), datamodel.ProvenanceExtension))
+	assert.Contains(t, string(again), "sha256: "+newDoc.GetProvenance().SHA256)
+}
+
+func TestDocument_Render_EmbedProvenance_JSON(t *testing.T) {
+	spec := []byte(`{"openapi": "3.1.0", "info": {"title": "provenance", "version": "1.0.0"}, "paths": {}}`)
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{EmbedProvenance: true})
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(rendered, &decoded))
+	provenance, ok := decoded[datamodel.ProvenanceExtension].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, doc.GetProvenance().SHA256, provenance["sha256"])
+	assert.NotEmpty(t, provenance["fetchedAt"])
+}
+
+func TestCompareDocuments_Normalize(t *testing.T) {
+	original := []byte(`openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: tags
+          in: query
+          schema:
+            type: [array]
+            items:
+              type: string
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: object
+                required: []
+                additionalProperties: {}`)
+	updated := []byte(`openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: tags
+          in: query
+          style: form
+          explode: true
+          schema:
+            type: array
+            items:
+              type: [string]
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: [object]`)
+
+	compare := func(config *datamodel.DocumentConfiguration) (*model.DocumentChanges, [32]byte, [32]byte) {
+		left, err := NewDocumentWithConfiguration(original, config)
+		require.NoError(t, err)
+		right, err := NewDocumentWithConfiguration(updated, config)
+		require.NoError(t, err)
+		changes, errs := CompareDocuments(left, right)
+		require.Empty(t, errs)
+		l, _ := left.BuildV3Model()
+		r, _ := right.BuildV3Model()
+		return changes, l.Model.Paths.GoLow().Hash(), r.Model.Paths.GoLow().Hash()
+	}
+
+	changes, leftHash, rightHash := compare(datamodel.NewDocumentConfiguration())
+	require.NotNil(t, changes)
+	assert.NotZero(t, changes.TotalChanges())
+	assert.NotEqual(t, leftHash, rightHash)
+
+	config := datamodel.NewDocumentConfiguration()
+	config.Normalize = true
+	changes, leftHash, rightHash = compare(config)
+	assert.Nil(t, changes)
+	assert.Equal(t, leftHash, rightHash)
+}
+
+func TestDocumentModel_Origin(t *testing.T) {
+	dir := t.TempDir()
+	// the schemas of both files are identical, nodes for nodes, so only the file holding the node itself can tell.
+	schema := `type: object
+description: |
+  a schema
+  of two lines
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte(schema), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "owner.yaml"), []byte(schema), 0o644))
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: origins
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: pet.yaml
+  /owners:
+    get:
+      responses:
+        "200":
+          description: owners
+          content:
+            application/json:
+              schema:
+                $ref: owner.yaml`)
+
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		AllowFileReferences: true,
+	})
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	owners := m.Model.Paths.PathItems.GetOrZero("/owners").Get
+	origin := m.Origin(owners)
+	require.NotNil(t, origin)
+	assert.Equal(t, 17, origin.Line)
+	assert.Equal(t, 23, origin.EndLine())
+	assert.Equal(t, m.Index.GetSpecAbsolutePath(), origin.AbsoluteLocation)
+
+	for file, path := range map[string]string{"pet.yaml": "/pets", "owner.yaml": "/owners"} {
+		response := m.Model.Paths.PathItems.GetOrZero(path).Get.Responses.Codes.GetOrZero("200")
+		s := response.Content.GetOrZero("application/json").Schema.Schema()
+		origin = m.Origin(s)
+		require.NotNil(t, origin, file)
+		assert.Equal(t, filepath.Join(dir, file), origin.AbsoluteLocation)
+		assert.Equal(t, 1, origin.Line)
+		assert.Equal(t, 4, origin.EndLine())
+	}
+
+	var missing *v3high.Operation
+	assert.Nil(t, m.Origin(missing))
+	assert.Nil(t, m.Origin(nil))
+	assert.Nil(t, (&DocumentModel[v3high.Document]{}).Origin(owners))
+}
+
+func TestDocumentModel_Origin_Swagger(t *testing.T) {
+	doc, err := NewDocument([]byte(`swagger: "2.0"
+info:
+  title: origins
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets`))
+	require.NoError(t, err)
+	m, errs := doc.BuildV2Model()
+	require.Empty(t, errs)
+
+	origin := m.Origin(m.Model.Paths.PathItems.GetOrZero("/pets").Get)
+	require.NotNil(t, origin)
+	assert.Equal(t, 8, origin.Line)
+	assert.Equal(t, 10, origin.EndLine())
+}
@@ -0,0 +1,290 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+const (
+	// DefaultWatchPollInterval is how often watched files are checked for changes, when
+	// DocumentWatcherConfig.PollInterval is not set.
+	DefaultWatchPollInterval = 500 * time.Millisecond
+
+	// DefaultWatchDebounce is how long watched files must stop changing before the document is rebuilt, when
+	// DocumentWatcherConfig.Debounce is not set.
+	DefaultWatchDebounce = 250 * time.Millisecond
+)
+
+// DocumentWatcherConfig configures a DocumentWatcher.
+type DocumentWatcherConfig struct {
+	// SpecFilePath is the path of the root specification file to watch (required).
+	SpecFilePath string
+
+	// Configuration is used to create every Document built by the watcher. If it's nil, a default configuration
+	// is used. If the BasePath is not set, it's set to the directory of the root specification file, so relative
+	// file references are followed (and watched).
+	Configuration *datamodel.DocumentConfiguration
+
+	// PollInterval is how often watched files are checked for changes. Defaults to DefaultWatchPollInterval.
+	PollInterval time.Duration
+
+	// Debounce is how long watched files must stop changing before the document is rebuilt, so saving several
+	// files at once causes a single rebuild. Defaults to DefaultWatchDebounce.
+	Debounce time.Duration
+}
+
+// DocumentWatchResult is the result of a document build made by a DocumentWatcher.
+type DocumentWatchResult struct {
+	// Document is the Document that was built, it's nil if the root specification could not be read or parsed.
+	// A model has already been built for it (BuildV3Model or BuildV2Model, depending on the version).
+	Document Document
+
+	// Errors are all the errors returned reading, parsing and building the document.
+	Errors []error
+
+	// Changes are the changes between the last document that was built without errors and this one. It's nil for
+	// the first build, when nothing changed, or when either document could not be built.
+	Changes *model.DocumentChanges
+
+	// ChangedFiles are the watched files that changed and caused this build, it's empty for the first build.
+	ChangedFiles []string
+}
+
+// DocumentWatcher watches a root specification file and every local file the rolodex loaded to build it. When any of
+// them change, the document is rebuilt and a DocumentWatchResult is sent on the Results channel. It's a building
+// block for tools that show a live preview of a specification being edited.
+//
+// Files are watched by polling their modification time and size, so no platform specific file notifications are
+// used. Remote files are not watched.
+type DocumentWatcher struct {
+	config   DocumentWatcherConfig
+	docConf  *datamodel.DocumentConfiguration
+	rootPath string
+	results  chan *DocumentWatchResult
+	done     chan struct{}
+	stopped  chan struct{}
+	close    sync.Once
+
+	lock     sync.RWMutex
+	files    map[string]watchedFile
+	document Document
+}
+
+type watchedFile struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+// NewDocumentWatcher creates a DocumentWatcher and builds the document for the first time. The result of that first
+// build is the first DocumentWatchResult sent on the Results channel, even if it failed. An error is returned if the
+// root specification file cannot be found. Call Close to stop watching.
+func NewDocumentWatcher(config *DocumentWatcherConfig) (*DocumentWatcher, error) {
+	if config == nil || config.SpecFilePath == "" {
+		return nil, errors.New("unable to watch document, no specification file path has been supplied")
+	}
+	rootPath, err := filepath.Abs(config.SpecFilePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = os.Stat(rootPath); err != nil {
+		return nil, err
+	}
+
+	w := &DocumentWatcher{
+		config:   *config,
+		rootPath: rootPath,
+		results:  make(chan *DocumentWatchResult, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+		files:    make(map[string]watchedFile),
+	}
+	if w.config.PollInterval <= 0 {
+		w.config.PollInterval = DefaultWatchPollInterval
+	}
+	if w.config.Debounce <= 0 {
+		w.config.Debounce = DefaultWatchDebounce
+	}
+	if config.Configuration != nil {
+		docConf := *config.Configuration
+		w.docConf = &docConf
+	} else {
+		w.docConf = datamodel.NewDocumentConfiguration()
+	}
+	if w.docConf.BasePath == "" {
+		w.docConf.BasePath = filepath.Dir(rootPath)
+	}
+	if w.docConf.SpecFilePath == "" {
+		w.docConf.SpecFilePath = filepath.Base(rootPath)
+	}
+
+	first := w.build(nil)
+	go w.watch(first)
+	return w, nil
+}
+
+// Results returns the channel build results are sent on. It's closed once the watcher has been closed.
+func (w *DocumentWatcher) Results() <-chan *DocumentWatchResult {
+	return w.results
+}
+
+// Document returns the last document that was built without errors, or nil if there hasn't been one.
+func (w *DocumentWatcher) Document() Document {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.document
+}
+
+// WatchedFiles returns the absolute paths of every file being watched, sorted.
+func (w *DocumentWatcher) WatchedFiles() []string {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	var files []string
+	for f := range w.files {
+		files = append(files, f)
+	}
+	slices.Sort(files)
+	return files
+}
+
+// Close stops watching and closes the Results channel. It's safe to call more than once.
+func (w *DocumentWatcher) Close() {
+	w.close.Do(func() {
+		close(w.done)
+	})
+	<-w.stopped
+}
+
+func (w *DocumentWatcher) watch(first *DocumentWatchResult) {
+	defer close(w.stopped)
+	defer close(w.results)
+	if !w.send(first) {
+		return
+	}
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+	var pending []string
+	var lastChange time.Time
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		}
+		if changed := w.poll(); len(changed) > 0 {
+			for _, f := range changed {
+				if !slices.Contains(pending, f) {
+					pending = append(pending, f)
+				}
+			}
+			lastChange = time.Now()
+		}
+		if len(pending) > 0 && time.Since(lastChange) >= w.config.Debounce {
+			result := w.build(pending)
+			pending = nil
+			if !w.send(result) {
+				return
+			}
+		}
+	}
+}
+
+func (w *DocumentWatcher) send(result *DocumentWatchResult) bool {
+	select {
+	case w.results <- result:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// poll checks every watched file, and returns those that have changed since they were last checked.
+func (w *DocumentWatcher) poll() []string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	var changed []string
+	for path, previous := range w.files {
+		current := statWatchedFile(path)
+		if current != previous {
+			w.files[path] = current
+			changed = append(changed, path)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// build builds the document, then watches every local file the rolodex loaded to build it.
+func (w *DocumentWatcher) build(changed []string) *DocumentWatchResult {
+	result := &DocumentWatchResult{ChangedFiles: changed}
+
+	// check the root before it's read, so changes made while building are picked up by the next poll.
+	rootState := statWatchedFile(w.rootPath)
+	files := map[string]watchedFile{w.rootPath: rootState}
+
+	var built bool
+	specBytes, err := os.ReadFile(w.rootPath)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+	} else {
+		doc, docErr := NewDocumentWithConfiguration(specBytes, w.docConf)
+		if docErr != nil {
+			result.Errors = append(result.Errors, utils.UnwrapErrors(docErr)...)
+		}
+		if doc != nil {
+			result.Document = doc
+			var buildErrs []error
+			built, buildErrs = buildModel(context.Background(), doc)
+			result.Errors = append(result.Errors, buildErrs...)
+			if rolodex := doc.GetRolodex(); rolodex != nil {
+				for _, idx := range rolodex.GetIndexes() {
+					path := idx.GetSpecAbsolutePath()
+					if filepath.IsAbs(path) {
+						files[path] = statWatchedFile(path)
+					}
+				}
+			}
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for path := range files {
+		if previous, ok := w.files[path]; ok && path != w.rootPath {
+			// keep the last known state, so a change made while building is picked up by the next poll.
+			files[path] = previous
+		}
+	}
+	w.files = files
+	if built && len(result.Errors) == 0 {
+		if w.document != nil {
+			changes, _ := CompareDocuments(w.document, result.Document)
+			if changes != nil && changes.TotalChanges() > 0 {
+				result.Changes = changes
+			}
+		}
+		w.document = result.Document
+	}
+	return result
+}
+
+func statWatchedFile(path string) watchedFile {
+	info, err := os.Stat(path)
+	if err != nil {
+		return watchedFile{}
+	}
+	return watchedFile{exists: true, modTime: info.ModTime(), size: info.Size()}
+}
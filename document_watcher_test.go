@@ -0,0 +1,136 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const watchRootSpec = `openapi: 3.1.0
+info:
+  title: Watched
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'pet.yaml'
+`
+
+func nextWatchResult(t *testing.T, w *DocumentWatcher) *DocumentWatchResult {
+	select {
+	case r, ok := <-w.Results():
+		require.True(t, ok)
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a watch result")
+		return nil
+	}
+}
+
+// touch writes a file and moves its modification time forward, so the change is seen on file systems with a
+// coarse modification time.
+func touch(t *testing.T, path, content string, offset time.Duration) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	future := time.Now().Add(offset)
+	require.NoError(t, os.Chtimes(path, future, future))
+}
+
+func TestNewDocumentWatcher(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "openapi.yaml")
+	pet := filepath.Join(dir, "pet.yaml")
+	touch(t, root, watchRootSpec, 0)
+	touch(t, pet, "type: object\n", 0)
+
+	w, err := NewDocumentWatcher(&DocumentWatcherConfig{
+		SpecFilePath: root,
+		PollInterval: 5 * time.Millisecond,
+		Debounce:     10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer w.Close()
+
+	first := nextWatchResult(t, w)
+	assert.Empty(t, first.Errors)
+	assert.NotNil(t, first.Document)
+	assert.Nil(t, first.Changes)
+	assert.Empty(t, first.ChangedFiles)
+	assert.Equal(t, first.Document, w.Document())
+	assert.Equal(t, []string{root, pet}, w.WatchedFiles())
+
+	// change the referenced file
+	touch(t, pet, "type: object\ndescription: a pet\n", time.Second)
+	second := nextWatchResult(t, w)
+	assert.Empty(t, second.Errors)
+	assert.Equal(t, []string{pet}, second.ChangedFiles)
+	assert.Equal(t, second.Document, w.Document())
+	assert.Contains(t, second.Document.GetRolodex().GetIndexes()[0].GetRootNode().Content[0].Content[3].Value, "a pet")
+
+	// change the root file
+	touch(t, root, strings.Replace(watchRootSpec, "title: Watched", "title: Watching", 1), time.Second)
+	changed := nextWatchResult(t, w)
+	assert.Equal(t, []string{root}, changed.ChangedFiles)
+	require.NotNil(t, changed.Changes)
+	assert.Equal(t, 1, changed.Changes.TotalChanges())
+
+	// break the root file, the last good document is kept.
+	touch(t, root, "openapi: 3.1.0\ninfo: [\n", 2*time.Second)
+	third := nextWatchResult(t, w)
+	assert.NotEmpty(t, third.Errors)
+	assert.Nil(t, third.Changes)
+	assert.Equal(t, []string{root}, third.ChangedFiles)
+	assert.Equal(t, changed.Document, w.Document())
+
+	w.Close()
+	_, ok := <-w.Results()
+	assert.False(t, ok)
+}
+
+func TestNewDocumentWatcher_Debounce(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "openapi.yaml")
+	pet := filepath.Join(dir, "pet.yaml")
+	touch(t, root, watchRootSpec, 0)
+	touch(t, pet, "type: object\n", 0)
+
+	w, err := NewDocumentWatcher(&DocumentWatcherConfig{
+		SpecFilePath: root,
+		PollInterval: 5 * time.Millisecond,
+		Debounce:     200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer w.Close()
+	nextWatchResult(t, w)
+
+	// both files change before the debounce has passed, so there is a single rebuild.
+	touch(t, pet, "type: string\n", time.Second)
+	time.Sleep(20 * time.Millisecond)
+	touch(t, root, watchRootSpec+"tags:\n  - name: pets\n", time.Second)
+
+	result := nextWatchResult(t, w)
+	assert.Equal(t, []string{pet, root}, result.ChangedFiles)
+	require.NotNil(t, result.Changes)
+	assert.Equal(t, 1, result.Changes.TotalChanges())
+}
+
+func TestNewDocumentWatcher_Errors(t *testing.T) {
+	_, err := NewDocumentWatcher(nil)
+	assert.Error(t, err)
+
+	_, err = NewDocumentWatcher(&DocumentWatcherConfig{SpecFilePath: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
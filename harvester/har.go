@@ -0,0 +1,80 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package harvester
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// har is the part of a HAR (HTTP Archive) file harvested.
+type har struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				Method   string `json:"method"`
+				URL      string `json:"url"`
+				PostData *struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+				} `json:"postData"`
+			} `json:"request"`
+			Response struct {
+				Status  int `json:"status"`
+				Content struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// AddHAR harvests every entry of a HAR (HTTP Archive) file. Entries that can't be harvested are skipped, and
+// returned as errors, once every other entry is harvested.
+func (h *Harvester) AddHAR(r io.Reader) error {
+	var archive har
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return fmt.Errorf("unable to read HAR file: %w", err)
+	}
+	var errs []error
+	for i, entry := range archive.Log.Entries {
+		exchange := &Exchange{
+			Method:              entry.Request.Method,
+			URL:                 entry.Request.URL,
+			StatusCode:          entry.Response.Status,
+			ResponseContentType: entry.Response.Content.MimeType,
+			ResponseBody:        []byte(entry.Response.Content.Text),
+		}
+		if entry.Request.PostData != nil {
+			exchange.RequestContentType = entry.Request.PostData.MimeType
+			exchange.RequestBody = []byte(entry.Request.PostData.Text)
+		}
+		if entry.Response.Content.Encoding == "base64" {
+			body, err := base64.StdEncoding.DecodeString(entry.Response.Content.Text)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("unable to decode the response of entry %d: %w", i, err))
+				continue
+			}
+			exchange.ResponseBody = body
+		}
+		if err := h.Add(exchange); err != nil {
+			errs = append(errs, fmt.Errorf("unable to harvest entry %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FromHAR harvests a HAR (HTTP Archive) file into a new draft document. See Harvester.AddHAR.
+func FromHAR(r io.Reader) (*v3.Document, error) {
+	h := NewHarvester()
+	err := h.AddHAR(r)
+	return h.Document(), err
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package harvester
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var harFile = `{
+  "log": {
+    "version": "1.2",
+    "entries": [
+      {
+        "request": {
+          "method": "POST",
+          "url": "https://api.example.com/v1/orders?dryRun=true",
+          "postData": {"mimeType": "application/json", "text": "{\"items\": [{\"sku\": \"a1\", \"quantity\": 2}]}"}
+        },
+        "response": {
+          "status": 201,
+          "content": {"mimeType": "application/json", "text": "eyJpZCI6ICIyMDI0LTAxLTAxVDEwOjAwOjAwWiJ9", "encoding": "base64"}
+        }
+      },
+      {
+        "request": {"method": "GET", "url": "https://api.example.com/v1/orders/981"},
+        "response": {"status": 200, "content": {"mimeType": "application/json", "text": "{\"id\": 981}"}}
+      },
+      {
+        "request": {"method": "CONNECT", "url": "https://api.example.com:443"},
+        "response": {"status": 200, "content": {}}
+      },
+      {
+        "request": {"method": "GET", "url": "https://api.example.com/v1/orders/982"},
+        "response": {"status": 200, "content": {"mimeType": "application/json", "text": "!!", "encoding": "base64"}}
+      }
+    ]
+  }
+}`
+
+func TestHarvester_AddHAR(t *testing.T) {
+	doc, err := FromHAR(strings.NewReader(harFile))
+	assert.EqualError(t, err, "unable to harvest entry 2: invalid exchange: unsupported method 'CONNECT'\n"+
+		"unable to decode the response of entry 3: illegal base64 data at input byte 0")
+	require.NotNil(t, doc)
+
+	require.Len(t, doc.Servers, 1)
+	assert.Equal(t, "https://api.example.com", doc.Servers[0].URL)
+
+	post := doc.Paths.PathItems.GetOrZero("/v1/orders").Post
+	require.NotNil(t, post)
+	require.Len(t, post.Parameters, 1)
+	assert.Equal(t, "dryRun", post.Parameters[0].Name)
+	assert.Equal(t, []string{"boolean"}, post.Parameters[0].Schema.Schema().Type)
+	items := post.RequestBody.Content.GetOrZero("application/json").Schema.Schema().Properties.GetOrZero("items")
+	item := items.Schema().Items.A.Schema()
+	assert.Equal(t, []string{"sku", "quantity"}, item.Required)
+	created := post.Responses.Codes.GetOrZero("201").Content.GetOrZero("application/json").Schema.Schema()
+	assert.Equal(t, "date-time", created.Properties.GetOrZero("id").Schema().Format)
+
+	order := doc.Paths.PathItems.GetOrZero("/v1/orders/{orderId}")
+	require.NotNil(t, order)
+	assert.Equal(t, "orderId", order.Parameters[0].Name)
+	assert.NotNil(t, order.Get)
+
+	_, err = FromHAR(strings.NewReader("not a har"))
+	assert.ErrorContains(t, err, "unable to read HAR file")
+}
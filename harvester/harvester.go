@@ -0,0 +1,409 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package harvester creates a draft OpenAPI 3+ document from recorded HTTP traffic, a starting point for documenting
+// an API that isn't. Exchanges (a request and its response) are harvested from HAR files, from requests and
+// responses of net/http, or one by one, and every exchange harvested adds to the document: its path, its method,
+// its query parameters, and the schemas of its request and response, inferred from their JSON payloads (see the
+// schemas package).
+//
+// The segments of a path that look like identifiers (numbers, UUIDs and long hexadecimal strings) become path
+// parameters, so `/pets/12` and `/pets/31` are both harvested as `/pets/{petId}`.
+//
+//	h := harvester.NewHarvester()
+//	if err := h.AddHAR(file); err != nil { ... }
+//	rendered, err := h.Document().Render()
+package harvester
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/schemas"
+)
+
+// ErrInvalidExchange is returned when an exchange can't be harvested.
+var ErrInvalidExchange = errors.New("invalid exchange")
+
+// Exchange is a recorded HTTP request and the response it was sent.
+type Exchange struct {
+	Method              string // the method of the request, such as GET.
+	URL                 string // the full URL of the request, such as https://api.example.com/pets?limit=10
+	RequestContentType  string
+	RequestBody         []byte
+	StatusCode          int
+	ResponseContentType string
+	ResponseBody        []byte
+}
+
+// methods are the methods harvested, in the order operations are rendered.
+var methods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodOptions, http.MethodHead,
+	http.MethodPatch, http.MethodTrace,
+}
+
+// Harvester collects exchanges, into a draft document. A Harvester is not safe for concurrent use.
+type Harvester struct {
+	options schemas.InferOptions
+	servers []string
+	paths   map[string]*harvestedPath
+}
+
+type harvestedPath struct {
+	parameters []*harvestedParameter // the path parameters, in the order of the path.
+	operations map[string]*harvestedOperation
+}
+
+type harvestedParameter struct {
+	name   string
+	schema *schemas.Inferrer
+}
+
+type harvestedOperation struct {
+	exchanges int
+	query     []*harvestedParameter
+	seen      map[string]int // the number of exchanges each query parameter is in.
+	bodies    int
+	requests  *orderedmap.Map[string, *schemas.Inferrer] // nil for payloads that aren't JSON.
+	responses map[int]*orderedmap.Map[string, *schemas.Inferrer]
+}
+
+// NewHarvester creates a new Harvester, without any exchanges, inferring schemas with the default options.
+func NewHarvester() *Harvester {
+	return NewHarvesterWithOptions(schemas.InferOptions{})
+}
+
+// NewHarvesterWithOptions creates a new Harvester, without any exchanges, inferring schemas with options.
+func NewHarvesterWithOptions(options schemas.InferOptions) *Harvester {
+	return &Harvester{options: options, paths: make(map[string]*harvestedPath)}
+}
+
+// Add harvests an exchange. Returns ErrInvalidExchange if the method isn't one of an operation of OpenAPI, or if the
+// URL can't be parsed.
+func (h *Harvester) Add(exchange *Exchange) error {
+	if exchange == nil {
+		return fmt.Errorf("%w: no exchange", ErrInvalidExchange)
+	}
+	method := strings.ToUpper(exchange.Method)
+	if !slices.Contains(methods, method) {
+		return fmt.Errorf("%w: unsupported method '%s'", ErrInvalidExchange, exchange.Method)
+	}
+	u, err := url.Parse(exchange.URL)
+	if err != nil {
+		return fmt.Errorf("%w: unable to parse URL '%s': %s", ErrInvalidExchange, exchange.URL, err.Error())
+	}
+	if u.Scheme != "" && u.Host != "" {
+		if server := u.Scheme + "://" + u.Host; !slices.Contains(h.servers, server) {
+			h.servers = append(h.servers, server)
+		}
+	}
+
+	template, values := templatePath(u.EscapedPath())
+	p := h.paths[template]
+	if p == nil {
+		p = &harvestedPath{operations: make(map[string]*harvestedOperation)}
+		for _, v := range values {
+			p.parameters = append(p.parameters, &harvestedParameter{name: v[0], schema: schemas.NewInferrer(h.options)})
+		}
+		h.paths[template] = p
+	}
+	for i, v := range values {
+		p.parameters[i].schema.AddScalar(v[1])
+	}
+
+	op := p.operations[method]
+	if op == nil {
+		op = &harvestedOperation{
+			seen:      make(map[string]int),
+			requests:  orderedmap.New[string, *schemas.Inferrer](),
+			responses: make(map[int]*orderedmap.Map[string, *schemas.Inferrer]),
+		}
+		p.operations[method] = op
+	}
+	op.exchanges++
+	query := u.Query()
+	for _, name := range queryNames(u.RawQuery) {
+		i := slices.IndexFunc(op.query, func(q *harvestedParameter) bool { return q.name == name })
+		if i < 0 {
+			op.query = append(op.query, &harvestedParameter{name: name, schema: schemas.NewInferrer(h.options)})
+			i = len(op.query) - 1
+		}
+		op.seen[name]++
+		for _, v := range query[name] {
+			op.query[i].schema.AddScalar(v)
+		}
+	}
+	if len(exchange.RequestBody) > 0 {
+		op.bodies++
+		h.addPayload(op.requests, exchange.RequestContentType, exchange.RequestBody)
+	}
+	if exchange.StatusCode > 0 {
+		content := op.responses[exchange.StatusCode]
+		if content == nil {
+			content = orderedmap.New[string, *schemas.Inferrer]()
+			op.responses[exchange.StatusCode] = content
+		}
+		if len(exchange.ResponseBody) > 0 {
+			h.addPayload(content, exchange.ResponseContentType, exchange.ResponseBody)
+		}
+	}
+	return nil
+}
+
+// AddHTTP harvests a request of net/http and the response it was sent. The bodies of both are read, and replaced
+// with the content read, so they can still be read once harvested.
+func (h *Harvester) AddHTTP(req *http.Request, res *http.Response) error {
+	if req == nil || req.URL == nil {
+		return fmt.Errorf("%w: no request", ErrInvalidExchange)
+	}
+	exchange := &Exchange{Method: req.Method, URL: req.URL.String(), RequestContentType: req.Header.Get("Content-Type")}
+	var err error
+	if exchange.RequestBody, err = readBody(&req.Body); err != nil {
+		return fmt.Errorf("unable to read request body: %w", err)
+	}
+	if res != nil {
+		exchange.StatusCode = res.StatusCode
+		exchange.ResponseContentType = res.Header.Get("Content-Type")
+		if exchange.ResponseBody, err = readBody(&res.Body); err != nil {
+			return fmt.Errorf("unable to read response body: %w", err)
+		}
+	}
+	return h.Add(exchange)
+}
+
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
+
+// Document returns the draft document of every exchange harvested, an OpenAPI 3.1 document, with paths sorted.
+func (h *Harvester) Document() *v3.Document {
+	doc := &v3.Document{
+		Version: "3.1.0",
+		Info:    &base.Info{Title: "Harvested API", Version: "1.0.0"},
+		Paths:   &v3.Paths{PathItems: orderedmap.New[string, *v3.PathItem]()},
+	}
+	for _, s := range h.servers {
+		doc.Servers = append(doc.Servers, &v3.Server{URL: s})
+	}
+	templates := make([]string, 0, len(h.paths))
+	for t := range h.paths {
+		templates = append(templates, t)
+	}
+	sort.Strings(templates)
+	for _, t := range templates {
+		p := h.paths[t]
+		item := &v3.PathItem{}
+		for _, param := range p.parameters {
+			item.Parameters = append(item.Parameters, &v3.Parameter{
+				Name: param.name, In: "path", Required: boolPtr(true), Schema: base.CreateSchemaProxy(param.schema.Schema()),
+			})
+		}
+		for _, method := range methods {
+			if op := p.operations[method]; op != nil {
+				setOperation(item, method, op.operation())
+			}
+		}
+		doc.Paths.PathItems.Set(t, item)
+	}
+	return doc
+}
+
+func (op *harvestedOperation) operation() *v3.Operation {
+	operation := &v3.Operation{Responses: &v3.Responses{Codes: orderedmap.New[string, *v3.Response]()}}
+	for _, q := range op.query {
+		param := &v3.Parameter{Name: q.name, In: "query", Schema: base.CreateSchemaProxy(q.schema.Schema())}
+		if op.seen[q.name] == op.exchanges {
+			param.Required = boolPtr(true)
+		}
+		operation.Parameters = append(operation.Parameters, param)
+	}
+	if op.bodies > 0 {
+		operation.RequestBody = &v3.RequestBody{Content: mediaTypes(op.requests)}
+		if op.bodies == op.exchanges {
+			operation.RequestBody.Required = boolPtr(true)
+		}
+	}
+	codes := make([]int, 0, len(op.responses))
+	for code := range op.responses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		response := &v3.Response{Description: http.StatusText(code)}
+		if response.Description == "" {
+			response.Description = "response"
+		}
+		if orderedmap.Len(op.responses[code]) > 0 {
+			response.Content = mediaTypes(op.responses[code])
+		}
+		operation.Responses.Codes.Set(strconv.Itoa(code), response)
+	}
+	return operation
+}
+
+func mediaTypes(content *orderedmap.Map[string, *schemas.Inferrer]) *orderedmap.Map[string, *v3.MediaType] {
+	media := orderedmap.New[string, *v3.MediaType]()
+	for contentType, inferrer := range content.FromOldest() {
+		schema := &base.Schema{Type: []string{"string"}}
+		if inferrer != nil {
+			schema = inferrer.Schema()
+		}
+		media.Set(contentType, &v3.MediaType{Schema: base.CreateSchemaProxy(schema)})
+	}
+	return media
+}
+
+func setOperation(item *v3.PathItem, method string, op *v3.Operation) {
+	switch method {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodDelete:
+		item.Delete = op
+	case http.MethodOptions:
+		item.Options = op
+	case http.MethodHead:
+		item.Head = op
+	case http.MethodPatch:
+		item.Patch = op
+	case http.MethodTrace:
+		item.Trace = op
+	}
+}
+
+// addPayload adds a payload to the schemas of its content type. JSON payloads are inferred, any other payload is a
+// string.
+func (h *Harvester) addPayload(content *orderedmap.Map[string, *schemas.Inferrer], contentType string, payload []byte) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	if !isJSON(mediaType) {
+		content.Set(mediaType, nil)
+		return
+	}
+	inferrer := content.GetOrZero(mediaType)
+	if inferrer == nil {
+		inferrer = schemas.NewInferrer(h.options)
+		content.Set(mediaType, inferrer)
+	}
+	_ = inferrer.AddPayload(payload)
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexPattern  = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+)
+
+// isIdentifier returns true if a segment of a path looks like it identifies something: a number, a UUID or a long
+// hexadecimal string.
+func isIdentifier(segment string) bool {
+	if _, err := strconv.ParseUint(segment, 10, 64); err == nil {
+		return true
+	}
+	return uuidPattern.MatchString(segment) ||
+		(hexPattern.MatchString(segment) && strings.ContainsAny(segment, "0123456789"))
+}
+
+// templatePath replaces the identifiers of a path with path parameters, named by the segment before them (`/pets/12`
+// is `/pets/{petId}`). Returns the template, and the name and value of each parameter.
+func templatePath(escaped string) (string, [][2]string) {
+	segments := strings.Split(escaped, "/")
+	var values [][2]string
+	for i, segment := range segments {
+		value, err := url.PathUnescape(segment)
+		if err != nil || !isIdentifier(value) {
+			continue
+		}
+		name := "id"
+		if i > 0 && segments[i-1] != "" && !strings.HasPrefix(segments[i-1], "{") {
+			name = parameterName(segments[i-1])
+		}
+		for n, taken := 2, name; ; n++ {
+			if !slices.ContainsFunc(values, func(v [2]string) bool { return v[0] == taken }) {
+				name = taken
+				break
+			}
+			taken = name + strconv.Itoa(n)
+		}
+		values = append(values, [2]string{name, value})
+		segments[i] = "{" + name + "}"
+	}
+	template := strings.Join(segments, "/")
+	if template == "" {
+		template = "/"
+	}
+	return template, values
+}
+
+// parameterName names the parameter identifying the segment before it, the segment in the singular and in camel
+// case, followed by Id (`line-items` is `lineItemId`).
+func parameterName(segment string) string {
+	segment, _ = url.PathUnescape(segment)
+	var sb strings.Builder
+	upper := false
+	for _, r := range segment {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = sb.Len() > 0
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	name := sb.String()
+	switch {
+	case name == "":
+		return "id"
+	case strings.HasSuffix(name, "ies"):
+		name = strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		name = strings.TrimSuffix(name, "s")
+	}
+	return name + "Id"
+}
+
+// queryNames returns the names of the parameters of a query, in the order of the query.
+func queryNames(rawQuery string) []string {
+	var names []string
+	for _, pair := range strings.Split(rawQuery, "&") {
+		name, _, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(name); err == nil && name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
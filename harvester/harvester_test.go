@@ -0,0 +1,190 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package harvester
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/schemas"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarvester_Document(t *testing.T) {
+	h := NewHarvester()
+	require.NoError(t, h.Add(&Exchange{
+		Method: "GET", URL: "https://api.example.com/pets?limit=10&tag=dog", StatusCode: 200,
+		ResponseContentType: "application/json; charset=utf-8",
+		ResponseBody:        []byte(`[{"name": "fido", "id": 1}, {"name": "rex", "id": 2, "tag": "dog"}]`),
+	}))
+	require.NoError(t, h.Add(&Exchange{
+		Method: "GET", URL: "https://api.example.com/pets?limit=20", StatusCode: 200,
+		ResponseContentType: "application/json", ResponseBody: []byte(`[]`),
+	}))
+	require.NoError(t, h.Add(&Exchange{
+		Method: "post", URL: "https://api.example.com/pets", StatusCode: 201,
+		RequestContentType: "application/json", RequestBody: []byte(`{"name": "fido"}`),
+	}))
+	require.NoError(t, h.Add(&Exchange{
+		Method: "GET", URL: "https://api.example.com/pets/12/toys/0cbd6a3a-1b7c-4d5e-9f6e-8a1b2c3d4e5f",
+		StatusCode: 404, ResponseContentType: "text/plain", ResponseBody: []byte("not found"),
+	}))
+
+	rendered, err := h.Document().Render()
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: Harvested API
+    version: 1.0.0
+servers:
+    - url: https://api.example.com
+paths:
+    /pets:
+        get:
+            parameters:
+                - name: limit
+                  in: query
+                  required: true
+                  schema:
+                    type: integer
+                - name: tag
+                  in: query
+                  schema:
+                    type: string
+            responses:
+                "200":
+                    description: OK
+                    content:
+                        application/json:
+                            schema:
+                                type: array
+                                items:
+                                    type: object
+                                    properties:
+                                        name:
+                                            type: string
+                                        id:
+                                            type: integer
+                                        tag:
+                                            type: string
+                                    required:
+                                        - name
+                                        - id
+        post:
+            requestBody:
+                content:
+                    application/json:
+                        schema:
+                            type: object
+                            properties:
+                                name:
+                                    type: string
+                            required:
+                                - name
+                required: true
+            responses:
+                "201":
+                    description: Created
+    /pets/{petId}/toys/{toyId}:
+        get:
+            responses:
+                "404":
+                    description: Not Found
+                    content:
+                        text/plain:
+                            schema:
+                                type: string
+        parameters:
+            - name: petId
+              in: path
+              required: true
+              schema:
+                type: integer
+            - name: toyId
+              in: path
+              required: true
+              schema:
+                type: string
+                format: uuid
+`, string(rendered))
+
+	// the draft is a valid document.
+	doc, err := libopenapi.NewDocument(rendered)
+	require.NoError(t, err)
+	_, errs := doc.BuildV3Model()
+	assert.Empty(t, errs)
+}
+
+func TestHarvester_Add_Invalid(t *testing.T) {
+	h := NewHarvester()
+	assert.ErrorIs(t, h.Add(nil), ErrInvalidExchange)
+	assert.ErrorIs(t, h.Add(&Exchange{Method: "CONNECT", URL: "/pets"}), ErrInvalidExchange)
+	err := h.Add(&Exchange{Method: "GET", URL: "http://[::1"})
+	assert.ErrorIs(t, err, ErrInvalidExchange)
+	assert.Contains(t, err.Error(), "unable to parse URL")
+	assert.Equal(t, 0, h.Document().Paths.PathItems.Len())
+}
+
+func TestHarvester_AddHTTP(t *testing.T) {
+	h := NewHarvester()
+	req := httptest.NewRequest(http.MethodPut, "http://localhost/stores/abc", strings.NewReader(`{"open": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	res := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/problem+json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"open": true, "rating": 4.5}`)),
+	}
+	require.NoError(t, h.AddHTTP(req, res))
+
+	// the bodies can still be read.
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"open": true, "rating": 4.5}`, string(body))
+	body, err = io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"open": true}`, string(body))
+
+	put := h.Document().Paths.PathItems.GetOrZero("/stores/abc").Put
+	require.NotNil(t, put)
+	schema := put.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/problem+json").Schema.Schema()
+	assert.Equal(t, []string{"number"}, schema.Properties.GetOrZero("rating").Schema().Type)
+	assert.Equal(t, []string{"boolean"},
+		put.RequestBody.Content.GetOrZero("application/json").Schema.Schema().Properties.GetOrZero("open").Schema().Type)
+
+	assert.ErrorIs(t, h.AddHTTP(nil, nil), ErrInvalidExchange)
+}
+
+func TestTemplatePath(t *testing.T) {
+	template, values := templatePath("/line-items/42/categories/7/1a2b3c4d5e6f7a8b9c")
+	assert.Equal(t, "/line-items/{lineItemId}/categories/{categoryId}/{id}", template)
+	assert.Equal(t, [][2]string{{"lineItemId", "42"}, {"categoryId", "7"}, {"id", "1a2b3c4d5e6f7a8b9c"}}, values)
+
+	template, values = templatePath("/12/users/me")
+	assert.Equal(t, "/{id}/users/me", template)
+	assert.Len(t, values, 1)
+
+	template, _ = templatePath("/pets/1/pets/2")
+	assert.Equal(t, "/pets/{petId}/pets/{petId2}", template)
+
+	template, _ = templatePath("")
+	assert.Equal(t, "/", template)
+}
+
+func TestNewHarvesterWithOptions(t *testing.T) {
+	h := NewHarvesterWithOptions(schemas.InferOptions{EnumThreshold: 2, EnumMinSamples: 2})
+	for _, status := range []string{"available", "sold", "sold"} {
+		require.NoError(t, h.Add(&Exchange{Method: "GET", URL: "/pets?status=" + status, StatusCode: 200}))
+	}
+	params := h.Document().Paths.PathItems.GetOrZero("/pets").Get.Parameters
+	require.Len(t, params, 1)
+	enum := params[0].Schema.Schema().Enum
+	require.Len(t, enum, 2)
+	assert.Equal(t, "available", enum[0].Value)
+	assert.Empty(t, h.Document().Servers)
+}
@@ -0,0 +1,216 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CallbackExpression describes a single expression of a Callback, and the runtime expressions used by it.
+type CallbackExpression struct {
+	// Expression is the key of the callback path item, e.g. {$request.body#/callbackUrl}/events
+	Expression string
+
+	// RuntimeExpressions are the runtime expressions found in the Expression, e.g. $request.body#/callbackUrl
+	RuntimeExpressions []string
+
+	// Callback is the name of the callback the expression belongs to, e.g. onEvent
+	Callback string
+
+	// Path and Method of the operation that owns the callback. Both are empty for callbacks defined
+	// under components.
+	Path   string
+	Method string
+
+	// Error is set if the expression contains an invalid runtime expression.
+	Error error
+
+	KeyNode  *yaml.Node // the key node of the expression
+	Node     *yaml.Node // the path item node of the expression
+	JSONPath string     // the JSON Path to the path item, e.g. $.paths['/pets'].post.callbacks['onEvent']['{$url}']
+}
+
+// GetAllCallbackExpressions returns every callback expression in the document, for callbacks used by operations
+// (referenced callbacks are followed), and callbacks defined under components. Expressions of operations come first,
+// in document order, followed by components.
+//
+// The view is built the first time it is requested.
+func (index *SpecIndex) GetAllCallbackExpressions() []*CallbackExpression {
+	index.callbackExpressionsOnce.Do(func() {
+		index.callbackExpressions = index.buildCallbackExpressions()
+	})
+	return index.callbackExpressions
+}
+
+func (index *SpecIndex) buildCallbackExpressions() []*CallbackExpression {
+	var expressions []*CallbackExpression
+	if index.root == nil {
+		return expressions
+	}
+
+	if index.pathsNode != nil && utils.IsNodeMap(index.pathsNode) {
+		for x := 0; x+1 < len(index.pathsNode.Content); x += 2 {
+			path := index.pathsNode.Content[x].Value
+			pathItemNode := index.pathsNode.Content[x+1]
+			if isRef, _, ref := utils.IsNodeRefValue(pathItemNode); isRef {
+				if pNode := seekRefEnd(index, ref); pNode != nil {
+					pathItemNode = pNode.Node
+				}
+			}
+			for y := 0; y+1 < len(pathItemNode.Content); y += 2 {
+				method := pathItemNode.Content[y].Value
+				if !isHttpMethod(method) && !strings.EqualFold(method, "trace") {
+					continue
+				}
+				_, callbacksNode := utils.FindKeyNodeTop("callbacks", pathItemNode.Content[y+1].Content)
+				jsonPath := fmt.Sprintf("$.paths['%s'].%s.callbacks", path, method)
+				expressions = append(expressions, index.extractCallbackExpressions(callbacksNode, path, method, jsonPath)...)
+			}
+		}
+	}
+
+	if len(index.root.Content) > 0 {
+		_, components := utils.FindKeyNodeTop("components", index.root.Content[0].Content)
+		if components != nil {
+			_, callbacksNode := utils.FindKeyNodeTop("callbacks", components.Content)
+			expressions = append(expressions,
+				index.extractCallbackExpressions(callbacksNode, "", "", "$.components.callbacks")...)
+		}
+	}
+	return expressions
+}
+
+func (index *SpecIndex) extractCallbackExpressions(callbacksNode *yaml.Node, path, method, jsonPath string) []*CallbackExpression {
+	if callbacksNode == nil || !utils.IsNodeMap(callbacksNode) {
+		return nil
+	}
+	var expressions []*CallbackExpression
+	for i := 0; i+1 < len(callbacksNode.Content); i += 2 {
+		name := callbacksNode.Content[i].Value
+		callbackNode := callbacksNode.Content[i+1]
+		if isRef, _, ref := utils.IsNodeRefValue(callbackNode); isRef {
+			if cNode := seekRefEnd(index, ref); cNode != nil {
+				callbackNode = cNode.Node
+			}
+		}
+		if !utils.IsNodeMap(callbackNode) {
+			continue
+		}
+		for j := 0; j+1 < len(callbackNode.Content); j += 2 {
+			key := callbackNode.Content[j]
+			if strings.HasPrefix(key.Value, "x-") || key.Value == "$ref" {
+				continue
+			}
+			runtime, err := ExtractRuntimeExpressions(key.Value)
+			expressions = append(expressions, &CallbackExpression{
+				Expression:         key.Value,
+				RuntimeExpressions: runtime,
+				Callback:           name,
+				Path:               path,
+				Method:             method,
+				Error:              err,
+				KeyNode:            key,
+				Node:               callbackNode.Content[j+1],
+				JSONPath:           fmt.Sprintf("%s['%s']['%s']", jsonPath, name, key.Value),
+			})
+		}
+	}
+	return expressions
+}
+
+// ExtractRuntimeExpressions returns the runtime expressions used by a callback or link expression. Runtime
+// expressions can be embedded in a string using curly braces (`https://{$request.query.host}/events`), or used as
+// the whole value (`$request.body#/url`). Every runtime expression found is validated, the first invalid one
+// (or unbalanced braces) returns an error.
+func ExtractRuntimeExpressions(expression string) ([]string, error) {
+	if !strings.Contains(expression, "{") && !strings.Contains(expression, "}") {
+		if strings.HasPrefix(expression, "$") {
+			return []string{expression}, ValidateRuntimeExpression(expression)
+		}
+		return nil, nil
+	}
+	var found []string
+	rest := expression
+	for {
+		open := strings.IndexByte(rest, '{')
+		end := strings.IndexByte(rest, '}')
+		if open < 0 && end < 0 {
+			return found, nil
+		}
+		if open < 0 || end < open {
+			return found, fmt.Errorf("invalid expression '%s': unbalanced braces", expression)
+		}
+		inner := rest[open+1:]
+		end = strings.IndexByte(inner, '}')
+		if end < 0 || strings.IndexByte(inner[:end], '{') >= 0 {
+			return found, fmt.Errorf("invalid expression '%s': unbalanced braces", expression)
+		}
+		exp := inner[:end]
+		found = append(found, exp)
+		if err := ValidateRuntimeExpression(exp); err != nil {
+			return found, fmt.Errorf("invalid expression '%s': %w", expression, err)
+		}
+		rest = inner[end+1:]
+	}
+}
+
+// ValidateRuntimeExpression checks a single runtime expression against the grammar defined by the specification.
+//   - https://spec.openapis.org/oas/v3.1.0#runtime-expressions
+func ValidateRuntimeExpression(expression string) error {
+	switch expression {
+	case "$url", "$method", "$statusCode":
+		return nil
+	}
+	var source string
+	switch {
+	case strings.HasPrefix(expression, "$request."):
+		source = strings.TrimPrefix(expression, "$request.")
+	case strings.HasPrefix(expression, "$response."):
+		source = strings.TrimPrefix(expression, "$response.")
+	default:
+		return fmt.Errorf("runtime expression '%s' must be $url, $method, $statusCode, "+
+			"or start with $request. or $response.", expression)
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(source, "header."):
+		token := strings.TrimPrefix(source, "header.")
+		if token == "" || strings.IndexFunc(token, func(r rune) bool { return !isHeaderTokenChar(r) }) >= 0 {
+			err = errors.New("header name is not a valid token")
+		}
+	case strings.HasPrefix(source, "query."):
+		if strings.TrimPrefix(source, "query.") == "" {
+			err = errors.New("query name is empty")
+		}
+	case strings.HasPrefix(source, "path."):
+		if strings.TrimPrefix(source, "path.") == "" {
+			err = errors.New("path name is empty")
+		}
+	case source == "body":
+	case strings.HasPrefix(source, "body#"):
+		if pointer := strings.TrimPrefix(source, "body#"); pointer != "" && !strings.HasPrefix(pointer, "/") {
+			err = errors.New("body reference must be a JSON pointer")
+		}
+	default:
+		err = errors.New("source must be header., query., path. or body")
+	}
+	if err != nil {
+		return fmt.Errorf("runtime expression '%s' is invalid: %w", expression, err)
+	}
+	return nil
+}
+
+// isHeaderTokenChar returns true if r can be used in an HTTP header name (tchar in RFC 7230).
+func isHeaderTokenChar(r rune) bool {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_GetAllCallbackExpressions(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /subscribe:
+    post:
+      callbacks:
+        onEvent:
+          '{$request.body#/callbackUrl}/events':
+            post:
+              description: event
+          x-thing: ignored
+        onStatus:
+          $ref: '#/components/callbacks/status'
+    get:
+      description: no callbacks
+components:
+  callbacks:
+    status:
+      'https://{$request.header.x-host}/status?id={$response.body#/id}':
+        post:
+          description: status
+    broken:
+      '{$request.nope}':
+        post:
+          description: broken`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	expressions := idx.GetAllCallbackExpressions()
+	assert.Len(t, expressions, 4)
+
+	assert.Equal(t, "{$request.body#/callbackUrl}/events", expressions[0].Expression)
+	assert.Equal(t, []string{"$request.body#/callbackUrl"}, expressions[0].RuntimeExpressions)
+	assert.Equal(t, "onEvent", expressions[0].Callback)
+	assert.Equal(t, "/subscribe", expressions[0].Path)
+	assert.Equal(t, "post", expressions[0].Method)
+	assert.NoError(t, expressions[0].Error)
+	assert.Equal(t, "$.paths['/subscribe'].post.callbacks['onEvent']['{$request.body#/callbackUrl}/events']",
+		expressions[0].JSONPath)
+	assert.Equal(t, 7, expressions[0].KeyNode.Line)
+	assert.Equal(t, 8, expressions[0].Node.Line)
+
+	// referenced callbacks are followed.
+	assert.Equal(t, "onStatus", expressions[1].Callback)
+	assert.Equal(t, "post", expressions[1].Method)
+	assert.Equal(t, []string{"$request.header.x-host", "$response.body#/id"}, expressions[1].RuntimeExpressions)
+
+	// component callbacks have no operation.
+	assert.Equal(t, "status", expressions[2].Callback)
+	assert.Empty(t, expressions[2].Path)
+	assert.Empty(t, expressions[2].Method)
+	assert.Equal(t, "$.components.callbacks['status']['https://{$request.header.x-host}/status?id={$response.body#/id}']",
+		expressions[2].JSONPath)
+
+	assert.Equal(t, "broken", expressions[3].Callback)
+	assert.EqualError(t, expressions[3].Error, "invalid expression '{$request.nope}': runtime expression "+
+		"'$request.nope' is invalid: source must be header., query., path. or body")
+
+	// the view is only built once.
+	assert.Same(t, expressions[0], idx.GetAllCallbackExpressions()[0])
+}
+
+func TestSpecIndex_GetAllCallbackExpressions_Empty(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0`), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetAllCallbackExpressions())
+}
+
+func TestExtractRuntimeExpressions(t *testing.T) {
+	exp, err := ExtractRuntimeExpressions("$request.body#/url")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"$request.body#/url"}, exp)
+
+	exp, err = ExtractRuntimeExpressions("https://example.com/events")
+	assert.NoError(t, err)
+	assert.Nil(t, exp)
+
+	exp, err = ExtractRuntimeExpressions("{$url}?method={$method}&code={$statusCode}")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"$url", "$method", "$statusCode"}, exp)
+
+	_, err = ExtractRuntimeExpressions("{$url")
+	assert.EqualError(t, err, "invalid expression '{$url': unbalanced braces")
+
+	_, err = ExtractRuntimeExpressions("$url}")
+	assert.EqualError(t, err, "invalid expression '$url}': unbalanced braces")
+
+	_, err = ExtractRuntimeExpressions("{{$url}}")
+	assert.EqualError(t, err, "invalid expression '{{$url}}': unbalanced braces")
+}
+
+func TestValidateRuntimeExpression(t *testing.T) {
+	valid := []string{
+		"$url", "$method", "$statusCode", "$request.header.X-Trace", "$request.query.id", "$request.path.petId",
+		"$request.body", "$request.body#", "$request.body#/a/b~1c", "$response.header.Location",
+	}
+	for _, v := range valid {
+		assert.NoError(t, ValidateRuntimeExpression(v), v)
+	}
+
+	invalid := map[string]string{
+		"$nope":                 "runtime expression '$nope' must be $url, $method, $statusCode, or start with $request. or $response.",
+		"$request.header.":      "runtime expression '$request.header.' is invalid: header name is not a valid token",
+		"$request.header.a b":   "runtime expression '$request.header.a b' is invalid: header name is not a valid token",
+		"$request.query.":       "runtime expression '$request.query.' is invalid: query name is empty",
+		"$response.path.":       "runtime expression '$response.path.' is invalid: path name is empty",
+		"$request.body#nope":    "runtime expression '$request.body#nope' is invalid: body reference must be a JSON pointer",
+		"$response.cookie.test": "runtime expression '$response.cookie.test' is invalid: source must be header., query., path. or body",
+	}
+	for v, msg := range invalid {
+		assert.EqualError(t, ValidateRuntimeExpression(v), msg, v)
+	}
+}
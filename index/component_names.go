@@ -0,0 +1,204 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"gopkg.in/yaml.v3"
+)
+
+// ComponentName is a component defined in a file of the rolodex, and the name it's given once the components of
+// every file are combined.
+type ComponentName struct {
+	Type           string     // the type of the component: schemas, parameters, responses...
+	Name           string     // the name of the component in its file.
+	MappedName     string     // the name of the component once combined.
+	FullDefinition string     // where the component is defined, such as /specs/pets.yaml#/components/schemas/Pet
+	Node           *yaml.Node // the component.
+	Index          *SpecIndex // the index of the file the component is defined in.
+
+	// Collision is true when a different component of the same type and name is defined before this one. With the
+	// first-wins strategy (datamodel.ComponentCollisionFirstWins) the component keeps its name, and is replaced by
+	// the one defined first.
+	Collision bool
+}
+
+// ComponentNameMapping is the name of every component of every file of a rolodex, once combined.
+type ComponentNameMapping struct {
+	Strategy   datamodel.ComponentCollisionStrategy
+	Components []*ComponentName // in the order they're combined: the root document, then every file by path.
+
+	definitions map[string]*ComponentName
+}
+
+// Find returns the component defined at a full definition (such as /specs/pets.yaml#/components/schemas/Pet), or
+// nil if there isn't one.
+func (m *ComponentNameMapping) Find(fullDefinition string) *ComponentName {
+	return m.definitions[fullDefinition]
+}
+
+// Collisions returns the components defined after a different component of the same type and name.
+func (m *ComponentNameMapping) Collisions() []*ComponentName {
+	var collisions []*ComponentName
+	for _, c := range m.Components {
+		if c.Collision {
+			collisions = append(collisions, c)
+		}
+	}
+	return collisions
+}
+
+// ComponentNames names the components of every file of the rolodex once they're combined into a single set of
+// components, using the ComponentCollisionStrategy of the index configuration. See ComponentNamesWithStrategy.
+func (r *Rolodex) ComponentNames() (*ComponentNameMapping, error) {
+	var strategy datamodel.ComponentCollisionStrategy
+	if r.indexConfig != nil {
+		strategy = r.indexConfig.ComponentCollisionStrategy
+	}
+	return r.ComponentNamesWithStrategy(strategy)
+}
+
+// ComponentNamesWithStrategy names the components of every file of the rolodex once they're combined into a single
+// set of components. The components of the root document keep their names, then the components of every other
+// file are added, in the order of their paths. A component colliding with one added before it (of the same type
+// and name) is named by the strategy, unless both have the same content, in which case they're the same component
+// and not a collision. With the datamodel.ComponentCollisionError strategy, every collision is returned as an
+// error.
+func (r *Rolodex) ComponentNamesWithStrategy(
+	strategy datamodel.ComponentCollisionStrategy,
+) (*ComponentNameMapping, error) {
+	mapping := &ComponentNameMapping{Strategy: strategy, definitions: make(map[string]*ComponentName)}
+	indexes := r.GetIndexes()
+	sorted := make([]*SpecIndex, 0, len(indexes)+1)
+	for _, idx := range indexes {
+		if idx != nil && idx != r.rootIndex &&
+			(r.rootIndex == nil || idx.GetSpecAbsolutePath() != r.rootIndex.GetSpecAbsolutePath()) {
+			sorted = append(sorted, idx)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GetSpecAbsolutePath() < sorted[j].GetSpecAbsolutePath()
+	})
+	if r.rootIndex != nil {
+		sorted = append([]*SpecIndex{r.rootIndex}, sorted...)
+	}
+
+	// the names given by type and name, then by content, and the type and names taken.
+	names := make(map[string]map[string]string)
+	taken := make(map[string]*ComponentName)
+	var errs []error
+	for _, idx := range sorted {
+		for _, c := range fileComponents(idx) {
+			mapping.Components = append(mapping.Components, c)
+			mapping.definitions[c.FullDefinition] = c
+			key, hash := c.Type+"/"+c.Name, componentHash(c.Node)
+			if names[key] == nil {
+				names[key] = make(map[string]string)
+			}
+			if mapped, ok := names[key][hash]; ok {
+				c.MappedName = mapped
+				continue
+			}
+			first, ok := taken[key]
+			if !ok {
+				names[key][hash] = c.Name
+				taken[key] = c
+				continue
+			}
+			c.Collision = true
+			switch strategy {
+			case datamodel.ComponentCollisionError:
+				errs = append(errs, fmt.Errorf("unable to combine components, %s '%s' is defined in both '%s' and '%s'",
+					c.Type, c.Name, first.FullDefinition, c.FullDefinition))
+				continue
+			case datamodel.ComponentCollisionFirstWins:
+				continue
+			case datamodel.ComponentCollisionFilePrefix:
+				base := filepath.Base(idx.GetSpecAbsolutePath())
+				c.MappedName = componentNamePrefix(strings.TrimSuffix(base, filepath.Ext(base))) + "_" + c.Name
+			case datamodel.ComponentCollisionContentHash:
+				c.MappedName = c.Name + "_" + hash[:8]
+			}
+			// a renamed component can collide again, with a component defined with that name.
+			mapped := c.MappedName
+			for i := 2; ; i++ {
+				if _, exists := taken[c.Type+"/"+mapped]; !exists {
+					break
+				}
+				mapped = c.MappedName + "_" + strconv.Itoa(i)
+			}
+			c.MappedName = mapped
+			names[key][hash] = mapped
+			taken[c.Type+"/"+mapped] = c
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return mapping, nil
+}
+
+// fileComponents returns the components of a file, by type, in the order of the file.
+func fileComponents(idx *SpecIndex) []*ComponentName {
+	root := idx.GetRootNode()
+	if root == nil || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	var components *yaml.Node
+	doc := root.Content[0]
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == "components" && doc.Content[i+1].Kind == yaml.MappingNode {
+			components = doc.Content[i+1]
+		}
+	}
+	if components == nil {
+		return nil
+	}
+	var found []*ComponentName
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		kind, defined := components.Content[i].Value, components.Content[i+1]
+		if strings.HasPrefix(kind, "x-") || defined.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j+1 < len(defined.Content); j += 2 {
+			name := defined.Content[j].Value
+			found = append(found, &ComponentName{
+				Type:           kind,
+				Name:           name,
+				MappedName:     name,
+				FullDefinition: fmt.Sprintf("%s#/components/%s/%s", idx.GetSpecAbsolutePath(), kind, name),
+				Node:           defined.Content[j+1],
+				Index:          idx,
+			})
+		}
+	}
+	return found
+}
+
+// componentHash hashes the content of a component, wherever it's defined.
+func componentHash(node *yaml.Node) string {
+	b, _ := yaml.Marshal(node)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// componentNamePrefix replaces the characters of a file name that can't be used in a component name.
+func componentNamePrefix(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' ||
+			r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
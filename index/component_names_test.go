@@ -0,0 +1,159 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func componentNamesRolodex(t *testing.T, strategy datamodel.ComponentCollisionStrategy) (*Rolodex, string) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"pets.yaml": `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tag:
+          type: string
+    Error:
+      type: string
+  parameters:
+    Limit:
+      name: limit
+      in: query`,
+		"stores.yaml": `components:
+  schemas:
+    Pet:
+      type: integer
+    Error:
+      type: string
+    pets_Pet:
+      type: boolean`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'pets.yaml#/components/parameters/Limit'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'stores.yaml#/components/schemas/Pet'
+        default:
+          description: error
+          content:
+            application/json:
+              schema:
+                $ref: 'pets.yaml#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object`), &rootNode)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	cf.SpecAbsolutePath = filepath.Join(dir, "root.yaml")
+	cf.ComponentCollisionStrategy = strategy
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: dir,
+		DirFS:         os.DirFS(dir),
+	})
+	require.NoError(t, err)
+	rolo.AddLocalFS(dir, fileFS)
+	require.NoError(t, rolo.IndexTheRolodex())
+	return rolo, dir
+}
+
+func mappedNames(mapping *ComponentNameMapping) map[string]string {
+	names := make(map[string]string)
+	for _, c := range mapping.Components {
+		names[filepath.Base(c.Index.GetSpecAbsolutePath())+"#/components/"+c.Type+"/"+c.Name] = c.MappedName
+	}
+	return names
+}
+
+func TestRolodex_ComponentNames_Error(t *testing.T) {
+	rolo, dir := componentNamesRolodex(t, datamodel.ComponentCollisionError)
+
+	_, err := rolo.ComponentNames()
+	assert.EqualError(t, err, "unable to combine components, schemas 'Pet' is defined in both '"+
+		filepath.Join(dir, "root.yaml")+"#/components/schemas/Pet' and '"+
+		filepath.Join(dir, "pets.yaml")+"#/components/schemas/Pet'\n"+
+		"unable to combine components, schemas 'Pet' is defined in both '"+
+		filepath.Join(dir, "root.yaml")+"#/components/schemas/Pet' and '"+
+		filepath.Join(dir, "stores.yaml")+"#/components/schemas/Pet'")
+}
+
+func TestRolodex_ComponentNames_FirstWins(t *testing.T) {
+	rolo, dir := componentNamesRolodex(t, datamodel.ComponentCollisionFirstWins)
+
+	mapping, err := rolo.ComponentNames()
+	require.NoError(t, err)
+	assert.Equal(t, datamodel.ComponentCollisionFirstWins, mapping.Strategy)
+	assert.Equal(t, map[string]string{
+		"root.yaml#/components/schemas/Pet":        "Pet",
+		"pets.yaml#/components/schemas/Pet":        "Pet",
+		"pets.yaml#/components/schemas/Error":      "Error",
+		"pets.yaml#/components/parameters/Limit":   "Limit",
+		"stores.yaml#/components/schemas/Pet":      "Pet",
+		"stores.yaml#/components/schemas/Error":    "Error",
+		"stores.yaml#/components/schemas/pets_Pet": "pets_Pet",
+	}, mappedNames(mapping))
+	require.Len(t, mapping.Collisions(), 2)
+
+	// the same component defined twice is not a collision.
+	stored := mapping.Find(filepath.Join(dir, "stores.yaml") + "#/components/schemas/Error")
+	require.NotNil(t, stored)
+	assert.False(t, stored.Collision)
+	assert.Equal(t, "schemas", stored.Type)
+	assert.Equal(t, "string", stored.Node.Content[1].Value)
+	assert.Equal(t, filepath.Join(dir, "stores.yaml"), stored.Index.GetSpecAbsolutePath())
+	assert.Nil(t, mapping.Find("nope.yaml#/components/schemas/Pet"))
+}
+
+func TestRolodex_ComponentNames_FilePrefix(t *testing.T) {
+	rolo, _ := componentNamesRolodex(t, datamodel.ComponentCollisionFilePrefix)
+
+	mapping, err := rolo.ComponentNames()
+	require.NoError(t, err)
+	names := mappedNames(mapping)
+	assert.Equal(t, "Pet", names["root.yaml#/components/schemas/Pet"])
+	assert.Equal(t, "pets_Pet", names["pets.yaml#/components/schemas/Pet"])
+	assert.Equal(t, "stores_Pet", names["stores.yaml#/components/schemas/Pet"])
+	// a component named like a component renamed before it collides with it.
+	assert.Equal(t, "stores_pets_Pet", names["stores.yaml#/components/schemas/pets_Pet"])
+	assert.Equal(t, "Error", names["stores.yaml#/components/schemas/Error"])
+}
+
+func TestRolodex_ComponentNames_ContentHash(t *testing.T) {
+	rolo, _ := componentNamesRolodex(t, datamodel.ComponentCollisionContentHash)
+
+	mapping, err := rolo.ComponentNamesWithStrategy(datamodel.ComponentCollisionContentHash)
+	require.NoError(t, err)
+	names := mappedNames(mapping)
+	assert.Equal(t, "Pet", names["root.yaml#/components/schemas/Pet"])
+	assert.Regexp(t, `^Pet_[0-9a-f]{8}$`, names["pets.yaml#/components/schemas/Pet"])
+	assert.Regexp(t, `^Pet_[0-9a-f]{8}$`, names["stores.yaml#/components/schemas/Pet"])
+	assert.NotEqual(t, names["pets.yaml#/components/schemas/Pet"], names["stores.yaml#/components/schemas/Pet"])
+	assert.Equal(t, "pets_Pet", names["stores.yaml#/components/schemas/pets_Pet"])
+}
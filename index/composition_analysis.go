@@ -0,0 +1,136 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CompositionStats describes how far the allOf, oneOf and anyOf compositions of a component schema reach, with
+// references followed.
+type CompositionStats struct {
+	// Definition is the definition of the schema, for example '#/components/schemas/Pet'.
+	Definition string
+
+	// Node is the schema node.
+	Node *yaml.Node
+
+	// Depth is the deepest nesting of compositions. A schema that isn't composed has a depth of 0, a schema
+	// composed of schemas that aren't composed has a depth of 1, and so on.
+	Depth int
+
+	// Size is the number of schemas the schema expands to when every composition is expanded, including itself.
+	// Schemas are counted every time they are referenced. Sizes too large to count are math.MaxInt.
+	Size int
+}
+
+// AnalyzeCompositions returns the CompositionStats of every component schema (or definition, for Swagger), the
+// largest first. Each schema is only analyzed once, however often it's referenced, so this is fast even for the
+// pathological specifications it finds. Circular references are not followed, they are reported by the resolver.
+func (index *SpecIndex) AnalyzeCompositions() []*CompositionStats {
+	a := &compositionAnalysis{
+		results:  make(map[*yaml.Node]compositionResult),
+		visiting: make(map[*yaml.Node]bool),
+	}
+	var stats []*CompositionStats
+	for def, ref := range index.GetAllComponentSchemas() {
+		if ref == nil || ref.Node == nil {
+			continue
+		}
+		r := a.analyze(ref.Node, index)
+		stats = append(stats, &CompositionStats{Definition: def, Node: ref.Node, Depth: r.depth, Size: r.size})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Size != stats[j].Size {
+			return stats[i].Size > stats[j].Size
+		}
+		return stats[i].Definition < stats[j].Definition
+	})
+	return stats
+}
+
+// CheckCompositionLimits checks every component schema against the composition limits of the index configuration
+// (MaxCompositionDepth and MaxCompositionSize), before the compositions are resolved. Returns an error naming every
+// schema over a limit, or nil if there are no composition limits or none are exceeded.
+func (index *SpecIndex) CheckCompositionLimits() error {
+	if index == nil || index.config == nil {
+		return nil
+	}
+	limits := index.config.Limits
+	if limits.MaxCompositionDepth <= 0 && limits.MaxCompositionSize <= 0 {
+		return nil
+	}
+	var errs []error
+	for _, s := range index.AnalyzeCompositions() {
+		if err := limits.CheckComposition(s.Definition, s.Depth, s.Size); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type compositionResult struct {
+	depth int
+	size  int
+}
+
+type compositionAnalysis struct {
+	results  map[*yaml.Node]compositionResult
+	visiting map[*yaml.Node]bool
+}
+
+func (a *compositionAnalysis) analyze(node *yaml.Node, idx *SpecIndex) compositionResult {
+	if r, ok := a.results[node]; ok {
+		return r
+	}
+	if a.visiting[node] {
+		return compositionResult{}
+	}
+	a.visiting[node] = true
+	defer delete(a.visiting, node)
+
+	var r compositionResult
+	if isRef, _, ref := utils.IsNodeRefValue(node); isRef {
+		if found := idx.FindComponent(ref); found != nil && found.Node != nil {
+			foundIdx := idx
+			if found.Index != nil {
+				foundIdx = found.Index
+			}
+			r = a.analyze(found.Node, foundIdx)
+		} else {
+			r = compositionResult{size: 1}
+		}
+		a.results[node] = r
+		return r
+	}
+
+	r.size = 1
+	if utils.IsNodeMap(node) {
+		for i := 0; i < len(node.Content)-1; i += 2 {
+			switch node.Content[i].Value {
+			case "allOf", "oneOf", "anyOf":
+				for _, schema := range node.Content[i+1].Content {
+					child := a.analyze(schema, idx)
+					r.depth = max(r.depth, child.depth+1)
+					r.size = addCompositionSize(r.size, child.size)
+				}
+			}
+		}
+	}
+	a.results[node] = r
+	return r
+}
+
+// addCompositionSize adds two sizes, saturating at math.MaxInt.
+func addCompositionSize(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
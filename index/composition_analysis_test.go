@@ -0,0 +1,104 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// compositionBomb returns a specification where each of the levels schemas is composed of the previous one twice,
+// so the last one expands to 2^(levels+1)-1 schemas.
+func compositionBomb(levels int) string {
+	var b strings.Builder
+	b.WriteString("openapi: 3.1.0\ncomponents:\n  schemas:\n    S0:\n      type: object\n")
+	for i := 1; i <= levels; i++ {
+		fmt.Fprintf(&b, "    S%d:\n      allOf:\n        - $ref: '#/components/schemas/S%d'\n"+
+			"        - $ref: '#/components/schemas/S%d'\n", i, i-1, i-1)
+	}
+	return b.String()
+}
+
+func indexComposition(t *testing.T, spec string, limits datamodel.DocumentLimits) *SpecIndex {
+	var rootNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &rootNode))
+	config := CreateOpenAPIIndexConfig()
+	config.Limits = limits
+	return NewSpecIndexWithConfig(&rootNode, config)
+}
+
+func TestSpecIndex_AnalyzeCompositions(t *testing.T) {
+	idx := indexComposition(t, compositionBomb(3), datamodel.DocumentLimits{})
+
+	stats := idx.AnalyzeCompositions()
+	require.Len(t, stats, 4)
+	assert.Equal(t, "#/components/schemas/S3", stats[0].Definition)
+	assert.Equal(t, 3, stats[0].Depth)
+	assert.Equal(t, 15, stats[0].Size)
+	assert.Equal(t, 7, stats[1].Size)
+	assert.Equal(t, "#/components/schemas/S0", stats[3].Definition)
+	assert.Equal(t, 0, stats[3].Depth)
+	assert.Equal(t, 1, stats[3].Size)
+	assert.NotNil(t, stats[3].Node)
+}
+
+func TestSpecIndex_AnalyzeCompositions_Saturates(t *testing.T) {
+	idx := indexComposition(t, compositionBomb(100), datamodel.DocumentLimits{})
+
+	stats := idx.AnalyzeCompositions()
+	require.Len(t, stats, 101)
+	assert.Equal(t, "#/components/schemas/S100", stats[0].Definition)
+	assert.Equal(t, 100, stats[0].Depth)
+	assert.Equal(t, math.MaxInt, stats[0].Size)
+}
+
+func TestSpecIndex_AnalyzeCompositions_Circular(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    A:
+      oneOf:
+        - $ref: '#/components/schemas/B'
+        - type: string
+    B:
+      anyOf:
+        - $ref: '#/components/schemas/A'
+        - $ref: '#/components/schemas/Missing'`
+
+	stats := indexComposition(t, spec, datamodel.DocumentLimits{}).AnalyzeCompositions()
+	require.Len(t, stats, 2)
+	for _, s := range stats {
+		assert.Positive(t, s.Size)
+		assert.LessOrEqual(t, s.Depth, 2)
+	}
+}
+
+func TestSpecIndex_CheckCompositionLimits(t *testing.T) {
+	spec := compositionBomb(20)
+
+	assert.NoError(t, indexComposition(t, spec, datamodel.DocumentLimits{}).CheckCompositionLimits())
+	assert.NoError(t, indexComposition(t, spec, datamodel.DocumentLimits{MaxCompositionSize: 1 << 22}).CheckCompositionLimits())
+
+	err := indexComposition(t, spec, datamodel.DocumentLimits{MaxCompositionSize: 1 << 19}).CheckCompositionLimits()
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+	assert.Equal(t, "document limit exceeded: schema '#/components/schemas/S20' expands to 2097151 schemas, which "+
+		"exceeds the maximum composition size of 524288 (reduce the schemas its allOf, oneOf and anyOf chains share)\n"+
+		"document limit exceeded: schema '#/components/schemas/S19' expands to 1048575 schemas, which "+
+		"exceeds the maximum composition size of 524288 (reduce the schemas its allOf, oneOf and anyOf chains share)",
+		err.Error())
+
+	err = indexComposition(t, spec, datamodel.DocumentLimits{MaxCompositionDepth: 19}).CheckCompositionLimits()
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+	assert.Contains(t, err.Error(), "schema '#/components/schemas/S20' nests compositions 20 deep")
+
+	var idx *SpecIndex
+	assert.NoError(t, idx.CheckCompositionLimits())
+}
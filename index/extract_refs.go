@@ -4,6 +4,7 @@
 package index
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -19,7 +20,14 @@ import (
 // ExtractRefs will return a deduplicated slice of references for every unique ref found in the document.
 // The total number of refs, will generally be much higher, you can extract those from GetRawReferenceCount()
 func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string, level int, poly bool, pName string) []*Reference {
-	if node == nil {
+	return index.extractRefs(context.Background(), node, parent, seenPath, level, poly, pName)
+}
+
+// extractRefs is ExtractRefs, stopping as soon as ctx is done, so a single large file can't be walked without limit.
+func (index *SpecIndex) extractRefs(ctx context.Context, node, parent *yaml.Node, seenPath []string, level int,
+	poly bool, pName string,
+) []*Reference {
+	if node == nil || ctx.Err() != nil {
 		return nil
 	}
 	var found []*Reference
@@ -38,7 +46,7 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 						polyName = prev
 					}
 				}
-				found = append(found, index.ExtractRefs(n, node, seenPath, level, poly, polyName)...)
+				found = append(found, index.extractRefs(ctx, n, node, seenPath, level, poly, polyName)...)
 			}
 
 			// check if we're dealing with an inline schema definition, that isn't part of an array
@@ -341,6 +349,11 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 					}
 
 					_, p := utils.ConvertComponentIdIntoFriendlyPathSearch(componentName)
+					if index.interner != nil {
+						fullDefinitionPath = index.interner.Intern(fullDefinitionPath)
+						componentName = index.interner.Intern(componentName)
+						p = index.interner.Intern(p)
+					}
 
 					ref := &Reference{
 						ParentNode:     parent,
@@ -616,6 +629,11 @@ func (index *SpecIndex) ExtractRefs(node, parent *yaml.Node, seenPath []string,
 // ExtractComponentsFromRefs returns located components from references. The returned nodes from here
 // can be used for resolving as they contain the actual object properties.
 func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Reference {
+	return index.extractComponentsFromRefs(context.Background(), refs)
+}
+
+// extractComponentsFromRefs is ExtractComponentsFromRefs, references not yet located once ctx is done are skipped.
+func (index *SpecIndex) extractComponentsFromRefs(ctx context.Context, refs []*Reference) []*Reference {
 	var found []*Reference
 
 	// run this async because when things get recursive, it can take a while
@@ -638,9 +656,14 @@ func (index *SpecIndex) ExtractComponentsFromRefs(refs []*Reference) []*Referenc
 				c <- true
 			}
 			index.refLock.Unlock()
+		} else if ctx.Err() != nil {
+			index.refLock.Unlock()
+			if !index.config.ExtractRefsSequentially {
+				c <- true
+			}
 		} else {
 			index.refLock.Unlock()
-			located := index.FindComponent(ref.FullDefinition)
+			located := index.FindComponentWithContext(ctx, ref.FullDefinition)
 			if located != nil {
 
 				// have we already mapped this?
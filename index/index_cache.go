@@ -0,0 +1,794 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrIndexCacheStale is returned when an index cache was not written for the same specification and configuration
+// (or was written by a different version of libopenapi), so it has to be rebuilt.
+var ErrIndexCacheStale = errors.New("index cache is stale")
+
+// indexCacheMagic starts every index cache file (nodeCacheMagic every tree of nodes encoded by EncodeNodes),
+// indexCacheVersion changes with the format of both.
+const (
+	indexCacheMagic   = "LIBOPENAPI-INDEX"
+	nodeCacheMagic    = "LIBOPENAPI-NODES"
+	indexCacheVersion = 1
+)
+
+// NewSpecIndexWithCache creates a new index of the specification in source, exactly like NewSpecIndexWithConfig
+// would, using the index cache at cachePath when it was written for the same source (and the same configuration).
+// The specification is then not parsed, and its references are not extracted again: the parsed nodes and the
+// extracted references are loaded from the cache, by memory mapping it.
+//
+// When the cache is missing, stale or damaged, the specification is parsed and indexed, and the cache is written
+// (atomically, so concurrent tools never read half a cache) with the result, ready for the next time. Returns an
+// error if the specification can't be parsed, or the cache can't be written.
+//
+// Remote and file references are still looked up through the rolodex of the configuration, every time.
+func NewSpecIndexWithCache(source []byte, cachePath string, config *SpecIndexConfig) (*SpecIndex, error) {
+	if index, err := LoadSpecIndexCache(source, cachePath, config); err == nil {
+		return index, nil
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(source, &root); err != nil {
+		return nil, fmt.Errorf("unable to parse the specification to index: %w", err)
+	}
+	index := newSpecIndexWithConfig(config)
+	if len(root.Content) == 0 {
+		return index, nil
+	}
+	index.root = &root
+	if config.Normalize {
+		utils.NormalizeNodes(&root)
+	}
+	if index.interner != nil {
+		index.interner.InternNodes(&root)
+	}
+	startNewIndex(index)
+	results := index.ExtractRefs(index.root.Content[0], index.root, []string{}, 0, false, "")
+
+	// the cache holds what was extracted before the components are, those are located again when loading.
+	data := encodeIndexCache(indexCacheKey(source, config), index, results)
+	index = completeNewIndex(context.Background(), index, results, config.AvoidBuildIndex)
+	if err := writeIndexCache(cachePath, data); err != nil {
+		return index, fmt.Errorf("unable to write the index cache '%s': %w", cachePath, err)
+	}
+	return index, nil
+}
+
+// LoadSpecIndexCache loads the index of the specification in source from the index cache at cachePath (written by
+// NewSpecIndexWithCache), without parsing the specification. Returns ErrIndexCacheStale if the cache was written
+// for something else, or any other error if it can't be read.
+func LoadSpecIndexCache(source []byte, cachePath string, config *SpecIndexConfig) (*SpecIndex, error) {
+	data, release, err := mapIndexCache(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// every string is copied out of the mapping, so it can be released once loaded.
+	r := &indexCacheReader{data: data}
+	if !r.header(indexCacheKey(source, config)) {
+		return nil, ErrIndexCacheStale
+	}
+	index := newSpecIndexWithConfig(config)
+	r.intern = index.interner
+	index.root = r.decodeRoot()
+	results := r.decode(index)
+	if r.err != nil {
+		return nil, fmt.Errorf("unable to load the index cache '%s': %w", cachePath, r.err)
+	}
+	if index.root == nil || len(index.root.Content) == 0 {
+		index.root = nil
+		return index, nil
+	}
+	startNewIndex(index)
+	return completeNewIndex(context.Background(), index, results, config.AvoidBuildIndex), nil
+}
+
+// indexCacheKey hashes everything the extracted references depend on.
+func indexCacheKey(source []byte, config *SpecIndexConfig) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write(source)
+	baseURL := ""
+	if config.BaseURL != nil {
+		baseURL = config.BaseURL.String()
+	}
+	for _, s := range []string{config.SpecAbsolutePath, config.BasePath, baseURL} {
+		h.Write(binary.AppendUvarint(nil, uint64(len(s))))
+		h.Write([]byte(s))
+	}
+	// normalized nodes are cached normalized, so they can't be served to those that didn't ask for it (or back).
+	if config.Normalize {
+		h.Write([]byte{1})
+	} else {
+		h.Write([]byte{0})
+	}
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+	return key
+}
+
+func writeIndexCache(cachePath string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(cachePath), filepath.Base(cachePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Close()
+	} else {
+		_ = f.Close()
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), cachePath)
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+	}
+	return err
+}
+
+// encodeIndexCache encodes the root node of index, and everything ExtractRefs found. Nodes and references are
+// written once, and referred to by ID (their position, plus one, so zero is nil) everywhere else.
+func encodeIndexCache(key [sha256.Size]byte, index *SpecIndex, results []*Reference) []byte {
+	w := newIndexCacheWriter()
+	w.node(&w.body, index.root)
+	w.refList(&w.body, results)
+	w.uint(&w.body, uint64(len(index.allRefs)))
+	for k, v := range index.allRefs {
+		w.string(&w.body, k)
+		w.ref(&w.body, v)
+	}
+	w.refList(&w.body, index.rawSequencedRefs)
+	w.lines(&w.body, index.linesWithRefs)
+	w.uint(&w.body, uint64(len(index.refsByLine)))
+	for k, v := range index.refsByLine {
+		w.string(&w.body, k)
+		w.lines(&w.body, v)
+	}
+	w.uint(&w.body, uint64(len(index.polymorphicRefs)))
+	for k, v := range index.polymorphicRefs {
+		w.string(&w.body, k)
+		w.ref(&w.body, v)
+	}
+	for _, refs := range [][]*Reference{
+		index.polymorphicAllOfRefs, index.polymorphicOneOfRefs, index.polymorphicAnyOfRefs,
+		index.allRefSchemaDefinitions, index.allInlineSchemaDefinitions, index.allInlineSchemaObjectDefinitions,
+	} {
+		w.refList(&w.body, refs)
+	}
+	for _, descriptions := range [][]*DescriptionReference{index.allDescriptions, index.allSummaries} {
+		w.uint(&w.body, uint64(len(descriptions)))
+		for _, d := range descriptions {
+			w.string(&w.body, d.Content)
+			w.string(&w.body, d.Path)
+			w.node(&w.body, d.KeyNode)
+			w.node(&w.body, d.Node)
+			w.node(&w.body, d.ParentNode)
+			w.bool(&w.body, d.IsSummary)
+		}
+	}
+	w.uint(&w.body, uint64(len(index.allEnums)))
+	for _, e := range index.allEnums {
+		for _, n := range []*yaml.Node{e.Node, e.KeyNode, e.Type, e.SchemaNode, e.ParentNode} {
+			w.node(&w.body, n)
+		}
+		w.string(&w.body, e.Path)
+	}
+	w.uint(&w.body, uint64(len(index.allObjectsWithProperties)))
+	for _, o := range index.allObjectsWithProperties {
+		w.node(&w.body, o.Node)
+		w.node(&w.body, o.KeyNode)
+		w.string(&w.body, o.Path)
+		w.node(&w.body, o.ParentNode)
+	}
+	w.uint(&w.body, uint64(len(index.refsWithSiblings)))
+	for k, v := range index.refsWithSiblings {
+		w.string(&w.body, k)
+		w.ref(&w.body, &v)
+	}
+	w.uint(&w.body, uint64(len(index.securityRequirementRefs)))
+	for k, v := range index.securityRequirementRefs {
+		w.string(&w.body, k)
+		w.uint(&w.body, uint64(len(v)))
+		for kk, refs := range v {
+			w.string(&w.body, kk)
+			w.refList(&w.body, refs)
+		}
+	}
+	for _, n := range []int{index.refCount, index.descriptionCount, index.summaryCount, index.enumCount} {
+		w.int(&w.body, n)
+	}
+	w.uint(&w.body, uint64(len(index.refErrors)))
+	for _, err := range index.refErrors {
+		var ie *IndexingError
+		if errors.As(err, &ie) {
+			w.bool(&w.body, true)
+			w.string(&w.body, err.Error())
+			w.node(&w.body, ie.Node)
+			w.node(&w.body, ie.KeyNode)
+			w.string(&w.body, ie.Path)
+		} else {
+			w.bool(&w.body, false)
+			w.string(&w.body, err.Error())
+		}
+	}
+
+	// references only refer to nodes and strings, and nodes only to nodes and strings, so they are encoded in
+	// that order, each adding what it refers to.
+	var refs, nodes []byte
+	for i := 0; i < len(w.refOrder); i++ {
+		w.encodeRef(&refs, w.refOrder[i])
+	}
+	for i := 0; i < len(w.nodeOrder); i++ {
+		w.encodeNode(&nodes, w.nodeOrder[i])
+	}
+
+	data := append([]byte(indexCacheMagic), key[:]...)
+	data = binary.AppendUvarint(data, indexCacheVersion)
+	data = w.appendTables(data, nodes)
+	data = binary.AppendUvarint(data, uint64(len(w.refOrder)))
+	data = append(data, refs...)
+	return append(data, w.body...)
+}
+
+// SpecIndexCache is an index cache held in memory rather than in a file: the parsed nodes of a specification, and the
+// references indexing them extracted. It is encoded by EncodeSpecIndexCache and decoded by DecodeSpecIndexCache.
+//
+// A rolodex indexing the same nodes, with the same configuration, uses the cache found in its context (see
+// WithSpecIndexCache) to create the root index without extracting the references again. It is used once: the
+// references are owned by the index created from them, so indexing again extracts them as usual.
+type SpecIndexCache struct {
+	lock sync.Mutex
+	key  [sha256.Size]byte
+	root *yaml.Node
+	r    *indexCacheReader
+}
+
+// SpecIndexCacheKey is the context key used to carry a *SpecIndexCache to the rolodex indexing the specification.
+const SpecIndexCacheKey ContextKey = "specIndexCache"
+
+// WithSpecIndexCache returns a copy of ctx that carries cache.
+func WithSpecIndexCache(ctx context.Context, cache *SpecIndexCache) context.Context {
+	return context.WithValue(ctx, SpecIndexCacheKey, cache)
+}
+
+// GetSpecIndexCache returns the *SpecIndexCache carried by ctx, or nil if there isn't one.
+func GetSpecIndexCache(ctx context.Context) *SpecIndexCache {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(SpecIndexCacheKey).(*SpecIndexCache)
+	return c
+}
+
+// EncodeSpecIndexCache extracts the references of root (the nodes parsed from source) exactly like indexing them
+// with config does, and encodes the nodes and the references as an index cache, held in memory.
+func EncodeSpecIndexCache(source []byte, root *yaml.Node, config *SpecIndexConfig) []byte {
+	index := newSpecIndexWithConfig(config)
+	index.root = root
+	var results []*Reference
+	if root != nil && len(root.Content) > 0 {
+		startNewIndex(index)
+		results = index.extractRefs(context.Background(), root.Content[0], root, []string{}, 0, false, "")
+		<-index.nodeMapCompleted
+	}
+	return encodeIndexCache(indexCacheKey(source, config), index, results)
+}
+
+// DecodeSpecIndexCache decodes an index cache encoded by EncodeSpecIndexCache. Returns an error if the data was not
+// encoded by EncodeSpecIndexCache (or by a different version of libopenapi), or is damaged.
+func DecodeSpecIndexCache(data []byte) (*SpecIndexCache, error) {
+	n := len(indexCacheMagic) + sha256.Size
+	if len(data) < n || string(data[:len(indexCacheMagic)]) != indexCacheMagic {
+		return nil, errIndexCacheUnknown
+	}
+	// the rest of the data is decoded when the cache is used, so it's kept.
+	c := &SpecIndexCache{key: [sha256.Size]byte(data[len(indexCacheMagic):n])}
+	c.r = &indexCacheReader{data: bytes.Clone(data), pos: n}
+	if c.r.uint() != indexCacheVersion || c.r.err != nil {
+		return nil, errIndexCacheUnknown
+	}
+	c.root = c.r.decodeRoot()
+	if c.r.err != nil {
+		return nil, c.r.err
+	}
+	return c, nil
+}
+
+// GetRootNode returns the root node of the specification held by the cache.
+func (c *SpecIndexCache) GetRootNode() *yaml.Node {
+	return c.root
+}
+
+// newSpecIndex creates the index of root from the cache, if the cache holds root and was encoded for the same
+// source and configuration, and hasn't been used already. Returns nil otherwise.
+func (c *SpecIndexCache) newSpecIndex(ctx context.Context, root *yaml.Node, config *SpecIndexConfig) *SpecIndex {
+	if c == nil || root == nil || root != c.root || len(root.Content) == 0 ||
+		config.SpecInfo == nil || config.SpecInfo.SpecBytes == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.r == nil || c.key != indexCacheKey(*config.SpecInfo.SpecBytes, config) {
+		return nil
+	}
+	r := c.r
+	c.r = nil
+	index := newSpecIndexWithConfig(config)
+	index.root = root
+	results := r.decode(index)
+	if r.err != nil {
+		return nil
+	}
+	if index.interner != nil {
+		index.interner.InternNodes(root)
+	}
+	startNewIndex(index)
+	return completeNewIndex(ctx, index, results, config.AvoidBuildIndex)
+}
+
+// EncodeNodes encodes a tree of nodes (with the line, column, style and comments of every node) in the binary
+// format of the index caches: every node and every string is written once, so aliases and shared nodes stay
+// shared. DecodeNodes decodes the tree again, far faster than the YAML it was parsed from can be parsed.
+func EncodeNodes(root *yaml.Node) []byte {
+	w := newIndexCacheWriter()
+	w.node(&w.body, root)
+	var nodes []byte
+	for i := 0; i < len(w.nodeOrder); i++ {
+		w.encodeNode(&nodes, w.nodeOrder[i])
+	}
+	data := binary.AppendUvarint([]byte(nodeCacheMagic), indexCacheVersion)
+	data = w.appendTables(data, nodes)
+	return append(data, w.body...)
+}
+
+// DecodeNodes decodes a tree of nodes encoded by EncodeNodes. Returns an error if the data was not encoded by
+// EncodeNodes (or by a different version of libopenapi), or is damaged.
+func DecodeNodes(data []byte) (*yaml.Node, error) {
+	r := &indexCacheReader{data: data}
+	if len(data) < len(nodeCacheMagic) || string(data[:len(nodeCacheMagic)]) != nodeCacheMagic {
+		return nil, errNodeCacheUnknown
+	}
+	r.pos = len(nodeCacheMagic)
+	if r.uint() != indexCacheVersion || r.err != nil {
+		return nil, errNodeCacheUnknown
+	}
+	r.decodeTables()
+	root := r.node()
+	if r.err == nil && r.pos != len(r.data) {
+		r.fail()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return root, nil
+}
+
+func newIndexCacheWriter() *indexCacheWriter {
+	return &indexCacheWriter{
+		strings: make(map[string]uint64),
+		nodes:   make(map[*yaml.Node]uint64),
+		refs:    make(map[*Reference]uint64),
+	}
+}
+
+type indexCacheWriter struct {
+	body        []byte
+	strings     map[string]uint64
+	stringOrder []string
+	nodes       map[*yaml.Node]uint64
+	nodeOrder   []*yaml.Node
+	refs        map[*Reference]uint64
+	refOrder    []*Reference
+}
+
+func (w *indexCacheWriter) uint(b *[]byte, v uint64) {
+	*b = binary.AppendUvarint(*b, v)
+}
+
+func (w *indexCacheWriter) int(b *[]byte, v int) {
+	*b = binary.AppendVarint(*b, int64(v))
+}
+
+func (w *indexCacheWriter) bool(b *[]byte, v bool) {
+	if v {
+		*b = append(*b, 1)
+	} else {
+		*b = append(*b, 0)
+	}
+}
+
+func (w *indexCacheWriter) string(b *[]byte, s string) {
+	id, ok := w.strings[s]
+	if !ok {
+		w.stringOrder = append(w.stringOrder, s)
+		id = uint64(len(w.stringOrder) - 1)
+		w.strings[s] = id
+	}
+	w.uint(b, id)
+}
+
+func (w *indexCacheWriter) node(b *[]byte, n *yaml.Node) {
+	if n == nil {
+		w.uint(b, 0)
+		return
+	}
+	id, ok := w.nodes[n]
+	if !ok {
+		w.nodeOrder = append(w.nodeOrder, n)
+		id = uint64(len(w.nodeOrder))
+		w.nodes[n] = id
+	}
+	w.uint(b, id)
+}
+
+func (w *indexCacheWriter) ref(b *[]byte, r *Reference) {
+	if r == nil {
+		w.uint(b, 0)
+		return
+	}
+	id, ok := w.refs[r]
+	if !ok {
+		w.refOrder = append(w.refOrder, r)
+		id = uint64(len(w.refOrder))
+		w.refs[r] = id
+	}
+	w.uint(b, id)
+}
+
+func (w *indexCacheWriter) refList(b *[]byte, refs []*Reference) {
+	w.uint(b, uint64(len(refs)))
+	for _, r := range refs {
+		w.ref(b, r)
+	}
+}
+
+func (w *indexCacheWriter) lines(b *[]byte, lines map[int]bool) {
+	w.uint(b, uint64(len(lines)))
+	for k, v := range lines {
+		w.int(b, k)
+		w.bool(b, v)
+	}
+}
+
+// appendTables appends the strings, then the encoded nodes, to data.
+func (w *indexCacheWriter) appendTables(data, nodes []byte) []byte {
+	data = binary.AppendUvarint(data, uint64(len(w.stringOrder)))
+	for _, s := range w.stringOrder {
+		data = binary.AppendUvarint(data, uint64(len(s)))
+		data = append(data, s...)
+	}
+	data = binary.AppendUvarint(data, uint64(len(w.nodeOrder)))
+	return append(data, nodes...)
+}
+
+func (w *indexCacheWriter) encodeNode(b *[]byte, n *yaml.Node) {
+	w.uint(b, uint64(n.Kind))
+	w.uint(b, uint64(n.Style))
+	for _, s := range []string{n.Tag, n.Value, n.Anchor, n.HeadComment, n.LineComment, n.FootComment} {
+		w.string(b, s)
+	}
+	w.int(b, n.Line)
+	w.int(b, n.Column)
+	w.node(b, n.Alias)
+	w.uint(b, uint64(len(n.Content)))
+	for _, c := range n.Content {
+		w.node(b, c)
+	}
+}
+
+func (w *indexCacheWriter) encodeRef(b *[]byte, r *Reference) {
+	for _, s := range []string{r.FullDefinition, r.Definition, r.Name} {
+		w.string(b, s)
+	}
+	w.node(b, r.Node)
+	w.node(b, r.KeyNode)
+	w.node(b, r.ParentNode)
+	w.string(b, r.ParentNodeSchemaType)
+	w.uint(b, uint64(len(r.ParentNodeTypes)))
+	for _, t := range r.ParentNodeTypes {
+		w.string(b, t)
+	}
+	for _, v := range []bool{r.Resolved, r.Circular, r.Seen, r.IsRemote, r.Index != nil} {
+		w.bool(b, v)
+	}
+	w.string(b, r.RemoteLocation)
+	w.string(b, r.Path)
+	w.uint(b, uint64(len(r.RequiredRefProperties)))
+	for k, v := range r.RequiredRefProperties {
+		w.string(b, k)
+		w.uint(b, uint64(len(v)))
+		for _, p := range v {
+			w.string(b, p)
+		}
+	}
+}
+
+type indexCacheReader struct {
+	data    []byte
+	pos     int
+	err     error
+	intern  *utils.Interner
+	strings []string
+	nodes   []yaml.Node
+	refs    []Reference
+	indexed []bool
+}
+
+var (
+	errIndexCacheDamaged = errors.New("the index cache is damaged")
+	errNodeCacheUnknown  = errors.New("the data is not encoded nodes, or was encoded by a different version")
+	errIndexCacheUnknown = errors.New("the data is not an index cache, or was encoded by a different version")
+)
+
+// header checks the magic, key and version of the cache.
+func (r *indexCacheReader) header(key [sha256.Size]byte) bool {
+	n := len(indexCacheMagic) + sha256.Size
+	if len(r.data) < n || string(r.data[:len(indexCacheMagic)]) != indexCacheMagic ||
+		[sha256.Size]byte(r.data[len(indexCacheMagic):n]) != key {
+		return false
+	}
+	r.pos = n
+	return r.uint() == indexCacheVersion && r.err == nil
+}
+
+func (r *indexCacheReader) fail() {
+	if r.err == nil {
+		r.err = errIndexCacheDamaged
+	}
+	r.pos = len(r.data)
+}
+
+func (r *indexCacheReader) uint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.pos += n
+	return v
+}
+
+func (r *indexCacheReader) int() int {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.pos += n
+	return int(v)
+}
+
+// count reads the length of something with at least one byte per item, so a damaged count can't be huge.
+func (r *indexCacheReader) count() int {
+	v := r.uint()
+	if v > uint64(len(r.data)-r.pos) {
+		r.fail()
+		return 0
+	}
+	return int(v)
+}
+
+func (r *indexCacheReader) bool() bool {
+	if r.pos >= len(r.data) {
+		r.fail()
+		return false
+	}
+	r.pos++
+	return r.data[r.pos-1] == 1
+}
+
+func (r *indexCacheReader) string() string {
+	id := r.uint()
+	if id >= uint64(len(r.strings)) {
+		r.fail()
+		return ""
+	}
+	return r.strings[id]
+}
+
+func (r *indexCacheReader) node() *yaml.Node {
+	id := r.uint()
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(r.nodes)) {
+		r.fail()
+		return nil
+	}
+	return &r.nodes[id-1]
+}
+
+func (r *indexCacheReader) ref() *Reference {
+	id := r.uint()
+	if id == 0 {
+		return nil
+	}
+	if id > uint64(len(r.refs)) {
+		r.fail()
+		return nil
+	}
+	return &r.refs[id-1]
+}
+
+func (r *indexCacheReader) refList() []*Reference {
+	n := r.count()
+	if n == 0 {
+		return nil
+	}
+	refs := make([]*Reference, n)
+	for i := range refs {
+		refs[i] = r.ref()
+	}
+	return refs
+}
+
+func (r *indexCacheReader) lines() map[int]bool {
+	n := r.count()
+	lines := make(map[int]bool, n)
+	for i := 0; i < n; i++ {
+		k := r.int()
+		lines[k] = r.bool()
+	}
+	return lines
+}
+
+// decodeTables decodes the strings, then the nodes, written by appendTables.
+func (r *indexCacheReader) decodeTables() {
+	r.strings = make([]string, r.count())
+	for i := range r.strings {
+		n := r.count()
+		r.strings[i] = string(r.data[r.pos : r.pos+n])
+		if r.intern != nil {
+			r.strings[i] = r.intern.Intern(r.strings[i])
+		}
+		r.pos += n
+	}
+	r.nodes = make([]yaml.Node, r.count())
+	for i := range r.nodes {
+		n := &r.nodes[i]
+		n.Kind = yaml.Kind(r.uint())
+		n.Style = yaml.Style(r.uint())
+		n.Tag, n.Value, n.Anchor = r.string(), r.string(), r.string()
+		n.HeadComment, n.LineComment, n.FootComment = r.string(), r.string(), r.string()
+		n.Line, n.Column = r.int(), r.int()
+		n.Alias = r.node()
+		if c := r.count(); c > 0 {
+			n.Content = make([]*yaml.Node, c)
+			for j := range n.Content {
+				n.Content[j] = r.node()
+			}
+		}
+	}
+}
+
+// decodeRoot decodes the strings, nodes and references encoded by encodeIndexCache, and returns the root node. The
+// references are not part of an index until the rest is decoded by decode.
+func (r *indexCacheReader) decodeRoot() *yaml.Node {
+	r.decodeTables()
+	r.refs = make([]Reference, r.count())
+	r.indexed = make([]bool, len(r.refs))
+	for i := range r.refs {
+		ref := &r.refs[i]
+		ref.FullDefinition, ref.Definition, ref.Name = r.string(), r.string(), r.string()
+		ref.Node, ref.KeyNode, ref.ParentNode = r.node(), r.node(), r.node()
+		ref.ParentNodeSchemaType = r.string()
+		if n := r.count(); n > 0 {
+			ref.ParentNodeTypes = make([]string, n)
+			for j := range ref.ParentNodeTypes {
+				ref.ParentNodeTypes[j] = r.string()
+			}
+		}
+		ref.Resolved, ref.Circular, ref.Seen, ref.IsRemote = r.bool(), r.bool(), r.bool(), r.bool()
+		r.indexed[i] = r.bool()
+		ref.RemoteLocation, ref.Path = r.string(), r.string()
+		if n := r.count(); n > 0 {
+			ref.RequiredRefProperties = make(map[string][]string, n)
+			for j := 0; j < n; j++ {
+				k := r.string()
+				props := make([]string, r.count())
+				for p := range props {
+					props[p] = r.string()
+				}
+				ref.RequiredRefProperties[k] = props
+			}
+		}
+	}
+
+	return r.node()
+}
+
+// decode decodes the rest of what encodeIndexCache encoded into index, and returns the references ExtractRefs
+// returned.
+func (r *indexCacheReader) decode(index *SpecIndex) []*Reference {
+	for i := range r.refs {
+		if r.indexed[i] {
+			r.refs[i].Index = index
+		}
+	}
+	results := r.refList()
+	for n := r.count(); n > 0; n-- {
+		k := r.string()
+		index.allRefs[k] = r.ref()
+	}
+	index.rawSequencedRefs = r.refList()
+	index.linesWithRefs = r.lines()
+	for n := r.count(); n > 0; n-- {
+		k := r.string()
+		index.refsByLine[k] = r.lines()
+	}
+	for n := r.count(); n > 0; n-- {
+		k := r.string()
+		index.polymorphicRefs[k] = r.ref()
+	}
+	for _, refs := range []*[]*Reference{
+		&index.polymorphicAllOfRefs, &index.polymorphicOneOfRefs, &index.polymorphicAnyOfRefs,
+		&index.allRefSchemaDefinitions, &index.allInlineSchemaDefinitions, &index.allInlineSchemaObjectDefinitions,
+	} {
+		*refs = r.refList()
+	}
+	for _, descriptions := range []*[]*DescriptionReference{&index.allDescriptions, &index.allSummaries} {
+		for n := r.count(); n > 0; n-- {
+			d := &DescriptionReference{Content: r.string(), Path: r.string()}
+			d.KeyNode, d.Node, d.ParentNode = r.node(), r.node(), r.node()
+			d.IsSummary = r.bool()
+			*descriptions = append(*descriptions, d)
+		}
+	}
+	for n := r.count(); n > 0; n-- {
+		e := new(EnumReference)
+		e.Node, e.KeyNode, e.Type, e.SchemaNode, e.ParentNode = r.node(), r.node(), r.node(), r.node(), r.node()
+		e.Path = r.string()
+		index.allEnums = append(index.allEnums, e)
+	}
+	for n := r.count(); n > 0; n-- {
+		o := new(ObjectReference)
+		o.Node, o.KeyNode, o.Path, o.ParentNode = r.node(), r.node(), r.string(), r.node()
+		index.allObjectsWithProperties = append(index.allObjectsWithProperties, o)
+	}
+	for n := r.count(); n > 0; n-- {
+		k := r.string()
+		if ref := r.ref(); ref != nil {
+			index.refsWithSiblings[k] = *ref
+		}
+	}
+	for n := r.count(); n > 0; n-- {
+		k := r.string()
+		requirements := make(map[string][]*Reference)
+		for m := r.count(); m > 0; m-- {
+			kk := r.string()
+			requirements[kk] = r.refList()
+		}
+		index.securityRequirementRefs[k] = requirements
+	}
+	index.refCount, index.descriptionCount, index.summaryCount, index.enumCount = r.int(), r.int(), r.int(), r.int()
+	for n := r.count(); n > 0; n-- {
+		if r.bool() {
+			ie := &IndexingError{Err: errors.New(r.string())}
+			ie.Node, ie.KeyNode, ie.Path = r.node(), r.node(), r.string()
+			index.refErrors = append(index.refErrors, ie)
+		} else {
+			index.refErrors = append(index.refErrors, errors.New(r.string()))
+		}
+	}
+	if r.err == nil && r.pos != len(r.data) {
+		r.fail()
+	}
+	return results
+}
@@ -0,0 +1,32 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+//go:build unix
+
+package index
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapIndexCache memory maps an index cache, read only. The mapping must be released once the cache is loaded.
+func mapIndexCache(cachePath string) ([]byte, func(), error) {
+	f, err := os.Open(cachePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Size() == 0 {
+		return nil, func() {}, nil
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() { _ = syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package index
+
+import "os"
+
+// mapIndexCache reads an index cache, on platforms without memory mapping.
+func mapIndexCache(cachePath string) ([]byte, func(), error) {
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() {}, nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewSpecIndexWithCache(t *testing.T) {
+	source, err := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	require.NoError(t, err)
+	cachePath := filepath.Join(t.TempDir(), "burgershop.idx")
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal(source, &root))
+	expected := NewSpecIndexWithConfig(&root, CreateOpenAPIIndexConfig())
+
+	built, err := NewSpecIndexWithCache(source, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	assert.FileExists(t, cachePath)
+
+	loaded, err := LoadSpecIndexCache(source, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+
+	for _, idx := range []*SpecIndex{built, loaded} {
+		assert.Equal(t, len(expected.GetAllReferences()), len(idx.GetAllReferences()))
+		assert.Equal(t, len(expected.GetRawReferencesSequenced()), len(idx.GetRawReferencesSequenced()))
+		assert.Equal(t, len(expected.GetMappedReferences()), len(idx.GetMappedReferences()))
+		assert.Equal(t, len(expected.GetMappedReferencesSequenced()), len(idx.GetMappedReferencesSequenced()))
+		assert.Equal(t, len(expected.GetAllComponentSchemas()), len(idx.GetAllComponentSchemas()))
+		assert.Equal(t, len(expected.GetAllInlineSchemas()), len(idx.GetAllInlineSchemas()))
+		assert.Equal(t, len(expected.GetAllDescriptions()), len(idx.GetAllDescriptions()))
+		assert.Equal(t, len(expected.GetAllEnums()), len(idx.GetAllEnums()))
+		assert.Equal(t, len(expected.GetReferenceIndexErrors()), len(idx.GetReferenceIndexErrors()))
+		assert.Equal(t, expected.GetPathCount(), idx.GetPathCount())
+		assert.Equal(t, expected.GetOperationCount(), idx.GetOperationCount())
+		assert.Equal(t, expected.GetTotalTagsCount(), idx.GetTotalTagsCount())
+		assert.Equal(t, expected.GetAllDescriptionsCount(), idx.GetAllDescriptionsCount())
+	}
+
+	// the loaded nodes are the same nodes, and the references point into them.
+	rendered, err := yaml.Marshal(loaded.GetRootNode())
+	require.NoError(t, err)
+	original, err := yaml.Marshal(&root)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(rendered))
+
+	for _, ref := range loaded.GetMappedReferences() {
+		require.NotNil(t, ref.Node)
+		assert.Equal(t, loaded, ref.Index)
+		found := loaded.GetNodeMap()[ref.Node.Line][ref.Node.Column]
+		assert.Same(t, ref.Node, found)
+	}
+}
+
+func TestNewSpecIndexWithCache_Invalidated(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "spec.idx")
+	source := []byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'`)
+
+	idx, err := NewSpecIndexWithCache(source, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	assert.Len(t, idx.GetAllComponentSchemas(), 2)
+
+	updated := []byte(string(source) + `
+    Toy:
+      $ref: '#/components/schemas/Pet'`)
+	_, err = LoadSpecIndexCache(updated, cachePath, CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrIndexCacheStale)
+
+	// a different configuration extracts different references, so it's stale too.
+	config := CreateOpenAPIIndexConfig()
+	config.SpecAbsolutePath = "/specs/pets.yaml"
+	_, err = LoadSpecIndexCache(source, cachePath, config)
+	assert.ErrorIs(t, err, ErrIndexCacheStale)
+
+	idx, err = NewSpecIndexWithCache(updated, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	assert.Len(t, idx.GetAllComponentSchemas(), 3)
+
+	idx, err = LoadSpecIndexCache(updated, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	assert.Len(t, idx.GetAllComponentSchemas(), 3)
+	assert.Len(t, idx.GetAllReferences(), 1)
+}
+
+func TestNewSpecIndexWithCache_Normalize(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "spec.idx")
+	source := []byte(`openapi: 3.1.0
+components:
+  schemas:
+    Name:
+      type: [string]`)
+	typeOf := func(idx *SpecIndex) *yaml.Node {
+		schema := idx.GetAllComponentSchemas()["#/components/schemas/Name"]
+		require.NotNil(t, schema)
+		_, typeNode := utils.FindKeyNodeTop("type", schema.Node.Content)
+		require.NotNil(t, typeNode)
+		return typeNode
+	}
+
+	idx, err := NewSpecIndexWithCache(source, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	assert.Equal(t, yaml.SequenceNode, typeOf(idx).Kind)
+
+	// the cache was written without normalizing, so it's stale for those that normalize.
+	config := CreateOpenAPIIndexConfig()
+	config.Normalize = true
+	_, err = LoadSpecIndexCache(source, cachePath, config)
+	assert.ErrorIs(t, err, ErrIndexCacheStale)
+	idx, err = NewSpecIndexWithCache(source, cachePath, config)
+	require.NoError(t, err)
+	assert.Equal(t, "string", typeOf(idx).Value)
+	idx, err = LoadSpecIndexCache(source, cachePath, config)
+	require.NoError(t, err)
+	assert.Equal(t, "string", typeOf(idx).Value)
+
+	// and the other way around.
+	_, err = LoadSpecIndexCache(source, cachePath, CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrIndexCacheStale)
+}
+
+func TestNewSpecIndexWithCache_Damaged(t *testing.T) {
+	cachePath := filepath.Join(t.TempDir(), "spec.idx")
+	source := []byte(`openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      description: pets
+      responses:
+        "200":
+          $ref: '#/components/responses/Missing'`)
+
+	_, err := NewSpecIndexWithCache(source, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	data, err := os.ReadFile(cachePath)
+	require.NoError(t, err)
+
+	loaded, err := LoadSpecIndexCache(source, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	require.Len(t, loaded.GetReferenceIndexErrors(), 1)
+	assert.Equal(t, "component `#/components/responses/Missing` does not exist in the specification",
+		loaded.GetReferenceIndexErrors()[0].Error())
+
+	require.NoError(t, os.WriteFile(cachePath, data[:len(data)-3], 0o644))
+	_, err = LoadSpecIndexCache(source, cachePath, CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, errIndexCacheDamaged)
+
+	// a damaged cache is rebuilt.
+	idx, err := NewSpecIndexWithCache(source, cachePath, CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	assert.Equal(t, 1, idx.GetPathCount())
+	rebuilt, err := os.ReadFile(cachePath)
+	require.NoError(t, err)
+	assert.Len(t, rebuilt, len(data))
+
+	require.NoError(t, os.WriteFile(cachePath, nil, 0o644))
+	_, err = LoadSpecIndexCache(source, cachePath, CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, ErrIndexCacheStale)
+
+	_, err = LoadSpecIndexCache(source, filepath.Join(t.TempDir(), "missing.idx"), CreateOpenAPIIndexConfig())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestEncodeNodes(t *testing.T) {
+	source := []byte(`# pets
+openapi: 3.1.0 # version
+paths:
+  /pets: &pets
+    get:
+      description: "pets"
+  /animals: *pets
+tags: [a, b]`)
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal(source, &root))
+
+	decoded, err := DecodeNodes(EncodeNodes(&root))
+	require.NoError(t, err)
+	rendered, err := yaml.Marshal(decoded)
+	require.NoError(t, err)
+	original, err := yaml.Marshal(&root)
+	require.NoError(t, err)
+	assert.Equal(t, string(original), string(rendered))
+
+	// positions and styles are kept, and aliases point at their anchors.
+	paths := decoded.Content[0].Content[3]
+	assert.Equal(t, 4, paths.Content[1].Line)
+	assert.Equal(t, 10, paths.Content[1].Column)
+	assert.Equal(t, yaml.DoubleQuotedStyle, paths.Content[1].Content[1].Content[1].Style)
+	assert.Same(t, paths.Content[1], paths.Content[3].Alias)
+	assert.Equal(t, "# pets", decoded.Content[0].Content[0].HeadComment)
+
+	empty, err := DecodeNodes(EncodeNodes(nil))
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestDecodeNodes_Errors(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`openapi: 3.1.0`), &root))
+	data := EncodeNodes(&root)
+
+	_, err := DecodeNodes(data[:len(data)-1])
+	assert.ErrorIs(t, err, errIndexCacheDamaged)
+	_, err = DecodeNodes(append(data, 0))
+	assert.ErrorIs(t, err, errIndexCacheDamaged)
+	_, err = DecodeNodes([]byte("something else"))
+	assert.ErrorIs(t, err, errNodeCacheUnknown)
+	_, err = DecodeNodes(append([]byte(nodeCacheMagic), 99))
+	assert.ErrorIs(t, err, errNodeCacheUnknown)
+}
+
+func TestNewSpecIndexWithCache_Errors(t *testing.T) {
+	_, err := NewSpecIndexWithCache([]byte("openapi: [3.1.0"), filepath.Join(t.TempDir(), "spec.idx"),
+		CreateOpenAPIIndexConfig())
+	assert.ErrorContains(t, err, "unable to parse the specification to index")
+
+	idx, err := NewSpecIndexWithCache([]byte("openapi: 3.1.0"), filepath.Join(t.TempDir(), "missing", "spec.idx"),
+		CreateOpenAPIIndexConfig())
+	assert.ErrorContains(t, err, "unable to write the index cache")
+	assert.NotNil(t, idx)
+
+	idx, err = NewSpecIndexWithCache(nil, filepath.Join(t.TempDir(), "spec.idx"), CreateOpenAPIIndexConfig())
+	assert.NoError(t, err)
+	assert.Nil(t, idx.GetRootNode())
+}
+
+func BenchmarkNewSpecIndexWithCache_Stripe(b *testing.B) {
+	source, _ := os.ReadFile("../test_specs/stripe.yaml")
+	cachePath := filepath.Join(b.TempDir(), "stripe.idx")
+	_, _ = NewSpecIndexWithCache(source, cachePath, CreateOpenAPIIndexConfig())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = LoadSpecIndexCache(source, cachePath, CreateOpenAPIIndexConfig())
+	}
+}
+
+func TestSpecIndexCache(t *testing.T) {
+	source, err := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	require.NoError(t, err)
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal(source, &root))
+	config := CreateOpenAPIIndexConfig()
+	expected := NewSpecIndexWithConfig(&root, config)
+
+	cache, err := DecodeSpecIndexCache(EncodeSpecIndexCache(source, &root, config))
+	require.NoError(t, err)
+
+	// the cache is only used for its own nodes, and the same source and configuration.
+	var other yaml.Node
+	require.NoError(t, yaml.Unmarshal(source, &other))
+	config.SpecInfo = &datamodel.SpecInfo{SpecBytes: &source}
+	assert.Nil(t, cache.newSpecIndex(context.Background(), &other, config))
+	changed := []byte(string(source) + "\n")
+	assert.Nil(t, cache.newSpecIndex(context.Background(), cache.GetRootNode(),
+		&SpecIndexConfig{SpecInfo: &datamodel.SpecInfo{SpecBytes: &changed}}))
+
+	rolodex := NewRolodex(config)
+	rolodex.SetRootNode(cache.GetRootNode())
+	require.NoError(t, rolodex.IndexTheRolodexWithContext(WithSpecIndexCache(context.Background(), cache)))
+	idx := rolodex.GetRootIndex()
+	assert.Equal(t, len(expected.GetAllReferences()), len(idx.GetAllReferences()))
+	assert.Equal(t, len(expected.GetMappedReferences()), len(idx.GetMappedReferences()))
+	assert.Equal(t, len(expected.GetAllComponentSchemas()), len(idx.GetAllComponentSchemas()))
+	assert.Equal(t, expected.GetOperationCount(), idx.GetOperationCount())
+	for _, ref := range idx.GetMappedReferences() {
+		assert.Equal(t, idx, ref.Index)
+	}
+
+	// the references belong to that index now, the cache is used up.
+	assert.Nil(t, cache.newSpecIndex(context.Background(), cache.GetRootNode(), config))
+
+	_, err = DecodeSpecIndexCache(EncodeNodes(&root))
+	assert.Error(t, err)
+	assert.Nil(t, GetSpecIndexCache(context.Background()))
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
 
 	"gopkg.in/yaml.v3"
 )
@@ -115,6 +116,22 @@ type SpecIndexConfig struct {
 	// will be used, set to the Error level.
 	Logger *slog.Logger
 
+	// Progress reports every file fetched and indexed, and every resolution pass of the rolodex. It's shared by
+	// the configurations copied for each file, so steps are counted across the whole rolodex. nil reports nothing.
+	Progress *datamodel.ProgressReporter
+
+	// PathFilter decides which paths are built into the model, by Paths.Build of the low-level models. It's created
+	// from the IncludePaths and ExcludePaths of a DocumentConfiguration. nil builds every path.
+	PathFilter *utils.PathFilter
+
+	// Strict is set from the Strict setting of a DocumentConfiguration, it has the low-level models return the
+	// errors they would otherwise log and tolerate, such as path items that fail to build.
+	Strict bool
+
+	// Lenient is set from the Lenient setting of a DocumentConfiguration, it has the low-level models carry on
+	// past objects that fail to build, collecting every error rather than returning the first.
+	Lenient bool
+
 	// SpecInfo is a pointer to the SpecInfo struct that contains the root node and the spec version. It's the
 	// struct that was used to create this index.
 	SpecInfo *datamodel.SpecInfo
@@ -151,6 +168,36 @@ type SpecIndexConfig struct {
 	// to be bundled.
 	ExtractRefsSequentially bool
 
+	// Limits are hard limits enforced during indexing and resolution, they are copied from the
+	// DocumentConfiguration when a document is created. No limits are applied by default.
+	Limits datamodel.DocumentLimits
+
+	// RemoteFetch controls how the RemoteFS fetches remote files, it is copied from the DocumentConfiguration
+	// when a document is created. No limits are applied by default.
+	RemoteFetch datamodel.RemoteFetchConfig
+
+	// InternStrings interns the keys and references of every file indexed (see utils.Interner) along with the
+	// definitions of references found, so each distinct string is held in memory once, the files of a rolodex sharing
+	// the same strings. Files are interned once they are parsed, before they are indexed. It is copied from the
+	// DocumentConfiguration when a document is created. This is false by default.
+	InternStrings bool
+
+	// Normalize rewrites semantically equivalent forms of every file indexed into a single canonical form (see
+	// utils.NormalizeNodes) before it's indexed. It is copied from the DocumentConfiguration when a document is
+	// created. This is false by default.
+	Normalize bool
+
+	// BuildSearchIndex builds the full-text search index of descriptions, summaries and titles (see Search) when
+	// the index is built, instead of the first time it's searched. It is copied from the DocumentConfiguration
+	// when a document is created. This is false by default.
+	BuildSearchIndex bool
+
+	// ComponentCollisionStrategy names the components of different files colliding with each other (defining
+	// different components of the same type and name) when the components of every file are combined, see
+	// Rolodex.ComponentNames. It is copied from the DocumentConfiguration when a document is created. Collisions
+	// are errors by default.
+	ComponentCollisionStrategy datamodel.ComponentCollisionStrategy
+
 	// private fields
 	uri []string
 }
@@ -296,6 +343,19 @@ type SpecIndex struct {
 	nodeMap                             map[int]map[int]*yaml.Node
 	nodeMapCompleted                    chan bool
 	pendingResolve                      []refMap
+	securityUsageOnce                   sync.Once
+	securityUsage                       *securityUsageIndex // lazily built view of security scheme usage
+	callbackExpressionsOnce             sync.Once
+	callbackExpressions                 []*CallbackExpression // lazily built view of callback expressions
+	linkErrorsOnce                      sync.Once
+	linkErrors                          []error // lazily built errors of dangling links
+	operationIdsOnce                    sync.Once
+	operationIds                        *operationIdIndex // lazily built map of operationIds
+	interner                            *utils.Interner   // strings interned, shared with the rolodex (when InternStrings is set)
+	textSearchOnce                      sync.Once
+	textSearch                          *textSearchIndex // lazily built full-text search index
+	referencesToOnce                    sync.Once
+	referencesTo                        map[string][]*ReferenceLocation // lazily built references by full definition
 }
 
 // GetResolver returns the resolver for this index.
@@ -0,0 +1,133 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// GetLinkIndexErrors returns an error for every dangling link in the document: a link with an operationId that no
+// operation uses (see GetOperationById), or an operationRef that can't be located (operationRef values are located
+// the same way as references, so they can point into other files). Links without an operationId or an
+// operationRef, and links with both, are errors too. Links of the responses of operations are checked (referenced
+// responses and links are followed), followed by links defined under components, and the links of component
+// responses.
+//
+// Each error is an *IndexingError, pointing at the link. The errors are built the first time they are requested.
+// When the index is strict, the rolodex adds the errors of the root index to its caught errors, so they fail the
+// document build too.
+func (index *SpecIndex) GetLinkIndexErrors() []error {
+	index.linkErrorsOnce.Do(func() {
+		index.linkErrors = index.buildLinkErrors()
+	})
+	return index.linkErrors
+}
+
+func (index *SpecIndex) buildLinkErrors() []error {
+	if index.root == nil || len(index.root.Content) == 0 {
+		return nil
+	}
+	type operationResponses struct {
+		node     *yaml.Node
+		jsonPath string
+	}
+	var operations []operationResponses
+	if index.pathsNode != nil && utils.IsNodeMap(index.pathsNode) {
+		for x := 0; x+1 < len(index.pathsNode.Content); x += 2 {
+			path := index.pathsNode.Content[x].Value
+			pathItemNode := index.followRef(index.pathsNode.Content[x+1])
+			for y := 0; y+1 < len(pathItemNode.Content); y += 2 {
+				method := pathItemNode.Content[y].Value
+				if !isHttpMethod(method) && !strings.EqualFold(method, "trace") {
+					continue
+				}
+				_, responses := utils.FindKeyNodeTop("responses", pathItemNode.Content[y+1].Content)
+				operations = append(operations, operationResponses{responses, fmt.Sprintf("$.paths['%s'].%s.responses", path, method)})
+			}
+		}
+	}
+
+	var errs []error
+	for _, op := range operations {
+		errs = append(errs, index.checkResponseLinks(op.node, op.jsonPath)...)
+	}
+	_, components := utils.FindKeyNodeTop("components", index.root.Content[0].Content)
+	if components != nil {
+		_, linksNode := utils.FindKeyNodeTop("links", components.Content)
+		errs = append(errs, index.checkLinks(linksNode, "$.components.links")...)
+		_, responses := utils.FindKeyNodeTop("responses", components.Content)
+		errs = append(errs, index.checkResponseLinks(responses, "$.components.responses")...)
+	}
+	return errs
+}
+
+// checkResponseLinks checks the links of every response in a map of responses.
+func (index *SpecIndex) checkResponseLinks(responses *yaml.Node, jsonPath string) []error {
+	if responses == nil || !utils.IsNodeMap(responses) {
+		return nil
+	}
+	var errs []error
+	for i := 0; i+1 < len(responses.Content); i += 2 {
+		code := responses.Content[i].Value
+		if strings.HasPrefix(code, "x-") {
+			continue
+		}
+		response := index.followRef(responses.Content[i+1])
+		_, linksNode := utils.FindKeyNodeTop("links", response.Content)
+		errs = append(errs, index.checkLinks(linksNode, fmt.Sprintf("%s['%s'].links", jsonPath, code))...)
+	}
+	return errs
+}
+
+// checkLinks checks every link in a map of links.
+func (index *SpecIndex) checkLinks(linksNode *yaml.Node, jsonPath string) []error {
+	if linksNode == nil || !utils.IsNodeMap(linksNode) {
+		return nil
+	}
+	var errs []error
+	for i := 0; i+1 < len(linksNode.Content); i += 2 {
+		keyNode := linksNode.Content[i]
+		link := index.followRef(linksNode.Content[i+1])
+		path := fmt.Sprintf("%s['%s']", jsonPath, keyNode.Value)
+		fail := func(format string, args ...any) {
+			errs = append(errs, &IndexingError{
+				Err:     fmt.Errorf("link `%s` at `%s` %s", keyNode.Value, path, fmt.Sprintf(format, args...)),
+				Node:    link,
+				KeyNode: keyNode,
+				Path:    path,
+			})
+		}
+		_, id := utils.FindKeyNodeTop("operationId", link.Content)
+		_, ref := utils.FindKeyNodeTop("operationRef", link.Content)
+		switch {
+		case id != nil && ref != nil:
+			fail("has both an operationId and an operationRef, only one is allowed")
+		case id != nil:
+			if index.GetOperationById(id.Value) == nil {
+				fail("has an operationId `%s` that does not match any operation", id.Value)
+			}
+		case ref != nil:
+			if index.FindComponent(ref.Value) == nil {
+				fail("has an operationRef `%s` that can't be located", ref.Value)
+			}
+		default:
+			fail("has no operationId or operationRef")
+		}
+	}
+	return errs
+}
+
+// followRef returns the node a reference points to, or the node if it's not a reference (or can't be located).
+func (index *SpecIndex) followRef(node *yaml.Node) *yaml.Node {
+	if isRef, _, ref := utils.IsNodeRefValue(node); isRef {
+		if found := seekRefEnd(index, ref); found != nil && found.Node != nil {
+			return found.Node
+		}
+	}
+	return node
+}
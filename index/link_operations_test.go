@@ -0,0 +1,84 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_GetLinkIndexErrors(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    $ref: '#/components/pathItems/pets'
+  /pets/{id}:
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          $ref: '#/components/responses/Pet'
+        x-note: ignored
+components:
+  pathItems:
+    pets:
+      get:
+        operationId: listPets
+        responses:
+          "200":
+            description: pets
+            links:
+              pet:
+                $ref: '#/components/links/pet'
+              owner:
+                operationId: getOwner
+  links:
+    pet:
+      operationId: getPet
+    self:
+      operationRef: '#/paths/~1pets~1{id}/get'
+  responses:
+    Pet:
+      description: a pet
+      links:
+        pets:
+          operationId: listPets
+        toys:
+          operationRef: '#/paths/~1toys/get'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	errs := idx.GetLinkIndexErrors()
+	require.Len(t, errs, 3)
+	assert.Equal(t, "link `owner` at `$.paths['/pets'].get.responses['200'].links['owner']` has an operationId "+
+		"`getOwner` that does not match any operation", errs[0].Error())
+	assert.Equal(t, "link `toys` at `$.paths['/pets/{id}'].get.responses['200'].links['toys']` has an operationRef "+
+		"`#/paths/~1toys/get` that can't be located", errs[1].Error())
+	assert.Equal(t, "link `toys` at `$.components.responses['Pet'].links['toys']` has an operationRef "+
+		"`#/paths/~1toys/get` that can't be located", errs[2].Error())
+
+	var indexErr *IndexingError
+	require.ErrorAs(t, errs[0], &indexErr)
+	assert.Equal(t, "$.paths['/pets'].get.responses['200'].links['owner']", indexErr.Path)
+	assert.Equal(t, "owner", indexErr.KeyNode.Value)
+	assert.Equal(t, 23, indexErr.KeyNode.Line)
+
+	// the errors are only built once.
+	assert.Equal(t, errs, idx.GetLinkIndexErrors())
+}
+
+func TestSpecIndex_GetLinkIndexErrors_Empty(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0`), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetLinkIndexErrors())
+
+	idx = NewSpecIndexWithConfig(new(yaml.Node), CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetLinkIndexErrors())
+}
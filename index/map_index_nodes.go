@@ -4,6 +4,8 @@
 package index
 
 import (
+	"strings"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -34,6 +36,24 @@ type NodeOrigin struct {
 	Index *SpecIndex `json:"-" yaml:"-"`
 }
 
+// EndLine returns the last line the node spans in its file (the line of the last value it holds, down to the last
+// line of a literal block scalar), so Line to EndLine is the line range of the node. Scalars end on their own line.
+func (n *NodeOrigin) EndLine() int {
+	if n == nil || n.Node == nil {
+		return 0
+	}
+	last := n.Node
+	for len(last.Content) > 0 {
+		last = last.Content[len(last.Content)-1]
+	}
+	end := last.Line
+	// literal block scalars start on the line of their indicator, the value is on the lines after it.
+	if last.Style&yaml.LiteralStyle != 0 && last.Value != "" {
+		end += strings.Count(strings.TrimRight(last.Value, "\n"), "\n") + 1
+	}
+	return max(end, n.Line)
+}
+
 // GetNode returns a node from the spec based on a line and column. The second return var bool is true
 // if the node was found, false if not.
 func (index *SpecIndex) GetNode(line int, column int) (*yaml.Node, bool) {
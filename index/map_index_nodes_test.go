@@ -84,3 +84,24 @@ func BenchmarkSpecIndex_MapNodes(b *testing.B) {
 		assert.Equal(b, p1, p2)
 	}
 }
+
+func TestNodeOrigin_EndLine(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`pet:
+  name: fido
+  description: |
+    a dog
+    a good one
+owner: bob`), &root)
+
+	pet := root.Content[0].Content[1]
+	assert.Equal(t, 5, (&NodeOrigin{Node: pet, Line: pet.Line}).EndLine())
+
+	owner := root.Content[0].Content[3]
+	assert.Equal(t, 6, (&NodeOrigin{Node: owner, Line: owner.Line}).EndLine())
+	assert.Equal(t, 6, (&NodeOrigin{Node: &root, Line: 1}).EndLine())
+
+	var none *NodeOrigin
+	assert.Zero(t, none.EndLine())
+	assert.Zero(t, (&NodeOrigin{}).EndLine())
+}
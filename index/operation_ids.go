@@ -0,0 +1,139 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+type operationIdIndex struct {
+	byId   map[string]*Reference
+	errors []error
+}
+
+// GetOperationById returns the operation with an operationId, or nil if no operation uses it. Operations of paths,
+// webhooks and callbacks are all found (referenced path items and callbacks are followed, into other files too).
+// The Node of the reference is the operation, the KeyNode is its method, the Path is the JSON Path to the operation
+// (e.g. $.paths['/pets'].get) and the Index is the index of the file the operation is in.
+//
+// If operationIds are duplicated, the first operation in document order is returned, see
+// GetOperationIdIndexErrors. The map of operationIds is built the first time it's requested, and reused by every
+// later lookup.
+func (index *SpecIndex) GetOperationById(operationId string) *Reference {
+	return index.getOperationIds().byId[operationId]
+}
+
+// GetOperationIdIndexErrors returns an error for every operation with an operationId already used by another
+// operation (operationIds must be unique across every path, webhook and callback of the document). Each error is
+// an *IndexingError, pointing at the operationId of the duplicate. When the index is strict, the rolodex adds the
+// errors of the root index to its caught errors, so they fail the document build too.
+func (index *SpecIndex) GetOperationIdIndexErrors() []error {
+	return index.getOperationIds().errors
+}
+
+func (index *SpecIndex) getOperationIds() *operationIdIndex {
+	index.operationIdsOnce.Do(func() {
+		index.operationIds = index.buildOperationIds()
+	})
+	return index.operationIds
+}
+
+func (index *SpecIndex) buildOperationIds() *operationIdIndex {
+	oi := &operationIdIndex{byId: make(map[string]*Reference)}
+	if index.root == nil || len(index.root.Content) == 0 {
+		return oi
+	}
+	visited := make(map[*yaml.Node]bool)
+	if index.pathsNode != nil && utils.IsNodeMap(index.pathsNode) {
+		for x := 0; x+1 < len(index.pathsNode.Content); x += 2 {
+			path := index.pathsNode.Content[x].Value
+			index.extractOperationIds(oi, index.pathsNode.Content[x+1], index, path,
+				fmt.Sprintf("$.paths['%s']", path), visited)
+		}
+	}
+	_, webhooks := utils.FindKeyNodeTop("webhooks", index.root.Content[0].Content)
+	if webhooks != nil && utils.IsNodeMap(webhooks) {
+		for x := 0; x+1 < len(webhooks.Content); x += 2 {
+			name := webhooks.Content[x].Value
+			index.extractOperationIds(oi, webhooks.Content[x+1], index, name,
+				fmt.Sprintf("$.webhooks['%s']", name), visited)
+		}
+	}
+	return oi
+}
+
+// extractOperationIds maps the operationIds of every operation of a path item, and of their callbacks.
+func (index *SpecIndex) extractOperationIds(oi *operationIdIndex, pathItemNode *yaml.Node, idx *SpecIndex,
+	path, jsonPath string, visited map[*yaml.Node]bool,
+) {
+	pathItemNode, idx = followRefIndex(pathItemNode, idx)
+	if visited[pathItemNode] || !utils.IsNodeMap(pathItemNode) {
+		return
+	}
+	visited[pathItemNode] = true
+	for y := 0; y+1 < len(pathItemNode.Content); y += 2 {
+		methodNode := pathItemNode.Content[y]
+		if !isHttpMethod(methodNode.Value) && !strings.EqualFold(methodNode.Value, "trace") {
+			continue
+		}
+		opNode := pathItemNode.Content[y+1]
+		opPath := fmt.Sprintf("%s.%s", jsonPath, methodNode.Value)
+		if idKey, id := utils.FindKeyNodeTop("operationId", opNode.Content); id != nil && id.Value != "" {
+			if first, ok := oi.byId[id.Value]; ok {
+				oi.errors = append(oi.errors, &IndexingError{
+					Err: fmt.Errorf("the operationId `%s` of the `%s` operation at `%s` is already used by the "+
+						"operation at `%s`, operationIds must be unique", id.Value, strings.ToUpper(methodNode.Value),
+						path, first.Path),
+					Node:    id,
+					KeyNode: idKey,
+					Path:    opPath,
+				})
+			} else {
+				oi.byId[id.Value] = &Reference{
+					Definition: id.Value,
+					Name:       id.Value,
+					Node:       opNode,
+					KeyNode:    methodNode,
+					Path:       opPath,
+					Index:      idx,
+				}
+			}
+		}
+
+		_, callbacks := utils.FindKeyNodeTop("callbacks", opNode.Content)
+		if callbacks == nil || !utils.IsNodeMap(callbacks) {
+			continue
+		}
+		for i := 0; i+1 < len(callbacks.Content); i += 2 {
+			name := callbacks.Content[i].Value
+			callbackNode, cIdx := followRefIndex(callbacks.Content[i+1], idx)
+			for j := 0; j+1 < len(callbackNode.Content); j += 2 {
+				expression := callbackNode.Content[j].Value
+				if strings.HasPrefix(expression, "x-") || expression == "$ref" {
+					continue
+				}
+				index.extractOperationIds(oi, callbackNode.Content[j+1], cIdx, expression,
+					fmt.Sprintf("%s.callbacks['%s']['%s']", opPath, name, expression), visited)
+			}
+		}
+	}
+}
+
+// followRefIndex returns the node a reference points to and the index of the file it's in, or the node and idx if
+// it's not a reference (or can't be located).
+func followRefIndex(node *yaml.Node, idx *SpecIndex) (*yaml.Node, *SpecIndex) {
+	if isRef, _, ref := utils.IsNodeRefValue(node); isRef {
+		if found := seekRefEnd(idx, ref); found != nil && found.Node != nil {
+			if found.Index != nil {
+				idx = found.Index
+			}
+			return found.Node, idx
+		}
+	}
+	return node, idx
+}
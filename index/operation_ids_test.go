@@ -0,0 +1,153 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_GetOperationById(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    $ref: '#/components/pathItems/pets'
+  /pets/{id}:
+    get:
+      operationId: getPet
+      callbacks:
+        adopted:
+          '{$request.body#/url}':
+            post:
+              operationId: petAdopted
+    x-get:
+      operationId: ignored
+webhooks:
+  newPet:
+    post:
+      operationId: newPet
+components:
+  pathItems:
+    pets:
+      get:
+        operationId: listPets`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	listPets := idx.GetOperationById("listPets")
+	require.NotNil(t, listPets)
+	assert.Equal(t, "$.paths['/pets'].get", listPets.Path)
+	assert.Equal(t, "get", listPets.KeyNode.Value)
+	assert.Equal(t, 23, listPets.Node.Line)
+	assert.Equal(t, idx, listPets.Index)
+
+	assert.Equal(t, "$.paths['/pets/{id}'].get", idx.GetOperationById("getPet").Path)
+	assert.Equal(t, "$.paths['/pets/{id}'].get.callbacks['adopted']['{$request.body#/url}'].post",
+		idx.GetOperationById("petAdopted").Path)
+	assert.Equal(t, "$.webhooks['newPet'].post", idx.GetOperationById("newPet").Path)
+	assert.Nil(t, idx.GetOperationById("ignored"))
+	assert.Nil(t, idx.GetOperationById("missing"))
+	assert.Empty(t, idx.GetOperationIdIndexErrors())
+}
+
+func TestSpecIndex_GetOperationIdIndexErrors(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+    post:
+      operationId: listPets
+  /toys:
+    get:
+      operationId: listToys
+webhooks:
+  newPet:
+    $ref: '#/components/pathItems/newPet'
+components:
+  pathItems:
+    newPet:
+      post:
+        operationId: listToys`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	errs := idx.GetOperationIdIndexErrors()
+	require.Len(t, errs, 2)
+	assert.Equal(t, "the operationId `listPets` of the `POST` operation at `/pets` is already used by the "+
+		"operation at `$.paths['/pets'].get`, operationIds must be unique", errs[0].Error())
+	assert.Equal(t, "the operationId `listToys` of the `POST` operation at `newPet` is already used by the "+
+		"operation at `$.paths['/toys'].get`, operationIds must be unique", errs[1].Error())
+
+	var indexingErr *IndexingError
+	require.ErrorAs(t, errs[1], &indexingErr)
+	assert.Equal(t, "$.webhooks['newPet'].post", indexingErr.Path)
+	assert.Equal(t, 18, indexingErr.Node.Line)
+	assert.Equal(t, "operationId", indexingErr.KeyNode.Value)
+
+	// the first operation in document order is kept.
+	assert.Equal(t, "$.paths['/pets'].get", idx.GetOperationById("listPets").Path)
+	assert.Equal(t, "$.paths['/toys'].get", idx.GetOperationById("listToys").Path)
+}
+
+func TestSpecIndex_GetOperationIdIndexErrors_ExternalPathItem(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.yaml"), []byte(`get:
+  operationId: listPets
+post:
+  operationId: createPet`), 0o644))
+
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    $ref: 'pets.yaml'
+  /animals:
+    post:
+      operationId: createPet`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	cf.Rolodex = rolo
+
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: dir,
+		DirFS:         os.DirFS(dir),
+	})
+	require.NoError(t, err)
+	rolo.AddLocalFS(dir, fileFS)
+	require.NoError(t, rolo.IndexTheRolodex())
+	rolo.BuildIndexes()
+
+	idx := rolo.GetRootIndex()
+	listPets := idx.GetOperationById("listPets")
+	require.NotNil(t, listPets)
+	assert.Equal(t, "$.paths['/pets'].get", listPets.Path)
+	assert.NotEqual(t, idx, listPets.Index)
+	assert.Equal(t, 2, listPets.Node.Line)
+
+	errs := idx.GetOperationIdIndexErrors()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "the operationId `createPet` of the `POST` operation at `/animals` is already used by the "+
+		"operation at `$.paths['/pets'].post`, operationIds must be unique", errs[0].Error())
+}
+
+func TestSpecIndex_GetOperationById_NoRoot(t *testing.T) {
+	idx := NewSpecIndexWithConfig(&yaml.Node{}, CreateOpenAPIIndexConfig())
+	assert.Nil(t, idx.GetOperationById("listPets"))
+	assert.Empty(t, idx.GetOperationIdIndexErrors())
+}
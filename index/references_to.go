@@ -0,0 +1,123 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"net/url"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ReferenceLocation is a place a component is referenced from.
+type ReferenceLocation struct {
+	Reference *Reference // the reference, its Node is the object holding the `$ref`.
+	Index     *SpecIndex // the index of the file the reference is in.
+
+	JSONPointer string // the JSON Pointer to the object holding the `$ref`, e.g. /paths/~1pets/get/requestBody
+	Path        string // the JSON Path to the object holding the `$ref`, e.g. $.paths['/pets'].get.requestBody
+}
+
+// GetReferencesTo returns every location a component is referenced from, in the file indexed and, if the index is
+// part of a rolodex, in every file of the rolodex (those of the root document first, then of each file by path,
+// each in the order of its file). The component is a JSON Pointer, local to the file indexed, such as
+// `#/components/schemas/Pet`, or including the file (or URL) it's defined in, such as
+// `schemas/pet.yaml#/components/schemas/Pet`, a relative path being relative to the file indexed. A reference to
+// a whole file is found with the path of the file.
+func (index *SpecIndex) GetReferencesTo(componentPointer string) []*ReferenceLocation {
+	target := index.referenceTarget(componentPointer)
+	indexes := []*SpecIndex{index}
+	if r := index.GetRolodex(); r != nil {
+		var others []*SpecIndex
+		for _, idx := range r.GetIndexes() {
+			if idx != nil && idx != r.GetRootIndex() {
+				others = append(others, idx)
+			}
+		}
+		sort.SliceStable(others, func(i, j int) bool {
+			return others[i].GetSpecAbsolutePath() < others[j].GetSpecAbsolutePath()
+		})
+		indexes = append([]*SpecIndex{r.GetRootIndex()}, others...)
+	}
+	var locations []*ReferenceLocation
+	for i, idx := range indexes {
+		if idx != nil && !slices.Contains(indexes[:i], idx) {
+			locations = append(locations, idx.getReferencesTo()[target]...)
+		}
+	}
+	return locations
+}
+
+// referenceTarget returns the full definition of a component pointer.
+func (index *SpecIndex) referenceTarget(pointer string) string {
+	location, fragment, _ := strings.Cut(pointer, "#")
+	if fragment != "" {
+		fragment = "#" + fragment
+	}
+	switch {
+	case location == "":
+		location = index.specAbsolutePath
+	case strings.HasPrefix(location, "http"), filepath.IsAbs(location):
+	case strings.HasPrefix(index.specAbsolutePath, "http"):
+		if base, err := url.Parse(index.specAbsolutePath); err == nil {
+			if u, e := base.Parse(location); e == nil {
+				location = u.String()
+			}
+		}
+	default:
+		location, _ = filepath.Abs(utils.CheckPathOverlap(filepath.Dir(index.specAbsolutePath), location,
+			string(filepath.Separator)))
+	}
+	return location + fragment
+}
+
+func (index *SpecIndex) getReferencesTo() map[string][]*ReferenceLocation {
+	index.referencesToOnce.Do(func() {
+		index.referencesTo = make(map[string][]*ReferenceLocation)
+		pointers := make(map[*yaml.Node][]string)
+		if index.root != nil && len(index.root.Content) > 0 {
+			locateReferenceNodes(index.root.Content[0], nil, pointers)
+		}
+		seen := make(map[*yaml.Node]struct{})
+		for _, ref := range index.rawSequencedRefs {
+			if _, ok := seen[ref.Node]; ok {
+				continue
+			}
+			seen[ref.Node] = struct{}{}
+			segments := pointers[ref.Node]
+			pointer := utils.BuildJSONPointer(segments)
+			_, path := utils.ConvertComponentIdIntoFriendlyPathSearch("#" + pointer)
+			index.referencesTo[ref.FullDefinition] = append(index.referencesTo[ref.FullDefinition],
+				&ReferenceLocation{Reference: ref, Index: index, JSONPointer: pointer, Path: path})
+		}
+	})
+	return index.referencesTo
+}
+
+// locateReferenceNodes records the segments of the JSON Pointer to every object holding a `$ref`.
+func locateReferenceNodes(node *yaml.Node, segments []string, pointers map[*yaml.Node][]string) {
+	if _, ok := pointers[node]; ok {
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" {
+				pointers[node] = segments
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			locateReferenceNodes(node.Content[i+1], appendSearchPath(segments, node.Content[i].Value), pointers)
+		}
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			locateReferenceNodes(n, appendSearchPath(segments, strconv.Itoa(i)), pointers)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_GetReferencesTo(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+components:
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pet:
+      type: object
+      properties:
+        friends:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+    Unused:
+      type: string`), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	locations := idx.GetReferencesTo("#/components/schemas/Pet")
+	require.Len(t, locations, 3)
+	assert.Equal(t, "/paths/~1pets/get/responses/200/content/application~1json/schema/items", locations[0].JSONPointer)
+	assert.Equal(t, "$.paths['/pets'].get.responses['200'].content['application/json'].schema.items",
+		locations[0].Path)
+	assert.Equal(t, 13, locations[0].Reference.Node.Line)
+	assert.Same(t, idx, locations[0].Index)
+	assert.Equal(t, "/components/requestBodies/Pet/content/application~1json/schema", locations[1].JSONPointer)
+	assert.Equal(t, "/components/schemas/Pet/properties/friends/items", locations[2].JSONPointer)
+
+	locations = idx.GetReferencesTo("#/components/requestBodies/Pet")
+	require.Len(t, locations, 1)
+	assert.Equal(t, "$.paths['/pets'].post.requestBody", locations[0].Path)
+
+	assert.Empty(t, idx.GetReferencesTo("#/components/schemas/Unused"))
+	assert.Empty(t, idx.GetReferencesTo("#/components/schemas/Nope"))
+}
+
+func TestRolodex_GetReferencesTo(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "schemas"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "pet.yaml"), []byte(`components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: 'owner.yaml'
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "owner.yaml"), []byte(`type: object
+properties:
+  pet:
+    $ref: 'pet.yaml#/components/schemas/Pet'`), 0o644))
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas/pet.yaml#/components/schemas/Pets'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: 'schemas/pet.yaml#/components/schemas/Pet'`), &rootNode)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	cf.SpecAbsolutePath = filepath.Join(dir, "root.yaml")
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: dir,
+		DirFS:         os.DirFS(dir),
+	})
+	require.NoError(t, err)
+	rolo.AddLocalFS(dir, fileFS)
+	require.NoError(t, rolo.IndexTheRolodex())
+	root := rolo.GetRootIndex()
+
+	locations := root.GetReferencesTo("schemas/pet.yaml#/components/schemas/Pet")
+	require.Len(t, locations, 3)
+	assert.Same(t, root, locations[0].Index)
+	assert.Equal(t, "$.paths['/pets'].post.requestBody.content['application/json'].schema", locations[0].Path)
+	assert.Equal(t, filepath.Join(dir, "schemas", "owner.yaml"), locations[1].Index.GetSpecAbsolutePath())
+	assert.Equal(t, "/properties/pet", locations[1].JSONPointer)
+	assert.Equal(t, filepath.Join(dir, "schemas", "pet.yaml"), locations[2].Index.GetSpecAbsolutePath())
+	assert.Equal(t, "/components/schemas/Pets/items", locations[2].JSONPointer)
+
+	// pointers are relative to the file indexed, files referenced whole are found by their path.
+	pets := locations[2].Index
+	assert.Len(t, pets.GetReferencesTo("#/components/schemas/Pet"), 3)
+	owner := pets.GetReferencesTo("owner.yaml")
+	require.Len(t, owner, 1)
+	assert.Equal(t, "/components/schemas/Pet/properties/owner", owner[0].JSONPointer)
+	assert.Len(t, root.GetReferencesTo(filepath.Join(dir, "schemas", "owner.yaml")), 1)
+}
@@ -4,6 +4,7 @@
 package index
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/url"
@@ -30,6 +31,12 @@ type ResolvingError struct {
 	CircularReference *CircularReferenceResult
 }
 
+// Unwrap returns the underlying error, so sentinel errors such as datamodel.ErrLimitExceeded can be
+// matched with errors.Is.
+func (r *ResolvingError) Unwrap() error {
+	return r.ErrorRef
+}
+
 func (r *ResolvingError) Error() string {
 	errs := utils.UnwrapErrors(r.ErrorRef)
 	var msgs []string
@@ -67,6 +74,7 @@ type Resolver struct {
 	IgnorePoly             bool
 	IgnoreArray            bool
 	circChecked            bool
+	depthLimitHit          bool
 }
 
 // NewResolver will create a new resolver from a *index.SpecIndex
@@ -190,7 +198,16 @@ func (resolver *Resolver) GetRelativesSeen() int {
 // re-organize the node tree. Make sure you have copied your original tree before running this (if you want to preserve
 // original data)
 func (resolver *Resolver) Resolve() []*ResolvingError {
-	visitIndex(resolver, resolver.specIndex)
+	return resolver.ResolveWithContext(context.Background())
+}
+
+// ResolveWithContext is the same as Resolve, but stops once ctx is cancelled or its deadline passes, returning the
+// error of ctx as a resolving error. The tree is then only partly resolved, and should not be used.
+func (resolver *Resolver) ResolveWithContext(ctx context.Context) []*ResolvingError {
+	visitIndex(ctx, resolver, resolver.specIndex)
+	if resolver.cancelled(ctx) {
+		return resolver.resolvingErrors
+	}
 
 	for _, circRef := range resolver.circularReferences {
 		// If the circular reference is not required, we can ignore it, as it's a terminable loop rather than an infinite one
@@ -216,7 +233,17 @@ func (resolver *Resolver) Resolve() []*ResolvingError {
 
 // CheckForCircularReferences Check for circular references, without resolving, a non-destructive run.
 func (resolver *Resolver) CheckForCircularReferences() []*ResolvingError {
-	visitIndexWithoutDamagingIt(resolver, resolver.specIndex)
+	return resolver.CheckForCircularReferencesWithContext(context.Background())
+}
+
+// CheckForCircularReferencesWithContext is the same as CheckForCircularReferences, but stops once ctx is cancelled
+// or its deadline passes, returning the error of ctx as a resolving error. The circular references found until then
+// are not set on the index.
+func (resolver *Resolver) CheckForCircularReferencesWithContext(ctx context.Context) []*ResolvingError {
+	visitIndexWithoutDamagingIt(ctx, resolver, resolver.specIndex)
+	if resolver.cancelled(ctx) {
+		return resolver.resolvingErrors
+	}
 	for _, circRef := range resolver.circularReferences {
 		// If the circular reference is not required, we can ignore it, as it's a terminable loop rather than an infinite one
 		if !circRef.IsInfiniteLoop {
@@ -239,23 +266,39 @@ func (resolver *Resolver) CheckForCircularReferences() []*ResolvingError {
 	return resolver.resolvingErrors
 }
 
-func visitIndexWithoutDamagingIt(res *Resolver, idx *SpecIndex) {
+// cancelled returns true once ctx is done, adding its error to the resolving errors.
+func (resolver *Resolver) cancelled(ctx context.Context) bool {
+	err := ctx.Err()
+	if err == nil {
+		return false
+	}
+	resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{ErrorRef: err})
+	return true
+}
+
+func visitIndexWithoutDamagingIt(ctx context.Context, res *Resolver, idx *SpecIndex) {
 	mapped := idx.GetMappedReferencesSequenced()
 	mappedIndex := idx.GetMappedReferences()
 	res.indexesVisited++
 	for _, ref := range mapped {
+		if ctx.Err() != nil {
+			return
+		}
 		seenReferences := make(map[string]bool)
 		var journey []*Reference
 		res.journeysTaken++
-		res.VisitReference(ref.Reference, seenReferences, journey, false)
+		res.visitReference(ctx, ref.Reference, seenReferences, journey, false)
 	}
 	schemas := idx.GetAllComponentSchemas()
 	for s, schemaRef := range schemas {
+		if ctx.Err() != nil {
+			return
+		}
 		if mappedIndex[s] == nil {
 			seenReferences := make(map[string]bool)
 			var journey []*Reference
 			res.journeysTaken++
-			res.VisitReference(schemaRef, seenReferences, journey, false)
+			res.visitReference(ctx, schemaRef, seenReferences, journey, false)
 		}
 	}
 }
@@ -265,18 +308,21 @@ type refMap struct {
 	nodes []*yaml.Node
 }
 
-func visitIndex(res *Resolver, idx *SpecIndex) {
+func visitIndex(ctx context.Context, res *Resolver, idx *SpecIndex) {
 	mapped := idx.GetMappedReferencesSequenced()
 	mappedIndex := idx.GetMappedReferences()
 	res.indexesVisited++
 
 	var refs []refMap
 	for _, ref := range mapped {
+		if ctx.Err() != nil {
+			return
+		}
 		seenReferences := make(map[string]bool)
 		var journey []*Reference
 		res.journeysTaken++
 		if ref != nil && ref.Reference != nil {
-			n := res.VisitReference(ref.Reference, seenReferences, journey, true)
+			n := res.visitReference(ctx, ref.Reference, seenReferences, journey, true)
 			if !ref.Reference.Circular {
 				// make a note of the reference and map the original ref after we're done
 				if ok, _, _ := utils.IsNodeRefValue(ref.OriginalReference.Node); ok {
@@ -292,21 +338,27 @@ func visitIndex(res *Resolver, idx *SpecIndex) {
 
 	schemas := idx.GetAllComponentSchemas()
 	for s, schemaRef := range schemas {
+		if ctx.Err() != nil {
+			return
+		}
 		if mappedIndex[s] == nil {
 			seenReferences := make(map[string]bool)
 			var journey []*Reference
 			res.journeysTaken++
-			schemaRef.Node.Content = res.VisitReference(schemaRef, seenReferences, journey, true)
+			schemaRef.Node.Content = res.visitReference(ctx, schemaRef, seenReferences, journey, true)
 		}
 	}
 
 	schemas = idx.GetAllSecuritySchemes()
 	for s, schemaRef := range schemas {
+		if ctx.Err() != nil {
+			return
+		}
 		if mappedIndex[s] == nil {
 			seenReferences := make(map[string]bool)
 			var journey []*Reference
 			res.journeysTaken++
-			schemaRef.Node.Content = res.VisitReference(schemaRef, seenReferences, journey, true)
+			schemaRef.Node.Content = res.visitReference(ctx, schemaRef, seenReferences, journey, true)
 		}
 	}
 
@@ -323,6 +375,16 @@ func visitIndex(res *Resolver, idx *SpecIndex) {
 
 // VisitReference will visit a reference as part of a journey and will return resolved nodes.
 func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, journey []*Reference, resolve bool) []*yaml.Node {
+	return resolver.visitReference(context.Background(), ref, seen, journey, resolve)
+}
+
+// visitReference is VisitReference, it stops following the relatives of references as soon as ctx is done.
+func (resolver *Resolver) visitReference(ctx context.Context, ref *Reference, seen map[string]bool, journey []*Reference,
+	resolve bool,
+) []*yaml.Node {
+	if ctx.Err() != nil {
+		return ref.Node.Content
+	}
 	resolver.referencesVisited++
 	if resolve && ref.Seen {
 		if ref.Resolved {
@@ -335,7 +397,7 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 
 	journey = append(journey, ref)
 	seenRelatives := make(map[int]bool)
-	relatives := resolver.extractRelatives(ref, ref.Node, nil, seen, journey, seenRelatives, resolve, 0)
+	relatives := resolver.extractRelatives(ctx, ref, ref.Node, nil, seen, journey, seenRelatives, resolve, 0)
 
 	seen = make(map[string]bool)
 
@@ -347,7 +409,7 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 			if j.FullDefinition == r.FullDefinition {
 
 				var foundDup *Reference
-				foundRef, _ := resolver.specIndex.SearchIndexForReferenceByReference(r)
+				foundRef, _, _ := resolver.specIndex.SearchIndexForReferenceByReferenceWithContext(ctx, r)
 				if foundRef != nil {
 					foundDup = foundRef
 				}
@@ -394,11 +456,11 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 
 		if !skip {
 			var original *Reference
-			foundRef, _ := resolver.specIndex.SearchIndexForReferenceByReference(r)
+			foundRef, _, _ := resolver.specIndex.SearchIndexForReferenceByReferenceWithContext(ctx, r)
 			if foundRef != nil {
 				original = foundRef
 			}
-			resolved := resolver.VisitReference(original, seen, journey, resolve)
+			resolved := resolver.visitReference(ctx, original, seen, journey, resolve)
 			if resolve && !original.Circular {
 				ref.Resolved = true
 				r.Resolved = true
@@ -451,10 +513,24 @@ func (resolver *Resolver) isInfiniteCircularDependency(ref *Reference, visitedDe
 	return false, visitedDefinitions
 }
 
-func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.Node,
+func (resolver *Resolver) extractRelatives(ctx context.Context, ref *Reference, node, parent *yaml.Node,
 	foundRelatives map[string]bool,
 	journey []*Reference, seen map[int]bool, resolve bool, depth int,
 ) []*Reference {
+	if ref != nil && resolver.specIndex != nil && resolver.specIndex.config != nil {
+		limits := resolver.specIndex.config.Limits
+		if limitErr := limits.CheckRefDepth(ref.FullDefinition, len(journey)); limitErr != nil {
+			if !resolver.depthLimitHit {
+				resolver.depthLimitHit = true
+				resolver.resolvingErrors = append(resolver.resolvingErrors, &ResolvingError{
+					ErrorRef: limitErr,
+					Node:     ref.Node,
+					Path:     ref.FullDefinition,
+				})
+			}
+			return nil
+		}
+	}
 	if len(journey) > 100 {
 		return nil
 	}
@@ -499,13 +575,13 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 				depth++
 
 				var foundRef *Reference
-				foundRef, _ = resolver.specIndex.SearchIndexForReferenceByReference(ref)
+				foundRef, _, _ = resolver.specIndex.SearchIndexForReferenceByReferenceWithContext(ctx, ref)
 				if foundRef != nil && !foundRef.Circular {
-					found = append(found, resolver.extractRelatives(foundRef, n, node, foundRelatives, journey, seen, resolve, depth)...)
+					found = append(found, resolver.extractRelatives(ctx, foundRef, n, node, foundRelatives, journey, seen, resolve, depth)...)
 					depth--
 				}
 				if foundRef == nil {
-					found = append(found, resolver.extractRelatives(ref, n, node, foundRelatives, journey, seen, resolve, depth)...)
+					found = append(found, resolver.extractRelatives(ctx, ref, n, node, foundRelatives, journey, seen, resolve, depth)...)
 					depth--
 				}
 
@@ -612,7 +688,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 					IsRemote:       true,
 				}
 
-				locatedRef, _ = resolver.specIndex.SearchIndexForReferenceByReference(searchRef)
+				locatedRef, _, _ = resolver.specIndex.SearchIndexForReferenceByReferenceWithContext(ctx, searchRef)
 
 				if locatedRef == nil {
 					_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(value)
@@ -665,7 +741,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 									// create full definition lookup based on ref.
 									def := resolver.buildDefPath(ref, l)
 
-									mappedRefs, _ := resolver.specIndex.SearchIndexForReference(def)
+									mappedRefs, _, _ := resolver.specIndex.SearchIndexForReferenceWithContext(ctx, def)
 									if mappedRefs != nil && !mappedRefs.Circular {
 										circ := false
 										for f := range journey {
@@ -675,7 +751,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 											}
 										}
 										if !circ {
-											resolver.VisitReference(mappedRefs, foundRelatives, journey, resolve)
+											resolver.visitReference(ctx, mappedRefs, foundRelatives, journey, resolve)
 										} else {
 											loop := append(journey, mappedRefs)
 											circRef := &CircularReferenceResult{
@@ -710,7 +786,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 									// create full definition lookup based on ref.
 									def := resolver.buildDefPath(ref, l)
 
-									mappedRefs, _ := resolver.specIndex.SearchIndexForReference(def)
+									mappedRefs, _, _ := resolver.specIndex.SearchIndexForReferenceWithContext(ctx, def)
 									if mappedRefs != nil && !mappedRefs.Circular {
 										circ := false
 										for f := range journey {
@@ -720,7 +796,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 											}
 										}
 										if !circ {
-											resolver.VisitReference(mappedRefs, foundRelatives, journey, resolve)
+											resolver.visitReference(ctx, mappedRefs, foundRelatives, journey, resolve)
 										} else {
 											loop := append(journey, mappedRefs)
 											circRef := &CircularReferenceResult{
@@ -755,7 +831,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 							if utils.IsNodeMap(v) {
 								if d, _, l := utils.IsNodeRefValue(v); d {
 									def := resolver.buildDefPath(ref, l)
-									mappedRefs, _ := resolver.specIndex.SearchIndexForReference(def)
+									mappedRefs, _, _ := resolver.specIndex.SearchIndexForReferenceWithContext(ctx, def)
 									if mappedRefs != nil && !mappedRefs.Circular {
 										circ := false
 										for f := range journey {
@@ -765,7 +841,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 											}
 										}
 										if !circ {
-											resolver.VisitReference(mappedRefs, foundRelatives, journey, resolve)
+											resolver.visitReference(ctx, mappedRefs, foundRelatives, journey, resolve)
 										} else {
 											loop := append(journey, mappedRefs)
 
@@ -792,7 +868,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 									}
 								} else {
 									depth++
-									found = append(found, resolver.extractRelatives(ref, v, n,
+									found = append(found, resolver.extractRelatives(ctx, ref, v, n,
 										foundRelatives, journey, seen, resolve, depth)...)
 								}
 							}
@@ -2,6 +2,7 @@ package index
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/pb33f/libopenapi/datamodel"
@@ -15,6 +16,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 )
 
@@ -145,6 +147,34 @@ func TestResolver_CheckForCircularReferences(t *testing.T) {
 
 }
 
+func TestResolver_CheckForCircularReferencesWithContext(t *testing.T) {
+	circular, _ := os.ReadFile("../test_specs/circular-tests.yaml")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(circular, &rootNode)
+
+	cf := CreateClosedAPIIndexConfig()
+	cf.AvoidCircularReferenceCheck = true
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	assert.NoError(t, rolo.IndexTheRolodex())
+
+	// a cancelled check stops, reporting why, and finds nothing.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rolo.CheckForCircularReferencesWithContext(ctx)
+	assert.ErrorIs(t, errors.Join(rolo.GetCaughtErrors()...), context.Canceled)
+	assert.Len(t, rolo.GetCaughtErrors(), 1)
+	assert.Empty(t, rolo.GetRootIndex().GetCircularReferences())
+	assert.Zero(t, rolo.GetRootIndex().GetResolver().GetReferenceVisited())
+
+	// resolving stops the same way.
+	resolver := NewResolver(rolo.GetRootIndex())
+	errs := resolver.ResolveWithContext(ctx)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], context.Canceled)
+	assert.Zero(t, resolver.GetReferenceVisited())
+}
+
 func TestResolver_CheckForCircularReferences_CatchArray(t *testing.T) {
 	circular := []byte(`openapi: 3.0.0
 components:
@@ -448,7 +478,7 @@ func TestResolver_DeepJourney(t *testing.T) {
 	}
 	idx := NewSpecIndexWithConfig(nil, CreateClosedAPIIndexConfig())
 	resolver := NewResolver(idx)
-	assert.Nil(t, resolver.extractRelatives(nil, nil, nil, nil, journey, nil, false, 0))
+	assert.Nil(t, resolver.extractRelatives(context.Background(), nil, nil, nil, nil, journey, nil, false, 0))
 }
 
 func TestResolver_DeepDepth(t *testing.T) {
@@ -481,7 +511,7 @@ func TestResolver_DeepDepth(t *testing.T) {
 	ref := &Reference{
 		FullDefinition: "#/components/schemas/A",
 	}
-	found := resolver.extractRelatives(ref, refA, nil, nil, nil, nil, false, 0)
+	found := resolver.extractRelatives(context.Background(), ref, refA, nil, nil, nil, nil, false, 0)
 
 	assert.Nil(t, found)
 	assert.Contains(t, buf.String(), "libopenapi resolver: relative depth exceeded 100 levels")
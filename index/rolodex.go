@@ -4,6 +4,7 @@
 package index
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"gopkg.in/yaml.v3"
@@ -18,7 +19,11 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
 )
 
 // CanBeIndexed is an interface that allows a file to be indexed.
@@ -28,6 +33,11 @@ type CanBeIndexed interface {
 
 // RolodexFile is an interface that represents a file in the rolodex. It combines multiple `fs` interfaces
 // like `fs.FileInfo` and `fs.File` into one interface, so the same struct can be used for everything.
+//
+// GetContentAsYAMLNode returns the tree the file was parsed into, which is the root of its index and is shared by
+// every reference into the file. It is not copied, so it must not be changed by callers, anything changed is seen
+// by every model and index that reads the file (resolving the rolodex is the only thing that changes it, in place).
+// A copy must be made (see utils.CopyNode) to change it.
 type RolodexFile interface {
 	GetContent() string
 	GetFileExtension() FileExtension
@@ -73,6 +83,8 @@ type Rolodex struct {
 	infiniteCircularReferences []*CircularReferenceResult
 	ignoredCircularReferences  []*CircularReferenceResult
 	logger                     *slog.Logger
+	interner                   *utils.Interner
+	fetchedRemoteFiles         atomic.Int64
 }
 
 // NewRolodex creates a new rolodex with the provided index configuration.
@@ -91,6 +103,7 @@ func NewRolodex(indexConfig *SpecIndexConfig) *Rolodex {
 		remoteFS:    make(map[string]fs.FS),
 		logger:      logger,
 		indexMap:    make(map[string]*SpecIndex),
+		interner:    utils.NewInterner(),
 	}
 	indexConfig.Rolodex = r
 	return r
@@ -127,6 +140,14 @@ func (r *Rolodex) GetConfig() *SpecIndexConfig {
 	return r.indexConfig
 }
 
+// progress returns the progress reporter of the rolodex, which can be nil.
+func (r *Rolodex) progress() *datamodel.ProgressReporter {
+	if r.indexConfig == nil {
+		return nil
+	}
+	return r.indexConfig.Progress
+}
+
 // GetRootNode returns the root index of the rolodex (the entry point, the main document)
 func (r *Rolodex) GetRootNode() *yaml.Node {
 	return r.rootNode
@@ -152,6 +173,13 @@ func (r *Rolodex) AddLocalFS(baseDir string, fileSystem fs.FS) {
 	r.localFS[absBaseDir] = fileSystem
 }
 
+// AddVirtualFS mounts any fs.FS (an embed.FS, an fstest.MapFS, a zip.Reader etc.) at baseDir, so local references
+// that resolve below baseDir are read from the supplied file system instead of the disk. Files are read and
+// indexed as they are referenced. The index configuration must have AllowFileLookup set for lookups to work.
+func (r *Rolodex) AddVirtualFS(baseDir string, fileSystem fs.FS) {
+	r.AddLocalFS(baseDir, NewLocalFSWithDirFS(baseDir, fileSystem, r.indexConfig))
+}
+
 // SetRootNode sets the root node of the rolodex (the entry point, the main document)
 func (r *Rolodex) SetRootNode(node *yaml.Node) {
 	r.rootNode = node
@@ -184,9 +212,22 @@ func (r *Rolodex) AddRemoteFS(baseURL string, fileSystem fs.FS) {
 
 // IndexTheRolodex indexes the rolodex, building out the indexes for each file, and then building the root index.
 func (r *Rolodex) IndexTheRolodex() error {
+	return r.IndexTheRolodexWithContext(context.Background())
+}
+
+// IndexTheRolodexWithContext is the same as IndexTheRolodex, except that indexing stops as soon as the supplied
+// context is cancelled or its deadline expires. Files that have not yet been indexed are skipped, and the
+// context error is added to the caught errors and returned. Files are indexed, and checked for circular references,
+// with the context too. Remote files fetched later on (as the model is built) use the context passed to
+// OpenWithContext, it's never kept by the rolodex. When the context carries a SpecIndexCache of the root node (see
+// WithSpecIndexCache), the root index is created from it.
+func (r *Rolodex) IndexTheRolodexWithContext(ctx context.Context) error {
 	if r.indexed {
 		return nil
 	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	var caughtErrors []error
 
@@ -202,12 +243,23 @@ func (r *Rolodex) IndexTheRolodex() error {
 
 		indexFileFunc := func(idxFile CanBeIndexed, fullPath string) {
 			defer wg.Done()
+			if ctx.Err() != nil {
+				return
+			}
 
 			// copy config and set the
 			copiedConfig := *r.indexConfig
 			copiedConfig.SpecAbsolutePath = fullPath
 			copiedConfig.AvoidBuildIndex = true // we will build out everything in two steps.
-			idx, err := idxFile.Index(&copiedConfig)
+			var idx *SpecIndex
+			var err error
+			if cf, ok := idxFile.(interface {
+				IndexWithContext(ctx context.Context, config *SpecIndexConfig) (*SpecIndex, error)
+			}); ok {
+				idx, err = cf.IndexWithContext(ctx, &copiedConfig)
+			} else {
+				idx, err = idxFile.Index(&copiedConfig)
+			}
 
 			if err != nil {
 				errChan <- err
@@ -284,11 +336,14 @@ func (r *Rolodex) IndexTheRolodex() error {
 	})
 
 	for _, idx := range indexBuildQueue {
-		idx.BuildIndex()
+		if ctx.Err() != nil {
+			break
+		}
+		idx.BuildIndexWithContext(ctx)
 		if r.indexConfig.AvoidCircularReferenceCheck {
 			continue
 		}
-		errs := idx.resolver.CheckForCircularReferences()
+		errs := idx.resolver.CheckForCircularReferencesWithContext(ctx)
 		for e := range errs {
 			caughtErrors = append(caughtErrors, errs[e])
 		}
@@ -300,6 +355,15 @@ func (r *Rolodex) IndexTheRolodex() error {
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		if !errors.Is(errors.Join(caughtErrors...), err) {
+			caughtErrors = append(caughtErrors, err)
+		}
+		r.indexingDuration = time.Since(started)
+		r.caughtErrors = caughtErrors
+		return errors.Join(caughtErrors...)
+	}
+
 	// indexed and built every supporting file, we can build the root index (our entry point)
 	if r.rootNode != nil {
 
@@ -321,7 +385,11 @@ func (r *Rolodex) IndexTheRolodex() error {
 			}
 		}
 
-		index := NewSpecIndexWithConfig(r.rootNode, r.indexConfig)
+		// the references of the root may have been extracted already, and cached.
+		index := GetSpecIndexCache(ctx).newSpecIndex(ctx, r.rootNode, r.indexConfig)
+		if index == nil {
+			index = NewSpecIndexWithContext(ctx, r.rootNode, r.indexConfig)
+		}
 		resolver := NewResolver(index)
 
 		if r.indexConfig.IgnoreArrayCircularReferences {
@@ -332,11 +400,11 @@ func (r *Rolodex) IndexTheRolodex() error {
 		}
 		r.rootIndex = index
 		r.logger.Debug("[rolodex] starting root index build")
-		index.BuildIndex()
+		index.BuildIndexWithContext(ctx)
 		r.logger.Debug("[rolodex] root index build completed")
 
 		if !r.indexConfig.AvoidCircularReferenceCheck {
-			resolvingErrors := resolver.CheckForCircularReferences()
+			resolvingErrors := resolver.CheckForCircularReferencesWithContext(ctx)
 			r.circChecked = true
 			for e := range resolvingErrors {
 				caughtErrors = append(caughtErrors, resolvingErrors[e])
@@ -352,6 +420,29 @@ func (r *Rolodex) IndexTheRolodex() error {
 		if len(index.refErrors) > 0 {
 			caughtErrors = append(caughtErrors, index.refErrors...)
 		}
+		// duplicated operationIds and dangling links are problems of the document as a whole, checked from the root.
+		// they only fail the build when strict, otherwise they are left to GetOperationIdIndexErrors and
+		// GetLinkIndexErrors.
+		if r.indexConfig.Strict {
+			caughtErrors = append(caughtErrors, index.GetOperationIdIndexErrors()...)
+			caughtErrors = append(caughtErrors, index.GetLinkIndexErrors()...)
+		}
+	}
+
+	// the root index stopped part way.
+	if err := ctx.Err(); err != nil && !errors.Is(errors.Join(caughtErrors...), err) {
+		caughtErrors = append(caughtErrors, err)
+	}
+
+	// a remote file that no longer matches its pin fails the build, not just the references to it.
+	for _, v := range r.remoteFS {
+		if rfs, ok := v.(interface{ GetErrors() []error }); ok {
+			for _, err := range rfs.GetErrors() {
+				if errors.Is(err, datamodel.ErrPinMismatch) {
+					caughtErrors = append(caughtErrors, err)
+				}
+			}
+		}
 	}
 	r.indexingDuration = time.Since(started)
 	r.indexed = true
@@ -363,12 +454,23 @@ func (r *Rolodex) IndexTheRolodex() error {
 
 // CheckForCircularReferences checks for circular references in the rolodex.
 func (r *Rolodex) CheckForCircularReferences() {
+	r.CheckForCircularReferencesWithContext(context.Background())
+}
+
+// CheckForCircularReferencesWithContext is the same as CheckForCircularReferences, except that the check stops as
+// soon as the supplied context is cancelled or its deadline expires, adding the context error to the caught errors.
+func (r *Rolodex) CheckForCircularReferencesWithContext(ctx context.Context) {
 	if !r.circChecked {
 		if r.rootIndex != nil && r.rootIndex.resolver != nil {
-			resolvingErrors := r.rootIndex.resolver.CheckForCircularReferences()
+			resolvingErrors := r.rootIndex.resolver.CheckForCircularReferencesWithContext(ctx)
+			r.progress().Report(datamodel.ProgressEvent{Phase: datamodel.PhaseResolve, Done: 1, Total: 1})
 			for e := range resolvingErrors {
 				r.caughtErrors = append(r.caughtErrors, resolvingErrors[e])
 			}
+			if ctx.Err() != nil {
+				r.circChecked = true
+				return
+			}
 			if len(r.rootIndex.resolver.ignoredPolyReferences) > 0 {
 				r.ignoredCircularReferences = append(r.ignoredCircularReferences, r.rootIndex.resolver.ignoredPolyReferences...)
 			}
@@ -384,6 +486,13 @@ func (r *Rolodex) CheckForCircularReferences() {
 
 // Resolve resolves references in the rolodex.
 func (r *Rolodex) Resolve() {
+	r.ResolveWithContext(context.Background())
+}
+
+// ResolveWithContext is the same as Resolve, except that resolving stops as soon as the supplied context is
+// cancelled or its deadline expires, adding the context error to the caught errors. The rolodex is then only partly
+// resolved, and should not be used.
+func (r *Rolodex) ResolveWithContext(ctx context.Context) {
 
 	var resolvers []*Resolver
 	if r.rootIndex != nil && r.rootIndex.resolver != nil {
@@ -394,11 +503,15 @@ func (r *Rolodex) Resolve() {
 			resolvers = append(resolvers, idx.resolver)
 		}
 	}
-	for _, res := range resolvers {
-		resolvingErrors := res.Resolve()
+	for i, res := range resolvers {
+		resolvingErrors := res.ResolveWithContext(ctx)
+		r.progress().Report(datamodel.ProgressEvent{Phase: datamodel.PhaseResolve, Done: i + 1, Total: len(resolvers)})
 		for e := range resolvingErrors {
 			r.caughtErrors = append(r.caughtErrors, resolvingErrors[e])
 		}
+		if ctx.Err() != nil {
+			return
+		}
 		if r.rootIndex != nil && len(r.rootIndex.resolver.ignoredPolyReferences) > 0 {
 			r.ignoredCircularReferences = append(r.ignoredCircularReferences, res.ignoredPolyReferences...)
 		}
@@ -458,6 +571,12 @@ func (r *Rolodex) GetAllMappedReferences() map[string]*Reference {
 
 // Open opens a file in the rolodex, and returns a RolodexFile.
 func (r *Rolodex) Open(location string) (RolodexFile, error) {
+	return r.OpenWithContext(context.Background(), location)
+}
+
+// OpenWithContext is the same as Open, except that a file not yet in the rolodex is fetched (if remote) and indexed
+// with ctx, so a cancelled context stops those too. File systems without an OpenWithContext method open it as usual.
+func (r *Rolodex) OpenWithContext(ctx context.Context, location string) (RolodexFile, error) {
 
 	if r == nil {
 		return nil, fmt.Errorf("rolodex has not been initialized, cannot open file '%s'", location)
@@ -489,10 +608,10 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 				fileLookup, _ = filepath.Abs(filepath.Join(k, location))
 			}
 
-			f, err := v.Open(fileLookup)
+			f, err := openWithContext(ctx, v, fileLookup)
 			if err != nil {
 				// try a lookup that is not absolute, but relative
-				f, err = v.Open(location)
+				f, err = openWithContext(ctx, v, location)
 				if err != nil {
 					errorStack = append(errorStack, err)
 					continue
@@ -538,7 +657,7 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 
 		for _, v := range r.remoteFS {
 
-			f, err := v.Open(fileLookup)
+			f, err := openWithContext(ctx, v, fileLookup)
 			if err != nil {
 				r.logger.Warn("[rolodex] errors opening remote file", "location", fileLookup, "error", err)
 			}
@@ -595,6 +714,16 @@ func (r *Rolodex) Open(location string) (RolodexFile, error) {
 	return nil, errors.Join(errorStack...)
 }
 
+// openWithContext opens name from fileSystem with ctx, if the file system supports it.
+func openWithContext(ctx context.Context, fileSystem fs.FS, name string) (fs.File, error) {
+	if cfs, ok := fileSystem.(interface {
+		OpenWithContext(ctx context.Context, name string) (fs.File, error)
+	}); ok {
+		return cfs.OpenWithContext(ctx, name)
+	}
+	return fileSystem.Open(name)
+}
+
 var suffixes = []string{"B", "KB", "MB", "GB", "TB"}
 
 func Round(val float64, roundOn float64, places int) (newVal float64) {
@@ -0,0 +1,159 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DependencyTree is every file and URL loaded by the rolodex, and which of them references which. It's there to
+// audit the dependencies of a specification, and check a build can be reproduced (see DependencyFile.Checksum).
+//
+// Files can reference each other in a loop, so the tree is held as a flat list of files, each listing the files it
+// references, starting from Root.
+type DependencyTree struct {
+	// Root is the location of the root specification.
+	Root string `json:"root"`
+
+	// Files are all the files loaded by the rolodex (along with the root specification), sorted by location.
+	Files []*DependencyFile `json:"files"`
+}
+
+// DependencyFile is a file (or URL) loaded by the rolodex.
+type DependencyFile struct {
+	// Location is the absolute path of a local file, or the URL of a remote file.
+	Location string `json:"location"`
+
+	// Remote is true if the file was fetched over HTTP.
+	Remote bool `json:"remote,omitempty"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size"`
+
+	// FetchDuration is how long it took to read (or fetch) the file, it's zero for the root specification.
+	FetchDuration time.Duration `json:"fetchDuration"`
+
+	// Checksum is the hex encoded SHA256 of the content of the file.
+	Checksum string `json:"checksum,omitempty"`
+
+	// References are the locations of the files this file references, sorted.
+	References []string `json:"references,omitempty"`
+
+	// ReferencedBy are the locations of the files that reference this file, sorted.
+	ReferencedBy []string `json:"referencedBy,omitempty"`
+}
+
+// fetchTimed is a file that knows how long it took to read (or fetch).
+type fetchTimed interface {
+	GetFetchDuration() time.Duration
+}
+
+// DependencyTree returns every file and URL loaded by the rolodex, with who referenced whom, their sizes, how long
+// they took to fetch and their checksums. The rolodex must have been indexed, everything loaded after
+// DependencyTree is called is not included.
+func (r *Rolodex) DependencyTree() *DependencyTree {
+	tree := new(DependencyTree)
+	if r == nil {
+		return tree
+	}
+	files := make(map[string]*DependencyFile)
+	add := func(location string, remote bool, data []byte, f RolodexFile) {
+		df := &DependencyFile{Location: location, Remote: remote, Size: int64(len(data))}
+		if data != nil {
+			sum := sha256.Sum256(data)
+			df.Checksum = hex.EncodeToString(sum[:])
+		}
+		if timed, ok := f.(fetchTimed); ok {
+			df.FetchDuration = timed.GetFetchDuration()
+		}
+		files[location] = df
+	}
+	for _, v := range r.localFS {
+		if lfs, ok := v.(RolodexFS); ok {
+			for _, f := range lfs.GetFiles() {
+				add(f.GetFullPath(), false, []byte(f.GetContent()), f)
+			}
+		}
+	}
+	for _, v := range r.remoteFS {
+		if rfs, ok := v.(RolodexFS); ok {
+			for _, f := range rfs.GetFiles() {
+				add(f.GetFullPath(), true, []byte(f.GetContent()), f)
+			}
+		}
+	}
+
+	indexes := r.GetIndexes()
+	if r.rootIndex != nil {
+		tree.Root = r.rootIndex.GetSpecAbsolutePath()
+		if _, ok := files[tree.Root]; !ok {
+			var data []byte
+			if info := r.indexConfig.SpecInfo; info != nil && info.SpecBytes != nil {
+				data = *info.SpecBytes
+			}
+			add(tree.Root, strings.HasPrefix(tree.Root, "http"), data, nil)
+		}
+		indexes = append([]*SpecIndex{r.rootIndex}, indexes...)
+	}
+
+	for _, idx := range indexes {
+		from, ok := files[idx.GetSpecAbsolutePath()]
+		if !ok {
+			continue
+		}
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			location, _, _ := strings.Cut(ref.FullDefinition, "#")
+			to, found := files[location]
+			if !found || to == from || slices.Contains(from.References, location) {
+				continue
+			}
+			from.References = append(from.References, location)
+			to.ReferencedBy = append(to.ReferencedBy, from.Location)
+		}
+	}
+
+	for _, df := range files {
+		slices.Sort(df.References)
+		slices.Sort(df.ReferencedBy)
+		tree.Files = append(tree.Files, df)
+	}
+	slices.SortFunc(tree.Files, func(a, b *DependencyFile) int {
+		return strings.Compare(a.Location, b.Location)
+	})
+	return tree
+}
+
+// Find returns the file at location, or nil if it's not in the tree.
+func (t *DependencyTree) Find(location string) *DependencyFile {
+	for _, f := range t.Files {
+		if f.Location == location {
+			return f
+		}
+	}
+	return nil
+}
+
+// RenderJSON renders the tree as indented JSON.
+func (t *DependencyTree) RenderJSON() []byte {
+	data, _ := json.MarshalIndent(t, "", "  ")
+	return data
+}
+
+// ExportPins returns the checksum (see DependencyFile.Checksum) of every remote file loaded by the rolodex, keyed by
+// URL. Use them as the Pins of the datamodel.RemoteFetchConfig of later builds, to make sure they load exactly the
+// same remote files.
+func (r *Rolodex) ExportPins() map[string]string {
+	pins := make(map[string]string)
+	for _, f := range r.DependencyTree().Files {
+		if f.Remote {
+			pins[f.Location] = f.Checksum
+		}
+	}
+	return pins
+}
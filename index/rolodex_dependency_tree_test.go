@@ -0,0 +1,193 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func buildDependencyRolodex(t *testing.T, root string, files map[string]string, remote *httptest.Server) *Rolodex {
+	rolo := newDependencyRolodex(t, root, files, remote)
+	require.NoError(t, rolo.IndexTheRolodex())
+	return rolo
+}
+
+// newDependencyRolodex sets up a rolodex for root, with files in a temporary directory, without indexing it.
+func newDependencyRolodex(t *testing.T, root string, files map[string]string, remote *httptest.Server) *Rolodex {
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	rootBytes := []byte(root)
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	cf.SpecFilePath = filepath.Join(dir, "openapi.yaml")
+	cf.SpecInfo = &datamodel.SpecInfo{SpecBytes: &rootBytes}
+
+	rolo := NewRolodex(cf)
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir, IndexConfig: cf})
+	require.NoError(t, err)
+	rolo.AddLocalFS(dir, fileFS)
+	if remote != nil {
+		cf.AllowRemoteLookup = true
+		remoteFS, rErr := NewRemoteFSWithConfig(cf)
+		require.NoError(t, rErr)
+		remoteFS.RemoteHandlerFunc = http.Get
+		rolo.AddRemoteFS(remote.URL, remoteFS)
+	}
+
+	var rootNode yaml.Node
+	require.NoError(t, yaml.Unmarshal(rootBytes, &rootNode))
+	rolo.SetRootNode(&rootNode)
+	return rolo
+}
+
+func TestRolodex_DependencyTree(t *testing.T) {
+	pets := `components:
+  schemas:
+    Pet:
+      properties:
+        owner:
+          $ref: 'owners.yaml#/components/schemas/Owner'`
+	owners := `components:
+  schemas:
+    Owner:
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: 'pets.yaml#/components/schemas/Pet'`
+	root := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'pets.yaml#/components/schemas/Pet'`
+
+	rolo := buildDependencyRolodex(t, root, map[string]string{
+		"pets.yaml":   pets,
+		"owners.yaml": owners,
+		"unused.yaml": "nobody: references me",
+	}, nil)
+	tree := rolo.DependencyTree()
+
+	dir := rolo.GetConfig().BasePath
+	rootPath := filepath.Join(dir, "openapi.yaml")
+	petsPath := filepath.Join(dir, "pets.yaml")
+	ownersPath := filepath.Join(dir, "owners.yaml")
+	assert.Equal(t, rootPath, tree.Root)
+
+	// only what was loaded is in the tree.
+	require.Len(t, tree.Files, 3)
+	assert.Nil(t, tree.Find(filepath.Join(dir, "unused.yaml")))
+
+	rootFile := tree.Find(rootPath)
+	require.NotNil(t, rootFile)
+	assert.Equal(t, []string{petsPath}, rootFile.References)
+	assert.Empty(t, rootFile.ReferencedBy)
+	assert.Equal(t, int64(len(root)), rootFile.Size)
+	assert.Zero(t, rootFile.FetchDuration)
+
+	petsFile := tree.Find(petsPath)
+	require.NotNil(t, petsFile)
+	assert.False(t, petsFile.Remote)
+	assert.Equal(t, int64(len(pets)), petsFile.Size)
+	sum := sha256.Sum256([]byte(pets))
+	assert.Equal(t, hex.EncodeToString(sum[:]), petsFile.Checksum)
+	assert.Equal(t, []string{ownersPath}, petsFile.References)
+	assert.Equal(t, []string{rootPath, ownersPath}, petsFile.ReferencedBy)
+	assert.Equal(t, []string{petsPath}, tree.Find(ownersPath).References)
+
+	var rendered DependencyTree
+	require.NoError(t, json.Unmarshal(tree.RenderJSON(), &rendered))
+	assert.Equal(t, tree, &rendered)
+
+	var nilRolodex *Rolodex
+	assert.Empty(t, nilRolodex.DependencyTree().Files)
+}
+
+func TestRolodex_DependencyTree_Remote(t *testing.T) {
+	remote := `components:
+  schemas:
+    Thing:
+      type: string`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remote))
+	}))
+	defer server.Close()
+
+	rolo := buildDependencyRolodex(t, `openapi: 3.1.0
+components:
+  schemas:
+    Local:
+      $ref: '`+server.URL+`/remote.yaml#/components/schemas/Thing'`, nil, server)
+	tree := rolo.DependencyTree()
+
+	require.Len(t, tree.Files, 2)
+	remoteFile := tree.Find(server.URL + "/remote.yaml")
+	require.NotNil(t, remoteFile)
+	assert.True(t, remoteFile.Remote)
+	assert.Equal(t, int64(len(remote)), remoteFile.Size)
+	assert.NotZero(t, remoteFile.FetchDuration)
+	assert.Equal(t, []string{tree.Root}, remoteFile.ReferencedBy)
+}
+
+func TestRolodex_ExportPins(t *testing.T) {
+	remote := `components:
+  schemas:
+    Thing:
+      type: string`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(remote))
+	}))
+	defer server.Close()
+	root := `openapi: 3.1.0
+components:
+  schemas:
+    Local:
+      $ref: 'local.yaml#/components/schemas/Thing'
+    Remote:
+      $ref: '` + server.URL + `/remote.yaml#/components/schemas/Thing'`
+	files := map[string]string{"local.yaml": remote}
+
+	// only remote files are pinned.
+	pins := buildDependencyRolodex(t, root, files, server).ExportPins()
+	sum := sha256.Sum256([]byte(remote))
+	assert.Equal(t, map[string]string{server.URL + "/remote.yaml": hex.EncodeToString(sum[:])}, pins)
+
+	// the same content matches its pin.
+	rolo := newDependencyRolodex(t, root, files, server)
+	rolo.GetConfig().RemoteFetch.Pins = pins
+	require.NoError(t, rolo.IndexTheRolodex())
+
+	// but changed content does not.
+	remote += "\n      format: uuid"
+	rolo = newDependencyRolodex(t, root, files, server)
+	rolo.GetConfig().RemoteFetch.Pins = pins
+	err := rolo.IndexTheRolodex()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, datamodel.ErrPinMismatch))
+	assert.ErrorContains(t, err, server.URL+"/remote.yaml")
+
+	var nilRolodex *Rolodex
+	assert.Empty(t, nilRolodex.ExportPins())
+}
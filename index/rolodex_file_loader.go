@@ -4,6 +4,8 @@
 package index
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -31,6 +33,7 @@ type LocalFS struct {
 	readingErrors       []error
 	rolodex             *Rolodex
 	processingFiles     sync.Map
+	lazyDirFS           bool
 }
 
 // GetFiles returns the files that have been indexed. A map of RolodexFile objects keyed by the full path of the file.
@@ -58,6 +61,11 @@ type waiterLocal struct {
 
 // Open opens a file, returning it or an error. If the file is not found, the error is of type *PathError.
 func (l *LocalFS) Open(name string) (fs.File, error) {
+	return l.OpenWithContext(context.Background(), name)
+}
+
+// OpenWithContext is the same as Open, except that a file not yet loaded is indexed with ctx.
+func (l *LocalFS) OpenWithContext(ctx context.Context, name string) (fs.File, error) {
 	if l.indexConfig != nil && !l.indexConfig.AllowFileLookup {
 		return nil, &fs.PathError{
 			Op: "open", Path: name,
@@ -73,7 +81,7 @@ func (l *LocalFS) Open(name string) (fs.File, error) {
 	if f, ok := l.Files.Load(name); ok {
 		return f.(*LocalFile), nil
 	} else {
-		if l.fsConfig != nil && l.fsConfig.DirFS == nil {
+		if l.fsConfig != nil && (l.fsConfig.DirFS == nil || l.lazyDirFS) {
 
 			// if we're processing, we need to block and wait for the file to be processed
 			// try path first
@@ -100,9 +108,15 @@ func (l *LocalFS) Open(name string) (fs.File, error) {
 
 			var extractedFile *LocalFile
 			var extErr error
-			// attempt to open the file from the local filesystem
-			l.logger.Debug("[rolodex file loader]: extracting file from OS", "file", name)
-			extractedFile, extErr = l.extractFile(name)
+			if l.lazyDirFS {
+				// a virtual file system only understands paths relative to its root.
+				l.logger.Debug("[rolodex file loader]: extracting file from virtual file system", "file", name)
+				extractedFile, extErr = l.extractFile(l.dirFSPath(name))
+			} else {
+				// attempt to open the file from the local filesystem
+				l.logger.Debug("[rolodex file loader]: extracting file from OS", "file", name)
+				extractedFile, extErr = l.extractFile(name)
+			}
 			if extErr != nil {
 				l.processingFiles.Delete(name)
 				processingWaiter.done = true
@@ -115,7 +129,7 @@ func (l *LocalFS) Open(name string) (fs.File, error) {
 					copiedCfg.SpecAbsolutePath = name
 					copiedCfg.AvoidBuildIndex = true
 
-					idx, idxError := extractedFile.Index(&copiedCfg)
+					idx, idxError := extractedFile.IndexWithContext(ctx, &copiedCfg)
 
 					if idx != nil && l.rolodex != nil {
 						idx.rolodex = l.rolodex
@@ -128,13 +142,15 @@ func (l *LocalFS) Open(name string) (fs.File, error) {
 						// for each index, we need a resolver
 						resolver := NewResolver(idx)
 						idx.resolver = resolver
-						idx.BuildIndex()
+						idx.BuildIndexWithContext(ctx)
 					}
 
 					if len(extractedFile.data) > 0 {
 						l.logger.Debug("[rolodex file loader]: successfully loaded and indexed file", "file", name)
 					}
 
+					l.indexConfig.Progress.Report(datamodel.ProgressEvent{Phase: datamodel.PhaseIndex, File: name})
+
 					// add index to rolodex indexes
 					if l.rolodex != nil {
 						l.rolodex.AddIndex(idx)
@@ -170,6 +186,7 @@ type LocalFile struct {
 	index         *SpecIndex
 	parsed        *yaml.Node
 	offset        int64
+	fetchDuration time.Duration
 }
 
 // GetIndex returns the *SpecIndex for the file.
@@ -177,20 +194,38 @@ func (l *LocalFile) GetIndex() *SpecIndex {
 	return l.index
 }
 
+// GetFetchDuration returns how long it took to read the file.
+func (l *LocalFile) GetFetchDuration() time.Duration {
+	return l.fetchDuration
+}
+
 // Index returns the *SpecIndex for the file. If the index has not been created, it will be created (indexed)
 func (l *LocalFile) Index(config *SpecIndexConfig) (*SpecIndex, error) {
+	return l.IndexWithContext(context.Background(), config)
+}
+
+// IndexWithContext is the same as Index, except that indexing stops as soon as ctx is done. The partly built index
+// is not kept, the error of ctx is returned instead.
+func (l *LocalFile) IndexWithContext(ctx context.Context, config *SpecIndexConfig) (*SpecIndex, error) {
 	if l.index != nil {
 		return l.index, nil
 	}
 	content := l.data
 
-	// first, we must parse the content of the file
-	info, err := datamodel.ExtractSpecInfoWithDocumentCheckSync(content, true)
+	// first, we must parse the content of the file, the nodes are counted before anything expands their aliases.
+	var limits datamodel.DocumentLimits
+	if config != nil {
+		limits = config.Limits
+	}
+	info, err := datamodel.ExtractSpecInfoWithLimits(content, true, l.fullPath, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	index := NewSpecIndexWithConfig(info.RootNode, config)
+	index := NewSpecIndexWithContext(ctx, info.RootNode, config)
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
 	index.specAbsolutePath = l.fullPath
 
 	l.index = index
@@ -203,7 +238,8 @@ func (l *LocalFile) GetContent() string {
 }
 
 // GetContentAsYAMLNode returns the content of the file as a *yaml.Node. If something went wrong
-// then an error is returned.
+// then an error is returned. The file is parsed once and the tree is shared with the index of the file, so it must
+// not be changed (see RolodexFile).
 func (l *LocalFile) GetContentAsYAMLNode() (*yaml.Node, error) {
 	if l.parsed != nil {
 		return l.parsed, nil
@@ -368,6 +404,11 @@ func NewLocalFSWithConfig(config *LocalFSConfig) (*LocalFS, error) {
 				}
 			}
 			_, fErr := localFS.extractFile(p)
+			if errors.Is(fErr, datamodel.ErrLimitExceeded) {
+				// oversized files are skipped, not fatal.
+				allErrors = append(allErrors, fErr)
+				return nil
+			}
 			return fErr
 		})
 
@@ -380,6 +421,47 @@ func NewLocalFSWithConfig(config *LocalFSConfig) (*LocalFS, error) {
 	return localFS, nil
 }
 
+// NewLocalFSWithDirFS creates a new LocalFS that reads files from any fs.FS (such as an embed.FS, an fstest.MapFS or
+// a zip.Reader) instead of the operating system. The baseDirectory is the location the virtual file system is mounted
+// at; absolute and relative references below it are mapped onto paths inside the fs.FS.
+//
+// Unlike setting LocalFSConfig.DirFS, the file system is not walked up front. Files are read (and indexed) only
+// when they are referenced, the same as files read from the OS.
+func NewLocalFSWithDirFS(baseDirectory string, dirFS fs.FS, indexConfig *SpecIndexConfig) *LocalFS {
+	absBaseDir, _ := filepath.Abs(baseDirectory)
+	var log *slog.Logger
+	if indexConfig != nil {
+		log = indexConfig.Logger
+	}
+	if log == nil {
+		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelError,
+		}))
+	}
+	return &LocalFS{
+		indexConfig: indexConfig,
+		fsConfig: &LocalFSConfig{
+			BaseDirectory: absBaseDir,
+			DirFS:         dirFS,
+			IndexConfig:   indexConfig,
+			Logger:        log,
+		},
+		logger:              log,
+		baseDirectory:       absBaseDir,
+		entryPointDirectory: baseDirectory,
+		lazyDirFS:           true,
+	}
+}
+
+// dirFSPath converts an absolute path into a path inside the DirFS, relative to the base directory.
+func (l *LocalFS) dirFSPath(name string) string {
+	rel, err := filepath.Rel(l.baseDirectory, name)
+	if err != nil {
+		return name
+	}
+	return filepath.ToSlash(rel)
+}
+
 func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 	extension := ExtractFileType(p)
 	var readingErrors []error
@@ -396,32 +478,37 @@ func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 
 	switch extension {
 	case YAML, JSON:
+		start := time.Now()
 		var file fs.File
 		var fileError error
 		if config != nil && config.DirFS != nil {
 			l.logger.Debug("[rolodex file loader]: collecting JSON/YAML file from dirFS", "file", abs)
-			file, _ = config.DirFS.Open(p)
+			file, fileError = config.DirFS.Open(p)
 		} else {
 			l.logger.Debug("[rolodex file loader]: reading local file from OS", "file", abs)
 			file, fileError = os.Open(abs)
 		}
 
-		if config != nil && config.DirFS != nil {
-		} else {
-			file, fileError = os.Open(abs)
-		}
-
-		// if reading without a directory FS, error out on any error, do not continue.
+		// error out on any error, do not continue.
 		if fileError != nil {
 			return nil, fileError
 		}
+		defer file.Close()
 
 		modTime := time.Now()
 		stat, _ := file.Stat()
 		if stat != nil {
 			modTime = stat.ModTime()
 		}
-		fileData, _ = io.ReadAll(file)
+		if l.indexConfig != nil && l.indexConfig.Limits.MaxFileSize > 0 {
+			// never read more than one byte over the limit.
+			fileData, _ = io.ReadAll(io.LimitReader(file, l.indexConfig.Limits.MaxFileSize+1))
+			if sErr := l.indexConfig.Limits.CheckFileSize(abs, int64(len(fileData))); sErr != nil {
+				return nil, sErr
+			}
+		} else {
+			fileData, _ = io.ReadAll(file)
+		}
 
 		lf := &LocalFile{
 			filename:      p,
@@ -431,6 +518,7 @@ func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 			fullPath:      abs,
 			lastModified:  modTime,
 			readingErrors: readingErrors,
+			fetchDuration: time.Since(start),
 		}
 		l.Files.Store(abs, lf)
 		return lf, nil
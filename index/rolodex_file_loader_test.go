@@ -4,6 +4,7 @@
 package index
 
 import (
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"io"
@@ -151,6 +152,26 @@ func TestRolodexLocalFile_NoIndexRoot(t *testing.T) {
 
 }
 
+func TestRolodexLocalFile_Index_MaxNodes(t *testing.T) {
+
+	// the aliases are never expanded, the nodes are counted before the file is decoded.
+	lf := &LocalFile{fullPath: "bomb.yaml", data: []byte(`a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]`)}
+	cf := CreateClosedAPIIndexConfig()
+	cf.Limits.MaxNodes = 10_000
+
+	idx, err := lf.Index(cf)
+	assert.Nil(t, idx)
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+	assert.Contains(t, err.Error(), "'bomb.yaml'")
+}
+
 func TestRolodexLocalFS_NoBaseRelative(t *testing.T) {
 
 	lfs := &LocalFS{}
@@ -0,0 +1,187 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// remoteFetcher applies a datamodel.RemoteFetchConfig to remote fetches made by a RemoteFS, limiting how many
+// fetches run at the same time, retrying failed fetches and enforcing the overall timeout. Waiting for a slot, waiting
+// to retry and waiting for the handler all stop once the context of the fetch is done.
+type remoteFetcher struct {
+	config   datamodel.RemoteFetchConfig
+	parallel chan struct{}
+	hostLock sync.Mutex
+	hosts    map[string]chan struct{}
+	start    sync.Once
+	deadline time.Time
+}
+
+func newRemoteFetcher(config datamodel.RemoteFetchConfig) *remoteFetcher {
+	f := &remoteFetcher{config: config, hosts: make(map[string]chan struct{})}
+	if config.MaxParallel > 0 {
+		f.parallel = make(chan struct{}, config.MaxParallel)
+	}
+	return f
+}
+
+// fetch fetches remoteURL using handler, it blocks until the fetch is allowed to run. The returned release function
+// must be called once the response body has been read, to allow waiting fetches to run.
+func (f *remoteFetcher) fetch(ctx context.Context, handler utils.RemoteURLHandler, remoteURL *url.URL,
+) (*http.Response, func(), error) {
+	f.start.Do(func() {
+		if f.config.Timeout > 0 {
+			f.deadline = time.Now().Add(f.config.Timeout)
+		}
+	})
+	location := remoteURL.String()
+
+	var host chan struct{}
+	if f.config.MaxPerHost > 0 {
+		f.hostLock.Lock()
+		host = f.hosts[remoteURL.Host]
+		if host == nil {
+			host = make(chan struct{}, f.config.MaxPerHost)
+			f.hosts[remoteURL.Host] = host
+		}
+		f.hostLock.Unlock()
+	}
+	// take the host slot first, so fetches waiting for a busy host don't hold a slot other hosts could use.
+	if err := f.acquire(ctx, host, location); err != nil {
+		return nil, func() {}, err
+	}
+	if err := f.acquire(ctx, f.parallel, location); err != nil {
+		release(host)
+		return nil, func() {}, err
+	}
+	done := func() {
+		release(f.parallel)
+		release(host)
+	}
+
+	for attempt := 0; ; attempt++ {
+		response, err := f.call(ctx, handler, location)
+		if attempt >= f.config.Retries || !f.config.ShouldRetry(response, err) || f.timedOut(err) || ctx.Err() != nil {
+			return response, done, err
+		}
+		wait := f.config.Backoff(attempt+1, response)
+		if response != nil && response.Body != nil {
+			_ = response.Body.Close()
+		}
+		if err = f.sleep(ctx, wait, location); err != nil {
+			return nil, done, err
+		}
+	}
+}
+
+// call runs the handler, abandoning it if the deadline passes, or ctx is done, before it returns.
+func (f *remoteFetcher) call(ctx context.Context, handler utils.RemoteURLHandler, location string,
+) (*http.Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, f.cancelledError(ctx, location)
+	}
+	if f.deadline.IsZero() && ctx.Done() == nil {
+		return handler(location)
+	}
+	var expired <-chan time.Time
+	if !f.deadline.IsZero() {
+		remaining := time.Until(f.deadline)
+		if remaining <= 0 {
+			return nil, f.timeoutError(location)
+		}
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	type result struct {
+		response *http.Response
+		err      error
+	}
+	results := make(chan result, 1)
+	go func() {
+		r, e := handler(location)
+		results <- result{r, e}
+	}()
+	abandon := func() {
+		go func() {
+			// the handler is still running, clean up whatever it eventually returns.
+			if r := <-results; r.response != nil && r.response.Body != nil {
+				_, _ = io.Copy(io.Discard, r.response.Body)
+				_ = r.response.Body.Close()
+			}
+		}()
+	}
+	select {
+	case r := <-results:
+		return r.response, r.err
+	case <-expired:
+		abandon()
+		return nil, f.timeoutError(location)
+	case <-ctx.Done():
+		abandon()
+		return nil, f.cancelledError(ctx, location)
+	}
+}
+
+func (f *remoteFetcher) acquire(ctx context.Context, slots chan struct{}, location string) error {
+	if slots == nil {
+		return nil
+	}
+	var expired <-chan time.Time
+	if !f.deadline.IsZero() {
+		timer := time.NewTimer(time.Until(f.deadline))
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-expired:
+		return f.timeoutError(location)
+	case <-ctx.Done():
+		return f.cancelledError(ctx, location)
+	}
+}
+
+func release(slots chan struct{}) {
+	if slots != nil {
+		<-slots
+	}
+}
+
+func (f *remoteFetcher) sleep(ctx context.Context, wait time.Duration, location string) error {
+	if !f.deadline.IsZero() && time.Now().Add(wait).After(f.deadline) {
+		return f.timeoutError(location)
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return f.cancelledError(ctx, location)
+	}
+}
+
+func (f *remoteFetcher) timedOut(err error) bool {
+	return err != nil && !f.deadline.IsZero() && !time.Now().Before(f.deadline)
+}
+
+func (f *remoteFetcher) cancelledError(ctx context.Context, location string) error {
+	return fmt.Errorf("unable to fetch '%s': %w", location, ctx.Err())
+}
+
+func (f *remoteFetcher) timeoutError(location string) error {
+	return fmt.Errorf("%w: unable to fetch '%s' within %s", datamodel.ErrRemoteFetchTimeout, location, f.config.Timeout)
+}
@@ -4,6 +4,8 @@
 package index
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -20,6 +22,7 @@ import (
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -46,6 +49,9 @@ type RemoteFS struct {
 	logger            *slog.Logger
 	extractedFiles    map[string]RolodexFile
 	rolodex           *Rolodex
+	fetched           atomic.Int64
+	fetcherOnce       sync.Once
+	fetcher           *remoteFetcher
 }
 
 // RemoteFile is a file that has been indexed by the RemoteFS. It implements the RolodexFile interface.
@@ -59,8 +65,11 @@ type RemoteFile struct {
 	lastModified  time.Time
 	seekingErrors []error
 	index         *SpecIndex
-	parsed        *yaml.Node
+	parsed        *yaml.Node // parsed once, shared by the index and every reference into the file, never copied.
+	parseOnce     sync.Once
+	parseErr      error
 	offset        int64
+	fetchDuration time.Duration
 }
 
 // GetFileName returns the name of the file.
@@ -68,32 +77,60 @@ func (f *RemoteFile) GetFileName() string {
 	return f.filename
 }
 
+// GetFetchDuration returns how long it took to fetch the file.
+func (f *RemoteFile) GetFetchDuration() time.Duration {
+	return f.fetchDuration
+}
+
 // GetContent returns the content of the file as a string.
 func (f *RemoteFile) GetContent() string {
 	return string(f.data)
 }
 
-// GetContentAsYAMLNode returns the content of the file as a yaml.Node.
+// GetContentAsYAMLNode returns the content of the file as a yaml.Node. The file is parsed once, the tree is shared
+// by the index of the file and every reference into the file, whatever its fragment. There is no copy-on-write, a
+// change made to the tree is seen by every model built from the file, so it must never be changed: use
+// GetContentAsYAMLNodeCopy to get a tree that can be.
 func (f *RemoteFile) GetContentAsYAMLNode() (*yaml.Node, error) {
-	if f.parsed != nil {
-		return f.parsed, nil
-	}
 	if f.index != nil && f.index.root != nil {
 		return f.index.root, nil
 	}
-	if f.data == nil {
-		return nil, fmt.Errorf("no data to parse for file: %s", f.fullPath)
-	}
-	var root yaml.Node
-	err := yaml.Unmarshal(f.data, &root)
+	root, err := f.parse()
 	if err != nil {
 		return nil, err
 	}
 	if f.index != nil && f.index.root == nil {
-		f.index.root = &root
+		f.index.root = root
+	}
+	return root, nil
+}
+
+// GetContentAsYAMLNodeCopy returns a copy of the content of the file as a yaml.Node, which can be mutated without
+// changing the tree shared by the references into the file.
+func (f *RemoteFile) GetContentAsYAMLNodeCopy() (*yaml.Node, error) {
+	root, err := f.GetContentAsYAMLNode()
+	if err != nil {
+		return nil, err
 	}
-	f.parsed = &root
-	return &root, nil
+	return utils.CopyNode(root), nil
+}
+
+// parse parses the content of the file the first time it's called, and returns the same tree from then on. The
+// tree is shared by every caller, see GetContentAsYAMLNode.
+func (f *RemoteFile) parse() (*yaml.Node, error) {
+	f.parseOnce.Do(func() {
+		if f.data == nil {
+			f.parseErr = fmt.Errorf("no data to parse for file: %s", f.fullPath)
+			return
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(f.data, &root); err != nil {
+			f.parseErr = err
+			return
+		}
+		f.parsed = &root
+	})
+	return f.parsed, f.parseErr
 }
 
 // GetFileExtension returns the file extension of the file.
@@ -175,18 +212,32 @@ func (f *RemoteFile) Read(b []byte) (int, error) {
 
 // Index indexes the file and returns a *SpecIndex, any errors are returned as well.
 func (f *RemoteFile) Index(config *SpecIndexConfig) (*SpecIndex, error) {
+	return f.IndexWithContext(context.Background(), config)
+}
+
+// IndexWithContext is the same as Index, except that indexing stops as soon as ctx is done. The partly built index
+// is not kept, the error of ctx is returned instead.
+func (f *RemoteFile) IndexWithContext(ctx context.Context, config *SpecIndexConfig) (*SpecIndex, error) {
 	if f.index != nil {
 		return f.index, nil
 	}
-	content := f.data
+	if len(bytes.TrimSpace(f.data)) == 0 {
+		return nil, errors.New("there is nothing in the spec, it's empty - so there is nothing to be done")
+	}
 
-	// first, we must parse the content of the file
-	info, err := datamodel.ExtractSpecInfoWithDocumentCheckSync(content, true)
+	// first, we must parse the content of the file, the index shares the tree with every lookup into the file.
+	root, err := f.parse()
 	if err != nil {
+		return nil, fmt.Errorf("unable to parse specification: %s", err.Error())
+	}
+	if err = config.Limits.CheckNodes(config.SpecAbsolutePath, root); err != nil {
 		return nil, err
 	}
 
-	index := NewSpecIndexWithConfig(info.RootNode, config)
+	index := NewSpecIndexWithContext(ctx, root, config)
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
 	index.specAbsolutePath = config.SpecAbsolutePath
 	f.index = index
 	return index, nil
@@ -279,6 +330,12 @@ type waiterRemote struct {
 
 // Open opens a file, returning it or an error. If the file is not found, the error is of type *PathError.
 func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
+	return i.OpenWithContext(context.Background(), remoteURL)
+}
+
+// OpenWithContext is the same as Open, except that a file not yet fetched is fetched and indexed with ctx. Waiting
+// for a fetch slot, waiting to retry and waiting for RemoteHandlerFunc all stop once ctx is done, returning its error.
+func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.File, error) {
 	if i.indexConfig != nil && !i.indexConfig.AllowRemoteLookup {
 		return nil, fmt.Errorf("remote lookup for '%s' is not allowed, please set "+
 			"AllowRemoteLookup to true as part of the index configuration", remoteURL)
@@ -356,10 +413,35 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		return nil, nil // not a remote file, nothing wrong with that - just we can't keep looking here partner.
 	}
 
+	var limits datamodel.DocumentLimits
+	if i.indexConfig != nil {
+		limits = i.indexConfig.Limits
+	}
+	// every remote file system of a rolodex counts its fetches against the same limit.
+	fetched := &i.fetched
+	if i.rolodex != nil {
+		fetched = &i.rolodex.fetchedRemoteFiles
+	}
+	if limitErr := limits.CheckRemoteFiles(remoteParsedURL.String(), int(fetched.Add(1))); limitErr != nil {
+		i.remoteErrors = append(i.remoteErrors, limitErr)
+		processingWaiter.done = true
+		i.ProcessingFiles.Delete(remoteParsedURL.Path)
+		return nil, limitErr
+	}
+
 	i.logger.Debug("[rolodex remote loader] loading remote file", "file", remoteURL, "remoteURL", remoteParsedURL.String())
 
-	response, clientErr := i.RemoteHandlerFunc(remoteParsedURL.String())
+	i.fetcherOnce.Do(func() {
+		var config datamodel.RemoteFetchConfig
+		if i.indexConfig != nil {
+			config = i.indexConfig.RemoteFetch
+		}
+		i.fetcher = newRemoteFetcher(config)
+	})
+	fetchStart := time.Now()
+	response, releaseFetch, clientErr := i.fetcher.fetch(ctx, i.RemoteHandlerFunc, remoteParsedURL)
 	if clientErr != nil {
+		releaseFetch()
 
 		i.remoteErrors = append(i.remoteErrors, clientErr)
 		// remove from processing
@@ -373,12 +455,36 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		return nil, clientErr
 	}
 	if response == nil {
+		releaseFetch()
 		// remove from processing
 		processingWaiter.done = true
 		i.ProcessingFiles.Delete(remoteParsedURL.Path)
 		return nil, fmt.Errorf("empty response from remote URL: %s", remoteParsedURL.String())
 	}
-	responseBytes, readError := io.ReadAll(response.Body)
+	var body io.Reader = response.Body
+	if limits.MaxFileSize > 0 {
+		// never read more than one byte over the limit.
+		body = io.LimitReader(response.Body, limits.MaxFileSize+1)
+	}
+	responseBytes, readError := io.ReadAll(body)
+	fetchDuration := time.Since(fetchStart)
+	releaseFetch()
+	if readError == nil {
+		if limitErr := limits.CheckFileSize(remoteParsedURL.String(), int64(len(responseBytes))); limitErr != nil {
+			i.remoteErrors = append(i.remoteErrors, limitErr)
+			processingWaiter.done = true
+			i.ProcessingFiles.Delete(remoteParsedURL.Path)
+			return nil, limitErr
+		}
+		if response.StatusCode < 400 {
+			if pinErr := i.fetcher.config.CheckPin(remoteParsedURL.String(), responseBytes); pinErr != nil {
+				i.remoteErrors = append(i.remoteErrors, pinErr)
+				processingWaiter.done = true
+				i.ProcessingFiles.Delete(remoteParsedURL.Path)
+				return nil, pinErr
+			}
+		}
+	}
 	if readError != nil {
 
 		// remove from processing
@@ -417,13 +523,14 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 	filename := filepath.Base(remoteParsedURL.Path)
 
 	remoteFile := &RemoteFile{
-		filename:     filename,
-		name:         remoteParsedURL.Path,
-		extension:    fileExt,
-		data:         responseBytes,
-		fullPath:     remoteParsedURL.String(),
-		URL:          remoteParsedURL,
-		lastModified: lastModifiedTime,
+		filename:      filename,
+		name:          remoteParsedURL.Path,
+		extension:     fileExt,
+		data:          responseBytes,
+		fullPath:      remoteParsedURL.String(),
+		URL:           remoteParsedURL,
+		lastModified:  lastModifiedTime,
+		fetchDuration: fetchDuration,
 	}
 
 	copiedCfg := *i.indexConfig
@@ -440,6 +547,7 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 	if len(remoteFile.data) > 0 {
 		i.logger.Debug("[rolodex remote loaded] successfully loaded file", "file", absolutePath)
 	}
+	i.indexConfig.Progress.Report(datamodel.ProgressEvent{Phase: datamodel.PhaseIndex, File: remoteParsedURL.String()})
 
 	processingWaiter.file = remoteFile
 	processingWaiter.done = true
@@ -448,7 +556,7 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 	i.ProcessingFiles.Delete(remoteParsedURL.Path)
 	i.Files.Store(absolutePath, remoteFile)
 
-	idx, idxError := remoteFile.Index(&copiedCfg)
+	idx, idxError := remoteFile.IndexWithContext(ctx, &copiedCfg)
 
 	if idxError != nil && idx == nil {
 		i.remoteErrors = append(i.remoteErrors, idxError)
@@ -457,7 +565,7 @@ func (i *RemoteFS) Open(remoteURL string) (fs.File, error) {
 		// for each index, we need a resolver
 		resolver := NewResolver(idx)
 		idx.resolver = resolver
-		idx.BuildIndex()
+		idx.BuildIndexWithContext(ctx)
 		if i.rolodex != nil {
 			i.rolodex.AddExternalIndex(idx, remoteParsedURL.String())
 		}
@@ -5,6 +5,7 @@ package index
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -12,10 +13,14 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var test_httpClient = &http.Client{Timeout: time.Duration(60) * time.Second}
@@ -230,6 +235,54 @@ func TestRemoteFile_GoodContent(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestRemoteFile_ParsedOnce(t *testing.T) {
+	rf := &RemoteFile{data: []byte("components:\n  schemas:\n    Pet:\n      type: object")}
+	x, err := rf.GetContentAsYAMLNode()
+	require.NoError(t, err)
+
+	// the index and every lookup share the tree parsed first.
+	idx, err := rf.Index(CreateOpenAPIIndexConfig())
+	require.NoError(t, err)
+	assert.Same(t, x, idx.GetRootNode())
+	y, _ := rf.GetContentAsYAMLNode()
+	assert.Same(t, x, y)
+
+	// copies can be changed without changing the tree that's shared.
+	c, err := rf.GetContentAsYAMLNodeCopy()
+	require.NoError(t, err)
+	assert.Equal(t, x, c)
+	c.Content[0].Content[0].Value = "changed"
+	assert.Equal(t, "components", x.Content[0].Content[0].Value)
+
+	_, err = (&RemoteFile{}).GetContentAsYAMLNodeCopy()
+	assert.Error(t, err)
+}
+
+func TestNewRemoteFS_OpenFragmentsParseOnce(t *testing.T) {
+	var fetches atomic.Int64
+	cf := CreateOpenAPIIndexConfig()
+	cf.RemoteURLHandler = func(url string) (*http.Response, error) {
+		fetches.Add(1)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader("components:\n  schemas:\n    Pet:\n      type: object")),
+		}, nil
+	}
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	a, err := rfs.Open("https://pb33f.io/pets.yaml#/components/schemas/Pet")
+	require.NoError(t, err)
+	b, err := rfs.Open("https://pb33f.io/pets.yaml#/components")
+	require.NoError(t, err)
+	assert.Same(t, a, b)
+	assert.Equal(t, int64(1), fetches.Load())
+
+	x, _ := a.(*RemoteFile).GetContentAsYAMLNode()
+	y, _ := b.(*RemoteFile).GetContentAsYAMLNode()
+	assert.Same(t, x, y)
+	assert.Same(t, x, a.(*RemoteFile).GetIndex().GetRootNode())
+}
+
 func TestRemoteFile_Index_AlreadySet(t *testing.T) {
 	rf := &RemoteFile{data: []byte("good: data"), index: &SpecIndex{}}
 	x, y := rf.Index(&SpecIndexConfig{})
@@ -442,3 +495,237 @@ func TestNewRemoteFS_BadURL(t *testing.T) {
 	assert.Nil(t, x)
 	assert.Error(t, y)
 }
+
+func TestNewRemoteFS_Limits_MaxRemoteFiles(t *testing.T) {
+	server := test_buildServer()
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	cf.RemoteURLHandler = test_httpClient.Get
+	cf.Limits.MaxRemoteFiles = 1
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	file, err := rfs.Open(server.URL + "/deeper/list.yaml")
+	assert.NoError(t, err)
+	assert.NotNil(t, file)
+
+	file, err = rfs.Open(server.URL + "/deeper/file2.yaml")
+	assert.Nil(t, file)
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+	assert.Len(t, rfs.GetErrors(), 1)
+}
+
+func TestNewRemoteFS_Limits_MaxRemoteFiles_Rolodex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`name: pizza`))
+	}))
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	cf.RemoteURLHandler = test_httpClient.Get
+	cf.Limits.MaxRemoteFiles = 1
+	rolodex := NewRolodex(cf)
+
+	// every remote file system of the rolodex counts against the same limit.
+	rfsA, _ := NewRemoteFSWithConfig(cf)
+	rfsB, _ := NewRemoteFSWithConfig(cf)
+	rolodex.AddRemoteFS(server.URL+"/a", rfsA)
+	rolodex.AddRemoteFS(server.URL+"/b", rfsB)
+
+	file, err := rfsA.Open(server.URL + "/a/pizza.yaml")
+	assert.NoError(t, err)
+	assert.NotNil(t, file)
+
+	file, err = rfsB.Open(server.URL + "/b/pizza.yaml")
+	assert.Nil(t, file)
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+}
+
+func TestNewRemoteFS_Limits_MaxFileSize(t *testing.T) {
+	server := test_buildServer()
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	cf.RemoteURLHandler = test_httpClient.Get
+	cf.Limits.MaxFileSize = 10
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	file, err := rfs.Open(server.URL + "/deeper/list.yaml")
+	assert.Nil(t, file)
+	assert.ErrorIs(t, err, datamodel.ErrLimitExceeded)
+}
+
+func TestNewRemoteFS_RemoteFetch_Retries(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) < 3 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = rw.Write([]byte(`name: pizza`))
+	}))
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	cf.RemoteURLHandler = test_httpClient.Get
+	cf.RemoteFetch.Retries = 2
+	cf.RemoteFetch.RetryBackoff = time.Millisecond
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	file, err := rfs.Open(server.URL + "/pizza.yaml")
+	assert.NoError(t, err)
+	assert.NotNil(t, file)
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestNewRemoteFS_RemoteFetch_RetriesExhausted(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	cf.RemoteURLHandler = test_httpClient.Get
+	cf.RemoteFetch.Retries = 1
+	cf.RemoteFetch.RetryBackoff = time.Millisecond
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	file, err := rfs.Open(server.URL + "/pizza.yaml")
+	assert.Nil(t, file)
+	assert.Error(t, err)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestNewRemoteFS_Context(t *testing.T) {
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	cf.RemoteFetch.Retries = 5
+	cf.RemoteFetch.RetryBackoff = time.Hour
+	rfs, _ := NewRemoteFSWithConfig(cf)
+	rolo := NewRolodex(cf)
+	rolo.AddRemoteFS(server.URL, rfs)
+
+	// the context of the rolodex build is not kept, a remote file opened once it's done is fetched as usual.
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.NoError(t, rolo.IndexTheRolodexWithContext(ctx))
+	cancel()
+
+	// waiting to retry stops with the context the file is opened with.
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	file, err := rfs.OpenWithContext(ctx, server.URL+"/pizza.yaml")
+	assert.Nil(t, file)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Minute)
+	assert.Equal(t, int64(1), calls.Load())
+
+	_, err = rfs.OpenWithContext(ctx, server.URL+"/pasta.yaml")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestNewRemoteFS_RemoteFetch_MaxParallel(t *testing.T) {
+	var running, most atomic.Int64
+	handler := func(u string) (*http.Response, error) {
+		n := running.Add(1)
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`name: pizza`))}, nil
+	}
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse("https://pb33f.io")
+	cf.RemoteURLHandler = handler
+	cf.RemoteFetch.MaxParallel = 2
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := rfs.Open(fmt.Sprintf("https://pb33f.io/file%d.yaml", i))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, most.Load(), int64(2))
+}
+
+func TestNewRemoteFS_RemoteFetch_MaxPerHost(t *testing.T) {
+	var running, most atomic.Int64
+	handler := func(u string) (*http.Response, error) {
+		n := running.Add(1)
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`name: pizza`))}, nil
+	}
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse("https://pb33f.io")
+	cf.RemoteURLHandler = handler
+	cf.RemoteFetch.MaxParallel = 4
+	cf.RemoteFetch.MaxPerHost = 1
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := rfs.Open(fmt.Sprintf("https://pb33f.io/file%d.yaml", i))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1), most.Load())
+}
+
+func TestNewRemoteFS_RemoteFetch_Timeout(t *testing.T) {
+	handler := func(u string) (*http.Response, error) {
+		time.Sleep(200 * time.Millisecond)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`name: pizza`))}, nil
+	}
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse("https://pb33f.io")
+	cf.RemoteURLHandler = handler
+	cf.RemoteFetch.Timeout = 20 * time.Millisecond
+	rfs, _ := NewRemoteFSWithConfig(cf)
+
+	file, err := rfs.Open("https://pb33f.io/slow.yaml")
+	assert.Nil(t, file)
+	assert.ErrorIs(t, err, datamodel.ErrRemoteFetchTimeout)
+
+	// the deadline has passed, so nothing else is fetched.
+	file, err = rfs.Open("https://pb33f.io/another.yaml")
+	assert.Nil(t, file)
+	assert.ErrorIs(t, err, datamodel.ErrRemoteFetchTimeout)
+}
@@ -4,6 +4,7 @@
 package index
 
 import (
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"io"
@@ -1845,3 +1846,83 @@ func TestHumanFileSize(t *testing.T) {
 	assert.Equal(t, "1 MB", HumanFileSize(1024*1024))
 
 }
+
+func TestRolodex_AddVirtualFS(t *testing.T) {
+	testFS := fstest.MapFS{
+		"schemas/pet.yaml": {Data: []byte(`type: object
+properties:
+  owner:
+    $ref: "../common.yaml#/components/schemas/Owner"`), ModTime: time.Now()},
+		"common.yaml": {Data: []byte(`components:
+  schemas:
+    Owner:
+      type: string`), ModTime: time.Now()},
+	}
+
+	root := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: "./schemas/pet.yaml"`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(root), &rootNode)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = "/not/on/disk"
+	cf.AllowFileLookup = true
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	rolo.AddVirtualFS(cf.BasePath, testFS)
+
+	assert.NoError(t, rolo.IndexTheRolodex())
+	assert.Empty(t, rolo.GetCaughtErrors())
+
+	f, err := rolo.Open("/not/on/disk/schemas/pet.yaml")
+	assert.NoError(t, err)
+	assert.NotNil(t, f.GetIndex())
+	assert.Equal(t, filepath.FromSlash("/not/on/disk/schemas/pet.yaml"), f.GetFullPath())
+
+	// the reference inside the virtual file is resolved relative to it.
+	f, err = rolo.Open("common.yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, f.GetContent(), "Owner")
+
+	// nothing outside the virtual file system can be read.
+	_, err = rolo.Open("/not/on/disk/../nope.yaml")
+	assert.Error(t, err)
+	_, err = rolo.Open("missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestRolodex_Resolve_Progress(t *testing.T) {
+	var d = `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+    Pets:
+      type: array
+      items:
+        $ref: "#/components/schemas/Pet"`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(d), &rootNode)
+
+	var events []datamodel.ProgressEvent
+	c := CreateClosedAPIIndexConfig()
+	c.AvoidCircularReferenceCheck = true
+	c.Progress = datamodel.NewProgressReporter("openapi.yaml", func(event datamodel.ProgressEvent) {
+		events = append(events, event)
+	})
+	rolo := NewRolodex(c)
+	rolo.SetRootNode(&rootNode)
+	_ = rolo.IndexTheRolodex()
+	rolo.Resolve()
+	rolo.CheckForCircularReferences()
+
+	assert.Equal(t, []datamodel.ProgressEvent{
+		{Phase: datamodel.PhaseResolve, Document: "openapi.yaml", Done: 1, Total: 1},
+		{Phase: datamodel.PhaseResolve, Document: "openapi.yaml", Done: 1, Total: 1},
+	}, events)
+}
@@ -128,7 +128,7 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 		if filepath.Base(roloLookup) == index.GetSpecFileName() {
 			return nil, index, ctx
 		}
-		rFile, err := index.rolodex.Open(roloLookup)
+		rFile, err := index.rolodex.OpenWithContext(ctx, roloLookup)
 		if err != nil {
 			return nil, index, ctx
 		}
@@ -200,7 +200,7 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 						found = FindComponent(node, compId, exp[0], idx)
 					}
 					if found == nil {
-						found = idx.FindComponent(ref)
+						found = idx.FindComponentWithContext(ctx, ref)
 					}
 
 					if found != nil {
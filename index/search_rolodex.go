@@ -10,6 +10,17 @@ import (
 // FindNodeOrigin searches all indexes for the origin of a node. If the node is found, a NodeOrigin
 // is returned, otherwise nil is returned.
 func (r *Rolodex) FindNodeOrigin(node *yaml.Node) *NodeOrigin {
+	// identical nodes (at the same line and column) can be found in many files, the file holding the node itself
+	// is the one it comes from.
+	if node != nil {
+		for _, idx := range append([]*SpecIndex{r.GetRootIndex()}, r.indexes...) {
+			if idx != nil && idx.ownsNode(node) {
+				if origin := idx.FindNodeOrigin(node); origin != nil {
+					return origin
+				}
+			}
+		}
+	}
 	f := make(chan *NodeOrigin)
 	d := make(chan bool)
 	findNode := func(i int, node *yaml.Node) {
@@ -70,3 +81,16 @@ func (index *SpecIndex) FindNodeOrigin(node *yaml.Node) *NodeOrigin {
 	}
 	return nil
 }
+
+// ownsNode returns true if the node itself (rather than an identical one) is mapped by this index. The first key
+// of a mapping is at the same line and column as the mapping, so either of them can be mapped.
+func (index *SpecIndex) ownsNode(node *yaml.Node) bool {
+	found := index.nodeMap[node.Line][node.Column]
+	if found == nil {
+		return false
+	}
+	if found.Kind == yaml.DocumentNode && len(found.Content) > 0 {
+		found = found.Content[0]
+	}
+	return found == node || (len(node.Content) > 0 && found == node.Content[0])
+}
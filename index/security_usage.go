@@ -0,0 +1,152 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SecurityUsage describes an operation that requires a security scheme, and the scopes it requires.
+type SecurityUsage struct {
+	Path   string   // the path of the operation, e.g. /pets/{id}
+	Method string   // the http method of the operation, e.g. get
+	Scheme string   // the name of the security scheme, e.g. petstore_auth
+	Scopes []string // the scopes (or roles) required, if any.
+
+	// Requirement is the index of the security requirement (in the security array) that uses the scheme. Schemes
+	// used by the same requirement must all be satisfied, different requirements are alternatives.
+	Requirement int
+
+	// Inherited is true when the operation does not define security, and uses the root security requirements.
+	Inherited bool
+
+	Node     *yaml.Node // the node of the scope array in the security requirement.
+	JSONPath string     // the JSON Path to the scope array, e.g. $.paths['/pets'].get.security[0].petstore_auth
+}
+
+type securityUsageIndex struct {
+	bySchemes    map[string][]*SecurityUsage
+	byOperations map[string]map[string][]*SecurityUsage
+}
+
+// GetSecuritySchemeUsage returns every operation that requires each security scheme, keyed by the name of the
+// scheme. Operations that do not define security inherit the root security requirements of the document (these
+// usages are marked as Inherited), an empty security array on an operation removes all requirements.
+//
+// Usages are returned in document order. The view is built the first time it is requested.
+func (index *SpecIndex) GetSecuritySchemeUsage() map[string][]*SecurityUsage {
+	return index.getSecurityUsage().bySchemes
+}
+
+// GetOperationSecurity returns the security schemes required by each operation, keyed by path and then by method.
+// Operations that do not require any security are not included. This is the reverse of GetSecuritySchemeUsage.
+func (index *SpecIndex) GetOperationSecurity() map[string]map[string][]*SecurityUsage {
+	return index.getSecurityUsage().byOperations
+}
+
+// GetOperationsUsingScope returns every operation that requires a scope of a security scheme, for example the
+// operations that use the `write:pets` scope of `petstore_auth`.
+func (index *SpecIndex) GetOperationsUsingScope(scheme, scope string) []*SecurityUsage {
+	var usages []*SecurityUsage
+	for _, u := range index.getSecurityUsage().bySchemes[scheme] {
+		if slices.Contains(u.Scopes, scope) {
+			usages = append(usages, u)
+		}
+	}
+	return usages
+}
+
+func (index *SpecIndex) getSecurityUsage() *securityUsageIndex {
+	index.securityUsageOnce.Do(func() {
+		index.securityUsage = index.buildSecurityUsage()
+	})
+	return index.securityUsage
+}
+
+func (index *SpecIndex) buildSecurityUsage() *securityUsageIndex {
+	su := &securityUsageIndex{
+		bySchemes:    make(map[string][]*SecurityUsage),
+		byOperations: make(map[string]map[string][]*SecurityUsage),
+	}
+	if index.root == nil || index.pathsNode == nil || !utils.IsNodeMap(index.pathsNode) {
+		return su
+	}
+
+	for x := 0; x+1 < len(index.pathsNode.Content); x += 2 {
+		path := index.pathsNode.Content[x].Value
+		pathItemNode := index.pathsNode.Content[x+1]
+
+		// is the path a ref?
+		if isRef, _, ref := utils.IsNodeRefValue(pathItemNode); isRef {
+			if pNode := seekRefEnd(index, ref); pNode != nil {
+				pathItemNode = pNode.Node
+			}
+		}
+
+		for y := 0; y+1 < len(pathItemNode.Content); y += 2 {
+			method := pathItemNode.Content[y].Value
+			if !isHttpMethod(method) && !strings.EqualFold(method, "trace") {
+				continue
+			}
+			opNode := pathItemNode.Content[y+1]
+
+			securityNode := index.rootSecurityNode
+			jsonPath := "$"
+			inherited := true
+			if _, n := utils.FindKeyNodeTop("security", opNode.Content); n != nil {
+				securityNode = n
+				jsonPath = fmt.Sprintf("$.paths['%s'].%s", path, method)
+				inherited = false
+			}
+
+			usages := extractSecurityUsage(securityNode, path, method, jsonPath, inherited)
+			if len(usages) == 0 {
+				continue
+			}
+			if su.byOperations[path] == nil {
+				su.byOperations[path] = make(map[string][]*SecurityUsage)
+			}
+			su.byOperations[path][method] = usages
+			for _, u := range usages {
+				su.bySchemes[u.Scheme] = append(su.bySchemes[u.Scheme], u)
+			}
+		}
+	}
+	return su
+}
+
+func extractSecurityUsage(securityNode *yaml.Node, path, method, jsonPath string, inherited bool) []*SecurityUsage {
+	if securityNode == nil || !utils.IsNodeArray(securityNode) {
+		return nil
+	}
+	var usages []*SecurityUsage
+	for i, requirement := range securityNode.Content {
+		if !utils.IsNodeMap(requirement) {
+			continue
+		}
+		for k := 0; k+1 < len(requirement.Content); k += 2 {
+			scheme := requirement.Content[k].Value
+			var scopes []string
+			for _, s := range requirement.Content[k+1].Content {
+				scopes = append(scopes, s.Value)
+			}
+			usages = append(usages, &SecurityUsage{
+				Path:        path,
+				Method:      method,
+				Scheme:      scheme,
+				Scopes:      scopes,
+				Requirement: i,
+				Inherited:   inherited,
+				Node:        requirement.Content[k+1],
+				JSONPath:    fmt.Sprintf("%s.security[%d].%s", jsonPath, i, scheme),
+			})
+		}
+	}
+	return usages
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_GetSecuritySchemeUsage(t *testing.T) {
+	yml := `openapi: 3.1.0
+security:
+  - api_key: []
+paths:
+  /pets:
+    get:
+      description: inherits root security
+    post:
+      security:
+        - petstore_auth:
+            - write:pets
+            - read:pets
+        - api_key: []
+    trace:
+      security: []
+  /pets/{id}:
+    $ref: '#/components/pathItems/pet'
+components:
+  pathItems:
+    pet:
+      delete:
+        security:
+          - petstore_auth:
+              - write:pets
+            api_key: []
+  securitySchemes:
+    api_key:
+      type: apiKey
+    petstore_auth:
+      type: oauth2`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	usage := idx.GetSecuritySchemeUsage()
+	assert.Len(t, usage, 2)
+
+	apiKey := usage["api_key"]
+	assert.Len(t, apiKey, 3)
+	assert.Equal(t, "/pets", apiKey[0].Path)
+	assert.Equal(t, "get", apiKey[0].Method)
+	assert.True(t, apiKey[0].Inherited)
+	assert.Equal(t, "$.security[0].api_key", apiKey[0].JSONPath)
+	assert.Equal(t, "post", apiKey[1].Method)
+	assert.Equal(t, 1, apiKey[1].Requirement)
+	assert.False(t, apiKey[1].Inherited)
+	assert.Equal(t, "/pets/{id}", apiKey[2].Path)
+	assert.Equal(t, 0, apiKey[2].Requirement)
+
+	auth := usage["petstore_auth"]
+	assert.Len(t, auth, 2)
+	assert.Equal(t, []string{"write:pets", "read:pets"}, auth[0].Scopes)
+	assert.Equal(t, "$.paths['/pets'].post.security[0].petstore_auth", auth[0].JSONPath)
+	assert.Equal(t, 11, auth[0].Node.Line)
+
+	ops := idx.GetOperationSecurity()
+	assert.Len(t, ops, 2)
+	assert.Len(t, ops["/pets"], 2)
+	assert.Len(t, ops["/pets"]["post"], 2)
+	assert.Nil(t, ops["/pets"]["trace"])
+	assert.Len(t, ops["/pets/{id}"]["delete"], 2)
+
+	write := idx.GetOperationsUsingScope("petstore_auth", "write:pets")
+	assert.Len(t, write, 2)
+	read := idx.GetOperationsUsingScope("petstore_auth", "read:pets")
+	assert.Len(t, read, 1)
+	assert.Equal(t, "post", read[0].Method)
+	assert.Empty(t, idx.GetOperationsUsingScope("nope", "read:pets"))
+}
+
+func TestSpecIndex_GetSecuritySchemeUsage_NoPaths(t *testing.T) {
+	yml := `openapi: 3.1.0
+security:
+  - api_key: []`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, idx.GetSecuritySchemeUsage())
+	assert.Empty(t, idx.GetOperationSecurity())
+}
@@ -13,6 +13,7 @@
 package index
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -35,10 +36,41 @@ const (
 // except it sets a base URL for resolving relative references, except it also allows for granular control over
 // how the index is set up.
 func NewSpecIndexWithConfig(rootNode *yaml.Node, config *SpecIndexConfig) *SpecIndex {
+	return NewSpecIndexWithContext(context.Background(), rootNode, config)
+}
+
+// NewSpecIndexWithContext is the same as NewSpecIndexWithConfig, except that extracting references and components,
+// and building out the index, stop as soon as ctx is cancelled or its deadline expires. The index is then only partly
+// built, and should not be used.
+func NewSpecIndexWithContext(ctx context.Context, rootNode *yaml.Node, config *SpecIndexConfig) *SpecIndex {
+	index := newSpecIndexWithConfig(config)
+	if rootNode == nil || len(rootNode.Content) <= 0 {
+		return index
+	}
+	index.root = rootNode
+	if config.Normalize {
+		utils.NormalizeNodes(rootNode)
+	}
+	if index.interner != nil {
+		index.interner.InternNodes(rootNode)
+	}
+	return createNewIndex(ctx, rootNode, index, config.AvoidBuildIndex)
+}
+
+// newSpecIndexWithConfig creates an empty index, ready to index a specification with config.
+func newSpecIndexWithConfig(config *SpecIndexConfig) *SpecIndex {
 	index := new(SpecIndex)
 	boostrapIndexCollections(index)
 	index.config = config
 	index.rolodex = config.Rolodex
+	if config.InternStrings {
+		// every file of a document shares the strings interned, through its rolodex.
+		if index.rolodex != nil {
+			index.interner = index.rolodex.interner
+		} else {
+			index.interner = utils.NewInterner()
+		}
+	}
 	index.uri = config.uri
 	index.specAbsolutePath = config.SpecAbsolutePath
 	if config.Logger != nil {
@@ -48,11 +80,7 @@ func NewSpecIndexWithConfig(rootNode *yaml.Node, config *SpecIndexConfig) *SpecI
 			Level: slog.LevelError,
 		}))
 	}
-	if rootNode == nil || len(rootNode.Content) <= 0 {
-		return index
-	}
-	index.root = rootNode
-	return createNewIndex(rootNode, index, config.AvoidBuildIndex)
+	return index
 }
 
 // NewSpecIndex will create a new index of an OpenAPI or Swagger spec. It's not resolved or converted into anything
@@ -66,23 +94,32 @@ func NewSpecIndex(rootNode *yaml.Node) *SpecIndex {
 	index.config = CreateOpenAPIIndexConfig()
 	index.root = rootNode
 	boostrapIndexCollections(index)
-	return createNewIndex(rootNode, index, false)
+	return createNewIndex(context.Background(), rootNode, index, false)
 }
 
-func createNewIndex(rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *SpecIndex {
+func createNewIndex(ctx context.Context, rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *SpecIndex {
 	// there is no node! return an empty index.
 	if rootNode == nil {
 		return index
 	}
+	startNewIndex(index)
+
+	// boot index.
+	results := index.extractRefs(ctx, index.root.Content[0], index.root, []string{}, 0, false, "")
+	return completeNewIndex(ctx, index, results, avoidBuildOut)
+}
+
+// startNewIndex starts mapping the nodes of the root of index.
+func startNewIndex(index *SpecIndex) {
 	index.nodeMapCompleted = make(chan bool)
 	index.nodeMap = make(map[int]map[int]*yaml.Node)
-	go index.MapNodes(rootNode) // this can run async.
+	go index.MapNodes(index.root) // this can run async.
 
 	index.cache = new(sync.Map)
+}
 
-	// boot index.
-	results := index.ExtractRefs(index.root.Content[0], index.root, []string{}, 0, false, "")
-
+// completeNewIndex extracts components from the references found by ExtractRefs and builds out the index.
+func completeNewIndex(ctx context.Context, index *SpecIndex, results []*Reference, avoidBuildOut bool) *SpecIndex {
 	// map poly refs
 	poly := make([]*Reference, len(index.polymorphicRefs))
 	z := 0
@@ -92,15 +129,18 @@ func createNewIndex(rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *
 	}
 
 	// pull out references
-	index.ExtractComponentsFromRefs(results)
-	index.ExtractComponentsFromRefs(poly)
+	index.extractComponentsFromRefs(ctx, results)
+	index.extractComponentsFromRefs(ctx, poly)
 
 	index.ExtractExternalDocuments(index.root)
 	index.GetPathCount()
 
 	// build out the index.
 	if !avoidBuildOut {
-		index.BuildIndex()
+		index.BuildIndexWithContext(ctx)
+	}
+	if index.config != nil && index.config.BuildSearchIndex {
+		index.getTextSearch()
 	}
 	<-index.nodeMapCompleted
 	return index
@@ -110,7 +150,13 @@ func createNewIndex(rootNode *yaml.Node, index *SpecIndex, avoidBuildOut bool) *
 // useful for looking up things, the count operations are all run in parallel and then the final calculations are run
 // the index is ready.
 func (index *SpecIndex) BuildIndex() {
-	if index.built {
+	index.BuildIndexWithContext(context.Background())
+}
+
+// BuildIndexWithContext is the same as BuildIndex, except that it stops between the count operations as soon as ctx
+// is cancelled or its deadline expires, leaving the index unbuilt.
+func (index *SpecIndex) BuildIndexWithContext(ctx context.Context) {
+	if index.built || ctx.Err() != nil {
 		return
 	}
 	countFuncs := []func() int{
@@ -125,6 +171,9 @@ func (index *SpecIndex) BuildIndex() {
 	wg.Add(len(countFuncs))
 	runIndexFunction(countFuncs, &wg) // run as fast as we can.
 	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
 
 	// these functions are aggregate and can only run once the rest of the datamodel is ready
 	countFuncs = []func() int{
@@ -137,6 +186,9 @@ func (index *SpecIndex) BuildIndex() {
 	wg.Add(len(countFuncs))
 	runIndexFunction(countFuncs, &wg) // run as fast as we can.
 	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
 
 	// these have final calculation dependencies
 	index.GetInlineDuplicateParamCount()
@@ -5,6 +5,7 @@ package index
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"log/slog"
@@ -17,9 +18,11 @@ import (
 	"runtime"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 	"sync"
 )
@@ -637,6 +640,25 @@ func TestSpecIndex_BurgerShop(t *testing.T) {
 	assert.Equal(t, 3, len(index.GetAllParametersFromOperations()))
 }
 
+func TestSpecIndex_BurgerShop_Cancelled(t *testing.T) {
+	burgershop, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(burgershop, &rootNode)
+
+	// nothing is extracted, or built, with a cancelled context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	index := NewSpecIndexWithContext(ctx, &rootNode, CreateOpenAPIIndexConfig())
+	assert.Empty(t, index.allRefs)
+	assert.Empty(t, index.GetMappedReferences())
+	assert.False(t, index.built)
+
+	index.BuildIndexWithContext(ctx)
+	assert.False(t, index.built)
+	index.BuildIndex()
+	assert.True(t, index.built)
+}
+
 func TestSpecIndex_GetAllParametersFromOperations(t *testing.T) {
 	yml := `openapi: 3.0.0
 servers:
@@ -1009,7 +1031,7 @@ func TestSpecIndex_TestPathsNodeAsArray(t *testing.T) {
 	_ = yaml.Unmarshal([]byte(yml), &rootNode)
 
 	index := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
-	assert.Nil(t, index.lookupRolodex(nil))
+	assert.Nil(t, index.lookupRolodex(context.Background(), nil))
 }
 
 func TestSpecIndex_CheckBadURLRefNoRemoteAllowed(t *testing.T) {
@@ -1808,3 +1830,40 @@ func Test_GetAllComponentSchemas(t *testing.T) {
 	index := SpecIndex{}
 	assert.Nil(t, index.GetAllComponentSchemas())
 }
+
+func TestSpecIndex_InternStrings(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object`
+
+	for _, intern := range []bool{true, false} {
+		var rootNode yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &rootNode)
+		config := CreateOpenAPIIndexConfig()
+		config.InternStrings = intern
+		idx := NewSpecIndexWithConfig(&rootNode, config)
+
+		refs := idx.GetRawReferencesSequenced()
+		require.Len(t, refs, 2)
+		assert.Equal(t, refs[0].FullDefinition, refs[1].FullDefinition)
+		assert.Equal(t, intern, unsafe.StringData(refs[0].FullDefinition) == unsafe.StringData(refs[1].FullDefinition))
+		assert.Equal(t, intern, unsafe.StringData(refs[0].KeyNode.Value) == unsafe.StringData(refs[1].KeyNode.Value))
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"slices"
+	"strings"
+)
+
+// SpecStats is a structured report of the size and shape of a specification, useful for dashboards and
+// for scoring the health of a spec.
+type SpecStats struct {
+	// Lines is the number of lines in the specification.
+	Lines int `json:"lines" yaml:"lines"`
+
+	// Paths is the number of paths defined.
+	Paths int `json:"paths" yaml:"paths"`
+
+	// Operations is the number of operations defined across all paths.
+	Operations int `json:"operations" yaml:"operations"`
+
+	// OperationsByMethod is the number of operations defined for each method (get, post etc.).
+	OperationsByMethod map[string]int `json:"operationsByMethod" yaml:"operationsByMethod"`
+
+	// Schemas is the number of every schema found (component, inline and referenced).
+	Schemas int `json:"schemas" yaml:"schemas"`
+
+	// ComponentSchemas is the number of schemas defined in components (or definitions for Swagger).
+	ComponentSchemas int `json:"componentSchemas" yaml:"componentSchemas"`
+
+	// Parameters is the number of parameters defined in components (or parameters for Swagger).
+	Parameters int `json:"parameters" yaml:"parameters"`
+
+	// OperationParameters is the number of parameters defined by paths and operations.
+	OperationParameters int `json:"operationParameters" yaml:"operationParameters"`
+
+	// Enums is the number of enums found.
+	Enums int `json:"enums" yaml:"enums"`
+
+	// References is the number of references found, InternalReferences and ExternalReferences split them
+	// by those that point to the same document, and those that point to another file or URL.
+	References         int `json:"references" yaml:"references"`
+	InternalReferences int `json:"internalReferences" yaml:"internalReferences"`
+	ExternalReferences int `json:"externalReferences" yaml:"externalReferences"`
+
+	// CircularReferences is the number of circular references found by the resolver (ignored polymorphic and
+	// array circular references are not included).
+	CircularReferences int `json:"circularReferences" yaml:"circularReferences"`
+
+	// ExternalFiles are the locations (absolute paths or URLs) of every other file indexed by the rolodex, sorted.
+	ExternalFiles []string `json:"externalFiles,omitempty" yaml:"externalFiles,omitempty"`
+}
+
+// Stats returns a SpecStats report for the index. Statistics cover the document of this index only, apart from
+// ExternalFiles, which lists every other file in the rolodex the index belongs to.
+func (index *SpecIndex) Stats() *SpecStats {
+	stats := &SpecStats{
+		OperationsByMethod:  make(map[string]int),
+		ComponentSchemas:    max(index.GetComponentSchemaCount(), 0),
+		Parameters:          max(index.GetComponentParameterCount(), 0),
+		OperationParameters: max(index.GetOperationsParameterCount(), 0),
+		Schemas:             len(index.GetAllSchemas()),
+		Enums:               len(index.GetAllEnums()),
+		CircularReferences:  len(index.GetCircularReferences()),
+	}
+	stats.Lines = index.lineCount()
+	stats.Paths = max(index.GetPathCount(), 0)
+
+	for _, methods := range index.GetAllPaths() {
+		for method := range methods {
+			stats.OperationsByMethod[method]++
+			stats.Operations++
+		}
+	}
+
+	for _, ref := range index.GetRawReferencesSequenced() {
+		stats.References++
+		if ref.KeyNode != nil && strings.HasPrefix(ref.KeyNode.Value, "#") {
+			stats.InternalReferences++
+		} else {
+			stats.ExternalReferences++
+		}
+	}
+
+	if index.rolodex != nil {
+		for _, idx := range index.rolodex.GetIndexes() {
+			if idx == nil || idx == index {
+				continue
+			}
+			if loc := idx.GetSpecAbsolutePath(); loc != "" && !slices.Contains(stats.ExternalFiles, loc) {
+				stats.ExternalFiles = append(stats.ExternalFiles, loc)
+			}
+		}
+		slices.Sort(stats.ExternalFiles)
+	}
+	return stats
+}
+
+// lineCount returns the number of lines of the specification, from the spec info if there is one, otherwise
+// the last line a node was found on.
+func (index *SpecIndex) lineCount() int {
+	if index.config != nil && index.config.SpecInfo != nil && index.config.SpecInfo.NumLines > 0 {
+		return index.config.SpecInfo.NumLines
+	}
+	lines := 0
+	for line := range index.nodeMap {
+		lines = max(lines, line)
+	}
+	return lines
+}
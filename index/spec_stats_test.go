@@ -0,0 +1,108 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSpecIndex_Stats(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    parameters:
+      - name: limit
+        in: query
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      responses:
+        "201":
+          description: created
+  /pets/{id}:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Id'
+      responses:
+        "200":
+          description: ok
+  /empty: {}
+components:
+  parameters:
+    Id:
+      name: id
+      in: path
+  schemas:
+    Pet:
+      type: object
+      properties:
+        kind:
+          type: string
+          enum: [cat, dog]
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	stats := idx.Stats()
+	assert.Equal(t, 42, stats.Lines)
+	assert.Equal(t, 3, stats.Paths)
+	assert.Equal(t, 3, stats.Operations)
+	assert.Equal(t, map[string]int{"get": 2, "post": 1}, stats.OperationsByMethod)
+	assert.Equal(t, 2, stats.ComponentSchemas)
+	assert.Equal(t, 1, stats.Parameters)
+	assert.Equal(t, 1, stats.Enums)
+	assert.Equal(t, 3, stats.References)
+	assert.Equal(t, 3, stats.InternalReferences)
+	assert.Zero(t, stats.ExternalReferences)
+	assert.Zero(t, stats.CircularReferences)
+	assert.Empty(t, stats.ExternalFiles)
+}
+
+func TestSpecIndex_Stats_Rolodex(t *testing.T) {
+	yml, _ := os.ReadFile("../test_specs/first.yaml")
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal(yml, &rootNode)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = "../test_specs"
+
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	cf.Rolodex = rolo
+
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: cf.BasePath,
+		FileFilters:   []string{"first.yaml", "second.yaml", "third.yaml", "fourth.yaml"},
+		DirFS:         os.DirFS(cf.BasePath),
+	})
+	require.NoError(t, err)
+	rolo.AddLocalFS(cf.BasePath, fileFS)
+	require.NoError(t, rolo.IndexTheRolodex())
+	rolo.CheckForCircularReferences()
+
+	stats := rolo.GetRootIndex().Stats()
+	assert.Positive(t, stats.ExternalReferences)
+	assert.Equal(t, stats.References, stats.InternalReferences+stats.ExternalReferences)
+
+	second, _ := filepath.Abs("../test_specs/second.yaml")
+	assert.Contains(t, stats.ExternalFiles, second)
+	assert.NotContains(t, stats.ExternalFiles, rolo.GetRootIndex().GetSpecAbsolutePath())
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// textSearchFields are the fields indexed for full-text search, and how much a match in each is worth.
+var textSearchFields = map[string]float64{"title": 3, "summary": 2, "description": 1}
+
+// TextSearchResult is a description, summary or title that matches a search.
+type TextSearchResult struct {
+	Field   string // description, summary or title.
+	Content string // the text of the field.
+
+	Path        string // the JSON Path to the field, e.g. $.paths['/pets'].get.description
+	JSONPointer string // the JSON Pointer to the object holding the field, e.g. /paths/~1pets/get
+
+	KeyNode    *yaml.Node // the key node of the field.
+	Node       *yaml.Node // the value node of the field.
+	ParentNode *yaml.Node // the object holding the field (an operation, a schema, a parameter...).
+
+	Index *SpecIndex // the index of the file the field is in.
+
+	// Score ranks results, the higher the better. Matches in titles are worth more than matches in summaries,
+	// which are worth more than matches in descriptions, and text holding the whole query as a phrase is worth
+	// more than text holding its words apart.
+	Score float64
+}
+
+type textSearchPosting struct {
+	entry int
+	count int
+}
+
+type textSearchIndex struct {
+	entries []*TextSearchResult
+	tokens  []string // every distinct token, sorted.
+	terms   map[string][]textSearchPosting
+}
+
+// Search returns every description, summary and title of the file indexed that holds every word of a query, best
+// matches first (see TextSearchResult.Score), then in the order of the file. Words are matched ignoring case and
+// punctuation, and the last word of the query also matches words it's the start of, so `rate lim` matches
+// "Rate limits apply". Examples and extensions are not searched.
+//
+// Every result locates the object holding the text, its JSONPointer can be handed to a Document (or
+// DocumentModel) ResolvePointer to find the model object. The search index is built the first time it's searched,
+// or when the index is built if SpecIndexConfig.BuildSearchIndex is set. Use Rolodex.Search to search every file.
+func (index *SpecIndex) Search(query string) []*TextSearchResult {
+	return index.getTextSearch().search(query)
+}
+
+// Search searches the descriptions, summaries and titles of every file of the rolodex (see SpecIndex.Search),
+// returning the best matches first, across every file. Matches of the same score are in the order of the root
+// document, then of each file indexed.
+func (r *Rolodex) Search(query string) []*TextSearchResult {
+	var results []*TextSearchResult
+	indexes := append([]*SpecIndex{r.GetRootIndex()}, r.GetIndexes()...)
+	for i, idx := range indexes {
+		if idx != nil && !slices.Contains(indexes[:i], idx) {
+			results = append(results, idx.Search(query)...)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results
+}
+
+func (index *SpecIndex) getTextSearch() *textSearchIndex {
+	index.textSearchOnce.Do(func() {
+		index.textSearch = index.buildTextSearch()
+	})
+	return index.textSearch
+}
+
+func (index *SpecIndex) buildTextSearch() *textSearchIndex {
+	ts := &textSearchIndex{terms: make(map[string][]textSearchPosting)}
+	if index.root != nil && len(index.root.Content) > 0 {
+		ts.collect(index, index.root.Content[0], nil, make(map[*yaml.Node]struct{}))
+	}
+	for i, e := range ts.entries {
+		counts := make(map[string]int)
+		for _, t := range textSearchTokens(e.Content) {
+			counts[t]++
+		}
+		for t, c := range counts {
+			ts.terms[t] = append(ts.terms[t], textSearchPosting{entry: i, count: c})
+		}
+	}
+	for t := range ts.terms {
+		ts.tokens = append(ts.tokens, t)
+	}
+	sort.Strings(ts.tokens)
+	return ts
+}
+
+// collect walks the tree of a file, collecting every text field. Examples, default, enum and const values hold
+// data rather than documentation and are skipped, as are extensions.
+func (ts *textSearchIndex) collect(index *SpecIndex, node *yaml.Node, path []string, seen map[*yaml.Node]struct{}) {
+	if node == nil {
+		return
+	}
+	if _, ok := seen[node]; ok {
+		return
+	}
+	seen[node] = struct{}{}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch {
+			case strings.HasPrefix(key.Value, "x-"), key.Value == "example", key.Value == "default",
+				key.Value == "enum", key.Value == "const", key.Value == "value" && slices.Contains(path, "examples"),
+				key.Value == "examples" && value.Kind == yaml.SequenceNode:
+				continue
+			}
+			if _, ok := textSearchFields[key.Value]; ok && value.Kind == yaml.ScalarNode && value.Value != "" {
+				pointer := utils.BuildJSONPointer(path)
+				_, jsonPath := utils.ConvertComponentIdIntoFriendlyPathSearch("#" + pointer + "/" + key.Value)
+				ts.entries = append(ts.entries, &TextSearchResult{
+					Field:       key.Value,
+					Content:     value.Value,
+					Path:        jsonPath,
+					JSONPointer: pointer,
+					KeyNode:     key,
+					Node:        value,
+					ParentNode:  node,
+					Index:       index,
+				})
+				continue
+			}
+			ts.collect(index, value, appendSearchPath(path, key.Value), seen)
+		}
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			ts.collect(index, n, appendSearchPath(path, strconv.Itoa(i)), seen)
+		}
+	}
+}
+
+func appendSearchPath(path []string, segment string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, segment)
+}
+
+func (ts *textSearchIndex) search(query string) []*TextSearchResult {
+	words := textSearchTokens(query)
+	if len(words) == 0 {
+		return nil
+	}
+	scores := make(map[int]float64)
+	for i, w := range words {
+		// the last word also matches the words it's the start of.
+		matches := make(map[int]int)
+		terms := []string{w}
+		if i == len(words)-1 {
+			terms = ts.prefixed(w)
+		}
+		for _, t := range terms {
+			for _, p := range ts.terms[t] {
+				matches[p.entry] += p.count
+			}
+		}
+		if i == 0 {
+			for e, c := range matches {
+				scores[e] = float64(c)
+			}
+			continue
+		}
+		for e := range scores {
+			if c, ok := matches[e]; ok {
+				scores[e] += float64(c)
+			} else {
+				delete(scores, e)
+			}
+		}
+	}
+
+	phrase := strings.Join(words, " ")
+	results := make([]*TextSearchResult, 0, len(scores))
+	entries := make([]int, 0, len(scores))
+	for e := range scores {
+		entries = append(entries, e)
+	}
+	sort.Ints(entries)
+	for _, e := range entries {
+		r := *ts.entries[e]
+		score := scores[e]
+		if len(words) > 1 && strings.Contains(strings.Join(textSearchTokens(r.Content), " "), phrase) {
+			score *= 2
+		}
+		r.Score = score * textSearchFields[r.Field]
+		results = append(results, &r)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results
+}
+
+// prefixed returns every token starting with prefix.
+func (ts *textSearchIndex) prefixed(prefix string) []string {
+	start := sort.SearchStrings(ts.tokens, prefix)
+	var tokens []string
+	for i := start; i < len(ts.tokens) && strings.HasPrefix(ts.tokens[i], prefix); i++ {
+		tokens = append(tokens, ts.tokens[i])
+	}
+	return tokens
+}
+
+// textSearchTokens splits text into lower case words, ignoring punctuation.
+func textSearchTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
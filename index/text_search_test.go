@@ -0,0 +1,137 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var textSearchSpec = `openapi: 3.1.0
+info:
+  title: Pet Store
+  description: A store selling pets. Requests are rate limited.
+paths:
+  /pets:
+    get:
+      summary: List pets
+      description: Rate limits apply to this endpoint, the limit is reset every hour.
+      x-internal:
+        description: rate limit rate limit
+      responses:
+        "200":
+          description: the pets
+          content:
+            application/json:
+              examples:
+                pets:
+                  summary: some pets
+                  value:
+                    description: a rate limit in an example
+components:
+  schemas:
+    RateLimit:
+      title: Rate Limit
+      type: object
+      example:
+        description: rate limit
+      properties:
+        title:
+          type: string
+          description: the title of the pet
+`
+
+func TestSpecIndex_Search(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(textSearchSpec), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	results := idx.Search("Rate limit")
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "title", results[0].Field)
+	assert.Equal(t, "Rate Limit", results[0].Content)
+	assert.Equal(t, "$.components.schemas['RateLimit'].title", results[0].Path)
+	assert.Equal(t, "/components/schemas/RateLimit", results[0].JSONPointer)
+	assert.Equal(t, 25, results[0].Node.Line)
+	assert.Equal(t, 25, results[0].ParentNode.Line)
+	assert.Equal(t, 12.0, results[0].Score)
+	assert.Same(t, idx, results[0].Index)
+
+	assert.Equal(t, "$.paths['/pets'].get.description", results[1].Path)
+	assert.Equal(t, "/paths/~1pets/get", results[1].JSONPointer)
+	assert.Equal(t, 6.0, results[1].Score)
+	assert.Equal(t, "$.info.description", results[2].Path)
+	assert.Equal(t, 4.0, results[2].Score)
+
+	// the last word matches the start of words.
+	results = idx.Search("list pe")
+	require.Len(t, results, 1)
+	assert.Equal(t, "summary", results[0].Field)
+	assert.Equal(t, "$.paths['/pets'].get.summary", results[0].Path)
+
+	results = idx.Search("title")
+	require.Len(t, results, 1)
+	assert.Equal(t, "$.components.schemas['RateLimit'].properties['title'].description", results[0].Path)
+
+	results = idx.Search("some")
+	require.Len(t, results, 1)
+	assert.Equal(t, "/paths/~1pets/get/responses/200/content/application~1json/examples/pets", results[0].JSONPointer)
+
+	assert.Empty(t, idx.Search("hourly"))
+	assert.Empty(t, idx.Search("  ...  "))
+}
+
+func TestSpecIndex_Search_BuildSearchIndex(t *testing.T) {
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(textSearchSpec), &rootNode)
+	cf := CreateOpenAPIIndexConfig()
+	cf.BuildSearchIndex = true
+	idx := NewSpecIndexWithConfig(&rootNode, cf)
+	require.NotNil(t, idx.textSearch)
+	assert.Len(t, idx.Search("pets"), 4)
+}
+
+func TestRolodex_Search(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pets.yaml"), []byte(`get:
+  summary: List the pets, rate limited
+  description: Rate limited`), 0o644))
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`openapi: 3.1.0
+info:
+  title: Rate limited pets
+paths:
+  /pets:
+    $ref: 'pets.yaml'`), &rootNode)
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	rolo := NewRolodex(cf)
+	rolo.SetRootNode(&rootNode)
+	cf.Rolodex = rolo
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: dir,
+		DirFS:         os.DirFS(dir),
+	})
+	require.NoError(t, err)
+	rolo.AddLocalFS(dir, fileFS)
+	require.NoError(t, rolo.IndexTheRolodex())
+	rolo.BuildIndexes()
+
+	results := rolo.Search("rate limited")
+	require.Len(t, results, 3)
+	assert.Equal(t, "Rate limited pets", results[0].Content)
+	assert.Same(t, rolo.GetRootIndex(), results[0].Index)
+	assert.Equal(t, "List the pets, rate limited", results[1].Content)
+	assert.Equal(t, "$.get.summary", results[1].Path)
+	assert.NotSame(t, rolo.GetRootIndex(), results[1].Index)
+	assert.Equal(t, "Rate limited", results[2].Content)
+}
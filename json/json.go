@@ -42,31 +42,40 @@ func handleMappingNode(node *yaml.Node) (any, error) {
 		if i%2 == 0 {
 			continue
 		}
-		keyNode := node.Content[i-1]
-		kv, err := handleYAMLNode(keyNode)
+		kv, err := mappingKey(node.Content[i-1])
 		if err != nil {
 			return nil, err
 		}
 
-		if reflect.TypeOf(kv).Kind() != reflect.String {
-			keyData, err := json.Marshal(kv)
-			if err != nil {
-				return nil, err
-			}
-			kv = string(keyData)
-		}
-
 		vv, err := handleYAMLNode(n)
 		if err != nil {
 			return nil, err
 		}
 
-		v.Set(fmt.Sprintf("%v", kv), vv)
+		v.Set(kv, vv)
 	}
 
 	return v, nil
 }
 
+// mappingKey converts a YAML map key into a JSON object key, non string keys are rendered as JSON.
+func mappingKey(keyNode *yaml.Node) (string, error) {
+	kv, err := handleYAMLNode(keyNode)
+	if err != nil {
+		return "", err
+	}
+
+	if reflect.TypeOf(kv).Kind() != reflect.String {
+		keyData, err := json.Marshal(kv)
+		if err != nil {
+			return "", err
+		}
+		kv = string(keyData)
+	}
+
+	return fmt.Sprintf("%v", kv), nil
+}
+
 func handleSequenceNode(node *yaml.Node) (any, error) {
 	var s []yaml.Node
 
@@ -0,0 +1,188 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SourceMapping links a JSON value in minified output back to the yaml.Node it was rendered from.
+type SourceMapping struct {
+	Pointer string // the JSON Pointer to the value, an empty string is the root.
+	Offset  int    // the byte offset of the first character of the value in the output.
+	Length  int    // the number of bytes the value occupies in the output.
+	Line    int    // the line of the value in the original document, zero if the node has no position.
+	Column  int    // the column of the value in the original document, zero if the node has no position.
+}
+
+// SourceMap is a parallel map for minified JSON output, containing a SourceMapping for every value in the output,
+// in the order they appear.
+type SourceMap struct {
+	Mappings []*SourceMapping
+}
+
+// Find returns the SourceMapping for a JSON Pointer, or nil if the pointer is not in the output.
+func (s *SourceMap) Find(pointer string) *SourceMapping {
+	if s == nil {
+		return nil
+	}
+	for _, m := range s.Mappings {
+		if m.Pointer == pointer {
+			return m
+		}
+	}
+	return nil
+}
+
+// At returns the innermost SourceMapping that contains the byte offset, or nil if the offset is out of range.
+// Useful for tracing an error reported against the minified output back to the model.
+func (s *SourceMap) At(offset int) *SourceMapping {
+	if s == nil {
+		return nil
+	}
+	var found *SourceMapping
+	// mappings are in output order, so a mapping that starts later is always nested inside an earlier one.
+	for _, m := range s.Mappings {
+		if m.Offset > offset {
+			break
+		}
+		if offset < m.Offset+m.Length {
+			found = m
+		}
+	}
+	return found
+}
+
+// YAMLNodeToMinifiedJSON converts yaml/json stored in a yaml.Node to minified json ordered matching the original
+// yaml/json. A SourceMap is returned alongside the output, linking every value back to its location in the
+// original document.
+func YAMLNodeToMinifiedJSON(node *yaml.Node) ([]byte, *SourceMap, error) {
+	m := &jsonWriter{sourceMap: new(SourceMap)}
+	if err := m.write(node, nil); err != nil {
+		return nil, nil, err
+	}
+	return m.buf.Bytes(), m.sourceMap, nil
+}
+
+// jsonWriter writes yaml.Node trees as compact JSON, directly from the nodes.
+type jsonWriter struct {
+	buf             bytes.Buffer
+	sourceMap       *SourceMap // mappings are only collected when set.
+	preserveNumbers bool       // numbers are written using their original text, rather than decoded.
+}
+
+func (m *jsonWriter) write(node *yaml.Node, path []string) error {
+	if node.Kind == yaml.DocumentNode {
+		return m.write(node.Content[0], path)
+	}
+	var mapping *SourceMapping
+	if m.sourceMap != nil {
+		mapping = &SourceMapping{
+			Pointer: utils.BuildJSONPointer(path),
+			Offset:  m.buf.Len(),
+			Line:    node.Line,
+			Column:  node.Column,
+		}
+		m.sourceMap.Mappings = append(m.sourceMap.Mappings, mapping)
+	}
+
+	value := node
+	if value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	var err error
+	switch value.Kind {
+	case yaml.SequenceNode:
+		err = m.writeSequence(value, path)
+	case yaml.MappingNode:
+		err = m.writeMapping(value, path)
+	case yaml.ScalarNode:
+		err = m.writeScalar(value)
+	default:
+		err = fmt.Errorf("unknown node kind: %v", value.Kind)
+	}
+	if err != nil {
+		return err
+	}
+	if mapping != nil {
+		mapping.Length = m.buf.Len() - mapping.Offset
+	}
+	return nil
+}
+
+func (m *jsonWriter) writeMapping(node *yaml.Node, path []string) error {
+	// duplicate keys keep the position of the first, and the value of the last (the same as YAMLNodeToJSON).
+	var keys []string
+	values := make(map[string]*yaml.Node)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, err := mappingKey(node.Content[i])
+		if err != nil {
+			return err
+		}
+		if _, ok := values[k]; !ok {
+			keys = append(keys, k)
+		}
+		values[k] = node.Content[i+1]
+	}
+
+	m.buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			m.buf.WriteByte(',')
+		}
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return err
+		}
+		m.buf.Write(kb)
+		m.buf.WriteByte(':')
+		if err = m.write(values[k], append(path[:len(path):len(path)], k)); err != nil {
+			return err
+		}
+	}
+	m.buf.WriteByte('}')
+	return nil
+}
+
+func (m *jsonWriter) writeSequence(node *yaml.Node, path []string) error {
+	m.buf.WriteByte('[')
+	for i, n := range node.Content {
+		if i > 0 {
+			m.buf.WriteByte(',')
+		}
+		if err := m.write(n, append(path[:len(path):len(path)], strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+	m.buf.WriteByte(']')
+	return nil
+}
+
+func (m *jsonWriter) writeScalar(node *yaml.Node) error {
+	if m.preserveNumbers {
+		if b, ok, err := preservedScalar(node); ok || err != nil {
+			if err != nil {
+				return err
+			}
+			m.buf.Write(b)
+			return nil
+		}
+	}
+	v, err := handleScalarNode(node)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	m.buf.Write(b)
+	return nil
+}
@@ -0,0 +1,105 @@
+package json_test
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLNodeToMinifiedJSON(t *testing.T) {
+	y := `root:
+  key1: scalar1
+  key2:
+    - scalar2
+    - subkey1: scalar3
+      sub/key~2:
+        - 1
+        - 2.5
+  key3: true
+  key4: null`
+
+	var v yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(y), &v))
+
+	j, sm, err := json.YAMLNodeToMinifiedJSON(&v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"root":{"key1":"scalar1","key2":["scalar2",{"subkey1":"scalar3","sub/key~2":[1,2.5]}],"key3":true,"key4":null}}`, string(j))
+
+	// the output is the same as the indented output, once compacted.
+	indented, err := json.YAMLNodeToJSON(&v, "  ")
+	require.NoError(t, err)
+	var compacted bytes.Buffer
+	require.NoError(t, stdjson.Compact(&compacted, indented))
+	assert.Equal(t, compacted.String(), string(j))
+
+	assert.Len(t, sm.Mappings, 12)
+
+	root := sm.Find("")
+	require.NotNil(t, root)
+	assert.Equal(t, 0, root.Offset)
+	assert.Equal(t, len(j), root.Length)
+
+	m := sm.Find("/root/key2/1/sub~1key~02/1")
+	require.NotNil(t, m)
+	assert.Equal(t, "2.5", string(j[m.Offset:m.Offset+m.Length]))
+	assert.Equal(t, 8, m.Line)
+	assert.Equal(t, 11, m.Column)
+
+	m = sm.Find("/root/key1")
+	require.NotNil(t, m)
+	assert.Equal(t, `"scalar1"`, string(j[m.Offset:m.Offset+m.Length]))
+	assert.Equal(t, 2, m.Line)
+	assert.Equal(t, 9, m.Column)
+
+	assert.Nil(t, sm.Find("/root/nope"))
+}
+
+func TestSourceMap_At(t *testing.T) {
+	var v yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("a:\n  b: [1, 22]\nc: x"), &v))
+
+	j, sm, err := json.YAMLNodeToMinifiedJSON(&v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":{"b":[1,22]},"c":"x"}`, string(j))
+
+	assert.Equal(t, "", sm.At(0).Pointer)
+	assert.Equal(t, "/a", sm.At(5).Pointer)
+	assert.Equal(t, "/a/b", sm.At(10).Pointer)
+	assert.Equal(t, "/a/b/0", sm.At(11).Pointer)
+	assert.Equal(t, "/a/b", sm.At(12).Pointer)
+	assert.Equal(t, "/a/b/1", sm.At(14).Pointer)
+	assert.Equal(t, "/c", sm.At(22).Pointer)
+	assert.Nil(t, sm.At(len(j)))
+
+	var nilMap *json.SourceMap
+	assert.Nil(t, nilMap.At(0))
+	assert.Nil(t, nilMap.Find(""))
+}
+
+func TestYAMLNodeToMinifiedJSON_Alias(t *testing.T) {
+	var v yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("a: &x\n  b: 1\nc: *x\n1: dupe\n1: last"), &v))
+
+	j, sm, err := json.YAMLNodeToMinifiedJSON(&v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":{"b":1},"c":{"b":1},"1":"last"}`, string(j))
+
+	m := sm.Find("/c")
+	require.NotNil(t, m)
+	assert.Equal(t, 3, m.Line)
+	assert.NotNil(t, sm.Find("/c/b"))
+	assert.Equal(t, 5, sm.Find("/1").Line)
+}
+
+func TestYAMLNodeToMinifiedJSON_Error(t *testing.T) {
+	_, _, err := json.YAMLNodeToMinifiedJSON(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "nope"})
+	assert.Error(t, err)
+
+	_, _, err = json.YAMLNodeToMinifiedJSON(&yaml.Node{Kind: 99})
+	assert.Error(t, err)
+}
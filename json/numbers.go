@@ -0,0 +1,102 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// YAMLNodeToJSONPreserveNumbers converts yaml/json stored in a yaml.Node to json ordered matching the original
+// yaml/json, just like YAMLNodeToJSON. However, scalars are not round-tripped through `any`: numbers are written
+// using their original text, so integers and floats keep their distinction (1.0 stays 1.0), large integers beyond
+// the range of float64 keep every digit, and the formatting of the source (2.50, 1e3) is kept. Numbers written in
+// a YAML only format (0x1F, 0o17, +5, 1_000, .5) are converted to their JSON equivalent. Timestamps are kept as
+// written, rather than re-formatted.
+func YAMLNodeToJSONPreserveNumbers(node *yaml.Node, indentation string) ([]byte, error) {
+	m := &jsonWriter{preserveNumbers: true}
+	if err := m.write(node, nil); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, m.buf.Bytes(), "", indentation); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// preservedScalar renders numbers and timestamps using the original text of the node. Returns false if the node
+// is not a number or timestamp.
+func preservedScalar(node *yaml.Node) ([]byte, bool, error) {
+	switch node.ShortTag() {
+	case "!!int":
+		n, err := jsonInteger(node.Value)
+		return []byte(n), true, err
+	case "!!float":
+		n, err := jsonFloat(node.Value)
+		return []byte(n), true, err
+	case "!!timestamp":
+		b, err := json.Marshal(node.Value)
+		return b, true, err
+	}
+	return nil, false, nil
+}
+
+func jsonInteger(value string) (string, error) {
+	if jsonNumber.MatchString(value) {
+		return value, nil
+	}
+	// YAML allows a sign, underscores and base prefixes (0x, 0o, 0b, and a leading 0 for octal in YAML 1.1).
+	plain := strings.ReplaceAll(value, "_", "")
+	plain = strings.TrimPrefix(plain, "+")
+	neg := strings.HasPrefix(plain, "-")
+	plain = strings.TrimPrefix(plain, "-")
+	if len(plain) > 1 && plain[0] == '0' && plain[1] >= '0' && plain[1] <= '7' {
+		plain = "0o" + plain[1:]
+	}
+	i, ok := new(big.Int).SetString(plain, 0)
+	if !ok {
+		// a value tagged as an integer, that is not one (1.5 set on an integer property).
+		return jsonFloat(value)
+	}
+	if neg {
+		i.Neg(i)
+	}
+	return i.String(), nil
+}
+
+func jsonFloat(value string) (string, error) {
+	if jsonNumber.MatchString(value) {
+		return value, nil
+	}
+	plain := strings.TrimPrefix(strings.ReplaceAll(value, "_", ""), "+")
+	neg := strings.HasPrefix(plain, "-")
+	plain = strings.TrimPrefix(plain, "-")
+
+	// fix up the YAML floats that are not valid JSON by one character: .5, 5. and 5.e3
+	if strings.HasPrefix(plain, ".") {
+		plain = "0" + plain
+	}
+	if i := strings.Index(plain, "."); i >= 0 && (i == len(plain)-1 || plain[i+1] == 'e' || plain[i+1] == 'E') {
+		plain = plain[:i+1] + "0" + plain[i+1:]
+	}
+	for len(plain) > 1 && plain[0] == '0' && plain[1] >= '0' && plain[1] <= '9' {
+		plain = plain[1:]
+	}
+	if neg {
+		plain = "-" + plain
+	}
+	if jsonNumber.MatchString(plain) {
+		return plain, nil
+	}
+	return "", fmt.Errorf("unable to render '%s' as a JSON number", value)
+}
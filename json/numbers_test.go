@@ -0,0 +1,81 @@
+package json_test
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLNodeToJSONPreserveNumbers(t *testing.T) {
+	y := `float: 1.0
+precise: 2.50
+exp: 1e3
+big: 123456789012345678901234567890
+bigNeg: -9223372036854775809
+hex: 0x1F
+octal: 0o17
+legacyOctal: 017
+binary: 0b101
+plus: +5
+dot: .5
+trailing: 5.
+negDot: -.5
+date: 2024-01-02
+str: "1.0"
+bool: true
+nothing: null
+list:
+  - 1.10
+  - 3`
+
+	var v yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(y), &v))
+
+	j, err := json.YAMLNodeToJSONPreserveNumbers(&v, "  ")
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "float": 1.0,
+  "precise": 2.50,
+  "exp": 1e3,
+  "big": 123456789012345678901234567890,
+  "bigNeg": -9223372036854775809,
+  "hex": 31,
+  "octal": 15,
+  "legacyOctal": 15,
+  "binary": 5,
+  "plus": 5,
+  "dot": 0.5,
+  "trailing": 5.0,
+  "negDot": -0.5,
+  "date": "2024-01-02",
+  "str": "1.0",
+  "bool": true,
+  "nothing": null,
+  "list": [
+    1.10,
+    3
+  ]
+}`, string(j))
+
+	// the default converter loses all of it.
+	j, err = json.YAMLNodeToJSON(&v, "")
+	require.NoError(t, err)
+	assert.Contains(t, string(j), `"float": 1,`)
+	assert.Contains(t, string(j), `"big": 1.2345678901234568e+29,`)
+}
+
+func TestYAMLNodeToJSONPreserveNumbers_Tagged(t *testing.T) {
+	// a float value tagged as an integer, the value is kept.
+	b, err := json.YAMLNodeToJSONPreserveNumbers(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "-999.99"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "-999.99", string(b))
+
+	_, err = json.YAMLNodeToJSONPreserveNumbers(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "nope"}, "")
+	assert.EqualError(t, err, "unable to render 'nope' as a JSON number")
+
+	_, err = json.YAMLNodeToJSONPreserveNumbers(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: ".inf"}, "")
+	assert.EqualError(t, err, "unable to render '.inf' as a JSON number")
+}
@@ -0,0 +1,331 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// Source is a single specification loaded by LoadAll. One of Bytes, Path or URL must be set, they are checked in
+// that order.
+type Source struct {
+	// Name identifies the source in the results. Defaults to the Path or URL.
+	Name string
+
+	// Bytes are the bytes of the specification.
+	Bytes []byte
+
+	// Path is the path of a specification file to read. Unless the configuration sets them, the BasePath and
+	// SpecFilePath are set from it, so relative file references are followed.
+	Path string
+
+	// URL is the URL of a specification to fetch. Unless the configuration sets it, the BaseURL is set to the
+	// directory of the URL, so relative remote references are followed.
+	URL string
+
+	// Configuration is used to create this document, instead of LoadAllConfig.Configuration. Its RemoteURLHandler
+	// is replaced by the shared remote cache, which fetches using the handler of LoadAllConfig.Configuration.
+	Configuration *datamodel.DocumentConfiguration
+}
+
+// LoadAllConfig configures LoadAll.
+type LoadAllConfig struct {
+	// Configuration is used to create every document that doesn't have a configuration of its own. If it's nil, a
+	// default configuration is used.
+	Configuration *datamodel.DocumentConfiguration
+
+	// MaxParallel is the maximum number of documents loaded at the same time. Defaults to the number of CPUs.
+	MaxParallel int
+
+	// Timeout is the time allowed to load and build each document. No timeout is applied by default.
+	Timeout time.Duration
+}
+
+// LoadResult is the result of loading a single Source.
+type LoadResult struct {
+	// Source is the source that was loaded.
+	Source Source
+
+	// Document is the document that was created, it's nil if the source could not be read or parsed. A model has
+	// already been built for it (BuildV3Model or BuildV2Model, depending on the version).
+	Document Document
+
+	// Errors are all the errors returned reading, parsing and building the document.
+	Errors []error
+
+	// Duration is how long it took to load and build the document.
+	Duration time.Duration
+}
+
+// LoadStats are aggregate statistics for a LoadAll call.
+type LoadStats struct {
+	Documents       int           // the number of sources loaded.
+	Succeeded       int           // the number of documents built without errors.
+	Failed          int           // the number of documents with errors.
+	Duration        time.Duration // how long LoadAll took.
+	TotalBuildTime  time.Duration // the sum of the time taken by every document.
+	Slowest         string        // the name of the source that took the longest.
+	SlowestDuration time.Duration // how long the slowest source took.
+	RemoteFetches   int64         // the number of remote files fetched.
+	RemoteCacheHits int64         // the number of remote files served from the shared cache, rather than fetched.
+}
+
+// LoadAllResult is the result of a LoadAll call.
+type LoadAllResult struct {
+	// Results holds a result for every source, in the same order as the sources.
+	Results []*LoadResult
+
+	// Stats are aggregate statistics for every source.
+	Stats LoadStats
+}
+
+// Failed returns the results that have errors.
+func (r *LoadAllResult) Failed() []*LoadResult {
+	var failed []*LoadResult
+	for _, res := range r.Results {
+		if len(res.Errors) > 0 {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// LoadAll loads and builds many documents in parallel. Remote files are fetched once and shared by every document
+// through a cache, so specifications that reference the same remote files don't fetch them again. A document that
+// fails (even by panicking) doesn't affect any other document, its errors are recorded in its LoadResult.
+func LoadAll(sources []Source, config *LoadAllConfig) *LoadAllResult {
+	return LoadAllWithContext(context.Background(), sources, config)
+}
+
+// LoadAllWithContext is the same as LoadAll, except the supplied context is honored by every document build.
+func LoadAllWithContext(ctx context.Context, sources []Source, config *LoadAllConfig) *LoadAllResult {
+	if config == nil {
+		config = new(LoadAllConfig)
+	}
+	parallel := config.MaxParallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+	base := config.Configuration
+	if base == nil {
+		base = datamodel.NewDocumentConfiguration()
+	}
+	cache := newRemoteCache(base.RemoteURLHandler)
+
+	start := time.Now()
+	result := &LoadAllResult{Results: make([]*LoadResult, len(sources))}
+	slots := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range sources {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result.Results[i] = loadSource(ctx, sources[i], base, config.Timeout, cache)
+		}(i)
+	}
+	wg.Wait()
+
+	stats := &result.Stats
+	stats.Documents = len(sources)
+	stats.Duration = time.Since(start)
+	stats.RemoteFetches = cache.fetches.Load()
+	stats.RemoteCacheHits = cache.hits.Load()
+	for _, res := range result.Results {
+		if len(res.Errors) > 0 {
+			stats.Failed++
+		} else {
+			stats.Succeeded++
+		}
+		stats.TotalBuildTime += res.Duration
+		if res.Duration > stats.SlowestDuration {
+			stats.Slowest = res.Source.Name
+			stats.SlowestDuration = res.Duration
+		}
+	}
+	return result
+}
+
+func loadSource(ctx context.Context, source Source, base *datamodel.DocumentConfiguration,
+	timeout time.Duration, cache *remoteCache,
+) (result *LoadResult) {
+	if source.Name == "" {
+		source.Name = source.Path
+		if source.Name == "" {
+			source.Name = source.URL
+		}
+	}
+	result = &LoadResult{Source: source}
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("unable to load '%s', building panicked: %v", source.Name, r))
+		}
+		result.Duration = time.Since(start)
+	}()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// every document gets its own copy of the configuration, so nothing is shared other than the remote cache.
+	config := *base
+	if source.Configuration != nil {
+		config = *source.Configuration
+	}
+	config.RemoteURLHandler = cache.handler
+
+	specBytes, err := readSource(source, &config, cache)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+		return result
+	}
+	doc, err := NewDocumentWithConfiguration(specBytes, &config)
+	if err != nil {
+		result.Errors = append(result.Errors, utils.UnwrapErrors(err)...)
+	}
+	if doc == nil {
+		return result
+	}
+	result.Document = doc
+	if built, errs := buildModel(ctx, doc); !built && len(errs) == 0 {
+		result.Errors = append(result.Errors, fmt.Errorf("unable to load '%s', the specification type '%s' is not supported",
+			source.Name, doc.GetSpecInfo().SpecType))
+	} else {
+		result.Errors = append(result.Errors, errs...)
+	}
+	return result
+}
+
+// readSource returns the bytes of the source, setting the location of the source in config (unless it's already set).
+func readSource(source Source, config *datamodel.DocumentConfiguration, cache *remoteCache) ([]byte, error) {
+	switch {
+	case source.Bytes != nil:
+		return source.Bytes, nil
+	case source.Path != "":
+		if config.BasePath == "" {
+			config.BasePath = filepath.Dir(source.Path)
+		}
+		if config.SpecFilePath == "" {
+			config.SpecFilePath = filepath.Base(source.Path)
+		}
+		return os.ReadFile(source.Path)
+	case source.URL != "":
+		u, err := url.Parse(source.URL)
+		if err != nil {
+			return nil, err
+		}
+		if config.BaseURL == nil {
+			dir := *u
+			dir.Path = path.Dir(u.Path)
+			config.BaseURL = &dir
+		}
+		resp, err := cache.handler(source.URL)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("unable to fetch '%s', status code %d", source.URL, resp.StatusCode)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	return nil, errors.New("unable to load source, no bytes, path or URL have been supplied")
+}
+
+// remoteCache fetches every remote URL once, and replays the response to every later fetch of the same URL. Failed
+// fetches (errors and server errors) are not cached, so they can be retried.
+type remoteCache struct {
+	fetch   utils.RemoteURLHandler
+	lock    sync.Mutex
+	entries map[string]*remoteCacheEntry
+	fetches atomic.Int64
+	hits    atomic.Int64
+}
+
+type remoteCacheEntry struct {
+	done   chan struct{}
+	status int
+	header http.Header
+	body   []byte
+	err    error
+}
+
+func newRemoteCache(fetch utils.RemoteURLHandler) *remoteCache {
+	if fetch == nil {
+		client := &http.Client{Timeout: time.Second * 120}
+		fetch = func(url string) (*http.Response, error) {
+			return client.Get(url)
+		}
+	}
+	return &remoteCache{fetch: fetch, entries: make(map[string]*remoteCacheEntry)}
+}
+
+func (c *remoteCache) handler(location string) (*http.Response, error) {
+	c.lock.Lock()
+	entry, cached := c.entries[location]
+	if !cached {
+		entry = &remoteCacheEntry{done: make(chan struct{})}
+		c.entries[location] = entry
+	}
+	c.lock.Unlock()
+
+	if cached {
+		<-entry.done
+		if entry.err == nil && entry.status < http.StatusInternalServerError {
+			c.hits.Add(1)
+			return entry.response(), nil
+		}
+		// the fetch this one waited for failed, so fetch it again.
+		return c.handler(location)
+	}
+
+	c.fetches.Add(1)
+	resp, err := c.fetch(location)
+	if err == nil {
+		entry.status, entry.header = resp.StatusCode, resp.Header
+		entry.body, err = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+	entry.err = err
+	if err != nil || entry.status >= http.StatusInternalServerError {
+		c.lock.Lock()
+		delete(c.entries, location)
+		c.lock.Unlock()
+	}
+	close(entry.done)
+	if err != nil {
+		return nil, err
+	}
+	return entry.response(), nil
+}
+
+func (e *remoteCacheEntry) response() *http.Response {
+	return &http.Response{
+		StatusCode:    e.status,
+		Status:        http.StatusText(e.status),
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+	}
+}
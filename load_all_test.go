@@ -0,0 +1,149 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAll(t *testing.T) {
+	sources := []Source{
+		{Path: "test_specs/burgershop.openapi.yaml"},
+		{Path: "test_specs/petstorev2.json"},
+		{Name: "bytes", Bytes: []byte("openapi: 3.1.0\ninfo:\n  title: bytes\n  version: 1.0.0\npaths: {}")},
+		{Name: "broken", Bytes: []byte("this is not a spec")},
+		{Name: "missing", Path: "test_specs/missing.yaml"},
+		{Name: "empty"},
+	}
+	result := LoadAll(sources, &LoadAllConfig{MaxParallel: 2})
+
+	require.Len(t, result.Results, len(sources))
+	for i, res := range result.Results {
+		assert.Equal(t, sources[i].Path, res.Source.Path)
+		assert.NotZero(t, res.Duration)
+	}
+	assert.Equal(t, "test_specs/burgershop.openapi.yaml", result.Results[0].Source.Name)
+	assert.Empty(t, result.Results[0].Errors)
+	assert.Equal(t, "3.1.0", result.Results[0].Document.GetVersion())
+	assert.Equal(t, "burgershop.openapi.yaml", result.Results[0].Document.GetConfiguration().SpecFilePath)
+	assert.Empty(t, result.Results[1].Errors)
+	assert.Equal(t, "2.0", result.Results[1].Document.GetVersion())
+	assert.Empty(t, result.Results[2].Errors)
+
+	failed := result.Failed()
+	require.Len(t, failed, 3)
+	assert.Equal(t, "broken", failed[0].Source.Name)
+	assert.Nil(t, failed[0].Document)
+	assert.Equal(t, "missing", failed[1].Source.Name)
+	assert.Equal(t, "empty", failed[2].Source.Name)
+
+	assert.Equal(t, 6, result.Stats.Documents)
+	assert.Equal(t, 3, result.Stats.Succeeded)
+	assert.Equal(t, 3, result.Stats.Failed)
+	assert.NotZero(t, result.Stats.Duration)
+	assert.NotZero(t, result.Stats.TotalBuildTime)
+	assert.NotEmpty(t, result.Stats.Slowest)
+	assert.NotZero(t, result.Stats.SlowestDuration)
+}
+
+func TestLoadAll_SharedRemoteCache(t *testing.T) {
+	files := map[string]string{
+		"https://example.com/specs/a.yaml": `openapi: 3.1.0
+info:
+  title: a
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'https://example.com/specs/common.yaml#/components/schemas/Pet'`,
+		"https://example.com/specs/b.yaml": `openapi: 3.1.0
+info:
+  title: b
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: 'https://example.com/specs/common.yaml#/components/schemas/Pet'`,
+		"https://example.com/specs/common.yaml": `components:
+  schemas:
+    Pet:
+      type: object`,
+	}
+	var calls atomic.Int64
+	handler := func(url string) (*http.Response, error) {
+		calls.Add(1)
+		body, ok := files[url]
+		if !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+	}
+
+	config := datamodel.NewDocumentConfiguration()
+	config.AllowRemoteReferences = true
+	config.RemoteURLHandler = handler
+	result := LoadAll([]Source{
+		{URL: "https://example.com/specs/a.yaml"},
+		{URL: "https://example.com/specs/b.yaml"},
+	}, &LoadAllConfig{Configuration: config, MaxParallel: 1})
+
+	require.Len(t, result.Failed(), 0)
+	for _, res := range result.Results {
+		assert.Equal(t, "https://example.com/specs", res.Document.GetConfiguration().BaseURL.String())
+	}
+	assert.Equal(t, int64(3), calls.Load())
+	assert.Equal(t, int64(3), result.Stats.RemoteFetches)
+	assert.Equal(t, int64(1), result.Stats.RemoteCacheHits)
+}
+
+func TestLoadAll_Timeout(t *testing.T) {
+	result := LoadAll([]Source{{Path: "test_specs/stripe.yaml"}}, &LoadAllConfig{Timeout: time.Nanosecond})
+	require.Len(t, result.Failed(), 1)
+}
+
+func TestRemoteCache_NoCacheOnFailure(t *testing.T) {
+	var calls int
+	cache := newRemoteCache(func(url string) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("oops")
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString("ok"))}, nil
+	})
+
+	_, err := cache.handler("https://example.com")
+	assert.Error(t, err)
+
+	resp, err := cache.handler("https://example.com")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "ok", string(body))
+
+	resp, err = cache.handler("https://example.com")
+	require.NoError(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, int64(1), cache.hits.Load())
+}
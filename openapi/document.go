@@ -0,0 +1,253 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package openapi is a fluent builder for authoring OpenAPI 3 specifications from scratch.
+//
+// A document is described one call at a time, and built into a complete libopenapi model:
+//
+//	model, err := openapi.NewDocument().
+//		Info("Pets", "1.0.0").
+//		AddPath("/pets").
+//		Get("listPets").
+//		Response(200, "every pet", openapi.JSON(openapi.SchemaRef("Pets"))).
+//		Build()
+//
+// Building renders the document and parses it again, so the model returned is the same as a model of a document
+// read from a file: every high-level object is backed by a low-level object, and every low-level object by the
+// YAML nodes of the document, and the document is indexed and resolved.
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultVersion is the version of OpenAPI documents are built for, unless another is set with OpenAPI.
+const DefaultVersion = "3.1.0"
+
+// DocumentBuilder builds an OpenAPI 3 document. Mistakes (such as an invalid response code) are recorded as they
+// are made, and returned by Build, so calls can always be chained.
+type DocumentBuilder struct {
+	doc   *v3.Document
+	paths []*PathBuilder
+	errs  []error
+}
+
+// NewDocument creates a builder for an empty document, of DefaultVersion.
+func NewDocument() *DocumentBuilder {
+	return &DocumentBuilder{doc: &v3.Document{Version: DefaultVersion, Info: new(base.Info)}}
+}
+
+// OpenAPI sets the version of OpenAPI the document is for, such as `3.0.3`.
+func (b *DocumentBuilder) OpenAPI(version string) *DocumentBuilder {
+	b.doc.Version = version
+	return b
+}
+
+// Info sets the title and the version of the API.
+func (b *DocumentBuilder) Info(title, version string) *DocumentBuilder {
+	b.doc.Info.Title = title
+	b.doc.Info.Version = version
+	return b
+}
+
+// Description sets the description of the API.
+func (b *DocumentBuilder) Description(description string) *DocumentBuilder {
+	b.doc.Info.Description = description
+	return b
+}
+
+// Server adds a server the API is available from.
+func (b *DocumentBuilder) Server(url, description string) *DocumentBuilder {
+	b.doc.Servers = append(b.doc.Servers, &v3.Server{URL: url, Description: description})
+	return b
+}
+
+// Tag adds a tag, operations are tagged with Tags.
+func (b *DocumentBuilder) Tag(name, description string) *DocumentBuilder {
+	b.doc.Tags = append(b.doc.Tags, &base.Tag{Name: name, Description: description})
+	return b
+}
+
+// Schema adds a schema to the components of the document, it can be referenced by SchemaRef.
+func (b *DocumentBuilder) Schema(name string, schema *base.Schema) *DocumentBuilder {
+	b.components().Schemas.Set(name, base.CreateSchemaProxy(schema))
+	return b
+}
+
+// SecurityScheme adds a security scheme to the components of the document.
+func (b *DocumentBuilder) SecurityScheme(name string, scheme *v3.SecurityScheme) *DocumentBuilder {
+	c := b.components()
+	if c.SecuritySchemes == nil {
+		c.SecuritySchemes = orderedmap.New[string, *v3.SecurityScheme]()
+	}
+	c.SecuritySchemes.Set(name, scheme)
+	return b
+}
+
+// Security adds a security requirement to every operation of the document, a security scheme and the scopes
+// required of it.
+func (b *DocumentBuilder) Security(scheme string, scopes ...string) *DocumentBuilder {
+	b.doc.Security = append(b.doc.Security, securityRequirement(scheme, scopes))
+	return b
+}
+
+// Extension adds an extension to the document. The value is encoded as YAML.
+func (b *DocumentBuilder) Extension(key string, value any) *DocumentBuilder {
+	b.doc.Extensions = addExtension(b.doc.Extensions, key, value, &b.errs)
+	return b
+}
+
+// AddPath adds a path, and returns a builder for its operations. Adding a path that was already added returns the
+// builder of the path.
+func (b *DocumentBuilder) AddPath(path string) *PathBuilder {
+	for _, p := range b.paths {
+		if p.path == path {
+			return p
+		}
+	}
+	if len(path) == 0 || path[0] != '/' {
+		b.errs = append(b.errs, fmt.Errorf("path '%s' must begin with a '/'", path))
+	}
+	if b.doc.Paths == nil {
+		b.doc.Paths = &v3.Paths{PathItems: orderedmap.New[string, *v3.PathItem]()}
+	}
+	p := &PathBuilder{document: b, path: path, item: new(v3.PathItem)}
+	b.doc.Paths.PathItems.Set(path, p.item)
+	b.paths = append(b.paths, p)
+	return p
+}
+
+// Model returns the high-level model being built. It is not backed by a low-level model (so it can only be
+// rendered), use Build for a complete model.
+func (b *DocumentBuilder) Model() *v3.Document {
+	return b.doc
+}
+
+// Validate returns every mistake made building the document, and everything missing from it, joined in a single
+// error. Nil is returned if the document is valid.
+func (b *DocumentBuilder) Validate() error {
+	errs := append([]error(nil), b.errs...)
+	if b.doc.Info.Title == "" {
+		errs = append(errs, errors.New("the document has no title, use Info to set one"))
+	}
+	if b.doc.Info.Version == "" {
+		errs = append(errs, errors.New("the document has no version, use Info to set one"))
+	}
+	operationIds := make(map[string]string)
+	for _, p := range b.paths {
+		for _, op := range p.operations {
+			errs = append(errs, op.validate()...)
+			if id := op.op.OperationId; id != "" {
+				name := op.method + " " + p.path
+				if first, ok := operationIds[id]; ok {
+					errs = append(errs, fmt.Errorf("operation %s has the same operationId '%s' as %s", name, id, first))
+				} else {
+					operationIds[id] = name
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Build validates the document, then renders it and builds it again with a default configuration, returning a
+// complete model.
+func (b *DocumentBuilder) Build() (*libopenapi.DocumentModel[v3.Document], error) {
+	return b.BuildWithConfiguration(datamodel.NewDocumentConfiguration())
+}
+
+// BuildWithConfiguration is the same as Build, except the model is built with the supplied configuration.
+func (b *DocumentBuilder) BuildWithConfiguration(config *datamodel.DocumentConfiguration) (*libopenapi.DocumentModel[v3.Document], error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	rendered, err := b.doc.Render()
+	if err != nil {
+		return nil, fmt.Errorf("unable to render the document: %w", err)
+	}
+	doc, err := libopenapi.NewDocumentWithConfiguration(rendered, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the rendered document: %w", err)
+	}
+	model, errs := doc.BuildV3Model()
+	if len(errs) > 0 {
+		return model, errors.Join(errs...)
+	}
+	return model, nil
+}
+
+func (b *DocumentBuilder) components() *v3.Components {
+	if b.doc.Components == nil {
+		b.doc.Components = &v3.Components{Schemas: orderedmap.New[string, *base.SchemaProxy]()}
+	}
+	return b.doc.Components
+}
+
+// Content is the content of a request or a response, of a single media type.
+type Content struct {
+	MediaType string
+	Schema    *base.SchemaProxy
+}
+
+// JSON is `application/json` content, of a schema.
+func JSON(schema *base.SchemaProxy) Content {
+	return Content{MediaType: "application/json", Schema: schema}
+}
+
+// Media is content of any media type, of a schema.
+func Media(mediaType string, schema *base.SchemaProxy) Content {
+	return Content{MediaType: mediaType, Schema: schema}
+}
+
+// Schema wraps a schema, so it can be used as Content.
+func Schema(schema *base.Schema) *base.SchemaProxy {
+	return base.CreateSchemaProxy(schema)
+}
+
+// SchemaRef references a schema added to the components of the document by name.
+func SchemaRef(name string) *base.SchemaProxy {
+	return base.CreateSchemaProxyRef("#/components/schemas/" + name)
+}
+
+func contentMap(content []Content) *orderedmap.Map[string, *v3.MediaType] {
+	if len(content) == 0 {
+		return nil
+	}
+	m := orderedmap.New[string, *v3.MediaType]()
+	for _, c := range content {
+		m.Set(c.MediaType, &v3.MediaType{Schema: c.Schema})
+	}
+	return m
+}
+
+func securityRequirement(scheme string, scopes []string) *base.SecurityRequirement {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	requirements := orderedmap.New[string, []string]()
+	requirements.Set(scheme, scopes)
+	return &base.SecurityRequirement{Requirements: requirements}
+}
+
+func addExtension(extensions *orderedmap.Map[string, *yaml.Node], key string, value any,
+	errs *[]error,
+) *orderedmap.Map[string, *yaml.Node] {
+	if !strings.HasPrefix(key, "x-") {
+		*errs = append(*errs, fmt.Errorf("extension '%s' must begin with 'x-'", key))
+	}
+	if extensions == nil {
+		extensions = orderedmap.New[string, *yaml.Node]()
+	}
+	extensions.Set(key, utils.CreateYamlNode(value))
+	return extensions
+}
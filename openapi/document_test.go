@@ -0,0 +1,125 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func petsDocument() *DocumentBuilder {
+	return NewDocument().
+		Info("Pets", "1.0.0").
+		Description("An API of pets").
+		Server("https://pets.example.com", "production").
+		Tag("pets", "Everything about pets").
+		Schema("Pet", &base.Schema{Type: []string{"object"}, Required: []string{"name"}}).
+		Schema("Pets", &base.Schema{Type: []string{"array"}, Items: &base.DynamicValue[*base.SchemaProxy, bool]{
+			A: SchemaRef("Pet"),
+		}}).
+		SecurityScheme("key", &v3.SecurityScheme{Type: "apiKey", Name: "api_key", In: "header"}).
+		Security("key").
+		Extension("x-audience", "public").
+		AddPath("/pets").
+		Get("listPets").
+		Tags("pets").
+		QueryParameter("limit", false, Schema(&base.Schema{Type: []string{"integer"}})).
+		Response(200, "every pet", JSON(SchemaRef("Pets"))).
+		Post("createPet").
+		RequestBody(true, JSON(SchemaRef("Pet"))).
+		Response(201, "the pet was created").
+		AddPath("/pets/{id}").
+		PathParameter("id", Schema(&base.Schema{Type: []string{"string"}})).
+		Get("getPet").
+		Response(200, "a pet", JSON(SchemaRef("Pet"))).
+		DefaultResponse("an error").
+		Document()
+}
+
+func TestNewDocument_Build(t *testing.T) {
+	model, err := petsDocument().Build()
+	require.NoError(t, err)
+
+	doc := model.Model
+	assert.Equal(t, "3.1.0", doc.Version)
+	assert.Equal(t, "Pets", doc.Info.Title)
+	assert.Equal(t, "https://pets.example.com", doc.Servers[0].URL)
+	assert.Equal(t, "pets", doc.Tags[0].Name)
+	assert.Equal(t, "public", doc.Extensions.GetOrZero("x-audience").Value)
+	assert.Equal(t, 2, doc.Components.Schemas.Len())
+	assert.Equal(t, "apiKey", doc.Components.SecuritySchemes.GetOrZero("key").Type)
+
+	// every object is backed by the low-level model, and nodes of the document.
+	require.NotNil(t, doc.GoLow())
+	assert.Equal(t, 1, doc.GoLow().Version.KeyNode.Line)
+
+	list := doc.Paths.PathItems.GetOrZero("/pets").Get
+	require.NotNil(t, list.GoLow())
+	assert.Equal(t, "listPets", list.OperationId)
+	assert.NotZero(t, list.GoLow().OperationId.ValueNode.Line)
+	assert.Equal(t, "limit", list.Parameters[0].Name)
+
+	ok := list.Responses.Codes.GetOrZero("200")
+	require.NotNil(t, ok)
+	schema := ok.Content.GetOrZero("application/json").Schema
+	assert.Equal(t, "#/components/schemas/Pets", schema.GetReference())
+	assert.Equal(t, []string{"array"}, schema.Schema().Type)
+	assert.Equal(t, []string{"object"}, schema.Schema().Items.A.Schema().Type)
+
+	create := doc.Paths.PathItems.GetOrZero("/pets").Post
+	assert.True(t, *create.RequestBody.Required)
+	assert.Equal(t, "the pet was created", create.Responses.Codes.GetOrZero("201").Description)
+
+	get := doc.Paths.PathItems.GetOrZero("/pets/{id}")
+	assert.Equal(t, "id", get.Parameters[0].Name)
+	assert.Equal(t, "an error", get.Get.Responses.Default.Description)
+
+	// the index is built too.
+	require.NotNil(t, model.Index)
+	assert.Equal(t, 3, model.Index.GetOperationCount())
+}
+
+func TestNewDocument_Model(t *testing.T) {
+	builder := NewDocument().OpenAPI("3.0.3").Info("Pets", "1.0.0")
+	assert.Equal(t, "3.0.3", builder.Model().Version)
+	assert.Nil(t, builder.Model().GoLow())
+
+	rendered, err := builder.Model().Render()
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.0.3\ninfo:\n    title: Pets\n    version: 1.0.0\n", string(rendered))
+
+	model, err := builder.Build()
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.3", model.Model.Version)
+}
+
+func TestNewDocument_Validate(t *testing.T) {
+	builder := NewDocument().Extension("audience", "public")
+	builder.AddPath("pets").Get("pets").Response(200, "pets")
+	builder.AddPath("/toys").Get("pets").Response(200, "toys")
+
+	model, err := builder.Build()
+	assert.Nil(t, model)
+	require.Error(t, err)
+	assert.Equal(t, `extension 'audience' must begin with 'x-'
+path 'pets' must begin with a '/'
+the document has no title, use Info to set one
+the document has no version, use Info to set one
+operation GET /toys has the same operationId 'pets' as GET pets`, err.Error())
+
+	assert.Nil(t, petsDocument().Validate())
+}
+
+func TestNewDocument_AddPath(t *testing.T) {
+	builder := NewDocument().Info("Pets", "1.0.0")
+	pets := builder.AddPath("/pets").Summary("pets").Description("all the pets")
+	assert.Same(t, pets, builder.AddPath("/pets"))
+	assert.Same(t, builder, pets.Document())
+	assert.Equal(t, 1, builder.Model().Paths.PathItems.Len())
+	assert.Equal(t, "pets", builder.Model().Paths.PathItems.GetOrZero("/pets").Summary)
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// OperationBuilder builds an operation of a path.
+type OperationBuilder struct {
+	path   *PathBuilder
+	method string
+	op     *v3.Operation
+}
+
+// Summary sets the summary of the operation.
+func (o *OperationBuilder) Summary(summary string) *OperationBuilder {
+	o.op.Summary = summary
+	return o
+}
+
+// Description sets the description of the operation.
+func (o *OperationBuilder) Description(description string) *OperationBuilder {
+	o.op.Description = description
+	return o
+}
+
+// Tags tags the operation.
+func (o *OperationBuilder) Tags(tags ...string) *OperationBuilder {
+	o.op.Tags = append(o.op.Tags, tags...)
+	return o
+}
+
+// Deprecated marks the operation as deprecated.
+func (o *OperationBuilder) Deprecated() *OperationBuilder {
+	deprecated := true
+	o.op.Deprecated = &deprecated
+	return o
+}
+
+// Parameter adds a parameter to the operation.
+func (o *OperationBuilder) Parameter(param *v3.Parameter) *OperationBuilder {
+	o.op.Parameters = append(o.op.Parameters, param)
+	return o
+}
+
+// PathParameter adds a required path parameter to the operation.
+func (o *OperationBuilder) PathParameter(name string, schema *base.SchemaProxy) *OperationBuilder {
+	return o.Parameter(pathParameter(name, schema))
+}
+
+// QueryParameter adds a query parameter to the operation.
+func (o *OperationBuilder) QueryParameter(name string, required bool, schema *base.SchemaProxy) *OperationBuilder {
+	return o.Parameter(&v3.Parameter{Name: name, In: "query", Required: &required, Schema: schema})
+}
+
+// HeaderParameter adds a header parameter to the operation.
+func (o *OperationBuilder) HeaderParameter(name string, required bool, schema *base.SchemaProxy) *OperationBuilder {
+	return o.Parameter(&v3.Parameter{Name: name, In: "header", Required: &required, Schema: schema})
+}
+
+// RequestBody sets the body of requests of the operation, and its content.
+func (o *OperationBuilder) RequestBody(required bool, content ...Content) *OperationBuilder {
+	o.op.RequestBody = &v3.RequestBody{Required: &required, Content: contentMap(content)}
+	return o
+}
+
+// Response adds a response to the operation, for a HTTP status code (100 to 599).
+func (o *OperationBuilder) Response(code int, description string, content ...Content) *OperationBuilder {
+	if code < 100 || code > 599 {
+		o.path.document.errs = append(o.path.document.errs,
+			fmt.Errorf("operation %s %s has an invalid response code %d", o.method, o.path.path, code))
+	}
+	responses := o.responses()
+	if responses.Codes == nil {
+		responses.Codes = orderedmap.New[string, *v3.Response]()
+	}
+	responses.Codes.Set(strconv.Itoa(code), &v3.Response{Description: description, Content: contentMap(content)})
+	return o
+}
+
+// DefaultResponse sets the response of the operation for any HTTP status code without a response of its own.
+func (o *OperationBuilder) DefaultResponse(description string, content ...Content) *OperationBuilder {
+	o.responses().Default = &v3.Response{Description: description, Content: contentMap(content)}
+	return o
+}
+
+// Security adds a security requirement to the operation, a security scheme and the scopes required of it.
+func (o *OperationBuilder) Security(scheme string, scopes ...string) *OperationBuilder {
+	o.op.Security = append(o.op.Security, securityRequirement(scheme, scopes))
+	return o
+}
+
+// Extension adds an extension to the operation. The value is encoded as YAML.
+func (o *OperationBuilder) Extension(key string, value any) *OperationBuilder {
+	o.op.Extensions = addExtension(o.op.Extensions, key, value, &o.path.document.errs)
+	return o
+}
+
+// Get adds a GET operation to the same path, see PathBuilder.Get.
+func (o *OperationBuilder) Get(operationId string) *OperationBuilder {
+	return o.path.Get(operationId)
+}
+
+// Put adds a PUT operation to the same path, see PathBuilder.Put.
+func (o *OperationBuilder) Put(operationId string) *OperationBuilder {
+	return o.path.Put(operationId)
+}
+
+// Post adds a POST operation to the same path, see PathBuilder.Post.
+func (o *OperationBuilder) Post(operationId string) *OperationBuilder {
+	return o.path.Post(operationId)
+}
+
+// Delete adds a DELETE operation to the same path, see PathBuilder.Delete.
+func (o *OperationBuilder) Delete(operationId string) *OperationBuilder {
+	return o.path.Delete(operationId)
+}
+
+// Patch adds a PATCH operation to the same path, see PathBuilder.Patch.
+func (o *OperationBuilder) Patch(operationId string) *OperationBuilder {
+	return o.path.Patch(operationId)
+}
+
+// AddPath adds another path to the document, see DocumentBuilder.AddPath.
+func (o *OperationBuilder) AddPath(path string) *PathBuilder {
+	return o.path.document.AddPath(path)
+}
+
+// Path returns the builder of the path the operation belongs to.
+func (o *OperationBuilder) Path() *PathBuilder {
+	return o.path
+}
+
+// Document returns the builder of the document the operation belongs to.
+func (o *OperationBuilder) Document() *DocumentBuilder {
+	return o.path.document
+}
+
+// Model returns the high-level model of the operation being built.
+func (o *OperationBuilder) Model() *v3.Operation {
+	return o.op
+}
+
+// Build builds the document the operation belongs to, see DocumentBuilder.Build.
+func (o *OperationBuilder) Build() (*libopenapi.DocumentModel[v3.Document], error) {
+	return o.path.document.Build()
+}
+
+func (o *OperationBuilder) responses() *v3.Responses {
+	if o.op.Responses == nil {
+		o.op.Responses = new(v3.Responses)
+	}
+	return o.op.Responses
+}
+
+// validate checks the operation has a response, and a path parameter for every parameter in the template of its path.
+func (o *OperationBuilder) validate() []error {
+	var errs []error
+	if o.op.Responses == nil {
+		errs = append(errs, fmt.Errorf("operation %s %s has no responses", o.method, o.path.path))
+	}
+	for _, name := range o.path.templateNames() {
+		defined := func(p *v3.Parameter) bool { return p.In == "path" && p.Name == name }
+		if !slices.ContainsFunc(o.op.Parameters, defined) && !slices.ContainsFunc(o.path.item.Parameters, defined) {
+			errs = append(errs, fmt.Errorf("operation %s %s has no path parameter '%s'", o.method, o.path.path, name))
+		}
+	}
+	return errs
+}
+
+func pathParameter(name string, schema *base.SchemaProxy) *v3.Parameter {
+	required := true
+	return &v3.Parameter{Name: name, In: "path", Required: &required, Schema: schema}
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationBuilder(t *testing.T) {
+	str := Schema(&base.Schema{Type: []string{"string"}})
+	model, err := NewDocument().
+		Info("Pets", "1.0.0").
+		AddPath("/pets/{id}").
+		Put("updatePet").
+		Summary("update a pet").
+		Description("updates a pet, by id").
+		Deprecated().
+		PathParameter("id", str).
+		HeaderParameter("X-Request-Id", true, str).
+		Parameter(&v3.Parameter{Name: "session", In: "cookie", Schema: str}).
+		RequestBody(false, Media("text/plain", str), JSON(str)).
+		Response(204, "updated").
+		Security("oauth", "write:pets").
+		Extension("x-internal", true).
+		Delete("").
+		PathParameter("id", str).
+		Response(204, "deleted").
+		Patch("").
+		PathParameter("id", str).
+		Response(200, "patched").
+		Path().
+		Options("").PathParameter("id", str).Response(200, "options").
+		Path().Head("").PathParameter("id", str).Response(200, "head").
+		Path().Trace("").PathParameter("id", str).Response(200, "trace").
+		Build()
+	require.NoError(t, err)
+
+	item := model.Model.Paths.PathItems.GetOrZero("/pets/{id}")
+	put := item.Put
+	assert.Equal(t, "update a pet", put.Summary)
+	assert.True(t, *put.Deprecated)
+	require.Len(t, put.Parameters, 3)
+	assert.Equal(t, "header", put.Parameters[1].In)
+	assert.True(t, *put.Parameters[1].Required)
+	assert.Equal(t, "cookie", put.Parameters[2].In)
+	assert.False(t, *put.RequestBody.Required)
+	assert.Equal(t, []string{"text/plain", "application/json"}, slices.Collect(put.RequestBody.Content.KeysFromOldest()))
+	assert.Equal(t, []string{"write:pets"}, put.Security[0].Requirements.GetOrZero("oauth"))
+	assert.Equal(t, "true", put.Extensions.GetOrZero("x-internal").Value)
+
+	assert.NotNil(t, item.Delete)
+	assert.NotNil(t, item.Patch)
+	assert.NotNil(t, item.Options)
+	assert.NotNil(t, item.Head)
+	assert.NotNil(t, item.Trace)
+}
+
+func TestOperationBuilder_Validate(t *testing.T) {
+	builder := NewDocument().Info("Pets", "1.0.0")
+	get := builder.AddPath("/pets/{id}/toys/{toy}").Get("getToy").PathParameter("toy", nil).Response(99, "invalid")
+	assert.Same(t, builder, get.Document())
+	get.Post("")
+	builder.AddPath("/pets").Get("first").Response(200, "pets").Get("second").Response(200, "pets")
+
+	err := builder.Validate()
+	require.Error(t, err)
+	assert.Equal(t, `operation GET /pets/{id}/toys/{toy} has an invalid response code 99
+path '/pets' already has a GET operation
+operation GET /pets/{id}/toys/{toy} has no path parameter 'id'
+operation POST /pets/{id}/toys/{toy} has no responses
+operation POST /pets/{id}/toys/{toy} has no path parameter 'id'
+operation POST /pets/{id}/toys/{toy} has no path parameter 'toy'`, err.Error())
+
+	// the second GET replaced the first.
+	assert.Equal(t, "second", builder.Model().Paths.PathItems.GetOrZero("/pets").Get.OperationId)
+}
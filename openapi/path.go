@@ -0,0 +1,130 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// PathBuilder builds a path of a document, and its operations.
+type PathBuilder struct {
+	document   *DocumentBuilder
+	path       string
+	item       *v3.PathItem
+	operations []*OperationBuilder
+}
+
+// Summary sets the summary of the path.
+func (p *PathBuilder) Summary(summary string) *PathBuilder {
+	p.item.Summary = summary
+	return p
+}
+
+// Description sets the description of the path.
+func (p *PathBuilder) Description(description string) *PathBuilder {
+	p.item.Description = description
+	return p
+}
+
+// Parameter adds a parameter to every operation of the path.
+func (p *PathBuilder) Parameter(param *v3.Parameter) *PathBuilder {
+	p.item.Parameters = append(p.item.Parameters, param)
+	return p
+}
+
+// PathParameter adds a required path parameter to every operation of the path.
+func (p *PathBuilder) PathParameter(name string, schema *base.SchemaProxy) *PathBuilder {
+	return p.Parameter(pathParameter(name, schema))
+}
+
+// Get adds a GET operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Get(operationId string) *OperationBuilder {
+	return p.operation(http.MethodGet, operationId, &p.item.Get)
+}
+
+// Put adds a PUT operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Put(operationId string) *OperationBuilder {
+	return p.operation(http.MethodPut, operationId, &p.item.Put)
+}
+
+// Post adds a POST operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Post(operationId string) *OperationBuilder {
+	return p.operation(http.MethodPost, operationId, &p.item.Post)
+}
+
+// Delete adds a DELETE operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Delete(operationId string) *OperationBuilder {
+	return p.operation(http.MethodDelete, operationId, &p.item.Delete)
+}
+
+// Options adds an OPTIONS operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Options(operationId string) *OperationBuilder {
+	return p.operation(http.MethodOptions, operationId, &p.item.Options)
+}
+
+// Head adds a HEAD operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Head(operationId string) *OperationBuilder {
+	return p.operation(http.MethodHead, operationId, &p.item.Head)
+}
+
+// Patch adds a PATCH operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Patch(operationId string) *OperationBuilder {
+	return p.operation(http.MethodPatch, operationId, &p.item.Patch)
+}
+
+// Trace adds a TRACE operation to the path, with an operationId (which may be empty).
+func (p *PathBuilder) Trace(operationId string) *OperationBuilder {
+	return p.operation(http.MethodTrace, operationId, &p.item.Trace)
+}
+
+// AddPath adds another path to the document, see DocumentBuilder.AddPath.
+func (p *PathBuilder) AddPath(path string) *PathBuilder {
+	return p.document.AddPath(path)
+}
+
+// Document returns the builder of the document the path belongs to.
+func (p *PathBuilder) Document() *DocumentBuilder {
+	return p.document
+}
+
+// Build builds the document the path belongs to, see DocumentBuilder.Build.
+func (p *PathBuilder) Build() (*libopenapi.DocumentModel[v3.Document], error) {
+	return p.document.Build()
+}
+
+func (p *PathBuilder) operation(method, operationId string, field **v3.Operation) *OperationBuilder {
+	if *field != nil {
+		p.document.errs = append(p.document.errs, fmt.Errorf("path '%s' already has a %s operation", p.path, method))
+		p.operations = slices.DeleteFunc(p.operations, func(o *OperationBuilder) bool { return o.op == *field })
+	}
+	o := &OperationBuilder{path: p, method: method, op: &v3.Operation{OperationId: operationId}}
+	*field = o.op
+	p.operations = append(p.operations, o)
+	return o
+}
+
+// templateNames returns the names of the parameters in the template of the path, such as `id` of `/pets/{id}`.
+func (p *PathBuilder) templateNames() []string {
+	var names []string
+	rest := p.path
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			return names
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return names
+		}
+		names = append(names, rest[start+1:start+end])
+		rest = rest[start+end+1:]
+	}
+}
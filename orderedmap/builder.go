@@ -63,7 +63,7 @@ func (o *Map[K, V]) ToYamlNode(n NodeBuilder, l any) *yaml.Node {
 		ks := k.(string)
 
 		var keyStyle yaml.Style
-		keyNode := findKeyNode(ks, vn)
+		keyNode, valueNode := findKeyNode(ks, vn)
 		if keyNode != nil {
 			keyStyle = keyNode.Style
 		}
@@ -79,12 +79,14 @@ func (o *Map[K, V]) ToYamlNode(n NodeBuilder, l any) *yaml.Node {
 		}
 
 		n.AddYAMLNode(p, &nodes.NodeEntry{
-			Tag:      ks,
-			Key:      ks,
-			Line:     i,
-			Value:    pair.Value(),
-			KeyStyle: keyStyle,
-			LowValue: lv,
+			Tag:       ks,
+			Key:       ks,
+			Line:      i,
+			Value:     pair.Value(),
+			KeyStyle:  keyStyle,
+			LowValue:  lv,
+			KeyNode:   keyNode,
+			ValueNode: valueNode,
 		})
 		i++
 	}
@@ -92,17 +94,20 @@ func (o *Map[K, V]) ToYamlNode(n NodeBuilder, l any) *yaml.Node {
 	return p
 }
 
-func findKeyNode(key string, m *yaml.Node) *yaml.Node {
+func findKeyNode(key string, m *yaml.Node) (*yaml.Node, *yaml.Node) {
 	if m == nil {
-		return nil
+		return nil, nil
 	}
 
 	for i := 0; i < len(m.Content); i += 2 {
 		if m.Content[i].Value == key {
-			return m.Content[i]
+			if i+1 < len(m.Content) {
+				return m.Content[i], m.Content[i+1]
+			}
+			return m.Content[i], nil
 		}
 	}
-	return nil
+	return nil, nil
 }
 
 // FindValueUntyped finds a value in the ordered map by key if the stored value for that key implements GetValueUntyped otherwise just returns the value.
@@ -0,0 +1,207 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package orderedmap
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	wk8orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// keyIndex finds the pairs of a map by the string of their keys, in constant time. When keys share a string, the
+// oldest pair is found.
+type keyIndex[K comparable, V any] struct {
+	exact  map[string]*wk8orderedmap.Pair[K, V]
+	folded map[string]*wk8orderedmap.Pair[K, V]
+}
+
+// FindKey returns the oldest pair whose key is `key`, or nil if there are none. Keys implementing
+// `GetValueUntyped()` (such as the key references of the low-level models) are found by their value, other keys
+// by their string (as formatted by `%v`).
+// The first lookup indexes the map, the index is dropped by any change made to the keys or order of the map.
+// Safely returns nil on nil pointer.
+func (o *Map[K, V]) FindKey(key string) Pair[K, V] {
+	if o == nil {
+		return nil
+	}
+	if idx := o.keyIndex(); idx != nil {
+		return wrap(idx.exact[key])
+	}
+	for pair := o.OrderedMap.Oldest(); pair != nil; pair = pair.Next() {
+		if keyString(pair.Key) == key {
+			return wrap(pair)
+		}
+	}
+	return nil
+}
+
+// FindKeyFold is the same as FindKey, except keys are matched under Unicode case-folding, like `strings.EqualFold`.
+func (o *Map[K, V]) FindKeyFold(key string) Pair[K, V] {
+	if o == nil {
+		return nil
+	}
+	if idx := o.keyIndex(); idx != nil {
+		return wrap(idx.folded[foldKey(key)])
+	}
+	for pair := o.OrderedMap.Oldest(); pair != nil; pair = pair.Next() {
+		if strings.EqualFold(keyString(pair.Key), key) {
+			return wrap(pair)
+		}
+	}
+	return nil
+}
+
+// keyIndex returns the index of the map, building it if the map has changed since it was last built. Maps that
+// weren't created by this package aren't indexed, and return nil.
+func (o *Map[K, V]) keyIndex() *keyIndex[K, V] {
+	if o.lookup == nil || o.OrderedMap == nil {
+		return nil
+	}
+	if idx := o.lookup.Load(); idx != nil {
+		return idx
+	}
+	idx := &keyIndex[K, V]{
+		exact:  make(map[string]*wk8orderedmap.Pair[K, V], o.Len()),
+		folded: make(map[string]*wk8orderedmap.Pair[K, V], o.Len()),
+	}
+	for pair := o.OrderedMap.Oldest(); pair != nil; pair = pair.Next() {
+		s := keyString(pair.Key)
+		if _, ok := idx.exact[s]; !ok {
+			idx.exact[s] = pair
+		}
+		if f := foldKey(s); idx.folded[f] == nil {
+			idx.folded[f] = pair
+		}
+	}
+	// concurrent lookups may each build the index, they build the same one.
+	o.lookup.Store(idx)
+	return idx
+}
+
+func (o *Map[K, V]) invalidate() {
+	if o.lookup != nil {
+		o.lookup.Store(nil)
+	}
+}
+
+// Set sets the value of a key, a key already in the map keeps its position.
+func (o *Map[K, V]) Set(key K, value V) (V, bool) {
+	v, present := o.OrderedMap.Set(key, value)
+	if !present {
+		o.invalidate()
+	}
+	return v, present
+}
+
+// Store is an alias of Set.
+func (o *Map[K, V]) Store(key K, value V) (V, bool) {
+	return o.Set(key, value)
+}
+
+// AddPairs sets every pair in order.
+func (o *Map[K, V]) AddPairs(pairs ...wk8orderedmap.Pair[K, V]) {
+	for _, pair := range pairs {
+		o.Set(pair.Key, pair.Value)
+	}
+}
+
+// Delete deletes a key, returning its value and whether it was in the map.
+func (o *Map[K, V]) Delete(key K) (V, bool) {
+	v, present := o.OrderedMap.Delete(key)
+	if present {
+		o.invalidate()
+	}
+	return v, present
+}
+
+// MoveAfter moves the pair of a key after the pair of another key.
+func (o *Map[K, V]) MoveAfter(key, markKey K) error {
+	o.invalidate()
+	return o.OrderedMap.MoveAfter(key, markKey)
+}
+
+// MoveBefore moves the pair of a key before the pair of another key.
+func (o *Map[K, V]) MoveBefore(key, markKey K) error {
+	o.invalidate()
+	return o.OrderedMap.MoveBefore(key, markKey)
+}
+
+// MoveToBack moves the pair of a key to the back of the map, making it the newest.
+func (o *Map[K, V]) MoveToBack(key K) error {
+	o.invalidate()
+	return o.OrderedMap.MoveToBack(key)
+}
+
+// MoveToFront moves the pair of a key to the front of the map, making it the oldest.
+func (o *Map[K, V]) MoveToFront(key K) error {
+	o.invalidate()
+	return o.OrderedMap.MoveToFront(key)
+}
+
+// GetAndMoveToBack returns the value of a key and moves its pair to the back of the map.
+func (o *Map[K, V]) GetAndMoveToBack(key K) (V, error) {
+	o.invalidate()
+	return o.OrderedMap.GetAndMoveToBack(key)
+}
+
+// GetAndMoveToFront returns the value of a key and moves its pair to the front of the map.
+func (o *Map[K, V]) GetAndMoveToFront(key K) (V, error) {
+	o.invalidate()
+	return o.OrderedMap.GetAndMoveToFront(key)
+}
+
+func wrap[K comparable, V any](pair *wk8orderedmap.Pair[K, V]) Pair[K, V] {
+	if pair == nil {
+		return nil
+	}
+	return &wrapPair[K, V]{Pair: pair}
+}
+
+// keyString returns the string a key is found by.
+func keyString[K comparable](k K) string {
+	var key any = k
+	switch kv := key.(type) {
+	case string:
+		return kv
+	case hasValueUntyped:
+		if s, ok := kv.GetValueUntyped().(string); ok {
+			return s
+		}
+		return fmt.Sprintf("%v", kv.GetValueUntyped())
+	}
+	return fmt.Sprintf("%v", key)
+}
+
+// foldKey returns the same string for all strings that are equal under Unicode case-folding, by replacing every
+// rune with the smallest rune it folds to, or with a lower case letter when that's ASCII (so ASCII strings in lower
+// case are their own key).
+func foldKey(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf || ('A' <= c && c <= 'Z') {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, r := range s {
+		smallest := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			smallest = min(smallest, f)
+		}
+		if smallest < utf8.RuneSelf {
+			smallest = unicode.ToLower(smallest)
+		}
+		sb.WriteRune(smallest)
+	}
+	return sb.String()
+}
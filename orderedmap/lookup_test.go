@@ -0,0 +1,96 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package orderedmap_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	wk8orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+type lookupKey struct {
+	value string
+	line  int
+}
+
+func (k lookupKey) GetValueUntyped() any {
+	return k.value
+}
+
+func TestFindKey(t *testing.T) {
+	m := orderedmap.New[lookupKey, int]()
+	m.Set(lookupKey{value: "/pets", line: 1}, 1)
+	m.Set(lookupKey{value: "/Pets", line: 2}, 2)
+	m.Set(lookupKey{value: "/pets", line: 3}, 3)
+
+	// keys sharing a value are found by the oldest.
+	pair := m.FindKey("/pets")
+	require.NotNil(t, pair)
+	assert.Equal(t, 1, pair.Key().line)
+	assert.Equal(t, 2, m.FindKey("/Pets").Value())
+	assert.Nil(t, m.FindKey("/PETS"))
+
+	// the pairs found are the pairs of the map.
+	*pair.ValuePtr() = 10
+	assert.Equal(t, 10, m.GetOrZero(lookupKey{value: "/pets", line: 1}))
+
+	m.Delete(lookupKey{value: "/pets", line: 1})
+	assert.Equal(t, 3, m.FindKey("/pets").Key().line)
+	m.Set(lookupKey{value: "/stores"}, 4)
+	assert.Equal(t, 4, m.FindKey("/stores").Value())
+
+	var empty *orderedmap.Map[lookupKey, int]
+	assert.Nil(t, empty.FindKey("/pets"))
+	assert.Nil(t, empty.FindKeyFold("/pets"))
+}
+
+func TestFindKeyFold(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	m.Set("Content-Type", 1)
+	m.Set("content-type", 2)
+	m.Set("straße", 3)
+	m.Set("\u212Aelvin", 4) // KELVIN SIGN folds to k.
+
+	assert.Equal(t, 1, m.FindKeyFold("CONTENT-TYPE").Value())
+	assert.Equal(t, 1, m.FindKeyFold("content-type").Value())
+	assert.Equal(t, 3, m.FindKeyFold("STRAßE").Value())
+	assert.Equal(t, 4, m.FindKeyFold("kelvin").Value())
+	assert.Equal(t, 4, m.FindKeyFold("KELVIN").Value())
+	assert.Nil(t, m.FindKeyFold("content"))
+
+	// moving pairs changes which is the oldest.
+	require.NoError(t, m.MoveToBack("Content-Type"))
+	assert.Equal(t, 2, m.FindKeyFold("CONTENT-TYPE").Value())
+	require.NoError(t, m.MoveBefore("Content-Type", "content-type"))
+	assert.Equal(t, 1, m.FindKeyFold("CONTENT-TYPE").Value())
+	_, err := m.GetAndMoveToFront("content-type")
+	require.NoError(t, err)
+	assert.Equal(t, 2, m.FindKeyFold("CONTENT-TYPE").Value())
+	m.AddPairs(wk8orderedmap.Pair[string, int]{Key: "Accept", Value: 5})
+	assert.Equal(t, 5, m.FindKeyFold("accept").Value())
+}
+
+func TestFindKey_NotIndexed(t *testing.T) {
+	m := &orderedmap.Map[int, string]{OrderedMap: wk8orderedmap.New[int, string]()}
+	m.Set(404, "not found")
+	assert.Equal(t, "not found", m.FindKey("404").Value())
+	assert.Equal(t, "not found", m.FindKeyFold("404").Value())
+	assert.Nil(t, m.FindKey("200"))
+	assert.Nil(t, m.FindKeyFold("200"))
+}
+
+func BenchmarkFindKeyFold(b *testing.B) {
+	m := orderedmap.New[string, int]()
+	for i := 0; i < 5000; i++ {
+		m.Set(fmt.Sprintf("/path/%d", i), i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.FindKeyFold("/path/4999")
+	}
+}
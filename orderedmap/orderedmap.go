@@ -6,11 +6,9 @@ package orderedmap
 
 import (
 	"context"
-	"fmt"
 	"iter"
 	"reflect"
-	"slices"
-	"strings"
+	"sync/atomic"
 
 	wk8orderedmap "github.com/wk8/go-ordered-map/v2"
 )
@@ -27,6 +25,7 @@ type Pair[K comparable, V any] interface {
 // Map represents an ordered map where the key must be a comparable type, the ordering is based on insertion order.
 type Map[K comparable, V any] struct {
 	*wk8orderedmap.OrderedMap[K, V]
+	lookup *atomic.Pointer[keyIndex[K, V]] // built lazily by FindKey and FindKeyFold.
 }
 
 type wrapPair[K comparable, V any] struct {
@@ -35,11 +34,22 @@ type wrapPair[K comparable, V any] struct {
 
 // New creates an ordered map generic object.
 func New[K comparable, V any]() *Map[K, V] {
+	return newMap(wk8orderedmap.New[K, V]())
+}
+
+func newMap[K comparable, V any](om *wk8orderedmap.OrderedMap[K, V]) *Map[K, V] {
 	return &Map[K, V]{
-		OrderedMap: wk8orderedmap.New[K, V](),
+		OrderedMap: om,
+		lookup:     new(atomic.Pointer[keyIndex[K, V]]),
 	}
 }
 
+// NewWithCapacity creates an ordered map generic object with room for `capacity` pairs, for maps whose size is known
+// before they're built.
+func NewWithCapacity[K comparable, V any](capacity int) *Map[K, V] {
+	return newMap(wk8orderedmap.New[K, V](wk8orderedmap.WithCapacity[K, V](capacity)))
+}
+
 // GetKeyType returns the reflection type of the key.
 func (o *Map[K, V]) GetKeyType() reflect.Type {
 	return reflect.TypeOf(new(K))
@@ -51,7 +61,12 @@ func (o *Map[K, V]) GetValueType() reflect.Type {
 }
 
 // GetOrZero will return the value for the key if it exists, otherwise it will return the zero value for the value type.
+// Safely returns the zero value on nil pointer.
 func (o *Map[K, V]) GetOrZero(k K) V {
+	if o == nil || o.OrderedMap == nil {
+		var zero V
+		return zero
+	}
 	v, ok := o.OrderedMap.Get(k)
 	if !ok {
 		var zero V
@@ -165,9 +180,7 @@ func (o *Map[K, V]) ValuesFromNewest() iter.Seq[V] {
 
 // From creates a new ordered map from an iterator.
 func From[K comparable, V any](iter iter.Seq2[K, V]) *Map[K, V] {
-	return &Map[K, V]{
-		OrderedMap: wk8orderedmap.From(iter),
-	}
+	return newMap(wk8orderedmap.From(iter))
 }
 
 // NewPair instantiates a `Pair` object for use with `FromPairs()`.
@@ -183,9 +196,45 @@ func NewPair[K comparable, V any](key K, value V) Pair[K, V] {
 // FromPairs creates an `OrderedMap` from an array of pairs.
 // Use `NewPair()` to generate input parameters.
 func FromPairs[K comparable, V any](pairs ...Pair[K, V]) *Map[K, V] {
-	om := New[K, V]()
+	om := NewWithCapacity[K, V](len(pairs))
+	om.SetAll(pairs...)
+	return om
+}
+
+// SetAll sets every pair in order, a key already in the map keeps its position.
+func (o *Map[K, V]) SetAll(pairs ...Pair[K, V]) {
 	for _, pair := range pairs {
-		om.Set(pair.Key(), pair.Value())
+		o.Set(pair.Key(), pair.Value())
+	}
+}
+
+// DeleteWhere deletes every pair matching the predicate and returns the number of pairs deleted.
+// Safely handles nil pointer.
+func (o *Map[K, V]) DeleteWhere(predicate func(k K, v V) bool) int {
+	if o == nil {
+		return 0
+	}
+	deleted := 0
+	for pair := o.OrderedMap.Oldest(); pair != nil; {
+		next := pair.Next()
+		if predicate(pair.Key, pair.Value) {
+			o.Delete(pair.Key)
+			deleted++
+		}
+		pair = next
+	}
+	return deleted
+}
+
+// Clone returns a shallow copy of the map, in the same order.
+// Safely returns nil on nil pointer.
+func (o *Map[K, V]) Clone() *Map[K, V] {
+	if o == nil {
+		return nil
+	}
+	om := NewWithCapacity[K, V](o.Len())
+	for k, v := range o.FromOldest() {
+		om.Set(k, v)
 	}
 	return om
 }
@@ -295,29 +344,5 @@ func SortAlpha[K comparable, V any](m *Map[K, V]) *Map[K, V] {
 	if m == nil {
 		return nil
 	}
-
-	om := New[K, V]()
-
-	type key struct {
-		key string
-		k   K
-	}
-
-	keys := []key{}
-	for pair := First(m); pair != nil; pair = pair.Next() {
-		keys = append(keys, key{
-			key: fmt.Sprintf("%v", pair.Key()),
-			k:   pair.Key(),
-		})
-	}
-
-	slices.SortFunc(keys, func(a, b key) int {
-		return strings.Compare(a.key, b.key)
-	})
-
-	for _, k := range keys {
-		om.Set(k.k, m.GetOrZero(k.k))
-	}
-
-	return om
+	return Sorted(m).ToMap()
 }
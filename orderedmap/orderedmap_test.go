@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -76,6 +77,9 @@ func TestOrderedMap(t *testing.T) {
 		}
 
 		assert.Equal(t, 0, m.GetOrZero("bogus"))
+
+		var nilMap *orderedmap.Map[string, int]
+		assert.Equal(t, 0, nilMap.GetOrZero("key0"))
 	})
 }
 
@@ -590,3 +594,54 @@ func requireClosed[K comparable, V any](t *testing.T, c <-chan orderedmap.Pair[K
 		t.Fatal("Timeout reading channel; expected channel to be closed")
 	}
 }
+
+func TestNewWithCapacity(t *testing.T) {
+	m := orderedmap.NewWithCapacity[string, int](10)
+	require.NotNil(t, m)
+	assert.Zero(t, m.Len())
+	m.Set("key", 1)
+	assert.Equal(t, 1, m.GetOrZero("key"))
+}
+
+func TestSetAll(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	m.Set("b", 1)
+	m.SetAll(
+		orderedmap.NewPair("a", 2),
+		orderedmap.NewPair("b", 3),
+		orderedmap.NewPair("c", 4),
+	)
+	assert.Equal(t, []string{"b", "a", "c"}, slices.Collect(m.KeysFromOldest()))
+	assert.Equal(t, []int{3, 2, 4}, slices.Collect(m.ValuesFromOldest()))
+}
+
+func TestDeleteWhere(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	for i := 0; i < 10; i++ {
+		m.Set(fmt.Sprintf("key%d", i), i)
+	}
+	deleted := m.DeleteWhere(func(k string, v int) bool {
+		return v%3 != 0
+	})
+	assert.Equal(t, 6, deleted)
+	assert.Equal(t, []string{"key0", "key3", "key6", "key9"}, slices.Collect(m.KeysFromOldest()))
+	assert.Zero(t, m.DeleteWhere(func(string, int) bool { return false }))
+
+	var empty *orderedmap.Map[string, int]
+	assert.Zero(t, empty.DeleteWhere(func(string, int) bool { return true }))
+}
+
+func TestClone(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	m.Set("b", 1)
+	m.Set("a", 2)
+
+	c := m.Clone()
+	assert.Equal(t, []string{"b", "a"}, slices.Collect(c.KeysFromOldest()))
+	c.Set("c", 3)
+	c.Delete("b")
+	assert.Equal(t, []string{"b", "a"}, slices.Collect(m.KeysFromOldest()))
+
+	var empty *orderedmap.Map[string, int]
+	assert.Nil(t, empty.Clone())
+}
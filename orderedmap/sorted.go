@@ -0,0 +1,127 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package orderedmap
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// SortedKeys returns the keys of the map in alphabetical order, without changing the order of the map.
+// Safely returns nil on nil pointer.
+func SortedKeys[K comparable, V any](m *Map[K, V]) []K {
+	if Len(m) == 0 {
+		return nil
+	}
+
+	type key struct {
+		key string
+		k   K
+	}
+
+	keys := make([]key, 0, m.Len())
+	for k := range m.KeysFromOldest() {
+		keys = append(keys, key{
+			key: fmt.Sprintf("%v", k),
+			k:   k,
+		})
+	}
+
+	slices.SortStableFunc(keys, func(a, b key) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	sorted := make([]K, len(keys))
+	for i, k := range keys {
+		sorted[i] = k.k
+	}
+	return sorted
+}
+
+// SortedView is a read-only view of a map, iterating its pairs in sorted order while the map keeps its insertion
+// order. The order of the keys is taken when the view is created, their values are read from the map.
+type SortedView[K comparable, V any] struct {
+	m    *Map[K, V]
+	keys []K
+}
+
+// Sorted returns a view of the map with its keys in alphabetical order.
+func Sorted[K comparable, V any](m *Map[K, V]) *SortedView[K, V] {
+	return &SortedView[K, V]{m: m, keys: SortedKeys(m)}
+}
+
+// SortBy returns a view of the map with its pairs sorted by `less`, pairs that are equal keep their insertion order.
+func SortBy[K comparable, V any](m *Map[K, V], less func(a, b Pair[K, V]) bool) *SortedView[K, V] {
+	var pairs []Pair[K, V]
+	for pair := First(m); pair != nil; pair = pair.Next() {
+		pairs = append(pairs, pair)
+	}
+
+	slices.SortStableFunc(pairs, func(a, b Pair[K, V]) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	})
+
+	v := &SortedView[K, V]{m: m}
+	for _, pair := range pairs {
+		v.keys = append(v.keys, pair.Key())
+	}
+	return v
+}
+
+// Len returns the number of pairs in the view.
+func (v *SortedView[K, V]) Len() int {
+	return len(v.keys)
+}
+
+// Get returns the value of a key, and whether the key is in the map.
+func (v *SortedView[K, V]) Get(k K) (V, bool) {
+	if v.m == nil {
+		var zero V
+		return zero, false
+	}
+	return v.m.Get(k)
+}
+
+// Keys returns the keys of the view, in sorted order.
+func (v *SortedView[K, V]) Keys() []K {
+	return slices.Clone(v.keys)
+}
+
+// All returns an iterator that yields the key-value pairs of the view, in sorted order.
+func (v *SortedView[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, k := range v.keys {
+			if !yield(k, v.m.GetOrZero(k)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator that yields the values of the view, in sorted order.
+func (v *SortedView[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, k := range v.keys {
+			if !yield(v.m.GetOrZero(k)) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap creates a new map holding the pairs of the view, in sorted order.
+func (v *SortedView[K, V]) ToMap() *Map[K, V] {
+	if v.m == nil {
+		return nil
+	}
+	return From(v.All())
+}
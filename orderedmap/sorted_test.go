@@ -0,0 +1,97 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package orderedmap_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedKeys(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	m.Set("pizza", 1)
+	m.Set("burger", 2)
+	m.Set("chips", 3)
+
+	assert.Equal(t, []string{"burger", "chips", "pizza"}, orderedmap.SortedKeys(m))
+	assert.Equal(t, []string{"pizza", "burger", "chips"}, slices.Collect(m.KeysFromOldest()))
+
+	assert.Nil(t, orderedmap.SortedKeys[string, int](nil))
+	assert.Nil(t, orderedmap.SortedKeys(orderedmap.New[string, int]()))
+}
+
+func TestSorted(t *testing.T) {
+	m := orderedmap.New[int, string]()
+	m.Set(3, "three")
+	m.Set(10, "ten")
+	m.Set(1, "one")
+
+	v := orderedmap.Sorted(m)
+	assert.Equal(t, 3, v.Len())
+	// keys are sorted alphabetically, as they're rendered.
+	assert.Equal(t, []int{1, 10, 3}, v.Keys())
+	assert.Equal(t, []string{"one", "ten", "three"}, slices.Collect(v.Values()))
+	assert.Equal(t, []int{3, 10, 1}, slices.Collect(m.KeysFromOldest()))
+
+	value, ok := v.Get(10)
+	assert.True(t, ok)
+	assert.Equal(t, "ten", value)
+
+	// values are read from the map.
+	m.Set(10, "TEN")
+	var values []string
+	for k, value := range v.All() {
+		values = append(values, value)
+		if k == 10 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"one", "TEN"}, values)
+
+	sorted := v.ToMap()
+	assert.Equal(t, []int{1, 10, 3}, slices.Collect(sorted.KeysFromOldest()))
+	sorted.Set(2, "two")
+	assert.Equal(t, 3, m.Len())
+}
+
+func TestSortBy(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	m.Set("a", 2)
+	m.Set("b", 1)
+	m.Set("c", 2)
+	m.Set("d", 0)
+
+	v := orderedmap.SortBy(m, func(a, b orderedmap.Pair[string, int]) bool {
+		return a.Value() < b.Value()
+	})
+	// equal values keep their insertion order.
+	assert.Equal(t, []string{"d", "b", "a", "c"}, v.Keys())
+	assert.Equal(t, []int{0, 1, 2, 2}, slices.Collect(v.Values()))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, slices.Collect(m.KeysFromOldest()))
+
+	for range v.Values() {
+		break
+	}
+}
+
+func TestSortedViewWithNilMap(t *testing.T) {
+	var m *orderedmap.Map[string, int]
+
+	for _, v := range []*orderedmap.SortedView[string, int]{
+		orderedmap.Sorted(m),
+		orderedmap.SortBy(m, func(a, b orderedmap.Pair[string, int]) bool { return a.Key() < b.Key() }),
+	} {
+		assert.Equal(t, 0, v.Len())
+		assert.Empty(t, v.Keys())
+		_, ok := v.Get("a")
+		assert.False(t, ok)
+		assert.Nil(t, v.ToMap())
+		for range v.All() {
+			t.Fail()
+		}
+	}
+}
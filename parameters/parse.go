@@ -0,0 +1,238 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package parameters
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// Parse parses the value of a parameter, using the style, explode setting and schema of the parameter. What's
+// parsed depends on the location of the parameter:
+//
+//	path    the value of the `{name}` template of the path, such as `;color=blue`
+//	query   the query of a URL, such as `color=blue&size=small`
+//	header  the value of the header
+//	cookie  a Cookie header, see Parameter.ParseCookie
+//
+// A string is returned for primitive schemas, a []string for `array` schemas and a map[string]string for `object`
+// schemas. Values are percent-decoded (except for headers), no type conversion is performed. Exploded `form`
+// objects are collected from the query parameters named after the properties of the schema, so the schema must
+// define properties. ErrParameterNotFound is returned (wrapped) if the parameter is not in the query.
+func Parse(param *v3.Parameter, raw string) (any, error) {
+	if param.In == "cookie" {
+		return param.ParseCookie(raw)
+	}
+	style, explode, err := Style(param)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{param: param, style: style, explode: explode, kind: schemaType(param)}
+	switch param.In {
+	case "query":
+		p.unescape = url.QueryUnescape
+		return p.query(raw)
+	case "path":
+		p.unescape = url.PathUnescape
+	default:
+		p.unescape = func(v string) (string, error) { return v, nil }
+	}
+	return p.value(raw)
+}
+
+type parser struct {
+	param    *v3.Parameter
+	style    string
+	explode  bool
+	kind     string
+	unescape func(string) (string, error)
+}
+
+func (p *parser) invalid(raw, reason string) error {
+	return fmt.Errorf("unable to parse parameter '%s', value '%s' %s", p.param.Name, raw, reason)
+}
+
+// value parses a path or a header value.
+func (p *parser) value(raw string) (any, error) {
+	rest := raw
+	switch p.style {
+	case StyleMatrix:
+		prefix := ";" + p.param.Name
+		if p.kind == "object" && p.explode {
+			prefix = ";"
+		} else if rest == prefix {
+			return p.typed("", nil)
+		} else {
+			prefix += "="
+		}
+		if !strings.HasPrefix(rest, prefix) {
+			return nil, p.invalid(raw, fmt.Sprintf("does not begin with '%s'", prefix))
+		}
+		rest = rest[len(prefix):]
+		if p.explode && p.kind == "array" {
+			return p.typed("", strings.Split(rest, ";"+p.param.Name+"="))
+		}
+		if p.explode && p.kind == "object" {
+			return p.pairs(raw, strings.Split(rest, ";"))
+		}
+	case StyleLabel:
+		if !strings.HasPrefix(rest, ".") {
+			return nil, p.invalid(raw, "does not begin with '.'")
+		}
+		rest = rest[1:]
+		if p.explode {
+			if p.kind == "object" {
+				return p.pairs(raw, strings.Split(rest, "."))
+			}
+			return p.typed(rest, strings.Split(rest, "."))
+		}
+	case StyleSimple:
+		if p.explode && p.kind == "object" {
+			return p.pairs(raw, strings.Split(rest, ","))
+		}
+	}
+	return p.typed(rest, strings.Split(rest, ","))
+}
+
+// query parses a query parameter from the query of a URL.
+func (p *parser) query(raw string) (any, error) {
+	raw = strings.TrimPrefix(raw, "?")
+	var pairs [][2]string
+	for _, part := range strings.Split(raw, "&") {
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		name, err := url.QueryUnescape(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the query '%s': %w", raw, err)
+		}
+		pairs = append(pairs, [2]string{name, value})
+	}
+	lookup := func(name string) []string {
+		var found []string
+		for _, pair := range pairs {
+			if pair[0] == name {
+				found = append(found, pair[1])
+			}
+		}
+		return found
+	}
+	notFound := fmt.Errorf("query parameter '%s': %w", p.param.Name, ErrParameterNotFound)
+
+	if p.style == StyleDeepObject {
+		var elements []string
+		prefix := p.param.Name + "["
+		for _, pair := range pairs {
+			if strings.HasPrefix(pair[0], prefix) && strings.HasSuffix(pair[0], "]") {
+				elements = append(elements, url.QueryEscape(pair[0][len(prefix):len(pair[0])-1]), pair[1])
+			}
+		}
+		if len(elements) == 0 {
+			return nil, notFound
+		}
+		return p.object(raw, elements)
+	}
+
+	if p.explode && p.kind == "object" {
+		props := schemaProperties(p.param)
+		if len(props) == 0 {
+			return nil, fmt.Errorf("unable to parse exploded query parameter '%s', "+
+				"the schema does not define any properties", p.param.Name)
+		}
+		var elements []string
+		for _, prop := range props {
+			if found := lookup(prop); len(found) > 0 {
+				elements = append(elements, url.QueryEscape(prop), found[0])
+			}
+		}
+		if len(elements) == 0 {
+			return nil, notFound
+		}
+		return p.object(raw, elements)
+	}
+
+	found := lookup(p.param.Name)
+	if len(found) == 0 {
+		return nil, notFound
+	}
+	if p.explode && p.kind == "array" {
+		return p.typed("", found)
+	}
+	var elements []string
+	switch p.style {
+	case StyleSpaceDelimited:
+		elements = strings.FieldsFunc(strings.ReplaceAll(found[0], "%20", " "), func(r rune) bool {
+			return r == ' ' || r == '+'
+		})
+	case StylePipeDelimited:
+		elements = strings.Split(found[0], "|")
+	default:
+		elements = strings.Split(found[0], ",")
+	}
+	return p.typed(found[0], elements)
+}
+
+// typed returns the value of a primitive, the elements of an array, or the object of alternating keys and values,
+// depending on the schema of the parameter. Values are unescaped.
+func (p *parser) typed(value string, elements []string) (any, error) {
+	if len(elements) == 1 && elements[0] == "" {
+		elements = nil
+	}
+	switch p.kind {
+	case "array":
+		values := make([]string, 0, len(elements))
+		for _, e := range elements {
+			d, err := p.unescape(e)
+			if err != nil {
+				return nil, p.invalid(e, err.Error())
+			}
+			values = append(values, d)
+		}
+		return values, nil
+	case "object":
+		return p.object(value, elements)
+	}
+	d, err := p.unescape(value)
+	if err != nil {
+		return nil, p.invalid(value, err.Error())
+	}
+	return d, nil
+}
+
+// object returns the object of alternating keys and values.
+func (p *parser) object(raw string, elements []string) (map[string]string, error) {
+	if len(elements)%2 != 0 {
+		return nil, p.invalid(raw, "is an object with an odd number of elements")
+	}
+	obj := make(map[string]string, len(elements)/2)
+	for i := 0; i < len(elements); i += 2 {
+		k, err := p.unescape(elements[i])
+		if err != nil {
+			return nil, p.invalid(elements[i], err.Error())
+		}
+		v, err := p.unescape(elements[i+1])
+		if err != nil {
+			return nil, p.invalid(elements[i+1], err.Error())
+		}
+		obj[k] = v
+	}
+	return obj, nil
+}
+
+// pairs returns the object of exploded `key=value` pairs.
+func (p *parser) pairs(raw string, pairs []string) (map[string]string, error) {
+	elements := make([]string, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, p.invalid(raw, fmt.Sprintf("has a property '%s' without a value", pair))
+		}
+		elements = append(elements, k, v)
+	}
+	return p.object(raw, elements)
+}
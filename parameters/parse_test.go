@@ -0,0 +1,127 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package parameters
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_SpecExamples(t *testing.T) {
+	for _, e := range specExamples() {
+		var expected any
+		switch v := e.value.(type) {
+		case map[string]int:
+			expected = map[string]string{"R": "100", "G": "200", "B": "150"}
+		default:
+			expected = v
+		}
+		parsed, err := Parse(exampleParameter(e), e.serialized)
+		require.NoError(t, err, "%s %s explode=%v", e.in, e.style, e.explode)
+		assert.Equal(t, expected, parsed, "%s %s explode=%v", e.in, e.style, e.explode)
+	}
+}
+
+func TestParse_Query(t *testing.T) {
+	array := base.CreateSchemaProxy(&base.Schema{Type: []string{"array"}})
+	param := &v3.Parameter{Name: "color", In: "query", Schema: array}
+
+	parsed, err := Parse(param, "?size=small&color=blue&color=a%20b&color=c+d")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blue", "a b", "c d"}, parsed)
+
+	_, err = Parse(param, "size=small")
+	assert.ErrorIs(t, err, ErrParameterNotFound)
+	assert.EqualError(t, err, "query parameter 'color': parameter not found")
+
+	// encoded delimiters are kept in values.
+	explode := false
+	param.Explode = &explode
+	parsed, err = Parse(param, "color=a%2Cb,c")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a,b", "c"}, parsed)
+
+	parsed, err = Parse(&v3.Parameter{Name: "q", In: "query"}, "q=a%20b%26c")
+	require.NoError(t, err)
+	assert.Equal(t, "a b&c", parsed)
+
+	parsed, err = Parse(&v3.Parameter{Name: "q", In: "query", Schema: array}, "q=")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, parsed)
+
+	_, err = Parse(&v3.Parameter{Name: "color", In: "query", Style: StyleDeepObject}, "size=small")
+	assert.ErrorIs(t, err, ErrParameterNotFound)
+
+	_, err = Parse(&v3.Parameter{Name: "color", In: "query", Schema: base.CreateSchemaProxy(rgbSchema())}, "size=small")
+	assert.ErrorIs(t, err, ErrParameterNotFound)
+
+	object := base.CreateSchemaProxy(&base.Schema{Type: []string{"object"}})
+	_, err = Parse(&v3.Parameter{Name: "color", In: "query", Schema: object}, "R=100")
+	assert.EqualError(t, err, "unable to parse exploded query parameter 'color', the schema does not define any properties")
+
+	_, err = Parse(&v3.Parameter{Name: "color", In: "query"}, "%zz=1")
+	assert.Error(t, err)
+}
+
+func TestParse_Path(t *testing.T) {
+	parsed, err := Parse(&v3.Parameter{Name: "id", In: "path"}, "a%2Fb")
+	require.NoError(t, err)
+	assert.Equal(t, "a/b", parsed)
+
+	array := base.CreateSchemaProxy(&base.Schema{Type: []string{"array"}})
+	parsed, err = Parse(&v3.Parameter{Name: "id", In: "path", Style: StyleMatrix, Schema: array}, ";id")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, parsed)
+
+	parsed, err = Parse(&v3.Parameter{Name: "id", In: "path", Schema: array}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, parsed)
+
+	_, err = Parse(&v3.Parameter{Name: "id", In: "path", Style: StyleMatrix}, ".blue")
+	assert.EqualError(t, err, "unable to parse parameter 'id', value '.blue' does not begin with ';id='")
+
+	_, err = Parse(&v3.Parameter{Name: "id", In: "path", Style: StyleLabel}, "blue")
+	assert.EqualError(t, err, "unable to parse parameter 'id', value 'blue' does not begin with '.'")
+
+	_, err = Parse(&v3.Parameter{Name: "id", In: "path"}, "a%zz")
+	assert.Error(t, err)
+
+	rgb := base.CreateSchemaProxy(rgbSchema())
+	_, err = Parse(&v3.Parameter{Name: "color", In: "path", Schema: rgb}, "R,100,G")
+	assert.EqualError(t, err, "unable to parse parameter 'color', value 'R,100,G' is an object with an odd number of elements")
+
+	explode := true
+	_, err = Parse(&v3.Parameter{Name: "color", In: "path", Explode: &explode, Schema: rgb}, "R=100,G")
+	assert.EqualError(t, err, "unable to parse parameter 'color', value 'R=100,G' has a property 'G' without a value")
+}
+
+func TestParse_HeaderAndCookie(t *testing.T) {
+	// headers are not percent-decoded.
+	parsed, err := Parse(&v3.Parameter{Name: "X-Name", In: "header"}, "a%20b")
+	require.NoError(t, err)
+	assert.Equal(t, "a%20b", parsed)
+
+	parsed, err = Parse(&v3.Parameter{Name: "session", In: "cookie"}, "theme=dark; session=abc")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", parsed)
+
+	_, err = Parse(&v3.Parameter{Name: "color", In: "header", Style: StyleMatrix}, ";color=blue")
+	assert.Error(t, err)
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	values := []string{"a b", "c,d", "e/f", "g&h", "ü"}
+	for _, in := range []string{"path", "query"} {
+		param := &v3.Parameter{Name: "v", In: in, Schema: base.CreateSchemaProxy(&base.Schema{Type: []string{"array"}})}
+		serialized, err := Serialize(param, values)
+		require.NoError(t, err)
+		parsed, err := Parse(param, serialized)
+		require.NoError(t, err)
+		assert.Equal(t, values, parsed, in)
+	}
+}
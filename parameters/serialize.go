@@ -0,0 +1,364 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package parameters serializes and parses the values of parameters, following the `style`, `explode` and
+// `allowReserved` rules of the parameter (in turn following RFC 6570). It's used to build clients (serializing
+// values into requests) and validators (parsing values out of requests).
+//
+// Every style defined by OpenAPI 3 is supported, for the locations the specification allows them in:
+//
+//	matrix, label               path
+//	simple                      path, header
+//	form                        query, cookie
+//	spaceDelimited, pipeDelimited, deepObject  query
+//
+// Using the examples from the specification, for a parameter named `color`:
+//
+//	style           explode  string         array                      object
+//	matrix          false    ;color=blue    ;color=blue,black,brown    ;color=R,100,G,200,B,150
+//	matrix          true     ;color=blue    ;color=blue;color=black    ;R=100;G=200;B=150
+//	label           false    .blue          .blue,black,brown          .R,100,G,200,B,150
+//	label           true     .blue          .blue.black.brown          .R=100.G=200.B=150
+//	form            false    color=blue     color=blue,black,brown     color=R,100,G,200,B,150
+//	form            true     color=blue     color=blue&color=black     R=100&G=200&B=150
+//	simple          false    blue           blue,black,brown           R,100,G,200,B,150
+//	simple          true     blue           blue,black,brown           R=100,G=200,B=150
+//	spaceDelimited  false                   color=blue%20black%20brown color=R%20100%20G%20200%20B%20150
+//	pipeDelimited   false                   color=blue|black|brown     color=R|100|G|200|B|150
+//	deepObject      true                                               color[R]=100&color[G]=200&color[B]=150
+//
+// Cookie parameters are serialized and parsed by the SerializeCookie and ParseCookie methods of the parameter.
+package parameters
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// The styles of parameters.
+const (
+	StyleMatrix         = "matrix"
+	StyleLabel          = "label"
+	StyleForm           = "form"
+	StyleSimple         = "simple"
+	StyleSpaceDelimited = "spaceDelimited"
+	StylePipeDelimited  = "pipeDelimited"
+	StyleDeepObject     = "deepObject"
+)
+
+// ErrParameterNotFound is returned by Parse when the value of a parameter is not present.
+var ErrParameterNotFound = errors.New("parameter not found")
+
+// allowedStyles are the styles allowed in each parameter location.
+var allowedStyles = map[string][]string{
+	"path":   {StyleMatrix, StyleLabel, StyleSimple},
+	"query":  {StyleForm, StyleSpaceDelimited, StylePipeDelimited, StyleDeepObject},
+	"header": {StyleSimple},
+	"cookie": {StyleForm},
+}
+
+// defaultStyles are the styles of parameters without a style, for each location.
+var defaultStyles = map[string]string{"path": StyleSimple, "query": StyleForm, "header": StyleSimple, "cookie": StyleForm}
+
+// Style returns the style of a parameter and if it's exploded, applying the defaults of the location of the
+// parameter when they're not set (`simple` for paths and headers, `form` for queries and cookies, explode only for
+// `form`). An error is returned if the style isn't allowed in the location of the parameter.
+func Style(param *v3.Parameter) (string, bool, error) {
+	allowed, ok := allowedStyles[param.In]
+	if !ok {
+		return "", false, fmt.Errorf("parameter '%s' has an unknown location '%s'", param.Name, param.In)
+	}
+	style := param.Style
+	if style == "" {
+		style = defaultStyles[param.In]
+	}
+	if !slices.Contains(allowed, style) {
+		return "", false, fmt.Errorf("parameter '%s' uses style '%s', which is not allowed for %s parameters",
+			param.Name, style, param.In)
+	}
+	explode := style == StyleForm
+	if param.Explode != nil {
+		explode = *param.Explode
+	}
+	return style, explode, nil
+}
+
+// Serialize serializes a value for a parameter, using the style, explode and allowReserved settings of the
+// parameter. What's returned depends on the location of the parameter:
+//
+//	path    the value the `{name}` template of the path is replaced with, such as `;color=blue`
+//	query   one or more query parameters, such as `color=blue&color=black`
+//	header  the value of the header
+//	cookie  one or more cookie pairs, see Parameter.SerializeCookie
+//
+// Primitive values are serialized with fmt, slices and arrays as arrays, and maps with string keys (or an
+// *orderedmap.Map[string, any]) as objects. Object keys are serialized in the order of the properties of the schema
+// of the parameter, followed by any other keys in alphabetical order, except for ordered maps, which keep the order
+// of their keys. A nil value is serialized as an empty value.
+func Serialize(param *v3.Parameter, value any) (string, error) {
+	if param.In == "cookie" {
+		return param.SerializeCookie(value)
+	}
+	style, explode, err := Style(param)
+	if err != nil {
+		return "", err
+	}
+	s := &serializer{param: param, style: style, explode: explode}
+	if param.In == "query" {
+		s.escape = func(v string) string { return escape(v, param.AllowReserved) }
+	} else if param.In == "path" {
+		s.escape = func(v string) string { return escape(v, false) }
+	} else {
+		s.escape = func(v string) string { return v }
+	}
+
+	if m, ok := value.(*orderedmap.Map[string, any]); ok && m != nil {
+		var keys, values []string
+		for k, val := range m.FromOldest() {
+			keys = append(keys, s.escape(k))
+			values = append(values, s.escape(fmt.Sprint(val)))
+		}
+		return s.object(keys, values)
+	}
+
+	v := reflect.ValueOf(value)
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			v = reflect.Value{}
+			break
+		}
+		v = v.Elem()
+	}
+	switch {
+	case !v.IsValid():
+		return s.empty()
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = s.escape(fmt.Sprint(v.Index(i).Interface()))
+		}
+		return s.array(values)
+	case v.Kind() == reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return "", fmt.Errorf("unable to serialize parameter '%s', object keys must be strings", param.Name)
+		}
+		var keys, values []string
+		for _, k := range objectKeys(param, v) {
+			keys = append(keys, s.escape(k))
+			values = append(values, s.escape(fmt.Sprint(v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())).Interface())))
+		}
+		return s.object(keys, values)
+	case v.Kind() == reflect.Struct || v.Kind() == reflect.Func || v.Kind() == reflect.Chan:
+		return "", fmt.Errorf("unable to serialize parameter '%s', unsupported type '%s'", param.Name, v.Type())
+	}
+	return s.primitive(s.escape(fmt.Sprint(v.Interface())))
+}
+
+type serializer struct {
+	param   *v3.Parameter
+	style   string
+	explode bool
+	escape  func(string) string
+}
+
+func (s *serializer) unsupported(kind string) error {
+	return fmt.Errorf("unable to serialize parameter '%s', style '%s' does not support %s values",
+		s.param.Name, s.style, kind)
+}
+
+func (s *serializer) empty() (string, error) {
+	switch s.style {
+	case StyleMatrix:
+		return ";" + s.param.Name, nil
+	case StyleLabel:
+		return ".", nil
+	case StyleForm:
+		return s.param.Name + "=", nil
+	case StyleSimple:
+		return "", nil
+	}
+	return "", s.unsupported("empty")
+}
+
+func (s *serializer) primitive(value string) (string, error) {
+	switch s.style {
+	case StyleMatrix:
+		return ";" + s.param.Name + "=" + value, nil
+	case StyleLabel:
+		return "." + value, nil
+	case StyleForm:
+		return s.param.Name + "=" + value, nil
+	case StyleSimple:
+		return value, nil
+	}
+	return "", s.unsupported("primitive")
+}
+
+func (s *serializer) array(values []string) (string, error) {
+	name := s.param.Name
+	switch s.style {
+	case StyleMatrix:
+		if s.explode {
+			return ";" + name + "=" + strings.Join(values, ";"+name+"="), nil
+		}
+		return ";" + name + "=" + strings.Join(values, ","), nil
+	case StyleLabel:
+		if s.explode {
+			return "." + strings.Join(values, "."), nil
+		}
+		return "." + strings.Join(values, ","), nil
+	case StyleSimple:
+		return strings.Join(values, ","), nil
+	case StyleForm, StyleSpaceDelimited, StylePipeDelimited:
+		if s.explode {
+			return name + "=" + strings.Join(values, "&"+name+"="), nil
+		}
+		return name + "=" + strings.Join(values, delimiter(s.style)), nil
+	}
+	return "", s.unsupported("array")
+}
+
+func (s *serializer) object(keys, values []string) (string, error) {
+	name := s.param.Name
+	pairs := func(sep, assign string) string {
+		parts := make([]string, len(keys))
+		for i := range keys {
+			parts[i] = keys[i] + assign + values[i]
+		}
+		return strings.Join(parts, sep)
+	}
+	switch s.style {
+	case StyleMatrix:
+		if s.explode {
+			return ";" + pairs(";", "="), nil
+		}
+		return ";" + name + "=" + pairs(",", ","), nil
+	case StyleLabel:
+		if s.explode {
+			return "." + pairs(".", "="), nil
+		}
+		return "." + pairs(",", ","), nil
+	case StyleSimple:
+		if s.explode {
+			return pairs(",", "="), nil
+		}
+		return pairs(",", ","), nil
+	case StyleForm:
+		if s.explode {
+			return pairs("&", "="), nil
+		}
+		return name + "=" + pairs(",", ","), nil
+	case StyleSpaceDelimited, StylePipeDelimited:
+		if s.explode {
+			break
+		}
+		d := delimiter(s.style)
+		return name + "=" + pairs(d, d), nil
+	case StyleDeepObject:
+		parts := make([]string, len(keys))
+		for i := range keys {
+			parts[i] = name + "[" + keys[i] + "]=" + values[i]
+		}
+		return strings.Join(parts, "&"), nil
+	}
+	return "", s.unsupported("exploded object")
+}
+
+// delimiter returns the (encoded) delimiter of array values, for a form style.
+func delimiter(style string) string {
+	switch style {
+	case StyleSpaceDelimited:
+		return "%20"
+	case StylePipeDelimited:
+		return "|"
+	}
+	return ","
+}
+
+// escape percent-encodes every character of a value that isn't unreserved (RFC 3986), so it can't break the
+// value or its delimiters. Reserved characters are kept as they are if allowReserved is true.
+func escape(value string, allowReserved bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if unreserved(c) || (allowReserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0) {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func unreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// objectKeys returns the keys of a map, in the order of the properties of the schema of the parameter, followed by
+// any other keys in alphabetical order.
+func objectKeys(param *v3.Parameter, v reflect.Value) []string {
+	var keys []string
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	props := schemaProperties(param)
+	if len(props) == 0 {
+		return keys
+	}
+	ordered := make([]string, 0, len(keys))
+	for _, prop := range props {
+		if slices.Contains(keys, prop) {
+			ordered = append(ordered, prop)
+		}
+	}
+	for _, k := range keys {
+		if !slices.Contains(ordered, k) {
+			ordered = append(ordered, k)
+		}
+	}
+	return ordered
+}
+
+// schemaType returns the type of the schema of the parameter ('array', 'object' or a primitive type).
+func schemaType(param *v3.Parameter) string {
+	if param.Schema == nil {
+		return ""
+	}
+	s := param.Schema.Schema()
+	if s == nil {
+		return ""
+	}
+	for _, t := range s.Type {
+		if t != "null" {
+			return t
+		}
+	}
+	if s.Properties != nil {
+		return "object"
+	}
+	if s.Items != nil {
+		return "array"
+	}
+	return ""
+}
+
+// schemaProperties returns the names of the properties of the schema of the parameter, in order.
+func schemaProperties(param *v3.Parameter) []string {
+	if param.Schema == nil {
+		return nil
+	}
+	s := param.Schema.Schema()
+	if s == nil {
+		return nil
+	}
+	return slices.Collect(s.Properties.KeysFromOldest())
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package parameters
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rgbSchema() *base.Schema {
+	props := orderedmap.New[string, *base.SchemaProxy]()
+	props.Set("R", base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}))
+	props.Set("G", base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}))
+	props.Set("B", base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}}))
+	return &base.Schema{Type: []string{"object"}, Properties: props}
+}
+
+type styleExample struct {
+	in, style  string
+	explode    bool
+	value      any
+	serialized string
+}
+
+// specExamples are the style examples of the specification: https://spec.openapis.org/oas/v3.1.0#style-examples
+func specExamples() []styleExample {
+	blue := "blue"
+	colors := []string{"blue", "black", "brown"}
+	rgb := map[string]int{"R": 100, "G": 200, "B": 150}
+	return []styleExample{
+		{"path", StyleMatrix, false, blue, ";color=blue"},
+		{"path", StyleMatrix, false, colors, ";color=blue,black,brown"},
+		{"path", StyleMatrix, false, rgb, ";color=R,100,G,200,B,150"},
+		{"path", StyleMatrix, true, blue, ";color=blue"},
+		{"path", StyleMatrix, true, colors, ";color=blue;color=black;color=brown"},
+		{"path", StyleMatrix, true, rgb, ";R=100;G=200;B=150"},
+		{"path", StyleLabel, false, blue, ".blue"},
+		{"path", StyleLabel, false, colors, ".blue,black,brown"},
+		{"path", StyleLabel, false, rgb, ".R,100,G,200,B,150"},
+		{"path", StyleLabel, true, blue, ".blue"},
+		{"path", StyleLabel, true, colors, ".blue.black.brown"},
+		{"path", StyleLabel, true, rgb, ".R=100.G=200.B=150"},
+		{"query", StyleForm, false, blue, "color=blue"},
+		{"query", StyleForm, false, colors, "color=blue,black,brown"},
+		{"query", StyleForm, false, rgb, "color=R,100,G,200,B,150"},
+		{"query", StyleForm, true, blue, "color=blue"},
+		{"query", StyleForm, true, colors, "color=blue&color=black&color=brown"},
+		{"query", StyleForm, true, rgb, "R=100&G=200&B=150"},
+		{"path", StyleSimple, false, blue, "blue"},
+		{"path", StyleSimple, false, colors, "blue,black,brown"},
+		{"header", StyleSimple, false, rgb, "R,100,G,200,B,150"},
+		{"header", StyleSimple, true, blue, "blue"},
+		{"header", StyleSimple, true, colors, "blue,black,brown"},
+		{"path", StyleSimple, true, rgb, "R=100,G=200,B=150"},
+		{"query", StyleSpaceDelimited, false, colors, "color=blue%20black%20brown"},
+		{"query", StyleSpaceDelimited, false, rgb, "color=R%20100%20G%20200%20B%20150"},
+		{"query", StylePipeDelimited, false, colors, "color=blue|black|brown"},
+		{"query", StylePipeDelimited, false, rgb, "color=R|100|G|200|B|150"},
+		{"query", StyleDeepObject, true, rgb, "color[R]=100&color[G]=200&color[B]=150"},
+	}
+}
+
+func exampleParameter(e styleExample) *v3.Parameter {
+	explode := e.explode
+	param := &v3.Parameter{Name: "color", In: e.in, Style: e.style, Explode: &explode}
+	switch e.value.(type) {
+	case []string:
+		param.Schema = base.CreateSchemaProxy(&base.Schema{Type: []string{"array"}})
+	case map[string]int:
+		param.Schema = base.CreateSchemaProxy(rgbSchema())
+	default:
+		param.Schema = base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})
+	}
+	return param
+}
+
+func TestSerialize_SpecExamples(t *testing.T) {
+	for _, e := range specExamples() {
+		serialized, err := Serialize(exampleParameter(e), e.value)
+		require.NoError(t, err, "%s %s explode=%v", e.in, e.style, e.explode)
+		assert.Equal(t, e.serialized, serialized, "%s %s explode=%v", e.in, e.style, e.explode)
+	}
+}
+
+func TestSerialize_Empty(t *testing.T) {
+	for style, expected := range map[string]string{StyleMatrix: ";color", StyleLabel: ".", StyleSimple: ""} {
+		serialized, err := Serialize(&v3.Parameter{Name: "color", In: "path", Style: style}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, serialized)
+	}
+	var missing *string
+	serialized, err := Serialize(&v3.Parameter{Name: "color", In: "query"}, missing)
+	require.NoError(t, err)
+	assert.Equal(t, "color=", serialized)
+
+	_, err = Serialize(&v3.Parameter{Name: "color", In: "query", Style: StyleDeepObject}, nil)
+	assert.EqualError(t, err, "unable to serialize parameter 'color', style 'deepObject' does not support empty values")
+}
+
+func TestSerialize_Defaults(t *testing.T) {
+	colors := []string{"blue", "black"}
+	serialized, err := Serialize(&v3.Parameter{Name: "color", In: "query"}, colors)
+	require.NoError(t, err)
+	assert.Equal(t, "color=blue&color=black", serialized)
+
+	serialized, err = Serialize(&v3.Parameter{Name: "color", In: "path"}, colors)
+	require.NoError(t, err)
+	assert.Equal(t, "blue,black", serialized)
+
+	serialized, err = Serialize(&v3.Parameter{Name: "color", In: "header"}, map[string]string{"b": "2", "a": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "a,1,b,2", serialized)
+
+	serialized, err = Serialize(&v3.Parameter{Name: "color", In: "cookie"}, colors)
+	require.NoError(t, err)
+	assert.Equal(t, "color=blue; color=black", serialized)
+
+	serialized, err = Serialize(&v3.Parameter{Name: "count", In: "query"}, 42)
+	require.NoError(t, err)
+	assert.Equal(t, "count=42", serialized)
+}
+
+func TestSerialize_Escaping(t *testing.T) {
+	serialized, err := Serialize(&v3.Parameter{Name: "q", In: "query"}, "a b&c=d/é")
+	require.NoError(t, err)
+	assert.Equal(t, "q=a%20b%26c%3Dd%2F%C3%A9", serialized)
+
+	serialized, err = Serialize(&v3.Parameter{Name: "q", In: "query", AllowReserved: true}, "a b&c=d/é")
+	require.NoError(t, err)
+	assert.Equal(t, "q=a%20b&c=d/%C3%A9", serialized)
+
+	serialized, err = Serialize(&v3.Parameter{Name: "id", In: "path"}, []string{"a,b", "c/d"})
+	require.NoError(t, err)
+	assert.Equal(t, "a%2Cb,c%2Fd", serialized)
+
+	// headers are not percent-encoded.
+	serialized, err = Serialize(&v3.Parameter{Name: "X-Name", In: "header"}, "a b/c")
+	require.NoError(t, err)
+	assert.Equal(t, "a b/c", serialized)
+}
+
+func TestSerialize_OrderedMap(t *testing.T) {
+	// the keys of an ordered map keep their order, rather than the order of the properties of the schema.
+	rgb := orderedmap.New[string, any]()
+	rgb.Set("B", 150)
+	rgb.Set("R", 100)
+	rgb.Set("G", 200)
+	for _, e := range []styleExample{
+		{"query", StyleDeepObject, true, rgb, "color[B]=150&color[R]=100&color[G]=200"},
+		{"query", StyleForm, true, rgb, "B=150&R=100&G=200"},
+		{"query", StyleForm, false, rgb, "color=B,150,R,100,G,200"},
+		{"path", StyleMatrix, true, rgb, ";B=150;R=100;G=200"},
+		{"header", StyleSimple, false, rgb, "B,150,R,100,G,200"},
+		{"header", StyleSimple, true, rgb, "B=150,R=100,G=200"},
+		{"cookie", StyleForm, true, rgb, "B=150; R=100; G=200"},
+	} {
+		explode := e.explode
+		param := &v3.Parameter{Name: "color", In: e.in, Style: e.style, Explode: &explode,
+			Schema: base.CreateSchemaProxy(rgbSchema())}
+		serialized, err := Serialize(param, e.value)
+		require.NoError(t, err, "%s %s explode=%v", e.in, e.style, e.explode)
+		assert.Equal(t, e.serialized, serialized, "%s %s explode=%v", e.in, e.style, e.explode)
+	}
+
+	// values are escaped, as they are for maps.
+	escaped := orderedmap.New[string, any]()
+	escaped.Set("a b", "c&d")
+	serialized, err := Serialize(&v3.Parameter{Name: "q", In: "query", Style: StyleDeepObject}, escaped)
+	require.NoError(t, err)
+	assert.Equal(t, "q[a%20b]=c%26d", serialized)
+
+	// a nil ordered map is empty.
+	serialized, err = Serialize(&v3.Parameter{Name: "color", In: "query"}, (*orderedmap.Map[string, any])(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "color=", serialized)
+}
+
+func TestSerialize_Errors(t *testing.T) {
+	_, err := Serialize(&v3.Parameter{Name: "color", In: "body"}, "blue")
+	assert.EqualError(t, err, "parameter 'color' has an unknown location 'body'")
+
+	_, err = Serialize(&v3.Parameter{Name: "color", In: "header", Style: StyleForm}, "blue")
+	assert.EqualError(t, err, "parameter 'color' uses style 'form', which is not allowed for header parameters")
+
+	_, err = Serialize(&v3.Parameter{Name: "color", In: "query", Style: StyleDeepObject}, "blue")
+	assert.EqualError(t, err, "unable to serialize parameter 'color', style 'deepObject' does not support primitive values")
+
+	_, err = Serialize(&v3.Parameter{Name: "color", In: "query", Style: StylePipeDelimited}, "blue")
+	assert.Error(t, err)
+
+	explode := true
+	_, err = Serialize(&v3.Parameter{Name: "color", In: "query", Style: StylePipeDelimited, Explode: &explode},
+		map[string]string{"R": "1"})
+	assert.EqualError(t, err, "unable to serialize parameter 'color', style 'pipeDelimited' does not support exploded object values")
+
+	_, err = Serialize(&v3.Parameter{Name: "color", In: "query"}, map[int]string{1: "a"})
+	assert.EqualError(t, err, "unable to serialize parameter 'color', object keys must be strings")
+
+	_, err = Serialize(&v3.Parameter{Name: "color", In: "query"}, struct{}{})
+	assert.EqualError(t, err, "unable to serialize parameter 'color', unsupported type 'struct {}'")
+}
+
+func TestStyle(t *testing.T) {
+	style, explode, err := Style(&v3.Parameter{Name: "color", In: "query"})
+	require.NoError(t, err)
+	assert.Equal(t, StyleForm, style)
+	assert.True(t, explode)
+
+	style, explode, err = Style(&v3.Parameter{Name: "color", In: "path", Style: StyleLabel})
+	require.NoError(t, err)
+	assert.Equal(t, StyleLabel, style)
+	assert.False(t, explode)
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"errors"
+	"fmt"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+)
+
+// GenerateEventStream generates an example stream of count events for a streaming MediaType (such as a
+// `text/event-stream` or `application/x-ndjson`), framed as contentType (see v3.FormatEventStream).
+//
+// Events are generated from the event schema of the MediaType (see MediaType.GetEventSchema). The examples of the
+// event schema are used in turn, then its example, and if it has neither, every event is rendered from the schema.
+// The mock type of the generator is not used, stream items are always JSON.
+func (mg *MockGenerator) GenerateEventStream(mediaType *v3.MediaType, contentType string, count int) ([]byte, error) {
+	if !v3.IsStreamingMediaType(contentType) {
+		return nil, fmt.Errorf("unable to generate event stream, '%s' is not a streaming media type", contentType)
+	}
+	if mediaType == nil || mediaType.GetEventSchema() == nil {
+		return nil, errors.New("unable to generate event stream, there is no event schema")
+	}
+	schema := mediaType.GetEventSchema().Schema()
+	if schema == nil {
+		return nil, fmt.Errorf("unable to generate event stream, the event schema cannot be built: %w",
+			mediaType.GetEventSchema().GetBuildError())
+	}
+
+	events := make([]any, 0, count)
+	for i := 0; i < count; i++ {
+		switch {
+		case len(schema.Examples) > 0:
+			events = append(events, schema.Examples[i%len(schema.Examples)])
+		case schema.Example != nil:
+			events = append(events, schema.Example)
+		default:
+			events = append(events, mg.renderer.RenderSchema(schema))
+		}
+	}
+	return v3.FormatEventStream(contentType, events)
+}
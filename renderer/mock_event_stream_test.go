@@ -0,0 +1,106 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func createStreamingMediaType(itemSchema string) *v3.MediaType {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(itemSchema), &root)
+	var lowProxy lowbase.SchemaProxy
+	_ = lowProxy.Build(context.Background(), &root, root.Content[0], nil)
+	lowRef := low.NodeReference[*lowbase.SchemaProxy]{
+		Value: &lowProxy,
+	}
+	return &v3.MediaType{ItemSchema: base.NewSchemaProxy(&lowRef)}
+}
+
+func TestMockGenerator_GenerateEventStream_Examples(t *testing.T) {
+	mt := createStreamingMediaType(`type: object
+properties:
+  event:
+    type: string
+  data:
+    type: string
+examples:
+  - event: tick
+    data: one
+  - event: done
+    data: two`)
+
+	mg := NewMockGenerator(JSON)
+	stream, err := mg.GenerateEventStream(mt, "text/event-stream", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "event: tick\ndata: one\n\nevent: done\ndata: two\n\nevent: tick\ndata: one\n\n", string(stream))
+
+	// the generated stream can be parsed and validated again.
+	violations, err := mt.ValidateEventStream("text/event-stream", stream)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestMockGenerator_GenerateEventStream_Example(t *testing.T) {
+	mt := createStreamingMediaType(`type: object
+example:
+  count: 1`)
+
+	mg := NewMockGenerator(YAML)
+	stream, err := mg.GenerateEventStream(mt, "application/x-ndjson", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"count\":1}\n{\"count\":1}\n", string(stream))
+}
+
+func TestMockGenerator_GenerateEventStream_Schema(t *testing.T) {
+	mt := createStreamingMediaType(`type: object
+required: [count, name]
+properties:
+  count:
+    type: integer
+    minimum: 1
+    maximum: 5
+  name:
+    type: string`)
+
+	mg := NewMockGenerator(JSON)
+	stream, err := mg.GenerateEventStream(mt, "application/jsonl", 4)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(stream), "\n"), "\n")
+	require.Len(t, lines, 4)
+	for _, line := range lines {
+		var event map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		assert.Contains(t, event, "count")
+		assert.Contains(t, event, "name")
+	}
+
+	violations, err := mt.ValidateEventStream("application/jsonl", stream)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestMockGenerator_GenerateEventStream_Errors(t *testing.T) {
+	mg := NewMockGenerator(JSON)
+
+	_, err := mg.GenerateEventStream(&v3.MediaType{}, "application/json", 1)
+	assert.EqualError(t, err, "unable to generate event stream, 'application/json' is not a streaming media type")
+
+	_, err = mg.GenerateEventStream(&v3.MediaType{}, "text/event-stream", 1)
+	assert.EqualError(t, err, "unable to generate event stream, there is no event schema")
+
+	_, err = mg.GenerateEventStream(nil, "text/event-stream", 1)
+	assert.Error(t, err)
+}
@@ -25,6 +25,7 @@ type MockType int
 const (
 	JSON MockType = iota
 	YAML
+	XML
 )
 
 // MockGenerator is used to generate mocks for high-level mockable structs or *base.Schema pointers.
@@ -57,7 +58,7 @@ func NewMockGenerator(mockType MockType) *MockGenerator {
 
 // SetPretty sets the pretty flag on the mock generator. If true, the mock will be rendered with indentation and newlines.
 // If false, the mock will be rendered as a single line which is good for API responses. False is the default.
-// This option only effects JSON mocks, there is no concept of pretty printing YAML.
+// This option only effects JSON and XML mocks, there is no concept of pretty printing YAML.
 func (mg *MockGenerator) SetPretty() {
 	mg.pretty = true
 }
@@ -101,6 +102,30 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 			"fields (%s, %s)", fieldCount, Example, Examples)
 	}
 
+	// find the schema, XML mocks are rendered following it, and if there are no examples, we can try and generate
+	// a mock from it. check if this is a SchemaProxy, if not, then see if it has a Schema, if not, then we can't
+	// generate a mock.
+	var schemaValue *highbase.Schema
+	switch reflect.TypeOf(mock) {
+	case reflect.TypeOf(&highbase.Schema{}):
+		schemaValue = mock.(*highbase.Schema)
+	default:
+		sf := v.FieldByName(Schema)
+		if !sf.IsValid() {
+			break
+		}
+		if sv, ok := sf.Interface().(*highbase.Schema); ok {
+			if sv != nil {
+				schemaValue = sv
+			}
+		}
+		if sv, ok := sf.Interface().(*highbase.SchemaProxy); ok {
+			if sv != nil {
+				schemaValue = sv.Schema()
+			}
+		}
+	}
+
 	// if the value has an example, try and render it out as is.
 	f := v.FieldByName(Example)
 	if !f.IsNil() {
@@ -115,7 +140,7 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 		}
 		if ex != nil {
 			// try and serialize the example value
-			return mg.renderMock(ex), nil
+			return mg.renderMock(ex, schemaValue), nil
 		}
 	}
 
@@ -130,32 +155,13 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 		// if the name is not empty, try and find the example by name
 		for k, exp := range examplesMap.FromOldest() {
 			if k == name {
-				return mg.renderMock(exp.Value), nil
+				return mg.renderMock(exp.Value, schemaValue), nil
 			}
 		}
 
 		// if the name is empty, just return the first example
 		for exp := range examplesMap.ValuesFromOldest() {
-			return mg.renderMock(exp.Value), nil
-		}
-	}
-
-	// no examples? no problem, we can try and generate a mock from the schema.
-	// check if this is a SchemaProxy, if not, then see if it has a Schema, if not, then we can't generate a mock.
-	var schemaValue *highbase.Schema
-	switch reflect.TypeOf(mock) {
-	case reflect.TypeOf(&highbase.Schema{}):
-		schemaValue = mock.(*highbase.Schema)
-	default:
-		if sv, ok := v.FieldByName(Schema).Interface().(*highbase.Schema); ok {
-			if sv != nil {
-				schemaValue = sv
-			}
-		}
-		if sv, ok := v.FieldByName(Schema).Interface().(*highbase.SchemaProxy); ok {
-			if sv != nil {
-				schemaValue = sv.Schema()
-			}
+			return mg.renderMock(exp.Value, schemaValue), nil
 		}
 	}
 
@@ -167,30 +173,32 @@ func (mg *MockGenerator) GenerateMock(mock any, name string) ([]byte, error) {
 				// try and convert the example to an integer
 				if i, err := strconv.Atoi(name); err == nil {
 					if i < len(schemaValue.Examples) {
-						return mg.renderMock(schemaValue.Examples[i]), nil
+						return mg.renderMock(schemaValue.Examples[i], schemaValue), nil
 					}
 				}
 			}
 			// if the name is empty, just return the first example
-			return mg.renderMock(schemaValue.Examples[0]), nil
+			return mg.renderMock(schemaValue.Examples[0], schemaValue), nil
 		}
 
 		// check the example field
 		if schemaValue.Example != nil {
-			return mg.renderMock(schemaValue.Example), nil
+			return mg.renderMock(schemaValue.Example, schemaValue), nil
 		}
 
 		// render the schema as our last hope.
 		renderMap := mg.renderer.RenderSchema(schemaValue)
-		return mg.renderMock(renderMap), nil
+		return mg.renderMock(renderMap, schemaValue), nil
 	}
 	return nil, nil
 }
 
-func (mg *MockGenerator) renderMock(v any) []byte {
+func (mg *MockGenerator) renderMock(v any, schema *highbase.Schema) []byte {
 	switch {
 	case mg.mockType == YAML:
 		return mg.renderMockYAML(v)
+	case mg.mockType == XML:
+		return mg.renderMockXML(v, schema)
 	default:
 		return mg.renderMockJSON(v)
 	}
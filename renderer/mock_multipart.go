@@ -0,0 +1,135 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"reflect"
+	"strings"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// PlaceholderFileContent is the content of every file part of a generated multipart request.
+const PlaceholderFileContent = "<placeholder file content>"
+
+// GenerateMultipartRequest generates an example `multipart/form-data` request body for mediaType, along with the
+// Content-Type header to send it with (which holds the boundary). If boundary is empty, a random one is used.
+//
+// Every property of the schema of mediaType is a part. Files (see MediaType.BinaryUploads) are parts named after
+// the property holding PlaceholderFileContent, with the first content type the file can be (or
+// `application/octet-stream`). All other properties are rendered the same way GenerateMock renders a schema (the
+// first example, the example, then the schema itself), objects and arrays are rendered as JSON. Parts use the
+// contentType of their Encoding, when there is one.
+func (mg *MockGenerator) GenerateMultipartRequest(mediaType *v3.MediaType, boundary string) ([]byte, string, error) {
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Schema() == nil {
+		return nil, "", errors.New("unable to generate multipart request, there is no schema")
+	}
+	schema := mediaType.Schema.Schema()
+	if orderedmap.Len(schema.Properties) == 0 {
+		return nil, "", errors.New("unable to generate multipart request, the schema has no properties")
+	}
+
+	files := make(map[string]*v3.BinaryUpload)
+	for _, upload := range mediaType.BinaryUploads("multipart/form-data") {
+		files[upload.Property] = upload
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if boundary != "" {
+		if err := w.SetBoundary(boundary); err != nil {
+			return nil, "", fmt.Errorf("unable to generate multipart request: %w", err)
+		}
+	}
+	for name, sp := range schema.Properties.FromOldest() {
+		header := make(textproto.MIMEHeader)
+		if upload, ok := files[name]; ok {
+			header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, name, name))
+			header.Set("Content-Type", placeholderContentType(upload.ContentTypes))
+			count := 1
+			if upload.Array {
+				count = 2
+			}
+			for i := 0; i < count; i++ {
+				part, err := w.CreatePart(header)
+				if err != nil {
+					return nil, "", err
+				}
+				_, _ = part.Write([]byte(PlaceholderFileContent))
+			}
+			continue
+		}
+
+		var value any
+		if sp != nil {
+			value = mg.exampleValue(sp.Schema())
+		}
+		content, isJSON := partContent(value)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, name))
+		if enc := mediaType.Encoding.GetOrZero(name); enc != nil && enc.ContentType != "" {
+			ct, _, _ := strings.Cut(enc.ContentType, ",")
+			header.Set("Content-Type", strings.TrimSpace(ct))
+		} else if isJSON {
+			header.Set("Content-Type", "application/json")
+		}
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		_, _ = part.Write(content)
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+// exampleValue returns the first example of a schema, its example, or a rendering of it.
+func (mg *MockGenerator) exampleValue(schema *highbase.Schema) any {
+	switch {
+	case schema == nil:
+		return nil
+	case len(schema.Examples) > 0:
+		return schema.Examples[0]
+	case schema.Example != nil:
+		return schema.Example
+	}
+	return mg.renderer.RenderSchema(schema)
+}
+
+// partContent renders the value of a part, objects and arrays are rendered as JSON.
+func partContent(value any) ([]byte, bool) {
+	if n, ok := value.(*yaml.Node); ok {
+		value = nil
+		_ = n.Decode(&value)
+	}
+	if value == nil {
+		return nil, false
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		data, _ := json.Marshal(value)
+		return data, true
+	}
+	return []byte(fmt.Sprint(value)), false
+}
+
+// placeholderContentType returns the first content type that isn't a range, or `application/octet-stream`.
+func placeholderContentType(contentTypes []string) string {
+	for _, ct := range contentTypes {
+		if !strings.Contains(ct, "*") {
+			return ct
+		}
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func createMultipartMediaType(schema string) *v3.MediaType {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(schema), &root)
+	config := index.CreateOpenAPIIndexConfig()
+	config.SpecInfo = &datamodel.SpecInfo{VersionNumeric: 3.1}
+	idx := index.NewSpecIndexWithConfig(&root, config)
+	var lowProxy lowbase.SchemaProxy
+	_ = lowProxy.Build(context.Background(), &root, root.Content[0], idx)
+	lowRef := low.NodeReference[*lowbase.SchemaProxy]{
+		Value: &lowProxy,
+	}
+	return &v3.MediaType{Schema: base.NewSchemaProxy(&lowRef)}
+}
+
+type generatedPart struct {
+	name, filename, contentType, content string
+}
+
+func readMultipart(t *testing.T, body []byte, contentType string) []generatedPart {
+	mt, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mt)
+	r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	var parts []generatedPart
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		require.NoError(t, err)
+		content, _ := io.ReadAll(p)
+		parts = append(parts, generatedPart{
+			name:        p.FormName(),
+			filename:    p.FileName(),
+			contentType: p.Header.Get("Content-Type"),
+			content:     string(content),
+		})
+	}
+}
+
+func TestMockGenerator_GenerateMultipartRequest(t *testing.T) {
+	mt := createMultipartMediaType(`type: object
+properties:
+  title:
+    type: string
+    example: holiday
+  tags:
+    type: array
+    examples:
+      - [sea, sun]
+  avatar:
+    type: string
+    format: binary
+  scans:
+    type: array
+    items:
+      type: string
+      contentMediaType: application/pdf`)
+	mt.Encoding = orderedmap.New[string, *v3.Encoding]()
+	mt.Encoding.Set("avatar", &v3.Encoding{ContentType: "image/*, image/png"})
+
+	mg := NewMockGenerator(JSON)
+	body, contentType, err := mg.GenerateMultipartRequest(mt, "boundary")
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/form-data; boundary=boundary", contentType)
+
+	parts := readMultipart(t, body, contentType)
+	require.Len(t, parts, 5)
+	assert.Equal(t, generatedPart{name: "title", content: "holiday"}, parts[0])
+	assert.Equal(t, generatedPart{name: "tags", contentType: "application/json", content: `["sea","sun"]`}, parts[1])
+	assert.Equal(t, generatedPart{name: "avatar", filename: "avatar", contentType: "image/png",
+		content: PlaceholderFileContent}, parts[2])
+	// an array of files is two parts of the same name.
+	for _, p := range parts[3:] {
+		assert.Equal(t, generatedPart{name: "scans", filename: "scans", contentType: "application/pdf",
+			content: PlaceholderFileContent}, p)
+	}
+}
+
+func TestMockGenerator_GenerateMultipartRequest_RenderedSchema(t *testing.T) {
+	mt := createMultipartMediaType(`type: object
+properties:
+  meta:
+    type: object
+    properties:
+      size:
+        type: integer
+        enum: [1]
+  file: {}`)
+	mt.Encoding = orderedmap.New[string, *v3.Encoding]()
+	mt.Encoding.Set("meta", &v3.Encoding{ContentType: "application/vnd.meta+json"})
+	mt.Encoding.Set("file", &v3.Encoding{ContentType: "text/plain"})
+
+	mg := NewMockGenerator(JSON)
+	body, contentType, err := mg.GenerateMultipartRequest(mt, "")
+	require.NoError(t, err)
+
+	parts := readMultipart(t, body, contentType)
+	require.Len(t, parts, 2)
+	assert.Equal(t, generatedPart{name: "meta", contentType: "application/vnd.meta+json", content: `{"size":1}`},
+		parts[0])
+	// not a file, the schema is empty.
+	assert.Equal(t, "file", parts[1].name)
+	assert.Empty(t, parts[1].filename)
+	assert.Equal(t, "text/plain", parts[1].contentType)
+}
+
+func TestMockGenerator_GenerateMultipartRequest_Errors(t *testing.T) {
+	mg := NewMockGenerator(JSON)
+
+	_, _, err := mg.GenerateMultipartRequest(nil, "")
+	assert.EqualError(t, err, "unable to generate multipart request, there is no schema")
+
+	_, _, err = mg.GenerateMultipartRequest(createMultipartMediaType(`type: string`), "")
+	assert.EqualError(t, err, "unable to generate multipart request, the schema has no properties")
+
+	_, _, err = mg.GenerateMultipartRequest(createMultipartMediaType(`properties: {a: {}}`), "not a boundary!")
+	assert.Error(t, err)
+}
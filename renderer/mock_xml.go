@@ -0,0 +1,201 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+)
+
+// xmlRootName is the name of the root element of an XML mock, when its schema has no name and is not a reference.
+const xmlRootName = "root"
+
+// renderMockXML renders a value as an `application/xml` payload, following the XML objects of the schema (and the
+// schemas of its properties and items) it was rendered from:
+//   - elements are named after their property, unless the XML object has a name (and a prefix, if there is one).
+//   - properties with `attribute: true` are rendered as attributes of their parent.
+//   - a namespace is declared on the element it belongs to.
+//   - array items are repeated elements, unless the array is `wrapped`, in which case they are wrapped in an element
+//     named after the array.
+//
+// The root element is named after the schema, or its component name if it's a reference, or `root` if it's neither.
+func (mg *MockGenerator) renderMockXML(v any, schema *highbase.Schema) []byte {
+	if y, ok := v.(*yaml.Node); ok {
+		v = nil
+		_ = y.Decode(&v)
+	}
+	var buf bytes.Buffer
+	enc := xml.NewEncoder(&buf)
+	if mg.pretty {
+		enc.Indent("", "  ")
+	}
+	name := xmlRootName
+	if schema != nil && schema.ParentProxy != nil && schema.ParentProxy.IsReference() {
+		ref := schema.ParentProxy.GetReference()
+		name = ref[strings.LastIndex(ref, "/")+1:]
+	}
+	x := &xmlWriter{enc: enc}
+	x.element(name, v, schema)
+	_ = enc.Flush()
+	if x.err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// xmlWriter writes a value as XML elements, holding on to the first error encountered.
+type xmlWriter struct {
+	enc *xml.Encoder
+	err error
+}
+
+func (x *xmlWriter) token(t xml.Token) {
+	if x.err == nil {
+		x.err = x.enc.EncodeToken(t)
+	}
+}
+
+// element writes a value as an element, name is used if the schema has no XML name.
+func (x *xmlWriter) element(name string, v any, schema *highbase.Schema) {
+	start := xml.StartElement{Name: xml.Name{Local: xmlName(name, schema)}}
+	if ns := xmlNamespace(schema); ns != nil {
+		start.Attr = append(start.Attr, *ns)
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map:
+		values := xmlMapValues(v)
+		var children []string
+		for _, key := range xmlKeys(values, schema) {
+			prop := xmlProperty(schema, key)
+			if prop != nil && prop.XML != nil && prop.XML.Attribute {
+				start.Attr = append(start.Attr, xml.Attr{
+					Name: xml.Name{Local: xmlName(key, prop)}, Value: xmlText(values[key]),
+				})
+				continue
+			}
+			children = append(children, key)
+		}
+		x.token(start)
+		for _, key := range children {
+			x.value(key, values[key], xmlProperty(schema, key))
+		}
+	case reflect.Slice, reflect.Array:
+		// an array on its own (rather than as a property) is always wrapped by its element.
+		x.token(start)
+		x.items(name, v, schema)
+	default:
+		x.token(start)
+		if v != nil {
+			x.token(xml.CharData(xmlText(v)))
+		}
+	}
+	x.token(start.End())
+}
+
+// value writes the value of a property, arrays are repeated (or wrapped) elements, everything else is an element.
+func (x *xmlWriter) value(name string, v any, schema *highbase.Schema) {
+	kind := reflect.ValueOf(v).Kind()
+	if kind != reflect.Slice && kind != reflect.Array {
+		x.element(name, v, schema)
+		return
+	}
+	if schema != nil && schema.XML != nil && schema.XML.Wrapped {
+		x.element(name, v, schema)
+		return
+	}
+	x.items(name, v, schema)
+}
+
+// items writes each item of an array as an element, named after the items (or the property, if they have no name).
+func (x *xmlWriter) items(name string, v any, schema *highbase.Schema) {
+	var items *highbase.Schema
+	if schema != nil && schema.Items != nil && schema.Items.IsA() && schema.Items.A != nil {
+		items = schema.Items.A.Schema()
+	}
+	rv := reflect.ValueOf(v)
+	for i := 0; i < rv.Len(); i++ {
+		x.element(name, rv.Index(i).Interface(), items)
+	}
+}
+
+// xmlName returns the name of the element (or attribute) of a schema, with its prefix.
+func xmlName(name string, schema *highbase.Schema) string {
+	if schema == nil || schema.XML == nil {
+		return name
+	}
+	if schema.XML.Name != "" {
+		name = schema.XML.Name
+	}
+	if schema.XML.Prefix != "" {
+		name = schema.XML.Prefix + ":" + name
+	}
+	return name
+}
+
+// xmlNamespace returns the declaration of the namespace of a schema, or nil if it has none.
+func xmlNamespace(schema *highbase.Schema) *xml.Attr {
+	if schema == nil || schema.XML == nil || schema.XML.Namespace == "" || schema.XML.Attribute {
+		return nil
+	}
+	attr := "xmlns"
+	if schema.XML.Prefix != "" {
+		attr += ":" + schema.XML.Prefix
+	}
+	return &xml.Attr{Name: xml.Name{Local: attr}, Value: schema.XML.Namespace}
+}
+
+// xmlProperty returns the schema of a property, or nil if there isn't one.
+func xmlProperty(schema *highbase.Schema, name string) *highbase.Schema {
+	if schema == nil {
+		return nil
+	}
+	if sp := schema.Properties.GetOrZero(name); sp != nil {
+		return sp.Schema()
+	}
+	return nil
+}
+
+// xmlKeys returns the keys of a map in the order of the properties of its schema, followed by any others sorted.
+func xmlKeys(values map[string]any, schema *highbase.Schema) []string {
+	var keys, others []string
+	if schema != nil {
+		for name := range schema.Properties.KeysFromOldest() {
+			if _, ok := values[name]; ok {
+				keys = append(keys, name)
+			}
+		}
+	}
+	for name := range values {
+		if !slices.Contains(keys, name) {
+			others = append(others, name)
+		}
+	}
+	slices.Sort(others)
+	return append(keys, others...)
+}
+
+// xmlMapValues returns the values of a map keyed by string.
+func xmlMapValues(v any) map[string]any {
+	values := make(map[string]any)
+	rv := reflect.ValueOf(v)
+	for _, k := range rv.MapKeys() {
+		values[fmt.Sprint(k.Interface())] = rv.MapIndex(k).Interface()
+	}
+	return values
+}
+
+func xmlText(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
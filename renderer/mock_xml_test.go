@@ -0,0 +1,106 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package renderer
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var xmlMockSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: pets
+          content:
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Pet'
+              example:
+                id: 1
+                name: rex
+                tags: [good, boy]
+                photos: [a.png, b.png]
+                owner:
+                  name: "dave & co"
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      xml:
+        namespace: https://pb33f.io/pets
+        prefix: pet
+      properties:
+        id:
+          type: integer
+          xml:
+            attribute: true
+        name:
+          type: string
+          xml:
+            name: petName
+        tags:
+          type: array
+          items:
+            type: string
+            xml:
+              name: tag
+        photos:
+          type: array
+          xml:
+            name: photoUrls
+            wrapped: true
+          items:
+            type: string
+            xml:
+              name: url
+        owner:
+          type: object
+          properties:
+            name:
+              type: string`
+
+func TestMockGenerator_GenerateMock_XML(t *testing.T) {
+	doc, err := libopenapi.NewDocument([]byte(xmlMockSpec))
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+
+	mg := NewMockGenerator(XML)
+	mediaType := model.Model.Paths.PathItems.GetOrZero("/pets").Get.Responses.Codes.GetOrZero("200").
+		Content.GetOrZero("application/xml")
+	mock, err := mg.GenerateMock(mediaType, "")
+	require.NoError(t, err)
+	assert.Equal(t, `<pet:Pet xmlns:pet="https://pb33f.io/pets" id="1">`+
+		`<petName>rex</petName><tag>good</tag><tag>boy</tag>`+
+		`<photoUrls><url>a.png</url><url>b.png</url></photoUrls>`+
+		`<owner><name>dave &amp; co</name></owner></pet:Pet>`, string(mock))
+
+	// rendered from the schema, with the required properties only. the schema isn't a reference, and has no name.
+	mg.SetPretty()
+	mock, err = mg.GenerateMock(model.Model.Components.Schemas.GetOrZero("Pet").Schema(), "")
+	require.NoError(t, err)
+	assert.Regexp(t, `^<pet:root xmlns:pet="https://pb33f.io/pets" id="\d+">\n  <petName>\w+</petName>\n</pet:root>$`,
+		string(mock))
+}
+
+func TestMockGenerator_GenerateMock_XMLArray(t *testing.T) {
+	fake := createFakeMock(`type: array
+items:
+  type: string`, nil, []any{"one", "two"})
+	mock, err := NewMockGenerator(XML).GenerateMock(fake, "")
+	require.NoError(t, err)
+	assert.Equal(t, `<root><root>one</root><root>two</root></root>`, string(mock))
+
+	fake = createFakeMock(simpleFakeMockSchema, nil, "magic-herbs")
+	mock, err = NewMockGenerator(XML).GenerateMock(fake, "")
+	require.NoError(t, err)
+	assert.Equal(t, `<root>magic-herbs</root>`, string(mock))
+}
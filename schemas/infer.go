@@ -0,0 +1,297 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package schemas infers schemas from example payloads. Every sample inferred adds to the schema: the types of the
+// values sampled, the properties of objects (required when every object sampled has them), the items of arrays,
+// the formats of strings and, optionally, the values of strings sampled often enough to be an enum.
+//
+//	schema, err := schemas.Infer([]byte(`{"id": 1, "name": "fido"}`), schemas.InferOptions{})
+package schemas
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoSamples is returned when a schema is inferred from a payload without any samples.
+var ErrNoSamples = errors.New("no samples")
+
+// InferOptions configures how schemas are inferred.
+type InferOptions struct {
+	// SkipFormats turns off the detection of the formats of strings (uuid, date-time, date, email, uri, ipv4 and
+	// ipv6). A format is detected when every string sampled has it.
+	SkipFormats bool
+
+	// EnumThreshold is the maximum number of distinct strings sampled for the strings to be an enum, zero turns off
+	// the detection of enums.
+	EnumThreshold int
+
+	// EnumMinSamples is the minimum number of strings sampled before an enum is detected, so that a value sampled
+	// only once isn't an enum.
+	EnumMinSamples int
+}
+
+// Infer infers a schema from a JSON or YAML payload. Every document of a YAML payload is a sample. Returns
+// ErrNoSamples if the payload is empty, or an error if the payload can't be parsed.
+func Infer(payload []byte, options InferOptions) (*base.Schema, error) {
+	inferrer := NewInferrer(options)
+	if err := inferrer.AddPayload(payload); err != nil {
+		return nil, err
+	}
+	if inferrer.Samples() == 0 {
+		return nil, fmt.Errorf("unable to infer schema: %w", ErrNoSamples)
+	}
+	return inferrer.Schema(), nil
+}
+
+// Inferrer infers a schema from samples, added one at a time. An Inferrer is not safe for concurrent use.
+type Inferrer struct {
+	options InferOptions
+	root    *shape
+	samples int
+}
+
+// NewInferrer creates a new Inferrer, without any samples.
+func NewInferrer(options InferOptions) *Inferrer {
+	return &Inferrer{options: options, root: new(shape)}
+}
+
+// AddPayload adds every document of a JSON or YAML payload as a sample. Returns an error, and adds no samples, if
+// the payload can't be parsed.
+func (i *Inferrer) AddPayload(payload []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(payload))
+	var documents []*yaml.Node
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("unable to parse payload: %w", err)
+		}
+		if len(document.Content) > 0 {
+			documents = append(documents, document.Content[0])
+		}
+	}
+	for _, d := range documents {
+		i.AddNode(d)
+	}
+	return nil
+}
+
+// AddNode adds a value (a node of a parsed JSON or YAML payload) as a sample.
+func (i *Inferrer) AddNode(node *yaml.Node) {
+	i.samples++
+	i.root.addNode(node, &i.options)
+}
+
+// AddScalar adds an untyped value as a sample, such as the value of a query parameter, the value is an integer, a
+// number, a boolean or a string.
+func (i *Inferrer) AddScalar(value string) {
+	i.samples++
+	switch {
+	case isInteger(value):
+		i.root.addType("integer", value, &i.options)
+	case isNumber(value):
+		i.root.addType("number", value, &i.options)
+	case value == "true" || value == "false":
+		i.root.addType("boolean", value, &i.options)
+	default:
+		i.root.addType("string", value, &i.options)
+	}
+}
+
+// Samples returns the number of samples added.
+func (i *Inferrer) Samples() int {
+	return i.samples
+}
+
+// Schema returns the schema inferred from every sample, an empty schema if there aren't any. Properties in every
+// object sampled are required, and integers sampled along with numbers are numbers.
+func (i *Inferrer) Schema() *base.Schema {
+	return i.root.schema(&i.options)
+}
+
+// shape is the schema inferred from every sample of a value: the types of the samples, the properties of the
+// objects and the items of the arrays.
+type shape struct {
+	types      map[string]bool
+	formats    map[string]int // the number of strings of each format.
+	strings    int
+	values     []string // the distinct strings, until there are too many to be an enum.
+	objects    int
+	properties *orderedmap.Map[string, *shape]
+	seen       map[string]int // the number of objects each property is in.
+	items      *shape
+}
+
+// shapeTypes are the types of a shape, in the order they're rendered.
+var shapeTypes = []string{"object", "array", "string", "integer", "number", "boolean", "null"}
+
+func (s *shape) addNode(node *yaml.Node, options *InferOptions) {
+	node = utils.NodeAlias(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		s.addType("object", "", options)
+		s.objects++
+		if s.properties == nil {
+			s.properties = orderedmap.New[string, *shape]()
+			s.seen = make(map[string]int)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			p := s.properties.GetOrZero(name)
+			if p == nil {
+				p = new(shape)
+				s.properties.Set(name, p)
+			}
+			s.seen[name]++
+			p.addNode(node.Content[i+1], options)
+		}
+	case yaml.SequenceNode:
+		s.addType("array", "", options)
+		if s.items == nil {
+			s.items = new(shape)
+		}
+		for _, item := range node.Content {
+			s.items.addNode(item, options)
+		}
+	case yaml.ScalarNode:
+		switch node.Tag {
+		case "!!int":
+			s.addType("integer", node.Value, options)
+		case "!!float":
+			s.addType("number", node.Value, options)
+		case "!!bool":
+			s.addType("boolean", node.Value, options)
+		case "!!null":
+			s.addType("null", node.Value, options)
+		default:
+			s.addType("string", node.Value, options)
+		}
+	}
+}
+
+func (s *shape) addType(t, value string, options *InferOptions) {
+	if s.types == nil {
+		s.types = make(map[string]bool)
+	}
+	s.types[t] = true
+	if t != "string" {
+		return
+	}
+	s.strings++
+	if options.EnumThreshold > 0 && len(s.values) <= options.EnumThreshold && !slices.Contains(s.values, value) {
+		s.values = append(s.values, value)
+	}
+	if options.SkipFormats {
+		return
+	}
+	if f := stringFormat(value); f != "" {
+		if s.formats == nil {
+			s.formats = make(map[string]int)
+		}
+		s.formats[f]++
+	}
+}
+
+func (s *shape) schema(options *InferOptions) *base.Schema {
+	schema := &base.Schema{}
+	for _, t := range shapeTypes {
+		if s.types[t] && !(t == "integer" && s.types["number"]) {
+			schema.Type = append(schema.Type, t)
+		}
+	}
+	for f, n := range s.formats {
+		if n == s.strings {
+			schema.Format = f
+		}
+	}
+	// strings of a format (dates, identifiers and so on) are values, rather than an enum.
+	if len(schema.Type) == 1 && schema.Type[0] == "string" && schema.Format == "" && options.EnumThreshold > 0 &&
+		len(s.values) <= options.EnumThreshold && s.strings >= options.EnumMinSamples {
+		for _, v := range s.values {
+			schema.Enum = append(schema.Enum, utils.CreateStringNode(v))
+		}
+	}
+	if orderedmap.Len(s.properties) > 0 {
+		schema.Properties = orderedmap.New[string, *base.SchemaProxy]()
+		for name, p := range s.properties.FromOldest() {
+			schema.Properties.Set(name, base.CreateSchemaProxy(p.schema(options)))
+			if s.seen[name] == s.objects {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+	if s.items != nil && len(s.items.types) > 0 {
+		schema.Items = &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(s.items.schema(options))}
+	}
+	return schema
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ipv4Pattern = regexp.MustCompile(`^\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}$`)
+)
+
+// stringFormat returns the format of a string, or an empty string if it has none.
+func stringFormat(value string) string {
+	switch {
+	case uuidPattern.MatchString(value):
+		return "uuid"
+	case isTime(time.RFC3339, value):
+		return "date-time"
+	case isTime(time.DateOnly, value):
+		return "date"
+	case ipv4Pattern.MatchString(value) && net.ParseIP(value) != nil:
+		return "ipv4"
+	case strings.Contains(value, ":") && net.ParseIP(value) != nil:
+		return "ipv6"
+	case isEmail(value):
+		return "email"
+	case isURI(value):
+		return "uri"
+	}
+	return ""
+}
+
+func isTime(layout, value string) bool {
+	_, err := time.Parse(layout, value)
+	return err == nil
+}
+
+func isEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Address == value
+}
+
+func isURI(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+func isInteger(value string) bool {
+	_, err := strconv.ParseInt(value, 10, 64)
+	return err == nil
+}
+
+func isNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil && strings.ContainsAny(value, "0123456789")
+}
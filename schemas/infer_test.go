@@ -0,0 +1,110 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package schemas
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfer(t *testing.T) {
+	schema, err := Infer([]byte(`{"id": 1, "price": 10, "tags": ["a"], "born": "2024-01-02", "owner": null}`),
+		InferOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"object"}, schema.Type)
+	assert.Equal(t, []string{"id", "price", "tags", "born", "owner"}, schema.Required)
+	assert.Equal(t, []string{"integer"}, schema.Properties.GetOrZero("id").Schema().Type)
+	assert.Equal(t, []string{"string"}, schema.Properties.GetOrZero("tags").Schema().Items.A.Schema().Type)
+	assert.Equal(t, "date", schema.Properties.GetOrZero("born").Schema().Format)
+	assert.Equal(t, []string{"null"}, schema.Properties.GetOrZero("owner").Schema().Type)
+
+	rendered, err := base.CreateSchemaProxy(schema).Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "born:\n        type: string\n        format: date\n")
+}
+
+func TestInfer_Samples(t *testing.T) {
+	// every document of a YAML payload is a sample.
+	schema, err := Infer([]byte(`id: 1
+price: 10
+---
+id: 2
+price: 9.99
+tags: []
+`), InferOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "price"}, schema.Required)
+	// integers sampled along with numbers are numbers.
+	assert.Equal(t, []string{"number"}, schema.Properties.GetOrZero("price").Schema().Type)
+	assert.Nil(t, schema.Properties.GetOrZero("tags").Schema().Items)
+
+	_, err = Infer([]byte(``), InferOptions{})
+	assert.ErrorIs(t, err, ErrNoSamples)
+	_, err = Infer([]byte(`{"id": `), InferOptions{})
+	assert.ErrorContains(t, err, "unable to parse payload")
+}
+
+func TestInferrer_Formats(t *testing.T) {
+	for value, format := range map[string]string{
+		"0cbd6a3a-1b7c-4d5e-9f6e-8a1b2c3d4e5f": "uuid",
+		"2024-01-01T10:00:00Z":                 "date-time",
+		"2024-01-01":                           "date",
+		"10.0.0.1":                             "ipv4",
+		"::1":                                  "ipv6",
+		"pets@example.com":                     "email",
+		"https://example.com/pets":             "uri",
+		"fido":                                 "",
+	} {
+		i := NewInferrer(InferOptions{})
+		i.AddScalar(value)
+		assert.Equal(t, format, i.Schema().Format, value)
+
+		i = NewInferrer(InferOptions{SkipFormats: true})
+		i.AddScalar(value)
+		assert.Empty(t, i.Schema().Format, value)
+	}
+
+	// strings of different formats have no format.
+	i := NewInferrer(InferOptions{})
+	i.AddScalar("2024-01-01")
+	i.AddScalar("fido")
+	assert.Empty(t, i.Schema().Format)
+}
+
+func TestInferrer_Enums(t *testing.T) {
+	options := InferOptions{EnumThreshold: 2, EnumMinSamples: 3}
+	i := NewInferrer(options)
+	require.NoError(t, i.AddPayload([]byte(`[{"status": "available"}, {"status": "sold"}]`)))
+	assert.Empty(t, i.Schema().Items.A.Schema().Properties.GetOrZero("status").Schema().Enum)
+
+	require.NoError(t, i.AddPayload([]byte(`[{"status": "sold"}]`)))
+	assert.Equal(t, 2, i.Samples())
+	enum := i.Schema().Items.A.Schema().Properties.GetOrZero("status").Schema().Enum
+	require.Len(t, enum, 2)
+	assert.Equal(t, "available", enum[0].Value)
+	assert.Equal(t, "sold", enum[1].Value)
+
+	// too many values aren't an enum.
+	require.NoError(t, i.AddPayload([]byte(`[{"status": "pending"}]`)))
+	assert.Empty(t, i.Schema().Items.A.Schema().Properties.GetOrZero("status").Schema().Enum)
+
+	// nor are strings of a format.
+	i = NewInferrer(InferOptions{EnumThreshold: 2})
+	i.AddScalar("2024-01-01")
+	assert.Empty(t, i.Schema().Enum)
+}
+
+func TestInferrer_AddScalar(t *testing.T) {
+	for value, expected := range map[string]string{
+		"12": "integer", "-1.5": "number", "true": "boolean", "dog": "string", "NaN": "string",
+	} {
+		i := NewInferrer(InferOptions{})
+		i.AddScalar(value)
+		assert.Equal(t, []string{expected}, i.Schema().Type, value)
+	}
+	assert.Empty(t, NewInferrer(InferOptions{}).Schema().Type)
+}
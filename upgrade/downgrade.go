@@ -0,0 +1,330 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package upgrade
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotOpenAPI31 is returned when the document to downgrade is not an OpenAPI 3.1 document.
+var ErrNotOpenAPI31 = errors.New("document is not an OpenAPI 3.1 document")
+
+// ErrUnsupportedConversion is returned by Convert when a document can't be converted to the version asked for.
+var ErrUnsupportedConversion = errors.New("unsupported conversion, only OpenAPI 3.0 and 3.1 documents can be converted")
+
+// unsupportedKeywords are the schema keywords of OpenAPI 3.1 (JSON Schema 2020-12) that OpenAPI 3.0 has no
+// equivalent for.
+var unsupportedKeywords = []string{
+	"$schema", "$id", "$anchor", "$dynamicAnchor", "$dynamicRef", "$defs", "$comment", "if", "then", "else",
+	"dependentSchemas", "dependentRequired", "prefixItems", "contains", "minContains", "maxContains",
+	"unevaluatedItems", "unevaluatedProperties", "propertyNames", "patternProperties",
+}
+
+// Downgrade converts an OpenAPI 3.1 document (the root node of a parsed specification) to OpenAPI 3.0, in place.
+// Everything that can be expressed in OpenAPI 3.0 is rewritten (type arrays become a type and nullable, examples
+// become an example, numeric exclusive bounds become boolean ones, const becomes an enum), and everything that
+// can't (such as webhooks, or JSON Schema keywords OpenAPI 3.0 doesn't have) is removed. Every hint of the Report
+// is rewritten, those that lost something are Lossy, and listed by the Losses of the Report.
+func Downgrade(root *yaml.Node) (*Report, error) {
+	return downgrade(root, DowngradeTargetVersion)
+}
+
+// Convert converts an OpenAPI 3.0 or 3.1 document (the root node of a parsed specification) to version, in place.
+// Documents are upgraded from 3.0 to 3.1 using Upgrade, and downgraded from 3.1 to 3.0 using Downgrade (version
+// is used as the openapi version of the result, rather than TargetVersion or DowngradeTargetVersion). A document
+// that's already of the same minor version as version only has its version changed.
+func Convert(root *yaml.Node, version string) (*Report, error) {
+	root, k, v := openAPIVersion(root)
+	if v == nil {
+		return nil, ErrUnsupportedConversion
+	}
+	from, to := minorVersion(v.Value), minorVersion(version)
+	switch {
+	case from == "3.0" && to == "3.1":
+		return run(root, true, version)
+	case from == "3.1" && to == "3.0":
+		return downgrade(root, version)
+	case from == to && (to == "3.0" || to == "3.1"):
+		a := &advisor{report: &Report{From: v.Value, To: version}, rewrite: true}
+		if v.Value != version {
+			a.version(k, v)
+		}
+		return a.report, nil
+	}
+	return nil, fmt.Errorf("%w: %s to %s", ErrUnsupportedConversion, v.Value, version)
+}
+
+// minorVersion returns the major and minor version of a version, e.g. 3.1 for 3.1.0.
+func minorVersion(version string) string {
+	if parts := strings.SplitN(version, ".", 3); len(parts) >= 2 {
+		return parts[0] + "." + parts[1]
+	}
+	return version
+}
+
+func downgrade(root *yaml.Node, target string) (*Report, error) {
+	root, k, v := openAPIVersion(root)
+	if v == nil || !strings.HasPrefix(v.Value, "3.1") {
+		return nil, ErrNotOpenAPI31
+	}
+	a := &advisor{
+		report:    &Report{From: v.Value, To: target},
+		rewrite:   true,
+		downgrade: true,
+		seen:      make(map[*yaml.Node]struct{}),
+	}
+	a.version(k, v)
+	a.downgradeDocument(root)
+	a.walk(root, nil)
+	return a.report, nil
+}
+
+// lossy records a change that loses something, it's always made.
+func (a *advisor) lossy(kind HintKind, key *yaml.Node, path []string, msg string) *Hint {
+	h := a.hint(kind, key, path, msg, true)
+	h.Rewritten, h.Lossy = true, true
+	return h
+}
+
+// rewritten records a change that loses nothing, it's always made.
+func (a *advisor) rewritten(kind HintKind, key *yaml.Node, path []string, msg string) *Hint {
+	h := a.hint(kind, key, path, msg, true)
+	h.Rewritten = true
+	return h
+}
+
+// downgradeDocument removes everything outside of schemas that OpenAPI 3.0 doesn't have.
+func (a *advisor) downgradeDocument(root *yaml.Node) {
+	for _, key := range []string{"webhooks", "jsonSchemaDialect"} {
+		if k, _ := keyValue(root, key); k != nil {
+			a.lossy(HintUnsupported, k, []string{key}, fmt.Sprintf("%s is not supported by OpenAPI 3.0, "+
+				"it has been removed", key))
+			removeKey(root, key)
+		}
+	}
+	if _, info := keyValue(root, "info"); info != nil && utils.IsNodeMap(info) {
+		if k, _ := keyValue(info, "summary"); k != nil {
+			a.lossy(HintUnsupported, k, []string{"info", "summary"}, "the info summary is not supported by "+
+				"OpenAPI 3.0, it has been removed")
+			removeKey(info, "summary")
+		}
+		if _, license := keyValue(info, "license"); license != nil && utils.IsNodeMap(license) {
+			if k, _ := keyValue(license, "identifier"); k != nil {
+				a.lossy(HintUnsupported, k, []string{"info", "license", "identifier"}, "the license identifier "+
+					"is not supported by OpenAPI 3.0, it has been removed")
+				removeKey(license, "identifier")
+			}
+		}
+	}
+
+	// path items are only components in 3.1, so references to them are replaced by the path items themselves.
+	_, components := keyValue(root, "components")
+	if components != nil && utils.IsNodeMap(components) {
+		if k, pathItems := keyValue(components, "pathItems"); k != nil {
+			used := a.inlinePathItems(root, pathItems)
+			path := []string{"components", "pathItems"}
+			if used {
+				a.rewritten(HintUnsupported, k, path, "pathItems components are not supported by OpenAPI 3.0, "+
+					"they have been removed, and references to them replaced by the path items")
+			} else {
+				a.lossy(HintUnsupported, k, path, "pathItems components are not supported by OpenAPI 3.0, "+
+					"they have been removed, not all of them were used by the paths")
+			}
+			removeKey(components, "pathItems")
+		}
+	}
+
+	if k, _ := keyValue(root, "paths"); k == nil {
+		a.rewritten(HintPaths, nil, []string{"paths"}, "paths is required by OpenAPI 3.0, an empty paths has "+
+			"been added")
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "paths"},
+			&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+	}
+}
+
+// inlinePathItems replaces every path of the document that refers to a pathItems component with a copy of it,
+// and returns true if every one of them was used.
+func (a *advisor) inlinePathItems(root, pathItems *yaml.Node) bool {
+	_, paths := keyValue(root, "paths")
+	if paths == nil || !utils.IsNodeMap(paths) || !utils.IsNodeMap(pathItems) {
+		return pathItems == nil || len(pathItems.Content) == 0
+	}
+	used := make(map[string]struct{})
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		_, ref := keyValue(paths.Content[i+1], "$ref")
+		if ref == nil || !strings.HasPrefix(ref.Value, "#/components/pathItems/") {
+			continue
+		}
+		name := strings.TrimPrefix(ref.Value, "#/components/pathItems/")
+		if _, item := keyValue(pathItems, name); item != nil {
+			paths.Content[i+1] = utils.CopyNode(item)
+			used[name] = struct{}{}
+		}
+	}
+	return len(used) == len(pathItems.Content)/2
+}
+
+// downgradeSchema rewrites a single schema (not its sub-schemas) for OpenAPI 3.0.
+func (a *advisor) downgradeSchema(node *yaml.Node, path []string) {
+	a.types(node, path)
+	a.constant(node, path)
+	a.examples(node, path)
+	a.exclusiveBound(node, path, "exclusiveMinimum", "minimum", HintExclusiveMinimum, 1)
+	a.exclusiveBound(node, path, "exclusiveMaximum", "maximum", HintExclusiveMaximum, -1)
+	a.content(node, path, "contentEncoding", "base64", "byte")
+	a.content(node, path, "contentMediaType", "application/octet-stream", "binary")
+	for _, keyword := range unsupportedKeywords {
+		if k, _ := keyValue(node, keyword); k != nil {
+			a.lossy(HintUnsupported, k, with(path, keyword), fmt.Sprintf("%s is not supported by OpenAPI 3.0, "+
+				"it has been removed", keyword))
+			removeKey(node, keyword)
+		}
+	}
+}
+
+func (a *advisor) types(node *yaml.Node, path []string) {
+	k, typ := keyValue(node, "type")
+	if typ == nil || (typ.Kind == yaml.ScalarNode && typ.Value != "null") {
+		return
+	}
+	p := with(path, "type")
+	var types []string
+	nullable := false
+	if typ.Kind == yaml.ScalarNode {
+		nullable = true
+	}
+	for _, t := range typ.Content {
+		if t.Value == "null" {
+			nullable = true
+		} else {
+			types = append(types, t.Value)
+		}
+	}
+	switch {
+	case len(types) == 0:
+		a.lossy(HintType, k, p, "a schema that only allows null can't be expressed in OpenAPI 3.0, the type has "+
+			"been removed, and the schema is nullable")
+		removeKey(node, "type")
+	case len(types) == 1:
+		a.rewritten(HintType, k, p, fmt.Sprintf("type arrays are not supported by OpenAPI 3.0, the type is '%s'",
+			types[0]))
+	default:
+		a.lossy(HintType, k, p, fmt.Sprintf("OpenAPI 3.0 schemas have a single type, the type is '%s', and "+
+			"the types %s have been removed", types[0], strings.Join(types[1:], ", ")))
+	}
+	if len(types) > 0 {
+		*typ = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: types[0], Line: typ.Line, Column: typ.Column}
+	}
+	if nullable {
+		if nk, _ := keyValue(node, "nullable"); nk == nil {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "nullable"},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+		}
+	}
+}
+
+func (a *advisor) constant(node *yaml.Node, path []string) {
+	k, v := keyValue(node, "const")
+	if v == nil {
+		return
+	}
+	p := with(path, "const")
+	if ek, _ := keyValue(node, "enum"); ek != nil {
+		a.lossy(HintConst, k, p, "const is not supported by OpenAPI 3.0, and there is already an enum, "+
+			"so it has been removed")
+		removeKey(node, "const")
+		return
+	}
+	a.rewritten(HintConst, k, p, "const is not supported by OpenAPI 3.0, it has been replaced by an enum of "+
+		"its value")
+	k.Value = "enum"
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i] == k {
+			node.Content[i+1] = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{v}}
+		}
+	}
+}
+
+func (a *advisor) examples(node *yaml.Node, path []string) {
+	k, v := keyValue(node, "examples")
+	if v == nil || !utils.IsNodeArray(v) {
+		return
+	}
+	p := with(path, "examples")
+	if ek, _ := keyValue(node, "example"); ek != nil || len(v.Content) == 0 {
+		a.lossy(HintExample, k, p, "schema examples are not supported by OpenAPI 3.0, and there is already an "+
+			"example, so they have been removed")
+		removeKey(node, "examples")
+		return
+	}
+	if len(v.Content) > 1 {
+		a.lossy(HintExample, k, p, fmt.Sprintf("schema examples are not supported by OpenAPI 3.0, the first "+
+			"is the example, and the other %d have been removed", len(v.Content)-1))
+	} else {
+		a.rewritten(HintExample, k, p, "schema examples are not supported by OpenAPI 3.0, it has been replaced "+
+			"by an example")
+	}
+	k.Value = "example"
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i] == k {
+			node.Content[i+1] = v.Content[0]
+		}
+	}
+}
+
+// exclusiveBound replaces a numeric exclusive bound with a boolean one and its bound, the stricter of the two is
+// kept if there's both (sign is 1 for minimums, where the larger is stricter, and -1 for maximums).
+func (a *advisor) exclusiveBound(node *yaml.Node, path []string, keyword, bound string, kind HintKind, sign float64) {
+	k, v := keyValue(node, keyword)
+	if v == nil || v.Tag == "!!bool" {
+		return
+	}
+	p := with(path, keyword)
+	_, b := keyValue(node, bound)
+	if b != nil {
+		exclusive, eErr := strconv.ParseFloat(v.Value, 64)
+		inclusive, iErr := strconv.ParseFloat(b.Value, 64)
+		if eErr == nil && iErr == nil && exclusive*sign < inclusive*sign {
+			a.rewritten(kind, k, p, fmt.Sprintf("%s is a boolean in OpenAPI 3.0, '%s: %s' is stricter, so it "+
+				"has been removed", keyword, bound, b.Value))
+			removeKey(node, keyword)
+			return
+		}
+		removeKey(node, bound)
+	}
+	a.rewritten(kind, k, p, fmt.Sprintf("%s is a boolean in OpenAPI 3.0, it has been replaced by '%s: %s'",
+		keyword, bound, v.Value))
+	value := *v
+	*v = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true", Line: v.Line, Column: v.Column}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: bound}, &value)
+}
+
+// content replaces contentEncoding or contentMediaType with the format OpenAPI 3.0 uses for value, anything else
+// is removed.
+func (a *advisor) content(node *yaml.Node, path []string, keyword, value, format string) {
+	k, v := keyValue(node, keyword)
+	if v == nil {
+		return
+	}
+	p := with(path, keyword)
+	_, f := keyValue(node, "format")
+	if v.Value != value || f != nil {
+		a.lossy(HintContent, k, p, fmt.Sprintf("%s is not supported by OpenAPI 3.0, it has been removed",
+			keyword))
+		removeKey(node, keyword)
+		return
+	}
+	a.rewritten(HintContent, k, p, fmt.Sprintf("%s is not supported by OpenAPI 3.0, it has been replaced by "+
+		"'format: %s'", keyword, format))
+	k.Value = "format"
+	*v = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: format, Line: v.Line, Column: v.Column}
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package upgrade_test
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var spec31 = `openapi: 3.1.0
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+info:
+  title: downgrade me
+  summary: a summary
+  version: "1"
+  license:
+    name: MIT
+    identifier: MIT
+webhooks:
+  newPet:
+    post:
+      responses:
+        "200":
+          description: ok
+paths:
+  /pets:
+    $ref: '#/components/pathItems/Pets'
+components:
+  pathItems:
+    Pets:
+      get:
+        parameters:
+          - name: limit
+            in: query
+            schema:
+              type: integer
+              minimum: 1
+              exclusiveMinimum: 5
+              exclusiveMaximum: 100
+        responses:
+          "200":
+            description: ok
+            content:
+              application/octet-stream:
+                schema:
+                  type: string
+                  contentMediaType: application/octet-stream
+  schemas:
+    Pet:
+      type: object
+      $comment: pets are great
+      examples:
+        - name: rex
+        - name: fido
+      properties:
+        name:
+          type: [string, "null"]
+        kind:
+          const: dog
+        photo:
+          type: string
+          contentEncoding: base64
+        id:
+          type: [integer, string]
+        nothing:
+          type: "null"`
+
+func TestDowngrade(t *testing.T) {
+	root := parse(t, spec31)
+	report, err := upgrade.Downgrade(root)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", report.From)
+	assert.Equal(t, upgrade.DowngradeTargetVersion, report.To)
+
+	type expected struct {
+		kind    upgrade.HintKind
+		pointer string
+		lossy   bool
+	}
+	var found []expected
+	for _, h := range report.Hints {
+		found = append(found, expected{h.Kind, h.Pointer, h.Lossy})
+		assert.True(t, h.Rewritten)
+		assert.NotEmpty(t, h.Message)
+	}
+	assert.Equal(t, []expected{
+		{upgrade.HintVersion, "/openapi", false},
+		{upgrade.HintUnsupported, "/webhooks", true},
+		{upgrade.HintUnsupported, "/jsonSchemaDialect", true},
+		{upgrade.HintUnsupported, "/info/summary", true},
+		{upgrade.HintUnsupported, "/info/license/identifier", true},
+		{upgrade.HintUnsupported, "/components/pathItems", false},
+		{upgrade.HintExclusiveMinimum, "/paths/~1pets/get/parameters/0/schema/exclusiveMinimum", false},
+		{upgrade.HintExclusiveMaximum, "/paths/~1pets/get/parameters/0/schema/exclusiveMaximum", false},
+		{upgrade.HintContent, "/paths/~1pets/get/responses/200/content/application~1octet-stream/schema/contentMediaType", false},
+		{upgrade.HintExample, "/components/schemas/Pet/examples", true},
+		{upgrade.HintUnsupported, "/components/schemas/Pet/$comment", true},
+		{upgrade.HintType, "/components/schemas/Pet/properties/name/type", false},
+		{upgrade.HintConst, "/components/schemas/Pet/properties/kind/const", false},
+		{upgrade.HintContent, "/components/schemas/Pet/properties/photo/contentEncoding", false},
+		{upgrade.HintType, "/components/schemas/Pet/properties/id/type", true},
+		{upgrade.HintType, "/components/schemas/Pet/properties/nothing/type", true},
+	}, found)
+	assert.Len(t, report.Losses(), 8)
+	assert.Equal(t, 10, report.Hints[1].Line)
+
+	out, err := yaml.Marshal(root)
+	require.NoError(t, err)
+	downgraded := string(out)
+	for _, removed := range []string{"webhooks", "jsonSchemaDialect", "summary", "identifier", "pathItems",
+		"$comment", "contentMediaType", "contentEncoding", "examples", "const", "$ref"} {
+		assert.NotContains(t, downgraded, removed)
+	}
+	assert.Contains(t, downgraded, "openapi: 3.0.3")
+	assert.Contains(t, downgraded, "minimum: 5\n")
+	assert.Contains(t, downgraded, "exclusiveMinimum: true\n")
+	assert.Contains(t, downgraded, "maximum: 100\n")
+	assert.Contains(t, downgraded, "format: binary")
+	assert.Contains(t, downgraded, "format: byte")
+	assert.Contains(t, downgraded, "example:\n                name: rex")
+	assert.Contains(t, downgraded, "name:\n                    type: string\n                    nullable: true")
+	assert.Contains(t, downgraded, "enum:\n                        - dog")
+	assert.Contains(t, downgraded, "id:\n                    type: integer\n")
+	assert.Contains(t, downgraded, "nothing:\n                    nullable: true")
+
+	// the result is a 3.0 document.
+	doc, err := libopenapi.NewDocument(out)
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	assert.Equal(t, "3.0.3", model.Model.Version)
+	name := model.Model.Components.Schemas.GetOrZero("Pet").Schema().Properties.GetOrZero("name").Schema()
+	assert.Equal(t, []string{"string"}, name.Type)
+	assert.True(t, *name.Nullable)
+	assert.NotNil(t, model.Model.Paths.PathItems.GetOrZero("/pets").Get)
+}
+
+func TestDowngrade_Paths(t *testing.T) {
+	root := parse(t, `openapi: 3.1.1
+components:
+  pathItems:
+    Unused:
+      get:
+        description: nobody uses me
+  schemas:
+    Range:
+      type: integer
+      minimum: 10
+      exclusiveMinimum: 5`)
+	report, err := upgrade.Downgrade(root)
+	require.NoError(t, err)
+	require.Len(t, report.Hints, 4)
+	assert.Equal(t, upgrade.HintUnsupported, report.Hints[1].Kind)
+	assert.True(t, report.Hints[1].Lossy)
+	assert.Equal(t, upgrade.HintPaths, report.Hints[2].Kind)
+	assert.Equal(t, upgrade.HintExclusiveMinimum, report.Hints[3].Kind)
+
+	out, err := yaml.Marshal(root)
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.0.3
+components:
+    schemas:
+        Range:
+            type: integer
+            minimum: 10
+paths: {}
+`, string(out))
+}
+
+func TestConvert(t *testing.T) {
+	root := parse(t, spec30)
+	report, err := upgrade.Convert(root, "3.1.1")
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.1", report.To)
+	assert.Len(t, report.Rewritable(), 8)
+
+	report, err = upgrade.Convert(root, "3.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.1", report.From)
+	assert.Equal(t, "3.0.1", report.To)
+
+	// the same minor version only changes the version.
+	report, err = upgrade.Convert(root, "3.0.3")
+	require.NoError(t, err)
+	require.Len(t, report.Hints, 1)
+	assert.Equal(t, upgrade.HintVersion, report.Hints[0].Kind)
+	out, _ := yaml.Marshal(root)
+	assert.Contains(t, string(out), "openapi: 3.0.3")
+
+	_, err = upgrade.Convert(root, "3.2.0")
+	assert.ErrorIs(t, err, upgrade.ErrUnsupportedConversion)
+	assert.EqualError(t, err, "unsupported conversion, only OpenAPI 3.0 and 3.1 documents can be converted: "+
+		"3.0.3 to 3.2.0")
+	_, err = upgrade.Convert(parse(t, `swagger: "2.0"`), "3.1.0")
+	assert.ErrorIs(t, err, upgrade.ErrUnsupportedConversion)
+	_, err = upgrade.Downgrade(parse(t, spec30))
+	assert.ErrorIs(t, err, upgrade.ErrNotOpenAPI31)
+}
@@ -0,0 +1,425 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package upgrade inspects OpenAPI 3.0 documents and reports what needs to change to migrate them to OpenAPI 3.1.
+//
+// Every migration hint carries a JSON Pointer and a position. Where a change can be made without changing the
+// meaning of the document, it can be rewritten automatically using Upgrade, or UpgradeBytes.
+//
+// OpenAPI 3.1 documents can be downgraded to OpenAPI 3.0 as well, using Downgrade. Not everything can be expressed
+// in OpenAPI 3.0, so downgrading is best-effort: what's lost is reported by the Losses of the Report. Convert
+// converts a document in either direction, to a specific version.
+package upgrade
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/json"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// TargetVersion is the version documents are upgraded to.
+const TargetVersion = "3.1.0"
+
+// DowngradeTargetVersion is the version documents are downgraded to.
+const DowngradeTargetVersion = "3.0.3"
+
+// HintKind is the kind of change a Hint is about.
+type HintKind string
+
+const (
+	HintVersion          HintKind = "version"          // the openapi version.
+	HintNullable         HintKind = "nullable"         // nullable is replaced by a 'null' type.
+	HintExclusiveMinimum HintKind = "exclusiveMinimum" // a boolean exclusiveMinimum is replaced by a number.
+	HintExclusiveMaximum HintKind = "exclusiveMaximum" // a boolean exclusiveMaximum is replaced by a number.
+	HintExample          HintKind = "example"          // the schema example is replaced by examples.
+	HintFormat           HintKind = "format"           // the byte and binary formats are replaced.
+	HintType             HintKind = "type"             // an array of types is replaced by a type (and nullable).
+	HintConst            HintKind = "const"            // const is replaced by an enum.
+	HintContent          HintKind = "content"          // contentEncoding and contentMediaType are replaced by a format.
+	HintPaths            HintKind = "paths"            // paths is required.
+	HintUnsupported      HintKind = "unsupported"      // a keyword that doesn't exist in the target version is removed.
+)
+
+// ErrNotOpenAPI30 is returned when the document to upgrade is not an OpenAPI 3.0 document.
+var ErrNotOpenAPI30 = errors.New("document is not an OpenAPI 3.0 document")
+
+// Hint is a single change required to migrate a document to OpenAPI 3.1.
+type Hint struct {
+	Kind       HintKind
+	Pointer    string     // the JSON Pointer of the keyword, e.g. /components/schemas/Pet/nullable
+	Line       int        // the line of the keyword in the original document.
+	Column     int        // the column of the keyword in the original document.
+	Message    string     // what needs to change, and why.
+	Node       *yaml.Node // the key node of the keyword.
+	Rewritable bool       // true if the change can be made automatically.
+	Rewritten  bool       // true if the change was made by Upgrade (or Downgrade).
+	Lossy      bool       // true if the change loses something the target version can't express (Downgrade only).
+}
+
+// Report is the result of analyzing (or upgrading) a document.
+type Report struct {
+	From  string // the version of the document that was analyzed.
+	To    string // the version the document is migrated to.
+	Hints []*Hint
+}
+
+// Rewritable returns every hint that can be (or was) rewritten automatically.
+func (r *Report) Rewritable() []*Hint {
+	var hints []*Hint
+	for _, h := range r.Hints {
+		if h.Rewritable {
+			hints = append(hints, h)
+		}
+	}
+	return hints
+}
+
+// Losses returns every hint that lost something the target version can't express.
+func (r *Report) Losses() []*Hint {
+	var hints []*Hint
+	for _, h := range r.Hints {
+		if h.Lossy {
+			hints = append(hints, h)
+		}
+	}
+	return hints
+}
+
+// Manual returns every hint that has to be migrated by hand.
+func (r *Report) Manual() []*Hint {
+	var hints []*Hint
+	for _, h := range r.Hints {
+		if !h.Rewritable {
+			hints = append(hints, h)
+		}
+	}
+	return hints
+}
+
+// Analyze inspects an OpenAPI 3.0 document (the root node of a parsed specification) and returns a Report of
+// everything that needs to change for OpenAPI 3.1. The document is never changed.
+func Analyze(root *yaml.Node) (*Report, error) {
+	return run(root, false, TargetVersion)
+}
+
+// Upgrade inspects an OpenAPI 3.0 document the same way as Analyze, except every rewritable hint is applied to the
+// document (in place). Hints that can't be rewritten safely are left alone, and must be migrated by hand.
+func Upgrade(root *yaml.Node) (*Report, error) {
+	return run(root, true, TargetVersion)
+}
+
+// UpgradeBytes parses an OpenAPI 3.0 specification, upgrades it using Upgrade and returns the rendered result.
+// JSON specifications are rendered as JSON, and YAML specifications as YAML (using the original indentation).
+func UpgradeBytes(spec []byte) ([]byte, *Report, error) {
+	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(spec, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	report, err := Upgrade(info.RootNode)
+	if err != nil {
+		return nil, report, err
+	}
+	if info.SpecFileType == datamodel.JSONFileType {
+		out, jErr := json.YAMLNodeToJSON(info.RootNode, "  ")
+		return out, report, jErr
+	}
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	indent := info.OriginalIndentation
+	if indent <= 0 {
+		indent = 2
+	}
+	enc.SetIndent(indent)
+	if err = enc.Encode(info.RootNode); err != nil {
+		return nil, report, err
+	}
+	_ = enc.Close()
+	return []byte(sb.String()), report, nil
+}
+
+func run(root *yaml.Node, rewrite bool, target string) (*Report, error) {
+	root, k, v := openAPIVersion(root)
+	if v == nil || !strings.HasPrefix(v.Value, "3.0") {
+		return nil, ErrNotOpenAPI30
+	}
+	a := &advisor{
+		report:  &Report{From: v.Value, To: target},
+		rewrite: rewrite,
+		seen:    make(map[*yaml.Node]struct{}),
+	}
+	a.version(k, v)
+	a.walk(root, nil)
+	return a.report, nil
+}
+
+// openAPIVersion returns the root map of a document, with the key and value nodes of its openapi version.
+func openAPIVersion(root *yaml.Node) (*yaml.Node, *yaml.Node, *yaml.Node) {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root == nil || !utils.IsNodeMap(root) {
+		return nil, nil, nil
+	}
+	k, v := keyValue(root, "openapi")
+	return root, k, v
+}
+
+type advisor struct {
+	report    *Report
+	rewrite   bool
+	downgrade bool
+	seen      map[*yaml.Node]struct{}
+}
+
+// version changes the openapi version of the document to the version of the report.
+func (a *advisor) version(k, v *yaml.Node) {
+	h := a.hint(HintVersion, k, []string{"openapi"},
+		fmt.Sprintf("the openapi version must be '%s'", a.report.To), true)
+	if a.rewrite {
+		v.Value = a.report.To
+		v.Tag = "!!str"
+		h.Rewritten = true
+	}
+}
+
+func (a *advisor) hint(kind HintKind, key *yaml.Node, path []string, msg string, rewritable bool) *Hint {
+	h := &Hint{
+		Kind: kind, Pointer: utils.BuildJSONPointer(path), Message: msg, Node: key, Rewritable: rewritable,
+	}
+	if key != nil {
+		h.Line, h.Column = key.Line, key.Column
+	}
+	a.report.Hints = append(a.report.Hints, h)
+	return h
+}
+
+// walk searches the document for schemas, every schema found is inspected by schema.
+func (a *advisor) walk(node *yaml.Node, path []string) {
+	node = utils.NodeAlias(node)
+	switch {
+	case utils.IsNodeMap(node):
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if strings.HasPrefix(key, "x-") {
+				continue
+			}
+			p := with(path, key)
+			switch {
+			case key == "schema":
+				a.schema(value, p)
+			case key == "schemas" && len(path) == 1 && path[0] == "components":
+				a.schemaMap(value, p)
+			default:
+				a.walk(value, p)
+			}
+		}
+	case utils.IsNodeArray(node):
+		for i, n := range node.Content {
+			a.walk(n, with(path, strconv.Itoa(i)))
+		}
+	}
+}
+
+func (a *advisor) schemaMap(node *yaml.Node, path []string) {
+	node = utils.NodeAlias(node)
+	if !utils.IsNodeMap(node) {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		a.schema(node.Content[i+1], with(path, node.Content[i].Value))
+	}
+}
+
+// schema inspects a schema, and all of its sub-schemas.
+func (a *advisor) schema(node *yaml.Node, path []string) {
+	node = utils.NodeAlias(node)
+	if !utils.IsNodeMap(node) {
+		return
+	}
+	if _, ok := a.seen[node]; ok {
+		return
+	}
+	a.seen[node] = struct{}{}
+
+	if a.downgrade {
+		a.downgradeSchema(node, path)
+	} else {
+		a.nullable(node, path)
+		a.exclusive(node, path, "exclusiveMinimum", "minimum", HintExclusiveMinimum)
+		a.exclusive(node, path, "exclusiveMaximum", "maximum", HintExclusiveMaximum)
+		a.example(node, path)
+		a.format(node, path)
+	}
+
+	for _, key := range []string{"items", "not", "additionalProperties"} {
+		if _, v := keyValue(node, key); v != nil {
+			a.schema(v, with(path, key))
+		}
+	}
+	if _, v := keyValue(node, "properties"); v != nil {
+		a.schemaMap(v, with(path, "properties"))
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if _, v := keyValue(node, key); v != nil && utils.IsNodeArray(v) {
+			for i, n := range v.Content {
+				a.schema(n, with(path, key, strconv.Itoa(i)))
+			}
+		}
+	}
+}
+
+func (a *advisor) nullable(node *yaml.Node, path []string) {
+	k, v := keyValue(node, "nullable")
+	if v == nil {
+		return
+	}
+	p := with(path, "nullable")
+	if v.Value != "true" {
+		h := a.hint(HintNullable, k, p, "nullable has been removed, 'nullable: false' can be deleted", true)
+		if a.rewrite {
+			removeKey(node, "nullable")
+			h.Rewritten = true
+		}
+		return
+	}
+	_, typ := keyValue(node, "type")
+	if typ == nil || typ.Kind != yaml.ScalarNode {
+		a.hint(HintNullable, k, p, "nullable has been removed, use 'type' with 'null' (or a oneOf with a "+
+			"'null' type schema) instead, this schema has no type to add 'null' to", false)
+		return
+	}
+	h := a.hint(HintNullable, k, p,
+		fmt.Sprintf("nullable has been removed, use 'type: [%s, \"null\"]' instead", typ.Value), true)
+	if !a.rewrite {
+		return
+	}
+	*typ = yaml.Node{
+		Kind: yaml.SequenceNode, Tag: "!!seq", Style: yaml.FlowStyle, Line: typ.Line, Column: typ.Column,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: typ.Value},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "null", Style: yaml.DoubleQuotedStyle},
+		},
+	}
+	// an enum has to allow null as well, or the schema is still not nullable.
+	if _, enum := keyValue(node, "enum"); enum != nil && utils.IsNodeArray(enum) {
+		hasNull := false
+		for _, e := range enum.Content {
+			if e.Tag == "!!null" {
+				hasNull = true
+			}
+		}
+		if !hasNull {
+			enum.Content = append(enum.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"})
+		}
+	}
+	removeKey(node, "nullable")
+	h.Rewritten = true
+}
+
+func (a *advisor) exclusive(node *yaml.Node, path []string, keyword, bound string, kind HintKind) {
+	k, v := keyValue(node, keyword)
+	if v == nil || v.Tag != "!!bool" {
+		return // missing, or already a number.
+	}
+	p := with(path, keyword)
+	if v.Value != "true" {
+		h := a.hint(kind, k, p, fmt.Sprintf("%s is now a number, '%s: false' can be deleted", keyword, keyword), true)
+		if a.rewrite {
+			removeKey(node, keyword)
+			h.Rewritten = true
+		}
+		return
+	}
+	_, b := keyValue(node, bound)
+	if b == nil {
+		a.hint(kind, k, p, fmt.Sprintf("%s is now a number, and there is no '%s' to use as its value",
+			keyword, bound), false)
+		return
+	}
+	h := a.hint(kind, k, p, fmt.Sprintf("%s is now a number, use '%s: %s' instead of '%s'",
+		keyword, keyword, b.Value, bound), true)
+	if a.rewrite {
+		*v = yaml.Node{Kind: yaml.ScalarNode, Tag: b.Tag, Value: b.Value, Line: v.Line, Column: v.Column}
+		removeKey(node, bound)
+		h.Rewritten = true
+	}
+}
+
+func (a *advisor) example(node *yaml.Node, path []string) {
+	k, v := keyValue(node, "example")
+	if v == nil {
+		return
+	}
+	p := with(path, "example")
+	if _, examples := keyValue(node, "examples"); examples != nil {
+		a.hint(HintExample, k, p, "the schema example is deprecated, move it into the existing 'examples'", false)
+		return
+	}
+	h := a.hint(HintExample, k, p, "the schema example is deprecated, use 'examples' (an array) instead", true)
+	if a.rewrite {
+		k.Value = "examples"
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i] == k {
+				node.Content[i+1] = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{v}}
+			}
+		}
+		h.Rewritten = true
+	}
+}
+
+func (a *advisor) format(node *yaml.Node, path []string) {
+	k, v := keyValue(node, "format")
+	if v == nil || (v.Value != "byte" && v.Value != "binary") {
+		return
+	}
+	if _, typ := keyValue(node, "type"); typ == nil || typ.Value != "string" {
+		return
+	}
+	p := with(path, "format")
+	replacement, value := "contentEncoding", "base64"
+	if v.Value == "binary" {
+		replacement, value = "contentMediaType", "application/octet-stream"
+	}
+	if _, existing := keyValue(node, replacement); existing != nil {
+		a.hint(HintFormat, k, p, fmt.Sprintf("format '%s' has been removed, '%s' is already defined, "+
+			"so the format can be deleted", v.Value, replacement), false)
+		return
+	}
+	h := a.hint(HintFormat, k, p, fmt.Sprintf("format '%s' has been removed, use '%s: %s' instead",
+		v.Value, replacement, value), true)
+	if a.rewrite {
+		k.Value = replacement
+		*v = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Line: v.Line, Column: v.Column}
+		h.Rewritten = true
+	}
+}
+
+// keyValue returns the key and value nodes of a key in a map node.
+func keyValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], utils.NodeAlias(node.Content[i+1])
+		}
+	}
+	return nil, nil
+}
+
+func removeKey(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func with(path []string, segments ...string) []string {
+	p := make([]string, 0, len(path)+len(segments))
+	return append(append(p, path...), segments...)
+}
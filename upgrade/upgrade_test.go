@@ -0,0 +1,182 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package upgrade_test
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/upgrade"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var spec30 = `openapi: 3.0.3
+info:
+  title: upgrade me
+  version: "1"
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            minimum: 1
+            exclusiveMinimum: true
+            exclusiveMaximum: false
+      responses:
+        "200":
+          description: ok
+          content:
+            application/octet-stream:
+              schema:
+                type: string
+                format: binary
+components:
+  schemas:
+    Pet:
+      type: object
+      nullable: false
+      example:
+        name: rex
+      properties:
+        name:
+          type: string
+          nullable: true
+          enum: [rex, fido]
+        photo:
+          type: string
+          format: byte
+        tags:
+          type: array
+          items:
+            nullable: true
+            allOf:
+              - $ref: '#/components/schemas/Tag'
+        age:
+          type: number
+          exclusiveMaximum: true
+    Tag:
+      type: string
+      x-custom:
+        nullable: true`
+
+func parse(t *testing.T, spec string) *yaml.Node {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	return &root
+}
+
+func TestAnalyze(t *testing.T) {
+	root := parse(t, spec30)
+	report, err := upgrade.Analyze(root)
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.3", report.From)
+	assert.Equal(t, upgrade.TargetVersion, report.To)
+
+	type expected struct {
+		kind       upgrade.HintKind
+		pointer    string
+		rewritable bool
+	}
+	var found []expected
+	for _, h := range report.Hints {
+		found = append(found, expected{h.Kind, h.Pointer, h.Rewritable})
+		assert.False(t, h.Rewritten)
+		assert.NotZero(t, h.Line)
+		assert.NotEmpty(t, h.Message)
+	}
+	assert.Equal(t, []expected{
+		{upgrade.HintVersion, "/openapi", true},
+		{upgrade.HintExclusiveMinimum, "/paths/~1pets/get/parameters/0/schema/exclusiveMinimum", true},
+		{upgrade.HintExclusiveMaximum, "/paths/~1pets/get/parameters/0/schema/exclusiveMaximum", true},
+		{upgrade.HintFormat, "/paths/~1pets/get/responses/200/content/application~1octet-stream/schema/format", true},
+		{upgrade.HintNullable, "/components/schemas/Pet/nullable", true},
+		{upgrade.HintExample, "/components/schemas/Pet/example", true},
+		{upgrade.HintNullable, "/components/schemas/Pet/properties/name/nullable", true},
+		{upgrade.HintFormat, "/components/schemas/Pet/properties/photo/format", true},
+		{upgrade.HintNullable, "/components/schemas/Pet/properties/tags/items/nullable", false},
+		{upgrade.HintExclusiveMaximum, "/components/schemas/Pet/properties/age/exclusiveMaximum", false},
+	}, found)
+	assert.Len(t, report.Manual(), 2)
+	assert.Len(t, report.Rewritable(), 8)
+	assert.Equal(t, 28, report.Hints[4].Line)
+	assert.Equal(t, 7, report.Hints[4].Column)
+
+	// the document is left alone.
+	out, _ := yaml.Marshal(root)
+	assert.Contains(t, string(out), "openapi: 3.0.3")
+	assert.Contains(t, string(out), "nullable: true")
+}
+
+func TestUpgrade(t *testing.T) {
+	root := parse(t, spec30)
+	report, err := upgrade.Upgrade(root)
+	require.NoError(t, err)
+	for _, h := range report.Hints {
+		assert.Equal(t, h.Rewritable, h.Rewritten, h.Pointer)
+	}
+
+	out, err := yaml.Marshal(root)
+	require.NoError(t, err)
+	upgraded := string(out)
+	assert.Contains(t, upgraded, "openapi: 3.1.0")
+	assert.Contains(t, upgraded, `type: [string, "null"]`)
+	assert.Contains(t, upgraded, "enum: [rex, fido, null]")
+	assert.Contains(t, upgraded, "exclusiveMinimum: 1\n")
+	assert.NotContains(t, upgraded, "minimum: 1")
+	assert.Contains(t, upgraded, "contentMediaType: application/octet-stream")
+	assert.Contains(t, upgraded, "contentEncoding: base64")
+	assert.Contains(t, upgraded, "examples:\n                - name: rex")
+	assert.NotContains(t, upgraded, "nullable: false")
+
+	// things that can't be rewritten are left for the author.
+	assert.Contains(t, upgraded, "exclusiveMaximum: true")
+	assert.Contains(t, upgraded, "nullable: true\n                        allOf")
+
+	// the result is a 3.1 document.
+	doc, err := libopenapi.NewDocument(out)
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	name := model.Model.Components.Schemas.GetOrZero("Pet").Schema().Properties.GetOrZero("name").Schema()
+	assert.Equal(t, []string{"string", "null"}, name.Type)
+	again, err := upgrade.Analyze(parse(t, upgraded))
+	assert.ErrorIs(t, err, upgrade.ErrNotOpenAPI30)
+	assert.Nil(t, again)
+}
+
+func TestUpgradeBytes(t *testing.T) {
+	out, report, err := upgrade.UpgradeBytes([]byte(spec30))
+	require.NoError(t, err)
+	assert.Len(t, report.Hints, 10)
+	assert.Contains(t, string(out), "openapi: 3.1.0")
+
+	j := `{"openapi": "3.0.0", "components": {"schemas": {"A": {"type": "integer", "nullable": true}}}}`
+	out, report, err = upgrade.UpgradeBytes([]byte(j))
+	require.NoError(t, err)
+	assert.Len(t, report.Hints, 2)
+	assert.JSONEq(t, `{"openapi": "3.1.0", "components": {"schemas": {"A": {"type": ["integer", "null"]}}}}`,
+		string(out))
+}
+
+func TestUpgrade_Errors(t *testing.T) {
+	_, err := upgrade.Analyze(nil)
+	assert.ErrorIs(t, err, upgrade.ErrNotOpenAPI30)
+
+	_, err = upgrade.Analyze(parse(t, `swagger: "2.0"`))
+	assert.ErrorIs(t, err, upgrade.ErrNotOpenAPI30)
+
+	_, err = upgrade.Upgrade(parse(t, `- openapi`))
+	assert.ErrorIs(t, err, upgrade.ErrNotOpenAPI30)
+
+	_, _, err = upgrade.UpgradeBytes([]byte(`openapi: 3.1.0`))
+	assert.ErrorIs(t, err, upgrade.ErrNotOpenAPI30)
+
+	_, _, err = upgrade.UpgradeBytes(nil)
+	assert.Error(t, err)
+}
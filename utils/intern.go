@@ -0,0 +1,61 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Interner interns strings: every string equal to one it has already interned shares the memory of the first.
+// It holds every string it interned for as long as it's used, so one is created for each document (and shared by
+// every file of it), rather than holding the strings of every document ever read. It is safe for concurrent use.
+type Interner struct {
+	lock    sync.Mutex
+	strings map[string]string
+}
+
+// NewInterner creates a new, empty Interner.
+func NewInterner() *Interner {
+	return &Interner{strings: make(map[string]string)}
+}
+
+// Intern returns the canonical copy of s, the first string equal to s interned.
+func (in *Interner) Intern(s string) string {
+	if s == "" {
+		return s
+	}
+	in.lock.Lock()
+	defer in.lock.Unlock()
+	if c, ok := in.strings[s]; ok {
+		return c
+	}
+	in.strings[s] = s
+	return s
+}
+
+// InternNodes interns the keys of every map under root, and the value of every $ref. Large documents repeat the
+// same keys (`type`, `description`, `schema`...) and references thousands of times, each decoded into its own copy.
+// Once interned, every copy (and every string taken from them, such as the keys and references of a low-level model)
+// shares the same memory. Aliases are not followed, as their anchors are interned where they are defined.
+func (in *Interner) InternNodes(root *yaml.Node) {
+	if root == nil {
+		return
+	}
+	if root.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			root.Content[i].Value = in.Intern(root.Content[i].Value)
+			if root.Content[i].Value == "$ref" && root.Content[i+1].Kind == yaml.ScalarNode {
+				root.Content[i+1].Value = in.Intern(root.Content[i+1].Value)
+			}
+		}
+	}
+	if root.Kind == yaml.AliasNode {
+		return
+	}
+	for _, n := range root.Content {
+		in.InternNodes(n)
+	}
+}
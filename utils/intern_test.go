@@ -0,0 +1,54 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestInterner_Intern(t *testing.T) {
+	a := strings.Repeat("description", 2)
+	b := strings.Repeat("description", 2)
+	require.NotSame(t, unsafe.StringData(a), unsafe.StringData(b))
+
+	in := NewInterner()
+	assert.Same(t, unsafe.StringData(in.Intern(a)), unsafe.StringData(in.Intern(b)))
+	assert.Same(t, unsafe.StringData(a), unsafe.StringData(in.Intern(b)))
+	assert.Equal(t, a, in.Intern(a))
+	assert.Empty(t, in.Intern(""))
+
+	// strings interned by another interner are not shared.
+	assert.NotSame(t, unsafe.StringData(a), unsafe.StringData(NewInterner().Intern(b)))
+}
+
+func TestInterner_InternNodes(t *testing.T) {
+	var one, two yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`pets:
+  items: &pet
+    $ref: '#/components/schemas/Pet'
+  other: *pet
+  description: pets`), &one))
+	require.NoError(t, yaml.Unmarshal([]byte(`[{description: '#/components/schemas/Pet', $ref: '#/components/schemas/Pet'}]`), &two))
+
+	in := NewInterner()
+	in.InternNodes(&one)
+	in.InternNodes(&two)
+	in.InternNodes(nil)
+
+	items := one.Content[0].Content[1].Content[1]
+	seq := two.Content[0].Content[0]
+
+	// keys are interned.
+	assert.Same(t, unsafe.StringData(one.Content[0].Content[1].Content[4].Value), unsafe.StringData(seq.Content[0].Value))
+	// as are references, but no other values.
+	assert.Same(t, unsafe.StringData(items.Content[1].Value), unsafe.StringData(seq.Content[3].Value))
+	assert.NotSame(t, unsafe.StringData(items.Content[1].Value), unsafe.StringData(seq.Content[1].Value))
+	assert.Equal(t, "#/components/schemas/Pet", seq.Content[1].Value)
+}
@@ -0,0 +1,318 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+type jsonPatchOperation struct {
+	Op    string           `json:"op"`
+	Path  *string          `json:"path"`
+	From  *string          `json:"from"`
+	Value *json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies a JSON Patch (RFC 6902) to a yaml tree in place. Every operation (add, remove, replace,
+// move, copy and test) is supported. Nodes that aren't patched are left untouched, keeping their comments, style
+// and positions, moved nodes are the same nodes, and added values are given the line and column of the node they
+// replace (or of the map or array they're added to), so they can still be located.
+//
+// The patch is applied atomically: if an operation fails, an error explains which one and why, and root is left
+// unchanged.
+func ApplyJSONPatch(root *yaml.Node, patch []byte) error {
+	var ops []jsonPatchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return fmt.Errorf("unable to parse JSON Patch: %w", err)
+	}
+	if root == nil {
+		return errors.New("unable to apply JSON Patch, root node is nil")
+	}
+	// dry run on a copy first, so a failing operation never leaves root half patched.
+	if err := applyJSONPatchOperations(copyNode(root, make(map[*yaml.Node]*yaml.Node)), ops); err != nil {
+		return err
+	}
+	return applyJSONPatchOperations(root, ops)
+}
+
+func applyJSONPatchOperations(root *yaml.Node, ops []jsonPatchOperation) error {
+	for i, op := range ops {
+		if op.Path == nil {
+			return fmt.Errorf("unable to apply JSON Patch operation %d (`%s`): it has no path", i, op.Op)
+		}
+		if err := applyJSONPatchOperation(root, op); err != nil {
+			return fmt.Errorf("unable to apply JSON Patch operation %d (`%s` at `%s`): %w", i, op.Op, *op.Path, err)
+		}
+	}
+	return nil
+}
+
+func applyJSONPatchOperation(root *yaml.Node, op jsonPatchOperation) error {
+	path, err := ParseJSONPointer(*op.Path)
+	if err != nil {
+		return err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return errors.New("it has no value")
+		}
+		value, err := jsonPatchValue(*op.Value)
+		if err != nil {
+			return err
+		}
+		switch op.Op {
+		case "add":
+			return patchAdd(root, path, value)
+		case "replace":
+			return patchReplace(root, path, value)
+		}
+		return patchTest(root, path, value)
+	case "remove":
+		_, err := patchRemove(root, path)
+		return err
+	case "move", "copy":
+		if op.From == nil {
+			return errors.New("it has no from")
+		}
+		from, err := ParseJSONPointer(*op.From)
+		if err != nil {
+			return err
+		}
+		if op.Op == "copy" {
+			node, err := FindNodeByJSONPointerSegments(root, from)
+			if err != nil {
+				return err
+			}
+			return patchAdd(root, path, copyNode(node, make(map[*yaml.Node]*yaml.Node)))
+		}
+		if len(from) <= len(path) && reflect.DeepEqual(from, path[:len(from)]) {
+			if len(from) == len(path) {
+				return nil
+			}
+			return fmt.Errorf("unable to move `%s` into one of its children", BuildJSONPointer(from))
+		}
+		node, err := patchRemove(root, from)
+		if err != nil {
+			return err
+		}
+		return patchAdd(root, path, node)
+	}
+	return fmt.Errorf("unknown operation `%s`", op.Op)
+}
+
+// jsonPatchValue converts a JSON value into a node, with the default style, so it's rendered like the rest of a
+// YAML document.
+func jsonPatchValue(raw json.RawMessage) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("unable to read value '%s'", string(raw))
+	}
+	value := doc.Content[0]
+	resetNodeStyle(value)
+	return value, nil
+}
+
+func resetNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	node.Line, node.Column = 0, 0
+	for _, n := range node.Content {
+		resetNodeStyle(n)
+	}
+}
+
+// positionNode gives every node of value without a position the position of at.
+func positionNode(value, at *yaml.Node) {
+	if value.Line == 0 {
+		value.Line, value.Column = at.Line, at.Column
+	}
+	for _, n := range value.Content {
+		positionNode(n, at)
+	}
+}
+
+// patchParent returns the map or array holding the last segment of path.
+func patchParent(root *yaml.Node, path []string) (*yaml.Node, error) {
+	parent, err := FindNodeByJSONPointerSegments(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	if parent.Kind != yaml.MappingNode && parent.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("`%s` is not an object or an array", BuildJSONPointer(path[:len(path)-1]))
+	}
+	return parent, nil
+}
+
+// replaceRoot replaces the content of the document (or the root node itself).
+func replaceRoot(root, value *yaml.Node) {
+	target := root
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		target = root.Content[0]
+	}
+	positionNode(value, target)
+	if root.Kind == yaml.DocumentNode {
+		root.Content = []*yaml.Node{value}
+		return
+	}
+	*root = *value
+}
+
+func patchAdd(root *yaml.Node, path []string, value *yaml.Node) error {
+	if len(path) == 0 {
+		replaceRoot(root, value)
+		return nil
+	}
+	parent, err := patchParent(root, path)
+	if err != nil {
+		return err
+	}
+	key := path[len(path)-1]
+	if parent.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == key {
+				positionNode(value, parent.Content[i+1])
+				parent.Content[i+1] = value
+				return nil
+			}
+		}
+		k := CreateStringNode(key)
+		positionNode(k, parent)
+		positionNode(value, parent)
+		parent.Content = append(parent.Content, k, value)
+		return nil
+	}
+	i := len(parent.Content)
+	if key != "-" {
+		if i, err = strconv.Atoi(key); err != nil || i < 0 || i > len(parent.Content) {
+			return fmt.Errorf("index '%s' is out of the bounds of the array", key)
+		}
+	}
+	positionNode(value, parent)
+	parent.Content = append(parent.Content[:i], append([]*yaml.Node{value}, parent.Content[i:]...)...)
+	return nil
+}
+
+func patchReplace(root *yaml.Node, path []string, value *yaml.Node) error {
+	if len(path) == 0 {
+		replaceRoot(root, value)
+		return nil
+	}
+	parent, err := patchParent(root, path)
+	if err != nil {
+		return err
+	}
+	i, err := patchIndex(parent, path)
+	if err != nil {
+		return err
+	}
+	positionNode(value, parent.Content[i])
+	parent.Content[i] = value
+	return nil
+}
+
+// patchRemove removes the node at path, and returns it.
+func patchRemove(root *yaml.Node, path []string) (*yaml.Node, error) {
+	if len(path) == 0 {
+		return nil, errors.New("the whole document can't be removed")
+	}
+	parent, err := patchParent(root, path)
+	if err != nil {
+		return nil, err
+	}
+	i, err := patchIndex(parent, path)
+	if err != nil {
+		return nil, err
+	}
+	node := parent.Content[i]
+	if parent.Kind == yaml.MappingNode {
+		parent.Content = append(parent.Content[:i-1], parent.Content[i+1:]...)
+	} else {
+		parent.Content = append(parent.Content[:i], parent.Content[i+1:]...)
+	}
+	return node, nil
+}
+
+// patchIndex returns the index in parent.Content of the existing value at path.
+func patchIndex(parent *yaml.Node, path []string) (int, error) {
+	key := path[len(path)-1]
+	if parent.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(parent.Content); i += 2 {
+			if parent.Content[i].Value == key {
+				return i + 1, nil
+			}
+		}
+	} else if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < len(parent.Content) {
+		return i, nil
+	}
+	return 0, fmt.Errorf("`%s` does not exist", BuildJSONPointer(path))
+}
+
+func patchTest(root *yaml.Node, path []string, value *yaml.Node) error {
+	node, err := FindNodeByJSONPointerSegments(root, path)
+	if err != nil {
+		return err
+	}
+	a, err := jsonNodeValue(node)
+	if err != nil {
+		return err
+	}
+	b, err := jsonNodeValue(value)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(a, b) {
+		return fmt.Errorf("`%s` is not the value tested", BuildJSONPointer(path))
+	}
+	return nil
+}
+
+// jsonNodeValue decodes a node into a JSON value, so values can be compared the way JSON compares them.
+func jsonNodeValue(node *yaml.Node) (any, error) {
+	var v any
+	if err := node.Decode(&v); err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var j any
+	err = json.Unmarshal(b, &j)
+	return j, err
+}
+
+// CopyNode returns a deep copy of a node and all of its children, aliases included (an anchor and its aliases keep
+// sharing the copied node).
+func CopyNode(node *yaml.Node) *yaml.Node {
+	return copyNode(node, make(map[*yaml.Node]*yaml.Node))
+}
+
+// copyNode returns a deep copy of a node, aliases included.
+func copyNode(node *yaml.Node, copies map[*yaml.Node]*yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if c, ok := copies[node]; ok {
+		return c
+	}
+	c := new(yaml.Node)
+	*c = *node
+	copies[node] = c
+	if node.Alias != nil {
+		c.Alias = copyNode(node.Alias, copies)
+	}
+	if node.Content != nil {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, n := range node.Content {
+			c.Content[i] = copyNode(n, copies)
+		}
+	}
+	return c
+}
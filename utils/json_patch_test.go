@@ -0,0 +1,129 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var jsonPatchSpec = `openapi: 3.1.0
+info:
+  title: pets # the title
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: toys
+paths:
+  /pets:
+    get:
+      summary: list pets
+`
+
+func parseJSONPatchSpec(t *testing.T) *yaml.Node {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(jsonPatchSpec), &root))
+	return &root
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	root := parseJSONPatchSpec(t)
+	title := root.Content[0].Content[3].Content[1]
+	get, _ := FindNodeByJSONPointer(root, "/paths/~1pets/get")
+
+	err := ApplyJSONPatch(root, []byte(`[
+		{"op": "test", "path": "/info/version", "value": "1.0.0"},
+		{"op": "replace", "path": "/info/version", "value": "2.0.0"},
+		{"op": "add", "path": "/info/description", "value": "all the pets"},
+		{"op": "add", "path": "/tags/1", "value": {"name": "food"}},
+		{"op": "remove", "path": "/tags/0"},
+		{"op": "add", "path": "/tags/-", "value": {"name": "pets"}},
+		{"op": "copy", "from": "/paths/~1pets/get", "path": "/paths/~1pets/head"},
+		{"op": "move", "from": "/paths/~1pets/get", "path": "/paths/~1animals"}
+	]`))
+	require.NoError(t, err)
+
+	out, _ := yaml.Marshal(root)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: pets # the title
+    version: 2.0.0
+    description: all the pets
+tags:
+    - name: food
+    - name: toys
+    - name: pets
+paths:
+    /pets:
+        head:
+            summary: list pets
+    /animals:
+        summary: list pets
+`, string(out))
+
+	// untouched and moved nodes are the same nodes, added nodes are located where they're added.
+	n, _ := FindNodeByJSONPointer(root, "/info/title")
+	assert.Same(t, title, n)
+	n, _ = FindNodeByJSONPointer(root, "/paths/~1animals")
+	assert.Same(t, get, n)
+	n, _ = FindNodeByJSONPointer(root, "/info/version")
+	assert.Equal(t, 4, n.Line)
+	n, _ = FindNodeByJSONPointer(root, "/tags/0/name")
+	assert.Equal(t, 6, n.Line)
+}
+
+func TestApplyJSONPatch_Root(t *testing.T) {
+	root := parseJSONPatchSpec(t)
+	require.NoError(t, ApplyJSONPatch(root, []byte(`[{"op": "replace", "path": "", "value": {"swagger": "2.0"}}]`)))
+	out, _ := yaml.Marshal(root)
+	assert.Equal(t, "swagger: \"2.0\"\n", string(out))
+	assert.Equal(t, 1, root.Content[0].Line)
+}
+
+func TestApplyJSONPatch_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		patch, err string
+	}{
+		{`{}`, "unable to parse JSON Patch: json: cannot unmarshal object into Go value of type []utils.jsonPatchOperation"},
+		{`[{"op": "remove"}]`, "unable to apply JSON Patch operation 0 (`remove`): it has no path"},
+		{`[{"op": "remove", "path": "/nope"}]`, "unable to apply JSON Patch operation 0 (`remove` at `/nope`): `/nope` does not exist"},
+		{`[{"op": "replace", "path": "/tags/2", "value": 1}]`, "unable to apply JSON Patch operation 0 (`replace` at `/tags/2`): `/tags/2` does not exist"},
+		{`[{"op": "add", "path": "/tags/3", "value": 1}]`, "unable to apply JSON Patch operation 0 (`add` at `/tags/3`): index '3' is out of the bounds of the array"},
+		{`[{"op": "add", "path": "/openapi/x", "value": 1}]`, "unable to apply JSON Patch operation 0 (`add` at `/openapi/x`): `/openapi` is not an object or an array"},
+		{`[{"op": "add", "path": "/info/x"}]`, "unable to apply JSON Patch operation 0 (`add` at `/info/x`): it has no value"},
+		{`[{"op": "test", "path": "/info/title", "value": "toys"}]`, "unable to apply JSON Patch operation 0 (`test` at `/info/title`): `/info/title` is not the value tested"},
+		{`[{"op": "move", "path": "/info/x"}]`, "unable to apply JSON Patch operation 0 (`move` at `/info/x`): it has no from"},
+		{`[{"op": "move", "from": "/info", "path": "/info/x"}]`, "unable to apply JSON Patch operation 0 (`move` at `/info/x`): unable to move `/info` into one of its children"},
+		{`[{"op": "remove", "path": ""}]`, "unable to apply JSON Patch operation 0 (`remove` at ``): the whole document can't be removed"},
+		{`[{"op": "rename", "path": "/info"}]`, "unable to apply JSON Patch operation 0 (`rename` at `/info`): unknown operation `rename`"},
+		{`[{"op": "remove", "path": "/info/title"}, {"op": "remove", "path": "/info/title"}]`, "unable to apply JSON Patch operation 1 (`remove` at `/info/title`): `/info/title` does not exist"},
+	} {
+		root := parseJSONPatchSpec(t)
+		assert.EqualError(t, ApplyJSONPatch(root, []byte(tc.patch)), tc.err)
+
+		// nothing is patched when an operation fails.
+		out, _ := yaml.Marshal(root)
+		expected, _ := yaml.Marshal(parseJSONPatchSpec(t))
+		assert.Equal(t, string(expected), string(out))
+	}
+	assert.EqualError(t, ApplyJSONPatch(nil, []byte(`[]`)), "unable to apply JSON Patch, root node is nil")
+}
+
+func TestCopyNode(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("a: &x\n  b: c\nd: *x"), &root)
+
+	c := CopyNode(&root)
+	assert.Equal(t, &root, c)
+	m := c.Content[0]
+	// the alias shares the copied anchor.
+	assert.Same(t, m.Content[1], m.Content[3].Alias)
+	m.Content[1].Content[1].Value = "changed"
+	assert.Equal(t, "c", root.Content[0].Content[1].Content[1].Value)
+
+	assert.Nil(t, CopyNode(nil))
+}
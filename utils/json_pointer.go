@@ -0,0 +1,157 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EscapeJSONPointerSegment escapes a single JSON Pointer (RFC 6901) reference token, '~' becomes '~0'
+// and '/' becomes '~1'.
+func EscapeJSONPointerSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1")
+}
+
+// UnescapeJSONPointerSegment reverses EscapeJSONPointerSegment.
+func UnescapeJSONPointerSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+}
+
+// BuildJSONPointer creates a JSON Pointer from a slice of un-escaped segments.
+func BuildJSONPointer(segments []string) string {
+	var sb strings.Builder
+	for _, s := range segments {
+		sb.WriteByte('/')
+		sb.WriteString(EscapeJSONPointerSegment(s))
+	}
+	return sb.String()
+}
+
+// ParseJSONPointer splits a JSON Pointer into un-escaped segments. Both the plain form (`/paths/~1pets`) and the
+// URI fragment form (`#/paths/~1pets`) are accepted. An empty pointer (or '#') refers to the whole document and
+// returns no segments.
+func ParseJSONPointer(pointer string) ([]string, error) {
+	if strings.HasPrefix(pointer, "#") {
+		unescaped, err := url.PathUnescape(pointer[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid json pointer '%s': %w", pointer, err)
+		}
+		pointer = unescaped
+	}
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid json pointer '%s': must be empty or start with '/'", pointer)
+	}
+	segments := strings.Split(pointer[1:], "/")
+	for i := range segments {
+		segments[i] = UnescapeJSONPointerSegment(segments[i])
+	}
+	return segments, nil
+}
+
+// FindNodeByJSONPointer locates the node a JSON Pointer refers to, starting from root. Document nodes and
+// aliases are followed. If the pointer cannot be resolved, an error is returned explaining which segment failed.
+func FindNodeByJSONPointer(root *yaml.Node, pointer string) (*yaml.Node, error) {
+	segments, err := ParseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	return FindNodeByJSONPointerSegments(root, segments)
+}
+
+// FindNodeByJSONPointerSegments is the same as FindNodeByJSONPointer, but accepts segments that have already
+// been parsed by ParseJSONPointer.
+func FindNodeByJSONPointerSegments(root *yaml.Node, segments []string) (*yaml.Node, error) {
+	if root == nil {
+		return nil, fmt.Errorf("unable to resolve json pointer, root node is nil")
+	}
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for i, seg := range segments {
+		node = NodeAlias(node)
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for j := 0; j+1 < len(node.Content); j += 2 {
+				if node.Content[j].Value == seg {
+					next = node.Content[j+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if n, convErr := strconv.Atoi(seg); convErr == nil && n >= 0 && n < len(node.Content) {
+				next = node.Content[n]
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("unable to resolve json pointer '%s': segment '%s' not found",
+				BuildJSONPointer(segments[:i+1]), seg)
+		}
+		node = next
+	}
+	return NodeAlias(node), nil
+}
+
+// JSONPointerForNode generates a JSON Pointer for target, relative to root. The target can be a value node or
+// a map key node (in which case the pointer to its value is returned). Returns false if target cannot be found
+// under root.
+func JSONPointerForNode(root, target *yaml.Node) (string, bool) {
+	if root == nil || target == nil {
+		return "", false
+	}
+	if root == target {
+		return "", true
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	segments, found := findNodePath(root, target, nil, make(map[*yaml.Node]struct{}))
+	if !found {
+		return "", false
+	}
+	return BuildJSONPointer(segments), true
+}
+
+func findNodePath(node, target *yaml.Node, path []string, seen map[*yaml.Node]struct{}) ([]string, bool) {
+	if node == target {
+		return path, true
+	}
+	if _, ok := seen[node]; ok {
+		return nil, false
+	}
+	seen[node] = struct{}{}
+	switch node.Kind {
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			return findNodePath(node.Alias, target, path, seen)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			childPath := append(path[:len(path):len(path)], key.Value)
+			if key == target {
+				return childPath, true
+			}
+			if p, ok := findNodePath(node.Content[i+1], target, childPath, seen); ok {
+				return p, true
+			}
+		}
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			if p, ok := findNodePath(n, target, append(path[:len(path):len(path)], strconv.Itoa(i)), seen); ok {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
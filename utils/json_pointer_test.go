@@ -0,0 +1,99 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var pointerTestSpec = `paths:
+  /pets/{id}:
+    get:
+      tags: [a, b]
+  ~tilde:
+    value: &shared
+      name: shared
+  alias: *shared`
+
+func TestParseJSONPointer(t *testing.T) {
+	segs, err := ParseJSONPointer("/paths/~1pets~1{id}/get")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"paths", "/pets/{id}", "get"}, segs)
+
+	segs, err = ParseJSONPointer("#/paths/~1pets~1%7Bid%7D/~0tilde")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"paths", "/pets/{id}", "~tilde"}, segs)
+
+	segs, err = ParseJSONPointer("")
+	assert.NoError(t, err)
+	assert.Nil(t, segs)
+
+	_, err = ParseJSONPointer("paths")
+	assert.Error(t, err)
+	_, err = ParseJSONPointer("#%zz")
+	assert.Error(t, err)
+
+	assert.Equal(t, "/paths/~1pets~1{id}/~0tilde", BuildJSONPointer([]string{"paths", "/pets/{id}", "~tilde"}))
+}
+
+func TestFindNodeByJSONPointer(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(pointerTestSpec), &root))
+
+	n, err := FindNodeByJSONPointer(&root, "/paths/~1pets~1{id}/get/tags/1")
+	require.NoError(t, err)
+	assert.Equal(t, "b", n.Value)
+
+	n, err = FindNodeByJSONPointer(&root, "/paths/alias/name")
+	require.NoError(t, err)
+	assert.Equal(t, "shared", n.Value)
+
+	n, err = FindNodeByJSONPointer(&root, "")
+	require.NoError(t, err)
+	assert.Equal(t, yaml.MappingNode, n.Kind)
+
+	_, err = FindNodeByJSONPointer(&root, "/paths/~1pets~1{id}/post/tags")
+	assert.EqualError(t, err, "unable to resolve json pointer '/paths/~1pets~1{id}/post': segment 'post' not found")
+
+	_, err = FindNodeByJSONPointer(&root, "/paths/~1pets~1{id}/get/tags/9")
+	assert.Error(t, err)
+	_, err = FindNodeByJSONPointer(nil, "/paths")
+	assert.Error(t, err)
+	_, err = FindNodeByJSONPointer(&root, "nope")
+	assert.Error(t, err)
+}
+
+func TestJSONPointerForNode(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(pointerTestSpec), &root))
+
+	tags, _ := FindNodeByJSONPointer(&root, "/paths/~1pets~1{id}/get/tags/0")
+	p, ok := JSONPointerForNode(&root, tags)
+	assert.True(t, ok)
+	assert.Equal(t, "/paths/~1pets~1{id}/get/tags/0", p)
+
+	// key nodes point at their value.
+	paths := root.Content[0].Content[1]
+	p, ok = JSONPointerForNode(&root, paths.Content[2])
+	assert.True(t, ok)
+	assert.Equal(t, "/paths/~0tilde", p)
+
+	// round trip.
+	n, err := FindNodeByJSONPointer(&root, p)
+	require.NoError(t, err)
+	assert.Equal(t, paths.Content[3], n)
+
+	p, ok = JSONPointerForNode(&root, &root)
+	assert.True(t, ok)
+	assert.Empty(t, p)
+
+	_, ok = JSONPointerForNode(&root, &yaml.Node{})
+	assert.False(t, ok)
+	_, ok = JSONPointerForNode(nil, &yaml.Node{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NodeRange is the span of a node in a document. The start is the position of the first character of the node, and
+// the end is the position directly after its last character (exclusive). Lines and columns are 1-based, the same as
+// yaml.Node positions.
+type NodeRange struct {
+	StartLine   int `json:"startLine" yaml:"startLine"`
+	StartColumn int `json:"startColumn" yaml:"startColumn"`
+	EndLine     int `json:"endLine" yaml:"endLine"`
+	EndColumn   int `json:"endColumn" yaml:"endColumn"`
+}
+
+// IsEmpty returns true if the range has no position (the node was not parsed from a document).
+func (r NodeRange) IsEmpty() bool {
+	return r.StartLine == 0
+}
+
+// Contains returns true if the position is inside the range.
+func (r NodeRange) Contains(line, column int) bool {
+	if r.IsEmpty() {
+		return false
+	}
+	if line < r.StartLine || (line == r.StartLine && column < r.StartColumn) {
+		return false
+	}
+	return line < r.EndLine || (line == r.EndLine && column < r.EndColumn)
+}
+
+// GetNodeRange computes the span of a node, including all of its children. yaml nodes only record where they
+// start, so the end is computed from the last child of maps and sequences, and from the value of scalars.
+//
+// Block scalars (| and >) end at the start of the line after their last line of content. Line breaks in folded
+// (>) and multi-line plain or quoted scalars are not kept by the parser, so those ranges are a best estimate, every
+// other range is exact. Aliases are not followed, the range of an alias is the alias itself (*name).
+func GetNodeRange(node *yaml.Node) NodeRange {
+	if node == nil || node.Line == 0 {
+		return NodeRange{}
+	}
+	r := NodeRange{StartLine: node.Line, StartColumn: node.Column}
+	r.EndLine, r.EndColumn = nodeEnd(node)
+	return r
+}
+
+// MergeNodeRanges returns the smallest range covering every non-empty range supplied.
+func MergeNodeRanges(ranges ...NodeRange) NodeRange {
+	var m NodeRange
+	for _, r := range ranges {
+		if r.IsEmpty() {
+			continue
+		}
+		if m.IsEmpty() || positionBefore(r.StartLine, r.StartColumn, m.StartLine, m.StartColumn) {
+			m.StartLine, m.StartColumn = r.StartLine, r.StartColumn
+		}
+		if m.EndLine == 0 || positionBefore(m.EndLine, m.EndColumn, r.EndLine, r.EndColumn) {
+			m.EndLine, m.EndColumn = r.EndLine, r.EndColumn
+		}
+	}
+	return m
+}
+
+func positionBefore(line, column, otherLine, otherColumn int) bool {
+	return line < otherLine || (line == otherLine && column < otherColumn)
+}
+
+func nodeEnd(node *yaml.Node) (int, int) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return node.Line, node.Column + 1 + utf8.RuneCountInString(node.Value)
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return node.Line, node.Column
+		}
+		return nodeEnd(node.Content[len(node.Content)-1])
+	case yaml.MappingNode, yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			return node.Line, anchoredColumn(node) + 2 // {} or []
+		}
+		line, column := node.Line, node.Column
+		for _, c := range node.Content {
+			if c == nil || c.Line == 0 {
+				continue
+			}
+			if l, col := nodeEnd(c); positionBefore(line, column, l, col) {
+				line, column = l, col
+			}
+		}
+		if node.Style&yaml.FlowStyle != 0 {
+			column++ // the closing bracket.
+		}
+		return line, column
+	}
+	return scalarEnd(node)
+}
+
+// anchoredColumn returns the column the value of a node starts at, after its anchor (if it has one).
+func anchoredColumn(node *yaml.Node) int {
+	if node.Anchor != "" {
+		return node.Column + utf8.RuneCountInString(node.Anchor) + 2 // &anchor and a space.
+	}
+	return node.Column
+}
+
+func scalarEnd(node *yaml.Node) (int, int) {
+	column := anchoredColumn(node)
+	value := node.Value
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		lines := strings.Count(value, "\n")
+		if value != "" && !strings.HasSuffix(value, "\n") {
+			lines++
+		}
+		if lines == 0 {
+			return node.Line, column + 1
+		}
+		return node.Line + lines + 1, 1
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		length := 2
+		for _, c := range value {
+			switch {
+			case c == '"' || c == '\\' || c == '\n' || c == '\t' || c == '\r':
+				length += 2
+			case c < 0x20:
+				length += 4 // \xXX
+			default:
+				length++
+			}
+		}
+		return node.Line, column + length
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		return node.Line, column + 2 + utf8.RuneCountInString(value) + strings.Count(value, "'")
+	}
+	if value == "" && node.Tag == "!!null" {
+		return node.Line, column // an empty value, rather than ~ or null.
+	}
+	return node.Line, column + utf8.RuneCountInString(value)
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGetNodeRange(t *testing.T) {
+	yml := `paths:
+  /pets:
+    get:
+      summary: list pets
+      description: |
+        Lists all the pets.
+        Every single one.
+      operationId: 'list''Pets'
+      tags: [pets, "a\"b"]
+      x-anchor: &anc {}
+      x-alias: *anc
+      x-empty:
+components: {}`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	paths := root.Content[0].Content[1]
+	get := paths.Content[1].Content[1]
+
+	// the whole get operation, the last child is the empty value on line 12.
+	assert.Equal(t, NodeRange{StartLine: 4, StartColumn: 7, EndLine: 12, EndColumn: 15}, GetNodeRange(get))
+
+	summary := get.Content[1]
+	assert.Equal(t, NodeRange{StartLine: 4, StartColumn: 16, EndLine: 4, EndColumn: 25}, GetNodeRange(summary))
+
+	// block scalars end at the start of the line after their content.
+	desc := get.Content[3]
+	assert.Equal(t, NodeRange{StartLine: 5, StartColumn: 20, EndLine: 8, EndColumn: 1}, GetNodeRange(desc))
+
+	opId := get.Content[5]
+	assert.Equal(t, NodeRange{StartLine: 8, StartColumn: 20, EndLine: 8, EndColumn: 32}, GetNodeRange(opId))
+
+	tags := get.Content[7]
+	assert.Equal(t, NodeRange{StartLine: 9, StartColumn: 13, EndLine: 9, EndColumn: 27}, GetNodeRange(tags))
+
+	anchored := get.Content[9]
+	assert.Equal(t, NodeRange{StartLine: 10, StartColumn: 17, EndLine: 10, EndColumn: 24}, GetNodeRange(anchored))
+
+	alias := get.Content[11]
+	assert.Equal(t, NodeRange{StartLine: 11, StartColumn: 16, EndLine: 11, EndColumn: 20}, GetNodeRange(alias))
+
+	components := root.Content[0].Content[3]
+	assert.Equal(t, NodeRange{StartLine: 13, StartColumn: 13, EndLine: 13, EndColumn: 15}, GetNodeRange(components))
+
+	doc := GetNodeRange(&root)
+	assert.Equal(t, 1, doc.StartLine)
+	assert.Equal(t, 13, doc.EndLine)
+
+	assert.True(t, GetNodeRange(get).Contains(6, 3))
+	assert.True(t, GetNodeRange(get).Contains(4, 7))
+	assert.False(t, GetNodeRange(get).Contains(4, 6))
+	assert.False(t, GetNodeRange(get).Contains(12, 15))
+	assert.False(t, GetNodeRange(get).Contains(13, 1))
+
+	assert.True(t, GetNodeRange(nil).IsEmpty())
+	assert.True(t, GetNodeRange(CreateStringNode("built")).IsEmpty())
+	assert.False(t, NodeRange{}.Contains(1, 1))
+}
+
+func TestGetNodeRange_BlockScalars(t *testing.T) {
+	yml := `a: |-
+  one
+  two
+b: >
+  folded
+c: |+
+  kept
+
+d: |
+e: end`
+
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &root)
+	m := root.Content[0]
+
+	assert.Equal(t, NodeRange{StartLine: 1, StartColumn: 4, EndLine: 4, EndColumn: 1}, GetNodeRange(m.Content[1]))
+	assert.Equal(t, NodeRange{StartLine: 4, StartColumn: 4, EndLine: 6, EndColumn: 1}, GetNodeRange(m.Content[3]))
+	assert.Equal(t, NodeRange{StartLine: 6, StartColumn: 4, EndLine: 9, EndColumn: 1}, GetNodeRange(m.Content[5]))
+	assert.Equal(t, NodeRange{StartLine: 9, StartColumn: 4, EndLine: 9, EndColumn: 5}, GetNodeRange(m.Content[7]))
+}
+
+func TestMergeNodeRanges(t *testing.T) {
+	a := NodeRange{StartLine: 2, StartColumn: 3, EndLine: 2, EndColumn: 10}
+	b := NodeRange{StartLine: 2, StartColumn: 12, EndLine: 5, EndColumn: 1}
+	assert.Equal(t, NodeRange{StartLine: 2, StartColumn: 3, EndLine: 5, EndColumn: 1}, MergeNodeRanges(b, NodeRange{}, a))
+	assert.True(t, MergeNodeRanges().IsEmpty())
+}
@@ -84,3 +84,31 @@ func CreateFloatNode(str string) *yaml.Node {
 	}
 	return n
 }
+
+// CountNodes will count every node in the tree below (and including) the supplied node. Aliases are followed, so the
+// count represents the size of the fully expanded tree. Counting stops as soon as the count exceeds max, which keeps
+// the cost of counting an alias bomb bounded. A max of zero or less counts everything.
+func CountNodes(node *yaml.Node, max int) int {
+	if node == nil {
+		return 0
+	}
+	count := 0
+	stack := []*yaml.Node{node}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n == nil {
+			continue
+		}
+		count++
+		if max > 0 && count > max {
+			return count
+		}
+		if n.Kind == yaml.AliasNode {
+			stack = append(stack, n.Alias)
+			continue
+		}
+		stack = append(stack, n.Content...)
+	}
+	return count
+}
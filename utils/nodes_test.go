@@ -6,6 +6,8 @@ package utils
 import (
 	"testing"
 
+	"gopkg.in/yaml.v3"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,3 +62,26 @@ func TestCreateYamlNode(t *testing.T) {
 	assert.Equal(t, "!!str", y.Tag)
 	assert.Equal(t, "foo", y.Value)
 }
+
+func TestCountNodes(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`a: [1, 2, 3]
+b:
+  c: d`), &root)
+	// document, map, a, seq, 1, 2, 3, b, map, c, d
+	assert.Equal(t, 11, CountNodes(&root, 0))
+	assert.Equal(t, 0, CountNodes(nil, 0))
+}
+
+func TestCountNodes_AliasBomb(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte(`a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]`), &root)
+
+	// counting stops just over the limit, no matter how big the expanded tree would be.
+	assert.Equal(t, 1001, CountNodes(&root, 1000))
+}
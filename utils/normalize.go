@@ -0,0 +1,191 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// namedMaps are keys holding maps keyed by names (of properties, components, paths, responses...) rather than by
+// keywords, so their keys are never normalized.
+var namedMaps = map[string]bool{
+	"properties": true, "patternProperties": true, "$defs": true, "definitions": true, "dependentSchemas": true,
+	"schemas": true, "parameters": true, "responses": true, "headers": true, "links": true, "callbacks": true,
+	"examples": true, "requestBodies": true, "securitySchemes": true, "securityDefinitions": true, "pathItems": true,
+	"paths": true, "webhooks": true, "encoding": true, "content": true, "variables": true, "mapping": true,
+	"scopes": true,
+}
+
+// dataKeys are keys holding arbitrary values (examples, defaults, enums...), which are never normalized. When
+// `examples` holds a map, it's a map of named examples instead.
+var dataKeys = map[string]bool{
+	"example": true, "examples": true, "default": true, "enum": true, "const": true, "value": true, "security": true,
+}
+
+// booleanSchemas are the keywords holding a schema that may be a boolean, and that mean `true` when absent.
+var booleanSchemas = map[string]bool{
+	"items": true, "additionalProperties": true, "unevaluatedItems": true, "unevaluatedProperties": true,
+}
+
+// NormalizeNodes rewrites semantically equivalent forms under root into a single canonical form, so documents that
+// only differ in how they're written hash the same and don't report changes when compared:
+//
+//   - a `type` holding a single type (`type: [string]`) is written as that type (`type: string`).
+//   - an empty `required` list, and `required: false`, are removed (the same as an absent `required`).
+//   - a `style`, `explode` or `allowReserved` holding the default value for a parameter, header or encoding is
+//     removed (`style: form` for a query parameter, `explode: false` for a header and so on).
+//   - an empty schema (`{}`) is written as the `true` boolean schema, and `{not: {}}` as the `false` boolean schema,
+//     for `items`, `additionalProperties`, `unevaluatedItems` and `unevaluatedProperties`. As `true` is the same as
+//     an absent schema for these keywords, it's removed.
+//
+// The keys of maps holding names (such as `properties` or `paths`) are never normalized, and neither are examples,
+// defaults, enums, constants or extensions. Aliases are not followed, as their anchors are normalized where they
+// are defined. Nodes are rewritten in place, keeping their positions.
+func NormalizeNodes(root *yaml.Node) {
+	if root == nil {
+		return
+	}
+	if root.Kind == yaml.DocumentNode {
+		for _, n := range root.Content {
+			normalizeObject(n, "")
+		}
+		return
+	}
+	normalizeObject(root, "")
+}
+
+// normalizeObject normalizes a map keyed by keywords, and everything under it. The kind is "header" or "encoding"
+// when the map is a header or an encoding, as neither can be told apart from its keys.
+func normalizeObject(node *yaml.Node, kind string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			normalizeObject(n, "")
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
+	normalizeStyle(node, kind)
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case strings.HasPrefix(key.Value, "x-"):
+		case namedMaps[key.Value] && value.Kind == yaml.MappingNode:
+			normalizeNamed(value, key.Value)
+		case dataKeys[key.Value]:
+		case key.Value == "type" && value.Kind == yaml.SequenceNode && len(value.Content) == 1 &&
+			value.Content[0].Kind == yaml.ScalarNode:
+			*value = *value.Content[0]
+		case key.Value == "required" && isEmptyOrFalse(value):
+			continue
+		case booleanSchemas[key.Value]:
+			normalizeBooleanSchema(value)
+			if IsNodeBoolValue(value) && value.Value == "true" {
+				continue
+			}
+		default:
+			normalizeObject(value, "")
+		}
+		content = append(content, key, value)
+	}
+	node.Content = content
+}
+
+// normalizeNamed normalizes the values of a map keyed by names, under a key such as `properties`.
+func normalizeNamed(node *yaml.Node, parentKey string) {
+	var kind string
+	switch parentKey {
+	case "headers", "encoding":
+		kind = strings.TrimSuffix(parentKey, "s")
+	case "mapping", "scopes":
+		return
+	}
+	for i := 1; i < len(node.Content); i += 2 {
+		if !strings.HasPrefix(node.Content[i-1].Value, "x-") {
+			normalizeObject(node.Content[i], kind)
+		}
+	}
+}
+
+// normalizeStyle removes a `style`, `explode` or `allowReserved` holding its default value, from a parameter (a map
+// with an `in` location), a header or an encoding.
+func normalizeStyle(node *yaml.Node, kind string) {
+	defaultStyle := kind
+	switch kind {
+	case "header":
+		defaultStyle = "simple"
+	case "encoding":
+		defaultStyle = "form"
+	default:
+		_, in := FindKeyNodeTop("in", node.Content)
+		if in == nil {
+			return
+		}
+		switch in.Value {
+		case "query", "cookie":
+			defaultStyle = "form"
+		case "path", "header":
+			defaultStyle = "simple"
+		default:
+			return
+		}
+	}
+	style := defaultStyle
+	if _, s := FindKeyNodeTop("style", node.Content); s != nil && s.Kind == yaml.ScalarNode {
+		style = s.Value
+	}
+	explode := "false"
+	if style == "form" {
+		explode = "true"
+	}
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind == yaml.ScalarNode && ((key.Value == "style" && value.Value == defaultStyle) ||
+			(key.Value == "explode" && IsNodeBoolValue(value) && value.Value == explode) ||
+			(key.Value == "allowReserved" && IsNodeBoolValue(value) && value.Value == "false")) {
+			continue
+		}
+		content = append(content, key, value)
+	}
+	node.Content = content
+}
+
+// normalizeBooleanSchema writes an empty schema as `true` and a schema of `{not: {}}` as `false`, or normalizes
+// the schema if it's neither.
+func normalizeBooleanSchema(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	switch {
+	case len(node.Content) == 0:
+		setBool(node, true)
+	case len(node.Content) == 2 && node.Content[0].Value == "not" && node.Content[1].Kind == yaml.MappingNode &&
+		len(node.Content[1].Content) == 0:
+		setBool(node, false)
+	default:
+		normalizeObject(node, "")
+	}
+}
+
+func isEmptyOrFalse(node *yaml.Node) bool {
+	return (node.Kind == yaml.SequenceNode && len(node.Content) == 0) ||
+		(IsNodeBoolValue(node) && node.Value == "false")
+}
+
+func setBool(node *yaml.Node, value bool) {
+	node.Kind = yaml.ScalarNode
+	node.Tag = "!!bool"
+	node.Style = 0
+	node.Content = nil
+	node.Value = "false"
+	if value {
+		node.Value = "true"
+	}
+}
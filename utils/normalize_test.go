@@ -0,0 +1,140 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func normalize(t *testing.T, yml string) string {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(yml), &root))
+	NormalizeNodes(&root)
+	out, err := yaml.Marshal(&root)
+	require.NoError(t, err)
+	return strings.TrimSpace(string(out))
+}
+
+func TestNormalizeNodes_Schemas(t *testing.T) {
+	yml := `components:
+  schemas:
+    Pet:
+      type: [object]
+      required: []
+      additionalProperties: {}
+      unevaluatedProperties:
+        not: {}
+      properties:
+        required:
+          type: [string, "null"]
+        items: {}
+        tags:
+          type: array
+          items:
+            type: [string]
+          unevaluatedItems: true
+      example:
+        type: [string]
+        required: []
+      x-schema:
+        type: [string]`
+
+	assert.Equal(t, `components:
+    schemas:
+        Pet:
+            type: object
+            unevaluatedProperties: false
+            properties:
+                required:
+                    type: [string, "null"]
+                items: {}
+                tags:
+                    type: array
+                    items:
+                        type: string
+            example:
+                type: [string]
+                required: []
+            x-schema:
+                type: [string]`, normalize(t, yml))
+}
+
+func TestNormalizeNodes_Parameters(t *testing.T) {
+	yml := `paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        style: simple
+        explode: false
+    get:
+      parameters:
+        - name: tags
+          in: query
+          required: false
+          style: form
+          explode: true
+          allowReserved: false
+        - name: color
+          in: query
+          style: pipeDelimited
+          explode: false
+        - name: session
+          in: cookie
+          explode: false
+      requestBody:
+        required: false
+        content:
+          application/x-www-form-urlencoded:
+            encoding:
+              tags:
+                style: form
+                explode: true
+      responses:
+        default:
+          description: ok
+          headers:
+            X-Rate-Limit:
+              style: simple
+              explode: false
+              required: false`
+
+	assert.Equal(t, `paths:
+    /pets/{id}:
+        parameters:
+            - name: id
+              in: path
+              required: true
+        get:
+            parameters:
+                - name: tags
+                  in: query
+                - name: color
+                  in: query
+                  style: pipeDelimited
+                - name: session
+                  in: cookie
+                  explode: false
+            requestBody:
+                content:
+                    application/x-www-form-urlencoded:
+                        encoding:
+                            tags: {}
+            responses:
+                default:
+                    description: ok
+                    headers:
+                        X-Rate-Limit: {}`, normalize(t, yml))
+}
+
+func TestNormalizeNodes_Nil(t *testing.T) {
+	NormalizeNodes(nil)
+	assert.Equal(t, "hello", normalize(t, "hello"))
+}
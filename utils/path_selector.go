@@ -0,0 +1,151 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RegexSelectorPrefix marks a path selector as a regular expression, rather than a glob.
+const RegexSelectorPrefix = "regex:"
+
+// PathSelector selects paths (and optionally methods) of an OpenAPI document, using a glob or a regular expression.
+// It is the shared selector engine used by anything that needs to pick out a subset of paths.
+//
+// Glob selectors match the whole path, using the following rules:
+//   - `*` matches anything within a single segment (`/users/*` matches `/users/{id}`, but not `/users/{id}/pets`)
+//   - `**` matches anything, across segments. A trailing `/**` also matches the parent (`/users/**` matches `/users`)
+//   - `?` matches a single character within a segment
+//   - everything else (including path parameters like `{id}`) is matched literally.
+//
+// Selectors prefixed with `regex:` are regular expressions (`regex:^/users/[^/]+$`), which match anywhere in the
+// path unless anchored.
+type PathSelector struct {
+	selector string
+	re       *regexp.Regexp
+	methods  map[string]bool
+}
+
+// CompilePathSelector creates a PathSelector from a glob or regex selector. If methods are supplied, only those
+// methods are selected (methods are case-insensitive), otherwise every method is. An error is returned if the
+// selector is an invalid regular expression.
+func CompilePathSelector(selector string, methods ...string) (*PathSelector, error) {
+	ps := &PathSelector{selector: selector}
+	var err error
+	if strings.HasPrefix(selector, RegexSelectorPrefix) {
+		ps.re, err = regexp.Compile(strings.TrimPrefix(selector, RegexSelectorPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid path selector '%s': %w", selector, err)
+		}
+	} else {
+		ps.re = regexp.MustCompile(globToRegex(selector))
+	}
+	if len(methods) > 0 {
+		ps.methods = make(map[string]bool, len(methods))
+		for _, m := range methods {
+			ps.methods[strings.ToLower(m)] = true
+		}
+	}
+	return ps, nil
+}
+
+// MatchPath returns true if the path is selected.
+func (ps *PathSelector) MatchPath(path string) bool {
+	return ps.re.MatchString(path)
+}
+
+// MatchMethod returns true if the method is selected.
+func (ps *PathSelector) MatchMethod(method string) bool {
+	return ps.methods == nil || ps.methods[strings.ToLower(method)]
+}
+
+// Match returns true if both the path and the method are selected.
+func (ps *PathSelector) Match(path, method string) bool {
+	return ps.MatchMethod(method) && ps.MatchPath(path)
+}
+
+// String returns the selector the PathSelector was compiled from.
+func (ps *PathSelector) String() string {
+	return ps.selector
+}
+
+// PathFilter decides which paths of a document are kept, using include and exclude path selectors (see
+// PathSelector). A path is kept if it matches any include selector (or there are none), and no exclude selector. A
+// nil PathFilter keeps every path.
+type PathFilter struct {
+	include []*PathSelector
+	exclude []*PathSelector
+}
+
+// NewPathFilter compiles the include and exclude selectors into a PathFilter. Returns nil (which keeps every path)
+// when there are no selectors at all, or an error if a selector is an invalid regular expression.
+func NewPathFilter(include, exclude []string) (*PathFilter, error) {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil, nil
+	}
+	f := new(PathFilter)
+	for _, s := range include {
+		ps, err := CompilePathSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		f.include = append(f.include, ps)
+	}
+	for _, s := range exclude {
+		ps, err := CompilePathSelector(s)
+		if err != nil {
+			return nil, err
+		}
+		f.exclude = append(f.exclude, ps)
+	}
+	return f, nil
+}
+
+// Keep returns true if the path is kept by the filter.
+func (f *PathFilter) Keep(path string) bool {
+	if f == nil {
+		return true
+	}
+	for _, ps := range f.exclude {
+		if ps.MatchPath(path) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, ps := range f.include {
+		if ps.MatchPath(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func globToRegex(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '/' && strings.HasPrefix(glob[i:], "/**") && (i+3 == len(glob) || glob[i+3] == '/'):
+			// zero or more segments.
+			sb.WriteString("(/.*)?")
+			i += 2
+		case c == '*' && i+1 < len(glob) && glob[i+1] == '*':
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathSelector_Glob(t *testing.T) {
+	tests := []struct {
+		selector string
+		path     string
+		match    bool
+	}{
+		{"/users", "/users", true},
+		{"/users", "/users/1", false},
+		{"/users/*", "/users/{id}", true},
+		{"/users/*", "/users/{id}/pets", false},
+		{"/users/*", "/users", false},
+		{"/users/**", "/users", true},
+		{"/users/**", "/users/{id}/pets", true},
+		{"/users/**", "/usersx", false},
+		{"/**/pets", "/pets", true},
+		{"/**/pets", "/users/{id}/pets", true},
+		{"/**/pets", "/users/{id}/pets/1", false},
+		{"/users/**pets", "/users/mypets", true},
+		{"/users/{id}", "/users/{id}", true},
+		{"/users/{id}", "/users/1", false},
+		{"/v?/users", "/v1/users", true},
+		{"/v?/users", "/v10/users", false},
+		{"/a.b", "/axb", false},
+		{"**", "/anything/at/all", true},
+	}
+	for _, tt := range tests {
+		ps, err := CompilePathSelector(tt.selector)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.match, ps.MatchPath(tt.path), "%s -> %s", tt.selector, tt.path)
+	}
+}
+
+func TestPathSelector_Regex(t *testing.T) {
+	ps, err := CompilePathSelector("regex:^/users/[^/]+$")
+	assert.NoError(t, err)
+	assert.True(t, ps.MatchPath("/users/{id}"))
+	assert.False(t, ps.MatchPath("/users/{id}/pets"))
+	assert.Equal(t, "regex:^/users/[^/]+$", ps.String())
+
+	ps, err = CompilePathSelector("regex:pets")
+	assert.NoError(t, err)
+	assert.True(t, ps.MatchPath("/users/{id}/pets/1"))
+
+	_, err = CompilePathSelector("regex:[")
+	assert.EqualError(t, err, "invalid path selector 'regex:[': error parsing regexp: missing closing ]: `[`")
+}
+
+func TestPathSelector_Methods(t *testing.T) {
+	ps, _ := CompilePathSelector("/users/**")
+	assert.True(t, ps.MatchMethod("delete"))
+
+	ps, _ = CompilePathSelector("/users/**", "GET", "post")
+	assert.True(t, ps.MatchMethod("get"))
+	assert.True(t, ps.MatchMethod("POST"))
+	assert.False(t, ps.MatchMethod("delete"))
+	assert.True(t, ps.Match("/users/1", "get"))
+	assert.False(t, ps.Match("/pets", "get"))
+	assert.False(t, ps.Match("/users/1", "put"))
+}
+
+func TestPathFilter(t *testing.T) {
+	f, err := NewPathFilter([]string{"/users/**", "regex:^/orders/[0-9]+$"}, []string{"/users/*/secrets"})
+	require.NoError(t, err)
+	assert.True(t, f.Keep("/users"))
+	assert.True(t, f.Keep("/users/{id}"))
+	assert.True(t, f.Keep("/orders/42"))
+	assert.False(t, f.Keep("/users/{id}/secrets"))
+	assert.False(t, f.Keep("/orders/{id}"))
+	assert.False(t, f.Keep("/pets"))
+
+	// only excluding keeps everything else.
+	f, err = NewPathFilter(nil, []string{"/internal/**"})
+	require.NoError(t, err)
+	assert.True(t, f.Keep("/pets"))
+	assert.False(t, f.Keep("/internal/health"))
+
+	f, err = NewPathFilter(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, f)
+	assert.True(t, f.Keep("/anything"))
+
+	_, err = NewPathFilter([]string{"regex:("}, nil)
+	assert.Error(t, err)
+	_, err = NewPathFilter(nil, []string{"regex:("})
+	assert.Error(t, err)
+}
@@ -28,6 +28,9 @@ const (
 	// AsyncApi is used by akk AsyncAPI docs, all versions.
 	AsyncApi = "asyncapi"
 
+	// Arazzo is used by all Arazzo (workflow) docs, all versions.
+	Arazzo = "arazzo"
+
 	PascalCase Case = iota
 	CamelCase
 	ScreamingSnakeCase
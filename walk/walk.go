@@ -0,0 +1,370 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package walk visits every schema of an OpenAPI 3+ document, along with its keyword location: the path of
+// keywords, names and indexes leading from the root of the document to the schema, such as
+// `components/schemas/Pet/properties/tags/items`.
+//
+// Schemas are visited depth first, in the order of the document, component schemas first (so a schema is located
+// where it's defined rather than where it's first used). Local references (`#/...`) are visited, but not walked
+// into: what they reference is walked where it's defined. References to other files are walked into the first time
+// they're reached, and located from there. Every schema is walked once, so a walk always terminates, however
+// circular the schemas are.
+//
+// A walk can be stopped and resumed:
+//
+//	w := walk.NewWalker(&model.Model)
+//	for s := w.Next(); s != nil; s = w.Next() {
+//		if s.Schema != nil && s.Schema.Deprecated != nil && *s.Schema.Deprecated {
+//			w.SkipChildren()
+//		}
+//	}
+package walk
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Action tells a Walker what to do after visiting a schema.
+type Action int
+
+const (
+	// Continue walks the schemas the schema visited is made of.
+	Continue Action = iota
+	// SkipChildren moves on to the next schema, without walking the schemas the schema visited is made of.
+	SkipChildren
+	// Stop stops the walk. The walk resumes when Walk (or Next) is called again.
+	Stop
+)
+
+// Location is a keyword location, one segment per keyword, name or index.
+type Location []string
+
+// String returns the location with its segments escaped as in a JSON Pointer, and separated by a `/`, such as
+// `paths/~1pets/get/responses/200/content/application~1json/schema`.
+func (l Location) String() string {
+	return strings.TrimPrefix(utils.BuildJSONPointer(l), "/")
+}
+
+// JSONPointer returns the location as a JSON Pointer URI fragment, such as `#/components/schemas/Pet`.
+func (l Location) JSONPointer() string {
+	return "#" + utils.BuildJSONPointer(l)
+}
+
+// JSONPath returns the location as a JSON Path, such as `$.components.schemas['Pet']`.
+func (l Location) JSONPath() string {
+	_, path := utils.ConvertComponentIdIntoFriendlyPathSearch(l.JSONPointer())
+	return path
+}
+
+// Schema is a schema visited by a walk.
+type Schema struct {
+	Location Location
+	Proxy    *base.SchemaProxy
+	Schema   *base.Schema // the schema, or the schema referenced. nil if it can't be built (see Proxy.GetBuildError).
+	Parent   *Schema      // the schema this schema is part of, nil for the schema of a parameter, a media type...
+	Depth    int          // the number of schemas this schema is nested in.
+}
+
+// Walker walks the schemas of a document. Walkers are not safe for concurrent use.
+type Walker struct {
+	stack    []*Schema
+	current  *Schema
+	skip     bool
+	visited  map[*yaml.Node]struct{}
+	expanded map[any]struct{}
+}
+
+// NewWalker creates a Walker, ready to walk every schema of a document.
+func NewWalker(doc *v3.Document) *Walker {
+	w := &Walker{visited: make(map[*yaml.Node]struct{}), expanded: make(map[any]struct{})}
+	if doc == nil {
+		return w
+	}
+	r := &roots{pathItems: make(map[*v3.PathItem]struct{})}
+	if c := doc.Components; c != nil {
+		for name, s := range c.Schemas.FromOldest() {
+			r.schema(s, Location{"components", "schemas", name})
+		}
+		for name, p := range c.Parameters.FromOldest() {
+			r.parameter(p, Location{"components", "parameters", name})
+		}
+		for name, h := range c.Headers.FromOldest() {
+			r.header(h, Location{"components", "headers", name})
+		}
+		for name, rb := range c.RequestBodies.FromOldest() {
+			if rb != nil {
+				r.content(rb.Content, Location{"components", "requestBodies", name})
+			}
+		}
+		for name, resp := range c.Responses.FromOldest() {
+			r.response(resp, Location{"components", "responses", name})
+		}
+		for name, cb := range c.Callbacks.FromOldest() {
+			r.callback(cb, Location{"components", "callbacks", name})
+		}
+		for name, pi := range c.PathItems.FromOldest() {
+			r.pathItem(pi, Location{"components", "pathItems", name})
+		}
+	}
+	if doc.Paths != nil {
+		for path, pi := range doc.Paths.PathItems.FromOldest() {
+			r.pathItem(pi, Location{"paths", path})
+		}
+	}
+	for name, pi := range doc.Webhooks.FromOldest() {
+		r.pathItem(pi, Location{"webhooks", name})
+	}
+	for i := len(r.schemas) - 1; i >= 0; i-- {
+		w.stack = append(w.stack, r.schemas[i])
+	}
+	return w
+}
+
+// Schemas walks every schema of a document, calling visit for each one, until visit returns Stop.
+func Schemas(doc *v3.Document, visit func(*Schema) Action) {
+	NewWalker(doc).Walk(visit)
+}
+
+// Walk calls visit for every schema left to walk, until visit returns Stop. It returns true once every schema has
+// been walked, and false if the walk was stopped, in which case calling Walk again resumes it.
+func (w *Walker) Walk(visit func(*Schema) Action) bool {
+	for s := w.Next(); s != nil; s = w.Next() {
+		switch visit(s) {
+		case SkipChildren:
+			w.SkipChildren()
+		case Stop:
+			return false
+		}
+	}
+	return true
+}
+
+// Next returns the next schema of the walk, nil once every schema has been walked.
+func (w *Walker) Next() *Schema {
+	if w.current != nil && !w.skip {
+		w.children(w.current)
+	}
+	w.current, w.skip = nil, false
+	for len(w.stack) > 0 {
+		s := w.stack[len(w.stack)-1]
+		w.stack = w.stack[:len(w.stack)-1]
+		// the same schema can be reached twice when the model resolves a reference to a parameter, a path item...
+		if n := proxyNode(s.Proxy); n != nil {
+			if _, ok := w.visited[n]; ok {
+				continue
+			}
+			w.visited[n] = struct{}{}
+		}
+		s.Schema = s.Proxy.Schema()
+		w.current = s
+		return s
+	}
+	return nil
+}
+
+// proxyNode returns the node a schema is defined by, the `$ref` node of a reference.
+func proxyNode(sp *base.SchemaProxy) *yaml.Node {
+	l := sp.GoLow()
+	if l == nil {
+		return nil
+	}
+	if l.IsReference() {
+		return l.GetReferenceNode()
+	}
+	return l.GetValueNode()
+}
+
+// SkipChildren skips the schemas the schema last returned by Next is made of.
+func (w *Walker) SkipChildren() {
+	w.skip = true
+}
+
+// children pushes the schemas a schema is made of on the stack, in reverse, so they're walked in order.
+func (w *Walker) children(parent *Schema) {
+	s := parent.Schema
+	if s == nil || (parent.Proxy.IsReference() && strings.HasPrefix(parent.Proxy.GetReference(), "#")) {
+		return
+	}
+	var key any = s
+	if l := s.GoLow(); l != nil && l.RootNode != nil {
+		key = l.RootNode
+	}
+	if _, ok := w.expanded[key]; ok {
+		return
+	}
+	w.expanded[key] = struct{}{}
+
+	var kids []*Schema
+	add := func(sp *base.SchemaProxy, segments ...string) {
+		if sp == nil {
+			return
+		}
+		kids = append(kids, &Schema{
+			Location: with(parent.Location, segments...),
+			Proxy:    sp,
+			Parent:   parent,
+			Depth:    parent.Depth + 1,
+		})
+	}
+	for _, m := range []struct {
+		keyword string
+		schemas *orderedmap.Map[string, *base.SchemaProxy]
+	}{
+		{"properties", s.Properties}, {"patternProperties", s.PatternProperties},
+		{"dependentSchemas", s.DependentSchemas}, {"$defs", s.Defs},
+	} {
+		for name, sp := range m.schemas.FromOldest() {
+			add(sp, m.keyword, name)
+		}
+	}
+	for _, l := range []struct {
+		keyword string
+		schemas []*base.SchemaProxy
+	}{{"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}, {"prefixItems", s.PrefixItems}} {
+		for i, sp := range l.schemas {
+			add(sp, l.keyword, strconv.Itoa(i))
+		}
+	}
+	for _, d := range []struct {
+		keyword string
+		value   *base.DynamicValue[*base.SchemaProxy, bool]
+	}{
+		{"items", s.Items}, {"additionalProperties", s.AdditionalProperties},
+		{"unevaluatedItems", s.UnevaluatedItems}, {"unevaluatedProperties", s.UnevaluatedProperties},
+	} {
+		if d.value != nil && d.value.IsA() {
+			add(d.value.A, d.keyword)
+		}
+	}
+	add(s.Contains, "contains")
+	add(s.PropertyNames, "propertyNames")
+	add(s.If, "if")
+	add(s.Then, "then")
+	add(s.Else, "else")
+	add(s.Not, "not")
+
+	for i := len(kids) - 1; i >= 0; i-- {
+		w.stack = append(w.stack, kids[i])
+	}
+}
+
+// roots collects the schemas of the objects of a document that aren't schemas: parameters, media types, headers...
+type roots struct {
+	schemas   []*Schema
+	pathItems map[*v3.PathItem]struct{}
+}
+
+func (r *roots) schema(sp *base.SchemaProxy, loc Location) {
+	if sp != nil {
+		r.schemas = append(r.schemas, &Schema{Location: loc, Proxy: sp})
+	}
+}
+
+func (r *roots) pathItem(pi *v3.PathItem, loc Location) {
+	if pi == nil {
+		return
+	}
+	if _, ok := r.pathItems[pi]; ok {
+		return
+	}
+	r.pathItems[pi] = struct{}{}
+	for i, p := range pi.Parameters {
+		r.parameter(p, with(loc, "parameters", strconv.Itoa(i)))
+	}
+	for method, op := range pi.GetOperations().FromOldest() {
+		if pi.AdditionalOperations != nil && pi.AdditionalOperations.GetOrZero(method) == op {
+			r.operation(op, with(loc, "additionalOperations", method))
+			continue
+		}
+		r.operation(op, with(loc, method))
+	}
+}
+
+func (r *roots) operation(op *v3.Operation, loc Location) {
+	if op == nil {
+		return
+	}
+	for i, p := range op.Parameters {
+		r.parameter(p, with(loc, "parameters", strconv.Itoa(i)))
+	}
+	if op.RequestBody != nil {
+		r.content(op.RequestBody.Content, with(loc, "requestBody"))
+	}
+	if op.Responses != nil {
+		for code, resp := range op.Responses.Codes.FromOldest() {
+			r.response(resp, with(loc, "responses", code))
+		}
+		r.response(op.Responses.Default, with(loc, "responses", "default"))
+	}
+	for name, cb := range op.Callbacks.FromOldest() {
+		r.callback(cb, with(loc, "callbacks", name))
+	}
+}
+
+func (r *roots) callback(cb *v3.Callback, loc Location) {
+	if cb == nil {
+		return
+	}
+	for expression, pi := range cb.Expression.FromOldest() {
+		r.pathItem(pi, with(loc, expression))
+	}
+}
+
+func (r *roots) parameter(p *v3.Parameter, loc Location) {
+	if p == nil {
+		return
+	}
+	r.schema(p.Schema, with(loc, "schema"))
+	r.content(p.Content, loc)
+}
+
+func (r *roots) header(h *v3.Header, loc Location) {
+	if h == nil {
+		return
+	}
+	r.schema(h.Schema, with(loc, "schema"))
+	r.content(h.Content, loc)
+}
+
+func (r *roots) response(resp *v3.Response, loc Location) {
+	if resp == nil {
+		return
+	}
+	for name, h := range resp.Headers.FromOldest() {
+		r.header(h, with(loc, "headers", name))
+	}
+	r.content(resp.Content, loc)
+}
+
+func (r *roots) content(content *orderedmap.Map[string, *v3.MediaType], loc Location) {
+	for mediaType, mt := range content.FromOldest() {
+		if mt == nil {
+			continue
+		}
+		mtLoc := with(loc, "content", mediaType)
+		r.schema(mt.Schema, with(mtLoc, "schema"))
+		r.schema(mt.ItemSchema, with(mtLoc, "itemSchema"))
+		for name, enc := range mt.Encoding.FromOldest() {
+			if enc != nil {
+				for header, h := range enc.Headers.FromOldest() {
+					r.header(h, with(mtLoc, "encoding", name, "headers", header))
+				}
+			}
+		}
+	}
+}
+
+// with returns a copy of a location, with segments appended.
+func with(loc Location, segments ...string) Location {
+	l := make(Location, len(loc), len(loc)+len(segments))
+	copy(l, loc)
+	return append(l, segments...)
+}
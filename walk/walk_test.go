@@ -0,0 +1,214 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package walk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var walkSpec = `openapi: 3.1.0
+info:
+  title: Walk
+  version: 1.0.0
+paths:
+  /pets:
+    parameters:
+      - $ref: '#/components/parameters/Limit'
+    get:
+      responses:
+        "200":
+          description: pets
+          headers:
+            X-Rate:
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tags:
+          type: array
+          items:
+            type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+      allOf:
+        - required: [tags]
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+
+func buildWalkModel(t *testing.T, spec string, config *datamodel.DocumentConfiguration) *v3.Document {
+	doc, err := libopenapi.NewDocumentWithConfiguration([]byte(spec), config)
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	require.Empty(t, errs)
+	return &model.Model
+}
+
+func locations(w *Walker, visit func(*Schema) Action) []string {
+	var locs []string
+	w.Walk(func(s *Schema) Action {
+		locs = append(locs, s.Location.String())
+		if visit != nil {
+			return visit(s)
+		}
+		return Continue
+	})
+	return locs
+}
+
+func TestSchemas(t *testing.T) {
+	model := buildWalkModel(t, walkSpec, nil)
+
+	var visited []*Schema
+	Schemas(model, func(s *Schema) Action {
+		visited = append(visited, s)
+		return Continue
+	})
+	var locs []string
+	for _, s := range visited {
+		locs = append(locs, s.Location.String())
+	}
+	assert.Equal(t, []string{
+		"components/schemas/Pet",
+		"components/schemas/Pet/properties/tags",
+		"components/schemas/Pet/properties/tags/items",
+		"components/schemas/Pet/properties/owner",
+		"components/schemas/Pet/allOf/0",
+		"components/schemas/Owner",
+		"components/schemas/Owner/properties/pets",
+		"components/schemas/Owner/properties/pets/items",
+		"components/parameters/Limit/schema",
+		"paths/~1pets/get/responses/200/headers/X-Rate/schema",
+		"paths/~1pets/get/responses/200/content/application~1json/schema",
+		"paths/~1pets/get/responses/200/content/application~1json/schema/items",
+	}, locs)
+
+	items := visited[2]
+	assert.Equal(t, []string{"string"}, items.Schema.Type)
+	assert.Equal(t, 2, items.Depth)
+	assert.Same(t, visited[1], items.Parent)
+	assert.Same(t, visited[0], items.Parent.Parent)
+	assert.Nil(t, visited[0].Parent)
+	assert.Equal(t, "#/components/schemas/Pet/properties/tags/items", items.Location.JSONPointer())
+	assert.Equal(t, "$.components.schemas['Pet'].properties['tags']['items']", items.Location.JSONPath())
+
+	// references are visited, and their schema is the schema referenced.
+	owner := visited[3]
+	assert.True(t, owner.Proxy.IsReference())
+	assert.Equal(t, []string{"object"}, owner.Schema.Type)
+	assert.Equal(t, "#/paths/~1pets/get/responses/200/content/application~1json/schema/items",
+		visited[11].Location.JSONPointer())
+}
+
+func TestWalker_SkipChildrenAndResume(t *testing.T) {
+	model := buildWalkModel(t, walkSpec, nil)
+
+	w := NewWalker(model)
+	first := w.Next()
+	require.NotNil(t, first)
+	assert.Equal(t, "components/schemas/Pet", first.Location.String())
+	w.SkipChildren()
+
+	var stopped *Schema
+	finished := w.Walk(func(s *Schema) Action {
+		if s.Location.String() == "components/schemas/Owner/properties/pets" {
+			stopped = s
+			return Stop
+		}
+		return Continue
+	})
+	assert.False(t, finished)
+	require.NotNil(t, stopped)
+
+	// the walk resumes with the schemas the schema it stopped on is made of.
+	var skipped bool
+	assert.Equal(t, []string{
+		"components/schemas/Owner/properties/pets/items",
+		"components/parameters/Limit/schema",
+		"paths/~1pets/get/responses/200/headers/X-Rate/schema",
+		"paths/~1pets/get/responses/200/content/application~1json/schema",
+	}, locations(w, func(s *Schema) Action {
+		if s.Schema.Items != nil {
+			skipped = true
+			return SkipChildren
+		}
+		return Continue
+	}))
+	assert.True(t, skipped)
+	assert.Nil(t, w.Next())
+	assert.True(t, w.Walk(func(*Schema) Action { return Stop }))
+}
+
+func TestWalker_ExternalCircular(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "node.yaml"), []byte(`type: object
+properties:
+  next:
+    $ref: 'node.yaml'
+  values:
+    type: array
+    items:
+      type: string`), 0o644))
+
+	model := buildWalkModel(t, `openapi: 3.1.0
+info:
+  title: Walk
+  version: 1.0.0
+paths:
+  /nodes:
+    get:
+      parameters:
+        - name: node
+          in: query
+          content:
+            application/json:
+              schema:
+                $ref: 'node.yaml'
+      responses:
+        "200":
+          description: ok`, &datamodel.DocumentConfiguration{
+		BasePath:            dir,
+		AllowFileReferences: true,
+	})
+
+	assert.Equal(t, []string{
+		"paths/~1nodes/get/parameters/0/content/application~1json/schema",
+		"paths/~1nodes/get/parameters/0/content/application~1json/schema/properties/next",
+		"paths/~1nodes/get/parameters/0/content/application~1json/schema/properties/values",
+		"paths/~1nodes/get/parameters/0/content/application~1json/schema/properties/values/items",
+	}, locations(NewWalker(model), nil))
+}
+
+func TestNewWalker_NoDocument(t *testing.T) {
+	assert.Nil(t, NewWalker(nil).Next())
+	assert.Empty(t, locations(NewWalker(&v3.Document{}), nil))
+}
@@ -0,0 +1,561 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// ArazzoChanges represents changes made between two Arazzo (workflow) documents.
+type ArazzoChanges struct {
+	*PropertyChanges
+	InfoChanges              *InfoChanges                         `json:"info,omitempty" yaml:"info,omitempty"`
+	SourceDescriptionChanges map[string]*SourceDescriptionChanges `json:"sourceDescriptions,omitempty" yaml:"sourceDescriptions,omitempty"`
+	WorkflowChanges          map[string]*WorkflowChanges          `json:"workflows,omitempty" yaml:"workflows,omitempty"`
+	ExtensionChanges         *ExtensionChanges                    `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+// GetAllChanges returns a slice of all changes made between Arazzo documents
+func (a *ArazzoChanges) GetAllChanges() []*Change {
+	var changes []*Change
+	changes = append(changes, a.Changes...)
+	if a.InfoChanges != nil {
+		changes = append(changes, a.InfoChanges.GetAllChanges()...)
+	}
+	for k := range a.SourceDescriptionChanges {
+		changes = append(changes, a.SourceDescriptionChanges[k].GetAllChanges()...)
+	}
+	for k := range a.WorkflowChanges {
+		changes = append(changes, a.WorkflowChanges[k].GetAllChanges()...)
+	}
+	if a.ExtensionChanges != nil {
+		changes = append(changes, a.ExtensionChanges.GetAllChanges()...)
+	}
+	return changes
+}
+
+// TotalChanges returns a count of everything that changed between Arazzo documents.
+func (a *ArazzoChanges) TotalChanges() int {
+	if a == nil {
+		return 0
+	}
+	c := a.PropertyChanges.TotalChanges()
+	if a.InfoChanges != nil {
+		c += a.InfoChanges.TotalChanges()
+	}
+	for k := range a.SourceDescriptionChanges {
+		c += a.SourceDescriptionChanges[k].TotalChanges()
+	}
+	for k := range a.WorkflowChanges {
+		c += a.WorkflowChanges[k].TotalChanges()
+	}
+	if a.ExtensionChanges != nil {
+		c += a.ExtensionChanges.TotalChanges()
+	}
+	return c
+}
+
+// TotalBreakingChanges returns a count of all breaking changes made between Arazzo documents.
+func (a *ArazzoChanges) TotalBreakingChanges() int {
+	if a == nil {
+		return 0
+	}
+	c := a.PropertyChanges.TotalBreakingChanges()
+	if a.InfoChanges != nil {
+		c += a.InfoChanges.TotalBreakingChanges()
+	}
+	for k := range a.SourceDescriptionChanges {
+		c += a.SourceDescriptionChanges[k].TotalBreakingChanges()
+	}
+	for k := range a.WorkflowChanges {
+		c += a.WorkflowChanges[k].TotalBreakingChanges()
+	}
+	return c
+}
+
+// CompareArazzoDocuments compares a left (original) and a right (new) Arazzo document for changes. Source
+// descriptions are matched by name and workflows by workflowId. Removing a source description or a workflow, or
+// changing where a source description points to, is a breaking change. Returns nil if nothing changed.
+func CompareArazzoDocuments(l, r *arazzo.Document) *ArazzoChanges {
+	if l == nil || r == nil || low.AreEqual(l, r) {
+		return nil
+	}
+	var changes []*Change
+	var props []*PropertyCheck
+
+	props = append(props, &PropertyCheck{
+		LeftNode:  l.Arazzo.ValueNode,
+		RightNode: r.Arazzo.ValueNode,
+		Label:     arazzo.ArazzoLabel,
+		Changes:   &changes,
+		Breaking:  false,
+		Original:  l,
+		New:       r,
+	})
+	CheckProperties(props)
+
+	ac := new(ArazzoChanges)
+
+	switch {
+	case !l.Info.IsEmpty() && !r.Info.IsEmpty():
+		if !low.AreEqual(l.Info.Value, r.Info.Value) {
+			ac.InfoChanges = CompareInfo(l.Info.Value, r.Info.Value)
+		}
+	case l.Info.IsEmpty() && !r.Info.IsEmpty():
+		CreateChange(&changes, PropertyAdded, arazzo.InfoLabel,
+			nil, r.Info.ValueNode, false, nil, r.Info.Value)
+	case !l.Info.IsEmpty() && r.Info.IsEmpty():
+		CreateChange(&changes, PropertyRemoved, arazzo.InfoLabel,
+			l.Info.ValueNode, nil, true, l.Info.Value, nil)
+	}
+
+	// source descriptions, matched by name.
+	sourceChanges := make(map[string]*SourceDescriptionChanges)
+	lSources, rSources, order := keyArazzoObjects(l.SourceDescriptions.Value, r.SourceDescriptions.Value,
+		func(s *arazzo.SourceDescription) string { return s.Name.Value })
+	for _, k := range order {
+		ls, rs := lSources[k], rSources[k]
+		switch {
+		case rs == nil:
+			CreateChange(&changes, ObjectRemoved, arazzo.SourceDescriptionsLabel,
+				ls.Name.ValueNode, nil, true, ls, nil)
+		case ls == nil:
+			CreateChange(&changes, ObjectAdded, arazzo.SourceDescriptionsLabel,
+				nil, rs.Name.ValueNode, false, nil, rs)
+		case !low.AreEqual(ls, rs):
+			if sc := CompareSourceDescriptions(ls, rs); sc != nil {
+				sourceChanges[k] = sc
+			}
+		}
+	}
+	if len(sourceChanges) > 0 {
+		ac.SourceDescriptionChanges = sourceChanges
+	}
+
+	// workflows, matched by workflowId.
+	workflowChanges := make(map[string]*WorkflowChanges)
+	lFlows, rFlows, order := keyArazzoObjects(l.Workflows.Value, r.Workflows.Value,
+		func(w *arazzo.Workflow) string { return w.WorkflowId.Value })
+	for _, k := range order {
+		lw, rw := lFlows[k], rFlows[k]
+		switch {
+		case rw == nil:
+			CreateChange(&changes, ObjectRemoved, arazzo.WorkflowsLabel,
+				lw.WorkflowId.ValueNode, nil, true, lw, nil)
+		case lw == nil:
+			CreateChange(&changes, ObjectAdded, arazzo.WorkflowsLabel,
+				nil, rw.WorkflowId.ValueNode, false, nil, rw)
+		case !low.AreEqual(lw, rw):
+			if wc := CompareWorkflows(lw, rw); wc != nil {
+				workflowChanges[k] = wc
+			}
+		}
+	}
+	if len(workflowChanges) > 0 {
+		ac.WorkflowChanges = workflowChanges
+	}
+
+	// components are only referenced from inside the document, so any change is recorded as a single modification.
+	switch {
+	case !l.Components.IsEmpty() && !r.Components.IsEmpty():
+		if !low.AreEqual(l.Components.Value, r.Components.Value) {
+			CreateChange(&changes, Modified, arazzo.ComponentsLabel,
+				l.Components.ValueNode, r.Components.ValueNode, false, l.Components.Value, r.Components.Value)
+		}
+	case l.Components.IsEmpty() && !r.Components.IsEmpty():
+		CreateChange(&changes, PropertyAdded, arazzo.ComponentsLabel,
+			nil, r.Components.ValueNode, false, nil, r.Components.Value)
+	case !l.Components.IsEmpty() && r.Components.IsEmpty():
+		CreateChange(&changes, PropertyRemoved, arazzo.ComponentsLabel,
+			l.Components.ValueNode, nil, false, l.Components.Value, nil)
+	}
+
+	ac.PropertyChanges = NewPropertyChanges(changes)
+	ac.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	if ac.TotalChanges() <= 0 {
+		return nil
+	}
+	return ac
+}
+
+// SourceDescriptionChanges represents changes made to an Arazzo Source Description object.
+type SourceDescriptionChanges struct {
+	*PropertyChanges
+	ExtensionChanges *ExtensionChanges `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+// GetAllChanges returns a slice of all changes made between Source Description objects
+func (s *SourceDescriptionChanges) GetAllChanges() []*Change {
+	var changes []*Change
+	changes = append(changes, s.Changes...)
+	if s.ExtensionChanges != nil {
+		changes = append(changes, s.ExtensionChanges.GetAllChanges()...)
+	}
+	return changes
+}
+
+// TotalChanges returns a count of everything that changed
+func (s *SourceDescriptionChanges) TotalChanges() int {
+	t := s.PropertyChanges.TotalChanges()
+	if s.ExtensionChanges != nil {
+		t += s.ExtensionChanges.TotalChanges()
+	}
+	return t
+}
+
+// TotalBreakingChanges returns a count of all breaking changes made between Source Description objects.
+func (s *SourceDescriptionChanges) TotalBreakingChanges() int {
+	return s.PropertyChanges.TotalBreakingChanges()
+}
+
+// CompareSourceDescriptions compares a left (original) and a right (new) Source Description for changes. Changing
+// the URL or type of a source description is a breaking change. Returns nil if nothing changed.
+func CompareSourceDescriptions(l, r *arazzo.SourceDescription) *SourceDescriptionChanges {
+	var changes []*Change
+	var props []*PropertyCheck
+
+	for _, p := range []struct {
+		label       string
+		left, right low.NodeReference[string]
+	}{
+		{arazzo.URLLabel, l.URL, r.URL},
+		{arazzo.TypeLabel, l.Type, r.Type},
+	} {
+		props = append(props, &PropertyCheck{
+			LeftNode:  p.left.ValueNode,
+			RightNode: p.right.ValueNode,
+			Label:     p.label,
+			Changes:   &changes,
+			Breaking:  true,
+			Original:  l,
+			New:       r,
+		})
+	}
+	CheckProperties(props)
+
+	sc := new(SourceDescriptionChanges)
+	sc.PropertyChanges = NewPropertyChanges(changes)
+	sc.ExtensionChanges = CheckExtensions(l, r)
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
+
+// WorkflowChanges represents changes made to an Arazzo Workflow object.
+type WorkflowChanges struct {
+	*PropertyChanges
+	InputChanges     *SchemaChanges          `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	StepChanges      map[string]*StepChanges `json:"steps,omitempty" yaml:"steps,omitempty"`
+	ExtensionChanges *ExtensionChanges       `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+// GetAllChanges returns a slice of all changes made between Workflow objects
+func (w *WorkflowChanges) GetAllChanges() []*Change {
+	var changes []*Change
+	changes = append(changes, w.Changes...)
+	if w.InputChanges != nil {
+		changes = append(changes, w.InputChanges.GetAllChanges()...)
+	}
+	for k := range w.StepChanges {
+		changes = append(changes, w.StepChanges[k].GetAllChanges()...)
+	}
+	if w.ExtensionChanges != nil {
+		changes = append(changes, w.ExtensionChanges.GetAllChanges()...)
+	}
+	return changes
+}
+
+// TotalChanges returns a count of everything that changed
+func (w *WorkflowChanges) TotalChanges() int {
+	t := w.PropertyChanges.TotalChanges()
+	if w.InputChanges != nil {
+		t += w.InputChanges.TotalChanges()
+	}
+	for k := range w.StepChanges {
+		t += w.StepChanges[k].TotalChanges()
+	}
+	if w.ExtensionChanges != nil {
+		t += w.ExtensionChanges.TotalChanges()
+	}
+	return t
+}
+
+// TotalBreakingChanges returns a count of all breaking changes made between Workflow objects.
+func (w *WorkflowChanges) TotalBreakingChanges() int {
+	t := w.PropertyChanges.TotalBreakingChanges()
+	if w.InputChanges != nil {
+		t += w.InputChanges.TotalBreakingChanges()
+	}
+	for k := range w.StepChanges {
+		t += w.StepChanges[k].TotalBreakingChanges()
+	}
+	return t
+}
+
+// CompareWorkflows compares a left (original) and a right (new) Workflow for changes. Steps are matched by stepId,
+// parameters by location and name, and actions by name. Changes to the inputs follow the schema rules, removing an
+// output or a dependency is breaking, everything else describes how the workflow runs and is not. Returns nil if
+// nothing changed.
+func CompareWorkflows(l, r *arazzo.Workflow) *WorkflowChanges {
+	var changes []*Change
+	var props []*PropertyCheck
+
+	for _, p := range []struct {
+		label       string
+		left, right low.NodeReference[string]
+	}{
+		{arazzo.SummaryLabel, l.Summary, r.Summary},
+		{arazzo.DescriptionLabel, l.Description, r.Description},
+	} {
+		props = append(props, &PropertyCheck{
+			LeftNode:  p.left.ValueNode,
+			RightNode: p.right.ValueNode,
+			Label:     p.label,
+			Changes:   &changes,
+			Breaking:  false,
+			Original:  l,
+			New:       r,
+		})
+	}
+	CheckProperties(props)
+
+	wc := new(WorkflowChanges)
+	if !l.Inputs.IsEmpty() || !r.Inputs.IsEmpty() {
+		wc.InputChanges = CompareSchemas(l.Inputs.Value, r.Inputs.Value)
+	}
+
+	ExtractStringValueSliceChanges(l.DependsOn.Value, r.DependsOn.Value, &changes, arazzo.DependsOnLabel, true)
+	compareArazzoObjects(arazzo.ParametersLabel, l.Parameters.Value, r.Parameters.Value, parameterKey, &changes)
+	compareArazzoObjects(arazzo.SuccessActionsLabel, l.SuccessActions.Value, r.SuccessActions.Value,
+		successActionKey, &changes)
+	compareArazzoObjects(arazzo.FailureActionsLabel, l.FailureActions.Value, r.FailureActions.Value,
+		failureActionKey, &changes)
+	compareArazzoOutputs(l.Outputs.Value, r.Outputs.Value, &changes, true)
+
+	stepChanges := make(map[string]*StepChanges)
+	lSteps, rSteps, order := keyArazzoObjects(l.Steps.Value, r.Steps.Value,
+		func(s *arazzo.Step) string { return s.StepId.Value })
+	for _, k := range order {
+		ls, rs := lSteps[k], rSteps[k]
+		switch {
+		case rs == nil:
+			CreateChange(&changes, ObjectRemoved, arazzo.StepsLabel,
+				ls.StepId.ValueNode, nil, false, ls, nil)
+		case ls == nil:
+			CreateChange(&changes, ObjectAdded, arazzo.StepsLabel,
+				nil, rs.StepId.ValueNode, false, nil, rs)
+		case !low.AreEqual(ls, rs):
+			if sc := CompareSteps(ls, rs); sc != nil {
+				stepChanges[k] = sc
+			}
+		}
+	}
+	if len(stepChanges) > 0 {
+		wc.StepChanges = stepChanges
+	}
+
+	wc.PropertyChanges = NewPropertyChanges(changes)
+	wc.ExtensionChanges = CheckExtensions(l, r)
+	if wc.TotalChanges() <= 0 {
+		return nil
+	}
+	return wc
+}
+
+// StepChanges represents changes made to an Arazzo Step object.
+type StepChanges struct {
+	*PropertyChanges
+	ExtensionChanges *ExtensionChanges `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+// GetAllChanges returns a slice of all changes made between Step objects
+func (s *StepChanges) GetAllChanges() []*Change {
+	var changes []*Change
+	changes = append(changes, s.Changes...)
+	if s.ExtensionChanges != nil {
+		changes = append(changes, s.ExtensionChanges.GetAllChanges()...)
+	}
+	return changes
+}
+
+// TotalChanges returns a count of everything that changed
+func (s *StepChanges) TotalChanges() int {
+	t := s.PropertyChanges.TotalChanges()
+	if s.ExtensionChanges != nil {
+		t += s.ExtensionChanges.TotalChanges()
+	}
+	return t
+}
+
+// TotalBreakingChanges returns a count of all breaking changes made between Step objects.
+func (s *StepChanges) TotalBreakingChanges() int {
+	return s.PropertyChanges.TotalBreakingChanges()
+}
+
+// CompareSteps compares a left (original) and a right (new) Step for changes. Steps are internal to a workflow, so
+// none of the changes are breaking. Returns nil if nothing changed.
+func CompareSteps(l, r *arazzo.Step) *StepChanges {
+	var changes []*Change
+	var props []*PropertyCheck
+
+	for _, p := range []struct {
+		label       string
+		left, right low.NodeReference[string]
+	}{
+		{arazzo.DescriptionLabel, l.Description, r.Description},
+		{arazzo.OperationIdLabel, l.OperationId, r.OperationId},
+		{arazzo.OperationPathLabel, l.OperationPath, r.OperationPath},
+		{arazzo.WorkflowIdLabel, l.WorkflowId, r.WorkflowId},
+	} {
+		props = append(props, &PropertyCheck{
+			LeftNode:  p.left.ValueNode,
+			RightNode: p.right.ValueNode,
+			Label:     p.label,
+			Changes:   &changes,
+			Breaking:  false,
+			Original:  l,
+			New:       r,
+		})
+	}
+	CheckProperties(props)
+
+	switch {
+	case !l.RequestBody.IsEmpty() && !r.RequestBody.IsEmpty():
+		if !low.AreEqual(l.RequestBody.Value, r.RequestBody.Value) {
+			CreateChange(&changes, Modified, arazzo.RequestBodyLabel,
+				l.RequestBody.ValueNode, r.RequestBody.ValueNode, false, l.RequestBody.Value, r.RequestBody.Value)
+		}
+	case l.RequestBody.IsEmpty() && !r.RequestBody.IsEmpty():
+		CreateChange(&changes, PropertyAdded, arazzo.RequestBodyLabel,
+			nil, r.RequestBody.ValueNode, false, nil, r.RequestBody.Value)
+	case !l.RequestBody.IsEmpty() && r.RequestBody.IsEmpty():
+		CreateChange(&changes, PropertyRemoved, arazzo.RequestBodyLabel,
+			l.RequestBody.ValueNode, nil, false, l.RequestBody.Value, nil)
+	}
+
+	compareArazzoObjects(arazzo.ParametersLabel, l.Parameters.Value, r.Parameters.Value, parameterKey, &changes)
+	compareArazzoObjects(arazzo.SuccessCriteriaLabel, l.SuccessCriteria.Value, r.SuccessCriteria.Value,
+		func(c *arazzo.Criterion) string { return c.Condition.Value }, &changes)
+	compareArazzoObjects(arazzo.OnSuccessLabel, l.OnSuccess.Value, r.OnSuccess.Value, successActionKey, &changes)
+	compareArazzoObjects(arazzo.OnFailureLabel, l.OnFailure.Value, r.OnFailure.Value, failureActionKey, &changes)
+	compareArazzoOutputs(l.Outputs.Value, r.Outputs.Value, &changes, false)
+
+	sc := new(StepChanges)
+	sc.PropertyChanges = NewPropertyChanges(changes)
+	sc.ExtensionChanges = CheckExtensions(l, r)
+	if sc.TotalChanges() <= 0 {
+		return nil
+	}
+	return sc
+}
+
+type arazzoObject interface {
+	low.Hashable
+	GetRootNode() *yaml.Node
+}
+
+// keyArazzoObjects keys the left and right objects using key, returning both maps and the order the keys were
+// first seen in (left first). Only the first object with a key is kept.
+func keyArazzoObjects[T arazzoObject](l, r []low.ValueReference[T], key func(T) string) (map[string]T, map[string]T, []string) {
+	lv := make(map[string]T, len(l))
+	rv := make(map[string]T, len(r))
+	var order []string
+	for i := range l {
+		if k := key(l[i].Value); !hasKey(lv, k) {
+			lv[k] = l[i].Value
+			order = append(order, k)
+		}
+	}
+	for i := range r {
+		if k := key(r[i].Value); !hasKey(rv, k) {
+			rv[k] = r[i].Value
+			if !hasKey(lv, k) {
+				order = append(order, k)
+			}
+		}
+	}
+	return lv, rv, order
+}
+
+func hasKey[T any](m map[string]T, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// compareArazzoObjects compares two lists of objects matched by key. Objects that have been added, removed or
+// modified are recorded in changes against their root nodes, none of them are breaking.
+func compareArazzoObjects[T arazzoObject](label string, l, r []low.ValueReference[T], key func(T) string,
+	changes *[]*Change,
+) {
+	lv, rv, order := keyArazzoObjects(l, r, key)
+	for _, k := range order {
+		lo, lok := lv[k]
+		ro, rok := rv[k]
+		switch {
+		case !rok:
+			CreateChange(changes, ObjectRemoved, label, lo.GetRootNode(), nil, false, lo, nil)
+		case !lok:
+			CreateChange(changes, ObjectAdded, label, nil, ro.GetRootNode(), false, nil, ro)
+		case !low.AreEqual(lo, ro):
+			CreateChange(changes, Modified, label, lo.GetRootNode(), ro.GetRootNode(), false, lo, ro)
+		}
+	}
+}
+
+// compareArazzoOutputs compares two maps of output expressions, removing or changing an output is breaking if
+// breaking is set.
+func compareArazzoOutputs(l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[string]],
+	changes *[]*Change, breaking bool,
+) {
+	for k, lv := range l.FromOldest() {
+		rv, ok := findOutput(r, k.Value)
+		switch {
+		case !ok:
+			CreateChange(changes, PropertyRemoved, arazzo.OutputsLabel, lv.ValueNode, nil, breaking, lv.Value, nil)
+		case lv.Value != rv.Value:
+			CreateChange(changes, Modified, arazzo.OutputsLabel, lv.ValueNode, rv.ValueNode, breaking, lv.Value, rv.Value)
+		}
+	}
+	for k, rv := range r.FromOldest() {
+		if _, ok := findOutput(l, k.Value); !ok {
+			CreateChange(changes, PropertyAdded, arazzo.OutputsLabel, nil, rv.ValueNode, false, nil, rv.Value)
+		}
+	}
+}
+
+func findOutput(m *orderedmap.Map[low.KeyReference[string], low.ValueReference[string]],
+	name string,
+) (low.ValueReference[string], bool) {
+	for k, v := range m.FromOldest() {
+		if k.Value == name {
+			return v, true
+		}
+	}
+	return low.ValueReference[string]{}, false
+}
+
+func parameterKey(p *arazzo.Parameter) string {
+	if !p.ComponentReference.IsEmpty() {
+		return p.ComponentReference.Value
+	}
+	return p.In.Value + ":" + p.Name.Value
+}
+
+func successActionKey(a *arazzo.SuccessAction) string {
+	if !a.ComponentReference.IsEmpty() {
+		return a.ComponentReference.Value
+	}
+	return a.Name.Value
+}
+
+func failureActionKey(a *arazzo.FailureAction) string {
+	if !a.ComponentReference.IsEmpty() {
+		return a.ComponentReference.Value
+	}
+	return a.Name.Value
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testArazzo = `arazzo: 1.0.0
+info:
+  title: Pet adoption
+  version: 1.0.0
+sourceDescriptions:
+  - name: pets
+    url: ./pets.yaml
+    type: openapi
+  - name: shelters
+    url: ./shelters.yaml
+workflows:
+  - workflowId: adopt
+    summary: adopt a pet
+    steps:
+      - stepId: find
+        operationId: findPet
+        parameters:
+          - name: id
+            in: path
+            value: $inputs.petId
+      - stepId: adopt
+        operationId: adoptPet
+    outputs:
+      pet: $steps.find.outputs.pet
+  - workflowId: return
+    steps:
+      - stepId: return
+        operationId: returnPet`
+
+func createArazzoDocument(t *testing.T, spec string) *arazzo.Document {
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+	doc, err := arazzo.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return doc
+}
+
+func TestCompareArazzoDocuments_Identical(t *testing.T) {
+	l := createArazzoDocument(t, testArazzo)
+	r := createArazzoDocument(t, testArazzo)
+	assert.Nil(t, CompareArazzoDocuments(l, r))
+	assert.Nil(t, CompareArazzoDocuments(l, nil))
+}
+
+func TestCompareArazzoDocuments(t *testing.T) {
+	right := `arazzo: 1.0.1
+info:
+  title: Pet adoption
+  version: 1.1.0
+sourceDescriptions:
+  - name: pets
+    url: ./pets-v2.yaml
+    type: openapi
+workflows:
+  - workflowId: adopt
+    summary: adopt a pet, quickly
+    steps:
+      - stepId: find
+        operationId: searchPets
+        parameters:
+          - name: id
+            in: path
+            value: $inputs.id
+      - stepId: pay
+        operationId: payFee
+  - workflowId: foster
+    steps:
+      - stepId: foster
+        operationId: fosterPet`
+
+	l := createArazzoDocument(t, testArazzo)
+	r := createArazzoDocument(t, right)
+	changes := CompareArazzoDocuments(l, r)
+	require.NotNil(t, changes)
+
+	// arazzo version, shelters removed, return removed, foster added.
+	assert.Len(t, changes.Changes, 4)
+	assert.Equal(t, 1, changes.InfoChanges.TotalChanges())
+
+	require.Contains(t, changes.SourceDescriptionChanges, "pets")
+	assert.Equal(t, 1, changes.SourceDescriptionChanges["pets"].TotalBreakingChanges())
+
+	adopt := changes.WorkflowChanges["adopt"]
+	require.NotNil(t, adopt)
+	// summary, adopt removed, pay added, pet output removed.
+	assert.Len(t, adopt.Changes, 4)
+	assert.Equal(t, 1, adopt.TotalBreakingChanges())
+	require.Contains(t, adopt.StepChanges, "find")
+	// operationId and the modified parameter.
+	assert.Equal(t, 2, adopt.StepChanges["find"].TotalChanges())
+	assert.Zero(t, adopt.StepChanges["find"].TotalBreakingChanges())
+
+	assert.Equal(t, 12, changes.TotalChanges())
+	assert.Len(t, changes.GetAllChanges(), 12)
+	// shelters removed, return removed, pets url changed and the pet output removed.
+	assert.Equal(t, 4, changes.TotalBreakingChanges())
+	assert.Equal(t, 12, GetSeverityRollup(changes).Total())
+
+	var leaves int
+	for range LeafChanges(changes) {
+		leaves++
+	}
+	assert.Equal(t, 12, leaves)
+}
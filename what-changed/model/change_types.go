@@ -25,6 +25,14 @@ const (
 
 	// PropertyRemoved means that a property of an object was removed
 	PropertyRemoved
+
+	// Moved means that an object (a path or an operation) was moved somewhere else without being changed, Original
+	// is where it was and New is where it is now.
+	Moved
+
+	// Renamed means that a component was renamed without being changed, Original is the old name and New is the
+	// new one.
+	Renamed
 )
 
 // WhatChanged is a summary object that contains a high level summary of everything changed.
@@ -107,6 +115,10 @@ func (c *Change) MarshalJSON() ([]byte, error) {
 		changeType = "object_removed"
 	case PropertyRemoved:
 		changeType = "property_removed"
+	case Moved:
+		changeType = "moved"
+	case Renamed:
+		changeType = "renamed"
 	}
 	data := map[string]interface{}{
 		"change":     c.ChangeType,
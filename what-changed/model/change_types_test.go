@@ -56,4 +56,18 @@ func TestChange_MarshalJSON(t *testing.T) {
 	assert.Equal(t, "property_removed", rebuilt["changeText"])
 	assert.Equal(t, float64(5), rebuilt["change"])
 
+	change = Change{
+		ChangeType: Moved,
+	}
+	rebuilt = rinseAndRepeat(&change)
+	assert.Equal(t, "moved", rebuilt["changeText"])
+	assert.Equal(t, float64(6), rebuilt["change"])
+
+	change = Change{
+		ChangeType: Renamed,
+	}
+	rebuilt = rinseAndRepeat(&change)
+	assert.Equal(t, "renamed", rebuilt["changeText"])
+	assert.Equal(t, float64(7), rebuilt["change"])
+
 }
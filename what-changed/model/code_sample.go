@@ -0,0 +1,144 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+// CodeSampleChanges represents changes made to a code sample (`x-codeSamples` or `x-code-samples`) of an operation.
+type CodeSampleChanges struct {
+	*PropertyChanges
+	ExtensionChanges *ExtensionChanges `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+}
+
+// GetAllChanges returns a slice of all changes made between CodeSample objects
+func (c *CodeSampleChanges) GetAllChanges() []*Change {
+	var changes []*Change
+	changes = append(changes, c.Changes...)
+	if c.ExtensionChanges != nil {
+		changes = append(changes, c.ExtensionChanges.GetAllChanges()...)
+	}
+	return changes
+}
+
+// TotalChanges returns a count of everything that changed
+func (c *CodeSampleChanges) TotalChanges() int {
+	t := c.PropertyChanges.TotalChanges()
+	if c.ExtensionChanges != nil {
+		t += c.ExtensionChanges.TotalChanges()
+	}
+	return t
+}
+
+// TotalBreakingChanges always returns 0 for CodeSample objects, they are documentation.
+func (c *CodeSampleChanges) TotalBreakingChanges() int {
+	return 0
+}
+
+// CompareCodeSamples will compare a left (original) and a right (new) CodeSample for any changes between them.
+// If there are changes, then a pointer to CodeSampleChanges is returned, otherwise if nothing changed - then nil
+// is returned.
+func CompareCodeSamples(l, r *base.CodeSample) *CodeSampleChanges {
+	var changes []*Change
+	var props []*PropertyCheck
+
+	for _, p := range []struct {
+		label       string
+		left, right low.NodeReference[string]
+	}{
+		{base.LangLabel, l.Lang, r.Lang},
+		{base.LabelLabel, l.Label, r.Label},
+		{base.SourceLabel, l.Source, r.Source},
+	} {
+		props = append(props, &PropertyCheck{
+			LeftNode:  p.left.ValueNode,
+			RightNode: p.right.ValueNode,
+			Label:     p.label,
+			Changes:   &changes,
+			Breaking:  false,
+			Original:  l,
+			New:       r,
+		})
+	}
+
+	// check everything.
+	CheckProperties(props)
+
+	cc := new(CodeSampleChanges)
+	cc.PropertyChanges = NewPropertyChanges(changes)
+	cc.ExtensionChanges = CheckExtensions(l, r)
+	if cc.TotalChanges() <= 0 {
+		return nil
+	}
+	return cc
+}
+
+// checkCodeSamples compares the code samples of two operations, matched by language (case-insensitively). Samples
+// that have been added or removed are recorded in changes (against the lang node), changed samples are returned keyed
+// by language.
+func checkCodeSamples(l, r low.NodeReference[[]low.ValueReference[*base.CodeSample]],
+	changes *[]*Change,
+) map[string]*CodeSampleChanges {
+	lv := make(map[string]*base.CodeSample, len(l.Value))
+	rv := make(map[string]*base.CodeSample, len(r.Value))
+	var order []string
+	for i := range l.Value {
+		if k := strings.ToLower(l.Value[i].Value.Lang.Value); lv[k] == nil {
+			lv[k] = l.Value[i].Value
+			order = append(order, k)
+		}
+	}
+	for i := range r.Value {
+		if k := strings.ToLower(r.Value[i].Value.Lang.Value); rv[k] == nil {
+			rv[k] = r.Value[i].Value
+			if lv[k] == nil {
+				order = append(order, k)
+			}
+		}
+	}
+
+	sampleChanges := make(map[string]*CodeSampleChanges)
+	for _, k := range order {
+		ls, rs := lv[k], rv[k]
+		switch {
+		case rs == nil:
+			CreateChange(changes, ObjectRemoved, base.CodeSamplesLabel,
+				ls.Lang.ValueNode, nil, false, ls, nil)
+		case ls == nil:
+			CreateChange(changes, ObjectAdded, base.CodeSamplesLabel,
+				nil, rs.Lang.ValueNode, false, nil, rs)
+		case !low.AreEqual(ls, rs):
+			if ch := CompareCodeSamples(ls, rs); ch != nil {
+				sampleChanges[rs.Lang.Value] = ch
+			}
+		}
+	}
+	if len(sampleChanges) == 0 {
+		return nil
+	}
+	return sampleChanges
+}
+
+// withoutCodeSamples returns the extensions without the code sample extension the samples were built from, code
+// samples are compared as objects rather than as a raw extension.
+func withoutCodeSamples(ext *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]],
+	samples low.NodeReference[[]low.ValueReference[*base.CodeSample]],
+) *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	if samples.KeyNode == nil || ext == nil {
+		return ext
+	}
+	filtered := orderedmap.New[low.KeyReference[string], low.ValueReference[*yaml.Node]]()
+	for k, v := range ext.FromOldest() {
+		if k.Value != samples.KeyNode.Value {
+			filtered.Set(k, v)
+		}
+	}
+	return filtered
+}
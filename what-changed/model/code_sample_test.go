@@ -0,0 +1,134 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCompareCodeSamples(t *testing.T) {
+	left := `lang: Go
+label: libopenapi
+source: doc, err := libopenapi.NewDocument(spec)
+x-generated: true`
+
+	right := `lang: Go
+label: libopenapi
+source: doc, _ := libopenapi.NewDocument(spec)
+x-generated: false`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lSample, rSample lowbase.CodeSample
+	_ = low.BuildModel(lNode.Content[0], &lSample)
+	_ = low.BuildModel(rNode.Content[0], &rSample)
+	_ = lSample.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rSample.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	changes := CompareCodeSamples(&lSample, &rSample)
+	require.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Len(t, changes.GetAllChanges(), 2)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	assert.Equal(t, lowbase.SourceLabel, changes.Changes[0].Property)
+	assert.Equal(t, 2, GetSeverityRollup(changes).Total())
+
+	leaves := 0
+	for range LeafChanges(changes) {
+		leaves++
+	}
+	assert.Equal(t, 2, leaves)
+
+	assert.Nil(t, CompareCodeSamples(&lSample, &lSample))
+}
+
+func TestCompareOperations_V3_CodeSamples(t *testing.T) {
+	left := `operationId: listPets
+x-codeSamples:
+  - lang: Shell
+    source: curl /pets
+  - lang: Go
+    source: client.ListPets()
+x-internal: true`
+
+	right := `operationId: listPets
+x-code-samples:
+  - lang: shell
+    source: curl -s /pets
+  - lang: Rust
+    source: client.list_pets()
+x-internal: true`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc, rDoc v3.Operation
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	changes := CompareOperations(&lDoc, &rDoc)
+	require.NotNil(t, changes)
+
+	// code samples are compared by language, rather than as a raw extension.
+	assert.Nil(t, changes.ExtensionChanges)
+	require.Len(t, changes.CodeSampleChanges, 1)
+	assert.Len(t, changes.CodeSampleChanges["shell"].Changes, 2) // lang (Shell to shell) and source.
+	assert.Len(t, changes.Changes, 2)
+	for _, c := range changes.Changes {
+		assert.Equal(t, lowbase.CodeSamplesLabel, c.Property)
+		switch c.ChangeType {
+		case ObjectRemoved:
+			assert.Equal(t, "Go", c.Original)
+			assert.Equal(t, "Go", c.OriginalObject.(*lowbase.CodeSample).Lang.Value)
+		case ObjectAdded:
+			assert.Equal(t, "Rust", c.New)
+			assert.Equal(t, "Rust", c.NewObject.(*lowbase.CodeSample).Lang.Value)
+		default:
+			t.Errorf("unexpected change %d", c.ChangeType)
+		}
+	}
+	assert.Equal(t, 4, changes.TotalChanges())
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+}
+
+func TestCompareOperations_V2_CodeSamples(t *testing.T) {
+	left := `operationId: listPets
+x-codeSamples:
+  - lang: Shell
+    source: curl /pets`
+
+	right := `operationId: listPets`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc, rDoc v2.Operation
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	changes := CompareOperations(&lDoc, &rDoc)
+	require.NotNil(t, changes)
+	assert.Nil(t, changes.ExtensionChanges)
+	assert.Nil(t, changes.CodeSampleChanges)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, ObjectRemoved, changes.Changes[0].ChangeType)
+	assert.Equal(t, 1, changes.TotalChanges())
+}
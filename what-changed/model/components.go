@@ -199,6 +199,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		}
 	}
 
+	changes = detectMoves(changes, Renamed, false)
 	cc.PropertyChanges = NewPropertyChanges(changes)
 	if cc.TotalChanges() <= 0 {
 		return nil
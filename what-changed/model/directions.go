@@ -0,0 +1,158 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// schemaUsage is where a schema is used: in requests, in responses, or both.
+type schemaUsage int
+
+const (
+	usedInRequests schemaUsage = 1 << iota
+	usedInResponses
+)
+
+// classifyDirectionalChanges classifies the changes made to schemas that narrow or widen the values they allow
+// (see narrowing) by where the schemas are used. Narrowing a schema breaks the clients sending values it no longer
+// allows, in requests, and widening a schema breaks the clients receiving values it now allows, in responses, as
+// strict consumers reject values they don't expect. Adding a required property breaks requests, but not responses,
+// which only promise more. Schemas used in callbacks are used the other way around. Changes to schemas whose use
+// can't be traced keep their classification.
+func (d *DocumentChanges) classifyDirectionalChanges() {
+	var a *impactAnalysis
+	changes, locations := collectLocatedChanges(d)
+	for i, c := range changes {
+		narrows, ok := narrowing(c)
+		if !ok {
+			continue
+		}
+		if a == nil {
+			a = newImpactAnalysis(d)
+		}
+		var usage schemaUsage
+		for _, l := range locations[i] {
+			usage |= a.usage(l)
+		}
+		switch {
+		case usage == 0:
+		case narrows:
+			c.Breaking = usage&usedInRequests != 0
+		default:
+			c.Breaking = usage&usedInResponses != 0
+		}
+	}
+}
+
+// lowerBounds and upperBounds are the keywords bounding the values of a schema from below, and from above.
+var (
+	lowerBounds = []string{
+		v3.MinimumLabel, v3.ExclusiveMinimumLabel, v3.MinLengthLabel, v3.MinItemsLabel, v3.MinPropertiesLabel,
+	}
+	upperBounds = []string{
+		v3.MaximumLabel, v3.ExclusiveMaximumLabel, v3.MaxLengthLabel, v3.MaxItemsLabel, v3.MaxPropertiesLabel,
+	}
+)
+
+// narrowing returns true if a change made to a schema narrows the values it allows, or false if it widens them.
+// ok is false for changes that do neither, or both (such as a const modified). The changes classified are made to
+// enums, consts, patterns, required properties and numeric bounds.
+func narrowing(c *Change) (narrows, ok bool) {
+	addedOrRemoved := c.ChangeType == PropertyAdded || c.ChangeType == PropertyRemoved
+	switch {
+	case c.Property == v3.EnumLabel:
+		// the enum itself is added or removed, otherwise one of its members is.
+		if _, enum := c.OriginalObject.(*base.Schema); enum {
+			return c.ChangeType == PropertyAdded, addedOrRemoved
+		}
+		return c.ChangeType == PropertyRemoved, addedOrRemoved
+	case c.Property == v3.ConstLabel || c.Property == v3.PatternLabel:
+		return c.ChangeType == PropertyAdded, addedOrRemoved
+	case c.Property == v3.RequiredLabel:
+		// the required properties of schemas are names, parameters and headers are required or not.
+		_, added := c.NewObject.(string)
+		_, removed := c.OriginalObject.(string)
+		return added, addedOrRemoved && (added || removed)
+	case slices.Contains(lowerBounds, c.Property), slices.Contains(upperBounds, c.Property):
+		lower := slices.Contains(lowerBounds, c.Property)
+		original, originalErr := strconv.ParseFloat(c.Original, 64)
+		updated, updatedErr := strconv.ParseFloat(c.New, 64)
+		switch c.ChangeType {
+		case PropertyAdded:
+			return true, updatedErr == nil
+		case PropertyRemoved:
+			return false, originalErr == nil
+		case Modified:
+			if originalErr != nil || updatedErr != nil || original == updated {
+				return false, false
+			}
+			return (updated > original) == lower, true
+		}
+	}
+	return false, false
+}
+
+// usage returns where the schema at a location of the report is used. A schema of the components is used wherever
+// it's referenced.
+func (a *impactAnalysis) usage(location []string) schemaUsage {
+	if u := locationUsage(location, a.d.swagger); u != 0 {
+		return u
+	}
+	if len(location) >= 3 && location[0] == v3.ComponentsLabel {
+		return a.componentUsage(a.componentPointer(location[1], location[2]))
+	}
+	return 0
+}
+
+// componentUsage returns where a component is used, by where it's referenced, directly or through other
+// components.
+func (a *impactAnalysis) componentUsage(pointer string) schemaUsage {
+	if u, ok := a.usages[pointer]; ok {
+		return u
+	}
+	var usage schemaUsage
+	a.referencingLocations(pointer, func(segments []string) bool {
+		u := locationUsage(segments, a.d.swagger)
+		usage |= u
+		return u == 0
+	})
+	a.usages[pointer] = usage
+	return usage
+}
+
+// locationUsage returns where a location of a document is used, by the parameters, request bodies or responses
+// it's in, or zero if it's in none of them (or in the schemas of the components).
+func locationUsage(location []string, swagger bool) schemaUsage {
+	if len(location) >= 2 && ((location[0] == v3.ComponentsLabel && location[1] == v3.SchemasLabel) ||
+		(swagger && location[0] == v2.DefinitionsLabel)) {
+		return 0
+	}
+	flipped := false
+	for _, segment := range location {
+		var u schemaUsage
+		switch segment {
+		case v3.CallbacksLabel:
+			flipped = !flipped
+		case v3.ParametersLabel, v3.RequestBodyLabel, v3.RequestBodiesLabel:
+			u = usedInRequests
+		case v3.ResponsesLabel:
+			u = usedInResponses
+		case v3.SchemaLabel:
+			return 0
+		}
+		if u != 0 {
+			if flipped {
+				u ^= usedInRequests | usedInResponses
+			}
+			return u
+		}
+	}
+	return 0
+}
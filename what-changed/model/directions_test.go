@@ -0,0 +1,286 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var directionsSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Sort'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                tone:
+                  type: string
+                  enum: [warm, cold]
+                status:
+                  $ref: '#/components/schemas/Status'
+      callbacks:
+        adopted:
+          '{$request.body#/callback}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      $ref: '#/components/schemas/Adoption'
+      responses:
+        "201":
+          description: created
+components:
+  parameters:
+    Sort:
+      name: sort
+      in: query
+      schema:
+        type: string
+        enum: [asc, desc]
+  schemas:
+    Pet:
+      type: object
+      properties:
+        kind:
+          type: string
+          %s
+        status:
+          $ref: '#/components/schemas/Status'
+    Status:
+      type: string
+      enum: [%s]
+    Adoption:
+      type: string
+      enum: [%s]
+    Unused:
+      type: string
+      enum: [%s]`
+
+// buildDirections fills the enums of the spec, in order.
+func buildDirections(kind, status, adoption, unused string) string {
+	parts := strings.Split(directionsSpec, "%s")
+	values := []string{kind, status, adoption, unused}
+	var sb strings.Builder
+	for i, p := range parts {
+		sb.WriteString(p)
+		if i < len(values) {
+			sb.WriteString(values[i])
+		}
+	}
+	return sb.String()
+}
+
+// enumChanges returns the breaking flag of the enum and const changes of a report, by where they're located.
+func enumChanges(t *testing.T, changes *DocumentChanges) map[string]bool {
+	require.NotNil(t, changes)
+	found := make(map[string]bool)
+	located, locations := collectLocatedChanges(changes)
+	for i, c := range located {
+		if c.Property == "enum" || c.Property == "const" {
+			value := c.New
+			if value == "" {
+				value = c.Original
+			}
+			found[strings.TrimPrefix(utils.BuildJSONPointer(locations[i][0]), "/")+" "+value] = c.Breaking
+		}
+	}
+	return found
+}
+
+func TestCompareDocuments_EnumDirections(t *testing.T) {
+	changes := compareMoves(t,
+		buildDirections("enum: [cat, dog]", "available, sold", "done", "a, b"),
+		buildDirections("enum: [cat, dog, bird]", "available", "done, undone", "a"))
+
+	assert.Equal(t, map[string]bool{
+		// a value added to a response breaks strict consumers.
+		"components/schemas/Pet/properties/kind/enum bird": true,
+		// a value removed from a schema used in requests and responses breaks the clients sending it.
+		"components/schemas/Status/enum sold": true,
+		// a value added to a request sent by a callback is received by clients.
+		"components/schemas/Adoption/enum undone": true,
+		// a schema used nowhere keeps the default classification.
+		"components/schemas/Unused/enum b": true,
+	}, enumChanges(t, changes))
+
+	changes = compareMoves(t,
+		buildDirections("enum: [cat, dog]", "available", "done, undone", "a"),
+		buildDirections("enum: [cat]", "available, sold", "done", "a, b"))
+	assert.Equal(t, map[string]bool{
+		// a value removed from a response doesn't break anyone.
+		"components/schemas/Pet/properties/kind/enum dog": false,
+		// a value added to a schema used in requests and responses breaks the clients receiving it.
+		"components/schemas/Status/enum sold":     true,
+		"components/schemas/Adoption/enum undone": false,
+		"components/schemas/Unused/enum b":        false,
+	}, enumChanges(t, changes))
+}
+
+func TestCompareDocuments_EnumDirections_Inline(t *testing.T) {
+	original := buildDirections("enum: [cat]", "a", "a", "a")
+	updated := strings.Replace(strings.Replace(original, "enum: [warm, cold]", "enum: [warm]", 1),
+		"enum: [asc, desc]", "enum: [asc, desc, random]", 1)
+	assert.Equal(t, map[string]bool{
+		// inline request schemas, and parameters, are used in requests.
+		"paths/~1pets/post/requestBody/content/application~1json/schema/properties/tone/enum cold": true,
+		"paths/~1pets/get/parameters/*/schema/enum random":                                         false,
+	}, enumChanges(t, compareMoves(t, original, updated)))
+}
+
+func TestCompareDocuments_ConstAndEnumKeywordDirections(t *testing.T) {
+	// a const (or an enum) added narrows the values allowed, a const removed widens them.
+	changes := compareMoves(t,
+		buildDirections("const: cat", "a", "a", "a"),
+		buildDirections("enum: [cat]", "a", "a", "a"))
+	found := enumChanges(t, changes)
+	assert.False(t, found["components/schemas/Pet/properties/kind/enum "])
+	assert.True(t, found["components/schemas/Pet/properties/kind/const cat"])
+
+	changes = compareMoves(t,
+		buildDirections("enum: [cat]", "a", "a", "a"),
+		buildDirections("const: cat", "a", "a", "a"))
+	found = enumChanges(t, changes)
+	assert.True(t, found["components/schemas/Pet/properties/kind/enum "])
+	assert.False(t, found["components/schemas/Pet/properties/kind/const cat"])
+}
+
+func TestNarrowing(t *testing.T) {
+	narrows, ok := narrowing(&Change{Property: "const", ChangeType: Modified})
+	assert.False(t, ok)
+	assert.False(t, narrows)
+	_, ok = narrowing(&Change{Property: "type", ChangeType: PropertyAdded})
+	assert.False(t, ok)
+}
+
+var requiredDirectionsSpec = `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - name: limit
+          in: query
+          required: %s
+          schema:
+            type: integer
+            minimum: %s
+            maximum: %s
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+      responses:
+        "201":
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    NewPet:
+      type: object
+      required: [%s]
+      properties:
+        name:
+          type: string
+          maxLength: %s
+        tag:
+          type: string
+    Pet:
+      type: object
+      required: [%s]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+          pattern: %s`
+
+// fillSpec fills the placeholders of a spec, in order.
+func fillSpec(spec string, values ...string) string {
+	parts := strings.Split(spec, "%s")
+	var sb strings.Builder
+	for i, p := range parts {
+		sb.WriteString(p)
+		if i < len(values) {
+			sb.WriteString(values[i])
+		}
+	}
+	return sb.String()
+}
+
+// breakingChanges returns the breaking flag of every change of a report, by where it's located.
+func breakingChanges(t *testing.T, changes *DocumentChanges) map[string]bool {
+	require.NotNil(t, changes)
+	found := make(map[string]bool)
+	located, locations := collectLocatedChanges(changes)
+	for i, c := range located {
+		found[strings.TrimPrefix(utils.BuildJSONPointer(locations[i][0]), "/")+" "+c.Original+">"+c.New] = c.Breaking
+	}
+	return found
+}
+
+func TestCompareDocuments_RequiredAndBoundsDirections(t *testing.T) {
+	changes := compareMoves(t,
+		fillSpec(requiredDirectionsSpec, "false", "1", "100", "name", "10", "id", "'^a'"),
+		fillSpec(requiredDirectionsSpec, "true", "0", "50", "name, tag", "20", "id, name", "'^b'"))
+	assert.Equal(t, map[string]bool{
+		// a required property added breaks requests, but not responses.
+		"components/schemas/NewPet/required >tag": true,
+		"components/schemas/Pet/required >name":   false,
+		// a bound raised widens the values of a request, a bound lowered narrows them.
+		"components/schemas/NewPet/properties/name/maxLength 10>20": false,
+		"paths/~1pets/post/parameters/*/schema/minimum 1>0":         false,
+		"paths/~1pets/post/parameters/*/schema/maximum 100>50":      true,
+		// patterns and parameters required modified do both, and keep their classification.
+		"components/schemas/Pet/properties/name/pattern ^a>^b": true,
+		"paths/~1pets/post/parameters/*/required false>true":   true,
+	}, breakingChanges(t, changes))
+
+	changes = compareMoves(t,
+		fillSpec(requiredDirectionsSpec, "true", "0", "50", "name, tag", "20", "id, name", "'^b'"),
+		fillSpec(requiredDirectionsSpec, "true", "0", "50", "name", "20", "id", "'^b'"))
+	assert.Equal(t, map[string]bool{
+		// a required property removed breaks the consumers of responses relying on it.
+		"components/schemas/NewPet/required tag>": false,
+		"components/schemas/Pet/required name>":   true,
+	}, breakingChanges(t, changes))
+}
+
+func TestNarrowing_Bounds(t *testing.T) {
+	narrows, ok := narrowing(&Change{Property: "minLength", ChangeType: PropertyAdded, New: "1"})
+	assert.True(t, ok)
+	assert.True(t, narrows)
+	narrows, ok = narrowing(&Change{Property: "maxItems", ChangeType: PropertyRemoved, Original: "10"})
+	assert.True(t, ok)
+	assert.False(t, narrows)
+	// exclusive bounds of OpenAPI 3.0 are booleans.
+	_, ok = narrowing(&Change{Property: "exclusiveMinimum", ChangeType: Modified, Original: "false", New: "true"})
+	assert.False(t, ok)
+	_, ok = narrowing(&Change{Property: "maximum", ChangeType: Modified, Original: "1.0", New: "1"})
+	assert.False(t, ok)
+	// parameters are required, or not.
+	_, ok = narrowing(&Change{Property: "required", ChangeType: PropertyAdded, New: "true", NewObject: true})
+	assert.False(t, ok)
+}
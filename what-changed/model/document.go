@@ -17,6 +17,8 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
 )
 
 // DocumentChanges represents all the changes made to an OpenAPI document.
@@ -31,6 +33,13 @@ type DocumentChanges struct {
 	SecurityRequirementChanges []*SecurityRequirementChanges `json:"securityRequirements,omitempty" yaml:"securityRequirements,omitempty"`
 	ComponentsChanges          *ComponentsChanges            `json:"components,omitempty" yaml:"components,omitempty"`
 	ExtensionChanges           *ExtensionChanges             `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+
+	// the root nodes of the compared documents, used to create patches.
+	originalRoot, updatedRoot *yaml.Node
+
+	// the indexes of the compared documents, used to trace changes through references.
+	originalIndex, updatedIndex *index.SpecIndex
+	swagger                     bool
 }
 
 // TotalChanges returns a total count of all changes made in the Document
@@ -153,6 +162,8 @@ func CompareDocuments(l, r any) *DocumentChanges {
 	if reflect.TypeOf(&v2.Swagger{}) == reflect.TypeOf(l) && reflect.TypeOf(&v2.Swagger{}) == reflect.TypeOf(r) {
 		lDoc := l.(*v2.Swagger)
 		rDoc := r.(*v2.Swagger)
+		dc.originalRoot, dc.updatedRoot = indexRoot(lDoc.Index), indexRoot(rDoc.Index)
+		dc.originalIndex, dc.updatedIndex, dc.swagger = lDoc.Index, rDoc.Index, true
 
 		// version
 		addPropertyCheck(&props, lDoc.Swagger.ValueNode, rDoc.Swagger.ValueNode,
@@ -228,6 +239,8 @@ func CompareDocuments(l, r any) *DocumentChanges {
 	if reflect.TypeOf(&v3.Document{}) == reflect.TypeOf(l) && reflect.TypeOf(&v3.Document{}) == reflect.TypeOf(r) {
 		lDoc := l.(*v3.Document)
 		rDoc := r.(*v3.Document)
+		dc.originalRoot, dc.updatedRoot = indexRoot(lDoc.Index), indexRoot(rDoc.Index)
+		dc.originalIndex, dc.updatedIndex = lDoc.Index, rDoc.Index
 
 		// version
 		addPropertyCheck(&props, lDoc.Version.ValueNode, rDoc.Version.ValueNode,
@@ -237,6 +250,10 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		addPropertyCheck(&props, lDoc.JsonSchemaDialect.ValueNode, rDoc.JsonSchemaDialect.ValueNode,
 			lDoc.JsonSchemaDialect.Value, rDoc.JsonSchemaDialect.Value, &changes, v3.JSONSchemaDialectLabel, true)
 
+		// self (3.2)
+		addPropertyCheck(&props, lDoc.Self.ValueNode, rDoc.Self.ValueNode,
+			lDoc.Self.Value, rDoc.Self.Value, &changes, v3.SelfLabel, true)
+
 		// tags
 		dc.TagChanges = CompareTags(lDoc.Tags.Value, rDoc.Tags.Value)
 
@@ -289,9 +306,18 @@ func CompareDocuments(l, r any) *DocumentChanges {
 	if dc.TotalChanges() <= 0 {
 		return nil
 	}
+	dc.classifyDirectionalChanges()
 	return dc
 }
 
+// indexRoot returns the root node of the document of an index.
+func indexRoot(idx *index.SpecIndex) *yaml.Node {
+	if idx == nil {
+		return nil
+	}
+	return idx.GetRootNode()
+}
+
 func compareDocumentExternalDocs(l, r low.HasExternalDocs, dc *DocumentChanges, changes *[]*Change) {
 	// external docs
 	if !l.GetExternalDocs().IsEmpty() && !r.GetExternalDocs().IsEmpty() {
@@ -670,8 +670,8 @@ paths:
 
 	// compare.
 	extChanges := CompareDocuments(lDoc, rDoc)
-	assert.Equal(t, 3, extChanges.TotalChanges())
-	assert.Len(t, extChanges.GetAllChanges(), 3)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 2)
 	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
 }
 
@@ -63,6 +63,19 @@ func CompareEncoding(l, r *v3.Encoding) *EncodingChanges {
 		New:       r,
 	})
 
+	// Style, an absent style is `form`, so making the default explicit (or dropping it) is not a change.
+	if encodingStyle(l) != encodingStyle(r) {
+		props = append(props, &PropertyCheck{
+			LeftNode:  l.Style.ValueNode,
+			RightNode: r.Style.ValueNode,
+			Label:     v3.StyleLabel,
+			Changes:   &changes,
+			Breaking:  true,
+			Original:  l,
+			New:       r,
+		})
+	}
+
 	// Explode
 	props = append(props, &PropertyCheck{
 		LeftNode:  l.Explode.ValueNode,
@@ -90,10 +103,18 @@ func CompareEncoding(l, r *v3.Encoding) *EncodingChanges {
 	ec := new(EncodingChanges)
 
 	// headers
-	ec.HeaderChanges = CheckMapForChanges(l.Headers.Value, r.Headers.Value, &changes, v3.HeadersLabel, CompareHeadersV3)
+	ec.HeaderChanges = CheckHeaderMapForChanges(l.Headers.Value, r.Headers.Value, &changes, CompareHeadersV3)
 	ec.PropertyChanges = NewPropertyChanges(changes)
 	if ec.TotalChanges() <= 0 {
 		return nil
 	}
 	return ec
 }
+
+// encodingStyle returns the effective style of an Encoding, `form` when it has none.
+func encodingStyle(e *v3.Encoding) string {
+	if e.Style.Value == "" {
+		return "form"
+	}
+	return e.Style.Value
+}
@@ -115,11 +115,13 @@ allowReserved: true`
 	// compare.
 	extChanges := CompareEncoding(&lDoc, &rDoc)
 	assert.NotNil(t, extChanges)
-	assert.Equal(t, 1, extChanges.TotalChanges())
-	assert.Len(t, extChanges.GetAllChanges(), 1)
-	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
-	assert.Equal(t, ObjectAdded, extChanges.Changes[0].ChangeType)
-	assert.Equal(t, v3.HeadersLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 2)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, PropertyAdded, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.StyleLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, ObjectAdded, extChanges.Changes[1].ChangeType)
+	assert.Equal(t, v3.HeadersLabel, extChanges.Changes[1].Property)
 }
 
 func TestCompareEncoding_Removed(t *testing.T) {
@@ -151,8 +153,41 @@ allowReserved: true`
 	// compare.
 	extChanges := CompareEncoding(&rDoc, &lDoc)
 	assert.NotNil(t, extChanges)
-	assert.Equal(t, 1, extChanges.TotalChanges())
-	assert.Len(t, extChanges.GetAllChanges(), 1)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 2)
+	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
+
+}
+
+func TestCompareEncoding_DefaultStyle(t *testing.T) {
+
+	build := func(yml string) *v3.Encoding {
+		var node yaml.Node
+		_ = yaml.Unmarshal([]byte(yml), &node)
+		var enc v3.Encoding
+		_ = low.BuildModel(node.Content[0], &enc)
+		_ = enc.Build(context.Background(), nil, node.Content[0], nil)
+		return &enc
+	}
+	implicit := build(`contentType: application/json`)
+	explicit := build(`contentType: application/json
+style: form`)
+	spaced := build(`contentType: application/json
+style: spaceDelimited`)
+
+	// making the default explicit (or dropping it) is not a change.
+	assert.Nil(t, CompareEncoding(implicit, explicit))
+	assert.Nil(t, CompareEncoding(explicit, implicit))
+
+	// but moving away from it is.
+	extChanges := CompareEncoding(implicit, spaced)
+	assert.NotNil(t, extChanges)
 	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, PropertyAdded, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v3.StyleLabel, extChanges.Changes[0].Property)
 
+	extChanges = CompareEncoding(explicit, spaced)
+	assert.NotNil(t, extChanges)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, Modified, extChanges.Changes[0].ChangeType)
 }
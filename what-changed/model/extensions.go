@@ -52,7 +52,7 @@ func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueR
 	var changes []*Change
 	for i := range seenLeft {
 
-		CheckForObjectAdditionOrRemoval[*yaml.Node](seenLeft, seenRight, i, &changes, false, true)
+		CheckForObjectAdditionOrRemoval[*yaml.Node](seenLeft, seenRight, i, &changes, false, false)
 
 		if seenRight[i] != nil {
 			var props []*PropertyCheck
@@ -73,7 +73,7 @@ func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueR
 	}
 	for i := range seenRight {
 		if seenLeft[i] == nil {
-			CheckForObjectAdditionOrRemoval[*yaml.Node](seenLeft, seenRight, i, &changes, false, true)
+			CheckForObjectAdditionOrRemoval[*yaml.Node](seenLeft, seenRight, i, &changes, false, false)
 		}
 	}
 	ex := new(ExtensionChanges)
@@ -0,0 +1,327 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ChangeFilter configures which changes to ignore, so a report can focus on the changes that matter to the contract
+// of an API, rather than every edit made to a specification.
+//
+// Changes are located by JSON Path, using the keys of the document, for example `$.info.description`,
+// `$.paths['/pets'].get.parameters` or `$.components.schemas.Pet`. The items of arrays (servers, tags, parameters
+// and the like) can't be addressed by index, only by `*`.
+type ChangeFilter struct {
+	// IgnorePaths are JSON Paths of the parts of a specification to ignore, such as `$.paths['/internal']` or
+	// `$.paths.*.*.x-code-samples`. Every change made at or below a path is ignored, as is adding or removing
+	// the part of the specification at the path. A `*` matches any single key.
+	IgnorePaths []string
+
+	// IgnoreExtensions are the extensions to ignore changes to, wherever they are, such as `x-internal`. Extensions
+	// can be matched with patterns, for example `x-amazon-*` (see path.Match for the syntax).
+	IgnoreExtensions []string
+
+	// IgnoreDescriptions ignores changes to descriptions.
+	IgnoreDescriptions bool
+}
+
+// Apply removes every ignored change from changes (DocumentChanges, or any other node of the what-changed tree),
+// and every node left without changes once they are removed. Returns an error if a JSON Path or extension pattern
+// is invalid, in which case changes are not modified.
+func (f *ChangeFilter) Apply(changes Changed) error {
+	if f == nil || changes == nil {
+		return nil
+	}
+	cf := &changeFilter{filter: f}
+	for _, p := range f.IgnorePaths {
+		segments, err := parseChangePath(p)
+		if err != nil {
+			return err
+		}
+		cf.paths = append(cf.paths, segments)
+	}
+	for _, e := range f.IgnoreExtensions {
+		if _, err := path.Match(e, ""); err != nil {
+			return fmt.Errorf("invalid extension pattern '%s': %w", e, err)
+		}
+	}
+	v := reflect.ValueOf(changes)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	cf.filterNode(v.Elem(), nil, false)
+	return nil
+}
+
+var (
+	changedType          = reflect.TypeOf((*Changed)(nil)).Elem()
+	changeSliceType      = reflect.TypeOf([]*Change(nil))
+	extensionChangesType = reflect.TypeOf((*ExtensionChanges)(nil))
+)
+
+// changePathFields are the JSON names of the fields of the what-changed tree that are not the keys of the same
+// part of a specification, and the keys they are. Fields with an empty key are not part of the path.
+var changePathFields = map[string]string{
+	"externalDoc":          "externalDocs",
+	"securityRequirements": "security",
+	"serverVariables":      "variables",
+	"oAuthFlow":            "flows",
+	"authCode":             "authorizationCode",
+	"requestBodies":        "requestBody",
+	"mappings":             "mapping",
+	"pathItems":            "",
+	"response":             "",
+	"extensions":           "",
+}
+
+// changeEntryLabels are the properties of changes to the entries of a map, rather than to a map.
+var changeEntryLabels = map[string]bool{v3.PathLabel: true, v3.CodesLabel: true}
+
+type changeFilter struct {
+	filter *ChangeFilter
+	paths  [][]string
+
+	// locations collects the JSON Paths of every change, instead of filtering changes, when it's not nil, and
+	// located the changes themselves.
+	locations *[][][]string
+	located   *[]*Change
+}
+
+// collectChangeLocations returns the JSON Paths of every change under changes (see changeLocations), one set
+// of paths per change.
+func collectChangeLocations(changes Changed) [][][]string {
+	_, locations := collectLocatedChanges(changes)
+	return locations
+}
+
+// collectLocatedChanges returns every change under changes, and the JSON Paths of each (see changeLocations).
+func collectLocatedChanges(changes Changed) ([]*Change, [][][]string) {
+	var located []*Change
+	var locations [][][]string
+	v := reflect.ValueOf(changes)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+	cf := &changeFilter{locations: &locations, located: &located}
+	cf.filterNode(v.Elem(), nil, false)
+	return located, locations
+}
+
+// filterNode filters the changes of a node of the what-changed tree, and of all of its children. extensions is
+// true when node is an ExtensionChanges.
+func (cf *changeFilter) filterNode(node reflect.Value, location []string, extensions bool) {
+	t := node.Type()
+	for i := 0; i < node.NumField(); i++ {
+		field, ft := node.Field(i), t.Field(i)
+		if !ft.IsExported() {
+			continue
+		}
+		if ft.Anonymous {
+			if pc, ok := field.Interface().(*PropertyChanges); ok && pc != nil {
+				pc.Changes = cf.filterChanges(pc.Changes, location, extensions)
+			}
+			continue
+		}
+		p := location
+		if segment := changePathField(ft); segment != "" {
+			p = appendChangePath(location, segment)
+		}
+		switch {
+		case field.Type() == changeSliceType:
+			if field.Len() > 0 {
+				field.Set(reflect.ValueOf(cf.filterChanges(field.Interface().([]*Change), p, false)))
+			}
+		case field.Kind() == reflect.Pointer:
+			if !field.IsNil() && field.Type().Implements(changedType) {
+				if cf.filterChild(field, p, field.Type() == extensionChangesType) {
+					field.Set(reflect.Zero(field.Type()))
+				}
+			}
+		case field.Kind() == reflect.Slice:
+			if field.Len() == 0 || !field.Type().Elem().Implements(changedType) {
+				continue
+			}
+			kept := reflect.MakeSlice(field.Type(), 0, field.Len())
+			for j := 0; j < field.Len(); j++ {
+				e := field.Index(j)
+				if e.IsNil() || !cf.filterChild(e, appendChangePath(p, "*"), false) {
+					kept = reflect.Append(kept, e)
+				}
+			}
+			field.Set(kept)
+		case field.Kind() == reflect.Map:
+			if field.Type().Key().Kind() != reflect.String || !field.Type().Elem().Implements(changedType) {
+				continue
+			}
+			for _, k := range field.MapKeys() {
+				e := field.MapIndex(k)
+				if !e.IsNil() && cf.filterChild(e, appendChangePath(p, k.String()), false) {
+					field.SetMapIndex(k, reflect.Value{})
+				}
+			}
+		}
+	}
+}
+
+// filterChild filters a child node (a pointer), and returns true if it had changes but has none left.
+func (cf *changeFilter) filterChild(child reflect.Value, location []string, extensions bool) bool {
+	c := child.Interface().(Changed)
+	before := c.TotalChanges()
+	cf.filterNode(child.Elem(), location, extensions)
+	return before > 0 && c.TotalChanges() == 0
+}
+
+func (cf *changeFilter) filterChanges(changes []*Change, location []string, extensions bool) []*Change {
+	if cf.locations != nil {
+		for _, c := range changes {
+			*cf.locations = append(*cf.locations, changeLocations(c, location))
+			*cf.located = append(*cf.located, c)
+		}
+		return changes
+	}
+	var kept []*Change
+	for _, c := range changes {
+		if !cf.ignored(c, location, extensions) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+func (cf *changeFilter) ignored(c *Change, location []string, extension bool) bool {
+	if extension {
+		for _, e := range cf.filter.IgnoreExtensions {
+			if ok, _ := path.Match(e, c.Property); ok {
+				return true
+			}
+		}
+	} else if cf.filter.IgnoreDescriptions && c.Property == v3.DescriptionLabel {
+		return true
+	}
+	for _, l := range changeLocations(c, location) {
+		for _, p := range cf.paths {
+			if matchChangePath(p, l) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// changeLocations returns the JSON Paths a change is found at: the property changed and, for additions, removals,
+// moves and renames of the entries of a map, the entries themselves.
+func changeLocations(c *Change, location []string) [][]string {
+	if c.Property == OperationLabel {
+		// operations are moved from `METHOD path` to `METHOD path`.
+		var locations [][]string
+		for _, name := range []string{c.Original, c.New} {
+			if method, p, ok := strings.Cut(name, " "); ok {
+				locations = append(locations, appendChangePath(appendChangePath(location, p), strings.ToLower(method)))
+			}
+		}
+		return locations
+	}
+	p := location
+	if !changeEntryLabels[c.Property] {
+		p = appendChangePath(location, c.Property)
+	}
+	locations := [][]string{p}
+	var keys []string
+	switch c.ChangeType {
+	case ObjectAdded:
+		keys = []string{c.New}
+	case ObjectRemoved:
+		keys = []string{c.Original}
+	case Moved, Renamed:
+		keys = []string{c.Original, c.New}
+	}
+	for _, k := range keys {
+		if k != "" {
+			locations = append(locations, appendChangePath(p, k))
+		}
+	}
+	return locations
+}
+
+func changePathField(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	if name == "schemas" && field.Type.Kind() == reflect.Pointer {
+		// a single schema, not a map of schemas.
+		return v3.SchemaLabel
+	}
+	if key, ok := changePathFields[name]; ok {
+		return key
+	}
+	return name
+}
+
+// appendChangePath appends a segment to a path, without modifying the path.
+func appendChangePath(location []string, segment string) []string {
+	p := make([]string, len(location), len(location)+1)
+	copy(p, location)
+	return append(p, segment)
+}
+
+// matchChangePath returns true if location is at or below pattern.
+func matchChangePath(pattern, location []string) bool {
+	if len(pattern) > len(location) {
+		return false
+	}
+	for i, segment := range pattern {
+		if segment != "*" && segment != location[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseChangePath parses a JSON Path of keys, such as `$.paths['/pets'].get`, into segments.
+func parseChangePath(p string) ([]string, error) {
+	if !strings.HasPrefix(p, "$") {
+		return nil, fmt.Errorf("invalid JSON Path '%s': it must start with '$'", p)
+	}
+	var segments []string
+	rest := p[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "['") || strings.HasPrefix(rest, `["`):
+			end := strings.Index(rest[2:], rest[1:2]+"]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON Path '%s': unterminated key '%s'", p, rest)
+			}
+			segments = append(segments, rest[2:2+end])
+			rest = rest[end+4:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON Path '%s': unterminated key '%s'", p, rest)
+			}
+			segments = append(segments, rest[1:end])
+			rest = rest[end+1:]
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSON Path '%s': empty key", p)
+			}
+			segments = append(segments, rest[:end])
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("invalid JSON Path '%s': expected '.' or '[' at '%s'", p, rest)
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var filterOriginal = `openapi: 3.1.0
+info:
+  title: Pets
+  description: all the pets
+  x-audience: public
+paths:
+  /pets:
+    get:
+      description: list pets
+      x-internal: false
+      x-amazon-gateway: one
+      responses:
+        "200":
+          description: ok
+  /internal:
+    get:
+      description: internal
+components:
+  schemas:
+    Pet:
+      type: object
+      description: a pet`
+
+var filterUpdated = `openapi: 3.1.0
+info:
+  title: Pets
+  description: every pet
+  x-audience: partners
+paths:
+  /pets:
+    get:
+      description: list all the pets
+      x-internal: true
+      x-amazon-gateway: two
+      responses:
+        "200":
+          description: ok
+        "404":
+          description: not found
+  /internal:
+    get:
+      description: internal, changed
+    post:
+      description: create internal
+components:
+  schemas:
+    Pet:
+      type: string
+      description: a pet, changed
+    Toy:
+      type: object`
+
+func TestChangeFilter_IgnoreDescriptions(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+	require.Equal(t, 11, changes.TotalChanges())
+
+	require.NoError(t, (&ChangeFilter{IgnoreDescriptions: true}).Apply(changes))
+	assert.Equal(t, 7, changes.TotalChanges())
+	for c := range LeafChanges(changes) {
+		assert.NotEqual(t, "description", c.Property)
+	}
+	assert.Empty(t, changes.InfoChanges.Changes)
+}
+
+func TestChangeFilter_IgnoreExtensions(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+
+	require.NoError(t, (&ChangeFilter{IgnoreExtensions: []string{"x-internal", "x-amazon-*"}}).Apply(changes))
+	assert.Equal(t, 9, changes.TotalChanges())
+	assert.Nil(t, changes.PathsChanges.PathItemsChanges["/pets"].GetChanges.ExtensionChanges)
+	require.NotNil(t, changes.InfoChanges.ExtensionChanges)
+	assert.Equal(t, "x-audience", changes.InfoChanges.ExtensionChanges.Changes[0].Property)
+}
+
+func TestChangeFilter_IgnorePaths(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+
+	require.NoError(t, (&ChangeFilter{IgnorePaths: []string{
+		"$.paths['/internal']",
+		"$.paths./pets.get.responses",
+		"$.components.schemas.*.type",
+		`$.components["schemas"]["Toy"]`,
+	}}).Apply(changes))
+	assert.Equal(t, 6, changes.TotalChanges())
+	assert.NotContains(t, changes.PathsChanges.PathItemsChanges, "/internal")
+	assert.Nil(t, changes.PathsChanges.PathItemsChanges["/pets"].GetChanges.ResponsesChanges)
+	assert.Empty(t, changes.ComponentsChanges.Changes)
+	require.Len(t, changes.ComponentsChanges.SchemaChanges["Pet"].Changes, 1)
+	assert.Equal(t, "description", changes.ComponentsChanges.SchemaChanges["Pet"].Changes[0].Property)
+}
+
+func TestChangeFilter_IgnoreEverything(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+
+	require.NoError(t, (&ChangeFilter{IgnorePaths: []string{"$.info", "$.paths", "$.components"}}).Apply(changes))
+	assert.Zero(t, changes.TotalChanges())
+	assert.Nil(t, changes.InfoChanges)
+	assert.Nil(t, changes.PathsChanges)
+	assert.Nil(t, changes.ComponentsChanges)
+}
+
+func TestChangeFilter_MovedOperation(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /a:
+    get:
+      description: a
+    put:
+      description: c
+  /b:
+    get:
+      description: b`
+
+	right := `openapi: 3.1.0
+paths:
+  /a:
+    put:
+      description: c
+  /b:
+    get:
+      description: b
+    post:
+      description: a`
+
+	changes := compareMoves(t, left, right)
+	require.Equal(t, 1, changes.TotalChanges())
+
+	require.NoError(t, (&ChangeFilter{IgnorePaths: []string{"$.paths['/b'].put"}}).Apply(changes))
+	assert.Equal(t, 1, changes.TotalChanges())
+	require.NoError(t, (&ChangeFilter{IgnorePaths: []string{"$.paths['/b'].post"}}).Apply(changes))
+	assert.Zero(t, changes.TotalChanges())
+}
+
+func TestChangeFilter_Invalid(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+
+	for path, message := range map[string]string{
+		"paths":        "invalid JSON Path 'paths': it must start with '$'",
+		"$.paths['/a'": "invalid JSON Path '$.paths['/a'': unterminated key '['/a''",
+		"$.paths[0":    "invalid JSON Path '$.paths[0': unterminated key '[0'",
+		"$..paths":     "invalid JSON Path '$..paths': empty key",
+		"$paths":       "invalid JSON Path '$paths': expected '.' or '[' at 'paths'",
+	} {
+		assert.EqualError(t, (&ChangeFilter{IgnorePaths: []string{path}}).Apply(changes), message)
+	}
+	assert.EqualError(t, (&ChangeFilter{IgnoreExtensions: []string{"x-["}}).Apply(changes),
+		"invalid extension pattern 'x-[': syntax error in pattern")
+	assert.Equal(t, 11, changes.TotalChanges())
+
+	var filter *ChangeFilter
+	assert.NoError(t, filter.Apply(changes))
+	assert.NoError(t, (&ChangeFilter{IgnoreDescriptions: true}).Apply((*DocumentChanges)(nil)))
+}
+
+func TestParseChangePath(t *testing.T) {
+	segments, err := parseChangePath(`$.paths['/pets'].get["x-code"][*].a`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"paths", "/pets", "get", "x-code", "*", "a"}, segments)
+
+	segments, err = parseChangePath("$")
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
 	"reflect"
+	"strings"
 )
 
 // HeaderChanges represents changes made between two Header objects. Supports both Swagger and OpenAPI header
@@ -190,6 +192,48 @@ func addCommonHeaderProperties(left, right low.HasDescription, changes *[]*Chang
 	return props
 }
 
+// CheckHeaderMapForChanges checks a left and right low level map of headers for any additions, removals or
+// modifications. HTTP header names are case-insensitive, so a header that has only changed the case of its name
+// is compared as the same header and the results are keyed using the original name. A header that has been
+// removed, or renamed to something else, is a breaking change.
+func CheckHeaderMapForChanges[T any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change, compareFunc func(l, r T) *HeaderChanges,
+) map[string]*HeaderChanges {
+	return CheckMapForChanges(expLeft, matchHeaderNames(expLeft, expRight), changes,
+		v3.HeadersLabel, compareFunc)
+}
+
+// matchHeaderNames returns a copy of the right header map, with any header names that only differ in case
+// re-keyed to the name used in the left map.
+func matchHeaderNames[T any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+) *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]] {
+	if orderedmap.Len(expLeft) == 0 || orderedmap.Len(expRight) == 0 {
+		return expRight
+	}
+	leftNames := make(map[string]low.KeyReference[string])
+	leftExact := make(map[string]bool)
+	rightNames := make(map[string]bool)
+	for k := range expLeft.KeysFromOldest() {
+		leftNames[strings.ToLower(k.Value)] = k
+		leftExact[k.Value] = true
+	}
+	for k := range expRight.KeysFromOldest() {
+		rightNames[k.Value] = true
+	}
+
+	matched := orderedmap.New[low.KeyReference[string], low.ValueReference[T]]()
+	for k, v := range expRight.FromOldest() {
+		lk, ok := leftNames[strings.ToLower(k.Value)]
+		// exact matches, and names that are still used by another header on the right, are left alone.
+		if !ok || leftExact[k.Value] || rightNames[lk.Value] {
+			matched.Set(k, v)
+			continue
+		}
+		matched.Set(low.KeyReference[string]{Value: lk.Value, KeyNode: k.KeyNode}, v)
+	}
+	return matched
+}
+
 // CompareHeadersV2 is a Swagger compatible, typed signature used for other generic functions. It simply
 // wraps CompareHeaders and provides nothing other that a typed interface.
 func CompareHeadersV2(l, r *v2.Header) *HeaderChanges {
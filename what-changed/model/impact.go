@@ -0,0 +1,229 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+	"sort"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// AffectedOperation is an operation whose contract is impacted by changes, made to the operation itself (or to the
+// path item it belongs to), or to a component it references, directly or through other components.
+type AffectedOperation struct {
+	Path    string `json:"path" yaml:"path"`     // the path of the operation, or the name of its webhook.
+	Method  string `json:"method" yaml:"method"` // the method of the operation, in lower case.
+	Webhook bool   `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+
+	// Components are the changed components the operation references, such as #/components/schemas/Pet.
+	Components []string `json:"components,omitempty" yaml:"components,omitempty"`
+
+	// Changes are all the changes impacting the operation, in the order of the report.
+	Changes []*Change `json:"changes" yaml:"changes"`
+}
+
+// Breaking returns true if any of the changes impacting the operation is breaking.
+func (a *AffectedOperation) Breaking() bool {
+	for _, c := range a.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// operationMethods are the methods of the operations of a path item, in the order operations are sorted.
+var operationMethods = []string{
+	v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel, v3.HeadLabel, v3.PatchLabel,
+	v3.TraceLabel, v3.QueryLabel,
+}
+
+// swaggerComponents are the Swagger definitions holding each type of component, as they're reported.
+var swaggerComponents = map[string]string{
+	"schemas":         "definitions",
+	"parameters":      "parameters",
+	"responses":       "responses",
+	"securitySchemes": "securityDefinitions",
+}
+
+type operationKey struct {
+	webhook      bool
+	path, method string
+}
+
+type impactAnalysis struct {
+	d          *DocumentChanges
+	operations map[operationKey]*AffectedOperation
+	dependents map[string][]operationKey
+	usages     map[string]schemaUsage
+}
+
+func newImpactAnalysis(d *DocumentChanges) *impactAnalysis {
+	return &impactAnalysis{
+		d:          d,
+		operations: make(map[operationKey]*AffectedOperation),
+		dependents: make(map[string][]operationKey),
+		usages:     make(map[string]schemaUsage),
+	}
+}
+
+// AffectedOperations traces every change through the references of both documents to the operations it impacts.
+// A change to an operation (or its path item) impacts the operation, a change to a component impacts every
+// operation referencing the component, directly or through other components (of any file of the rolodex). Changes
+// to the components of the documents are only traced when the report was created by comparing documents, as the
+// indexes of the documents are needed. Operations are sorted by path then method, those of webhooks last.
+func (d *DocumentChanges) AffectedOperations() []*AffectedOperation {
+	if d == nil {
+		return nil
+	}
+	a := newImpactAnalysis(d)
+	changes, locations := collectLocatedChanges(d)
+	for i, c := range changes {
+		for _, l := range locations[i] {
+			switch {
+			case len(l) >= 2 && (l[0] == v3.PathsLabel || l[0] == v3.WebhooksLabel):
+				for _, k := range a.operationsAt(l, l[0] == v3.WebhooksLabel) {
+					a.affect(k, c, "")
+				}
+			case len(l) >= 3 && l[0] == v3.ComponentsLabel:
+				pointer := a.componentPointer(l[1], l[2])
+				for _, k := range a.dependentOperations(pointer) {
+					a.affect(k, c, pointer)
+				}
+			}
+		}
+	}
+
+	affected := make([]*AffectedOperation, 0, len(a.operations))
+	for _, op := range a.operations {
+		sort.Strings(op.Components)
+		affected = append(affected, op)
+	}
+	sort.Slice(affected, func(i, j int) bool {
+		x, y := affected[i], affected[j]
+		if x.Webhook != y.Webhook {
+			return !x.Webhook
+		}
+		if x.Path != y.Path {
+			return x.Path < y.Path
+		}
+		return slices.Index(operationMethods, x.Method) < slices.Index(operationMethods, y.Method)
+	})
+	return affected
+}
+
+func (a *impactAnalysis) affect(k operationKey, c *Change, component string) {
+	op := a.operations[k]
+	if op == nil {
+		op = &AffectedOperation{Path: k.path, Method: k.method, Webhook: k.webhook}
+		a.operations[k] = op
+	}
+	if !slices.Contains(op.Changes, c) {
+		op.Changes = append(op.Changes, c)
+	}
+	if component != "" && !slices.Contains(op.Components, component) {
+		op.Components = append(op.Components, component)
+	}
+}
+
+// componentPointer returns the JSON Pointer of a component, by the type and name it's reported with.
+func (a *impactAnalysis) componentPointer(kind, name string) string {
+	segments := []string{v3.ComponentsLabel, kind, name}
+	if a.d.swagger {
+		if definitions, ok := swaggerComponents[kind]; ok {
+			segments = []string{definitions, name}
+		}
+	}
+	return "#" + utils.BuildJSONPointer(segments)
+}
+
+// operationsAt returns the operations at a location of the paths (or webhooks) of either document. A location above
+// the operations of a path item is the location of every operation of the path item.
+func (a *impactAnalysis) operationsAt(location []string, webhook bool) []operationKey {
+	if len(location) >= 3 && slices.Contains(operationMethods, location[2]) {
+		return []operationKey{{webhook: webhook, path: location[1], method: location[2]}}
+	}
+	var keys []operationKey
+	for _, root := range []*yaml.Node{a.d.originalRoot, a.d.updatedRoot} {
+		pathItem := utils.NodeAlias(locateNode(documentContent(root), location[:2]))
+		if pathItem == nil || pathItem.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(pathItem.Content); i += 2 {
+			k := operationKey{webhook: webhook, path: location[1], method: pathItem.Content[i].Value}
+			if slices.Contains(operationMethods, k.method) && !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	return keys
+}
+
+// dependentOperations returns the operations of either document referencing a component, directly or through
+// other components.
+func (a *impactAnalysis) dependentOperations(pointer string) []operationKey {
+	if keys, ok := a.dependents[pointer]; ok {
+		return keys
+	}
+	var keys []operationKey
+	a.referencingLocations(pointer, func(segments []string) bool {
+		if len(segments) < 2 || (segments[0] != v3.PathsLabel && segments[0] != v3.WebhooksLabel) {
+			return true
+		}
+		for _, k := range a.operationsAt(segments, segments[0] == v3.WebhooksLabel) {
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+		return false
+	})
+	a.dependents[pointer] = keys
+	return keys
+}
+
+// referencingLocations visits the location (the JSON Pointer segments) of every reference to a component in
+// either document, directly or through other components: when visit returns true, the references to the
+// component the location is in are visited too. References in other files of the rolodex aren't visited, but the
+// references to their components are.
+func (a *impactAnalysis) referencingLocations(pointer string, visit func(segments []string) bool) {
+	for _, idx := range []*index.SpecIndex{a.d.originalIndex, a.d.updatedIndex} {
+		if idx == nil {
+			continue
+		}
+		seen := map[string]bool{pointer: true}
+		queue := []string{pointer}
+		for len(queue) > 0 {
+			component := queue[0]
+			queue = queue[1:]
+			for _, ref := range idx.GetReferencesTo(component) {
+				segments, err := utils.ParseJSONPointer(ref.JSONPointer)
+				if err != nil || (ref.Index == idx && !visit(segments)) {
+					continue
+				}
+				dependent := ""
+				switch {
+				case len(segments) >= 3 && segments[0] == v3.ComponentsLabel:
+					dependent = "#" + utils.BuildJSONPointer(segments[:3])
+				case a.d.swagger && len(segments) >= 2 && slices.Contains(
+					[]string{"definitions", "parameters", "responses"}, segments[0]):
+					dependent = "#" + utils.BuildJSONPointer(segments[:2])
+				case ref.Index == idx:
+					continue
+				}
+				// components of other files are found by their file, which may be referenced whole.
+				if ref.Index != idx {
+					dependent = ref.Index.GetSpecAbsolutePath() + dependent
+				}
+				if !seen[dependent] {
+					seen[dependent] = true
+					queue = append(queue, dependent)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var impactOriginal = `openapi: 3.1.0
+info:
+  title: impact
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/NewPet'
+      responses:
+        "201":
+          description: created
+  /stores:
+    parameters:
+      - name: region
+        in: query
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: ok
+    delete:
+      responses:
+        "204":
+          description: deleted
+  /owners:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Owner'
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  requestBodies:
+    NewPet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Owner:
+      type: object`
+
+var impactUpdated = `openapi: 3.1.0
+info:
+  title: impact
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/NewPet'
+      responses:
+        "201":
+          description: created
+  /stores:
+    parameters:
+      - name: region
+        in: query
+        required: true
+        schema:
+          type: string
+    get:
+      responses:
+        "200":
+          description: ok
+    delete:
+      responses:
+        "204":
+          description: deleted
+  /owners:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Owner'
+webhooks:
+  newPet:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+components:
+  requestBodies:
+    NewPet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  schemas:
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'
+    Pet:
+      type: object
+      properties:
+        name:
+          type: integer
+        pet:
+          $ref: '#/components/schemas/Pet'
+    Owner:
+      type: object
+      description: an owner`
+
+func TestDocumentChanges_AffectedOperations(t *testing.T) {
+	changes := compareMoves(t, impactOriginal, impactUpdated)
+	require.NotNil(t, changes)
+
+	affected := changes.AffectedOperations()
+	var operations []string
+	for _, op := range affected {
+		operations = append(operations, op.Method+" "+op.Path)
+	}
+	assert.Equal(t, []string{
+		"get /owners", "get /pets", "post /pets", "get /stores", "delete /stores", "post newPet",
+	}, operations)
+
+	// the owner is referenced directly, pets through the list of pets and the request body, which isn't changed.
+	assert.Equal(t, []string{"#/components/schemas/Owner"}, affected[0].Components)
+	assert.False(t, affected[0].Breaking())
+	assert.Equal(t, []string{"#/components/schemas/Pet"}, affected[1].Components)
+	assert.Equal(t, []string{"#/components/schemas/Pet"}, affected[2].Components)
+	require.Len(t, affected[1].Changes, 1)
+	assert.Equal(t, "type", affected[1].Changes[0].Property)
+	assert.True(t, affected[1].Breaking())
+
+	// the parameters of the path item impact every operation of the path item.
+	assert.Empty(t, affected[3].Components)
+	require.Len(t, affected[3].Changes, 1)
+	assert.Same(t, affected[3].Changes[0], affected[4].Changes[0])
+
+	assert.True(t, affected[5].Webhook)
+	assert.Equal(t, []string{"#/components/schemas/Pet"}, affected[5].Components)
+
+	var none *DocumentChanges
+	assert.Nil(t, none.AffectedOperations())
+}
+
+func TestDocumentChanges_AffectedOperations_AddedOperation(t *testing.T) {
+	changes := compareMoves(t, `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      description: list pets
+  /stores:
+    get:
+      description: list stores`, `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      description: list pets
+    post:
+      description: add a pet
+components:
+  schemas:
+    Pet:
+      type: object`)
+	require.NotNil(t, changes)
+
+	affected := changes.AffectedOperations()
+	require.Len(t, affected, 2)
+	assert.Equal(t, "post", affected[0].Method)
+	assert.Equal(t, "/pets", affected[0].Path)
+	// a path removed impacts every operation it had.
+	assert.Equal(t, "get", affected[1].Method)
+	assert.Equal(t, "/stores", affected[1].Path)
+	assert.True(t, affected[1].Breaking())
+}
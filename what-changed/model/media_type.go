@@ -13,10 +13,11 @@ import (
 // MediaTypeChanges represent changes made between two OpenAPI MediaType instances.
 type MediaTypeChanges struct {
 	*PropertyChanges
-	SchemaChanges    *SchemaChanges              `json:"schemas,omitempty" yaml:"schemas,omitempty"`
-	ExtensionChanges *ExtensionChanges           `json:"extensions,omitempty" yaml:"extensions,omitempty"`
-	ExampleChanges   map[string]*ExampleChanges  `json:"examples,omitempty" yaml:"examples,omitempty"`
-	EncodingChanges  map[string]*EncodingChanges `json:"encoding,omitempty" yaml:"encoding,omitempty"`
+	SchemaChanges     *SchemaChanges              `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	ItemSchemaChanges *SchemaChanges              `json:"itemSchema,omitempty" yaml:"itemSchema,omitempty"`
+	ExtensionChanges  *ExtensionChanges           `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+	ExampleChanges    map[string]*ExampleChanges  `json:"examples,omitempty" yaml:"examples,omitempty"`
+	EncodingChanges   map[string]*EncodingChanges `json:"encoding,omitempty" yaml:"encoding,omitempty"`
 }
 
 // GetAllChanges returns a slice of all changes made between MediaType objects
@@ -26,6 +27,9 @@ func (m *MediaTypeChanges) GetAllChanges() []*Change {
 	if m.SchemaChanges != nil {
 		changes = append(changes, m.SchemaChanges.GetAllChanges()...)
 	}
+	if m.ItemSchemaChanges != nil {
+		changes = append(changes, m.ItemSchemaChanges.GetAllChanges()...)
+	}
 	for k := range m.ExampleChanges {
 		changes = append(changes, m.ExampleChanges[k].GetAllChanges()...)
 	}
@@ -47,6 +51,9 @@ func (m *MediaTypeChanges) TotalChanges() int {
 	if m.SchemaChanges != nil {
 		c += m.SchemaChanges.TotalChanges()
 	}
+	if m.ItemSchemaChanges != nil {
+		c += m.ItemSchemaChanges.TotalChanges()
+	}
 	if len(m.EncodingChanges) > 0 {
 		for i := range m.EncodingChanges {
 			c += m.EncodingChanges[i].TotalChanges()
@@ -67,6 +74,9 @@ func (m *MediaTypeChanges) TotalBreakingChanges() int {
 	if m.SchemaChanges != nil {
 		c += m.SchemaChanges.TotalBreakingChanges()
 	}
+	if m.ItemSchemaChanges != nil {
+		c += m.ItemSchemaChanges.TotalBreakingChanges()
+	}
 	if len(m.EncodingChanges) > 0 {
 		for i := range m.EncodingChanges {
 			c += m.EncodingChanges[i].TotalBreakingChanges()
@@ -135,6 +145,19 @@ func CompareMediaTypes(l, r *v3.MediaType) *MediaTypeChanges {
 			r.Schema.ValueNode, true, nil, r.Schema.Value)
 	}
 
+	// item schema
+	if !l.ItemSchema.IsEmpty() && !r.ItemSchema.IsEmpty() {
+		mc.ItemSchemaChanges = CompareSchemas(l.ItemSchema.Value, r.ItemSchema.Value)
+	}
+	if !l.ItemSchema.IsEmpty() && r.ItemSchema.IsEmpty() {
+		CreateChange(&changes, ObjectRemoved, v3.ItemSchemaLabel, l.ItemSchema.ValueNode,
+			nil, true, l.ItemSchema.Value, nil)
+	}
+	if l.ItemSchema.IsEmpty() && !r.ItemSchema.IsEmpty() {
+		CreateChange(&changes, ObjectAdded, v3.ItemSchemaLabel, nil,
+			r.ItemSchema.ValueNode, true, nil, r.ItemSchema.Value)
+	}
+
 	// examples
 	mc.ExampleChanges = CheckMapForChanges(l.Examples.Value, r.Examples.Value,
 		&changes, v3.ExamplesLabel, CompareExamples)
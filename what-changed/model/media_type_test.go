@@ -315,3 +315,56 @@ x-tea: cup`
 	assert.Len(t, extChanges.GetAllChanges(), 5)
 	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
 }
+
+func TestCompareMediaTypes_ItemSchema(t *testing.T) {
+	left := `itemSchema:
+  type: object
+  properties:
+    data:
+      type: string`
+
+	right := `itemSchema:
+  type: object
+  properties:
+    data:
+      type: integer`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.MediaType
+	var rDoc v3.MediaType
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare.
+	extChanges := CompareMediaTypes(&lDoc, &rDoc)
+	assert.NotNil(t, extChanges)
+	assert.NotNil(t, extChanges.ItemSchemaChanges)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 1)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+
+	// remove the item schema.
+	var eNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`example: nothing`), &eNode)
+	var eDoc v3.MediaType
+	_ = low.BuildModel(eNode.Content[0], &eDoc)
+	_ = eDoc.Build(context.Background(), nil, eNode.Content[0], nil)
+
+	extChanges = CompareMediaTypes(&lDoc, &eDoc)
+	assert.NotNil(t, extChanges)
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	var removed *Change
+	for _, c := range extChanges.GetAllChanges() {
+		if c.Property == v3.ItemSchemaLabel {
+			removed = c
+		}
+	}
+	assert.NotNil(t, removed)
+	assert.Equal(t, ObjectRemoved, removed.ChangeType)
+}
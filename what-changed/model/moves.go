@@ -0,0 +1,182 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// OperationLabel is the property of a change made to an operation as a whole, such as an operation that was Moved
+// to another path or method.
+const OperationLabel = "operation"
+
+// detectMoves replaces every removal in changes that has a matching addition (of the same property, with an object
+// of the same hash) with a single change of changeType, from the original key to the new key. A path renamed from
+// `/v1/users` to `/v2/users`, or a schema renamed without being changed, is then reported once instead of being a
+// removal and an addition. Changes without objects can't be matched and are left alone.
+func detectMoves(changes []*Change, changeType int, breaking bool) []*Change {
+	var removed, added []*Change
+	for _, c := range changes {
+		switch {
+		case c.ChangeType == ObjectRemoved && c.OriginalObject != nil:
+			removed = append(removed, c)
+		case c.ChangeType == ObjectAdded && c.NewObject != nil:
+			added = append(added, c)
+		}
+	}
+	if len(removed) == 0 || len(added) == 0 {
+		return changes
+	}
+	moves, paired := pairMoves(removed, added,
+		func(c *Change) string { return c.Property + ":" + low.GenerateHashString(c.OriginalObject) },
+		func(c *Change) string { return c.Property + ":" + low.GenerateHashString(c.NewObject) },
+	)
+	if len(moves) == 0 {
+		return changes
+	}
+	var result []*Change
+	for _, c := range changes {
+		if a, ok := moves[c]; ok {
+			result = append(result, createMove(changeType, c.Property, c, a, c.Original, a.New, breaking))
+			continue
+		}
+		if !paired[c] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// detectOperationMoves finds every operation removed from a path item that was added to another path item (or to
+// the same path item, under a different method) without being changed, removes both changes from their path items
+// and returns a single Moved change for each, from `METHOD path` to `METHOD path`. Path items left without changes
+// are deleted from pathChanges.
+func detectOperationMoves(pathChanges map[string]*PathItemChanges) []*Change {
+	location := make(map[*Change]string)
+	var removed, added []*Change
+	for path, pc := range pathChanges {
+		if pc == nil || pc.PropertyChanges == nil {
+			continue
+		}
+		for _, c := range pc.Changes {
+			if !isOperationLabel(c.Property) {
+				continue
+			}
+			switch {
+			case c.ChangeType == PropertyRemoved && c.OriginalObject != nil:
+				removed = append(removed, c)
+			case c.ChangeType == PropertyAdded && c.NewObject != nil:
+				added = append(added, c)
+			default:
+				continue
+			}
+			location[c] = path
+		}
+	}
+	if len(removed) == 0 || len(added) == 0 {
+		return nil
+	}
+	name := func(c *Change) string { return strings.ToUpper(c.Property) + " " + location[c] }
+	moves, paired := pairMoves(removed, added,
+		func(c *Change) string { return low.GenerateHashString(c.OriginalObject) },
+		func(c *Change) string { return low.GenerateHashString(c.NewObject) },
+	)
+	if len(moves) == 0 {
+		return nil
+	}
+	var result []*Change
+	for _, r := range removed {
+		if a, ok := moves[r]; ok {
+			result = append(result, createMove(Moved, OperationLabel, r, a, name(r), name(a), true))
+		}
+	}
+	for path, pc := range pathChanges {
+		if pc == nil || pc.PropertyChanges == nil {
+			continue
+		}
+		var kept []*Change
+		for _, c := range pc.Changes {
+			if _, ok := moves[c]; !ok && !paired[c] {
+				kept = append(kept, c)
+			}
+		}
+		pc.Changes = kept
+		if pc.TotalChanges() == 0 {
+			delete(pathChanges, path)
+		}
+	}
+	return result
+}
+
+// pairMoves pairs each removal with the first unpaired addition with the same key, in the order of their positions
+// (so the pairs are the same however the changes were collected). Returns the addition paired with each removal,
+// and the set of paired additions.
+func pairMoves(removed, added []*Change, removedKey, addedKey func(c *Change) string) (map[*Change]*Change, map[*Change]bool) {
+	sort.SliceStable(removed, func(i, j int) bool { return originalPosition(removed[i]) < originalPosition(removed[j]) })
+	sort.SliceStable(added, func(i, j int) bool { return newPosition(added[i]) < newPosition(added[j]) })
+
+	candidates := make(map[string][]*Change)
+	for _, a := range added {
+		k := addedKey(a)
+		candidates[k] = append(candidates[k], a)
+	}
+	moves := make(map[*Change]*Change)
+	paired := make(map[*Change]bool)
+	for _, r := range removed {
+		k := removedKey(r)
+		if len(candidates[k]) > 0 {
+			moves[r] = candidates[k][0]
+			paired[candidates[k][0]] = true
+			candidates[k] = candidates[k][1:]
+		}
+	}
+	return moves, paired
+}
+
+func createMove(changeType int, property string, removed, added *Change, original, updated string, breaking bool) *Change {
+	ctx := new(ChangeContext)
+	if removed.Context != nil {
+		ctx.OriginalLine, ctx.OriginalColumn = removed.Context.OriginalLine, removed.Context.OriginalColumn
+	}
+	if added.Context != nil {
+		ctx.NewLine, ctx.NewColumn = added.Context.NewLine, added.Context.NewColumn
+	}
+	return &Change{
+		Context:        ctx,
+		ChangeType:     changeType,
+		Property:       property,
+		Original:       original,
+		New:            updated,
+		Breaking:       breaking,
+		OriginalObject: removed.OriginalObject,
+		NewObject:      added.NewObject,
+	}
+}
+
+func isOperationLabel(label string) bool {
+	switch label {
+	case v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel, v3.HeadLabel, v3.PatchLabel,
+		v3.TraceLabel, v3.QueryLabel:
+		return true
+	}
+	return false
+}
+
+func originalPosition(c *Change) int {
+	if c.Context == nil || c.Context.OriginalLine == nil {
+		return 0
+	}
+	return *c.Context.OriginalLine
+}
+
+func newPosition(c *Change) int {
+	if c.Context == nil || c.Context.NewLine == nil {
+		return 0
+	}
+	return *c.Context.NewLine
+}
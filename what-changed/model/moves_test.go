@@ -0,0 +1,180 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compareMoves(t *testing.T, left, right string) *DocumentChanges {
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, err := v3.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	rDoc, err := v3.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return CompareDocuments(lDoc, rDoc)
+}
+
+func TestCompareDocuments_MovedPath(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /v1/users:
+    get:
+      description: list users
+  /v1/pets:
+    get:
+      description: list pets`
+
+	right := `openapi: 3.1.0
+paths:
+  /v2/users:
+    get:
+      description: list users
+  /v1/pets:
+    get:
+      description: list all the pets
+  /v2/pets:
+    get:
+      description: list pets, but new`
+
+	changes := compareMoves(t, left, right)
+	require.NotNil(t, changes)
+	assert.Equal(t, 3, changes.TotalChanges())
+
+	moved := changes.PathsChanges.Changes[0]
+	for _, c := range changes.PathsChanges.Changes {
+		if c.ChangeType == Moved {
+			moved = c
+		}
+	}
+	assert.Equal(t, Moved, moved.ChangeType)
+	assert.Equal(t, v3.PathLabel, moved.Property)
+	assert.Equal(t, "/v1/users", moved.Original)
+	assert.Equal(t, "/v2/users", moved.New)
+	assert.True(t, moved.Breaking)
+	assert.Equal(t, 3, *moved.Context.OriginalLine)
+	assert.Equal(t, 3, *moved.Context.NewLine)
+	assert.NotNil(t, moved.OriginalObject)
+	assert.NotNil(t, moved.NewObject)
+}
+
+func TestCompareDocuments_MovedOperation(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /users:
+    get:
+      description: list users
+    post:
+      description: create a user
+  /accounts:
+    get:
+      description: list accounts`
+
+	right := `openapi: 3.1.0
+paths:
+  /users:
+    get:
+      description: list users
+  /accounts:
+    get:
+      description: list accounts
+    put:
+      description: create a user
+  /people:
+    summary: people`
+
+	changes := compareMoves(t, left, right)
+	require.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Empty(t, changes.PathsChanges.PathItemsChanges)
+
+	var moved *Change
+	for _, c := range changes.PathsChanges.Changes {
+		if c.ChangeType == Moved {
+			moved = c
+		}
+	}
+	require.NotNil(t, moved)
+	assert.Equal(t, OperationLabel, moved.Property)
+	assert.Equal(t, "POST /users", moved.Original)
+	assert.Equal(t, "PUT /accounts", moved.New)
+	assert.True(t, moved.Breaking)
+	assert.Equal(t, 7, *moved.Context.OriginalLine)
+	assert.Equal(t, 10, *moved.Context.NewLine)
+}
+
+func TestCompareDocuments_RenamedComponent(t *testing.T) {
+	left := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+    Toy:
+      type: string
+  responses:
+    Gone:
+      description: it's gone`
+
+	right := `openapi: 3.1.0
+components:
+  schemas:
+    Animal:
+      type: object
+    Toy:
+      type: string
+    Ball:
+      type: integer
+  responses:
+    Lost:
+      description: it's lost`
+
+	changes := compareMoves(t, left, right)
+	require.NotNil(t, changes)
+
+	var renamed []*Change
+	for _, c := range changes.ComponentsChanges.Changes {
+		if c.ChangeType == Renamed {
+			renamed = append(renamed, c)
+		}
+	}
+	require.Len(t, renamed, 1)
+	assert.Equal(t, v3.SchemasLabel, renamed[0].Property)
+	assert.Equal(t, "Pet", renamed[0].Original)
+	assert.Equal(t, "Animal", renamed[0].New)
+	assert.False(t, renamed[0].Breaking)
+
+	// Ball was added, and the changed response was removed and added.
+	assert.Equal(t, 4, changes.ComponentsChanges.TotalChanges())
+	assert.Equal(t, 1, changes.ComponentsChanges.TotalBreakingChanges())
+}
+
+func TestDetectMoves_Identical(t *testing.T) {
+	// two identical objects removed, and two added, are paired in the order they are found.
+	one, two, three, four := 1, 2, 3, 4
+	obj := &v3.PathItem{}
+	changes := []*Change{
+		{ChangeType: ObjectAdded, Property: "path", New: "/d", NewObject: obj, Context: &ChangeContext{NewLine: &four}},
+		{ChangeType: ObjectRemoved, Property: "path", Original: "/b", OriginalObject: obj, Context: &ChangeContext{OriginalLine: &two}},
+		{ChangeType: ObjectRemoved, Property: "path", Original: "/a", OriginalObject: obj, Context: &ChangeContext{OriginalLine: &one}},
+		{ChangeType: ObjectAdded, Property: "path", New: "/c", NewObject: obj, Context: &ChangeContext{NewLine: &three}},
+		{ChangeType: ObjectAdded, Property: "other", New: "/e", NewObject: obj},
+	}
+	moves := detectMoves(changes, Moved, true)
+	require.Len(t, moves, 3)
+	assert.Equal(t, "/b", moves[0].Original)
+	assert.Equal(t, "/d", moves[0].New)
+	assert.Equal(t, "/a", moves[1].Original)
+	assert.Equal(t, "/c", moves[1].New)
+	assert.Equal(t, "/e", moves[2].New)
+
+	moves = detectMoves(changes[3:], Moved, true)
+	assert.Equal(t, changes[3:], moves)
+	assert.Empty(t, detectOperationMoves(nil))
+}
@@ -35,11 +35,31 @@ func (o *OAuthFlowsChanges) GetAllChanges() []*Change {
 		changes = append(changes, o.AuthorizationCodeChanges.GetAllChanges()...)
 	}
 	if o.ExtensionChanges != nil {
-		changes = append(changes, o.ImplicitChanges.GetAllChanges()...)
+		changes = append(changes, o.ExtensionChanges.GetAllChanges()...)
 	}
 	return changes
 }
 
+// FlowChanges returns the changes of every flow that exists on both sides and has changed, keyed by the name of
+// the flow in the specification.
+func (o *OAuthFlowsChanges) FlowChanges() map[string]*OAuthFlowChanges {
+	flows := make(map[string]*OAuthFlowChanges)
+	if o == nil {
+		return flows
+	}
+	for name, fc := range map[string]*OAuthFlowChanges{
+		v3.ImplicitLabel:          o.ImplicitChanges,
+		v3.PasswordLabel:          o.PasswordChanges,
+		v3.ClientCredentialsLabel: o.ClientCredentialsChanges,
+		v3.AuthorizationCodeLabel: o.AuthorizationCodeChanges,
+	} {
+		if fc != nil {
+			flows[name] = fc
+		}
+	}
+	return flows
+}
+
 // TotalChanges returns the number of changes made between two OAuthFlows instances.
 func (o *OAuthFlowsChanges) TotalChanges() int {
 	c := o.PropertyChanges.TotalChanges()
@@ -154,8 +174,15 @@ func CompareOAuthFlows(l, r *v3.OAuthFlows) *OAuthFlowsChanges {
 }
 
 // OAuthFlowChanges represents an OpenAPI OAuthFlow object.
+//
+// Every scope that is added, removed or has its description changed is a change of the `scopes` property, the names
+// of those scopes are also listed by ScopesAdded, ScopesRemoved and ScopesModified. Removing a scope is a breaking
+// change, as clients requesting it will be refused.
 type OAuthFlowChanges struct {
 	*PropertyChanges
+	ScopesAdded      []string          `json:"scopesAdded,omitempty" yaml:"scopesAdded,omitempty"`
+	ScopesRemoved    []string          `json:"scopesRemoved,omitempty" yaml:"scopesRemoved,omitempty"`
+	ScopesModified   []string          `json:"scopesModified,omitempty" yaml:"scopesModified,omitempty"`
 	ExtensionChanges *ExtensionChanges `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 }
 
@@ -228,9 +255,11 @@ func CompareOAuthFlow(l, r *v3.OAuthFlow) *OAuthFlowChanges {
 
 	CheckProperties(props)
 
+	oa := new(OAuthFlowChanges)
 	for k, v := range l.Scopes.Value.FromOldest() {
 		if r != nil && r.FindScope(k.Value) == nil {
 			CreateChange(&changes, ObjectRemoved, v3.Scopes, v.ValueNode, nil, true, k.Value, nil)
+			oa.ScopesRemoved = append(oa.ScopesRemoved, k.Value)
 			continue
 		}
 		if r != nil && r.FindScope(k.Value) != nil {
@@ -238,15 +267,16 @@ func CompareOAuthFlow(l, r *v3.OAuthFlow) *OAuthFlowChanges {
 				CreateChange(&changes, Modified, v3.Scopes,
 					v.ValueNode, r.FindScope(k.Value).ValueNode, true,
 					v.Value, r.FindScope(k.Value).Value)
+				oa.ScopesModified = append(oa.ScopesModified, k.Value)
 			}
 		}
 	}
 	for k, v := range r.Scopes.Value.FromOldest() {
 		if l != nil && l.FindScope(k.Value) == nil {
 			CreateChange(&changes, ObjectAdded, v3.Scopes, nil, v.ValueNode, false, nil, k.Value)
+			oa.ScopesAdded = append(oa.ScopesAdded, k.Value)
 		}
 	}
-	oa := new(OAuthFlowChanges)
 	oa.PropertyChanges = NewPropertyChanges(changes)
 	oa.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
 	return oa
@@ -335,3 +335,61 @@ x-coke: cherry`
 	assert.Len(t, extChanges.GetAllChanges(), 5)
 	assert.Equal(t, 4, extChanges.TotalBreakingChanges())
 }
+
+func TestCompareOAuthFlows_ScopesPerFlow(t *testing.T) {
+	left := `implicit:
+  authorizationUrl: https://pb33f.io/auth
+  scopes:
+    read: read burgers
+    write: write burgers
+clientCredentials:
+  tokenUrl: https://pb33f.io/token
+  scopes:
+    admin: eat all the burgers
+x-coke: cola`
+
+	right := `implicit:
+  authorizationUrl: https://pb33f.io/auth
+  scopes:
+    read: read all the burgers
+    delete: delete burgers
+clientCredentials:
+  tokenUrl: https://pb33f.io/new-token
+  scopes:
+    admin: eat all the burgers
+x-coke: pepsi`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.OAuthFlows
+	var rDoc v3.OAuthFlows
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare
+	extChanges := CompareOAuthFlows(&lDoc, &rDoc)
+	assert.Equal(t, 5, extChanges.TotalChanges())
+	assert.Len(t, extChanges.GetAllChanges(), 5)
+	assert.Equal(t, 3, extChanges.TotalBreakingChanges())
+
+	flows := extChanges.FlowChanges()
+	assert.Len(t, flows, 2)
+	implicit := flows[v3.ImplicitLabel]
+	assert.Equal(t, []string{"delete"}, implicit.ScopesAdded)
+	assert.Equal(t, []string{"write"}, implicit.ScopesRemoved)
+	assert.Equal(t, []string{"read"}, implicit.ScopesModified)
+
+	clientCredentials := flows[v3.ClientCredentialsLabel]
+	assert.Empty(t, clientCredentials.ScopesAdded)
+	assert.Equal(t, 1, clientCredentials.TotalBreakingChanges())
+	assert.Equal(t, v3.TokenUrlLabel, clientCredentials.Changes[0].Property)
+	assert.Equal(t, "https://pb33f.io/new-token", clientCredentials.Changes[0].New)
+
+	var nilChanges *OAuthFlowsChanges
+	assert.Empty(t, nilChanges.FlowChanges())
+}
@@ -22,6 +22,7 @@ type OperationChanges struct {
 	ParameterChanges           []*ParameterChanges           `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 	ResponsesChanges           *ResponsesChanges             `json:"responses,omitempty" yaml:"responses,omitempty"`
 	SecurityRequirementChanges []*SecurityRequirementChanges `json:"securityRequirements,omitempty" yaml:"securityRequirements,omitempty"`
+	CodeSampleChanges          map[string]*CodeSampleChanges `json:"codeSamples,omitempty" yaml:"codeSamples,omitempty"`
 
 	// OpenAPI 3+ only changes
 	RequestBodyChanges *RequestBodyChanges         `json:"requestBodies,omitempty" yaml:"requestBodies,omitempty"`
@@ -55,6 +56,9 @@ func (o *OperationChanges) GetAllChanges() []*Change {
 	for k := range o.CallbackChanges {
 		changes = append(changes, o.CallbackChanges[k].GetAllChanges()...)
 	}
+	for k := range o.CodeSampleChanges {
+		changes = append(changes, o.CodeSampleChanges[k].GetAllChanges()...)
+	}
 	if o.ExtensionChanges != nil {
 		changes = append(changes, o.ExtensionChanges.GetAllChanges()...)
 	}
@@ -85,6 +89,9 @@ func (o *OperationChanges) TotalChanges() int {
 	for k := range o.CallbackChanges {
 		c += o.CallbackChanges[k].TotalChanges()
 	}
+	for k := range o.CodeSampleChanges {
+		c += o.CodeSampleChanges[k].TotalChanges()
+	}
 	if o.ExtensionChanges != nil {
 		c += o.ExtensionChanges.TotalChanges()
 	}
@@ -297,7 +304,9 @@ func CompareOperations(l, r any) *OperationChanges {
 				&changes, v3.SchemesLabel, true)
 		}
 
-		oc.ExtensionChanges = CompareExtensions(lOperation.Extensions, rOperation.Extensions)
+		oc.CodeSampleChanges = checkCodeSamples(lOperation.CodeSamples, rOperation.CodeSamples, &changes)
+		oc.ExtensionChanges = CompareExtensions(withoutCodeSamples(lOperation.Extensions, lOperation.CodeSamples),
+			withoutCodeSamples(rOperation.Extensions, rOperation.CodeSamples))
 	}
 
 	// OpenAPI
@@ -417,7 +426,9 @@ func CompareOperations(l, r any) *OperationChanges {
 
 		// servers
 		oc.ServerChanges = checkServers(lOperation.Servers, rOperation.Servers)
-		oc.ExtensionChanges = CompareExtensions(lOperation.Extensions, rOperation.Extensions)
+		oc.CodeSampleChanges = checkCodeSamples(lOperation.CodeSamples, rOperation.CodeSamples, &changes)
+		oc.ExtensionChanges = CompareExtensions(withoutCodeSamples(lOperation.Extensions, lOperation.CodeSamples),
+			withoutCodeSamples(rOperation.Extensions, rOperation.CodeSamples))
 
 	}
 	CheckProperties(props)
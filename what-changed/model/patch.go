@@ -0,0 +1,367 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// JSON Patch operations.
+const (
+	JSONPatchAdd     = "add"
+	JSONPatchRemove  = "remove"
+	JSONPatchReplace = "replace"
+)
+
+// JSONPatchOperation is a single operation of a JSON Patch (RFC 6902).
+type JSONPatchOperation struct {
+	Op    string // JSONPatchAdd, JSONPatchRemove or JSONPatchReplace.
+	Path  string // the JSON Pointer to the value added, removed or replaced.
+	Value any    // the value added or replaced, as a JSON value. Not set when a value is removed.
+}
+
+// MarshalJSON renders the operation as a JSON Patch operation, with a `value` unless it's a removal.
+func (o *JSONPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == JSONPatchRemove {
+		return json.Marshal(map[string]any{"op": o.Op, "path": o.Path})
+	}
+	return json.Marshal(map[string]any{"op": o.Op, "path": o.Path, "value": o.Value})
+}
+
+var errNoPatchDocuments = errors.New("unable to create a patch, the compared documents are not available")
+
+// AsJSONPatch returns the JSON Patch (RFC 6902) operations that transform the original document into the updated
+// document, for every change in the report. Only the parts of the documents with changes are patched, so changes
+// removed by a ChangeFilter are not in the patch. The documents are patched as they're written: a change found
+// through a local reference patches the referenced node, and changes made in other files are not patched.
+//
+// Maps are patched key by key and arrays item by item (items added or removed at the end of an array are added to
+// or removed from the end). The operations are in the order of the documents, and must be applied in that order.
+// A report without changes returns no operations.
+func (d *DocumentChanges) AsJSONPatch() ([]*JSONPatchOperation, error) {
+	if d == nil {
+		return nil, nil
+	}
+	p, err := d.newPatcher()
+	if err != nil {
+		return nil, err
+	}
+	p.diff(p.original, p.updated, nil)
+	return p.ops, nil
+}
+
+// AsJSONMergePatch returns a JSON Merge Patch (RFC 7386) that transforms the original document into the updated
+// document, for every change in the report, in the same way as AsJSONPatch. As merge patches can't patch the items
+// of an array, an array with changes is replaced, and as a null removes a value, null values of the updated
+// document can't be patched. A report without changes returns an empty patch (`{}`).
+func (d *DocumentChanges) AsJSONMergePatch() ([]byte, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	p, err := d.newPatcher()
+	if err != nil {
+		return nil, err
+	}
+	patch, changed := p.merge(p.original, p.updated, nil)
+	if !changed {
+		patch = map[string]any{}
+	}
+	return json.Marshal(patch)
+}
+
+type patcher struct {
+	original, updated *yaml.Node
+	locations         [][]string
+	ops               []*JSONPatchOperation
+}
+
+func (d *DocumentChanges) newPatcher() (*patcher, error) {
+	original, updated := documentContent(d.originalRoot), documentContent(d.updatedRoot)
+	if original == nil || updated == nil {
+		return nil, errNoPatchDocuments
+	}
+	p := &patcher{original: original, updated: updated}
+	for _, locations := range collectChangeLocations(d) {
+		var resolved [][]string
+		for _, l := range locations {
+			resolved = append(resolved, p.resolveLocations(l, 0)...)
+		}
+		p.locations = append(p.locations, p.specificLocations(resolved)...)
+	}
+	return p, nil
+}
+
+// resolveLocations returns where a change is made in the documents. Changes are reported where they're found,
+// following references, so a location through the same local reference in both documents is moved to the
+// referenced node, and a location through a reference changed between the documents is cut at the reference,
+// patching the reference itself. Changes to the items of an array are reported by the name of the item (or `*`),
+// so a location is also cut at the first array it doesn't index, patching the whole array, and a location through
+// every item (`*`) is also resolved through each item.
+func (p *patcher) resolveLocations(location []string, depth int) [][]string {
+	if depth > 10 {
+		return [][]string{location}
+	}
+	for i := 0; i < len(location); i++ {
+		l := utils.NodeAlias(locateNode(p.original, location[:i]))
+		r := utils.NodeAlias(locateNode(p.updated, location[:i]))
+		if (l != nil && l.Kind == yaml.SequenceNode) || (r != nil && r.Kind == yaml.SequenceNode) {
+			if _, err := strconv.Atoi(location[i]); err == nil {
+				continue
+			}
+			locations := [][]string{location[:i]}
+			if location[i] == "*" {
+				for j := 0; j < max(nodeLength(l), nodeLength(r)); j++ {
+					item := append(appendChangePath(location[:i], strconv.Itoa(j)), location[i+1:]...)
+					locations = append(locations, p.resolveLocations(item, depth+1)...)
+				}
+			}
+			return locations
+		}
+		lr, rr := localReference(l), localReference(r)
+		if lr == nil && rr == nil {
+			continue
+		}
+		if lr == nil || rr == nil || !slices.Equal(lr, rr) {
+			return [][]string{location[:i]}
+		}
+		return p.resolveLocations(append(slices.Clone(lr), location[i:]...), depth+1)
+	}
+	return [][]string{location}
+}
+
+func nodeLength(node *yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+	return len(node.Content)
+}
+
+// localReference returns the segments of the JSON Pointer of a reference into the same document, or nil.
+func localReference(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	ref := mappingValue(node, "$ref")
+	if ref == nil || !strings.HasPrefix(ref.Value, "#/") {
+		return nil
+	}
+	segments, err := utils.ParseJSONPointer(ref.Value)
+	if err != nil {
+		return nil
+	}
+	return segments
+}
+
+// specificLocations drops the locations of a change that are above another of its locations found in either
+// document, so the addition of an entry to a map patches the entry, not the whole map.
+func (p *patcher) specificLocations(locations [][]string) [][]string {
+	var specific [][]string
+	for i, l := range locations {
+		above := false
+		for j, other := range locations {
+			if i != j && len(other) > len(l) && matchChangePath(l, other) &&
+				(locateNode(p.original, other) != nil || locateNode(p.updated, other) != nil) {
+				above = true
+				break
+			}
+		}
+		if !above {
+			specific = append(specific, l)
+		}
+	}
+	return specific
+}
+
+// covered returns true if there is a change at or above a path.
+func (p *patcher) covered(path []string) bool {
+	for _, l := range p.locations {
+		if matchChangePath(l, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// relevant returns true if there is a change at, above or below a path.
+func (p *patcher) relevant(path []string) bool {
+	for _, l := range p.locations {
+		if matchChangePath(l, path) || (len(l) > len(path) && matchChangePath(l[:len(path)], path)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *patcher) add(op string, path []string, value *yaml.Node) {
+	o := &JSONPatchOperation{Op: op, Path: utils.BuildJSONPointer(path)}
+	if value != nil {
+		o.Value = patchValue(value)
+	}
+	p.ops = append(p.ops, o)
+}
+
+// diff adds the operations patching l into r, at path.
+func (p *patcher) diff(l, r *yaml.Node, path []string) {
+	if !p.relevant(path) {
+		return
+	}
+	l, r = utils.NodeAlias(l), utils.NodeAlias(r)
+	switch {
+	case l.Kind == yaml.MappingNode && r.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(l.Content); i += 2 {
+			child := appendChangePath(path, l.Content[i].Value)
+			if rv := mappingValue(r, l.Content[i].Value); rv != nil {
+				p.diff(l.Content[i+1], rv, child)
+			} else if p.covered(child) {
+				p.add(JSONPatchRemove, child, nil)
+			}
+		}
+		for i := 0; i+1 < len(r.Content); i += 2 {
+			child := appendChangePath(path, r.Content[i].Value)
+			if mappingValue(l, r.Content[i].Value) == nil && p.covered(child) {
+				p.add(JSONPatchAdd, child, r.Content[i+1])
+			}
+		}
+	case l.Kind == yaml.SequenceNode && r.Kind == yaml.SequenceNode:
+		for i := 0; i < len(l.Content) && i < len(r.Content); i++ {
+			p.diff(l.Content[i], r.Content[i], appendChangePath(path, strconv.Itoa(i)))
+		}
+		for i := len(l.Content) - 1; i >= len(r.Content); i-- {
+			if child := appendChangePath(path, strconv.Itoa(i)); p.covered(child) {
+				p.add(JSONPatchRemove, child, nil)
+			}
+		}
+		for i := len(l.Content); i < len(r.Content); i++ {
+			if child := appendChangePath(path, strconv.Itoa(i)); p.covered(child) {
+				p.add(JSONPatchAdd, child, r.Content[i])
+			}
+		}
+	default:
+		if p.covered(path) && !reflect.DeepEqual(patchValue(l), patchValue(r)) {
+			p.add(JSONPatchReplace, path, r)
+		}
+	}
+}
+
+// merge returns the merge patch of l into r at path, and true if anything is patched.
+func (p *patcher) merge(l, r *yaml.Node, path []string) (any, bool) {
+	if !p.relevant(path) {
+		return nil, false
+	}
+	l, r = utils.NodeAlias(l), utils.NodeAlias(r)
+	if l.Kind != yaml.MappingNode || r.Kind != yaml.MappingNode {
+		if reflect.DeepEqual(patchValue(l), patchValue(r)) {
+			return nil, false
+		}
+		return patchValue(r), true
+	}
+	patch := make(map[string]any)
+	for i := 0; i+1 < len(l.Content); i += 2 {
+		key := l.Content[i].Value
+		child := appendChangePath(path, key)
+		if rv := mappingValue(r, key); rv != nil {
+			if v, ok := p.merge(l.Content[i+1], rv, child); ok {
+				patch[key] = v
+			}
+		} else if p.covered(child) {
+			patch[key] = nil
+		}
+	}
+	for i := 0; i+1 < len(r.Content); i += 2 {
+		key := r.Content[i].Value
+		if mappingValue(l, key) == nil && p.covered(appendChangePath(path, key)) {
+			patch[key] = patchValue(r.Content[i+1])
+		}
+	}
+	return patch, len(patch) > 0
+}
+
+func documentContent(root *yaml.Node) *yaml.Node {
+	if root != nil && root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil
+		}
+		return root.Content[0]
+	}
+	return root
+}
+
+// locateNode returns the node at path, or nil if there is none.
+func locateNode(node *yaml.Node, path []string) *yaml.Node {
+	for _, segment := range path {
+		node = utils.NodeAlias(node)
+		switch node.Kind {
+		case yaml.MappingNode:
+			node = mappingValue(node, segment)
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[i]
+		default:
+			return nil
+		}
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// patchValue converts a node into a JSON value (maps, slices, strings, numbers, booleans and nil).
+func patchValue(n *yaml.Node) any {
+	n = utils.NodeAlias(n)
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			m[n.Content[i].Value] = patchValue(n.Content[i+1])
+		}
+		return m
+	case yaml.SequenceNode:
+		s := make([]any, len(n.Content))
+		for i := range n.Content {
+			s[i] = patchValue(n.Content[i])
+		}
+		return s
+	}
+	switch n.ShortTag() {
+	case "!!null":
+		return nil
+	case "!!bool":
+		if b, err := strconv.ParseBool(n.Value); err == nil {
+			return b
+		}
+	case "!!int":
+		if i, err := strconv.ParseInt(n.Value, 0, 64); err == nil {
+			return i
+		}
+	case "!!float":
+		if f, err := strconv.ParseFloat(n.Value, 64); err == nil {
+			return f
+		}
+	}
+	return n.Value
+}
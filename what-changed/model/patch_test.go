@@ -0,0 +1,257 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func patchDocument(t *testing.T, spec string) any {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &node))
+	return patchValue(node.Content[0])
+}
+
+// applyJSONPatch applies JSON Patch operations to a JSON value.
+func applyJSONPatch(t *testing.T, doc any, ops []*JSONPatchOperation) any {
+	for _, op := range ops {
+		segments, err := utils.ParseJSONPointer(op.Path)
+		require.NoError(t, err)
+		doc = applyPatchOperation(t, doc, segments, op)
+	}
+	return doc
+}
+
+func applyPatchOperation(t *testing.T, doc any, segments []string, op *JSONPatchOperation) any {
+	if len(segments) == 0 {
+		return op.Value
+	}
+	key, rest := segments[0], segments[1:]
+	switch v := doc.(type) {
+	case map[string]any:
+		if len(rest) == 0 {
+			if op.Op == JSONPatchRemove {
+				require.Contains(t, v, key, op.Path)
+				delete(v, key)
+			} else {
+				if op.Op == JSONPatchReplace {
+					require.Contains(t, v, key, op.Path)
+				}
+				v[key] = op.Value
+			}
+			return v
+		}
+		require.Contains(t, v, key, op.Path)
+		v[key] = applyPatchOperation(t, v[key], rest, op)
+		return v
+	case []any:
+		i, err := strconv.Atoi(key)
+		require.NoError(t, err, op.Path)
+		if len(rest) == 0 {
+			switch op.Op {
+			case JSONPatchRemove:
+				require.Less(t, i, len(v), op.Path)
+				return append(v[:i:i], v[i+1:]...)
+			case JSONPatchAdd:
+				require.LessOrEqual(t, i, len(v), op.Path)
+				return append(v[:i:i], append([]any{op.Value}, v[i:]...)...)
+			}
+			require.Less(t, i, len(v), op.Path)
+			v[i] = op.Value
+			return v
+		}
+		require.Less(t, i, len(v), op.Path)
+		v[i] = applyPatchOperation(t, v[i], rest, op)
+		return v
+	}
+	require.Failf(t, "unable to apply operation", "%s %s", op.Op, op.Path)
+	return nil
+}
+
+func assertJSONEqual(t *testing.T, expected, actual any) {
+	e, err := json.Marshal(expected)
+	require.NoError(t, err)
+	a, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(e), string(a))
+}
+
+// applyMergePatch applies a JSON Merge Patch to a JSON value.
+func applyMergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = applyMergePatch(t[k], v)
+		}
+	}
+	return t
+}
+
+func TestDocumentChanges_AsJSONPatch(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+	require.NotNil(t, changes)
+
+	ops, err := changes.AsJSONPatch()
+	require.NoError(t, err)
+	patched := applyJSONPatch(t, patchDocument(t, filterOriginal), ops)
+	assert.Equal(t, patchDocument(t, filterUpdated), patched)
+
+	rendered, err := json.Marshal(ops[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"op": "replace", "path": "/info/description", "value": "every pet"}`, string(rendered))
+	for _, op := range ops {
+		if op.Path == "/components/schemas/Toy" {
+			assert.Equal(t, JSONPatchAdd, op.Op)
+			assert.Equal(t, map[string]any{"type": "object"}, op.Value)
+		}
+	}
+}
+
+func TestDocumentChanges_AsJSONPatch_Arrays(t *testing.T) {
+	left := `openapi: 3.1.0
+servers:
+  - url: https://one.example.com
+  - url: https://two.example.com
+  - url: https://three.example.com
+tags:
+  - name: pets
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+      responses:
+        "200":
+          description: ok`
+	right := `openapi: 3.1.0
+servers:
+  - url: https://uno.example.com
+tags:
+  - name: pets
+  - name: toys
+    description: toys
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+        - name: verbose
+          in: query
+      responses:
+        "200":
+          description: ok
+          x-rate-limit: 10`
+
+	changes := compareMoves(t, left, right)
+	require.NotNil(t, changes)
+	ops, err := changes.AsJSONPatch()
+	require.NoError(t, err)
+	assert.Equal(t, patchDocument(t, right), applyJSONPatch(t, patchDocument(t, left), ops))
+
+	patch, err := changes.AsJSONMergePatch()
+	require.NoError(t, err)
+	var merge any
+	require.NoError(t, json.Unmarshal(patch, &merge))
+	assertJSONEqual(t, patchDocument(t, right), applyMergePatch(patchDocument(t, left), merge))
+}
+
+func TestDocumentChanges_AsJSONPatch_Burgershop(t *testing.T) {
+	original, err := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	require.NoError(t, err)
+	modified, err := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+	require.NoError(t, err)
+
+	changes := compareMoves(t, string(original), string(modified))
+	require.NotNil(t, changes)
+	ops, err := changes.AsJSONPatch()
+	require.NoError(t, err)
+	expected := patchDocument(t, string(modified))
+	patched := applyJSONPatch(t, patchDocument(t, string(original)), ops)
+
+	// the report doesn't have the change of an items schema into an array (an OpenAPI 3.0 mistake), and two
+	// callbacks added with the same reference are reported as the same callback, so neither can be patched.
+	for _, doc := range []any{expected, patched} {
+		components := doc.(map[string]any)["components"].(map[string]any)
+		delete(components["schemas"].(map[string]any)["SomePayload"].(map[string]any), "items")
+		burger := doc.(map[string]any)["paths"].(map[string]any)["/burgers/{burgerId}"].(map[string]any)
+		delete(burger["get"].(map[string]any), "callbacks")
+	}
+	assert.Equal(t, expected, patched)
+}
+
+func TestDocumentChanges_AsJSONMergePatch(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+	require.NotNil(t, changes)
+
+	patch, err := changes.AsJSONMergePatch()
+	require.NoError(t, err)
+	var merge any
+	require.NoError(t, json.Unmarshal(patch, &merge))
+	assertJSONEqual(t, patchDocument(t, filterUpdated), applyMergePatch(patchDocument(t, filterOriginal), merge))
+
+	m := merge.(map[string]any)
+	assert.Equal(t, map[string]any{"description": "every pet", "x-audience": "partners"}, m["info"])
+	assert.NotContains(t, m, "openapi")
+}
+
+func TestDocumentChanges_AsJSONPatch_Filtered(t *testing.T) {
+	changes := compareMoves(t, filterOriginal, filterUpdated)
+	require.NotNil(t, changes)
+	filter := &ChangeFilter{IgnoreDescriptions: true, IgnorePaths: []string{"$.paths['/internal']"}}
+	require.NoError(t, filter.Apply(changes))
+
+	ops, err := changes.AsJSONPatch()
+	require.NoError(t, err)
+	var paths []string
+	for _, op := range ops {
+		paths = append(paths, op.Op+" "+op.Path)
+	}
+	assert.Equal(t, []string{
+		"replace /info/x-audience",
+		"replace /paths/~1pets/get/x-internal",
+		"replace /paths/~1pets/get/x-amazon-gateway",
+		"add /paths/~1pets/get/responses/404",
+		"replace /components/schemas/Pet/type",
+		"add /components/schemas/Toy",
+	}, paths)
+
+	patch, err := changes.AsJSONMergePatch()
+	require.NoError(t, err)
+	assert.NotContains(t, string(patch), "every pet")
+	assert.NotContains(t, string(patch), "/internal")
+}
+
+func TestDocumentChanges_AsJSONPatch_NoChanges(t *testing.T) {
+	var changes *DocumentChanges
+	ops, err := changes.AsJSONPatch()
+	assert.NoError(t, err)
+	assert.Empty(t, ops)
+	patch, err := changes.AsJSONMergePatch()
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(patch))
+
+	_, err = new(DocumentChanges).AsJSONPatch()
+	assert.EqualError(t, err, "unable to create a patch, the compared documents are not available")
+}
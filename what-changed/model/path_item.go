@@ -22,9 +22,14 @@ type PathItemChanges struct {
 	HeadChanges      *OperationChanges   `json:"head,omitempty" yaml:"head,omitempty"`
 	PatchChanges     *OperationChanges   `json:"patch,omitempty" yaml:"patch,omitempty"`
 	TraceChanges     *OperationChanges   `json:"trace,omitempty" yaml:"trace,omitempty"`
+	QueryChanges     *OperationChanges   `json:"query,omitempty" yaml:"query,omitempty"`
 	ServerChanges    []*ServerChanges    `json:"servers,omitempty" yaml:"servers,omitempty"`
 	ParameterChanges []*ParameterChanges `json:"parameters,omitempty" yaml:"parameters,omitempty"`
 	ExtensionChanges *ExtensionChanges   `json:"extensions,omitempty" yaml:"extensions,omitempty"`
+
+	// AdditionalOperationChanges are the changes to operations found in both additionalOperations (3.2), keyed
+	// by method.
+	AdditionalOperationChanges map[string]*OperationChanges `json:"additionalOperations,omitempty" yaml:"additionalOperations,omitempty"`
 }
 
 // GetAllChanges returns a slice of all changes made between PathItem objects
@@ -55,6 +60,12 @@ func (p *PathItemChanges) GetAllChanges() []*Change {
 	if p.TraceChanges != nil {
 		changes = append(changes, p.TraceChanges.GetAllChanges()...)
 	}
+	if p.QueryChanges != nil {
+		changes = append(changes, p.QueryChanges.GetAllChanges()...)
+	}
+	for k := range p.AdditionalOperationChanges {
+		changes = append(changes, p.AdditionalOperationChanges[k].GetAllChanges()...)
+	}
 	for i := range p.ServerChanges {
 		changes = append(changes, p.ServerChanges[i].GetAllChanges()...)
 	}
@@ -94,6 +105,12 @@ func (p *PathItemChanges) TotalChanges() int {
 	if p.TraceChanges != nil {
 		c += p.TraceChanges.TotalChanges()
 	}
+	if p.QueryChanges != nil {
+		c += p.QueryChanges.TotalChanges()
+	}
+	for _, oc := range p.AdditionalOperationChanges {
+		c += oc.TotalChanges()
+	}
 	for i := range p.ServerChanges {
 		c += p.ServerChanges[i].TotalChanges()
 	}
@@ -133,6 +150,12 @@ func (p *PathItemChanges) TotalBreakingChanges() int {
 	if p.TraceChanges != nil {
 		c += p.TraceChanges.TotalBreakingChanges()
 	}
+	if p.QueryChanges != nil {
+		c += p.QueryChanges.TotalBreakingChanges()
+	}
+	for _, oc := range p.AdditionalOperationChanges {
+		c += oc.TotalBreakingChanges()
+	}
 	for i := range p.ServerChanges {
 		c += p.ServerChanges[i].TotalBreakingChanges()
 	}
@@ -249,7 +272,7 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Put.IsEmpty() && !rPath.Put.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PutLabel,
-			nil, rPath.Put.ValueNode, false, nil, lPath.Put.Value)
+			nil, rPath.Put.ValueNode, false, nil, rPath.Put.Value)
 	}
 
 	// post
@@ -263,7 +286,7 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Post.IsEmpty() && !rPath.Post.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PostLabel,
-			nil, rPath.Post.ValueNode, false, nil, lPath.Post.Value)
+			nil, rPath.Post.ValueNode, false, nil, rPath.Post.Value)
 	}
 
 	// delete
@@ -277,7 +300,7 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Delete.IsEmpty() && !rPath.Delete.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.DeleteLabel,
-			nil, rPath.Delete.ValueNode, false, nil, lPath.Delete.Value)
+			nil, rPath.Delete.ValueNode, false, nil, rPath.Delete.Value)
 	}
 
 	// options
@@ -291,7 +314,7 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Options.IsEmpty() && !rPath.Options.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.OptionsLabel,
-			nil, rPath.Options.ValueNode, false, nil, lPath.Options.Value)
+			nil, rPath.Options.ValueNode, false, nil, rPath.Options.Value)
 	}
 
 	// head
@@ -305,7 +328,7 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Head.IsEmpty() && !rPath.Head.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.HeadLabel,
-			nil, rPath.Head.ValueNode, false, nil, lPath.Head.Value)
+			nil, rPath.Head.ValueNode, false, nil, rPath.Head.Value)
 	}
 
 	// patch
@@ -319,7 +342,7 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Patch.IsEmpty() && !rPath.Patch.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PatchLabel,
-			nil, rPath.Patch.ValueNode, false, nil, lPath.Patch.Value)
+			nil, rPath.Patch.ValueNode, false, nil, rPath.Patch.Value)
 	}
 
 	// parameters
@@ -471,7 +494,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Get.IsEmpty() && !rPath.Get.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.GetLabel,
-			nil, rPath.Get.ValueNode, false, nil, lPath.Get.Value)
+			nil, rPath.Get.ValueNode, false, nil, rPath.Get.Value)
 	}
 
 	// put
@@ -485,7 +508,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Put.IsEmpty() && !rPath.Put.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PutLabel,
-			nil, rPath.Put.ValueNode, false, nil, lPath.Put.Value)
+			nil, rPath.Put.ValueNode, false, nil, rPath.Put.Value)
 	}
 
 	// post
@@ -499,7 +522,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Post.IsEmpty() && !rPath.Post.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PostLabel,
-			nil, rPath.Post.ValueNode, false, nil, lPath.Post.Value)
+			nil, rPath.Post.ValueNode, false, nil, rPath.Post.Value)
 	}
 
 	// delete
@@ -513,7 +536,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Delete.IsEmpty() && !rPath.Delete.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.DeleteLabel,
-			nil, rPath.Delete.ValueNode, false, nil, lPath.Delete.Value)
+			nil, rPath.Delete.ValueNode, false, nil, rPath.Delete.Value)
 	}
 
 	// options
@@ -527,7 +550,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Options.IsEmpty() && !rPath.Options.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.OptionsLabel,
-			nil, rPath.Options.ValueNode, false, nil, lPath.Options.Value)
+			nil, rPath.Options.ValueNode, false, nil, rPath.Options.Value)
 	}
 
 	// head
@@ -541,7 +564,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Head.IsEmpty() && !rPath.Head.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.HeadLabel,
-			nil, rPath.Head.ValueNode, false, nil, lPath.Head.Value)
+			nil, rPath.Head.ValueNode, false, nil, rPath.Head.Value)
 	}
 
 	// patch
@@ -555,7 +578,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Patch.IsEmpty() && !rPath.Patch.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PatchLabel,
-			nil, rPath.Patch.ValueNode, false, nil, lPath.Patch.Value)
+			nil, rPath.Patch.ValueNode, false, nil, rPath.Patch.Value)
 	}
 
 	// trace
@@ -569,9 +592,26 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	}
 	if lPath.Trace.IsEmpty() && !rPath.Trace.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.TraceLabel,
-			nil, rPath.Trace.ValueNode, false, nil, lPath.Trace.Value)
+			nil, rPath.Trace.ValueNode, false, nil, rPath.Trace.Value)
 	}
 
+	// query (3.2)
+	if !lPath.Query.IsEmpty() && !rPath.Query.IsEmpty() {
+		totalOps++
+		go checkOperation(lPath.Query.Value, rPath.Query.Value, opChan, v3.QueryLabel)
+	}
+	if !lPath.Query.IsEmpty() && rPath.Query.IsEmpty() {
+		CreateChange(changes, PropertyRemoved, v3.QueryLabel,
+			lPath.Query.ValueNode, nil, true, lPath.Query.Value, nil)
+	}
+	if lPath.Query.IsEmpty() && !rPath.Query.IsEmpty() {
+		CreateChange(changes, PropertyAdded, v3.QueryLabel,
+			nil, rPath.Query.ValueNode, false, nil, rPath.Query.Value)
+	}
+
+	// additional operations (3.2)
+	pc.AdditionalOperationChanges = checkAdditionalOperations(lPath, rPath, changes)
+
 	// servers
 	pc.ServerChanges = checkServers(lPath.Servers, rPath.Servers)
 
@@ -623,12 +663,43 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 			pc.PatchChanges = n.changes
 		case v3.TraceLabel:
 			pc.TraceChanges = n.changes
+		case v3.QueryLabel:
+			pc.QueryChanges = n.changes
 		}
 		completedOperations++
 	}
 	pc.ExtensionChanges = CompareExtensions(lPath.Extensions, rPath.Extensions)
 }
 
+// checkAdditionalOperations compares the additional operations (3.2) of two path items by method. Added and removed
+// operations are reported against the additionalOperations label.
+// checkAdditionalOperations compares the additional operations (3.2) of two path items by method. Added and removed
+// operations are reported against the additionalOperations label.
+func checkAdditionalOperations(lPath, rPath *v3.PathItem, changes *[]*Change) map[string]*OperationChanges {
+	opChanges := make(map[string]*OperationChanges)
+	for k, l := range lPath.AdditionalOperations.Value.FromOldest() {
+		r := low.FindItemInOrderedMap(k.Value, rPath.AdditionalOperations.Value)
+		if r == nil {
+			CreateChange(changes, ObjectRemoved, v3.AdditionalOperationsLabel,
+				l.ValueNode, nil, true, l.Value, nil)
+			continue
+		}
+		if oc := CompareOperations(l.Value, r.Value); oc != nil {
+			opChanges[k.Value] = oc
+		}
+	}
+	for k, r := range rPath.AdditionalOperations.Value.FromOldest() {
+		if low.FindItemInOrderedMap(k.Value, lPath.AdditionalOperations.Value) == nil {
+			CreateChange(changes, ObjectAdded, v3.AdditionalOperationsLabel,
+				nil, r.ValueNode, false, nil, r.Value)
+		}
+	}
+	if len(opChanges) == 0 {
+		return nil
+	}
+	return opChanges
+}
+
 func checkOperation(l, r any, done chan opCheck, method string) {
 	done <- opCheck{
 		label:   method,
@@ -125,6 +125,8 @@ func ComparePaths(l, r any) *PathsChanges {
 			<-doneChan
 			completedChecks++
 		}
+		changes = append(changes, detectOperationMoves(pathChanges)...)
+		changes = detectMoves(changes, Moved, true)
 		if len(pathChanges) > 0 {
 			pc.PathItemsChanges = pathChanges
 		}
@@ -194,6 +196,8 @@ func ComparePaths(l, r any) *PathsChanges {
 			<-doneChan
 			completedChecks++
 		}
+		changes = append(changes, detectOperationMoves(pathChanges)...)
+		changes = detectMoves(changes, Moved, true)
 		if len(pathChanges) > 0 {
 			pc.PathItemsChanges = pathChanges
 		}
@@ -7,6 +7,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"reflect"
+	"slices"
 )
 
 import (
@@ -120,8 +121,11 @@ func CompareResponse(l, r any) *ResponseChanges {
 		lResponse := l.(*v2.Response)
 		rResponse := r.(*v2.Response)
 
-		// perform hash check to avoid further processing
-		if low.AreEqual(lResponse, rResponse) {
+		// perform hash check to avoid further processing, the swagger response hash does not include headers,
+		// so they are checked separately.
+		if low.AreEqual(lResponse, rResponse) &&
+			slices.Equal(low.AppendMapHashes(nil, lResponse.Headers.Value),
+				low.AppendMapHashes(nil, rResponse.Headers.Value)) {
 			return nil
 		}
 
@@ -144,8 +148,8 @@ func CompareResponse(l, r any) *ResponseChanges {
 		}
 
 		rc.HeadersChanges =
-			CheckMapForChanges(lResponse.Headers.Value, rResponse.Headers.Value,
-				&changes, v3.HeadersLabel, CompareHeadersV2)
+			CheckHeaderMapForChanges(lResponse.Headers.Value, rResponse.Headers.Value,
+				&changes, CompareHeadersV2)
 
 		if !lResponse.Examples.IsEmpty() && !rResponse.Examples.IsEmpty() {
 			rc.ExamplesChanges = CompareExamplesV2(lResponse.Examples.Value, rResponse.Examples.Value)
@@ -179,8 +183,8 @@ func CompareResponse(l, r any) *ResponseChanges {
 			lResponse.Description.Value, lResponse.Description.Value, &changes, v3.DescriptionLabel, false)
 
 		rc.HeadersChanges =
-			CheckMapForChanges(lResponse.Headers.Value, rResponse.Headers.Value,
-				&changes, v3.HeadersLabel, CompareHeadersV3)
+			CheckHeaderMapForChanges(lResponse.Headers.Value, rResponse.Headers.Value,
+				&changes, CompareHeadersV3)
 
 		rc.ContentChanges =
 			CheckMapForChanges(lResponse.Content.Value, rResponse.Content.Value,
@@ -195,5 +199,8 @@ func CompareResponse(l, r any) *ResponseChanges {
 
 	CheckProperties(props)
 	rc.PropertyChanges = NewPropertyChanges(changes)
+	if rc.TotalChanges() <= 0 {
+		return nil
+	}
 	return rc
 }
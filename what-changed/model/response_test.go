@@ -232,3 +232,119 @@ x-toot: pooty`
 	assert.Len(t, extChanges.GetAllChanges(), 5)
 	assert.Equal(t, 2, extChanges.TotalBreakingChanges())
 }
+
+func TestCompareResponse_V3_HeaderNameCase(t *testing.T) {
+	left := `description: response
+headers:
+  X-Rate-Limit:
+    description: calls per hour
+    schema:
+      type: integer
+  X-Request-Id:
+    schema:
+      type: string`
+
+	right := `description: response
+headers:
+  x-rate-limit:
+    description: calls per hour
+    required: true
+    deprecated: true
+    schema:
+      type: string
+  X-Correlation-Id:
+    schema:
+      type: string`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Response
+	var rDoc v3.Response
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	extChanges := CompareResponse(&lDoc, &rDoc)
+
+	// rate limit header matched by name regardless of case, request id renamed to correlation id.
+	assert.Len(t, extChanges.HeadersChanges, 1)
+	hc := extChanges.HeadersChanges["X-Rate-Limit"]
+	assert.NotNil(t, hc)
+	assert.Equal(t, 3, hc.TotalChanges())
+	assert.Equal(t, 2, hc.TotalBreakingChanges())
+	assert.Equal(t, 1, hc.SchemaChanges.TotalChanges())
+
+	var removed, added *Change
+	for _, c := range extChanges.Changes {
+		switch c.ChangeType {
+		case ObjectRemoved:
+			removed = c
+		case ObjectAdded:
+			added = c
+		}
+	}
+	assert.True(t, removed.Breaking)
+	assert.False(t, added.Breaking)
+
+	assert.Equal(t, 5, extChanges.TotalChanges())
+	assert.Equal(t, 3, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareResponse_V2_HeaderNameCase(t *testing.T) {
+	left := `description: response
+headers:
+  X-Rate-Limit:
+    type: integer`
+
+	right := `description: response
+headers:
+  x-rate-limit:
+    type: string`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Response
+	var rDoc v2.Response
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	extChanges := CompareResponse(&lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Equal(t, 1, extChanges.HeadersChanges["X-Rate-Limit"].TotalChanges())
+}
+
+func TestCompareResponse_V2_HeaderNameCase_Only(t *testing.T) {
+	left := `description: response
+headers:
+  X-Rate-Limit:
+    type: integer`
+
+	right := `description: response
+headers:
+  x-rate-limit:
+    type: integer`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Response
+	var rDoc v2.Response
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	assert.Nil(t, CompareResponse(&lDoc, &rDoc))
+}
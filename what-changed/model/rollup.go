@@ -0,0 +1,130 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "iter"
+
+// Changed is implemented by every changes node in the what-changed tree (DocumentChanges, PathsChanges,
+// SchemaChanges and so on), regardless of depth.
+type Changed interface {
+	// GetAllChanges returns every leaf change found in this node and all of its children.
+	GetAllChanges() []*Change
+
+	// TotalChanges returns the number of leaf changes found in this node and all of its children.
+	TotalChanges() int
+
+	// TotalBreakingChanges returns the number of breaking leaf changes found in this node and all of its children.
+	TotalBreakingChanges() int
+}
+
+// changesNode is a pointer to a changes node, so a nil node can be told apart.
+type changesNode[C any] interface {
+	*C
+	Changed
+}
+
+// GetSeverityRollup returns a count of every leaf change found in a changes node (any of them, DocumentChanges,
+// SchemaChanges and so on) and all of its children, grouped by severity. A nil node has no changes.
+func GetSeverityRollup[C any, T changesNode[C]](changes T) *SeverityRollup {
+	if changes == nil {
+		return new(SeverityRollup)
+	}
+	return NewSeverityRollup(changes.GetAllChanges())
+}
+
+// LeafChanges returns an iterator over every leaf change found in a changes node and all of its children.
+// A nil node has no changes.
+func LeafChanges[C any, T changesNode[C]](changes T) iter.Seq[*Change] {
+	if changes == nil {
+		return iterateChanges(nil)
+	}
+	return iterateChanges(changes.GetAllChanges())
+}
+
+// ChangeSeverity describes how serious a change is for consumers of a specification.
+type ChangeSeverity int
+
+const (
+	// SeverityInfo is a non-breaking addition or modification.
+	SeverityInfo ChangeSeverity = iota
+
+	// SeverityWarning is a non-breaking removal, something consumers may have been relying on.
+	SeverityWarning
+
+	// SeverityBreaking is a change that breaks the contract.
+	SeverityBreaking
+)
+
+// String returns the name of the severity.
+func (s ChangeSeverity) String() string {
+	switch s {
+	case SeverityBreaking:
+		return "breaking"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Severity returns the ChangeSeverity of the change.
+func (c *Change) Severity() ChangeSeverity {
+	if c.Breaking {
+		return SeverityBreaking
+	}
+	if c.ChangeType == ObjectRemoved || c.ChangeType == PropertyRemoved {
+		return SeverityWarning
+	}
+	return SeverityInfo
+}
+
+// SeverityRollup holds counts of changes, grouped by severity and by change type.
+type SeverityRollup struct {
+	Breaking int `json:"breaking" yaml:"breaking"`
+	Warning  int `json:"warning" yaml:"warning"`
+	Info     int `json:"info" yaml:"info"`
+	Added    int `json:"added" yaml:"added"`
+	Modified int `json:"modified" yaml:"modified"`
+	Removed  int `json:"removed" yaml:"removed"`
+}
+
+// Total returns the total number of changes counted by the rollup.
+func (s *SeverityRollup) Total() int {
+	return s.Breaking + s.Warning + s.Info
+}
+
+// NewSeverityRollup counts a slice of changes into a *SeverityRollup.
+func NewSeverityRollup(changes []*Change) *SeverityRollup {
+	r := new(SeverityRollup)
+	for _, c := range changes {
+		switch c.Severity() {
+		case SeverityBreaking:
+			r.Breaking++
+		case SeverityWarning:
+			r.Warning++
+		default:
+			r.Info++
+		}
+		switch c.ChangeType {
+		case PropertyAdded, ObjectAdded:
+			r.Added++
+		case PropertyRemoved, ObjectRemoved:
+			r.Removed++
+		default:
+			r.Modified++
+		}
+	}
+	return r
+}
+
+// iterateChanges returns an iterator over a slice of changes.
+func iterateChanges(changes []*Change) iter.Seq[*Change] {
+	return func(yield func(*Change) bool) {
+		for _, c := range changes {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// walkChanged calls f for every Changed node found in the tree, at any depth.
+func walkChanged(v reflect.Value, path string, f func(path string, c Changed)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if v.Kind() == reflect.Pointer {
+			if c, ok := v.Interface().(Changed); ok {
+				f(path, c)
+			}
+		}
+		walkChanged(v.Elem(), path, f)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				walkChanged(v.Field(i), path+"."+v.Type().Field(i).Name, f)
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			walkChanged(v.Index(i), path, f)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			walkChanged(v.MapIndex(k), path+"["+k.String()+"]", f)
+		}
+	}
+}
+
+func checkChangedConsistency(t *testing.T, root Changed) int {
+	nodes := 0
+	walkChanged(reflect.ValueOf(root), "root", func(path string, c Changed) {
+		nodes++
+		all := c.GetAllChanges()
+		assert.Equal(t, len(all), c.TotalChanges(), "total changes: %s", path)
+		assert.Equal(t, CountBreakingChanges(all), c.TotalBreakingChanges(), "breaking changes: %s", path)
+
+		rollup := NewSeverityRollup(all)
+		assert.Equal(t, c.TotalChanges(), rollup.Total(), "rollup: %s", path)
+		assert.Equal(t, c.TotalBreakingChanges(), rollup.Breaking, "rollup breaking: %s", path)
+		assert.Equal(t, c.TotalChanges(), rollup.Added+rollup.Modified+rollup.Removed, "rollup types: %s", path)
+	})
+	return nodes
+}
+
+func TestChanged_Consistency_OpenAPI(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	modified, _ := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	changes := CompareDocuments(origDoc, modDoc)
+	assert.Greater(t, checkChangedConsistency(t, changes), 20)
+}
+
+func TestChanged_Consistency_Swagger(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/petstorev2-complete.yaml")
+	modified, _ := os.ReadFile("../../test_specs/petstorev2-complete-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+	origDoc, _ := v2.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v2.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	changes := CompareDocuments(origDoc, modDoc)
+	assert.Greater(t, checkChangedConsistency(t, changes), 10)
+}
+
+func TestChanged_NilNodes(t *testing.T) {
+	var d *DocumentChanges
+	assert.Equal(t, 0, GetSeverityRollup(d).Total())
+	for range LeafChanges(d) {
+		t.Fatal("nil node has no changes")
+	}
+	var s *SchemaChanges
+	assert.Equal(t, 0, GetSeverityRollup(s).Total())
+}
+
+func TestGetSeverityRollup(t *testing.T) {
+	h := &HeaderChanges{
+		PropertyChanges: NewPropertyChanges([]*Change{{ChangeType: Modified, Breaking: true}}),
+		SchemaChanges: &SchemaChanges{
+			PropertyChanges: NewPropertyChanges([]*Change{{ChangeType: PropertyRemoved}, {ChangeType: ObjectAdded}}),
+		},
+	}
+	assert.Equal(t, &SeverityRollup{Breaking: 1, Warning: 1, Info: 1, Added: 1, Modified: 1, Removed: 1},
+		GetSeverityRollup(h))
+
+	n := 0
+	for range LeafChanges(h) {
+		n++
+	}
+	assert.Equal(t, 3, n)
+}
+
+func TestSeverityRollup(t *testing.T) {
+	changes := []*Change{
+		{ChangeType: Modified, Breaking: true},
+		{ChangeType: PropertyRemoved},
+		{ChangeType: ObjectRemoved, Breaking: true},
+		{ChangeType: ObjectAdded},
+		{ChangeType: Modified},
+	}
+	r := NewSeverityRollup(changes)
+	assert.Equal(t, &SeverityRollup{Breaking: 2, Warning: 1, Info: 2, Added: 1, Modified: 2, Removed: 2}, r)
+	assert.Equal(t, 5, r.Total())
+
+	assert.Equal(t, "breaking", changes[0].Severity().String())
+	assert.Equal(t, "warning", changes[1].Severity().String())
+	assert.Equal(t, "info", changes[3].Severity().String())
+
+	// iterators can stop early.
+	n := 0
+	for range iterateChanges(changes) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, n)
+}
@@ -46,6 +46,7 @@ type SchemaChanges struct {
 	UnevaluatedPropertiesChanges *SchemaChanges            `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
 	DependentSchemasChanges      map[string]*SchemaChanges `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
 	PatternPropertiesChanges     map[string]*SchemaChanges `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
+	DefsChanges                  map[string]*SchemaChanges `json:"$defs,omitempty" yaml:"$defs,omitempty"`
 }
 
 // GetAllChanges returns a slice of all changes made between Responses objects
@@ -127,6 +128,13 @@ func (s *SchemaChanges) GetAllChanges() []*Change {
 			}
 		}
 	}
+	if s.DefsChanges != nil {
+		for n := range s.DefsChanges {
+			if s.DefsChanges[n] != nil {
+				changes = append(changes, s.DefsChanges[n].GetAllChanges()...)
+			}
+		}
+	}
 	if s.ExternalDocChanges != nil {
 		changes = append(changes, s.ExternalDocChanges.GetAllChanges()...)
 	}
@@ -213,6 +221,11 @@ func (s *SchemaChanges) TotalChanges() int {
 			t += s.PatternPropertiesChanges[n].TotalChanges()
 		}
 	}
+	if s.DefsChanges != nil {
+		for n := range s.DefsChanges {
+			t += s.DefsChanges[n].TotalChanges()
+		}
+	}
 	if s.ExternalDocChanges != nil {
 		t += s.ExternalDocChanges.TotalChanges()
 	}
@@ -295,6 +308,11 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
 			t += s.PatternPropertiesChanges[n].TotalBreakingChanges()
 		}
 	}
+	if s.DefsChanges != nil {
+		for n := range s.DefsChanges {
+			t += s.DefsChanges[n].TotalBreakingChanges()
+		}
+	}
 	if s.XMLChanges != nil {
 		t += s.XMLChanges.TotalBreakingChanges()
 	}
@@ -401,6 +419,9 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 		patterns, patternsTotal := checkMappedSchemaOfASchema(lSchema.PatternProperties.Value, rSchema.PatternProperties.Value, &changes, doneChan)
 		sc.PatternPropertiesChanges = patterns
 
+		defs, defsTotal := checkMappedSchemaOfASchema(lSchema.Defs.Value, rSchema.Defs.Value, &changes, doneChan)
+		sc.DefsChanges = defs
+
 		// check polymorphic and multi-values async for speed.
 		go extractSchemaChanges(lSchema.OneOf.Value, rSchema.OneOf.Value, v3.OneOfLabel,
 			&sc.OneOfChanges, &changes, doneChan)
@@ -411,7 +432,7 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 		go extractSchemaChanges(lSchema.AnyOf.Value, rSchema.AnyOf.Value, v3.AnyOfLabel,
 			&sc.AnyOfChanges, &changes, doneChan)
 
-		totalChecks := totalProperties + depsTotal + patternsTotal + 3
+		totalChecks := totalProperties + depsTotal + patternsTotal + defsTotal + 3
 		completedChecks := 0
 		for completedChecks < totalChecks {
 			<-doneChan
@@ -898,6 +919,39 @@ func checkSchemaPropertyChanges(
 		New:       rSchema,
 	})
 
+	// $anchor (breaking change)
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.Anchor.ValueNode,
+		RightNode: rSchema.Anchor.ValueNode,
+		Label:     v3.AnchorLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
+	// $dynamicAnchor (breaking change)
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.DynamicAnchor.ValueNode,
+		RightNode: rSchema.DynamicAnchor.ValueNode,
+		Label:     v3.DynamicAnchorLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
+	// $dynamicRef (breaking change)
+	props = append(props, &PropertyCheck{
+		LeftNode:  lSchema.DynamicRef.ValueNode,
+		RightNode: rSchema.DynamicRef.ValueNode,
+		Label:     v3.DynamicRefLabel,
+		Changes:   changes,
+		Breaking:  true,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+
 	// Required
 	j := make(map[string]int)
 	k := make(map[string]int)
@@ -907,42 +961,52 @@ func checkSchemaPropertyChanges(
 	for i := range rSchema.Required.Value {
 		k[rSchema.Required.Value[i].Value] = i
 	}
-	for g := range k {
-		if _, ok := j[g]; !ok {
+	// required properties are added and removed in the order of the document.
+	for i, required := range rSchema.Required.Value {
+		if _, ok := j[required.Value]; !ok && k[required.Value] == i {
 			CreateChange(changes, PropertyAdded, v3.RequiredLabel,
-				nil, rSchema.Required.Value[k[g]].GetValueNode(), true, nil,
-				rSchema.Required.Value[k[g]].GetValue)
+				nil, required.GetValueNode(), true, nil, required.Value)
 		}
 	}
-	for g := range j {
-		if _, ok := k[g]; !ok {
+	for i, required := range lSchema.Required.Value {
+		if _, ok := k[required.Value]; !ok && j[required.Value] == i {
 			CreateChange(changes, PropertyRemoved, v3.RequiredLabel,
-				lSchema.Required.Value[j[g]].GetValueNode(), nil, true, lSchema.Required.Value[j[g]].GetValue,
-				nil)
+				required.GetValueNode(), nil, true, required.Value, nil)
 		}
 	}
 
-	// Enums
-	j = make(map[string]int)
-	k = make(map[string]int)
-	for i := range lSchema.Enum.Value {
-		j[toString(lSchema.Enum.Value[i].Value)] = i
-	}
-	for i := range rSchema.Enum.Value {
-		k[toString(rSchema.Enum.Value[i].Value)] = i
-	}
-	for g := range k {
-		if _, ok := j[g]; !ok {
-			CreateChange(changes, PropertyAdded, v3.EnumLabel,
-				nil, rSchema.Enum.Value[k[g]].GetValueNode(), false, nil,
-				rSchema.Enum.Value[k[g]].GetValue)
+	// Enums, an enum added (or removed) is a single change, otherwise every member added or removed is a change of
+	// its own, in the order of the enums. The changes of a document are classified by where the schema is used
+	// once compared (see classifyDirectionalChanges).
+	switch {
+	case len(lSchema.Enum.Value) == 0 && len(rSchema.Enum.Value) > 0:
+		CreateChange(changes, PropertyAdded, v3.EnumLabel,
+			nil, rSchema.Enum.ValueNode, true, lSchema, rSchema)
+	case len(lSchema.Enum.Value) > 0 && len(rSchema.Enum.Value) == 0:
+		CreateChange(changes, PropertyRemoved, v3.EnumLabel,
+			lSchema.Enum.ValueNode, nil, true, lSchema, rSchema)
+	default:
+		j = make(map[string]int)
+		k = make(map[string]int)
+		for i := range lSchema.Enum.Value {
+			j[toString(lSchema.Enum.Value[i].Value)] = i
 		}
-	}
-	for g := range j {
-		if _, ok := k[g]; !ok {
-			CreateChange(changes, PropertyRemoved, v3.EnumLabel,
-				lSchema.Enum.Value[j[g]].GetValueNode(), nil, true, lSchema.Enum.Value[j[g]].GetValue,
-				nil)
+		for i := range rSchema.Enum.Value {
+			k[toString(rSchema.Enum.Value[i].Value)] = i
+		}
+		for i, member := range rSchema.Enum.Value {
+			g := toString(member.Value)
+			if _, ok := j[g]; !ok && k[g] == i {
+				CreateChange(changes, PropertyAdded, v3.EnumLabel,
+					nil, member.GetValueNode(), false, nil, member.Value)
+			}
+		}
+		for i, member := range lSchema.Enum.Value {
+			g := toString(member.Value)
+			if _, ok := k[g]; !ok && j[g] == i {
+				CreateChange(changes, PropertyRemoved, v3.EnumLabel,
+					member.GetValueNode(), nil, true, member.Value, nil)
+			}
 		}
 	}
 
@@ -1065,8 +1129,22 @@ func checkSchemaPropertyChanges(
 	}
 	// UnevaluatedItems
 	if lSchema.UnevaluatedItems.Value != nil && rSchema.UnevaluatedItems.Value != nil {
-		if !low.AreEqual(lSchema.UnevaluatedItems.Value, rSchema.UnevaluatedItems.Value) {
-			sc.UnevaluatedItemsChanges = CompareSchemas(lSchema.UnevaluatedItems.Value, rSchema.UnevaluatedItems.Value)
+		if lSchema.UnevaluatedItems.Value.IsA() && rSchema.UnevaluatedItems.Value.IsA() {
+			if !low.AreEqual(lSchema.UnevaluatedItems.Value.A, rSchema.UnevaluatedItems.Value.A) {
+				sc.UnevaluatedItemsChanges = CompareSchemas(lSchema.UnevaluatedItems.Value.A, rSchema.UnevaluatedItems.Value.A)
+			}
+		} else {
+			if lSchema.UnevaluatedItems.Value.IsB() && rSchema.UnevaluatedItems.Value.IsB() {
+				if lSchema.UnevaluatedItems.Value.B != rSchema.UnevaluatedItems.Value.B {
+					CreateChange(changes, Modified, v3.UnevaluatedItemsLabel,
+						lSchema.UnevaluatedItems.ValueNode, rSchema.UnevaluatedItems.ValueNode, true,
+						lSchema.UnevaluatedItems.Value.B, rSchema.UnevaluatedItems.Value.B)
+				}
+			} else {
+				CreateChange(changes, Modified, v3.UnevaluatedItemsLabel,
+					lSchema.UnevaluatedItems.ValueNode, rSchema.UnevaluatedItems.ValueNode, true,
+					lSchema.UnevaluatedItems.Value.B, rSchema.UnevaluatedItems.Value.B)
+			}
 		}
 	}
 	// added UnevaluatedItems
@@ -15,6 +15,8 @@ import (
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // These tests require full documents to be tested properly. schemas are perhaps the most complex
@@ -2942,3 +2944,154 @@ components:
 	assert.Equal(t, 1, changes.TotalChanges())
 
 }
+
+func TestCompareSchemas_UnevaluatedItems_Bool(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      unevaluatedItems: true`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      unevaluatedItems: false`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, Modified, changes.Changes[0].ChangeType)
+	assert.Equal(t, v3.UnevaluatedItemsLabel, changes.Changes[0].Property)
+
+	// schema to bool
+	left = `openapi: 3.1
+components:
+  schemas:
+    OK:
+      unevaluatedItems:
+        type: string`
+
+	leftDoc, rightDoc = test_BuildDoc(left, right)
+	lSchemaProxy = leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy = rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes = CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, v3.UnevaluatedItemsLabel, changes.Changes[0].Property)
+}
+
+func TestCompareSchemas_DynamicKeywords(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $anchor: tree
+      $dynamicAnchor: node
+      items:
+        $dynamicRef: '#node'`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $anchor: forest
+      $dynamicAnchor: leaf
+      items:
+        $dynamicRef: '#leaf'`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 3, changes.TotalBreakingChanges())
+	assert.Equal(t, v3.DynamicRefLabel, changes.ItemsChanges.Changes[0].Property)
+	assert.Equal(t, "#node", changes.ItemsChanges.Changes[0].Original)
+	assert.Equal(t, "#leaf", changes.ItemsChanges.Changes[0].New)
+}
+
+func TestCompareSchemas_Defs(t *testing.T) {
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $defs:
+        leaf:
+          type: string
+        branch:
+          type: object`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      $defs:
+        leaf:
+          type: integer
+        root:
+          type: object`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+	assert.Equal(t, 1, changes.DefsChanges["leaf"].TotalChanges())
+	assert.Len(t, changes.GetAllChanges(), 3)
+}
+
+func TestCompareSchemas_EnumMembersInOrder(t *testing.T) {
+	left := `openapi: 3.0
+components:
+  schemas:
+    OK:
+      enum: [a,b,c,d]
+    None:
+      type: string`
+
+	right := `openapi: 3.0
+components:
+  schemas:
+    OK:
+      enum: [e,a,f,g]
+    None:
+      type: string
+      enum: [a]`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	changes := CompareSchemas(leftDoc.Components.Value.FindSchema("OK").Value,
+		rightDoc.Components.Value.FindSchema("OK").Value)
+	require.Len(t, changes.Changes, 6)
+	var members []string
+	for _, c := range changes.Changes {
+		assert.Equal(t, v3.EnumLabel, c.Property)
+		members = append(members, c.Original+c.New)
+	}
+	assert.Equal(t, []string{"e", "f", "g", "b", "c", "d"}, members)
+	assert.False(t, changes.Changes[0].Breaking)
+	assert.True(t, changes.Changes[3].Breaking)
+	assert.Equal(t, "b", changes.Changes[3].OriginalObject.(*yaml.Node).Value)
+
+	// an enum added is a single change.
+	changes = CompareSchemas(leftDoc.Components.Value.FindSchema("None").Value,
+		rightDoc.Components.Value.FindSchema("None").Value)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, PropertyAdded, changes.Changes[0].ChangeType)
+	assert.True(t, changes.Changes[0].Breaking)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+// ChangeSeries is a timeline of the changes made across a series of versions of a specification, oldest first.
+// Each step compares a version with the one before it, and keeps a running total of the changes made since the
+// first version, so a changelog can be generated for a whole release history.
+type ChangeSeries struct {
+	Steps                []*SeriesStep `json:"steps,omitempty" yaml:"steps,omitempty"`
+	TotalChanges         int           `json:"totalChanges" yaml:"totalChanges"`
+	TotalBreakingChanges int           `json:"totalBreakingChanges" yaml:"totalBreakingChanges"`
+}
+
+// SeriesStep holds the changes made between two consecutive versions of a series.
+type SeriesStep struct {
+	// From is the name of the earlier version (the version of its info object, or its position in the series if it
+	// has no version), To is the name of the later one.
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+
+	// Changes are the changes made between From and To, nil if nothing changed.
+	Changes *DocumentChanges `json:"changes,omitempty" yaml:"changes,omitempty"`
+
+	// TotalChanges and TotalBreakingChanges count the changes made between From and To.
+	TotalChanges         int `json:"totalChanges" yaml:"totalChanges"`
+	TotalBreakingChanges int `json:"totalBreakingChanges" yaml:"totalBreakingChanges"`
+
+	// CumulativeChanges and CumulativeBreakingChanges count the changes made since the first version of the
+	// series, up to and including this step.
+	CumulativeChanges         int `json:"cumulativeChanges" yaml:"cumulativeChanges"`
+	CumulativeBreakingChanges int `json:"cumulativeBreakingChanges" yaml:"cumulativeBreakingChanges"`
+}
+
+// AddStep appends the changes made between two versions to the series, and updates the running totals.
+func (s *ChangeSeries) AddStep(from, to string, changes *DocumentChanges) *SeriesStep {
+	step := &SeriesStep{
+		From:                 from,
+		To:                   to,
+		Changes:              changes,
+		TotalChanges:         changes.TotalChanges(),
+		TotalBreakingChanges: changes.TotalBreakingChanges(),
+	}
+	s.TotalChanges += step.TotalChanges
+	s.TotalBreakingChanges += step.TotalBreakingChanges
+	step.CumulativeChanges = s.TotalChanges
+	step.CumulativeBreakingChanges = s.TotalBreakingChanges
+	s.Steps = append(s.Steps, step)
+	return step
+}
+
+// GetStep returns the step that ends at version to, or nil if there isn't one.
+func (s *ChangeSeries) GetStep(to string) *SeriesStep {
+	for _, step := range s.Steps {
+		if step.To == to {
+			return step
+		}
+	}
+	return nil
+}
+
+// GetBreakingSteps returns every step that made breaking changes, oldest first.
+func (s *ChangeSeries) GetBreakingSteps() []*SeriesStep {
+	var steps []*SeriesStep
+	for _, step := range s.Steps {
+		if step.TotalBreakingChanges > 0 {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// GetLastBreakingStep returns the latest step that made breaking changes, or nil if the series has never broken
+// its contract. Every later version is compatible with the To version of the step.
+func (s *ChangeSeries) GetLastBreakingStep() *SeriesStep {
+	for i := len(s.Steps) - 1; i >= 0; i-- {
+		if s.Steps[i].TotalBreakingChanges > 0 {
+			return s.Steps[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSeries_AddStep(t *testing.T) {
+	changes := func(breaking ...bool) *DocumentChanges {
+		var c []*Change
+		for _, b := range breaking {
+			c = append(c, &Change{ChangeType: Modified, Property: "title", Breaking: b})
+		}
+		return &DocumentChanges{PropertyChanges: NewPropertyChanges(c)}
+	}
+
+	series := new(ChangeSeries)
+	series.AddStep("1.0", "1.1", changes(false, false))
+	series.AddStep("1.1", "2.0", changes(true, false, true))
+	series.AddStep("2.0", "2.1", nil)
+	series.AddStep("2.1", "2.2", changes(false))
+
+	assert.Len(t, series.Steps, 4)
+	assert.Equal(t, 6, series.TotalChanges)
+	assert.Equal(t, 2, series.TotalBreakingChanges)
+
+	step := series.GetStep("2.0")
+	assert.Equal(t, "1.1", step.From)
+	assert.Equal(t, 3, step.TotalChanges)
+	assert.Equal(t, 2, step.TotalBreakingChanges)
+	assert.Equal(t, 5, step.CumulativeChanges)
+	assert.Equal(t, 2, step.CumulativeBreakingChanges)
+
+	step = series.GetStep("2.1")
+	assert.Nil(t, step.Changes)
+	assert.Zero(t, step.TotalChanges)
+	assert.Equal(t, 5, step.CumulativeChanges)
+
+	assert.Equal(t, 6, series.GetStep("2.2").CumulativeChanges)
+	assert.Nil(t, series.GetStep("3.0"))
+
+	assert.Equal(t, []*SeriesStep{series.GetStep("2.0")}, series.GetBreakingSteps())
+	assert.Same(t, series.GetStep("2.0"), series.GetLastBreakingStep())
+}
+
+func TestChangeSeries_NoBreakingChanges(t *testing.T) {
+	series := new(ChangeSeries)
+	assert.Nil(t, series.GetLastBreakingStep())
+	assert.Empty(t, series.GetBreakingSteps())
+	series.AddStep("1.0", "1.1", nil)
+	assert.Nil(t, series.GetLastBreakingStep())
+}
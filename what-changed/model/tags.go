@@ -101,6 +101,39 @@ func CompareTags(l, r []low.ValueReference[*base.Tag]) []*TagChanges {
 				New:       seenRight[i].Value,
 			})
 
+			// Summary (3.2)
+			props = append(props, &PropertyCheck{
+				LeftNode:  seenLeft[i].Value.Summary.ValueNode,
+				RightNode: seenRight[i].Value.Summary.ValueNode,
+				Label:     v3.SummaryLabel,
+				Changes:   &changes,
+				Breaking:  false,
+				Original:  seenLeft[i].Value,
+				New:       seenRight[i].Value,
+			})
+
+			// Parent (3.2)
+			props = append(props, &PropertyCheck{
+				LeftNode:  seenLeft[i].Value.Parent.ValueNode,
+				RightNode: seenRight[i].Value.Parent.ValueNode,
+				Label:     base.ParentLabel,
+				Changes:   &changes,
+				Breaking:  false,
+				Original:  seenLeft[i].Value,
+				New:       seenRight[i].Value,
+			})
+
+			// Kind (3.2)
+			props = append(props, &PropertyCheck{
+				LeftNode:  seenLeft[i].Value.Kind.ValueNode,
+				RightNode: seenRight[i].Value.Kind.ValueNode,
+				Label:     base.KindLabel,
+				Changes:   &changes,
+				Breaking:  false,
+				Original:  seenLeft[i].Value,
+				New:       seenRight[i].Value,
+			})
+
 			// check properties
 			CheckProperties(props)
 
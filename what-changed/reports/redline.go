@@ -0,0 +1,205 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// redlineValueLength is the longest a value is shown in a marker, before it's truncated.
+const redlineValueLength = 60
+
+// Redline is the updated version of a specification, with every change made to it (relative to the original
+// version) attached to the line it was made on. It renders the "redline" view of a document reviewers ask for,
+// either as YAML with the changes as comments, or as HTML.
+type Redline struct {
+	// Lines are the lines of the updated specification.
+	Lines []string
+
+	// Markers are the changes made on each line of the updated specification, keyed by line (starting at 1).
+	Markers map[int][]*model.Change
+
+	// Removed are the changes that have no position in the updated specification, because what changed was
+	// removed. They are in the order of their position in the original specification.
+	Removed []*model.Change
+}
+
+// CreateRedline creates a Redline for the updated specification, from the changes between the original and updated
+// specification (see what_changed.CompareOpenAPIDocuments). updatedSpec must be the exact bytes of the updated
+// specification that was compared, so the lines of the changes match. Changes without a position in the updated
+// specification are Removed.
+func CreateRedline(updatedSpec []byte, changes *model.DocumentChanges) *Redline {
+	r := &Redline{
+		Lines:   strings.Split(strings.TrimSuffix(string(updatedSpec), "\n"), "\n"),
+		Markers: make(map[int][]*model.Change),
+	}
+	if changes == nil {
+		return r
+	}
+	for _, c := range changes.GetAllChanges() {
+		if c.Context != nil && c.Context.NewLine != nil && *c.Context.NewLine >= 1 && *c.Context.NewLine <= len(r.Lines) {
+			r.Markers[*c.Context.NewLine] = append(r.Markers[*c.Context.NewLine], c)
+			continue
+		}
+		r.Removed = append(r.Removed, c)
+	}
+	sort.SliceStable(r.Removed, func(i, j int) bool {
+		return originalLine(r.Removed[i]) < originalLine(r.Removed[j])
+	})
+	return r
+}
+
+// RenderYAML renders the updated specification with a comment above every changed line describing each change,
+// indented to match the line. Changes that were removed are described in a block of comments at the top. Comments
+// don't change the meaning of a YAML (or JSON) document, so the result is read exactly like the updated
+// specification, but it's only valid JSON if nothing changed.
+func (r *Redline) RenderYAML() []byte {
+	var buf bytes.Buffer
+	if len(r.Removed) > 0 {
+		buf.WriteString("# redline: removed from the original specification\n")
+		for _, c := range r.Removed {
+			buf.WriteString("#   " + DescribeChange(c) + "\n")
+		}
+	}
+	for i, line := range r.Lines {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		for _, c := range r.Markers[i+1] {
+			buf.WriteString(indent + "# redline: " + DescribeChange(c) + "\n")
+		}
+		buf.WriteString(line + "\n")
+	}
+	return buf.Bytes()
+}
+
+// RenderHTML renders the updated specification as a standalone HTML page, with line numbers, every changed line
+// highlighted (by its kind of change, and whether it's breaking) and followed by a description of each change. Changes
+// that were removed are listed above the specification.
+func (r *Redline) RenderHTML() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>redline</title>
+<style>
+.redline { font-family: monospace; white-space: pre; }
+.redline .ln { display: inline-block; width: 5em; color: #888; user-select: none; }
+.redline .modified { background: #fff5cc; }
+.redline .added { background: #e6ffec; }
+.redline .removed { background: #ffebe9; }
+.redline .marker { color: #555; font-style: italic; }
+.redline .breaking { color: #b00020; font-weight: bold; }
+</style>
+</head>
+<body>
+`)
+	if len(r.Removed) > 0 {
+		buf.WriteString("<ul class=\"redline removed\">\n")
+		for _, c := range r.Removed {
+			buf.WriteString("<li class=\"" + markerClass(c) + "\">" + html.EscapeString(DescribeChange(c)) + "</li>\n")
+		}
+		buf.WriteString("</ul>\n")
+	}
+	buf.WriteString("<div class=\"redline\">\n")
+	for i, line := range r.Lines {
+		markers := r.Markers[i+1]
+		class := "line"
+		if len(markers) > 0 {
+			class += " " + lineClass(markers)
+		}
+		fmt.Fprintf(&buf, "<div class=\"%s\" id=\"L%d\"><span class=\"ln\">%d</span>%s</div>\n",
+			class, i+1, i+1, html.EscapeString(line))
+		for _, c := range markers {
+			fmt.Fprintf(&buf, "<div class=\"%s\"><span class=\"ln\"></span>%s</div>\n",
+				markerClass(c), html.EscapeString(DescribeChange(c)))
+		}
+	}
+	buf.WriteString("</div>\n</body>\n</html>\n")
+	return buf.Bytes()
+}
+
+// DescribeChange returns a single line description of a change, such as `~ type: 'string' -> 'integer' (breaking)`.
+// Added changes start with `+`, removed changes with `-`, modifications with `~` and moves or renames with `>`. Long values are truncated, and
+// values over multiple lines are shown on one.
+func DescribeChange(c *model.Change) string {
+	var s string
+	switch c.ChangeType {
+	case model.Modified:
+		s = fmt.Sprintf("~ %s: %s -> %s", c.Property, redlineValue(c.Original), redlineValue(c.New))
+	case model.PropertyAdded, model.ObjectAdded:
+		s = "+ " + c.Property
+		if c.New != "" {
+			s += ": " + redlineValue(c.New)
+		}
+	case model.PropertyRemoved, model.ObjectRemoved:
+		s = "- " + c.Property
+		if c.Original != "" {
+			s += ": " + redlineValue(c.Original)
+		}
+		if line := originalLine(c); line > 0 {
+			s += fmt.Sprintf(" (line %d of the original)", line)
+		}
+	case model.Moved, model.Renamed:
+		s = fmt.Sprintf("> %s: %s -> %s", c.Property, redlineValue(c.Original), redlineValue(c.New))
+		if line := originalLine(c); line > 0 {
+			s += fmt.Sprintf(" (line %d of the original)", line)
+		}
+	default:
+		s = "? " + c.Property
+	}
+	if c.Breaking {
+		s += " (breaking)"
+	}
+	return s
+}
+
+func redlineValue(v string) string {
+	v = strings.ReplaceAll(strings.TrimSpace(v), "\n", `\n`)
+	if utf8.RuneCountInString(v) > redlineValueLength {
+		v = string([]rune(v)[:redlineValueLength]) + "..."
+	}
+	return "'" + v + "'"
+}
+
+func originalLine(c *model.Change) int {
+	if c.Context == nil || c.Context.OriginalLine == nil {
+		return 0
+	}
+	return *c.Context.OriginalLine
+}
+
+// markerClass returns the HTML classes of the description of a change.
+func markerClass(c *model.Change) string {
+	class := "marker"
+	if c.Breaking {
+		class += " breaking"
+	}
+	return class
+}
+
+// lineClass returns the HTML class of a changed line, a line with a modification (or a move) is modified, otherwise it's
+// added or removed (the object of a removal can remain on the line, such as a removed enum value).
+func lineClass(changes []*model.Change) string {
+	class := ""
+	for _, c := range changes {
+		switch c.ChangeType {
+		case model.Modified, model.Moved, model.Renamed:
+			return "modified"
+		case model.PropertyAdded, model.ObjectAdded:
+			if class == "" {
+				class = "added"
+			}
+		default:
+			class = "removed"
+		}
+	}
+	return class
+}
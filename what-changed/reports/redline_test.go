@@ -0,0 +1,139 @@
+// Copyright 2024 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var redlineOriginal = `openapi: 3.1.0
+info:
+  title: Pets
+  version: "1"
+paths:
+  /pets:
+    get:
+      description: list pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+  /toys:
+    get:
+      description: list toys`
+
+var redlineUpdated = `openapi: 3.1.0
+info:
+  title: Pets
+  version: "2"
+paths:
+  /pets:
+    get:
+      description: list all the pets
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer`
+
+func createRedlineDiff(t *testing.T) *model.DocumentChanges {
+	originalDoc, err := libopenapi.NewDocument([]byte(redlineOriginal))
+	require.NoError(t, err)
+	updatedDoc, err := libopenapi.NewDocument([]byte(redlineUpdated))
+	require.NoError(t, err)
+	changes, errs := libopenapi.CompareDocuments(originalDoc, updatedDoc)
+	require.Empty(t, errs)
+	return changes
+}
+
+func TestCreateRedline(t *testing.T) {
+	r := CreateRedline([]byte(redlineUpdated), createRedlineDiff(t))
+
+	assert.Len(t, r.Lines, 14)
+	require.Len(t, r.Markers[4], 1)
+	assert.Equal(t, "~ version: '1' -> '2'", DescribeChange(r.Markers[4][0]))
+	require.Len(t, r.Markers[8], 1)
+	assert.Equal(t, "~ description: 'list pets' -> 'list all the pets'", DescribeChange(r.Markers[8][0]))
+	require.Len(t, r.Markers[12], 1)
+	assert.Equal(t, "+ required: 'true' (breaking)", DescribeChange(r.Markers[12][0]))
+
+	require.Len(t, r.Removed, 1)
+	assert.Equal(t, "- path: '/toys' (line 14 of the original) (breaking)", DescribeChange(r.Removed[0]))
+}
+
+func TestRedline_RenderYAML(t *testing.T) {
+	r := CreateRedline([]byte(redlineUpdated), createRedlineDiff(t))
+	rendered := string(r.RenderYAML())
+
+	assert.True(t, strings.HasPrefix(rendered, `# redline: removed from the original specification
+#   - path: '/toys' (line 14 of the original) (breaking)
+openapi: 3.1.0
+info:
+  title: Pets
+  # redline: ~ version: '1' -> '2'
+  version: "2"
+`))
+	assert.Contains(t, rendered, `          in: query
+          # redline: + required: 'true' (breaking)
+          required: true
+`)
+
+	// the markers are comments, the document means exactly the same.
+	var annotated, updated any
+	require.NoError(t, yaml.Unmarshal([]byte(rendered), &annotated))
+	require.NoError(t, yaml.Unmarshal([]byte(redlineUpdated), &updated))
+	assert.Equal(t, updated, annotated)
+}
+
+func TestRedline_RenderHTML(t *testing.T) {
+	r := CreateRedline([]byte(redlineUpdated), createRedlineDiff(t))
+	rendered := string(r.RenderHTML())
+
+	assert.True(t, strings.HasPrefix(rendered, "<!DOCTYPE html>"))
+	assert.Contains(t, rendered, `<li class="marker breaking">- path: &#39;/toys&#39; (line 14 of the original) (breaking)</li>`)
+	assert.Contains(t, rendered, `<div class="line modified" id="L4"><span class="ln">4</span>  version: &#34;2&#34;</div>
+<div class="marker"><span class="ln"></span>~ version: &#39;1&#39; -&gt; &#39;2&#39;</div>`)
+	assert.Contains(t, rendered, `<div class="line added" id="L12"><span class="ln">12</span>          required: true</div>
+<div class="marker breaking"><span class="ln"></span>+ required: &#39;true&#39; (breaking)</div>`)
+	assert.Contains(t, rendered, `<div class="line" id="L1"><span class="ln">1</span>openapi: 3.1.0</div>`)
+}
+
+func TestCreateRedline_NoChanges(t *testing.T) {
+	r := CreateRedline([]byte(redlineUpdated+"\n"), nil)
+	assert.Len(t, r.Lines, 14)
+	assert.Empty(t, r.Markers)
+	assert.Equal(t, redlineUpdated+"\n", string(r.RenderYAML()))
+}
+
+func TestDescribeChange(t *testing.T) {
+	line := 3
+	assert.Equal(t, "+ summary: 'a\\nb'", DescribeChange(&model.Change{
+		ChangeType: model.PropertyAdded, Property: "summary", New: "a\nb\n",
+	}))
+	assert.Equal(t, "+ servers", DescribeChange(&model.Change{ChangeType: model.ObjectAdded, Property: "servers"}))
+	assert.Equal(t, "- summary: 'old' (line 3 of the original)", DescribeChange(&model.Change{
+		ChangeType: model.PropertyRemoved, Property: "summary", Original: "old",
+		Context: &model.ChangeContext{OriginalLine: &line},
+	}))
+	assert.Equal(t, "~ description: '"+strings.Repeat("x", 60)+"...' -> 'short'", DescribeChange(&model.Change{
+		ChangeType: model.Modified, Property: "description", Original: strings.Repeat("x", 100), New: "short",
+	}))
+	assert.Equal(t, "> path: '/v1/users' -> '/v2/users' (line 3 of the original) (breaking)", DescribeChange(&model.Change{
+		ChangeType: model.Moved, Property: "path", Original: "/v1/users", New: "/v2/users", Breaking: true,
+		Context: &model.ChangeContext{OriginalLine: &line},
+	}))
+	assert.Equal(t, "> schemas: 'Pet' -> 'Animal'", DescribeChange(&model.Change{
+		ChangeType: model.Renamed, Property: "schemas", Original: "Pet", New: "Animal",
+	}))
+	assert.Equal(t, "? odd", DescribeChange(&model.Change{Property: "odd"}))
+}
@@ -16,6 +16,9 @@
 package what_changed
 
 import (
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel/low/arazzo"
 	"github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/what-changed/model"
@@ -34,3 +37,84 @@ func CompareOpenAPIDocuments(original, updated *v3.Document) *model.DocumentChan
 func CompareSwaggerDocuments(original, updated *v2.Swagger) *model.DocumentChanges {
 	return model.CompareDocuments(original, updated)
 }
+
+// CompareArazzoDocuments will compare left (original) and right (updated) Arazzo documents and extract every change
+// made to their source descriptions and workflows. The report outlines every property changed, everything that was
+// added, or removed and which of those changes were breaking.
+func CompareArazzoDocuments(original, updated *arazzo.Document) *model.ArazzoChanges {
+	return model.CompareArazzoDocuments(original, updated)
+}
+
+// CompareOpenAPIDocumentsWithFilter will compare left (original) and right (updated) OpenAPI 3+ documents in the same
+// way as CompareOpenAPIDocuments, leaving out every change the filter ignores. Returns nil if there are no changes
+// left, or an error if the filter is invalid.
+func CompareOpenAPIDocumentsWithFilter(original, updated *v3.Document, filter *model.ChangeFilter) (*model.DocumentChanges, error) {
+	return filterDocumentChanges(model.CompareDocuments(original, updated), filter)
+}
+
+// CompareSwaggerDocumentsWithFilter will compare left (original) and right (updated) Swagger documents in the same
+// way as CompareSwaggerDocuments, leaving out every change the filter ignores. Returns nil if there are no changes
+// left, or an error if the filter is invalid.
+func CompareSwaggerDocumentsWithFilter(original, updated *v2.Swagger, filter *model.ChangeFilter) (*model.DocumentChanges, error) {
+	return filterDocumentChanges(model.CompareDocuments(original, updated), filter)
+}
+
+// AffectedOperations traces the changes of a report through the references of the documents compared, to every
+// operation whose contract they impact: operations changed themselves, and operations referencing changed
+// components, directly or through other components. See model.DocumentChanges.AffectedOperations.
+func AffectedOperations(changes *model.DocumentChanges) []*model.AffectedOperation {
+	return changes.AffectedOperations()
+}
+
+func filterDocumentChanges(changes *model.DocumentChanges, filter *model.ChangeFilter) (*model.DocumentChanges, error) {
+	if changes == nil {
+		return nil, nil
+	}
+	if err := filter.Apply(changes); err != nil {
+		return nil, err
+	}
+	if changes.TotalChanges() == 0 {
+		return nil, nil
+	}
+	return changes, nil
+}
+
+// CompareSeries compares every consecutive pair of a series of OpenAPI 3+ documents (versions of the same
+// specification, oldest first) and returns a timeline of the changes made across the whole series, with running
+// totals of changes and breaking changes. Versions are named by the version of their info object.
+func CompareSeries(documents []*v3.Document) *model.ChangeSeries {
+	names := make([]string, len(documents))
+	for i, d := range documents {
+		if d != nil && d.Info.Value != nil {
+			names[i] = d.Info.Value.Version.Value
+		}
+	}
+	return compareSeries(documents, names, CompareOpenAPIDocuments)
+}
+
+// CompareSwaggerSeries compares every consecutive pair of a series of Swagger documents in the same way as
+// CompareSeries, and returns a timeline of the changes made across the whole series.
+func CompareSwaggerSeries(documents []*v2.Swagger) *model.ChangeSeries {
+	names := make([]string, len(documents))
+	for i, d := range documents {
+		if d != nil && d.Info.Value != nil {
+			names[i] = d.Info.Value.Version.Value
+		}
+	}
+	return compareSeries(documents, names, CompareSwaggerDocuments)
+}
+
+// compareSeries compares consecutive documents in order, documents are not compared concurrently as comparisons
+// share them. Versions without names are named by their position in the series.
+func compareSeries[T any](documents []T, names []string, compare func(original, updated T) *model.DocumentChanges) *model.ChangeSeries {
+	for i := range names {
+		if names[i] == "" {
+			names[i] = fmt.Sprintf("#%d", i+1)
+		}
+	}
+	series := new(model.ChangeSeries)
+	for i := 1; i < len(documents); i++ {
+		series.AddStep(names[i-1], names[i], compare(documents[i-1], documents[i]))
+	}
+	return series
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,7 +26,7 @@ func TestCompareOpenAPIDocuments(t *testing.T) {
 	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
 
 	changes := CompareOpenAPIDocuments(origDoc, modDoc)
-	assert.Equal(t, 75, changes.TotalChanges())
+	assert.Equal(t, 74, changes.TotalChanges())
 	assert.Equal(t, 20, changes.TotalBreakingChanges())
 
 }
@@ -42,7 +43,7 @@ func TestCompareSwaggerDocuments(t *testing.T) {
 
 	changes := CompareSwaggerDocuments(origDoc, modDoc)
 	assert.Equal(t, 52, changes.TotalChanges())
-	assert.Equal(t, 27, changes.TotalBreakingChanges())
+	assert.Equal(t, 26, changes.TotalBreakingChanges())
 
 }
 
@@ -146,5 +147,207 @@ func ExampleCompareOpenAPIDocuments() {
 	// Print out some interesting stats.
 	fmt.Printf("There are %d changes, of which %d are breaking. %v schemas have changes.",
 		changes.TotalChanges(), changes.TotalBreakingChanges(), len(schemaChanges))
-	//Output: There are 75 changes, of which 20 are breaking. 6 schemas have changes.
+	//Output: There are 74 changes, of which 20 are breaking. 6 schemas have changes.
+}
+
+func TestCompareOpenAPIDocuments_Callbacks(t *testing.T) {
+	left := `openapi: 3.1.0
+paths:
+  /subscribe:
+    post:
+      callbacks:
+        onEvent:
+          '{$request.body#/callbackUrl}':
+            post:
+              description: an event
+        onStatus:
+          $ref: '#/components/callbacks/status'
+        onDelete:
+          '{$request.body#/deleteUrl}':
+            delete:
+              description: a delete
+components:
+  callbacks:
+    status:
+      '{$request.body#/statusUrl}':
+        post:
+          description: a status`
+
+	right := `openapi: 3.1.0
+paths:
+  /subscribe:
+    post:
+      callbacks:
+        onEvent:
+          '{$request.body#/callbackUrl}':
+            post:
+              description: a changed event
+          '{$request.body#/otherUrl}':
+            post:
+              description: another event
+        onStatus:
+          $ref: '#/components/callbacks/status'
+        onCreate:
+          '{$request.body#/createUrl}':
+            post:
+              description: a create
+components:
+  callbacks:
+    status:
+      '{$request.body#/statusUrl}':
+        post:
+          description: a changed status`
+
+	infoLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	infoRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	leftDoc, _ := v3.CreateDocumentFromConfig(infoLeft, datamodel.NewDocumentConfiguration())
+	rightDoc, _ := v3.CreateDocumentFromConfig(infoRight, datamodel.NewDocumentConfiguration())
+
+	changes := CompareOpenAPIDocuments(leftDoc, rightDoc)
+	assert.NotNil(t, changes)
+
+	// callback changes are nested under the operation that owns them.
+	op := changes.PathsChanges.PathItemsChanges["/subscribe"].PostChanges
+	assert.NotNil(t, op)
+	assert.Len(t, op.CallbackChanges, 2)
+
+	// a modified expression and an added expression.
+	onEvent := op.CallbackChanges["onEvent"]
+	assert.Equal(t, 2, onEvent.TotalChanges())
+	assert.Equal(t, 0, onEvent.TotalBreakingChanges())
+	assert.Equal(t, "{$request.body#/otherUrl}", onEvent.Changes[0].Property)
+	assert.Equal(t, model.ObjectAdded, onEvent.Changes[0].ChangeType)
+	desc := onEvent.ExpressionChanges["{$request.body#/callbackUrl}"].PostChanges.Changes[0]
+	assert.Equal(t, "a changed event", desc.New)
+
+	// a referenced callback that changed at the source.
+	onStatus := op.CallbackChanges["onStatus"]
+	assert.Equal(t, 1, onStatus.ExpressionChanges["{$request.body#/statusUrl}"].TotalChanges())
+
+	// a removed callback and an added callback.
+	assert.Equal(t, 2, op.PropertyChanges.TotalChanges())
+	assert.Equal(t, 1, op.PropertyChanges.TotalBreakingChanges())
+
+	// the component itself is only checked for additions and removals.
+	assert.Nil(t, changes.ComponentsChanges)
+	assert.Equal(t, 5, changes.TotalChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithFilter(t *testing.T) {
+	original, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	modified, _ := os.ReadFile("../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	changes, err := CompareOpenAPIDocumentsWithFilter(origDoc, modDoc, &model.ChangeFilter{IgnoreDescriptions: true})
+	assert.NoError(t, err)
+	assert.Less(t, changes.TotalChanges(), 74)
+	for c := range model.LeafChanges(changes) {
+		assert.NotEqual(t, "description", c.Property)
+	}
+
+	changes, err = CompareOpenAPIDocumentsWithFilter(origDoc, modDoc, &model.ChangeFilter{IgnorePaths: []string{"$"}})
+	assert.NoError(t, err)
+	assert.Nil(t, changes)
+
+	changes, err = CompareOpenAPIDocumentsWithFilter(origDoc, modDoc, &model.ChangeFilter{IgnorePaths: []string{"paths"}})
+	assert.Error(t, err)
+	assert.Nil(t, changes)
+
+	changes, err = CompareOpenAPIDocumentsWithFilter(origDoc, origDoc, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, changes)
+}
+
+func TestCompareSwaggerDocumentsWithFilter(t *testing.T) {
+	original, _ := os.ReadFile("../test_specs/petstorev2-complete.yaml")
+	modified, _ := os.ReadFile("../test_specs/petstorev2-complete-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v2.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v2.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	changes, err := CompareSwaggerDocumentsWithFilter(origDoc, modDoc, &model.ChangeFilter{IgnorePaths: []string{"$.paths"}})
+	assert.NoError(t, err)
+	assert.Nil(t, changes.PathsChanges)
+	assert.Less(t, changes.TotalChanges(), 52)
+}
+
+func TestCompareSeries(t *testing.T) {
+	original, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	modified, _ := os.ReadFile("../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	series := CompareSeries([]*v3.Document{origDoc, modDoc, modDoc, origDoc})
+	assert.Len(t, series.Steps, 3)
+
+	first := series.Steps[0]
+	assert.Equal(t, "1.2", first.From)
+	assert.Equal(t, "1.2", first.To)
+	assert.Equal(t, 74, first.TotalChanges)
+	assert.Equal(t, 20, first.TotalBreakingChanges)
+	assert.Equal(t, 74, first.CumulativeChanges)
+	assert.Equal(t, 20, first.CumulativeBreakingChanges)
+
+	// nothing changed between the same versions.
+	assert.Nil(t, series.Steps[1].Changes)
+	assert.Equal(t, 74, series.Steps[1].CumulativeChanges)
+
+	last := series.Steps[2]
+	assert.NotNil(t, last.Changes)
+	assert.Equal(t, 74+last.TotalChanges, last.CumulativeChanges)
+	assert.Equal(t, 20+last.TotalBreakingChanges, last.CumulativeBreakingChanges)
+	assert.Equal(t, last.CumulativeChanges, series.TotalChanges)
+	assert.Equal(t, last.CumulativeBreakingChanges, series.TotalBreakingChanges)
+	assert.Same(t, last, series.GetLastBreakingStep())
+	assert.Len(t, series.GetBreakingSteps(), 2)
+
+	// single documents have nothing to compare.
+	series = CompareSeries([]*v3.Document{origDoc})
+	assert.Empty(t, series.Steps)
+	assert.Zero(t, series.TotalChanges)
+}
+
+func TestCompareSwaggerSeries(t *testing.T) {
+	original, _ := os.ReadFile("../test_specs/petstorev2-complete.yaml")
+	modified, _ := os.ReadFile("../test_specs/petstorev2-complete-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v2.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v2.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	series := CompareSwaggerSeries([]*v2.Swagger{origDoc, modDoc})
+	assert.Len(t, series.Steps, 1)
+	step := series.GetStep("1.0.7")
+	assert.NotNil(t, step)
+	assert.Equal(t, "1.0.6", step.From)
+	assert.Equal(t, 52, series.TotalChanges)
+	assert.Equal(t, 26, series.TotalBreakingChanges)
+	assert.Nil(t, series.GetStep("1.0.6"))
+}
+
+func TestAffectedOperations(t *testing.T) {
+	original, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	modified, _ := os.ReadFile("../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	affected := AffectedOperations(CompareOpenAPIDocuments(origDoc, modDoc))
+	assert.NotEmpty(t, affected)
+	for _, op := range affected {
+		assert.NotEmpty(t, op.Changes)
+	}
+	assert.Nil(t, AffectedOperations(nil))
 }